@@ -0,0 +1,332 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/certificate"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+// restartSample is the restart count of a pod observed at given time.
+type restartSample struct {
+	time  time.Time
+	count int32
+}
+
+// statsSummary is the summary of kubelet stats. Client library does not contain its types, so only
+// the fields used by Dashboard are declared.
+type statsSummary struct {
+	Pods []struct {
+		Volumes []struct {
+			PVCRef *struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"pvcRef"`
+			CapacityBytes *uint64 `json:"capacityBytes"`
+			UsedBytes     *uint64 `json:"usedBytes"`
+		} `json:"volume"`
+	} `json:"pods"`
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Get() *rest.Request
+}
+
+// getStatsSummary returns summary of kubelet stats of the node through the apiserver proxy. Rest
+// client is the client of core API group.
+func getStatsSummary(restClient RESTClient, node string) ([]byte, error) {
+	return restClient.Get().
+		Resource("nodes").
+		Name(node).
+		SubResource("proxy").
+		Suffix("stats/summary").
+		DoRaw()
+}
+
+// evaluator checks rules against current state of the cluster. Restart counts of pods are kept
+// between evaluations, so that restarts within the window of rules can be counted.
+type evaluator struct {
+	restarts map[types.UID][]restartSample
+}
+
+// evaluate returns firing alerts of enabled rules. Objects are read from the resource cache, when
+// it is enabled. Rules whose objects could not be read are skipped and the error is returned.
+func (self *evaluator) evaluate(client kubernetes.Interface, rules []Rule, now time.Time) ([]Alert, []error) {
+	needed := make(map[RuleType]bool)
+	for _, rule := range rules {
+		if !rule.Disabled {
+			needed[rule.Type] = true
+		}
+	}
+
+	errs := make([]error, 0)
+	var pods []v1.Pod
+	if needed[RulePodRestarts] || needed[RulePersistentVolumeClaimUsage] {
+		channel := common.GetPodListChannel(client, common.NewNamespaceQuery(nil), 1)
+		list, err := <-channel.List, <-channel.Error
+		if err != nil {
+			errs = append(errs, err)
+			needed[RulePodRestarts], needed[RulePersistentVolumeClaimUsage] = false, false
+		} else {
+			pods = list.Items
+		}
+	}
+	if needed[RulePodRestarts] {
+		self.sampleRestarts(pods, rules, now)
+	}
+
+	var nodes []v1.Node
+	if needed[RuleNodeNotReady] {
+		channel := common.GetNodeListChannel(client, 1)
+		list, err := <-channel.List, <-channel.Error
+		if err != nil {
+			errs = append(errs, err)
+			needed[RuleNodeNotReady] = false
+		} else {
+			nodes = list.Items
+		}
+	}
+
+	var volumes map[string]volumeUsage
+	if needed[RulePersistentVolumeClaimUsage] {
+		var volumeErrs []error
+		volumes, volumeErrs = getVolumeUsage(client.CoreV1().RESTClient(), pods)
+		errs = append(errs, volumeErrs...)
+	}
+
+	var certificates []certificate.Certificate
+	if needed[RuleCertificateExpiring] {
+		list, err := certificate.GetCertificateList(client, common.NewNamespaceQuery(nil),
+			dataselect.NoDataSelect, 0)
+		if err != nil {
+			errs = append(errs, err)
+			needed[RuleCertificateExpiring] = false
+		} else {
+			certificates = list.Certificates
+			errs = append(errs, list.Errors...)
+		}
+	}
+
+	alerts := make([]Alert, 0)
+	for _, rule := range rules {
+		if rule.Disabled || !needed[rule.Type] {
+			continue
+		}
+		switch rule.Type {
+		case RulePodRestarts:
+			alerts = append(alerts, self.evaluatePodRestarts(rule, pods, now)...)
+		case RuleNodeNotReady:
+			alerts = append(alerts, evaluateNodeNotReady(rule, nodes)...)
+		case RulePersistentVolumeClaimUsage:
+			alerts = append(alerts, evaluateVolumeUsage(rule, volumes)...)
+		case RuleCertificateExpiring:
+			alerts = append(alerts, evaluateCertificateExpiry(rule, certificates)...)
+		}
+	}
+	return alerts, errs
+}
+
+// sampleRestarts records current restart counts of pods. Samples older than the longest window are
+// dropped, except the newest of them, which is the baseline restarts within the window are counted
+// from.
+func (self *evaluator) sampleRestarts(pods []v1.Pod, rules []Rule, now time.Time) {
+	longest := 0
+	for _, rule := range rules {
+		if rule.Type == RulePodRestarts && !rule.Disabled && rule.windowMinutes() > longest {
+			longest = rule.windowMinutes()
+		}
+	}
+	since := now.Add(-time.Duration(longest) * time.Minute)
+
+	restarts := make(map[types.UID][]restartSample, len(pods))
+	for _, pod := range pods {
+		samples := self.restarts[pod.UID]
+		for len(samples) > 1 && !samples[1].time.After(since) {
+			samples = samples[1:]
+		}
+		restarts[pod.UID] = append(samples, restartSample{time: now, count: getRestartCount(pod)})
+	}
+	self.restarts = restarts
+}
+
+// evaluatePodRestarts fires for pods restarted more than threshold times within the window of the
+// rule. Pods observed for a shorter time than the window are checked since they were first seen.
+func (self *evaluator) evaluatePodRestarts(rule Rule, pods []v1.Pod, now time.Time) []Alert {
+	since := now.Add(-time.Duration(rule.windowMinutes()) * time.Minute)
+	alerts := make([]Alert, 0)
+	for _, pod := range pods {
+		if !matchesNamespace(rule, pod.Namespace) {
+			continue
+		}
+
+		samples := self.restarts[pod.UID]
+		if len(samples) < 2 {
+			continue
+		}
+		baseline := samples[0]
+		for _, sample := range samples[1:] {
+			if sample.time.After(since) {
+				break
+			}
+			baseline = sample
+		}
+
+		restarts := float64(samples[len(samples)-1].count - baseline.count)
+		if restarts > rule.threshold() {
+			alerts = append(alerts, newAlert(rule, api.ResourceKindPod, pod.Namespace, pod.Name, restarts,
+				fmt.Sprintf("Pod %s/%s restarted %.0f times in the last %d minutes", pod.Namespace, pod.Name,
+					restarts, rule.windowMinutes())))
+		}
+	}
+	return alerts
+}
+
+// evaluateNodeNotReady fires for nodes whose Ready condition is false or unknown.
+func evaluateNodeNotReady(rule Rule, nodes []v1.Node) []Alert {
+	alerts := make([]Alert, 0)
+	for _, node := range nodes {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type != v1.NodeReady || condition.Status == v1.ConditionTrue {
+				continue
+			}
+			message := fmt.Sprintf("Node %s is not ready", node.Name)
+			if condition.Reason != "" {
+				message = fmt.Sprintf("%s: %s", message, condition.Reason)
+			}
+			alerts = append(alerts, newAlert(rule, api.ResourceKindNode, "", node.Name, 0, message))
+		}
+	}
+	return alerts
+}
+
+// volumeUsage is usage of a volume reported by kubelet.
+type volumeUsage struct {
+	namespace string
+	name      string
+	used      uint64
+	capacity  uint64
+}
+
+// getVolumeUsage returns usage of persistent volume claims by namespace and name. Stats are read
+// only from nodes running pods that mount a claim. Nodes whose stats could not be read are skipped.
+func getVolumeUsage(restClient RESTClient, pods []v1.Pod) (map[string]volumeUsage, []error) {
+	nodes := make(map[string]bool)
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.Status.Phase != v1.PodRunning {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				nodes[pod.Spec.NodeName] = true
+			}
+		}
+	}
+
+	result := make(map[string]volumeUsage)
+	errs := make([]error, 0)
+	for node := range nodes {
+		raw, err := getStatsSummary(restClient, node)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not get stats of node %s: %s", node, err))
+			continue
+		}
+		summary := statsSummary{}
+		if err := json.Unmarshal(raw, &summary); err != nil {
+			errs = append(errs, fmt.Errorf("could not parse stats of node %s: %s", node, err))
+			continue
+		}
+
+		for _, pod := range summary.Pods {
+			for _, volume := range pod.Volumes {
+				if volume.PVCRef == nil || volume.UsedBytes == nil || volume.CapacityBytes == nil ||
+					*volume.CapacityBytes == 0 {
+					continue
+				}
+				result[volume.PVCRef.Namespace+"/"+volume.PVCRef.Name] = volumeUsage{
+					namespace: volume.PVCRef.Namespace,
+					name:      volume.PVCRef.Name,
+					used:      *volume.UsedBytes,
+					capacity:  *volume.CapacityBytes,
+				}
+			}
+		}
+	}
+	return result, errs
+}
+
+// evaluateVolumeUsage fires for persistent volume claims filled above threshold percent.
+func evaluateVolumeUsage(rule Rule, volumes map[string]volumeUsage) []Alert {
+	alerts := make([]Alert, 0)
+	for _, volume := range volumes {
+		if !matchesNamespace(rule, volume.namespace) {
+			continue
+		}
+		percentage := float64(volume.used) / float64(volume.capacity) * 100
+		if percentage > rule.threshold() {
+			alerts = append(alerts, newAlert(rule, api.ResourceKindPersistentVolumeClaim, volume.namespace,
+				volume.name, percentage, fmt.Sprintf("Persistent volume claim %s/%s is %.1f%% full",
+					volume.namespace, volume.name, percentage)))
+		}
+	}
+	return alerts
+}
+
+// evaluateCertificateExpiry fires for certificates that expired or expire within threshold days.
+func evaluateCertificateExpiry(rule Rule, certificates []certificate.Certificate) []Alert {
+	alerts := make([]Alert, 0)
+	for _, cert := range certificates {
+		namespace, name := cert.ObjectMeta.Namespace, cert.ObjectMeta.Name
+		if !matchesNamespace(rule, namespace) {
+			continue
+		}
+
+		var message string
+		switch {
+		case cert.Status == certificate.StatusExpired:
+			message = fmt.Sprintf("Certificate of secret %s/%s expired", namespace, name)
+		case cert.Status == certificate.StatusValid && float64(cert.DaysToExpiry) < rule.threshold():
+			message = fmt.Sprintf("Certificate of secret %s/%s expires in %d days", namespace, name,
+				cert.DaysToExpiry)
+		default:
+			continue
+		}
+		alerts = append(alerts, newAlert(rule, api.ResourceKindSecret, namespace, name,
+			float64(cert.DaysToExpiry), message))
+	}
+	return alerts
+}
+
+func matchesNamespace(rule Rule, namespace string) bool {
+	return rule.Namespace == "" || rule.Namespace == namespace
+}
+
+func getRestartCount(pod v1.Pod) int32 {
+	var count int32
+	for _, status := range pod.Status.ContainerStatuses {
+		count += status.RestartCount
+	}
+	return count
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+func newPod(namespace, name string, restarts int32) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID("uid-" + name)},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{Name: "app", RestartCount: restarts}},
+		},
+	}
+}
+
+func TestEvaluatePodRestarts(t *testing.T) {
+	rule := Rule{Name: "restarts", Type: RulePodRestarts, Severity: SeverityWarning, Threshold: 2}
+	rules := []Rule{rule}
+	evaluator := &evaluator{}
+	start := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		minute   int
+		restarts int32
+		expected int
+	}{
+		// First observation has no baseline.
+		{0, 10, 0},
+		{5, 12, 0},
+		{8, 13, 1},
+		// Restarts before minute 1 are outside of the window.
+		{11, 13, 1},
+		// Restarts before minute 8 are outside of the window.
+		{18, 13, 0},
+	}
+
+	for _, c := range cases {
+		now := start.Add(time.Duration(c.minute) * time.Minute)
+		pods := []v1.Pod{newPod("default", "app", c.restarts)}
+		evaluator.sampleRestarts(pods, rules, now)
+		alerts := evaluator.evaluatePodRestarts(rule, pods, now)
+		if len(alerts) != c.expected {
+			t.Errorf("evaluatePodRestarts() at minute %d returned %#v, expected %d alerts", c.minute,
+				alerts, c.expected)
+		}
+	}
+
+	pods := []v1.Pod{newPod("default", "app", 20)}
+	if alerts := evaluator.evaluatePodRestarts(Rule{Name: "other", Type: RulePodRestarts,
+		Namespace: "other"}, pods, start); len(alerts) != 0 {
+		t.Errorf("evaluatePodRestarts() returned %#v for pod in other namespace", alerts)
+	}
+}
+
+func TestGetVolumeUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/nodes/node-1/proxy/stats/summary" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"pods": [{"volume": [
+			{"name": "data", "pvcRef": {"name": "data", "namespace": "default"},
+			 "capacityBytes": 1000, "usedBytes": 950},
+			{"name": "logs", "pvcRef": {"name": "logs", "namespace": "default"},
+			 "capacityBytes": 1000, "usedBytes": 100},
+			{"name": "token", "capacityBytes": 1000, "usedBytes": 1000}
+		]}]}`))
+	}))
+	defer server.Close()
+	restClient, err := apply.NewRESTClient(&rest.Config{Host: server.URL}, schema.GroupVersion{Version: "v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pod := newPod("default", "db", 0)
+	pod.Spec.NodeName = "node-1"
+	pod.Status.Phase = v1.PodRunning
+	pod.Spec.Volumes = []v1.Volume{{Name: "data", VolumeSource: v1.VolumeSource{
+		PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}}}
+	pending := newPod("default", "pending", 0)
+	pending.Spec.NodeName = "node-2"
+	pending.Spec.Volumes = pod.Spec.Volumes
+
+	volumes, errs := getVolumeUsage(restClient, []v1.Pod{pod, pending})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if len(volumes) != 2 {
+		t.Fatalf("getVolumeUsage() == %#v, expected usage of claims only", volumes)
+	}
+
+	rule := Rule{Name: "volumes", Type: RulePersistentVolumeClaimUsage, Severity: SeverityWarning}
+	alerts := evaluateVolumeUsage(rule, volumes)
+	if len(alerts) != 1 || alerts[0].Name != "data" || alerts[0].Value != 95 ||
+		alerts[0].Message != "Persistent volume claim default/data is 95.0% full" {
+		t.Errorf("evaluateVolumeUsage() == %#v, expected alert of data claim", alerts)
+	}
+}
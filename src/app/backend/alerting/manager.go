@@ -0,0 +1,472 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package alerting evaluates rules defined by users, e.g. pods restarting too often or nodes that
+// are not ready, and notifies about alerts they raise. Rules are stored in a ConfigMap, which is
+// watched, so that all replicas evaluate the same rules. Alerts are kept in memory of every replica.
+package alerting
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/notification"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/accessreview"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// ConfigMapName is the name of ConfigMap that rules are stored in.
+	ConfigMapName = "kubernetes-dashboard-alerting"
+	// RulesKey is the key of rules in the ConfigMap.
+	RulesKey = "rules.json"
+
+	// DefaultInterval is used when the interval of evaluation is not set.
+	DefaultInterval = time.Minute
+
+	// resolvedRetention is how long resolved alerts are kept, so that UI can show them.
+	resolvedRetention = time.Hour
+)
+
+// AlertState tells whether the condition of an alert still holds.
+type AlertState string
+
+const (
+	StateFiring   AlertState = "firing"
+	StateResolved AlertState = "resolved"
+)
+
+// Alert is raised by a rule for a single object.
+type Alert struct {
+	Rule     string     `json:"rule"`
+	Type     RuleType   `json:"type"`
+	Severity Severity   `json:"severity"`
+	State    AlertState `json:"state"`
+
+	// Object the alert is about. Namespace is empty for nodes.
+	Kind      api.ResourceKind `json:"kind"`
+	Namespace string           `json:"namespace,omitempty"`
+	Name      string           `json:"name"`
+
+	Message string `json:"message"`
+	// Value that was compared with threshold of the rule, i.e. number of restarts.
+	Value float64 `json:"value"`
+
+	FiredAt    time.Time  `json:"firedAt"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+}
+
+// key identifies the alert of a rule for an object across evaluations.
+func (self Alert) key() string {
+	return fmt.Sprintf("%s/%s/%s/%s", self.Rule, self.Kind, self.Namespace, self.Name)
+}
+
+// AlertList contains firing alerts and alerts resolved within the last hour.
+type AlertList struct {
+	Alerts []Alert `json:"alerts"`
+	Firing int     `json:"firing"`
+
+	// Time of the last evaluation of rules. Nil before the first evaluation.
+	EvaluatedAt *time.Time `json:"evaluatedAt,omitempty"`
+
+	// List of non-critical errors, that occurred during the last evaluation.
+	Errors []error `json:"errors"`
+}
+
+// Options configure evaluation of rules.
+type Options struct {
+	// Interval between evaluations of rules.
+	Interval time.Duration
+	// Notifiers that are notified when alerts fire and resolve.
+	Notifiers []notification.Notifier
+}
+
+// AlertManager keeps rules loaded from the ConfigMap up to date, evaluates them periodically and
+// saves changes to them.
+type AlertManager interface {
+	// GetAlerts returns alerts of objects in the namespaces. Alerts of nodes are returned only for
+	// all namespaces.
+	GetAlerts(nsQuery *common.NamespaceQuery) *AlertList
+	// GetRules returns all rules sorted by name.
+	GetRules() []Rule
+	// SaveRule creates the rule or replaces rule with the same name. It is saved with given client,
+	// so that only users allowed to update the ConfigMap can change rules.
+	SaveRule(client kubernetes.Interface, rule Rule) error
+	// DeleteRule deletes rule with given name with given client.
+	DeleteRule(client kubernetes.Interface, name string) error
+}
+
+// alertManager implements AlertManager with rules in ConfigMap watched by Dashboard's own client,
+// which also evaluates the rules.
+type alertManager struct {
+	sync.RWMutex
+	client    kubernetes.Interface
+	namespace string
+	options   Options
+	evaluator *evaluator
+
+	rules       []Rule
+	alerts      map[string]Alert
+	evaluatedAt *time.Time
+	errors      []error
+}
+
+// GetAlerts implements AlertManager interface.
+func (self *alertManager) GetAlerts(nsQuery *common.NamespaceQuery) *AlertList {
+	self.RLock()
+	defer self.RUnlock()
+
+	result := &AlertList{Alerts: make([]Alert, 0), EvaluatedAt: self.evaluatedAt, Errors: self.errors}
+	for _, alert := range self.alerts {
+		if !nsQuery.Matches(alert.Namespace) {
+			continue
+		}
+		if alert.State == StateFiring {
+			result.Firing++
+		}
+		result.Alerts = append(result.Alerts, alert)
+	}
+	sortAlerts(result.Alerts)
+	return result
+}
+
+// GetRules implements AlertManager interface.
+func (self *alertManager) GetRules() []Rule {
+	self.RLock()
+	defer self.RUnlock()
+	return append(make([]Rule, 0, len(self.rules)), self.rules...)
+}
+
+// SaveRule implements AlertManager interface.
+func (self *alertManager) SaveRule(client kubernetes.Interface, rule Rule) error {
+	if err := ValidateRule(rule); err != nil {
+		return err
+	}
+
+	return self.save(client, func(rules []Rule) ([]Rule, error) {
+		for i := range rules {
+			if rules[i].Name == rule.Name {
+				rules[i] = rule
+				return rules, nil
+			}
+		}
+		return append(rules, rule), nil
+	})
+}
+
+// DeleteRule implements AlertManager interface.
+func (self *alertManager) DeleteRule(client kubernetes.Interface, name string) error {
+	return self.save(client, func(rules []Rule) ([]Rule, error) {
+		for i := range rules {
+			if rules[i].Name == name {
+				return append(rules[:i], rules[i+1:]...), nil
+			}
+		}
+		return nil, errorsK8s.NewNotFound(schema.GroupResource{Resource: "alert rules"}, name)
+	})
+}
+
+// save applies the change to rules in the ConfigMap, which is created if it does not exist yet, and
+// updates rules in memory without waiting for the watch event.
+func (self *alertManager) save(client kubernetes.Interface, change func([]Rule) ([]Rule, error)) error {
+	configMaps := client.CoreV1().ConfigMaps(self.namespace)
+	configMap, err := configMaps.Get(ConfigMapName, metaV1.GetOptions{})
+	notFound := errorsK8s.IsNotFound(err)
+	if err != nil && !notFound {
+		return err
+	}
+	if notFound {
+		configMap = &v1.ConfigMap{
+			ObjectMeta: metaV1.ObjectMeta{Name: ConfigMapName, Namespace: self.namespace},
+		}
+	}
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+
+	rules := make([]Rule, 0)
+	if data, ok := configMap.Data[RulesKey]; ok {
+		if err := json.Unmarshal([]byte(data), &rules); err != nil {
+			return err
+		}
+	}
+	rules, err = change(rules)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return err
+	}
+	configMap.Data[RulesKey] = string(data)
+
+	if notFound {
+		configMap, err = configMaps.Create(configMap)
+	} else {
+		configMap, err = configMaps.Update(configMap)
+	}
+	if err != nil {
+		return err
+	}
+
+	self.load(configMap)
+	return nil
+}
+
+// load replaces rules in memory with rules from the ConfigMap. Invalid rules are skipped. Nil
+// ConfigMap removes all rules.
+func (self *alertManager) load(configMap *v1.ConfigMap) {
+	rules := make([]Rule, 0)
+	if configMap != nil {
+		if data, ok := configMap.Data[RulesKey]; ok {
+			loaded := make([]Rule, 0)
+			if err := json.Unmarshal([]byte(data), &loaded); err != nil {
+				logger.Errorf("Invalid alert rules in ConfigMap %s: %s", ConfigMapName, err)
+			}
+			for _, rule := range loaded {
+				if err := ValidateRule(rule); err != nil {
+					logger.Warningf("Skipping invalid alert rule in ConfigMap %s: %s", ConfigMapName, err)
+					continue
+				}
+				rules = append(rules, rule)
+			}
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+
+	self.Lock()
+	defer self.Unlock()
+	self.rules = rules
+}
+
+// watch watches the ConfigMap and reloads rules on every change.
+func (self *alertManager) watch(stop <-chan struct{}) {
+	selector := fields.OneTermEqualSelector("metadata.name", ConfigMapName).String()
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options metaV1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return self.client.CoreV1().ConfigMaps(self.namespace).List(options)
+		},
+		WatchFunc: func(options metaV1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return self.client.CoreV1().ConfigMaps(self.namespace).Watch(options)
+		},
+	}
+
+	_, controller := cache.NewInformer(listWatch, &v1.ConfigMap{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			self.load(obj.(*v1.ConfigMap))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			self.load(newObj.(*v1.ConfigMap))
+		},
+		DeleteFunc: func(obj interface{}) {
+			self.load(nil)
+		},
+	})
+	controller.Run(stop)
+}
+
+// run evaluates rules every interval until stop channel is closed.
+func (self *alertManager) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(self.options.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			self.evaluate(now)
+		}
+	}
+}
+
+// evaluate evaluates rules and updates alerts. Notifiers are notified about alerts that started
+// firing and alerts that resolved since the previous evaluation.
+func (self *alertManager) evaluate(now time.Time) {
+	rules := self.GetRules()
+	firing, errs := self.evaluator.evaluate(self.client, rules, now)
+	for _, err := range errs {
+		logger.Errorf("Error during evaluation of alert rules: %s", err)
+	}
+
+	self.Lock()
+	changed := make([]Alert, 0)
+	current := make(map[string]bool, len(firing))
+	for _, alert := range firing {
+		key := alert.key()
+		current[key] = true
+		if previous, ok := self.alerts[key]; ok && previous.State == StateFiring {
+			alert.FiredAt = previous.FiredAt
+		} else {
+			alert.FiredAt = now
+			changed = append(changed, alert)
+		}
+		self.alerts[key] = alert
+	}
+	for key, alert := range self.alerts {
+		if current[key] {
+			continue
+		}
+		if alert.State == StateFiring {
+			resolvedAt := now
+			alert.State, alert.ResolvedAt = StateResolved, &resolvedAt
+			self.alerts[key] = alert
+			changed = append(changed, alert)
+		} else if now.Sub(*alert.ResolvedAt) > resolvedRetention {
+			delete(self.alerts, key)
+		}
+	}
+	self.evaluatedAt = &now
+	self.errors = errs
+	self.Unlock()
+
+	sortAlerts(changed)
+	for _, alert := range changed {
+		for _, notifier := range self.options.Notifiers {
+			go func(notifier notification.Notifier, alert Alert) {
+				if err := notifier.Notify(toMessage(alert, now)); err != nil {
+					logger.Errorf("Could not send notification about alert %s: %s", alert.key(), err)
+				}
+			}(notifier, alert)
+		}
+	}
+}
+
+// toMessage creates notification message about the alert that fired or resolved.
+func toMessage(alert Alert, now time.Time) notification.Message {
+	message := notification.Message{
+		Event:     notification.EventAlertFiring,
+		Severity:  string(alert.Severity),
+		Title:     fmt.Sprintf("[%s] %s", alert.Severity, alert.Message),
+		Text:      fmt.Sprintf("%s\n\nRule: %s\nSeverity: %s", alert.Message, alert.Rule, alert.Severity),
+		Kind:      alert.Kind,
+		Namespace: alert.Namespace,
+		Name:      alert.Name,
+		Timestamp: now,
+	}
+	if alert.State == StateResolved {
+		message.Event = notification.EventAlertResolved
+		message.Title = fmt.Sprintf("[resolved] %s", alert.Message)
+	}
+	return message
+}
+
+// FilterAlerts removes alerts of objects the user is not allowed to list, as rules are evaluated
+// with Dashboard's own client. Access is reviewed once for every kind and namespace.
+func FilterAlerts(client kubernetes.Interface, list *AlertList) *AlertList {
+	allowed := make(map[string]bool)
+	result := &AlertList{Alerts: make([]Alert, 0), EvaluatedAt: list.EvaluatedAt, Errors: list.Errors}
+	for _, alert := range list.Alerts {
+		key := fmt.Sprintf("%s/%s", alert.Kind, alert.Namespace)
+		if _, ok := allowed[key]; !ok {
+			review := accessreview.ReviewAction(client, accessreview.ResourceAction{
+				Verb:      "list",
+				Resource:  resourceOf(alert.Kind),
+				Namespace: alert.Namespace,
+			})
+			allowed[key] = review.Allowed
+		}
+		if !allowed[key] {
+			continue
+		}
+		if alert.State == StateFiring {
+			result.Firing++
+		}
+		result.Alerts = append(result.Alerts, alert)
+	}
+	return result
+}
+
+// resourceOf returns API resource of objects alerts are raised for.
+func resourceOf(kind api.ResourceKind) string {
+	switch kind {
+	case api.ResourceKindNode:
+		return "nodes"
+	case api.ResourceKindPersistentVolumeClaim:
+		return "persistentvolumeclaims"
+	case api.ResourceKindSecret:
+		return "secrets"
+	}
+	return "pods"
+}
+
+// newAlert creates firing alert of the rule for given object.
+func newAlert(rule Rule, kind api.ResourceKind, namespace, name string, value float64, message string) Alert {
+	return Alert{
+		Rule:      rule.Name,
+		Type:      rule.Type,
+		Severity:  rule.Severity,
+		State:     StateFiring,
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		Message:   message,
+		Value:     value,
+	}
+}
+
+// sortAlerts sorts firing alerts first, then the most recent ones.
+func sortAlerts(alerts []Alert) {
+	sort.SliceStable(alerts, func(i, j int) bool {
+		if alerts[i].State != alerts[j].State {
+			return alerts[i].State == StateFiring
+		}
+		if !alerts[i].FiredAt.Equal(alerts[j].FiredAt) {
+			return alerts[i].FiredAt.After(alerts[j].FiredAt)
+		}
+		return alerts[i].key() < alerts[j].key()
+	})
+}
+
+// NewAlertManager creates alert manager that stores rules in ConfigMap in given namespace, watches
+// it and evaluates rules with given client until stop channel is closed.
+func NewAlertManager(client kubernetes.Interface, namespace string, options Options,
+	stop <-chan struct{}) AlertManager {
+	if options.Interval <= 0 {
+		options.Interval = DefaultInterval
+	}
+	manager := newAlertManager(client, namespace, options)
+	go manager.watch(stop)
+	go manager.run(stop)
+	return manager
+}
+
+func newAlertManager(client kubernetes.Interface, namespace string, options Options) *alertManager {
+	manager := &alertManager{
+		client:    client,
+		namespace: namespace,
+		options:   options,
+		evaluator: &evaluator{restarts: make(map[types.UID][]restartSample)},
+		alerts:    make(map[string]Alert),
+		errors:    make([]error, 0),
+	}
+	manager.load(nil)
+	return manager
+}
@@ -0,0 +1,171 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/notification"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+type recordingNotifier struct {
+	sync.WaitGroup
+	sync.Mutex
+	messages []notification.Message
+}
+
+func (self *recordingNotifier) Notify(message notification.Message) error {
+	defer self.Done()
+	self.Lock()
+	defer self.Unlock()
+	self.messages = append(self.messages, message)
+	return nil
+}
+
+func TestSaveRule(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	manager := newAlertManager(client, "kube-system", Options{})
+
+	restarts := Rule{Name: "restarts", Type: RulePodRestarts, Severity: SeverityWarning, Threshold: 5}
+	nodes := Rule{Name: "nodes", Type: RuleNodeNotReady, Severity: SeverityCritical}
+	for _, rule := range []Rule{restarts, nodes} {
+		if err := manager.SaveRule(client, rule); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if actual := manager.GetRules(); !reflect.DeepEqual(actual, []Rule{nodes, restarts}) {
+		t.Errorf("GetRules() == %#v, expected rules sorted by name", actual)
+	}
+
+	restarts.Threshold = 10
+	if err := manager.SaveRule(client, restarts); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.DeleteRule(client, "nodes"); err != nil {
+		t.Fatal(err)
+	}
+	if actual := manager.GetRules(); !reflect.DeepEqual(actual, []Rule{restarts}) {
+		t.Errorf("GetRules() == %#v, expected updated restart rule only", actual)
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps("kube-system").Get(ConfigMapName, metaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"name":"restarts","type":"PodRestarts","severity":"warning","threshold":10}]`
+	if configMap.Data[RulesKey] != expected {
+		t.Errorf("ConfigMap has rules %s, expected %s", configMap.Data[RulesKey], expected)
+	}
+
+	if err := manager.DeleteRule(client, "nodes"); !errorsK8s.IsNotFound(err) {
+		t.Errorf("DeleteRule() of missing rule returned %v, expected not found error", err)
+	}
+	if err := manager.SaveRule(client, Rule{Name: "Invalid", Type: RuleNodeNotReady}); !errorsK8s.IsBadRequest(err) {
+		t.Errorf("SaveRule() of invalid rule returned %v, expected bad request error", err)
+	}
+}
+
+func TestValidateRule(t *testing.T) {
+	cases := []struct {
+		rule  Rule
+		valid bool
+	}{
+		{Rule{Name: "restarts", Type: RulePodRestarts, Severity: SeverityInfo, WindowMinutes: 30}, true},
+		{Rule{Name: "restarts", Type: "Unknown", Severity: SeverityInfo}, false},
+		{Rule{Name: "restarts", Type: RulePodRestarts, Severity: "fatal"}, false},
+		{Rule{Name: "restarts", Type: RulePodRestarts, Severity: SeverityInfo, Namespace: "Apps"}, false},
+		{Rule{Name: "restarts", Type: RulePodRestarts, Severity: SeverityInfo, WindowMinutes: 2000}, false},
+		{Rule{Name: "volumes", Type: RulePersistentVolumeClaimUsage, Severity: SeverityInfo,
+			Threshold: 150}, false},
+		{Rule{Name: "certificates", Type: RuleCertificateExpiring, Severity: SeverityInfo,
+			Threshold: -1}, false},
+	}
+
+	for _, c := range cases {
+		err := ValidateRule(c.rule)
+		if c.valid && err != nil {
+			t.Errorf("ValidateRule(%#v) returned error %s, expected valid rule", c.rule, err)
+		}
+		if !c.valid && !errorsK8s.IsBadRequest(err) {
+			t.Errorf("ValidateRule(%#v) returned %v, expected bad request error", c.rule, err)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	node := v1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: "node-1"},
+		Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+			{Type: v1.NodeReady, Status: v1.ConditionFalse, Reason: "KubeletNotReady"},
+		}},
+	}
+	client := fake.NewSimpleClientset(&node)
+	notifier := &recordingNotifier{}
+	manager := newAlertManager(client, "kube-system", Options{Notifiers: []notification.Notifier{notifier}})
+	if err := manager.SaveRule(client, Rule{Name: "nodes", Type: RuleNodeNotReady,
+		Severity: SeverityCritical}); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	notifier.Add(1)
+	manager.evaluate(start)
+	manager.evaluate(start.Add(time.Minute))
+	notifier.Wait()
+
+	list := manager.GetAlerts(common.NewNamespaceQuery(nil))
+	if list.Firing != 1 || len(list.Alerts) != 1 {
+		t.Fatalf("GetAlerts() == %#v, expected single firing alert", list)
+	}
+	alert := list.Alerts[0]
+	if alert.Name != "node-1" || alert.Message != "Node node-1 is not ready: KubeletNotReady" ||
+		!alert.FiredAt.Equal(start) {
+		t.Errorf("Unexpected alert %#v", alert)
+	}
+	if actual := manager.GetAlerts(common.NewNamespaceQuery([]string{"default"})); len(actual.Alerts) != 0 {
+		t.Errorf("GetAlerts() of default namespace == %#v, expected no alerts of nodes", actual)
+	}
+
+	node.Status.Conditions[0].Status = v1.ConditionTrue
+	if _, err := client.CoreV1().Nodes().Update(&node); err != nil {
+		t.Fatal(err)
+	}
+	notifier.Add(1)
+	manager.evaluate(start.Add(2 * time.Minute))
+	notifier.Wait()
+
+	list = manager.GetAlerts(common.NewNamespaceQuery(nil))
+	if list.Firing != 0 || len(list.Alerts) != 1 || list.Alerts[0].State != StateResolved {
+		t.Fatalf("GetAlerts() == %#v, expected single resolved alert", list)
+	}
+	if len(notifier.messages) != 2 || notifier.messages[0].Event != notification.EventAlertFiring ||
+		notifier.messages[1].Event != notification.EventAlertResolved ||
+		notifier.messages[1].Title != "[resolved] Node node-1 is not ready: KubeletNotReady" {
+		t.Errorf("Notifier received %#v, expected firing and resolved alert", notifier.messages)
+	}
+
+	manager.evaluate(start.Add(2 * time.Hour))
+	if list := manager.GetAlerts(common.NewNamespaceQuery(nil)); len(list.Alerts) != 0 {
+		t.Errorf("GetAlerts() == %#v, expected resolved alert to be dropped after an hour", list)
+	}
+}
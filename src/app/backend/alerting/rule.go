@@ -0,0 +1,143 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"fmt"
+
+	"github.com/kubernetes/dashboard/src/app/backend/notification"
+	"github.com/kubernetes/dashboard/src/app/backend/settings"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// RuleType tells which condition a rule checks.
+type RuleType string
+
+const (
+	// RulePodRestarts fires for pods restarted more than threshold times within the window.
+	RulePodRestarts RuleType = "PodRestarts"
+	// RuleNodeNotReady fires for nodes whose Ready condition is not true.
+	RuleNodeNotReady RuleType = "NodeNotReady"
+	// RulePersistentVolumeClaimUsage fires for persistent volume claims filled above threshold
+	// percent of their capacity.
+	RulePersistentVolumeClaimUsage RuleType = "PersistentVolumeClaimUsage"
+	// RuleCertificateExpiring fires for certificates of TLS secrets expiring within threshold days.
+	RuleCertificateExpiring RuleType = "CertificateExpiring"
+)
+
+// Severity of alerts raised by a rule.
+type Severity string
+
+const (
+	SeverityInfo     Severity = notification.SeverityInfo
+	SeverityWarning  Severity = notification.SeverityWarning
+	SeverityCritical Severity = notification.SeverityCritical
+)
+
+const (
+	// DefaultRestartThreshold is used by pod restart rules without threshold.
+	DefaultRestartThreshold = 3
+	// DefaultRestartWindowMinutes is used by pod restart rules without window.
+	DefaultRestartWindowMinutes = 10
+	// DefaultUsageThreshold is used by persistent volume claim usage rules without threshold.
+	DefaultUsageThreshold = 90
+
+	// maxWindowMinutes limits window of pod restart rules, as restart counts are kept in memory for
+	// the whole window.
+	maxWindowMinutes = 24 * 60
+)
+
+// Rule is a condition defined by users that raises an alert for every object matching it.
+type Rule struct {
+	Name     string   `json:"name"`
+	Type     RuleType `json:"type"`
+	Severity Severity `json:"severity"`
+
+	// Namespace the rule is limited to. Empty namespace means all namespaces. Node rules ignore it.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Threshold is the number of restarts, percent of capacity or days to expiry, depending on type
+	// of the rule. Default of the type is used when it is not set.
+	Threshold float64 `json:"threshold,omitempty"`
+
+	// WindowMinutes is the time window restarts are counted in by pod restart rules.
+	WindowMinutes int `json:"windowMinutes,omitempty"`
+
+	// Disabled rules are not evaluated.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// threshold returns threshold of the rule or default of its type.
+func (self Rule) threshold() float64 {
+	if self.Threshold > 0 {
+		return self.Threshold
+	}
+	switch self.Type {
+	case RulePodRestarts:
+		return DefaultRestartThreshold
+	case RulePersistentVolumeClaimUsage:
+		return DefaultUsageThreshold
+	case RuleCertificateExpiring:
+		return settings.DefaultCertificateExpiryWarningDays
+	}
+	return 0
+}
+
+// windowMinutes returns window of the rule or the default one.
+func (self Rule) windowMinutes() int {
+	if self.WindowMinutes > 0 {
+		return self.WindowMinutes
+	}
+	return DefaultRestartWindowMinutes
+}
+
+// ValidateRule returns bad request error if the rule is not valid.
+func ValidateRule(rule Rule) error {
+	if errs := validation.IsDNS1123Label(rule.Name); len(errs) > 0 {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("invalid rule name %q: %v", rule.Name, errs))
+	}
+
+	switch rule.Type {
+	case RulePodRestarts, RuleNodeNotReady, RulePersistentVolumeClaimUsage, RuleCertificateExpiring:
+	default:
+		return errorsK8s.NewBadRequest(fmt.Sprintf("unknown type of rule %s: %q", rule.Name, rule.Type))
+	}
+
+	switch rule.Severity {
+	case SeverityInfo, SeverityWarning, SeverityCritical:
+	default:
+		return errorsK8s.NewBadRequest(fmt.Sprintf("unknown severity of rule %s: %q", rule.Name,
+			rule.Severity))
+	}
+
+	if rule.Namespace != "" {
+		if errs := validation.IsDNS1123Label(rule.Namespace); len(errs) > 0 {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("invalid namespace of rule %s: %v", rule.Name, errs))
+		}
+	}
+	if rule.Threshold < 0 {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("threshold of rule %s cannot be negative", rule.Name))
+	}
+	if rule.Type == RulePersistentVolumeClaimUsage && rule.Threshold > 100 {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("threshold of rule %s has to be a percentage",
+			rule.Name))
+	}
+	if rule.WindowMinutes < 0 || rule.WindowMinutes > maxWindowMinutes {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("window of rule %s has to be between 1 and %d minutes",
+			rule.Name, maxWindowMinutes))
+	}
+	return nil
+}
@@ -71,8 +71,17 @@ type TypeMeta struct {
 
 // ListMeta describes list of objects, i.e. holds information about pagination options set for the list.
 type ListMeta struct {
-	// Total number of items on the list. Used for pagination.
+	// Total number of items on the list. Used for pagination. When the list was fetched in chunks,
+	// it is the number of items of the chunk.
 	TotalItems int `json:"totalItems"`
+
+	// Token to fetch the next chunk of the list. Set only if the list was fetched in chunks and
+	// there are more items.
+	Continue string `json:"continue,omitempty"`
+
+	// Number of items on the list after the chunk. Set only if the list was fetched in chunks and
+	// the apiserver reports it.
+	RemainingItemCount *int64 `json:"remainingItemCount,omitempty"`
 }
 
 // NewObjectMeta returns internal endpoint name for the given service properties, e.g.,
@@ -101,31 +110,51 @@ type ResourceKind string
 
 // List of all resource kinds supported by the UI.
 const (
-	ResourceKindConfigMap               = "configmap"
-	ResourceKindDaemonSet               = "daemonset"
-	ResourceKindDeployment              = "deployment"
-	ResourceKindEvent                   = "event"
-	ResourceKindHorizontalPodAutoscaler = "horizontalpodautoscaler"
-	ResourceKindIngress                 = "ingress"
-	ResourceKindJob                     = "job"
-	ResourceKindLimitRange              = "limitrange"
-	ResourceKindNamespace               = "namespace"
-	ResourceKindNode                    = "node"
-	ResourceKindPersistentVolumeClaim   = "persistentvolumeclaim"
-	ResourceKindPersistentVolume        = "persistentvolume"
-	ResourceKindPod                     = "pod"
-	ResourceKindReplicaSet              = "replicaset"
-	ResourceKindReplicationController   = "replicationcontroller"
-	ResourceKindResourceQuota           = "resourcequota"
-	ResourceKindSecret                  = "secret"
-	ResourceKindService                 = "service"
-	ResourceKindStatefulSet             = "statefulset"
-	ResourceKindThirdPartyResource      = "thirdpartyresource"
-	ResourceKindStorageClass            = "storageclass"
-	ResourceKindRbacRole                = "role"
-	ResourceKindRbacClusterRole         = "clusterrole"
-	ResourceKindRbacRoleBinding         = "rolebinding"
-	ResourceKindRbacClusterRoleBinding  = "clusterrolebinding"
+	ResourceKindCertificateRequest             = "certificaterequest"
+	ResourceKindCertManagerCertificate         = "certmanagercertificate"
+	ResourceKindClusterIssuer                  = "clusterissuer"
+	ResourceKindConfigMap                      = "configmap"
+	ResourceKindCronJob                        = "cronjob"
+	ResourceKindCustomResourceDefinition       = "customresourcedefinition"
+	ResourceKindDaemonSet                      = "daemonset"
+	ResourceKindDeployment                     = "deployment"
+	ResourceKindDestinationRule                = "destinationrule"
+	ResourceKindEvent                          = "event"
+	ResourceKindHorizontalPodAutoscaler        = "horizontalpodautoscaler"
+	ResourceKindIngress                        = "ingress"
+	ResourceKindIssuer                         = "issuer"
+	ResourceKindJob                            = "job"
+	ResourceKindLimitRange                     = "limitrange"
+	ResourceKindMutatingWebhookConfiguration   = "mutatingwebhookconfiguration"
+	ResourceKindNamespace                      = "namespace"
+	ResourceKindNetworkPolicy                  = "networkpolicy"
+	ResourceKindNode                           = "node"
+	ResourceKindPeerAuthentication             = "peerauthentication"
+	ResourceKindPersistentVolumeClaim          = "persistentvolumeclaim"
+	ResourceKindPersistentVolume               = "persistentvolume"
+	ResourceKindPodDisruptionBudget            = "poddisruptionbudget"
+	ResourceKindPod                            = "pod"
+	ResourceKindPriorityClass                  = "priorityclass"
+	ResourceKindReplicaSet                     = "replicaset"
+	ResourceKindReplicationController          = "replicationcontroller"
+	ResourceKindResourceQuota                  = "resourcequota"
+	ResourceKindSecret                         = "secret"
+	ResourceKindService                        = "service"
+	ResourceKindServiceAccount                 = "serviceaccount"
+	ResourceKindServiceProfile                 = "serviceprofile"
+	ResourceKindStatefulSet                    = "statefulset"
+	ResourceKindThirdPartyResource             = "thirdpartyresource"
+	ResourceKindValidatingWebhookConfiguration = "validatingwebhookconfiguration"
+	ResourceKindStorageClass                   = "storageclass"
+	ResourceKindVirtualService                 = "virtualservice"
+	ResourceKindVeleroBackup                   = "velerobackup"
+	ResourceKindVeleroRestore                  = "velerorestore"
+	ResourceKindVeleroSchedule                 = "veleroschedule"
+	ResourceKindVolumeSnapshot                 = "volumesnapshot"
+	ResourceKindRbacRole                       = "role"
+	ResourceKindRbacClusterRole                = "clusterrole"
+	ResourceKindRbacRoleBinding                = "rolebinding"
+	ResourceKindRbacClusterRoleBinding         = "clusterrolebinding"
 )
 
 // ClientType represents type of client that is used to perform generic operations on resources.
@@ -141,6 +170,7 @@ const (
 	ClientTypeBatchClient       = "batchclient"
 	ClientTypeAutoscalingClient = "autoscalingclient"
 	ClientTypeStorageClient     = "storageclient"
+	ClientTypePolicyClient      = "policyclient"
 )
 
 // Mapping from resource kind to K8s apiserver API path. This is mostly pluralization, because
@@ -161,17 +191,20 @@ var KindToAPIMapping = map[string]struct {
 	ResourceKindHorizontalPodAutoscaler: {"horizontalpodautoscalers", ClientTypeAutoscalingClient, true},
 	ResourceKindIngress:                 {"ingresses", ClientTypeExtensionClient, true},
 	ResourceKindJob:                     {"jobs", ClientTypeBatchClient, true},
-	ResourceKindLimitRange:              {"limitrange", ClientTypeDefault, true},
+	ResourceKindLimitRange:              {"limitranges", ClientTypeDefault, true},
 	ResourceKindNamespace:               {"namespaces", ClientTypeDefault, false},
+	ResourceKindNetworkPolicy:           {"networkpolicies", ClientTypeExtensionClient, true},
 	ResourceKindNode:                    {"nodes", ClientTypeDefault, false},
 	ResourceKindPersistentVolumeClaim:   {"persistentvolumeclaims", ClientTypeDefault, true},
 	ResourceKindPersistentVolume:        {"persistentvolumes", ClientTypeDefault, false},
 	ResourceKindPod:                     {"pods", ClientTypeDefault, true},
+	ResourceKindPodDisruptionBudget:     {"poddisruptionbudgets", ClientTypePolicyClient, true},
 	ResourceKindReplicaSet:              {"replicasets", ClientTypeExtensionClient, true},
 	ResourceKindReplicationController:   {"replicationcontrollers", ClientTypeDefault, true},
 	ResourceKindResourceQuota:           {"resourcequotas", ClientTypeDefault, true},
 	ResourceKindSecret:                  {"secrets", ClientTypeDefault, true},
 	ResourceKindService:                 {"services", ClientTypeDefault, true},
+	ResourceKindServiceAccount:          {"serviceaccounts", ClientTypeDefault, true},
 	ResourceKindStatefulSet:             {"statefulsets", ClientTypeAppsClient, true},
 	ResourceKindThirdPartyResource:      {"thirdpartyresources", ClientTypeExtensionClient, true},
 	ResourceKindStorageClass:            {"storageclasses", ClientTypeStorageClient, false},
@@ -0,0 +1,167 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records operations that users perform through Dashboard. Events are written to
+// sinks configured by the operator, e.g. a file or a webhook, and form a trail separate from the
+// audit log of the apiserver.
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+)
+
+const (
+	// ResultSuccess is result of operations that the apiserver accepted.
+	ResultSuccess = "success"
+	// ResultFailure is result of operations that failed or were rejected.
+	ResultFailure = "failure"
+
+	// webhookTimeout limits time of a single webhook call.
+	webhookTimeout = 10 * time.Second
+	// bufferSize is the number of events that wait to be written before new events are dropped.
+	bufferSize = 1000
+)
+
+// Event describes a single operation performed by a user.
+type Event struct {
+	Timestamp time.Time `json:"timestamp"`
+	User      string    `json:"user"`
+	Groups    []string  `json:"groups,omitempty"`
+	SourceIP  string    `json:"sourceIP"`
+	// Verb of the operation, i.e. create, update, delete, exec or portforward.
+	Verb      string `json:"verb"`
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Fields is a summary of the change, i.e. paths of fields set in the request body. Values are
+	// not recorded, as they may contain secrets.
+	Fields []string `json:"fields,omitempty"`
+	// Code is the HTTP status code of the response.
+	Code   int    `json:"code"`
+	Result string `json:"result"`
+}
+
+// Sink writes audit events.
+type Sink interface {
+	// Write writes given event. It returns error if the event could not be written.
+	Write(event Event) error
+}
+
+// fileSink writes events as JSON lines.
+type fileSink struct {
+	sync.Mutex
+	out io.Writer
+}
+
+// Write implements Sink interface.
+func (self *fileSink) Write(event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	self.Lock()
+	defer self.Unlock()
+	_, err = self.out.Write(append(line, '\n'))
+	return err
+}
+
+// NewFileSink creates sink that appends events as JSON lines to given file. "-" writes events to
+// standard output.
+func NewFileSink(path string) (Sink, error) {
+	if path == "-" {
+		return &fileSink{out: os.Stdout}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{out: file}, nil
+}
+
+// webhookSink posts events as JSON to a webhook.
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// Write implements Sink interface.
+func (self webhookSink) Write(event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	response, err := self.client.Post(self.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("audit webhook %s returned status %d", self.url, response.StatusCode)
+	}
+	return nil
+}
+
+// NewWebhookSink creates sink that posts every event as JSON object to given URL.
+func NewWebhookSink(url string) Sink {
+	return webhookSink{url: url, client: &http.Client{Timeout: webhookTimeout}}
+}
+
+// asyncSink buffers events and writes them to other sinks in background, so that slow sinks do not
+// delay responses. Events are dropped when the buffer is full.
+type asyncSink struct {
+	sinks  []Sink
+	events chan Event
+}
+
+// Write implements Sink interface.
+func (self asyncSink) Write(event Event) error {
+	select {
+	case self.events <- event:
+		return nil
+	default:
+		return fmt.Errorf("audit buffer is full, event %s %s %s/%s of user %q dropped", event.Verb,
+			event.Resource, event.Namespace, event.Name, event.User)
+	}
+}
+
+// run writes buffered events to all sinks.
+func (self asyncSink) run() {
+	for event := range self.events {
+		for _, sink := range self.sinks {
+			if err := sink.Write(event); err != nil {
+				logger.Errorf("Failed to write audit event: %s", err)
+			}
+		}
+	}
+}
+
+// NewAsyncSink creates sink that writes events to given sinks in background.
+func NewAsyncSink(sinks ...Sink) Sink {
+	sink := asyncSink{sinks: sinks, events: make(chan Event, bufferSize)}
+	go sink.run()
+	return sink
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testEvent = Event{
+	Timestamp: time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC),
+	User:      "jane",
+	SourceIP:  "10.0.0.1",
+	Verb:      "update",
+	Resource:  "deployment",
+	Namespace: "default",
+	Name:      "nginx",
+	Fields:    []string{"spec.replicas"},
+	Code:      http.StatusOK,
+	Result:    ResultSuccess,
+}
+
+func TestFileSink(t *testing.T) {
+	out := &bytes.Buffer{}
+	sink := &fileSink{out: out}
+	if err := sink.Write(testEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"timestamp":"2017-06-01T12:00:00Z","user":"jane","sourceIP":"10.0.0.1",` +
+		`"verb":"update","resource":"deployment","namespace":"default","name":"nginx",` +
+		`"fields":["spec.replicas"],"code":200,"result":"success"}` + "\n"
+	if out.String() != expected {
+		t.Errorf("Write() wrote %s, expected %s", out.String(), expected)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	cases := []struct {
+		status int
+		valid  bool
+	}{
+		{http.StatusOK, true},
+		{http.StatusInternalServerError, false},
+	}
+
+	for _, c := range cases {
+		received := make(chan Event, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			event := Event{}
+			json.NewDecoder(r.Body).Decode(&event)
+			received <- event
+			w.WriteHeader(c.status)
+		}))
+
+		err := NewWebhookSink(server.URL).Write(testEvent)
+		server.Close()
+		if (err == nil) != c.valid {
+			t.Errorf("Write() to webhook returning %d returns error %v, expected valid %t", c.status,
+				err, c.valid)
+		}
+		if actual := <-received; !reflect.DeepEqual(actual, testEvent) {
+			t.Errorf("Webhook received %#v, expected %#v", actual, testEvent)
+		}
+	}
+}
+
+type channelSink chan Event
+
+func (self channelSink) Write(event Event) error {
+	self <- event
+	return nil
+}
+
+func TestAsyncSink(t *testing.T) {
+	first, second := make(channelSink, 1), make(channelSink, 1)
+	if err := NewAsyncSink(first, second).Write(testEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, sink := range []channelSink{first, second} {
+		select {
+		case actual := <-sink:
+			if !reflect.DeepEqual(actual, testEvent) {
+				t.Errorf("Sink received %#v, expected %#v", actual, testEvent)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("Event was not written to sink")
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"k8s.io/client-go/pkg/apis/authorization/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// toCredentials returns credentials from login spec. Client certificate and kubeconfig are parsed,
+// so that invalid ones are rejected on login.
+func toCredentials(spec LoginSpec) (Credentials, error) {
+	switch {
+	case spec.KubeConfig != "":
+		return credentialsFromKubeConfig([]byte(spec.KubeConfig))
+	case spec.ClientCertificate != "" || spec.ClientKey != "":
+		credentials := Credentials{
+			ClientCertificate: []byte(spec.ClientCertificate),
+			ClientKey:         []byte(spec.ClientKey),
+		}
+		return credentials, validateCertificate(credentials)
+	case spec.Token != "" || (spec.Username != "" && spec.Password != ""):
+		return Credentials{Token: spec.Token, Username: spec.Username, Password: spec.Password}, nil
+	}
+	return Credentials{}, errors.New("token, username and password, client certificate and key or " +
+		"kubeconfig are required")
+}
+
+// credentialsFromKubeConfig returns credentials of the current context of kubeconfig. Credentials
+// have to be embedded, as files referenced by kubeconfig and auth provider plugins are not
+// available to Dashboard.
+func credentialsFromKubeConfig(data []byte) (Credentials, error) {
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("invalid kubeconfig: %s", err)
+	}
+
+	context, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return Credentials{}, fmt.Errorf("current context %q not found in kubeconfig",
+			config.CurrentContext)
+	}
+	authInfo, ok := config.AuthInfos[context.AuthInfo]
+	if !ok {
+		return Credentials{}, fmt.Errorf("user %q not found in kubeconfig", context.AuthInfo)
+	}
+
+	credentials := Credentials{
+		Token:             authInfo.Token,
+		Username:          authInfo.Username,
+		Password:          authInfo.Password,
+		ClientCertificate: authInfo.ClientCertificateData,
+		ClientKey:         authInfo.ClientKeyData,
+	}
+	switch {
+	case len(credentials.ClientCertificate) > 0:
+		return credentials, validateCertificate(credentials)
+	case credentials.Token != "" || (credentials.Username != "" && credentials.Password != ""):
+		return credentials, nil
+	}
+	return Credentials{}, fmt.Errorf("user %q in kubeconfig has no embedded token, username and "+
+		"password or client certificate data", context.AuthInfo)
+}
+
+// validateCertificate returns error if client certificate and key are not a valid pair.
+func validateCertificate(credentials Credentials) error {
+	if _, err := tls.X509KeyPair(credentials.ClientCertificate, credentials.ClientKey); err != nil {
+		return fmt.Errorf("invalid client certificate: %s", err)
+	}
+	return nil
+}
+
+// toAuthInfo returns credentials in the format used by client manager.
+func toAuthInfo(credentials Credentials) api.AuthInfo {
+	return api.AuthInfo{
+		Token:                 credentials.Token,
+		Username:              credentials.Username,
+		Password:              credentials.Password,
+		ClientCertificateData: credentials.ClientCertificate,
+		ClientKeyData:         credentials.ClientKey,
+	}
+}
+
+// validateCredentials checks that the apiserver of the cluster the credentials were issued for
+// authenticates the user with given credentials. Self subject access review can be created by every
+// authenticated user, but not by anonymous ones.
+func validateCredentials(clientManager client.ClientManager, credentials Credentials) error {
+	query := url.Values{client.ClusterParameter: []string{credentials.Cluster}}
+	httpRequest, err := http.NewRequest(http.MethodPost, "/login?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	request := restful.NewRequest(httpRequest)
+	setCredentials(request, credentials)
+
+	k8sClient, err := clientManager.Client(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = k8sClient.AuthorizationV1().SelfSubjectAccessReviews().Create(
+		&v1.SelfSubjectAccessReview{
+			Spec: v1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &v1.ResourceAttributes{Verb: "get", Resource: "namespaces"},
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("could not authenticate to the apiserver: %s", err)
+	}
+	return nil
+}
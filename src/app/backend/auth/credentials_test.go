@@ -0,0 +1,166 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+)
+
+const testKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: production
+  cluster:
+    server: https://production.example.com
+contexts:
+- name: production
+  context:
+    cluster: production
+    user: %s
+current-context: production
+users:
+- name: jane
+  user:
+    token: %s
+- name: external
+  user:
+    client-certificate: /home/jane/.kube/client.crt
+    client-key: /home/jane/.kube/client.key
+`
+
+func newTestCertificatePEM(t *testing.T) ([]byte, []byte) {
+	ca, caKey := newTestCertificate(t, "ca", 1, nil, nil)
+	certificate, key := newTestCertificate(t, "jane", 2, ca, caKey)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestToCredentials(t *testing.T) {
+	certificate, key := newTestCertificatePEM(t)
+
+	cases := []struct {
+		info        string
+		spec        LoginSpec
+		expected    Credentials
+		expectedErr bool
+	}{
+		{"token", LoginSpec{Token: "token"}, Credentials{Token: "token"}, false},
+		{"no credentials", LoginSpec{Username: "jane"}, Credentials{}, true},
+		{
+			"client certificate",
+			LoginSpec{ClientCertificate: string(certificate), ClientKey: string(key)},
+			Credentials{ClientCertificate: certificate, ClientKey: key},
+			false,
+		},
+		{
+			"client certificate without key",
+			LoginSpec{ClientCertificate: string(certificate)},
+			Credentials{ClientCertificate: certificate, ClientKey: []byte{}},
+			true,
+		},
+		{
+			"kubeconfig with token",
+			LoginSpec{KubeConfig: fmt.Sprintf(testKubeConfig, "jane", "kubeconfig-token")},
+			Credentials{Token: "kubeconfig-token"},
+			false,
+		},
+		{
+			"kubeconfig with certificate files",
+			LoginSpec{KubeConfig: fmt.Sprintf(testKubeConfig, "external", "")},
+			Credentials{},
+			true,
+		},
+		{"invalid kubeconfig", LoginSpec{KubeConfig: "{"}, Credentials{}, true},
+	}
+
+	for _, c := range cases {
+		credentials, err := toCredentials(c.spec)
+		if (err != nil) != c.expectedErr {
+			t.Errorf("%s: expected error %t, but got %v", c.info, c.expectedErr, err)
+			continue
+		}
+		if !c.expectedErr && !reflect.DeepEqual(credentials, c.expected) {
+			t.Errorf("%s: expected credentials %#v, but got %#v", c.info, c.expected, credentials)
+		}
+	}
+}
+
+func TestLoginWithKubeConfig(t *testing.T) {
+	apiserver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews" ||
+			r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"kind":"SelfSubjectAccessReview","apiVersion":"authorization.k8s.io/v1",`+
+			`"status":{"allowed":true}}`)
+	}))
+	defer apiserver.Close()
+
+	// Credentials are sent only to apiservers with TLS, so Dashboard has to skip verification of
+	// the test server certificate.
+	kubeConfig, err := ioutil.TempFile("", "kubeconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(kubeConfig.Name())
+	fmt.Fprintf(kubeConfig, `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+    insecure-skip-tls-verify: true
+contexts:
+- name: test
+  context:
+    cluster: test
+current-context: test
+`, apiserver.URL)
+	kubeConfig.Close()
+
+	now := time.Now()
+	manager := NewAuthManager(client.NewClientManager(kubeConfig.Name(), ""), OIDCOptions{},
+		AuthProxyOptions{}, newTestTokenManager(t, &now))
+
+	if _, err := manager.Login(LoginSpec{
+		KubeConfig: fmt.Sprintf(testKubeConfig, "jane", "valid-token"),
+	}); err != nil {
+		t.Errorf("Login(): expected kubeconfig with valid token to be accepted, but got %v", err)
+	}
+
+	if _, err := manager.Login(LoginSpec{
+		KubeConfig: fmt.Sprintf(testKubeConfig, "jane", "invalid-token"),
+	}); err == nil {
+		t.Error("Login(): expected kubeconfig with invalid token to be rejected")
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+)
+
+const (
+	// oidcCookiePath is the path, relative to the base path of Dashboard, of cookies used only by
+	// OpenID Connect endpoints. It has to be explicit, so that logout, which is served outside of
+	// it, expires the same cookies.
+	oidcCookiePath = "api/v1/oidc"
+	// stateMaxAge is the time the user has to log in with the provider.
+	stateMaxAge = 5 * time.Minute
+	// postLoginRedirect is the Dashboard root relative to the callback endpoint. It is relative, so
+	// that it works when Dashboard is served under a path prefix.
+	postLoginRedirect = "../../../"
+	// proxiedResponseSandbox lets proxied pages run scripts, submit forms and open popups, but not
+	// in the Dashboard origin.
+	proxiedResponseSandbox = "sandbox allow-scripts allow-forms allow-popups"
+)
+
+// AuthHandler manages all endpoints related to login and sessions.
+type AuthHandler struct {
+	manager AuthManager
+	// cookiePath of cookies used only by OpenID Connect endpoints.
+	cookiePath string
+}
+
+// Install creates new endpoints for login with credentials:
+//
+//   - POST /login creates session with token or username and password of the user. Session token
+//     is returned in the response and stored in SessionCookie.
+//   - POST /logout removes cookies of the session.
+//
+// and for OpenID Connect authorization code flow:
+//
+//   - GET /oidc/login redirects the user to the provider.
+//   - GET /oidc/callback is called by the provider, stores tokens in cookies and redirects to
+//     Dashboard.
+//   - POST /oidc/token exchanges authorization code for tokens returned in the response.
+//   - POST /oidc/refresh obtains new tokens with refresh token.
+func (self AuthHandler) Install(ws *restful.WebService) {
+	ws.Route(
+		ws.POST("/login").
+			To(self.handleLogin).
+			Reads(LoginSpec{}).
+			Writes(LoginResponse{}))
+	ws.Route(
+		ws.POST("/logout").
+			To(self.handleLogout))
+	ws.Route(
+		ws.GET("/oidc/login").
+			To(self.handleOIDCLogin))
+	ws.Route(
+		ws.GET("/oidc/callback").
+			To(self.handleCallback))
+	ws.Route(
+		ws.POST("/oidc/token").
+			To(self.handleTokenExchange).
+			Reads(TokenExchangeSpec{}).
+			Writes(AuthResponse{}))
+	ws.Route(
+		ws.POST("/oidc/refresh").
+			To(self.handleTokenRefresh).
+			Reads(TokenRefreshSpec{}).
+			Writes(AuthResponse{}))
+}
+
+func (self AuthHandler) handleLogin(request *restful.Request, response *restful.Response) {
+	spec := new(LoginSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleError(response, http.StatusBadRequest, err)
+		return
+	}
+	spec.Cluster = request.QueryParameter(client.ClusterParameter)
+
+	loginResponse, err := self.manager.Login(*spec)
+	if err != nil {
+		handleError(response, http.StatusUnauthorized, err)
+		return
+	}
+
+	http.SetCookie(response, newCookie(request, SessionCookie, loginResponse.SessionToken, "/",
+		time.Time{}))
+	response.WriteHeaderAndEntity(http.StatusOK, loginResponse)
+}
+
+func (self AuthHandler) handleLogout(request *restful.Request, response *restful.Response) {
+	expired := time.Unix(0, 0)
+	http.SetCookie(response, newCookie(request, SessionCookie, "", "/", expired))
+	http.SetCookie(response, newCookie(request, IDTokenCookie, "", "/", expired))
+	http.SetCookie(response, newCookie(request, RefreshTokenCookie, "", self.cookiePath, expired))
+	http.SetCookie(response, newCookie(request, StateCookie, "", self.cookiePath, expired))
+	response.WriteHeader(http.StatusOK)
+}
+
+func (self AuthHandler) handleOIDCLogin(request *restful.Request, response *restful.Response) {
+	state, err := generateState()
+	if err != nil {
+		handleError(response, http.StatusInternalServerError, err)
+		return
+	}
+
+	loginURL, err := self.manager.LoginURL(state)
+	if err != nil {
+		handleError(response, http.StatusServiceUnavailable, err)
+		return
+	}
+
+	http.SetCookie(response, newCookie(request, StateCookie, state, self.cookiePath,
+		time.Now().Add(stateMaxAge)))
+	http.Redirect(response, request.Request, loginURL, http.StatusFound)
+}
+
+func (self AuthHandler) handleCallback(request *restful.Request, response *restful.Response) {
+	if providerErr := request.QueryParameter("error"); providerErr != "" {
+		handleError(response, http.StatusUnauthorized, errors.New(providerErr+": "+
+			request.QueryParameter("error_description")))
+		return
+	}
+
+	state, err := request.Request.Cookie(StateCookie)
+	if err != nil || state.Value == "" || state.Value != request.QueryParameter("state") {
+		handleError(response, http.StatusBadRequest, errors.New("invalid login state"))
+		return
+	}
+	http.SetCookie(response, newCookie(request, StateCookie, "", self.cookiePath, time.Unix(0, 0)))
+
+	authResponse, err := self.manager.Exchange(request.QueryParameter("code"))
+	if err != nil {
+		handleError(response, http.StatusUnauthorized, err)
+		return
+	}
+
+	self.setTokenCookies(request, response, authResponse)
+	response.AddHeader("Location", postLoginRedirect)
+	response.WriteHeader(http.StatusFound)
+}
+
+func (self AuthHandler) handleTokenExchange(request *restful.Request, response *restful.Response) {
+	spec := new(TokenExchangeSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleError(response, http.StatusBadRequest, err)
+		return
+	}
+
+	authResponse, err := self.manager.Exchange(spec.Code)
+	if err != nil {
+		handleError(response, http.StatusUnauthorized, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, authResponse)
+}
+
+func (self AuthHandler) handleTokenRefresh(request *restful.Request, response *restful.Response) {
+	spec := new(TokenRefreshSpec)
+	if err := request.ReadEntity(spec); err != nil && err != io.EOF {
+		handleError(response, http.StatusBadRequest, err)
+		return
+	}
+
+	fromCookie := false
+	if spec.RefreshToken == "" {
+		if cookie, err := request.Request.Cookie(RefreshTokenCookie); err == nil {
+			spec.RefreshToken = cookie.Value
+			fromCookie = true
+		}
+	}
+
+	authResponse, err := self.manager.Refresh(spec.RefreshToken)
+	if err != nil {
+		handleError(response, http.StatusUnauthorized, err)
+		return
+	}
+
+	// Tokens kept in cookies are never exposed to scripts.
+	if fromCookie {
+		self.setTokenCookies(request, response, authResponse)
+		authResponse = &AuthResponse{Expiry: authResponse.Expiry}
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, authResponse)
+}
+
+// TokenCookieFilter forwards ID token stored in IDTokenCookie to the apiserver by setting it as
+// bearer token of requests that do not have Authorization header.
+func TokenCookieFilter(request *restful.Request, response *restful.Response,
+	chain *restful.FilterChain) {
+	if request.HeaderParameter("Authorization") == "" {
+		if cookie, err := request.Request.Cookie(IDTokenCookie); err == nil && cookie.Value != "" {
+			request.Request.Header.Set("Authorization", "Bearer "+cookie.Value)
+			request.SetAttribute(CookieAuthAttribute, true)
+		}
+	}
+	chain.ProcessFilter(request, response)
+}
+
+// RemoveTokenCookies removes cookies of the auth module from the request, so that tokens of the
+// user are not forwarded to other servers.
+func RemoveTokenCookies(request *http.Request) {
+	cookies := request.Cookies()
+	request.Header.Del("Cookie")
+	for _, cookie := range cookies {
+		if cookie.Name != IDTokenCookie && cookie.Name != RefreshTokenCookie &&
+			cookie.Name != StateCookie && cookie.Name != SessionCookie {
+			request.AddCookie(cookie)
+		}
+	}
+}
+
+// SandboxProxiedResponse isolates the response of a server proxied by Dashboard, i.e. a web UI
+// running in the cluster, from the Dashboard origin it is served on. Content security policy runs
+// the page in a sandbox with a unique origin, so that its scripts can neither read cookies nor
+// call the API with the session of the user, and cookies set by the server are dropped.
+func SandboxProxiedResponse(response *http.Response) {
+	response.Header.Add("Content-Security-Policy", proxiedResponseSandbox)
+	response.Header.Del("Set-Cookie")
+}
+
+func (self AuthHandler) setTokenCookies(request *restful.Request, response *restful.Response,
+	authResponse *AuthResponse) {
+	http.SetCookie(response, newCookie(request, IDTokenCookie, authResponse.IDToken, "/",
+		authResponse.Expiry))
+	if authResponse.RefreshToken != "" {
+		http.SetCookie(response, newCookie(request, RefreshTokenCookie, authResponse.RefreshToken,
+			self.cookiePath, time.Time{}))
+	}
+}
+
+// newCookie creates cookie that is not accessible to scripts. Zero expiration time creates
+// session cookie.
+func newCookie(request *restful.Request, name, value, path string, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   request.Request.TLS != nil,
+	}
+}
+
+func generateState() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func handleError(response *restful.Response, status int, err error) {
+	logger.Error(err)
+	response.AddHeader("Content-Type", "text/plain")
+	response.WriteErrorString(status, err.Error()+"\n")
+}
+
+// NewAuthHandler creates AuthHandler for Dashboard exposed under given base path.
+func NewAuthHandler(manager AuthManager, basePath string) AuthHandler {
+	return AuthHandler{manager: manager, cookiePath: path.Join("/", basePath, oidcCookiePath)}
+}
@@ -0,0 +1,184 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/emicklei/go-restful"
+)
+
+func newTestContainer(manager AuthManager, echo func(*restful.Request)) *restful.Container {
+	ws := new(restful.WebService)
+	ws.Path("/api/v1")
+	ws.Filter(TokenCookieFilter)
+	NewAuthHandler(manager, "/").Install(ws)
+	ws.Route(ws.GET("/echo").To(func(request *restful.Request, response *restful.Response) {
+		echo(request)
+	}))
+	container := restful.NewContainer()
+	container.Add(ws)
+	return container
+}
+
+func TestLoginFlow(t *testing.T) {
+	provider := newFakeProvider(t)
+	defer provider.Close()
+	container := newTestContainer(newTestManager(provider.URL), nil)
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/oidc/login", nil)
+	container.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusFound {
+		t.Fatalf("Login returns %d status code, expected %d", recorder.Code, http.StatusFound)
+	}
+	state := (&http.Response{Header: recorder.Header()}).Cookies()[0]
+	if state.Name != StateCookie || state.Value == "" {
+		t.Fatalf("Login sets %#v cookie, expected login state", state)
+	}
+
+	// Callback with state that does not match the cookie is rejected.
+	recorder = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/v1/oidc/callback?code=valid-code&state=other", nil)
+	req.AddCookie(state)
+	container.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Callback with invalid state returns %d status code, expected %d", recorder.Code,
+			http.StatusBadRequest)
+	}
+
+	recorder = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/v1/oidc/callback?code=valid-code&state="+state.Value, nil)
+	req.AddCookie(state)
+	container.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusFound || recorder.Header().Get("Location") != postLoginRedirect {
+		t.Fatalf("Callback returns %d status code with %q location, expected redirect to %q",
+			recorder.Code, recorder.Header().Get("Location"), postLoginRedirect)
+	}
+
+	var idToken string
+	for _, cookie := range (&http.Response{Header: recorder.Header()}).Cookies() {
+		if cookie.Name == IDTokenCookie {
+			idToken = cookie.Value
+		}
+	}
+	if idToken != "id-token-authorization_code" {
+		t.Errorf("Callback sets %q ID token cookie, expected %q", idToken,
+			"id-token-authorization_code")
+	}
+}
+
+func TestLogoutAfterLogin(t *testing.T) {
+	provider := newFakeProvider(t)
+	defer provider.Close()
+	container := newTestContainer(newTestManager(provider.URL), nil)
+
+	state := &http.Cookie{Name: StateCookie, Value: "state"}
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/oidc/callback?code=valid-code&state=state", nil)
+	req.AddCookie(state)
+	container.ServeHTTP(recorder, req)
+	refreshToken := findCookie(recorder, RefreshTokenCookie)
+	if refreshToken == nil || refreshToken.Value == "" || refreshToken.Path != "/api/v1/oidc" {
+		t.Fatalf("Callback sets %#v refresh token cookie, expected one with %q path", refreshToken,
+			"/api/v1/oidc")
+	}
+
+	recorder = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/api/v1/logout", nil)
+	container.ServeHTTP(recorder, req)
+	expired := findCookie(recorder, RefreshTokenCookie)
+	if expired == nil || expired.Value != "" || expired.Path != refreshToken.Path ||
+		expired.Expires.After(time.Now()) {
+		t.Errorf("Logout sets %#v refresh token cookie, expected expired one with %q path", expired,
+			refreshToken.Path)
+	}
+}
+
+func TestNewAuthHandlerCookiePath(t *testing.T) {
+	cases := []struct {
+		basePath string
+		expected string
+	}{
+		{"/", "/api/v1/oidc"},
+		{"", "/api/v1/oidc"},
+		{"/dashboard/", "/dashboard/api/v1/oidc"},
+	}
+
+	for _, c := range cases {
+		actual := NewAuthHandler(nil, c.basePath).cookiePath
+		if actual != c.expected {
+			t.Errorf("NewAuthHandler(nil, %q) uses %q cookie path, expected %q", c.basePath, actual,
+				c.expected)
+		}
+	}
+}
+
+func findCookie(recorder *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range (&http.Response{Header: recorder.Header()}).Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestTokenCookieFilter(t *testing.T) {
+	cases := []struct {
+		header   string
+		cookie   string
+		expected string
+	}{
+		{"", "", ""},
+		{"", "id-token", "Bearer id-token"},
+		{"Bearer header-token", "id-token", "Bearer header-token"},
+	}
+
+	for _, c := range cases {
+		var actual string
+		container := newTestContainer(newTestManager(""), func(request *restful.Request) {
+			actual = request.HeaderParameter("Authorization")
+		})
+
+		req, _ := http.NewRequest("GET", "/api/v1/echo", nil)
+		if c.header != "" {
+			req.Header.Set("Authorization", c.header)
+		}
+		if c.cookie != "" {
+			req.AddCookie(&http.Cookie{Name: IDTokenCookie, Value: c.cookie})
+		}
+		container.ServeHTTP(httptest.NewRecorder(), req)
+
+		if actual != c.expected {
+			t.Errorf("Authorization header is %q, expected %q", actual, c.expected)
+		}
+	}
+}
+
+func TestRemoveTokenCookies(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/", nil)
+	req.AddCookie(&http.Cookie{Name: IDTokenCookie, Value: "id-token"})
+	req.AddCookie(&http.Cookie{Name: "app", Value: "value"})
+	req.AddCookie(&http.Cookie{Name: RefreshTokenCookie, Value: "refresh-token"})
+
+	RemoveTokenCookies(req)
+
+	if cookie := req.Header.Get("Cookie"); cookie != "app=value" {
+		t.Errorf("Cookie header is %q, expected %q", cookie, "app=value")
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// jweHeader is the protected header of session tokens. Tokens are encrypted directly with the
+// shared key (alg "dir") using AES-256-GCM (enc "A256GCM"), see RFC 7516.
+const jweHeader = `{"alg":"dir","enc":"A256GCM","typ":"JWT"}`
+
+// sessionKeySize is the size of AES-256 key in bytes.
+const sessionKeySize = 32
+
+// sessionClaims is the encrypted content of session token.
+type sessionClaims struct {
+	// ID of the session, the same for all tokens of the session.
+	ID          string      `json:"jti"`
+	Credentials Credentials `json:"credentials"`
+	// Start of the session as Unix time. Session can not be refreshed past AbsoluteTTL from start.
+	Start int64 `json:"sst"`
+	// IssuedAt is Unix time when the token was issued.
+	IssuedAt int64 `json:"iat"`
+	// Expiry is Unix time when the token expires.
+	Expiry int64 `json:"exp"`
+}
+
+// Implements TokenManager interface.
+type jweTokenManager struct {
+	aead    cipher.AEAD
+	options SessionOptions
+	// now returns current time. Replaced in tests.
+	now func() time.Time
+}
+
+// Generate implements token manager interface. See TokenManager for more information.
+func (self *jweTokenManager) Generate(credentials Credentials) (string, *Session, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", nil, err
+	}
+	return self.issue(hex.EncodeToString(id), credentials, self.now())
+}
+
+// Decrypt implements token manager interface. See TokenManager for more information.
+func (self *jweTokenManager) Decrypt(token string) (*Session, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 5 || parts[1] != "" {
+		return nil, errors.New("invalid session token")
+	}
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || string(header) != jweHeader {
+		return nil, errors.New("unsupported session token")
+	}
+
+	var decoded [3][]byte
+	for i, part := range parts[2:] {
+		if decoded[i], err = base64.RawURLEncoding.DecodeString(part); err != nil {
+			return nil, errors.New("invalid session token")
+		}
+	}
+	nonce, ciphertext, tag := decoded[0], decoded[1], decoded[2]
+	if len(nonce) != self.aead.NonceSize() {
+		return nil, errors.New("invalid session token")
+	}
+
+	plaintext, err := self.aead.Open(nil, nonce, append(ciphertext, tag...), []byte(parts[0]))
+	if err != nil {
+		return nil, errors.New("invalid session token")
+	}
+
+	claims := sessionClaims{}
+	if err := json.Unmarshal(plaintext, &claims); err != nil {
+		return nil, err
+	}
+	if !self.now().Before(time.Unix(claims.Expiry, 0)) {
+		return nil, ErrSessionExpired
+	}
+
+	return &Session{
+		ID:          claims.ID,
+		Credentials: claims.Credentials,
+		Start:       time.Unix(claims.Start, 0),
+		IssuedAt:    time.Unix(claims.IssuedAt, 0),
+		Expiry:      time.Unix(claims.Expiry, 0),
+	}, nil
+}
+
+// Refresh implements token manager interface. See TokenManager for more information.
+func (self *jweTokenManager) Refresh(session *Session) (string, *Session, error) {
+	return self.issue(session.ID, session.Credentials, session.Start)
+}
+
+// ShouldRefresh implements token manager interface. See TokenManager for more information.
+func (self *jweTokenManager) ShouldRefresh(session *Session) bool {
+	absoluteExpiry := session.Start.Add(self.options.AbsoluteTTL)
+	return session.Expiry.Before(absoluteExpiry) &&
+		session.Expiry.Sub(self.now()) < self.options.IdleTTL/2
+}
+
+// issue encrypts token of the session started at given time. Token expires after IdleTTL, but
+// not later than AbsoluteTTL after start of the session.
+func (self *jweTokenManager) issue(id string, credentials Credentials, start time.Time) (string,
+	*Session, error) {
+	now := self.now()
+	expiry := now.Add(self.options.IdleTTL)
+	if absoluteExpiry := start.Add(self.options.AbsoluteTTL); absoluteExpiry.Before(expiry) {
+		expiry = absoluteExpiry
+	}
+
+	session := &Session{
+		ID:          id,
+		Credentials: credentials,
+		Start:       time.Unix(start.Unix(), 0),
+		IssuedAt:    time.Unix(now.Unix(), 0),
+		Expiry:      time.Unix(expiry.Unix(), 0),
+	}
+	plaintext, err := json.Marshal(sessionClaims{
+		ID:          id,
+		Credentials: credentials,
+		Start:       session.Start.Unix(),
+		IssuedAt:    session.IssuedAt.Unix(),
+		Expiry:      session.Expiry.Unix(),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	nonce := make([]byte, self.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, err
+	}
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(jweHeader))
+	sealed := self.aead.Seal(nil, nonce, plaintext, []byte(header))
+	tagStart := len(sealed) - self.aead.Overhead()
+	token := strings.Join([]string{
+		header,
+		"",
+		base64.RawURLEncoding.EncodeToString(nonce),
+		base64.RawURLEncoding.EncodeToString(sealed[:tagStart]),
+		base64.RawURLEncoding.EncodeToString(sealed[tagStart:]),
+	}, ".")
+	return token, session, nil
+}
+
+// NewTokenManager creates token manager that issues session tokens encrypted as JWE. The key has
+// to be the same for all replicas of Dashboard, otherwise sessions are valid only in the replica
+// that created them.
+func NewTokenManager(options SessionOptions) (TokenManager, error) {
+	if len(options.Key) != sessionKeySize {
+		return nil, errors.New("session key has to be 32 bytes long")
+	}
+	if options.IdleTTL <= 0 || options.AbsoluteTTL <= 0 {
+		return nil, errors.New("session TTLs have to be positive")
+	}
+
+	block, err := aes.NewCipher(options.Key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &jweTokenManager{aead: aead, options: options, now: time.Now}, nil
+}
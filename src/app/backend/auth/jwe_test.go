@@ -0,0 +1,106 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestTokenManager(t *testing.T, now *time.Time) *jweTokenManager {
+	tokenManager, err := NewTokenManager(SessionOptions{
+		Key:         bytes.Repeat([]byte{1}, sessionKeySize),
+		IdleTTL:     30 * time.Minute,
+		AbsoluteTTL: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewTokenManager() returns unexpected error: %v", err)
+	}
+	manager := tokenManager.(*jweTokenManager)
+	manager.now = func() time.Time { return *now }
+	return manager
+}
+
+func TestSessionTokenLifecycle(t *testing.T) {
+	now := time.Date(2017, 5, 1, 10, 0, 0, 0, time.UTC)
+	manager := newTestTokenManager(t, &now)
+	credentials := Credentials{Token: "user-token"}
+
+	token, _, err := manager.Generate(credentials)
+	if err != nil {
+		t.Fatalf("Generate() returns unexpected error: %v", err)
+	}
+	if strings.Contains(token, "user-token") || strings.Count(token, ".") != 4 {
+		t.Errorf("Generate() returns %q, expected JWE compact serialization", token)
+	}
+
+	now = now.Add(10 * time.Minute)
+	session, err := manager.Decrypt(token)
+	if err != nil {
+		t.Fatalf("Decrypt() returns unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(session.Credentials, credentials) {
+		t.Errorf("Decrypt() returns %#v credentials, expected %#v", session.Credentials, credentials)
+	}
+	if manager.ShouldRefresh(session) {
+		t.Error("ShouldRefresh() expected false for recently issued token")
+	}
+
+	// Activity in the second half of idle TTL extends the session.
+	now = now.Add(10 * time.Minute)
+	if !manager.ShouldRefresh(session) {
+		t.Fatal("ShouldRefresh() expected true for token close to idle expiration")
+	}
+	token, session, err = manager.Refresh(session)
+	if err != nil {
+		t.Fatalf("Refresh() returns unexpected error: %v", err)
+	}
+	if expected := now.Add(30 * time.Minute); !session.Expiry.Equal(expected) {
+		t.Errorf("Refreshed token expires at %v, expected %v", session.Expiry, expected)
+	}
+
+	// Refresh does not extend the session past absolute TTL.
+	now = now.Add(25 * time.Minute)
+	_, session, _ = manager.Refresh(session)
+	if expected := time.Date(2017, 5, 1, 11, 0, 0, 0, time.UTC); !session.Expiry.Equal(expected) {
+		t.Errorf("Refreshed token expires at %v, expected absolute expiration %v", session.Expiry,
+			expected)
+	}
+	if manager.ShouldRefresh(session) {
+		t.Error("ShouldRefresh() expected false for token that reached absolute expiration")
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := manager.Decrypt(token); err != ErrSessionExpired {
+		t.Errorf("Decrypt() returns %v for expired token, expected %v", err, ErrSessionExpired)
+	}
+}
+
+func TestDecryptInvalidToken(t *testing.T) {
+	now := time.Now()
+	manager := newTestTokenManager(t, &now)
+	token, _, _ := manager.Generate(Credentials{Username: "admin", Password: "secret"})
+
+	parts := strings.Split(token, ".")
+	parts[3] = parts[3][:len(parts[3])-2] + "AA"
+	for _, invalid := range []string{"", "a.b.c", strings.Join(parts, ".")} {
+		if _, err := manager.Decrypt(invalid); err == nil {
+			t.Errorf("Decrypt(%q) expected error", invalid)
+		}
+	}
+}
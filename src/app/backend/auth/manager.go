@@ -0,0 +1,237 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+)
+
+// discoveryPath is the path of OpenID Connect provider configuration relative to the issuer URL.
+const discoveryPath = "/.well-known/openid-configuration"
+
+// providerConfig contains endpoints of OpenID Connect provider published in its configuration.
+type providerConfig struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+// Implements AuthManager interface.
+type authManager struct {
+	clientManager client.ClientManager
+	options       OIDCOptions
+	proxyOptions  AuthProxyOptions
+	client        *http.Client
+	tokenManager  TokenManager
+
+	// config of the OAuth2 client, created when the provider configuration is discovered.
+	config     *oauth2.Config
+	configLock sync.Mutex
+}
+
+// Enabled implements auth manager interface. See AuthManager for more information.
+func (self *authManager) Enabled() bool {
+	return self.options.IssuerURL != ""
+}
+
+// LoginURL implements auth manager interface. See AuthManager for more information.
+func (self *authManager) LoginURL(state string) (string, error) {
+	config, err := self.getConfig()
+	if err != nil {
+		return "", err
+	}
+	return config.AuthCodeURL(state), nil
+}
+
+// Exchange implements auth manager interface. See AuthManager for more information.
+func (self *authManager) Exchange(code string) (*AuthResponse, error) {
+	config, err := self.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := config.Exchange(self.context(), code)
+	if err != nil {
+		return nil, err
+	}
+	return toAuthResponse(token, "")
+}
+
+// Refresh implements auth manager interface. See AuthManager for more information.
+func (self *authManager) Refresh(refreshToken string) (*AuthResponse, error) {
+	if refreshToken == "" {
+		return nil, errors.New("refresh token is required")
+	}
+
+	config, err := self.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// Expired token makes the token source refresh it immediately.
+	expired := &oauth2.Token{RefreshToken: refreshToken, Expiry: time.Now().Add(-time.Minute)}
+	token, err := config.TokenSource(self.context(), expired).Token()
+	if err != nil {
+		return nil, err
+	}
+	return toAuthResponse(token, refreshToken)
+}
+
+// Login implements auth manager interface. See AuthManager for more information. Token and
+// username and password are not verified on login, the apiserver verifies them on every request
+// made with the session. Client certificates and kubeconfig are verified against the apiserver, as
+// users who do not use tokens at all can not find out why they are rejected later.
+func (self *authManager) Login(spec LoginSpec) (*LoginResponse, error) {
+	if self.tokenManager == nil {
+		return nil, errors.New("Login is not configured")
+	}
+
+	credentials, err := toCredentials(spec)
+	if err != nil {
+		return nil, err
+	}
+	credentials.Cluster = spec.Cluster
+	if spec.KubeConfig != "" || len(credentials.ClientCertificate) > 0 {
+		if self.clientManager == nil {
+			return nil, errors.New("Login with client certificate is not configured")
+		}
+		if err := validateCredentials(self.clientManager, credentials); err != nil {
+			return nil, err
+		}
+	}
+
+	token, session, err := self.tokenManager.Generate(credentials)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResponse{SessionToken: token, Expiry: session.Expiry}, nil
+}
+
+// TokenManager implements auth manager interface. See AuthManager for more information.
+func (self *authManager) TokenManager() TokenManager {
+	return self.tokenManager
+}
+
+// VerifyProxy implements auth manager interface. See AuthManager for more information.
+func (self *authManager) VerifyProxy(request *http.Request) error {
+	secret := self.proxyOptions.SharedSecret
+	if secret != "" && subtle.ConstantTimeCompare([]byte(request.Header.Get(ProxySecretHeader)),
+		[]byte(secret)) == 1 {
+		return nil
+	}
+
+	if self.proxyOptions.ClientCAs != nil && request.TLS != nil &&
+		verifyClientCertificate(request.TLS.PeerCertificates, self.proxyOptions) == nil {
+		return nil
+	}
+
+	return ErrUntrustedProxy
+}
+
+func (self *authManager) context() context.Context {
+	return context.WithValue(context.Background(), oauth2.HTTPClient, self.client)
+}
+
+// getConfig returns OAuth2 client configuration. Provider configuration is discovered on first
+// use, so that Dashboard starts even if the provider is not reachable yet.
+func (self *authManager) getConfig() (*oauth2.Config, error) {
+	if !self.Enabled() {
+		return nil, errors.New("OpenID Connect login is not configured")
+	}
+
+	self.configLock.Lock()
+	defer self.configLock.Unlock()
+	if self.config != nil {
+		return self.config, nil
+	}
+
+	issuer := strings.TrimSuffix(self.options.IssuerURL, "/")
+	response, err := self.client.Get(issuer + discoveryPath)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not discover OpenID Connect provider configuration: %s",
+			response.Status)
+	}
+
+	provider := providerConfig{}
+	if err := json.NewDecoder(response.Body).Decode(&provider); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(provider.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("issuer %s of OpenID Connect provider does not match %s",
+			provider.Issuer, self.options.IssuerURL)
+	}
+
+	self.config = &oauth2.Config{
+		ClientID:     self.options.ClientID,
+		ClientSecret: self.options.ClientSecret,
+		RedirectURL:  self.options.RedirectURL,
+		Scopes:       self.options.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  provider.AuthorizationEndpoint,
+			TokenURL: provider.TokenEndpoint,
+		},
+	}
+	return self.config, nil
+}
+
+// toAuthResponse extracts ID token from OAuth2 token. Providers may not return new refresh token
+// on refresh, in which case the previous one is kept.
+func toAuthResponse(token *oauth2.Token, refreshToken string) (*AuthResponse, error) {
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok || idToken == "" {
+		return nil, errors.New("OpenID Connect provider did not return ID token")
+	}
+
+	if token.RefreshToken != "" {
+		refreshToken = token.RefreshToken
+	}
+	return &AuthResponse{IDToken: idToken, RefreshToken: refreshToken, Expiry: token.Expiry}, nil
+}
+
+// NewAuthManager creates auth manager. Login with OpenID Connect provider is disabled if issuer URL
+// is not set. Sessions are disabled if token manager is nil. Client manager is used to validate
+// client certificates on login, which is disabled if it is nil.
+func NewAuthManager(clientManager client.ClientManager, options OIDCOptions,
+	proxyOptions AuthProxyOptions, tokenManager TokenManager) AuthManager {
+	if options.IssuerURL != "" {
+		logger.Infof("Using OpenID Connect provider %s", options.IssuerURL)
+	}
+	if proxyOptions.ClientCAs != nil || proxyOptions.SharedSecret != "" {
+		logger.Info("Accepting impersonation headers from trusted auth proxy")
+	}
+	return &authManager{
+		clientManager: clientManager,
+		options:       options,
+		proxyOptions:  proxyOptions,
+		client:        http.DefaultClient,
+		tokenManager:  tokenManager,
+	}
+}
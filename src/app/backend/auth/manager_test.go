@@ -0,0 +1,128 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// newFakeProvider creates OpenID Connect provider that issues tokens for code "valid-code" and
+// refresh token "valid-refresh-token". Refresh token is issued only for the code.
+func newFakeProvider(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case discoveryPath:
+			json.NewEncoder(w).Encode(providerConfig{
+				Issuer:                server.URL,
+				AuthorizationEndpoint: server.URL + "/auth",
+				TokenEndpoint:         server.URL + "/token",
+			})
+		case "/token":
+			r.ParseForm()
+			valid := r.Form.Get("grant_type") == "authorization_code" && r.Form.Get("code") == "valid-code" ||
+				r.Form.Get("grant_type") == "refresh_token" &&
+					r.Form.Get("refresh_token") == "valid-refresh-token"
+			if !valid {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": "invalid_grant"}`))
+				return
+			}
+			refreshToken := ""
+			if r.Form.Get("grant_type") == "authorization_code" {
+				refreshToken = "valid-refresh-token"
+			}
+			w.Write([]byte(`{"access_token": "access", "token_type": "Bearer", "expires_in": 3600,
+				"id_token": "id-token-` + r.Form.Get("grant_type") + `",
+				"refresh_token": "` + refreshToken + `"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return server
+}
+
+func newTestManager(issuerURL string) AuthManager {
+	return NewAuthManager(nil, OIDCOptions{
+		IssuerURL:    issuerURL,
+		ClientID:     "dashboard",
+		ClientSecret: "secret",
+		RedirectURL:  "https://dashboard.example.com/api/v1/oidc/callback",
+		Scopes:       []string{"openid", "email"},
+	}, AuthProxyOptions{}, nil)
+}
+
+func TestLoginURL(t *testing.T) {
+	provider := newFakeProvider(t)
+	defer provider.Close()
+
+	loginURL, err := newTestManager(provider.URL).LoginURL("some-state")
+	if err != nil {
+		t.Fatalf("LoginURL() returns unexpected error: %v", err)
+	}
+
+	parsed, err := url.Parse(loginURL)
+	if err != nil {
+		t.Fatalf("LoginURL() returns invalid URL %s: %v", loginURL, err)
+	}
+	query := parsed.Query()
+	if parsed.Path != "/auth" || query.Get("client_id") != "dashboard" ||
+		query.Get("state") != "some-state" || query.Get("scope") != "openid email" ||
+		query.Get("response_type") != "code" {
+		t.Errorf("LoginURL() returns unexpected URL %s", loginURL)
+	}
+}
+
+func TestExchangeAndRefresh(t *testing.T) {
+	provider := newFakeProvider(t)
+	defer provider.Close()
+	manager := newTestManager(provider.URL)
+
+	response, err := manager.Exchange("valid-code")
+	if err != nil {
+		t.Fatalf("Exchange() returns unexpected error: %v", err)
+	}
+	if response.IDToken != "id-token-authorization_code" {
+		t.Errorf("Exchange() returns %q ID token, expected %q", response.IDToken,
+			"id-token-authorization_code")
+	}
+
+	if _, err := manager.Exchange("invalid-code"); err == nil {
+		t.Error("Exchange() expected error for invalid code")
+	}
+
+	response, err = manager.Refresh("valid-refresh-token")
+	if err != nil {
+		t.Fatalf("Refresh() returns unexpected error: %v", err)
+	}
+	if response.IDToken != "id-token-refresh_token" || response.RefreshToken != "valid-refresh-token" {
+		t.Errorf("Refresh() returns %#v, expected new ID token and the same refresh token", response)
+	}
+}
+
+func TestDisabledManager(t *testing.T) {
+	manager := newTestManager("")
+	if manager.Enabled() {
+		t.Error("Enabled() expected false without issuer URL")
+	}
+	if _, err := manager.LoginURL("state"); err == nil {
+		t.Error("LoginURL() expected error when OpenID Connect is not configured")
+	}
+}
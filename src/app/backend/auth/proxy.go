@@ -0,0 +1,92 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/client-go/transport"
+)
+
+// NewAuthProxyFilter creates filter that rejects requests with impersonation headers, unless they
+// come from trusted auth proxy. Requests of the trusted proxy are forwarded to the apiserver on
+// behalf of the impersonated user, so that RBAC rules of the end user apply to them.
+func NewAuthProxyFilter(manager AuthManager) restful.FilterFunction {
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		if hasImpersonationHeaders(request.Request) {
+			err := ErrUntrustedProxy
+			if manager != nil {
+				err = manager.VerifyProxy(request.Request)
+			}
+			if err != nil {
+				handleError(response, http.StatusForbidden, err)
+				return
+			}
+		}
+
+		// Shared secret must not be forwarded to the apiserver or proxied services.
+		request.Request.Header.Del(ProxySecretHeader)
+		chain.ProcessFilter(request, response)
+	}
+}
+
+// hasImpersonationHeaders returns true if the request has any of the headers used by the apiserver
+// to impersonate users.
+func hasImpersonationHeaders(request *http.Request) bool {
+	for name := range request.Header {
+		if name == transport.ImpersonateUserHeader || name == transport.ImpersonateGroupHeader ||
+			strings.HasPrefix(name, transport.ImpersonateUserExtraHeaderPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyClientCertificate verifies that the first certificate is a client certificate signed by
+// one of ClientCAs, with one of AllowedNames as common name. Remaining certificates are used as
+// intermediates.
+func verifyClientCertificate(certificates []*x509.Certificate, options AuthProxyOptions) error {
+	if len(certificates) == 0 {
+		return ErrUntrustedProxy
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	_, err := certificates[0].Verify(x509.VerifyOptions{
+		Roots:         options.ClientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(options.AllowedNames) == 0 {
+		return nil
+	}
+	commonName := certificates[0].Subject.CommonName
+	for _, name := range options.AllowedNames {
+		if name == commonName {
+			return nil
+		}
+	}
+	return fmt.Errorf("common name %s of client certificate is not allowed", commonName)
+}
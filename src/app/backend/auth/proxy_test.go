@@ -0,0 +1,146 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/emicklei/go-restful"
+)
+
+func newTestCertificate(t *testing.T, commonName string, serial int64, parent *x509.Certificate,
+	parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certificate, key
+}
+
+func TestAuthProxyFilter(t *testing.T) {
+	ca, caKey := newTestCertificate(t, "ca", 1, nil, nil)
+	proxyCert, _ := newTestCertificate(t, "auth-proxy", 2, ca, caKey)
+	otherCert, _ := newTestCertificate(t, "other", 3, ca, caKey)
+	untrustedCA, untrustedKey := newTestCertificate(t, "untrusted-ca", 4, nil, nil)
+	untrustedCert, _ := newTestCertificate(t, "auth-proxy", 5, untrustedCA, untrustedKey)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+	manager := NewAuthManager(nil, OIDCOptions{}, AuthProxyOptions{
+		ClientCAs:    clientCAs,
+		AllowedNames: []string{"auth-proxy"},
+		SharedSecret: "proxy-secret",
+	}, nil)
+
+	var secret string
+	ws := new(restful.WebService)
+	ws.Filter(NewAuthProxyFilter(manager))
+	ws.Route(ws.GET("/echo").To(func(request *restful.Request, response *restful.Response) {
+		secret = request.HeaderParameter(ProxySecretHeader)
+	}))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	cases := []struct {
+		info           string
+		user           string
+		secret         string
+		certificate    *x509.Certificate
+		expectedStatus int
+	}{
+		{"no impersonation", "", "", nil, http.StatusOK},
+		{"no proxy credentials", "jane", "", nil, http.StatusForbidden},
+		{"valid secret", "jane", "proxy-secret", nil, http.StatusOK},
+		{"invalid secret", "jane", "other-secret", nil, http.StatusForbidden},
+		{"valid certificate", "jane", "", proxyCert, http.StatusOK},
+		{"not allowed name", "jane", "", otherCert, http.StatusForbidden},
+		{"untrusted certificate", "jane", "", untrustedCert, http.StatusForbidden},
+	}
+
+	for _, c := range cases {
+		secret = ""
+		request := httptest.NewRequest("GET", "/echo", nil)
+		if c.user != "" {
+			request.Header.Set("Impersonate-User", c.user)
+			request.Header.Add("Impersonate-Group", "developers")
+		}
+		if c.secret != "" {
+			request.Header.Set(ProxySecretHeader, c.secret)
+		}
+		if c.certificate != nil {
+			request.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{c.certificate}}
+		}
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, request)
+
+		if recorder.Code != c.expectedStatus {
+			t.Errorf("%s: expected status %d, but got %d", c.info, c.expectedStatus, recorder.Code)
+		}
+		if secret != "" {
+			t.Errorf("%s: expected proxy secret not to be forwarded", c.info)
+		}
+	}
+}
+
+func TestAuthProxyFilterDisabled(t *testing.T) {
+	ws := new(restful.WebService)
+	ws.Filter(NewAuthProxyFilter(NewAuthManager(nil, OIDCOptions{}, AuthProxyOptions{}, nil)))
+	ws.Route(ws.GET("/echo").To(func(request *restful.Request, response *restful.Response) {}))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	request := httptest.NewRequest("GET", "/echo", nil)
+	request.Header.Set("Impersonate-User", "system:admin")
+	recorder := httptest.NewRecorder()
+	container.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected impersonation to be rejected without trusted proxy, but got status %d",
+			recorder.Code)
+	}
+}
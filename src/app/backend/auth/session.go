@@ -0,0 +1,92 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+)
+
+// NewSessionFilter creates filter that authenticates requests with session token from
+// SessionTokenHeader or SessionCookie. Credentials wrapped by the token are forwarded to the
+// apiserver. Tokens that are about to expire are refreshed, so that sessions of active users last
+// until their absolute expiration. Requests with Authorization header are not changed.
+func NewSessionFilter(manager AuthManager) restful.FilterFunction {
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		var tokenManager TokenManager
+		if manager != nil {
+			tokenManager = manager.TokenManager()
+		}
+		if tokenManager == nil || request.HeaderParameter("Authorization") != "" {
+			chain.ProcessFilter(request, response)
+			return
+		}
+
+		token, fromCookie := request.HeaderParameter(SessionTokenHeader), false
+		if token == "" {
+			if cookie, err := request.Request.Cookie(SessionCookie); err == nil {
+				token, fromCookie = cookie.Value, true
+			}
+		}
+		if token == "" {
+			chain.ProcessFilter(request, response)
+			return
+		}
+
+		session, err := tokenManager.Decrypt(token)
+		if err != nil {
+			if fromCookie {
+				http.SetCookie(response, newCookie(request, SessionCookie, "", "/", time.Unix(0, 0)))
+			}
+			handleError(response, http.StatusUnauthorized, err)
+			return
+		}
+
+		if tokenManager.ShouldRefresh(session) {
+			if refreshed, _, err := tokenManager.Refresh(session); err == nil {
+				response.AddHeader(SessionTokenHeader, refreshed)
+				if fromCookie {
+					http.SetCookie(response, newCookie(request, SessionCookie, refreshed, "/",
+						time.Time{}))
+				}
+			}
+		}
+
+		request.SetAttribute(SessionAttribute, session)
+		if fromCookie {
+			request.SetAttribute(CookieAuthAttribute, true)
+		}
+		setCredentials(request, session.Credentials)
+		chain.ProcessFilter(request, response)
+	}
+}
+
+// setCredentials sets Authorization header of the request, that is forwarded to the apiserver.
+// Client certificates can not be passed in the header, so they are set as request attribute read by
+// client manager, as well as the cluster the credentials were issued for.
+func setCredentials(request *restful.Request, credentials Credentials) {
+	request.SetAttribute(client.CredentialsClusterAttribute, credentials.Cluster)
+	switch {
+	case len(credentials.ClientCertificate) > 0:
+		request.SetAttribute(client.AuthInfoAttribute, toAuthInfo(credentials))
+	case credentials.Token != "":
+		request.Request.Header.Set("Authorization", "Bearer "+credentials.Token)
+	default:
+		request.Request.SetBasicAuth(credentials.Username, credentials.Password)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+)
+
+func TestSessionFilter(t *testing.T) {
+	now := time.Now()
+	tokenManager := newTestTokenManager(t, &now)
+	manager := NewAuthManager(nil, OIDCOptions{}, AuthProxyOptions{}, tokenManager)
+
+	var authorization string
+	ws := new(restful.WebService)
+	ws.Path("/api/v1")
+	ws.Filter(NewSessionFilter(manager))
+	NewAuthHandler(manager, "/").Install(ws)
+	ws.Route(ws.GET("/echo").To(func(request *restful.Request, response *restful.Response) {
+		authorization = request.HeaderParameter("Authorization")
+	}))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	login, _ := manager.Login(LoginSpec{Token: "user-token"})
+
+	cases := []struct {
+		header         string
+		cookie         string
+		elapsed        time.Duration
+		expectedStatus int
+		expectedAuth   string
+		refreshed      bool
+	}{
+		{"", "", 0, http.StatusOK, "", false},
+		{login.SessionToken, "", 0, http.StatusOK, "Bearer user-token", false},
+		{"", login.SessionToken, 20 * time.Minute, http.StatusOK, "Bearer user-token", true},
+		{"", "invalid", 0, http.StatusUnauthorized, "", false},
+		{login.SessionToken, "", time.Hour, http.StatusUnauthorized, "", false},
+	}
+
+	for _, c := range cases {
+		now = now.Add(c.elapsed)
+		authorization = ""
+		req, _ := http.NewRequest("GET", "/api/v1/echo", nil)
+		if c.header != "" {
+			req.Header.Set(SessionTokenHeader, c.header)
+		}
+		if c.cookie != "" {
+			req.AddCookie(&http.Cookie{Name: SessionCookie, Value: c.cookie})
+		}
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, req)
+
+		if recorder.Code != c.expectedStatus || authorization != c.expectedAuth {
+			t.Errorf("Request with %q header and %q cookie returns %d status code with %q "+
+				"authorization, expected %d and %q", c.header, c.cookie, recorder.Code, authorization,
+				c.expectedStatus, c.expectedAuth)
+		}
+		if refreshed := recorder.Header().Get(SessionTokenHeader) != ""; refreshed != c.refreshed {
+			t.Errorf("Session token refreshed: %v, expected %v", refreshed, c.refreshed)
+		}
+	}
+}
+
+func TestSessionIsBoundToCluster(t *testing.T) {
+	now := time.Now()
+	manager := NewAuthManager(nil, OIDCOptions{}, AuthProxyOptions{}, newTestTokenManager(t, &now))
+
+	var credentialsCluster interface{}
+	ws := new(restful.WebService)
+	ws.Path("/api/v1").Consumes(restful.MIME_JSON).Produces(restful.MIME_JSON)
+	ws.Filter(NewSessionFilter(manager))
+	NewAuthHandler(manager, "/").Install(ws)
+	ws.Route(ws.GET("/echo").To(func(request *restful.Request, response *restful.Response) {
+		credentialsCluster = request.Attribute(client.CredentialsClusterAttribute)
+	}))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	body := bytes.NewBufferString(`{"token": "user-token"}`)
+	req, _ := http.NewRequest("POST", "/api/v1/login?cluster=prod", body)
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	container.ServeHTTP(recorder, req)
+	login := LoginResponse{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &login); err != nil {
+		t.Fatalf("Login to cluster prod returns %d status code: %s", recorder.Code, recorder.Body)
+	}
+
+	req, _ = http.NewRequest("GET", "/api/v1/echo?cluster=prod", nil)
+	req.Header.Set(SessionTokenHeader, login.SessionToken)
+	container.ServeHTTP(httptest.NewRecorder(), req)
+	if credentialsCluster != "prod" {
+		t.Errorf("Session of login to cluster prod sets credentials cluster %v, expected prod",
+			credentialsCluster)
+	}
+}
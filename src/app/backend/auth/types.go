@@ -0,0 +1,201 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const (
+	// IDTokenCookie is the cookie that keeps ID token of the user logged in with OpenID Connect
+	// provider. The token is forwarded to the apiserver as the user's bearer token.
+	IDTokenCookie = "kd-oidc-id-token"
+	// RefreshTokenCookie is the cookie that keeps refresh token issued by OpenID Connect provider.
+	RefreshTokenCookie = "kd-oidc-refresh-token"
+	// StateCookie keeps state of the authorization code flow in progress, which is compared with
+	// state returned by the provider to prevent CSRF.
+	StateCookie = "kd-oidc-state"
+	// SessionCookie keeps session token issued by Dashboard after login.
+	SessionCookie = "kd-session"
+	// SessionTokenHeader is the header with session token, used by clients that do not keep it in
+	// SessionCookie. Refreshed tokens are returned in the same response header.
+	SessionTokenHeader = "X-Session-Token"
+	// ProxySecretHeader is the header with the secret shared with trusted auth proxy.
+	ProxySecretHeader = "X-Dashboard-Proxy-Secret"
+	// SessionAttribute is the name of request attribute with *Session of requests authenticated
+	// with session token.
+	SessionAttribute = "session"
+	// CookieAuthAttribute is the name of request attribute set to true for requests authenticated
+	// with credentials from cookies. Browsers send cookies automatically, also with requests forged
+	// by other sites, so these requests need CSRF protection.
+	CookieAuthAttribute = "cookieAuth"
+)
+
+var (
+	// ErrSessionExpired is returned for session tokens that expired.
+	ErrSessionExpired = errors.New("session expired")
+	// ErrUntrustedProxy is returned for requests with impersonation headers that do not come from
+	// trusted auth proxy.
+	ErrUntrustedProxy = errors.New("impersonation headers are only accepted from trusted auth proxy")
+)
+
+// OIDCOptions is a configuration of login with OpenID Connect provider, i.e. Dex, Keycloak or Okta.
+type OIDCOptions struct {
+	// IssuerURL is the URL of the provider. It has to match issuer of ID tokens accepted by the
+	// apiserver (--oidc-issuer-url).
+	IssuerURL string
+	// ClientID of Dashboard registered in the provider. It has to match client ID accepted by the
+	// apiserver (--oidc-client-id).
+	ClientID string
+	// ClientSecret of Dashboard registered in the provider.
+	ClientSecret string
+	// RedirectURL is the address of the callback endpoint, i.e.
+	// https://dashboard.example.com/api/v1/oidc/callback.
+	RedirectURL string
+	// Scopes requested from the provider.
+	Scopes []string
+}
+
+// AuthProxyOptions is a configuration of trusted auth proxy, i.e. oauth2_proxy, that authenticates
+// users in front of Dashboard and passes their identity in Impersonate-User, Impersonate-Group and
+// Impersonate-Extra-* headers. The proxy is trusted if it presents client certificate or shared
+// secret. Impersonation headers are rejected if neither is configured.
+type AuthProxyOptions struct {
+	// ClientCAs verify client certificates presented by the proxy. Client certificates are not
+	// accepted if nil.
+	ClientCAs *x509.CertPool
+	// AllowedNames are common names of accepted client certificates. Any certificate signed by
+	// ClientCAs is accepted if empty.
+	AllowedNames []string
+	// SharedSecret sent by the proxy in ProxySecretHeader. Shared secret is not accepted if empty.
+	SharedSecret string
+}
+
+// AuthResponse contains tokens obtained from OpenID Connect provider.
+type AuthResponse struct {
+	// IDToken is used as bearer token in requests to the apiserver.
+	IDToken string `json:"idToken"`
+	// RefreshToken is used to obtain new ID token when the current one expires. Empty if the
+	// provider does not issue refresh tokens.
+	RefreshToken string `json:"refreshToken,omitempty"`
+	// Expiry is the time when the tokens expire.
+	Expiry time.Time `json:"expiry"`
+}
+
+// TokenRefreshSpec is a request to refresh tokens. If refresh token is not provided, the one
+// stored in RefreshTokenCookie is used.
+type TokenRefreshSpec struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// TokenExchangeSpec is a request to exchange authorization code returned by the provider for
+// tokens.
+type TokenExchangeSpec struct {
+	Code string `json:"code"`
+}
+
+// SessionOptions is a configuration of sessions created on login.
+type SessionOptions struct {
+	// Key used to encrypt session tokens, 32 bytes long.
+	Key []byte
+	// IdleTTL is the time after which the session expires if the user is not active. Tokens of
+	// active users are refreshed.
+	IdleTTL time.Duration
+	// AbsoluteTTL is the maximum duration of a session regardless of activity.
+	AbsoluteTTL time.Duration
+}
+
+// Credentials of the user that are wrapped by session token and forwarded to the apiserver.
+type Credentials struct {
+	Token    string `json:"token,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// ClientCertificate and ClientKey are PEM encoded client certificate and key.
+	ClientCertificate []byte `json:"clientCertificate,omitempty"`
+	ClientKey         []byte `json:"clientKey,omitempty"`
+	// Cluster the credentials were issued for, empty for the default cluster. Credentials are not
+	// sent to other clusters.
+	Cluster string `json:"cluster,omitempty"`
+}
+
+// Session of the user logged in to Dashboard.
+type Session struct {
+	// ID is a random identifier of the session, which does not change when the token is refreshed.
+	ID          string
+	Credentials Credentials
+	// Start of the session.
+	Start time.Time
+	// IssuedAt is the time when the current session token was issued.
+	IssuedAt time.Time
+	// Expiry of the current session token.
+	Expiry time.Time
+}
+
+// LoginSpec contains credentials of the user logging in, either bearer token, username and
+// password, PEM encoded client certificate and key or kubeconfig file content. Only credentials
+// embedded in the current context of kubeconfig are used, cluster is always one of the clusters
+// Dashboard is connected to. Login requests carry private keys and whole kubeconfig files, so their
+// bodies are never written to request logs.
+type LoginSpec struct {
+	Token             string `json:"token"`
+	Username          string `json:"username"`
+	Password          string `json:"password"`
+	ClientCertificate string `json:"clientCertificate"`
+	ClientKey         string `json:"clientKey"`
+	KubeConfig        string `json:"kubeConfig"`
+	// Cluster the user logs in to, selected by client.ClusterParameter of the login request.
+	Cluster string `json:"-"`
+}
+
+// LoginResponse contains session token issued on login.
+type LoginResponse struct {
+	SessionToken string    `json:"sessionToken"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// TokenManager is responsible for self-contained session tokens that wrap credentials of the user,
+// so that they are not kept by Dashboard between requests.
+type TokenManager interface {
+	// Generate creates token of a new session with given credentials.
+	Generate(credentials Credentials) (string, *Session, error)
+	// Decrypt returns session of the token. ErrSessionExpired is returned if the token expired.
+	Decrypt(token string) (*Session, error)
+	// Refresh creates new token of the session, which extends its idle expiration.
+	Refresh(session *Session) (string, *Session, error)
+	// ShouldRefresh returns true if the session token is about to expire and can be extended.
+	ShouldRefresh(session *Session) bool
+}
+
+// AuthManager is responsible for authentication of users with OpenID Connect provider and for
+// sessions of logged in users.
+type AuthManager interface {
+	// Enabled returns true if login with OpenID Connect provider is configured.
+	Enabled() bool
+	// LoginURL returns URL of the provider the user is redirected to in order to log in.
+	LoginURL(state string) (string, error)
+	// Exchange exchanges authorization code returned by the provider for tokens.
+	Exchange(code string) (*AuthResponse, error)
+	// Refresh obtains new tokens using the refresh token.
+	Refresh(refreshToken string) (*AuthResponse, error)
+	// Login creates session wrapping credentials of the user.
+	Login(spec LoginSpec) (*LoginResponse, error)
+	// TokenManager returns manager of session tokens or nil if sessions are not configured.
+	TokenManager() TokenManager
+	// VerifyProxy returns ErrUntrustedProxy if the request does not come from trusted auth proxy.
+	VerifyProxy(request *http.Request) error
+}
@@ -0,0 +1,264 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache provides an in-memory cache of Kubernetes resources built on shared informers, so
+// that list requests can be served without issuing fresh LIST calls to the apiserver.
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	apps "k8s.io/client-go/pkg/apis/apps/v1beta1"
+	batch "k8s.io/client-go/pkg/apis/batch/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	cacheK8s "k8s.io/client-go/tools/cache"
+)
+
+// Kinds of resources that can be cached. Names are the same as resource names in the API path,
+// except TLS secrets. Secrets are deliberately not cached to avoid keeping them in memory, only TLS
+// secrets are cached for certificate views, with their private keys removed.
+const (
+	Pods                   = "pods"
+	Services               = "services"
+	ReplicationControllers = "replicationcontrollers"
+	ConfigMaps             = "configmaps"
+	Events                 = "events"
+	Nodes                  = "nodes"
+	Namespaces             = "namespaces"
+	PersistentVolumes      = "persistentvolumes"
+	PersistentVolumeClaims = "persistentvolumeclaims"
+	StatefulSets           = "statefulsets"
+	Jobs                   = "jobs"
+	Deployments            = "deployments"
+	ReplicaSets            = "replicasets"
+	DaemonSets             = "daemonsets"
+	Ingresses              = "ingresses"
+	TLSSecrets             = "tlssecrets"
+)
+
+// kindInfo describes how to list and watch a single kind of resources.
+type kindInfo struct {
+	// getter returns REST client of the API group the kind belongs to.
+	getter func(client kubernetes.Interface) cacheK8s.Getter
+	// object is an empty object of the kind expected by the informer.
+	object runtime.Object
+}
+
+func coreGetter(client kubernetes.Interface) cacheK8s.Getter {
+	return client.CoreV1().RESTClient()
+}
+
+func appsGetter(client kubernetes.Interface) cacheK8s.Getter {
+	return client.AppsV1beta1().RESTClient()
+}
+
+func batchGetter(client kubernetes.Interface) cacheK8s.Getter {
+	return client.BatchV1().RESTClient()
+}
+
+func extensionsGetter(client kubernetes.Interface) cacheK8s.Getter {
+	return client.ExtensionsV1beta1().RESTClient()
+}
+
+// kinds are all kinds supported by the cache.
+var kinds = map[string]kindInfo{
+	Pods:                   {coreGetter, &v1.Pod{}},
+	Services:               {coreGetter, &v1.Service{}},
+	ReplicationControllers: {coreGetter, &v1.ReplicationController{}},
+	ConfigMaps:             {coreGetter, &v1.ConfigMap{}},
+	Events:                 {coreGetter, &v1.Event{}},
+	Nodes:                  {coreGetter, &v1.Node{}},
+	Namespaces:             {coreGetter, &v1.Namespace{}},
+	PersistentVolumes:      {coreGetter, &v1.PersistentVolume{}},
+	PersistentVolumeClaims: {coreGetter, &v1.PersistentVolumeClaim{}},
+	StatefulSets:           {appsGetter, &apps.StatefulSet{}},
+	Jobs:                   {batchGetter, &batch.Job{}},
+	Deployments:            {extensionsGetter, &extensions.Deployment{}},
+	ReplicaSets:            {extensionsGetter, &extensions.ReplicaSet{}},
+	DaemonSets:             {extensionsGetter, &extensions.DaemonSet{}},
+	Ingresses:              {extensionsGetter, &extensions.Ingress{}},
+	TLSSecrets:             {coreGetter, &v1.Secret{}},
+}
+
+// listWatches create list watches of kinds, which are not listed and watched by resource name.
+var listWatches = map[string]func(client kubernetes.Interface) cacheK8s.ListerWatcher{
+	TLSSecrets: newTLSSecretListWatch,
+}
+
+// Options of the resource cache.
+type Options struct {
+	// ResyncPeriod is how often informers resync their caches. Zero disables resync.
+	ResyncPeriod time.Duration
+	// DisabledKinds are kinds that are always listed from the apiserver.
+	DisabledKinds []string
+}
+
+// ResourceCache keeps indexed, in-memory copies of resources watched with given client.
+type ResourceCache struct {
+	client    kubernetes.Interface
+	informers map[string]cacheK8s.SharedIndexInformer
+	stop      chan struct{}
+}
+
+// NewResourceCache creates resource cache for all supported kinds except the disabled ones.
+// Returns error if any of the disabled kinds is not supported.
+func NewResourceCache(client kubernetes.Interface, options Options) (*ResourceCache, error) {
+	disabled := make(map[string]bool)
+	for _, kind := range options.DisabledKinds {
+		if _, ok := kinds[kind]; !ok {
+			return nil, fmt.Errorf("unsupported resource cache kind: %s", kind)
+		}
+		disabled[kind] = true
+	}
+
+	resourceCache := &ResourceCache{
+		client:    client,
+		informers: make(map[string]cacheK8s.SharedIndexInformer),
+		stop:      make(chan struct{}),
+	}
+
+	for name, kind := range kinds {
+		if disabled[name] {
+			continue
+		}
+
+		var listWatch cacheK8s.ListerWatcher
+		if newListWatch, ok := listWatches[name]; ok {
+			listWatch = newListWatch(client)
+		} else {
+			listWatch = cacheK8s.NewListWatchFromClient(kind.getter(client), name, v1.NamespaceAll,
+				fields.Everything())
+		}
+		resourceCache.informers[name] = newInformer(name, listWatch, kind.object, options.ResyncPeriod)
+	}
+
+	return resourceCache, nil
+}
+
+// newTLSSecretListWatch lists and watches TLS secrets. Private keys are removed before secrets reach
+// the cache.
+func newTLSSecretListWatch(client kubernetes.Interface) cacheK8s.ListerWatcher {
+	listWatch := cacheK8s.NewListWatchFromClient(coreGetter(client), "secrets", v1.NamespaceAll,
+		fields.OneTermEqualSelector("type", string(v1.SecretTypeTLS)))
+	return &cacheK8s.ListWatch{
+		ListFunc: func(options metaV1.ListOptions) (runtime.Object, error) {
+			list, err := listWatch.List(options)
+			if secrets, ok := list.(*v1.SecretList); ok {
+				for i := range secrets.Items {
+					delete(secrets.Items[i].Data, v1.TLSPrivateKeyKey)
+				}
+			}
+			return list, err
+		},
+		WatchFunc: func(options metaV1.ListOptions) (watch.Interface, error) {
+			watcher, err := listWatch.Watch(options)
+			if err != nil {
+				return nil, err
+			}
+			return watch.Filter(watcher, func(event watch.Event) (watch.Event, bool) {
+				if secret, ok := event.Object.(*v1.Secret); ok {
+					delete(secret.Data, v1.TLSPrivateKeyKey)
+				}
+				return event, true
+			}), nil
+		},
+	}
+}
+
+// newInformer creates informer of given kind indexed by namespace, which keeps cache metrics up
+// to date.
+func newInformer(kind string, listWatch cacheK8s.ListerWatcher, object runtime.Object,
+	resyncPeriod time.Duration) cacheK8s.SharedIndexInformer {
+	informer := cacheK8s.NewSharedIndexInformer(listWatch, object, resyncPeriod,
+		cacheK8s.Indexers{cacheK8s.NamespaceIndex: cacheK8s.MetaNamespaceIndexFunc})
+
+	objects := cachedObjects.WithLabelValues(kind)
+	informer.AddEventHandler(cacheK8s.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { objects.Inc() },
+		DeleteFunc: func(obj interface{}) { objects.Dec() },
+	})
+
+	return informer
+}
+
+// Start starts all informers of the cache. Kinds are served from the cache once their initial
+// list is loaded.
+func (self *ResourceCache) Start() {
+	for name, informer := range self.informers {
+		logger.Infof("Starting resource cache for %s", name)
+		go informer.Run(self.stop)
+	}
+}
+
+// Stop stops all informers of the cache.
+func (self *ResourceCache) Stop() {
+	close(self.stop)
+}
+
+// Serves returns true if the cache holds resources visible to the client, i.e., it was created
+// with the same client.
+func (self *ResourceCache) Serves(client kubernetes.Interface) bool {
+	return self != nil && self.client == client
+}
+
+// List returns cached objects of given kind in the namespace matching the label selector. Empty
+// namespace means all namespaces. False is returned if the kind is not cached or its initial list
+// is not loaded yet, in which case the objects have to be listed from the apiserver.
+func (self *ResourceCache) List(kind, namespace string, selector labels.Selector) ([]interface{}, bool) {
+	informer, ok := self.informers[kind]
+	if !ok || !informer.HasSynced() {
+		cacheRequests.WithLabelValues(kind, "miss").Inc()
+		return nil, false
+	}
+
+	var objects []interface{}
+	var err error
+	if namespace == v1.NamespaceAll {
+		objects = informer.GetIndexer().List()
+	} else {
+		objects, err = informer.GetIndexer().ByIndex(cacheK8s.NamespaceIndex, namespace)
+		if err != nil {
+			cacheRequests.WithLabelValues(kind, "miss").Inc()
+			return nil, false
+		}
+	}
+
+	result := make([]interface{}, 0, len(objects))
+	for _, obj := range objects {
+		if selector.Empty() || matchesLabels(obj, selector) {
+			result = append(result, obj)
+		}
+	}
+
+	cacheRequests.WithLabelValues(kind, "hit").Inc()
+	return result, true
+}
+
+func matchesLabels(obj interface{}, selector labels.Selector) bool {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(accessor.GetLabels()))
+}
@@ -0,0 +1,127 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	cacheK8s "k8s.io/client-go/tools/cache"
+)
+
+func TestNewResourceCache(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	resourceCache, err := NewResourceCache(client, Options{DisabledKinds: []string{Events, Pods}})
+	if err != nil {
+		t.Fatalf("NewResourceCache() returns unexpected error: %v", err)
+	}
+	if _, ok := resourceCache.informers[Events]; ok {
+		t.Error("NewResourceCache() should not create informer for disabled kind")
+	}
+	if len(resourceCache.informers) != len(kinds)-2 {
+		t.Errorf("NewResourceCache() creates %d informers, expected %d", len(resourceCache.informers),
+			len(kinds)-2)
+	}
+	if !resourceCache.Serves(client) || resourceCache.Serves(fake.NewSimpleClientset()) {
+		t.Error("Serves() should return true only for the client the cache was created with")
+	}
+
+	if _, err := NewResourceCache(client, Options{DisabledKinds: []string{"secrets"}}); err == nil {
+		t.Error("NewResourceCache() should return error for unsupported kind")
+	}
+
+	var nilCache *ResourceCache
+	if nilCache.Serves(client) {
+		t.Error("Serves() should return false for nil cache")
+	}
+}
+
+func TestList(t *testing.T) {
+	newPod := func(namespace, name string, podLabels map[string]string) v1.Pod {
+		return v1.Pod{ObjectMeta: metaV1.ObjectMeta{Namespace: namespace, Name: name, Labels: podLabels}}
+	}
+	pods := []v1.Pod{
+		newPod("default", "web-1", map[string]string{"app": "web"}),
+		newPod("default", "db-1", map[string]string{"app": "db"}),
+		newPod("kube-system", "dns-1", map[string]string{"app": "dns"}),
+	}
+
+	listWatch := &cacheK8s.ListWatch{
+		ListFunc: func(options metaV1.ListOptions) (runtime.Object, error) {
+			return &v1.PodList{Items: pods}, nil
+		},
+		WatchFunc: func(options metaV1.ListOptions) (watch.Interface, error) {
+			return watch.NewFake(), nil
+		},
+	}
+	resourceCache := &ResourceCache{
+		informers: map[string]cacheK8s.SharedIndexInformer{
+			Pods: newInformer(Pods, listWatch, &v1.Pod{}, 0),
+		},
+		stop: make(chan struct{}),
+	}
+
+	if _, ok := resourceCache.List(Pods, "", labels.Everything()); ok {
+		t.Error("List() should not serve kind that is not synced yet")
+	}
+
+	resourceCache.Start()
+	defer resourceCache.Stop()
+	for i := 0; i < 100 && !resourceCache.informers[Pods].HasSynced(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cases := []struct {
+		namespace string
+		selector  string
+		expected  []string
+	}{
+		{"", "", []string{"db-1", "dns-1", "web-1"}},
+		{"default", "", []string{"db-1", "web-1"}},
+		{"default", "app=web", []string{"web-1"}},
+		{"kube-system", "app=web", []string{}},
+	}
+	for _, c := range cases {
+		selector, _ := labels.Parse(c.selector)
+		objects, ok := resourceCache.List(Pods, c.namespace, selector)
+		if !ok {
+			t.Errorf("List(%#v, %#v) should be served from the cache", c.namespace, c.selector)
+			continue
+		}
+
+		actual := []string{}
+		for _, obj := range objects {
+			actual = append(actual, obj.(*v1.Pod).Name)
+		}
+		sort.Strings(actual)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("List(%#v, %#v) returns %#v, expected %#v", c.namespace, c.selector, actual,
+				c.expected)
+		}
+	}
+
+	if _, ok := resourceCache.List(Services, "", labels.Everything()); ok {
+		t.Error("List() should not serve kind that is not cached")
+	}
+}
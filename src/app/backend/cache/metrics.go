@@ -0,0 +1,40 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	cacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "resource_cache_request_count",
+			Help: "Counter of resource cache list requests broken out for each kind and result (hit or miss).",
+		},
+		[]string{"kind", "result"},
+	)
+	cachedObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "resource_cache_object_count",
+			Help: "Number of objects held by the resource cache for each kind.",
+		},
+		[]string{"kind"},
+	)
+)
+
+// Initialize all metrics in prometheus
+func init() {
+	prometheus.MustRegister(cacheRequests)
+	prometheus.MustRegister(cachedObjects)
+}
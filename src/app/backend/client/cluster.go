@@ -0,0 +1,122 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ClusterParameter is the name of query parameter that selects the cluster a request is made to.
+// Requests without it are made to the default cluster, i.e. the one Dashboard runs in or the one
+// set by --apiserver-host and --kubeconfig flags. Resource cache is kept only for the default
+// cluster, requests to other clusters are always served by their apiservers.
+const ClusterParameter = "cluster"
+
+// Cluster is a cluster managed by Dashboard.
+type Cluster struct {
+	// Name of the cluster, used as value of ClusterParameter. Empty for the default cluster.
+	Name string `json:"name"`
+	// Default is true for the default cluster.
+	Default bool `json:"default"`
+}
+
+// ClusterList is a list of clusters managed by Dashboard.
+type ClusterList struct {
+	Clusters []Cluster `json:"clusters"`
+}
+
+// LoadClusterConfigs reads kubeconfig files from given directory and returns configs of clusters
+// set by their current contexts. Clusters are named after files, without the extension, e.g.
+// file prod.yaml adds cluster prod.
+func LoadClusterConfigs(dir string) (map[string]*rest.Config, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := make(map[string]*rest.Config)
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+
+		name := strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))
+		if _, exists := configs[name]; exists {
+			return nil, fmt.Errorf("duplicate kubeconfig of cluster %s in %s", name, dir)
+		}
+
+		config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig of cluster %s: %s", name, err)
+		}
+		configs[name] = config
+	}
+
+	return configs, nil
+}
+
+// getClusterName returns name of the cluster selected by the request or empty string for the
+// default cluster.
+func getClusterName(req *restful.Request) string {
+	if req == nil || req.Request == nil || req.Request.URL == nil {
+		return ""
+	}
+	return req.QueryParameter(ClusterParameter)
+}
+
+// getCredentialsCluster returns name of the cluster credentials of the request were issued for or
+// empty string for the default cluster.
+func getCredentialsCluster(req *restful.Request) string {
+	if req == nil {
+		return ""
+	}
+	cluster, _ := req.Attribute(CredentialsClusterAttribute).(string)
+	return cluster
+}
+
+// getClusterConfig returns config of the named cluster without user credentials applied.
+func (self *clientManager) getClusterConfig(name string) (*rest.Config, error) {
+	if name == "" {
+		return self.buildConfigFromFlags(self.apiserverHost, self.kubeConfigPath)
+	}
+
+	config, ok := self.clusters[name]
+	if !ok {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("unknown cluster '%s'", name))
+	}
+	copied := *config
+	return &copied, nil
+}
+
+// Clusters returns the default cluster and clusters added from kubeconfig files, sorted by name.
+func (self *clientManager) Clusters() ClusterList {
+	list := ClusterList{Clusters: []Cluster{{Default: true}}}
+	for name := range self.clusters {
+		list.Clusters = append(list.Clusters, Cluster{Name: name})
+	}
+	sort.Slice(list.Clusters, func(i, j int) bool {
+		return list.Clusters[i].Name < list.Clusters[j].Name
+	})
+	return list
+}
@@ -0,0 +1,174 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+)
+
+const testClusterKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: prod
+  cluster:
+    server: https://prod.example.com
+contexts:
+- name: prod
+  context:
+    cluster: prod
+    user: admin
+current-context: prod
+users:
+- name: admin
+  user:
+    token: prod-token
+`
+
+func newClusterRequest(cluster string) *restful.Request {
+	req := httptest.NewRequest("GET", "/api/v1/pod?cluster="+cluster, nil)
+	return restful.NewRequest(req)
+}
+
+func TestLoadClusterConfigs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clusters")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "prod.yaml"), []byte(testClusterKubeConfig), 0600)
+	ioutil.WriteFile(filepath.Join(dir, ".hidden"), []byte("invalid"), 0600)
+
+	configs, err := LoadClusterConfigs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 1 || configs["prod"] == nil {
+		t.Fatalf("LoadClusterConfigs() == %#v, expected config of cluster prod", configs)
+	}
+	if prod := configs["prod"]; prod.Host != "https://prod.example.com" ||
+		prod.BearerToken != "prod-token" {
+		t.Errorf("LoadClusterConfigs() returns config with host %s and token %s, expected "+
+			"https://prod.example.com and prod-token", prod.Host, prod.BearerToken)
+	}
+
+	ioutil.WriteFile(filepath.Join(dir, "prod.conf"), []byte(testClusterKubeConfig), 0600)
+	if _, err := LoadClusterConfigs(dir); err == nil {
+		t.Error("LoadClusterConfigs() should fail for duplicate cluster names")
+	}
+}
+
+func TestClusterSelection(t *testing.T) {
+	manager := NewMultiClusterClientManager("", "https://localhost:8080", map[string]*rest.Config{
+		"prod": {Host: "https://prod.example.com", BearerToken: "prod-token"},
+	})
+
+	cases := []struct {
+		request       *restful.Request
+		expectedHost  string
+		expectedToken string
+	}{
+		{nil, "https://localhost:8080", ""},
+		{newClusterRequest(""), "https://localhost:8080", ""},
+		{newClusterRequest("prod"), "https://prod.example.com", "prod-token"},
+	}
+
+	for _, c := range cases {
+		cfg, err := manager.Config(c.request)
+		if err != nil {
+			t.Fatalf("Config(%v): Expected config to be created but error was thrown: %s",
+				c.request, err)
+		}
+		if cfg.Host != c.expectedHost || cfg.BearerToken != c.expectedToken {
+			t.Errorf("Config(%v) returns host %s and token %s, expected %s and %s", c.request,
+				cfg.Host, cfg.BearerToken, c.expectedHost, c.expectedToken)
+		}
+	}
+
+	if _, err := manager.Config(newClusterRequest("unknown")); !errorsK8s.IsBadRequest(err) {
+		t.Errorf("Config() for unknown cluster returns %v, expected bad request error", err)
+	}
+
+	defaultClient, _ := manager.Client(newClusterRequest(""))
+	prodClient, _ := manager.Client(newClusterRequest("prod"))
+	if prodClient == nil || prodClient == defaultClient {
+		t.Error("Client(): Expected requests to other clusters not to share default client")
+	}
+	if shared, _ := manager.Client(newClusterRequest("prod")); shared != prodClient {
+		t.Error("Client(): Expected requests without authorization header to share client of cluster")
+	}
+}
+
+func TestCredentialsAreBoundToCluster(t *testing.T) {
+	manager := NewMultiClusterClientManager("", "https://localhost:8080", map[string]*rest.Config{
+		"prod": {Host: "https://prod.example.com", BearerToken: "prod-token"},
+	})
+
+	cases := []struct {
+		cluster            string
+		credentialsCluster string
+		expectedError      bool
+	}{
+		{"", "", false},
+		{"prod", "prod", false},
+		{"prod", "", true},
+		{"", "prod", true},
+	}
+
+	for _, c := range cases {
+		request := newClusterRequest(c.cluster)
+		request.Request.Header.Set("Authorization", "Bearer user-token")
+		if c.credentialsCluster != "" {
+			request.SetAttribute(CredentialsClusterAttribute, c.credentialsCluster)
+		}
+
+		cfg, err := manager.Config(request)
+		if c.expectedError {
+			if !errorsK8s.IsUnauthorized(err) {
+				t.Errorf("Config() for cluster '%s' with credentials of cluster '%s' returns %v, "+
+					"expected unauthorized error", c.cluster, c.credentialsCluster, err)
+			}
+			continue
+		}
+		if err != nil || cfg.BearerToken != "user-token" {
+			t.Errorf("Config() for cluster '%s' with credentials of cluster '%s' returns %v, %v, "+
+				"expected token of the user", c.cluster, c.credentialsCluster, cfg, err)
+		}
+	}
+}
+
+func TestClusters(t *testing.T) {
+	manager := NewMultiClusterClientManager("", "http://localhost:8080", map[string]*rest.Config{
+		"prod":    {Host: "https://prod.example.com"},
+		"staging": {Host: "https://staging.example.com"},
+	})
+
+	expected := ClusterList{Clusters: []Cluster{
+		{Default: true},
+		{Name: "prod"},
+		{Name: "staging"},
+	}}
+	if actual := manager.Clusters(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Clusters() == %#v, expected %#v", actual, expected)
+	}
+}
@@ -17,14 +17,18 @@ package client
 import (
 	"crypto/rand"
 	"errors"
-	"log"
+	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/transport"
 )
 
 // Dashboard UI default values for client configs.
@@ -39,6 +43,14 @@ const (
 	DefaultContentType = "application/vnd.kubernetes.protobuf"
 	// Default cluster/context/auth name to be set in clientcmd config
 	DefaultCmdConfigName = "kubernetes"
+	// AuthInfoAttribute is the name of request attribute with api.AuthInfo of the user, set for
+	// credentials that can not be passed in Authorization header, i.e. client certificate. It takes
+	// precedence over Authorization header.
+	AuthInfoAttribute = "authInfo"
+	// CredentialsClusterAttribute is the name of request attribute with the name of the cluster
+	// credentials of the user were issued for, set for credentials wrapped by sessions. Other
+	// credentials, i.e. from Authorization header, are credentials of the default cluster.
+	CredentialsClusterAttribute = "credentialsCluster"
 )
 
 // ClientManager is responsible for initializing and creating clients to communicate with
@@ -49,6 +61,7 @@ type ClientManager interface {
 	ClientCmdConfig(req *restful.Request) (clientcmd.ClientConfig, error)
 	CSRFKey() string
 	VerberClient(req *restful.Request) (ResourceVerber, error)
+	Clusters() ClusterList
 }
 
 // clientManager implements ClientManager interface
@@ -63,11 +76,48 @@ type clientManager struct {
 	// Initialized on clientManager creation and used if kubeconfigPath and apiserverHost are
 	// empty
 	inClusterConfig *rest.Config
+	// Configs of clusters other than the default one, by name
+	clusters map[string]*rest.Config
+	// Clients shared by all requests without authorization header, by cluster name, so that
+	// resources cached for the default cluster can be used to serve these requests
+	defaultClients map[string]*kubernetes.Clientset
+	// Guards lazy initialization of defaultClients
+	defaultClientLock sync.Mutex
 }
 
 // Client returns kubernetes client that is created based on authentication information extracted
-// from request. If request is nil then authentication will be skipped.
+// from request. If request is nil then authentication will be skipped. Requests without
+// authorization and impersonation headers to the same cluster share the same client.
 func (self *clientManager) Client(req *restful.Request) (*kubernetes.Clientset, error) {
+	if authInfo := self.extractAuthInfo(req); !hasCredentials(authInfo) &&
+		len(authInfo.Impersonate) == 0 {
+		return self.getDefaultClient(req)
+	}
+
+	return self.newClient(req)
+}
+
+// getDefaultClient returns client shared by requests without authorization header to the
+// cluster selected by the request. It is created on first use.
+func (self *clientManager) getDefaultClient(req *restful.Request) (*kubernetes.Clientset, error) {
+	self.defaultClientLock.Lock()
+	defer self.defaultClientLock.Unlock()
+
+	cluster := getClusterName(req)
+	if client, ok := self.defaultClients[cluster]; ok {
+		return client, nil
+	}
+
+	client, err := self.newClient(req)
+	if err != nil {
+		return nil, err
+	}
+	self.defaultClients[cluster] = client
+	return client, nil
+}
+
+// Creates new client based on authentication information extracted from request.
+func (self *clientManager) newClient(req *restful.Request) (*kubernetes.Clientset, error) {
 	cfg, err := self.Config(req)
 	if err != nil {
 		return nil, err
@@ -99,18 +149,31 @@ func (self *clientManager) Config(req *restful.Request) (*rest.Config, error) {
 }
 
 // ClientCmdConfig creates ClientCmd Config based on authentication information extracted from request.
-// Currently request header is only checked for existence of 'Authentication: BearerToken'
+// Currently request header is only checked for existence of 'Authentication: BearerToken'. The
+// request is made to the cluster selected by ClusterParameter. Credentials of the user are only
+// sent to the cluster they were issued for, requests to other clusters are rejected.
 func (self *clientManager) ClientCmdConfig(req *restful.Request) (clientcmd.ClientConfig, error) {
 	authInfo := self.extractAuthInfo(req)
 
-	cfg, err := self.buildConfigFromFlags(self.apiserverHost, self.kubeConfigPath)
+	cluster := getClusterName(req)
+	cfg, err := self.getClusterConfig(cluster)
 	if err != nil {
 		return nil, err
 	}
 
-	// Use auth data provided in cfg if there is no token in header
-	if len(authInfo.Token) == 0 {
+	if hasCredentials(authInfo) && getCredentialsCluster(req) != cluster {
+		return nil, errorsK8s.NewUnauthorized(fmt.Sprintf(
+			"credentials of the user were not issued for cluster '%s', log in to the cluster", cluster))
+	}
+
+	// Use auth data provided in cfg if there are no credentials in header. Users impersonated on
+	// behalf of trusted auth proxy are impersonated with these credentials.
+	if !hasCredentials(authInfo) {
+		impersonated := authInfo
 		authInfo = self.buildAuthInfoFromConfig(cfg)
+		authInfo.Impersonate = impersonated.Impersonate
+		authInfo.ImpersonateGroups = impersonated.ImpersonateGroups
+		authInfo.ImpersonateUserExtra = impersonated.ImpersonateUserExtra
 	}
 
 	cmdCfg := api.NewConfig()
@@ -148,7 +211,7 @@ func (self *clientManager) VerberClient(req *restful.Request) (ResourceVerber, e
 	return NewResourceVerber(client.CoreV1().RESTClient(),
 		client.ExtensionsV1beta1().RESTClient(), client.AppsV1beta1().RESTClient(),
 		client.BatchV1().RESTClient(), client.AutoscalingV1().RESTClient(),
-		client.StorageV1beta1().RESTClient()), nil
+		client.StorageV1beta1().RESTClient(), client.PolicyV1beta1().RESTClient()), nil
 }
 
 // Initializes config with default values
@@ -188,20 +251,47 @@ func (self *clientManager) buildAuthInfoFromConfig(cfg *rest.Config) api.AuthInf
 	}
 }
 
-// Extracts authentication information from request header
+// Extracts authentication information, either bearer token, basic auth credentials or client
+// certificate, and the impersonated user from request. Impersonation headers are only accepted from
+// trusted auth proxy, which is verified before request reaches handlers.
 func (self *clientManager) extractAuthInfo(req *restful.Request) api.AuthInfo {
 	if req == nil {
-		log.Print("No request provided. Skipping authorization header")
+		logger.Info("No request provided. Skipping authorization header")
 		return api.AuthInfo{}
 	}
 
+	authInfo := api.AuthInfo{}
 	authHeader := req.HeaderParameter("Authorization")
-	token := ""
-	if strings.HasPrefix(authHeader, "Bearer ") {
-		token = strings.TrimPrefix(authHeader, "Bearer ")
+	if attribute, ok := req.Attribute(AuthInfoAttribute).(api.AuthInfo); ok {
+		authInfo = attribute
+	} else if username, password, ok := req.Request.BasicAuth(); ok {
+		authInfo.Username, authInfo.Password = username, password
+	} else if strings.HasPrefix(authHeader, "Bearer ") {
+		authInfo.Token = strings.TrimPrefix(authHeader, "Bearer ")
 	}
 
-	return api.AuthInfo{Token: token}
+	if user := req.HeaderParameter(transport.ImpersonateUserHeader); len(user) > 0 {
+		authInfo.Impersonate = user
+		authInfo.ImpersonateGroups = req.Request.Header[transport.ImpersonateGroupHeader]
+		prefix := transport.ImpersonateUserExtraHeaderPrefix
+		for name, values := range req.Request.Header {
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			if authInfo.ImpersonateUserExtra == nil {
+				authInfo.ImpersonateUserExtra = map[string][]string{}
+			}
+			authInfo.ImpersonateUserExtra[strings.ToLower(strings.TrimPrefix(name, prefix))] = values
+		}
+	}
+
+	return authInfo
+}
+
+// Returns true if auth info contains bearer token, basic auth credentials or client certificate.
+func hasCredentials(authInfo api.AuthInfo) bool {
+	return len(authInfo.Token) > 0 || len(authInfo.Username) > 0 ||
+		len(authInfo.ClientCertificateData) > 0
 }
 
 // Initializes client manager
@@ -215,27 +305,27 @@ func (self *clientManager) init() {
 func (self *clientManager) initCSRFKey() {
 	if self.inClusterConfig == nil {
 		// Most likely running for a dev, so no replica issues, just generate a random key
-		log.Println("Using random key for csrf signing")
+		logger.Info("Using random key for csrf signing")
 		self.generateCSRFKey()
 		return
 	}
 
 	// We run in a cluster, so we should use a signing key that is the same for potential replications
-	log.Println("Using service account token for csrf signing")
+	logger.Info("Using service account token for csrf signing")
 	self.csrfKey = self.inClusterConfig.BearerToken
 }
 
 // Initializes in-cluster config if apiserverHost and kubeConfigPath were not provided.
 func (self *clientManager) initInClusterConfig() {
 	if len(self.apiserverHost) > 0 || len(self.kubeConfigPath) > 0 {
-		log.Print("Skipping in-cluster config")
+		logger.Info("Skipping in-cluster config")
 		return
 	}
 
-	log.Print("Using in-cluster config to connect to apiserver")
+	logger.Info("Using in-cluster config to connect to apiserver")
 	cfg, err := rest.InClusterConfig()
 	if err != nil {
-		log.Printf("Could not init in cluster config: %s", err.Error())
+		logger.Warningf("Could not init in cluster config: %s", err.Error())
 		return
 	}
 
@@ -261,9 +351,19 @@ func (self *clientManager) isRunningInCluster() bool {
 // NewClientManager creates client manager based on kubeConfigPath and apiserverHost parameters.
 // If both are empty then in-cluster config is used.
 func NewClientManager(kubeConfigPath, apiserverHost string) ClientManager {
+	return NewMultiClusterClientManager(kubeConfigPath, apiserverHost, nil)
+}
+
+// NewMultiClusterClientManager creates client manager that makes requests to the default cluster,
+// set by kubeConfigPath and apiserverHost parameters as in NewClientManager, and to given clusters
+// selected by ClusterParameter of requests.
+func NewMultiClusterClientManager(kubeConfigPath, apiserverHost string,
+	clusters map[string]*rest.Config) ClientManager {
 	result := &clientManager{
 		kubeConfigPath: kubeConfigPath,
 		apiserverHost:  apiserverHost,
+		clusters:       clusters,
+		defaultClients: make(map[string]*kubernetes.Clientset),
 	}
 
 	result.init()
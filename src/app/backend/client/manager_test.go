@@ -16,9 +16,11 @@ package client
 
 import (
 	"net/http"
+	"reflect"
 	"testing"
 
 	"github.com/emicklei/go-restful"
+	"k8s.io/client-go/rest"
 )
 
 func TestNewClientManager(t *testing.T) {
@@ -65,6 +67,33 @@ func TestClient(t *testing.T) {
 	}
 }
 
+func TestClientSharedWithoutAuthorization(t *testing.T) {
+	newRequest := func(authorization string) *restful.Request {
+		header := http.Header(map[string][]string{})
+		if len(authorization) > 0 {
+			header.Set("Authorization", authorization)
+		}
+		return &restful.Request{Request: &http.Request{Header: header}}
+	}
+
+	manager := NewClientManager("", "http://localhost:8080")
+	first, _ := manager.Client(nil)
+	second, _ := manager.Client(newRequest(""))
+	if first != second {
+		t.Error("Client(): Expected requests without authorization header to share client")
+	}
+
+	withToken, _ := manager.Client(newRequest("Bearer token"))
+	if withToken == first {
+		t.Error("Client(): Expected request with token not to use shared client")
+	}
+
+	withBasicAuth, _ := manager.Client(newRequest("Basic YWRtaW46c2VjcmV0"))
+	if withBasicAuth == first {
+		t.Error("Client(): Expected request with basic auth credentials not to use shared client")
+	}
+}
+
 func TestCSRFKey(t *testing.T) {
 	manager := NewClientManager("", "http://localhost:8080")
 	key := manager.CSRFKey()
@@ -180,3 +209,34 @@ func TestVerberClient(t *testing.T) {
 			err.Error())
 	}
 }
+
+func TestConfigImpersonation(t *testing.T) {
+	header := http.Header(map[string][]string{})
+	header.Set("Impersonate-User", "jane")
+	header.Add("Impersonate-Group", "developers")
+	header.Add("Impersonate-Group", "admins")
+	header.Set("Impersonate-Extra-Scopes", "view")
+	request := &restful.Request{Request: &http.Request{Header: header}}
+
+	manager := NewClientManager("", "https://localhost:8080")
+	cfg, err := manager.Config(request)
+	if err != nil {
+		t.Fatalf("Config(): Expected config to be created but error was thrown: %s", err.Error())
+	}
+
+	expected := rest.ImpersonationConfig{
+		UserName: "jane",
+		Groups:   []string{"developers", "admins"},
+		Extra:    map[string][]string{"scopes": {"view"}},
+	}
+	if !reflect.DeepEqual(cfg.Impersonate, expected) {
+		t.Errorf("Config(): Expected impersonation config %#v, but got %#v", expected,
+			cfg.Impersonate)
+	}
+
+	shared, _ := manager.Client(nil)
+	impersonated, _ := manager.Client(request)
+	if shared == impersonated {
+		t.Error("Client(): Expected request with impersonation headers not to use shared client")
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/tools/metrics"
+)
+
+var (
+	clientRequestLatencies = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "dashboard_kubernetes_client_request_duration_seconds",
+			Help: "Latency distribution in seconds of requests sent to the apiserver for each verb and host.",
+		},
+		[]string{"verb", "host"},
+	)
+	clientRequestResults = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dashboard_kubernetes_client_request_count",
+			Help: "Counter of requests sent to the apiserver broken out for each response code, method and host. Requests that failed without response have <error> code.",
+		},
+		[]string{"code", "method", "host"},
+	)
+)
+
+// Initialize all metrics in prometheus and make Kubernetes clients report to them
+func init() {
+	prometheus.MustRegister(clientRequestLatencies)
+	prometheus.MustRegister(clientRequestResults)
+	metrics.Register(latencyMetric{}, resultMetric{})
+}
+
+// latencyMetric implements client-go LatencyMetric interface. Path of the URL passed by the client
+// has only namespace and name replaced by placeholders, while paths of subresources, proxies and
+// absolute paths still contain names of objects, so only the host is used as label.
+type latencyMetric struct{}
+
+func (latencyMetric) Observe(verb string, u url.URL, latency time.Duration) {
+	clientRequestLatencies.WithLabelValues(verb, u.Host).Observe(latency.Seconds())
+}
+
+// resultMetric implements client-go ResultMetric interface.
+type resultMetric struct{}
+
+func (resultMetric) Increment(code string, method string, host string) {
+	clientRequestResults.WithLabelValues(code, method, host).Inc()
+}
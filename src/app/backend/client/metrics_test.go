@@ -0,0 +1,48 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/client-go/tools/metrics"
+)
+
+func TestClientMetricsAreRegistered(t *testing.T) {
+	metrics.RequestResult.Increment("200", "GET", "apiserver:443")
+	metrics.RequestLatency.Observe("GET", url.URL{Host: "apiserver:443",
+		Path: "/api/v1/namespaces/{namespace}/pods/web-1/log"}, time.Second)
+
+	result := &dto.Metric{}
+	if err := clientRequestResults.WithLabelValues("200", "GET", "apiserver:443").Write(result); err != nil {
+		t.Fatalf("Could not read request count: %v", err)
+	}
+	if result.GetCounter().GetValue() != 1 {
+		t.Errorf("Request count is %v, expected 1", result.GetCounter().GetValue())
+	}
+
+	latency := &dto.Metric{}
+	err := clientRequestLatencies.WithLabelValues("GET", "apiserver:443").Write(latency)
+	if err != nil {
+		t.Fatalf("Could not read request latency: %v", err)
+	}
+	if latency.GetHistogram().GetSampleCount() != 1 {
+		t.Errorf("Request latency sample count is %v, expected 1",
+			latency.GetHistogram().GetSampleCount())
+	}
+}
@@ -15,14 +15,23 @@
 package client
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
 	restclient "k8s.io/client-go/rest"
 )
 
+// lastAppliedConfigAnnotation is set by kubectl apply to the applied object, including values of
+// secrets.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
 // ResourceVerber is a struct responsible for doing common verb operations on resources, like
 // DELETE, PUT, UPDATE.
 type ResourceVerber struct {
@@ -32,6 +41,7 @@ type ResourceVerber struct {
 	batchClient       RESTClient
 	autoscalingClient RESTClient
 	storageClient     RESTClient
+	policyClient      RESTClient
 }
 
 func (verber *ResourceVerber) getRESTClientByType(clientType api.ClientType) RESTClient {
@@ -46,6 +56,8 @@ func (verber *ResourceVerber) getRESTClientByType(clientType api.ClientType) RES
 		return verber.autoscalingClient
 	case api.ClientTypeStorageClient:
 		return verber.storageClient
+	case api.ClientTypePolicyClient:
+		return verber.policyClient
 	default:
 		return verber.client
 	}
@@ -56,12 +68,41 @@ type RESTClient interface {
 	Delete() *restclient.Request
 	Put() *restclient.Request
 	Get() *restclient.Request
+	Patch(types.PatchType) *restclient.Request
+}
+
+// ResourceUpdateSpec is a specification of resource update done in the YAML editor.
+type ResourceUpdateSpec struct {
+	// Edited YAML or JSON content of the resource.
+	Content string `json:"content"`
+
+	// Resource version of the resource when it was loaded into the editor.
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+// ConflictError is returned by Update when the resource was modified since the edited version was
+// loaded. It carries the current version of the resource, so that the changes can be resolved.
+type ConflictError struct {
+	// Human readable description of the conflict.
+	Message string `json:"message"`
+
+	// Resource version of the edited resource.
+	ResourceVersion string `json:"resourceVersion"`
+
+	// Current version of the resource.
+	Current *runtime.Unknown `json:"current"`
+}
+
+// Error implements error interface.
+func (self *ConflictError) Error() string {
+	return self.Message
 }
 
 // NewResourceVerber creates a new resource verber that uses the given client for performing operations.
 func NewResourceVerber(client, extensionsClient, appsClient,
-	batchClient, autoscalingClient, storageClient RESTClient) ResourceVerber {
-	return ResourceVerber{client, extensionsClient, appsClient, batchClient, autoscalingClient, storageClient}
+	batchClient, autoscalingClient, storageClient, policyClient RESTClient) ResourceVerber {
+	return ResourceVerber{client, extensionsClient, appsClient, batchClient, autoscalingClient, storageClient,
+		policyClient}
 }
 
 // Delete deletes the resource of the given kind in the given namespace with the given name.
@@ -113,13 +154,26 @@ func (verber *ResourceVerber) Put(kind string, namespaceSet bool, namespace stri
 		}
 	}
 
+	raw := object.Raw
+	if kind == api.ResourceKindSecret {
+		// Secrets are edited in their masked form, so values left masked by the user are taken
+		// from the stored secret.
+		stored, err := verber.get(kind, namespace, name)
+		if err != nil {
+			return err
+		}
+		if raw, err = restoreSecretValues(stored.Raw, raw); err != nil {
+			return err
+		}
+	}
+
 	client := verber.getRESTClientByType(resourceSpec.ClientType)
 
 	req := client.Put().
 		Resource(resourceSpec.Resource).
 		Name(name).
 		SetHeader("Content-Type", "application/json").
-		Body([]byte(object.Raw))
+		Body(raw)
 
 	if resourceSpec.Namespaced {
 		req.Namespace(namespace)
@@ -128,7 +182,8 @@ func (verber *ResourceVerber) Put(kind string, namespaceSet bool, namespace stri
 	return req.Do().Error()
 }
 
-// Get gets the resource of the given kind in the given namespace with the given name.
+// Get gets the resource of the given kind in the given namespace with the given name. Values of
+// secrets are masked.
 func (verber *ResourceVerber) Get(kind string, namespaceSet bool, namespace string, name string) (runtime.Object, error) {
 	resourceSpec, ok := api.KindToAPIMapping[kind]
 	if !ok {
@@ -143,6 +198,16 @@ func (verber *ResourceVerber) Get(kind string, namespaceSet bool, namespace stri
 		}
 	}
 
+	result, err := verber.get(kind, namespace, name)
+	if err != nil {
+		return result, err
+	}
+	return result, maskSecretValues(kind, result)
+}
+
+// get gets the resource of the given, already validated kind without masking any values.
+func (verber *ResourceVerber) get(kind string, namespace string, name string) (*runtime.Unknown, error) {
+	resourceSpec := api.KindToAPIMapping[kind]
 	client := verber.getRESTClientByType(resourceSpec.ClientType)
 	result := &runtime.Unknown{}
 	req := client.Get().Resource(resourceSpec.Resource).Name(name).SetHeader("Accept", "application/json")
@@ -151,6 +216,271 @@ func (verber *ResourceVerber) Get(kind string, namespaceSet bool, namespace stri
 		req.Namespace(namespace)
 	}
 
-	err := req.Do().Into(result)
-	return result, err
+	return result, req.Do().Into(result)
+}
+
+// Update updates the resource of the given kind in the given namespace with the given name with
+// the edited YAML or JSON content, if the resource is still at the given resource version. Only
+// the changes between the current and the edited resource are sent as a merge patch, together with
+// the resource version, so that concurrent modifications are rejected by the apiserver as well.
+// When the resource was modified in the meantime, ConflictError is returned.
+func (verber *ResourceVerber) Update(kind string, namespaceSet bool, namespace string, name string,
+	resourceVersion string, content []byte) (runtime.Object, error) {
+
+	resourceSpec, ok := api.KindToAPIMapping[kind]
+	if !ok {
+		return nil, fmt.Errorf("Unknown resource kind: %s", kind)
+	}
+
+	edited, err := yaml.ToJSON(content)
+	if err != nil {
+		return nil, errorsK8s.NewBadRequest(err.Error())
+	}
+
+	current, err := verber.Get(kind, namespaceSet, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	currentVersion, err := getResourceVersion(current.(*runtime.Unknown).Raw)
+	if err != nil {
+		return nil, err
+	}
+	if currentVersion != resourceVersion {
+		return nil, newConflictError(kind, name, resourceVersion, current.(*runtime.Unknown))
+	}
+
+	patch, err := createMergePatch(current.(*runtime.Unknown).Raw, edited, resourceVersion)
+	if err != nil {
+		return nil, errorsK8s.NewBadRequest(err.Error())
+	}
+
+	client := verber.getRESTClientByType(resourceSpec.ClientType)
+	result := &runtime.Unknown{}
+	req := client.Patch(types.MergePatchType).
+		Resource(resourceSpec.Resource).
+		Name(name).
+		SetHeader("Accept", "application/json").
+		Body(patch)
+
+	if resourceSpec.Namespaced {
+		req.Namespace(namespace)
+	}
+
+	err = req.Do().Into(result)
+	if errorsK8s.IsConflict(err) {
+		// Resource was modified between the get and the patch.
+		current, getErr := verber.Get(kind, namespaceSet, namespace, name)
+		if getErr != nil {
+			return nil, err
+		}
+		return nil, newConflictError(kind, name, resourceVersion, current.(*runtime.Unknown))
+	}
+	if err != nil {
+		return result, err
+	}
+	return result, maskSecretValues(kind, result)
+}
+
+// Patch applies the patch of the given type to the resource of the given kind in the given
+// namespace with the given name.
+func (verber *ResourceVerber) Patch(kind string, namespaceSet bool, namespace string, name string,
+	patchType types.PatchType, patch []byte) (runtime.Object, error) {
+
+	resourceSpec, ok := api.KindToAPIMapping[kind]
+	if !ok {
+		return nil, fmt.Errorf("Unknown resource kind: %s", kind)
+	}
+
+	if namespaceSet != resourceSpec.Namespaced {
+		if namespaceSet {
+			return nil, fmt.Errorf("Set namespace for not-namespaced resource kind: %s", kind)
+		} else {
+			return nil, fmt.Errorf("Set no namespace for namespaced resource kind: %s", kind)
+		}
+	}
+
+	client := verber.getRESTClientByType(resourceSpec.ClientType)
+	result := &runtime.Unknown{}
+	req := client.Patch(patchType).
+		Resource(resourceSpec.Resource).
+		Name(name).
+		SetHeader("Accept", "application/json").
+		Body(patch)
+
+	if resourceSpec.Namespaced {
+		req.Namespace(namespace)
+	}
+
+	if err := req.Do().Into(result); err != nil {
+		return result, err
+	}
+	return result, maskSecretValues(kind, result)
+}
+
+// maskSecretValues replaces values of the secret data, string data and last applied configuration
+// with empty strings when the object is a secret, so that values are returned only by the reveal
+// endpoint, which is audited. Keys are kept, so that merge patches created from the masked object
+// change only values edited by the user, and Put restores values which are still masked.
+func maskSecretValues(kind string, object *runtime.Unknown) error {
+	if kind != api.ResourceKindSecret {
+		return nil
+	}
+
+	secret := map[string]interface{}{}
+	if err := json.Unmarshal(object.Raw, &secret); err != nil {
+		return err
+	}
+	for _, field := range []string{"data", "stringData"} {
+		if values, ok := secret[field].(map[string]interface{}); ok {
+			for key := range values {
+				values[key] = ""
+			}
+		}
+	}
+	if metadata, ok := secret["metadata"].(map[string]interface{}); ok {
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			if _, ok := annotations[lastAppliedConfigAnnotation]; ok {
+				annotations[lastAppliedConfigAnnotation] = ""
+			}
+		}
+	}
+
+	raw, err := json.Marshal(secret)
+	if err != nil {
+		return err
+	}
+	object.Raw = raw
+	return nil
+}
+
+// restoreSecretValues replaces values of the submitted secret, which are still masked, with the
+// values of the stored secret, so that a masked secret returned by Get can be put back without
+// wiping its data. Values changed by the user and keys missing in the stored secret are kept.
+func restoreSecretValues(stored, submitted []byte) ([]byte, error) {
+	storedSecret := map[string]interface{}{}
+	if err := json.Unmarshal(stored, &storedSecret); err != nil {
+		return nil, err
+	}
+	submittedSecret := map[string]interface{}{}
+	if err := json.Unmarshal(submitted, &submittedSecret); err != nil {
+		return nil, err
+	}
+
+	for _, field := range []string{"data", "stringData"} {
+		storedValues, _ := storedSecret[field].(map[string]interface{})
+		submittedValues, _ := submittedSecret[field].(map[string]interface{})
+		restoreMaskedValues(storedValues, submittedValues)
+	}
+	restoreMaskedValues(getAnnotations(storedSecret), getAnnotations(submittedSecret),
+		lastAppliedConfigAnnotation)
+
+	return json.Marshal(submittedSecret)
+}
+
+// restoreMaskedValues sets values of the submitted map, which are equal to the mask, to the stored
+// ones. When keys are given, only these keys are restored.
+func restoreMaskedValues(stored, submitted map[string]interface{}, keys ...string) {
+	if stored == nil || submitted == nil {
+		return
+	}
+	if len(keys) == 0 {
+		for key := range submitted {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		storedValue, ok := stored[key]
+		if !ok {
+			continue
+		}
+		if value, ok := submitted[key]; ok && value == "" {
+			submitted[key] = storedValue
+		}
+	}
+}
+
+// getAnnotations returns annotations of the decoded object or nil, if it has none.
+func getAnnotations(object map[string]interface{}) map[string]interface{} {
+	metadata, _ := object["metadata"].(map[string]interface{})
+	annotations, _ := metadata["annotations"].(map[string]interface{})
+	return annotations
+}
+
+func newConflictError(kind, name, resourceVersion string, current *runtime.Unknown) *ConflictError {
+	return &ConflictError{
+		Message: fmt.Sprintf("%s %s has been modified since version %s was loaded", kind, name,
+			resourceVersion),
+		ResourceVersion: resourceVersion,
+		Current:         current,
+	}
+}
+
+// getResourceVersion returns resource version of JSON encoded object.
+func getResourceVersion(raw []byte) (string, error) {
+	object := struct {
+		Metadata v1.ObjectMeta `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return "", err
+	}
+	return object.Metadata.ResourceVersion, nil
+}
+
+// createMergePatch creates JSON merge patch (RFC 7386) turning the original object into the
+// modified one. Resource version is always set in the patch, so that it is used as a precondition.
+func createMergePatch(original, modified []byte, resourceVersion string) ([]byte, error) {
+	originalMap := map[string]interface{}{}
+	if err := json.Unmarshal(original, &originalMap); err != nil {
+		return nil, err
+	}
+
+	modifiedMap := map[string]interface{}{}
+	if err := json.Unmarshal(modified, &modifiedMap); err != nil {
+		return nil, err
+	}
+
+	patch := getMergePatch(originalMap, modifiedMap)
+	metadata, ok := patch["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = map[string]interface{}{}
+		patch["metadata"] = metadata
+	}
+	metadata["resourceVersion"] = resourceVersion
+
+	return json.Marshal(patch)
+}
+
+// getMergePatch returns changes between original and modified maps. Removed keys are set to nil,
+// maps are compared recursively and all other values, including lists, are replaced.
+func getMergePatch(original, modified map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for key, originalValue := range original {
+		if _, ok := modified[key]; !ok {
+			patch[key] = nil
+		} else if originalMap, ok := originalValue.(map[string]interface{}); ok {
+			if modifiedMap, ok := modified[key].(map[string]interface{}); ok {
+				if nested := getMergePatch(originalMap, modifiedMap); len(nested) > 0 {
+					patch[key] = nested
+				}
+				continue
+			}
+		}
+	}
+
+	for key, modifiedValue := range modified {
+		if _, ok := patch[key]; ok {
+			continue
+		}
+		if _, ok := modifiedValue.(map[string]interface{}); ok {
+			if _, ok := original[key].(map[string]interface{}); ok {
+				// Already compared recursively above.
+				continue
+			}
+		}
+		if !reflect.DeepEqual(original[key], modifiedValue) {
+			patch[key] = modifiedValue
+		}
+	}
+	return patch
 }
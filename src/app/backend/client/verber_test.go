@@ -15,7 +15,10 @@
 package client
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"testing"
@@ -25,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
 	restclient "k8s.io/client-go/rest"
 )
 
@@ -64,7 +68,27 @@ func (c *FakeRESTClient) Put() *restclient.Request {
 func (c *FakeRESTClient) Get() *restclient.Request {
 	return restclient.NewRequest(clientFunc(func(req *http.Request) (*http.Response, error) {
 		return c.response, c.err
-	}), "GET", nil, "/api/v1", restclient.ContentConfig{}, restclient.Serializers{}, nil, nil)
+	}), "GET", nil, "/api/v1", restclient.ContentConfig{}, restclient.Serializers{
+		Decoder: rawDecoder{},
+	}, nil, nil)
+}
+
+func (c *FakeRESTClient) Patch(pt types.PatchType) *restclient.Request {
+	return restclient.NewRequest(clientFunc(func(req *http.Request) (*http.Response, error) {
+		return c.response, c.err
+	}), "PATCH", nil, "/api/v1", restclient.ContentConfig{}, restclient.Serializers{
+		Decoder: rawDecoder{},
+	}, nil, nil)
+}
+
+// rawDecoder decodes responses into runtime.Unknown objects.
+type rawDecoder struct{}
+
+func (rawDecoder) Decode(data []byte, defaults *schema.GroupVersionKind,
+	into runtime.Object) (runtime.Object, *schema.GroupVersionKind, error) {
+	unknown := into.(*runtime.Unknown)
+	unknown.Raw = data
+	return unknown, defaults, nil
 }
 
 func TestDeleteShouldPropagateErrorsAndChoseClient(t *testing.T) {
@@ -149,6 +173,26 @@ func TestPutShouldThrowErrorOnUnknownResourceKind(t *testing.T) {
 	}
 }
 
+func TestPatchShouldThrowErrorOnUnknownResourceKind(t *testing.T) {
+	verber := ResourceVerber{client: &FakeRESTClient{}}
+
+	_, err := verber.Patch("foo", true, "bar", "baz", types.StrategicMergePatchType, []byte("{}"))
+
+	if !reflect.DeepEqual(err, errors.New("Unknown resource kind: foo")) {
+		t.Fatalf("Expected error on verber patch but got %#v", err)
+	}
+}
+
+func TestPatchShouldRespectNamespacednessOfResourceKind(t *testing.T) {
+	verber := ResourceVerber{client: &FakeRESTClient{}}
+
+	_, err := verber.Patch("node", true, "bar", "baz", types.StrategicMergePatchType, []byte("{}"))
+
+	if !reflect.DeepEqual(err, errors.New("Set namespace for not-namespaced resource kind: node")) {
+		t.Fatalf("Expected error on verber patch but got %#v", err)
+	}
+}
+
 func TestGetShouldRespectNamespacednessOfResourceKind(t *testing.T) {
 	verber := ResourceVerber{client: &FakeRESTClient{}}
 
@@ -208,3 +252,152 @@ func TestDeleteShouldRespectNotNamespacednessOfResourceKind(t *testing.T) {
 		t.Fatalf("Expected error on verber delete but got %#v", err)
 	}
 }
+
+func TestUpdateShouldReturnConflictOnModifiedResource(t *testing.T) {
+	current := `{"kind": "Service", "metadata": {"name": "baz", "resourceVersion": "2"}}`
+	verber := ResourceVerber{client: &FakeRESTClient{response: &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(current)),
+	}}}
+
+	_, err := verber.Update("service", true, "bar", "baz", "1",
+		[]byte("kind: Service\nmetadata:\n  name: baz\n  resourceVersion: \"1\"\n"))
+
+	conflict, ok := err.(*ConflictError)
+	if !ok {
+		t.Fatalf("Expected conflict error on verber update but got %#v", err)
+	}
+	if conflict.ResourceVersion != "1" || string(conflict.Current.Raw) != current {
+		t.Fatalf("Expected conflict with current resource but got %#v", conflict)
+	}
+}
+
+func TestGetShouldMaskValuesOfSecret(t *testing.T) {
+	current := `{"kind": "Secret", "metadata": {"name": "baz", "annotations": {` +
+		`"kubectl.kubernetes.io/last-applied-configuration": "{\"data\":{\"password\":\"czNjcjN0\"}}",` +
+		`"owner": "team-a"}}, "data": {"password": "czNjcjN0"}, "stringData": {"token": "s3cr3t"}}`
+	verber := ResourceVerber{client: &FakeRESTClient{response: &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(current)),
+	}}}
+
+	result, err := verber.Get("secret", true, "bar", "baz")
+	if err != nil {
+		t.Fatalf("Expected no error on verber get but got %#v", err)
+	}
+
+	expected := `{"data":{"password":""},"kind":"Secret","metadata":{"annotations":{` +
+		`"kubectl.kubernetes.io/last-applied-configuration":"","owner":"team-a"},"name":"baz"},` +
+		`"stringData":{"token":""}}`
+	if actual := string(result.(*runtime.Unknown).Raw); actual != expected {
+		t.Fatalf("Expected masked secret %s but got %s", expected, actual)
+	}
+}
+
+// storeRESTClient serves the stored object on GET and replaces it with the body of PUT.
+type storeRESTClient struct {
+	FakeRESTClient
+	stored string
+}
+
+func (c *storeRESTClient) Get() *restclient.Request {
+	return restclient.NewRequest(clientFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(c.stored)),
+		}, nil
+	}), "GET", nil, "/api/v1", restclient.ContentConfig{}, restclient.Serializers{
+		Decoder: rawDecoder{},
+	}, nil, nil)
+}
+
+func (c *storeRESTClient) Put() *restclient.Request {
+	return restclient.NewRequest(clientFunc(func(req *http.Request) (*http.Response, error) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		c.stored = string(body)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBuffer(body)),
+		}, nil
+	}), "PUT", nil, "/api/v1", restclient.ContentConfig{}, restclient.Serializers{}, nil, nil)
+}
+
+func TestPutShouldKeepMaskedValuesOfSecret(t *testing.T) {
+	store := &storeRESTClient{stored: `{"kind":"Secret","metadata":{"annotations":{` +
+		`"kubectl.kubernetes.io/last-applied-configuration":"{\"data\":{\"password\":\"czNjcjN0\"}}"},` +
+		`"name":"baz"},"data":{"password":"czNjcjN0","user":"YWRtaW4="}}`}
+	verber := ResourceVerber{client: store}
+
+	result, err := verber.Get("secret", true, "bar", "baz")
+	if err != nil {
+		t.Fatalf("Expected no error on verber get but got %#v", err)
+	}
+
+	// Edit a label and the user only, as the edit dialog would.
+	secret := map[string]interface{}{}
+	if err := json.Unmarshal(result.(*runtime.Unknown).Raw, &secret); err != nil {
+		t.Fatalf("Expected masked secret to be JSON but got %#v", err)
+	}
+	secret["metadata"].(map[string]interface{})["labels"] = map[string]interface{}{"app": "foo"}
+	secret["data"].(map[string]interface{})["user"] = "cm9vdA=="
+	raw, _ := json.Marshal(secret)
+
+	if err := verber.Put("secret", true, "bar", "baz", &runtime.Unknown{Raw: raw}); err != nil {
+		t.Fatalf("Expected no error on verber put but got %#v", err)
+	}
+
+	expected := `{"data":{"password":"czNjcjN0","user":"cm9vdA=="},"kind":"Secret","metadata":{` +
+		`"annotations":{"kubectl.kubernetes.io/last-applied-configuration":` +
+		`"{\"data\":{\"password\":\"czNjcjN0\"}}"},"labels":{"app":"foo"},"name":"baz"}}`
+	if store.stored != expected {
+		t.Fatalf("Expected stored secret %s but got %s", expected, store.stored)
+	}
+}
+
+func TestUpdateShouldThrowErrorOnUnknownResourceKind(t *testing.T) {
+	verber := ResourceVerber{client: &FakeRESTClient{}}
+
+	_, err := verber.Update("foo", true, "bar", "baz", "1", nil)
+
+	if !reflect.DeepEqual(err, errors.New("Unknown resource kind: foo")) {
+		t.Fatalf("Expected error on verber update but got %#v", err)
+	}
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	cases := []struct {
+		original, modified, expected string
+	}{
+		{
+			`{"metadata": {"name": "a", "resourceVersion": "1"}, "spec": {"replicas": 1}}`,
+			`{"metadata": {"name": "a", "resourceVersion": "1"}, "spec": {"replicas": 1}}`,
+			`{"metadata":{"resourceVersion":"1"}}`,
+		},
+		{
+			`{"metadata": {"name": "a", "labels": {"app": "a", "k8s.io/tier": "web"}}, "spec": {"replicas": 1}}`,
+			`{"metadata": {"name": "a", "labels": {"k8s.io/tier": "db"}}, "spec": {"replicas": 3}}`,
+			`{"metadata":{"labels":{"app":null,"k8s.io/tier":"db"},"resourceVersion":"1"},` +
+				`"spec":{"replicas":3}}`,
+		},
+		{
+			`{"metadata": {"name": "a"}, "spec": {"ports": [{"port": 80}, {"port": 443}]}}`,
+			`{"metadata": {"name": "a"}, "spec": {"ports": [{"port": 80}]}, "extra": "value"}`,
+			`{"extra":"value","metadata":{"resourceVersion":"1"},"spec":{"ports":[{"port":80}]}}`,
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := createMergePatch([]byte(c.original), []byte(c.modified), "1")
+		if err != nil {
+			t.Errorf("createMergePatch(%#v, %#v) returns error %s", c.original, c.modified, err)
+			continue
+		}
+		if string(actual) != c.expected {
+			t.Errorf("createMergePatch(%#v, %#v) returns %s, expected %s", c.original, c.modified,
+				actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/auth"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+)
+
+// ErrValidationFailed is returned for requests with missing or invalid CSRF token.
+var ErrValidationFailed = errors.New("CSRF validation failed")
+
+// protectedMethods change state of the cluster, so their requests have to be validated.
+var protectedMethods = []string{
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// InteractiveRoutes are GET routes that give access to running containers, i.e. create exec and
+// port forwarding sessions. They change state of the cluster, so their requests are validated.
+var InteractiveRoutes = []string{
+	"/api/v1/pod/{namespace}/{pod}/shell/{container}",
+	"/api/v1/pod/{namespace}/{pod}/portforward/{port}",
+	"/api/v1/service/{namespace}/{service}/portforward/{port}",
+}
+
+// exemptRoutePrefixes are routes that do not change state of the cluster, even though they use
+// protected methods.
+var exemptRoutePrefixes = []string{
+	// Validation handlers are idempotent functions, and not actual data modification operations.
+	"/api/v1/appdeployment/validate/",
+	// Access reviews only check permissions of the user.
+	"/api/v1/rbac/accessreview",
+}
+
+// NewFilter creates filter that rejects requests that change state of the cluster without valid
+// CSRF token in TokenHeader. Requests of clients that send their own credentials in headers, i.e.
+// bearer token or session token, are exempt, as other sites can not make browsers send them.
+func NewFilter(manager TokenManager) restful.FilterFunction {
+	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+		route := req.SelectedRoutePath()
+		action := mapUrlToAction(route)
+
+		if action == nil || (shouldDoCsrfValidation(req.Request.Method, route) && !isExempt(req) &&
+			!manager.Valid(req, req.HeaderParameter(TokenHeader), *action)) {
+			logger.Warning(ErrValidationFailed)
+			resp.AddHeader("Content-Type", "text/plain")
+			resp.WriteErrorString(http.StatusUnauthorized, ErrValidationFailed.Error()+"\n")
+			return
+		}
+
+		chain.ProcessFilter(req, resp)
+	}
+}
+
+// shouldDoCsrfValidation returns true for requests that change state of the cluster: POST, PUT,
+// PATCH and DELETE requests and creation of exec and port forwarding sessions.
+func shouldDoCsrfValidation(method, route string) bool {
+	for _, prefix := range exemptRoutePrefixes {
+		if strings.HasPrefix(route, prefix) {
+			return false
+		}
+	}
+
+	return contains(protectedMethods, method) ||
+		(method == http.MethodGet && contains(InteractiveRoutes, route))
+}
+
+// isExempt returns true for requests authenticated with bearer token or session token sent in
+// headers by the client, i.e. kubectl-like tools and scripts. Browsers send basic auth credentials
+// and cookies automatically, so requests authenticated with them are not exempt.
+func isExempt(req *restful.Request) bool {
+	if fromCookie, _ := req.Attribute(auth.CookieAuthAttribute).(bool); fromCookie {
+		return false
+	}
+
+	_, hasSession := req.Attribute(auth.SessionAttribute).(*auth.Session)
+	return hasSession || strings.HasPrefix(req.HeaderParameter("Authorization"), "Bearer ")
+}
+
+// mapUrlToAction extracts the action tokens are issued for, which is the resource from the URL
+// path /api/v1/<resource>. Ignores potential subresources.
+func mapUrlToAction(url string) *string {
+	parts := strings.Split(url, "/")
+	if len(parts) < 4 {
+		return nil
+	}
+	return &parts[3]
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
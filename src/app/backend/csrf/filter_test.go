@@ -0,0 +1,134 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/auth"
+)
+
+func TestShouldDoCsrfValidation(t *testing.T) {
+	cases := []struct {
+		method   string
+		route    string
+		expected bool
+	}{
+		{"GET", "/api/v1/pod", false},
+		{"POST", "/api/v1/pod", true},
+		{"PUT", "/api/v1/_raw/pod/namespace/default/name/web", true},
+		{"DELETE", "/api/v1/_raw/pod/namespace/default/name/web", true},
+		{"GET", "/api/v1/pod/{namespace}/{pod}/shell/{container}", true},
+		{"GET", "/api/v1/pod/{namespace}/{pod}/portforward/{port}", true},
+		{"GET", "/api/v1/service/{namespace}/{service}/portforward/{port}", true},
+		{"POST", "/api/v1/appdeployment/validate/name", false},
+	}
+	for _, c := range cases {
+		actual := shouldDoCsrfValidation(c.method, c.route)
+		if actual != c.expected {
+			t.Errorf("shouldDoCsrfValidation(%s %s) returns %#v, expected %#v", c.method, c.route,
+				actual, c.expected)
+		}
+	}
+}
+
+func TestMapUrlToAction(t *testing.T) {
+	cases := []struct {
+		url, expected string
+	}{
+		{
+			"/api/v1/pod",
+			"pod",
+		},
+		{
+			"/api/v1/node",
+			"node",
+		},
+	}
+	for _, c := range cases {
+		actual := mapUrlToAction(c.url)
+		if !reflect.DeepEqual(actual, &c.expected) {
+			t.Errorf("mapUrlToAction(%#v) returns %#v, expected %#v", c.url, actual, c.expected)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	manager := NewTokenManager("csrf-key")
+	session := &auth.Session{ID: "session-id"}
+
+	ws := new(restful.WebService)
+	ws.Path("/api/v1")
+	ws.Filter(func(request *restful.Request, response *restful.Response,
+		chain *restful.FilterChain) {
+		// Simulates session created by login, which is sent in a cookie.
+		if request.HeaderParameter("Cookie") != "" {
+			request.SetAttribute(auth.SessionAttribute, session)
+			request.SetAttribute(auth.CookieAuthAttribute, true)
+		}
+		chain.ProcessFilter(request, response)
+	})
+	ws.Filter(NewFilter(manager))
+	ws.Route(ws.DELETE("/pod/{name}").To(func(request *restful.Request,
+		response *restful.Response) {
+	}))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	sessionRequest := restful.NewRequest(&http.Request{})
+	sessionRequest.SetAttribute(auth.SessionAttribute, session)
+	sessionToken := manager.Generate(sessionRequest, "pod")
+	anonymousToken := manager.Generate(restful.NewRequest(&http.Request{}), "pod")
+
+	cases := []struct {
+		info           string
+		cookie         string
+		authorization  string
+		token          string
+		expectedStatus int
+	}{
+		{"anonymous without token", "", "", "", http.StatusUnauthorized},
+		{"anonymous with token", "", "", anonymousToken, http.StatusOK},
+		{"session cookie without token", "kd-session=token", "", "", http.StatusUnauthorized},
+		{"session cookie with token", "kd-session=token", "", sessionToken, http.StatusOK},
+		{"session cookie with token of other session", "kd-session=token", "", anonymousToken,
+			http.StatusUnauthorized},
+		{"bearer token", "", "Bearer token", "", http.StatusOK},
+		{"basic auth", "", "Basic YWRtaW46c2VjcmV0", "", http.StatusUnauthorized},
+	}
+
+	for _, c := range cases {
+		request := httptest.NewRequest("DELETE", "/api/v1/pod/web", nil)
+		if c.cookie != "" {
+			request.Header.Set("Cookie", c.cookie)
+		}
+		if c.authorization != "" {
+			request.Header.Set("Authorization", c.authorization)
+		}
+		if c.token != "" {
+			request.Header.Set(TokenHeader, c.token)
+		}
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, request)
+
+		if recorder.Code != c.expectedStatus {
+			t.Errorf("%s: expected status %d, but got %d", c.info, c.expectedStatus, recorder.Code)
+		}
+	}
+}
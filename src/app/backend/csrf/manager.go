@@ -0,0 +1,76 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/auth"
+	"golang.org/x/net/xsrftoken"
+)
+
+// TokenHeader is the header with CSRF token of requests that change state of the cluster.
+const TokenHeader = "X-CSRF-TOKEN"
+
+// anonymousSession identifies requests without credentials, which all use Dashboard credentials.
+const anonymousSession = "none"
+
+// TokenManager is responsible for CSRF tokens. Tokens are issued for an action, which is the
+// resource the request is made for, and bound to the session of the user, so that token of one
+// user can not be used by another one.
+type TokenManager interface {
+	// Generate returns token for the action, bound to the session of the request.
+	Generate(request *restful.Request, action string) string
+	// Valid returns true if the token is valid for the action and the session of the request.
+	Valid(request *restful.Request, token, action string) bool
+}
+
+// Implements TokenManager interface.
+type xsrfTokenManager struct {
+	key string
+}
+
+// Generate implements token manager interface. See TokenManager for more information.
+func (self xsrfTokenManager) Generate(request *restful.Request, action string) string {
+	return xsrftoken.Generate(self.key, sessionID(request), action)
+}
+
+// Valid implements token manager interface. See TokenManager for more information.
+func (self xsrfTokenManager) Valid(request *restful.Request, token, action string) bool {
+	return xsrftoken.Valid(token, self.key, sessionID(request), action)
+}
+
+// sessionID identifies session of the request. Sessions created on login have stable ID. Other
+// requests are identified by their credentials.
+func sessionID(request *restful.Request) string {
+	if session, ok := request.Attribute(auth.SessionAttribute).(*auth.Session); ok {
+		return "session:" + session.ID
+	}
+
+	if authorization := request.HeaderParameter("Authorization"); authorization != "" {
+		hash := sha256.Sum256([]byte(authorization))
+		return "credentials:" + hex.EncodeToString(hash[:])
+	}
+
+	return anonymousSession
+}
+
+// NewTokenManager creates token manager that signs tokens with given key. Tokens are valid for 24
+// hours.
+func NewTokenManager(key string) TokenManager {
+	return xsrfTokenManager{key: key}
+}
@@ -15,19 +15,42 @@
 package main
 
 import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
 	"fmt"
-	"log"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
+	"path"
+	"strings"
+	"time"
 
+	"github.com/kubernetes/dashboard/src/app/backend/alerting"
+	"github.com/kubernetes/dashboard/src/app/backend/audit"
+	"github.com/kubernetes/dashboard/src/app/backend/auth"
+	"github.com/kubernetes/dashboard/src/app/backend/cache"
 	"github.com/kubernetes/dashboard/src/app/backend/client"
 	"github.com/kubernetes/dashboard/src/app/backend/handler"
 	"github.com/kubernetes/dashboard/src/app/backend/integration"
 	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/metricsserver"
+	prometheusmetric "github.com/kubernetes/dashboard/src/app/backend/integration/metric/prometheus"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/scanner/clair"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/scanner/harbor"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/scanner/trivy"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/notification"
+	"github.com/kubernetes/dashboard/src/app/backend/plugin"
+	"github.com/kubernetes/dashboard/src/app/backend/policy"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/helm"
+	"github.com/kubernetes/dashboard/src/app/backend/settings"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/pflag"
+	"k8s.io/client-go/rest"
 )
 
 var (
@@ -45,26 +68,193 @@ var (
 		"to connect to in the format of protocol://address:port, e.g., "+
 		"http://localhost:8082. If not specified, the assumption is that the binary runs inside a "+
 		"Kubernetes cluster and service proxy will be used.")
+	argMetricsProvider = pflag.String("metrics-provider", "heapster", "Provider of metrics shown in "+
+		"graphs and sparklines, either heapster, prometheus or metrics-server. metrics-server reads current "+
+		"usage from the Kubernetes Metrics API.")
+	argPrometheusHost = pflag.String("prometheus-host", "", "The address of Prometheus to connect to "+
+		"in the format of protocol://address:port, e.g., http://prometheus.monitoring:9090. Used when "+
+		"--metrics-provider is prometheus.")
+	argPrometheusBearerTokenFile = pflag.String("prometheus-bearer-token-file", "", "File containing "+
+		"the bearer token sent to Prometheus.")
+	argPrometheusCAFile = pflag.String("prometheus-ca-file", "", "File containing the certificate "+
+		"authority used to verify the Prometheus certificate.")
+	argPrometheusInsecureSkipTLSVerify = pflag.Bool("prometheus-insecure-skip-tls-verify", false, "When "+
+		"set, the Prometheus certificate is not verified.")
+	argMetricsScraperWindow = pflag.Duration("metrics-scraper-window", 15*time.Minute, "How long usage "+
+		"scraped from metrics-server is kept in memory to draw graphs. Used when --metrics-provider is "+
+		"metrics-server.")
+	argMetricsScraperResolution = pflag.Duration("metrics-scraper-resolution", 30*time.Second, "How often "+
+		"usage is scraped from metrics-server. Set to 0 to show only current usage.")
 	argKubeConfigFile = pflag.String("kubeconfig", "", "Path to kubeconfig file with authorization and master location information.")
+	argBasePath       = pflag.String("base-path", "/", "The base path under which Dashboard is exposed, e.g. "+
+		"/dashboard/ when it runs behind an ingress that does not strip the path prefix. SockJS endpoints "+
+		"are served under this path in addition to the default one.")
+	argClusterKubeConfigDir = pflag.String("cluster-kubeconfig-dir", "", "Directory with kubeconfig "+
+		"files of additional clusters managed by Dashboard, e.g. mounted from secrets. Every file adds "+
+		"a cluster named after the file, selected by the cluster query parameter of API requests.")
+	argSockJSHeartbeatDelay = pflag.Duration("sockjs-heartbeat-delay",
+		handler.DefaultSockJSOptions.HeartbeatDelay, "How often a heartbeat is sent over SockJS connections "+
+			"to keep proxies and load balancers from closing them.")
+	argSockJSDisconnectDelay = pflag.Duration("sockjs-disconnect-delay",
+		handler.DefaultSockJSOptions.DisconnectDelay, "Time after which a SockJS session is closed if the "+
+			"client connection was not seen.")
+	argSockJSWebsocketOnly = pflag.Bool("sockjs-websocket-only", false, "When set, only websocket "+
+		"transport is allowed for SockJS connections.")
+	argSockJSMaxMessageSize = pflag.Int("sockjs-max-message-size", 0, "Maximum size in bytes of a single "+
+		"message received over a SockJS connection. Zero means no limit.")
+	argTerminalProtocol = pflag.String("terminal-protocol", handler.TerminalProtocolSPDY, "Protocol used to "+
+		"stream terminal sessions from the apiserver, either spdy or websocket. Use websocket when SPDY "+
+		"connections are blocked, e.g. by egress policies or proxies in front of the apiserver.")
+	argEnableResourceCache = pflag.Bool("enable-resource-cache", false, "When set, resources are watched "+
+		"with shared informers and lists requested without authorization header are served from the "+
+		"in-memory cache instead of the apiserver.")
+	argResourceCacheResyncPeriod = pflag.Duration("resource-cache-resync-period", 0, "How often the "+
+		"resource cache is resynced. Zero disables resync.")
+	argResourceCacheDisabledKinds = pflag.StringSlice("resource-cache-disabled-kinds", []string{},
+		"Comma separated list of resource kinds, e.g. events,pods, that are always listed from the "+
+			"apiserver when the resource cache is enabled.")
+	argOIDCIssuerURL = pflag.String("oidc-issuer-url", "", "The URL of OpenID Connect provider, e.g. "+
+		"Dex, Keycloak or Okta, users log in with. It has to match --oidc-issuer-url of the apiserver. "+
+		"If not specified, login with OpenID Connect is disabled.")
+	argOIDCClientID = pflag.String("oidc-client-id", "", "The client ID of Dashboard registered in "+
+		"the OpenID Connect provider. It has to match --oidc-client-id of the apiserver.")
+	argOIDCClientSecret = pflag.String("oidc-client-secret", "", "The client secret of Dashboard "+
+		"registered in the OpenID Connect provider.")
+	argOIDCRedirectURL = pflag.String("oidc-redirect-url", "", "The URL the OpenID Connect provider "+
+		"redirects users to after login, e.g. https://dashboard.example.com/api/v1/oidc/callback.")
+	argOIDCScopes = pflag.StringSlice("oidc-scopes", []string{"openid", "email", "profile",
+		"offline_access"}, "Comma separated list of scopes requested from the OpenID Connect provider.")
+	argSessionKeyFile = pflag.String("session-key-file", "", "File with the key used to encrypt session "+
+		"tokens. It has to be the same for all replicas. If not specified, the key is derived from the "+
+		"CSRF key.")
+	argSessionIdleTTL = pflag.Duration("session-idle-ttl", 30*time.Minute, "Time after which the session "+
+		"of an inactive user expires. Sessions of active users are refreshed.")
+	argSessionAbsoluteTTL = pflag.Duration("session-absolute-ttl", 12*time.Hour, "Maximum duration of "+
+		"a session regardless of user activity.")
+	argAuthProxyClientCAFile = pflag.String("auth-proxy-client-ca-file", "", "File with CA "+
+		"certificates that sign client certificates of trusted auth proxy. Requests of the proxy with "+
+		"Impersonate-User and Impersonate-Group headers are made on behalf of the impersonated user.")
+	argAuthProxyAllowedNames = pflag.StringSlice("auth-proxy-allowed-names", []string{}, "Comma "+
+		"separated list of common names of accepted client certificates of trusted auth proxy. If not "+
+		"specified, any certificate signed by --auth-proxy-client-ca-file is accepted.")
+	argAuthProxySecretFile = pflag.String("auth-proxy-secret-file", "", "File with the secret that "+
+		"trusted auth proxy sends in X-Dashboard-Proxy-Secret header, when client certificates can "+
+		"not be used.")
+	argLogFormat = pflag.String("log-format", handler.LogFormatText, "Format of logs, either text or "+
+		"json. In json format every log line is a JSON object and every API call is logged as a single "+
+		"structured entry.")
+	argRedactRequestPayloads = pflag.Bool("redact-request-payloads", true, "When set, bodies of API "+
+		"requests are not written to logs, as they may contain secrets, registry passwords, manifests "+
+		"of Secrets or tokens. Bodies of login requests are never logged.")
+	argReadOnly = pflag.Bool("read-only", false, "When set, all API requests that change state of the "+
+		"cluster, exec and port forwarding are rejected with 403, so that Dashboard can be safely exposed "+
+		"as a view-only UI.")
+	argPolicyFile = pflag.String("policy-file", "", "YAML file with Dashboard policy that denies "+
+		"actions of users, e.g. exec in production namespaces, independently of Kubernetes RBAC.")
+	argSettingsNamespace = pflag.String("settings-namespace", "kube-system", "Namespace of the "+
+		"ConfigMap that global and per-user settings are stored in.")
+	argAuditLogFile = pflag.String("audit-log-file", "", "File that an audit event is appended to, "+
+		"as a JSON line, for every create, update, delete, scale, exec and port forward performed "+
+		"through Dashboard. Use - for standard output.")
+	argAuditWebhookURL = pflag.String("audit-webhook-url", "", "URL that every audit event is posted "+
+		"to as a JSON object.")
+	argAlertingInterval = pflag.Duration("alerting-interval", alerting.DefaultInterval, "How often "+
+		"alert rules are evaluated.")
+	argAlertingWebhookURL = pflag.String("alerting-webhook-url", "", "URL that alerts are posted to "+
+		"as JSON objects when they fire and resolve.")
+	argAlertingSlackWebhookURL = pflag.String("alerting-slack-webhook-url", "", "URL of Slack "+
+		"incoming webhook that alerts are posted to when they fire and resolve.")
+	argAlertingEmailTo = pflag.StringSlice("alerting-email-to", []string{}, "Recipients of alert "+
+		"emails sent with --smtp-server. Can be repeated.")
+	argSMTPServer = pflag.String("smtp-server", "", "Address of the SMTP server, i.e. host:port, "+
+		"that alerts and notifications are sent by email with.")
+	argSMTPUsername = pflag.String("smtp-username", "", "Username used to authenticate to "+
+		"--smtp-server.")
+	argSMTPPasswordFile = pflag.String("smtp-password-file", "", "File containing the password of "+
+		"--smtp-username.")
+	argSMTPFrom       = pflag.String("smtp-from", "", "Sender of emails sent with --smtp-server.")
+	argSidecarPlugins = pflag.StringSlice("sidecar-plugin", []string{}, "Plugin running as a sidecar "+
+		"in the form of name=url, e.g. istio=http://localhost:9091. Requests to /api/v1/plugin/<name> "+
+		"are forwarded to the URL without credentials of the user. Can be repeated.")
+	argSidecarPluginCredentials = pflag.StringSlice("sidecar-plugin-forward-credentials", []string{},
+		"Name of a sidecar plugin that receives authorization and impersonation headers of the user, "+
+			"so that it can access the apiserver on behalf of the user. Can be repeated.")
+	argHelmRepositories = pflag.StringSlice("helm-repository", []string{}, "Helm chart repository "+
+		"in the form of name=url, e.g. stable=https://charts.helm.sh/stable, whose charts are listed "+
+		"in Dashboard. Can be repeated.")
+	argHelmBinary = pflag.String("helm-binary", "", "Path to Helm 3 binary used to install charts "+
+		"from the app catalog with credentials of the user. If not specified, charts can only be "+
+		"browsed.")
+	argImageScanner = pflag.String("image-scanner", "", "Scanner whose vulnerability summaries are "+
+		"attached to images in the image inventory, either trivy, clair or harbor. If not specified, "+
+		"images are listed without vulnerabilities.")
+	argImageScannerHost = pflag.String("image-scanner-host", "", "The address of the image scanner in "+
+		"the format of protocol://address:port, e.g. http://trivy.security:4954.")
+	argImageScannerTokenFile = pflag.String("image-scanner-token-file", "", "File containing the token "+
+		"of Trivy server or the bearer token sent to Clair.")
+	argTrivyBinary = pflag.String("trivy-binary", "trivy", "Path to Trivy binary used as the client of "+
+		"Trivy server. Used when --image-scanner is trivy.")
+	argHarborRegistry = pflag.String("harbor-registry", "", "Registry host images stored in Harbor are "+
+		"pulled from. Defaults to the host of --image-scanner-host. Used when --image-scanner is harbor.")
+	argHarborUsername = pflag.String("harbor-username", "", "Username of a Harbor robot account "+
+		"allowed to read scan reports. Used when --image-scanner is harbor.")
+	argHarborPasswordFile = pflag.String("harbor-password-file", "", "File containing the password of "+
+		"--harbor-username.")
 )
 
 func main() {
 	// Set logging output to standard console out
-	log.SetOutput(os.Stdout)
+	logger.SetOutput(os.Stdout)
 
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 	pflag.Parse()
 	flag.CommandLine.Parse(make([]string, 0)) // Init for glog calls in kubernetes packages
 
-	log.Printf("Using HTTP port: %d", *argPort)
+	err := handler.ConfigureLogging(handler.LoggingOptions{
+		Format:         *argLogFormat,
+		RedactPayloads: *argRedactRequestPayloads,
+	})
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	handler.ConfigureReadOnlyMode(*argReadOnly)
+	if *argReadOnly {
+		logger.Info("Running in read-only mode")
+	}
+
+	if *argPolicyFile != "" {
+		policyEngine, err := policy.LoadFile(*argPolicyFile)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		handler.ConfigurePolicy(policyEngine)
+		logger.Infof("Using Dashboard policy from %s", *argPolicyFile)
+	}
+
+	if sinks := getAuditSinks(); len(sinks) > 0 {
+		handler.ConfigureAudit(audit.NewAsyncSink(sinks...))
+	}
+
+	registerSidecarPlugins()
+
+	helmRepositories, err := helm.ParseRepositories(*argHelmRepositories)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	helm.SetRepositories(helmRepositories)
+	helm.SetHelmBinary(*argHelmBinary)
+
+	logger.Infof("Using HTTP port: %d", *argPort)
 	if *argApiserverHost != "" {
-		log.Printf("Using apiserver-host location: %s", *argApiserverHost)
+		logger.Infof("Using apiserver-host location: %s", *argApiserverHost)
 	}
 	if *argKubeConfigFile != "" {
-		log.Printf("Using kubeconfig file: %s", *argKubeConfigFile)
+		logger.Infof("Using kubeconfig file: %s", *argKubeConfigFile)
 	}
 
-	clientManager := client.NewClientManager(*argKubeConfigFile, *argApiserverHost)
+	clientManager := client.NewMultiClusterClientManager(*argKubeConfigFile, *argApiserverHost,
+		getClusterConfigs())
 	apiserverClient, err := clientManager.Client(nil)
 	if err != nil {
 		handleFatalInitError(err)
@@ -75,20 +265,83 @@ func main() {
 		handleFatalInitError(err)
 	}
 
-	log.Printf("Successful initial request to the apiserver, version: %s", versionInfo.String())
+	logger.Infof("Successful initial request to the apiserver, version: %s", versionInfo.String())
+
+	if *argEnableResourceCache {
+		resourceCache, err := cache.NewResourceCache(apiserverClient, cache.Options{
+			ResyncPeriod:  *argResourceCacheResyncPeriod,
+			DisabledKinds: *argResourceCacheDisabledKinds,
+		})
+		if err != nil {
+			logger.Fatalf("Could not create resource cache: %v", err)
+		}
+		resourceCache.Start()
+		common.SetResourceCache(resourceCache)
+	}
 
 	// Init integrations
 	integrationManager := integration.NewIntegrationManager(clientManager)
-	err = integrationManager.Metric().
-		ConfigureHeapster(*argHeapsterHost).
-		Enable(integrationapi.HeapsterIntegrationID)
+	switch *argMetricsProvider {
+	case string(integrationapi.HeapsterIntegrationID):
+		err = integrationManager.Metric().
+			ConfigureHeapster(*argHeapsterHost).
+			Enable(integrationapi.HeapsterIntegrationID)
+	case string(integrationapi.PrometheusIntegrationID):
+		err = integrationManager.Metric().
+			ConfigurePrometheus(getPrometheusOptions()).
+			Enable(integrationapi.PrometheusIntegrationID)
+	case string(integrationapi.MetricsServerIntegrationID):
+		err = integrationManager.Metric().
+			ConfigureMetricsServer(metricsserver.ScraperOptions{
+				Window:     *argMetricsScraperWindow,
+				Resolution: *argMetricsScraperResolution,
+			}).
+			Enable(integrationapi.MetricsServerIntegrationID)
+	default:
+		logger.Fatalf("Unknown metrics provider: %s", *argMetricsProvider)
+	}
 	if err != nil {
-		log.Printf("Could not enable metric client: %s. Continuing.", err)
+		logger.Warningf("Could not enable metric client: %s. Continuing.", err)
 	}
+	configureImageScanner(integrationManager)
+
+	tokenManager, err := auth.NewTokenManager(auth.SessionOptions{
+		Key:         getSessionKey(clientManager),
+		IdleTTL:     *argSessionIdleTTL,
+		AbsoluteTTL: *argSessionAbsoluteTTL,
+	})
+	if err != nil {
+		logger.Fatalf("Could not create session token manager: %s", err)
+	}
+
+	authManager := auth.NewAuthManager(clientManager, auth.OIDCOptions{
+		IssuerURL:    *argOIDCIssuerURL,
+		ClientID:     *argOIDCClientID,
+		ClientSecret: *argOIDCClientSecret,
+		RedirectURL:  *argOIDCRedirectURL,
+		Scopes:       *argOIDCScopes,
+	}, getAuthProxyOptions(), tokenManager)
+
+	settingsManager := settings.NewSettingsManager(apiserverClient, *argSettingsNamespace,
+		make(chan struct{}))
+
+	emailOptions := getEmailOptions()
+	notificationManager := notification.NewNotificationManager(apiserverClient, *argSettingsNamespace,
+		emailOptions, make(chan struct{}))
+	handler.ConfigureNotifications(notificationManager)
+	handler.ConfigureAlerting(alerting.NewAlertManager(apiserverClient, *argSettingsNamespace,
+		alerting.Options{
+			Interval:  *argAlertingInterval,
+			Notifiers: append(getAlertNotifiers(emailOptions), notificationManager),
+		},
+		make(chan struct{})))
 
 	apiHandler, err := handler.CreateHTTPAPIHandler(
 		integrationManager,
-		clientManager)
+		clientManager,
+		authManager,
+		settingsManager,
+		*argBasePath)
 	if err != nil {
 		handleFatalInitError(err)
 	}
@@ -99,28 +352,255 @@ func main() {
 	http.Handle("/api/", apiHandler)
 	// TODO(maciaszczykm): Move to /appConfig.json as it was discussed in #640.
 	http.Handle("/api/appConfig.json", handler.AppHandler(handler.ConfigHandler))
+	handler.ConfigureSockJS(handler.SockJSOptions{
+		HeartbeatDelay:  *argSockJSHeartbeatDelay,
+		DisconnectDelay: *argSockJSDisconnectDelay,
+		WebsocketOnly:   *argSockJSWebsocketOnly,
+		MaxMessageSize:  *argSockJSMaxMessageSize,
+	})
+	if err := handler.ConfigureTerminalProtocol(*argTerminalProtocol); err != nil {
+		logger.Fatal(err)
+	}
 	http.Handle("/api/sockjs/", handler.CreateAttachHandler("/api/sockjs"))
+	http.Handle("/api/sockjs/logs/", handler.CreateLogStreamHandler("/api/sockjs/logs"))
+	http.Handle("/api/sockjs/watch/", handler.CreateWatchHandler("/api/sockjs/watch"))
+	http.Handle("/api/sockjs/portforward/", handler.CreatePortForwardHandler("/api/sockjs/portforward"))
+	if sockJSPath := path.Join("/", *argBasePath, "api/sockjs"); sockJSPath != "/api/sockjs" {
+		http.Handle(sockJSPath+"/", handler.CreateAttachHandler(sockJSPath))
+		http.Handle(sockJSPath+"/logs/", handler.CreateLogStreamHandler(sockJSPath+"/logs"))
+		http.Handle(sockJSPath+"/watch/", handler.CreateWatchHandler(sockJSPath+"/watch"))
+		http.Handle(sockJSPath+"/portforward/", handler.CreatePortForwardHandler(sockJSPath+"/portforward"))
+	}
 	http.Handle("/metrics", prometheus.Handler())
 
 	// Listen for http and https
 	addr := fmt.Sprintf("%s:%d", *argInsecureBindAddress, *argInsecurePort)
-	go log.Fatal(http.ListenAndServe(addr, nil))
+	go func() { logger.Fatal(http.ListenAndServe(addr, nil)) }()
 	secureAddr := fmt.Sprintf("%s:%d", *argBindAddress, *argPort)
 	if len(*argCertFile) != 0 && len(*argKeyFile) != 0 {
-		go log.Fatal(http.ListenAndServeTLS(secureAddr, *argCertFile, *argKeyFile, nil))
+		server := &http.Server{Addr: secureAddr}
+		if clientCAs := getAuthProxyClientCAs(); clientCAs != nil {
+			server.TLSConfig = &tls.Config{
+				ClientCAs:  clientCAs,
+				ClientAuth: tls.VerifyClientCertIfGiven,
+			}
+		}
+		go func() { logger.Fatal(server.ListenAndServeTLS(*argCertFile, *argKeyFile)) }()
 	}
 	select {}
 }
 
+// getSessionKey returns 32 bytes long key used to encrypt session tokens, derived from the content
+// of --session-key-file or from the CSRF key, which is the same for all replicas in a cluster.
+func getSessionKey(clientManager client.ClientManager) []byte {
+	secret := []byte("session:" + clientManager.CSRFKey())
+	if *argSessionKeyFile != "" {
+		var err error
+		if secret, err = ioutil.ReadFile(*argSessionKeyFile); err != nil {
+			logger.Fatalf("Could not read session key: %s", err)
+		}
+	}
+
+	key := sha256.Sum256(secret)
+	return key[:]
+}
+
+// getAuthProxyOptions returns options of trusted auth proxy set by flags.
+func getAuthProxyOptions() auth.AuthProxyOptions {
+	options := auth.AuthProxyOptions{
+		ClientCAs:    getAuthProxyClientCAs(),
+		AllowedNames: *argAuthProxyAllowedNames,
+	}
+	if *argAuthProxySecretFile != "" {
+		secret, err := ioutil.ReadFile(*argAuthProxySecretFile)
+		if err != nil {
+			logger.Fatalf("Could not read auth proxy secret: %s", err)
+		}
+		options.SharedSecret = strings.TrimSpace(string(secret))
+	}
+	return options
+}
+
+// getAuthProxyClientCAs returns CA certificates from --auth-proxy-client-ca-file or nil if it is not
+// specified.
+func getAuthProxyClientCAs() *x509.CertPool {
+	if *argAuthProxyClientCAFile == "" {
+		return nil
+	}
+
+	pem, err := ioutil.ReadFile(*argAuthProxyClientCAFile)
+	if err != nil {
+		logger.Fatalf("Could not read auth proxy client CA file: %s", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		logger.Fatalf("No certificates found in %s", *argAuthProxyClientCAFile)
+	}
+	return pool
+}
+
+// getClusterConfigs returns configs of additional clusters from --cluster-kubeconfig-dir.
+func getClusterConfigs() map[string]*rest.Config {
+	if *argClusterKubeConfigDir == "" {
+		return nil
+	}
+
+	configs, err := client.LoadClusterConfigs(*argClusterKubeConfigDir)
+	if err != nil {
+		logger.Fatalf("Could not load kubeconfigs of clusters: %s", err)
+	}
+	for name, config := range configs {
+		logger.Infof("Managing cluster %s at %s", name, config.Host)
+	}
+	return configs
+}
+
+// getAuditSinks returns sinks of audit events set by flags.
+func getAuditSinks() []audit.Sink {
+	sinks := make([]audit.Sink, 0)
+	if *argAuditLogFile != "" {
+		sink, err := audit.NewFileSink(*argAuditLogFile)
+		if err != nil {
+			logger.Fatalf("Could not open audit log file: %s", err)
+		}
+		sinks = append(sinks, sink)
+	}
+	if *argAuditWebhookURL != "" {
+		sinks = append(sinks, audit.NewWebhookSink(*argAuditWebhookURL))
+	}
+	return sinks
+}
+
+// getEmailOptions returns options of the SMTP server set by flags. Nil is returned if the server is
+// not set.
+func getEmailOptions() *notification.EmailOptions {
+	if *argSMTPServer == "" {
+		return nil
+	}
+	return &notification.EmailOptions{
+		Server:   *argSMTPServer,
+		Username: *argSMTPUsername,
+		Password: readSecretFile(*argSMTPPasswordFile),
+		From:     *argSMTPFrom,
+	}
+}
+
+// getAlertNotifiers returns notifiers of alerts configured by flags.
+func getAlertNotifiers(emailOptions *notification.EmailOptions) []notification.Notifier {
+	notifiers := make([]notification.Notifier, 0)
+	if *argAlertingWebhookURL != "" {
+		notifiers = append(notifiers, notification.NewWebhookNotifier(*argAlertingWebhookURL))
+	}
+	if *argAlertingSlackWebhookURL != "" {
+		notifiers = append(notifiers, notification.NewSlackNotifier(*argAlertingSlackWebhookURL))
+	}
+	if len(*argAlertingEmailTo) > 0 {
+		if emailOptions == nil {
+			logger.Fatal("--alerting-email-to requires --smtp-server")
+		}
+		notifier, err := notification.NewEmailNotifier(*emailOptions, *argAlertingEmailTo)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		notifiers = append(notifiers, notifier)
+	}
+	return notifiers
+}
+
+// registerSidecarPlugins registers plugins given by --sidecar-plugin flags next to plugins compiled
+// into Dashboard.
+func registerSidecarPlugins() {
+	sidecars, err := plugin.ParseSidecarPlugins(*argSidecarPlugins, *argSidecarPluginCredentials)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	for _, sidecar := range sidecars {
+		if _, exists := plugin.Lookup(sidecar.Name()); exists {
+			logger.Fatalf("Plugin %s is already registered", sidecar.Name())
+		}
+		plugin.Register(sidecar)
+		logger.Infof("Using sidecar plugin %s", sidecar.Name())
+	}
+}
+
 /**
  * Handles fatal init error that prevents server from doing any work. Prints verbose error
  * message and quits the server.
  */
 func handleFatalInitError(err error) {
-	log.Fatalf("Error while initializing connection to Kubernetes apiserver. "+
+	logger.Fatalf("Error while initializing connection to Kubernetes apiserver. "+
 		"This most likely means that the cluster is misconfigured (e.g., it has "+
 		"invalid apiserver certificates or service accounts configuration) or the "+
 		"--apiserver-host param points to a server that does not exist. Reason: %s\n"+
 		"Refer to the troubleshooting guide for more information: "+
 		"https://github.com/kubernetes/dashboard/blob/master/docs/user-guide/troubleshooting.md", err)
 }
+
+// configureImageScanner configures and enables the image scanner set by flags.
+func configureImageScanner(integrationManager integration.IntegrationManager) {
+	var id integrationapi.IntegrationID
+	switch *argImageScanner {
+	case "":
+		return
+	case string(integrationapi.TrivyIntegrationID):
+		id = integrationapi.TrivyIntegrationID
+		integrationManager.Scanner().ConfigureTrivy(trivy.TrivyOptions{
+			Host:   *argImageScannerHost,
+			Binary: *argTrivyBinary,
+			Token:  readSecretFile(*argImageScannerTokenFile),
+		})
+	case string(integrationapi.ClairIntegrationID):
+		id = integrationapi.ClairIntegrationID
+		integrationManager.Scanner().ConfigureClair(clair.ClairOptions{
+			Host:        *argImageScannerHost,
+			BearerToken: readSecretFile(*argImageScannerTokenFile),
+		})
+	case string(integrationapi.HarborIntegrationID):
+		id = integrationapi.HarborIntegrationID
+		integrationManager.Scanner().ConfigureHarbor(harbor.HarborOptions{
+			Host:     *argImageScannerHost,
+			Registry: *argHarborRegistry,
+			Username: *argHarborUsername,
+			Password: readSecretFile(*argHarborPasswordFile),
+		})
+	default:
+		logger.Fatalf("Unknown image scanner: %s", *argImageScanner)
+	}
+
+	if err := integrationManager.Scanner().Enable(id); err != nil {
+		logger.Warningf("Could not enable image scanner: %s. Continuing.", err)
+	}
+}
+
+// readSecretFile returns trimmed content of the file. Empty string is returned if path is empty.
+func readSecretFile(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		logger.Fatalf("Could not read %s: %v", path, err)
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// getPrometheusOptions returns options of the Prometheus metrics provider set by flags.
+func getPrometheusOptions() prometheusmetric.PrometheusOptions {
+	options := prometheusmetric.PrometheusOptions{
+		Host:     *argPrometheusHost,
+		CAFile:   *argPrometheusCAFile,
+		Insecure: *argPrometheusInsecureSkipTLSVerify,
+	}
+
+	if *argPrometheusBearerTokenFile != "" {
+		token, err := ioutil.ReadFile(*argPrometheusBearerTokenFile)
+		if err != nil {
+			logger.Fatalf("Could not read Prometheus bearer token: %v", err)
+		}
+		options.BearerToken = strings.TrimSpace(string(token))
+	}
+
+	return options
+}
@@ -15,9 +15,9 @@
 package errors
 
 import (
-	"log"
 	"net/http"
 
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"k8s.io/apimachinery/pkg/api/errors"
 )
 
@@ -41,7 +41,7 @@ func AppendError(err error, nonCriticalErrors []error) ([]error, error) {
 		if isErrorCritical(err) {
 			return nonCriticalErrors, err
 		} else {
-			log.Printf("Non-critical error occurred during resource retrieval: %s", err)
+			logger.Warningf("Non-critical error occurred during resource retrieval: %s", err)
 			nonCriticalErrors = append(nonCriticalErrors, err)
 		}
 	}
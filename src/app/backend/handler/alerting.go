@@ -0,0 +1,37 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/alerting"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// alertManager evaluates alert rules, when configured.
+var alertManager alerting.AlertManager
+
+// ConfigureAlerting sets manager that stores and evaluates alert rules. Nil manager disables
+// alerting API.
+func ConfigureAlerting(manager alerting.AlertManager) {
+	alertManager = manager
+}
+
+// getAlertManager returns the configured alert manager or service unavailable error.
+func getAlertManager() (alerting.AlertManager, error) {
+	if alertManager == nil {
+		return nil, errorsK8s.NewServiceUnavailable("alerting is not configured")
+	}
+	return alertManager, nil
+}
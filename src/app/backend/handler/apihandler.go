@@ -15,52 +15,107 @@
 package handler
 
 import (
-	"log"
+	"compress/gzip"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/alerting"
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/auth"
 	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/csrf"
 	"github.com/kubernetes/dashboard/src/app/backend/integration"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/notification"
+	"github.com/kubernetes/dashboard/src/app/backend/plugin"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/accessreview"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/admissionwebhook"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apiresource"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/bulkedit"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/capacity"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/certificate"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/certmanager"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/cluster"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/clusterautoscaler"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/config"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/configmap"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/container"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/controller"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/cost"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/cronjob"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/customresourcedefinition"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/daemonset"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diagnosis"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/discovery"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dns"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/export"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/genericresource"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gitops"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/graph"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/helm"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/history"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/horizontalpodautoscaler"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/image"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/ingress"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/job"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/limitrange"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/lint"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/logs"
 	ns "github.com/kubernetes/dashboard/src/app/backend/resource/namespace"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/networkpolicy"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/node"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/overview"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/persistentvolume"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/persistentvolumeclaim"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/pod"
+	pdb "github.com/kubernetes/dashboard/src/app/backend/resource/poddisruptionbudget"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/podsecurity"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/priorityclass"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/rbacpermissions"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/rbacrolebindings"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/rbacroles"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/recommendation"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/replicaset"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/replicationcontroller"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/resourcequota"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/restart"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/rollout"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/scheduling"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/secret"
 	resourceService "github.com/kubernetes/dashboard/src/app/backend/resource/service"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/serviceaccount"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/servicemesh"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/statefulset"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/storageclass"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/thirdpartyresource"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/velero"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/workload"
 	"github.com/kubernetes/dashboard/src/app/backend/scaling"
 	"github.com/kubernetes/dashboard/src/app/backend/search"
+	"github.com/kubernetes/dashboard/src/app/backend/settings"
 	"github.com/kubernetes/dashboard/src/app/backend/validation"
-	"golang.org/x/net/xsrftoken"
 	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	authentication "k8s.io/client-go/pkg/apis/authentication/v1"
+	authorization "k8s.io/client-go/pkg/apis/authorization/v1"
 	"k8s.io/kubernetes/pkg/client/unversioned/remotecommand"
 )
 
@@ -69,13 +124,14 @@ const (
 	RequestLogString = "[%s] Incoming %s %s %s request from %s: %s"
 
 	// ResponseLogString is a template for response log message.
-	ResponseLogString = "[%s] Outcoming response to %s with %d status code"
+	ResponseLogString = "[%s] Outcoming response to %s with %d status code in %s (user: %s, resource: %s)"
 )
 
 // APIHandler is a representation of API handler. Structure contains client, Heapster client and client configuration.
 type APIHandler struct {
 	iManager integration.IntegrationManager
 	cManager client.ClientManager
+	sManager settings.SettingsManager
 }
 
 // TerminalResponse is sent by handleExecShell. The Id is a random session id that binds the original REST request and the SockJS connection.
@@ -84,15 +140,20 @@ type TerminalResponse struct {
 	Id string `json:"id"`
 }
 
-// CreateHTTPAPIHandler creates a new HTTP handler that handles all requests to the API of the backend.
-func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager client.ClientManager) (
-	http.Handler, error) {
-	apiHandler := APIHandler{iManager: iManager, cManager: cManager}
+// CreateHTTPAPIHandler creates a new HTTP handler that handles all requests to the API of the
+// backend. Base path is the path under which Dashboard is exposed to browsers.
+func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager client.ClientManager,
+	authManager auth.AuthManager, sManager settings.SettingsManager, basePath string) (http.Handler,
+	error) {
+	apiHandler := APIHandler{iManager: iManager, cManager: cManager, sManager: sManager}
 	wsContainer := restful.NewContainer()
 	wsContainer.EnableContentEncoding(true)
 
 	apiV1Ws := new(restful.WebService)
 
+	apiV1Ws.Filter(auth.NewAuthProxyFilter(authManager))
+	apiV1Ws.Filter(auth.NewSessionFilter(authManager))
+	apiV1Ws.Filter(auth.TokenCookieFilter)
 	InstallFilters(apiV1Ws, cManager)
 
 	apiV1Ws.Path("/api/v1").
@@ -100,19 +161,58 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		Produces(restful.MIME_JSON)
 	wsContainer.Add(apiV1Ws)
 
-	integrationHandler := integration.NewIntegrationHandler(iManager)
+	integrationHandler := integration.NewIntegrationHandler(iManager, cManager)
 	integrationHandler.Install(apiV1Ws)
 
+	authHandler := auth.NewAuthHandler(authManager, basePath)
+	authHandler.Install(apiV1Ws)
+
+	proxyHandler := ServiceProxyHandler{cManager: cManager, authManager: authManager}
+	proxyHandler.Install(wsContainer)
+
+	pluginHandler := PluginHandler{cManager: cManager, authManager: authManager,
+		plugins: plugin.Plugins()}
+	pluginHandler.Install(wsContainer)
+
 	apiV1Ws.Route(
 		apiV1Ws.GET("csrftoken/{action}").
 			To(apiHandler.handleGetCsrfToken).
 			Writes(api.CsrfToken{}))
 
+	apiV1Ws.Route(
+		apiV1Ws.GET("/clusterlist").
+			To(apiHandler.handleGetClusterList).
+			Writes(client.ClusterList{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/settings/global").
+			To(apiHandler.handleGetGlobalSettings).
+			Writes(settings.Settings{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/settings/global").
+			To(apiHandler.handleSaveGlobalSettings).
+			Reads(settings.Settings{}).
+			Writes(settings.Settings{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/settings/user").
+			To(apiHandler.handleGetUserSettings).
+			Writes(settings.UserSettings{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/settings/user").
+			To(apiHandler.handleSaveUserSettings).
+			Reads(settings.UserSettings{}).
+			Writes(settings.UserSettings{}))
+
 	apiV1Ws.Route(
 		apiV1Ws.POST("/appdeployment").
 			To(apiHandler.handleDeploy).
 			Reads(deployment.AppDeploymentSpec{}).
 			Writes(deployment.AppDeploymentSpec{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeployment/preview").
+			To(apiHandler.handleDeployPreview).
+			Reads(deployment.AppDeploymentSpec{}).
+			Writes(deployment.AppDeploymentPreview{}))
 	apiV1Ws.Route(
 		apiV1Ws.POST("/appdeployment/validate/name").
 			To(apiHandler.handleNameValidity).
@@ -138,6 +238,20 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			To(apiHandler.handleDeployFromFile).
 			Reads(deployment.AppDeploymentFromFileSpec{}).
 			Writes(deployment.AppDeploymentFromFileResponse{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/apply").
+			To(apiHandler.handleApply).
+			Reads(apply.ApplySpec{}).
+			Writes(apply.ApplyResult{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/apply/diff").
+			To(apiHandler.handleApplyDiff).
+			Reads(apply.DiffSpec{}).
+			Writes(apply.DiffResult{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/export").
+			To(apiHandler.handleExport).
+			Reads(export.ExportSpec{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/replicationcontroller").
@@ -177,11 +291,102 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			To(apiHandler.handleGetWorkloads).
 			Writes(workload.Workloads{}))
 
+	apiV1Ws.Route(
+		apiV1Ws.GET("/lint").
+			To(apiHandler.handleGetLintReport).
+			Writes(lint.LintReport{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/lint/{namespace}").
+			To(apiHandler.handleGetLintReport).
+			Writes(lint.LintReport{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/lint").
+			To(apiHandler.handleLintManifests).
+			Reads(lint.LintSpec{}).
+			Writes(lint.LintReport{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/podsecurity/{namespace}").
+			To(apiHandler.handleEvaluatePodSecurity).
+			Writes(podsecurity.PodSecurityEvaluation{}))
+
 	apiV1Ws.Route(
 		apiV1Ws.GET("/cluster").
 			To(apiHandler.handleGetCluster).
 			Writes(cluster.Cluster{}))
 
+	apiV1Ws.Route(
+		apiV1Ws.GET("/overview").
+			To(apiHandler.handleGetOverview).
+			Writes(overview.Overview{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/capacity").
+			To(apiHandler.handleGetCapacityReport).
+			Writes(capacity.Report{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/cost").
+			To(apiHandler.handleGetCostReport).
+			Writes(cost.Report{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/cost/{namespace}").
+			To(apiHandler.handleGetCostReport).
+			Writes(cost.Report{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/alert").
+			To(apiHandler.handleGetAlerts).
+			Writes(alerting.AlertList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/alert/{namespace}").
+			To(apiHandler.handleGetAlerts).
+			Writes(alerting.AlertList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/alertrule").
+			To(apiHandler.handleGetAlertRules).
+			Writes([]alerting.Rule{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/alertrule/{name}").
+			To(apiHandler.handleSaveAlertRule).
+			Reads(alerting.Rule{}).
+			Writes(alerting.Rule{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/alertrule/{name}").
+			To(apiHandler.handleDeleteAlertRule))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/notification/channel").
+			To(apiHandler.handleGetNotificationChannels).
+			Writes([]notification.Channel{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/notification/channel/{name}").
+			To(apiHandler.handleSaveNotificationChannel).
+			Reads(notification.Channel{}).
+			Writes(notification.Channel{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/notification/channel/{name}").
+			To(apiHandler.handleDeleteNotificationChannel))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/notification/route").
+			To(apiHandler.handleGetNotificationRoutes).
+			Writes([]notification.Route{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/notification/route/{name}").
+			To(apiHandler.handleSaveNotificationRoute).
+			Reads(notification.Route{}).
+			Writes(notification.Route{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/notification/route/{name}").
+			To(apiHandler.handleDeleteNotificationRoute))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/image").
+			To(apiHandler.handleGetImageList).
+			Writes(image.ImageList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/image/{namespace}").
+			To(apiHandler.handleGetImageList).
+			Writes(image.ImageList{}))
+
 	apiV1Ws.Route(
 		apiV1Ws.GET("/discovery").
 			To(apiHandler.handleGetDiscovery).
@@ -245,6 +450,23 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/pod/{namespace}/{pod}/shell/{container}").
 			To(apiHandler.handleExecShell).
 			Writes(TerminalResponse{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/pod/{namespace}/{pod}/portforward/{port}").
+			To(apiHandler.handlePodPortForward).
+			Writes(PortForwardResponse{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/pod/{namespace}/{pod}/eviction").
+			To(apiHandler.handleEvictPod).
+			Reads(pod.PodDeleteOptions{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/pod/{namespace}/{pod}/scheduling").
+			To(apiHandler.handleExplainPodScheduling).
+			Writes(scheduling.SchedulingExplanation{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/pod/delete").
+			To(apiHandler.handleDeletePods).
+			Reads(pod.PodBatchDeleteSpec{}).
+			Writes(pod.PodBatchDeleteResult{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/deployment").
@@ -266,11 +488,35 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/deployment/{namespace}/{deployment}/oldreplicaset").
 			To(apiHandler.handleGetDeploymentOldReplicaSets).
 			Writes(replicaset.ReplicaSetList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/deployment/{namespace}/{deployment}/history").
+			To(apiHandler.handleGetDeploymentRolloutHistory).
+			Writes(deployment.RolloutHistory{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/deployment/{namespace}/{deployment}/pause").
+			To(apiHandler.handlePauseDeployment).
+			Writes(deployment.RolloutStatus{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/deployment/{namespace}/{deployment}/resume").
+			To(apiHandler.handleResumeDeployment).
+			Writes(deployment.RolloutStatus{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/deployment/{namespace}/{deployment}/restart").
+			To(apiHandler.handleRestartDeployment).
+			Writes(deployment.RolloutStatus{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/deployment/{namespace}/{deployment}/rollback").
+			To(apiHandler.handleRollbackDeployment).
+			Writes(deployment.RolloutStatus{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.PUT("/scale/{kind}/{namespace}/{name}/").
 			To(apiHandler.handleScaleResource).
 			Writes(scaling.ReplicaCounts{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/scale/{kind}/{namespace}/{name}").
+			To(apiHandler.handleScaleResource).
+			Writes(scaling.ReplicaCounts{}))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/scale/{kind}/{namespace}/{name}").
 			To(apiHandler.handleGetReplicaCount).
@@ -315,6 +561,76 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/horizontalpodautoscaler/{namespace}/{horizontalpodautoscaler}").
 			To(apiHandler.handleGetHorizontalPodAutoscalerDetail).
 			Writes(horizontalpodautoscaler.HorizontalPodAutoscalerDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/horizontalpodautoscaler").
+			To(apiHandler.handleCreateHorizontalPodAutoscaler).
+			Reads(horizontalpodautoscaler.HorizontalPodAutoscalerSpec{}).
+			Writes(horizontalpodautoscaler.HorizontalPodAutoscalerDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/horizontalpodautoscaler/{namespace}/{horizontalpodautoscaler}").
+			To(apiHandler.handleUpdateHorizontalPodAutoscaler).
+			Reads(horizontalpodautoscaler.HorizontalPodAutoscalerSpec{}).
+			Writes(horizontalpodautoscaler.HorizontalPodAutoscalerDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/poddisruptionbudget").
+			To(apiHandler.handleGetPodDisruptionBudgetList).
+			Writes(pdb.PodDisruptionBudgetList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/poddisruptionbudget/{namespace}").
+			To(apiHandler.handleGetPodDisruptionBudgetList).
+			Writes(pdb.PodDisruptionBudgetList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/poddisruptionbudget/{namespace}/{name}").
+			To(apiHandler.handleGetPodDisruptionBudgetDetail).
+			Writes(pdb.PodDisruptionBudgetDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/resourcequota").
+			To(apiHandler.handleGetResourceQuotaList).
+			Writes(resourcequota.ResourceQuotaDetailList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/resourcequota/{namespace}").
+			To(apiHandler.handleGetResourceQuotaList).
+			Writes(resourcequota.ResourceQuotaDetailList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/resourcequota/{namespace}/{name}").
+			To(apiHandler.handleGetResourceQuotaDetail).
+			Writes(resourcequota.ResourceQuotaDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/limitrange").
+			To(apiHandler.handleGetLimitRangeList).
+			Writes(limitrange.LimitRangeList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/limitrange/{namespace}").
+			To(apiHandler.handleGetLimitRangeList).
+			Writes(limitrange.LimitRangeList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/limitrange/{namespace}/{name}").
+			To(apiHandler.handleGetLimitRangeDetail).
+			Writes(limitrange.LimitRangeDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/networkpolicy").
+			To(apiHandler.handleGetNetworkPolicyList).
+			Writes(networkpolicy.NetworkPolicyList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/networkpolicy/{namespace}").
+			To(apiHandler.handleGetNetworkPolicyList).
+			Writes(networkpolicy.NetworkPolicyList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/networkpolicy/{namespace}/{name}").
+			To(apiHandler.handleGetNetworkPolicyDetail).
+			Writes(networkpolicy.NetworkPolicyDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/namespace/{name}/networkgraph").
+			To(apiHandler.handleGetNamespaceNetworkGraph).
+			Writes(networkpolicy.NetworkGraph{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/namespace/{name}/quotatrend").
+			To(apiHandler.handleGetQuotaTrend).
+			Writes(resourcequota.QuotaTrend{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/pod/{namespace}/{pod}/networkgraph").
+			To(apiHandler.handleGetPodNetworkGraph).
+			Writes(networkpolicy.NetworkGraph{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/job").
@@ -336,6 +652,43 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/job/{namespace}/{job}/event").
 			To(apiHandler.handleGetJobEvents).
 			Writes(common.EventList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/job/{namespace}/{job}/failedlogs").
+			To(apiHandler.handleGetJobFailedPodLogs).
+			Writes(logs.LogDetails{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/job/{namespace}/{job}/recreate").
+			To(apiHandler.handleRecreateJob).
+			Writes(job.Job{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/cronjob").
+			To(apiHandler.handleGetCronJobList).
+			Writes(cronjob.CronJobList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/cronjob/{namespace}").
+			To(apiHandler.handleGetCronJobList).
+			Writes(cronjob.CronJobList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/cronjob/{namespace}/{name}").
+			To(apiHandler.handleGetCronJobDetail).
+			Writes(cronjob.CronJobDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/cronjob/{namespace}/{name}/job").
+			To(apiHandler.handleGetCronJobJobs).
+			Writes(job.JobList{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/cronjob/{namespace}/{name}/trigger").
+			To(apiHandler.handleTriggerCronJob).
+			Writes(api.ObjectMeta{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/cronjob/{namespace}/{name}/suspend").
+			To(apiHandler.handleSuspendCronJob).
+			Writes(cronjob.CronJob{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/cronjob/{namespace}/{name}/resume").
+			To(apiHandler.handleResumeCronJob).
+			Writes(cronjob.CronJob{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.POST("/namespace").
@@ -354,6 +707,19 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/namespace/{name}/event").
 			To(apiHandler.handleGetNamespaceEvents).
 			Writes(common.EventList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/namespace/{name}/termination").
+			To(apiHandler.handleGetNamespaceTermination).
+			Writes(ns.NamespaceTermination{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/namespace/{name}/finalizers").
+			To(apiHandler.handleClearNamespaceFinalizers).
+			Writes(ns.NamespaceTermination{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/namespace/{name}/clone").
+			To(apiHandler.handleCloneNamespace).
+			Reads(ns.NamespaceCloneSpec{}).
+			Writes(ns.NamespaceCloneResult{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/secret").
@@ -367,11 +733,75 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/secret/{namespace}/{name}").
 			To(apiHandler.handleGetSecretDetail).
 			Writes(secret.SecretDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/secret/{namespace}/{name}/reveal").
+			To(apiHandler.handleRevealSecretData).
+			Writes(secret.SecretData{}))
 	apiV1Ws.Route(
 		apiV1Ws.POST("/secret").
 			To(apiHandler.handleCreateImagePullSecret).
 			Reads(secret.ImagePullSecretSpec{}).
 			Writes(secret.Secret{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/secret/registry").
+			To(apiHandler.handleCreateRegistryCredentials).
+			Reads(secret.RegistryCredentialSpec{}).
+			Writes(secret.RegistryCredentialResult{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/secret/registry/test").
+			To(apiHandler.handleTestRegistryCredentials).
+			Reads(secret.RegistryCredentialSpec{}).
+			Writes(secret.RegistryCredentialTest{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/secret/{namespace}/{name}/attach").
+			To(apiHandler.handleAttachImagePullSecret).
+			Reads(secret.ImagePullSecretTarget{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/certificate").
+			To(apiHandler.handleGetCertificateList).
+			Writes(certificate.CertificateList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/certificate/{namespace}").
+			To(apiHandler.handleGetCertificateList).
+			Writes(certificate.CertificateList{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/certmanager").
+			To(apiHandler.handleGetCertManagerStatus).
+			Writes(certmanager.Status{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/certmanager/certificate").
+			To(apiHandler.handleGetCertManagerCertificateList).
+			Writes(certmanager.CertificateList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/certmanager/certificate/{namespace}").
+			To(apiHandler.handleGetCertManagerCertificateList).
+			Writes(certmanager.CertificateList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/certmanager/certificate/{namespace}/{name}").
+			To(apiHandler.handleGetCertManagerCertificateDetail).
+			Writes(certmanager.CertificateDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/certmanager/certificate/{namespace}/{name}/renew").
+			To(apiHandler.handleRenewCertManagerCertificate).
+			Writes(certmanager.Certificate{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/certmanager/issuer").
+			To(apiHandler.handleGetCertManagerIssuerList).
+			Writes(certmanager.IssuerList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/certmanager/issuer/{namespace}").
+			To(apiHandler.handleGetCertManagerIssuerList).
+			Writes(certmanager.IssuerList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/certmanager/certificaterequest").
+			To(apiHandler.handleGetCertManagerCertificateRequestList).
+			Writes(certmanager.CertificateRequestList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/certmanager/certificaterequest/{namespace}").
+			To(apiHandler.handleGetCertManagerCertificateRequestList).
+			Writes(certmanager.CertificateRequestList{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/configmap").
@@ -402,6 +832,109 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/service/{namespace}/{service}/pod").
 			To(apiHandler.handleGetServicePods).
 			Writes(pod.PodList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/service/{namespace}/{service}/portforward/{port}").
+			To(apiHandler.handleServicePortForward).
+			Writes(PortForwardResponse{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/service/{namespace}/{service}/endpointslice").
+			To(apiHandler.handleGetServiceEndpointSlices).
+			Writes(resourceService.ServiceEndpointSliceList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/service/{namespace}/{service}/probe").
+			To(apiHandler.handleProbeService).
+			Writes(resourceService.ConnectivityProbe{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/servicemesh").
+			To(apiHandler.handleGetServiceMeshStatus).
+			Writes(servicemesh.Status{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/servicemesh/virtualservice").
+			To(apiHandler.handleGetVirtualServiceList).
+			Writes(servicemesh.VirtualServiceList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/servicemesh/virtualservice/{namespace}").
+			To(apiHandler.handleGetVirtualServiceList).
+			Writes(servicemesh.VirtualServiceList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/servicemesh/destinationrule").
+			To(apiHandler.handleGetDestinationRuleList).
+			Writes(servicemesh.DestinationRuleList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/servicemesh/destinationrule/{namespace}").
+			To(apiHandler.handleGetDestinationRuleList).
+			Writes(servicemesh.DestinationRuleList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/servicemesh/peerauthentication").
+			To(apiHandler.handleGetPeerAuthenticationList).
+			Writes(servicemesh.PeerAuthenticationList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/servicemesh/peerauthentication/{namespace}").
+			To(apiHandler.handleGetPeerAuthenticationList).
+			Writes(servicemesh.PeerAuthenticationList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/servicemesh/serviceprofile").
+			To(apiHandler.handleGetServiceProfileList).
+			Writes(servicemesh.ServiceProfileList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/servicemesh/serviceprofile/{namespace}").
+			To(apiHandler.handleGetServiceProfileList).
+			Writes(servicemesh.ServiceProfileList{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/velero").
+			To(apiHandler.handleGetVeleroStatus).
+			Writes(velero.Status{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/velero/backup").
+			To(apiHandler.handleGetVeleroBackupList).
+			Writes(velero.BackupList{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/velero/backup").
+			To(apiHandler.handleCreateVeleroBackup).
+			Reads(velero.BackupSpec{}).
+			Writes(velero.Backup{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/velero/backup/{namespace}").
+			To(apiHandler.handleGetVeleroBackupList).
+			Writes(velero.BackupList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/velero/backup/{namespace}/{name}").
+			To(apiHandler.handleGetVeleroBackupDetail).
+			Writes(velero.BackupDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/velero/backup/{namespace}/{name}/restore").
+			To(apiHandler.handleRestoreVeleroBackup).
+			Reads(velero.RestoreSpec{}).
+			Writes(velero.Restore{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/velero/schedule").
+			To(apiHandler.handleGetVeleroScheduleList).
+			Writes(velero.ScheduleList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/velero/schedule/{namespace}").
+			To(apiHandler.handleGetVeleroScheduleList).
+			Writes(velero.ScheduleList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/velero/restore").
+			To(apiHandler.handleGetVeleroRestoreList).
+			Writes(velero.RestoreList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/velero/restore/{namespace}").
+			To(apiHandler.handleGetVeleroRestoreList).
+			Writes(velero.RestoreList{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/clusterautoscaler").
+			To(apiHandler.handleGetClusterAutoscalerStatus).
+			Writes(clusterautoscaler.Status{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.POST("/dns/lookup").
+			To(apiHandler.handleDNSLookup).
+			Reads(dns.DNSLookupSpec{}).
+			Writes(dns.DNSLookupResult{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/ingress").
@@ -436,6 +969,23 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/statefulset/{namespace}/{statefulset}/event").
 			To(apiHandler.handleGetStatefulSetEvents).
 			Writes(common.EventList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/statefulset/{namespace}/{statefulset}/ordinal").
+			To(apiHandler.handleGetStatefulSetOrdinals).
+			Writes(statefulset.StatefulSetOrdinals{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/statefulset/{namespace}/{statefulset}/ordinal/{ordinal}").
+			To(apiHandler.handleDeleteStatefulSetPod))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/statefulset/{namespace}/{statefulset}/partition").
+			To(apiHandler.handleSetStatefulSetPartition).
+			Reads(statefulset.PartitionSpec{}).
+			Writes(statefulset.StatefulSetUpdateStrategy{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/statefulset/{namespace}/{statefulset}/scaledown").
+			To(apiHandler.handleScaleDownStatefulSet).
+			Reads(statefulset.ScaleDownSpec{}).
+			Writes(statefulset.ScaleDownResult{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/node").
@@ -453,6 +1003,28 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/node/{name}/pod").
 			To(apiHandler.handleGetNodePods).
 			Writes(pod.PodList{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/node/{name}/cordon").
+			To(apiHandler.handleCordonNode).
+			Writes(node.NodeSchedulability{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/node/{name}/uncordon").
+			To(apiHandler.handleUncordonNode).
+			Writes(node.NodeSchedulability{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/node/{name}/drain").
+			To(apiHandler.handleDrainNode).
+			Reads(node.DrainOptions{}).
+			Writes(WatchResponse{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/node/{name}/taint").
+			To(apiHandler.handleAddNodeTaint).
+			Reads(v1.Taint{}).
+			Writes(node.NodeTaints{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/node/{name}/taint/{key}").
+			To(apiHandler.handleRemoveNodeTaint).
+			Writes(node.NodeTaints{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.DELETE("/_raw/{kind}/namespace/{namespace}/name/{name}").
@@ -463,6 +1035,10 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 	apiV1Ws.Route(
 		apiV1Ws.PUT("/_raw/{kind}/namespace/{namespace}/name/{name}").
 			To(apiHandler.handlePutResource))
+	apiV1Ws.Route(
+		apiV1Ws.PATCH("/_raw/{kind}/namespace/{namespace}/name/{name}").
+			To(apiHandler.handleUpdateResource).
+			Reads(client.ResourceUpdateSpec{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.DELETE("/_raw/{kind}/name/{name}").
@@ -473,19 +1049,111 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 	apiV1Ws.Route(
 		apiV1Ws.PUT("/_raw/{kind}/name/{name}").
 			To(apiHandler.handlePutResource))
+	apiV1Ws.Route(
+		apiV1Ws.PATCH("/_raw/{kind}/name/{name}").
+			To(apiHandler.handleUpdateResource).
+			Reads(client.ResourceUpdateSpec{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/relatedobjects/{kind}/{namespace}/{name}").
+			To(apiHandler.handleGetRelatedObjects).
+			Writes(graph.RelatedObjects{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/usage/{kind}/{namespace}/{name}").
+			To(apiHandler.handleGetObjectUsage).
+			Writes(graph.ObjectUsage{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/serviceaccount/{namespace}/kubeconfig").
+			To(apiHandler.handleGenerateKubeConfig).
+			Reads(serviceaccount.KubeConfigSpec{}).
+			Writes(serviceaccount.GeneratedKubeConfig{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/usage/{kind}/{namespace}/{name}/restart").
+			To(apiHandler.handleRestartObjectUsers).
+			Reads(restart.RestartSpec{}).
+			Writes(restart.RestartResultList{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/setimage/{kind}/{namespace}/{name}").
+			To(apiHandler.handleSetImage).
+			Reads(rollout.SetImageSpec{}).
+			Writes(SetImageResponse{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/rollout/{kind}/{namespace}/{name}").
+			To(apiHandler.handleGetRolloutProgress).
+			Writes(rollout.RolloutProgress{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/rollout/{kind}/{namespace}/{name}/notify").
+			To(apiHandler.handleNotifyOnRollout).
+			Reads(notification.RolloutNotificationSpec{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.POST("/bulkedit/metadata").
+			To(apiHandler.handleBulkEditMetadata).
+			Reads(bulkedit.MetadataEditSpec{}).
+			Writes(bulkedit.MetadataEditResultList{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/rbac/role").
 			To(apiHandler.handleGetRbacRoleList).
 			Writes(rbacroles.RbacRoleList{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/rbac/role").
+			To(apiHandler.handleCreateRbacRole).
+			Reads(rbacroles.RbacRoleSpec{}).
+			Writes(rbacroles.RbacRole{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/rbac/role").
+			To(apiHandler.handleUpdateRbacRole).
+			Reads(rbacroles.RbacRoleSpec{}).
+			Writes(rbacroles.RbacRole{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/rbac/role/{namespace}/{name}").
+			To(apiHandler.handleDeleteRbacRole))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/rbac/clusterrole/{name}").
+			To(apiHandler.handleDeleteRbacRole))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/rbac/rolebinding").
 			To(apiHandler.handleGetRbacRoleBindingList).
 			Writes(rbacrolebindings.RbacRoleBindingList{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/rbac/rolebinding").
+			To(apiHandler.handleCreateRbacRoleBinding).
+			Reads(rbacrolebindings.RbacRoleBindingSpec{}).
+			Writes(rbacrolebindings.RbacRoleBinding{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/rbac/rolebinding").
+			To(apiHandler.handleUpdateRbacRoleBinding).
+			Reads(rbacrolebindings.RbacRoleBindingSpec{}).
+			Writes(rbacrolebindings.RbacRoleBinding{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/rbac/rolebinding/{namespace}/{name}").
+			To(apiHandler.handleDeleteRbacRoleBinding))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/rbac/clusterrolebinding/{name}").
+			To(apiHandler.handleDeleteRbacRoleBinding))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/rbac/status").
 			To(apiHandler.handleRbacStatus).
 			Writes(validation.RbacStatus{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/rbac/accessreview").
+			To(apiHandler.handleAccessReview).
+			Reads(accessreview.AccessReviewSpec{}).
+			Writes(accessreview.AccessReviewList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/rbac/namespaces").
+			To(apiHandler.handleGetAccessibleNamespaces).
+			Writes(ns.NamespaceAccessList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/rbac/permissions/{kind}/{name}").
+			To(apiHandler.handleGetSubjectPermissions).
+			Writes(rbacpermissions.SubjectPermissions{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/rbac/whocan/{verb}/{resource}").
+			To(apiHandler.handleGetWhoCan).
+			Writes(rbacpermissions.WhoCanResult{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/persistentvolume").
@@ -512,6 +1180,25 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/persistentvolumeclaim/{namespace}/{name}").
 			To(apiHandler.handleGetPersistentVolumeClaimDetail).
 			Writes(persistentvolumeclaim.PersistentVolumeClaimDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/persistentvolumeclaim/{namespace}/{name}/size").
+			To(apiHandler.handleResizePersistentVolumeClaim).
+			Reads(persistentvolumeclaim.PersistentVolumeClaimResizeSpec{}).
+			Writes(persistentvolumeclaim.PersistentVolumeClaimDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/persistentvolumeclaim/{namespace}/{name}/snapshot").
+			To(apiHandler.handleGetVolumeSnapshotList).
+			Writes(persistentvolumeclaim.VolumeSnapshotList{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/persistentvolumeclaim/{namespace}/{name}/snapshot").
+			To(apiHandler.handleCreateVolumeSnapshot).
+			Reads(persistentvolumeclaim.VolumeSnapshotSpec{}).
+			Writes(persistentvolumeclaim.VolumeSnapshot{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/volumesnapshot/{namespace}/{name}/restore").
+			To(apiHandler.handleRestoreVolumeSnapshot).
+			Reads(persistentvolumeclaim.VolumeSnapshotRestoreSpec{}).
+			Writes(persistentvolumeclaim.PersistentVolumeClaimDetail{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/thirdpartyresource").
@@ -526,6 +1213,98 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			To(apiHandler.handleGetThirdPartyResourceObjects).
 			Writes(thirdpartyresource.ThirdPartyResourceObjectList{}))
 
+	apiV1Ws.Route(
+		apiV1Ws.GET("/crd").
+			To(apiHandler.handleGetCustomResourceDefinitionList).
+			Writes(customresourcedefinition.CustomResourceDefinitionList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/crd/{crd}").
+			To(apiHandler.handleGetCustomResourceDefinitionDetail).
+			Writes(customresourcedefinition.CustomResourceDefinitionDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/crd/{crd}/object").
+			To(apiHandler.handleGetCustomResourceObjectList).
+			Writes(customresourcedefinition.CustomResourceObjectList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/crd/{crd}/object/{namespace}").
+			To(apiHandler.handleGetCustomResourceObjectList).
+			Writes(customresourcedefinition.CustomResourceObjectList{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/crd/{crd}/object").
+			To(apiHandler.handleCreateCustomResourceObject).
+			Reads(customresourcedefinition.CustomResourceObjectSpec{}).
+			Writes(customresourcedefinition.CustomResourceObjectDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/crd/{crd}/object/{namespace}").
+			To(apiHandler.handleCreateCustomResourceObject).
+			Reads(customresourcedefinition.CustomResourceObjectSpec{}).
+			Writes(customresourcedefinition.CustomResourceObjectDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/crd/{crd}/namespace/{namespace}/name/{object}").
+			To(apiHandler.handleGetCustomResourceObjectDetail).
+			Writes(customresourcedefinition.CustomResourceObjectDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/crd/{crd}/namespace/{namespace}/name/{object}").
+			To(apiHandler.handleUpdateCustomResourceObject).
+			Reads(customresourcedefinition.CustomResourceObjectSpec{}).
+			Writes(customresourcedefinition.CustomResourceObjectDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/crd/{crd}/namespace/{namespace}/name/{object}").
+			To(apiHandler.handleDeleteCustomResourceObject))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/crd/{crd}/name/{object}").
+			To(apiHandler.handleGetCustomResourceObjectDetail).
+			Writes(customresourcedefinition.CustomResourceObjectDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/crd/{crd}/name/{object}").
+			To(apiHandler.handleUpdateCustomResourceObject).
+			Reads(customresourcedefinition.CustomResourceObjectSpec{}).
+			Writes(customresourcedefinition.CustomResourceObjectDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/crd/{crd}/name/{object}").
+			To(apiHandler.handleDeleteCustomResourceObject))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/generic/{group}/{version}/{resource}").
+			To(apiHandler.handleGetGenericResourceList).
+			Writes(genericresource.GenericResourceList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/generic/{group}/{version}/{resource}/{namespace}").
+			To(apiHandler.handleGetGenericResourceList).
+			Writes(genericresource.GenericResourceList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/generic/{group}/{version}/{resource}/namespace/{namespace}/name/{name}").
+			To(apiHandler.handleGetGenericResourceDetail).
+			Writes(genericresource.GenericResourceDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/generic/{group}/{version}/{resource}/namespace/{namespace}/name/{name}").
+			To(apiHandler.handleUpdateGenericResource).
+			Reads(genericresource.GenericResourceSpec{}).
+			Writes(genericresource.GenericResourceDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/generic/{group}/{version}/{resource}/namespace/{namespace}/name/{name}").
+			To(apiHandler.handleDeleteGenericResource))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/generic/{group}/{version}/{resource}/name/{name}").
+			To(apiHandler.handleGetGenericResourceDetail).
+			Writes(genericresource.GenericResourceDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/generic/{group}/{version}/{resource}/name/{name}").
+			To(apiHandler.handleUpdateGenericResource).
+			Reads(genericresource.GenericResourceSpec{}).
+			Writes(genericresource.GenericResourceDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/generic/{group}/{version}/{resource}/name/{name}").
+			To(apiHandler.handleDeleteGenericResource))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/history/{group}/{version}/{resource}/namespace/{namespace}/name/{name}").
+			To(apiHandler.handleGetObjectHistory).
+			Writes(history.ObjectHistory{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/history/{group}/{version}/{resource}/name/{name}").
+			To(apiHandler.handleGetObjectHistory).
+			Writes(history.ObjectHistory{}))
+
 	apiV1Ws.Route(
 		apiV1Ws.GET("/storageclass").
 			To(apiHandler.handleGetStorageClassList).
@@ -533,7 +1312,78 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 	apiV1Ws.Route(
 		apiV1Ws.GET("/storageclass/{storageclass}").
 			To(apiHandler.handleGetStorageClass).
-			Writes(storageclass.StorageClass{}))
+			Writes(storageclass.StorageClassDetail{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/priorityclass").
+			To(apiHandler.handleGetPriorityClassList).
+			Writes(priorityclass.PriorityClassList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/priorityclass/{priorityclass}").
+			To(apiHandler.handleGetPriorityClassDetail).
+			Writes(priorityclass.PriorityClass{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/admissionwebhook").
+			To(apiHandler.handleGetAdmissionWebhookConfigurationList).
+			Writes(admissionwebhook.AdmissionWebhookConfigurationList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/admissionwebhook/{type}/{name}").
+			To(apiHandler.handleGetAdmissionWebhookConfigurationDetail).
+			Writes(admissionwebhook.AdmissionWebhookConfigurationDetail{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/apiresource").
+			To(apiHandler.handleGetAPIResourceList).
+			Writes(apiresource.APIResourceList{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/helmrelease").
+			To(apiHandler.handleGetHelmReleaseList).
+			Writes(helm.ReleaseList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/helmrelease/{namespace}").
+			To(apiHandler.handleGetHelmReleaseList).
+			Writes(helm.ReleaseList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/helmrelease/{namespace}/{name}").
+			To(apiHandler.handleGetHelmReleaseDetail).
+			Writes(helm.ReleaseDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/helmrelease/{namespace}/{name}/rollback").
+			To(apiHandler.handleRollbackHelmRelease).
+			Writes(helm.ReleaseDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/helmrelease/{namespace}/{name}/upgrade").
+			To(apiHandler.handleUpgradeHelmRelease).
+			Reads(helm.UpgradeSpec{}).
+			Writes(helm.ReleaseDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/helmrelease/{namespace}/{name}").
+			To(apiHandler.handleUninstallHelmRelease).
+			Writes(apply.ApplyResult{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/helmchart").
+			To(apiHandler.handleGetHelmChartList).
+			Writes(helm.ChartList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/helmchart/{repository}/{chart}").
+			To(apiHandler.handleGetHelmChartDetail).
+			Writes(helm.ChartDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/helmchart/{repository}/{chart}/install").
+			To(apiHandler.handleInstallHelmChart).
+			Reads(helm.InstallSpec{}).
+			Writes(helm.ReleaseDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/helmrepository").
+			To(apiHandler.handleGetHelmRepositoryList).
+			Writes(helm.RepositoryList{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/helmrepository").
+			To(apiHandler.handleSaveHelmRepositoryList).
+			Reads(helm.RepositoryList{}).
+			Writes(helm.RepositoryList{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/search").
@@ -548,6 +1398,10 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/log/source/{namespace}/{resourceName}/{resourceType}").
 			To(apiHandler.handleLogSource).
 			Writes(controller.LogSources{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/log/aggregated/{namespace}/{resourceName}/{resourceType}").
+			To(apiHandler.handleAggregatedLogs).
+			Writes(logs.AggregatedLogDetails{}))
 	apiV1Ws.Route(
 		apiV1Ws.GET("/log/{namespace}/{pod}").
 			To(apiHandler.handleLogs).
@@ -556,6 +1410,50 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/log/{namespace}/{pod}/{container}").
 			To(apiHandler.handleLogs).
 			Writes(logs.LogDetails{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/log/{namespace}/{pod}/{container}/stream").
+			To(apiHandler.handleLogStream).
+			Writes(LogStreamResponse{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/log/{namespace}/{pod}/{container}/file").
+			To(apiHandler.handleLogFile))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/watch/{kind}").
+			To(apiHandler.handleWatch).
+			Writes(WatchResponse{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/watch/{kind}/{namespace}").
+			To(apiHandler.handleWatch).
+			Writes(WatchResponse{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/event/{namespace}/{kind}/{name}").
+			To(apiHandler.handleGetAggregatedEvents).
+			Writes(event.AggregatedEventList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/eventstream").
+			To(apiHandler.handleEventStream).
+			Writes(WatchResponse{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/eventstream/{namespace}").
+			To(apiHandler.handleEventStream).
+			Writes(WatchResponse{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/diagnosis/{kind}/{namespace}/{name}").
+			To(apiHandler.handleGetWorkloadDiagnosis).
+			Writes(diagnosis.Diagnosis{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/recommendation/{kind}/{namespace}/{name}").
+			To(apiHandler.handleGetRecommendation).
+			Writes(recommendation.Recommendation{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/recommendation/{kind}/{namespace}/{name}").
+			To(apiHandler.handleApplyRecommendation).
+			Reads(recommendation.ApplySpec{}).
+			Writes(recommendation.ApplyResult{}))
 
 	return wsContainer, nil
 }
@@ -611,7 +1509,7 @@ func (apiHandler *APIHandler) handleRbacStatus(request *restful.Request, respons
 
 func (apiHandler *APIHandler) handleGetCsrfToken(request *restful.Request, response *restful.Response) {
 	action := request.PathParameter("action")
-	token := xsrftoken.Generate(apiHandler.cManager.CSRFKey(), "none", action)
+	token := csrf.NewTokenManager(apiHandler.cManager.CSRFKey()).Generate(request, action)
 	response.WriteHeaderAndEntity(http.StatusOK, api.CsrfToken{Token: token})
 }
 
@@ -649,6 +1547,7 @@ func (apiHandler *APIHandler) handleGetStatefulSetDetail(request *restful.Reques
 		handleInternalError(response, err)
 		return
 	}
+	result.GitOps = apiHandler.getGitOpsOwnership(request, "apps", "StatefulSet", result.ObjectMeta)
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
@@ -720,6 +1619,15 @@ func (apiHandler *APIHandler) handleGetServiceDetail(request *restful.Request, r
 		handleInternalError(response, err)
 		return
 	}
+
+	// Service mesh status is optional, so failures to get it are non-critical.
+	if cfg, err := apiHandler.cManager.Config(request); err == nil {
+		result.Mesh, err = servicemesh.GetServiceMesh(k8sClient, cfg, namespace, name)
+		if err != nil {
+			result.Errors = append(result.Errors, err)
+		}
+	}
+	result.GitOps = apiHandler.getGitOpsOwnership(request, "", "Service", result.ObjectMeta)
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
@@ -878,6 +1786,20 @@ func (apiHandler *APIHandler) handleDeploy(request *restful.Request, response *r
 	response.WriteHeaderAndEntity(http.StatusCreated, appDeploymentSpec)
 }
 
+func (apiHandler *APIHandler) handleDeployPreview(request *restful.Request, response *restful.Response) {
+	appDeploymentSpec := new(deployment.AppDeploymentSpec)
+	if err := request.ReadEntity(appDeploymentSpec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	result, err := deployment.PreviewApp(appDeploymentSpec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
 func (apiHandler *APIHandler) handleScaleResource(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
@@ -1041,6 +1963,16 @@ func (apiHandler *APIHandler) handleSearch(request *restful.Request, response *r
 
 	namespace := parseNamespacePathParameter(request)
 	dataSelect := parseDataSelectPathParameter(request)
+	if query := request.QueryParameter("q"); len(query) > 0 {
+		result, err := search.GlobalSearch(k8sClient, namespace, query, dataSelect)
+		if err != nil {
+			handleInternalError(response, err)
+			return
+		}
+		response.WriteHeaderAndEntity(http.StatusOK, result)
+		return
+	}
+
 	dataSelect.MetricQuery = dataselect.NoMetrics
 	result, err := search.Search(k8sClient, apiHandler.iManager.Metric().Client(), namespace, dataSelect)
 	if err != nil {
@@ -1185,7 +2117,7 @@ func (apiHandler *APIHandler) handleGetPodEvents(request *restful.Request, respo
 		return
 	}
 
-	log.Println("Getting events related to a pod in namespace")
+	logger.Info("Getting events related to a pod in namespace")
 	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("pod")
 	dataSelect := parseDataSelectPathParameter(request)
@@ -1259,6 +2191,7 @@ func (apiHandler *APIHandler) handleGetDeploymentDetail(request *restful.Request
 		return
 	}
 
+	result.GitOps = apiHandler.getGitOpsOwnership(request, "apps", "Deployment", result.ObjectMeta)
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
@@ -1330,12 +2263,26 @@ func (apiHandler *APIHandler) handleGetPodDetail(request *restful.Request, respo
 		handleInternalError(response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, result)
-}
 
-func (apiHandler *APIHandler) handleGetReplicationControllerDetail(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
-	if err != nil {
+	// Service mesh status is optional, so failures to get it are non-critical.
+	if cfg, err := apiHandler.cManager.Config(request); err == nil {
+		result.Mesh, err = servicemesh.GetPodMesh(k8sClient, cfg, namespace, name)
+		if err != nil {
+			result.Errors = append(result.Errors, err)
+		}
+	}
+
+	// Priority is optional as well, as the user may not be allowed to list events.
+	result.Priority, err = priorityclass.GetPodPriority(k8sClient, namespace, name)
+	if err != nil {
+		result.Errors = append(result.Errors, err)
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetReplicationControllerDetail(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
 		handleInternalError(response, err)
 		return
 	}
@@ -1794,7 +2741,7 @@ func (apiHandler *APIHandler) handleGetReplicationControllerServices(request *re
 
 // Handler that writes the given error to the response and sets appropriate HTTP status headers.
 func handleInternalError(response *restful.Response, err error) {
-	log.Print(err)
+	logger.Error(err)
 	statusCode := http.StatusInternalServerError
 	statusError, ok := err.(*errorsK8s.StatusError)
 	if ok && statusError.Status().Code > 0 {
@@ -1837,6 +2784,7 @@ func (apiHandler *APIHandler) handleGetDaemonSetDetail(
 		handleInternalError(response, err)
 		return
 	}
+	result.GitOps = apiHandler.getGitOpsOwnership(request, "apps", "DaemonSet", result.ObjectMeta)
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
@@ -2110,7 +3058,15 @@ func (apiHandler *APIHandler) handleLogs(request *restful.Request, response *res
 		}
 	}
 
-	result, err := container.GetPodLogs(k8sClient, namespace, podID, containerID, logSelector)
+	logFilter, err := parseLogFilter(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	usePreviousLogs := request.QueryParameter("previous") == "true"
+	result, err := container.GetPodLogs(k8sClient, namespace, podID, containerID, logSelector, logFilter,
+		usePreviousLogs)
 	if err != nil {
 		handleInternalError(response, err)
 		return
@@ -2118,68 +3074,3500 @@ func (apiHandler *APIHandler) handleLogs(request *restful.Request, response *res
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-// parseNamespacePathParameter parses namespace selector for list pages in path parameter.
-// The namespace selector is a comma separated list of namespaces that are trimmed.
-// No namespaces means "view all user namespaces", i.e., everything except kube-system.
-func parseNamespacePathParameter(request *restful.Request) *common.NamespaceQuery {
+func (apiHandler *APIHandler) handleLogStream(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	session, err := newLogStreamSession()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	go WaitForLogStream(k8sClient, request, session)
+	response.WriteHeaderAndEntity(http.StatusOK, LogStreamResponse{Id: session.id})
+}
+
+func (apiHandler *APIHandler) handleAggregatedLogs(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	resourceName := request.PathParameter("resourceName")
+	resourceType := request.PathParameter("resourceType")
 	namespace := request.PathParameter("namespace")
-	namespaces := strings.Split(namespace, ",")
-	var nonEmptyNamespaces []string
-	for _, n := range namespaces {
-		n = strings.Trim(n, " ")
-		if len(n) > 0 {
-			nonEmptyNamespaces = append(nonEmptyNamespaces, n)
-		}
+	logSources, err := logs.GetLogSources(k8sClient, namespace, resourceName, resourceType)
+	if err != nil {
+		handleInternalError(response, err)
+		return
 	}
-	return common.NewNamespaceQuery(nonEmptyNamespaces)
+
+	options := &container.AggregatedLogOptions{}
+	if tailLines, err := strconv.ParseInt(request.QueryParameter("tailLines"), 10, 64); err == nil {
+		options.TailLines = tailLines
+	}
+	if sinceTime, err := time.Parse(time.RFC3339, request.QueryParameter("sinceTime")); err == nil {
+		options.SinceTime = &metaV1.Time{Time: sinceTime}
+	}
+
+	result, err := container.GetAggregatedLogs(k8sClient, namespace, logSources, options)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func parsePaginationPathParameter(request *restful.Request) *dataselect.PaginationQuery {
-	itemsPerPage, err := strconv.ParseInt(request.QueryParameter("itemsPerPage"), 10, 0)
+func (apiHandler *APIHandler) handleLogFile(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
-		return dataselect.NoPagination
+		handleInternalError(response, err)
+		return
 	}
 
-	page, err := strconv.ParseInt(request.QueryParameter("page"), 10, 0)
+	namespace := request.PathParameter("namespace")
+	podID := request.PathParameter("pod")
+	containerID := request.PathParameter("container")
+	logFile, err := container.GetLogFile(k8sClient, namespace, podID, containerID)
 	if err != nil {
-		return dataselect.NoPagination
+		handleInternalError(response, err)
+		return
 	}
+	defer logFile.Close()
 
-	// Frontend pages start from 1 and backend starts from 0
-	return dataselect.NewPaginationQuery(int(itemsPerPage), int(page-1))
+	var writer io.Writer = response
+	fileName := logFile.Name
+	if request.QueryParameter("compressed") == "true" {
+		fileName += ".gz"
+		response.AddHeader("Content-Type", "application/gzip")
+		gzipWriter := gzip.NewWriter(response)
+		defer gzipWriter.Close()
+		writer = gzipWriter
+	} else {
+		response.AddHeader("Content-Type", "text/plain; charset=utf-8")
+	}
+	response.AddHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	response.WriteHeader(http.StatusOK)
+
+	// Headers are already sent, so the error can only be logged.
+	if _, err := io.Copy(writer, logFile); err != nil {
+		logger.Errorf("Error while sending log file %s: %v", fileName, err)
+	}
 }
 
-func parseFilterPathParameter(request *restful.Request) *dataselect.FilterQuery {
-	return dataselect.NewFilterQuery(strings.Split(request.QueryParameter("filterBy"), ","))
+func (apiHandler *APIHandler) handleWatch(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	if err := validateWatchKind(request.PathParameter("kind")); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	session, err := newWatchSession()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	go WaitForWatch(k8sClient, request, session)
+	response.WriteHeaderAndEntity(http.StatusOK, WatchResponse{Id: session.id})
 }
 
-// Parses query parameters of the request and returns a SortQuery object
-func parseSortPathParameter(request *restful.Request) *dataselect.SortQuery {
-	return dataselect.NewSortQuery(strings.Split(request.QueryParameter("sortBy"), ","))
+func (apiHandler *APIHandler) handleGetCustomResourceDefinitionList(request *restful.Request,
+	response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := customresourcedefinition.GetCustomResourceDefinitionList(cfg, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-// Parses query parameters of the request and returns a MetricQuery object
-func parseMetricPathParameter(request *restful.Request) *dataselect.MetricQuery {
-	metricNamesParam := request.QueryParameter("metricNames")
-	var metricNames []string
-	if metricNamesParam != "" {
-		metricNames = strings.Split(metricNamesParam, ",")
-	} else {
-		metricNames = nil
+func (apiHandler *APIHandler) handleGetCustomResourceDefinitionDetail(request *restful.Request,
+	response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
 	}
-	aggregationsParam := request.QueryParameter("aggregations")
-	var rawAggregations []string
-	if aggregationsParam != "" {
-		rawAggregations = strings.Split(aggregationsParam, ",")
-	} else {
-		rawAggregations = nil
+
+	name := request.PathParameter("crd")
+	result, err := customresourcedefinition.GetCustomResourceDefinitionDetail(cfg, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
 	}
-	aggregationModes := metricapi.AggregationModes{}
-	for _, e := range rawAggregations {
-		aggregationModes = append(aggregationModes, metricapi.AggregationMode(e))
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetCustomResourceObjectList(request *restful.Request,
+	response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	crdName := request.PathParameter("crd")
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := customresourcedefinition.GetCustomResourceObjectList(cfg, crdName, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetCustomResourceObjectDetail(request *restful.Request,
+	response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	crdName := request.PathParameter("crd")
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("object")
+	result, err := customresourcedefinition.GetCustomResourceObjectDetail(cfg, crdName, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleCreateCustomResourceObject(request *restful.Request,
+	response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(customresourcedefinition.CustomResourceObjectSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	crdName := request.PathParameter("crd")
+	namespace := request.PathParameter("namespace")
+	result, err := customresourcedefinition.CreateCustomResourceObject(cfg, crdName, namespace, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleUpdateCustomResourceObject(request *restful.Request,
+	response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(customresourcedefinition.CustomResourceObjectSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	crdName := request.PathParameter("crd")
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("object")
+	result, err := customresourcedefinition.UpdateCustomResourceObject(cfg, crdName, namespace, name, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleDeleteCustomResourceObject(request *restful.Request,
+	response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	crdName := request.PathParameter("crd")
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("object")
+	if err := customresourcedefinition.DeleteCustomResourceObject(cfg, crdName, namespace, name); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+func (apiHandler *APIHandler) handleApply(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
 	}
-	return dataselect.NewMetricQuery(metricNames, aggregationModes)
 
+	spec := new(apply.ApplySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := apply.Apply(k8sClient, cfg, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleApplyDiff(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(apply.DiffSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := apply.Diff(k8sClient, cfg, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleUpdateResource(
+	request *restful.Request, response *restful.Response) {
+	verber, err := apiHandler.cManager.VerberClient(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	kind := request.PathParameter("kind")
+	namespace, ok := request.PathParameters()["namespace"]
+	name := request.PathParameter("name")
+	updateSpec := new(client.ResourceUpdateSpec)
+	if err := request.ReadEntity(updateSpec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := verber.Update(kind, ok, namespace, name, updateSpec.ResourceVersion,
+		[]byte(updateSpec.Content))
+	if conflictError, isConflict := err.(*client.ConflictError); isConflict {
+		response.WriteHeaderAndEntity(http.StatusConflict, conflictError)
+		return
+	}
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	if k8sClient, err := apiHandler.cManager.Client(request); err == nil {
+		updated := &unstructured.Unstructured{}
+		if err := updated.UnmarshalJSON(result.(*runtime.Unknown).Raw); err == nil {
+			recordSnapshot(k8sClient, request, updated)
+			apiHandler.warnOnGitOpsEdit(request, response, updated)
+		}
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetDeploymentRolloutHistory(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("deployment")
+	result, err := deployment.GetDeploymentRolloutHistory(k8sClient, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handlePauseDeployment(request *restful.Request, response *restful.Response) {
+	apiHandler.handleDeploymentRolloutAction(request, response, deployment.PauseDeployment)
+}
+
+func (apiHandler *APIHandler) handleResumeDeployment(request *restful.Request, response *restful.Response) {
+	apiHandler.handleDeploymentRolloutAction(request, response, deployment.ResumeDeployment)
+}
+
+func (apiHandler *APIHandler) handleRestartDeployment(request *restful.Request, response *restful.Response) {
+	apiHandler.handleDeploymentRolloutAction(request, response, deployment.RestartDeployment)
+}
+
+func (apiHandler *APIHandler) handleRollbackDeployment(request *restful.Request, response *restful.Response) {
+	revision := int64(0)
+	if value := request.QueryParameter("revision"); len(value) > 0 {
+		var err error
+		revision, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || revision < 0 {
+			handleInternalError(response, errorsK8s.NewBadRequest("revision must be a non-negative integer"))
+			return
+		}
+	}
+
+	apiHandler.handleDeploymentRolloutAction(request, response,
+		func(client kubernetes.Interface, namespace, name string) (*deployment.RolloutStatus, error) {
+			return deployment.RollbackDeployment(client, namespace, name, revision)
+		})
+}
+
+// handleDeploymentRolloutAction performs rollout action on the deployment from path parameters.
+func (apiHandler *APIHandler) handleDeploymentRolloutAction(request *restful.Request,
+	response *restful.Response,
+	action func(kubernetes.Interface, string, string) (*deployment.RolloutStatus, error)) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("deployment")
+	result, err := action(k8sClient, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetCronJobList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := cronjob.GetCronJobList(k8sClient, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetCronJobDetail(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := cronjob.GetCronJobDetail(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetCronJobJobs(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	dataSelect := parseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := cronjob.GetCronJobJobs(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect,
+		namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleTriggerCronJob(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := cronjob.TriggerCronJob(k8sClient, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleSuspendCronJob(request *restful.Request, response *restful.Response) {
+	apiHandler.handleSetCronJobSuspend(request, response, true)
+}
+
+func (apiHandler *APIHandler) handleResumeCronJob(request *restful.Request, response *restful.Response) {
+	apiHandler.handleSetCronJobSuspend(request, response, false)
+}
+
+func (apiHandler *APIHandler) handleSetCronJobSuspend(request *restful.Request,
+	response *restful.Response, suspend bool) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := cronjob.SetCronJobSuspend(k8sClient, namespace, name, suspend)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleCordonNode(request *restful.Request, response *restful.Response) {
+	apiHandler.handleSetNodeUnschedulable(request, response, true)
+}
+
+func (apiHandler *APIHandler) handleUncordonNode(request *restful.Request, response *restful.Response) {
+	apiHandler.handleSetNodeUnschedulable(request, response, false)
+}
+
+func (apiHandler *APIHandler) handleSetNodeUnschedulable(request *restful.Request,
+	response *restful.Response, unschedulable bool) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	result, err := node.CordonNode(k8sClient, name, unschedulable)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleDrainNode(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	options := new(node.DrainOptions)
+	if err := request.ReadEntity(options); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	session, err := newWatchSession()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	go WaitForDrain(k8sClient, request.PathParameter("name"), options, session)
+	response.WriteHeaderAndEntity(http.StatusOK, WatchResponse{Id: session.id})
+}
+
+func (apiHandler *APIHandler) handleEvictPod(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	options := new(pod.PodDeleteOptions)
+	if err := request.ReadEntity(options); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("pod")
+	if err := pod.EvictPod(k8sClient, namespace, name, options); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+func (apiHandler *APIHandler) handleDeletePods(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(pod.PodBatchDeleteSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := pod.DeletePods(k8sClient, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleCreateHorizontalPodAutoscaler(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(horizontalpodautoscaler.HorizontalPodAutoscalerSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := horizontalpodautoscaler.CreateHorizontalPodAutoscaler(k8sClient, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleUpdateHorizontalPodAutoscaler(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(horizontalpodautoscaler.HorizontalPodAutoscalerSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("horizontalpodautoscaler")
+	result, err := horizontalpodautoscaler.UpdateHorizontalPodAutoscaler(k8sClient, namespace, name,
+		spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetPodDisruptionBudgetList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := pdb.GetPodDisruptionBudgetList(k8sClient, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetPodDisruptionBudgetDetail(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := pdb.GetPodDisruptionBudgetDetail(k8sClient, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetResourceQuotaList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := resourcequota.GetResourceQuotaList(k8sClient, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetResourceQuotaDetail(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := resourcequota.GetResourceQuotaDetail(k8sClient, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetLimitRangeList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := limitrange.GetLimitRangeList(k8sClient, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetLimitRangeDetail(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := limitrange.GetLimitRangeDetail(k8sClient, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNetworkPolicyList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := networkpolicy.GetNetworkPolicyList(k8sClient, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNetworkPolicyDetail(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := networkpolicy.GetNetworkPolicyDetail(k8sClient, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNamespaceNetworkGraph(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	result, err := networkpolicy.GetNamespaceNetworkGraph(k8sClient, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetPodNetworkGraph(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("pod")
+	result, err := networkpolicy.GetPodNetworkGraph(k8sClient, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// handlePodPortForward creates a port forward session to the pod port. The connection is forwarded
+// once the client binds the session over SockJS.
+func (apiHandler *APIHandler) handlePodPortForward(request *restful.Request, response *restful.Response) {
+	port, err := parsePortForwardPort(request.PathParameter("port"))
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	apiHandler.startPortForward(request, response, request.PathParameter("pod"), port)
+}
+
+// handleServicePortForward creates a port forward session to a ready pod that backs the service
+// port.
+func (apiHandler *APIHandler) handleServicePortForward(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	port, err := parsePortForwardPort(request.PathParameter("port"))
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("service")
+	target, err := resourceService.GetPortForwardTarget(k8sClient, namespace, name, port)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	apiHandler.startPortForward(request, response, target.Pod, target.Port)
+}
+
+// startPortForward registers a port forward session to the pod port and sends its id to the client.
+func (apiHandler *APIHandler) startPortForward(request *restful.Request, response *restful.Response,
+	podName string, port int32) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	session, err := newPortForwardSession()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	go WaitForPortForward(k8sClient, cfg, request.PathParameter("namespace"), podName, port, session)
+	response.WriteHeaderAndEntity(http.StatusOK, PortForwardResponse{Id: session.id, Pod: podName, Port: port})
+}
+
+func (apiHandler *APIHandler) handleGetAggregatedEvents(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	kind := request.PathParameter("kind")
+	name := request.PathParameter("name")
+	result, err := event.GetAggregatedEvents(k8sClient, namespace, kind, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleEventStream(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	session, err := newWatchSession()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	filter := event.EventStreamFilter{
+		Type:   request.QueryParameter("type"),
+		Kind:   request.QueryParameter("kind"),
+		Reason: request.QueryParameter("reason"),
+	}
+	go WaitForEventStream(k8sClient, request, filter, session)
+	response.WriteHeaderAndEntity(http.StatusOK, WatchResponse{Id: session.id})
+}
+
+func (apiHandler *APIHandler) handleAccessReview(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(accessreview.AccessReviewSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := accessreview.ReviewAccess(k8sClient, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetAccessibleNamespaces(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	// Client of Dashboard lists namespaces if the user is not allowed to.
+	dashboardClient, err := apiHandler.cManager.Client(nil)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := ns.GetAccessibleNamespaces(k8sClient, dashboardClient,
+		request.QueryParameter("resource"))
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// handleRevealSecretData returns values of the secret. Every attempt is logged with the identity of
+// the user for audit purposes.
+func (apiHandler *APIHandler) handleRevealSecretData(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	user := getRequestUser(request)
+	result, err := secret.RevealSecretData(k8sClient, namespace, name)
+	if err != nil {
+		logger.Errorf("Audit: revealing values of secret %s/%s to user %q failed: %s", namespace, name,
+			user, err)
+		handleInternalError(response, err)
+		return
+	}
+
+	logger.Infof("Audit: values of secret %s/%s revealed to user %q", namespace, name, user)
+	response.AddHeader("Cache-Control", "no-store")
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetClusterList(request *restful.Request, response *restful.Response) {
+	response.WriteHeaderAndEntity(http.StatusOK, apiHandler.cManager.Clusters())
+}
+
+func (apiHandler *APIHandler) handleGetGlobalSettings(request *restful.Request, response *restful.Response) {
+	response.WriteHeaderAndEntity(http.StatusOK, apiHandler.sManager.GetGlobalSettings())
+}
+
+func (apiHandler *APIHandler) handleSaveGlobalSettings(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	globalSettings := new(settings.Settings)
+	if err := request.ReadEntity(globalSettings); err != nil {
+		handleInternalError(response, errorsK8s.NewBadRequest(err.Error()))
+		return
+	}
+
+	if err := apiHandler.sManager.SaveGlobalSettings(k8sClient, *globalSettings); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, apiHandler.sManager.GetGlobalSettings())
+}
+
+// handleGetUserSettings returns settings of the user. Users, whose name can not be verified, get
+// default settings.
+func (apiHandler *APIHandler) handleGetUserSettings(request *restful.Request, response *restful.Response) {
+	dashboardClient, err := apiHandler.cManager.Client(nil)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	user, err := getSettingsUser(request, dashboardClient)
+	if err != nil {
+		logger.Warningf("Could not verify name of the user, returning default settings: %s", err)
+		user = ""
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, apiHandler.sManager.GetUserSettings(user))
+}
+
+// handleSaveUserSettings saves settings of the user. Settings are saved with Dashboard's own client,
+// so credentials of the user are verified by the apiserver first, as the user name is not.
+func (apiHandler *APIHandler) handleSaveUserSettings(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	userSettings := new(settings.UserSettings)
+	if err := request.ReadEntity(userSettings); err != nil {
+		handleInternalError(response, errorsK8s.NewBadRequest(err.Error()))
+		return
+	}
+
+	dashboardClient, err := apiHandler.cManager.Client(nil)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	user, err := getSettingsUser(request, dashboardClient)
+	if err != nil {
+		handleInternalError(response, errorsK8s.NewForbidden(settingsResource, "",
+			fmt.Errorf("name of the user could not be verified: %s", err)))
+		return
+	}
+	if user != "" {
+		_, err = k8sClient.AuthorizationV1().SelfSubjectAccessReviews().Create(
+			&authorization.SelfSubjectAccessReview{
+				Spec: authorization.SelfSubjectAccessReviewSpec{
+					NonResourceAttributes: &authorization.NonResourceAttributes{
+						Path: "/api",
+						Verb: "get",
+					},
+				},
+			})
+		if err != nil {
+			handleInternalError(response, err)
+			return
+		}
+	}
+
+	if err := apiHandler.sManager.SaveUserSettings(user, *userSettings); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, apiHandler.sManager.GetUserSettings(user))
+}
+
+// settingsResource is used in errors about settings.
+var settingsResource = schema.GroupResource{Resource: "settings"}
+
+// getSettingsUser returns name of the user, that settings of the request belong to. Users
+// authenticated with bearer tokens without claims are named by the apiserver, which reviews the
+// token with the client of Dashboard, as all of them would share settings otherwise.
+func getSettingsUser(request *restful.Request, dashboardClient kubernetes.Interface) (string,
+	error) {
+	user := getRequestUser(request)
+	if user != bearerTokenUser {
+		return user, nil
+	}
+
+	token := strings.TrimPrefix(request.HeaderParameter("Authorization"), "Bearer ")
+	review, err := dashboardClient.AuthenticationV1().TokenReviews().Create(
+		&authentication.TokenReview{Spec: authentication.TokenReviewSpec{Token: token}})
+	if err != nil {
+		return "", err
+	}
+	if !review.Status.Authenticated || review.Status.User.Username == "" {
+		return "", fmt.Errorf("token is not authenticated: %s", review.Status.Error)
+	}
+	return review.Status.User.Username, nil
+}
+
+func (apiHandler *APIHandler) handleGetHelmReleaseList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := helm.GetReleaseList(k8sClient, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetHelmReleaseDetail(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := helm.GetReleaseDetail(k8sClient, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleRollbackHelmRelease(request *restful.Request, response *restful.Response) {
+	revision := 0
+	if value := request.QueryParameter("revision"); len(value) > 0 {
+		var err error
+		revision, err = strconv.Atoi(value)
+		if err != nil || revision < 0 {
+			handleInternalError(response, errorsK8s.NewBadRequest("revision must be a non-negative integer"))
+			return
+		}
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := helm.RollbackRelease(k8sClient, cfg, namespace, name, revision)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleUpgradeHelmRelease(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cmdConfig, err := apiHandler.cManager.ClientCmdConfig(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	kubeConfig, err := cmdConfig.RawConfig()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(helm.UpgradeSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, errorsK8s.NewBadRequest(err.Error()))
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := helm.UpgradeRelease(k8sClient, kubeConfig, apiHandler.getHelmRepositories(),
+		namespace, name, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleUninstallHelmRelease(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := helm.UninstallRelease(k8sClient, cfg, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetHelmChartList(request *restful.Request, response *restful.Response) {
+	query := request.QueryParameter("query")
+	result := helm.GetChartList(apiHandler.getHelmRepositories(), query)
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetHelmChartDetail(request *restful.Request, response *restful.Response) {
+	repository := request.PathParameter("repository")
+	chart := request.PathParameter("chart")
+	version := request.QueryParameter("version")
+	result, err := helm.GetChartDetail(apiHandler.getHelmRepositories(), repository, chart, version)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleInstallHelmChart(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cmdConfig, err := apiHandler.cManager.ClientCmdConfig(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	kubeConfig, err := cmdConfig.RawConfig()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(helm.InstallSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, errorsK8s.NewBadRequest(err.Error()))
+		return
+	}
+
+	repository := request.PathParameter("repository")
+	chart := request.PathParameter("chart")
+	result, err := helm.InstallChart(k8sClient, kubeConfig, apiHandler.getHelmRepositories(),
+		repository, chart, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleGetHelmRepositoryList(request *restful.Request, response *restful.Response) {
+	response.WriteHeaderAndEntity(http.StatusOK,
+		helm.RepositoryList{Repositories: apiHandler.getHelmRepositories()})
+}
+
+// handleSaveHelmRepositoryList saves repositories added in Dashboard to global settings. Built-in
+// repositories in the list are skipped.
+func (apiHandler *APIHandler) handleSaveHelmRepositoryList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	list := new(helm.RepositoryList)
+	if err := request.ReadEntity(list); err != nil {
+		handleInternalError(response, errorsK8s.NewBadRequest(err.Error()))
+		return
+	}
+
+	globalSettings := apiHandler.sManager.GetGlobalSettings()
+	globalSettings.ChartRepositories = make([]settings.ChartRepository, 0, len(list.Repositories))
+	for _, repo := range list.Repositories {
+		if !repo.BuiltIn {
+			globalSettings.ChartRepositories = append(globalSettings.ChartRepositories,
+				settings.ChartRepository{Name: repo.Name, URL: strings.TrimSuffix(repo.URL, "/")})
+		}
+	}
+
+	if err := apiHandler.sManager.SaveGlobalSettings(k8sClient, globalSettings); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK,
+		helm.RepositoryList{Repositories: apiHandler.getHelmRepositories()})
+}
+
+// getHelmRepositories returns built-in chart repositories followed by repositories added in
+// Dashboard and saved in global settings.
+func (apiHandler *APIHandler) getHelmRepositories() []helm.Repository {
+	repos := append([]helm.Repository{}, helm.Repositories()...)
+	if apiHandler.sManager == nil {
+		return repos
+	}
+
+	for _, repo := range apiHandler.sManager.GetGlobalSettings().ChartRepositories {
+		repos = append(repos, helm.Repository{Name: repo.Name, URL: repo.URL})
+	}
+	return repos
+}
+
+func (apiHandler *APIHandler) handleGetRelatedObjects(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	kind := api.ResourceKind(request.PathParameter("kind"))
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := graph.GetRelatedObjects(k8sClient, kind, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleBulkEditMetadata(request *restful.Request, response *restful.Response) {
+	verber, err := apiHandler.cManager.VerberClient(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(bulkedit.MetadataEditSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	result, err := bulkedit.EditMetadata(&verber, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNamespaceTermination(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	result, err := ns.GetNamespaceTermination(k8sClient, cfg, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleClearNamespaceFinalizers(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	result, err := ns.ClearFinalizers(k8sClient, cfg, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleResizePersistentVolumeClaim(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	spec := new(persistentvolumeclaim.PersistentVolumeClaimResizeSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	result, err := persistentvolumeclaim.ResizePersistentVolumeClaim(k8sClient, namespace, name, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetVolumeSnapshotList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := persistentvolumeclaim.GetVolumeSnapshotList(cfg, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleCreateVolumeSnapshot(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	spec := new(persistentvolumeclaim.VolumeSnapshotSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	result, err := persistentvolumeclaim.CreateVolumeSnapshot(k8sClient, cfg, namespace, name, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleRestoreVolumeSnapshot(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	spec := new(persistentvolumeclaim.VolumeSnapshotRestoreSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	result, err := persistentvolumeclaim.RestoreVolumeSnapshot(k8sClient, cfg, namespace, name, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleGetObjectUsage(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	kind := api.ResourceKind(request.PathParameter("kind"))
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := graph.GetObjectUsage(k8sClient, kind, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleRestartObjectUsers(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	kind := api.ResourceKind(request.PathParameter("kind"))
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	spec := new(restart.RestartSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	result, err := restart.RestartUsers(k8sClient, kind, namespace, name, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGenerateKubeConfig(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	spec := new(serviceaccount.KubeConfigSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	result, err := serviceaccount.GenerateKubeConfig(k8sClient, cfg, namespace, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleGetSubjectPermissions(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	subject := rbacpermissions.Subject{
+		Kind:      request.PathParameter("kind"),
+		Name:      request.PathParameter("name"),
+		Namespace: request.QueryParameter("namespace"),
+	}
+	result, err := rbacpermissions.GetSubjectPermissions(k8sClient, subject)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetWhoCan(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	query := rbacpermissions.WhoCanQuery{
+		Verb:         request.PathParameter("verb"),
+		Resource:     request.PathParameter("resource"),
+		APIGroup:     request.QueryParameter("apiGroup"),
+		ResourceName: request.QueryParameter("resourceName"),
+		Namespace:    request.QueryParameter("namespace"),
+	}
+	result, err := rbacpermissions.GetWhoCan(k8sClient, query)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleCreateRbacRole(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(rbacroles.RbacRoleSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	result, err := rbacroles.CreateRbacRole(k8sClient, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleUpdateRbacRole(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(rbacroles.RbacRoleSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	result, err := rbacroles.UpdateRbacRole(k8sClient, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleDeleteRbacRole(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	// Namespace is empty for cluster roles.
+	err = rbacroles.DeleteRbacRole(k8sClient, request.PathParameter("namespace"),
+		request.PathParameter("name"))
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+func (apiHandler *APIHandler) handleCreateRbacRoleBinding(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(rbacrolebindings.RbacRoleBindingSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	result, err := rbacrolebindings.CreateRbacRoleBinding(k8sClient, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleUpdateRbacRoleBinding(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(rbacrolebindings.RbacRoleBindingSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	result, err := rbacrolebindings.UpdateRbacRoleBinding(k8sClient, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleDeleteRbacRoleBinding(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	// Namespace is empty for cluster role bindings.
+	err = rbacrolebindings.DeleteRbacRoleBinding(k8sClient, request.PathParameter("namespace"),
+		request.PathParameter("name"))
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+func (apiHandler *APIHandler) handleGetWorkloadDiagnosis(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	kind := api.ResourceKind(request.PathParameter("kind"))
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := diagnosis.GetWorkloadDiagnosis(k8sClient, kind, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetOverview(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := overview.GetOverview(k8sClient)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetCapacityReport(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := capacity.GetCapacityReport(k8sClient, apiHandler.iManager.Metric().Client())
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleAddNodeTaint(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	taint := new(v1.Taint)
+	if err := request.ReadEntity(taint); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := node.AddNodeTaint(k8sClient, request.PathParameter("name"), *taint)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleRemoveNodeTaint(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := node.RemoveNodeTaint(k8sClient, request.PathParameter("name"),
+		request.PathParameter("key"), v1.TaintEffect(request.QueryParameter("effect")))
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetImageList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	result, err := image.GetImageList(k8sClient, apiHandler.iManager.Scanner().Client(), namespace)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleCreateRegistryCredentials(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(secret.RegistryCredentialSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := secret.CreateRegistryCredentials(k8sClient, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleTestRegistryCredentials(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(secret.RegistryCredentialSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := secret.TestRegistryCredentials(k8sClient, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleAttachImagePullSecret(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	target := new(secret.ImagePullSecretTarget)
+	if err := request.ReadEntity(target); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	err = secret.AttachImagePullSecret(k8sClient, request.PathParameter("namespace"),
+		request.PathParameter("name"), *target)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+// SetImageResponse is sent by handleSetImage. Id of the watch session streaming rollout progress
+// is set when the rollout is watched.
+type SetImageResponse struct {
+	rollout.SetImageResult
+	Id string `json:"id,omitempty"`
+}
+
+func (apiHandler *APIHandler) handleSetImage(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(rollout.SetImageSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	kind := api.ResourceKind(request.PathParameter("kind"))
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := rollout.SetImage(k8sClient, kind, namespace, name, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	setImageResponse := SetImageResponse{SetImageResult: *result}
+	if spec.Watch && result.Changed && rollout.RollsOut(kind) {
+		session, err := newWatchSession()
+		if err != nil {
+			handleInternalError(response, err)
+			return
+		}
+		setImageResponse.Id = session.id
+		go WaitForRollout(k8sClient, kind, namespace, name, session)
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, setImageResponse)
+}
+
+func (apiHandler *APIHandler) handleGetRolloutProgress(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := rollout.GetRolloutProgress(k8sClient, api.ResourceKind(request.PathParameter("kind")),
+		request.PathParameter("namespace"), request.PathParameter("name"))
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetJobFailedPodLogs(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	logFilter, err := parseLogFilter(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("job")
+	result, err := job.GetFailedPodLogs(k8sClient, namespace, name, logs.DefaultSelection, logFilter)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleRecreateJob(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("job")
+	result, err := job.RecreateJob(k8sClient, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleGetStatefulSetOrdinals(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("statefulset")
+	result, err := statefulset.GetStatefulSetOrdinals(k8sClient, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleDeleteStatefulSetPod(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	ordinal, err := strconv.Atoi(request.PathParameter("ordinal"))
+	if err != nil || ordinal < 0 {
+		handleInternalError(response, errorsK8s.NewBadRequest("ordinal must be a non-negative integer"))
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("statefulset")
+	if err := statefulset.DeleteStatefulSetPod(k8sClient, namespace, name, ordinal); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+func (apiHandler *APIHandler) handleSetStatefulSetPartition(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(statefulset.PartitionSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("statefulset")
+	result, err := statefulset.SetStatefulSetPartition(k8sClient, namespace, name, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleScaleDownStatefulSet(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(statefulset.ScaleDownSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("statefulset")
+	result, err := statefulset.ScaleDownStatefulSet(k8sClient, namespace, name, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	if len(result.DeletedClaims) > 0 {
+		go func() {
+			err := statefulset.DeleteScaledDownClaims(k8sClient, namespace, name, result.Replicas,
+				result.DeletedClaims)
+			if err != nil {
+				logger.Errorf("Error while deleting claims of scaled down stateful set %s: %v", name, err)
+			}
+		}()
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetServiceEndpointSlices(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("service")
+	result, err := resourceService.GetServiceEndpointSlices(k8sClient, cfg, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleProbeService(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	var port int64
+	if portParam := request.QueryParameter("port"); len(portParam) > 0 {
+		port, err = strconv.ParseInt(portParam, 10, 32)
+		if err != nil || port <= 0 {
+			handleInternalError(response, errorsK8s.NewBadRequest("port must be a positive integer"))
+			return
+		}
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("service")
+	result, err := resourceService.ProbeService(k8sClient, cfg, namespace, name, int32(port))
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleDNSLookup(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(dns.DNSLookupSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := dns.Lookup(k8sClient, cfg, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetAdmissionWebhookConfigurationList(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := admissionwebhook.GetAdmissionWebhookConfigurationList(k8sClient, cfg, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetAdmissionWebhookConfigurationDetail(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	webhookType, err := admissionwebhook.ParseWebhookType(request.PathParameter("type"))
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	result, err := admissionwebhook.GetAdmissionWebhookConfigurationDetail(k8sClient, cfg, webhookType, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetAPIResourceList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	query, err := parseAPIResourceQuery(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := apiresource.GetAPIResourceList(k8sClient.Discovery(), query)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// parseAPIResourceQuery parses filters of API resource list, i.e. group, namespaced, comma separated
+// verbs, allVersions and refresh query parameters.
+func parseAPIResourceQuery(request *restful.Request) (*apiresource.APIResourceQuery, error) {
+	query := &apiresource.APIResourceQuery{Group: request.QueryParameter("group")}
+
+	if verbs := request.QueryParameter("verbs"); len(verbs) > 0 {
+		for _, verb := range strings.Split(verbs, ",") {
+			query.Verbs = append(query.Verbs, strings.TrimSpace(verb))
+		}
+	}
+
+	for name, value := range map[string]*bool{"allVersions": &query.AllVersions, "refresh": &query.Refresh} {
+		if param := request.QueryParameter(name); len(param) > 0 {
+			parsed, err := strconv.ParseBool(param)
+			if err != nil {
+				return nil, errorsK8s.NewBadRequest(fmt.Sprintf("%s must be a boolean", name))
+			}
+			*value = parsed
+		}
+	}
+
+	if param := request.QueryParameter("namespaced"); len(param) > 0 {
+		namespaced, err := strconv.ParseBool(param)
+		if err != nil {
+			return nil, errorsK8s.NewBadRequest("namespaced must be a boolean")
+		}
+		query.Namespaced = &namespaced
+	}
+	return query, nil
+}
+
+func (apiHandler *APIHandler) handleGetGenericResourceList(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := genericresource.GetGenericResourceList(k8sClient.Discovery(), cfg,
+		parseResourceRef(request), namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetGenericResourceDetail(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := genericresource.GetGenericResourceDetail(k8sClient.Discovery(), cfg,
+		parseResourceRef(request), namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	result.GitOps = apiHandler.getGitOpsOwnership(request, result.Resource.Group, result.Resource.Kind,
+		result.ObjectMeta)
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleUpdateGenericResource(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(genericresource.GenericResourceSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := genericresource.UpdateGenericResource(k8sClient.Discovery(), cfg,
+		parseResourceRef(request), namespace, name, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	updated := &unstructured.Unstructured{Object: result.Object}
+	recordSnapshot(k8sClient, request, updated)
+	apiHandler.warnOnGitOpsEdit(request, response, updated)
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleDeleteGenericResource(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	if err := genericresource.DeleteGenericResource(k8sClient.Discovery(), cfg, parseResourceRef(request),
+		namespace, name); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+// parseResourceRef parses group, version and resource path parameters of generic resource
+// endpoints. Core API group is selected by "core".
+func parseResourceRef(request *restful.Request) genericresource.ResourceRef {
+	return genericresource.ResourceRef{
+		Group:    request.PathParameter("group"),
+		Version:  request.PathParameter("version"),
+		Resource: request.PathParameter("resource"),
+	}
+}
+
+// getGenericResourceKind returns kind of the resource of generic resource endpoints, i.e. secret
+// for secrets, so that policy rules and audit entries of generic and dedicated endpoints match.
+// Resources without dedicated endpoints are returned as they are.
+func getGenericResourceKind(resource string) string {
+	for kind, spec := range api.KindToAPIMapping {
+		if spec.Resource == resource {
+			return kind
+		}
+	}
+	return resource
+}
+
+func (apiHandler *APIHandler) handleExport(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(export.ExportSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	bundle, err := export.Export(k8sClient.Discovery(), cfg, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	response.AddHeader("Content-Type", "application/x-yaml; charset=utf-8")
+	response.AddHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", bundle.FileName))
+	response.WriteHeader(http.StatusOK)
+	if _, err := response.Write(bundle.Content); err != nil {
+		logger.Errorf("Error while sending export bundle %s: %v", bundle.FileName, err)
+	}
+}
+
+func (apiHandler *APIHandler) handleCloneNamespace(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(ns.NamespaceCloneSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	result, err := ns.CloneNamespace(k8sClient, k8sClient.Discovery(), cfg, name, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleGetObjectHistory(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := history.GetObjectHistory(k8sClient, cfg, parseResourceRef(request), namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// recordSnapshot stores snapshot of the edited object for its change history. Failures are only
+// logged, as the edit itself succeeded.
+func recordSnapshot(client kubernetes.Interface, request *restful.Request, obj *unstructured.Unstructured) {
+	if err := history.RecordSnapshot(client, obj, getRequestUser(request)); err != nil {
+		logger.Errorf("Failed to record snapshot of %s %s: %s", obj.GetKind(), obj.GetName(), err)
+	}
+}
+
+// getGitOpsOwnership returns GitOps tool managing the object. Ownership is optional in detail
+// responses, so failures are only logged.
+func (apiHandler *APIHandler) getGitOpsOwnership(request *restful.Request, group, kind string,
+	meta api.ObjectMeta) *gitops.Ownership {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		logger.Errorf("Failed to get GitOps ownership of %s %s: %s", kind, meta.Name, err)
+		return nil
+	}
+
+	ownership, err := gitops.GetOwnership(cfg, gitops.ObjectRef{
+		Group:       group,
+		Kind:        kind,
+		Namespace:   meta.Namespace,
+		Name:        meta.Name,
+		Labels:      meta.Labels,
+		Annotations: meta.Annotations,
+	})
+	if err != nil {
+		logger.Errorf("Failed to get GitOps ownership of %s %s: %s", kind, meta.Name, err)
+	}
+	return ownership
+}
+
+// warnOnGitOpsEdit adds warning to the response, when the edited object is managed by a GitOps tool
+// and such warnings are enabled in settings. Warning is added as a Warning header, the same as
+// Kubernetes API server does.
+func (apiHandler *APIHandler) warnOnGitOpsEdit(request *restful.Request, response *restful.Response,
+	obj *unstructured.Unstructured) {
+	if !apiHandler.sManager.GetGlobalSettings().GitOpsEditWarning {
+		return
+	}
+
+	gvk := obj.GroupVersionKind()
+	ownership := apiHandler.getGitOpsOwnership(request, gvk.Group, gvk.Kind, api.ObjectMeta{
+		Namespace:   obj.GetNamespace(),
+		Name:        obj.GetName(),
+		Labels:      obj.GetLabels(),
+		Annotations: obj.GetAnnotations(),
+	})
+	if ownership != nil {
+		response.AddHeader("Warning", fmt.Sprintf("299 - %q", ownership.EditWarning()))
+	}
+}
+
+func (apiHandler *APIHandler) handleGetLintReport(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	result, err := lint.GetLintReport(k8sClient, namespace)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleLintManifests(request *restful.Request, response *restful.Response) {
+	spec := new(lint.LintSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := lint.LintManifests(spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleEvaluatePodSecurity(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	level, err := podsecurity.ParseLevel(request.QueryParameter("level"))
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	result, err := podsecurity.EvaluateNamespace(k8sClient, cfg, namespace, level)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetCertificateList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	warningDays := apiHandler.sManager.GetGlobalSettings().CertificateExpiryWarningDays
+	if warningDays == 0 {
+		warningDays = settings.DefaultCertificateExpiryWarningDays
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := certificate.GetCertificateList(k8sClient, namespace, dataSelect, warningDays)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetCertManagerStatus(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := certmanager.GetStatus(k8sClient.Discovery())
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetCertManagerCertificateList(request *restful.Request,
+	response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := certmanager.GetCertificateList(cfg, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetCertManagerCertificateDetail(request *restful.Request,
+	response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := certmanager.GetCertificateDetail(cfg, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleRenewCertManagerCertificate(request *restful.Request,
+	response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := certmanager.RenewCertificate(cfg, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetCertManagerIssuerList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := certmanager.GetIssuerList(cfg, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetCertManagerCertificateRequestList(request *restful.Request,
+	response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := certmanager.GetCertificateRequestList(cfg, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetServiceMeshStatus(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := servicemesh.GetStatus(k8sClient.Discovery())
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetVirtualServiceList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := servicemesh.GetVirtualServiceList(cfg, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetDestinationRuleList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := servicemesh.GetDestinationRuleList(cfg, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetPeerAuthenticationList(request *restful.Request,
+	response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := servicemesh.GetPeerAuthenticationList(cfg, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetServiceProfileList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := servicemesh.GetServiceProfileList(cfg, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetVeleroStatus(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := velero.GetStatus(k8sClient.Discovery(), cfg)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetVeleroBackupList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := velero.GetBackupList(cfg, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetVeleroBackupDetail(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := velero.GetBackupDetail(cfg, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleCreateVeleroBackup(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(velero.BackupSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	result, err := velero.CreateBackup(cfg, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleRestoreVeleroBackup(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	spec := new(velero.RestoreSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	result, err := velero.RestoreBackup(cfg, namespace, name, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
+}
+
+func (apiHandler *APIHandler) handleGetVeleroScheduleList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := velero.GetScheduleList(cfg, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetVeleroRestoreList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := velero.GetRestoreList(cfg, namespace, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetClusterAutoscalerStatus(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := clusterautoscaler.GetStatus(k8sClient, request.QueryParameter("namespace"))
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetPriorityClassList(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	dataSelect := parseDataSelectPathParameter(request)
+	result, err := priorityclass.GetPriorityClassList(cfg, dataSelect)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetPriorityClassDetail(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("priorityclass")
+	result, err := priorityclass.GetPriorityClassDetail(cfg, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// handleExplainPodScheduling evaluates scheduling predicates of the pod against current nodes.
+func (apiHandler *APIHandler) handleExplainPodScheduling(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("pod")
+	result, err := scheduling.ExplainPodScheduling(k8sClient, namespace, name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// handleGetRecommendation returns recommended resources of containers of the workload. Optional
+// cpuPercentile and memoryPercentile query parameters override percentiles of usage used for
+// requests.
+func (apiHandler *APIHandler) handleGetRecommendation(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	options := recommendation.Options{}
+	for parameter, value := range map[string]*float64{
+		"cpuPercentile":    &options.CPUPercentile,
+		"memoryPercentile": &options.MemoryPercentile,
+	} {
+		if query := request.QueryParameter(parameter); len(query) > 0 {
+			if *value, err = strconv.ParseFloat(query, 64); err != nil {
+				handleInternalError(response, errorsK8s.NewBadRequest(parameter+" must be a number"))
+				return
+			}
+		}
+	}
+
+	kind := api.ResourceKind(request.PathParameter("kind"))
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := recommendation.GetRecommendation(k8sClient, cfg, apiHandler.iManager.Metric().Client(), kind,
+		namespace, name, options)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// handleApplyRecommendation sets requests and limits of containers of the workload.
+func (apiHandler *APIHandler) handleApplyRecommendation(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(recommendation.ApplySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	kind := api.ResourceKind(request.PathParameter("kind"))
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := recommendation.ApplyRecommendation(k8sClient, kind, namespace, name, spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// handleGetCostReport returns estimated monthly cost of namespaces and workloads with prices
+// configured in global settings.
+func (apiHandler *APIHandler) handleGetCostReport(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	model := cost.NewPriceModel(apiHandler.sManager.GetGlobalSettings().CostModel)
+	result, err := cost.GetCostReport(k8sClient, apiHandler.iManager.Metric().Client(), model, namespace)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// handleGetQuotaTrend returns utilization of CPU and memory quotas of the namespace over time.
+func (apiHandler *APIHandler) handleGetQuotaTrend(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	result, err := resourcequota.GetQuotaTrend(k8sClient, apiHandler.iManager.Metric().Client(), name)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// handleGetAlerts returns alerts raised by alert rules in the namespaces, so that UI can poll them.
+// Rules are evaluated with Dashboard's own client, so only alerts of objects the user can list are
+// returned.
+func (apiHandler *APIHandler) handleGetAlerts(request *restful.Request, response *restful.Response) {
+	manager, err := getAlertManager()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	result := alerting.FilterAlerts(k8sClient, manager.GetAlerts(namespace))
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetAlertRules(request *restful.Request, response *restful.Response) {
+	manager, err := getAlertManager()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, manager.GetRules())
+}
+
+// handleSaveAlertRule creates or replaces alert rule. Rules are saved with the client of the user, so
+// only users allowed to update the ConfigMap with rules can change them.
+func (apiHandler *APIHandler) handleSaveAlertRule(request *restful.Request, response *restful.Response) {
+	manager, err := getAlertManager()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	rule := new(alerting.Rule)
+	if err := request.ReadEntity(rule); err != nil {
+		handleInternalError(response, errorsK8s.NewBadRequest(err.Error()))
+		return
+	}
+	if name := request.PathParameter("name"); rule.Name != name {
+		handleInternalError(response, errorsK8s.NewBadRequest(fmt.Sprintf(
+			"rule name %q does not match %q", rule.Name, name)))
+		return
+	}
+
+	if err := manager.SaveRule(k8sClient, *rule); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, rule)
+}
+
+func (apiHandler *APIHandler) handleDeleteAlertRule(request *restful.Request, response *restful.Response) {
+	manager, err := getAlertManager()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	if err := manager.DeleteRule(k8sClient, request.PathParameter("name")); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+// handleGetNotificationChannels returns notification channels read with the client of the user, as
+// URLs of channels may contain secrets.
+func (apiHandler *APIHandler) handleGetNotificationChannels(request *restful.Request,
+	response *restful.Response) {
+	manager, err := getNotificationManager()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	result, err := manager.GetChannels(k8sClient)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleSaveNotificationChannel(request *restful.Request,
+	response *restful.Response) {
+	manager, err := getNotificationManager()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	channel := new(notification.Channel)
+	if err := request.ReadEntity(channel); err != nil {
+		handleInternalError(response, errorsK8s.NewBadRequest(err.Error()))
+		return
+	}
+	if name := request.PathParameter("name"); channel.Name != name {
+		handleInternalError(response, errorsK8s.NewBadRequest(fmt.Sprintf(
+			"channel name %q does not match %q", channel.Name, name)))
+		return
+	}
+
+	if err := manager.SaveChannel(k8sClient, *channel); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, channel)
+}
+
+func (apiHandler *APIHandler) handleDeleteNotificationChannel(request *restful.Request,
+	response *restful.Response) {
+	manager, err := getNotificationManager()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	if err := manager.DeleteChannel(k8sClient, request.PathParameter("name")); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+func (apiHandler *APIHandler) handleGetNotificationRoutes(request *restful.Request,
+	response *restful.Response) {
+	manager, err := getNotificationManager()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, manager.GetRoutes())
+}
+
+func (apiHandler *APIHandler) handleSaveNotificationRoute(request *restful.Request,
+	response *restful.Response) {
+	manager, err := getNotificationManager()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	route := new(notification.Route)
+	if err := request.ReadEntity(route); err != nil {
+		handleInternalError(response, errorsK8s.NewBadRequest(err.Error()))
+		return
+	}
+	if name := request.PathParameter("name"); route.Name != name {
+		handleInternalError(response, errorsK8s.NewBadRequest(fmt.Sprintf(
+			"route name %q does not match %q", route.Name, name)))
+		return
+	}
+
+	if err := manager.SaveRoute(k8sClient, *route); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, route)
+}
+
+func (apiHandler *APIHandler) handleDeleteNotificationRoute(request *restful.Request,
+	response *restful.Response) {
+	manager, err := getNotificationManager()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	if err := manager.DeleteRoute(k8sClient, request.PathParameter("name")); err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+// handleNotifyOnRollout sends notification when the rollout of the workload finishes, i.e. when
+// the user asks to be notified after changing the image.
+func (apiHandler *APIHandler) handleNotifyOnRollout(request *restful.Request, response *restful.Response) {
+	manager, err := getNotificationManager()
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	spec := new(notification.RolloutNotificationSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		handleInternalError(response, errorsK8s.NewBadRequest(err.Error()))
+		return
+	}
+
+	err = notification.NotifyOnRollout(k8sClient, manager, api.ResourceKind(request.PathParameter("kind")),
+		request.PathParameter("namespace"), request.PathParameter("name"), spec)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusAccepted)
+}
+
+// parseNamespacePathParameter parses namespace selector for list pages in path parameter.
+// The namespace selector is a comma separated list of namespaces that are trimmed.
+// No namespaces means "view all user namespaces", i.e., everything except kube-system.
+func parseNamespacePathParameter(request *restful.Request) *common.NamespaceQuery {
+	namespace := request.PathParameter("namespace")
+	namespaces := strings.Split(namespace, ",")
+	var nonEmptyNamespaces []string
+	for _, n := range namespaces {
+		n = strings.Trim(n, " ")
+		if len(n) > 0 {
+			nonEmptyNamespaces = append(nonEmptyNamespaces, n)
+		}
+	}
+	return common.NewNamespaceQuery(nonEmptyNamespaces)
+}
+
+func parsePaginationPathParameter(request *restful.Request) *dataselect.PaginationQuery {
+	itemsPerPage, err := strconv.ParseInt(request.QueryParameter("itemsPerPage"), 10, 0)
+	if err != nil {
+		return dataselect.NoPagination
+	}
+
+	page, err := strconv.ParseInt(request.QueryParameter("page"), 10, 0)
+	if err != nil {
+		return dataselect.NoPagination
+	}
+
+	// Frontend pages start from 1 and backend starts from 0
+	return dataselect.NewPaginationQuery(int(itemsPerPage), int(page-1))
+}
+
+// Parses limit and continue query parameters used to fetch lists from the apiserver in chunks.
+// Parameters are validated by chunkFilter.
+func parseChunkPathParameter(request *restful.Request) *dataselect.ChunkQuery {
+	limit, err := strconv.ParseInt(request.QueryParameter("limit"), 10, 64)
+	if err != nil || limit <= 0 {
+		return dataselect.NoChunk
+	}
+
+	return dataselect.NewChunkQuery(limit, request.QueryParameter("continue"))
+}
+
+// Parses labelSelector, fieldSelector and search query parameters. Requests with invalid selectors
+// are rejected by selectorFilter. Should they get here, they match nothing, so that unfiltered
+// list is not returned by mistake.
+func parseSelectorPathParameter(request *restful.Request) *dataselect.SelectorQuery {
+	selectorQuery, err := dataselect.NewSelectorQuery(request.QueryParameter("labelSelector"),
+		request.QueryParameter("fieldSelector"), request.QueryParameter("search"))
+	if err != nil {
+		logger.Errorf("Invalid selector: %v", err)
+		return &dataselect.SelectorQuery{
+			LabelSelector: labels.Nothing(),
+			FieldSelector: fields.Everything(),
+		}
+	}
+
+	return selectorQuery
+}
+
+func parseFilterPathParameter(request *restful.Request) *dataselect.FilterQuery {
+	return dataselect.NewFilterQuery(strings.Split(request.QueryParameter("filterBy"), ","))
+}
+
+// Parses query parameters of the request and returns a SortQuery object
+func parseSortPathParameter(request *restful.Request) *dataselect.SortQuery {
+	return dataselect.NewSortQuery(strings.Split(request.QueryParameter("sortBy"), ","))
+}
+
+// Parses query parameters of the request and returns a MetricQuery object
+func parseMetricPathParameter(request *restful.Request) *dataselect.MetricQuery {
+	metricNamesParam := request.QueryParameter("metricNames")
+	var metricNames []string
+	if metricNamesParam != "" {
+		metricNames = strings.Split(metricNamesParam, ",")
+	} else {
+		metricNames = nil
+	}
+	aggregationsParam := request.QueryParameter("aggregations")
+	var rawAggregations []string
+	if aggregationsParam != "" {
+		rawAggregations = strings.Split(aggregationsParam, ",")
+	} else {
+		rawAggregations = nil
+	}
+	aggregationModes := metricapi.AggregationModes{}
+	for _, e := range rawAggregations {
+		aggregationModes = append(aggregationModes, metricapi.AggregationMode(e))
+	}
+	return dataselect.NewMetricQuery(metricNames, aggregationModes)
+
+}
+
+// parseLogFilter parses query parameters of the request used for server-side log filtering:
+// search, searchRegexp, logLevel (comma separated), sinceTime and untilTime (RFC3339).
+func parseLogFilter(request *restful.Request) (*logs.LogFilter, error) {
+	var levels []logs.LogLevel
+	if logLevel := request.QueryParameter("logLevel"); logLevel != "" {
+		for _, level := range strings.Split(logLevel, ",") {
+			levels = append(levels, logs.LogLevel(strings.ToLower(strings.TrimSpace(level))))
+		}
+	}
+
+	sinceTime, err := parseTimeQueryParameter(request, "sinceTime")
+	if err != nil {
+		return nil, err
+	}
+
+	untilTime, err := parseTimeQueryParameter(request, "untilTime")
+	if err != nil {
+		return nil, err
+	}
+
+	logFilter, err := logs.NewLogFilter(request.QueryParameter("search"),
+		request.QueryParameter("searchRegexp") == "true", levels, sinceTime, untilTime)
+	if err != nil {
+		return nil, errorsK8s.NewBadRequest(err.Error())
+	}
+	return logFilter, nil
+}
+
+// parseTimeQueryParameter parses optional RFC3339 time from the query parameter with given name.
+func parseTimeQueryParameter(request *restful.Request, name string) (*time.Time, error) {
+	value := request.QueryParameter(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	result, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("invalid %s: %s", name, err.Error()))
+	}
+	return &result, nil
 }
 
 // Parses query parameters of the request and returns a DataSelectQuery object
@@ -2188,5 +6576,8 @@ func parseDataSelectPathParameter(request *restful.Request) *dataselect.DataSele
 	sortQuery := parseSortPathParameter(request)
 	filterQuery := parseFilterPathParameter(request)
 	metricQuery := parseMetricPathParameter(request)
-	return dataselect.NewDataSelectQuery(paginationQuery, sortQuery, filterQuery, metricQuery)
+	dataSelect := dataselect.NewDataSelectQuery(paginationQuery, sortQuery, filterQuery, metricQuery)
+	dataSelect.ChunkQuery = parseChunkPathParameter(request)
+	dataSelect.SelectorQuery = parseSelectorPathParameter(request)
+	return dataSelect
 }
@@ -23,42 +23,57 @@ import (
 	"strings"
 
 	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/auth"
 	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	authentication "k8s.io/client-go/pkg/apis/authentication/v1"
+	core "k8s.io/client-go/testing"
 )
 
 func TestCreateHTTPAPIHandler(t *testing.T) {
-	_, err := CreateHTTPAPIHandler(nil, client.NewClientManager("", "http://localhost:8080"))
+	_, err := CreateHTTPAPIHandler(nil, client.NewClientManager("", "http://localhost:8080"),
+		auth.NewAuthManager(nil, auth.OIDCOptions{}, auth.AuthProxyOptions{}, nil), nil, "/")
 	if err != nil {
 		t.Fatal("CreateHTTPAPIHandler() cannot create HTTP API handler")
 	}
 }
 
-func TestShouldDoCsrfValidation(t *testing.T) {
+func TestGetSettingsUser(t *testing.T) {
+	dashboardClient := fake.NewSimpleClientset()
+	dashboardClient.PrependReactor("create", "tokenreviews", func(action core.Action) (bool,
+		runtime.Object, error) {
+		review := action.(core.CreateAction).GetObject().(*authentication.TokenReview)
+		if review.Spec.Token == "alice-token" {
+			review.Status.Authenticated = true
+			review.Status.User.Username = "alice"
+		}
+		return true, review, nil
+	})
+
 	cases := []struct {
-		request  *restful.Request
-		expected bool
+		authorization string
+		expected      string
+		expectedError bool
 	}{
-		{
-			&restful.Request{
-				Request: &http.Request{
-					Method: "PUT",
-				},
-			},
-			false,
-		},
-		{
-			&restful.Request{
-				Request: &http.Request{
-					Method: "POST",
-				},
-			},
-			true,
-		},
+		{"", "", false},
+		{"Bearer " + newJWT(`{"sub":"bob"}`), "bob", false},
+		{"Bearer alice-token", "alice", false},
+		{"Bearer invalid-token", "", true},
 	}
 	for _, c := range cases {
-		actual := shouldDoCsrfValidation(c.request)
+		req, _ := http.NewRequest("GET", "/api/v1/settings/user", nil)
+		if c.authorization != "" {
+			req.Header.Set("Authorization", c.authorization)
+		}
+
+		actual, err := getSettingsUser(restful.NewRequest(req), dashboardClient)
+		if (err != nil) != c.expectedError {
+			t.Errorf("getSettingsUser(%s) returns error %v, expected error %t", c.authorization,
+				err, c.expectedError)
+		}
 		if actual != c.expected {
-			t.Errorf("shouldDoCsrfValidation(%#v) returns %#v, expected %#v", c.request, actual, c.expected)
+			t.Errorf("getSettingsUser(%s) == %q, expected %q", c.authorization, actual, c.expected)
 		}
 	}
 }
@@ -0,0 +1,129 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/audit"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+)
+
+const (
+	// maxAuditFieldDepth limits depth of field paths recorded in audit events.
+	maxAuditFieldDepth = 3
+	// maxAuditFields limits number of field paths recorded in audit events.
+	maxAuditFields = 50
+)
+
+// auditSink receives audit events of operations performed by users, when configured.
+var auditSink audit.Sink
+
+// ConfigureAudit sets sink that receives an audit event for every create, update, delete, exec and
+// port forward operation and secret reveal. Nil sink disables audit events.
+func ConfigureAudit(sink audit.Sink) {
+	auditSink = sink
+}
+
+// newAuditFilter creates filter that writes audit events of operations to the configured sink.
+// Summary of fields set in request body is recorded only if summarizePayload is true, as body of
+// some requests, i.e. proxied ones, does not have to be JSON.
+func newAuditFilter(summarizePayload bool) restful.FilterFunction {
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		if auditSink == nil {
+			chain.ProcessFilter(request, response)
+			return
+		}
+
+		attributes := getPolicyAttributes(request)
+		if !isAudited(request.Request.Method, request.SelectedRoutePath(), attributes.Verb) {
+			chain.ProcessFilter(request, response)
+			return
+		}
+
+		event := audit.Event{
+			Timestamp: time.Now().UTC(),
+			User:      attributes.User,
+			Groups:    attributes.Groups,
+			SourceIP:  getSourceIP(request.Request),
+			Verb:      attributes.Verb,
+			Resource:  attributes.Resource,
+			Namespace: attributes.Namespace,
+			Name:      attributes.Name,
+		}
+		if summarizePayload {
+			event.Fields = getPayloadFields(request)
+		}
+
+		chain.ProcessFilter(request, response)
+
+		event.Code = response.StatusCode()
+		event.Result = audit.ResultSuccess
+		if event.Code >= http.StatusBadRequest {
+			event.Result = audit.ResultFailure
+		}
+		if err := auditSink.Write(event); err != nil {
+			logger.Errorf("Failed to write audit event: %s", err)
+		}
+	}
+}
+
+// isAudited returns true for requests that change state of the cluster, give access to running
+// containers or reveal secrets.
+func isAudited(method, route, verb string) bool {
+	return verb == "reveal" || !isAllowedInReadOnlyMode(method, route)
+}
+
+// getSourceIP returns address of the client without port.
+func getSourceIP(request *http.Request) string {
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		return request.RemoteAddr
+	}
+	return host
+}
+
+// getPayloadFields returns sorted paths of fields set in JSON body of the request, i.e.
+// spec.replicas. Only maxAuditFieldDepth levels of nested objects are listed.
+func getPayloadFields(request *restful.Request) []string {
+	entity := make(map[string]interface{})
+	if err := request.ReadEntity(&entity); err != nil || len(entity) == 0 {
+		return nil
+	}
+
+	fields := make([]string, 0)
+	collectFields(entity, "", 1, &fields)
+	sort.Strings(fields)
+	if len(fields) > maxAuditFields {
+		fields = fields[:maxAuditFields]
+	}
+	return fields
+}
+
+func collectFields(object map[string]interface{}, prefix string, depth int, fields *[]string) {
+	for key, value := range object {
+		path := prefix + key
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 &&
+			depth < maxAuditFieldDepth {
+			collectFields(nested, path+".", depth+1, fields)
+			continue
+		}
+		*fields = append(*fields, path)
+	}
+}
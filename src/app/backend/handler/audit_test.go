@@ -0,0 +1,99 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/audit"
+)
+
+type testAuditSink struct {
+	events []audit.Event
+}
+
+func (self *testAuditSink) Write(event audit.Event) error {
+	self.events = append(self.events, event)
+	return nil
+}
+
+func TestAuditFilter(t *testing.T) {
+	defer ConfigureAudit(nil)
+
+	ws := new(restful.WebService)
+	ws.Filter(newAuditFilter(true))
+	ws.Path("/api/v1")
+	ws.Route(ws.GET("/deployment/{namespace}/{deployment}").To(
+		func(request *restful.Request, response *restful.Response) {
+			response.WriteHeader(http.StatusOK)
+		}))
+	ws.Route(ws.PUT("/_raw/{kind}/namespace/{namespace}/name/{name}").To(
+		func(request *restful.Request, response *restful.Response) {
+			response.WriteHeader(http.StatusForbidden)
+		}))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	cases := []struct {
+		method   string
+		path     string
+		body     string
+		expected []audit.Event
+	}{
+		{"GET", "/api/v1/deployment/default/nginx", "", nil},
+		{
+			"PUT", "/api/v1/_raw/deployment/namespace/default/name/nginx",
+			`{"metadata": {"labels": {"app": "nginx"}}, "spec": {"replicas": 3, "template": ` +
+				`{"spec": {"containers": []}}}}`,
+			[]audit.Event{{
+				User:      "jane",
+				SourceIP:  "192.0.2.1",
+				Verb:      "update",
+				Resource:  "deployment",
+				Namespace: "default",
+				Name:      "nginx",
+				Fields:    []string{"metadata.labels.app", "spec.replicas", "spec.template.spec"},
+				Code:      http.StatusForbidden,
+				Result:    audit.ResultFailure,
+			}},
+		},
+	}
+
+	for _, c := range cases {
+		sink := &testAuditSink{}
+		ConfigureAudit(sink)
+		req := httptest.NewRequest(c.method, c.path, strings.NewReader(c.body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Impersonate-User", "jane")
+		container.ServeHTTP(httptest.NewRecorder(), req)
+
+		for i := range sink.events {
+			if sink.events[i].Timestamp.IsZero() {
+				t.Errorf("%s %s wrote audit event without timestamp", c.method, c.path)
+			}
+			sink.events[i].Timestamp = time.Time{}
+		}
+		if !reflect.DeepEqual(sink.events, c.expected) {
+			t.Errorf("%s %s wrote audit events %#v, expected %#v", c.method, c.path, sink.events,
+				c.expected)
+		}
+	}
+}
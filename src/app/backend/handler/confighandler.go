@@ -16,10 +16,11 @@ package handler
 
 import (
 	"encoding/json"
-	"log"
 	"net/http"
 	"text/template"
 	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 )
 
 // AppHandler is an application handler.
@@ -29,6 +30,8 @@ type AppHandler func(http.ResponseWriter, *http.Request) (int, error)
 type AppConfig struct {
 	// ServerTime is current server time (milliseconds elapsed since 1 January 1970 00:00:00 UTC).
 	ServerTime int64 `json:"serverTime"`
+	// ReadOnly is true when Dashboard rejects all requests that change state of the cluster.
+	ReadOnly bool `json:"readOnly"`
 }
 
 const (
@@ -47,15 +50,16 @@ func (fn AppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 func getAppConfigJSON() string {
-	log.Println("Getting application global configuration")
+	logger.Info("Getting application global configuration")
 
 	config := &AppConfig{
 		// TODO(maciaszczykm): Get time from API server instead directly from backend.
 		ServerTime: time.Now().UTC().UnixNano() / 1e6,
+		ReadOnly:   readOnlyMode,
 	}
 
 	jsonConfig, _ := json.Marshal(config)
-	log.Printf("Application configuration %s", jsonConfig)
+	logger.Infof("Application configuration %s", jsonConfig)
 	return string(jsonConfig)
 }
 
@@ -16,16 +16,17 @@ package handler
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
-	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/emicklei/go-restful"
 	"github.com/kubernetes/dashboard/src/app/backend/client"
-	"golang.org/x/net/xsrftoken"
+	"github.com/kubernetes/dashboard/src/app/backend/csrf"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	utilnet "k8s.io/apimachinery/pkg/util/net"
 )
 
@@ -33,15 +34,113 @@ import (
 func InstallFilters(ws *restful.WebService, manager client.ClientManager) {
 	ws.Filter(requestAndResponseLogger)
 	ws.Filter(metricsFilter)
-	ws.Filter(validateXSRFFilter(manager.CSRFKey()))
+	ws.Filter(selectorFilter)
+	ws.Filter(chunkFilter)
+	ws.Filter(readOnlyFilter)
+	ws.Filter(policyFilter)
+	ws.Filter(newAuditFilter(true))
+	ws.Filter(csrf.NewFilter(csrf.NewTokenManager(manager.CSRFKey())))
+}
+
+// chunkedListRoutes are routes of lists of a single resource kind, that can be fetched from the
+// apiserver in chunks. Other lists, e.g. workloads or overview, aggregate many kinds.
+var chunkedListRoutes = map[string]bool{
+	"/api/v1/configmap":                         true,
+	"/api/v1/configmap/{namespace}":             true,
+	"/api/v1/cronjob":                           true,
+	"/api/v1/cronjob/{namespace}":               true,
+	"/api/v1/daemonset":                         true,
+	"/api/v1/daemonset/{namespace}":             true,
+	"/api/v1/deployment":                        true,
+	"/api/v1/deployment/{namespace}":            true,
+	"/api/v1/ingress":                           true,
+	"/api/v1/ingress/{namespace}":               true,
+	"/api/v1/job":                               true,
+	"/api/v1/job/{namespace}":                   true,
+	"/api/v1/limitrange":                        true,
+	"/api/v1/limitrange/{namespace}":            true,
+	"/api/v1/namespace":                         true,
+	"/api/v1/networkpolicy":                     true,
+	"/api/v1/networkpolicy/{namespace}":         true,
+	"/api/v1/node":                              true,
+	"/api/v1/persistentvolume":                  true,
+	"/api/v1/persistentvolumeclaim/":            true,
+	"/api/v1/persistentvolumeclaim/{namespace}": true,
+	"/api/v1/pod":                               true,
+	"/api/v1/pod/{namespace}":                   true,
+	"/api/v1/poddisruptionbudget":               true,
+	"/api/v1/poddisruptionbudget/{namespace}":   true,
+	"/api/v1/replicaset":                        true,
+	"/api/v1/replicaset/{namespace}":            true,
+	"/api/v1/replicationcontroller":             true,
+	"/api/v1/replicationcontroller/{namespace}": true,
+	"/api/v1/resourcequota":                     true,
+	"/api/v1/resourcequota/{namespace}":         true,
+	"/api/v1/secret":                            true,
+	"/api/v1/secret/{namespace}":                true,
+	"/api/v1/service":                           true,
+	"/api/v1/service/{namespace}":               true,
+	"/api/v1/statefulset":                       true,
+	"/api/v1/statefulset/{namespace}":           true,
+	"/api/v1/storageclass":                      true,
+}
+
+// chunkFilter rejects limit and continue parameters with 400 on routes of lists that are not
+// fetched in chunks, so that whole lists are not returned as if they were a single chunk.
+func chunkFilter(request *restful.Request, response *restful.Response,
+	chain *restful.FilterChain) {
+	limit := request.QueryParameter("limit")
+	if len(limit) == 0 && len(request.QueryParameter("continue")) == 0 {
+		chain.ProcessFilter(request, response)
+		return
+	}
+
+	message := ""
+	if !chunkedListRoutes[request.SelectedRoutePath()] {
+		message = "List can not be fetched in chunks"
+	} else if value, err := strconv.ParseInt(limit, 10, 64); err != nil || value <= 0 {
+		message = fmt.Sprintf("Invalid limit %q", limit)
+	}
+	if len(message) > 0 {
+		response.AddHeader("Content-Type", "text/plain")
+		response.WriteErrorString(http.StatusBadRequest, message+"\n")
+		return
+	}
+
+	chain.ProcessFilter(request, response)
+}
+
+// selectorFilter rejects requests with invalid label or field selector with 400, so that invalid
+// selectors of list requests are not taken as selecting nothing.
+func selectorFilter(request *restful.Request, response *restful.Response,
+	chain *restful.FilterChain) {
+	_, err := dataselect.NewSelectorQuery(request.QueryParameter("labelSelector"),
+		request.QueryParameter("fieldSelector"), "")
+	if err != nil {
+		response.AddHeader("Content-Type", "text/plain")
+		response.WriteErrorString(http.StatusBadRequest, fmt.Sprintf("Invalid selector: %v\n", err))
+		return
+	}
+
+	chain.ProcessFilter(request, response)
 }
 
 // logRequestAndReponse is a web-service filter function used for request and response logging.
+// In JSON format a single structured entry is written per request.
 func requestAndResponseLogger(request *restful.Request, response *restful.Response,
 	chain *restful.FilterChain) {
-	log.Printf(formatRequestLog(request))
+	if logger.Format() == logger.FormatJSON {
+		payload := formatRequestPayload(request, false)
+		reqStart := time.Now()
+		chain.ProcessFilter(request, response)
+		logger.WriteEntry(newRequestLogEntry(request, response, payload, time.Since(reqStart)))
+		return
+	}
+
+	logger.Info(formatRequestLog(request))
+	reqStart := time.Now()
 	chain.ProcessFilter(request, response)
-	log.Printf(formatResponseLog(response, request))
+	logger.Info(formatResponseLog(response, request, time.Since(reqStart)))
 }
 
 // formatRequestLog formats request log string.
@@ -51,79 +150,81 @@ func formatRequestLog(request *restful.Request) string {
 		uri = request.Request.URL.RequestURI()
 	}
 
+	return fmt.Sprintf(RequestLogString, time.Now().Format(time.RFC3339), request.Request.Proto,
+		request.Request.Method, uri, request.Request.RemoteAddr, formatRequestPayload(request, true))
+}
+
+// credentialRoutes are routes whose request bodies carry credentials, i.e. tokens, passwords and
+// authorization codes. Their bodies are never logged, even if payloads are not redacted.
+var credentialRoutes = []string{
+	"/api/v1/login",
+	"/api/v1/oidc/token",
+	"/api/v1/oidc/refresh",
+}
+
+// formatRequestPayload formats body of the request. Empty body is formatted as {} and content of
+// non-empty body is hidden if payloads are redacted or the body carries credentials.
+func formatRequestPayload(request *restful.Request, indent bool) string {
+	if contains(credentialRoutes, request.SelectedRoutePath()) {
+		return redactedPayload
+	}
+
 	content := "{}"
 	entity := make(map[string]interface{})
 	request.ReadEntity(&entity)
-	if len(entity) > 0 {
-		bytes, err := json.MarshalIndent(entity, "", "  ")
-		if err == nil {
-			content = string(bytes)
-		}
+	if len(entity) == 0 {
+		return content
+	}
+	if loggingOptions.RedactPayloads {
+		return redactedPayload
 	}
 
-	return fmt.Sprintf(RequestLogString, time.Now().Format(time.RFC3339), request.Request.Proto,
-		request.Request.Method, uri, request.Request.RemoteAddr, content)
+	var bytes []byte
+	var err error
+	if indent {
+		bytes, err = json.MarshalIndent(entity, "", "  ")
+	} else {
+		bytes, err = json.Marshal(entity)
+	}
+	if err == nil {
+		content = string(bytes)
+	}
+	return content
 }
 
 // formatResponseLog formats response log string.
-func formatResponseLog(response *restful.Response, request *restful.Request) string {
+func formatResponseLog(response *restful.Response, request *restful.Request,
+	latency time.Duration) string {
+	user := getRequestUser(request)
+	if user == "" {
+		user = "-"
+	}
+	resource, namespace, name := getRequestResource(request)
 	return fmt.Sprintf(ResponseLogString, time.Now().Format(time.RFC3339),
-		request.Request.RemoteAddr, response.StatusCode())
+		request.Request.RemoteAddr, response.StatusCode(), latency, user,
+		strings.Trim(strings.Join([]string{resource, namespace, name}, "/"), "/"))
 }
 
 func metricsFilter(req *restful.Request, resp *restful.Response,
 	chain *restful.FilterChain) {
 	resource := mapUrlToResource(req.SelectedRoutePath())
 	httpClient := utilnet.GetHTTPClient(req.Request)
+	reqStart := time.Now()
 
 	chain.ProcessFilter(req, resp)
 
+	monitorHandler(req.SelectedRoutePath(), req.Request.Method, resp.StatusCode(), reqStart)
 	if resource != nil {
 		monitor(
 			req.Request.Method,
 			*resource, httpClient,
 			resp.Header().Get("Content-Type"),
 			resp.StatusCode(),
-			time.Now(),
+			reqStart,
 		)
 	}
 }
 
-func validateXSRFFilter(csrfKey string) restful.FilterFunction {
-	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
-		resource := mapUrlToResource(req.SelectedRoutePath())
-
-		if resource == nil || (shouldDoCsrfValidation(req) &&
-			!xsrftoken.Valid(req.HeaderParameter("X-CSRF-TOKEN"), csrfKey, "none",
-				*resource)) {
-			err := errors.New("CSRF validation failed")
-			log.Print(err)
-			resp.AddHeader("Content-Type", "text/plain")
-			resp.WriteErrorString(http.StatusUnauthorized, err.Error()+"\n")
-			return
-		}
-
-		chain.ProcessFilter(req, resp)
-	}
-}
-
-// Post requests should set correct X-CSRF-TOKEN header, all other requests
-// should either not edit anything or be already safe to CSRF attacks (PUT
-// and DELETE)
-func shouldDoCsrfValidation(req *restful.Request) bool {
-	if req.Request.Method != http.MethodPost {
-		return false
-	}
-
-	// Validation handlers are idempotent functions, and not actual data
-	// modification operations
-	if strings.HasPrefix(req.SelectedRoutePath(), "/api/v1/appdeployment/validate/") {
-		return false
-	}
-
-	return true
-}
-
 // mapUrlToResource extracts the resource from the URL path /api/v1/<resource>.
 // Ignores potential subresources.
 func mapUrlToResource(url string) *string {
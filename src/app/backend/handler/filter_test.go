@@ -0,0 +1,89 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	restful "github.com/emicklei/go-restful"
+)
+
+func TestSelectorFilter(t *testing.T) {
+	ws := new(restful.WebService)
+	ws.Filter(selectorFilter)
+	ws.Path("/api/v1")
+	ws.Route(ws.GET("/pod").To(func(request *restful.Request, response *restful.Response) {
+		response.WriteHeader(http.StatusOK)
+	}))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	cases := []struct {
+		query    string
+		expected int
+	}{
+		{"", http.StatusOK},
+		{"?labelSelector=app%3Dweb,tier!%3Dcache&fieldSelector=metadata.name%3Dweb", http.StatusOK},
+		{"?labelSelector=app%3D%3D%3Dweb", http.StatusBadRequest},
+		{"?fieldSelector=metadata.name", http.StatusBadRequest},
+	}
+
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, httptest.NewRequest("GET", "/api/v1/pod"+c.query, nil))
+
+		if recorder.Code != c.expected {
+			t.Errorf("Request with query %q returns %d, expected %d", c.query, recorder.Code, c.expected)
+		}
+	}
+}
+
+func TestChunkFilter(t *testing.T) {
+	ws := new(restful.WebService)
+	ws.Filter(chunkFilter)
+	ws.Path("/api/v1")
+	handler := func(request *restful.Request, response *restful.Response) {
+		response.WriteHeader(http.StatusOK)
+	}
+	ws.Route(ws.GET("/pod/{namespace}").To(handler))
+	ws.Route(ws.GET("/deployment/{namespace}").To(handler))
+	ws.Route(ws.GET("/workload/{namespace}").To(handler))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	cases := []struct {
+		path     string
+		expected int
+	}{
+		{"/api/v1/pod/default", http.StatusOK},
+		{"/api/v1/pod/default?limit=100&continue=token", http.StatusOK},
+		{"/api/v1/pod/default?limit=0", http.StatusBadRequest},
+		{"/api/v1/pod/default?continue=token", http.StatusBadRequest},
+		{"/api/v1/deployment/default?limit=100", http.StatusOK},
+		{"/api/v1/workload/default", http.StatusOK},
+		{"/api/v1/workload/default?limit=100", http.StatusBadRequest},
+	}
+
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, httptest.NewRequest("GET", c.path, nil))
+
+		if recorder.Code != c.expected {
+			t.Errorf("Request of %s returns %d, expected %d", c.path, recorder.Code, c.expected)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/transport"
+)
+
+const (
+	// LogFormatText writes logs as plain text lines.
+	LogFormatText = logger.FormatText
+	// LogFormatJSON writes every log line as a JSON object.
+	LogFormatJSON = logger.FormatJSON
+
+	// redactedPayload replaces content of request bodies when payloads are redacted.
+	redactedPayload = "<redacted>"
+)
+
+// LoggingOptions is a configuration of logs written by the backend.
+type LoggingOptions struct {
+	// Format of logs, either text or json.
+	Format string
+	// RedactPayloads hides content of request bodies in request logs, as they may contain
+	// secrets or tokens. Many routes accept secrets, e.g. creation of secrets, apply of manifests
+	// and notification channels, so payloads are redacted unless it is disabled explicitly.
+	RedactPayloads bool
+}
+
+// loggingOptions is the configuration used by the request logger.
+var loggingOptions = LoggingOptions{Format: LogFormatText, RedactPayloads: true}
+
+// ConfigureLogging sets format of all logs written by the backend and options of request logs.
+func ConfigureLogging(options LoggingOptions) error {
+	if err := logger.SetFormat(options.Format); err != nil {
+		return err
+	}
+
+	loggingOptions = options
+	return nil
+}
+
+// RequestLogEntry is a structured log record of a single API call.
+type RequestLogEntry struct {
+	Time       string       `json:"time"`
+	Level      logger.Level `json:"level"`
+	Method     string       `json:"method"`
+	Path       string       `json:"path"`
+	User       string       `json:"user"`
+	RemoteAddr string       `json:"remoteAddr"`
+	Status     int          `json:"status"`
+	LatencyMs  float64      `json:"latencyMs"`
+	Resource   string       `json:"resource,omitempty"`
+	Namespace  string       `json:"namespace,omitempty"`
+	Name       string       `json:"name,omitempty"`
+	Payload    string       `json:"payload,omitempty"`
+}
+
+// newRequestLogEntry creates log record of the request, that was served in given time.
+func newRequestLogEntry(request *restful.Request, response *restful.Response, payload string,
+	latency time.Duration) RequestLogEntry {
+	entry := RequestLogEntry{
+		Time:       time.Now().Format(time.RFC3339Nano),
+		Level:      logger.LevelInfo,
+		Method:     request.Request.Method,
+		User:       getRequestUser(request),
+		RemoteAddr: request.Request.RemoteAddr,
+		Status:     response.StatusCode(),
+		LatencyMs:  float64(latency) / float64(time.Millisecond),
+		Payload:    payload,
+	}
+	if request.Request.URL != nil {
+		entry.Path = request.Request.URL.Path
+	}
+	entry.Resource, entry.Namespace, entry.Name = getRequestResource(request)
+	return entry
+}
+
+// bearerTokenUser is the name of users authenticated with bearer tokens, that do not carry claims
+// with their name, e.g. opaque tokens of webhook token authenticators.
+const bearerTokenUser = "bearer token"
+
+// getRequestUser returns name of the user as claimed by credentials of the request. Credentials
+// are not verified here, this is done by the apiserver when the request is forwarded to it.
+// Empty string is returned for requests without credentials, which use the Dashboard's own
+// service account. Users impersonated by trusted auth proxy take precedence over credentials.
+// Users of sessions with client certificate, including ones logged in with kubeconfig, are named
+// by common name of the certificate, as the apiserver does.
+func getRequestUser(request *restful.Request) string {
+	if user := request.HeaderParameter(transport.ImpersonateUserHeader); user != "" {
+		return user
+	}
+
+	if certificate := getRequestCertificate(request); certificate != nil {
+		return certificate.Subject.CommonName
+	}
+
+	if username, _, ok := request.Request.BasicAuth(); ok {
+		return username
+	}
+
+	token := strings.TrimPrefix(request.HeaderParameter("Authorization"), "Bearer ")
+	if token == "" || token == request.HeaderParameter("Authorization") {
+		return ""
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return bearerTokenUser
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return bearerTokenUser
+	}
+	claims := make(map[string]interface{})
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return bearerTokenUser
+	}
+
+	if namespace, ok := claims["kubernetes.io/serviceaccount/namespace"].(string); ok {
+		if name, ok := claims["kubernetes.io/serviceaccount/service-account.name"].(string); ok {
+			return "system:serviceaccount:" + namespace + ":" + name
+		}
+	}
+	for _, claim := range []string{"email", "preferred_username", "sub"} {
+		if user, ok := claims[claim].(string); ok && user != "" {
+			return user
+		}
+	}
+	return bearerTokenUser
+}
+
+// getRequestCertificate returns client certificate of the session the request is authenticated
+// with, or nil if the request is not authenticated with client certificate.
+func getRequestCertificate(request *restful.Request) *x509.Certificate {
+	authInfo, ok := request.Attribute(client.AuthInfoAttribute).(api.AuthInfo)
+	if !ok {
+		return nil
+	}
+	block, _ := pem.Decode(authInfo.ClientCertificateData)
+	if block == nil {
+		return nil
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil
+	}
+	return certificate
+}
+
+// getRequestResource returns kind, namespace and name of the resource the request is about,
+// based on parameters of the selected route, i.e. /api/v1/pod/{namespace}/{pod}. Name is the
+// first path parameter other than the namespace and kind. Generic routes, i.e.
+// /api/v1/scale/{kind}/{namespace}/{name}, take the resource from the kind parameter and routes
+// of discovered resources, i.e. /api/v1/generic/{group}/{version}/{resource}/..., from the
+// resource parameter.
+func getRequestResource(request *restful.Request) (resource, namespace, name string) {
+	routePath := request.SelectedRoutePath()
+	if strings.Contains(routePath, "/{group}/{version}/{resource}") {
+		return getGenericResourceKind(request.PathParameter("resource")),
+			request.PathParameter("namespace"), request.PathParameter("name")
+	}
+	if res := mapUrlToResource(routePath); res != nil {
+		resource = *res
+	}
+	if kind := request.PathParameter("kind"); kind != "" {
+		resource = kind
+	}
+	namespace = request.PathParameter("namespace")
+
+	for _, segment := range strings.Split(routePath, "/") {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+		param := strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "}")
+		param = strings.Split(param, ":")[0]
+		if param != "namespace" && param != "kind" {
+			name = request.PathParameter(param)
+			break
+		}
+	}
+	return resource, namespace, name
+}
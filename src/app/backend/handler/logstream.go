@@ -0,0 +1,232 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/container"
+	"gopkg.in/igm/sockjs-go.v2/sockjs"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Status codes used when closing the SockJS connection of a log stream session.
+const (
+	// LogStreamCloseEnded is used when all logs were sent and the stream has ended.
+	LogStreamCloseEnded uint32 = 1
+	// LogStreamCloseFailed is used when the log stream could not be opened or was interrupted.
+	LogStreamCloseFailed uint32 = 2
+)
+
+// logStreamBindTimeout is the time client has to open the SockJS connection after the session
+// was created.
+const logStreamBindTimeout = 30 * time.Second
+
+// LogStreamSession sends container logs to the client over a SockJS connection.
+type LogStreamSession struct {
+	id            string
+	bound         chan error
+	sockJSSession sockjs.Session
+}
+
+// LogStreamMessage is the messaging protocol between log view and LogStreamSession.
+//
+// OP      DIRECTION  FIELD(S) USED  DESCRIPTION
+// ---------------------------------------------------------------------
+// bind    fe->be     SessionID      Id sent back from LogStreamResponse
+// stdout  be->fe     Data           Chunk of logs ending with a new line
+type LogStreamMessage struct {
+	Op, Data, SessionID string
+}
+
+// LogStreamResponse is sent by handleLogStream. The Id is a random session id that binds the
+// original REST request and the SockJS connection.
+type LogStreamResponse struct {
+	Id string `json:"id"`
+}
+
+// logStreamSessions stores all log stream sessions that were not bound yet or are in progress.
+var logStreamSessions = struct {
+	sync.Mutex
+	sessions map[string]*LogStreamSession
+}{sessions: make(map[string]*LogStreamSession)}
+
+// newLogStreamSession creates and registers a new log stream session.
+func newLogStreamSession() (*LogStreamSession, error) {
+	sessionId, err := genTerminalSessionId()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &LogStreamSession{id: sessionId, bound: make(chan error, 1)}
+	logStreamSessions.Lock()
+	logStreamSessions.sessions[sessionId] = session
+	sockJSSessions.WithLabelValues("logstream").Set(float64(len(logStreamSessions.sessions)))
+	logStreamSessions.Unlock()
+	return session, nil
+}
+
+// removeLogStreamSession unregisters the log stream session.
+func removeLogStreamSession(sessionId string) {
+	logStreamSessions.Lock()
+	delete(logStreamSessions.sessions, sessionId)
+	sockJSSessions.WithLabelValues("logstream").Set(float64(len(logStreamSessions.sessions)))
+	logStreamSessions.Unlock()
+}
+
+// Send sends a chunk of logs to the client.
+func (s *LogStreamSession) Send(data string) error {
+	msg, err := json.Marshal(LogStreamMessage{
+		Op:   "stdout",
+		Data: data,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.sockJSSession.Send(string(msg))
+}
+
+// handleLogStreamSession is called by net/http for any new /api/sockjs/logs connections.
+func handleLogStreamSession(session sockjs.Session) {
+	buf, err := session.Recv()
+	if err != nil {
+		logger.Errorf("handleLogStreamSession: can't Recv: %v", err)
+		return
+	}
+
+	var msg LogStreamMessage
+	if err := checkMessageSize(buf); err != nil {
+		logger.Errorf("handleLogStreamSession: %v", err)
+		return
+	}
+
+	if err := json.Unmarshal([]byte(buf), &msg); err != nil {
+		logger.Errorf("handleLogStreamSession: can't UnMarshal (%v): %s", err, buf)
+		return
+	}
+
+	if msg.Op != "bind" {
+		logger.Infof("handleLogStreamSession: expected 'bind' message, got: %s", buf)
+		return
+	}
+
+	logStreamSessions.Lock()
+	logStreamSession, ok := logStreamSessions.sessions[msg.SessionID]
+	if ok && logStreamSession.sockJSSession == nil {
+		logStreamSession.sockJSSession = session
+		logStreamSession.bound <- nil
+	}
+	logStreamSessions.Unlock()
+
+	if !ok {
+		logger.Errorf("handleLogStreamSession: can't find session '%s'", msg.SessionID)
+	}
+}
+
+// CreateLogStreamHandler is called from main for /api/sockjs/logs.
+func CreateLogStreamHandler(path string) http.Handler {
+	return newSockJSHandler(path, handleLogStreamSession)
+}
+
+// parseLogStreamOptions parses live log stream options from the request query parameters.
+func parseLogStreamOptions(request *restful.Request) *container.LogStreamOptions {
+	options := &container.LogStreamOptions{
+		Follow:     request.QueryParameter("follow") != "false",
+		Timestamps: request.QueryParameter("timestamps") == "true",
+	}
+
+	if tailLines, err := strconv.ParseInt(request.QueryParameter("tailLines"), 10, 64); err == nil {
+		options.TailLines = &tailLines
+	}
+
+	if sinceSeconds, err := strconv.ParseInt(request.QueryParameter("sinceSeconds"), 10, 64); err == nil &&
+		sinceSeconds > 0 {
+		options.SinceSeconds = &sinceSeconds
+	}
+
+	return options
+}
+
+// WaitForLogStream is called from apihandler.handleLogStream as a goroutine. Waits for the SockJS
+// connection to be opened by the client and then streams container logs to it.
+func WaitForLogStream(k8sClient *kubernetes.Clientset, request *restful.Request,
+	session *LogStreamSession) {
+	defer removeLogStreamSession(session.id)
+
+	select {
+	case <-session.bound:
+	case <-time.After(logStreamBindTimeout):
+		logger.Warningf("WaitForLogStream: session '%s' was not bound in time", session.id)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	podID := request.PathParameter("pod")
+	containerID := request.PathParameter("container")
+
+	stream, err := container.StreamPodLogs(k8sClient, namespace, podID, containerID,
+		parseLogStreamOptions(request))
+	if err != nil {
+		session.sockJSSession.Close(LogStreamCloseFailed, err.Error())
+		return
+	}
+	defer stream.Close()
+
+	// Close the stream when client disconnects, as followed stream may not end on its own.
+	go func() {
+		for {
+			if _, err := session.sockJSSession.Recv(); err != nil {
+				stream.Close()
+				return
+			}
+		}
+	}()
+
+	if err := pipeLogStream(stream, session); err != nil {
+		session.sockJSSession.Close(LogStreamCloseFailed, err.Error())
+		return
+	}
+
+	session.sockJSSession.Close(LogStreamCloseEnded, "Log stream ended")
+}
+
+// pipeLogStream sends logs line by line from the stream to the session until the stream ends.
+func pipeLogStream(stream io.Reader, session *LogStreamSession) error {
+	reader := bufio.NewReader(stream)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			if sendErr := session.Send(line); sendErr != nil {
+				return sendErr
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	restful "github.com/emicklei/go-restful"
+)
+
+// fakeSockJSSession records messages sent to the client.
+type fakeSockJSSession struct {
+	sync.Mutex
+	sent []string
+}
+
+func (s *fakeSockJSSession) ID() string                               { return "fake" }
+func (s *fakeSockJSSession) Recv() (string, error)                    { return "", nil }
+func (s *fakeSockJSSession) Close(status uint32, reason string) error { return nil }
+func (s *fakeSockJSSession) Send(msg string) error {
+	s.Lock()
+	defer s.Unlock()
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+// messages returns a copy of messages sent so far.
+func (s *fakeSockJSSession) messages() []string {
+	s.Lock()
+	defer s.Unlock()
+	return append([]string{}, s.sent...)
+}
+
+func TestPipeLogStream(t *testing.T) {
+	sockJSSession := &fakeSockJSSession{}
+	session := &LogStreamSession{sockJSSession: sockJSSession}
+
+	err := pipeLogStream(strings.NewReader("line 1\nline 2\nline 3"), session)
+	if err != nil {
+		t.Fatalf("pipeLogStream() returns unexpected error: %v", err)
+	}
+
+	var actual []string
+	for _, sent := range sockJSSession.sent {
+		msg := LogStreamMessage{}
+		if err := json.Unmarshal([]byte(sent), &msg); err != nil {
+			t.Fatalf("pipeLogStream() sends invalid message %#v", sent)
+		}
+		actual = append(actual, msg.Data)
+	}
+
+	expected := []string{"line 1\n", "line 2\n", "line 3"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("pipeLogStream() sends %#v, expected %#v", actual, expected)
+	}
+}
+
+func TestParseLogStreamOptions(t *testing.T) {
+	tailLines := int64(10)
+	sinceSeconds := int64(60)
+	cases := []struct {
+		query    string
+		follow   bool
+		tail     *int64
+		since    *int64
+		withTime bool
+	}{
+		{"", true, nil, nil, false},
+		{"follow=false&timestamps=true", false, nil, nil, true},
+		{"tailLines=10&sinceSeconds=60", true, &tailLines, &sinceSeconds, false},
+		{"tailLines=abc&sinceSeconds=-5", true, nil, nil, false},
+	}
+	for _, c := range cases {
+		httpRequest, _ := http.NewRequest("GET", "/api/v1/log/ns/pod/container/stream?"+c.query, nil)
+		actual := parseLogStreamOptions(restful.NewRequest(httpRequest))
+		if actual.Follow != c.follow || actual.Timestamps != c.withTime ||
+			!reflect.DeepEqual(actual.TailLines, c.tail) || !reflect.DeepEqual(actual.SinceSeconds, c.since) {
+			t.Errorf("parseLogStreamOptions(%#v) returns %#v", c.query, actual)
+		}
+	}
+}
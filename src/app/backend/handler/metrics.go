@@ -47,6 +47,27 @@ var (
 		},
 		[]string{"verb", "resource"},
 	)
+	handlerLatencies = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "dashboard_handler_request_duration_seconds",
+			Help: "Response latency distribution in seconds for each route, method and response code of Dashboard API handlers.",
+		},
+		[]string{"route", "method", "code"},
+	)
+	sockJSSessions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "dashboard_sockjs_sessions",
+			Help: "Number of SockJS sessions for each type (terminal, logstream, watch or portforward).",
+		},
+		[]string{"type"},
+	)
+	watchHubSubscribers = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "dashboard_watch_hub_subscribers",
+			Help: "Number of sessions subscribed to watches of the watch hub for each kind.",
+		},
+		[]string{"kind"},
+	)
 )
 
 // Initialize all metrics in prometheus
@@ -54,6 +75,9 @@ func init() {
 	prometheus.MustRegister(requestCounter)
 	prometheus.MustRegister(requestLatencies)
 	prometheus.MustRegister(requestLatenciesSummary)
+	prometheus.MustRegister(handlerLatencies)
+	prometheus.MustRegister(sockJSSessions)
+	prometheus.MustRegister(watchHubSubscribers)
 }
 
 // Track API call in prometheus
@@ -63,3 +87,9 @@ func monitor(verb, resource string, client, contentType string, httpCode int, re
 	requestLatencies.WithLabelValues(verb, resource).Observe(elapsed)
 	requestLatenciesSummary.WithLabelValues(verb, resource).Observe(elapsed)
 }
+
+// Track latency of Dashboard API handler in prometheus
+func monitorHandler(route, method string, httpCode int, reqStart time.Time) {
+	handlerLatencies.WithLabelValues(route, method, strconv.Itoa(httpCode)).
+		Observe(time.Since(reqStart).Seconds())
+}
@@ -0,0 +1,37 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/notification"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// notificationManager stores notification channels and routes, when configured.
+var notificationManager notification.NotificationManager
+
+// ConfigureNotifications sets manager of notification channels and routes. Nil manager disables
+// notifications API.
+func ConfigureNotifications(manager notification.NotificationManager) {
+	notificationManager = manager
+}
+
+// getNotificationManager returns the configured notification manager or service unavailable error.
+func getNotificationManager() (notification.NotificationManager, error) {
+	if notificationManager == nil {
+		return nil, errorsK8s.NewServiceUnavailable("notifications are not configured")
+	}
+	return notificationManager, nil
+}
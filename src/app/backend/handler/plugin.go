@@ -0,0 +1,69 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/auth"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/csrf"
+	"github.com/kubernetes/dashboard/src/app/backend/plugin"
+)
+
+// PluginHandler serves API routes of plugins under /api/v1/plugin/<name> and the list of plugins
+// under /api/v1/plugin.
+type PluginHandler struct {
+	cManager    client.ClientManager
+	authManager auth.AuthManager
+	plugins     []plugin.Plugin
+}
+
+// Install creates new web service for plugins and adds it to the container. Plugins can serve any
+// content, so request logger, which reads request bodies, is not installed, same as for the
+// service proxy.
+func (self PluginHandler) Install(container *restful.Container) {
+	ws := new(restful.WebService)
+	ws.Filter(auth.NewAuthProxyFilter(self.authManager))
+	ws.Filter(auth.NewSessionFilter(self.authManager))
+	ws.Filter(auth.TokenCookieFilter)
+	ws.Filter(metricsFilter)
+	ws.Filter(readOnlyFilter)
+	ws.Filter(policyFilter)
+	ws.Filter(newAuditFilter(false))
+	ws.Filter(csrf.NewFilter(csrf.NewTokenManager(self.cManager.CSRFKey())))
+	ws.Path("/api/v1/plugin")
+
+	ws.Route(ws.GET("").
+		To(self.handleGetPluginList).
+		Produces(restful.MIME_JSON).
+		Writes(plugin.PluginList{}))
+
+	for _, p := range self.plugins {
+		for _, route := range p.Routes(self.cManager) {
+			ws.Route(ws.Method(route.Method).
+				Path("/" + p.Name() + route.Path).
+				Consumes("*/*").
+				To(route.Handler))
+		}
+	}
+
+	container.Add(ws)
+}
+
+func (self PluginHandler) handleGetPluginList(request *restful.Request, response *restful.Response) {
+	response.WriteHeaderAndEntity(http.StatusOK, plugin.GetPluginList(self.plugins))
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/plugin"
+)
+
+type greetingPlugin struct{}
+
+func (greetingPlugin) Name() string { return "greeting" }
+
+func (greetingPlugin) Routes(cManager client.ClientManager) []plugin.Route {
+	return []plugin.Route{{
+		Method: http.MethodGet,
+		Path:   "/hello/{name}",
+		Handler: func(request *restful.Request, response *restful.Response) {
+			response.Write([]byte("hello " + request.PathParameter("name")))
+		},
+	}}
+}
+
+func (greetingPlugin) MenuEntries() []plugin.MenuEntry {
+	return []plugin.MenuEntry{{Label: "Greeting", Path: "/hello/world"}}
+}
+
+func TestPluginHandler(t *testing.T) {
+	var proxiedURI string
+	sidecar := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURI = r.URL.RequestURI()
+		w.Write([]byte("sidecar"))
+	}))
+	defer sidecar.Close()
+
+	sidecarPlugin, err := plugin.NewSidecarPlugin("sidecar", sidecar.URL+"/ui", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	container := restful.NewContainer()
+	PluginHandler{
+		cManager: client.NewClientManager("", "http://localhost:8080"),
+		plugins:  []plugin.Plugin{greetingPlugin{}, sidecarPlugin},
+	}.Install(container)
+	dashboard := httptest.NewServer(container)
+	defer dashboard.Close()
+
+	resp, err := http.Get(dashboard.URL + "/api/v1/plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := plugin.PluginList{}
+	err = json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedList := plugin.PluginList{Plugins: []plugin.PluginInfo{
+		{Name: "greeting", MenuEntries: []plugin.MenuEntry{{Label: "Greeting", Path: "/hello/world"}}},
+		{Name: "sidecar", MenuEntries: []plugin.MenuEntry{{Label: "sidecar", Path: "/"}}},
+	}}
+	if !reflect.DeepEqual(list, expectedList) {
+		t.Errorf("Plugin list is %#v, expected %#v", list, expectedList)
+	}
+
+	cases := []struct {
+		path, expectedBody, expectedURI string
+	}{
+		{"/api/v1/plugin/greeting/hello/world", "hello world", ""},
+		{"/api/v1/plugin/sidecar", "sidecar", "/ui/"},
+		{"/api/v1/plugin/sidecar/static/app.js?v=1", "sidecar", "/ui/static/app.js?v=1"},
+	}
+
+	for _, c := range cases {
+		proxiedURI = ""
+		resp, err := http.Get(dashboard.URL + c.path)
+		if err != nil {
+			t.Fatalf("Request to %s returned unexpected error: %v", c.path, err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Request to %s returned status %d, expected %d", c.path, resp.StatusCode,
+				http.StatusOK)
+		}
+		if string(body) != c.expectedBody {
+			t.Errorf("Request to %s returned %s, expected %s", c.path, body, c.expectedBody)
+		}
+		if proxiedURI != c.expectedURI {
+			t.Errorf("Request to %s was forwarded as %s, expected %s", c.path, proxiedURI,
+				c.expectedURI)
+		}
+	}
+}
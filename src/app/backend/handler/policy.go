@@ -0,0 +1,155 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/policy"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dns"
+	"k8s.io/client-go/transport"
+)
+
+// policyEngine authorizes actions of users, when configured.
+var policyEngine policy.Engine
+
+// dnsLookupRoute runs nslookup in the pod selected by the request body, so the pod is the resource
+// of the action.
+const dnsLookupRoute = "/api/v1/dns/lookup"
+
+// routeVerbs are verbs of routes whose action does not follow from the HTTP method.
+var routeVerbs = map[string]string{
+	"/api/v1/pod/{namespace}/{pod}/shell/{container}":          "exec",
+	"/api/v1/pod/{namespace}/{pod}/portforward/{port}":         "portforward",
+	"/api/v1/service/{namespace}/{service}/portforward/{port}": "portforward",
+	"/api/v1/secret/{namespace}/{name}/reveal":                 "reveal",
+	"/api/v1/pod/delete":                                       "delete",
+	"/api/v1/pod/{namespace}/{pod}/eviction":                   "delete",
+	"/api/v1/rollout/{kind}/{namespace}/{name}/notify":         "get",
+	"/api/v1/dns/lookup":                                       "exec",
+}
+
+// methodVerbs map HTTP methods to verbs of actions.
+var methodVerbs = map[string]string{
+	http.MethodGet:     "get",
+	http.MethodHead:    "get",
+	http.MethodOptions: "get",
+	http.MethodPost:    "create",
+	http.MethodPut:     "update",
+	http.MethodPatch:   "update",
+	http.MethodDelete:  "delete",
+}
+
+// ConfigurePolicy sets engine that authorizes actions of users before they reach Kubernetes. Nil
+// engine allows all actions.
+func ConfigurePolicy(engine policy.Engine) {
+	policyEngine = engine
+}
+
+// policyFilter rejects requests denied by the configured policy with 403.
+func policyFilter(request *restful.Request, response *restful.Response,
+	chain *restful.FilterChain) {
+	if policyEngine == nil {
+		chain.ProcessFilter(request, response)
+		return
+	}
+
+	attributes := getPolicyAttributes(request)
+	if decision := policyEngine.Authorize(attributes); !decision.Allowed {
+		logger.Infof("Audit: user %q denied to %s %s %s/%s: %s", attributes.User, attributes.Verb,
+			attributes.Resource, attributes.Namespace, attributes.Name, decision.Reason)
+		response.AddHeader("Content-Type", "text/plain")
+		response.WriteErrorString(http.StatusForbidden, decision.Reason+"\n")
+		return
+	}
+
+	chain.ProcessFilter(request, response)
+}
+
+// getPolicyAttributes describes the action requested by the user.
+func getPolicyAttributes(request *restful.Request) policy.Attributes {
+	resource, namespace, name := getRequestResource(request)
+	if request.SelectedRoutePath() == dnsLookupRoute {
+		resource, namespace, name = getDNSLookupPod(request)
+	}
+	return policy.Attributes{
+		User:      getRequestUser(request),
+		Groups:    getRequestGroups(request),
+		Verified:  isVerifiedIdentity(request),
+		Verb:      getRequestVerb(request.Request.Method, request.SelectedRoutePath()),
+		Resource:  resource,
+		Namespace: namespace,
+		Name:      name,
+	}
+}
+
+// getDNSLookupPod returns the pod DNS lookup runs in. Lookups without pod run in the Dashboard pod,
+// namespace and name are empty then.
+func getDNSLookupPod(request *restful.Request) (resource, namespace, name string) {
+	spec := dns.DNSLookupSpec{}
+	request.ReadEntity(&spec)
+	return "pod", spec.PodNamespace, spec.Pod
+}
+
+// isVerifiedIdentity returns true if user and groups of the request are authenticated, either by
+// trusted auth proxy or by the apiserver, which verifies client certificates on login. Claims of
+// bearer tokens and basic auth usernames are taken as they are sent by the client.
+func isVerifiedIdentity(request *restful.Request) bool {
+	return request.HeaderParameter(transport.ImpersonateUserHeader) != "" ||
+		getRequestCertificate(request) != nil
+}
+
+// getRequestVerb returns verb of the action requested with given method and route.
+func getRequestVerb(method, route string) string {
+	if verb, ok := routeVerbs[route]; ok {
+		return verb
+	}
+	return methodVerbs[method]
+}
+
+// getRequestGroups returns groups of the user, either impersonated by trusted auth proxy, from
+// organizations of the client certificate or from the groups claim of the bearer token. The claim
+// is not verified, so it is only used to match deny rules, see isVerifiedIdentity.
+func getRequestGroups(request *restful.Request) []string {
+	if groups := request.Request.Header[transport.ImpersonateGroupHeader]; len(groups) > 0 {
+		return groups
+	}
+
+	if certificate := getRequestCertificate(request); certificate != nil {
+		return certificate.Subject.Organization
+	}
+
+	token := strings.TrimPrefix(request.HeaderParameter("Authorization"), "Bearer ")
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil
+	}
+	claims := struct {
+		Groups []string `json:"groups"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil
+	}
+	return claims.Groups
+}
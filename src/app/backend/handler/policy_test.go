@@ -0,0 +1,178 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/policy"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dns"
+)
+
+func TestGetRequestVerb(t *testing.T) {
+	cases := []struct {
+		method   string
+		route    string
+		expected string
+	}{
+		{"GET", "/api/v1/pod/{namespace}", "get"},
+		{"POST", "/api/v1/appdeployment", "create"},
+		{"PUT", "/api/v1/scale/{kind}/{namespace}/{name}", "update"},
+		{"DELETE", "/api/v1/_raw/{kind}/namespace/{namespace}/name/{name}", "delete"},
+		{"POST", "/api/v1/pod/delete", "delete"},
+		{"GET", "/api/v1/pod/{namespace}/{pod}/shell/{container}", "exec"},
+		{"GET", "/api/v1/secret/{namespace}/{name}/reveal", "reveal"},
+	}
+
+	for _, c := range cases {
+		actual := getRequestVerb(c.method, c.route)
+		if actual != c.expected {
+			t.Errorf("getRequestVerb(%s, %s) returns %s, expected %s", c.method, c.route, actual,
+				c.expected)
+		}
+	}
+}
+
+func TestPolicyFilter(t *testing.T) {
+	defer ConfigurePolicy(nil)
+	ConfigurePolicy(policy.NewRuleEngine(policy.Policy{
+		Deny: []policy.Rule{
+			{Name: "no-exec-for-interns", Verbs: []string{"exec"}, Groups: []string{"interns"}},
+		},
+		Allow: []policy.Rule{
+			{Name: "admins", Groups: []string{"admins"}},
+		},
+	}))
+
+	ws := new(restful.WebService)
+	ws.Filter(policyFilter)
+	ws.Path("/api/v1")
+	ws.Route(ws.GET("/pod/{namespace}/{pod}/shell/{container}").To(
+		func(request *restful.Request, response *restful.Response) {
+			response.WriteHeader(http.StatusOK)
+		}))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	cases := []struct {
+		groups   []string
+		expected int
+	}{
+		{nil, http.StatusOK},
+		{[]string{"devs"}, http.StatusOK},
+		{[]string{"devs", "interns"}, http.StatusForbidden},
+		{[]string{"admins", "interns"}, http.StatusOK},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest("GET", "/api/v1/pod/default/foo/shell/bar", nil)
+		req.Header.Set("Impersonate-User", "jane")
+		for _, group := range c.groups {
+			req.Header.Add("Impersonate-Group", group)
+		}
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, req)
+
+		if recorder.Code != c.expected {
+			t.Errorf("exec request of user in groups %v returns %d, expected %d", c.groups,
+				recorder.Code, c.expected)
+		}
+	}
+
+	// Groups claim of the token is not verified, so it must not match allow rules.
+	req := httptest.NewRequest("GET", "/api/v1/pod/default/foo/shell/bar", nil)
+	req.Header.Set("Authorization", "Bearer "+newJWT(`{"sub": "jane", "groups": ["admins", "interns"]}`))
+	recorder := httptest.NewRecorder()
+	container.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("exec request with forged groups claim returns %d, expected %d", recorder.Code,
+			http.StatusForbidden)
+	}
+}
+
+func TestGetRequestGroups(t *testing.T) {
+	request := newCertificateRequest(t, "jane", "devs", "interns")
+	if actual := getRequestGroups(request); !reflect.DeepEqual(actual, []string{"devs", "interns"}) {
+		t.Errorf("getRequestGroups() of client certificate session returns %v, expected %v", actual,
+			[]string{"devs", "interns"})
+	}
+	if !isVerifiedIdentity(request) {
+		t.Error("isVerifiedIdentity() of client certificate session returns false, expected true")
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/pod", nil)
+	req.Header.Set("Authorization", "Bearer "+newJWT(`{"sub": "jane", "groups": ["admins"]}`))
+	request = restful.NewRequest(req)
+	if actual := getRequestGroups(request); !reflect.DeepEqual(actual, []string{"admins"}) {
+		t.Errorf("getRequestGroups() of bearer token returns %v, expected %v", actual,
+			[]string{"admins"})
+	}
+	if isVerifiedIdentity(request) {
+		t.Error("isVerifiedIdentity() of bearer token returns true, expected false")
+	}
+}
+
+func TestGetPolicyAttributesOfDNSLookup(t *testing.T) {
+	var attributes policy.Attributes
+	var spec dns.DNSLookupSpec
+	ws := new(restful.WebService)
+	ws.Path("/api/v1")
+	ws.Route(ws.POST("/dns/lookup").To(func(request *restful.Request, response *restful.Response) {
+		attributes = getPolicyAttributes(request)
+		request.ReadEntity(&spec)
+	}))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	req := httptest.NewRequest("POST", "/api/v1/dns/lookup",
+		strings.NewReader(`{"name": "web", "pod": "debug", "podNamespace": "prod"}`))
+	req.Header.Set("Content-Type", "application/json")
+	container.ServeHTTP(httptest.NewRecorder(), req)
+
+	if attributes.Verb != "exec" || attributes.Resource != "pod" || attributes.Namespace != "prod" ||
+		attributes.Name != "debug" {
+		t.Errorf("getPolicyAttributes() of DNS lookup returns %#v, expected exec in pod prod/debug",
+			attributes)
+	}
+	if spec.Name != "web" {
+		t.Errorf("Body of DNS lookup read after policy attributes is %#v", spec)
+	}
+}
+
+func TestGetPolicyAttributesOfGenericResource(t *testing.T) {
+	var attributes policy.Attributes
+	ws := new(restful.WebService)
+	ws.Path("/api/v1")
+	ws.Route(ws.GET("/generic/{group}/{version}/{resource}/namespace/{namespace}/name/{name}").To(
+		func(request *restful.Request, response *restful.Response) {
+			attributes = getPolicyAttributes(request)
+		}))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	container.ServeHTTP(httptest.NewRecorder(),
+		httptest.NewRequest("GET", "/api/v1/generic/core/v1/secrets/namespace/prod/name/token", nil))
+
+	if attributes.Verb != "get" || attributes.Resource != "secret" || attributes.Namespace != "prod" ||
+		attributes.Name != "token" {
+		t.Errorf("getPolicyAttributes() of generic resource returns %#v, expected get secret prod/token",
+			attributes)
+	}
+}
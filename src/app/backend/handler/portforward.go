@@ -0,0 +1,308 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"gopkg.in/igm/sockjs-go.v2/sockjs"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/kubernetes/pkg/client/unversioned/remotecommand"
+)
+
+// Status codes used when closing the SockJS connection of a port forward session.
+const (
+	// PortForwardCloseFinished is used when the connection to the pod port was closed.
+	PortForwardCloseFinished uint32 = 1
+	// PortForwardCloseFailed is used when the connection to the pod port could not be opened or
+	// was interrupted by an error.
+	PortForwardCloseFailed uint32 = 2
+)
+
+// portForwardBindTimeout is the time client has to open the SockJS connection after the session
+// was created.
+const portForwardBindTimeout = 30 * time.Second
+
+// PortForwardSession tunnels a single TCP connection to a pod port over a SockJS connection.
+type PortForwardSession struct {
+	id            string
+	bound         chan error
+	sockJSSession sockjs.Session
+	// pending keeps received data that did not fit into the buffer passed to Read.
+	pending []byte
+}
+
+// PortForwardMessage is the messaging protocol between the browser and PortForwardSession. Data
+// is base64 encoded, as SockJS transports can only carry text.
+//
+// OP      DIRECTION  FIELD(S) USED  DESCRIPTION
+// ---------------------------------------------------------------------
+// bind    fe->be     SessionID      Id sent back from PortForwardResponse
+// data    fe->be     Data           Bytes to be written to the pod port
+// data    be->fe     Data           Bytes read from the pod port
+type PortForwardMessage struct {
+	Op, Data, SessionID string
+}
+
+// PortForwardResponse is sent by handlePodPortForward and handleServicePortForward. The Id is
+// a random session id that binds the original REST request and the SockJS connection. Any client
+// in possession of this Id can use the tunnel.
+type PortForwardResponse struct {
+	Id string `json:"id"`
+
+	// Name of the pod that the connection is forwarded to.
+	Pod string `json:"pod"`
+
+	// Port of the pod that the connection is forwarded to.
+	Port int32 `json:"port"`
+}
+
+// portForwardSessions stores all port forward sessions that were not bound yet or are in progress.
+var portForwardSessions = struct {
+	sync.Mutex
+	sessions map[string]*PortForwardSession
+}{sessions: make(map[string]*PortForwardSession)}
+
+// newPortForwardSession creates and registers a new port forward session.
+func newPortForwardSession() (*PortForwardSession, error) {
+	sessionId, err := genTerminalSessionId()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &PortForwardSession{id: sessionId, bound: make(chan error, 1)}
+	portForwardSessions.Lock()
+	portForwardSessions.sessions[sessionId] = session
+	sockJSSessions.WithLabelValues("portforward").Set(float64(len(portForwardSessions.sessions)))
+	portForwardSessions.Unlock()
+	return session, nil
+}
+
+// removePortForwardSession unregisters the port forward session.
+func removePortForwardSession(sessionId string) {
+	portForwardSessions.Lock()
+	delete(portForwardSessions.sessions, sessionId)
+	sockJSSessions.WithLabelValues("portforward").Set(float64(len(portForwardSessions.sessions)))
+	portForwardSessions.Unlock()
+}
+
+// Read returns data sent by the client. Called in a loop while the connection is forwarded.
+func (s *PortForwardSession) Read(p []byte) (int, error) {
+	for len(s.pending) == 0 {
+		m, err := s.sockJSSession.Recv()
+		if err != nil {
+			return 0, err
+		}
+
+		if err := checkMessageSize(m); err != nil {
+			return 0, err
+		}
+
+		var msg PortForwardMessage
+		if err := json.Unmarshal([]byte(m), &msg); err != nil {
+			return 0, err
+		}
+
+		if msg.Op != "data" {
+			return 0, fmt.Errorf("unknown message type '%s'", msg.Op)
+		}
+
+		if s.pending, err = base64.StdEncoding.DecodeString(msg.Data); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	return n, nil
+}
+
+// Write sends data read from the pod port to the client.
+func (s *PortForwardSession) Write(p []byte) (int, error) {
+	msg, err := json.Marshal(PortForwardMessage{
+		Op:   "data",
+		Data: base64.StdEncoding.EncodeToString(p),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err = s.sockJSSession.Send(string(msg)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// handlePortForwardSession is called by net/http for any new /api/sockjs/portforward connections.
+func handlePortForwardSession(session sockjs.Session) {
+	buf, err := session.Recv()
+	if err != nil {
+		logger.Errorf("handlePortForwardSession: can't Recv: %v", err)
+		return
+	}
+
+	var msg PortForwardMessage
+	if err := checkMessageSize(buf); err != nil {
+		logger.Errorf("handlePortForwardSession: %v", err)
+		return
+	}
+
+	if err := json.Unmarshal([]byte(buf), &msg); err != nil {
+		logger.Errorf("handlePortForwardSession: can't UnMarshal (%v): %s", err, buf)
+		return
+	}
+
+	if msg.Op != "bind" {
+		logger.Infof("handlePortForwardSession: expected 'bind' message, got: %s", buf)
+		return
+	}
+
+	portForwardSessions.Lock()
+	portForwardSession, ok := portForwardSessions.sessions[msg.SessionID]
+	if ok && portForwardSession.sockJSSession == nil {
+		portForwardSession.sockJSSession = session
+		portForwardSession.bound <- nil
+	}
+	portForwardSessions.Unlock()
+
+	if !ok {
+		logger.Errorf("handlePortForwardSession: can't find session '%s'", msg.SessionID)
+	}
+}
+
+// CreatePortForwardHandler is called from main for /api/sockjs/portforward.
+func CreatePortForwardHandler(path string) http.Handler {
+	return newSockJSHandler(path, handlePortForwardSession)
+}
+
+// parsePortForwardPort parses the port that connections should be forwarded to.
+func parsePortForwardPort(value string) (int32, error) {
+	port, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, errorsK8s.NewBadRequest(fmt.Sprintf("invalid port '%s'", value))
+	}
+	return int32(port), nil
+}
+
+// WaitForPortForward is called from apihandler as a goroutine. Waits for the SockJS connection to
+// be opened by the client and then forwards it to the port of the pod.
+func WaitForPortForward(k8sClient *kubernetes.Clientset, cfg *rest.Config, namespace, podName string,
+	port int32, session *PortForwardSession) {
+	defer removePortForwardSession(session.id)
+
+	select {
+	case <-session.bound:
+	case <-time.After(portForwardBindTimeout):
+		logger.Warningf("WaitForPortForward: session '%s' was not bound in time", session.id)
+		return
+	}
+
+	req := k8sClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("portforward")
+
+	dialer, err := remotecommand.NewExecutor(cfg, "POST", req.URL())
+	if err != nil {
+		session.sockJSSession.Close(PortForwardCloseFailed, err.Error())
+		return
+	}
+
+	if err := forwardPort(dialer, port, session); err != nil {
+		session.sockJSSession.Close(PortForwardCloseFailed, err.Error())
+		return
+	}
+
+	session.sockJSSession.Close(PortForwardCloseFinished, "Connection closed")
+}
+
+// forwardPort opens a port forward stream to the pod and copies data between it and the session
+// until either side closes the connection. It mirrors what kubectl port-forward does for a single
+// local connection.
+func forwardPort(dialer httpstream.Dialer, port int32, session *PortForwardSession) error {
+	conn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+	if err != nil {
+		return fmt.Errorf("error upgrading connection: %v", err)
+	}
+	defer conn.Close()
+
+	headers := http.Header{}
+	headers.Set(v1.StreamType, v1.StreamTypeError)
+	headers.Set(v1.PortHeader, strconv.Itoa(int(port)))
+	headers.Set(v1.PortForwardRequestIDHeader, "0")
+	errorStream, err := conn.CreateStream(headers)
+	if err != nil {
+		return fmt.Errorf("error creating error stream for port %d: %v", port, err)
+	}
+	// Nothing is written to the error stream.
+	errorStream.Close()
+
+	errorChan := make(chan error, 1)
+	go func() {
+		message, err := ioutil.ReadAll(errorStream)
+		switch {
+		case err != nil:
+			errorChan <- fmt.Errorf("error reading from error stream for port %d: %v", port, err)
+		case len(message) > 0:
+			errorChan <- fmt.Errorf("an error occurred forwarding port %d: %s", port, message)
+		}
+		close(errorChan)
+	}()
+
+	headers.Set(v1.StreamType, v1.StreamTypeData)
+	dataStream, err := conn.CreateStream(headers)
+	if err != nil {
+		return fmt.Errorf("error creating forwarding stream for port %d: %v", port, err)
+	}
+
+	localDone := make(chan struct{})
+	remoteDone := make(chan struct{})
+
+	go func() {
+		io.Copy(session, dataStream)
+		close(remoteDone)
+	}()
+
+	go func() {
+		// Client disconnected or sent an invalid message, there is nothing more to forward.
+		io.Copy(dataStream, session)
+		dataStream.Close()
+		close(localDone)
+	}()
+
+	select {
+	case <-remoteDone:
+	case <-localDone:
+		// Unblock reading from the pod port, the client can't receive the data anymore.
+		conn.Close()
+	}
+
+	return <-errorChan
+}
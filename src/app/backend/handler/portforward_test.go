@@ -0,0 +1,111 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+// fakeRecvSockJSSession returns queued messages from Recv and io.EOF once all were received.
+type fakeRecvSockJSSession struct {
+	fakeSockJSSession
+	received []string
+}
+
+func (s *fakeRecvSockJSSession) Recv() (string, error) {
+	if len(s.received) == 0 {
+		return "", io.EOF
+	}
+	msg := s.received[0]
+	s.received = s.received[1:]
+	return msg, nil
+}
+
+func TestPortForwardSessionRead(t *testing.T) {
+	sockJSSession := &fakeRecvSockJSSession{received: []string{
+		`{"Op":"data","Data":"aGVsbG8g"}`,
+		`{"Op":"data","Data":"d29ybGQ="}`,
+	}}
+	session := &PortForwardSession{sockJSSession: sockJSSession}
+
+	actual, err := ioutil.ReadAll(session)
+	if err != nil {
+		t.Fatalf("Read() returns unexpected error: %v", err)
+	}
+	if string(actual) != "hello world" {
+		t.Errorf("Read() == %q, expected %q", actual, "hello world")
+	}
+}
+
+func TestPortForwardSessionReadInvalidMessage(t *testing.T) {
+	sockJSSession := &fakeRecvSockJSSession{received: []string{`{"Op":"stdin","Data":"aGVsbG8="}`}}
+	session := &PortForwardSession{sockJSSession: sockJSSession}
+
+	if _, err := session.Read(make([]byte, 16)); err == nil {
+		t.Error("Read() expected error for unknown message type")
+	}
+}
+
+func TestPortForwardSessionWrite(t *testing.T) {
+	sockJSSession := &fakeSockJSSession{}
+	session := &PortForwardSession{sockJSSession: sockJSSession}
+
+	if _, err := session.Write([]byte("hello")); err != nil {
+		t.Fatalf("Write() returns unexpected error: %v", err)
+	}
+
+	var actual []PortForwardMessage
+	for _, m := range sockJSSession.messages() {
+		var msg PortForwardMessage
+		if err := json.Unmarshal([]byte(m), &msg); err != nil {
+			t.Fatalf("Write() sent invalid message %s: %v", m, err)
+		}
+		actual = append(actual, msg)
+	}
+
+	expected := []PortForwardMessage{{Op: "data", Data: "aGVsbG8="}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Write() sent %#v, expected %#v", actual, expected)
+	}
+}
+
+func TestParsePortForwardPort(t *testing.T) {
+	cases := []struct {
+		value         string
+		expected      int32
+		expectedError bool
+	}{
+		{"8080", 8080, false},
+		{"65535", 65535, false},
+		{"0", 0, true},
+		{"65536", 0, true},
+		{"http", 0, true},
+	}
+
+	for _, c := range cases {
+		actual, err := parsePortForwardPort(c.value)
+		if (err != nil) != c.expectedError {
+			t.Errorf("parsePortForwardPort(%s) returned error %v, expected error: %t", c.value, err,
+				c.expectedError)
+		}
+		if actual != c.expected {
+			t.Errorf("parsePortForwardPort(%s) == %d, expected %d", c.value, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/auth"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/csrf"
+	"k8s.io/client-go/rest"
+)
+
+// Methods that are forwarded by the service proxy.
+var serviceProxyMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+// ServiceProxyHandler forwards requests to services running in the cluster. Requests go through
+// the service proxy of the API server with credentials of the dashboard user, so the user needs
+// to be allowed to access the proxy subresource of the service. Responses are sandboxed, as they
+// are served on the Dashboard origin.
+type ServiceProxyHandler struct {
+	cManager    client.ClientManager
+	authManager auth.AuthManager
+}
+
+// Install creates new web service for the service proxy and adds it to the container. Proxied
+// requests can carry any content, so request logger, which reads request bodies, is not installed.
+// In read-only mode only GET, HEAD and OPTIONS requests are forwarded.
+func (self ServiceProxyHandler) Install(container *restful.Container) {
+	ws := new(restful.WebService)
+	ws.Filter(auth.NewAuthProxyFilter(self.authManager))
+	ws.Filter(auth.NewSessionFilter(self.authManager))
+	ws.Filter(auth.TokenCookieFilter)
+	ws.Filter(metricsFilter)
+	ws.Filter(readOnlyFilter)
+	ws.Filter(policyFilter)
+	ws.Filter(newAuditFilter(false))
+	ws.Filter(csrf.NewFilter(csrf.NewTokenManager(self.cManager.CSRFKey())))
+	ws.Path("/api/v1/proxy")
+
+	for _, method := range serviceProxyMethods {
+		ws.Route(ws.Method(method).
+			Path("/namespaces/{namespace}/services/{service}").
+			Consumes("*/*").
+			To(self.handleServiceProxy))
+		ws.Route(ws.Method(method).
+			Path("/namespaces/{namespace}/services/{service}/{path:*}").
+			Consumes("*/*").
+			To(self.handleServiceProxy))
+	}
+
+	container.Add(ws)
+}
+
+// handleServiceProxy forwards the request to the service. Service parameter has the same format
+// as in the API server proxy, i.e. "name", "name:port" or "scheme:name:port".
+func (self ServiceProxyHandler) handleServiceProxy(request *restful.Request, response *restful.Response) {
+	k8sClient, err := self.cManager.Client(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	cfg, err := self.cManager.Config(request)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	transport, err := rest.TransportFor(cfg)
+	if err != nil {
+		handleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	service := request.PathParameter("service")
+	location := k8sClient.CoreV1().RESTClient().Get().
+		Namespace(namespace).
+		Resource("services").
+		Name(service).
+		SubResource("proxy").
+		URL()
+
+	apiServerPrefix := strings.TrimSuffix(location.Path, "/")
+	dashboardPrefix := fmt.Sprintf("/api/v1/proxy/namespaces/%s/services/%s", namespace, service)
+
+	location.Path = apiServerPrefix + "/" + request.PathParameter("path")
+	if path := request.PathParameter("path"); path != "" && strings.HasSuffix(request.Request.URL.Path, "/") {
+		// Trailing slash is dropped by the router, but matters for relative links of web UIs.
+		location.Path += "/"
+	}
+	location.RawQuery = request.Request.URL.RawQuery
+
+	proxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL = location
+			req.Host = location.Host
+			// Response is compressed by the container if client accepts it.
+			req.Header.Del("Accept-Encoding")
+			req.Header.Del(csrf.TokenHeader)
+			req.Header.Del(auth.SessionTokenHeader)
+			auth.RemoveTokenCookies(req)
+		},
+		Transport: transport,
+		ModifyResponse: func(resp *http.Response) error {
+			rewriteProxyRedirect(resp, apiServerPrefix, dashboardPrefix)
+			auth.SandboxProxiedResponse(resp)
+			return nil
+		},
+	}
+
+	proxy.ServeHTTP(response, request.Request)
+}
+
+// rewriteProxyRedirect makes redirects that point to the API server proxy go through the
+// dashboard proxy instead.
+func rewriteProxyRedirect(resp *http.Response, apiServerPrefix, dashboardPrefix string) {
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil || !strings.HasPrefix(location.Path, apiServerPrefix) {
+		return
+	}
+
+	location.Scheme = ""
+	location.Host = ""
+	location.Path = dashboardPrefix + strings.TrimPrefix(location.Path, apiServerPrefix)
+	resp.Header.Set("Location", location.String())
+}
@@ -0,0 +1,112 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/auth"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+)
+
+func TestServiceProxyHandler(t *testing.T) {
+	var proxiedURI, proxiedSessionToken string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURI = r.URL.RequestURI()
+		proxiedSessionToken = r.Header.Get(auth.SessionTokenHeader)
+		if r.URL.Path == "/api/v1/namespaces/ns-1/services/svc-1:80/proxy/login" {
+			w.Header().Set("Location", "/api/v1/namespaces/ns-1/services/svc-1:80/proxy/graph?g=1")
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		w.Header().Set("Set-Cookie", "grafana_session=1")
+		w.Write([]byte("ok"))
+	}))
+	defer apiServer.Close()
+
+	container := restful.NewContainer()
+	ServiceProxyHandler{cManager: client.NewClientManager("", apiServer.URL)}.Install(container)
+	dashboard := httptest.NewServer(container)
+	defer dashboard.Close()
+
+	// Redirects are checked instead of being followed.
+	httpClient := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	cases := []struct {
+		path, expectedURI, expectedLocation string
+		expectedStatus                      int
+	}{
+		{
+			"/api/v1/proxy/namespaces/ns-1/services/svc-1:80/",
+			"/api/v1/namespaces/ns-1/services/svc-1:80/proxy/",
+			"",
+			http.StatusOK,
+		},
+		{
+			"/api/v1/proxy/namespaces/ns-1/services/svc-1:80/static/app.js?v=2",
+			"/api/v1/namespaces/ns-1/services/svc-1:80/proxy/static/app.js?v=2",
+			"",
+			http.StatusOK,
+		},
+		{
+			"/api/v1/proxy/namespaces/ns-1/services/svc-1:80/graph/",
+			"/api/v1/namespaces/ns-1/services/svc-1:80/proxy/graph/",
+			"",
+			http.StatusOK,
+		},
+		{
+			"/api/v1/proxy/namespaces/ns-1/services/svc-1:80/login",
+			"/api/v1/namespaces/ns-1/services/svc-1:80/proxy/login",
+			"/api/v1/proxy/namespaces/ns-1/services/svc-1:80/graph?g=1",
+			http.StatusFound,
+		},
+	}
+
+	for _, c := range cases {
+		req, _ := http.NewRequest("GET", dashboard.URL+c.path, nil)
+		req.Header.Set(auth.SessionTokenHeader, "session-token")
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			t.Fatalf("Proxy of %s returned unexpected error: %v", c.path, err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != c.expectedStatus {
+			t.Errorf("Proxy of %s returned status %d (%s), expected %d", c.path, resp.StatusCode, body,
+				c.expectedStatus)
+		}
+		if proxiedURI != c.expectedURI {
+			t.Errorf("Proxy of %s requested %s, expected %s", c.path, proxiedURI, c.expectedURI)
+		}
+		if location := resp.Header.Get("Location"); location != c.expectedLocation {
+			t.Errorf("Proxy of %s redirected to %s, expected %s", c.path, location, c.expectedLocation)
+		}
+		if proxiedSessionToken != "" {
+			t.Errorf("Proxy of %s forwarded session token of the user", c.path)
+		}
+		if !strings.HasPrefix(resp.Header.Get("Content-Security-Policy"), "sandbox") ||
+			resp.Header.Get("Set-Cookie") != "" {
+			t.Errorf("Proxy of %s returned response that is not sandboxed: %v", c.path, resp.Header)
+		}
+	}
+}
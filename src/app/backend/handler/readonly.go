@@ -0,0 +1,108 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/csrf"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+)
+
+// ReadOnlyHeader can be set by a reverse proxy in front of Dashboard to enable read-only mode for
+// a single request. It can only make requests more restricted, so it does not need to be trusted.
+const ReadOnlyHeader = "X-Dashboard-Read-Only"
+
+// ErrReadOnly is returned for requests that are rejected in read-only mode.
+var ErrReadOnly = errors.New("Dashboard is running in read-only mode")
+
+// readOnlyMode rejects all requests that change state of the cluster, when enabled.
+var readOnlyMode = false
+
+// readOnlyMethods do not change state of the cluster.
+var readOnlyMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+}
+
+// readOnlyRoutePrefixes are routes that do not change state of the cluster, even though they use
+// other methods than readOnlyMethods.
+var readOnlyRoutePrefixes = []string{
+	// Validation handlers are idempotent functions, and not actual data modification operations.
+	"/api/v1/appdeployment/validate/",
+	// Access reviews only check permissions of the user.
+	"/api/v1/rbac/accessreview",
+	// Rollout notifications only watch progress of the rollout.
+	"/api/v1/rollout/",
+	// Users still have to be able to log in and out.
+	"/api/v1/login",
+	"/api/v1/logout",
+	"/api/v1/oidc/",
+}
+
+// ConfigureReadOnlyMode enables or disables read-only mode of the API. Exec and port forwarding
+// sessions are created through the API, so they are rejected too.
+func ConfigureReadOnlyMode(enabled bool) {
+	readOnlyMode = enabled
+}
+
+// readOnlyFilter rejects requests that change state of the cluster with 403, when read-only mode is
+// enabled by flag or by ReadOnlyHeader.
+func readOnlyFilter(request *restful.Request, response *restful.Response,
+	chain *restful.FilterChain) {
+	if isReadOnlyRequest(request.Request) &&
+		!isAllowedInReadOnlyMode(request.Request.Method, request.SelectedRoutePath()) {
+		logger.Infof("%s: %s %s", ErrReadOnly, request.Request.Method, request.Request.URL.Path)
+		response.AddHeader("Content-Type", "text/plain")
+		response.WriteErrorString(http.StatusForbidden, ErrReadOnly.Error()+"\n")
+		return
+	}
+
+	chain.ProcessFilter(request, response)
+}
+
+// isReadOnlyRequest returns true if read-only mode applies to the request.
+func isReadOnlyRequest(request *http.Request) bool {
+	if readOnlyMode {
+		return true
+	}
+	readOnly, _ := strconv.ParseBool(request.Header.Get(ReadOnlyHeader))
+	return readOnly
+}
+
+// isAllowedInReadOnlyMode returns true for requests that do not change state of the cluster.
+func isAllowedInReadOnlyMode(method, route string) bool {
+	for _, prefix := range readOnlyRoutePrefixes {
+		if strings.HasPrefix(route, prefix) {
+			return true
+		}
+	}
+
+	return contains(readOnlyMethods, method) && !contains(csrf.InteractiveRoutes, route)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
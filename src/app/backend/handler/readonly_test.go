@@ -0,0 +1,99 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+)
+
+func TestIsAllowedInReadOnlyMode(t *testing.T) {
+	cases := []struct {
+		method   string
+		route    string
+		expected bool
+	}{
+		{"GET", "/api/v1/pod/{namespace}", true},
+		{"HEAD", "/api/v1/proxy/namespaces/{namespace}/services/{service}", true},
+		{"POST", "/api/v1/appdeployment", false},
+		{"PUT", "/api/v1/scale/{kind}/{namespace}/{name}", false},
+		{"PATCH", "/api/v1/_raw/{kind}/name/{name}", false},
+		{"DELETE", "/api/v1/_raw/{kind}/namespace/{namespace}/name/{name}", false},
+		{"POST", "/api/v1/proxy/namespaces/{namespace}/services/{service}/{path:*}", false},
+		{"GET", "/api/v1/pod/{namespace}/{pod}/shell/{container}", false},
+		{"GET", "/api/v1/pod/{namespace}/{pod}/portforward/{port}", false},
+		{"GET", "/api/v1/service/{namespace}/{service}/portforward/{port}", false},
+		{"POST", "/api/v1/appdeployment/validate/name", true},
+		{"POST", "/api/v1/rbac/accessreview", true},
+		{"POST", "/api/v1/rollout/{kind}/{namespace}/{name}/notify", true},
+		{"POST", "/api/v1/login", true},
+		{"POST", "/api/v1/oidc/refresh", true},
+	}
+
+	for _, c := range cases {
+		actual := isAllowedInReadOnlyMode(c.method, c.route)
+		if actual != c.expected {
+			t.Errorf("isAllowedInReadOnlyMode(%s, %s) returns %t, expected %t", c.method, c.route,
+				actual, c.expected)
+		}
+	}
+}
+
+func TestReadOnlyFilter(t *testing.T) {
+	defer ConfigureReadOnlyMode(false)
+
+	ws := new(restful.WebService)
+	ws.Filter(readOnlyFilter)
+	ws.Path("/api/v1")
+	handler := func(request *restful.Request, response *restful.Response) {
+		response.WriteHeader(http.StatusOK)
+	}
+	ws.Route(ws.GET("/pod").To(handler))
+	ws.Route(ws.DELETE("/pod").To(handler))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	cases := []struct {
+		readOnly bool
+		header   string
+		method   string
+		expected int
+	}{
+		{false, "", "DELETE", http.StatusOK},
+		{false, "true", "DELETE", http.StatusForbidden},
+		{false, "true", "GET", http.StatusOK},
+		{true, "", "DELETE", http.StatusForbidden},
+		{true, "false", "DELETE", http.StatusForbidden},
+		{true, "", "GET", http.StatusOK},
+	}
+
+	for _, c := range cases {
+		ConfigureReadOnlyMode(c.readOnly)
+		req := httptest.NewRequest(c.method, "/api/v1/pod", nil)
+		if c.header != "" {
+			req.Header.Set(ReadOnlyHeader, c.header)
+		}
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, req)
+
+		if recorder.Code != c.expected {
+			t.Errorf("%s request in read-only mode %t with header %q returns %d, expected %d",
+				c.method, c.readOnly, c.header, recorder.Code, c.expected)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"gopkg.in/igm/sockjs-go.v2/sockjs"
+)
+
+// SockJSOptions is a configuration of SockJS endpoints exposed by the backend.
+type SockJSOptions struct {
+	// HeartbeatDelay controls how often a heartbeat packet is sent to keep proxies and load
+	// balancers from closing long running connections.
+	HeartbeatDelay time.Duration
+	// DisconnectDelay is the time after which a session is closed if the client connection was
+	// not seen.
+	DisconnectDelay time.Duration
+	// WebsocketOnly disables all SockJS transports except of websocket.
+	WebsocketOnly bool
+	// MaxMessageSize is the maximum size in bytes of a single message received from the client.
+	// Zero means no limit.
+	MaxMessageSize int
+}
+
+// DefaultSockJSOptions is a SockJS configuration used if nothing else was provided.
+var DefaultSockJSOptions = SockJSOptions{
+	HeartbeatDelay:  sockjs.DefaultOptions.HeartbeatDelay,
+	DisconnectDelay: sockjs.DefaultOptions.DisconnectDelay,
+	WebsocketOnly:   false,
+	MaxMessageSize:  0,
+}
+
+// sockJSOptions is the configuration used by all SockJS handlers created in this package.
+var sockJSOptions = DefaultSockJSOptions
+
+// toSockJSOptions maps SockJSOptions to the options understood by the SockJS library.
+func (options SockJSOptions) toSockJSOptions() sockjs.Options {
+	result := sockjs.DefaultOptions
+	result.HeartbeatDelay = options.HeartbeatDelay
+	result.DisconnectDelay = options.DisconnectDelay
+	// Websocket transport has to be enabled when it is the only allowed one.
+	result.Websocket = true
+	return result
+}
+
+// checkMessageSize returns an error if the message received from the client exceeds the
+// configured maximum message size.
+func checkMessageSize(message string) error {
+	if sockJSOptions.MaxMessageSize > 0 && len(message) > sockJSOptions.MaxMessageSize {
+		return fmt.Errorf("message size %d exceeds the limit of %d bytes", len(message),
+			sockJSOptions.MaxMessageSize)
+	}
+	return nil
+}
+
+// newSockJSHandler creates a SockJS handler for the given path using the configured options.
+func newSockJSHandler(path string, handleSession func(sockjs.Session)) http.Handler {
+	var sockJSHandler http.Handler = sockjs.NewHandler(path, sockJSOptions.toSockJSOptions(),
+		handleSession)
+	if sockJSOptions.MaxMessageSize > 0 {
+		sockJSHandler = messageSizeLimitHandler(sockJSHandler,
+			maxEncodedMessageSize(sockJSOptions.MaxMessageSize))
+	}
+	if !sockJSOptions.WebsocketOnly {
+		return sockJSHandler
+	}
+
+	return websocketOnlyHandler(path, sockJSHandler)
+}
+
+// maxEncodedMessageSize returns the maximum size of a message encoded by SockJS transports. Messages
+// are sent as JSON arrays of strings and JSON escapes a byte into at most 6 bytes.
+func maxEncodedMessageSize(maxMessageSize int) int64 {
+	return int64(maxMessageSize*6 + len(`[""]`))
+}
+
+// messageSizeLimitHandler limits the size of messages received by SockJS transports, so that
+// messages over the limit are rejected before they are buffered by the SockJS library. Bodies of
+// HTTP requests are limited and so are websocket messages, whose connection is closed by the
+// limit. Decoded messages are still checked by checkMessageSize.
+func messageSizeLimitHandler(sockJSHandler http.Handler, limit int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/websocket") {
+			if hijacker, ok := w.(http.Hijacker); ok {
+				w = &limitedHijacker{ResponseWriter: w, hijacker: hijacker, limit: limit}
+			}
+			sockJSHandler.ServeHTTP(w, r)
+			return
+		}
+
+		if r.ContentLength > limit {
+			http.Error(w, "Message too large.", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if r.Body != nil {
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+		}
+		sockJSHandler.ServeHTTP(w, r)
+	})
+}
+
+// limitedHijacker is a response writer, which limits the size of websocket messages read from the
+// hijacked connection.
+type limitedHijacker struct {
+	http.ResponseWriter
+	hijacker http.Hijacker
+	limit    int64
+}
+
+// Hijack implements http.Hijacker interface.
+func (self *limitedHijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := self.hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	return &limitedWebsocketConn{Conn: conn, limit: self.limit}, rw, nil
+}
+
+// errMessageTooLarge is returned by limitedWebsocketConn when a message exceeds the limit.
+var errMessageTooLarge = errors.New("websocket message exceeds the size limit")
+
+// limitedWebsocketConn is a server side websocket connection, which fails reads when a message sent
+// by the client exceeds the limit. Frame headers are parsed to sum payload lengths of fragments of
+// a message, payloads are passed on untouched.
+type limitedWebsocketConn struct {
+	net.Conn
+	limit int64
+	// header is the incomplete header of the next frame.
+	header []byte
+	// remaining is the number of payload bytes of the current frame not read yet.
+	remaining int64
+	// messageSize is the payload size of the current message received so far.
+	messageSize int64
+}
+
+// Read implements net.Conn interface.
+func (self *limitedWebsocketConn) Read(p []byte) (int, error) {
+	n, err := self.Conn.Read(p)
+	if scanErr := self.scan(p[:n]); scanErr != nil {
+		return 0, scanErr
+	}
+	return n, err
+}
+
+// scan parses frame headers in data read from the client.
+func (self *limitedWebsocketConn) scan(data []byte) error {
+	for len(data) > 0 {
+		if self.remaining > 0 {
+			skipped := self.remaining
+			if int64(len(data)) < skipped {
+				skipped = int64(len(data))
+			}
+			self.remaining -= skipped
+			data = data[skipped:]
+			continue
+		}
+
+		self.header = append(self.header, data[0])
+		data = data[1:]
+		if len(self.header) < 2 || len(self.header) < websocketHeaderSize(self.header) {
+			continue
+		}
+
+		opcode := self.header[0] & 0x0f
+		final := self.header[0]&0x80 != 0
+		length := websocketPayloadLength(self.header)
+		self.header = self.header[:0]
+		self.remaining = length
+		// Control frames can not be fragmented and are not part of messages.
+		if opcode >= 0x8 {
+			continue
+		}
+		// Opcode of continuation frames is zero, other data frames start a new message.
+		if opcode != 0x0 {
+			self.messageSize = 0
+		}
+		self.messageSize += length
+		if length < 0 || self.messageSize > self.limit {
+			return errMessageTooLarge
+		}
+		if final {
+			self.messageSize = 0
+		}
+	}
+	return nil
+}
+
+// websocketHeaderSize returns the size of a frame header sent by the client, which has at least two
+// bytes. Client frames are always masked.
+func websocketHeaderSize(header []byte) int {
+	size := 2
+	switch header[1] & 0x7f {
+	case 126:
+		size += 2
+	case 127:
+		size += 8
+	}
+	if header[1]&0x80 != 0 {
+		size += 4
+	}
+	return size
+}
+
+// websocketPayloadLength returns the payload length of a complete frame header.
+func websocketPayloadLength(header []byte) int64 {
+	switch header[1] & 0x7f {
+	case 126:
+		return int64(binary.BigEndian.Uint16(header[2:4]))
+	case 127:
+		return int64(binary.BigEndian.Uint64(header[2:10]))
+	default:
+		return int64(header[1] & 0x7f)
+	}
+}
+
+// websocketOnlyHandler rejects requests to all SockJS transports except of websocket. Info and
+// welcome endpoints are still served as the client needs them to establish a connection.
+func websocketOnlyHandler(path string, sockJSHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebsocketOnlyPathAllowed(path, r.URL.Path) {
+			sockJSHandler.ServeHTTP(w, r)
+			return
+		}
+		http.NotFound(w, r)
+	})
+}
+
+// isWebsocketOnlyPathAllowed checks if the request path is allowed in the websocket only mode.
+func isWebsocketOnlyPathAllowed(prefix, path string) bool {
+	path = strings.TrimSuffix(path, "/")
+	return path == prefix || path == prefix+"/info" || strings.HasSuffix(path, "/websocket")
+}
+
+// ConfigureSockJS sets the options used by SockJS handlers. It has to be called before any of
+// the handlers is created.
+func ConfigureSockJS(options SockJSOptions) {
+	sockJSOptions = options
+}
@@ -0,0 +1,170 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"gopkg.in/igm/sockjs-go.v2/sockjs"
+)
+
+func TestIsWebsocketOnlyPathAllowed(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected bool
+	}{
+		{"/api/sockjs", true},
+		{"/api/sockjs/", true},
+		{"/api/sockjs/info", true},
+		{"/api/sockjs/123/abcdef/websocket", true},
+		{"/api/sockjs/123/abcdef/xhr_streaming", false},
+		{"/api/sockjs/123/abcdef/eventsource", false},
+	}
+	for _, c := range cases {
+		actual := isWebsocketOnlyPathAllowed("/api/sockjs", c.path)
+		if actual != c.expected {
+			t.Errorf("isWebsocketOnlyPathAllowed(%#v) returns %#v, expected %#v", c.path, actual, c.expected)
+		}
+	}
+}
+
+func TestCheckMessageSize(t *testing.T) {
+	defer ConfigureSockJS(DefaultSockJSOptions)
+
+	cases := []struct {
+		maxMessageSize int
+		message        string
+		expectError    bool
+	}{
+		{0, "some long message", false},
+		{4, "abcd", false},
+		{4, "abcde", true},
+	}
+	for _, c := range cases {
+		ConfigureSockJS(SockJSOptions{MaxMessageSize: c.maxMessageSize})
+		err := checkMessageSize(c.message)
+		if (err != nil) != c.expectError {
+			t.Errorf("checkMessageSize(%#v) with limit %d returns %#v", c.message, c.maxMessageSize, err)
+		}
+	}
+}
+
+// clientFrame returns a masked websocket frame as sent by a client.
+func clientFrame(final bool, opcode byte, payload string) []byte {
+	first := opcode
+	if final {
+		first |= 0x80
+	}
+	frame := []byte{first}
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, 0x80|byte(len(payload)))
+	default:
+		frame = append(frame, 0x80|126, byte(len(payload)>>8), byte(len(payload)))
+	}
+	// Zero mask key leaves the payload as it is.
+	frame = append(frame, 0, 0, 0, 0)
+	return append(frame, payload...)
+}
+
+func TestLimitedWebsocketConnScan(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	cases := []struct {
+		info        string
+		frames      [][]byte
+		expectError bool
+	}{
+		{"small messages", [][]byte{clientFrame(true, 1, "abcd"), clientFrame(true, 1, "efgh")}, false},
+		{"long message", [][]byte{clientFrame(true, 1, long)}, true},
+		{"fragmented message", [][]byte{clientFrame(false, 1, "abc"), clientFrame(true, 0, "de")}, true},
+		{"control frame", [][]byte{clientFrame(true, 9, "abcdefgh"), clientFrame(true, 1, "abcd")},
+			false},
+	}
+	for _, c := range cases {
+		conn := &limitedWebsocketConn{limit: 4}
+		var err error
+		// Data is scanned a byte at a time to split frame headers between reads.
+		for _, frame := range c.frames {
+			for i := range frame {
+				if err == nil {
+					err = conn.scan(frame[i : i+1])
+				}
+			}
+		}
+		if (err != nil) != c.expectError {
+			t.Errorf("Test Case: %s. scan() returns %#v, expected error: %t", c.info, err, c.expectError)
+		}
+	}
+}
+
+func TestMessageSizeLimitHandler(t *testing.T) {
+	defer ConfigureSockJS(DefaultSockJSOptions)
+	ConfigureSockJS(SockJSOptions{HeartbeatDelay: time.Minute, DisconnectDelay: time.Minute,
+		MaxMessageSize: 4})
+
+	received := make(chan string, 1)
+	server := httptest.NewServer(newSockJSHandler("/api/sockjs", func(session sockjs.Session) {
+		if message, err := session.Recv(); err == nil {
+			received <- message
+		}
+	}))
+	defer server.Close()
+
+	response, err := http.Post(server.URL+"/api/sockjs/0/session/xhr_send", "text/plain",
+		strings.NewReader(`["`+strings.Repeat("a", 100)+`"]`))
+	if err != nil {
+		t.Fatalf("Post returned error: %s", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status %d of long xhr message, got %d", http.StatusRequestEntityTooLarge,
+			response.StatusCode)
+	}
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/sockjs/0/session/websocket"
+	send := func(message string) {
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		if err != nil {
+			t.Fatalf("Dial returned error: %s", err)
+		}
+		defer conn.Close()
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(`["`+message+`"]`)); err != nil {
+			t.Fatalf("WriteMessage returned error: %s", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	send("abcd")
+	select {
+	case message := <-received:
+		if message != "abcd" {
+			t.Errorf("Expected message abcd, got %s", message)
+		}
+	default:
+		t.Error("Expected short websocket message to be received")
+	}
+
+	send(strings.Repeat("a", 100))
+	select {
+	case message := <-received:
+		t.Errorf("Expected long websocket message to be rejected, received %d bytes", len(message))
+	default:
+	}
+}
@@ -20,18 +20,74 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
+	"strings"
 
 	restful "github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"gopkg.in/igm/sockjs-go.v2/sockjs"
 	remotecommandconsts "k8s.io/apimachinery/pkg/util/remotecommand"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/client/unversioned/remotecommand"
+	"k8s.io/kubernetes/pkg/util/exec"
 )
 
+// Status codes used when closing the SockJS connection of a terminal session.
+const (
+	// TerminalCloseProcessExited is used when the process started in the container has exited.
+	TerminalCloseProcessExited uint32 = 1
+	// TerminalCloseSetupFailed is used when the process could not be started in the container.
+	TerminalCloseSetupFailed uint32 = 2
+)
+
+// Reasons sent to the client in the exit message. They let the frontend distinguish between
+// a process that finished on its own, a process that was killed, a failed exec setup and
+// a container without any of the shells that were tried.
+const (
+	TerminalExitCompleted    = "Completed"
+	TerminalExitError        = "Error"
+	TerminalExitOOMKilled    = "OOMKilled"
+	TerminalExitSetupFailed  = "SetupFailed"
+	TerminalExitNoShellFound = "NoShellFound"
+)
+
+// Protocols that can be used to stream exec sessions from the API server.
+const (
+	// TerminalProtocolSPDY streams exec sessions over SPDY, the same way as kubectl does.
+	TerminalProtocolSPDY = "spdy"
+	// TerminalProtocolWebSocket streams exec sessions over websocket. It can be used when SPDY
+	// connections to the API server are blocked, e.g. by proxies or egress policies.
+	TerminalProtocolWebSocket = "websocket"
+)
+
+// terminalProtocol is the protocol used to stream exec sessions from the API server.
+var terminalProtocol = TerminalProtocolSPDY
+
+// ConfigureTerminalProtocol sets the protocol used to stream exec sessions from the API server.
+func ConfigureTerminalProtocol(protocol string) error {
+	if protocol != TerminalProtocolSPDY && protocol != TerminalProtocolWebSocket {
+		return fmt.Errorf("unknown terminal protocol '%s'", protocol)
+	}
+	terminalProtocol = protocol
+	return nil
+}
+
+// Exit code of a process killed with SIGKILL, which is what the OOM killer sends.
+const oomKilledExitCode = 137
+
+// Exit codes of a shell that could not be executed, either because it is not executable (126) or
+// it does not exist in the container (127).
+const (
+	notExecutableExitCode = 126
+	notFoundExitCode      = 127
+)
+
+// execFailureMessages are parts of the messages with which container runtimes report that
+// a command could not be executed in the container.
+var execFailureMessages = []string{"executable file not found", "exec failed"}
+
 // PtyHandler is what remotecommand expects from a pty
 type PtyHandler interface {
 	io.Reader
@@ -56,9 +112,12 @@ type TerminalSession struct {
 // resize  fe->be     Rows, Cols     New terminal size
 // stdout  be->fe     Data           Output from the process
 // toast   be->fe     Data           OOB message to be shown to the user
+// exit    be->fe     ExitCode, ...  Process exit code (-1 if setup failed), Reason and message
 type TerminalMessage struct {
 	Op, Data, SessionID string
 	Rows, Cols          uint16
+	ExitCode            int
+	Reason              string
 }
 
 // TerminalSize handles pty->process resize events
@@ -78,6 +137,10 @@ func (t TerminalSession) Read(p []byte) (int, error) {
 		return 0, err
 	}
 
+	if err := checkMessageSize(m); err != nil {
+		return 0, err
+	}
+
 	var msg TerminalMessage
 	if err := json.Unmarshal([]byte(m), &msg); err != nil {
 		return 0, err
@@ -128,6 +191,39 @@ func (t TerminalSession) Toast(p string) error {
 	return nil
 }
 
+// Exit sends the exit status of the process to the client. It should be sent right before the
+// connection is closed.
+func (t TerminalSession) Exit(exitCode int, reason, message string) error {
+	msg, err := json.Marshal(TerminalMessage{
+		Op:       "exit",
+		Data:     message,
+		ExitCode: exitCode,
+		Reason:   reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return t.sockJSSession.Send(string(msg))
+}
+
+// shellStart is PtyHandler that records the first output of a shell, so that a shell that could
+// not be executed can be told apart from one that ran and exited with the same exit code.
+type shellStart struct {
+	PtyHandler
+	output []byte
+	wrote  bool
+}
+
+// Write records the first output of the shell and passes it on.
+func (s *shellStart) Write(p []byte) (int, error) {
+	if !s.wrote {
+		s.output = append([]byte(nil), p...)
+		s.wrote = true
+	}
+	return s.PtyHandler.Write(p)
+}
+
 // Close shuts down the SockJS connection and sends the status code and reason to the client
 // Can happen if the process exits or if there is an error starting up the process
 // For now the status code is unused and reason is shown to the user (unless "")
@@ -150,22 +246,27 @@ func handleTerminalSession(session sockjs.Session) {
 	)
 
 	if buf, err = session.Recv(); err != nil {
-		log.Printf("handleTerminalSession: can't Recv: %v", err)
+		logger.Errorf("handleTerminalSession: can't Recv: %v", err)
+		return
+	}
+
+	if err = checkMessageSize(buf); err != nil {
+		logger.Errorf("handleTerminalSession: %v", err)
 		return
 	}
 
 	if err = json.Unmarshal([]byte(buf), &msg); err != nil {
-		log.Printf("handleTerminalSession: can't UnMarshal (%v): %s", err, buf)
+		logger.Errorf("handleTerminalSession: can't UnMarshal (%v): %s", err, buf)
 		return
 	}
 
 	if msg.Op != "bind" {
-		log.Printf("handleTerminalSession: expected 'bind' message, got: %s", buf)
+		logger.Infof("handleTerminalSession: expected 'bind' message, got: %s", buf)
 		return
 	}
 
 	if terminalSession, ok = terminalSessions[msg.SessionID]; !ok {
-		log.Printf("handleTerminalSession: can't find session '%s'", msg.SessionID)
+		logger.Errorf("handleTerminalSession: can't find session '%s'", msg.SessionID)
 		return
 	}
 
@@ -176,7 +277,7 @@ func handleTerminalSession(session sockjs.Session) {
 
 // CreateAttachHandler is called from main for /api/sockjs
 func CreateAttachHandler(path string) http.Handler {
-	return sockjs.NewHandler(path, sockjs.DefaultOptions, handleTerminalSession)
+	return newSockJSHandler(path, handleTerminalSession)
 }
 
 // startProcess is called by handleAttach
@@ -201,6 +302,10 @@ func startProcess(k8sClient *kubernetes.Clientset, cfg *rest.Config, request *re
 		TTY:       true,
 	}, api.ParameterCodec)
 
+	if terminalProtocol == TerminalProtocolWebSocket {
+		return streamWebSocket(cfg, req.URL(), ptyHandler)
+	}
+
 	exec, err := remotecommand.NewExecutor(cfg, "POST", req.URL())
 	if err != nil {
 		return err
@@ -235,6 +340,82 @@ func genTerminalSessionId() (string, error) {
 	return string(id), nil
 }
 
+// getExitStatus maps the error returned by startProcess to the exit code and reason that are sent
+// to the client. Errors other than exec.ExitError mean that the process could not be started.
+func getExitStatus(err error) (int, string) {
+	if err == nil {
+		return 0, TerminalExitCompleted
+	}
+
+	exitErr, ok := err.(exec.ExitError)
+	if !ok {
+		return -1, TerminalExitSetupFailed
+	}
+
+	switch exitErr.ExitStatus() {
+	case 0:
+		return 0, TerminalExitCompleted
+	case oomKilledExitCode:
+		return oomKilledExitCode, TerminalExitOOMKilled
+	default:
+		return exitErr.ExitStatus(), TerminalExitError
+	}
+}
+
+// getShellExitStatus returns exit code, reason and message sent to the client after the shell
+// exited. Shells, that could not be executed, are reported as not found, as there is no other
+// shell to try.
+func getShellExitStatus(err error, shellMissing bool, triedShells []string) (int, string, string) {
+	if shellMissing {
+		exitCode := -1
+		if exitErr, ok := err.(exec.ExitError); ok {
+			exitCode = exitErr.ExitStatus()
+		}
+		return exitCode, TerminalExitNoShellFound,
+			fmt.Sprintf("No shell found in the container, tried: %s", strings.Join(triedShells, ", "))
+	}
+
+	exitCode, reason := getExitStatus(err)
+	message := "Process exited"
+	if err != nil {
+		message = err.Error()
+	}
+	return exitCode, reason, message
+}
+
+// isShellMissing returns true if the shell could not be executed in the container, so that the
+// next shell should be tried. Output is the first output of the shell. A shell that ran can exit
+// with the same exit codes as one that could not be executed, e.g. after a command that was not
+// found, but it has printed at least a prompt, while container runtimes print nothing or their
+// error message.
+func isShellMissing(err error, output string) bool {
+	if err == nil {
+		return false
+	}
+
+	exitErr, ok := err.(exec.ExitError)
+	if !ok {
+		return isExecFailure(err.Error())
+	}
+
+	code := exitErr.ExitStatus()
+	if code != notExecutableExitCode && code != notFoundExitCode {
+		return false
+	}
+	return output == "" || isExecFailure(output)
+}
+
+// isExecFailure returns true if the message reports that a command could not be executed in the
+// container.
+func isExecFailure(message string) bool {
+	for _, failure := range execFailureMessages {
+		if strings.Contains(message, failure) {
+			return true
+		}
+	}
+	return false
+}
+
 // isValidShell checks if the shell is an allowed one
 func isValidShell(validShells []string, shell string) bool {
 	for _, validShell := range validShells {
@@ -253,29 +434,41 @@ func WaitForTerminal(k8sClient *kubernetes.Clientset, cfg *rest.Config, request
 	select {
 	case <-terminalSessions[sessionId].bound:
 		close(terminalSessions[sessionId].bound)
+		sockJSSessions.WithLabelValues("terminal").Inc()
+		defer sockJSSessions.WithLabelValues("terminal").Dec()
 
 		var err error
+		shellMissing := false
 		validShells := []string{"bash", "sh"}
+		triedShells := validShells
 
 		if isValidShell(validShells, shell) {
-			cmd := []string{shell}
-			err = startProcess(k8sClient, cfg, request, cmd, terminalSessions[sessionId])
-		} else {
-			// No shell given or it was not valid: try some shells until one succeeds or all fail
-			// FIXME: if the first shell fails then the first keyboard event is lost
-			for _, testShell := range validShells {
-				cmd := []string{testShell}
-				if err = startProcess(k8sClient, cfg, request, cmd, terminalSessions[sessionId]); err == nil {
-					break
-				}
+			triedShells = []string{shell}
+		}
+
+		// No shell given or it was not valid: try some shells until one starts or all fail
+		// FIXME: if the first shell fails then the first keyboard event is lost
+		for _, testShell := range triedShells {
+			cmd := []string{testShell}
+			ptyHandler := &shellStart{PtyHandler: terminalSessions[sessionId]}
+			err = startProcess(k8sClient, cfg, request, cmd, ptyHandler)
+			// Shell was started, it is not needed to try the next one
+			if shellMissing = isShellMissing(err, string(ptyHandler.output)); !shellMissing {
+				break
 			}
 		}
 
-		if err != nil {
-			terminalSessions[sessionId].Close(2, err.Error())
+		exitCode, reason, message := getShellExitStatus(err, shellMissing, triedShells)
+
+		if err := terminalSessions[sessionId].Exit(exitCode, reason, message); err != nil {
+			logger.Errorf("WaitForTerminal: can't send exit status: %v", err)
+		}
+
+		if reason == TerminalExitSetupFailed || reason == TerminalExitNoShellFound {
+			terminalSessions[sessionId].Close(TerminalCloseSetupFailed, message)
 			return
 		}
 
-		terminalSessions[sessionId].Close(1, "Process exited")
+		terminalSessions[sessionId].Close(TerminalCloseProcessExited, message)
 	}
 }
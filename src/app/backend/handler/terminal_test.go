@@ -0,0 +1,131 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/client/unversioned/remotecommand"
+	"k8s.io/kubernetes/pkg/util/exec"
+)
+
+func TestGetExitStatus(t *testing.T) {
+	cases := []struct {
+		err              error
+		expectedExitCode int
+		expectedReason   string
+	}{
+		{nil, 0, TerminalExitCompleted},
+		{exec.CodeExitError{Err: errors.New("exit code 1"), Code: 1}, 1, TerminalExitError},
+		{exec.CodeExitError{Err: errors.New("exit code 137"), Code: 137}, 137, TerminalExitOOMKilled},
+		{errors.New("container not found"), -1, TerminalExitSetupFailed},
+	}
+	for _, c := range cases {
+		exitCode, reason := getExitStatus(c.err)
+		if exitCode != c.expectedExitCode || reason != c.expectedReason {
+			t.Errorf("getExitStatus(%#v) returns (%d, %s), expected (%d, %s)", c.err, exitCode, reason,
+				c.expectedExitCode, c.expectedReason)
+		}
+	}
+}
+
+func TestGetShellExitStatus(t *testing.T) {
+	shells := []string{"bash", "sh"}
+	cases := []struct {
+		err              error
+		shellMissing     bool
+		expectedExitCode int
+		expectedReason   string
+		expectedMessage  string
+	}{
+		{nil, false, 0, TerminalExitCompleted, "Process exited"},
+		{exec.CodeExitError{Err: errors.New("exit code 1"), Code: 1}, false, 1, TerminalExitError,
+			"exit code 1"},
+		{exec.CodeExitError{Err: errors.New("exit code 127"), Code: 127}, true, 127,
+			TerminalExitNoShellFound, "No shell found in the container, tried: bash, sh"},
+		{exec.CodeExitError{Err: errors.New("exit code 127"), Code: 127}, false, 127,
+			TerminalExitError, "exit code 127"},
+		{errors.New("executable file not found in $PATH"), true, -1, TerminalExitNoShellFound,
+			"No shell found in the container, tried: bash, sh"},
+		{errors.New("container not found"), false, -1, TerminalExitSetupFailed,
+			"container not found"},
+	}
+	for _, c := range cases {
+		exitCode, reason, message := getShellExitStatus(c.err, c.shellMissing, shells)
+		if exitCode != c.expectedExitCode || reason != c.expectedReason ||
+			message != c.expectedMessage {
+			t.Errorf("getShellExitStatus(%#v, %t) returns (%d, %s, %s), expected (%d, %s, %s)",
+				c.err, c.shellMissing, exitCode, reason, message, c.expectedExitCode,
+				c.expectedReason, c.expectedMessage)
+		}
+	}
+}
+
+func TestIsShellMissing(t *testing.T) {
+	execFailure := "OCI runtime exec failed: exec failed: starting container process caused: " +
+		"exec: bash: executable file not found in $PATH"
+	cases := []struct {
+		err      error
+		output   string
+		expected bool
+	}{
+		{nil, "", false},
+		{exec.CodeExitError{Err: errors.New("exit code 1"), Code: 1}, "", false},
+		{exec.CodeExitError{Err: errors.New("exit code 137"), Code: 137}, "", false},
+		{exec.CodeExitError{Err: errors.New("exit code 126"), Code: 126}, "", true},
+		{exec.CodeExitError{Err: errors.New("exit code 127"), Code: 127}, "", true},
+		{exec.CodeExitError{Err: errors.New("exit code 126"), Code: 126}, execFailure, true},
+		// Shell that ran, e.g. user typed unknown command and exit.
+		{exec.CodeExitError{Err: errors.New("exit code 127"), Code: 127}, "root@pod:/# ", false},
+		{errors.New("executable file not found in $PATH"), "", true},
+		{errors.New("stream closed"), "", false},
+	}
+	for _, c := range cases {
+		if actual := isShellMissing(c.err, c.output); actual != c.expected {
+			t.Errorf("isShellMissing(%#v, %q) returns %t, expected %t", c.err, c.output, actual,
+				c.expected)
+		}
+	}
+}
+
+func TestShellStart(t *testing.T) {
+	session := &fakePtyHandler{}
+	ptyHandler := &shellStart{PtyHandler: session}
+	ptyHandler.Write([]byte("root@pod:/# "))
+	ptyHandler.Write([]byte("exit"))
+
+	if string(ptyHandler.output) != "root@pod:/# " {
+		t.Errorf("shellStart records %q output, expected %q", ptyHandler.output, "root@pod:/# ")
+	}
+	if session.output != "root@pod:/# exit" {
+		t.Errorf("shellStart passes %q output, expected %q", session.output, "root@pod:/# exit")
+	}
+}
+
+// fakePtyHandler is PtyHandler that records written output.
+type fakePtyHandler struct {
+	output string
+}
+
+func (f *fakePtyHandler) Read(p []byte) (int, error) { return 0, io.EOF }
+
+func (f *fakePtyHandler) Write(p []byte) (int, error) {
+	f.output += string(p)
+	return len(p), nil
+}
+
+func (f *fakePtyHandler) Next() *remotecommand.TerminalSize { return nil }
@@ -0,0 +1,216 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	remotecommandconsts "k8s.io/apimachinery/pkg/util/remotecommand"
+	"k8s.io/client-go/rest"
+	"k8s.io/kubernetes/pkg/util/exec"
+)
+
+// Channels multiplexed over a single websocket connection by the API server. The first byte of
+// every message identifies the channel.
+const (
+	stdinChannel byte = iota
+	stdoutChannel
+	stderrChannel
+	errorChannel
+	resizeChannel
+)
+
+// Size of a buffer used to read user input from the terminal session.
+const stdinBufferSize = 32 * 1024
+
+// webSocketProtocols are the exec subprotocols supported over websocket, the preferred one first.
+// Only v4 supports terminal resizing and structured exit codes.
+var webSocketProtocols = []string{
+	remotecommandconsts.StreamProtocolV4Name,
+	remotecommandconsts.StreamProtocolV1Name,
+}
+
+// streamWebSocket executes the exec request using websocket connection to the API server instead
+// of SPDY. This works through proxies and API server aggregation layers that only support
+// websocket upgrades.
+func streamWebSocket(cfg *rest.Config, execURL *url.URL, ptyHandler PtyHandler) error {
+	tlsConfig, err := rest.TLSConfigFor(cfg)
+	if err != nil {
+		return err
+	}
+
+	dialer := websocket.Dialer{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+		Subprotocols:    webSocketProtocols,
+	}
+
+	header, err := getWebSocketHeaders(cfg)
+	if err != nil {
+		return err
+	}
+	conn, _, err := dialer.Dial(toWebSocketURL(execURL).String(), header)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	isV4 := conn.Subprotocol() == remotecommandconsts.StreamProtocolV4Name
+	writeLock := sync.Mutex{}
+	write := func(channel byte, data []byte) error {
+		writeLock.Lock()
+		defer writeLock.Unlock()
+		return conn.WriteMessage(websocket.BinaryMessage, append([]byte{channel}, data...))
+	}
+
+	go func() {
+		buffer := make([]byte, stdinBufferSize)
+		for {
+			n, err := ptyHandler.Read(buffer)
+			if err != nil {
+				return
+			}
+			if n > 0 && write(stdinChannel, buffer[:n]) != nil {
+				return
+			}
+		}
+	}()
+
+	if isV4 {
+		go func() {
+			for size := ptyHandler.Next(); size != nil; size = ptyHandler.Next() {
+				data, err := json.Marshal(size)
+				if err != nil || write(resizeChannel, data) != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return nil
+			}
+			return err
+		}
+
+		if len(message) == 0 {
+			continue
+		}
+
+		switch message[0] {
+		case stdoutChannel, stderrChannel:
+			if _, err := ptyHandler.Write(message[1:]); err != nil {
+				return err
+			}
+		case errorChannel:
+			return decodeWebSocketError(message[1:], isV4)
+		}
+	}
+}
+
+// toWebSocketURL changes the scheme of the exec URL to the corresponding websocket scheme.
+func toWebSocketURL(execURL *url.URL) *url.URL {
+	result := *execURL
+	if result.Scheme == "https" {
+		result.Scheme = "wss"
+	} else {
+		result.Scheme = "ws"
+	}
+	return &result
+}
+
+// headerRecorder is a round tripper that records headers of the request instead of sending it.
+type headerRecorder struct {
+	header http.Header
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (self *headerRecorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	self.header = request.Header
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: request}, nil
+}
+
+// getWebSocketHeaders returns headers for the websocket handshake. They are collected by passing
+// a request through the same wrappers client library uses for other requests, so that bearer
+// token, basic auth and impersonation of the user all apply to the exec session. Client
+// certificates are handled by the TLS config.
+func getWebSocketHeaders(cfg *rest.Config) (http.Header, error) {
+	recorder := &headerRecorder{}
+	roundTripper, err := rest.HTTPWrappersForConfig(cfg, recorder)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest(http.MethodGet, cfg.Host, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := roundTripper.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	response.Body.Close()
+	return recorder.header, nil
+}
+
+// decodeWebSocketError maps a message from the error channel to an error. In v4 protocol it is a
+// JSON encoded status which carries the exit code of the process, older protocols send plain text.
+func decodeWebSocketError(message []byte, isV4 bool) error {
+	if !isV4 {
+		if len(message) == 0 {
+			return nil
+		}
+		return errors.New(string(message))
+	}
+
+	status := metav1.Status{}
+	if err := json.Unmarshal(message, &status); err != nil {
+		return fmt.Errorf("error stream protocol error: %v in %q", err, string(message))
+	}
+
+	if status.Status == metav1.StatusSuccess {
+		return nil
+	}
+
+	if status.Reason == remotecommandconsts.NonZeroExitCodeReason && status.Details != nil {
+		for _, cause := range status.Details.Causes {
+			if cause.Type != remotecommandconsts.ExitCodeCauseType {
+				continue
+			}
+
+			code, err := strconv.Atoi(cause.Message)
+			if err != nil {
+				return fmt.Errorf("error stream protocol error: invalid exit code value %q", cause.Message)
+			}
+			return exec.CodeExitError{
+				Err:  fmt.Errorf("command terminated with exit code %d", code),
+				Code: code,
+			}
+		}
+	}
+
+	return errors.New(status.Message)
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/kubernetes/pkg/util/exec"
+)
+
+func TestDecodeWebSocketError(t *testing.T) {
+	cases := []struct {
+		message  string
+		isV4     bool
+		expected error
+	}{
+		{"", false, nil},
+		{`{"status":"Success"}`, true, nil},
+		{`{"status":"Failure","message":"container not found"}`, true, errors.New("container not found")},
+		{`{"status":"Failure","reason":"NonZeroExitCode","details":{"causes":[{"reason":"ExitCode","message":"137"}]}}`,
+			true, exec.CodeExitError{Code: 137}},
+		{"container not found", false, errors.New("container not found")},
+	}
+	for _, c := range cases {
+		actual := decodeWebSocketError([]byte(c.message), c.isV4)
+		switch expected := c.expected.(type) {
+		case nil:
+			if actual != nil {
+				t.Errorf("decodeWebSocketError(%#v) returns %#v, expected nil", c.message, actual)
+			}
+		case exec.CodeExitError:
+			exitErr, ok := actual.(exec.CodeExitError)
+			if !ok || exitErr.Code != expected.Code {
+				t.Errorf("decodeWebSocketError(%#v) returns %#v, expected exit code %d", c.message, actual,
+					expected.Code)
+			}
+		default:
+			if actual == nil || actual.Error() != expected.Error() {
+				t.Errorf("decodeWebSocketError(%#v) returns %#v, expected %#v", c.message, actual, expected)
+			}
+		}
+	}
+}
+
+func TestToWebSocketURL(t *testing.T) {
+	cases := []struct {
+		url, expected string
+	}{
+		{"https://10.0.0.1:443/api/v1/namespaces/default/pods/p/exec", "wss://10.0.0.1:443/api/v1/namespaces/default/pods/p/exec"},
+		{"http://localhost:8080/api/v1/namespaces/default/pods/p/exec", "ws://localhost:8080/api/v1/namespaces/default/pods/p/exec"},
+	}
+	for _, c := range cases {
+		u, _ := url.Parse(c.url)
+		actual := toWebSocketURL(u).String()
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("toWebSocketURL(%#v) returns %#v, expected %#v", c.url, actual, c.expected)
+		}
+	}
+}
+
+func TestGetWebSocketHeaders(t *testing.T) {
+	cases := []struct {
+		cfg      *rest.Config
+		expected map[string][]string
+	}{
+		{
+			&rest.Config{Host: "https://10.0.0.1", BearerToken: "token"},
+			map[string][]string{"Authorization": {"Bearer token"}},
+		},
+		{
+			&rest.Config{Host: "https://10.0.0.1", Username: "admin", Password: "secret"},
+			map[string][]string{
+				"Authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte("admin:secret"))},
+			},
+		},
+		{
+			&rest.Config{
+				Host:        "https://10.0.0.1",
+				BearerToken: "dashboard-token",
+				Impersonate: rest.ImpersonationConfig{
+					UserName: "jane",
+					Groups:   []string{"developers", "interns"},
+					Extra:    map[string][]string{"scopes": {"view"}},
+				},
+			},
+			map[string][]string{
+				"Authorization":            {"Bearer dashboard-token"},
+				"Impersonate-User":         {"jane"},
+				"Impersonate-Group":        {"developers", "interns"},
+				"Impersonate-Extra-Scopes": {"view"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := getWebSocketHeaders(c.cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, values := range c.expected {
+			if !reflect.DeepEqual(actual[name], values) {
+				t.Errorf("getWebSocketHeaders(%#v) has %s header %v, expected %v", c.cfg, name, actual[name],
+					values)
+			}
+		}
+	}
+}
@@ -0,0 +1,627 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/node"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/rollout"
+	"gopkg.in/igm/sockjs-go.v2/sockjs"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	apps "k8s.io/client-go/pkg/apis/apps/v1beta1"
+	batch "k8s.io/client-go/pkg/apis/batch/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/transport"
+)
+
+const (
+	// WatchCloseFailed is used when closing the SockJS connection of a watch session, because the
+	// watch or the operation reporting progress failed.
+	WatchCloseFailed uint32 = 1
+	// WatchCloseFinished is used when closing the SockJS connection of a watch session after the
+	// operation reporting progress finished.
+	WatchCloseFinished uint32 = 2
+)
+
+// watchBindTimeout is the time client has to open the SockJS connection after the session was
+// created.
+const watchBindTimeout = 30 * time.Second
+
+// eventRateWindow is the interval in which event rates are sent to event stream sessions.
+const eventRateWindow = 10 * time.Second
+
+// watchKind describes how to watch a single kind of resources.
+type watchKind struct {
+	// getter returns REST client of the API group the kind belongs to.
+	getter func(client *kubernetes.Clientset) cache.Getter
+	// resource is the plural resource name used in the API path.
+	resource string
+	// object is an empty object of the kind expected by the informer.
+	object runtime.Object
+	// namespaced is false for cluster scoped resources, which are always watched in all namespaces.
+	namespaced bool
+}
+
+func coreGetter(client *kubernetes.Clientset) cache.Getter {
+	return client.CoreV1().RESTClient()
+}
+
+func appsGetter(client *kubernetes.Clientset) cache.Getter {
+	return client.AppsV1beta1().RESTClient()
+}
+
+func batchGetter(client *kubernetes.Clientset) cache.Getter {
+	return client.BatchV1().RESTClient()
+}
+
+func extensionsGetter(client *kubernetes.Clientset) cache.Getter {
+	return client.ExtensionsV1beta1().RESTClient()
+}
+
+// watchKinds are kinds that can be watched, keyed by the name used in the API path.
+var watchKinds = map[string]watchKind{
+	"pod":                   {coreGetter, "pods", &v1.Pod{}, true},
+	"replicationcontroller": {coreGetter, "replicationcontrollers", &v1.ReplicationController{}, true},
+	"service":               {coreGetter, "services", &v1.Service{}, true},
+	"configmap":             {coreGetter, "configmaps", &v1.ConfigMap{}, true},
+	"persistentvolumeclaim": {coreGetter, "persistentvolumeclaims", &v1.PersistentVolumeClaim{}, true},
+	"event":                 {coreGetter, "events", &v1.Event{}, true},
+	"node":                  {coreGetter, "nodes", &v1.Node{}, false},
+	"namespace":             {coreGetter, "namespaces", &v1.Namespace{}, false},
+	"persistentvolume":      {coreGetter, "persistentvolumes", &v1.PersistentVolume{}, false},
+	"statefulset":           {appsGetter, "statefulsets", &apps.StatefulSet{}, true},
+	"job":                   {batchGetter, "jobs", &batch.Job{}, true},
+	"deployment":            {extensionsGetter, "deployments", &extensions.Deployment{}, true},
+	"replicaset":            {extensionsGetter, "replicasets", &extensions.ReplicaSet{}, true},
+	"daemonset":             {extensionsGetter, "daemonsets", &extensions.DaemonSet{}, true},
+	"ingress":               {extensionsGetter, "ingresses", &extensions.Ingress{}, true},
+}
+
+// WatchSession sends events of a resource watch to the client over a SockJS connection.
+type WatchSession struct {
+	id            string
+	bound         chan error
+	sockJSSession sockjs.Session
+	// filter decides which watch events are sent to the client. All are sent if it is nil.
+	filter func(eventType watch.EventType, object runtime.Object) bool
+	// sendMux is held by the watch hub while sending events, so that objects known when the session
+	// subscribed are sent before events broadcast after that.
+	sendMux sync.Mutex
+}
+
+// WatchMessage is the messaging protocol between frontend and WatchSession.
+//
+// OP        DIRECTION  FIELD(S) USED  DESCRIPTION
+// ---------------------------------------------------------------------
+// bind      fe->be     SessionID      Id sent back from WatchResponse
+// event     be->fe     Type, Object   Resource was ADDED, MODIFIED or DELETED
+// progress  be->fe     Data           Progress of a long running operation, e.g. node drain or rollout
+// rate      be->fe     Data           Event rates counted by an event stream session
+type WatchMessage struct {
+	Op        string          `json:"Op"`
+	SessionID string          `json:"SessionID,omitempty"`
+	Type      watch.EventType `json:"Type,omitempty"`
+	Object    runtime.Object  `json:"Object,omitempty"`
+	Data      interface{}     `json:"Data,omitempty"`
+}
+
+// WatchResponse is sent by handleWatch. The Id is a random session id that binds the original
+// REST request and the SockJS connection.
+type WatchResponse struct {
+	Id string `json:"id"`
+}
+
+// watchSessions stores all watch sessions that were not bound yet or are in progress.
+var watchSessions = struct {
+	sync.Mutex
+	sessions map[string]*WatchSession
+}{sessions: make(map[string]*WatchSession)}
+
+// newWatchSession creates and registers a new watch session.
+func newWatchSession() (*WatchSession, error) {
+	sessionId, err := genTerminalSessionId()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &WatchSession{id: sessionId, bound: make(chan error, 1)}
+	watchSessions.Lock()
+	watchSessions.sessions[sessionId] = session
+	sockJSSessions.WithLabelValues("watch").Set(float64(len(watchSessions.sessions)))
+	watchSessions.Unlock()
+	return session, nil
+}
+
+// removeWatchSession unregisters the watch session.
+func removeWatchSession(sessionId string) {
+	watchSessions.Lock()
+	delete(watchSessions.sessions, sessionId)
+	sockJSSessions.WithLabelValues("watch").Set(float64(len(watchSessions.sessions)))
+	watchSessions.Unlock()
+}
+
+// Send sends a single watch event to the client.
+func (s *WatchSession) Send(eventType watch.EventType, object runtime.Object) error {
+	if s.filter != nil && !s.filter(eventType, object) {
+		return nil
+	}
+
+	msg, err := json.Marshal(WatchMessage{
+		Op:     "event",
+		Type:   eventType,
+		Object: object,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.sockJSSession.Send(string(msg))
+}
+
+// SendProgress sends progress of a long running operation to the client.
+func (s *WatchSession) SendProgress(data interface{}) error {
+	msg, err := json.Marshal(WatchMessage{
+		Op:   "progress",
+		Data: data,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.sockJSSession.Send(string(msg))
+}
+
+// SendRates sends event rates counted by an event stream session to the client.
+func (s *WatchSession) SendRates(rates event.EventRates) error {
+	msg, err := json.Marshal(WatchMessage{
+		Op:   "rate",
+		Data: rates,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.sockJSSession.Send(string(msg))
+}
+
+// resourceWatch is a single informer shared by all sessions watching the same kind of resources
+// in the same namespace with the same credentials.
+type resourceWatch struct {
+	sync.Mutex
+	// kind of watched resources.
+	kind        string
+	informer    cache.SharedIndexInformer
+	stop        chan struct{}
+	subscribers map[string]*WatchSession
+}
+
+// broadcast sends the event to all subscribers of the watch.
+func (self *resourceWatch) broadcast(eventType watch.EventType, obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	object, ok := obj.(runtime.Object)
+	if !ok {
+		return
+	}
+
+	self.Lock()
+	subscribers := make([]*WatchSession, 0, len(self.subscribers))
+	for _, session := range self.subscribers {
+		subscribers = append(subscribers, session)
+	}
+	self.Unlock()
+
+	for _, session := range subscribers {
+		session.sendMux.Lock()
+		if err := session.Send(eventType, object); err != nil {
+			logger.Errorf("Error while sending watch event to session '%s': %v", session.id, err)
+		}
+		session.sendMux.Unlock()
+	}
+}
+
+// WatchHub multiplexes Kubernetes watches between browser sessions, so that every kind of
+// resources is watched only once per namespace and user regardless of how many views display it.
+type WatchHub struct {
+	sync.Mutex
+	watches map[string]*resourceWatch
+	// newListWatch creates list watch for given kind and namespace. Replaced in tests.
+	newListWatch func(client *kubernetes.Clientset, kind watchKind, namespace string) cache.ListerWatcher
+}
+
+// NewWatchHub creates watch hub that watches resources using the apiserver.
+func NewWatchHub() *WatchHub {
+	return &WatchHub{
+		watches: make(map[string]*resourceWatch),
+		newListWatch: func(client *kubernetes.Clientset, kind watchKind, namespace string) cache.ListerWatcher {
+			return cache.NewListWatchFromClient(kind.getter(client), kind.resource, namespace,
+				fields.Everything())
+		},
+	}
+}
+
+// watchHub is the hub used by all watch sessions.
+var watchHub = NewWatchHub()
+
+// Subscribe adds the session to subscribers of the watch identified by the key. A new informer
+// is started if the session is the first subscriber, after the resources were listed successfully,
+// so that a client without access fails instead of an informer retrying forever. Objects that are
+// already known to the informer are sent to the session as ADDED events.
+func (self *WatchHub) Subscribe(client *kubernetes.Clientset, key, kindName, namespace string,
+	session *WatchSession) error {
+	kind, ok := watchKinds[kindName]
+	if !ok {
+		return fmt.Errorf("unsupported kind: %s", kindName)
+	}
+	if !kind.namespaced {
+		namespace = v1.NamespaceAll
+	}
+
+	self.Lock()
+	_, ok = self.watches[key]
+	self.Unlock()
+	var listWatch cache.ListerWatcher
+	if !ok {
+		listWatch = self.newListWatch(client, kind, namespace)
+		if _, err := listWatch.List(metaV1.ListOptions{}); err != nil &&
+			(errorsK8s.IsUnauthorized(err) || errorsK8s.IsForbidden(err)) {
+			return err
+		}
+	}
+
+	self.Lock()
+	resWatch, ok := self.watches[key]
+	if !ok {
+		if listWatch == nil {
+			// The watch was stopped after it was looked up, its credentials were verified already.
+			listWatch = self.newListWatch(client, kind, namespace)
+		}
+		resWatch = self.startWatch(kindName, kind, listWatch)
+		self.watches[key] = resWatch
+	}
+
+	resWatch.Lock()
+	self.Unlock()
+	if _, ok := resWatch.subscribers[session.id]; !ok {
+		watchHubSubscribers.WithLabelValues(resWatch.kind).Inc()
+	}
+	resWatch.subscribers[session.id] = session
+	objects := resWatch.informer.GetStore().List()
+	// Events broadcast after the session subscribed wait until the objects are sent.
+	session.sendMux.Lock()
+	resWatch.Unlock()
+
+	defer session.sendMux.Unlock()
+	for _, obj := range objects {
+		if object, ok := obj.(runtime.Object); ok {
+			if err := session.Send(watch.Added, object); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// startWatch creates and starts an informer of the kind, which broadcasts its events to
+// subscribers of the returned watch.
+func (self *WatchHub) startWatch(kindName string, kind watchKind,
+	listWatch cache.ListerWatcher) *resourceWatch {
+	resWatch := &resourceWatch{
+		kind:        kindName,
+		informer:    cache.NewSharedIndexInformer(listWatch, kind.object, 0, cache.Indexers{}),
+		stop:        make(chan struct{}),
+		subscribers: make(map[string]*WatchSession),
+	}
+	resWatch.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			resWatch.broadcast(watch.Added, obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			resWatch.broadcast(watch.Modified, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			resWatch.broadcast(watch.Deleted, obj)
+		},
+	})
+	go resWatch.informer.Run(resWatch.stop)
+	return resWatch
+}
+
+// Unsubscribe removes the session from subscribers of the watch identified by the key. The
+// informer is stopped when the last subscriber leaves.
+func (self *WatchHub) Unsubscribe(key string, session *WatchSession) {
+	self.Lock()
+	defer self.Unlock()
+
+	resWatch, ok := self.watches[key]
+	if !ok {
+		return
+	}
+
+	resWatch.Lock()
+	if _, ok := resWatch.subscribers[session.id]; ok {
+		watchHubSubscribers.WithLabelValues(resWatch.kind).Dec()
+	}
+	delete(resWatch.subscribers, session.id)
+	empty := len(resWatch.subscribers) == 0
+	resWatch.Unlock()
+
+	if empty {
+		close(resWatch.stop)
+		delete(self.watches, key)
+	}
+}
+
+// getWatchKey returns key of the watch shared by requests with the same effective identity that
+// watch the same kind of resources in the same namespace of the same cluster. The identity is made of
+// the authorization header, credentials of the session, e.g. client certificate, and the impersonated
+// user, groups and extras. It is hashed, so that credentials are not kept in memory in plain text.
+func getWatchKey(request *restful.Request, kind, namespace string) string {
+	identity := struct {
+		Authorization string
+		AuthInfo      interface{}
+		Impersonate   map[string][]string
+	}{
+		Authorization: request.HeaderParameter("Authorization"),
+		AuthInfo:      request.Attribute(client.AuthInfoAttribute),
+		Impersonate:   map[string][]string{},
+	}
+	for name, values := range request.Request.Header {
+		if name == transport.ImpersonateUserHeader || name == transport.ImpersonateGroupHeader ||
+			strings.HasPrefix(name, transport.ImpersonateUserExtraHeaderPrefix) {
+			identity.Impersonate[name] = values
+		}
+	}
+
+	// Maps are marshalled with sorted keys, so the same identity always results in the same key.
+	marshalled, _ := json.Marshal(identity)
+	hash := sha256.Sum256(marshalled)
+	return fmt.Sprintf("%s/%s/%s/%s", request.QueryParameter(client.ClusterParameter),
+		hex.EncodeToString(hash[:]), kind, namespace)
+}
+
+// handleWatchSession is called by net/http for any new /api/sockjs/watch connections.
+func handleWatchSession(session sockjs.Session) {
+	buf, err := session.Recv()
+	if err != nil {
+		logger.Errorf("handleWatchSession: can't Recv: %v", err)
+		return
+	}
+
+	var msg WatchMessage
+	if err := checkMessageSize(buf); err != nil {
+		logger.Errorf("handleWatchSession: %v", err)
+		return
+	}
+
+	if err := json.Unmarshal([]byte(buf), &msg); err != nil {
+		logger.Errorf("handleWatchSession: can't UnMarshal (%v): %s", err, buf)
+		return
+	}
+
+	if msg.Op != "bind" {
+		logger.Infof("handleWatchSession: expected 'bind' message, got: %s", buf)
+		return
+	}
+
+	watchSessions.Lock()
+	watchSession, ok := watchSessions.sessions[msg.SessionID]
+	if ok && watchSession.sockJSSession == nil {
+		watchSession.sockJSSession = session
+		watchSession.bound <- nil
+	}
+	watchSessions.Unlock()
+
+	if !ok {
+		logger.Errorf("handleWatchSession: can't find session '%s'", msg.SessionID)
+	}
+}
+
+// CreateWatchHandler is called from main for /api/sockjs/watch.
+func CreateWatchHandler(path string) http.Handler {
+	return newSockJSHandler(path, handleWatchSession)
+}
+
+// validateWatchKind returns bad request error if the kind can not be watched.
+func validateWatchKind(kind string) error {
+	if _, ok := watchKinds[kind]; !ok {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("unsupported kind: %s", kind))
+	}
+	return nil
+}
+
+// WaitForWatch is called from apihandler.handleWatch as a goroutine. Waits for the SockJS
+// connection to be opened by the client, subscribes it to the watch hub and keeps it subscribed
+// until the client disconnects.
+func WaitForWatch(k8sClient *kubernetes.Clientset, request *restful.Request, session *WatchSession) {
+	defer removeWatchSession(session.id)
+
+	select {
+	case <-session.bound:
+	case <-time.After(watchBindTimeout):
+		logger.Warningf("WaitForWatch: session '%s' was not bound in time", session.id)
+		return
+	}
+
+	kind := request.PathParameter("kind")
+	namespace := request.PathParameter("namespace")
+	key := getWatchKey(request, kind, namespace)
+
+	if err := watchHub.Subscribe(k8sClient, key, kind, namespace, session); err != nil {
+		watchHub.Unsubscribe(key, session)
+		session.sockJSSession.Close(WatchCloseFailed, err.Error())
+		return
+	}
+	defer watchHub.Unsubscribe(key, session)
+
+	// Client does not send anything after bind, so this only returns when it disconnects.
+	for {
+		if _, err := session.sockJSSession.Recv(); err != nil {
+			return
+		}
+	}
+}
+
+// WaitForDrain is called from apihandler.handleDrainNode as a goroutine. Waits for the SockJS
+// connection to be opened by the client and drains the node, sending drain events as progress
+// messages. The node is drained even if the client does not connect in time, as the drain was
+// already requested.
+func WaitForDrain(k8sClient kubernetes.Interface, name string, options *node.DrainOptions,
+	session *WatchSession) {
+	defer removeWatchSession(session.id)
+
+	bound := true
+	select {
+	case <-session.bound:
+	case <-time.After(watchBindTimeout):
+		logger.Warningf("WaitForDrain: session '%s' was not bound in time", session.id)
+		bound = false
+	}
+
+	err := node.DrainNode(k8sClient, name, options, func(event node.DrainEvent) {
+		if !bound {
+			return
+		}
+		if err := session.SendProgress(event); err != nil {
+			logger.Errorf("Error while sending drain event to session '%s': %v", session.id, err)
+		}
+	})
+
+	if err != nil {
+		logger.Errorf("Error while draining %s node: %v", name, err)
+		if bound {
+			session.sockJSSession.Close(WatchCloseFailed, err.Error())
+		}
+		return
+	}
+
+	if bound {
+		session.sockJSSession.Close(WatchCloseFinished, "Node drained")
+	}
+}
+
+// WaitForRollout is called from apihandler.handleSetImage as a goroutine. Waits for the SockJS
+// connection to be opened by the client and sends rollout progress of the workload as progress
+// messages until the rollout finishes.
+func WaitForRollout(k8sClient kubernetes.Interface, kind api.ResourceKind, namespace, name string,
+	session *WatchSession) {
+	defer removeWatchSession(session.id)
+
+	select {
+	case <-session.bound:
+	case <-time.After(watchBindTimeout):
+		logger.Warningf("WaitForRollout: session '%s' was not bound in time", session.id)
+		return
+	}
+
+	err := rollout.WaitForRollout(k8sClient, kind, namespace, name, func(progress rollout.RolloutProgress) {
+		if err := session.SendProgress(progress); err != nil {
+			logger.Errorf("Error while sending rollout progress to session '%s': %v", session.id, err)
+		}
+	})
+
+	if err != nil {
+		logger.Errorf("Error while watching rollout of %s %s: %v", kind, name, err)
+		session.sockJSSession.Close(WatchCloseFailed, err.Error())
+		return
+	}
+	session.sockJSSession.Close(WatchCloseFinished, "Rollout finished")
+}
+
+// WaitForEventStream is called from apihandler.handleEventStream as a goroutine. Waits for the
+// SockJS connection to be opened by the client and streams events matching the filter to it.
+// Rates of matching events are sent periodically until the client disconnects.
+func WaitForEventStream(k8sClient *kubernetes.Clientset, request *restful.Request,
+	filter event.EventStreamFilter, session *WatchSession) {
+	defer removeWatchSession(session.id)
+
+	select {
+	case <-session.bound:
+	case <-time.After(watchBindTimeout):
+		logger.Warningf("WaitForEventStream: session '%s' was not bound in time", session.id)
+		return
+	}
+
+	counter := event.NewEventRateCounter(time.Now())
+	session.filter = func(eventType watch.EventType, object runtime.Object) bool {
+		e, ok := object.(*v1.Event)
+		if !ok || !filter.Matches(e) {
+			return false
+		}
+
+		if eventType == watch.Deleted {
+			counter.Remove(e)
+		} else {
+			counter.Add(e)
+		}
+		return true
+	}
+
+	namespace := request.PathParameter("namespace")
+	key := getWatchKey(request, api.ResourceKindEvent, namespace)
+	if err := watchHub.Subscribe(k8sClient, key, api.ResourceKindEvent, namespace, session); err != nil {
+		watchHub.Unsubscribe(key, session)
+		session.sockJSSession.Close(WatchCloseFailed, err.Error())
+		return
+	}
+	defer watchHub.Unsubscribe(key, session)
+
+	// Client does not send anything after bind, so this only returns when it disconnects.
+	disconnected := make(chan struct{})
+	go func() {
+		for {
+			if _, err := session.sockJSSession.Recv(); err != nil {
+				close(disconnected)
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(eventRateWindow)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := session.SendRates(counter.Flush(now)); err != nil {
+				logger.Errorf("Error while sending event rates to session '%s': %v", session.id, err)
+			}
+		case <-disconnected:
+			return
+		}
+	}
+}
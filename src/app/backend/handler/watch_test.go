@@ -0,0 +1,278 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// watchEvent is a simplified event received by the client.
+type watchEvent struct {
+	Type watch.EventType
+	Name string
+}
+
+// receivedWatchEvents decodes events sent to the session.
+func receivedWatchEvents(t *testing.T, session *fakeSockJSSession) []watchEvent {
+	events := []watchEvent{}
+	for _, sent := range session.messages() {
+		msg := struct {
+			Op     string
+			Type   watch.EventType
+			Object v1.Pod
+		}{}
+		if err := json.Unmarshal([]byte(sent), &msg); err != nil || msg.Op != "event" {
+			t.Fatalf("invalid watch message sent: %#v", sent)
+		}
+		events = append(events, watchEvent{msg.Type, msg.Object.Name})
+	}
+	return events
+}
+
+// waitForWatchEvents waits until the session receives expected events.
+func waitForWatchEvents(t *testing.T, session *fakeSockJSSession, expected []watchEvent) {
+	var actual []watchEvent
+	for i := 0; i < 100; i++ {
+		actual = receivedWatchEvents(t, session)
+		if reflect.DeepEqual(actual, expected) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("session received %#v, expected %#v", actual, expected)
+}
+
+func TestWatchHub(t *testing.T) {
+	pod := &v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pod-1", Namespace: "default"}}
+	fakeWatcher := watch.NewFake()
+	listWatchCount := 0
+
+	hub := NewWatchHub()
+	hub.newListWatch = func(client *kubernetes.Clientset, kind watchKind, namespace string) cache.ListerWatcher {
+		listWatchCount++
+		return &cache.ListWatch{
+			ListFunc: func(options metaV1.ListOptions) (runtime.Object, error) {
+				return &v1.PodList{Items: []v1.Pod{*pod}}, nil
+			},
+			WatchFunc: func(options metaV1.ListOptions) (watch.Interface, error) {
+				return fakeWatcher, nil
+			},
+		}
+	}
+
+	first := &fakeSockJSSession{}
+	firstSession := &WatchSession{id: "first", sockJSSession: first}
+	if err := hub.Subscribe(nil, "key", "pod", "default", firstSession); err != nil {
+		t.Fatalf("Subscribe() returns unexpected error: %v", err)
+	}
+	waitForWatchEvents(t, first, []watchEvent{{watch.Added, "pod-1"}})
+
+	second := &fakeSockJSSession{}
+	secondSession := &WatchSession{id: "second", sockJSSession: second}
+	if err := hub.Subscribe(nil, "key", "pod", "default", secondSession); err != nil {
+		t.Fatalf("Subscribe() returns unexpected error: %v", err)
+	}
+	waitForWatchEvents(t, second, []watchEvent{{watch.Added, "pod-1"}})
+
+	if listWatchCount != 1 {
+		t.Errorf("sessions with the same key should share a watch, got %d watches", listWatchCount)
+	}
+
+	fakeWatcher.Delete(pod)
+	waitForWatchEvents(t, first, []watchEvent{{watch.Added, "pod-1"}, {watch.Deleted, "pod-1"}})
+	waitForWatchEvents(t, second, []watchEvent{{watch.Added, "pod-1"}, {watch.Deleted, "pod-1"}})
+
+	hub.Unsubscribe("key", firstSession)
+	if len(hub.watches) != 1 {
+		t.Errorf("watch should be kept while it has subscribers")
+	}
+
+	hub.Unsubscribe("key", secondSession)
+	if len(hub.watches) != 0 {
+		t.Errorf("watch should be stopped when the last subscriber leaves")
+	}
+}
+
+// blockingSockJSSession blocks sending until it is released.
+type blockingSockJSSession struct {
+	fakeSockJSSession
+	sending chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingSockJSSession) Send(msg string) error {
+	s.sending <- struct{}{}
+	<-s.release
+	return s.fakeSockJSSession.Send(msg)
+}
+
+func getTestListWatch(pod *v1.Pod) func(client *kubernetes.Clientset, kind watchKind,
+	namespace string) cache.ListerWatcher {
+	return func(client *kubernetes.Clientset, kind watchKind, namespace string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(options metaV1.ListOptions) (runtime.Object, error) {
+				return &v1.PodList{Items: []v1.Pod{*pod}}, nil
+			},
+			WatchFunc: func(options metaV1.ListOptions) (watch.Interface, error) {
+				return watch.NewFake(), nil
+			},
+		}
+	}
+}
+
+func TestWatchHubSendWithoutLocks(t *testing.T) {
+	pod := &v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pod-1", Namespace: "default"}}
+	hub := NewWatchHub()
+	hub.newListWatch = getTestListWatch(pod)
+
+	first := &fakeSockJSSession{}
+	firstSession := &WatchSession{id: "first", sockJSSession: first}
+	if err := hub.Subscribe(nil, "key", "pod", "default", firstSession); err != nil {
+		t.Fatalf("Subscribe() returns unexpected error: %v", err)
+	}
+	waitForWatchEvents(t, first, []watchEvent{{watch.Added, "pod-1"}})
+
+	blocking := &blockingSockJSSession{sending: make(chan struct{}), release: make(chan struct{})}
+	blockingSession := &WatchSession{id: "blocking", sockJSSession: blocking}
+	subscribed := make(chan error)
+	go func() {
+		subscribed <- hub.Subscribe(nil, "key", "pod", "default", blockingSession)
+	}()
+	<-blocking.sending
+
+	// Another session subscribes while the blocking session is sending.
+	second := &fakeSockJSSession{}
+	secondSession := &WatchSession{id: "second", sockJSSession: second}
+	if err := hub.Subscribe(nil, "key", "pod", "default", secondSession); err != nil {
+		t.Fatalf("Subscribe() returns unexpected error: %v", err)
+	}
+	waitForWatchEvents(t, second, []watchEvent{{watch.Added, "pod-1"}})
+
+	close(blocking.release)
+	if err := <-subscribed; err != nil {
+		t.Errorf("Subscribe() returns unexpected error: %v", err)
+	}
+	for _, session := range []*WatchSession{firstSession, blockingSession, secondSession} {
+		hub.Unsubscribe("key", session)
+	}
+}
+
+func TestWatchHubForbidden(t *testing.T) {
+	hub := NewWatchHub()
+	hub.newListWatch = func(client *kubernetes.Clientset, kind watchKind, namespace string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(options metaV1.ListOptions) (runtime.Object, error) {
+				return nil, errorsK8s.NewForbidden(v1.Resource("pods"), "", nil)
+			},
+		}
+	}
+
+	err := hub.Subscribe(nil, "key", "pod", "default", &WatchSession{id: "id"})
+	if !errorsK8s.IsForbidden(err) {
+		t.Errorf("Subscribe() returns %v, expected forbidden error", err)
+	}
+	if len(hub.watches) != 0 {
+		t.Errorf("watch should not be started when resources can not be listed")
+	}
+}
+
+func TestWatchHubUnsupportedKind(t *testing.T) {
+	hub := NewWatchHub()
+	err := hub.Subscribe(nil, "key", "unknown", "default", &WatchSession{id: "id"})
+	if err == nil {
+		t.Error("Subscribe() should return error for unsupported kind")
+	}
+	if err := validateWatchKind("unknown"); err == nil {
+		t.Error("validateWatchKind() should return error for unsupported kind")
+	}
+}
+
+func TestGetWatchKey(t *testing.T) {
+	newRequest := func(token string) *restful.Request {
+		httpRequest, _ := http.NewRequest("GET", "/api/v1/watch/pod/default", nil)
+		httpRequest.Header.Set("Authorization", "Bearer "+token)
+		return restful.NewRequest(httpRequest)
+	}
+
+	first := getWatchKey(newRequest("first"), "pod", "default")
+	if first != getWatchKey(newRequest("first"), "pod", "default") {
+		t.Error("getWatchKey() should return the same key for the same request")
+	}
+	if first == getWatchKey(newRequest("second"), "pod", "default") {
+		t.Error("getWatchKey() should return different keys for different credentials")
+	}
+	if strings.Contains(first, "first") {
+		t.Errorf("getWatchKey() returns %#v, which contains credentials", first)
+	}
+
+	newCertRequest := func(cert string) *restful.Request {
+		request := newRequest("")
+		request.Request.Header.Del("Authorization")
+		request.SetAttribute(client.AuthInfoAttribute, api.AuthInfo{
+			ClientCertificateData: []byte(cert),
+			ClientKeyData:         []byte(cert + "-key"),
+		})
+		return request
+	}
+	anonymous := newRequest("")
+	anonymous.Request.Header.Del("Authorization")
+	anonymousKey := getWatchKey(anonymous, "pod", "default")
+
+	certKey := getWatchKey(newCertRequest("first"), "pod", "default")
+	if certKey != getWatchKey(newCertRequest("first"), "pod", "default") {
+		t.Error("getWatchKey() should return the same key for the same client certificate")
+	}
+	if certKey == getWatchKey(newCertRequest("second"), "pod", "default") || certKey == anonymousKey {
+		t.Error("getWatchKey() should return different keys for different client certificates")
+	}
+
+	impersonate := func(request *restful.Request, user string, groups ...string) *restful.Request {
+		request.Request.Header.Set("Impersonate-User", user)
+		for _, group := range groups {
+			request.Request.Header.Add("Impersonate-Group", group)
+		}
+		return request
+	}
+	impersonatedKey := getWatchKey(impersonate(newRequest("first"), "alice", "dev"), "pod", "default")
+	for _, key := range []string{
+		first,
+		getWatchKey(impersonate(newRequest("first"), "bob", "dev"), "pod", "default"),
+		getWatchKey(impersonate(newRequest("first"), "alice", "dev", "admin"), "pod", "default"),
+		getWatchKey(impersonate(anonymous, "alice", "dev"), "pod", "default"),
+	} {
+		if key == impersonatedKey {
+			t.Error("getWatchKey() should return different keys for different impersonated users")
+		}
+	}
+	if impersonatedKey != getWatchKey(impersonate(newRequest("first"), "alice", "dev"), "pod", "default") {
+		t.Error("getWatchKey() should return the same key for the same impersonated user")
+	}
+}
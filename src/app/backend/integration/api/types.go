@@ -23,7 +23,12 @@ type IntegrationID string
 
 // Integration app IDs should be registered in this block.
 const (
-	HeapsterIntegrationID IntegrationID = "heapster"
+	HeapsterIntegrationID      IntegrationID = "heapster"
+	PrometheusIntegrationID    IntegrationID = "prometheus"
+	MetricsServerIntegrationID IntegrationID = "metrics-server"
+	TrivyIntegrationID         IntegrationID = "trivy"
+	ClairIntegrationID         IntegrationID = "clair"
+	HarborIntegrationID        IntegrationID = "harbor"
 )
 
 // Integration represents application integrated into the dashboard. Every application
@@ -18,12 +18,18 @@ import (
 	"net/http"
 
 	"github.com/emicklei/go-restful"
+	kdapi "github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // IntegrationHandler manages all endpoints related to integrated applications, such as state.
 type IntegrationHandler struct {
-	manager IntegrationManager
+	manager  IntegrationManager
+	cManager client.ClientManager
 }
 
 // Install creates new endpoints for integrations. All information that any integration would want
@@ -32,11 +38,23 @@ type IntegrationHandler struct {
 //
 // By default endpoint for checking state of the integrations is installed. It allows user
 // to check state of integration by accessing `<DASHBOARD_URL>/api/v1/integration/{name}/state`.
+//
+// Time series of CPU and memory usage of single nodes and pods are served by the active metric
+// integration under `<DASHBOARD_URL>/api/v1/integration/metric/...`. Metrics are scraped with
+// the Dashboard service account, so they are served only if the user can get the node or pod.
 func (self IntegrationHandler) Install(ws *restful.WebService) {
 	ws.Route(
 		ws.GET("/integration/{name}/state").
 			To(self.handleGetState).
 			Writes(api.IntegrationState{}))
+	ws.Route(
+		ws.GET("/integration/metric/node/{name}").
+			To(self.handleGetNodeMetrics).
+			Writes([]metricapi.Metric{}))
+	ws.Route(
+		ws.GET("/integration/metric/pod/{namespace}/{name}").
+			To(self.handleGetPodMetrics).
+			Writes([]metricapi.Metric{}))
 }
 
 func (self IntegrationHandler) handleGetState(request *restful.Request, response *restful.Response) {
@@ -50,7 +68,82 @@ func (self IntegrationHandler) handleGetState(request *restful.Request, response
 	response.WriteHeaderAndEntity(http.StatusOK, state)
 }
 
-// NewIntegrationHandler creates IntegrationHandler.
-func NewIntegrationHandler(manager IntegrationManager) IntegrationHandler {
-	return IntegrationHandler{manager: manager}
+func (self IntegrationHandler) handleGetNodeMetrics(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := self.cManager.Client(request)
+	if err != nil {
+		writeError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	if _, err := k8sClient.CoreV1().Nodes().Get(name, metaV1.GetOptions{}); err != nil {
+		writeError(response, err)
+		return
+	}
+
+	self.writeMetrics(response, metricapi.ResourceSelector{
+		ResourceType: kdapi.ResourceKindNode,
+		ResourceName: name,
+	})
+}
+
+func (self IntegrationHandler) handleGetPodMetrics(request *restful.Request,
+	response *restful.Response) {
+	k8sClient, err := self.cManager.Client(request)
+	if err != nil {
+		writeError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	if _, err := k8sClient.CoreV1().Pods(namespace).Get(name, metaV1.GetOptions{}); err != nil {
+		writeError(response, err)
+		return
+	}
+
+	self.writeMetrics(response, metricapi.ResourceSelector{
+		Namespace:    namespace,
+		ResourceType: kdapi.ResourceKindPod,
+		ResourceName: name,
+	})
+}
+
+// writeMetrics downloads CPU and memory usage of the resource from the active metric client.
+func (self IntegrationHandler) writeMetrics(response *restful.Response,
+	selector metricapi.ResourceSelector) {
+	metricClient := self.manager.Metric().Client()
+	if metricClient == nil {
+		response.AddHeader("Content-Type", "text/plain")
+		response.WriteErrorString(http.StatusServiceUnavailable,
+			"No metric client is enabled\n")
+		return
+	}
+
+	metrics, err := metricClient.DownloadMetrics([]metricapi.ResourceSelector{selector},
+		[]string{metricapi.CpuUsage, metricapi.MemoryUsage}, nil).GetMetrics()
+	if err != nil {
+		response.AddHeader("Content-Type", "text/plain")
+		response.WriteErrorString(http.StatusInternalServerError, err.Error()+"\n")
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, metrics)
+}
+
+// writeError writes the error with status code of the apiserver error, i.e. 403 when the user can
+// not get the resource, or 500 for other errors.
+func writeError(response *restful.Response, err error) {
+	statusCode := http.StatusInternalServerError
+	if statusError, ok := err.(*errorsK8s.StatusError); ok && statusError.Status().Code > 0 {
+		statusCode = int(statusError.Status().Code)
+	}
+	response.AddHeader("Content-Type", "text/plain")
+	response.WriteErrorString(statusCode, err.Error()+"\n")
+}
+
+// NewIntegrationHandler creates IntegrationHandler. Client manager creates clients of the user,
+// which are used to check that the user can get resources before their metrics are served.
+func NewIntegrationHandler(manager IntegrationManager, cManager client.ClientManager) IntegrationHandler {
+	return IntegrationHandler{manager: manager, cManager: cManager}
 }
@@ -15,13 +15,16 @@
 package integration
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
 )
 
 func TestIntegrationHandler_Install(t *testing.T) {
-	iHandler := NewIntegrationHandler(nil)
+	iHandler := NewIntegrationHandler(nil, nil)
 	ws := new(restful.WebService)
 	iHandler.Install(ws)
 
@@ -29,3 +32,28 @@ func TestIntegrationHandler_Install(t *testing.T) {
 		t.Error("Failed to install routes.")
 	}
 }
+
+func TestIntegrationHandlerMetricsOfForbiddenPod(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "Forbidden",
+			"code": 403}`))
+	}))
+	defer apiServer.Close()
+
+	// Integration manager is nil, so metrics must not be downloaded for forbidden pods.
+	iHandler := NewIntegrationHandler(nil, client.NewClientManager("", apiServer.URL))
+	ws := new(restful.WebService)
+	iHandler.Install(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	recorder := httptest.NewRecorder()
+	container.ServeHTTP(recorder, httptest.NewRequest("GET", "/integration/metric/pod/prod/web", nil))
+
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("Metrics of forbidden pod returned status %d, expected %d", recorder.Code,
+			http.StatusForbidden)
+	}
+}
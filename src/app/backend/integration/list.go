@@ -31,6 +31,7 @@ func (self *integrationManager) List() []api.Integration {
 
 	// Append all types of integrations
 	result = append(result, self.Metric().List()...)
+	result = append(result, self.Scanner().List()...)
 
 	return result
 }
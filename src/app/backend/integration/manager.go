@@ -20,6 +20,7 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/client"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/api"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/metric"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/scanner"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -31,11 +32,15 @@ type IntegrationManager interface {
 	GetState(id api.IntegrationID) (*api.IntegrationState, error)
 	// Metric returns metric manager that is responsible for management of metric integrations.
 	Metric() metric.MetricManager
+	// Scanner returns scanner manager that is responsible for management of integrations scanning
+	// images for vulnerabilities.
+	Scanner() scanner.ScannerManager
 }
 
 // Implements IntegrationManager interface
 type integrationManager struct {
-	metric metric.MetricManager
+	metric  metric.MetricManager
+	scanner scanner.ScannerManager
 }
 
 // Metric implements integration manager interface. See IntegrationManager for more information.
@@ -43,6 +48,11 @@ func (self *integrationManager) Metric() metric.MetricManager {
 	return self.metric
 }
 
+// Scanner implements integration manager interface. See IntegrationManager for more information.
+func (self *integrationManager) Scanner() scanner.ScannerManager {
+	return self.scanner
+}
+
 // GetState implements integration manager interface. See IntegrationManager for more information.
 func (self *integrationManager) GetState(id api.IntegrationID) (
 	*api.IntegrationState, error) {
@@ -69,6 +79,7 @@ func (self *integrationManager) getState(integration api.Integration) *api.Integ
 // NewIntegrationManager creates integration manager.
 func NewIntegrationManager(manager client.ClientManager) IntegrationManager {
 	return &integrationManager{
-		metric: metric.NewMetricManager(manager),
+		metric:  metric.NewMetricManager(manager),
+		scanner: scanner.NewScannerManager(),
 	}
 }
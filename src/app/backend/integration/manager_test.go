@@ -92,3 +92,10 @@ func TestIntegrationManager_Metric(t *testing.T) {
 		t.Error("Failed to get metric manager.")
 	}
 }
+
+func TestIntegrationManager_Scanner(t *testing.T) {
+	scannerManager := NewIntegrationManager(nil).Scanner()
+	if scannerManager == nil {
+		t.Error("Failed to get scanner manager.")
+	}
+}
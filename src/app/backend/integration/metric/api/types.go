@@ -46,6 +46,50 @@ type MetricClient interface {
 	integrationapi.Integration
 }
 
+// ContainerMetricClient is implemented by metric clients that can report usage of single
+// containers of a pod. Heapster client does not implement it.
+type ContainerMetricClient interface {
+	// ContainerUsage returns the most recent usage of containers of the pod keyed by container
+	// name. Containers without known usage are omitted.
+	ContainerUsage(namespace, pod string) (map[string]ContainerUsage, error)
+}
+
+// ContainerHistoryClient is implemented by metric clients that keep usage history of single
+// containers. Metrics server client implements it when its scraper is enabled.
+type ContainerHistoryClient interface {
+	// ContainerUsageHistory returns usage samples of containers of the pods in the namespace keyed by
+	// container name. Samples of containers with the same name in different pods are merged.
+	ContainerUsageHistory(namespace string, pods []string) map[string][]ContainerUsage
+}
+
+// NamespaceHistoryClient is implemented by metric clients that keep usage history of pods, so that
+// usage of whole namespaces over time can be shown. Metrics server client implements it when its
+// scraper is enabled.
+type NamespaceHistoryClient interface {
+	// NamespaceUsageHistory returns usage of pods in the namespace summed up for every timestamp,
+	// oldest first. Pods, which do not exist anymore, are included as long as their samples are kept.
+	NamespaceUsageHistory(namespace string) []UsageSample
+}
+
+// UsageSample is resource usage at a point in time.
+type UsageSample struct {
+	Timestamp time.Time
+	// CPU usage in millicores.
+	CPUUsage uint64
+	// Memory usage in bytes.
+	MemoryUsage uint64
+}
+
+// ContainerUsage is the most recent resource usage of a single container.
+type ContainerUsage struct {
+	// CPU usage in millicores. Nil if unknown.
+	CPUUsage *uint64
+	// Memory usage in bytes. Nil if unknown.
+	MemoryUsage *uint64
+	// Share of CPU periods, in which the container was throttled by its CPU limit. Nil if unknown.
+	CPUThrottledRatio *float64
+}
+
 // CachedResources contains all resources that may be required by DataSelect functions for metric
 // gathering. Depending on the need you may have to provide DataSelect with resources it
 // requires, for example resource like deployment will need Pods in order to calculate its metrics.
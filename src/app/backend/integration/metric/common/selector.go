@@ -0,0 +1,61 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	resourcecommon "github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// GetNativeResources returns kind, names and UIDs of resources that metrics of the selector are
+// collected for. Derived resources, e.g. deployments, are converted to their pods, which have to
+// be provided in cached resources.
+func GetNativeResources(selector metricapi.ResourceSelector,
+	cachedResources *metricapi.CachedResources) (api.ResourceKind, []string, []types.UID, error) {
+	nativeKind, isDerivedResource := metricapi.DerivedResources[selector.ResourceType]
+	if !isDerivedResource {
+		return selector.ResourceType, []string{selector.ResourceName}, []types.UID{selector.UID}, nil
+	}
+
+	if nativeKind != api.ResourceKindPod || cachedResources == nil || cachedResources.Pods == nil {
+		return "", nil, nil, fmt.Errorf("pods are required to download metrics of %s",
+			selector.ResourceType)
+	}
+
+	var pods []v1.Pod
+	if selector.ResourceType == api.ResourceKindDeployment {
+		for _, pod := range cachedResources.Pods {
+			if pod.Namespace == selector.Namespace && api.IsSelectorMatching(selector.Selector, pod.Labels) {
+				pods = append(pods, pod)
+			}
+		}
+	} else {
+		pods = resourcecommon.FilterPodsByOwnerReference(selector.Namespace, selector.UID,
+			cachedResources.Pods)
+	}
+
+	names := make([]string, len(pods))
+	uids := make([]types.UID, len(pods))
+	for i, pod := range pods {
+		names[i] = pod.Name
+		uids[i] = pod.UID
+	}
+	return api.ResourceKindPod, names, uids, nil
+}
@@ -18,13 +18,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"strings"
 
 	"github.com/kubernetes/dashboard/src/app/backend/client"
 	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/common"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -236,7 +236,7 @@ func CreateHeapsterClient(host string, k8sClient *kubernetes.Clientset) (
 	metricapi.MetricClient, error) {
 
 	if host == "" && k8sClient != nil {
-		log.Print("Creating in-cluster Heapster client")
+		logger.Info("Creating in-cluster Heapster client")
 		c := inClusterHeapsterClient{client: k8sClient.Core().RESTClient()}
 		return heapsterClient{client: c}, nil
 	}
@@ -246,7 +246,7 @@ func CreateHeapsterClient(host string, k8sClient *kubernetes.Clientset) (
 	if err != nil {
 		return heapsterClient{}, err
 	}
-	log.Printf("Creating remote Heapster client for %s", host)
+	logger.Infof("Creating remote Heapster client for %s", host)
 	c := remoteHeapsterClient{client: restClient.Core().RESTClient()}
 	return heapsterClient{client: c}, nil
 }
@@ -15,8 +15,7 @@
 package heapster
 
 import (
-	"log"
-
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"k8s.io/client-go/rest"
 )
 
@@ -76,7 +75,7 @@ func (self remoteHeapsterClient) HealthCheck() error {
 func healthCheck(client HeapsterRESTClient) error {
 	_, err := client.Get("healthz").AbsPath("/").DoRaw()
 	if err == nil {
-		log.Print("Successful initial request to heapster")
+		logger.Info("Successful initial request to heapster")
 		return nil
 	}
 
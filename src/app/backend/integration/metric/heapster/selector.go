@@ -17,9 +17,9 @@ package heapster
 import (
 	"fmt"
 
-	"github.com/emicklei/go-restful/log"
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/pkg/api/v1"
@@ -38,7 +38,7 @@ func getHeapsterSelectors(selectors []metricapi.ResourceSelector,
 	for i, selector := range selectors {
 		heapsterSelector, err := getHeapsterSelector(selector, cachedResources)
 		if err != nil {
-			log.Printf("There was an error during transformation to heapster selector: %s", err.Error())
+			logger.Errorf("There was an error during transformation to heapster selector: %s", err.Error())
 			continue
 		}
 
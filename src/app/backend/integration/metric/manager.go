@@ -17,11 +17,13 @@ package metric
 import (
 	"fmt"
 
-	"github.com/emicklei/go-restful/log"
 	"github.com/kubernetes/dashboard/src/app/backend/client"
 	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/heapster"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/metricsserver"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/prometheus"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 )
 
 // MetricManager is responsible for management of all integrated applications related to metrics.
@@ -37,6 +39,10 @@ type MetricManager interface {
 	List() []integrationapi.Integration
 	// ConfigureHeapster configures and adds heapster to clients list.
 	ConfigureHeapster(host string) MetricManager
+	// ConfigurePrometheus configures and adds Prometheus to clients list.
+	ConfigurePrometheus(options prometheus.PrometheusOptions) MetricManager
+	// ConfigureMetricsServer configures and adds metrics server to clients list.
+	ConfigureMetricsServer(options metricsserver.ScraperOptions) MetricManager
 }
 
 // Implements MetricManager interface.
@@ -90,13 +96,43 @@ func (self *metricManager) List() []integrationapi.Integration {
 func (self *metricManager) ConfigureHeapster(host string) MetricManager {
 	kubeClient, err := self.manager.Client(nil)
 	if err != nil {
-		log.Print(err)
+		logger.Error(err)
 		return self
 	}
 
 	metricClient, err := heapster.CreateHeapsterClient(host, kubeClient)
 	if err != nil {
-		log.Printf("There was an error during heapster client creation: %s", err.Error())
+		logger.Errorf("There was an error during heapster client creation: %s", err.Error())
+		return self
+	}
+
+	self.clients[metricClient.ID()] = metricClient
+	return self
+}
+
+// ConfigurePrometheus implements metric manager interface. See MetricManager for more information.
+func (self *metricManager) ConfigurePrometheus(options prometheus.PrometheusOptions) MetricManager {
+	metricClient, err := prometheus.CreatePrometheusClient(options)
+	if err != nil {
+		logger.Errorf("There was an error during Prometheus client creation: %s", err.Error())
+		return self
+	}
+
+	self.clients[metricClient.ID()] = metricClient
+	return self
+}
+
+// ConfigureMetricsServer implements metric manager interface. See MetricManager for more information.
+func (self *metricManager) ConfigureMetricsServer(options metricsserver.ScraperOptions) MetricManager {
+	kubeClient, err := self.manager.Client(nil)
+	if err != nil {
+		logger.Error(err)
+		return self
+	}
+
+	metricClient, err := metricsserver.CreateMetricsServerClient(kubeClient, options)
+	if err != nil {
+		logger.Errorf("There was an error during metrics server client creation: %s", err.Error())
 		return self
 	}
 
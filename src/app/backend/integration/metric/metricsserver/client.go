@@ -0,0 +1,375 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/common"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+// metricsGroup is the API group of the Kubernetes Metrics API served by metrics-server.
+const metricsGroup = "metrics.k8s.io"
+
+// podMetrics and nodeMetrics mirror types of the Metrics API, which is not part of client-go.
+type podMetrics struct {
+	metaV1.ObjectMeta `json:"metadata"`
+	Timestamp         metaV1.Time        `json:"timestamp"`
+	Containers        []containerMetrics `json:"containers"`
+}
+
+type podMetricsList struct {
+	Items []podMetrics `json:"items"`
+}
+
+type containerMetrics struct {
+	Name  string          `json:"name"`
+	Usage v1.ResourceList `json:"usage"`
+}
+
+type nodeMetrics struct {
+	metaV1.ObjectMeta `json:"metadata"`
+	Timestamp         metaV1.Time     `json:"timestamp"`
+	Usage             v1.ResourceList `json:"usage"`
+}
+
+type nodeMetricsList struct {
+	Items []nodeMetrics `json:"items"`
+}
+
+// usage is a single sample of resource usage.
+type usage struct {
+	timestamp time.Time
+	resources v1.ResourceList
+	// containers keeps usage of single containers of pods keyed by container name. It is nil for
+	// nodes.
+	containers map[string]v1.ResourceList
+}
+
+// Metrics server client implements MetricClient and Integration interfaces. The Metrics API only
+// serves current usage, so metrics contain a single data point unless the scraper keeps history.
+type metricsServerClient struct {
+	client rest.Interface
+	// version of the Metrics API preferred by the apiserver, discovered on first use.
+	version     string
+	versionLock sync.Mutex
+	// store keeps usage collected by the scraper. It is nil if scraping is disabled.
+	store *usageStore
+}
+
+// Implement Integration interface.
+
+// HealthCheck implements integration app interface. See Integration interface for more information.
+func (self *metricsServerClient) HealthCheck() error {
+	if self.client == nil {
+		return errors.New("Metrics server not configured")
+	}
+
+	_, err := self.getVersion()
+	return err
+}
+
+// ID implements integration app interface. See Integration interface for more information.
+func (self *metricsServerClient) ID() integrationapi.IntegrationID {
+	return integrationapi.MetricsServerIntegrationID
+}
+
+// Implement MetricClient interface
+
+// DownloadMetrics implements metric client interface. See MetricClient for more information.
+func (self *metricsServerClient) DownloadMetrics(selectors []metricapi.ResourceSelector,
+	metricNames []string, cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	result := metricapi.MetricPromises{}
+	for _, metricName := range metricNames {
+		collectedMetrics := self.DownloadMetric(selectors, metricName, cachedResources)
+		result = append(result, collectedMetrics...)
+	}
+	return result
+}
+
+// DownloadMetric implements metric client interface. See MetricClient for more information.
+// Usage kept by the scraper is used if available, otherwise usage of pods is listed once per
+// namespace and shared by all selectors.
+func (self *metricsServerClient) DownloadMetric(selectors []metricapi.ResourceSelector,
+	metricName string, cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	result := metricapi.NewMetricPromises(len(selectors))
+	go func() {
+		podUsage := make(map[string]map[string]usage)
+		var nodeUsage map[string]usage
+
+		for i, selector := range selectors {
+			kind, names, uids, err := common.GetNativeResources(selector, cachedResources)
+			if err != nil {
+				result[i].Metric <- nil
+				result[i].Error <- err
+				continue
+			}
+
+			if self.store != nil {
+				history := self.store.history(kind, selector.Namespace, names)
+				if metric, ok := toHistoryMetric(metricName, kind, names, uids, history); ok {
+					result[i].Metric <- &metric
+					result[i].Error <- nil
+					continue
+				}
+			}
+
+			var usages map[string]usage
+			switch kind {
+			case api.ResourceKindPod:
+				if _, ok := podUsage[selector.Namespace]; !ok {
+					podUsage[selector.Namespace], err = self.getPodUsage(selector.Namespace)
+				}
+				usages = podUsage[selector.Namespace]
+			case api.ResourceKindNode:
+				if nodeUsage == nil {
+					nodeUsage, err = self.getNodeUsage()
+				}
+				usages = nodeUsage
+			default:
+				err = fmt.Errorf("resource %s is not supported by metrics server integration", kind)
+			}
+
+			if err != nil {
+				result[i].Metric <- nil
+				result[i].Error <- err
+				continue
+			}
+
+			metric := toMetric(metricName, kind, names, uids, usages)
+			result[i].Metric <- &metric
+			result[i].Error <- nil
+		}
+	}()
+	return result
+}
+
+// AggregateMetrics implements metric client interface. See MetricClient for more information.
+func (self *metricsServerClient) AggregateMetrics(metrics metricapi.MetricPromises, metricName string,
+	aggregations metricapi.AggregationModes) metricapi.MetricPromises {
+	return common.AggregateMetricPromises(metrics, metricName, aggregations, nil)
+}
+
+// ContainerUsage implements container metric client interface. See ContainerMetricClient for more
+// information. Only current usage of CPU and memory is served by the Metrics API.
+func (self *metricsServerClient) ContainerUsage(namespace, pod string) (
+	map[string]metricapi.ContainerUsage, error) {
+	metrics := podMetrics{}
+	result := make(map[string]metricapi.ContainerUsage)
+	if ok, err := self.getUsage([]string{"namespaces", namespace, "pods", pod}, &metrics); !ok {
+		return result, err
+	}
+
+	for _, container := range metrics.Containers {
+		cpu := container.Usage[v1.ResourceCPU]
+		memory := container.Usage[v1.ResourceMemory]
+		cpuUsage, memoryUsage := uint64(cpu.MilliValue()), uint64(memory.Value())
+		result[container.Name] = metricapi.ContainerUsage{CPUUsage: &cpuUsage, MemoryUsage: &memoryUsage}
+	}
+	return result, nil
+}
+
+// getVersion returns version of the Metrics API preferred by the apiserver.
+func (self *metricsServerClient) getVersion() (string, error) {
+	self.versionLock.Lock()
+	defer self.versionLock.Unlock()
+	if self.version != "" {
+		return self.version, nil
+	}
+
+	rawGroup, err := self.client.Get().AbsPath("/apis", metricsGroup).DoRaw()
+	if err != nil {
+		return "", err
+	}
+
+	group := metaV1.APIGroup{}
+	if err := json.Unmarshal(rawGroup, &group); err != nil {
+		return "", err
+	}
+	if group.PreferredVersion.Version == "" {
+		return "", fmt.Errorf("no versions of %s API are served", metricsGroup)
+	}
+
+	self.version = group.PreferredVersion.Version
+	return self.version, nil
+}
+
+// getUsage gets usage from the Metrics API path. Missing API is not an error, as metrics-server
+// may be not deployed or not ready yet, in which case false is returned.
+func (self *metricsServerClient) getUsage(path []string, v interface{}) (bool, error) {
+	version, err := self.getVersion()
+	if isUnavailable(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	raw, err := self.client.Get().
+		AbsPath(append([]string{"/apis", metricsGroup, version}, path...)...).
+		DoRaw()
+	if isUnavailable(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, json.Unmarshal(raw, v)
+}
+
+// isUnavailable returns true if the error means that the Metrics API is not registered in the
+// apiserver or the server behind it does not respond.
+func isUnavailable(err error) bool {
+	statusErr, ok := err.(*k8serrors.StatusError)
+	if !ok {
+		return false
+	}
+
+	code := statusErr.Status().Code
+	return code == http.StatusNotFound || code == http.StatusServiceUnavailable
+}
+
+// getPodUsage returns usage of pods in the namespace keyed by pod name.
+func (self *metricsServerClient) getPodUsage(namespace string) (map[string]usage, error) {
+	list := podMetricsList{}
+	if ok, err := self.getUsage([]string{"namespaces", namespace, "pods"}, &list); !ok {
+		return map[string]usage{}, err
+	}
+
+	result := make(map[string]usage)
+	for _, item := range list.Items {
+		result[item.Name] = item.usage()
+	}
+	return result, nil
+}
+
+// usage returns usage of the pod, that is the sum of usage of its containers.
+func (self podMetrics) usage() usage {
+	resources := v1.ResourceList{}
+	containers := make(map[string]v1.ResourceList, len(self.Containers))
+	for _, container := range self.Containers {
+		containers[container.Name] = container.Usage
+		for name, quantity := range container.Usage {
+			total := resources[name]
+			total.Add(quantity)
+			resources[name] = total
+		}
+	}
+	return usage{timestamp: self.Timestamp.Time, resources: resources, containers: containers}
+}
+
+// getNodeUsage returns usage of nodes keyed by node name.
+func (self *metricsServerClient) getNodeUsage() (map[string]usage, error) {
+	list := nodeMetricsList{}
+	if ok, err := self.getUsage([]string{"nodes"}, &list); !ok {
+		return map[string]usage{}, err
+	}
+
+	result := make(map[string]usage)
+	for _, item := range list.Items {
+		result[item.Name] = usage{timestamp: item.Timestamp.Time, resources: item.Usage}
+	}
+	return result, nil
+}
+
+// toMetric sums up usage of the resources with given names. Usage samples of different
+// resources are taken at slightly different times, the most recent time is used for the sum.
+// Metric without data points is returned if usage of none of the resources is known.
+func toMetric(metricName string, kind api.ResourceKind, names []string, uids []types.UID,
+	usages map[string]usage) metricapi.Metric {
+	metric := metricapi.Metric{
+		DataPoints:   metricapi.DataPoints{},
+		MetricPoints: []metricapi.MetricPoint{},
+		MetricName:   metricName,
+		Label:        metricapi.Label{kind: uids},
+		Aggregate:    metricapi.SumAggregation,
+	}
+
+	var timestamp time.Time
+	var value int64
+	found := false
+	for _, name := range names {
+		sample, ok := usages[name]
+		if !ok {
+			continue
+		}
+
+		sampleValue, ok := usageValue(metricName, sample)
+		if !ok {
+			continue
+		}
+
+		value += sampleValue
+		found = true
+		if sample.timestamp.After(timestamp) {
+			timestamp = sample.timestamp
+		}
+	}
+
+	if found {
+		metric.DataPoints = append(metric.DataPoints, metricapi.DataPoint{X: timestamp.Unix(), Y: value})
+		metric.MetricPoints = append(metric.MetricPoints,
+			metricapi.MetricPoint{Timestamp: timestamp, Value: uint64(value)})
+	}
+	return metric
+}
+
+// usageValue returns value of the metric in the sample, i.e. CPU usage in millicores or memory
+// usage in bytes. False is returned for metrics that are not served by the Metrics API.
+func usageValue(metricName string, sample usage) (int64, bool) {
+	switch metricName {
+	case metricapi.CpuUsage:
+		quantity := sample.resources[v1.ResourceCPU]
+		return quantity.MilliValue(), true
+	case metricapi.MemoryUsage:
+		quantity := sample.resources[v1.ResourceMemory]
+		return quantity.Value(), true
+	default:
+		return 0, false
+	}
+}
+
+// CreateMetricsServerClient creates new metrics server client that reads the Metrics API
+// through the apiserver. If the scraper is enabled, usage is collected in the background and
+// kept for the configured window.
+func CreateMetricsServerClient(k8sClient *kubernetes.Clientset,
+	options ScraperOptions) (metricapi.MetricClient, error) {
+	if k8sClient == nil {
+		return &metricsServerClient{}, errors.New("Kubernetes client is not configured")
+	}
+
+	logger.Info("Creating metrics server client")
+	client := &metricsServerClient{client: k8sClient.CoreV1().RESTClient()}
+	if options.Resolution > 0 {
+		client.startScraper(options)
+	}
+	return client, nil
+}
@@ -0,0 +1,188 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+var metricsAPIResponses = map[string]string{
+	"/apis/metrics.k8s.io": `{"kind": "APIGroup", "name": "metrics.k8s.io",
+		"versions": [{"groupVersion": "metrics.k8s.io/v1beta1", "version": "v1beta1"}],
+		"preferredVersion": {"groupVersion": "metrics.k8s.io/v1beta1", "version": "v1beta1"}}`,
+	"/apis/metrics.k8s.io/v1beta1/namespaces/ns-1/pods": `{"items": [
+		{"metadata": {"name": "pod-1", "namespace": "ns-1"}, "timestamp": "2017-05-01T10:00:00Z",
+			"containers": [
+				{"name": "app", "usage": {"cpu": "100m", "memory": "64Mi"}},
+				{"name": "sidecar", "usage": {"cpu": "5m", "memory": "16Mi"}}]},
+		{"metadata": {"name": "pod-2", "namespace": "ns-1"}, "timestamp": "2017-05-01T10:00:30Z",
+			"containers": [{"name": "app", "usage": {"cpu": "250m", "memory": "128Mi"}}]}]}`,
+	"/apis/metrics.k8s.io/v1beta1/namespaces/ns-1/pods/pod-1": `{"metadata": {"name": "pod-1",
+		"namespace": "ns-1"}, "timestamp": "2017-05-01T10:00:00Z", "containers": [
+			{"name": "app", "usage": {"cpu": "100m", "memory": "64Mi"}},
+			{"name": "sidecar", "usage": {"cpu": "5m", "memory": "16Mi"}}]}`,
+	"/apis/metrics.k8s.io/v1beta1/nodes": `{"items": [
+		{"metadata": {"name": "node-1"}, "timestamp": "2017-05-01T10:00:00Z",
+			"usage": {"cpu": "1500m", "memory": "2Gi"}}]}`,
+}
+
+func newTestClient(t *testing.T, responses map[string]string) (*metricsServerClient, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+
+	k8sClient, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("Could not create client: %v", err)
+	}
+	metricClient, err := CreateMetricsServerClient(k8sClient, ScraperOptions{})
+	if err != nil {
+		t.Fatalf("CreateMetricsServerClient() returns unexpected error: %v", err)
+	}
+	return metricClient.(*metricsServerClient), server.Close
+}
+
+func TestDownloadMetric(t *testing.T) {
+	client, closeServer := newTestClient(t, metricsAPIResponses)
+	defer closeServer()
+
+	if err := client.HealthCheck(); err != nil {
+		t.Fatalf("HealthCheck() returns unexpected error: %v", err)
+	}
+
+	owner := metaV1.OwnerReference{UID: "rs-1", Controller: new(bool)}
+	*owner.Controller = true
+	pods := []v1.Pod{
+		{ObjectMeta: metaV1.ObjectMeta{Name: "pod-1", Namespace: "ns-1", UID: "p-1",
+			OwnerReferences: []metaV1.OwnerReference{owner}}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "pod-2", Namespace: "ns-1", UID: "p-2",
+			OwnerReferences: []metaV1.OwnerReference{owner}}},
+	}
+	selectors := []metricapi.ResourceSelector{
+		{Namespace: "ns-1", ResourceType: api.ResourceKindReplicaSet, ResourceName: "rs", UID: "rs-1"},
+		{Namespace: "ns-1", ResourceType: api.ResourceKindPod, ResourceName: "pod-1", UID: "p-1"},
+		{ResourceType: api.ResourceKindNode, ResourceName: "node-1", UID: "n-1"},
+	}
+	cached := &metricapi.CachedResources{Pods: pods}
+
+	cases := []struct {
+		metricName string
+		expected   []metricapi.DataPoints
+	}{
+		{
+			metricapi.CpuUsage,
+			[]metricapi.DataPoints{
+				{{X: 1493632830, Y: 355}},
+				{{X: 1493632800, Y: 105}},
+				{{X: 1493632800, Y: 1500}},
+			},
+		},
+		{
+			metricapi.MemoryUsage,
+			[]metricapi.DataPoints{
+				{{X: 1493632830, Y: 208 * 1024 * 1024}},
+				{{X: 1493632800, Y: 80 * 1024 * 1024}},
+				{{X: 1493632800, Y: 2 * 1024 * 1024 * 1024}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		metrics, err := client.DownloadMetric(selectors, c.metricName, cached).GetMetrics()
+		if err != nil {
+			t.Fatalf("DownloadMetric(%s) returns unexpected error: %v", c.metricName, err)
+		}
+
+		actual := make([]metricapi.DataPoints, len(metrics))
+		for i, metric := range metrics {
+			actual[i] = metric.DataPoints
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("DownloadMetric(%s) == %v, expected %v", c.metricName, actual, c.expected)
+		}
+	}
+}
+
+func TestDownloadMetricWithoutMetricsAPI(t *testing.T) {
+	client, closeServer := newTestClient(t, map[string]string{})
+	defer closeServer()
+
+	if err := client.HealthCheck(); err == nil {
+		t.Error("HealthCheck() expected error when Metrics API is not available")
+	}
+
+	selectors := []metricapi.ResourceSelector{
+		{Namespace: "ns-1", ResourceType: api.ResourceKindPod, ResourceName: "pod-1", UID: "p-1"},
+	}
+	metrics, err := client.DownloadMetric(selectors, metricapi.CpuUsage,
+		metricapi.NoResourceCache).GetMetrics()
+	if err != nil {
+		t.Fatalf("DownloadMetric() returns unexpected error: %v", err)
+	}
+	if len(metrics) != 1 || len(metrics[0].DataPoints) != 0 {
+		t.Errorf("DownloadMetric() == %v, expected single metric without data points", metrics)
+	}
+}
+
+func TestToMetricUsesLatestTimestamp(t *testing.T) {
+	first := time.Date(2017, 5, 1, 10, 0, 0, 0, time.UTC)
+	usages := map[string]usage{
+		"pod-1": {timestamp: first, resources: v1.ResourceList{}},
+		"pod-2": {timestamp: first.Add(time.Minute), resources: v1.ResourceList{}},
+	}
+
+	metric := toMetric(metricapi.CpuUsage, api.ResourceKindPod, []string{"pod-1", "pod-2"}, nil, usages)
+	if len(metric.DataPoints) != 1 || metric.DataPoints[0].X != first.Add(time.Minute).Unix() {
+		t.Errorf("toMetric() == %v, expected single data point at %v", metric.DataPoints,
+			first.Add(time.Minute))
+	}
+}
+
+func TestContainerUsage(t *testing.T) {
+	client, closeServer := newTestClient(t, metricsAPIResponses)
+	defer closeServer()
+
+	usage, err := client.ContainerUsage("ns-1", "pod-1")
+	if err != nil {
+		t.Fatalf("ContainerUsage() returns unexpected error: %v", err)
+	}
+	app := usage["app"]
+	if len(usage) != 2 || *app.CPUUsage != 100 || *app.MemoryUsage != 64*1024*1024 ||
+		app.CPUThrottledRatio != nil {
+		t.Errorf("ContainerUsage() == %#v, unexpected usage", usage)
+	}
+
+	usage, err = client.ContainerUsage("ns-1", "pod-2")
+	if err != nil || len(usage) != 0 {
+		t.Errorf("ContainerUsage() == %#v, %v, expected no usage of unknown pod", usage, err)
+	}
+}
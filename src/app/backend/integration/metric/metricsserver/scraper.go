@@ -0,0 +1,266 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsserver
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// ScraperOptions configures the scraper that periodically pulls usage from the Metrics API and
+// keeps it in memory, so that graphs can be drawn instead of single data points.
+type ScraperOptions struct {
+	// Window is the period of time for which usage samples are kept.
+	Window time.Duration
+	// Resolution is the interval between scrapes. Scraping is disabled if it is not positive.
+	Resolution time.Duration
+}
+
+// usageStore keeps usage samples of pods and nodes collected within the window. Samples are
+// aligned to the resolution, so that samples of different resources can be summed up.
+type usageStore struct {
+	window     time.Duration
+	resolution time.Duration
+
+	lock sync.RWMutex
+	// pods keeps samples of pods keyed by namespace and name of the pod.
+	pods map[string][]usage
+	// nodes keeps samples of nodes keyed by name of the node.
+	nodes map[string][]usage
+}
+
+func newUsageStore(options ScraperOptions) *usageStore {
+	return &usageStore{
+		window:     options.Window,
+		resolution: options.Resolution,
+		pods:       make(map[string][]usage),
+		nodes:      make(map[string][]usage),
+	}
+}
+
+// podKey returns key of the pod in the store.
+func podKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// update adds the latest usage of pods and nodes and removes samples older than the window.
+func (self *usageStore) update(pods, nodes map[string]usage, now time.Time) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	self.add(self.pods, pods)
+	self.add(self.nodes, nodes)
+	self.prune(self.pods, now)
+	self.prune(self.nodes, now)
+}
+
+// add appends samples to the series. Metrics server refreshes usage less often than it may be
+// scraped, so a sample with a timestamp that is already stored replaces the stored one.
+func (self *usageStore) add(series map[string][]usage, samples map[string]usage) {
+	for key, sample := range samples {
+		sample.timestamp = sample.timestamp.Truncate(self.resolution)
+		samples := series[key]
+		if last := len(samples) - 1; last >= 0 && !sample.timestamp.After(samples[last].timestamp) {
+			if sample.timestamp.Equal(samples[last].timestamp) {
+				samples[last] = sample
+			}
+			continue
+		}
+		series[key] = append(samples, sample)
+	}
+}
+
+func (self *usageStore) prune(series map[string][]usage, now time.Time) {
+	oldest := now.Add(-self.window)
+	for key, samples := range series {
+		i := sort.Search(len(samples), func(i int) bool {
+			return !samples[i].timestamp.Before(oldest)
+		})
+		if i == len(samples) {
+			delete(series, key)
+			continue
+		}
+		series[key] = samples[i:]
+	}
+}
+
+// history returns copy of samples of the resources with given names keyed by name.
+func (self *usageStore) history(kind api.ResourceKind, namespace string,
+	names []string) map[string][]usage {
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+
+	result := make(map[string][]usage)
+	for _, name := range names {
+		var samples []usage
+		switch kind {
+		case api.ResourceKindPod:
+			samples = self.pods[podKey(namespace, name)]
+		case api.ResourceKindNode:
+			samples = self.nodes[name]
+		}
+		if len(samples) > 0 {
+			result[name] = append([]usage(nil), samples...)
+		}
+	}
+	return result
+}
+
+// namespaceHistory returns usage of pods in the namespace summed up for every timestamp, oldest
+// first.
+func (self *usageStore) namespaceHistory(namespace string) []metricapi.UsageSample {
+	self.lock.RLock()
+	defer self.lock.RUnlock()
+
+	prefix := podKey(namespace, "")
+	sums := make(map[time.Time]*metricapi.UsageSample)
+	for key, samples := range self.pods {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		for _, sample := range samples {
+			sum, ok := sums[sample.timestamp]
+			if !ok {
+				sum = &metricapi.UsageSample{Timestamp: sample.timestamp}
+				sums[sample.timestamp] = sum
+			}
+			cpu, memory := sample.resources[v1.ResourceCPU], sample.resources[v1.ResourceMemory]
+			sum.CPUUsage += uint64(cpu.MilliValue())
+			sum.MemoryUsage += uint64(memory.Value())
+		}
+	}
+
+	result := make([]metricapi.UsageSample, 0, len(sums))
+	for _, sum := range sums {
+		result = append(result, *sum)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Timestamp.Before(result[j].Timestamp) })
+	return result
+}
+
+// toHistoryMetric sums up samples of the resources with given names for every timestamp. False
+// is returned if there are no samples for any of the resources.
+func toHistoryMetric(metricName string, kind api.ResourceKind, names []string, uids []types.UID,
+	history map[string][]usage) (metricapi.Metric, bool) {
+	values := make(map[time.Time]int64)
+	for _, name := range names {
+		for _, sample := range history[name] {
+			value, ok := usageValue(metricName, sample)
+			if !ok {
+				continue
+			}
+			values[sample.timestamp] += value
+		}
+	}
+	if len(values) == 0 {
+		return metricapi.Metric{}, false
+	}
+
+	timestamps := make([]time.Time, 0, len(values))
+	for timestamp := range values {
+		timestamps = append(timestamps, timestamp)
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i].Before(timestamps[j]) })
+
+	metric := metricapi.Metric{
+		DataPoints:   metricapi.DataPoints{},
+		MetricPoints: []metricapi.MetricPoint{},
+		MetricName:   metricName,
+		Label:        metricapi.Label{kind: uids},
+		Aggregate:    metricapi.SumAggregation,
+	}
+	for _, timestamp := range timestamps {
+		value := values[timestamp]
+		metric.DataPoints = append(metric.DataPoints, metricapi.DataPoint{X: timestamp.Unix(), Y: value})
+		metric.MetricPoints = append(metric.MetricPoints,
+			metricapi.MetricPoint{Timestamp: timestamp, Value: uint64(value)})
+	}
+	return metric, true
+}
+
+// ContainerUsageHistory implements container history client interface. See ContainerHistoryClient
+// for more information. Nothing is returned if the scraper is disabled.
+func (self *metricsServerClient) ContainerUsageHistory(namespace string,
+	pods []string) map[string][]metricapi.ContainerUsage {
+	result := make(map[string][]metricapi.ContainerUsage)
+	if self.store == nil {
+		return result
+	}
+
+	for _, samples := range self.store.history(api.ResourceKindPod, namespace, pods) {
+		for _, sample := range samples {
+			for name, resources := range sample.containers {
+				containerUsage := metricapi.ContainerUsage{}
+				if cpu, ok := resources[v1.ResourceCPU]; ok {
+					value := uint64(cpu.MilliValue())
+					containerUsage.CPUUsage = &value
+				}
+				if memory, ok := resources[v1.ResourceMemory]; ok {
+					value := uint64(memory.Value())
+					containerUsage.MemoryUsage = &value
+				}
+				result[name] = append(result[name], containerUsage)
+			}
+		}
+	}
+	return result
+}
+
+// NamespaceUsageHistory implements namespace history client interface. See NamespaceHistoryClient
+// for more information. Nothing is returned if the scraper is disabled.
+func (self *metricsServerClient) NamespaceUsageHistory(namespace string) []metricapi.UsageSample {
+	if self.store == nil {
+		return []metricapi.UsageSample{}
+	}
+	return self.store.namespaceHistory(namespace)
+}
+
+// startScraper starts collecting usage of all pods and nodes every resolution.
+func (self *metricsServerClient) startScraper(options ScraperOptions) {
+	logger.Infof("Starting metrics scraper with %s window and %s resolution", options.Window,
+		options.Resolution)
+	self.store = newUsageStore(options)
+	go wait.Until(self.scrape, options.Resolution, wait.NeverStop)
+}
+
+// scrape stores current usage of all pods and nodes.
+func (self *metricsServerClient) scrape() {
+	list := podMetricsList{}
+	if _, err := self.getUsage([]string{"pods"}, &list); err != nil {
+		logger.Errorf("Could not scrape usage of pods: %s", err)
+		return
+	}
+	pods := make(map[string]usage)
+	for _, item := range list.Items {
+		pods[podKey(item.Namespace, item.Name)] = item.usage()
+	}
+
+	nodes, err := self.getNodeUsage()
+	if err != nil {
+		logger.Errorf("Could not scrape usage of nodes: %s", err)
+		return
+	}
+
+	self.store.update(pods, nodes, time.Now())
+}
@@ -0,0 +1,144 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsserver
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func cpuUsage(timestamp time.Time, cpu string) usage {
+	return usage{
+		timestamp: timestamp,
+		resources: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)},
+	}
+}
+
+func TestUsageStore(t *testing.T) {
+	start := time.Date(2017, 5, 1, 10, 0, 0, 0, time.UTC)
+	store := newUsageStore(ScraperOptions{Window: 2 * time.Minute, Resolution: 30 * time.Second})
+
+	store.update(map[string]usage{podKey("ns-1", "pod-1"): cpuUsage(start.Add(10*time.Second), "100m")},
+		map[string]usage{"node-1": cpuUsage(start, "1")}, start)
+	// Metrics server has not refreshed usage of the node yet, so the sample is not duplicated.
+	store.update(map[string]usage{podKey("ns-1", "pod-1"): cpuUsage(start.Add(40*time.Second), "200m")},
+		map[string]usage{"node-1": cpuUsage(start, "1")}, start.Add(30*time.Second))
+	// First sample of the pod falls out of the window and the node is gone.
+	store.update(map[string]usage{podKey("ns-1", "pod-1"): cpuUsage(start.Add(2*time.Minute), "300m")},
+		map[string]usage{}, start.Add(150*time.Second))
+
+	expected := map[string][]usage{
+		"pod-1": {
+			cpuUsage(start.Add(30*time.Second), "200m"),
+			cpuUsage(start.Add(2*time.Minute), "300m"),
+		},
+	}
+	actual := store.history(api.ResourceKindPod, "ns-1", []string{"pod-1", "pod-2"})
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("history() == %v, expected %v", actual, expected)
+	}
+
+	namespace := store.namespaceHistory("ns-1")
+	if len(namespace) != 2 || namespace[1].CPUUsage != 300 {
+		t.Errorf("namespaceHistory() == %v, expected samples of pod-1", namespace)
+	}
+	if samples := store.namespaceHistory("ns"); len(samples) != 0 {
+		t.Errorf("namespaceHistory() == %v, expected no samples of other namespace", samples)
+	}
+
+	if nodes := store.history(api.ResourceKindNode, "", []string{"node-1"}); len(nodes) != 0 {
+		t.Errorf("history() == %v, expected no samples of pruned node", nodes)
+	}
+}
+
+func TestToHistoryMetric(t *testing.T) {
+	start := time.Date(2017, 5, 1, 10, 0, 0, 0, time.UTC)
+	history := map[string][]usage{
+		"pod-1": {cpuUsage(start, "100m"), cpuUsage(start.Add(30*time.Second), "150m")},
+		"pod-2": {cpuUsage(start.Add(30*time.Second), "50m")},
+	}
+
+	metric, ok := toHistoryMetric(metricapi.CpuUsage, api.ResourceKindPod,
+		[]string{"pod-1", "pod-2"}, nil, history)
+	if !ok {
+		t.Fatal("toHistoryMetric() expected to return metric")
+	}
+
+	expected := metricapi.DataPoints{
+		{X: start.Unix(), Y: 100},
+		{X: start.Add(30 * time.Second).Unix(), Y: 200},
+	}
+	if !reflect.DeepEqual(metric.DataPoints, expected) {
+		t.Errorf("toHistoryMetric() == %v, expected %v", metric.DataPoints, expected)
+	}
+
+	if _, ok := toHistoryMetric(metricapi.CpuUsage, api.ResourceKindPod, []string{"pod-3"}, nil,
+		history); ok {
+		t.Error("toHistoryMetric() expected no metric for resources without samples")
+	}
+}
+
+func TestScrape(t *testing.T) {
+	responses := map[string]string{
+		"/apis/metrics.k8s.io":               metricsAPIResponses["/apis/metrics.k8s.io"],
+		"/apis/metrics.k8s.io/v1beta1/nodes": metricsAPIResponses["/apis/metrics.k8s.io/v1beta1/nodes"],
+		"/apis/metrics.k8s.io/v1beta1/pods": `{"items": [
+			{"metadata": {"name": "pod-1", "namespace": "ns-1"}, "timestamp": "2017-05-01T10:00:00Z",
+				"containers": [{"name": "app", "usage": {"cpu": "100m", "memory": "64Mi"}}]},
+			{"metadata": {"name": "pod-1", "namespace": "ns-2"}, "timestamp": "2017-05-01T10:00:00Z",
+				"containers": [{"name": "app", "usage": {"cpu": "300m", "memory": "64Mi"}}]}]}`,
+	}
+	client, closeServer := newTestClient(t, responses)
+	defer closeServer()
+
+	// Samples of the test server are old, the window has to reach them.
+	start := time.Date(2017, 5, 1, 10, 0, 0, 0, time.UTC)
+	client.store = newUsageStore(ScraperOptions{
+		Window:     time.Since(start) + time.Hour,
+		Resolution: 30 * time.Second,
+	})
+	client.scrape()
+
+	selectors := []metricapi.ResourceSelector{
+		{Namespace: "ns-2", ResourceType: api.ResourceKindPod, ResourceName: "pod-1", UID: "p-1"},
+		{ResourceType: api.ResourceKindNode, ResourceName: "node-1", UID: "n-1"},
+	}
+	metrics, err := client.DownloadMetric(selectors, metricapi.CpuUsage,
+		metricapi.NoResourceCache).GetMetrics()
+	if err != nil {
+		t.Fatalf("DownloadMetric() returns unexpected error: %v", err)
+	}
+
+	expected := []metricapi.DataPoints{
+		{{X: start.Unix(), Y: 300}},
+		{{X: start.Unix(), Y: 1500}},
+	}
+	actual := []metricapi.DataPoints{metrics[0].DataPoints, metrics[1].DataPoints}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("DownloadMetric() == %v, expected %v", actual, expected)
+	}
+
+	containers := client.ContainerUsageHistory("ns-2", []string{"pod-1"})
+	if samples := containers["app"]; len(containers) != 1 || len(samples) != 1 ||
+		*samples[0].CPUUsage != 300 || *samples[0].MemoryUsage != 64*1024*1024 {
+		t.Errorf("ContainerUsageHistory() == %v, expected single sample of app container", containers)
+	}
+}
@@ -0,0 +1,389 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/metric/common"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Time window and resolution of downloaded metrics. They match the ones used by Heapster model.
+const (
+	metricWindow     = 15 * time.Minute
+	metricResolution = time.Minute
+)
+
+// PrometheusOptions contains options used to connect to Prometheus.
+type PrometheusOptions struct {
+	// Host is the address of Prometheus in the format of protocol://address:port.
+	Host string
+	// BearerToken is sent in Authorization header of every request if it is not empty.
+	BearerToken string
+	// CAFile is the path to a file with certificate authority used to verify Prometheus
+	// certificate. System roots are used if it is empty.
+	CAFile string
+	// Insecure disables verification of Prometheus certificate.
+	Insecure bool
+}
+
+// Prometheus client implements MetricClient and Integration interfaces.
+type prometheusClient struct {
+	host        string
+	bearerToken string
+	client      *http.Client
+	// now returns current time. Replaced in tests.
+	now func() time.Time
+}
+
+// queryRangeResponse is a response of Prometheus range query API.
+type queryRangeResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Values [][]interface{}   `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// queryResponse is a response of Prometheus instant query API.
+type queryResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Result []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// Implement Integration interface.
+
+// HealthCheck implements integration app interface. See Integration interface for more information.
+func (self prometheusClient) HealthCheck() error {
+	if self.client == nil {
+		return errors.New("Prometheus not configured")
+	}
+
+	_, err := self.query("/api/v1/query", url.Values{"query": []string{"vector(1)"}})
+	return err
+}
+
+// ID implements integration app interface. See Integration interface for more information.
+func (self prometheusClient) ID() integrationapi.IntegrationID {
+	return integrationapi.PrometheusIntegrationID
+}
+
+// Implement MetricClient interface
+
+// DownloadMetrics implements metric client interface. See MetricClient for more information.
+func (self prometheusClient) DownloadMetrics(selectors []metricapi.ResourceSelector,
+	metricNames []string, cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	result := metricapi.MetricPromises{}
+	for _, metricName := range metricNames {
+		collectedMetrics := self.DownloadMetric(selectors, metricName, cachedResources)
+		result = append(result, collectedMetrics...)
+	}
+	return result
+}
+
+// DownloadMetric implements metric client interface. See MetricClient for more information.
+// Every selector is downloaded with a single query that returns series of all its native
+// resources, which are then summed up.
+func (self prometheusClient) DownloadMetric(selectors []metricapi.ResourceSelector,
+	metricName string, cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	result := metricapi.NewMetricPromises(len(selectors))
+	for i, selector := range selectors {
+		go func(promise metricapi.MetricPromise, selector metricapi.ResourceSelector) {
+			metric, err := self.downloadSelectorMetric(selector, metricName, cachedResources)
+			promise.Metric <- metric
+			promise.Error <- err
+		}(result[i], selector)
+	}
+	return result
+}
+
+// AggregateMetrics implements metric client interface. See MetricClient for more information.
+func (self prometheusClient) AggregateMetrics(metrics metricapi.MetricPromises, metricName string,
+	aggregations metricapi.AggregationModes) metricapi.MetricPromises {
+	return common.AggregateMetricPromises(metrics, metricName, aggregations, nil)
+}
+
+// ContainerUsage implements container metric client interface. See ContainerMetricClient for more
+// information.
+func (self prometheusClient) ContainerUsage(namespace, pod string) (
+	map[string]metricapi.ContainerUsage, error) {
+	matchers := containerMatchers(namespace, pod)
+	cpu, err := self.queryVector(fmt.Sprintf(containerCPUQuery, matchers), containerLabel)
+	if err != nil {
+		return nil, err
+	}
+	memory, err := self.queryVector(fmt.Sprintf(containerMemoryQuery, matchers), containerLabel)
+	if err != nil {
+		return nil, err
+	}
+	throttled, err := self.queryVector(fmt.Sprintf(containerThrottledQuery, matchers), containerLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]metricapi.ContainerUsage)
+	for name, value := range cpu {
+		usage := result[name]
+		cpuUsage := uint64(value)
+		usage.CPUUsage = &cpuUsage
+		result[name] = usage
+	}
+	for name, value := range memory {
+		usage := result[name]
+		memoryUsage := uint64(value)
+		usage.MemoryUsage = &memoryUsage
+		result[name] = usage
+	}
+	for name, value := range throttled {
+		usage := result[name]
+		ratio := value
+		usage.CPUThrottledRatio = &ratio
+		result[name] = usage
+	}
+	return result, nil
+}
+
+// downloadSelectorMetric downloads metric of all native resources of the selector and sums it up.
+func (self prometheusClient) downloadSelectorMetric(selector metricapi.ResourceSelector,
+	metricName string, cachedResources *metricapi.CachedResources) (*metricapi.Metric, error) {
+	kind, names, uids, err := common.GetNativeResources(selector, cachedResources)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := make([]metricapi.Metric, 0)
+	if len(names) > 0 {
+		query, err := buildQuery(metricName, kind, selector.Namespace, names)
+		if err != nil {
+			return nil, err
+		}
+
+		series, err := self.queryRange(query, resourceLabels[kind])
+		if err != nil {
+			return nil, err
+		}
+
+		for i, name := range names {
+			dataPoints, ok := series[name]
+			if !ok {
+				continue
+			}
+			metrics = append(metrics, metricapi.Metric{
+				DataPoints:   dataPoints,
+				MetricPoints: toMetricPoints(dataPoints),
+				MetricName:   metricName,
+				Label:        metricapi.Label{kind: []types.UID{uids[i]}},
+			})
+		}
+	}
+
+	aggregatedMetric := common.AggregateData(metrics, metricName, metricapi.SumAggregation)
+	return &aggregatedMetric, nil
+}
+
+// queryRange runs range query over the metric window and returns data points of returned
+// series keyed by the value of given label.
+func (self prometheusClient) queryRange(query, label string) (map[string]metricapi.DataPoints, error) {
+	end := self.now().Truncate(metricResolution)
+	start := end.Add(-metricWindow)
+
+	body, err := self.query("/api/v1/query_range", url.Values{
+		"query": []string{query},
+		"start": []string{strconv.FormatInt(start.Unix(), 10)},
+		"end":   []string{strconv.FormatInt(end.Unix(), 10)},
+		"step":  []string{strconv.FormatInt(int64(metricResolution/time.Second), 10)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := queryRangeResponse{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]metricapi.DataPoints)
+	for _, series := range response.Data.Result {
+		dataPoints := metricapi.DataPoints{}
+		for _, value := range series.Values {
+			dataPoint, err := toDataPoint(value)
+			if err != nil {
+				return nil, err
+			}
+			dataPoints = append(dataPoints, dataPoint)
+		}
+		result[series.Metric[label]] = dataPoints
+	}
+	return result, nil
+}
+
+// queryVector runs instant query and returns values of returned samples keyed by the value of
+// given label. Samples that are not a number, i.e. ratios of zero rates, are skipped.
+func (self prometheusClient) queryVector(query, label string) (map[string]float64, error) {
+	body, err := self.query("/api/v1/query", url.Values{
+		"query": []string{query},
+		"time":  []string{strconv.FormatInt(self.now().Unix(), 10)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := queryResponse{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]float64)
+	for _, sample := range response.Data.Result {
+		if len(sample.Value) != 2 {
+			return nil, fmt.Errorf("invalid Prometheus sample: %v", sample.Value)
+		}
+		raw, ok := sample.Value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid Prometheus sample value: %v", sample.Value[1])
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, err
+		}
+		if !math.IsNaN(value) && !math.IsInf(value, 0) {
+			result[sample.Metric[label]] = value
+		}
+	}
+	return result, nil
+}
+
+// query performs GET request to Prometheus API and returns body of successful response.
+func (self prometheusClient) query(path string, params url.Values) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(self.host, "/")+path+"?"+
+		params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if self.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+self.bearerToken)
+	}
+
+	resp, err := self.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		response := queryRangeResponse{}
+		if json.Unmarshal(body, &response) == nil && response.Error != "" {
+			return nil, fmt.Errorf("Prometheus query failed: %s", response.Error)
+		}
+		return nil, fmt.Errorf("Prometheus query failed with status %d", resp.StatusCode)
+	}
+
+	return body, nil
+}
+
+// toDataPoint converts [timestamp, "value"] pair returned by Prometheus to data point.
+func toDataPoint(value []interface{}) (metricapi.DataPoint, error) {
+	if len(value) != 2 {
+		return metricapi.DataPoint{}, fmt.Errorf("invalid Prometheus sample: %v", value)
+	}
+
+	timestamp, ok := value[0].(float64)
+	if !ok {
+		return metricapi.DataPoint{}, fmt.Errorf("invalid Prometheus sample timestamp: %v", value[0])
+	}
+
+	raw, ok := value[1].(string)
+	if !ok {
+		return metricapi.DataPoint{}, fmt.Errorf("invalid Prometheus sample value: %v", value[1])
+	}
+
+	y, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return metricapi.DataPoint{}, err
+	}
+
+	return metricapi.DataPoint{X: int64(timestamp), Y: int64(y)}, nil
+}
+
+func toMetricPoints(dataPoints metricapi.DataPoints) []metricapi.MetricPoint {
+	metricPoints := make([]metricapi.MetricPoint, len(dataPoints))
+	for i, dataPoint := range dataPoints {
+		metricPoints[i] = metricapi.MetricPoint{
+			Timestamp: time.Unix(dataPoint.X, 0).UTC(),
+			Value:     uint64(dataPoint.Y),
+		}
+	}
+	return metricPoints
+}
+
+// CreatePrometheusClient creates new Prometheus client.
+func CreatePrometheusClient(options PrometheusOptions) (metricapi.MetricClient, error) {
+	if options.Host == "" {
+		return prometheusClient{}, errors.New("Prometheus host is not set")
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: options.Insecure}
+	if options.CAFile != "" {
+		caData, err := ioutil.ReadFile(options.CAFile)
+		if err != nil {
+			return prometheusClient{}, err
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caData) {
+			return prometheusClient{}, fmt.Errorf("no certificates found in %s", options.CAFile)
+		}
+	}
+
+	logger.Infof("Creating Prometheus client for %s", options.Host)
+	return prometheusClient{
+		host:        options.Host,
+		bearerToken: options.BearerToken,
+		client: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+			Timeout:   30 * time.Second,
+		},
+		now: time.Now,
+	}, nil
+}
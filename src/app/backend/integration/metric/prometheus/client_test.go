@@ -0,0 +1,184 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+const queryRangeResult = `{
+  "status": "success",
+  "data": {
+    "resultType": "matrix",
+    "result": [
+      {"metric": {"pod_name": "pod-1"}, "values": [[1493632800, "100.4"], [1493632860, "200"]]},
+      {"metric": {"pod_name": "pod-2"}, "values": [[1493632800, "10"], [1493632860, "20"]]}
+    ]
+  }
+}`
+
+func TestDownloadMetric(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		w.Write([]byte(queryRangeResult))
+	}))
+	defer server.Close()
+
+	metricClient, err := CreatePrometheusClient(PrometheusOptions{Host: server.URL, BearerToken: "token"})
+	if err != nil {
+		t.Fatalf("CreatePrometheusClient() returns unexpected error: %v", err)
+	}
+	client := metricClient.(prometheusClient)
+	client.now = func() time.Time { return time.Unix(1493633730, 0) }
+
+	owner := metaV1.OwnerReference{UID: "rs-1", Controller: new(bool)}
+	*owner.Controller = true
+	pods := []v1.Pod{
+		{ObjectMeta: metaV1.ObjectMeta{Name: "pod-1", Namespace: "ns-1", UID: "p-1",
+			OwnerReferences: []metaV1.OwnerReference{owner}}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "pod-2", Namespace: "ns-1", UID: "p-2",
+			OwnerReferences: []metaV1.OwnerReference{owner}}},
+	}
+	selectors := []metricapi.ResourceSelector{{
+		Namespace:    "ns-1",
+		ResourceType: api.ResourceKindReplicaSet,
+		ResourceName: "rs",
+		UID:          "rs-1",
+	}}
+
+	metrics, err := client.DownloadMetric(selectors, metricapi.CpuUsage,
+		&metricapi.CachedResources{Pods: pods}).GetMetrics()
+	if err != nil {
+		t.Fatalf("DownloadMetric() returns unexpected error: %v", err)
+	}
+
+	expectedPoints := metricapi.DataPoints{{X: 1493632800, Y: 110}, {X: 1493632860, Y: 220}}
+	if len(metrics) != 1 || !reflect.DeepEqual(metrics[0].DataPoints, expectedPoints) {
+		t.Errorf("DownloadMetric() == %v, expected data points %v", metrics, expectedPoints)
+	}
+	if len(metrics) == 1 && !reflect.DeepEqual(metrics[0].Label,
+		metricapi.Label{api.ResourceKindPod: []types.UID{"p-1", "p-2"}}) {
+		t.Errorf("DownloadMetric() returned label %v", metrics[0].Label)
+	}
+
+	if len(requests) != 1 {
+		t.Fatalf("DownloadMetric() sent %d requests, expected 1", len(requests))
+	}
+	query := requests[0].URL.Query()
+	expectedQuery := `sum(rate(container_cpu_usage_seconds_total{pod_name=~"pod-1|pod-2",` +
+		`namespace="ns-1",container_name!="POD",container_name!=""}[5m])) by (pod_name) * 1000`
+	if query.Get("query") != expectedQuery {
+		t.Errorf("DownloadMetric() sent query %s, expected %s", query.Get("query"), expectedQuery)
+	}
+	if query.Get("start") != "1493632800" || query.Get("end") != "1493633700" || query.Get("step") != "60" {
+		t.Errorf("DownloadMetric() sent invalid range %v", query)
+	}
+	if auth := requests[0].Header.Get("Authorization"); auth != "Bearer token" {
+		t.Errorf("DownloadMetric() sent Authorization header %s, expected Bearer token", auth)
+	}
+}
+
+func TestBuildQuery(t *testing.T) {
+	cases := []struct {
+		metricName string
+		kind       api.ResourceKind
+		names      []string
+		expected   string
+	}{
+		{
+			metricapi.MemoryUsage, api.ResourceKindNode, []string{"node-1.example.com"},
+			`sum(container_memory_usage_bytes{kubernetes_io_hostname=~"node-1\\.example\\.com",id="/"}) ` +
+				`by (kubernetes_io_hostname)`,
+		},
+		{
+			NetworkRxRate, api.ResourceKindPod, []string{"pod-1"},
+			`sum(rate(container_network_receive_bytes_total{pod_name=~"pod-1",namespace="ns-1"}[5m])) ` +
+				`by (pod_name)`,
+		},
+		{
+			metricapi.MemoryUsage, api.ResourceKindNamespace, []string{"ns-1"},
+			`sum(container_memory_usage_bytes{namespace=~"ns-1",container_name!="POD",` +
+				`container_name!=""}) by (namespace)`,
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := buildQuery(c.metricName, c.kind, "ns-1", c.names)
+		if err != nil {
+			t.Errorf("buildQuery(%s, %s) returns unexpected error: %v", c.metricName, c.kind, err)
+		}
+		if actual != c.expected {
+			t.Errorf("buildQuery(%s, %s) == %s, expected %s", c.metricName, c.kind, actual, c.expected)
+		}
+	}
+
+	if _, err := buildQuery("unknown", api.ResourceKindPod, "ns-1", []string{"pod-1"}); err == nil {
+		t.Error("buildQuery() expected error for unsupported metric")
+	}
+}
+
+func TestContainerUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("query")
+		if r.URL.Path != "/api/v1/query" || !strings.Contains(query, `pod_name="pod-1"`) {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		switch {
+		case strings.Contains(query, "cfs_throttled"):
+			w.Write([]byte(`{"status": "success", "data": {"resultType": "vector", "result": [
+				{"metric": {"container_name": "app"}, "value": [1493633730, "0.5"]},
+				{"metric": {"container_name": "sidecar"}, "value": [1493633730, "NaN"]}]}}`))
+		case strings.Contains(query, "cpu_usage"):
+			w.Write([]byte(`{"status": "success", "data": {"resultType": "vector", "result": [
+				{"metric": {"container_name": "app"}, "value": [1493633730, "250.7"]},
+				{"metric": {"container_name": "sidecar"}, "value": [1493633730, "5"]}]}}`))
+		default:
+			w.Write([]byte(`{"status": "success", "data": {"resultType": "vector", "result": [
+				{"metric": {"container_name": "app"}, "value": [1493633730, "1024"]}]}}`))
+		}
+	}))
+	defer server.Close()
+
+	metricClient, err := CreatePrometheusClient(PrometheusOptions{Host: server.URL})
+	if err != nil {
+		t.Fatalf("CreatePrometheusClient() returns unexpected error: %v", err)
+	}
+	usage, err := metricClient.(metricapi.ContainerMetricClient).ContainerUsage("ns-1", "pod-1")
+	if err != nil {
+		t.Fatalf("ContainerUsage() returns unexpected error: %v", err)
+	}
+
+	app, sidecar := usage["app"], usage["sidecar"]
+	if len(usage) != 2 || *app.CPUUsage != 250 || *app.MemoryUsage != 1024 ||
+		math.Abs(*app.CPUThrottledRatio-0.5) > 1e-9 {
+		t.Errorf("ContainerUsage() == %#v, unexpected usage of app container", usage)
+	}
+	if *sidecar.CPUUsage != 5 || sidecar.MemoryUsage != nil || sidecar.CPUThrottledRatio != nil {
+		t.Errorf("ContainerUsage() == %#v, unexpected usage of sidecar container", usage)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+)
+
+// Names of metrics that are supported in addition to the ones defined in metric API.
+const (
+	NetworkRxRate = "network/rx_rate"
+	NetworkTxRate = "network/tx_rate"
+)
+
+// rateRange is the range over which rates of counters are computed.
+const rateRange = "5m"
+
+// queryTemplate describes how to compute a metric from the data collected by cAdvisor.
+type queryTemplate struct {
+	// expression is formatted with label matchers of the queried resources.
+	expression string
+	// multiplier converts values to units used by Heapster, e.g. CPU cores to millicores.
+	multiplier float64
+	// perContainer is true for metrics reported separately for every container of a pod. Network
+	// metrics are reported only for the pod infrastructure container.
+	perContainer bool
+}
+
+// queryTemplates are keyed by metric name.
+var queryTemplates = map[string]queryTemplate{
+	metricapi.CpuUsage:    {"rate(container_cpu_usage_seconds_total{%s}[" + rateRange + "])", 1000, true},
+	metricapi.MemoryUsage: {"container_memory_usage_bytes{%s}", 1, true},
+	NetworkRxRate:         {"rate(container_network_receive_bytes_total{%s}[" + rateRange + "])", 1, false},
+	NetworkTxRate:         {"rate(container_network_transmit_bytes_total{%s}[" + rateRange + "])", 1, false},
+}
+
+// Labels that identify resources in metrics exported by cAdvisor.
+const (
+	podLabel       = "pod_name"
+	namespaceLabel = "namespace"
+	containerLabel = "container_name"
+	nodeLabel      = "kubernetes_io_hostname"
+)
+
+// resourceLabels maps native resource kinds to the label that holds their names.
+var resourceLabels = map[api.ResourceKind]string{
+	api.ResourceKindPod:       podLabel,
+	api.ResourceKindNode:      nodeLabel,
+	api.ResourceKindNamespace: namespaceLabel,
+}
+
+// buildQuery returns PromQL query that computes the metric for every resource with given names
+// and returns one series per resource, labeled by the resource name.
+func buildQuery(metricName string, kind api.ResourceKind, namespace string, names []string) (
+	string, error) {
+	template, ok := queryTemplates[metricName]
+	if !ok {
+		return "", fmt.Errorf("metric %s is not supported by Prometheus integration", metricName)
+	}
+
+	label, ok := resourceLabels[kind]
+	if !ok {
+		return "", fmt.Errorf("resource %s is not supported by Prometheus integration", kind)
+	}
+
+	matchers := []string{fmt.Sprintf(`%s=~"%s"`, label, namesRegexp(names))}
+	switch kind {
+	case api.ResourceKindPod:
+		matchers = append(matchers, fmt.Sprintf(`%s="%s"`, namespaceLabel, namespace))
+	case api.ResourceKindNode:
+		// Root cgroup contains usage of the whole node.
+		matchers = append(matchers, `id="/"`)
+	}
+
+	if kind != api.ResourceKindNode && template.perContainer {
+		// Skip pod infrastructure containers and pod level cgroup totals, which would be counted
+		// twice.
+		matchers = append(matchers, fmt.Sprintf(`%s!="POD"`, containerLabel),
+			fmt.Sprintf(`%s!=""`, containerLabel))
+	}
+
+	expression := fmt.Sprintf(template.expression, strings.Join(matchers, ","))
+	query := fmt.Sprintf("sum(%s) by (%s)", expression, label)
+	if template.multiplier != 1 {
+		query = fmt.Sprintf("%s * %g", query, template.multiplier)
+	}
+	return query, nil
+}
+
+// namesRegexp returns regular expression that matches exactly given names, escaped to be used
+// in a PromQL string.
+func namesRegexp(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = strings.Replace(regexp.QuoteMeta(name), `\`, `\\`, -1)
+	}
+	return strings.Join(quoted, "|")
+}
+
+// Queries computing usage of single containers of a pod, formatted with containerMatchers.
+// Throttled ratio is the share of CFS periods, in which the container was throttled.
+const (
+	containerCPUQuery = "sum(rate(container_cpu_usage_seconds_total{%[1]s}[" + rateRange + "])) by (" +
+		containerLabel + ") * 1000"
+	containerMemoryQuery    = "sum(container_memory_usage_bytes{%[1]s}) by (" + containerLabel + ")"
+	containerThrottledQuery = "sum(rate(container_cpu_cfs_throttled_periods_total{%[1]s}[" + rateRange +
+		"])) by (" + containerLabel + ") / sum(rate(container_cpu_cfs_periods_total{%[1]s}[" + rateRange +
+		"])) by (" + containerLabel + ")"
+)
+
+// containerMatchers returns label matchers selecting application containers of the pod.
+func containerMatchers(namespace, pod string) string {
+	return strings.Join([]string{
+		fmt.Sprintf(`%s="%s"`, namespaceLabel, namespace),
+		fmt.Sprintf(`%s="%s"`, podLabel, pod),
+		fmt.Sprintf(`%s!="POD"`, containerLabel),
+		fmt.Sprintf(`%s!=""`, containerLabel),
+	}, ",")
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"strings"
+
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Severities of vulnerabilities reported by scanners. Scanners use different names, they are
+// normalized by VulnerabilitySummary.Add.
+const (
+	SeverityCritical = "Critical"
+	SeverityHigh     = "High"
+	SeverityMedium   = "Medium"
+	SeverityLow      = "Low"
+	SeverityUnknown  = "Unknown"
+)
+
+// ScannerClient is an interface that exposes API used by dashboard to show vulnerabilities of
+// container images.
+type ScannerClient interface {
+	// Scan returns summary of vulnerabilities found in the image. Summary with Scanned set to false
+	// is returned when the scanner has no report of the image.
+	Scan(image ImageReference) (*VulnerabilitySummary, error)
+
+	// Implements IntegrationApp interface
+	integrationapi.Integration
+}
+
+// ImageReference identifies a container image.
+type ImageReference struct {
+	// Name is the image as specified in the pod spec, e.g. nginx:1.13.
+	Name string
+	// Digest is the digest of the image run by the container runtime, e.g. sha256:abc... Empty if
+	// unknown.
+	Digest string
+}
+
+// Pinned returns the image reference pinned to the digest, e.g. nginx@sha256:abc..., if the digest
+// is known and the name otherwise.
+func (self ImageReference) Pinned() string {
+	if len(self.Digest) == 0 {
+		return self.Name
+	}
+	return self.Repository() + "@" + self.Digest
+}
+
+// Repository returns the name of the image without tag and digest, e.g. nginx for nginx:1.13.
+func (self ImageReference) Repository() string {
+	name := self.Name
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	return name
+}
+
+// VulnerabilitySummary contains numbers of vulnerabilities found in an image by severity.
+type VulnerabilitySummary struct {
+	// Scanner is the id of the integration that scanned the image.
+	Scanner integrationapi.IntegrationID `json:"scanner"`
+	// Scanned is false if the scanner has no report of the image, e.g. because the image is not
+	// stored in a registry scanned by it.
+	Scanned  bool `json:"scanned"`
+	Critical int  `json:"critical"`
+	High     int  `json:"high"`
+	Medium   int  `json:"medium"`
+	Low      int  `json:"low"`
+	Unknown  int  `json:"unknown"`
+	Total    int  `json:"total"`
+	// ScannedAt is the time the report was created if the scanner provides it.
+	ScannedAt *metaV1.Time `json:"scannedAt,omitempty"`
+}
+
+// Add adds count vulnerabilities of given severity to the summary. Severity names of all supported
+// scanners are recognized case insensitively, unrecognized ones are counted as unknown.
+func (self *VulnerabilitySummary) Add(severity string, count int) {
+	switch strings.ToLower(severity) {
+	case "critical", "defcon1":
+		self.Critical += count
+	case "high":
+		self.High += count
+	case "medium", "moderate":
+		self.Medium += count
+	case "low", "negligible":
+		self.Low += count
+	default:
+		self.Unknown += count
+	}
+	self.Total += count
+}
+
+// DigestFromImageID returns manifest digest of the image from image ID reported in container
+// status, e.g. docker-pullable://nginx@sha256:abc... Empty string is returned if the ID contains no
+// repository digest, e.g. for locally built images identified only by their config digest.
+func DigestFromImageID(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		return imageID[i+1:]
+	}
+	return ""
+}
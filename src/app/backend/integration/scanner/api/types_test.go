@@ -0,0 +1,72 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVulnerabilitySummaryAdd(t *testing.T) {
+	summary := &VulnerabilitySummary{}
+	summary.Add("CRITICAL", 1)
+	summary.Add("Defcon1", 1)
+	summary.Add("high", 2)
+	summary.Add("Moderate", 3)
+	summary.Add("Negligible", 4)
+	summary.Add("whatever", 5)
+
+	expected := &VulnerabilitySummary{Critical: 2, High: 2, Medium: 3, Low: 4, Unknown: 5, Total: 16}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("Expected %#v, got %#v", expected, summary)
+	}
+}
+
+func TestDigestFromImageID(t *testing.T) {
+	cases := []struct {
+		imageID, expected string
+	}{
+		{"docker-pullable://nginx@sha256:abc", "sha256:abc"},
+		{"nginx@sha256:abc", "sha256:abc"},
+		{"docker://sha256:def", ""},
+		{"", ""},
+	}
+	for _, c := range cases {
+		if actual := DigestFromImageID(c.imageID); actual != c.expected {
+			t.Errorf("DigestFromImageID(%q) == %q, expected %q", c.imageID, actual, c.expected)
+		}
+	}
+}
+
+func TestImageReferencePinned(t *testing.T) {
+	cases := []struct {
+		image              ImageReference
+		repository, pinned string
+	}{
+		{ImageReference{Name: "nginx:1.13"}, "nginx", "nginx:1.13"},
+		{ImageReference{Name: "nginx:1.13", Digest: "sha256:abc"}, "nginx", "nginx@sha256:abc"},
+		{ImageReference{Name: "localhost:5000/app", Digest: "sha256:abc"}, "localhost:5000/app",
+			"localhost:5000/app@sha256:abc"},
+		{ImageReference{Name: "app@sha256:def", Digest: "sha256:abc"}, "app", "app@sha256:abc"},
+	}
+	for _, c := range cases {
+		if actual := c.image.Repository(); actual != c.repository {
+			t.Errorf("%#v.Repository() == %q, expected %q", c.image, actual, c.repository)
+		}
+		if actual := c.image.Pinned(); actual != c.pinned {
+			t.Errorf("%#v.Pinned() == %q, expected %q", c.image, actual, c.pinned)
+		}
+	}
+}
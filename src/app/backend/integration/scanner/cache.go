@@ -0,0 +1,104 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"sync"
+	"time"
+
+	scannerapi "github.com/kubernetes/dashboard/src/app/backend/integration/scanner/api"
+)
+
+// scanResultTTL is how long summaries of scanned images are cached. Summaries of images the
+// scanner has no report of are cached for a shorter time, so that new reports show up soon.
+const (
+	scanResultTTL       = time.Hour
+	notScannedResultTTL = 5 * time.Minute
+)
+
+// cachedClient is a scanner client, which caches summaries returned by the wrapped client. Scans
+// can take minutes and image inventory requests summaries of all images in the cluster. Concurrent
+// scans of the same image share a single scan of the wrapped client.
+type cachedClient struct {
+	scannerapi.ScannerClient
+
+	mux      sync.Mutex
+	entries  map[scannerapi.ImageReference]cacheEntry
+	inFlight map[scannerapi.ImageReference]*scanCall
+	// now returns current time. Replaced in tests.
+	now func() time.Time
+}
+
+type cacheEntry struct {
+	summary *scannerapi.VulnerabilitySummary
+	expires time.Time
+}
+
+// scanCall is a scan in progress. Done is closed when summary and err are set.
+type scanCall struct {
+	done    chan struct{}
+	summary *scannerapi.VulnerabilitySummary
+	err     error
+}
+
+// Scan implements scanner client interface. See ScannerClient for more information. Errors are not
+// cached.
+func (self *cachedClient) Scan(image scannerapi.ImageReference) (*scannerapi.VulnerabilitySummary, error) {
+	self.mux.Lock()
+	entry, ok := self.entries[image]
+	if ok && self.now().Before(entry.expires) {
+		self.mux.Unlock()
+		return entry.summary, nil
+	}
+	if call, ok := self.inFlight[image]; ok {
+		self.mux.Unlock()
+		<-call.done
+		return call.summary, call.err
+	}
+	call := &scanCall{done: make(chan struct{})}
+	self.inFlight[image] = call
+	self.mux.Unlock()
+
+	call.summary, call.err = self.ScannerClient.Scan(image)
+
+	self.mux.Lock()
+	defer self.mux.Unlock()
+	delete(self.inFlight, image)
+	close(call.done)
+	if call.err != nil {
+		return nil, call.err
+	}
+
+	ttl := scanResultTTL
+	if !call.summary.Scanned {
+		ttl = notScannedResultTTL
+	}
+	self.entries[image] = cacheEntry{summary: call.summary, expires: self.now().Add(ttl)}
+	for key, cached := range self.entries {
+		if !self.now().Before(cached.expires) {
+			delete(self.entries, key)
+		}
+	}
+	return call.summary, nil
+}
+
+func newCachedClient(client scannerapi.ScannerClient) scannerapi.ScannerClient {
+	return &cachedClient{
+		ScannerClient: client,
+		entries:       make(map[scannerapi.ImageReference]cacheEntry),
+		inFlight:      make(map[scannerapi.ImageReference]*scanCall),
+		now:           time.Now,
+	}
+}
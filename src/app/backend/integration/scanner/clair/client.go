@@ -0,0 +1,146 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clair
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	scannerapi "github.com/kubernetes/dashboard/src/app/backend/integration/scanner/api"
+)
+
+// requestTimeout is the timeout of requests to Clair.
+const requestTimeout = 30 * time.Second
+
+// ClairOptions contains options used to connect to Clair.
+type ClairOptions struct {
+	// Host is the address of Clair v4 serving both indexer and matcher APIs in the format of
+	// protocol://address:port.
+	Host string
+	// BearerToken is sent in Authorization header of every request if it is not empty.
+	BearerToken string
+}
+
+// Clair client implements ScannerClient and Integration interfaces. Clair only matches
+// vulnerabilities of manifests indexed before, e.g. by a registry or clairctl, so images are
+// looked up by digest and images without a digest are reported as not scanned.
+type clairClient struct {
+	host        string
+	bearerToken string
+	client      *http.Client
+}
+
+// vulnerabilityReport is the vulnerability report of a manifest returned by Clair matcher API.
+type vulnerabilityReport struct {
+	Vulnerabilities map[string]struct {
+		Name               string `json:"name"`
+		NormalizedSeverity string `json:"normalized_severity"`
+	} `json:"vulnerabilities"`
+}
+
+// Implement Integration interface.
+
+// HealthCheck implements integration app interface. See Integration interface for more information.
+func (self clairClient) HealthCheck() error {
+	if self.client == nil {
+		return errors.New("Clair not configured")
+	}
+
+	_, err := self.get("/indexer/api/v1/index_state")
+	return err
+}
+
+// ID implements integration app interface. See Integration interface for more information.
+func (self clairClient) ID() integrationapi.IntegrationID {
+	return integrationapi.ClairIntegrationID
+}
+
+// Implement ScannerClient interface.
+
+// Scan implements scanner client interface. See ScannerClient for more information.
+func (self clairClient) Scan(image scannerapi.ImageReference) (*scannerapi.VulnerabilitySummary, error) {
+	summary := &scannerapi.VulnerabilitySummary{Scanner: self.ID()}
+	if len(image.Digest) == 0 {
+		return summary, nil
+	}
+
+	response, err := self.get("/matcher/api/v1/vulnerability_report/" + url.PathEscape(image.Digest))
+	if err == errNotFound {
+		return summary, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	report := new(vulnerabilityReport)
+	if err := json.NewDecoder(response.Body).Decode(report); err != nil {
+		return nil, fmt.Errorf("Could not parse Clair report of %s image: %s", image.Name, err)
+	}
+
+	summary.Scanned = true
+	for _, vulnerability := range report.Vulnerabilities {
+		summary.Add(vulnerability.NormalizedSeverity, 1)
+	}
+	return summary, nil
+}
+
+// errNotFound is returned by get when Clair responds with not found status.
+var errNotFound = errors.New("Not found")
+
+// get sends GET request to Clair. Body of the response has to be closed by the caller if no error
+// is returned.
+func (self clairClient) get(path string) (*http.Response, error) {
+	request, err := http.NewRequest(http.MethodGet, self.host+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(self.bearerToken) > 0 {
+		request.Header.Set("Authorization", "Bearer "+self.bearerToken)
+	}
+
+	response, err := self.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode == http.StatusNotFound {
+		response.Body.Close()
+		return nil, errNotFound
+	}
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf("Clair request %s failed with status %s", path, response.Status)
+	}
+	return response, nil
+}
+
+// CreateClairClient creates new Clair client.
+func CreateClairClient(options ClairOptions) (scannerapi.ScannerClient, error) {
+	if len(options.Host) == 0 {
+		return nil, errors.New("Clair host is required")
+	}
+
+	return clairClient{
+		host:        strings.TrimSuffix(options.Host, "/"),
+		bearerToken: options.BearerToken,
+		client:      &http.Client{Timeout: requestTimeout},
+	}, nil
+}
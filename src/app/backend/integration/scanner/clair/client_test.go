@@ -0,0 +1,93 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clair
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	scannerapi "github.com/kubernetes/dashboard/src/app/backend/integration/scanner/api"
+)
+
+const testReport = `{
+  "manifest_hash": "sha256:abc",
+  "vulnerabilities": {
+    "1": {"name": "CVE-1", "normalized_severity": "Critical"},
+    "2": {"name": "CVE-2", "normalized_severity": "Medium"},
+    "3": {"name": "CVE-3", "normalized_severity": "Negligible"}
+  }
+}`
+
+func TestClairClientScan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/indexer/api/v1/index_state":
+			w.Write([]byte(`{"state": "abc"}`))
+		case "/matcher/api/v1/vulnerability_report/sha256:abc":
+			w.Write([]byte(testReport))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := CreateClairClient(ClairOptions{Host: server.URL, BearerToken: "token"})
+	if err != nil {
+		t.Fatalf("CreateClairClient returned error: %s", err)
+	}
+	if err := client.HealthCheck(); err != nil {
+		t.Errorf("HealthCheck returned error: %s", err)
+	}
+
+	cases := []struct {
+		image    scannerapi.ImageReference
+		expected *scannerapi.VulnerabilitySummary
+	}{
+		{
+			scannerapi.ImageReference{Name: "nginx", Digest: "sha256:abc"},
+			&scannerapi.VulnerabilitySummary{Scanner: "clair", Scanned: true, Critical: 1, Medium: 1,
+				Low: 1, Total: 3},
+		},
+		{
+			scannerapi.ImageReference{Name: "nginx", Digest: "sha256:def"},
+			&scannerapi.VulnerabilitySummary{Scanner: "clair"},
+		},
+		{
+			scannerapi.ImageReference{Name: "nginx"},
+			&scannerapi.VulnerabilitySummary{Scanner: "clair"},
+		},
+	}
+	for _, c := range cases {
+		actual, err := client.Scan(c.image)
+		if err != nil {
+			t.Errorf("Scan(%#v) returned error: %s", c.image, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Scan(%#v) == %#v, expected %#v", c.image, actual, c.expected)
+		}
+	}
+
+	unauthorized, _ := CreateClairClient(ClairOptions{Host: server.URL})
+	if _, err := unauthorized.Scan(scannerapi.ImageReference{Name: "nginx", Digest: "sha256:abc"}); err == nil {
+		t.Error("Expected error of unauthorized request")
+	}
+}
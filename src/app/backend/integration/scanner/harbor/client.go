@@ -0,0 +1,205 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package harbor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	scannerapi "github.com/kubernetes/dashboard/src/app/backend/integration/scanner/api"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// requestTimeout is the timeout of requests to Harbor API.
+const requestTimeout = 30 * time.Second
+
+// scanStatusSuccess is the status of a finished scan in Harbor scan overview.
+const scanStatusSuccess = "Success"
+
+// HarborOptions contains options used to connect to Harbor API.
+type HarborOptions struct {
+	// Host is the address of Harbor in the format of protocol://address:port.
+	Host string
+	// Registry is the registry host images stored in Harbor are pulled from, e.g.
+	// harbor.example.com. Defaults to the host of Harbor address.
+	Registry string
+	// Username and Password are used for basic authentication of requests. Optional for public
+	// projects.
+	Username string
+	Password string
+}
+
+// Harbor client implements ScannerClient and Integration interfaces. Only images pulled from the
+// Harbor registry have scan reports, other images are reported as not scanned.
+type harborClient struct {
+	host     string
+	registry string
+	username string
+	password string
+	client   *http.Client
+}
+
+// artifact is an artifact returned by Harbor API with scan overview.
+type artifact struct {
+	ScanOverview map[string]struct {
+		ScanStatus string       `json:"scan_status"`
+		EndTime    *metaV1.Time `json:"end_time"`
+		Summary    *struct {
+			Total   int            `json:"total"`
+			Summary map[string]int `json:"summary"`
+		} `json:"summary"`
+	} `json:"scan_overview"`
+}
+
+// Implement Integration interface.
+
+// HealthCheck implements integration app interface. See Integration interface for more information.
+func (self harborClient) HealthCheck() error {
+	if self.client == nil {
+		return errors.New("Harbor not configured")
+	}
+
+	health := struct {
+		Status string `json:"status"`
+	}{}
+	if err := self.get("/api/v2.0/health", &health); err != nil {
+		return err
+	}
+	if health.Status != "healthy" {
+		return fmt.Errorf("Harbor is %s", health.Status)
+	}
+	return nil
+}
+
+// ID implements integration app interface. See Integration interface for more information.
+func (self harborClient) ID() integrationapi.IntegrationID {
+	return integrationapi.HarborIntegrationID
+}
+
+// Implement ScannerClient interface.
+
+// Scan implements scanner client interface. See ScannerClient for more information.
+func (self harborClient) Scan(image scannerapi.ImageReference) (*scannerapi.VulnerabilitySummary, error) {
+	summary := &scannerapi.VulnerabilitySummary{Scanner: self.ID()}
+	path, ok := self.getArtifactPath(image)
+	if !ok {
+		return summary, nil
+	}
+
+	result := new(artifact)
+	err := self.get(path+"?with_scan_overview=true", result)
+	if err == errNotFound {
+		return summary, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, overview := range result.ScanOverview {
+		if overview.ScanStatus != scanStatusSuccess || overview.Summary == nil {
+			continue
+		}
+		summary.Scanned = true
+		summary.ScannedAt = overview.EndTime
+		for severity, count := range overview.Summary.Summary {
+			summary.Add(severity, count)
+		}
+		break
+	}
+	return summary, nil
+}
+
+// getArtifactPath returns path of Harbor API artifact of the image. Returns false if the image is
+// not pulled from Harbor registry.
+func (self harborClient) getArtifactPath(image scannerapi.ImageReference) (string, bool) {
+	repository := image.Repository()
+	if !strings.HasPrefix(repository, self.registry+"/") {
+		return "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(repository, self.registry+"/"), "/", 2)
+	if len(parts) != 2 {
+		return "", false
+	}
+
+	reference := image.Digest
+	if len(reference) == 0 {
+		reference = "latest"
+		name := strings.TrimPrefix(image.Name, repository)
+		if strings.HasPrefix(name, "@") {
+			reference = name[1:]
+		} else if strings.HasPrefix(name, ":") {
+			reference = strings.SplitN(name[1:], "@", 2)[0]
+		}
+	}
+
+	// Harbor requires slashes in repository names to be escaped twice.
+	return fmt.Sprintf("/api/v2.0/projects/%s/repositories/%s/artifacts/%s", url.PathEscape(parts[0]),
+		url.PathEscape(url.PathEscape(parts[1])), url.PathEscape(reference)), true
+}
+
+// errNotFound is returned by get when Harbor responds with not found status.
+var errNotFound = errors.New("Not found")
+
+// get sends GET request to Harbor API and decodes JSON response into result.
+func (self harborClient) get(path string, result interface{}) error {
+	request, err := http.NewRequest(http.MethodGet, self.host+path, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	if len(self.username) > 0 {
+		request.SetBasicAuth(self.username, self.password)
+	}
+
+	response, err := self.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("Harbor request %s failed with status %s", path, response.Status)
+	}
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+// CreateHarborClient creates new Harbor client.
+func CreateHarborClient(options HarborOptions) (scannerapi.ScannerClient, error) {
+	hostURL, err := url.Parse(options.Host)
+	if err != nil || len(hostURL.Host) == 0 {
+		return nil, fmt.Errorf("Invalid Harbor host %q", options.Host)
+	}
+
+	registry := options.Registry
+	if len(registry) == 0 {
+		registry = hostURL.Host
+	}
+
+	return harborClient{
+		host:     strings.TrimSuffix(options.Host, "/"),
+		registry: registry,
+		username: options.Username,
+		password: options.Password,
+		client:   &http.Client{Timeout: requestTimeout},
+	}, nil
+}
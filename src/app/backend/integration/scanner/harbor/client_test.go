@@ -0,0 +1,117 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package harbor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	scannerapi "github.com/kubernetes/dashboard/src/app/backend/integration/scanner/api"
+)
+
+const testArtifact = `{
+  "digest": "sha256:abc",
+  "scan_overview": {
+    "application/vnd.security.vulnerability.report; version=1.1": {
+      "scan_status": "Success",
+      "summary": {"total": 5, "summary": {"Critical": 1, "High": 2, "Low": 2}}
+    }
+  }
+}`
+
+func TestHarborClientScan(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		if user, password, ok := r.BasicAuth(); !ok || user != "admin" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.EscapedPath() {
+		case "/api/v2.0/health":
+			w.Write([]byte(`{"status": "healthy"}`))
+		case "/api/v2.0/projects/library/repositories/team%252Fapp/artifacts/sha256:abc":
+			w.Write([]byte(testArtifact))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := CreateHarborClient(HarborOptions{Host: server.URL, Registry: "harbor.local",
+		Username: "admin", Password: "pass"})
+	if err != nil {
+		t.Fatalf("CreateHarborClient returned error: %s", err)
+	}
+	if err := client.HealthCheck(); err != nil {
+		t.Errorf("HealthCheck returned error: %s", err)
+	}
+
+	cases := []struct {
+		image        scannerapi.ImageReference
+		expected     *scannerapi.VulnerabilitySummary
+		expectedPath string
+	}{
+		{
+			scannerapi.ImageReference{Name: "harbor.local/library/team/app:1.0", Digest: "sha256:abc"},
+			&scannerapi.VulnerabilitySummary{Scanner: "harbor", Scanned: true, Critical: 1, High: 2,
+				Low: 2, Total: 5},
+			"/api/v2.0/projects/library/repositories/team%252Fapp/artifacts/sha256:abc",
+		},
+		{
+			scannerapi.ImageReference{Name: "harbor.local/library/app:1.0"},
+			&scannerapi.VulnerabilitySummary{Scanner: "harbor"},
+			"/api/v2.0/projects/library/repositories/app/artifacts/1.0",
+		},
+		{
+			scannerapi.ImageReference{Name: "nginx:1.13", Digest: "sha256:abc"},
+			&scannerapi.VulnerabilitySummary{Scanner: "harbor"},
+			"",
+		},
+	}
+	for _, c := range cases {
+		paths = nil
+		actual, err := client.Scan(c.image)
+		if err != nil {
+			t.Errorf("Scan(%#v) returned error: %s", c.image, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Scan(%#v) == %#v, expected %#v", c.image, actual, c.expected)
+		}
+		if len(c.expectedPath) > 0 && (len(paths) != 1 || paths[0] != c.expectedPath) {
+			t.Errorf("Scan(%#v) requested %v, expected %s", c.image, paths, c.expectedPath)
+		}
+		if len(c.expectedPath) == 0 && len(paths) > 0 {
+			t.Errorf("Scan(%#v) requested %v, expected no request", c.image, paths)
+		}
+	}
+}
+
+func TestCreateHarborClient(t *testing.T) {
+	if _, err := CreateHarborClient(HarborOptions{Host: "harbor"}); err == nil {
+		t.Error("Expected error of invalid host")
+	}
+
+	client, err := CreateHarborClient(HarborOptions{Host: "https://harbor.example.com/"})
+	if err != nil {
+		t.Fatalf("CreateHarborClient returned error: %s", err)
+	}
+	if registry := client.(harborClient).registry; registry != "harbor.example.com" {
+		t.Errorf("Expected registry to default to harbor.example.com, got %s", registry)
+	}
+}
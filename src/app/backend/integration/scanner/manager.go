@@ -0,0 +1,135 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"fmt"
+
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	scannerapi "github.com/kubernetes/dashboard/src/app/backend/integration/scanner/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/scanner/clair"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/scanner/harbor"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/scanner/trivy"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+)
+
+// ScannerManager is responsible for management of all integrated applications scanning container
+// images for vulnerabilities.
+type ScannerManager interface {
+	// AddClient adds scanner client to client list supported by this manager.
+	AddClient(scannerapi.ScannerClient) ScannerManager
+	// Client returns active scanner client. Nil if no scanner is enabled.
+	Client() scannerapi.ScannerClient
+	// Enable is responsible for switching active client if given integration application id
+	// is found and related application is healthy (we can connect to it).
+	Enable(integrationapi.IntegrationID) error
+	// List returns list of available scanner related integrations.
+	List() []integrationapi.Integration
+	// ConfigureTrivy configures and adds Trivy server to clients list.
+	ConfigureTrivy(options trivy.TrivyOptions) ScannerManager
+	// ConfigureClair configures and adds Clair to clients list.
+	ConfigureClair(options clair.ClairOptions) ScannerManager
+	// ConfigureHarbor configures and adds Harbor to clients list.
+	ConfigureHarbor(options harbor.HarborOptions) ScannerManager
+}
+
+// Implements ScannerManager interface.
+type scannerManager struct {
+	clients map[integrationapi.IntegrationID]scannerapi.ScannerClient
+	active  scannerapi.ScannerClient
+}
+
+// AddClient implements scanner manager interface. See ScannerManager for more information.
+func (self *scannerManager) AddClient(client scannerapi.ScannerClient) ScannerManager {
+	if client != nil {
+		self.clients[client.ID()] = client
+	}
+
+	return self
+}
+
+// Client implements scanner manager interface. See ScannerManager for more information.
+func (self *scannerManager) Client() scannerapi.ScannerClient {
+	return self.active
+}
+
+// Enable implements scanner manager interface. See ScannerManager for more information.
+func (self *scannerManager) Enable(id integrationapi.IntegrationID) error {
+	scannerClient, exists := self.clients[id]
+	if !exists {
+		return fmt.Errorf("No scanner client found for integration id: %s", id)
+	}
+
+	err := scannerClient.HealthCheck()
+	if err != nil {
+		return fmt.Errorf("Health check failed: %s", err.Error())
+	}
+
+	self.active = newCachedClient(scannerClient)
+	return nil
+}
+
+// List implements scanner manager interface. See ScannerManager for more information.
+func (self *scannerManager) List() []integrationapi.Integration {
+	result := make([]integrationapi.Integration, 0)
+	for _, c := range self.clients {
+		result = append(result, c)
+	}
+
+	return result
+}
+
+// ConfigureTrivy implements scanner manager interface. See ScannerManager for more information.
+func (self *scannerManager) ConfigureTrivy(options trivy.TrivyOptions) ScannerManager {
+	scannerClient, err := trivy.CreateTrivyClient(options)
+	if err != nil {
+		logger.Errorf("There was an error during Trivy client creation: %s", err.Error())
+		return self
+	}
+
+	self.clients[scannerClient.ID()] = scannerClient
+	return self
+}
+
+// ConfigureClair implements scanner manager interface. See ScannerManager for more information.
+func (self *scannerManager) ConfigureClair(options clair.ClairOptions) ScannerManager {
+	scannerClient, err := clair.CreateClairClient(options)
+	if err != nil {
+		logger.Errorf("There was an error during Clair client creation: %s", err.Error())
+		return self
+	}
+
+	self.clients[scannerClient.ID()] = scannerClient
+	return self
+}
+
+// ConfigureHarbor implements scanner manager interface. See ScannerManager for more information.
+func (self *scannerManager) ConfigureHarbor(options harbor.HarborOptions) ScannerManager {
+	scannerClient, err := harbor.CreateHarborClient(options)
+	if err != nil {
+		logger.Errorf("There was an error during Harbor client creation: %s", err.Error())
+		return self
+	}
+
+	self.clients[scannerClient.ID()] = scannerClient
+	return self
+}
+
+// NewScannerManager creates scanner manager.
+func NewScannerManager() ScannerManager {
+	return &scannerManager{
+		clients: make(map[integrationapi.IntegrationID]scannerapi.ScannerClient),
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	scannerapi "github.com/kubernetes/dashboard/src/app/backend/integration/scanner/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/scanner/clair"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/scanner/harbor"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/scanner/trivy"
+)
+
+const fakeScannerClientID integrationapi.IntegrationID = "test-id"
+
+type FakeScannerClient struct {
+	healthOk bool
+	scanned  bool
+	scans    int
+}
+
+func (FakeScannerClient) ID() integrationapi.IntegrationID {
+	return fakeScannerClientID
+}
+
+func (self *FakeScannerClient) HealthCheck() error {
+	if self.healthOk {
+		return nil
+	}
+
+	return errors.New("test-error")
+}
+
+func (self *FakeScannerClient) Scan(image scannerapi.ImageReference) (*scannerapi.VulnerabilitySummary, error) {
+	self.scans++
+	if image.Name == "error" {
+		return nil, errors.New("test-error")
+	}
+	return &scannerapi.VulnerabilitySummary{Scanner: fakeScannerClientID, Scanned: self.scanned}, nil
+}
+
+func TestScannerManager_Enable(t *testing.T) {
+	cases := []struct {
+		client      *FakeScannerClient
+		id          integrationapi.IntegrationID
+		expectedErr bool
+	}{
+		{&FakeScannerClient{healthOk: true}, fakeScannerClientID, false},
+		{&FakeScannerClient{healthOk: false}, fakeScannerClientID, true},
+		{&FakeScannerClient{healthOk: true}, integrationapi.TrivyIntegrationID, true},
+	}
+
+	for _, c := range cases {
+		manager := NewScannerManager().AddClient(c.client)
+		err := manager.Enable(c.id)
+		if (err != nil) != c.expectedErr {
+			t.Errorf("Enable(%s) == %v, expected error: %t", c.id, err, c.expectedErr)
+		}
+		if c.expectedErr && manager.Client() != nil {
+			t.Errorf("Expected no active client after failed enable, got %#v", manager.Client())
+		}
+		if !c.expectedErr && (manager.Client() == nil || manager.Client().ID() != c.id) {
+			t.Errorf("Expected active client %s, got %#v", c.id, manager.Client())
+		}
+	}
+}
+
+func TestScannerManager_Configure(t *testing.T) {
+	manager := NewScannerManager().
+		ConfigureTrivy(trivy.TrivyOptions{Host: "http://trivy:4954", Binary: "trivy"}).
+		ConfigureClair(clair.ClairOptions{Host: "http://clair:6060"}).
+		ConfigureHarbor(harbor.HarborOptions{Host: "invalid"})
+
+	if actual := len(manager.List()); actual != 2 {
+		t.Errorf("Expected 2 configured scanners, got %d", actual)
+	}
+}
+
+func TestCachedClient(t *testing.T) {
+	fake := &FakeScannerClient{healthOk: true, scanned: true}
+	now := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	client := newCachedClient(fake).(*cachedClient)
+	client.now = func() time.Time { return now }
+
+	image := scannerapi.ImageReference{Name: "nginx", Digest: "sha256:abc"}
+	client.Scan(image)
+	client.Scan(image)
+	if fake.scans != 1 {
+		t.Errorf("Expected cached summary to be reused, got %d scans", fake.scans)
+	}
+
+	now = now.Add(scanResultTTL)
+	client.Scan(image)
+	if fake.scans != 2 {
+		t.Errorf("Expected expired summary to be rescanned, got %d scans", fake.scans)
+	}
+
+	fake.scanned = false
+	other := scannerapi.ImageReference{Name: "other"}
+	client.Scan(other)
+	now = now.Add(notScannedResultTTL)
+	client.Scan(other)
+	if fake.scans != 4 {
+		t.Errorf("Expected not scanned image to be rescanned, got %d scans", fake.scans)
+	}
+
+	failing := scannerapi.ImageReference{Name: "error"}
+	client.Scan(failing)
+	if _, err := client.Scan(failing); err == nil || fake.scans != 6 {
+		t.Errorf("Expected errors not to be cached, got %v after %d scans", err, fake.scans)
+	}
+}
+
+type blockingScannerClient struct {
+	FakeScannerClient
+	started chan struct{}
+	release chan struct{}
+}
+
+func (self *blockingScannerClient) Scan(image scannerapi.ImageReference) (*scannerapi.VulnerabilitySummary, error) {
+	self.started <- struct{}{}
+	<-self.release
+	return self.FakeScannerClient.Scan(image)
+}
+
+func TestCachedClientInFlight(t *testing.T) {
+	fake := &blockingScannerClient{started: make(chan struct{}, 2), release: make(chan struct{})}
+	client := newCachedClient(fake)
+	image := scannerapi.ImageReference{Name: "nginx", Digest: "sha256:abc"}
+
+	done := make(chan error, 2)
+	scan := func() {
+		_, err := client.Scan(image)
+		done <- err
+	}
+	go scan()
+	<-fake.started
+	go scan()
+
+	// The second scan waits for the first one instead of starting its own.
+	select {
+	case <-fake.started:
+		t.Fatal("Expected concurrent scans of the same image to share a single scan")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(fake.release)
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("Scan returned error: %s", err)
+		}
+	}
+	if fake.scans != 1 {
+		t.Errorf("Expected 1 scan, got %d", fake.scans)
+	}
+}
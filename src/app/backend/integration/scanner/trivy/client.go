@@ -0,0 +1,179 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trivy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	scannerapi "github.com/kubernetes/dashboard/src/app/backend/integration/scanner/api"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// requestTimeout is the timeout of health checks of Trivy server. Trivy binary is killed, when a
+// scan takes longer than scanTimeout.
+const (
+	requestTimeout = 10 * time.Second
+	scanTimeout    = 5 * time.Minute
+)
+
+// TrivyOptions contains options used to scan images with Trivy server.
+type TrivyOptions struct {
+	// Host is the address of Trivy server in the format of protocol://address:port.
+	Host string
+	// Binary is the path to Trivy binary used as the client of Trivy server. Images are analyzed
+	// by the binary and only vulnerability database lookups are done by the server.
+	Binary string
+	// Token is the token Trivy server is protected with. Optional.
+	Token string
+}
+
+// Trivy client implements ScannerClient and Integration interfaces.
+type trivyClient struct {
+	host   string
+	binary string
+	token  string
+	client *http.Client
+	// run runs the Trivy binary with given arguments and returns its standard output. Replaced in
+	// tests.
+	run func(args []string, env []string) ([]byte, error)
+}
+
+// report is the JSON report printed by Trivy.
+type report struct {
+	CreatedAt *time.Time `json:"CreatedAt"`
+	Results   []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID string `json:"VulnerabilityID"`
+			Severity        string `json:"Severity"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// Implement Integration interface.
+
+// HealthCheck implements integration app interface. See Integration interface for more information.
+func (self trivyClient) HealthCheck() error {
+	if self.client == nil {
+		return errors.New("Trivy not configured")
+	}
+
+	response, err := self.client.Get(self.host + "/healthz")
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("Trivy server health check failed with status %s", response.Status)
+	}
+	return nil
+}
+
+// ID implements integration app interface. See Integration interface for more information.
+func (self trivyClient) ID() integrationapi.IntegrationID {
+	return integrationapi.TrivyIntegrationID
+}
+
+// Implement ScannerClient interface.
+
+// Scan implements scanner client interface. See ScannerClient for more information.
+func (self trivyClient) Scan(image scannerapi.ImageReference) (*scannerapi.VulnerabilitySummary, error) {
+	// Image names come from pod specs, which do not prevent names that look like flags.
+	if strings.HasPrefix(image.Pinned(), "-") {
+		return nil, fmt.Errorf("Invalid image name %q", image.Name)
+	}
+	args := []string{"image", "--server", self.host, "--format", "json", "--quiet", "--no-progress",
+		"--", image.Pinned()}
+	env := os.Environ()
+	if len(self.token) > 0 {
+		// Passed in environment, so that the token is not visible in the process list.
+		env = append(env, "TRIVY_TOKEN="+self.token)
+	}
+
+	output, err := self.run(args, env)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(report)
+	if err := json.Unmarshal(output, result); err != nil {
+		return nil, fmt.Errorf("Could not parse Trivy report of %s image: %s", image.Name, err)
+	}
+
+	summary := &scannerapi.VulnerabilitySummary{Scanner: self.ID(), Scanned: true}
+	seen := make(map[string]bool)
+	for _, target := range result.Results {
+		for _, vulnerability := range target.Vulnerabilities {
+			// The same vulnerability is reported once for every affected package.
+			if seen[vulnerability.VulnerabilityID] {
+				continue
+			}
+			seen[vulnerability.VulnerabilityID] = true
+			summary.Add(vulnerability.Severity, 1)
+		}
+	}
+	if result.CreatedAt != nil {
+		scannedAt := metaV1.NewTime(*result.CreatedAt)
+		summary.ScannedAt = &scannedAt
+	}
+	return summary, nil
+}
+
+// runBinary runs the binary and returns its standard output. Standard error is returned as an
+// error message if the binary fails. The binary is killed after the timeout.
+func runBinary(binary string, timeout time.Duration) func(args []string, env []string) ([]byte, error) {
+	return func(args []string, env []string) ([]byte, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		cmd := exec.CommandContext(ctx, binary, args...)
+		cmd.Env = env
+		output, err := cmd.Output()
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("Trivy did not finish the scan within %s", timeout)
+		}
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("Trivy failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return output, err
+	}
+}
+
+// CreateTrivyClient creates new Trivy client. Trivy binary is required, because Trivy server has
+// no API to request a scan of an image by its name.
+func CreateTrivyClient(options TrivyOptions) (scannerapi.ScannerClient, error) {
+	if len(options.Host) == 0 {
+		return nil, errors.New("Trivy server host is required")
+	}
+	if len(options.Binary) == 0 {
+		return nil, errors.New("Trivy binary is required")
+	}
+
+	return trivyClient{
+		host:   strings.TrimSuffix(options.Host, "/"),
+		binary: options.Binary,
+		token:  options.Token,
+		client: &http.Client{Timeout: requestTimeout},
+		run:    runBinary(options.Binary, scanTimeout),
+	}, nil
+}
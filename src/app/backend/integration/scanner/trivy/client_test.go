@@ -0,0 +1,140 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trivy
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	scannerapi "github.com/kubernetes/dashboard/src/app/backend/integration/scanner/api"
+)
+
+const testReport = `{
+  "SchemaVersion": 2,
+  "ArtifactName": "nginx@sha256:abc",
+  "Results": [
+    {"Target": "debian", "Vulnerabilities": [
+      {"VulnerabilityID": "CVE-1", "Severity": "CRITICAL"},
+      {"VulnerabilityID": "CVE-2", "Severity": "HIGH"},
+      {"VulnerabilityID": "CVE-2", "Severity": "HIGH"},
+      {"VulnerabilityID": "CVE-3", "Severity": "LOW"}
+    ]},
+    {"Target": "app", "Vulnerabilities": null}
+  ]
+}`
+
+func TestTrivyClientScan(t *testing.T) {
+	var actualArgs, actualEnv []string
+	client := trivyClient{
+		host:  "http://trivy:4954",
+		token: "secret",
+		run: func(args []string, env []string) ([]byte, error) {
+			actualArgs, actualEnv = args, env
+			return []byte(testReport), nil
+		},
+	}
+
+	summary, err := client.Scan(scannerapi.ImageReference{Name: "nginx:1.13", Digest: "sha256:abc"})
+	if err != nil {
+		t.Fatalf("Scan returned error: %s", err)
+	}
+
+	expected := &scannerapi.VulnerabilitySummary{Scanner: "trivy", Scanned: true, Critical: 1, High: 1,
+		Low: 1, Total: 3}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("Expected %#v, got %#v", expected, summary)
+	}
+
+	expectedArgs := []string{"image", "--server", "http://trivy:4954", "--format", "json", "--quiet",
+		"--no-progress", "--", "nginx@sha256:abc"}
+	if !reflect.DeepEqual(actualArgs, expectedArgs) {
+		t.Errorf("Expected args %v, got %v", expectedArgs, actualArgs)
+	}
+	if actualEnv[len(actualEnv)-1] != "TRIVY_TOKEN=secret" {
+		t.Errorf("Expected token in environment, got %v", actualEnv)
+	}
+	for _, arg := range actualArgs {
+		if strings.Contains(arg, "secret") {
+			t.Errorf("Token must not be passed in arguments, got %v", actualArgs)
+		}
+	}
+}
+
+func TestTrivyClientScanError(t *testing.T) {
+	client := trivyClient{run: func(args []string, env []string) ([]byte, error) {
+		return nil, errors.New("Trivy failed")
+	}}
+	if _, err := client.Scan(scannerapi.ImageReference{Name: "nginx"}); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestTrivyClientScanInvalidImage(t *testing.T) {
+	client := trivyClient{run: func(args []string, env []string) ([]byte, error) {
+		t.Errorf("Trivy must not run for invalid image, got args %v", args)
+		return []byte(testReport), nil
+	}}
+	if _, err := client.Scan(scannerapi.ImageReference{Name: "--output=/etc/passwd"}); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestTrivyClientHealthCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client, err := CreateTrivyClient(TrivyOptions{Host: server.URL + "/", Binary: "trivy"})
+	if err != nil {
+		t.Fatalf("CreateTrivyClient returned error: %s", err)
+	}
+	if err := client.HealthCheck(); err != nil {
+		t.Errorf("HealthCheck returned error: %s", err)
+	}
+	if err := (trivyClient{}).HealthCheck(); err == nil {
+		t.Error("Expected error of not configured client")
+	}
+}
+
+func TestCreateTrivyClient(t *testing.T) {
+	if _, err := CreateTrivyClient(TrivyOptions{Host: "http://trivy:4954"}); err == nil {
+		t.Error("Expected error when binary is not set")
+	}
+	if _, err := CreateTrivyClient(TrivyOptions{Binary: "trivy"}); err == nil {
+		t.Error("Expected error when host is not set")
+	}
+}
+
+func TestRunBinaryTimeout(t *testing.T) {
+	run := runBinary("sleep", 50*time.Millisecond)
+	start := time.Now()
+	_, err := run([]string{"10"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "did not finish") {
+		t.Errorf("Expected timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected binary to be killed after the timeout, it ran %s", elapsed)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logger implements leveled logging of the backend. Entries are written either as text
+// lines through the standard logger or as JSON objects with time, level and message, so that logs
+// can be parsed by log collectors.
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// FormatText writes logs as plain text lines.
+	FormatText = "text"
+	// FormatJSON writes every log line as a JSON object.
+	FormatJSON = "json"
+)
+
+// Level tells how severe the logged event is.
+type Level string
+
+const (
+	// LevelInfo is used for events of normal operation.
+	LevelInfo Level = "info"
+	// LevelWarning is used for problems Dashboard recovers from, e.g. optional features that could
+	// not be initialized.
+	LevelWarning Level = "warning"
+	// LevelError is used for failed operations.
+	LevelError Level = "error"
+)
+
+// Entry is a log record written in JSON format.
+type Entry struct {
+	Time    string `json:"time"`
+	Level   Level  `json:"level"`
+	Message string `json:"msg"`
+}
+
+var (
+	// lock guards logFormat and logOutput.
+	lock      sync.RWMutex
+	logFormat           = FormatText
+	logOutput io.Writer = os.Stdout
+)
+
+// SetFormat sets format of logs, either FormatText or FormatJSON. Lines written directly through
+// the standard logger, e.g. by libraries, are written in the same format with info level.
+func SetFormat(newFormat string) error {
+	if newFormat != FormatText && newFormat != FormatJSON {
+		return fmt.Errorf("unknown log format: %s", newFormat)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	logFormat = newFormat
+	configureStandardLogger()
+	return nil
+}
+
+// Format returns format of logs.
+func Format() string {
+	lock.RLock()
+	defer lock.RUnlock()
+	return logFormat
+}
+
+// SetOutput sets writer logs are written to. Logs are written to the standard output by default.
+func SetOutput(writer io.Writer) {
+	lock.Lock()
+	defer lock.Unlock()
+	logOutput = writer
+	configureStandardLogger()
+}
+
+// configureStandardLogger makes the standard logger write in the current format to the current
+// output. It has to be called with the lock held.
+func configureStandardLogger() {
+	if logFormat == FormatJSON {
+		log.SetFlags(0)
+		log.SetOutput(standardLogWriter{})
+		return
+	}
+
+	log.SetFlags(log.LstdFlags)
+	log.SetOutput(logOutput)
+}
+
+// standardLogWriter wraps every line written by the standard logger in an info entry.
+type standardLogWriter struct{}
+
+// Write implements io.Writer interface. It is called once per log line.
+func (standardLogWriter) Write(p []byte) (int, error) {
+	err := WriteEntry(newEntry(LevelInfo, strings.TrimSuffix(string(p), "\n")))
+	return len(p), err
+}
+
+// WriteEntry writes the entry as a single JSON line, regardless of the format of logs. It is used to
+// write structured records, e.g. of API calls.
+func WriteEntry(entry interface{}) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	lock.RLock()
+	defer lock.RUnlock()
+	_, err = logOutput.Write(append(data, '\n'))
+	return err
+}
+
+func newEntry(level Level, message string) Entry {
+	return Entry{Time: time.Now().Format(time.RFC3339Nano), Level: level, Message: message}
+}
+
+// output writes the message with given level. In text format the level is a prefix of the line.
+func output(level Level, message string) {
+	if Format() == FormatJSON {
+		WriteEntry(newEntry(level, message))
+		return
+	}
+	log.Output(3, strings.ToUpper(string(level))+" "+message)
+}
+
+// Info logs arguments formatted as by fmt.Sprint with info level.
+func Info(args ...interface{}) {
+	output(LevelInfo, fmt.Sprint(args...))
+}
+
+// Infof logs arguments formatted as by fmt.Sprintf with info level.
+func Infof(format string, args ...interface{}) {
+	output(LevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Warning logs arguments formatted as by fmt.Sprint with warning level.
+func Warning(args ...interface{}) {
+	output(LevelWarning, fmt.Sprint(args...))
+}
+
+// Warningf logs arguments formatted as by fmt.Sprintf with warning level.
+func Warningf(format string, args ...interface{}) {
+	output(LevelWarning, fmt.Sprintf(format, args...))
+}
+
+// Error logs arguments formatted as by fmt.Sprint with error level.
+func Error(args ...interface{}) {
+	output(LevelError, fmt.Sprint(args...))
+}
+
+// Errorf logs arguments formatted as by fmt.Sprintf with error level.
+func Errorf(format string, args ...interface{}) {
+	output(LevelError, fmt.Sprintf(format, args...))
+}
+
+// Fatal logs arguments formatted as by fmt.Sprint with error level and exits the process.
+func Fatal(args ...interface{}) {
+	output(LevelError, fmt.Sprint(args...))
+	os.Exit(1)
+}
+
+// Fatalf logs arguments formatted as by fmt.Sprintf with error level and exits the process.
+func Fatalf(format string, args ...interface{}) {
+	output(LevelError, fmt.Sprintf(format, args...))
+	os.Exit(1)
+}
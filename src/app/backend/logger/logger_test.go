@@ -0,0 +1,70 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	defer SetOutput(os.Stdout)
+	defer SetFormat(FormatText)
+
+	out := &bytes.Buffer{}
+	SetOutput(out)
+
+	Infof("Using %s", "apiserver")
+	Warning("Metric client is not available")
+	Errorf("Could not create %s", "client")
+	if lines := out.String(); !strings.Contains(lines, "INFO Using apiserver\n") ||
+		!strings.Contains(lines, "WARNING Metric client is not available\n") ||
+		!strings.Contains(lines, "ERROR Could not create client\n") {
+		t.Errorf("Text logs are %q, expected lines with levels", lines)
+	}
+
+	if err := SetFormat("xml"); err == nil {
+		t.Error("SetFormat(xml) should return error")
+	}
+	if err := SetFormat(FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	Errorf("Could not create %s", "client")
+	log.Print("Logged by a library")
+
+	expected := []Entry{
+		{Level: LevelError, Message: "Could not create client"},
+		{Level: LevelInfo, Message: "Logged by a library"},
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(expected) {
+		t.Fatalf("JSON logs are %q, expected %d lines", out.String(), len(expected))
+	}
+	for i, line := range lines {
+		entry := Entry{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Log line %q is not valid JSON: %v", line, err)
+		}
+		if entry.Time == "" || entry.Level != expected[i].Level ||
+			entry.Message != expected[i].Message {
+			t.Errorf("Log entry is %#v, expected %#v", entry, expected[i])
+		}
+	}
+}
@@ -0,0 +1,506 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notification sends messages about events, e.g. alerts or finished rollouts, to channels
+// configured by users. Routing rules select channels by namespace and severity of messages.
+// Channels and routes are stored in a Secret, as URLs of webhooks usually contain tokens. The
+// Secret is watched, so that all replicas send messages to the same channels.
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// SecretName is the name of Secret that channels and routes are stored in.
+	SecretName = "kubernetes-dashboard-notifications"
+	// ChannelsKey is the key of channels in the Secret.
+	ChannelsKey = "channels.json"
+	// RoutesKey is the key of routes in the Secret.
+	RoutesKey = "routes.json"
+)
+
+// ChannelType tells how messages are sent to a channel.
+type ChannelType string
+
+const (
+	ChannelWebhook ChannelType = "webhook"
+	ChannelSlack   ChannelType = "slack"
+	ChannelEmail   ChannelType = "email"
+)
+
+// Channel is a destination of messages.
+type Channel struct {
+	Name string      `json:"name"`
+	Type ChannelType `json:"type"`
+	// URL of webhook and Slack channels.
+	URL string `json:"url,omitempty"`
+	// To are recipients of email channels.
+	To []string `json:"to,omitempty"`
+}
+
+// Route sends messages matching it to its channels.
+type Route struct {
+	Name string `json:"name"`
+	// Namespaces of objects the route matches. Empty list matches all messages, including messages
+	// about cluster scoped objects.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Severities of messages the route matches. Empty list matches all severities.
+	Severities []string `json:"severities,omitempty"`
+	Channels   []string `json:"channels"`
+}
+
+// matches returns true if the route matches namespace and severity of the message.
+func (self Route) matches(message Message) bool {
+	return (len(self.Namespaces) == 0 || contains(self.Namespaces, message.Namespace)) &&
+		(len(self.Severities) == 0 || contains(self.Severities, message.Severity))
+}
+
+// config is the content of the Secret.
+type config struct {
+	channels []Channel
+	routes   []Route
+}
+
+// NotificationManager keeps channels and routes loaded from the Secret up to date, saves changes to
+// them and sends messages. Changes are saved with given client, so that only users allowed to
+// update the Secret can change them.
+type NotificationManager interface {
+	// Notify sends the message to channels of all routes matching it. It implements Notifier
+	// interface, so that the manager can be used wherever a notifier is expected.
+	Notify(message Message) error
+	// NotifyChannel sends the message to channel with given name.
+	NotifyChannel(name string, message Message) error
+	// HasChannel returns true if channel with given name exists.
+	HasChannel(name string) bool
+
+	// GetChannels returns channels sorted by name. They are read with given client, as their URLs
+	// may contain secrets.
+	GetChannels(client kubernetes.Interface) ([]Channel, error)
+	SaveChannel(client kubernetes.Interface, channel Channel) error
+	// DeleteChannel deletes the channel. Channels used by routes cannot be deleted.
+	DeleteChannel(client kubernetes.Interface, name string) error
+
+	// GetRoutes returns routes sorted by name.
+	GetRoutes() []Route
+	SaveRoute(client kubernetes.Interface, route Route) error
+	DeleteRoute(client kubernetes.Interface, name string) error
+}
+
+// notificationManager implements NotificationManager with Secret watched by Dashboard's own client.
+type notificationManager struct {
+	sync.RWMutex
+	client    kubernetes.Interface
+	namespace string
+	// email configures the SMTP server of email channels. Nil if it is not configured.
+	email *EmailOptions
+
+	channels map[string]Channel
+	routes   []Route
+}
+
+// Notify implements NotificationManager interface.
+func (self *notificationManager) Notify(message Message) error {
+	names := make([]string, 0)
+	for _, route := range self.GetRoutes() {
+		if !route.matches(message) {
+			continue
+		}
+		for _, name := range route.Channels {
+			if !contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	failed := make([]string, 0)
+	for _, name := range names {
+		if err := self.NotifyChannel(name, message); err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("could not send message to all channels: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// NotifyChannel implements NotificationManager interface.
+func (self *notificationManager) NotifyChannel(name string, message Message) error {
+	self.RLock()
+	channel, ok := self.channels[name]
+	self.RUnlock()
+	if !ok {
+		return errorsK8s.NewNotFound(schema.GroupResource{Resource: "notification channels"}, name)
+	}
+
+	notifier, err := self.newNotifier(channel)
+	if err != nil {
+		return err
+	}
+	if err := notifier.Notify(message); err != nil {
+		return fmt.Errorf("channel %s: %s", name, err)
+	}
+	return nil
+}
+
+// HasChannel implements NotificationManager interface.
+func (self *notificationManager) HasChannel(name string) bool {
+	self.RLock()
+	defer self.RUnlock()
+	_, ok := self.channels[name]
+	return ok
+}
+
+// GetChannels implements NotificationManager interface.
+func (self *notificationManager) GetChannels(client kubernetes.Interface) ([]Channel, error) {
+	secret, err := client.CoreV1().Secrets(self.namespace).Get(SecretName, metaV1.GetOptions{})
+	if errorsK8s.IsNotFound(err) {
+		return make([]Channel, 0), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	loaded, err := parse(secret)
+	if err != nil {
+		return nil, err
+	}
+	return loaded.channels, nil
+}
+
+// SaveChannel implements NotificationManager interface.
+func (self *notificationManager) SaveChannel(client kubernetes.Interface, channel Channel) error {
+	if err := self.validateChannel(channel); err != nil {
+		return err
+	}
+
+	return self.save(client, func(current *config) error {
+		for i := range current.channels {
+			if current.channels[i].Name == channel.Name {
+				current.channels[i] = channel
+				return nil
+			}
+		}
+		current.channels = append(current.channels, channel)
+		return nil
+	})
+}
+
+// DeleteChannel implements NotificationManager interface.
+func (self *notificationManager) DeleteChannel(client kubernetes.Interface, name string) error {
+	return self.save(client, func(current *config) error {
+		for _, route := range current.routes {
+			if contains(route.Channels, name) {
+				return errorsK8s.NewBadRequest(fmt.Sprintf("channel %s is used by route %s", name,
+					route.Name))
+			}
+		}
+		for i := range current.channels {
+			if current.channels[i].Name == name {
+				current.channels = append(current.channels[:i], current.channels[i+1:]...)
+				return nil
+			}
+		}
+		return errorsK8s.NewNotFound(schema.GroupResource{Resource: "notification channels"}, name)
+	})
+}
+
+// GetRoutes implements NotificationManager interface.
+func (self *notificationManager) GetRoutes() []Route {
+	self.RLock()
+	defer self.RUnlock()
+	return append(make([]Route, 0, len(self.routes)), self.routes...)
+}
+
+// SaveRoute implements NotificationManager interface.
+func (self *notificationManager) SaveRoute(client kubernetes.Interface, route Route) error {
+	if err := validateRoute(route); err != nil {
+		return err
+	}
+
+	return self.save(client, func(current *config) error {
+		for _, name := range route.Channels {
+			if !hasChannel(current.channels, name) {
+				return errorsK8s.NewBadRequest(fmt.Sprintf("channel %s of route %s does not exist", name,
+					route.Name))
+			}
+		}
+		for i := range current.routes {
+			if current.routes[i].Name == route.Name {
+				current.routes[i] = route
+				return nil
+			}
+		}
+		current.routes = append(current.routes, route)
+		return nil
+	})
+}
+
+// DeleteRoute implements NotificationManager interface.
+func (self *notificationManager) DeleteRoute(client kubernetes.Interface, name string) error {
+	return self.save(client, func(current *config) error {
+		for i := range current.routes {
+			if current.routes[i].Name == name {
+				current.routes = append(current.routes[:i], current.routes[i+1:]...)
+				return nil
+			}
+		}
+		return errorsK8s.NewNotFound(schema.GroupResource{Resource: "notification routes"}, name)
+	})
+}
+
+// save applies the change to channels and routes in the Secret, which is created if it does not
+// exist yet, and updates them in memory without waiting for the watch event.
+func (self *notificationManager) save(client kubernetes.Interface, change func(*config) error) error {
+	secrets := client.CoreV1().Secrets(self.namespace)
+	secret, err := secrets.Get(SecretName, metaV1.GetOptions{})
+	notFound := errorsK8s.IsNotFound(err)
+	if err != nil && !notFound {
+		return err
+	}
+	if notFound {
+		secret = &v1.Secret{ObjectMeta: metaV1.ObjectMeta{Name: SecretName, Namespace: self.namespace}}
+	}
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+
+	current, err := parse(secret)
+	if err != nil {
+		return err
+	}
+	if err := change(current); err != nil {
+		return err
+	}
+	if secret.Data[ChannelsKey], err = json.Marshal(current.channels); err != nil {
+		return err
+	}
+	if secret.Data[RoutesKey], err = json.Marshal(current.routes); err != nil {
+		return err
+	}
+
+	if notFound {
+		secret, err = secrets.Create(secret)
+	} else {
+		secret, err = secrets.Update(secret)
+	}
+	if err != nil {
+		return err
+	}
+
+	self.load(secret)
+	return nil
+}
+
+// load replaces channels and routes in memory with the ones from the Secret. Nil Secret removes
+// all of them.
+func (self *notificationManager) load(secret *v1.Secret) {
+	loaded := &config{channels: make([]Channel, 0), routes: make([]Route, 0)}
+	if secret != nil {
+		var err error
+		if loaded, err = parse(secret); err != nil {
+			logger.Errorf("Invalid notification channels or routes in Secret %s: %s", SecretName, err)
+			loaded = &config{channels: make([]Channel, 0), routes: make([]Route, 0)}
+		}
+	}
+
+	channels := make(map[string]Channel, len(loaded.channels))
+	for _, channel := range loaded.channels {
+		channels[channel.Name] = channel
+	}
+
+	self.Lock()
+	defer self.Unlock()
+	self.channels = channels
+	self.routes = loaded.routes
+}
+
+// watch watches the Secret and reloads channels and routes on every change.
+func (self *notificationManager) watch(stop <-chan struct{}) {
+	selector := fields.OneTermEqualSelector("metadata.name", SecretName).String()
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options metaV1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return self.client.CoreV1().Secrets(self.namespace).List(options)
+		},
+		WatchFunc: func(options metaV1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return self.client.CoreV1().Secrets(self.namespace).Watch(options)
+		},
+	}
+
+	_, controller := cache.NewInformer(listWatch, &v1.Secret{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			self.load(obj.(*v1.Secret))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			self.load(newObj.(*v1.Secret))
+		},
+		DeleteFunc: func(obj interface{}) {
+			self.load(nil)
+		},
+	})
+	controller.Run(stop)
+}
+
+// newNotifier creates notifier that sends messages to the channel.
+func (self *notificationManager) newNotifier(channel Channel) (Notifier, error) {
+	switch channel.Type {
+	case ChannelWebhook:
+		return NewWebhookNotifier(channel.URL), nil
+	case ChannelSlack:
+		return NewSlackNotifier(channel.URL), nil
+	case ChannelEmail:
+		if self.email == nil {
+			return nil, fmt.Errorf("SMTP server of email channel %s is not configured", channel.Name)
+		}
+		return NewEmailNotifier(*self.email, channel.To)
+	}
+	return nil, fmt.Errorf("unknown type of channel %s: %q", channel.Name, channel.Type)
+}
+
+// validateChannel returns bad request error if the channel is not valid.
+func (self *notificationManager) validateChannel(channel Channel) error {
+	if errs := validation.IsDNS1123Label(channel.Name); len(errs) > 0 {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("invalid channel name %q: %v", channel.Name, errs))
+	}
+
+	switch channel.Type {
+	case ChannelWebhook, ChannelSlack:
+		location, err := url.Parse(channel.URL)
+		if err != nil || (location.Scheme != "http" && location.Scheme != "https") || location.Host == "" {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("invalid URL of channel %s: %q", channel.Name,
+				channel.URL))
+		}
+	case ChannelEmail:
+		if self.email == nil {
+			return errorsK8s.NewBadRequest("email channels cannot be used, SMTP server is not configured")
+		}
+		if len(channel.To) == 0 {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("recipients of channel %s are required",
+				channel.Name))
+		}
+		for _, address := range channel.To {
+			if _, err := mail.ParseAddress(address); err != nil {
+				return errorsK8s.NewBadRequest(fmt.Sprintf("invalid recipient of channel %s: %q",
+					channel.Name, address))
+			}
+		}
+	default:
+		return errorsK8s.NewBadRequest(fmt.Sprintf("unknown type of channel %s: %q", channel.Name,
+			channel.Type))
+	}
+	return nil
+}
+
+// validateRoute returns bad request error if the route is not valid. Existence of its channels is
+// checked when it is saved.
+func validateRoute(route Route) error {
+	if errs := validation.IsDNS1123Label(route.Name); len(errs) > 0 {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("invalid route name %q: %v", route.Name, errs))
+	}
+	if len(route.Channels) == 0 {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("channels of route %s are required", route.Name))
+	}
+	for _, namespace := range route.Namespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("invalid namespace of route %s: %v", route.Name,
+				errs))
+		}
+	}
+	for _, severity := range route.Severities {
+		switch severity {
+		case SeverityInfo, SeverityWarning, SeverityCritical:
+		default:
+			return errorsK8s.NewBadRequest(fmt.Sprintf("unknown severity of route %s: %q", route.Name,
+				severity))
+		}
+	}
+	return nil
+}
+
+// parse returns channels and routes stored in the Secret, sorted by name.
+func parse(secret *v1.Secret) (*config, error) {
+	result := &config{channels: make([]Channel, 0), routes: make([]Route, 0)}
+	if data, ok := secret.Data[ChannelsKey]; ok {
+		if err := json.Unmarshal(data, &result.channels); err != nil {
+			return nil, err
+		}
+	}
+	if data, ok := secret.Data[RoutesKey]; ok {
+		if err := json.Unmarshal(data, &result.routes); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(result.channels, func(i, j int) bool {
+		return result.channels[i].Name < result.channels[j].Name
+	})
+	sort.SliceStable(result.routes, func(i, j int) bool { return result.routes[i].Name < result.routes[j].Name })
+	return result, nil
+}
+
+func hasChannel(channels []Channel, name string) bool {
+	for _, channel := range channels {
+		if channel.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// NewNotificationManager creates notification manager that stores channels and routes in Secret in
+// given namespace and watches it with given client until stop channel is closed. Email channels can
+// be used only if SMTP server is configured.
+func NewNotificationManager(client kubernetes.Interface, namespace string, email *EmailOptions,
+	stop <-chan struct{}) NotificationManager {
+	manager := newNotificationManager(client, namespace, email)
+	go manager.watch(stop)
+	return manager
+}
+
+func newNotificationManager(client kubernetes.Interface, namespace string,
+	email *EmailOptions) *notificationManager {
+	manager := &notificationManager{client: client, namespace: namespace, email: email}
+	manager.load(nil)
+	return manager
+}
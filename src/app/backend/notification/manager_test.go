@@ -0,0 +1,202 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// recordingServer records messages posted to webhooks by the path of their URLs.
+type recordingServer struct {
+	*httptest.Server
+	sync.Mutex
+	messages map[string][]Message
+	// posted receives path of every posted message.
+	posted chan string
+}
+
+func newRecordingServer(t *testing.T) *recordingServer {
+	server := &recordingServer{messages: make(map[string][]Message), posted: make(chan string, 10)}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := ioutil.ReadAll(r.Body)
+		message := Message{}
+		if err := json.Unmarshal(raw, &message); err != nil {
+			t.Error(err)
+		}
+		server.Lock()
+		server.messages[r.URL.Path] = append(server.messages[r.URL.Path], message)
+		server.Unlock()
+		server.posted <- r.URL.Path
+	}))
+	return server
+}
+
+// received returns paths of webhooks that received messages since the last call.
+func (self *recordingServer) received() []string {
+	for len(self.posted) > 0 {
+		<-self.posted
+	}
+	self.Lock()
+	defer self.Unlock()
+	result := make([]string, 0)
+	for path := range self.messages {
+		result = append(result, path)
+	}
+	sort.Strings(result)
+	self.messages = make(map[string][]Message)
+	return result
+}
+
+func TestSaveChannelsAndRoutes(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	manager := newNotificationManager(client, "kube-system", nil)
+
+	team := Channel{Name: "team", Type: ChannelSlack, URL: "https://hooks.slack.com/services/T0/B0/X"}
+	oncall := Channel{Name: "oncall", Type: ChannelWebhook, URL: "http://pager.example.com/hook"}
+	for _, channel := range []Channel{team, oncall} {
+		if err := manager.SaveChannel(client, channel); err != nil {
+			t.Fatal(err)
+		}
+	}
+	channels, err := manager.GetChannels(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(channels, []Channel{oncall, team}) {
+		t.Errorf("GetChannels() == %#v, expected channels sorted by name", channels)
+	}
+
+	route := Route{Name: "critical", Severities: []string{SeverityCritical}, Channels: []string{"oncall"}}
+	if err := manager.SaveRoute(client, route); err != nil {
+		t.Fatal(err)
+	}
+	if actual := manager.GetRoutes(); !reflect.DeepEqual(actual, []Route{route}) {
+		t.Errorf("GetRoutes() == %#v, expected %#v", actual, []Route{route})
+	}
+
+	missing := Route{Name: "missing", Channels: []string{"unknown"}}
+	if err := manager.SaveRoute(client, missing); !errorsK8s.IsBadRequest(err) {
+		t.Errorf("SaveRoute() with unknown channel returned %v, expected bad request error", err)
+	}
+	if err := manager.DeleteChannel(client, "oncall"); !errorsK8s.IsBadRequest(err) {
+		t.Errorf("DeleteChannel() of used channel returned %v, expected bad request error", err)
+	}
+
+	if err := manager.DeleteRoute(client, "critical"); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.DeleteChannel(client, "oncall"); err != nil {
+		t.Fatal(err)
+	}
+	if manager.HasChannel("oncall") || !manager.HasChannel("team") {
+		t.Error("Expected only team channel to exist after deletion of oncall channel")
+	}
+	if err := manager.DeleteRoute(client, "critical"); !errorsK8s.IsNotFound(err) {
+		t.Errorf("DeleteRoute() of missing route returned %v, expected not found error", err)
+	}
+}
+
+func TestValidateChannel(t *testing.T) {
+	withoutEmail := newNotificationManager(nil, "kube-system", nil)
+	withEmail := newNotificationManager(nil, "kube-system", &EmailOptions{Server: "smtp.example.com:25",
+		From: "dashboard@example.com"})
+
+	cases := []struct {
+		manager *notificationManager
+		channel Channel
+		valid   bool
+	}{
+		{withoutEmail, Channel{Name: "hook", Type: ChannelWebhook, URL: "https://example.com"}, true},
+		{withoutEmail, Channel{Name: "hook", Type: ChannelWebhook, URL: "ftp://example.com"}, false},
+		{withoutEmail, Channel{Name: "Hook", Type: ChannelSlack, URL: "https://example.com"}, false},
+		{withoutEmail, Channel{Name: "mail", Type: ChannelEmail, To: []string{"a@example.com"}}, false},
+		{withEmail, Channel{Name: "mail", Type: ChannelEmail, To: []string{"a@example.com"}}, true},
+		{withEmail, Channel{Name: "mail", Type: ChannelEmail, To: []string{"not an address"}}, false},
+		{withEmail, Channel{Name: "mail", Type: ChannelEmail}, false},
+		{withEmail, Channel{Name: "sms", Type: "sms"}, false},
+	}
+
+	for _, c := range cases {
+		err := c.manager.validateChannel(c.channel)
+		if c.valid && err != nil {
+			t.Errorf("validateChannel(%#v) returned error %s, expected valid channel", c.channel, err)
+		}
+		if !c.valid && !errorsK8s.IsBadRequest(err) {
+			t.Errorf("validateChannel(%#v) returned %v, expected bad request error", c.channel, err)
+		}
+	}
+}
+
+func TestNotify(t *testing.T) {
+	server := newRecordingServer(t)
+	defer server.Close()
+
+	client := fake.NewSimpleClientset()
+	manager := newNotificationManager(client, "kube-system", nil)
+	for _, name := range []string{"all", "apps", "critical"} {
+		if err := manager.SaveChannel(client, Channel{Name: name, Type: ChannelWebhook,
+			URL: server.URL + "/" + name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	routes := []Route{
+		{Name: "all", Channels: []string{"all"}},
+		{Name: "apps", Namespaces: []string{"apps"}, Channels: []string{"apps", "all"}},
+		{Name: "critical", Severities: []string{SeverityCritical}, Channels: []string{"critical"}},
+	}
+	for _, route := range routes {
+		if err := manager.SaveRoute(client, route); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		message  Message
+		expected []string
+	}{
+		{Message{Namespace: "apps", Severity: SeverityInfo}, []string{"/all", "/apps"}},
+		{Message{Namespace: "apps", Severity: SeverityCritical}, []string{"/all", "/apps", "/critical"}},
+		{Message{Severity: SeverityWarning}, []string{"/all"}},
+	}
+
+	for _, c := range cases {
+		if err := manager.Notify(c.message); err != nil {
+			t.Fatal(err)
+		}
+		if actual := server.received(); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Notify(%#v) sent messages to %v, expected %v", c.message, actual, c.expected)
+		}
+	}
+
+	if err := manager.NotifyChannel("critical", Message{}); err != nil {
+		t.Fatal(err)
+	}
+	if actual := server.received(); !reflect.DeepEqual(actual, []string{"/critical"}) {
+		t.Errorf("NotifyChannel() sent messages to %v, expected critical channel only", actual)
+	}
+	if err := manager.NotifyChannel("unknown", Message{}); !errorsK8s.IsNotFound(err) {
+		t.Errorf("NotifyChannel() of unknown channel returned %v, expected not found error", err)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+)
+
+// notifyTimeout limits time of a single webhook call.
+const notifyTimeout = 10 * time.Second
+
+// Severities of messages, which routes can match.
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Events that messages are sent about.
+const (
+	EventAlertFiring     = "alertFiring"
+	EventAlertResolved   = "alertResolved"
+	EventRolloutFinished = "rolloutFinished"
+	EventRolloutFailed   = "rolloutFailed"
+)
+
+// Message is a notification about an event of an object.
+type Message struct {
+	Event    string `json:"event"`
+	Severity string `json:"severity"`
+	// Title is a single line summary, i.e. for chat messages and email subjects.
+	Title string `json:"title"`
+	Text  string `json:"text"`
+
+	// Object the message is about. Namespace is empty for cluster scoped objects.
+	Kind      api.ResourceKind `json:"kind"`
+	Namespace string           `json:"namespace,omitempty"`
+	Name      string           `json:"name"`
+
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Notifier sends messages, i.e. to a webhook or by email.
+type Notifier interface {
+	// Notify sends given message. It returns error if it could not be sent.
+	Notify(message Message) error
+}
+
+// webhookNotifier posts messages as JSON to a webhook.
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// Notify implements Notifier interface.
+func (self webhookNotifier) Notify(message Message) error {
+	return postJSON(self.client, self.url, message)
+}
+
+// NewWebhookNotifier creates notifier that posts every message as JSON object to given URL.
+func NewWebhookNotifier(url string) Notifier {
+	return webhookNotifier{url: url, client: &http.Client{Timeout: notifyTimeout}}
+}
+
+// slackNotifier posts messages to a Slack incoming webhook.
+type slackNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// Notify implements Notifier interface.
+func (self slackNotifier) Notify(message Message) error {
+	return postJSON(self.client, self.url, map[string]string{"text": message.Title})
+}
+
+// NewSlackNotifier creates notifier that posts title of every message to Slack incoming webhook with
+// given URL.
+func NewSlackNotifier(url string) Notifier {
+	return slackNotifier{url: url, client: &http.Client{Timeout: notifyTimeout}}
+}
+
+// EmailOptions configure the SMTP server messages are sent with.
+type EmailOptions struct {
+	// Server is the address of the SMTP server, i.e. host:port.
+	Server string
+	// Username and Password authenticate to the server. Authentication is skipped without username.
+	Username string
+	Password string
+	From     string
+}
+
+// emailNotifier sends messages as emails.
+type emailNotifier struct {
+	options EmailOptions
+	to      []string
+	auth    smtp.Auth
+}
+
+// Notify implements Notifier interface.
+func (self emailNotifier) Notify(message Message) error {
+	email := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n", self.options.From,
+		strings.Join(self.to, ", "), message.Title, message.Text)
+	return smtp.SendMail(self.options.Server, self.auth, self.options.From, self.to, []byte(email))
+}
+
+// NewEmailNotifier creates notifier that sends every message as email to the recipients.
+func NewEmailNotifier(options EmailOptions, to []string) (Notifier, error) {
+	host, _, err := net.SplitHostPort(options.Server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address of SMTP server %q: %s", options.Server, err)
+	}
+	if options.From == "" || len(to) == 0 {
+		return nil, fmt.Errorf("sender and recipients of emails are required")
+	}
+
+	notifier := emailNotifier{options: options, to: to}
+	if options.Username != "" {
+		notifier.auth = smtp.PlainAuth("", options.Username, options.Password, host)
+	}
+	return notifier, nil
+}
+
+func postJSON(client *http.Client, url string, value interface{}) error {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	response, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned status %d", url, response.StatusCode)
+	}
+	return nil
+}
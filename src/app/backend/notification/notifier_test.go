@@ -0,0 +1,73 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookNotifiers(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := ioutil.ReadAll(r.Body)
+		body = make(map[string]interface{})
+		if err := json.Unmarshal(raw, &body); err != nil {
+			t.Error(err)
+		}
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	message := Message{Event: EventAlertFiring, Severity: SeverityCritical, Name: "node-1",
+		Title: "[critical] Node node-1 is not ready", Text: "Node node-1 is not ready"}
+
+	if err := NewWebhookNotifier(server.URL).Notify(message); err != nil {
+		t.Fatal(err)
+	}
+	if body["event"] != EventAlertFiring || body["text"] != message.Text {
+		t.Errorf("Webhook received %v, expected message", body)
+	}
+
+	if err := NewSlackNotifier(server.URL).Notify(message); err != nil {
+		t.Fatal(err)
+	}
+	if body["text"] != message.Title {
+		t.Errorf("Slack received %v, expected text %q", body, message.Title)
+	}
+
+	if err := NewWebhookNotifier(server.URL + "/failing").Notify(message); err == nil {
+		t.Error("Notify() succeeded, expected error of failing webhook")
+	}
+}
+
+func TestNewEmailNotifier(t *testing.T) {
+	to := []string{"b@example.com"}
+	if _, err := NewEmailNotifier(EmailOptions{Server: "smtp.example.com", From: "a@example.com"}, to); err == nil {
+		t.Error("NewEmailNotifier() succeeded without port of the server")
+	}
+	if _, err := NewEmailNotifier(EmailOptions{Server: "smtp.example.com:587"}, nil); err == nil {
+		t.Error("NewEmailNotifier() succeeded without sender and recipients")
+	}
+	if _, err := NewEmailNotifier(EmailOptions{Server: "smtp.example.com:587", From: "a@example.com",
+		Username: "a"}, to); err != nil {
+		t.Error(err)
+	}
+}
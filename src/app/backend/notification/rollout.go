@@ -0,0 +1,90 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/rollout"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+// RolloutNotificationSpec describes where the message about a finished rollout is sent.
+type RolloutNotificationSpec struct {
+	// Channel the message is sent to. Message is sent to channels of matching routes, if it is empty.
+	Channel string `json:"channel,omitempty"`
+}
+
+// waitForRollout waits until the rollout is done. It is a variable, so that it can be replaced in
+// tests.
+var waitForRollout = rollout.WaitForRollout
+
+// NotifyOnRollout sends message when the rollout of the workload finishes or fails. Progress is
+// watched in background with given client, so that the user has to be allowed to get the workload.
+func NotifyOnRollout(client kubernetes.Interface, manager NotificationManager, kind api.ResourceKind,
+	namespace, name string, spec *RolloutNotificationSpec) error {
+	if spec.Channel != "" && !manager.HasChannel(spec.Channel) {
+		return errorsK8s.NewNotFound(schema.GroupResource{Resource: "notification channels"}, spec.Channel)
+	}
+	if _, err := rollout.GetRolloutProgress(client, kind, namespace, name); err != nil {
+		return err
+	}
+
+	go func() {
+		var last rollout.RolloutProgress
+		err := waitForRollout(client, kind, namespace, name, func(progress rollout.RolloutProgress) {
+			last = progress
+		})
+		message := newRolloutMessage(kind, namespace, name, last, err, time.Now())
+
+		if spec.Channel != "" {
+			err = manager.NotifyChannel(spec.Channel, message)
+		} else {
+			err = manager.Notify(message)
+		}
+		if err != nil {
+			logger.Errorf("Could not send notification about rollout of %s %s/%s: %s", kind, namespace, name, err)
+		}
+	}()
+	return nil
+}
+
+// newRolloutMessage creates message about the rollout that finished with the progress or failed
+// with the error.
+func newRolloutMessage(kind api.ResourceKind, namespace, name string, progress rollout.RolloutProgress,
+	err error, now time.Time) Message {
+	message := Message{
+		Event:     EventRolloutFinished,
+		Severity:  SeverityInfo,
+		Title:     fmt.Sprintf("Rollout of %s %s/%s finished", kind, namespace, name),
+		Text:      progress.Message,
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		Timestamp: now,
+	}
+	if err != nil {
+		message.Event = EventRolloutFailed
+		message.Severity = SeverityWarning
+		message.Title = fmt.Sprintf("Rollout of %s %s/%s failed", kind, namespace, name)
+		message.Text = err.Error()
+	}
+	return message
+}
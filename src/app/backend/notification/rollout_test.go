@@ -0,0 +1,99 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/rollout"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func TestNewRolloutMessage(t *testing.T) {
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	progress := rollout.RolloutProgress{Replicas: 3, Done: true, Message: "Rollout finished"}
+
+	expected := Message{Event: EventRolloutFinished, Severity: SeverityInfo, Kind: api.ResourceKindDeployment,
+		Namespace: "default", Name: "app", Title: "Rollout of deployment default/app finished",
+		Text: "Rollout finished", Timestamp: now}
+	actual := newRolloutMessage(api.ResourceKindDeployment, "default", "app", progress, nil, now)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("newRolloutMessage() == %#v, expected %#v", actual, expected)
+	}
+
+	actual = newRolloutMessage(api.ResourceKindDeployment, "default", "app", progress,
+		fmt.Errorf("rollout of deployment app exceeded its progress deadline"), now)
+	if actual.Event != EventRolloutFailed || actual.Severity != SeverityWarning ||
+		actual.Title != "Rollout of deployment default/app failed" {
+		t.Errorf("newRolloutMessage() == %#v, expected failed rollout", actual)
+	}
+}
+
+func TestNotifyOnRollout(t *testing.T) {
+	server := newRecordingServer(t)
+	defer server.Close()
+
+	k8sClient := fake.NewSimpleClientset(&extensions.Deployment{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: "default", Name: "app"},
+	})
+	manager := newNotificationManager(k8sClient, "kube-system", nil)
+	if err := manager.SaveChannel(k8sClient, Channel{Name: "team", Type: ChannelWebhook,
+		URL: server.URL + "/team"}); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForRollout = func(client client.Interface, kind api.ResourceKind, namespace, name string,
+		report func(rollout.RolloutProgress)) error {
+		report(rollout.RolloutProgress{Done: true, Message: "Rollout finished"})
+		return nil
+	}
+	defer func() { waitForRollout = rollout.WaitForRollout }()
+
+	err := NotifyOnRollout(k8sClient, manager, api.ResourceKindDeployment, "default", "app",
+		&RolloutNotificationSpec{Channel: "team"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-server.posted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Message about finished rollout was not sent")
+	}
+	server.Lock()
+	messages := server.messages["/team"]
+	server.Unlock()
+	if len(messages) != 1 || messages[0].Event != EventRolloutFinished {
+		t.Errorf("Channel received %#v, expected message about finished rollout", messages)
+	}
+
+	err = NotifyOnRollout(k8sClient, manager, api.ResourceKindDeployment, "default", "app",
+		&RolloutNotificationSpec{Channel: "unknown"})
+	if !errorsK8s.IsNotFound(err) {
+		t.Errorf("NotifyOnRollout() with unknown channel returned %v, expected not found error", err)
+	}
+
+	err = NotifyOnRollout(k8sClient, manager, api.ResourceKindPod, "default", "app", &RolloutNotificationSpec{})
+	if !errorsK8s.IsBadRequest(err) {
+		t.Errorf("NotifyOnRollout() of pod returned %v, expected bad request error", err)
+	}
+}
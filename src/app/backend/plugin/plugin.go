@@ -0,0 +1,141 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Plugin is an extension of Dashboard backend that adds API routes and menu entries. Routes of a
+// plugin are served under /api/v1/plugin/<name> and go through the same filters as the rest of the
+// API, i.e. authentication, read-only mode, policy, audit and CSRF protection.
+//
+// Plugins can be compiled into Dashboard. Such plugin registers itself with Register in an init
+// function of its package, which is blank imported from a file of the main package guarded by a
+// build tag, e.g.:
+//
+//	// +build istio
+//
+//	package main
+//
+//	import _ "example.com/dashboard-istio/plugin"
+//
+// Plugins that are developed and released independently run as sidecars instead, see
+// NewSidecarPlugin.
+type Plugin interface {
+	// Name of the plugin. It has to be a DNS label, as it is a part of the plugin URL.
+	Name() string
+	// Routes returns API routes of the plugin. Paths of routes are relative to the plugin root.
+	Routes(cManager client.ClientManager) []Route
+	// MenuEntries returns entries the plugin adds to the navigation menu.
+	MenuEntries() []MenuEntry
+}
+
+// Route is an API route of a plugin.
+type Route struct {
+	// HTTP method of the route.
+	Method string
+	// Path relative to the plugin root, e.g. "/virtualservice/{namespace}". Empty path is the root.
+	Path string
+	// Handler of the route.
+	Handler restful.RouteFunction
+}
+
+// MenuEntry is an entry of the navigation menu that links to a page served by a plugin.
+type MenuEntry struct {
+	// Label shown in the menu.
+	Label string `json:"label"`
+	// Path of the page relative to the plugin root.
+	Path string `json:"path"`
+}
+
+// PluginInfo describes a plugin to the frontend.
+type PluginInfo struct {
+	Name        string      `json:"name"`
+	MenuEntries []MenuEntry `json:"menuEntries"`
+}
+
+// PluginList is a list of plugins installed in Dashboard.
+type PluginList struct {
+	Plugins []PluginInfo `json:"plugins"`
+}
+
+var (
+	registryMutex sync.RWMutex
+	registry      = make(map[string]Plugin)
+)
+
+// Register makes a plugin available in Dashboard. It panics if the plugin name is not valid or
+// a plugin with the same name is already registered.
+func Register(plugin Plugin) {
+	if err := Validate(plugin); err != nil {
+		panic(err)
+	}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	if _, exists := registry[plugin.Name()]; exists {
+		panic(fmt.Sprintf("plugin %s is already registered", plugin.Name()))
+	}
+	registry[plugin.Name()] = plugin
+}
+
+// Plugins returns registered plugins sorted by name.
+func Plugins() []Plugin {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	plugins := make([]Plugin, 0, len(registry))
+	for _, plugin := range registry {
+		plugins = append(plugins, plugin)
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name() < plugins[j].Name() })
+	return plugins
+}
+
+// Lookup returns registered plugin with given name.
+func Lookup(name string) (Plugin, bool) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	plugin, exists := registry[name]
+	return plugin, exists
+}
+
+// Validate checks that the plugin name is a valid DNS label.
+func Validate(plugin Plugin) error {
+	if errs := validation.IsDNS1123Label(plugin.Name()); len(errs) > 0 {
+		return fmt.Errorf("invalid plugin name %q: %v", plugin.Name(), errs)
+	}
+	return nil
+}
+
+// GetPluginList returns names and menu entries of given plugins.
+func GetPluginList(plugins []Plugin) PluginList {
+	list := PluginList{Plugins: make([]PluginInfo, 0, len(plugins))}
+	for _, plugin := range plugins {
+		entries := plugin.MenuEntries()
+		if entries == nil {
+			entries = []MenuEntry{}
+		}
+		list.Plugins = append(list.Plugins, PluginInfo{Name: plugin.Name(), MenuEntries: entries})
+	}
+	return list
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+)
+
+type testPlugin struct {
+	name string
+}
+
+func (self testPlugin) Name() string                                 { return self.name }
+func (self testPlugin) Routes(cManager client.ClientManager) []Route { return nil }
+func (self testPlugin) MenuEntries() []MenuEntry                     { return nil }
+
+func TestRegister(t *testing.T) {
+	Register(testPlugin{name: "velero"})
+	Register(testPlugin{name: "istio"})
+
+	names := []string{}
+	for _, plugin := range Plugins() {
+		names = append(names, plugin.Name())
+	}
+	if expected := []string{"istio", "velero"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Plugins() returns %v, expected %v", names, expected)
+	}
+
+	if _, exists := Lookup("istio"); !exists {
+		t.Error("Lookup(istio) does not find registered plugin")
+	}
+	if _, exists := Lookup("helm"); exists {
+		t.Error("Lookup(helm) finds plugin that is not registered")
+	}
+
+	for _, name := range []string{"istio", "Invalid_Name", ""} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) does not panic", name)
+				}
+			}()
+			Register(testPlugin{name: name})
+		}()
+	}
+}
+
+func TestParseSidecarPlugins(t *testing.T) {
+	cases := []struct {
+		specs             []string
+		credentialPlugins []string
+		expected          PluginList
+		expectedError     bool
+	}{
+		{[]string{}, nil, PluginList{Plugins: []PluginInfo{}}, false},
+		{
+			[]string{"istio=http://localhost:9091", "velero=http://localhost:9092/ui"},
+			[]string{"velero"},
+			PluginList{Plugins: []PluginInfo{
+				{Name: "istio", MenuEntries: []MenuEntry{{Label: "istio", Path: "/"}}},
+				{Name: "velero", MenuEntries: []MenuEntry{{Label: "velero", Path: "/"}}},
+			}},
+			false,
+		},
+		{[]string{"istio"}, nil, PluginList{}, true},
+		{[]string{"istio=localhost"}, nil, PluginList{}, true},
+		{[]string{"Istio=http://localhost:9091"}, nil, PluginList{}, true},
+		{[]string{"istio=http://localhost:9091"}, []string{"velero"}, PluginList{}, true},
+	}
+
+	for _, c := range cases {
+		plugins, err := ParseSidecarPlugins(c.specs, c.credentialPlugins)
+		if (err != nil) != c.expectedError {
+			t.Errorf("ParseSidecarPlugins(%v) returns error %v, expected error %t", c.specs, err,
+				c.expectedError)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if actual := GetPluginList(plugins); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("GetPluginList(ParseSidecarPlugins(%v)) == %#v, expected %#v", c.specs, actual,
+				c.expected)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/auth"
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/csrf"
+	"k8s.io/client-go/transport"
+)
+
+// Methods of requests forwarded to sidecar plugins.
+var sidecarMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+// sidecarPlugin forwards all requests under the plugin root to a server running next to
+// Dashboard, e.g. in another container of the Dashboard pod.
+type sidecarPlugin struct {
+	name   string
+	target *url.URL
+	// forwardCredentials is true if the sidecar receives authorization and impersonation headers of
+	// the user.
+	forwardCredentials bool
+}
+
+// NewSidecarPlugin creates plugin that forwards requests to the server at given URL, so that
+// /api/v1/plugin/<name>/path is served by <url>/path. Dashboard session cookies, CSRF token and
+// credentials of the user, i.e. authorization and impersonation headers, are removed. Credentials
+// are only forwarded if forwardCredentials is true, so that the sidecar can access the apiserver
+// on behalf of the user. Responses of the sidecar are sandboxed. The plugin adds a single menu entry that links to the root page of the
+// sidecar.
+func NewSidecarPlugin(name, rawURL string, forwardCredentials bool) (Plugin, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil || target.Scheme == "" || target.Host == "" {
+		return nil, fmt.Errorf("invalid URL of sidecar plugin %s: %q", name, rawURL)
+	}
+
+	plugin := sidecarPlugin{name: name, target: target, forwardCredentials: forwardCredentials}
+	if err := Validate(plugin); err != nil {
+		return nil, err
+	}
+	return plugin, nil
+}
+
+// ParseSidecarPlugins creates sidecar plugins from specs in the form of name=url. Credentials of
+// the user are forwarded only to plugins named in credentialPlugins.
+func ParseSidecarPlugins(specs, credentialPlugins []string) ([]Plugin, error) {
+	plugins := make([]Plugin, 0, len(specs))
+	names := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid sidecar plugin %q, expected name=url", spec)
+		}
+
+		plugin, err := NewSidecarPlugin(parts[0], parts[1], contains(credentialPlugins, parts[0]))
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, plugin)
+		names = append(names, parts[0])
+	}
+
+	for _, name := range credentialPlugins {
+		if !contains(names, name) {
+			return nil, fmt.Errorf("credentials can not be forwarded to unknown sidecar plugin %s", name)
+		}
+	}
+	return plugins, nil
+}
+
+// Name implements Plugin.
+func (self sidecarPlugin) Name() string {
+	return self.name
+}
+
+// Routes implements Plugin.
+func (self sidecarPlugin) Routes(cManager client.ClientManager) []Route {
+	routes := make([]Route, 0, 2*len(sidecarMethods))
+	for _, method := range sidecarMethods {
+		routes = append(routes,
+			Route{Method: method, Path: "", Handler: self.handleProxy},
+			Route{Method: method, Path: "/{path:*}", Handler: self.handleProxy})
+	}
+	return routes
+}
+
+// MenuEntries implements Plugin.
+func (self sidecarPlugin) MenuEntries() []MenuEntry {
+	return []MenuEntry{{Label: self.name, Path: "/"}}
+}
+
+func (self sidecarPlugin) handleProxy(request *restful.Request, response *restful.Response) {
+	location := *self.target
+	location.Path = strings.TrimSuffix(location.Path, "/") + "/" + request.PathParameter("path")
+	if path := request.PathParameter("path"); path != "" && strings.HasSuffix(request.Request.URL.Path, "/") {
+		// Trailing slash is dropped by the router, but matters for relative links of web UIs.
+		location.Path += "/"
+	}
+	location.RawQuery = request.Request.URL.RawQuery
+
+	proxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL = &location
+			req.Host = location.Host
+			// Response is compressed by the container if client accepts it.
+			req.Header.Del("Accept-Encoding")
+			req.Header.Del(csrf.TokenHeader)
+			// Session token is never forwarded, credentials unwrapped from it are forwarded below.
+			req.Header.Del(auth.SessionTokenHeader)
+			auth.RemoveTokenCookies(req)
+			if !self.forwardCredentials {
+				removeCredentials(req.Header)
+			}
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			auth.SandboxProxiedResponse(resp)
+			return nil
+		},
+	}
+
+	proxy.ServeHTTP(response, request.Request)
+}
+
+// removeCredentials removes authorization and impersonation headers, including the token
+// unwrapped from the session of the user.
+func removeCredentials(header http.Header) {
+	header.Del("Authorization")
+	for name := range header {
+		if name == transport.ImpersonateUserHeader || name == transport.ImpersonateGroupHeader ||
+			strings.HasPrefix(name, transport.ImpersonateUserExtraHeaderPrefix) {
+			header.Del(name)
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
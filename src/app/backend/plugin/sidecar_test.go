@@ -0,0 +1,92 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	restful "github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/auth"
+)
+
+func TestSidecarPluginCredentials(t *testing.T) {
+	var proxiedHeader http.Header
+	sidecar := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHeader = r.Header
+		w.Header().Set("Set-Cookie", "grafana_session=1")
+	}))
+	defer sidecar.Close()
+
+	plugins, err := ParseSidecarPlugins([]string{"untrusted=" + sidecar.URL, "trusted=" + sidecar.URL},
+		[]string{"trusted"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ws := new(restful.WebService)
+	for _, plugin := range plugins {
+		for _, route := range plugin.Routes(nil) {
+			ws.Route(ws.Method(route.Method).Path("/" + plugin.Name() + route.Path).To(route.Handler))
+		}
+	}
+	container := restful.NewContainer()
+	container.Add(ws)
+	dashboard := httptest.NewServer(container)
+	defer dashboard.Close()
+
+	cases := []struct {
+		plugin   string
+		expected bool
+	}{
+		{"untrusted", false},
+		{"trusted", true},
+	}
+
+	for _, c := range cases {
+		req, _ := http.NewRequest("GET", dashboard.URL+"/"+c.plugin, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set("Impersonate-User", "jane")
+		req.Header.Set("Impersonate-Group", "admins")
+		req.Header.Set("Impersonate-Extra-Scopes", "view")
+		req.Header.Set(auth.SessionTokenHeader, "session-token")
+		proxiedHeader = nil
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if proxiedHeader == nil {
+			t.Fatalf("Request to plugin %s was not forwarded", c.plugin)
+		}
+		for _, name := range []string{"Authorization", "Impersonate-User", "Impersonate-Group",
+			"Impersonate-Extra-Scopes"} {
+			if forwarded := proxiedHeader.Get(name) != ""; forwarded != c.expected {
+				t.Errorf("Header %s forwarded to plugin %s: %t, expected %t", name, c.plugin,
+					forwarded, c.expected)
+			}
+		}
+		if proxiedHeader.Get(auth.SessionTokenHeader) != "" {
+			t.Errorf("Session token forwarded to plugin %s", c.plugin)
+		}
+		if !strings.HasPrefix(resp.Header.Get("Content-Security-Policy"), "sandbox") ||
+			resp.Header.Get("Set-Cookie") != "" {
+			t.Errorf("Response of plugin %s is not sandboxed: %v", c.plugin, resp.Header)
+		}
+	}
+}
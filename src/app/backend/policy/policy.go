@@ -0,0 +1,168 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy implements action-level authorization of Dashboard requests. Policies are
+// evaluated before requests reach Kubernetes, so they can restrict what users do through Dashboard
+// independently of Kubernetes RBAC, e.g. "no exec in namespace prod".
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ghodss/yaml"
+)
+
+// Wildcard matches any value in rules.
+const Wildcard = "*"
+
+// Attributes describe an action of a user in Dashboard.
+type Attributes struct {
+	// User is the name of the user.
+	User string
+	// Groups the user belongs to.
+	Groups []string
+	// Verified is true if User and Groups are authenticated. Identity claimed by unverified
+	// credentials, e.g. claims of bearer tokens, could be forged, so it is only matched by deny rules.
+	Verified bool
+	// Verb of the action, i.e. get, create, update, delete, exec, portforward or reveal.
+	Verb string
+	// Resource the action is about, i.e. pod or secret.
+	Resource string
+	// Namespace of the resource, empty for cluster scoped resources and lists in all namespaces.
+	Namespace string
+	// Name of the resource, empty for lists.
+	Name string
+}
+
+// Decision is the result of policy evaluation.
+type Decision struct {
+	// Allowed is true if the action is allowed.
+	Allowed bool
+	// Reason explains why the action is denied.
+	Reason string
+}
+
+// Engine decides whether an action is allowed. It is the extension point for other policy
+// languages, e.g. Rego.
+type Engine interface {
+	// Authorize returns decision for given action.
+	Authorize(attributes Attributes) Decision
+}
+
+// Rule matches actions. Empty lists and Wildcard match any value.
+type Rule struct {
+	// Name of the rule, used in reasons of denied actions.
+	Name       string   `json:"name"`
+	Users      []string `json:"users,omitempty"`
+	Groups     []string `json:"groups,omitempty"`
+	Verbs      []string `json:"verbs,omitempty"`
+	Resources  []string `json:"resources,omitempty"`
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// Policy is a set of rules. Actions matched by any deny rule are denied, unless they are matched
+// by an allow rule too. Allow rules restricted to some users or groups only match actions of
+// verified users. All other actions are allowed, so the policy can only restrict what Kubernetes
+// RBAC allows.
+type Policy struct {
+	Deny  []Rule `json:"deny"`
+	Allow []Rule `json:"allow,omitempty"`
+}
+
+// ruleEngine evaluates Policy.
+type ruleEngine struct {
+	policy Policy
+}
+
+// Authorize implements Engine interface.
+func (self ruleEngine) Authorize(attributes Attributes) Decision {
+	for _, rule := range self.policy.Allow {
+		if rule.matches(attributes) && (attributes.Verified || rule.matchesAnyUser()) {
+			return Decision{Allowed: true}
+		}
+	}
+
+	for _, rule := range self.policy.Deny {
+		if rule.matches(attributes) {
+			return Decision{Reason: fmt.Sprintf("action denied by Dashboard policy rule '%s'", rule.Name)}
+		}
+	}
+
+	return Decision{Allowed: true}
+}
+
+// matches returns true if all attributes of the action match the rule.
+func (self Rule) matches(attributes Attributes) bool {
+	if !matchesAny(self.Users, attributes.User) || !matchesAny(self.Verbs, attributes.Verb) ||
+		!matchesAny(self.Resources, attributes.Resource) ||
+		!matchesAny(self.Namespaces, attributes.Namespace) {
+		return false
+	}
+
+	if len(self.Groups) == 0 {
+		return true
+	}
+	for _, group := range attributes.Groups {
+		if matchesAny(self.Groups, group) {
+			return true
+		}
+	}
+	return contains(self.Groups, Wildcard)
+}
+
+// matchesAnyUser returns true if the rule is not restricted to some users or groups.
+func (self Rule) matchesAnyUser() bool {
+	return (len(self.Users) == 0 || contains(self.Users, Wildcard)) &&
+		(len(self.Groups) == 0 || contains(self.Groups, Wildcard))
+}
+
+// NewRuleEngine creates engine that evaluates given policy.
+func NewRuleEngine(policy Policy) Engine {
+	return ruleEngine{policy: policy}
+}
+
+// LoadFile reads policy in YAML or JSON format from file and creates engine that evaluates it.
+func LoadFile(path string) (Engine, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := Policy{}
+	if err := yaml.Unmarshal(content, &policy); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %s", path, err)
+	}
+
+	for _, rule := range append(policy.Deny, policy.Allow...) {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("invalid policy file %s: every rule has to have a name", path)
+		}
+	}
+
+	return NewRuleEngine(policy), nil
+}
+
+func matchesAny(values []string, value string) bool {
+	return len(values) == 0 || contains(values, Wildcard) || contains(values, value)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
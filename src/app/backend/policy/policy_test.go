@@ -0,0 +1,120 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestAuthorize(t *testing.T) {
+	engine := NewRuleEngine(Policy{
+		Deny: []Rule{
+			{Name: "no-exec-in-prod", Verbs: []string{"exec"}, Namespaces: []string{"prod"}},
+			{Name: "no-reveal-for-interns", Verbs: []string{"reveal"}, Resources: []string{"secret"},
+				Groups: []string{"interns"}},
+			{Name: "no-deletes", Users: []string{"jane"}, Verbs: []string{"delete"},
+				Resources: []string{Wildcard}},
+		},
+		Allow: []Rule{
+			{Name: "admins", Groups: []string{"admins"}},
+		},
+	})
+
+	cases := []struct {
+		attributes Attributes
+		expected   Decision
+	}{
+		{
+			Attributes{User: "john", Verb: "exec", Resource: "pod", Namespace: "prod"},
+			Decision{Reason: "action denied by Dashboard policy rule 'no-exec-in-prod'"},
+		},
+		{
+			Attributes{User: "john", Verb: "exec", Resource: "pod", Namespace: "dev"},
+			Decision{Allowed: true},
+		},
+		{
+			Attributes{User: "john", Groups: []string{"admins"}, Verified: true, Verb: "exec",
+				Namespace: "prod"},
+			Decision{Allowed: true},
+		},
+		{
+			Attributes{User: "john", Groups: []string{"admins"}, Verb: "exec", Namespace: "prod"},
+			Decision{Reason: "action denied by Dashboard policy rule 'no-exec-in-prod'"},
+		},
+		{
+			Attributes{User: "bob", Groups: []string{"devs", "interns"}, Verb: "reveal",
+				Resource: "secret", Namespace: "dev"},
+			Decision{Reason: "action denied by Dashboard policy rule 'no-reveal-for-interns'"},
+		},
+		{
+			Attributes{User: "bob", Groups: []string{"devs"}, Verb: "reveal", Resource: "secret"},
+			Decision{Allowed: true},
+		},
+		{
+			Attributes{User: "jane", Verb: "delete", Resource: "deployment", Namespace: "dev"},
+			Decision{Reason: "action denied by Dashboard policy rule 'no-deletes'"},
+		},
+		{
+			Attributes{User: "jane", Verb: "update", Resource: "deployment", Namespace: "dev"},
+			Decision{Allowed: true},
+		},
+	}
+
+	for _, c := range cases {
+		actual := engine.Authorize(c.attributes)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Authorize(%#v) == %#v, expected %#v", c.attributes, actual, c.expected)
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	cases := []struct {
+		content  string
+		expected Engine
+		valid    bool
+	}{
+		{
+			"deny:\n- name: no-exec\n  verbs: [exec]\n  namespaces: [prod]\n",
+			ruleEngine{policy: Policy{Deny: []Rule{
+				{Name: "no-exec", Verbs: []string{"exec"}, Namespaces: []string{"prod"}},
+			}}},
+			true,
+		},
+		{"deny:\n- verbs: [exec]\n", nil, false},
+		{"deny: no-exec", nil, false},
+	}
+
+	for _, c := range cases {
+		file, err := ioutil.TempFile("", "policy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(file.Name())
+		file.WriteString(c.content)
+		file.Close()
+
+		actual, err := LoadFile(file.Name())
+		if (err == nil) != c.valid {
+			t.Errorf("LoadFile() with %q returns error %v, expected valid %t", c.content, err, c.valid)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("LoadFile() with %q == %#v, expected %#v", c.content, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessreview
+
+import (
+	"fmt"
+	"sync"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	authorization "k8s.io/client-go/pkg/apis/authorization/v1"
+)
+
+// MaxReviews is the maximum number of actions that can be checked in a single request.
+const MaxReviews = 100
+
+// ResourceAction is an action on resources the user wants to perform. Empty namespace means all
+// namespaces for namespaced resources, empty name means all resources of the kind.
+type ResourceAction struct {
+	Verb        string `json:"verb"`
+	Group       string `json:"group"`
+	Resource    string `json:"resource"`
+	Subresource string `json:"subresource,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+}
+
+// AccessReviewSpec is a batch of actions to check, i.e. all actions available on a page.
+type AccessReviewSpec struct {
+	Actions []ResourceAction `json:"actions"`
+}
+
+// AccessReview tells whether the user is allowed to perform the action.
+type AccessReview struct {
+	ResourceAction `json:",inline"`
+
+	Allowed bool `json:"allowed"`
+
+	// Reason of the decision given by the authorizer, if any.
+	Reason string `json:"reason,omitempty"`
+
+	// Error of the review. Actions that could not be reviewed are not allowed.
+	Error string `json:"error,omitempty"`
+}
+
+// AccessReviewList contains reviews of actions in the order they were requested.
+type AccessReviewList struct {
+	Reviews []AccessReview `json:"reviews"`
+}
+
+// ReviewAccess checks which actions the user is allowed to perform with SelfSubjectAccessReviews,
+// so that UI can hide actions the user can not use. Actions are reviewed concurrently.
+func ReviewAccess(client kubernetes.Interface, spec *AccessReviewSpec) (*AccessReviewList, error) {
+	if len(spec.Actions) > MaxReviews {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("at most %d actions can be reviewed at once",
+			MaxReviews))
+	}
+
+	reviews := make([]AccessReview, len(spec.Actions))
+	var wg sync.WaitGroup
+	for i := range spec.Actions {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			reviews[i] = ReviewAction(client, spec.Actions[i])
+		}(i)
+	}
+	wg.Wait()
+
+	return &AccessReviewList{Reviews: reviews}, nil
+}
+
+// ReviewAction checks whether the user is allowed to perform single action.
+func ReviewAction(client kubernetes.Interface, action ResourceAction) AccessReview {
+	review := AccessReview{ResourceAction: action}
+	if action.Verb == "" || action.Resource == "" {
+		review.Error = "verb and resource are required"
+		return review
+	}
+
+	result, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(
+		&authorization.SelfSubjectAccessReview{
+			Spec: authorization.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorization.ResourceAttributes{
+					Verb:        action.Verb,
+					Group:       action.Group,
+					Resource:    action.Resource,
+					Subresource: action.Subresource,
+					Namespace:   action.Namespace,
+					Name:        action.Name,
+				},
+			},
+		})
+	if err != nil {
+		review.Error = err.Error()
+		return review
+	}
+
+	review.Allowed = result.Status.Allowed
+	review.Reason = result.Status.Reason
+	return review
+}
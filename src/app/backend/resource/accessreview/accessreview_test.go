@@ -0,0 +1,71 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accessreview
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	authorization "k8s.io/client-go/pkg/apis/authorization/v1"
+	core "k8s.io/client-go/testing"
+)
+
+func TestReviewAccess(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "selfsubjectaccessreviews",
+		func(action core.Action) (bool, runtime.Object, error) {
+			review := action.(core.CreateAction).GetObject().(*authorization.SelfSubjectAccessReview)
+			attributes := review.Spec.ResourceAttributes
+			review.Status.Allowed = attributes.Verb == "get" ||
+				(attributes.Verb == "create" && attributes.Subresource == "exec" &&
+					attributes.Namespace == "default")
+			if !review.Status.Allowed {
+				review.Status.Reason = "no RBAC policy matched"
+			}
+			return true, review, nil
+		})
+
+	spec := &AccessReviewSpec{Actions: []ResourceAction{
+		{Verb: "get", Resource: "pods", Namespace: "default"},
+		{Verb: "delete", Group: "apps", Resource: "deployments", Namespace: "default", Name: "web"},
+		{Verb: "create", Resource: "pods", Subresource: "exec", Namespace: "default"},
+		{Verb: "create", Resource: "pods", Subresource: "exec", Namespace: "kube-system"},
+		{Resource: "pods"},
+	}}
+	expected := &AccessReviewList{Reviews: []AccessReview{
+		{ResourceAction: spec.Actions[0], Allowed: true},
+		{ResourceAction: spec.Actions[1], Reason: "no RBAC policy matched"},
+		{ResourceAction: spec.Actions[2], Allowed: true},
+		{ResourceAction: spec.Actions[3], Reason: "no RBAC policy matched"},
+		{ResourceAction: spec.Actions[4], Error: "verb and resource are required"},
+	}}
+
+	actual, err := ReviewAccess(client, spec)
+	if err != nil {
+		t.Fatalf("ReviewAccess(): unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("ReviewAccess() == %#v, expected %#v", actual, expected)
+	}
+}
+
+func TestReviewAccessTooManyActions(t *testing.T) {
+	spec := &AccessReviewSpec{Actions: make([]ResourceAction, MaxReviews+1)}
+	if _, err := ReviewAccess(fake.NewSimpleClientset(), spec); err == nil {
+		t.Error("ReviewAccess(): expected error for too many actions")
+	}
+}
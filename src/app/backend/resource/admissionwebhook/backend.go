@@ -0,0 +1,171 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissionwebhook
+
+import (
+	"fmt"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// defaultServicePort is used by the apiserver to call webhook services without a port.
+const defaultServicePort = 443
+
+// BackendStatus tells whether the apiserver is able to reach the webhook.
+type BackendStatus string
+
+// List of webhook backend statuses.
+const (
+	// BackendStatusHealthy means that the webhook service has ready endpoints.
+	BackendStatusHealthy BackendStatus = "Healthy"
+	// BackendStatusNoReadyEndpoints means that no pod backing the webhook service is ready.
+	BackendStatusNoReadyEndpoints BackendStatus = "NoReadyEndpoints"
+	// BackendStatusServiceNotFound means that the webhook service does not exist.
+	BackendStatusServiceNotFound BackendStatus = "ServiceNotFound"
+	// BackendStatusPortNotFound means that the webhook service does not expose the called port.
+	BackendStatusPortNotFound BackendStatus = "PortNotFound"
+	// BackendStatusExternal means that the webhook is called by URL or external name, so its health
+	// can not be checked from the cluster.
+	BackendStatusExternal BackendStatus = "External"
+)
+
+// WebhookBackend describes health of the service called by the apiserver.
+type WebhookBackend struct {
+	Status            BackendStatus `json:"status"`
+	ReadyEndpoints    int           `json:"readyEndpoints"`
+	NotReadyEndpoints int           `json:"notReadyEndpoints"`
+	Message           string        `json:"message"`
+}
+
+// IsAvailable returns true when the apiserver may be able to call the webhook.
+func (b WebhookBackend) IsAvailable() bool {
+	return b.Status == BackendStatusHealthy || b.Status == BackendStatusExternal
+}
+
+// backendResolver checks health of webhook services. Services and endpoints are cached, because
+// many webhooks usually share a single service.
+type backendResolver struct {
+	client    client.Interface
+	services  map[string]*v1.Service
+	endpoints map[string]*v1.Endpoints
+}
+
+func newBackendResolver(client client.Interface) *backendResolver {
+	return &backendResolver{
+		client:    client,
+		services:  make(map[string]*v1.Service),
+		endpoints: make(map[string]*v1.Endpoints),
+	}
+}
+
+func (r *backendResolver) getBackend(config webhookClientConfig) (WebhookBackend, error) {
+	if config.Service == nil {
+		return WebhookBackend{Status: BackendStatusExternal, Message: "Webhook is called by URL"}, nil
+	}
+
+	ref := config.Service
+	service, endpoints, err := r.get(ref.Namespace, ref.Name)
+	if err != nil {
+		return WebhookBackend{}, err
+	}
+	if service == nil {
+		return WebhookBackend{Status: BackendStatusServiceNotFound,
+			Message: fmt.Sprintf("Service %s/%s does not exist", ref.Namespace, ref.Name)}, nil
+	}
+	if service.Spec.Type == v1.ServiceTypeExternalName {
+		return WebhookBackend{Status: BackendStatusExternal,
+			Message: fmt.Sprintf("Service %s/%s points to %s", ref.Namespace, ref.Name, service.Spec.ExternalName)}, nil
+	}
+
+	port := int32(defaultServicePort)
+	if ref.Port != nil {
+		port = *ref.Port
+	}
+	var servicePort *v1.ServicePort
+	for i := range service.Spec.Ports {
+		if service.Spec.Ports[i].Port == port {
+			servicePort = &service.Spec.Ports[i]
+		}
+	}
+	if servicePort == nil {
+		return WebhookBackend{Status: BackendStatusPortNotFound,
+			Message: fmt.Sprintf("Service %s/%s does not expose port %d", ref.Namespace, ref.Name, port)}, nil
+	}
+
+	backend := WebhookBackend{}
+	if endpoints != nil {
+		for _, subset := range endpoints.Subsets {
+			if !hasPort(subset, servicePort.Name) {
+				continue
+			}
+			backend.ReadyEndpoints += len(subset.Addresses)
+			backend.NotReadyEndpoints += len(subset.NotReadyAddresses)
+		}
+	}
+
+	if backend.ReadyEndpoints == 0 {
+		backend.Status = BackendStatusNoReadyEndpoints
+		backend.Message = fmt.Sprintf("Service %s/%s has no ready endpoints for port %d", ref.Namespace,
+			ref.Name, port)
+	} else {
+		backend.Status = BackendStatusHealthy
+	}
+	return backend, nil
+}
+
+// hasPort returns true when endpoint subset serves the service port with the given name. Ports of
+// single port services may be unnamed.
+func hasPort(subset v1.EndpointSubset, name string) bool {
+	for _, port := range subset.Ports {
+		if port.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// get returns the service and its endpoints or nil, if they do not exist.
+func (r *backendResolver) get(namespace, name string) (*v1.Service, *v1.Endpoints, error) {
+	key := namespace + "/" + name
+	if service, ok := r.services[key]; ok {
+		return service, r.endpoints[key], nil
+	}
+
+	service, err := r.client.CoreV1().Services(namespace).Get(name, metaV1.GetOptions{})
+	if errorsK8s.IsNotFound(err) {
+		service, err = nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var endpoints *v1.Endpoints
+	if service != nil {
+		endpoints, err = r.client.CoreV1().Endpoints(namespace).Get(name, metaV1.GetOptions{})
+		if errorsK8s.IsNotFound(err) {
+			endpoints, err = nil, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	r.services[key] = service
+	r.endpoints[key] = endpoints
+	return service, endpoints, nil
+}
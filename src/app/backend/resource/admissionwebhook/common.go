@@ -0,0 +1,198 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissionwebhook
+
+import (
+	"encoding/json"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+// AdmissionRegistrationGroupVersions are the group versions of admission webhook configuration API
+// in order of preference. Older clusters serve only v1beta1.
+var AdmissionRegistrationGroupVersions = []schema.GroupVersion{
+	{Group: "admissionregistration.k8s.io", Version: "v1"},
+	{Group: "admissionregistration.k8s.io", Version: "v1beta1"},
+}
+
+// WebhookType is the type of admission webhook configuration.
+type WebhookType string
+
+// List of admission webhook configuration types.
+const (
+	WebhookTypeValidating WebhookType = "validating"
+	WebhookTypeMutating   WebhookType = "mutating"
+)
+
+// Resource returns name of the API resource of webhook configurations of this type.
+func (t WebhookType) Resource() string {
+	return string(t) + "webhookconfigurations"
+}
+
+// ParseWebhookType returns webhook type with the given name.
+func ParseWebhookType(name string) (WebhookType, error) {
+	switch WebhookType(name) {
+	case WebhookTypeValidating, WebhookTypeMutating:
+		return WebhookType(name), nil
+	}
+	return "", errorsK8s.NewBadRequest("webhook type must be validating or mutating")
+}
+
+// webhookConfiguration is the API representation of validating and mutating webhook configurations.
+// Client library does not contain admission registration types, so only the fields used by
+// Dashboard are declared.
+type webhookConfiguration struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Webhooks          []webhook `json:"webhooks"`
+}
+
+type webhook struct {
+	Name               string                `json:"name"`
+	ClientConfig       webhookClientConfig   `json:"clientConfig"`
+	Rules              []WebhookRule         `json:"rules"`
+	FailurePolicy      *string               `json:"failurePolicy"`
+	MatchPolicy        *string               `json:"matchPolicy"`
+	NamespaceSelector  *metaV1.LabelSelector `json:"namespaceSelector"`
+	ObjectSelector     *metaV1.LabelSelector `json:"objectSelector"`
+	SideEffects        *string               `json:"sideEffects"`
+	TimeoutSeconds     *int32                `json:"timeoutSeconds"`
+	ReinvocationPolicy *string               `json:"reinvocationPolicy"`
+}
+
+type webhookClientConfig struct {
+	URL      *string           `json:"url"`
+	Service  *serviceReference `json:"service"`
+	CABundle []byte            `json:"caBundle"`
+}
+
+type serviceReference struct {
+	Namespace string  `json:"namespace"`
+	Name      string  `json:"name"`
+	Path      *string `json:"path"`
+	Port      *int32  `json:"port"`
+}
+
+type webhookConfigurationList struct {
+	Items []webhookConfiguration `json:"items"`
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Get() *rest.Request
+}
+
+// newRESTClients creates REST clients of AdmissionRegistrationGroupVersions in the same order.
+func newRESTClients(config *rest.Config) ([]RESTClient, error) {
+	restClients := make([]RESTClient, 0, len(AdmissionRegistrationGroupVersions))
+	for _, gv := range AdmissionRegistrationGroupVersions {
+		restClient, err := apply.NewRESTClient(config, gv)
+		if err != nil {
+			return nil, err
+		}
+		restClients = append(restClients, restClient)
+	}
+	return restClients, nil
+}
+
+// listWebhookConfigurations returns all webhook configurations of the given type.
+func listWebhookConfigurations(restClients []RESTClient, webhookType WebhookType) (
+	[]webhookConfiguration, error) {
+	raw, err := getRaw(restClients, webhookType, "")
+	if err != nil {
+		return nil, err
+	}
+
+	list := webhookConfigurationList{}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// getWebhookConfiguration returns webhook configuration of the given type and name.
+func getWebhookConfiguration(restClients []RESTClient, webhookType WebhookType, name string) (
+	*webhookConfiguration, error) {
+	raw, err := getRaw(restClients, webhookType, name)
+	if err != nil {
+		return nil, err
+	}
+
+	configuration := &webhookConfiguration{}
+	if err := json.Unmarshal(raw, configuration); err != nil {
+		return nil, err
+	}
+	return configuration, nil
+}
+
+// getRaw gets webhook configurations from the first admission registration API version served by
+// the cluster, clients are ordered by preference of the versions. All configurations of the type
+// are listed if name is empty. Not found errors fall through to the next version, as they are also
+// returned for versions that are not served.
+func getRaw(restClients []RESTClient, webhookType WebhookType, name string) ([]byte, error) {
+	var err error
+	for _, restClient := range restClients {
+		request := restClient.Get().Resource(webhookType.Resource())
+		if len(name) > 0 {
+			request = request.Name(name)
+		}
+
+		var raw []byte
+		raw, err = request.Do().Raw()
+		if err == nil || !errorsK8s.IsNotFound(err) {
+			return raw, err
+		}
+	}
+	// Either the API is not served or the configuration does not exist in any version.
+	return nil, err
+}
+
+// The code below allows to perform complex data section on []AdmissionWebhookConfiguration
+
+type AdmissionWebhookConfigurationCell AdmissionWebhookConfiguration
+
+func (self AdmissionWebhookConfigurationCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.StatusProperty:
+		return dataselect.StdComparableInt(self.CriticalIssues)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []AdmissionWebhookConfiguration) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = AdmissionWebhookConfigurationCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []AdmissionWebhookConfiguration {
+	std := make([]AdmissionWebhookConfiguration, len(cells))
+	for i := range std {
+		std[i] = AdmissionWebhookConfiguration(cells[i].(AdmissionWebhookConfigurationCell))
+	}
+	return std
+}
@@ -0,0 +1,186 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissionwebhook
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Failure policies of webhooks.
+const (
+	FailurePolicyFail   = "Fail"
+	FailurePolicyIgnore = "Ignore"
+)
+
+// AdmissionWebhookConfigurationDetail contains webhooks of a configuration with health of their
+// backends and diagnostics of possible failures.
+type AdmissionWebhookConfigurationDetail struct {
+	AdmissionWebhookConfiguration `json:",inline"`
+
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// Webhook is a single webhook called by the apiserver.
+type Webhook struct {
+	Name string `json:"name"`
+
+	// Either Service or URL is set.
+	Service *WebhookService `json:"service"`
+	URL     string          `json:"url"`
+
+	// Rules describe operations and resources intercepted by the webhook.
+	Rules []WebhookRule `json:"rules"`
+
+	// FailurePolicy tells whether requests are rejected (Fail) or admitted (Ignore) when the webhook
+	// can not be called.
+	FailurePolicy      string `json:"failurePolicy"`
+	MatchPolicy        string `json:"matchPolicy"`
+	SideEffects        string `json:"sideEffects"`
+	TimeoutSeconds     int32  `json:"timeoutSeconds"`
+	ReinvocationPolicy string `json:"reinvocationPolicy"`
+
+	// Selectors limiting namespaces and objects sent to the webhook. Empty means everything.
+	NamespaceSelector string `json:"namespaceSelector"`
+	ObjectSelector    string `json:"objectSelector"`
+
+	Backend     WebhookBackend      `json:"backend"`
+	Diagnostics []WebhookDiagnostic `json:"diagnostics"`
+}
+
+// WebhookService is the service called by the apiserver.
+type WebhookService struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Port      int32  `json:"port"`
+}
+
+// WebhookRule describes operations on resources intercepted by a webhook.
+type WebhookRule struct {
+	Operations  []string `json:"operations"`
+	APIGroups   []string `json:"apiGroups"`
+	APIVersions []string `json:"apiVersions"`
+	Resources   []string `json:"resources"`
+	Scope       string   `json:"scope"`
+}
+
+// GetAdmissionWebhookConfigurationDetail returns webhooks of the configuration together with health
+// of their backends.
+func GetAdmissionWebhookConfigurationDetail(client client.Interface, config *rest.Config,
+	webhookType WebhookType, name string) (*AdmissionWebhookConfigurationDetail, error) {
+	logger.Infof("Getting details of %s %s webhook configuration", name, webhookType)
+
+	restClients, err := newRESTClients(config)
+	if err != nil {
+		return nil, err
+	}
+	return getAdmissionWebhookConfigurationDetail(client, restClients, webhookType, name)
+}
+
+func getAdmissionWebhookConfigurationDetail(client client.Interface, restClients []RESTClient,
+	webhookType WebhookType, name string) (*AdmissionWebhookConfigurationDetail, error) {
+	configuration, err := getWebhookConfiguration(restClients, webhookType, name)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := toWebhooks(configuration.Webhooks, newBackendResolver(client))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdmissionWebhookConfigurationDetail{
+		AdmissionWebhookConfiguration: toAdmissionWebhookConfiguration(configuration, webhookType, webhooks),
+		Webhooks:                      webhooks,
+	}, nil
+}
+
+func toWebhooks(raw []webhook, resolver *backendResolver) ([]Webhook, error) {
+	webhooks := make([]Webhook, 0, len(raw))
+	for _, item := range raw {
+		backend, err := resolver.getBackend(item.ClientConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		webhook := toWebhook(item)
+		webhook.Backend = backend
+		webhook.Diagnostics = getDiagnostics(webhook)
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, nil
+}
+
+func toWebhook(raw webhook) Webhook {
+	webhook := Webhook{
+		Name:               raw.Name,
+		Rules:              make([]WebhookRule, 0, len(raw.Rules)),
+		FailurePolicy:      stringOrDefault(raw.FailurePolicy, FailurePolicyFail),
+		MatchPolicy:        stringOrDefault(raw.MatchPolicy, ""),
+		SideEffects:        stringOrDefault(raw.SideEffects, ""),
+		ReinvocationPolicy: stringOrDefault(raw.ReinvocationPolicy, ""),
+		NamespaceSelector:  selectorString(raw.NamespaceSelector),
+		ObjectSelector:     selectorString(raw.ObjectSelector),
+		// Default timeout of the admissionregistration.k8s.io/v1 API.
+		TimeoutSeconds: 10,
+	}
+	if raw.TimeoutSeconds != nil {
+		webhook.TimeoutSeconds = *raw.TimeoutSeconds
+	}
+
+	if ref := raw.ClientConfig.Service; ref != nil {
+		webhook.Service = &WebhookService{
+			Namespace: ref.Namespace,
+			Name:      ref.Name,
+			Path:      stringOrDefault(ref.Path, ""),
+			Port:      defaultServicePort,
+		}
+		if ref.Port != nil {
+			webhook.Service.Port = *ref.Port
+		}
+	}
+	webhook.URL = stringOrDefault(raw.ClientConfig.URL, "")
+
+	for _, rule := range raw.Rules {
+		if len(rule.Scope) == 0 {
+			rule.Scope = "*"
+		}
+		webhook.Rules = append(webhook.Rules, rule)
+	}
+	return webhook
+}
+
+func stringOrDefault(value *string, defaultValue string) string {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
+
+// selectorString returns the selector in the label selector syntax. Empty string means that the
+// selector matches everything.
+func selectorString(selector *metaV1.LabelSelector) string {
+	if selector == nil {
+		return ""
+	}
+	parsed, err := metaV1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return ""
+	}
+	return parsed.String()
+}
@@ -0,0 +1,183 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissionwebhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
+
+func newWebhook(name, service string, port *int32, failurePolicy string) webhook {
+	return webhook{
+		Name: name,
+		ClientConfig: webhookClientConfig{
+			Service: &serviceReference{Namespace: "webhooks", Name: service, Port: port},
+		},
+		Rules: []WebhookRule{{Operations: []string{"CREATE"}, APIGroups: []string{"apps"},
+			APIVersions: []string{"v1"}, Resources: []string{"deployments"}}},
+		FailurePolicy:     stringPtr(failurePolicy),
+		NamespaceSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"policy": "enabled"}},
+	}
+}
+
+// newTestServer returns apiserver, which responds with JSON of objects keyed by request path. Other
+// paths are not found.
+func newTestServer(objects map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		object, ok := objects[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(object)
+	}))
+}
+
+func newBackendObjects() (*v1.Service, *v1.Endpoints, *v1.Service) {
+	healthy := &v1.Service{
+		ObjectMeta: metaV1.ObjectMeta{Name: "healthy", Namespace: "webhooks"},
+		Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Name: "https", Port: 443}}},
+	}
+	endpoints := &v1.Endpoints{
+		ObjectMeta: metaV1.ObjectMeta{Name: "healthy", Namespace: "webhooks"},
+		Subsets: []v1.EndpointSubset{{
+			Addresses:         []v1.EndpointAddress{{IP: "10.0.0.1"}},
+			NotReadyAddresses: []v1.EndpointAddress{{IP: "10.0.0.2"}},
+			Ports:             []v1.EndpointPort{{Name: "https", Port: 8443}},
+		}},
+	}
+	empty := &v1.Service{
+		ObjectMeta: metaV1.ObjectMeta{Name: "empty", Namespace: "webhooks"},
+		Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 443}}},
+	}
+	return healthy, endpoints, empty
+}
+
+func TestGetAdmissionWebhookConfigurationDetail(t *testing.T) {
+	server := newTestServer(map[string]interface{}{
+		"/apis/admissionregistration.k8s.io/v1/validatingwebhookconfigurations/policy": webhookConfiguration{
+			ObjectMeta: metaV1.ObjectMeta{Name: "policy"},
+			Webhooks: []webhook{
+				newWebhook("healthy.example.com", "healthy", nil, FailurePolicyFail),
+				newWebhook("empty.example.com", "empty", nil, FailurePolicyFail),
+				newWebhook("port.example.com", "healthy", int32Ptr(8080), FailurePolicyIgnore),
+				newWebhook("missing.example.com", "missing", nil, FailurePolicyFail),
+				{Name: "url.example.com", ClientConfig: webhookClientConfig{URL: stringPtr("https://example.com")}},
+			},
+		},
+	})
+	defer server.Close()
+	restClients, err := newRESTClients(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	healthy, endpoints, empty := newBackendObjects()
+	client := fake.NewSimpleClientset(healthy, endpoints, empty)
+
+	actual, err := getAdmissionWebhookConfigurationDetail(client, restClients, WebhookTypeValidating,
+		"policy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedBackends := []WebhookBackend{
+		{Status: BackendStatusHealthy, ReadyEndpoints: 1, NotReadyEndpoints: 1},
+		{Status: BackendStatusNoReadyEndpoints,
+			Message: "Service webhooks/empty has no ready endpoints for port 443"},
+		{Status: BackendStatusPortNotFound, Message: "Service webhooks/healthy does not expose port 8080"},
+		{Status: BackendStatusServiceNotFound, Message: "Service webhooks/missing does not exist"},
+		{Status: BackendStatusExternal, Message: "Webhook is called by URL"},
+	}
+	for i, webhook := range actual.Webhooks {
+		if !reflect.DeepEqual(webhook.Backend, expectedBackends[i]) {
+			t.Errorf("Backend of %s == %#v, expected %#v", webhook.Name, webhook.Backend, expectedBackends[i])
+		}
+	}
+
+	if actual.WebhookCount != 5 || actual.UnavailableWebhooks != 3 || actual.CriticalIssues != 2 {
+		t.Errorf("Got summary %#v, expected 5 webhooks, 3 unavailable and 2 critical issues",
+			actual.AdmissionWebhookConfiguration)
+	}
+	if actual.Webhooks[0].NamespaceSelector != "policy=enabled" || actual.Webhooks[0].TimeoutSeconds != 10 ||
+		actual.Webhooks[0].Service.Port != 443 || actual.Webhooks[0].Rules[0].Scope != "*" {
+		t.Errorf("Defaults were not applied to %#v", actual.Webhooks[0])
+	}
+	if actual.Webhooks[4].FailurePolicy != FailurePolicyFail || actual.Webhooks[4].URL != "https://example.com" {
+		t.Errorf("Got URL webhook %#v", actual.Webhooks[4])
+	}
+}
+
+func TestGetAdmissionWebhookConfigurationList(t *testing.T) {
+	// Mutating configurations are served by the older version only.
+	server := newTestServer(map[string]interface{}{
+		"/apis/admissionregistration.k8s.io/v1beta1/mutatingwebhookconfigurations": webhookConfigurationList{
+			Items: []webhookConfiguration{{
+				ObjectMeta: metaV1.ObjectMeta{Name: "injector"},
+				Webhooks:   []webhook{newWebhook("inject.example.com", "empty", nil, FailurePolicyFail)},
+			}},
+		},
+		"/apis/admissionregistration.k8s.io/v1/validatingwebhookconfigurations": webhookConfigurationList{
+			Items: []webhookConfiguration{{
+				ObjectMeta: metaV1.ObjectMeta{Name: "policy"},
+				Webhooks:   []webhook{newWebhook("healthy.example.com", "healthy", nil, FailurePolicyFail)},
+			}},
+		},
+	})
+	defer server.Close()
+	restClients, err := newRESTClients(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	healthy, endpoints, empty := newBackendObjects()
+	client := fake.NewSimpleClientset(healthy, endpoints, empty)
+
+	actual, err := getAdmissionWebhookConfigurationList(client, restClients, dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual.ListMeta.TotalItems != 2 || len(actual.Configurations) != 2 {
+		t.Fatalf("Got %#v, expected 2 configurations", actual)
+	}
+	for _, configuration := range actual.Configurations {
+		expectedCritical := 0
+		if configuration.Type == WebhookTypeMutating {
+			expectedCritical = 1
+		}
+		if configuration.CriticalIssues != expectedCritical || configuration.WebhookCount != 1 {
+			t.Errorf("Got %#v, expected %d critical issues", configuration, expectedCritical)
+		}
+	}
+}
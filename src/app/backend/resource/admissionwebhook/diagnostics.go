@@ -0,0 +1,133 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissionwebhook
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DiagnosticSeverity tells how likely a webhook problem breaks requests to the apiserver.
+type DiagnosticSeverity string
+
+// List of diagnostic severities.
+const (
+	// DiagnosticSeverityCritical means that matching requests are rejected right now.
+	DiagnosticSeverityCritical DiagnosticSeverity = "Critical"
+	// DiagnosticSeverityWarning means that requests may be rejected or skip the webhook.
+	DiagnosticSeverityWarning DiagnosticSeverity = "Warning"
+)
+
+// WebhookDiagnostic is a problem of a webhook, that may cause failures of apiserver requests.
+type WebhookDiagnostic struct {
+	Severity DiagnosticSeverity `json:"severity"`
+	Message  string             `json:"message"`
+}
+
+// getDiagnostics returns problems of the webhook, most severe first.
+func getDiagnostics(webhook Webhook) []WebhookDiagnostic {
+	diagnostics := make([]WebhookDiagnostic, 0)
+	failing := webhook.FailurePolicy == FailurePolicyFail
+
+	if !webhook.Backend.IsAvailable() {
+		if failing {
+			diagnostics = append(diagnostics, WebhookDiagnostic{DiagnosticSeverityCritical, fmt.Sprintf(
+				"%s. Failure policy is Fail, so %s are rejected.", webhook.Backend.Message,
+				describeRules(webhook.Rules))})
+		} else {
+			diagnostics = append(diagnostics, WebhookDiagnostic{DiagnosticSeverityWarning, fmt.Sprintf(
+				"%s. Failure policy is Ignore, so %s are admitted without calling the webhook.",
+				webhook.Backend.Message, describeRules(webhook.Rules))})
+		}
+	}
+
+	if failing && len(webhook.NamespaceSelector) == 0 {
+		if webhook.Service != nil && interceptsResource(webhook.Rules, "pods") {
+			diagnostics = append(diagnostics, WebhookDiagnostic{DiagnosticSeverityWarning, fmt.Sprintf(
+				"Webhook intercepts pods in all namespaces including %s, where its backend runs. Lost "+
+					"backend pods can not be recreated until the webhook is removed.",
+				webhook.Service.Namespace)})
+		} else if interceptsResource(webhook.Rules, "*") {
+			diagnostics = append(diagnostics, WebhookDiagnostic{DiagnosticSeverityWarning,
+				"Webhook intercepts all resources in all namespaces including kube-system. Add a namespace " +
+					"selector to keep system components working when the webhook fails."})
+		}
+	}
+
+	if webhook.SideEffects == "Unknown" || webhook.SideEffects == "Some" {
+		diagnostics = append(diagnostics, WebhookDiagnostic{DiagnosticSeverityWarning, fmt.Sprintf(
+			"Side effects are %s, so dry-run requests matching the webhook are rejected.", webhook.SideEffects)})
+	}
+
+	return diagnostics
+}
+
+// interceptsResource returns true when a rule matches the resource in the core API group. Wildcard
+// resource is only matched by wildcard rules.
+func interceptsResource(rules []WebhookRule, resource string) bool {
+	for _, rule := range rules {
+		if !contains(rule.APIGroups, "") && !contains(rule.APIGroups, "*") {
+			continue
+		}
+		for _, ruleResource := range rule.Resources {
+			name := strings.SplitN(ruleResource, "/", 2)[0]
+			if name == "*" || name == resource {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// describeRules returns human readable description of operations intercepted by rules, e.g.
+// "CREATE, UPDATE requests for deployments, pods".
+func describeRules(rules []WebhookRule) string {
+	operations := make(map[string]bool)
+	resources := make(map[string]bool)
+	for _, rule := range rules {
+		for _, operation := range rule.Operations {
+			operations[operation] = true
+		}
+		for _, resource := range rule.Resources {
+			resources[resource] = true
+		}
+	}
+	if len(operations) == 0 || operations["*"] {
+		operations = map[string]bool{"all": true}
+	}
+	if len(resources) == 0 || resources["*"] || resources["*/*"] {
+		resources = map[string]bool{"all resources": true}
+	}
+	return fmt.Sprintf("%s requests for %s", joinKeys(operations), joinKeys(resources))
+}
+
+func joinKeys(set map[string]bool) string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
+
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
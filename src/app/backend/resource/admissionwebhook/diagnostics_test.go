@@ -0,0 +1,74 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissionwebhook
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetDiagnostics(t *testing.T) {
+	podRules := []WebhookRule{{Operations: []string{"UPDATE", "CREATE"}, APIGroups: []string{""},
+		Resources: []string{"pods"}}}
+	allRules := []WebhookRule{{Operations: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}}}
+	service := &WebhookService{Namespace: "webhooks", Name: "policy"}
+	healthy := WebhookBackend{Status: BackendStatusHealthy, ReadyEndpoints: 1}
+	unavailable := WebhookBackend{Status: BackendStatusNoReadyEndpoints, Message: "No endpoints"}
+
+	cases := []struct {
+		webhook  Webhook
+		expected []WebhookDiagnostic
+	}{
+		{
+			Webhook{Rules: podRules, FailurePolicy: FailurePolicyFail, NamespaceSelector: "a=b", Service: service,
+				Backend: healthy},
+			[]WebhookDiagnostic{},
+		},
+		{
+			Webhook{Rules: podRules, FailurePolicy: FailurePolicyFail, NamespaceSelector: "a=b", Service: service,
+				Backend: unavailable},
+			[]WebhookDiagnostic{{DiagnosticSeverityCritical, "No endpoints. Failure policy is Fail, so " +
+				"CREATE, UPDATE requests for pods are rejected."}},
+		},
+		{
+			Webhook{Rules: allRules, FailurePolicy: FailurePolicyIgnore, Service: service, Backend: unavailable},
+			[]WebhookDiagnostic{{DiagnosticSeverityWarning, "No endpoints. Failure policy is Ignore, so " +
+				"all requests for all resources are admitted without calling the webhook."}},
+		},
+		{
+			Webhook{Rules: podRules, FailurePolicy: FailurePolicyFail, Service: service, Backend: healthy},
+			[]WebhookDiagnostic{{DiagnosticSeverityWarning, "Webhook intercepts pods in all namespaces " +
+				"including webhooks, where its backend runs. Lost backend pods can not be recreated until the " +
+				"webhook is removed."}},
+		},
+		{
+			Webhook{Rules: allRules, FailurePolicy: FailurePolicyFail, SideEffects: "Unknown",
+				Backend: WebhookBackend{Status: BackendStatusExternal}},
+			[]WebhookDiagnostic{
+				{DiagnosticSeverityWarning, "Webhook intercepts all resources in all namespaces including " +
+					"kube-system. Add a namespace selector to keep system components working when the webhook fails."},
+				{DiagnosticSeverityWarning, "Side effects are Unknown, so dry-run requests matching the " +
+					"webhook are rejected."},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		actual := getDiagnostics(c.webhook)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("getDiagnostics(%#v) == \ngot %#v, \nexpected %#v", c.webhook, actual, c.expected)
+		}
+	}
+}
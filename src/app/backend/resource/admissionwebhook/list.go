@@ -0,0 +1,124 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissionwebhook
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// AdmissionWebhookConfiguration is a validating or mutating webhook configuration with a summary of
+// its webhooks health.
+type AdmissionWebhookConfiguration struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	Type WebhookType `json:"type"`
+
+	WebhookCount int `json:"webhookCount"`
+	// Number of webhooks, that can not be called by the apiserver.
+	UnavailableWebhooks int `json:"unavailableWebhooks"`
+	// Number of critical diagnostics of all webhooks, i.e. problems rejecting requests right now.
+	CriticalIssues int `json:"criticalIssues"`
+}
+
+// AdmissionWebhookConfigurationList contains validating and mutating webhook configurations.
+type AdmissionWebhookConfigurationList struct {
+	ListMeta       api.ListMeta                    `json:"listMeta"`
+	Configurations []AdmissionWebhookConfiguration `json:"configurations"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetAdmissionWebhookConfigurationList returns validating and mutating webhook configurations of the
+// cluster.
+func GetAdmissionWebhookConfigurationList(client client.Interface, config *rest.Config,
+	dsQuery *dataselect.DataSelectQuery) (*AdmissionWebhookConfigurationList, error) {
+	logger.Info("Getting list of admission webhook configurations")
+
+	restClients, err := newRESTClients(config)
+	if err != nil {
+		return nil, err
+	}
+	return getAdmissionWebhookConfigurationList(client, restClients, dsQuery)
+}
+
+func getAdmissionWebhookConfigurationList(client client.Interface, restClients []RESTClient,
+	dsQuery *dataselect.DataSelectQuery) (*AdmissionWebhookConfigurationList, error) {
+	resolver := newBackendResolver(client)
+	configurations := make([]AdmissionWebhookConfiguration, 0)
+	nonCriticalErrors := make([]error, 0)
+
+	for _, webhookType := range []WebhookType{WebhookTypeValidating, WebhookTypeMutating} {
+		items, err := listWebhookConfigurations(restClients, webhookType)
+		errs, criticalError := errors.HandleError(err)
+		if criticalError != nil {
+			return nil, criticalError
+		}
+		nonCriticalErrors = append(nonCriticalErrors, errs...)
+
+		for i := range items {
+			webhooks, err := toWebhooks(items[i].Webhooks, resolver)
+			if err != nil {
+				return nil, err
+			}
+			configurations = append(configurations, toAdmissionWebhookConfiguration(&items[i], webhookType,
+				webhooks))
+		}
+	}
+
+	return toAdmissionWebhookConfigurationList(configurations, nonCriticalErrors, dsQuery), nil
+}
+
+func toAdmissionWebhookConfigurationList(configurations []AdmissionWebhookConfiguration,
+	nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *AdmissionWebhookConfigurationList {
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(configurations), dsQuery)
+	return &AdmissionWebhookConfigurationList{
+		ListMeta:       api.ListMeta{TotalItems: filteredTotal},
+		Configurations: fromCells(cells),
+		Errors:         nonCriticalErrors,
+	}
+}
+
+func toAdmissionWebhookConfiguration(configuration *webhookConfiguration, webhookType WebhookType,
+	webhooks []Webhook) AdmissionWebhookConfiguration {
+	var kind api.ResourceKind = api.ResourceKindValidatingWebhookConfiguration
+	if webhookType == WebhookTypeMutating {
+		kind = api.ResourceKindMutatingWebhookConfiguration
+	}
+
+	result := AdmissionWebhookConfiguration{
+		ObjectMeta:   api.NewObjectMeta(configuration.ObjectMeta),
+		TypeMeta:     api.NewTypeMeta(kind),
+		Type:         webhookType,
+		WebhookCount: len(webhooks),
+	}
+	for _, webhook := range webhooks {
+		if !webhook.Backend.IsAvailable() {
+			result.UnavailableWebhooks++
+		}
+		for _, diagnostic := range webhook.Diagnostics {
+			if diagnostic.Severity == DiagnosticSeverityCritical {
+				result.CriticalIssues++
+			}
+		}
+	}
+	return result
+}
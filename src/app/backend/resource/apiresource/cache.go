@@ -0,0 +1,60 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiresource
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/discovery"
+)
+
+// discoveryTTL is how long discovered resources are cached. New custom resource definitions show
+// up after this time or after an explicit refresh.
+const discoveryTTL = 5 * time.Minute
+
+// cache holds the last discovery result. Discovery documents are the same for all users of the
+// cluster, so a single entry is shared by all requests.
+var cache = &discoveryCache{now: time.Now}
+
+type discoveryCache struct {
+	mux     sync.Mutex
+	result  *APIResourceList
+	expires time.Time
+	// now returns current time. Replaced in tests.
+	now func() time.Time
+}
+
+// get returns cached discovery result or discovers resources, when the cache has expired or refresh
+// is requested. Errors are not cached.
+func (self *discoveryCache) get(client discovery.DiscoveryInterface, refresh bool) (*APIResourceList, error) {
+	self.mux.Lock()
+	result, expires := self.result, self.expires
+	self.mux.Unlock()
+	if !refresh && result != nil && self.now().Before(expires) {
+		return result, nil
+	}
+
+	result, err := discover(client, self.now())
+	if err != nil {
+		return nil, err
+	}
+
+	self.mux.Lock()
+	defer self.mux.Unlock()
+	self.result = result
+	self.expires = self.now().Add(discoveryTTL)
+	return result, nil
+}
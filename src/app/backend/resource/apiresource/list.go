@@ -0,0 +1,281 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apiresource lists API resources served by the cluster, similarly to
+// "kubectl api-resources".
+package apiresource
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// APIGroup is an API group served by the cluster.
+type APIGroup struct {
+	Name             string   `json:"name"`
+	PreferredVersion string   `json:"preferredVersion"`
+	Versions         []string `json:"versions"`
+}
+
+// APIResource is a resource served in a group version.
+type APIResource struct {
+	Name         string   `json:"name"`
+	SingularName string   `json:"singularName"`
+	ShortNames   []string `json:"shortNames"`
+	Kind         string   `json:"kind"`
+
+	Group        string `json:"group"`
+	Version      string `json:"version"`
+	GroupVersion string `json:"groupVersion"`
+	// Preferred is true when the version is the preferred version of the group.
+	Preferred bool `json:"preferred"`
+
+	Namespaced bool     `json:"namespaced"`
+	Verbs      []string `json:"verbs"`
+	// Subresources of the resource, e.g. status or scale.
+	Subresources []string `json:"subresources"`
+}
+
+// FailedGroupVersion is a group version, whose resources could not be discovered, usually because
+// its aggregated API server is unavailable.
+type FailedGroupVersion struct {
+	GroupVersion string `json:"groupVersion"`
+	Error        string `json:"error"`
+}
+
+// APIResourceList contains API groups and resources served by the cluster.
+type APIResourceList struct {
+	Groups    []APIGroup    `json:"groups"`
+	Resources []APIResource `json:"resources"`
+
+	FailedGroupVersions []FailedGroupVersion `json:"failedGroupVersions"`
+
+	// Time when resources were discovered. Discovery results are cached.
+	DiscoveredAt metaV1.Time `json:"discoveredAt"`
+}
+
+// APIResourceQuery filters listed resources. Zero value returns resources in preferred versions
+// of all groups.
+type APIResourceQuery struct {
+	// Group selects resources of a single group. Core group is selected by "core".
+	Group string
+	// Namespaced selects namespaced or cluster scoped resources, when set.
+	Namespaced *bool
+	// Verbs selects resources supporting all the verbs.
+	Verbs []string
+	// AllVersions includes resources of all versions, not only the preferred ones.
+	AllVersions bool
+	// Refresh skips the discovery cache.
+	Refresh bool
+}
+
+// GetAPIResourceList returns API groups and resources served by the cluster. Discovery results are
+// cached, because discovering all group versions takes a request per group version.
+func GetAPIResourceList(client discovery.DiscoveryInterface, query *APIResourceQuery) (*APIResourceList,
+	error) {
+	logger.Info("Getting list of API resources")
+
+	discovered, err := cache.get(client, query.Refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &APIResourceList{
+		Groups:              discovered.Groups,
+		Resources:           make([]APIResource, 0),
+		FailedGroupVersions: discovered.FailedGroupVersions,
+		DiscoveredAt:        discovered.DiscoveredAt,
+	}
+	for _, resource := range discovered.Resources {
+		if query.matches(resource) {
+			result.Resources = append(result.Resources, resource)
+		}
+	}
+	return result, nil
+}
+
+// FindAPIResource returns the resource served in the group version. Cached discovery results are
+// refreshed once, when the resource is not found, so that resources of new definitions are found.
+func FindAPIResource(client discovery.DiscoveryInterface, group, version, resource string) (*APIResource,
+	error) {
+	found, err := findAPIResource(client, func(item APIResource) bool {
+		return item.Group == group && item.Version == version && item.Name == resource
+	})
+	if err == nil && found == nil {
+		gv := schema.GroupVersion{Group: group, Version: version}
+		err = errorsK8s.NewBadRequest(fmt.Sprintf("resource %s is not served in %s", resource, gv))
+	}
+	return found, err
+}
+
+// FindAPIResourceByKind returns the resource serving the kind in the group version. See
+// FindAPIResource for more information.
+func FindAPIResourceByKind(client discovery.DiscoveryInterface, group, version, kind string) (*APIResource,
+	error) {
+	found, err := findAPIResource(client, func(item APIResource) bool {
+		return item.Group == group && item.Version == version && item.Kind == kind
+	})
+	if err == nil && found == nil {
+		gv := schema.GroupVersion{Group: group, Version: version}
+		err = errorsK8s.NewBadRequest(fmt.Sprintf("kind %s is not served in %s", kind, gv))
+	}
+	return found, err
+}
+
+// findAPIResource returns the first discovered resource matching the function or nil, if no
+// resource matches.
+func findAPIResource(client discovery.DiscoveryInterface, match func(APIResource) bool) (*APIResource, error) {
+	for _, refresh := range []bool{false, true} {
+		discovered, err := cache.get(client, refresh)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range discovered.Resources {
+			if match(item) {
+				return &item, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (query *APIResourceQuery) matches(resource APIResource) bool {
+	if !query.AllVersions && !resource.Preferred {
+		return false
+	}
+	if len(query.Group) > 0 && query.Group != resource.Group &&
+		!(query.Group == "core" && len(resource.Group) == 0) {
+		return false
+	}
+	if query.Namespaced != nil && *query.Namespaced != resource.Namespaced {
+		return false
+	}
+	for _, verb := range query.Verbs {
+		if !contains(resource.Verbs, verb) {
+			return false
+		}
+	}
+	return true
+}
+
+// discover returns all groups and resources served by the cluster. Group versions that cannot be
+// discovered are reported, but do not fail the discovery.
+func discover(client discovery.DiscoveryInterface, now time.Time) (*APIResourceList, error) {
+	groupList, err := client.ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &APIResourceList{
+		Groups:              make([]APIGroup, 0),
+		Resources:           make([]APIResource, 0),
+		FailedGroupVersions: make([]FailedGroupVersion, 0),
+		DiscoveredAt:        metaV1.NewTime(now),
+	}
+	if groupList == nil {
+		return result, nil
+	}
+
+	for _, group := range groupList.Groups {
+		apiGroup := APIGroup{
+			Name:             group.Name,
+			PreferredVersion: group.PreferredVersion.Version,
+			Versions:         make([]string, 0, len(group.Versions)),
+		}
+
+		for _, version := range group.Versions {
+			apiGroup.Versions = append(apiGroup.Versions, version.Version)
+
+			list, err := client.ServerResourcesForGroupVersion(version.GroupVersion)
+			if err != nil {
+				result.FailedGroupVersions = append(result.FailedGroupVersions,
+					FailedGroupVersion{GroupVersion: version.GroupVersion, Error: err.Error()})
+				continue
+			}
+			result.Resources = append(result.Resources, toAPIResources(group.Name, version.Version,
+				version.GroupVersion, version.Version == group.PreferredVersion.Version, list.APIResources)...)
+		}
+		result.Groups = append(result.Groups, apiGroup)
+	}
+
+	sort.SliceStable(result.Resources, func(i, j int) bool {
+		if result.Resources[i].Group != result.Resources[j].Group {
+			return result.Resources[i].Group < result.Resources[j].Group
+		}
+		return result.Resources[i].Name < result.Resources[j].Name
+	})
+	return result, nil
+}
+
+// toAPIResources converts discovered resources of a group version. Subresources, e.g.
+// deployments/scale, are listed on their parent resources.
+func toAPIResources(group, version, groupVersion string, preferred bool,
+	list []metaV1.APIResource) []APIResource {
+	resources := make([]APIResource, 0, len(list))
+	subresources := make(map[string][]string)
+
+	for _, resource := range list {
+		if parts := strings.SplitN(resource.Name, "/", 2); len(parts) == 2 {
+			subresources[parts[0]] = append(subresources[parts[0]], parts[1])
+			continue
+		}
+
+		apiResource := APIResource{
+			Name:         resource.Name,
+			SingularName: resource.SingularName,
+			ShortNames:   resource.ShortNames,
+			Kind:         resource.Kind,
+			Group:        group,
+			Version:      version,
+			GroupVersion: groupVersion,
+			Preferred:    preferred,
+			Namespaced:   resource.Namespaced,
+			Verbs:        resource.Verbs,
+		}
+		if apiResource.ShortNames == nil {
+			apiResource.ShortNames = make([]string, 0)
+		}
+		if apiResource.Verbs == nil {
+			apiResource.Verbs = make([]string, 0)
+		}
+		resources = append(resources, apiResource)
+	}
+
+	for i := range resources {
+		resources[i].Subresources = subresources[resources[i].Name]
+		if resources[i].Subresources == nil {
+			resources[i].Subresources = make([]string, 0)
+		}
+		sort.Strings(resources[i].Subresources)
+	}
+	return resources
+}
+
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiresource
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	core "k8s.io/client-go/testing"
+)
+
+// fakeDiscovery returns groups, which are not returned by the fake discovery client.
+type fakeDiscovery struct {
+	*fakediscovery.FakeDiscovery
+	groups *metaV1.APIGroupList
+}
+
+func (self *fakeDiscovery) ServerGroups() (*metaV1.APIGroupList, error) {
+	return self.groups, nil
+}
+
+func newFakeDiscovery() *fakeDiscovery {
+	return &fakeDiscovery{
+		FakeDiscovery: &fakediscovery.FakeDiscovery{Fake: &core.Fake{Resources: []*metaV1.APIResourceList{
+			{
+				GroupVersion: "v1",
+				APIResources: []metaV1.APIResource{
+					{Name: "pods", SingularName: "pod", Namespaced: true, Kind: "Pod",
+						Verbs: []string{"get", "list", "delete"}, ShortNames: []string{"po"}},
+					{Name: "pods/log", Namespaced: true, Kind: "Pod", Verbs: []string{"get"}},
+					{Name: "pods/exec", Namespaced: true, Kind: "PodExecOptions"},
+					{Name: "nodes", Namespaced: false, Kind: "Node", Verbs: []string{"get", "list"}},
+				},
+			},
+			{
+				GroupVersion: "apps/v1beta1",
+				APIResources: []metaV1.APIResource{
+					{Name: "deployments", Namespaced: true, Kind: "Deployment", Verbs: []string{"get", "list"}},
+				},
+			},
+			{
+				GroupVersion: "apps/v1",
+				APIResources: []metaV1.APIResource{
+					{Name: "deployments", Namespaced: true, Kind: "Deployment", Verbs: []string{"get", "list"}},
+				},
+			},
+		}}},
+		groups: &metaV1.APIGroupList{Groups: []metaV1.APIGroup{
+			{
+				Versions:         []metaV1.GroupVersionForDiscovery{{GroupVersion: "v1", Version: "v1"}},
+				PreferredVersion: metaV1.GroupVersionForDiscovery{GroupVersion: "v1", Version: "v1"},
+			},
+			{
+				Name: "apps",
+				Versions: []metaV1.GroupVersionForDiscovery{{GroupVersion: "apps/v1", Version: "v1"},
+					{GroupVersion: "apps/v1beta1", Version: "v1beta1"}},
+				PreferredVersion: metaV1.GroupVersionForDiscovery{GroupVersion: "apps/v1", Version: "v1"},
+			},
+			{
+				Name: "metrics.k8s.io",
+				Versions: []metaV1.GroupVersionForDiscovery{
+					{GroupVersion: "metrics.k8s.io/v1beta1", Version: "v1beta1"}},
+				PreferredVersion: metaV1.GroupVersionForDiscovery{
+					GroupVersion: "metrics.k8s.io/v1beta1", Version: "v1beta1"},
+			},
+		}},
+	}
+}
+
+func names(resources []APIResource) []string {
+	result := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		result = append(result, resource.GroupVersion+"/"+resource.Name)
+	}
+	return result
+}
+
+func TestGetAPIResourceList(t *testing.T) {
+	namespaced := true
+	cases := []struct {
+		query    *APIResourceQuery
+		expected []string
+	}{
+		{&APIResourceQuery{}, []string{"v1/nodes", "v1/pods", "apps/v1/deployments"}},
+		{&APIResourceQuery{AllVersions: true},
+			[]string{"v1/nodes", "v1/pods", "apps/v1/deployments", "apps/v1beta1/deployments"}},
+		{&APIResourceQuery{Group: "core", Namespaced: &namespaced}, []string{"v1/pods"}},
+		{&APIResourceQuery{Verbs: []string{"list", "delete"}}, []string{"v1/pods"}},
+		{&APIResourceQuery{Group: "apps"}, []string{"apps/v1/deployments"}},
+	}
+
+	for _, c := range cases {
+		cache = &discoveryCache{now: time.Now}
+		actual, err := GetAPIResourceList(newFakeDiscovery(), c.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(names(actual.Resources), c.expected) {
+			t.Errorf("GetAPIResourceList(%#v) == %v, expected %v", c.query, names(actual.Resources), c.expected)
+		}
+	}
+
+	cache = &discoveryCache{now: time.Now}
+	actual, _ := GetAPIResourceList(newFakeDiscovery(), &APIResourceQuery{Group: "core"})
+	pods := actual.Resources[1]
+	if !reflect.DeepEqual(pods.Subresources, []string{"exec", "log"}) || !pods.Preferred || pods.Group != "" {
+		t.Errorf("Got pods resource %#v", pods)
+	}
+	expectedFailed := []FailedGroupVersion{{GroupVersion: "metrics.k8s.io/v1beta1",
+		Error: `GroupVersion "metrics.k8s.io/v1beta1" not found`}}
+	if !reflect.DeepEqual(actual.FailedGroupVersions, expectedFailed) {
+		t.Errorf("Got failed group versions %#v, expected %#v", actual.FailedGroupVersions, expectedFailed)
+	}
+	if len(actual.Groups) != 3 || !reflect.DeepEqual(actual.Groups[1].Versions, []string{"v1", "v1beta1"}) {
+		t.Errorf("Got groups %#v", actual.Groups)
+	}
+}
+
+func TestDiscoveryCache(t *testing.T) {
+	now := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache = &discoveryCache{now: func() time.Time { return now }}
+	client := newFakeDiscovery()
+
+	first, _ := GetAPIResourceList(client, &APIResourceQuery{})
+	client.Resources = client.Resources[:1]
+
+	now = now.Add(time.Minute)
+	cached, _ := GetAPIResourceList(client, &APIResourceQuery{})
+	if len(cached.Resources) != len(first.Resources) || !cached.DiscoveredAt.Equal(first.DiscoveredAt) {
+		t.Errorf("Expected cached resources, got %v", names(cached.Resources))
+	}
+
+	refreshed, _ := GetAPIResourceList(client, &APIResourceQuery{Refresh: true})
+	if len(refreshed.Resources) != 2 {
+		t.Errorf("Expected refreshed resources, got %v", names(refreshed.Resources))
+	}
+
+	client.Resources = newFakeDiscovery().Resources
+	now = now.Add(discoveryTTL)
+	expired, _ := GetAPIResourceList(client, &APIResourceQuery{})
+	if len(expired.Resources) != 3 {
+		t.Errorf("Expected resources discovered after expiration, got %v", names(expired.Resources))
+	}
+}
+
+func TestFindAPIResource(t *testing.T) {
+	cache = &discoveryCache{now: time.Now}
+	client := newFakeDiscovery()
+	client.Resources = client.Resources[:1]
+	GetAPIResourceList(client, &APIResourceQuery{})
+
+	// Resources of the apps group are discovered after refresh of the cache.
+	client.Resources = newFakeDiscovery().Resources
+	actual, err := FindAPIResource(client, "apps", "v1beta1", "deployments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual.Kind != "Deployment" || actual.Preferred {
+		t.Errorf("Got %#v, expected apps/v1beta1 deployments", actual)
+	}
+
+	if _, err := FindAPIResource(client, "apps", "v1", "widgets"); err == nil {
+		t.Error("Expected error for resource, which is not served")
+	}
+
+	byKind, err := FindAPIResourceByKind(client, "", "v1", "Pod")
+	if err != nil || byKind.Name != "pods" {
+		t.Errorf("FindAPIResourceByKind returned %#v, %v, expected pods", byKind, err)
+	}
+	if _, err := FindAPIResourceByKind(client, "apps", "v1", "Pod"); err == nil {
+		t.Error("Expected error for kind, which is not served")
+	}
+}
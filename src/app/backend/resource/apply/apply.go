@@ -0,0 +1,439 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// FieldManager is the name of the field manager used for server-side apply.
+	FieldManager = "kubernetes-dashboard"
+
+	// applyPatchType is the content type of server-side apply patches.
+	applyPatchType = types.PatchType("application/apply-patch+yaml")
+)
+
+// Operation describes what happened, or would happen during dry-run, to the applied object.
+type Operation string
+
+const (
+	OperationCreated    Operation = "created"
+	OperationConfigured Operation = "configured"
+	OperationUnchanged  Operation = "unchanged"
+	OperationDeleted    Operation = "deleted"
+	OperationFailed     Operation = "failed"
+	// OperationPending is the result of dry-run of objects that can not be validated until objects
+	// applied earlier in the same bundle exist, i.e. objects in a new namespace or custom resources
+	// of a new custom resource definition.
+	OperationPending Operation = "pending"
+)
+
+// applyOrder are kinds applied before all other kinds, in this order, so that objects can depend on
+// namespaces, custom resource definitions and other objects they refer to from the same bundle.
+var applyOrder = []string{
+	"Namespace",
+	"CustomResourceDefinition",
+	"ResourceQuota",
+	"LimitRange",
+	"ServiceAccount",
+	"Secret",
+	"ConfigMap",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"Role",
+	"RoleBinding",
+	"Service",
+}
+
+// Kinds defined by custom resource definitions may not be served right after the definition is
+// created, so applying them is retried.
+var (
+	definedKindRetries       = 10
+	definedKindRetryInterval = time.Second
+)
+
+// ApplySpec is a specification of objects to apply.
+type ApplySpec struct {
+	// Multi-document YAML or JSON content.
+	Content string `json:"content"`
+
+	// Namespace used for namespaced objects that do not specify one.
+	Namespace string `json:"namespace"`
+
+	// When true, objects are only validated by the server and nothing is persisted.
+	DryRun bool `json:"dryRun"`
+
+	// When true, conflicts with other field managers are overridden.
+	Force bool `json:"force"`
+}
+
+// AppliedObject is a result of applying a single document.
+type AppliedObject struct {
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Name       string    `json:"name"`
+	Operation  Operation `json:"operation"`
+
+	// Unified diff between the live object and the object returned by the server.
+	Diff string `json:"diff,omitempty"`
+
+	// Error returned by the server, e.g. validation error or field manager conflict.
+	Error string `json:"error,omitempty"`
+}
+
+// ApplyResult is a result of applying all documents.
+type ApplyResult struct {
+	DryRun  bool            `json:"dryRun"`
+	Objects []AppliedObject `json:"objects"`
+}
+
+// Apply validates all documents with server-side dry-run and, unless dry-run was requested and
+// all documents are valid, applies them with server-side apply. Nothing is applied when any of the
+// documents fails validation. Documents are applied in applyOrder of their kinds, results are
+// returned in the order of documents.
+func Apply(client kubernetes.Interface, config *rest.Config, spec *ApplySpec) (*ApplyResult, error) {
+	objects, err := ParseDocuments(spec.Content)
+	if err != nil {
+		return nil, errorsK8s.NewBadRequest(err.Error())
+	}
+	if len(objects) == 0 {
+		return nil, errorsK8s.NewBadRequest("no objects to apply")
+	}
+
+	applier := &applier{client: client, config: config, force: spec.Force,
+		resources:         make(map[string]*metaV1.APIResourceList),
+		pendingNamespaces: make(map[string]bool),
+		definedKinds:      make(map[schema.GroupKind]bool)}
+	result := &ApplyResult{DryRun: true, Objects: make([]AppliedObject, len(objects))}
+
+	order := getApplyOrder(objects)
+	failed := false
+	for _, i := range order {
+		applied := applier.apply(objects[i], spec.Namespace, true)
+		failed = failed || applied.Operation == OperationFailed
+		result.Objects[i] = applied
+	}
+
+	if spec.DryRun || failed {
+		return result, nil
+	}
+
+	logger.Infof("Applying %d objects", len(objects))
+	result.DryRun = false
+	for _, i := range order {
+		result.Objects[i] = applier.apply(objects[i], spec.Namespace, false)
+	}
+
+	return result, nil
+}
+
+// getApplyOrder returns indexes of objects sorted by applyOrder of their kinds. Objects of the
+// same kind keep the order of documents.
+func getApplyOrder(objects []*unstructured.Unstructured) []int {
+	order := make([]int, len(objects))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return getKindPriority(objects[order[i]].GetKind()) < getKindPriority(objects[order[j]].GetKind())
+	})
+	return order
+}
+
+func getKindPriority(kind string) int {
+	for i, ordered := range applyOrder {
+		if ordered == kind {
+			return i
+		}
+	}
+	return len(applyOrder)
+}
+
+// ParseDocuments splits multi-document YAML or JSON content into objects. Every object has to have
+// a name.
+func ParseDocuments(content string) ([]*unstructured.Unstructured, error) {
+	reader := yaml.NewYAMLReader(bufio.NewReader(strings.NewReader(content)))
+	objects := make([]*unstructured.Unstructured, 0)
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Skip empty documents, e.g. comments only.
+		if isEmptyDocument(document) {
+			continue
+		}
+
+		raw, err := yaml.ToJSON(document)
+		if err != nil {
+			return nil, err
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(raw); err != nil {
+			return nil, err
+		}
+
+		if len(obj.GetName()) == 0 {
+			return nil, fmt.Errorf("document %d: metadata.name is required", len(objects)+1)
+		}
+		objects = append(objects, obj)
+	}
+}
+
+// isEmptyDocument returns true when YAML document contains only whitespace and comments.
+func isEmptyDocument(document []byte) bool {
+	for _, line := range strings.Split(string(document), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) > 0 && !strings.HasPrefix(line, "#") && line != "---" {
+			return false
+		}
+	}
+	return true
+}
+
+// applier applies objects of arbitrary kinds, resolving their resources with discovery.
+type applier struct {
+	client kubernetes.Interface
+	config *rest.Config
+	force  bool
+
+	// Discovered resources by group version.
+	resources map[string]*metaV1.APIResourceList
+
+	// Namespaces created by the applied bundle, which do not exist during dry-run.
+	pendingNamespaces map[string]bool
+	// Kinds defined by custom resource definitions of the applied bundle.
+	definedKinds map[schema.GroupKind]bool
+}
+
+func (self *applier) apply(obj *unstructured.Unstructured, namespace string, dryRun bool) AppliedObject {
+	result := AppliedObject{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+
+	err := self.doApply(obj, namespace, dryRun, &result)
+	retry := !dryRun && self.definedKinds[obj.GroupVersionKind().GroupKind()]
+	for attempt := 0; err != nil && retry && attempt < definedKindRetries; attempt++ {
+		// Discovery of the kind is cached before its definition is created.
+		delete(self.resources, obj.GetAPIVersion())
+		time.Sleep(definedKindRetryInterval)
+		err = self.doApply(obj, namespace, dryRun, &result)
+	}
+
+	switch {
+	case err != nil && dryRun && self.dependsOnBundle(obj, err):
+		result.Operation = OperationPending
+	case err != nil:
+		result.Operation = OperationFailed
+		result.Error = err.Error()
+	case dryRun:
+		self.recordPending(obj, result.Operation)
+	}
+	return result
+}
+
+// recordPending records namespaces and kinds the dry-run of the object would create.
+func (self *applier) recordPending(obj *unstructured.Unstructured, operation Operation) {
+	switch obj.GetKind() {
+	case "Namespace":
+		if operation == OperationCreated {
+			self.pendingNamespaces[obj.GetName()] = true
+		}
+	case "CustomResourceDefinition":
+		spec, _ := obj.Object["spec"].(map[string]interface{})
+		names, _ := spec["names"].(map[string]interface{})
+		group, _ := spec["group"].(string)
+		kind, _ := names["kind"].(string)
+		self.definedKinds[schema.GroupKind{Group: group, Kind: kind}] = true
+	}
+}
+
+// dependsOnBundle returns true if dry-run of the object failed because it depends on a namespace or
+// a custom resource definition that is created by the bundle.
+func (self *applier) dependsOnBundle(obj *unstructured.Unstructured, err error) bool {
+	if self.definedKinds[obj.GroupVersionKind().GroupKind()] {
+		return true
+	}
+	return errorsK8s.IsNotFound(err) && self.pendingNamespaces[obj.GetNamespace()]
+}
+
+func (self *applier) doApply(obj *unstructured.Unstructured, namespace string, dryRun bool,
+	result *AppliedObject) error {
+	client, resource, live, err := self.getLive(obj, namespace)
+	if err != nil {
+		return err
+	}
+	result.Namespace = obj.GetNamespace()
+
+	applied, err := self.patch(client, resource, obj, dryRun)
+	if err != nil {
+		return err
+	}
+
+	result.Diff, err = getDiff(live, applied)
+	if err != nil {
+		return err
+	}
+	result.Operation = getOperation(live, result.Diff)
+	return nil
+}
+
+// patch applies the object with server-side apply and returns the object returned by the server.
+func (self *applier) patch(client *rest.RESTClient, resource *metaV1.APIResource,
+	obj *unstructured.Unstructured, dryRun bool) (*unstructured.Unstructured, error) {
+	body, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	request := client.Patch(applyPatchType).
+		NamespaceIfScoped(obj.GetNamespace(), resource.Namespaced).
+		Resource(resource.Name).
+		Name(obj.GetName()).
+		Param("fieldManager", FieldManager).
+		Body(body)
+	if self.force {
+		request = request.Param("force", "true")
+	}
+	if dryRun {
+		request = request.Param("dryRun", "All")
+	}
+
+	applied := &unstructured.Unstructured{}
+	if err := request.Do().Into(applied); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// getLive resolves resource of the object, defaults its namespace and returns the live object, or nil
+// when it does not exist yet, together with the client used to get it.
+func (self *applier) getLive(obj *unstructured.Unstructured, namespace string) (
+	*rest.RESTClient, *metaV1.APIResource, *unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	resource, err := self.getResource(gv, obj.GetKind())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if !resource.Namespaced {
+		obj.SetNamespace("")
+	} else if len(obj.GetNamespace()) == 0 {
+		obj.SetNamespace(namespace)
+	}
+
+	client, err := NewRESTClient(self.config, gv)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	live := &unstructured.Unstructured{}
+	err = client.Get().
+		NamespaceIfScoped(obj.GetNamespace(), resource.Namespaced).
+		Resource(resource.Name).
+		Name(obj.GetName()).
+		Do().
+		Into(live)
+	if errorsK8s.IsNotFound(err) {
+		return client, resource, nil, nil
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return client, resource, live, nil
+}
+
+// getResource finds resource serving the given kind in the group version.
+func (self *applier) getResource(gv schema.GroupVersion, kind string) (*metaV1.APIResource, error) {
+	list, ok := self.resources[gv.String()]
+	if !ok {
+		var err error
+		list, err = self.client.Discovery().ServerResourcesForGroupVersion(gv.String())
+		if err != nil {
+			return nil, err
+		}
+		self.resources[gv.String()] = list
+	}
+
+	for i := range list.APIResources {
+		resource := &list.APIResources[i]
+		// Skip subresources, e.g. deployments/scale.
+		if resource.Kind == kind && !strings.Contains(resource.Name, "/") {
+			return resource, nil
+		}
+	}
+	return nil, errorsK8s.NewBadRequest(fmt.Sprintf("kind %s is not served in %s", kind, gv))
+}
+
+// NewRESTClient creates REST client decoding objects of the group version to unstructured objects.
+func NewRESTClient(config *rest.Config, gv schema.GroupVersion) (*rest.RESTClient, error) {
+	cfg := *config
+	cfg.ContentConfig = dynamic.ContentConfig()
+	cfg.GroupVersion = &gv
+	if len(gv.Group) == 0 {
+		cfg.APIPath = "/api"
+	} else {
+		cfg.APIPath = "/apis"
+	}
+	if len(cfg.UserAgent) == 0 {
+		cfg.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+	return rest.RESTClientFor(&cfg)
+}
+
+// getOperation returns operation performed on the live object.
+func getOperation(live *unstructured.Unstructured, diff string) Operation {
+	switch {
+	case live == nil:
+		return OperationCreated
+	case len(bytes.TrimSpace([]byte(diff))) == 0:
+		return OperationUnchanged
+	default:
+		return OperationConfigured
+	}
+}
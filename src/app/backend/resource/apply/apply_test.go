@@ -0,0 +1,202 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestParseDocuments(t *testing.T) {
+	cases := []struct {
+		content  string
+		expected []string
+		err      bool
+	}{
+		{"", []string{}, false},
+		{
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\n# comment only\n---\n" +
+				"apiVersion: apps/v1beta1\nkind: Deployment\nmetadata:\n  name: b\n",
+			[]string{"ConfigMap/a", "Deployment/b"}, false,
+		},
+		{
+			`{"apiVersion": "v1", "kind": "Service", "metadata": {"name": "c"}}`,
+			[]string{"Service/c"}, false,
+		},
+		{"apiVersion: v1\nkind: ConfigMap\n", nil, true},
+		{"kind: [", nil, true},
+	}
+
+	for _, c := range cases {
+		objects, err := ParseDocuments(c.content)
+		if c.err {
+			if err == nil {
+				t.Errorf("ParseDocuments(%#v) returns no error, expected one", c.content)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseDocuments(%#v) returns error %s", c.content, err)
+			continue
+		}
+
+		actual := make([]string, 0)
+		for _, obj := range objects {
+			actual = append(actual, obj.GetKind()+"/"+obj.GetName())
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("ParseDocuments(%#v) returns %#v, expected %#v", c.content, actual, c.expected)
+		}
+	}
+}
+
+func TestGetDiff(t *testing.T) {
+	newConfigMap := func(value, resourceVersion string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":            "config",
+				"resourceVersion": resourceVersion,
+			},
+			"data": map[string]interface{}{"key": value},
+		}}
+	}
+
+	cases := []struct {
+		live, applied *unstructured.Unstructured
+		operation     Operation
+		contains      []string
+	}{
+		{nil, newConfigMap("a", "1"), OperationCreated, []string{"+data:", "+  key: a"}},
+		{newConfigMap("a", "1"), newConfigMap("a", "2"), OperationUnchanged, nil},
+		{newConfigMap("a", "1"), newConfigMap("b", "2"), OperationConfigured,
+			[]string{"-  key: a", "+  key: b"}},
+	}
+
+	for _, c := range cases {
+		diff, err := getDiff(c.live, c.applied)
+		if err != nil {
+			t.Errorf("getDiff(%#v, %#v) returns error %s", c.live, c.applied, err)
+			continue
+		}
+		for _, line := range c.contains {
+			if !strings.Contains(diff, line) {
+				t.Errorf("getDiff(%#v, %#v) returns %#v, expected it to contain %#v", c.live,
+					c.applied, diff, line)
+			}
+		}
+		if operation := getOperation(c.live, diff); operation != c.operation {
+			t.Errorf("getOperation(%#v, %#v) returns %#v, expected %#v", c.live, diff, operation,
+				c.operation)
+		}
+	}
+}
+
+func TestGetApplyOrder(t *testing.T) {
+	objects, _ := ParseDocuments("apiVersion: apps/v1beta1\nkind: Deployment\nmetadata:\n  name: a\n---\n" +
+		"apiVersion: v1\nkind: Service\nmetadata:\n  name: b\n---\n" +
+		"apiVersion: v1\nkind: Namespace\nmetadata:\n  name: c\n---\n" +
+		"apiVersion: apps/v1beta1\nkind: Deployment\nmetadata:\n  name: d\n")
+
+	expected := []int{2, 1, 0, 3}
+	if actual := getApplyOrder(objects); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("getApplyOrder() returns %v, expected %v", actual, expected)
+	}
+}
+
+func TestApplyNamespaceWithDeployment(t *testing.T) {
+	namespaceCreated := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		dryRun := r.URL.Query().Get("dryRun") != ""
+		notFound := func(resource, name string) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound", `+
+				`"code": 404, "details": {"kind": "%s", "name": "%s"}}`, resource, name)
+		}
+
+		switch {
+		case r.URL.Path == "/api/v1":
+			fmt.Fprint(w, `{"kind": "APIResourceList", "groupVersion": "v1", "resources": [`+
+				`{"name": "namespaces", "namespaced": false, "kind": "Namespace"}]}`)
+		case r.URL.Path == "/apis/apps/v1beta1":
+			fmt.Fprint(w, `{"kind": "APIResourceList", "groupVersion": "apps/v1beta1", "resources": [`+
+				`{"name": "deployments", "namespaced": true, "kind": "Deployment"}]}`)
+		case r.URL.Path == "/api/v1/namespaces/prod" && r.Method == "GET":
+			if !namespaceCreated {
+				notFound("namespaces", "prod")
+				return
+			}
+			fmt.Fprint(w, `{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "prod"}}`)
+		case r.URL.Path == "/api/v1/namespaces/prod" && r.Method == "PATCH":
+			namespaceCreated = namespaceCreated || !dryRun
+			fmt.Fprint(w, `{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "prod"}}`)
+		case r.URL.Path == "/apis/apps/v1beta1/namespaces/prod/deployments/web" && r.Method == "GET":
+			notFound("deployments", "web")
+		case r.URL.Path == "/apis/apps/v1beta1/namespaces/prod/deployments/web" && r.Method == "PATCH":
+			if !namespaceCreated {
+				notFound("namespaces", "prod")
+				return
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			w.Write(body)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	config := &rest.Config{Host: server.URL}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := "apiVersion: apps/v1beta1\nkind: Deployment\nmetadata:\n  name: web\n  namespace: prod\n---\n" +
+		"apiVersion: v1\nkind: Namespace\nmetadata:\n  name: prod\n"
+
+	result, err := Apply(client, config, &ApplySpec{Content: content, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	operations := []Operation{result.Objects[0].Operation, result.Objects[1].Operation}
+	if expected := []Operation{OperationPending, OperationCreated}; !reflect.DeepEqual(operations, expected) {
+		t.Errorf("Dry-run of namespace with deployment returns %v, expected %v", result.Objects, expected)
+	}
+	if namespaceCreated {
+		t.Error("Dry-run of namespace with deployment created the namespace")
+	}
+
+	result, err = Apply(client, config, &ApplySpec{Content: content})
+	if err != nil {
+		t.Fatal(err)
+	}
+	operations = []Operation{result.Objects[0].Operation, result.Objects[1].Operation}
+	if expected := []Operation{OperationCreated, OperationCreated}; !reflect.DeepEqual(operations, expected) ||
+		result.DryRun {
+		t.Errorf("Apply of namespace with deployment returns %v, expected %v", result.Objects, expected)
+	}
+}
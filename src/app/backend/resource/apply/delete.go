@@ -0,0 +1,100 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Delete deletes objects described by multi-document YAML or JSON content, e.g. a manifest of
+// a Helm release. Dependents of deleted objects are garbage collected in the background. Objects
+// that do not exist are reported as unchanged, objects that failed to be deleted do not stop
+// deletion of the others.
+func Delete(client kubernetes.Interface, config *rest.Config, content, namespace string) (
+	*ApplyResult, error) {
+	objects, err := ParseDocuments(content)
+	if err != nil {
+		return nil, errorsK8s.NewBadRequest(err.Error())
+	}
+
+	logger.Infof("Deleting %d objects", len(objects))
+	applier := &applier{client: client, config: config,
+		resources: make(map[string]*metaV1.APIResourceList)}
+	result := &ApplyResult{Objects: make([]AppliedObject, 0, len(objects))}
+	for _, obj := range objects {
+		result.Objects = append(result.Objects, applier.delete(obj, namespace))
+	}
+
+	return result, nil
+}
+
+func (self *applier) delete(obj *unstructured.Unstructured, namespace string) AppliedObject {
+	result := AppliedObject{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Operation:  OperationDeleted,
+	}
+
+	err := self.doDelete(obj, namespace, &result)
+	if errorsK8s.IsNotFound(err) {
+		result.Operation = OperationUnchanged
+	} else if err != nil {
+		result.Operation = OperationFailed
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (self *applier) doDelete(obj *unstructured.Unstructured, namespace string,
+	result *AppliedObject) error {
+	gv, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+	if err != nil {
+		return err
+	}
+
+	resource, err := self.getResource(gv, obj.GetKind())
+	if err != nil {
+		return err
+	}
+
+	if !resource.Namespaced {
+		obj.SetNamespace("")
+	} else if len(obj.GetNamespace()) == 0 {
+		obj.SetNamespace(namespace)
+	}
+	result.Namespace = obj.GetNamespace()
+
+	client, err := NewRESTClient(self.config, gv)
+	if err != nil {
+		return err
+	}
+
+	propagation := metaV1.DeletePropagationBackground
+	return client.Delete().
+		NamespaceIfScoped(obj.GetNamespace(), resource.Namespaced).
+		Resource(resource.Name).
+		Name(obj.GetName()).
+		Body(&metaV1.DeleteOptions{PropagationPolicy: &propagation}).
+		Do().
+		Error()
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"github.com/ghodss/yaml"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Metadata fields maintained by the server, which are left out of the diff.
+var ignoredMetadataFields = []string{"managedFields", "resourceVersion", "generation", "uid",
+	"selfLink", "creationTimestamp"}
+
+// getDiff returns unified diff of YAML representations of the live object and the object returned
+// by the server. Live object is nil, when it does not exist yet.
+func getDiff(live, applied *unstructured.Unstructured) (string, error) {
+	liveYAML, err := toDiffableYAML(live)
+	if err != nil {
+		return "", err
+	}
+
+	appliedYAML, err := toDiffableYAML(applied)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(liveYAML),
+		B:        difflib.SplitLines(appliedYAML),
+		FromFile: "live",
+		ToFile:   "applied",
+		Context:  3,
+	})
+}
+
+// toDiffableYAML serializes the object to YAML without status and server maintained metadata.
+func toDiffableYAML(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+
+	out, err := yaml.Marshal(diffableContent(obj))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// diffableContent returns content of the object without status and server maintained metadata.
+func diffableContent(obj *unstructured.Unstructured) map[string]interface{} {
+	content := make(map[string]interface{}, len(obj.Object))
+	for key, value := range obj.Object {
+		if key != "status" {
+			content[key] = value
+		}
+	}
+
+	if metadata, ok := obj.Object["metadata"].(map[string]interface{}); ok {
+		diffable := make(map[string]interface{}, len(metadata))
+		for key, value := range metadata {
+			diffable[key] = value
+		}
+		for _, field := range ignoredMetadataFields {
+			delete(diffable, field)
+		}
+		content["metadata"] = diffable
+	}
+	return content
+}
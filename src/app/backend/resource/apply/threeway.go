@@ -0,0 +1,466 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// LastAppliedAnnotation is the annotation in which client-side apply stores the applied manifest.
+const LastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// FieldChange describes how a field of the live object would change.
+type FieldChange string
+
+const (
+	FieldAdded   FieldChange = "added"
+	FieldChanged FieldChange = "changed"
+	FieldRemoved FieldChange = "removed"
+)
+
+// DiffSpec is a specification of manifests to compare with live objects.
+type DiffSpec struct {
+	// Multi-document YAML or JSON content.
+	Content string `json:"content"`
+
+	// Namespace used for namespaced objects that do not specify one.
+	Namespace string `json:"namespace"`
+}
+
+// FieldOwner is a field manager owning a field of the live object.
+type FieldOwner struct {
+	Manager   string `json:"manager"`
+	Operation string `json:"operation"`
+}
+
+// FieldDiff is a change of a single field.
+type FieldDiff struct {
+	// Path of the field, e.g. spec.template.spec.containers[name=nginx].image.
+	Path   string      `json:"path"`
+	Change FieldChange `json:"change"`
+
+	Live        interface{} `json:"live,omitempty"`
+	Manifest    interface{} `json:"manifest,omitempty"`
+	LastApplied interface{} `json:"lastApplied,omitempty"`
+
+	// Field managers owning the field in the live object.
+	Owners []FieldOwner `json:"owners"`
+
+	// True when the field would get a different value and it is owned by another field manager.
+	Conflict bool `json:"conflict"`
+}
+
+// ObjectDiff is a three-way diff of a single document: the last applied configuration, the live
+// object and the manifest.
+type ObjectDiff struct {
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Name       string    `json:"name"`
+	Operation  Operation `json:"operation"`
+
+	Fields    []FieldDiff `json:"fields"`
+	Conflicts int         `json:"conflicts"`
+
+	// Error returned by the server during dry-run, e.g. validation error or field manager conflict.
+	Error string `json:"error,omitempty"`
+}
+
+// DiffResult is a result of comparing all documents with live objects.
+type DiffResult struct {
+	Objects []ObjectDiff `json:"objects"`
+}
+
+// Diff compares each document with its live object and the configuration last applied to it. The
+// last applied configuration is read from the client-side apply annotation or, when it is missing,
+// from the fields owned by the dashboard field manager. Documents are also validated with
+// server-side dry-run, nothing is persisted.
+func Diff(client kubernetes.Interface, config *rest.Config, spec *DiffSpec) (*DiffResult, error) {
+	objects, err := ParseDocuments(spec.Content)
+	if err != nil {
+		return nil, errorsK8s.NewBadRequest(err.Error())
+	}
+	if len(objects) == 0 {
+		return nil, errorsK8s.NewBadRequest("no objects to diff")
+	}
+
+	applier := &applier{client: client, config: config,
+		resources: make(map[string]*metaV1.APIResourceList)}
+	result := &DiffResult{Objects: make([]ObjectDiff, 0, len(objects))}
+	for _, obj := range objects {
+		result.Objects = append(result.Objects, applier.diff(obj, spec.Namespace))
+	}
+	return result, nil
+}
+
+func (self *applier) diff(obj *unstructured.Unstructured, namespace string) ObjectDiff {
+	result := ObjectDiff{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Fields:     make([]FieldDiff, 0),
+	}
+
+	client, resource, live, err := self.getLive(obj, namespace)
+	if err != nil {
+		result.Operation = OperationFailed
+		result.Error = err.Error()
+		return result
+	}
+	result.Namespace = obj.GetNamespace()
+
+	result.Fields = getFieldDiffs(live, obj)
+	for _, field := range result.Fields {
+		if field.Conflict {
+			result.Conflicts++
+		}
+	}
+
+	switch {
+	case live == nil:
+		result.Operation = OperationCreated
+	case len(result.Fields) == 0:
+		result.Operation = OperationUnchanged
+	default:
+		result.Operation = OperationConfigured
+	}
+
+	if _, err := self.patch(client, resource, obj, true); err != nil {
+		// Conflicts are already reported per field, the object can still be applied with force.
+		if !errorsK8s.IsConflict(err) {
+			result.Operation = OperationFailed
+		}
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// managedField is a field manager together with the fields it owns, in FieldsV1 format.
+type managedField struct {
+	owner  FieldOwner
+	fields map[string]interface{}
+}
+
+// fieldDiffer walks the manifest together with the live object and the last applied configuration.
+type fieldDiffer struct {
+	diffs []FieldDiff
+}
+
+// getFieldDiffs returns changes of fields, which the manifest would make to the live object. Fields
+// set by the server or by other clients, which are not in the manifest, are not reported.
+func getFieldDiffs(live, manifest *unstructured.Unstructured) []FieldDiff {
+	differ := &fieldDiffer{diffs: make([]FieldDiff, 0)}
+	manifestContent := diffableContent(manifest)
+	if live == nil {
+		differ.walk("", manifestContent, map[string]interface{}{}, true, nil, false, nil)
+		return differ.diffs
+	}
+
+	managed := getManagedFields(live)
+	liveContent := diffableContent(live)
+	lastApplied, ok := getLastApplied(live)
+	if !ok {
+		lastApplied, ok = getLastAppliedFromManagedFields(liveContent, managed)
+	}
+	differ.walk("", manifestContent, liveContent, true, lastApplied, ok, managed)
+	return differ.diffs
+}
+
+// walk compares the manifest value with the live and last applied values at the given path. Nested
+// maps and lists of maps are compared field by field, so that fields defaulted by the server are not
+// reported as changed.
+func (self *fieldDiffer) walk(path string, manifest, live interface{}, liveFound bool,
+	lastApplied interface{}, lastAppliedFound bool, managed []managedField) {
+	if path == joinPath("metadata.annotations", LastAppliedAnnotation) {
+		return
+	}
+
+	if !liveFound {
+		self.add(path, FieldAdded, nil, manifest, lastApplied, managed)
+		return
+	}
+
+	manifestMap, isManifestMap := manifest.(map[string]interface{})
+	liveMap, isLiveMap := live.(map[string]interface{})
+	if isManifestMap && isLiveMap {
+		lastAppliedMap, _ := lastApplied.(map[string]interface{})
+		for _, key := range sortedKeys(manifestMap) {
+			liveValue, liveOk := liveMap[key]
+			lastAppliedValue, lastAppliedOk := lastAppliedMap[key]
+			self.walk(joinPath(path, key), manifestMap[key], liveValue, liveOk, lastAppliedValue,
+				lastAppliedOk, descendKey(managed, key))
+		}
+
+		// Fields applied previously and left out of the manifest are removed from the live object.
+		for _, key := range sortedKeys(lastAppliedMap) {
+			if _, ok := manifestMap[key]; ok {
+				continue
+			}
+			if liveValue, ok := liveMap[key]; ok {
+				self.add(joinPath(path, key), FieldRemoved, liveValue, nil, lastAppliedMap[key],
+					descendKey(managed, key))
+			}
+		}
+		return
+	}
+
+	manifestList, isManifestList := manifest.([]interface{})
+	liveList, isLiveList := live.([]interface{})
+	if isManifestList && isLiveList && isNamedList(manifestList) && isNamedList(liveList) {
+		lastAppliedList, _ := lastApplied.([]interface{})
+		for _, item := range manifestList {
+			name := item.(map[string]interface{})["name"]
+			liveItem, liveOk := findNamed(liveList, name)
+			lastAppliedItem, lastAppliedOk := findNamed(lastAppliedList, name)
+			self.walk(fmt.Sprintf("%s[name=%v]", path, name), item, liveItem, liveOk, lastAppliedItem,
+				lastAppliedOk, descendNamed(managed, name))
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(manifest, live) {
+		self.add(path, FieldChanged, live, manifest, lastApplied, managed)
+	}
+}
+
+func (self *fieldDiffer) add(path string, change FieldChange, live, manifest, lastApplied interface{},
+	managed []managedField) {
+	diff := FieldDiff{
+		Path:        path,
+		Change:      change,
+		Live:        live,
+		Manifest:    manifest,
+		LastApplied: lastApplied,
+		Owners:      make([]FieldOwner, 0),
+	}
+
+	for _, field := range managed {
+		if field.fields == nil {
+			continue
+		}
+		diff.Owners = append(diff.Owners, field.owner)
+		if change == FieldChanged && field.owner.Manager != FieldManager {
+			diff.Conflict = true
+		}
+	}
+	self.diffs = append(self.diffs, diff)
+}
+
+// getLastApplied returns configuration stored by client-side apply in the live object.
+func getLastApplied(live *unstructured.Unstructured) (map[string]interface{}, bool) {
+	annotation, ok := live.GetAnnotations()[LastAppliedAnnotation]
+	if !ok {
+		return nil, false
+	}
+
+	lastApplied := &unstructured.Unstructured{}
+	if err := lastApplied.UnmarshalJSON([]byte(annotation)); err != nil {
+		return nil, false
+	}
+	return diffableContent(lastApplied), true
+}
+
+// getLastAppliedFromManagedFields returns the part of the live object owned by the dashboard field
+// manager through server-side apply.
+func getLastAppliedFromManagedFields(live map[string]interface{}, managed []managedField) (
+	map[string]interface{}, bool) {
+	for _, field := range managed {
+		if field.owner.Manager == FieldManager && field.owner.Operation == "Apply" {
+			lastApplied, _ := project(live, field.fields).(map[string]interface{})
+			return lastApplied, lastApplied != nil
+		}
+	}
+	return nil, false
+}
+
+// project returns the part of the value described by the FieldsV1 set. Items of keyed lists are
+// projected by name, other lists are taken as a whole.
+func project(value interface{}, fields map[string]interface{}) interface{} {
+	if list, ok := value.([]interface{}); ok && isNamedList(list) {
+		result := make([]interface{}, 0)
+		for _, item := range list {
+			itemFields, ok := namedItemFields(fields, item.(map[string]interface{})["name"]).(map[string]interface{})
+			if ok {
+				result = append(result, project(item, itemFields))
+			}
+		}
+		return result
+	}
+
+	valueMap, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	result := make(map[string]interface{})
+	for key, child := range fields {
+		if !strings.HasPrefix(key, "f:") {
+			continue
+		}
+		name := strings.TrimPrefix(key, "f:")
+		childValue, ok := valueMap[name]
+		if !ok {
+			continue
+		}
+		childFields, _ := child.(map[string]interface{})
+		if hasFieldKeys(childFields) {
+			result[name] = project(childValue, childFields)
+		} else {
+			result[name] = childValue
+		}
+	}
+	return result
+}
+
+// getManagedFields returns field managers of the live object. Managed fields are read from the
+// unstructured object, as they are not part of the vendored object meta.
+func getManagedFields(live *unstructured.Unstructured) []managedField {
+	metadata, _ := live.Object["metadata"].(map[string]interface{})
+	entries, _ := metadata["managedFields"].([]interface{})
+	result := make([]managedField, 0, len(entries))
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		fields, _ := entryMap["fieldsV1"].(map[string]interface{})
+		if fields == nil {
+			continue
+		}
+		manager, _ := entryMap["manager"].(string)
+		operation, _ := entryMap["operation"].(string)
+		result = append(result, managedField{
+			owner:  FieldOwner{Manager: manager, Operation: operation},
+			fields: fields,
+		})
+	}
+	return result
+}
+
+// descendKey returns fields owned by each manager under the map key. Managers owning the whole
+// value atomically keep owning it.
+func descendKey(managed []managedField, key string) []managedField {
+	return descend(managed, func(fields map[string]interface{}) interface{} {
+		return fields["f:"+key]
+	})
+}
+
+// descendNamed returns fields owned by each manager under the list item with the given name.
+func descendNamed(managed []managedField, name interface{}) []managedField {
+	return descend(managed, func(fields map[string]interface{}) interface{} {
+		return namedItemFields(fields, name)
+	})
+}
+
+// namedItemFields returns FieldsV1 set of the keyed list item with the given name.
+func namedItemFields(fields map[string]interface{}, name interface{}) interface{} {
+	for key, child := range fields {
+		if !strings.HasPrefix(key, "k:") {
+			continue
+		}
+		itemKey := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(key, "k:")), &itemKey); err != nil {
+			continue
+		}
+		if reflect.DeepEqual(itemKey["name"], name) {
+			return child
+		}
+	}
+	return nil
+}
+
+func descend(managed []managedField, child func(map[string]interface{}) interface{}) []managedField {
+	result := make([]managedField, len(managed))
+	for i, field := range managed {
+		result[i].owner = field.owner
+		if field.fields == nil {
+			continue
+		}
+		// Empty set owns the whole value, e.g. an atomic map.
+		if len(field.fields) == 0 {
+			result[i].fields = field.fields
+			continue
+		}
+		if fields, ok := child(field.fields).(map[string]interface{}); ok {
+			result[i].fields = fields
+		}
+	}
+	return result
+}
+
+// hasFieldKeys returns true when the FieldsV1 set has nested fields.
+func hasFieldKeys(fields map[string]interface{}) bool {
+	for key := range fields {
+		if key != "." {
+			return true
+		}
+	}
+	return false
+}
+
+// isNamedList returns true when all items of the list are maps with a name.
+func isNamedList(list []interface{}) bool {
+	for _, item := range list {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := itemMap["name"]; !ok {
+			return false
+		}
+	}
+	return len(list) > 0
+}
+
+func findNamed(list []interface{}, name interface{}) (interface{}, bool) {
+	for _, item := range list {
+		if itemMap, ok := item.(map[string]interface{}); ok && reflect.DeepEqual(itemMap["name"], name) {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+// joinPath appends map key to the path. Keys with dots or slashes, e.g. annotations, are quoted.
+func joinPath(path, key string) string {
+	switch {
+	case strings.ContainsAny(key, "./[]"):
+		return fmt.Sprintf("%s[%q]", path, key)
+	case len(path) == 0:
+		return key
+	default:
+		return path + "." + key
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
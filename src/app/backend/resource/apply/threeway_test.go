@@ -0,0 +1,105 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetFieldDiffs(t *testing.T) {
+	newDeployment := func(replicas int64, container, labels map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "web", "labels": labels},
+			"spec": map[string]interface{}{
+				"replicas": replicas,
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							container,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	live := &unstructured.Unstructured{Object: newDeployment(3,
+		map[string]interface{}{"name": "nginx", "image": "nginx:1.0",
+			"terminationMessagePath": "/dev/termination-log"},
+		map[string]interface{}{"app": "web", "tier": "front"})}
+	metadata := live.Object["metadata"].(map[string]interface{})
+	metadata["resourceVersion"] = "10"
+	metadata["managedFields"] = []interface{}{
+		map[string]interface{}{
+			"manager":   FieldManager,
+			"operation": "Apply",
+			"fieldsV1": map[string]interface{}{
+				"f:metadata": map[string]interface{}{
+					"f:labels": map[string]interface{}{"f:app": map[string]interface{}{},
+						"f:tier": map[string]interface{}{}},
+				},
+				"f:spec": map[string]interface{}{
+					"f:template": map[string]interface{}{"f:spec": map[string]interface{}{
+						"f:containers": map[string]interface{}{
+							`k:{"name":"nginx"}`: map[string]interface{}{
+								".":       map[string]interface{}{},
+								"f:image": map[string]interface{}{},
+								"f:name":  map[string]interface{}{},
+							},
+						},
+					}},
+				},
+			},
+		},
+		map[string]interface{}{
+			"manager":   "autoscaler",
+			"operation": "Update",
+			"fieldsV1": map[string]interface{}{
+				"f:spec": map[string]interface{}{"f:replicas": map[string]interface{}{}},
+			},
+		},
+	}
+
+	manifest := &unstructured.Unstructured{Object: newDeployment(2,
+		map[string]interface{}{"name": "nginx", "image": "nginx:1.1"},
+		map[string]interface{}{"app": "web", "example.com/team": "a"})}
+
+	expected := []FieldDiff{
+		{Path: `metadata.labels["example.com/team"]`, Change: FieldAdded, Manifest: "a",
+			Owners: []FieldOwner{}},
+		{Path: "metadata.labels.tier", Change: FieldRemoved, Live: "front", LastApplied: "front",
+			Owners: []FieldOwner{{Manager: FieldManager, Operation: "Apply"}}},
+		{Path: "spec.replicas", Change: FieldChanged, Live: int64(3), Manifest: int64(2),
+			Owners: []FieldOwner{{Manager: "autoscaler", Operation: "Update"}}, Conflict: true},
+		{Path: "spec.template.spec.containers[name=nginx].image", Change: FieldChanged,
+			Live: "nginx:1.0", Manifest: "nginx:1.1", LastApplied: "nginx:1.0",
+			Owners: []FieldOwner{{Manager: FieldManager, Operation: "Apply"}}},
+	}
+
+	actual := getFieldDiffs(live, manifest)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("getFieldDiffs() returns %#v, expected %#v", actual, expected)
+	}
+
+	created := getFieldDiffs(nil, manifest)
+	if len(created) != 4 || created[0].Path != "apiVersion" || created[0].Change != FieldAdded {
+		t.Errorf("getFieldDiffs(nil, manifest) returns %#v, expected top-level fields added", created)
+	}
+}
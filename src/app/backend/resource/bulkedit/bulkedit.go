@@ -0,0 +1,235 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkedit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Verber gets and patches resources of any kind known to Dashboard. It is implemented by
+// client.ResourceVerber.
+type Verber interface {
+	Get(kind string, namespaceSet bool, namespace string, name string) (runtime.Object, error)
+	Patch(kind string, namespaceSet bool, namespace string, name string, patchType types.PatchType,
+		patch []byte) (runtime.Object, error)
+}
+
+// ResourceRef identifies a resource selected for editing. Namespace is empty for cluster scoped
+// resources.
+type ResourceRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// MetadataEditSpec describes changes of labels and annotations applied to all selected resources.
+type MetadataEditSpec struct {
+	Resources []ResourceRef `json:"resources"`
+
+	// Labels added or updated on every resource.
+	Labels map[string]string `json:"labels"`
+
+	// Keys of labels removed from every resource.
+	RemoveLabels []string `json:"removeLabels"`
+
+	// Annotations added or updated on every resource.
+	Annotations map[string]string `json:"annotations"`
+
+	// Keys of annotations removed from every resource.
+	RemoveAnnotations []string `json:"removeAnnotations"`
+
+	// When set, resulting labels and annotations are computed but resources are not patched.
+	DryRun bool `json:"dryRun"`
+}
+
+// MetadataEditResult is the outcome of the edit for a single resource.
+type MetadataEditResult struct {
+	ResourceRef `json:",inline"`
+
+	// Labels and annotations after the edit. In dry run, these are the values the resource would
+	// have after the edit.
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+
+	// Whether the edit changes the resource. Unchanged resources are not patched.
+	Changed bool `json:"changed"`
+
+	// Error of getting or patching the resource, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// MetadataEditResultList contains results for all selected resources, in the order of the spec.
+type MetadataEditResultList struct {
+	DryRun  bool                 `json:"dryRun"`
+	Results []MetadataEditResult `json:"results"`
+}
+
+// EditMetadata applies label and annotation changes to all selected resources with strategic merge
+// patches. Failure on one resource does not stop editing of the others; it is reported in the
+// result of that resource.
+func EditMetadata(verber Verber, spec *MetadataEditSpec) (*MetadataEditResultList, error) {
+	if err := validateSpec(spec); err != nil {
+		return nil, err
+	}
+	logger.Infof("Editing metadata of %d resources (dry run: %t)", len(spec.Resources), spec.DryRun)
+
+	result := &MetadataEditResultList{DryRun: spec.DryRun, Results: []MetadataEditResult{}}
+	for _, ref := range spec.Resources {
+		result.Results = append(result.Results, editResource(verber, spec, ref))
+	}
+	return result, nil
+}
+
+func editResource(verber Verber, spec *MetadataEditSpec, ref ResourceRef) MetadataEditResult {
+	result := MetadataEditResult{ResourceRef: ref}
+	namespaceSet := ref.Namespace != ""
+
+	current, err := verber.Get(ref.Kind, namespaceSet, ref.Namespace, ref.Name)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	meta, err := getObjectMeta(current)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Labels = applyChanges(meta.Labels, spec.Labels, spec.RemoveLabels)
+	result.Annotations = applyChanges(meta.Annotations, spec.Annotations, spec.RemoveAnnotations)
+	result.Changed = !reflect.DeepEqual(result.Labels, nonNil(meta.Labels)) ||
+		!reflect.DeepEqual(result.Annotations, nonNil(meta.Annotations))
+	if spec.DryRun || !result.Changed {
+		return result
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels":      getPatch(meta.Labels, result.Labels),
+			"annotations": getPatch(meta.Annotations, result.Annotations),
+		},
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	patched, err := verber.Patch(ref.Kind, namespaceSet, ref.Namespace, ref.Name,
+		types.StrategicMergePatchType, patch)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if meta, err := getObjectMeta(patched); err == nil {
+		result.Labels = nonNil(meta.Labels)
+		result.Annotations = nonNil(meta.Annotations)
+	}
+	return result
+}
+
+// applyChanges returns copy of values with the updates set and the removed keys deleted.
+func applyChanges(values, updates map[string]string, removed []string) map[string]string {
+	result := map[string]string{}
+	for key, value := range values {
+		result[key] = value
+	}
+	for _, key := range removed {
+		delete(result, key)
+	}
+	for key, value := range updates {
+		result[key] = value
+	}
+	return result
+}
+
+// getPatch returns merge patch of the map, where removed keys are set to null.
+func getPatch(original, modified map[string]string) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for key := range original {
+		if _, ok := modified[key]; !ok {
+			patch[key] = nil
+		}
+	}
+	for key, value := range modified {
+		if originalValue, ok := original[key]; !ok || originalValue != value {
+			patch[key] = value
+		}
+	}
+	return patch
+}
+
+func getObjectMeta(object runtime.Object) (*metaV1.ObjectMeta, error) {
+	unknown, ok := object.(*runtime.Unknown)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T", object)
+	}
+	content := struct {
+		Metadata metaV1.ObjectMeta `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(unknown.Raw, &content); err != nil {
+		return nil, err
+	}
+	return &content.Metadata, nil
+}
+
+func nonNil(values map[string]string) map[string]string {
+	if values == nil {
+		return map[string]string{}
+	}
+	return values
+}
+
+func validateSpec(spec *MetadataEditSpec) error {
+	var errs []string
+	if len(spec.Resources) == 0 {
+		errs = append(errs, "no resources selected")
+	}
+	for _, ref := range spec.Resources {
+		if _, ok := api.KindToAPIMapping[ref.Kind]; !ok {
+			errs = append(errs, fmt.Sprintf("unknown resource kind %q", ref.Kind))
+		}
+		if ref.Name == "" {
+			errs = append(errs, "resource name is required")
+		}
+	}
+	for key, value := range spec.Labels {
+		for _, msg := range validation.IsQualifiedName(key) {
+			errs = append(errs, fmt.Sprintf("label key %q: %s", key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(value) {
+			errs = append(errs, fmt.Sprintf("label value %q: %s", value, msg))
+		}
+	}
+	for key := range spec.Annotations {
+		for _, msg := range validation.IsQualifiedName(strings.ToLower(key)) {
+			errs = append(errs, fmt.Sprintf("annotation key %q: %s", key, msg))
+		}
+	}
+	if len(errs) > 0 {
+		return errorsK8s.NewBadRequest(strings.Join(errs, "; "))
+	}
+	return nil
+}
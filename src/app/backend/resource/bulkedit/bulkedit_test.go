@@ -0,0 +1,150 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkedit
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type fakeVerber struct {
+	objects map[string]string
+	patches map[string]string
+}
+
+func (v *fakeVerber) Get(kind string, namespaceSet bool, namespace, name string) (runtime.Object, error) {
+	raw, ok := v.objects[kind+"/"+namespace+"/"+name]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &runtime.Unknown{Raw: []byte(raw)}, nil
+}
+
+func (v *fakeVerber) Patch(kind string, namespaceSet bool, namespace, name string,
+	patchType types.PatchType, patch []byte) (runtime.Object, error) {
+	if patchType != types.StrategicMergePatchType {
+		return nil, errors.New("unexpected patch type")
+	}
+	key := kind + "/" + namespace + "/" + name
+	v.patches[key] = string(patch)
+	return &runtime.Unknown{Raw: []byte(v.objects[key])}, nil
+}
+
+func newFakeVerber() *fakeVerber {
+	return &fakeVerber{
+		objects: map[string]string{
+			"deployment/default/web": `{"metadata":{"labels":{"team":"a","tier":"web"},` +
+				`"annotations":{"owner":"alice"}}}`,
+			"node//node-1": `{"metadata":{"labels":{"team":"b"}}}`,
+		},
+		patches: map[string]string{},
+	}
+}
+
+func TestEditMetadata(t *testing.T) {
+	verber := newFakeVerber()
+	spec := &MetadataEditSpec{
+		Resources: []ResourceRef{
+			{Kind: "deployment", Namespace: "default", Name: "web"},
+			{Kind: "node", Name: "node-1"},
+			{Kind: "deployment", Namespace: "default", Name: "missing"},
+		},
+		Labels:            map[string]string{"team": "b"},
+		RemoveLabels:      []string{"tier"},
+		RemoveAnnotations: []string{"owner"},
+	}
+
+	actual, err := EditMetadata(verber, spec)
+	if err != nil {
+		t.Fatalf("EditMetadata(): unexpected error %v", err)
+	}
+
+	expected := &MetadataEditResultList{Results: []MetadataEditResult{
+		{
+			ResourceRef: spec.Resources[0],
+			// Labels are taken from the object returned by the patch.
+			Labels:      map[string]string{"team": "a", "tier": "web"},
+			Annotations: map[string]string{"owner": "alice"},
+			Changed:     true,
+		},
+		{
+			ResourceRef: spec.Resources[1],
+			Labels:      map[string]string{"team": "b"},
+			Annotations: map[string]string{},
+		},
+		{ResourceRef: spec.Resources[2], Error: "not found"},
+	}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("EditMetadata() ==\n%#v\nexpected\n%#v", actual, expected)
+	}
+
+	patch := map[string]interface{}{}
+	json.Unmarshal([]byte(verber.patches["deployment/default/web"]), &patch)
+	expectedPatch := map[string]interface{}{"metadata": map[string]interface{}{
+		"labels":      map[string]interface{}{"team": "b", "tier": nil},
+		"annotations": map[string]interface{}{"owner": nil},
+	}}
+	if !reflect.DeepEqual(patch, expectedPatch) {
+		t.Errorf("Expected patch %#v but got %#v", expectedPatch, patch)
+	}
+	if _, ok := verber.patches["node//node-1"]; ok {
+		t.Error("Expected unchanged node not to be patched")
+	}
+}
+
+func TestEditMetadataDryRun(t *testing.T) {
+	verber := newFakeVerber()
+	spec := &MetadataEditSpec{
+		Resources:   []ResourceRef{{Kind: "deployment", Namespace: "default", Name: "web"}},
+		Annotations: map[string]string{"example.com/reviewed": "yes"},
+		DryRun:      true,
+	}
+
+	actual, err := EditMetadata(verber, spec)
+	if err != nil {
+		t.Fatalf("EditMetadata(): unexpected error %v", err)
+	}
+
+	expected := map[string]string{"owner": "alice", "example.com/reviewed": "yes"}
+	if !actual.DryRun || !actual.Results[0].Changed ||
+		!reflect.DeepEqual(actual.Results[0].Annotations, expected) {
+		t.Errorf("Unexpected dry run result %#v", actual)
+	}
+	if len(verber.patches) != 0 {
+		t.Errorf("Expected no patches in dry run but got %#v", verber.patches)
+	}
+}
+
+func TestEditMetadataValidation(t *testing.T) {
+	cases := []*MetadataEditSpec{
+		{},
+		{Resources: []ResourceRef{{Kind: "foo", Name: "bar"}}},
+		{Resources: []ResourceRef{{Kind: "pod", Namespace: "default"}}},
+		{Resources: []ResourceRef{{Kind: "pod", Name: "bar"}}, Labels: map[string]string{"-bad": "x"}},
+		{Resources: []ResourceRef{{Kind: "pod", Name: "bar"}}, Labels: map[string]string{"ok": "bad value"}},
+	}
+	for _, c := range cases {
+		_, err := EditMetadata(newFakeVerber(), c)
+		if !errorsK8s.IsBadRequest(err) {
+			t.Errorf("EditMetadata(%#v): expected bad request but got %v", c, err)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capacity
+
+import (
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"k8s.io/apimachinery/pkg/types"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	helper "k8s.io/client-go/pkg/api/v1/resource"
+)
+
+// Allocation describes how much of a resource is allocatable, requested by pods and actually used.
+type Allocation struct {
+	// Allocatable amount. For namespaces it is the amount allocatable in the whole cluster. CPU is
+	// in millicores and memory in bytes.
+	Allocatable int64 `json:"allocatable"`
+
+	// Amount requested by scheduled pods.
+	Requested int64 `json:"requested"`
+
+	// Amount used by scheduled pods. Nil if usage is not available.
+	Used *int64 `json:"used"`
+
+	// Requested share of the allocatable amount in percents.
+	RequestedFraction float64 `json:"requestedFraction"`
+
+	// Used share of the allocatable amount in percents. Nil if usage is not available.
+	UsedFraction *float64 `json:"usedFraction"`
+}
+
+// Entry is allocation of CPU and memory on a node, in a namespace or in the whole cluster. Entries
+// share the same structure, so they can be rendered as rows of a heatmap.
+type Entry struct {
+	// Name of the node or namespace. Empty for the whole cluster.
+	Name   string     `json:"name"`
+	CPU    Allocation `json:"cpu"`
+	Memory Allocation `json:"memory"`
+	// Number of scheduled pods.
+	Pods int `json:"pods"`
+}
+
+// Report is allocation of CPU and memory per node and per namespace.
+type Report struct {
+	Cluster    Entry   `json:"cluster"`
+	Nodes      []Entry `json:"nodes"`
+	Namespaces []Entry `json:"namespaces"`
+
+	// UsageAvailable is false when usage could not be downloaded from the metrics provider.
+	UsageAvailable bool `json:"usageAvailable"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// PodUsage is the most recent CPU (in millicores) and memory (in bytes) usage of a pod.
+type PodUsage struct {
+	CPU    int64
+	Memory int64
+}
+
+// sums accumulates requests and usage of pods.
+type sums struct {
+	cpuRequested    int64
+	memoryRequested int64
+	cpuUsed         int64
+	memoryUsed      int64
+	pods            int
+}
+
+// GetCapacityReport returns allocatable, requested and used CPU and memory per node and per
+// namespace. Pods and nodes are listed through resource channels, so they are served from the
+// resource cache when it is enabled. Usage is taken from the metric client, which may be nil.
+func GetCapacityReport(client client.Interface, metricClient metricapi.MetricClient) (*Report, error) {
+	logger.Info("Getting capacity report")
+
+	channels := &common.ResourceChannels{
+		NodeList: common.GetNodeListChannel(client, 1),
+		PodList:  common.GetPodListChannel(client, common.NewNamespaceQuery(nil), 1),
+	}
+
+	nodes := <-channels.NodeList.List
+	err := <-channels.NodeList.Error
+	nonCriticalErrors, criticalError := errors.AppendError(err, make([]error, 0))
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	pods := <-channels.PodList.List
+	err = <-channels.PodList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	scheduled := GetScheduledPods(pods.Items)
+	usage, err := GetPodUsage(scheduled, metricClient)
+	if err != nil {
+		logger.Warningf("Skipping pod usage in capacity report because of error: %s", err.Error())
+	}
+
+	report := toReport(nodes.Items, scheduled, usage)
+	report.Errors = nonCriticalErrors
+	return report, nil
+}
+
+// GetScheduledPods returns pods, which are scheduled to a node and are not finished.
+func GetScheduledPods(pods []v1.Pod) []v1.Pod {
+	result := make([]v1.Pod, 0)
+	for _, pod := range pods {
+		if len(pod.Spec.NodeName) > 0 && pod.Status.Phase != v1.PodSucceeded &&
+			pod.Status.Phase != v1.PodFailed {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
+
+// GetPodUsage downloads the most recent usage of pods. Nil is returned when usage is not available.
+func GetPodUsage(pods []v1.Pod, metricClient metricapi.MetricClient) (map[types.UID]PodUsage, error) {
+	if metricClient == nil {
+		return nil, nil
+	}
+
+	selectors := make([]metricapi.ResourceSelector, len(pods))
+	for i, pod := range pods {
+		selectors[i] = metricapi.ResourceSelector{
+			Namespace:    pod.Namespace,
+			ResourceType: api.ResourceKindPod,
+			ResourceName: pod.Name,
+			UID:          pod.UID,
+		}
+	}
+
+	cpu, err := metricClient.DownloadMetric(selectors, metricapi.CpuUsage, metricapi.NoResourceCache).GetMetrics()
+	if err != nil {
+		return nil, err
+	}
+	memory, err := metricClient.DownloadMetric(selectors, metricapi.MemoryUsage, metricapi.NoResourceCache).GetMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[types.UID]PodUsage)
+	for _, pod := range pods {
+		result[pod.UID] = PodUsage{}
+	}
+	for i, metric := range cpu {
+		uid := getPodUID(metric, pods, i)
+		usage := result[uid]
+		usage.CPU = lastValue(metric)
+		result[uid] = usage
+	}
+	for i, metric := range memory {
+		uid := getPodUID(metric, pods, i)
+		usage := result[uid]
+		usage.Memory = lastValue(metric)
+		result[uid] = usage
+	}
+	return result, nil
+}
+
+// getPodUID returns UID of the pod described by the metric. Metrics without label are matched with
+// pods by their position as promises are returned in the same order as selectors.
+func getPodUID(metric metricapi.Metric, pods []v1.Pod, index int) types.UID {
+	if uids := metric.Label[api.ResourceKindPod]; len(uids) == 1 {
+		return uids[0]
+	}
+	if index < len(pods) {
+		return pods[index].UID
+	}
+	return ""
+}
+
+// lastValue returns the most recent value of the metric or zero if there is none.
+func lastValue(metric metricapi.Metric) int64 {
+	if len(metric.MetricPoints) > 0 {
+		return int64(metric.MetricPoints[len(metric.MetricPoints)-1].Value)
+	}
+	if len(metric.DataPoints) > 0 {
+		return metric.DataPoints[len(metric.DataPoints)-1].Y
+	}
+	return 0
+}
+
+func toReport(nodes []v1.Node, pods []v1.Pod, usage map[types.UID]PodUsage) *Report {
+	var cpuAllocatable, memoryAllocatable int64
+	allocatable := make(map[string]v1.ResourceList)
+	for _, node := range nodes {
+		resources := node.Status.Allocatable
+		if len(resources) == 0 {
+			resources = node.Status.Capacity
+		}
+		allocatable[node.Name] = resources
+		cpuAllocatable += resources.Cpu().MilliValue()
+		memoryAllocatable += resources.Memory().Value()
+	}
+
+	cluster := &sums{}
+	byNode := make(map[string]*sums)
+	byNamespace := make(map[string]*sums)
+	for _, node := range nodes {
+		byNode[node.Name] = &sums{}
+	}
+
+	for _, pod := range pods {
+		podSums := getPodSums(pod, usage)
+		if nodeSums, ok := byNode[pod.Spec.NodeName]; ok {
+			nodeSums.add(podSums)
+		}
+		if _, ok := byNamespace[pod.Namespace]; !ok {
+			byNamespace[pod.Namespace] = &sums{}
+		}
+		byNamespace[pod.Namespace].add(podSums)
+		cluster.add(podSums)
+	}
+
+	hasUsage := usage != nil
+	report := &Report{
+		Cluster:        cluster.toEntry("", cpuAllocatable, memoryAllocatable, hasUsage),
+		Nodes:          make([]Entry, 0),
+		Namespaces:     make([]Entry, 0),
+		UsageAvailable: hasUsage,
+	}
+	for name, nodeSums := range byNode {
+		resources := allocatable[name]
+		report.Nodes = append(report.Nodes, nodeSums.toEntry(name, resources.Cpu().MilliValue(),
+			resources.Memory().Value(), hasUsage))
+	}
+	for name, namespaceSums := range byNamespace {
+		report.Namespaces = append(report.Namespaces, namespaceSums.toEntry(name, cpuAllocatable,
+			memoryAllocatable, hasUsage))
+	}
+
+	sort.Slice(report.Nodes, func(i, j int) bool { return report.Nodes[i].Name < report.Nodes[j].Name })
+	sort.Slice(report.Namespaces, func(i, j int) bool {
+		return report.Namespaces[i].Name < report.Namespaces[j].Name
+	})
+	return report
+}
+
+func getPodSums(pod v1.Pod, usage map[types.UID]PodUsage) sums {
+	result := sums{pods: 1}
+	requests, _, err := helper.PodRequestsAndLimits(&pod)
+	if err == nil {
+		cpu := requests[v1.ResourceCPU]
+		memory := requests[v1.ResourceMemory]
+		result.cpuRequested = cpu.MilliValue()
+		result.memoryRequested = memory.Value()
+	}
+	if podUsage, ok := usage[pod.UID]; ok {
+		result.cpuUsed = podUsage.CPU
+		result.memoryUsed = podUsage.Memory
+	}
+	return result
+}
+
+func (self *sums) add(other sums) {
+	self.cpuRequested += other.cpuRequested
+	self.memoryRequested += other.memoryRequested
+	self.cpuUsed += other.cpuUsed
+	self.memoryUsed += other.memoryUsed
+	self.pods += other.pods
+}
+
+func (self *sums) toEntry(name string, cpuAllocatable, memoryAllocatable int64, hasUsage bool) Entry {
+	entry := Entry{
+		Name:   name,
+		CPU:    newAllocation(cpuAllocatable, self.cpuRequested),
+		Memory: newAllocation(memoryAllocatable, self.memoryRequested),
+		Pods:   self.pods,
+	}
+	if hasUsage {
+		entry.CPU.setUsed(self.cpuUsed)
+		entry.Memory.setUsed(self.memoryUsed)
+	}
+	return entry
+}
+
+func newAllocation(allocatable, requested int64) Allocation {
+	return Allocation{
+		Allocatable:       allocatable,
+		Requested:         requested,
+		RequestedFraction: fraction(requested, allocatable),
+	}
+}
+
+func (self *Allocation) setUsed(used int64) {
+	usedFraction := fraction(used, self.Allocatable)
+	self.Used = &used
+	self.UsedFraction = &usedFraction
+}
+
+// fraction returns value as percents of total or zero if total is zero.
+func fraction(value, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(value) / float64(total) * 100
+}
@@ -0,0 +1,186 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capacity
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// fakeMetricClient returns usage of pods by their names.
+type fakeMetricClient struct {
+	usage map[string]map[string]uint64
+}
+
+func (self fakeMetricClient) DownloadMetric(selectors []metricapi.ResourceSelector, metricName string,
+	cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	promises := metricapi.NewMetricPromises(len(selectors))
+	for i, selector := range selectors {
+		promises[i].Metric <- &metricapi.Metric{
+			MetricName:   metricName,
+			MetricPoints: []metricapi.MetricPoint{{Value: self.usage[metricName][selector.ResourceName]}},
+			Label:        metricapi.Label{api.ResourceKindPod: []types.UID{selector.UID}},
+		}
+		promises[i].Error <- nil
+	}
+	return promises
+}
+
+func (self fakeMetricClient) DownloadMetrics(selectors []metricapi.ResourceSelector, metricNames []string,
+	cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	return nil
+}
+
+func (self fakeMetricClient) AggregateMetrics(metrics metricapi.MetricPromises, metricName string,
+	aggregations metricapi.AggregationModes) metricapi.MetricPromises {
+	return metrics
+}
+
+func (self fakeMetricClient) HealthCheck() error {
+	return nil
+}
+
+func (self fakeMetricClient) ID() integrationapi.IntegrationID {
+	return "fake"
+}
+
+func newNode(name, cpu, memory string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{Allocatable: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse(cpu),
+			v1.ResourceMemory: resource.MustParse(memory),
+		}},
+	}
+}
+
+func newPod(namespace, name, node string, phase v1.PodPhase, cpu, memory string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(name)},
+		Spec: v1.PodSpec{
+			NodeName: node,
+			Containers: []v1.Container{{
+				Name: "app",
+				Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse(cpu),
+					v1.ResourceMemory: resource.MustParse(memory),
+				}},
+			}},
+		},
+		Status: v1.PodStatus{Phase: phase},
+	}
+}
+
+func getTestClient() *fake.Clientset {
+	return fake.NewSimpleClientset(
+		newNode("node-1", "2", "2000"),
+		newNode("node-2", "2", "2000"),
+		newPod("a", "web", "node-1", v1.PodRunning, "500m", "500"),
+		newPod("b", "db", "node-1", v1.PodRunning, "1", "1000"),
+		newPod("b", "pending", "", v1.PodPending, "1", "1000"),
+		newPod("b", "done", "node-2", v1.PodSucceeded, "1", "1000"),
+	)
+}
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
+
+func float64Ptr(value float64) *float64 {
+	return &value
+}
+
+func TestGetCapacityReport(t *testing.T) {
+	metricClient := fakeMetricClient{usage: map[string]map[string]uint64{
+		metricapi.CpuUsage:    {"web": 100, "db": 900},
+		metricapi.MemoryUsage: {"web": 200, "db": 400},
+	}}
+
+	actual, err := GetCapacityReport(getTestClient(), metricClient)
+	if err != nil {
+		t.Fatalf("GetCapacityReport() returned error: %s", err.Error())
+	}
+
+	expected := &Report{
+		Cluster: Entry{
+			CPU: Allocation{Allocatable: 4000, Requested: 1500, Used: int64Ptr(1000), RequestedFraction: 37.5,
+				UsedFraction: float64Ptr(25)},
+			Memory: Allocation{Allocatable: 4000, Requested: 1500, Used: int64Ptr(600), RequestedFraction: 37.5,
+				UsedFraction: float64Ptr(15)},
+			Pods: 2,
+		},
+		Nodes: []Entry{
+			{
+				Name: "node-1",
+				CPU: Allocation{Allocatable: 2000, Requested: 1500, Used: int64Ptr(1000), RequestedFraction: 75,
+					UsedFraction: float64Ptr(50)},
+				Memory: Allocation{Allocatable: 2000, Requested: 1500, Used: int64Ptr(600), RequestedFraction: 75,
+					UsedFraction: float64Ptr(30)},
+				Pods: 2,
+			},
+			{
+				Name:   "node-2",
+				CPU:    Allocation{Allocatable: 2000, Used: int64Ptr(0), UsedFraction: float64Ptr(0)},
+				Memory: Allocation{Allocatable: 2000, Used: int64Ptr(0), UsedFraction: float64Ptr(0)},
+			},
+		},
+		Namespaces: []Entry{
+			{
+				Name: "a",
+				CPU: Allocation{Allocatable: 4000, Requested: 500, Used: int64Ptr(100), RequestedFraction: 12.5,
+					UsedFraction: float64Ptr(2.5)},
+				Memory: Allocation{Allocatable: 4000, Requested: 500, Used: int64Ptr(200), RequestedFraction: 12.5,
+					UsedFraction: float64Ptr(5)},
+				Pods: 1,
+			},
+			{
+				Name: "b",
+				CPU: Allocation{Allocatable: 4000, Requested: 1000, Used: int64Ptr(900), RequestedFraction: 25,
+					UsedFraction: float64Ptr(22.5)},
+				Memory: Allocation{Allocatable: 4000, Requested: 1000, Used: int64Ptr(400), RequestedFraction: 25,
+					UsedFraction: float64Ptr(10)},
+				Pods: 1,
+			},
+		},
+		UsageAvailable: true,
+		Errors:         make([]error, 0),
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetCapacityReport() == \n%#v\nexpected \n%#v", actual, expected)
+	}
+}
+
+func TestGetCapacityReportWithoutMetrics(t *testing.T) {
+	actual, err := GetCapacityReport(getTestClient(), nil)
+	if err != nil {
+		t.Fatalf("GetCapacityReport() returned error: %s", err.Error())
+	}
+
+	if actual.UsageAvailable || actual.Cluster.CPU.Used != nil || actual.Nodes[0].Memory.UsedFraction != nil {
+		t.Errorf("GetCapacityReport() without metric client expected to report no usage, got %#v", actual)
+	}
+	if actual.Cluster.CPU.Requested != 1500 {
+		t.Errorf("GetCapacityReport() requested CPU == %d, expected 1500", actual.Cluster.CPU.Requested)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package certificate lists certificates of TLS secrets together with their expiry.
+package certificate
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// CertificateStatus tells whether a certificate is valid and for how long.
+type CertificateStatus string
+
+const (
+	StatusValid       CertificateStatus = "Valid"
+	StatusExpiring    CertificateStatus = "Expiring"
+	StatusExpired     CertificateStatus = "Expired"
+	StatusNotYetValid CertificateStatus = "NotYetValid"
+	StatusInvalid     CertificateStatus = "Invalid"
+)
+
+// Certificate is the leaf certificate of a TLS secret.
+type Certificate struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	Subject      string   `json:"subject"`
+	Issuer       string   `json:"issuer"`
+	SerialNumber string   `json:"serialNumber"`
+	DNSNames     []string `json:"dnsNames"`
+	IPAddresses  []string `json:"ipAddresses"`
+
+	NotBefore *metaV1.Time `json:"notBefore,omitempty"`
+	NotAfter  *metaV1.Time `json:"notAfter,omitempty"`
+
+	// Whole days until the certificate expires, negative for expired certificates.
+	DaysToExpiry int `json:"daysToExpiry"`
+
+	// Number of certificates in the secret, including intermediate certificates of the chain.
+	ChainLength int `json:"chainLength"`
+
+	Status CertificateStatus `json:"status"`
+
+	// Why the certificate could not be parsed, set for invalid certificates.
+	Error string `json:"error,omitempty"`
+}
+
+// CertificateList contains certificates of TLS secrets.
+type CertificateList struct {
+	ListMeta     api.ListMeta  `json:"listMeta"`
+	Certificates []Certificate `json:"certificates"`
+
+	// Certificates expiring within this number of days are reported as expiring.
+	WarningDays int `json:"warningDays"`
+
+	// Numbers of all certificates, before data select, by status.
+	Expired  int `json:"expired"`
+	Expiring int `json:"expiring"`
+	Invalid  int `json:"invalid"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetCertificateList returns certificates of TLS secrets in the namespaces, sorted by expiry unless
+// the data select query sorts them. Secrets are read from the resource cache, when it is enabled.
+func GetCertificateList(client client.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery, warningDays int) (*CertificateList, error) {
+	logger.Infof("Getting list of certificates in %s namespace", nsQuery.ToRequestParam())
+	channel := common.GetTLSSecretListChannel(client, nsQuery, 1)
+	secrets := <-channel.List
+	nonCriticalErrors, criticalError := errors.HandleError(<-channel.Error)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	return toCertificateList(secrets.Items, nonCriticalErrors, dsQuery, warningDays, time.Now()), nil
+}
+
+func toCertificateList(secrets []v1.Secret, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery,
+	warningDays int, now time.Time) *CertificateList {
+	result := &CertificateList{
+		Certificates: make([]Certificate, 0),
+		WarningDays:  warningDays,
+		Errors:       nonCriticalErrors,
+	}
+
+	certificates := make([]Certificate, 0, len(secrets))
+	for i := range secrets {
+		certificate := toCertificate(&secrets[i], warningDays, now)
+		switch certificate.Status {
+		case StatusExpired:
+			result.Expired++
+		case StatusExpiring:
+			result.Expiring++
+		case StatusInvalid:
+			result.Invalid++
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	// Invalid certificates have no expiry and go first, as they need attention as well.
+	sort.SliceStable(certificates, func(i, j int) bool {
+		return expiryOf(certificates[i]).Before(expiryOf(certificates[j]))
+	})
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(certificates), dsQuery)
+	result.Certificates = fromCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+	return result
+}
+
+// toCertificate parses the first certificate of the secret, which is the leaf certificate.
+func toCertificate(secret *v1.Secret, warningDays int, now time.Time) Certificate {
+	result := Certificate{
+		ObjectMeta:  api.NewObjectMeta(secret.ObjectMeta),
+		TypeMeta:    api.NewTypeMeta(api.ResourceKindSecret),
+		DNSNames:    make([]string, 0),
+		IPAddresses: make([]string, 0),
+		Status:      StatusInvalid,
+	}
+
+	chain, err := parseCertificates(secret.Data[v1.TLSCertKey])
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	leaf := chain[0]
+	notBefore, notAfter := metaV1.NewTime(leaf.NotBefore), metaV1.NewTime(leaf.NotAfter)
+	result.Subject = leaf.Subject.CommonName
+	result.Issuer = leaf.Issuer.CommonName
+	result.SerialNumber = leaf.SerialNumber.String()
+	result.DNSNames = append(result.DNSNames, leaf.DNSNames...)
+	for _, ip := range leaf.IPAddresses {
+		result.IPAddresses = append(result.IPAddresses, ip.String())
+	}
+	result.NotBefore, result.NotAfter = &notBefore, &notAfter
+	result.ChainLength = len(chain)
+	result.DaysToExpiry = int(math.Floor(leaf.NotAfter.Sub(now).Hours() / 24))
+
+	switch {
+	case now.After(leaf.NotAfter):
+		result.Status = StatusExpired
+	case now.Before(leaf.NotBefore):
+		result.Status = StatusNotYetValid
+	case result.DaysToExpiry < warningDays:
+		result.Status = StatusExpiring
+	default:
+		result.Status = StatusValid
+	}
+	return result
+}
+
+// parseCertificates parses all PEM encoded certificates. Other PEM blocks are skipped.
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	certificates := make([]*x509.Certificate, 0)
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("%s does not contain PEM encoded certificate", v1.TLSCertKey)
+	}
+	return certificates, nil
+}
+
+func expiryOf(certificate Certificate) time.Time {
+	if certificate.NotAfter == nil {
+		return time.Time{}
+	}
+	return certificate.NotAfter.Time
+}
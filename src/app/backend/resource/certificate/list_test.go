@@ -0,0 +1,99 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func newCertificatePEM(t *testing.T, name string, notBefore, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestToCertificateList(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	newSecret := func(name string, crt []byte) v1.Secret {
+		return v1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+			Type:       v1.SecretTypeTLS,
+			Data:       map[string][]byte{v1.TLSCertKey: crt},
+		}
+	}
+
+	secrets := []v1.Secret{
+		newSecret("valid", newCertificatePEM(t, "valid.example.com", now.Add(-day), now.Add(90*day))),
+		newSecret("expiring", newCertificatePEM(t, "expiring.example.com", now.Add(-day), now.Add(10*day+time.Hour))),
+		newSecret("expired", newCertificatePEM(t, "expired.example.com", now.Add(-10*day), now.Add(-day))),
+		newSecret("future", newCertificatePEM(t, "future.example.com", now.Add(day), now.Add(100*day))),
+		newSecret("invalid", []byte("not a certificate")),
+	}
+
+	list := toCertificateList(secrets, nil, dataselect.NoDataSelect, 30, now)
+
+	actual := make([]string, 0)
+	for _, certificate := range list.Certificates {
+		actual = append(actual, certificate.ObjectMeta.Name+"/"+string(certificate.Status))
+	}
+	expected := []string{"invalid/Invalid", "expired/Expired", "expiring/Expiring", "valid/Valid",
+		"future/NotYetValid"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("toCertificateList() returns %#v, expected %#v", actual, expected)
+	}
+	if list.Expired != 1 || list.Expiring != 1 || list.Invalid != 1 || list.ListMeta.TotalItems != 5 {
+		t.Errorf("toCertificateList() returns counts %#v, expected one expired, expiring and invalid", list)
+	}
+
+	expiring := list.Certificates[2]
+	if expiring.DaysToExpiry != 10 || expiring.Subject != "expiring.example.com" ||
+		!reflect.DeepEqual(expiring.DNSNames, []string{"expiring.example.com"}) ||
+		!reflect.DeepEqual(expiring.IPAddresses, []string{"10.0.0.1"}) || expiring.ChainLength != 1 {
+		t.Errorf("toCertificateList() returns %#v for expiring certificate", expiring)
+	}
+	if expired := list.Certificates[1]; expired.DaysToExpiry != -1 {
+		t.Errorf("toCertificateList() returns %d days to expiry of expired certificate, expected -1",
+			expired.DaysToExpiry)
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+// Certificate provides the presentation layer view of cert-manager certificate.
+type Certificate struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	SecretName string          `json:"secretName"`
+	CommonName string          `json:"commonName"`
+	DNSNames   []string        `json:"dnsNames"`
+	IssuerRef  IssuerReference `json:"issuerRef"`
+
+	// Ready is true when the secret holds an up to date certificate matching the spec.
+	Ready bool `json:"ready"`
+
+	// Issuing is true while a new certificate is being issued.
+	Issuing bool `json:"issuing"`
+
+	// Reason and message of the ready condition.
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+
+	NotBefore   *metaV1.Time `json:"notBefore,omitempty"`
+	NotAfter    *metaV1.Time `json:"notAfter,omitempty"`
+	RenewalTime *metaV1.Time `json:"renewalTime,omitempty"`
+
+	// Revision is the number of the issued certificate, incremented with every issuance.
+	Revision               int `json:"revision"`
+	FailedIssuanceAttempts int `json:"failedIssuanceAttempts"`
+
+	Conditions []common.Condition `json:"conditions"`
+}
+
+// CertificateList contains a list of cert-manager certificates.
+type CertificateList struct {
+	ListMeta     api.ListMeta  `json:"listMeta"`
+	Certificates []Certificate `json:"certificates"`
+
+	// Number of all certificates, before data select, that are not ready.
+	NotReady int `json:"notReady"`
+}
+
+// CertificateDetail is a cert-manager certificate with its certificate requests.
+type CertificateDetail struct {
+	Certificate `json:",inline"`
+
+	Requests []CertificateRequest `json:"requests"`
+}
+
+// certificate is the API representation of cert-manager certificate.
+type certificate struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              certificateSpec   `json:"spec"`
+	Status            certificateStatus `json:"status"`
+}
+
+type certificateSpec struct {
+	SecretName string          `json:"secretName"`
+	CommonName string          `json:"commonName"`
+	DNSNames   []string        `json:"dnsNames"`
+	IssuerRef  IssuerReference `json:"issuerRef"`
+}
+
+type certificateStatus struct {
+	Conditions             []condition  `json:"conditions"`
+	NotBefore              *metaV1.Time `json:"notBefore"`
+	NotAfter               *metaV1.Time `json:"notAfter"`
+	RenewalTime            *metaV1.Time `json:"renewalTime"`
+	Revision               *int         `json:"revision"`
+	FailedIssuanceAttempts *int         `json:"failedIssuanceAttempts"`
+}
+
+type certificateList struct {
+	Items []certificate `json:"items"`
+}
+
+// GetCertificateList returns cert-manager certificates in the namespaces.
+func GetCertificateList(config *rest.Config, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*CertificateList, error) {
+	restClient, err := newRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return getCertificateList(restClient, nsQuery, dsQuery)
+}
+
+func getCertificateList(restClient RESTClient, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*CertificateList, error) {
+	logger.Info("Getting list of cert-manager certificates")
+
+	raw, err := getRaw(restClient, certificateResource, nsQuery.ToRequestParam(), "")
+	if err != nil {
+		return nil, err
+	}
+	list := certificateList{}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	return toCertificateList(list.Items, nsQuery, dsQuery), nil
+}
+
+// GetCertificateDetail returns cert-manager certificate with certificate requests issued for it.
+func GetCertificateDetail(config *rest.Config, namespace, name string) (*CertificateDetail, error) {
+	restClient, err := newRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return getCertificateDetail(restClient, namespace, name)
+}
+
+func getCertificateDetail(restClient RESTClient, namespace, name string) (*CertificateDetail, error) {
+	logger.Infof("Getting details of %s cert-manager certificate in %s namespace", name, namespace)
+
+	raw, err := getRaw(restClient, certificateResource, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	crt := certificate{}
+	if err := json.Unmarshal(raw, &crt); err != nil {
+		return nil, err
+	}
+
+	raw, err = getRaw(restClient, certificateRequestResource, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	requests := certificateRequestList{}
+	if err := json.Unmarshal(raw, &requests); err != nil {
+		return nil, err
+	}
+
+	return &CertificateDetail{
+		Certificate: toCertificate(crt),
+		Requests:    toCertificateRequestsOf(requests.Items, name),
+	}, nil
+}
+
+// RenewCertificate triggers issuance of a new certificate, the same as cmctl renew. cert-manager
+// issues the certificate when it observes Issuing condition set to true.
+func RenewCertificate(config *rest.Config, namespace, name string) (*Certificate, error) {
+	logger.Infof("Triggering renewal of %s cert-manager certificate in %s namespace", name, namespace)
+
+	restClient, err := apply.NewRESTClient(config, GroupVersion)
+	if err != nil {
+		return nil, err
+	}
+	obj := new(unstructured.Unstructured)
+	if err := restClient.Get().Namespace(namespace).Resource(certificateResource).Name(name).Do().
+		Into(obj); err != nil {
+		return nil, err
+	}
+
+	if err := setIssuingCondition(obj, time.Now()); err != nil {
+		return nil, err
+	}
+	body, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := restClient.Put().Namespace(namespace).Resource(certificateResource).Name(name).
+		SubResource("status").Body(body).Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+	updated := certificate{}
+	if err := json.Unmarshal(raw, &updated); err != nil {
+		return nil, err
+	}
+	result := toCertificate(updated)
+	return &result, nil
+}
+
+// setIssuingCondition sets Issuing condition of the raw certificate to true. Certificates, that are
+// already being issued, are rejected with a conflict.
+func setIssuingCondition(obj *unstructured.Unstructured, now time.Time) error {
+	status, _ := obj.Object["status"].(map[string]interface{})
+	if status == nil {
+		status = map[string]interface{}{}
+		obj.Object["status"] = status
+	}
+	conditions, _ := status["conditions"].([]interface{})
+
+	issuing := map[string]interface{}{
+		"type":               ConditionIssuing,
+		"status":             string(v1.ConditionTrue),
+		"reason":             "ManuallyTriggered",
+		"message":            "Certificate re-issuance manually triggered",
+		"lastTransitionTime": now.UTC().Format(time.RFC3339),
+		"observedGeneration": obj.GetGeneration(),
+	}
+	for i, item := range conditions {
+		c, _ := item.(map[string]interface{})
+		if c == nil || c["type"] != ConditionIssuing {
+			continue
+		}
+		if c["status"] == string(v1.ConditionTrue) {
+			return errorsK8s.NewConflict(schema.GroupResource{Group: GroupVersion.Group, Resource: certificateResource},
+				obj.GetName(), errors.New("certificate is already being issued"))
+		}
+		conditions[i] = issuing
+		return nil
+	}
+	status["conditions"] = append(conditions, issuing)
+	return nil
+}
+
+func toCertificateList(certificates []certificate, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) *CertificateList {
+	result := &CertificateList{Certificates: make([]Certificate, 0)}
+
+	items := make([]Certificate, 0, len(certificates))
+	for _, crt := range certificates {
+		if !nsQuery.Matches(crt.Namespace) {
+			continue
+		}
+		item := toCertificate(crt)
+		if !item.Ready {
+			result.NotReady++
+		}
+		items = append(items, item)
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCertificateCells(items), dsQuery)
+	result.Certificates = fromCertificateCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+	return result
+}
+
+func toCertificate(crt certificate) Certificate {
+	result := Certificate{
+		ObjectMeta:  api.NewObjectMeta(crt.ObjectMeta),
+		TypeMeta:    api.NewTypeMeta(api.ResourceKindCertManagerCertificate),
+		SecretName:  crt.Spec.SecretName,
+		CommonName:  crt.Spec.CommonName,
+		DNSNames:    crt.Spec.DNSNames,
+		IssuerRef:   crt.Spec.IssuerRef,
+		Ready:       isTrue(crt.Status.Conditions, ConditionReady),
+		Issuing:     isTrue(crt.Status.Conditions, ConditionIssuing),
+		NotBefore:   crt.Status.NotBefore,
+		NotAfter:    crt.Status.NotAfter,
+		RenewalTime: crt.Status.RenewalTime,
+		Conditions:  toConditions(crt.Status.Conditions),
+	}
+	if result.DNSNames == nil {
+		result.DNSNames = make([]string, 0)
+	}
+	if ready := findCondition(crt.Status.Conditions, ConditionReady); ready != nil {
+		result.Reason = ready.Reason
+		result.Message = ready.Message
+	}
+	if crt.Status.Revision != nil {
+		result.Revision = *crt.Status.Revision
+	}
+	if crt.Status.FailedIssuanceAttempts != nil {
+		result.FailedIssuanceAttempts = *crt.Status.FailedIssuanceAttempts
+	}
+	return result
+}
@@ -0,0 +1,179 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+)
+
+const testCertificates = `{"items": [
+  {"metadata": {"name": "web", "namespace": "default"},
+   "spec": {"secretName": "web-tls", "dnsNames": ["web.example.com"],
+            "issuerRef": {"name": "letsencrypt", "kind": "ClusterIssuer"}},
+   "status": {"conditions": [{"type": "Ready", "status": "True", "reason": "Ready"}],
+              "notAfter": "2021-09-01T00:00:00Z", "renewalTime": "2021-08-02T00:00:00Z", "revision": 3}},
+  {"metadata": {"name": "api", "namespace": "default"},
+   "spec": {"secretName": "api-tls", "issuerRef": {"name": "ca", "kind": "Issuer"}},
+   "status": {"conditions": [
+     {"type": "Ready", "status": "False", "reason": "DoesNotExist", "message": "Issuing certificate"},
+     {"type": "Issuing", "status": "True"}],
+              "failedIssuanceAttempts": 2}},
+  {"metadata": {"name": "db", "namespace": "kube-system"},
+   "spec": {"secretName": "db-tls", "issuerRef": {"name": "ca", "kind": "Issuer"}}}
+]}`
+
+const testCertificateRequests = `{"items": [
+  {"metadata": {"name": "web-1", "namespace": "default", "creationTimestamp": "2021-06-01T00:00:00Z",
+                "annotations": {"cert-manager.io/certificate-name": "web",
+                                "cert-manager.io/certificate-revision": "1"}},
+   "status": {"conditions": [{"type": "Approved", "status": "True"}, {"type": "Ready", "status": "True"}]}},
+  {"metadata": {"name": "web-3", "namespace": "default", "creationTimestamp": "2021-07-01T00:00:00Z",
+                "annotations": {"cert-manager.io/certificate-name": "web",
+                                "cert-manager.io/certificate-revision": "3"}},
+   "status": {"conditions": [{"type": "Denied", "status": "True"},
+                             {"type": "Ready", "status": "False", "reason": "Denied"}]}},
+  {"metadata": {"name": "api-1", "namespace": "default", "creationTimestamp": "2021-07-01T00:00:00Z",
+                "annotations": {"cert-manager.io/certificate-name": "api"}}}
+]}`
+
+// newCertManagerClient returns client of cert-manager API, which serves test certificates and
+// certificate requests in default namespace.
+func newCertManagerClient(t *testing.T) (RESTClient, func()) {
+	objects := map[string]string{
+		"/apis/cert-manager.io/v1/namespaces/default/certificates": testCertificates,
+		"/apis/cert-manager.io/v1/namespaces/default/certificates/web": `{"metadata": {"name": "web",
+		  "namespace": "default"}}`,
+		"/apis/cert-manager.io/v1/namespaces/default/certificaterequests": testCertificateRequests,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, ok := objects[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(raw))
+	}))
+	restClient, err := newRESTClient(&rest.Config{Host: server.URL})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return restClient, server.Close
+}
+
+func TestGetCertificateList(t *testing.T) {
+	restClient, closeServer := newCertManagerClient(t)
+	defer closeServer()
+
+	list, err := getCertificateList(restClient, common.NewNamespaceQuery([]string{"default"}), dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.ListMeta.TotalItems != 2 || list.NotReady != 1 {
+		t.Fatalf("GetCertificateList() returns %d certificates, %d not ready, expected 2 and 1",
+			list.ListMeta.TotalItems, list.NotReady)
+	}
+
+	web, api := list.Certificates[0], list.Certificates[1]
+	if !web.Ready || web.Issuing || web.Revision != 3 || web.RenewalTime == nil ||
+		!reflect.DeepEqual(web.DNSNames, []string{"web.example.com"}) ||
+		web.IssuerRef != (IssuerReference{Name: "letsencrypt", Kind: "ClusterIssuer"}) {
+		t.Errorf("GetCertificateList() returns %#v for web certificate", web)
+	}
+	if api.Ready || !api.Issuing || api.Reason != "DoesNotExist" || api.Message != "Issuing certificate" ||
+		api.FailedIssuanceAttempts != 2 || len(api.Conditions) != 2 || len(api.DNSNames) != 0 {
+		t.Errorf("GetCertificateList() returns %#v for api certificate", api)
+	}
+}
+
+func TestGetCertificateDetail(t *testing.T) {
+	restClient, closeServer := newCertManagerClient(t)
+	defer closeServer()
+
+	detail, err := getCertificateDetail(restClient, "default", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual := make([]string, 0)
+	for _, request := range detail.Requests {
+		actual = append(actual, request.ObjectMeta.Name)
+	}
+	if !reflect.DeepEqual(actual, []string{"web-3", "web-1"}) {
+		t.Fatalf("GetCertificateDetail() returns requests %#v, expected newest requests of web first", actual)
+	}
+	if denied := detail.Requests[0]; !denied.Denied || denied.Approved || denied.Ready || denied.Revision != 3 ||
+		denied.Reason != "Denied" {
+		t.Errorf("GetCertificateDetail() returns %#v for denied request", denied)
+	}
+	if issued := detail.Requests[1]; !issued.Approved || !issued.Ready || issued.Certificate != "web" {
+		t.Errorf("GetCertificateDetail() returns %#v for issued request", issued)
+	}
+}
+
+func TestSetIssuingCondition(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	newCertificate := func(conditions ...interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "web", "generation": int64(2)},
+			"status":   map[string]interface{}{"conditions": conditions},
+		}}
+	}
+
+	obj := newCertificate(map[string]interface{}{"type": "Ready", "status": "True"},
+		map[string]interface{}{"type": "Issuing", "status": "False"})
+	if err := setIssuingCondition(obj, now); err != nil {
+		t.Fatal(err)
+	}
+	conditions := obj.Object["status"].(map[string]interface{})["conditions"].([]interface{})
+	expected := map[string]interface{}{
+		"type":               "Issuing",
+		"status":             "True",
+		"reason":             "ManuallyTriggered",
+		"message":            "Certificate re-issuance manually triggered",
+		"lastTransitionTime": "2021-06-01T12:00:00Z",
+		"observedGeneration": int64(2),
+	}
+	if len(conditions) != 2 || !reflect.DeepEqual(conditions[1], expected) {
+		t.Errorf("setIssuingCondition() sets conditions %#v, expected Issuing condition %#v", conditions,
+			expected)
+	}
+
+	obj = &unstructured.Unstructured{Object: map[string]interface{}{"metadata": map[string]interface{}{}}}
+	if err := setIssuingCondition(obj, now); err != nil {
+		t.Fatal(err)
+	}
+	conditions = obj.Object["status"].(map[string]interface{})["conditions"].([]interface{})
+	if len(conditions) != 1 {
+		t.Errorf("setIssuingCondition() sets conditions %#v for certificate without status", conditions)
+	}
+
+	obj = newCertificate(map[string]interface{}{"type": "Issuing", "status": "True"})
+	if err := setIssuingCondition(obj, now); !errorsK8s.IsConflict(err) {
+		t.Errorf("setIssuingCondition() returns %v for certificate being issued, expected conflict", err)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// CertificateRequest provides the presentation layer view of cert-manager certificate request.
+type CertificateRequest struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Certificate is the name of the certificate the request was created for. Empty for requests
+	// created directly.
+	Certificate string `json:"certificate"`
+
+	// Revision of the certificate the request issues.
+	Revision int `json:"revision,omitempty"`
+
+	IssuerRef IssuerReference `json:"issuerRef"`
+	Approved  bool            `json:"approved"`
+	Denied    bool            `json:"denied"`
+	Ready     bool            `json:"ready"`
+
+	// Reason and message of the ready condition.
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+
+	FailureTime *metaV1.Time `json:"failureTime,omitempty"`
+
+	Conditions []common.Condition `json:"conditions"`
+}
+
+// CertificateRequestList contains a list of cert-manager certificate requests.
+type CertificateRequestList struct {
+	ListMeta api.ListMeta         `json:"listMeta"`
+	Requests []CertificateRequest `json:"requests"`
+}
+
+// certificateRequest is the API representation of cert-manager certificate request.
+type certificateRequest struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              certificateRequestSpec   `json:"spec"`
+	Status            certificateRequestStatus `json:"status"`
+}
+
+type certificateRequestSpec struct {
+	IssuerRef IssuerReference `json:"issuerRef"`
+}
+
+type certificateRequestStatus struct {
+	Conditions  []condition  `json:"conditions"`
+	FailureTime *metaV1.Time `json:"failureTime"`
+}
+
+type certificateRequestList struct {
+	Items []certificateRequest `json:"items"`
+}
+
+// GetCertificateRequestList returns cert-manager certificate requests in the namespaces.
+func GetCertificateRequestList(config *rest.Config, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*CertificateRequestList, error) {
+	logger.Info("Getting list of cert-manager certificate requests")
+
+	restClient, err := newRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := getRaw(restClient, certificateRequestResource, nsQuery.ToRequestParam(), "")
+	if err != nil {
+		return nil, err
+	}
+	list := certificateRequestList{}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+
+	items := make([]CertificateRequest, 0, len(list.Items))
+	for _, request := range list.Items {
+		if nsQuery.Matches(request.Namespace) {
+			items = append(items, toCertificateRequest(request))
+		}
+	}
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCertificateRequestCells(items), dsQuery)
+	return &CertificateRequestList{
+		ListMeta: api.ListMeta{TotalItems: filteredTotal},
+		Requests: fromCertificateRequestCells(cells),
+	}, nil
+}
+
+// toCertificateRequestsOf returns requests created for the certificate, newest first.
+func toCertificateRequestsOf(requests []certificateRequest, certificate string) []CertificateRequest {
+	result := make([]CertificateRequest, 0)
+	for _, request := range requests {
+		if request.Annotations[certificateNameAnnotation] == certificate {
+			result = append(result, toCertificateRequest(request))
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[j].ObjectMeta.CreationTimestamp.Before(result[i].ObjectMeta.CreationTimestamp)
+	})
+	return result
+}
+
+func toCertificateRequest(request certificateRequest) CertificateRequest {
+	result := CertificateRequest{
+		ObjectMeta:  api.NewObjectMeta(request.ObjectMeta),
+		TypeMeta:    api.NewTypeMeta(api.ResourceKindCertificateRequest),
+		Certificate: request.Annotations[certificateNameAnnotation],
+		IssuerRef:   request.Spec.IssuerRef,
+		Approved:    isTrue(request.Status.Conditions, conditionApproved),
+		Denied:      isTrue(request.Status.Conditions, conditionDenied),
+		Ready:       isTrue(request.Status.Conditions, ConditionReady),
+		FailureTime: request.Status.FailureTime,
+		Conditions:  toConditions(request.Status.Conditions),
+	}
+	if revision, err := strconv.Atoi(request.Annotations[certificateRevisionAnnotation]); err == nil {
+		result.Revision = revision
+	}
+	if ready := findCondition(request.Status.Conditions, ConditionReady); ready != nil {
+		result.Reason = ready.Reason
+		result.Message = ready.Message
+	}
+	return result
+}
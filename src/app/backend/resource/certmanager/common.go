@@ -0,0 +1,274 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package certmanager serves certificates, issuers and certificate requests managed by
+// cert-manager, when it is installed in the cluster.
+package certmanager
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+// GroupVersion is the group version of cert-manager API.
+var GroupVersion = schema.GroupVersion{Group: "cert-manager.io", Version: "v1"}
+
+// Names of cert-manager resources.
+const (
+	certificateResource        = "certificates"
+	certificateRequestResource = "certificaterequests"
+	issuerResource             = "issuers"
+	clusterIssuerResource      = "clusterissuers"
+)
+
+// Condition types and annotations set by cert-manager.
+const (
+	ConditionReady   = "Ready"
+	ConditionIssuing = "Issuing"
+
+	conditionApproved = "Approved"
+	conditionDenied   = "Denied"
+
+	certificateNameAnnotation     = "cert-manager.io/certificate-name"
+	certificateRevisionAnnotation = "cert-manager.io/certificate-revision"
+)
+
+// Status tells whether cert-manager is installed in the cluster.
+type Status struct {
+	Installed    bool   `json:"installed"`
+	GroupVersion string `json:"groupVersion"`
+
+	// Resources are the cert-manager resources served by the cluster.
+	Resources []string `json:"resources"`
+}
+
+// IssuerReference identifies issuer of a certificate or a certificate request.
+type IssuerReference struct {
+	Name  string `json:"name"`
+	Kind  string `json:"kind"`
+	Group string `json:"group,omitempty"`
+}
+
+// condition is the API representation of cert-manager conditions. Client library does not contain
+// cert-manager types, so only the fields used by Dashboard are declared in this package.
+type condition struct {
+	Type               string             `json:"type"`
+	Status             v1.ConditionStatus `json:"status"`
+	Reason             string             `json:"reason,omitempty"`
+	Message            string             `json:"message,omitempty"`
+	LastTransitionTime *metaV1.Time       `json:"lastTransitionTime,omitempty"`
+}
+
+// GetStatus detects cert-manager by looking for its API group in discovery.
+func GetStatus(client discovery.DiscoveryInterface) (*Status, error) {
+	result := &Status{GroupVersion: GroupVersion.String(), Resources: make([]string, 0)}
+
+	list, err := client.ServerResourcesForGroupVersion(GroupVersion.String())
+	if errorsK8s.IsNotFound(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resource := range list.APIResources {
+		switch resource.Name {
+		case certificateResource, certificateRequestResource, issuerResource, clusterIssuerResource:
+			result.Resources = append(result.Resources, resource.Name)
+		}
+	}
+	sort.Strings(result.Resources)
+	result.Installed = len(result.Resources) > 0
+	return result, nil
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Get() *rest.Request
+}
+
+// newRESTClient creates REST client of cert-manager API.
+func newRESTClient(config *rest.Config) (RESTClient, error) {
+	return apply.NewRESTClient(config, GroupVersion)
+}
+
+// getRaw gets objects of a cert-manager resource. All objects in the namespace are listed if name
+// is empty.
+func getRaw(restClient RESTClient, resource, namespace, name string) ([]byte, error) {
+	request := restClient.Get().Namespace(namespace).Resource(resource)
+	if len(name) > 0 {
+		request = request.Name(name)
+	}
+	raw, err := request.Do().Raw()
+	if errorsK8s.IsNotFound(err) && len(name) == 0 {
+		return nil, errorsK8s.NewNotFound(schema.GroupResource{Group: GroupVersion.Group, Resource: resource},
+			fmt.Sprintf("%s (cert-manager is not installed)", resource))
+	}
+	return raw, err
+}
+
+func toConditions(conditions []condition) []common.Condition {
+	result := make([]common.Condition, 0, len(conditions))
+	for _, c := range conditions {
+		converted := common.Condition{
+			Type:    c.Type,
+			Status:  c.Status,
+			Reason:  c.Reason,
+			Message: c.Message,
+		}
+		if c.LastTransitionTime != nil {
+			converted.LastTransitionTime = *c.LastTransitionTime
+		}
+		result = append(result, converted)
+	}
+	return result
+}
+
+// findCondition returns condition of the type or nil, when it is not reported.
+func findCondition(conditions []condition, conditionType string) *condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// isTrue returns true when condition of the type is reported with true status.
+func isTrue(conditions []condition, conditionType string) bool {
+	c := findCondition(conditions, conditionType)
+	return c != nil && c.Status == v1.ConditionTrue
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// The code below allows to perform complex data section on []Certificate, []CertificateRequest
+// and []Issuer
+
+type CertificateCell Certificate
+
+func (self CertificateCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	case dataselect.StatusProperty:
+		return dataselect.StdComparableInt(boolToInt(self.Ready))
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCertificateCells(std []Certificate) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = CertificateCell(std[i])
+	}
+	return cells
+}
+
+func fromCertificateCells(cells []dataselect.DataCell) []Certificate {
+	std := make([]Certificate, len(cells))
+	for i := range std {
+		std[i] = Certificate(cells[i].(CertificateCell))
+	}
+	return std
+}
+
+type CertificateRequestCell CertificateRequest
+
+func (self CertificateRequestCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	case dataselect.StatusProperty:
+		return dataselect.StdComparableInt(boolToInt(self.Ready))
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCertificateRequestCells(std []CertificateRequest) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = CertificateRequestCell(std[i])
+	}
+	return cells
+}
+
+func fromCertificateRequestCells(cells []dataselect.DataCell) []CertificateRequest {
+	std := make([]CertificateRequest, len(cells))
+	for i := range std {
+		std[i] = CertificateRequest(cells[i].(CertificateRequestCell))
+	}
+	return std
+}
+
+type IssuerCell Issuer
+
+func (self IssuerCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	case dataselect.StatusProperty:
+		return dataselect.StdComparableInt(boolToInt(self.Ready))
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toIssuerCells(std []Issuer) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = IssuerCell(std[i])
+	}
+	return cells
+}
+
+func fromIssuerCells(cells []dataselect.DataCell) []Issuer {
+	std := make([]Issuer, len(cells))
+	for i := range std {
+		std[i] = Issuer(cells[i].(IssuerCell))
+	}
+	return std
+}
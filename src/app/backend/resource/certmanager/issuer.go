@@ -0,0 +1,154 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// Issuer provides the presentation layer view of cert-manager issuers and cluster issuers.
+type Issuer struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Type is the configured issuing backend, e.g. acme, ca, selfSigned or vault.
+	Type string `json:"type"`
+
+	// Server and email of ACME account, set for acme issuers.
+	Server string `json:"server,omitempty"`
+	Email  string `json:"email,omitempty"`
+
+	Ready bool `json:"ready"`
+
+	// Reason and message of the ready condition.
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+
+	Conditions []common.Condition `json:"conditions"`
+}
+
+// IssuerList contains a list of cert-manager issuers and cluster issuers.
+type IssuerList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+	Issuers  []Issuer     `json:"issuers"`
+}
+
+// issuer is the API representation of cert-manager issuer and cluster issuer. Spec holds exactly
+// one issuing backend, so it is kept raw.
+type issuer struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              map[string]json.RawMessage `json:"spec"`
+	Status            issuerStatus               `json:"status"`
+}
+
+type issuerStatus struct {
+	Conditions []condition `json:"conditions"`
+}
+
+type acmeIssuer struct {
+	Server string `json:"server"`
+	Email  string `json:"email"`
+}
+
+type issuerList struct {
+	Items []issuer `json:"items"`
+}
+
+// GetIssuerList returns cert-manager issuers in the namespaces together with cluster issuers,
+// which can be used by certificates in any namespace.
+func GetIssuerList(config *rest.Config, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*IssuerList, error) {
+	logger.Info("Getting list of cert-manager issuers")
+
+	restClient, err := newRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	issuers, err := listIssuers(restClient, issuerResource, nsQuery.ToRequestParam())
+	if err != nil {
+		return nil, err
+	}
+	clusterIssuers, err := listIssuers(restClient, clusterIssuerResource, "")
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]Issuer, 0, len(issuers)+len(clusterIssuers))
+	for _, item := range issuers {
+		if nsQuery.Matches(item.Namespace) {
+			items = append(items, toIssuer(item, api.ResourceKindIssuer))
+		}
+	}
+	for _, item := range clusterIssuers {
+		items = append(items, toIssuer(item, api.ResourceKindClusterIssuer))
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toIssuerCells(items), dsQuery)
+	return &IssuerList{
+		ListMeta: api.ListMeta{TotalItems: filteredTotal},
+		Issuers:  fromIssuerCells(cells),
+	}, nil
+}
+
+func listIssuers(restClient RESTClient, resource, namespace string) ([]issuer, error) {
+	raw, err := getRaw(restClient, resource, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	list := issuerList{}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func toIssuer(item issuer, kind api.ResourceKind) Issuer {
+	result := Issuer{
+		ObjectMeta: api.NewObjectMeta(item.ObjectMeta),
+		TypeMeta:   api.NewTypeMeta(kind),
+		Ready:      isTrue(item.Status.Conditions, ConditionReady),
+		Conditions: toConditions(item.Status.Conditions),
+	}
+
+	types := make([]string, 0, len(item.Spec))
+	for name := range item.Spec {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+	if len(types) > 0 {
+		result.Type = types[0]
+	}
+	if raw, ok := item.Spec["acme"]; ok {
+		acme := acmeIssuer{}
+		if err := json.Unmarshal(raw, &acme); err == nil {
+			result.Server = acme.Server
+			result.Email = acme.Email
+		}
+	}
+
+	if ready := findCondition(item.Status.Conditions, ConditionReady); ready != nil {
+		result.Reason = ready.Reason
+		result.Message = ready.Message
+	}
+	return result
+}
@@ -15,10 +15,9 @@
 package cluster
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/namespace"
@@ -45,7 +44,7 @@ type Cluster struct {
 func GetCluster(client *kubernetes.Clientset, dsQuery *dataselect.DataSelectQuery,
 	metricClient metricapi.MetricClient) (*Cluster, error) {
 
-	log.Print("Getting cluster category")
+	logger.Info("Getting cluster category")
 	channels := &common.ResourceChannels{
 		NamespaceList:        common.GetNamespaceListChannel(client, 1),
 		NodeList:             common.GetNodeListChannel(client, 1),
@@ -0,0 +1,191 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clusterautoscaler reports activity of cluster-autoscaler and state of node groups it
+// scales, from its status ConfigMap and events.
+package clusterautoscaler
+
+import (
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+const (
+	// StatusConfigMapName is the name of ConfigMap cluster-autoscaler writes its status to.
+	StatusConfigMapName = "cluster-autoscaler-status"
+	// DefaultNamespace is the namespace cluster-autoscaler is usually deployed to.
+	DefaultNamespace = "kube-system"
+
+	statusKey = "status"
+
+	// sourceComponent is the source of events reported by cluster-autoscaler.
+	sourceComponent = "cluster-autoscaler"
+
+	// maxActivity limits number of scaling events returned.
+	maxActivity = 50
+)
+
+// Reasons of events reported by cluster-autoscaler on pods.
+const (
+	ReasonTriggeredScaleUp  = "TriggeredScaleUp"
+	ReasonNotTriggerScaleUp = "NotTriggerScaleUp"
+)
+
+// Status is the state of cluster-autoscaler.
+type Status struct {
+	// Installed is true when status ConfigMap or events of cluster-autoscaler are found.
+	Installed bool   `json:"installed"`
+	Namespace string `json:"namespace"`
+
+	// Time when the status was written.
+	Time *metaV1.Time `json:"time,omitempty"`
+
+	// AutoscalerStatus is Initializing or Running. It is reported by cluster-autoscaler since
+	// 1.30 only.
+	AutoscalerStatus string `json:"autoscalerStatus,omitempty"`
+	Message          string `json:"message,omitempty"`
+
+	ClusterWide ClusterWide `json:"clusterWide"`
+	NodeGroups  []NodeGroup `json:"nodeGroups"`
+
+	// Activity are scale-up and scale-down events of node groups and nodes, newest first.
+	Activity []ScalingEvent `json:"activity"`
+
+	// UnschedulablePods are pods, which triggered scale-up or could not trigger it, with their
+	// latest event.
+	UnschedulablePods []ScalingEvent `json:"unschedulablePods"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// ScalingEvent is an event reported by cluster-autoscaler.
+type ScalingEvent struct {
+	Kind      string      `json:"kind"`
+	Namespace string      `json:"namespace"`
+	Name      string      `json:"name"`
+	Reason    string      `json:"reason"`
+	Message   string      `json:"message"`
+	Type      string      `json:"type"`
+	Count     int32       `json:"count"`
+	LastSeen  metaV1.Time `json:"lastSeen"`
+}
+
+// GetStatus returns state of cluster-autoscaler deployed to the namespace. Events are read from all
+// namespaces, failure to read them is reported as non-critical error.
+func GetStatus(client client.Interface, namespace string) (*Status, error) {
+	if len(namespace) == 0 {
+		namespace = DefaultNamespace
+	}
+	logger.Infof("Getting status of cluster-autoscaler in %s namespace", namespace)
+
+	result := &Status{
+		Namespace:         namespace,
+		NodeGroups:        make([]NodeGroup, 0),
+		Activity:          make([]ScalingEvent, 0),
+		UnschedulablePods: make([]ScalingEvent, 0),
+		Errors:            make([]error, 0),
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Get(StatusConfigMapName, metaV1.GetOptions{})
+	if err != nil && !errorsK8s.IsNotFound(err) {
+		nonCriticalErrors, criticalError := errors.HandleError(err)
+		if criticalError != nil {
+			return nil, criticalError
+		}
+		result.Errors = append(result.Errors, nonCriticalErrors...)
+	}
+	if err == nil {
+		result.Installed = true
+		if status, err := parseStatus(configMap.Data[statusKey]); err != nil {
+			logger.Errorf("Invalid status of cluster-autoscaler in %s ConfigMap: %s", StatusConfigMapName, err)
+		} else {
+			result.Time = status.time
+			result.AutoscalerStatus = status.autoscalerStatus
+			result.Message = status.message
+			result.ClusterWide = status.clusterWide
+			result.NodeGroups = status.nodeGroups
+		}
+	}
+
+	events, err := client.CoreV1().Events(v1.NamespaceAll).List(metaV1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("source", sourceComponent).String(),
+	})
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	result.Errors = append(result.Errors, nonCriticalErrors...)
+	if err == nil {
+		result.Activity, result.UnschedulablePods = toScalingEvents(events.Items)
+		result.Installed = result.Installed || len(events.Items) > 0
+	}
+	return result, nil
+}
+
+// toScalingEvents splits events of cluster-autoscaler to scaling activity and latest events of pods
+// waiting for scale-up. Events of evicted pods are skipped, as their nodes are reported already.
+func toScalingEvents(events []v1.Event) ([]ScalingEvent, []ScalingEvent) {
+	activity := make([]ScalingEvent, 0)
+	pods := make(map[string]ScalingEvent)
+	for _, event := range events {
+		if event.Source.Component != sourceComponent {
+			continue
+		}
+		converted := ScalingEvent{
+			Kind:      event.InvolvedObject.Kind,
+			Namespace: event.InvolvedObject.Namespace,
+			Name:      event.InvolvedObject.Name,
+			Reason:    event.Reason,
+			Message:   event.Message,
+			Type:      event.Type,
+			Count:     event.Count,
+			LastSeen:  event.LastTimestamp,
+		}
+
+		switch {
+		case converted.Kind != "Pod":
+			activity = append(activity, converted)
+		case converted.Reason == ReasonTriggeredScaleUp || converted.Reason == ReasonNotTriggerScaleUp:
+			key := converted.Namespace + "/" + converted.Name
+			if latest, ok := pods[key]; !ok || latest.LastSeen.Before(converted.LastSeen) {
+				pods[key] = converted
+			}
+		}
+	}
+
+	sort.SliceStable(activity, func(i, j int) bool { return activity[j].LastSeen.Before(activity[i].LastSeen) })
+	if len(activity) > maxActivity {
+		activity = activity[:maxActivity]
+	}
+
+	unschedulable := make([]ScalingEvent, 0, len(pods))
+	for _, event := range pods {
+		unschedulable = append(unschedulable, event)
+	}
+	sort.Slice(unschedulable, func(i, j int) bool {
+		if unschedulable[i].Namespace != unschedulable[j].Namespace {
+			return unschedulable[i].Namespace < unschedulable[j].Namespace
+		}
+		return unschedulable[i].Name < unschedulable[j].Name
+	})
+	return activity, unschedulable
+}
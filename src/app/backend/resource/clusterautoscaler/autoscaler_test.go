@@ -0,0 +1,90 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterautoscaler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func newEvent(name, kind, namespace, object, reason string, minute int) *v1.Event {
+	return &v1.Event{
+		ObjectMeta:     metaV1.ObjectMeta{Name: name, Namespace: namespace},
+		InvolvedObject: v1.ObjectReference{Kind: kind, Namespace: namespace, Name: object},
+		Reason:         reason,
+		Source:         v1.EventSource{Component: sourceComponent},
+		LastTimestamp:  metaV1.NewTime(time.Date(2021, 6, 1, 12, minute, 0, 0, time.UTC)),
+	}
+}
+
+func TestGetStatus(t *testing.T) {
+	scheduler := newEvent("scheduled", "Pod", "shop", "web-1", "Scheduled", 9)
+	scheduler.Source.Component = "default-scheduler"
+
+	client := fake.NewSimpleClientset(
+		&v1.ConfigMap{
+			ObjectMeta: metaV1.ObjectMeta{Name: StatusConfigMapName, Namespace: DefaultNamespace},
+			Data:       map[string]string{statusKey: testTextStatus},
+		},
+		newEvent("scale-up", "ConfigMap", DefaultNamespace, StatusConfigMapName, "ScaledUpGroup", 1),
+		newEvent("scale-down", "Node", "", "node-3", "ScaleDown", 5),
+		newEvent("web-1-old", "Pod", "shop", "web-1", ReasonNotTriggerScaleUp, 0),
+		newEvent("web-1-new", "Pod", "shop", "web-1", ReasonTriggeredScaleUp, 2),
+		newEvent("evicted", "Pod", "shop", "cache-0", "ScaleDown", 6),
+		newEvent("batch", "Pod", "batch", "job-1", ReasonNotTriggerScaleUp, 3),
+		scheduler,
+	)
+
+	status, err := GetStatus(client, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Installed || status.Namespace != DefaultNamespace || len(status.NodeGroups) != 2 ||
+		status.ClusterWide.ScaleUp.Status != "InProgress" || len(status.Errors) != 0 {
+		t.Fatalf("GetStatus() returns %#v", status)
+	}
+
+	actual := make([]string, 0)
+	for _, event := range status.Activity {
+		actual = append(actual, event.Reason)
+	}
+	if expected := []string{"ScaleDown", "ScaledUpGroup"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetStatus() returns activity %v, expected %v", actual, expected)
+	}
+
+	actual = make([]string, 0)
+	for _, event := range status.UnschedulablePods {
+		actual = append(actual, event.Namespace+"/"+event.Name+" "+event.Reason)
+	}
+	expected := []string{"batch/job-1 NotTriggerScaleUp", "shop/web-1 TriggeredScaleUp"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetStatus() returns unschedulable pods %v, expected %v", actual, expected)
+	}
+}
+
+func TestGetStatusNotInstalled(t *testing.T) {
+	status, err := GetStatus(fake.NewSimpleClientset(), "autoscaler")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Installed || status.Namespace != "autoscaler" || len(status.NodeGroups) != 0 {
+		t.Errorf("GetStatus() returns %#v, expected cluster-autoscaler not to be installed", status)
+	}
+}
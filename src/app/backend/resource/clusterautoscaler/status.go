@@ -0,0 +1,332 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterautoscaler
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// textStatusPrefix starts status written by cluster-autoscaler before 1.30, which uses plain
+// text. Newer versions write YAML.
+const textStatusPrefix = "Cluster-autoscaler status at "
+
+// statusTimeLayout is the layout of times in the text status, the same as of time.Time String.
+const statusTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// Condition is a health, scale-up or scale-down condition of the cluster or a node group.
+type Condition struct {
+	// Status is e.g. Healthy or Unhealthy for health, NoActivity, InProgress or Backoff for
+	// scale-up and NoCandidates or CandidatesPresent for scale-down.
+	Status string `json:"status"`
+
+	// Details are the values reported next to the status in the text format.
+	Details string `json:"details,omitempty"`
+
+	LastProbeTime      *metaV1.Time `json:"lastProbeTime,omitempty"`
+	LastTransitionTime *metaV1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// NodeCounts are numbers of nodes of the cluster or a node group by their state.
+type NodeCounts struct {
+	Registered       int `json:"registered"`
+	Ready            int `json:"ready"`
+	Unready          int `json:"unready"`
+	NotStarted       int `json:"notStarted"`
+	LongUnregistered int `json:"longUnregistered"`
+}
+
+// ClusterWide is the state of the whole cluster.
+type ClusterWide struct {
+	Health     Condition  `json:"health"`
+	NodeCounts NodeCounts `json:"nodeCounts"`
+	ScaleUp    Condition  `json:"scaleUp"`
+	ScaleDown  Condition  `json:"scaleDown"`
+
+	// ScaleDownCandidates is the number of nodes considered for removal.
+	ScaleDownCandidates int `json:"scaleDownCandidates"`
+}
+
+// NodeGroup is the state of a node group, e.g. an auto scaling group or a managed node pool.
+type NodeGroup struct {
+	Name       string     `json:"name"`
+	Health     Condition  `json:"health"`
+	NodeCounts NodeCounts `json:"nodeCounts"`
+
+	// Target is the current size of the node group in the cloud provider.
+	Target  int `json:"target"`
+	MinSize int `json:"minSize"`
+	MaxSize int `json:"maxSize"`
+
+	ScaleUp   Condition `json:"scaleUp"`
+	ScaleDown Condition `json:"scaleDown"`
+
+	ScaleDownCandidates int `json:"scaleDownCandidates"`
+
+	// BackoffError is the reason of the last failed scale-up, while the node group is backed off.
+	BackoffError string `json:"backoffError,omitempty"`
+}
+
+// autoscalerStatus is status written by cluster-autoscaler since 1.30. Only the fields used by
+// Dashboard are declared.
+type autoscalerStatus struct {
+	Time             string             `json:"time"`
+	AutoscalerStatus string             `json:"autoscalerStatus"`
+	Message          string             `json:"message"`
+	ClusterWide      groupStatus        `json:"clusterWide"`
+	NodeGroups       []namedGroupStatus `json:"nodeGroups"`
+}
+
+type namedGroupStatus struct {
+	Name        string `json:"name"`
+	groupStatus `json:",inline"`
+}
+
+type groupStatus struct {
+	Health    healthCondition    `json:"health"`
+	ScaleUp   scaleUpCondition   `json:"scaleUp"`
+	ScaleDown scaleDownCondition `json:"scaleDown"`
+}
+
+type condition struct {
+	Status             string       `json:"status"`
+	LastProbeTime      *metaV1.Time `json:"lastProbeTime"`
+	LastTransitionTime *metaV1.Time `json:"lastTransitionTime"`
+}
+
+type healthCondition struct {
+	condition           `json:",inline"`
+	NodeCounts          nodeCounts `json:"nodeCounts"`
+	CloudProviderTarget int        `json:"cloudProviderTarget"`
+	MinSize             int        `json:"minSize"`
+	MaxSize             int        `json:"maxSize"`
+}
+
+type nodeCounts struct {
+	Registered struct {
+		Total      int `json:"total"`
+		Ready      int `json:"ready"`
+		NotStarted int `json:"notStarted"`
+		Unready    struct {
+			Total int `json:"total"`
+		} `json:"unready"`
+	} `json:"registered"`
+	LongUnregistered int `json:"longUnregistered"`
+}
+
+type scaleUpCondition struct {
+	condition   `json:",inline"`
+	BackoffInfo struct {
+		ErrorCode    string `json:"errorCode"`
+		ErrorMessage string `json:"errorMessage"`
+	} `json:"backoffInfo"`
+}
+
+type scaleDownCondition struct {
+	condition  `json:",inline"`
+	Candidates int `json:"candidates"`
+}
+
+// parsedStatus is the content of the status ConfigMap in either format.
+type parsedStatus struct {
+	time             *metaV1.Time
+	autoscalerStatus string
+	message          string
+	clusterWide      ClusterWide
+	nodeGroups       []NodeGroup
+}
+
+// parseStatus parses content of the status ConfigMap written by any version of cluster-autoscaler.
+func parseStatus(content string) (*parsedStatus, error) {
+	if strings.HasPrefix(strings.TrimSpace(content), textStatusPrefix) {
+		return parseTextStatus(content), nil
+	}
+
+	status := autoscalerStatus{}
+	if err := yaml.Unmarshal([]byte(content), &status); err != nil {
+		return nil, err
+	}
+	result := &parsedStatus{
+		time:             parseStatusTime(status.Time),
+		autoscalerStatus: status.AutoscalerStatus,
+		message:          status.Message,
+		clusterWide: ClusterWide{
+			Health:              status.ClusterWide.Health.toCondition(),
+			NodeCounts:          status.ClusterWide.Health.NodeCounts.toNodeCounts(),
+			ScaleUp:             status.ClusterWide.ScaleUp.toCondition(),
+			ScaleDown:           status.ClusterWide.ScaleDown.toCondition(),
+			ScaleDownCandidates: status.ClusterWide.ScaleDown.Candidates,
+		},
+		nodeGroups: make([]NodeGroup, 0, len(status.NodeGroups)),
+	}
+	for _, group := range status.NodeGroups {
+		nodeGroup := NodeGroup{
+			Name:                group.Name,
+			Health:              group.Health.toCondition(),
+			NodeCounts:          group.Health.NodeCounts.toNodeCounts(),
+			Target:              group.Health.CloudProviderTarget,
+			MinSize:             group.Health.MinSize,
+			MaxSize:             group.Health.MaxSize,
+			ScaleUp:             group.ScaleUp.toCondition(),
+			ScaleDown:           group.ScaleDown.toCondition(),
+			ScaleDownCandidates: group.ScaleDown.Candidates,
+			BackoffError:        group.ScaleUp.BackoffInfo.ErrorMessage,
+		}
+		if len(nodeGroup.BackoffError) == 0 {
+			nodeGroup.BackoffError = group.ScaleUp.BackoffInfo.ErrorCode
+		}
+		result.nodeGroups = append(result.nodeGroups, nodeGroup)
+	}
+	return result, nil
+}
+
+func (self condition) toCondition() Condition {
+	return Condition{
+		Status:             self.Status,
+		LastProbeTime:      self.LastProbeTime,
+		LastTransitionTime: self.LastTransitionTime,
+	}
+}
+
+func (self nodeCounts) toNodeCounts() NodeCounts {
+	return NodeCounts{
+		Registered:       self.Registered.Total,
+		Ready:            self.Registered.Ready,
+		Unready:          self.Registered.Unready.Total,
+		NotStarted:       self.Registered.NotStarted,
+		LongUnregistered: self.LongUnregistered,
+	}
+}
+
+// countPattern matches counts reported in details of conditions in the text format, e.g.
+// ready=3 or (minSize=1.
+var countPattern = regexp.MustCompile(`(\w+)=(\d+)`)
+
+// parseTextStatus parses status in the text format. Each condition is a line with the status
+// followed by counts, and lines with probe and transition times:
+//
+//	Health:      Healthy (ready=3 unready=0 ... cloudProviderTarget=3 (minSize=1, maxSize=5))
+//	             LastProbeTime:      2021-06-01 12:00:00.1 +0000 UTC m=+3600.1
+//	             LastTransitionTime: 2021-05-01 12:00:00.1 +0000 UTC m=+0.1
+func parseTextStatus(content string) *parsedStatus {
+	result := &parsedStatus{nodeGroups: make([]NodeGroup, 0)}
+
+	var group *NodeGroup
+	var current *Condition
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, textStatusPrefix) {
+			result.time = parseStatusTime(strings.TrimSuffix(strings.TrimPrefix(line, textStatusPrefix), ":"))
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key, value := parts[0], strings.TrimSpace(parts[1])
+		switch key {
+		case "Name":
+			result.nodeGroups = append(result.nodeGroups, NodeGroup{Name: value})
+			group = &result.nodeGroups[len(result.nodeGroups)-1]
+		case "Health", "ScaleUp", "ScaleDown":
+			current = parseTextCondition(result, group, key, value)
+		case "LastProbeTime":
+			if current != nil {
+				current.LastProbeTime = parseStatusTime(value)
+			}
+		case "LastTransitionTime":
+			if current != nil {
+				current.LastTransitionTime = parseStatusTime(value)
+			}
+		}
+	}
+	return result
+}
+
+// parseTextCondition sets condition of the node group or, if it is nil, of the whole cluster, and
+// returns it, so that times on the following lines can be set.
+func parseTextCondition(status *parsedStatus, group *NodeGroup, key, value string) *Condition {
+	parsed := Condition{Status: value}
+	if i := strings.Index(value, " "); i > 0 {
+		parsed.Status = value[:i]
+		parsed.Details = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(value[i:]), "("), ")")
+	}
+	counts := make(map[string]int)
+	for _, match := range countPattern.FindAllStringSubmatch(parsed.Details, -1) {
+		counts[match[1]], _ = strconv.Atoi(match[2])
+	}
+	nodeCounts := NodeCounts{
+		Registered:       counts["registered"],
+		Ready:            counts["ready"],
+		Unready:          counts["unready"],
+		NotStarted:       counts["notStarted"],
+		LongUnregistered: counts["longUnregistered"],
+	}
+
+	if group == nil {
+		switch key {
+		case "Health":
+			status.clusterWide.Health = parsed
+			status.clusterWide.NodeCounts = nodeCounts
+			return &status.clusterWide.Health
+		case "ScaleUp":
+			status.clusterWide.ScaleUp = parsed
+			return &status.clusterWide.ScaleUp
+		default:
+			status.clusterWide.ScaleDown = parsed
+			status.clusterWide.ScaleDownCandidates = counts["candidates"]
+			return &status.clusterWide.ScaleDown
+		}
+	}
+
+	switch key {
+	case "Health":
+		group.Health = parsed
+		group.NodeCounts = nodeCounts
+		group.Target = counts["cloudProviderTarget"]
+		group.MinSize = counts["minSize"]
+		group.MaxSize = counts["maxSize"]
+		return &group.Health
+	case "ScaleUp":
+		group.ScaleUp = parsed
+		return &group.ScaleUp
+	default:
+		group.ScaleDown = parsed
+		group.ScaleDownCandidates = counts["candidates"]
+		return &group.ScaleDown
+	}
+}
+
+// parseStatusTime parses time written by cluster-autoscaler. Monotonic clock reading, added by
+// older versions, is ignored. Nil is returned for invalid times.
+func parseStatusTime(value string) *metaV1.Time {
+	if i := strings.Index(value, " m="); i > 0 {
+		value = value[:i]
+	}
+	parsed, err := time.Parse(statusTimeLayout, strings.TrimSpace(value))
+	if err != nil {
+		return nil
+	}
+	result := metaV1.NewTime(parsed)
+	return &result
+}
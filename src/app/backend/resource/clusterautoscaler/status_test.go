@@ -0,0 +1,175 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterautoscaler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testTextStatus = `Cluster-autoscaler status at 2021-06-01 12:00:00.123456789 +0000 UTC:
+Cluster-wide:
+  Health:      Healthy (ready=4 unready=1 (resourceUnready=0) notStarted=0 longNotStarted=0 registered=5 longUnregistered=0)
+               LastProbeTime:      2021-06-01 12:00:00.1 +0000 UTC m=+3600.000000001
+               LastTransitionTime: 2021-05-01 08:00:00 +0000 UTC m=+0.5
+  ScaleUp:     InProgress (ready=4 registered=5)
+               LastProbeTime:      2021-06-01 12:00:00.1 +0000 UTC m=+3600.000000001
+               LastTransitionTime: 2021-06-01 11:58:00 +0000 UTC m=+3480.2
+  ScaleDown:   CandidatesPresent (candidates=1)
+               LastProbeTime:      2021-06-01 12:00:00.1 +0000 UTC m=+3600.000000001
+               LastTransitionTime: 2021-06-01 11:00:00 +0000 UTC m=+0.3
+
+NodeGroups:
+  Name:        pool-cpu
+  Health:      Healthy (ready=3 unready=0 (resourceUnready=0) notStarted=0 longNotStarted=0 registered=3 longUnregistered=0 cloudProviderTarget=4 (minSize=1, maxSize=10))
+               LastProbeTime:      2021-06-01 12:00:00.1 +0000 UTC m=+3600.000000001
+               LastTransitionTime: 2021-05-01 08:00:00 +0000 UTC m=+0.5
+  ScaleUp:     InProgress (ready=3 cloudProviderTarget=4)
+               LastProbeTime:      2021-06-01 12:00:00.1 +0000 UTC m=+3600.000000001
+               LastTransitionTime: 2021-06-01 11:58:00 +0000 UTC m=+3480.2
+  ScaleDown:   NoCandidates (candidates=0)
+               LastProbeTime:      2021-06-01 12:00:00.1 +0000 UTC m=+3600.000000001
+               LastTransitionTime: 2021-05-01 08:00:00 +0000 UTC m=+0.5
+
+  Name:        pool-gpu
+  Health:      Healthy (ready=1 unready=1 (resourceUnready=0) notStarted=0 longNotStarted=0 registered=2 longUnregistered=0 cloudProviderTarget=2 (minSize=0, maxSize=2))
+               LastProbeTime:      2021-06-01 12:00:00.1 +0000 UTC m=+3600.000000001
+               LastTransitionTime: 2021-05-01 08:00:00 +0000 UTC m=+0.5
+  ScaleUp:     NoActivity (ready=1 cloudProviderTarget=2)
+               LastProbeTime:      2021-06-01 12:00:00.1 +0000 UTC m=+3600.000000001
+               LastTransitionTime: 2021-05-01 08:00:00 +0000 UTC m=+0.5
+  ScaleDown:   CandidatesPresent (candidates=1)
+               LastProbeTime:      2021-06-01 12:00:00.1 +0000 UTC m=+3600.000000001
+               LastTransitionTime: 2021-06-01 11:00:00 +0000 UTC m=+0.3
+`
+
+const testYAMLStatus = `time: 2024-06-01 12:00:00.5 +0000 UTC
+autoscalerStatus: Running
+clusterWide:
+  health:
+    status: Healthy
+    nodeCounts:
+      registered:
+        total: 5
+        ready: 4
+        notStarted: 0
+        unready:
+          total: 1
+          resourceUnready: 0
+      longUnregistered: 0
+      unregistered: 0
+    lastProbeTime: "2024-06-01T12:00:00Z"
+    lastTransitionTime: "2024-05-01T08:00:00Z"
+  scaleUp:
+    status: Backoff
+    lastProbeTime: "2024-06-01T12:00:00Z"
+    lastTransitionTime: "2024-06-01T11:58:00Z"
+  scaleDown:
+    status: NoCandidates
+    candidates: 0
+    lastProbeTime: "2024-06-01T12:00:00Z"
+    lastTransitionTime: "2024-05-01T08:00:00Z"
+nodeGroups:
+- name: pool-gpu
+  health:
+    status: Healthy
+    nodeCounts:
+      registered:
+        total: 2
+        ready: 2
+    cloudProviderTarget: 2
+    minSize: 0
+    maxSize: 4
+  scaleUp:
+    status: Backoff
+    backoffInfo:
+      errorCode: OutOfResource
+      errorMessage: GPUs are not available in the zone
+  scaleDown:
+    status: NoCandidates
+    candidates: 0
+`
+
+func TestParseTextStatus(t *testing.T) {
+	status, err := parseStatus(testTextStatus)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status.time == nil || !status.time.Time.Equal(time.Date(2021, 6, 1, 12, 0, 0, 123456789, time.UTC)) {
+		t.Errorf("parseStatus() returns time %v", status.time)
+	}
+	cluster := status.clusterWide
+	if cluster.Health.Status != "Healthy" || cluster.ScaleUp.Status != "InProgress" ||
+		cluster.ScaleDown.Status != "CandidatesPresent" || cluster.ScaleDownCandidates != 1 ||
+		cluster.NodeCounts != (NodeCounts{Registered: 5, Ready: 4, Unready: 1}) {
+		t.Errorf("parseStatus() returns %#v for cluster", cluster)
+	}
+	if cluster.ScaleUp.LastTransitionTime == nil ||
+		!cluster.ScaleUp.LastTransitionTime.Time.Equal(time.Date(2021, 6, 1, 11, 58, 0, 0, time.UTC)) {
+		t.Errorf("parseStatus() returns scale-up transition time %v", cluster.ScaleUp.LastTransitionTime)
+	}
+
+	actual := make([][]int, 0)
+	for _, group := range status.nodeGroups {
+		actual = append(actual, []int{group.NodeCounts.Ready, group.Target, group.MinSize, group.MaxSize,
+			group.ScaleDownCandidates})
+	}
+	if expected := [][]int{{3, 4, 1, 10, 0}, {1, 2, 0, 2, 1}}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("parseStatus() returns node groups with counts %v, expected %v", actual, expected)
+	}
+	if group := status.nodeGroups[0]; group.Name != "pool-cpu" || group.ScaleUp.Status != "InProgress" ||
+		group.ScaleUp.Details != "ready=3 cloudProviderTarget=4" || group.ScaleUp.LastProbeTime == nil {
+		t.Errorf("parseStatus() returns %#v for pool-cpu node group", group)
+	}
+}
+
+func TestParseYAMLStatus(t *testing.T) {
+	status, err := parseStatus(testYAMLStatus)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status.time == nil || status.autoscalerStatus != "Running" {
+		t.Errorf("parseStatus() returns time %v and autoscaler status %q", status.time, status.autoscalerStatus)
+	}
+	cluster := status.clusterWide
+	if cluster.Health.Status != "Healthy" || cluster.ScaleUp.Status != "Backoff" ||
+		cluster.NodeCounts != (NodeCounts{Registered: 5, Ready: 4, Unready: 1}) ||
+		cluster.Health.LastTransitionTime == nil {
+		t.Errorf("parseStatus() returns %#v for cluster", cluster)
+	}
+
+	expected := []NodeGroup{{
+		Name:         "pool-gpu",
+		Health:       Condition{Status: "Healthy"},
+		NodeCounts:   NodeCounts{Registered: 2, Ready: 2},
+		Target:       2,
+		MaxSize:      4,
+		ScaleUp:      Condition{Status: "Backoff"},
+		ScaleDown:    Condition{Status: "NoCandidates"},
+		BackoffError: "GPUs are not available in the zone",
+	}}
+	if !reflect.DeepEqual(status.nodeGroups, expected) {
+		t.Errorf("parseStatus() returns node groups \n%#v, expected \n%#v", status.nodeGroups, expected)
+	}
+}
+
+func TestParseInvalidStatus(t *testing.T) {
+	if _, err := parseStatus("clusterWide: ["); err == nil {
+		t.Error("parseStatus() returns no error for invalid status")
+	}
+}
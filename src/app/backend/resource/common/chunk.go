@@ -0,0 +1,114 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"k8s.io/client-go/rest"
+)
+
+// ChunkMeta is the part of list metadata that describes a chunk of the list.
+type ChunkMeta struct {
+	// Continue is the token to fetch the next chunk. Empty token means that there are no more
+	// items.
+	Continue string `json:"continue"`
+	// RemainingItemCount is the number of items that follow the chunk. Nil if the apiserver does
+	// not report it.
+	RemainingItemCount *int64 `json:"remainingItemCount"`
+}
+
+// listChunkMeta is the part of the list that holds chunk metadata.
+type listChunkMeta struct {
+	Metadata ChunkMeta `json:"metadata"`
+}
+
+// ListChunk selects a single chunk of a list fetched from the apiserver, e.g. through resource
+// channels. Metadata of the chunk is stored in it when the chunk is fetched, i.e. before the list
+// is sent to the channel. Nil ListChunk selects whole list.
+type ListChunk struct {
+	query *dataselect.ChunkQuery
+	meta  ChunkMeta
+}
+
+// NewListChunk returns ListChunk selected by the query. Nil is returned if the query does not
+// enable chunking.
+func NewListChunk(query *dataselect.ChunkQuery) *ListChunk {
+	if !query.IsEnabled() {
+		return nil
+	}
+	return &ListChunk{query: query}
+}
+
+// IsEnabled returns true if a single chunk of the list should be fetched.
+func (self *ListChunk) IsEnabled() bool {
+	return self != nil
+}
+
+// List fetches the chunk of resources from the apiserver into the list and stores its metadata,
+// including token to fetch the next one. Namespace query is nil for resources that are not
+// namespaced. Apiservers that do not support chunking ignore the limit and return all items at
+// once.
+func (self *ListChunk) List(restClient rest.Interface, resource string, nsQuery *NamespaceQuery,
+	list interface{}) error {
+	namespace := ""
+	if nsQuery != nil {
+		namespace = nsQuery.ToRequestParam()
+	}
+	request := restClient.Get().
+		NamespaceIfScoped(namespace, len(namespace) > 0).
+		Resource(resource).
+		Param("limit", strconv.FormatInt(self.query.Limit, 10)).
+		// Continue token is only available in JSON representation of the list.
+		SetHeader("Accept", "application/json")
+	if len(self.query.Continue) > 0 {
+		request = request.Param("continue", self.query.Continue)
+	}
+
+	raw, err := request.Do().Raw()
+	if err != nil {
+		return err
+	}
+
+	self.meta, err = decodeListChunk(raw, list)
+	return err
+}
+
+// SetListMeta sets token to fetch the next chunk and the number of remaining items to the list
+// metadata. It does nothing if whole list was fetched.
+func (self *ListChunk) SetListMeta(listMeta *api.ListMeta) {
+	if !self.IsEnabled() {
+		return
+	}
+	listMeta.Continue = self.meta.Continue
+	listMeta.RemainingItemCount = self.meta.RemainingItemCount
+}
+
+// decodeListChunk decodes list returned by the apiserver and its chunk metadata.
+func decodeListChunk(raw []byte, list interface{}) (ChunkMeta, error) {
+	if err := json.Unmarshal(raw, list); err != nil {
+		return ChunkMeta{}, err
+	}
+
+	meta := listChunkMeta{}
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return ChunkMeta{}, err
+	}
+
+	return meta.Metadata, nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+func TestDecodeListChunk(t *testing.T) {
+	remaining := int64(1)
+	cases := []struct {
+		raw               string
+		expectedToken     string
+		expectedRemaining *int64
+		expectedNames     []string
+	}{
+		{
+			`{"kind":"PodList","metadata":{"continue":"token-2","remainingItemCount":1},` +
+				`"items":[{"metadata":{"name":"pod-1"}},{"metadata":{"name":"pod-2"}}]}`,
+			"token-2",
+			&remaining,
+			[]string{"pod-1", "pod-2"},
+		},
+		{
+			`{"kind":"PodList","metadata":{},"items":[{"metadata":{"name":"pod-3"}}]}`,
+			"",
+			nil,
+			[]string{"pod-3"},
+		},
+	}
+
+	for _, c := range cases {
+		list := &v1.PodList{}
+		meta, err := decodeListChunk([]byte(c.raw), list)
+		if err != nil {
+			t.Fatalf("decodeListChunk(%#v) returns unexpected error: %v", c.raw, err)
+		}
+
+		names := []string{}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+		if meta.Continue != c.expectedToken || !reflect.DeepEqual(meta.RemainingItemCount, c.expectedRemaining) ||
+			!reflect.DeepEqual(names, c.expectedNames) {
+			t.Errorf("decodeListChunk(%#v) returns %#v and %#v, expected %#v, %v and %#v", c.raw, meta,
+				names, c.expectedToken, c.expectedRemaining, c.expectedNames)
+		}
+	}
+
+	if _, err := decodeListChunk([]byte("not json"), &v1.PodList{}); err == nil {
+		t.Error("decodeListChunk() should return error for invalid list")
+	}
+}
+
+func TestGetDeploymentListChunkChannel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/apis/extensions/v1beta1/namespaces/default/deployments" ||
+			query.Get("limit") != "2" || query.Get("continue") != "token-1" {
+			t.Errorf("Unexpected request of %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"DeploymentList","apiVersion":"extensions/v1beta1",` +
+			`"metadata":{"continue":"token-2","remainingItemCount":3},` +
+			`"items":[{"metadata":{"name":"deployment-1","namespace":"default"}},` +
+			`{"metadata":{"name":"deployment-2","namespace":"default"}}]}`))
+	}))
+	defer server.Close()
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("Cannot create client: %v", err)
+	}
+
+	chunk := NewListChunk(dataselect.NewChunkQuery(2, "token-1"))
+	channel := GetDeploymentListChunkChannel(client, NewSameNamespaceQuery("default"), chunk, 1)
+	list := <-channel.List
+	if err := <-channel.Error; err != nil {
+		t.Fatalf("GetDeploymentListChunkChannel() returns unexpected error: %v", err)
+	}
+	if len(list.Items) != 2 || list.Items[0].Name != "deployment-1" {
+		t.Errorf("GetDeploymentListChunkChannel() returns %#v, expected 2 deployments", list.Items)
+	}
+
+	listMeta := api.ListMeta{TotalItems: 2}
+	chunk.SetListMeta(&listMeta)
+	if listMeta.Continue != "token-2" || listMeta.RemainingItemCount == nil ||
+		*listMeta.RemainingItemCount != 3 {
+		t.Errorf("SetListMeta() sets %#v, expected continue token and remaining items of the chunk",
+			listMeta)
+	}
+}
+
+func TestNewListChunk(t *testing.T) {
+	if chunk := NewListChunk(dataselect.NoChunk); chunk.IsEnabled() {
+		t.Errorf("NewListChunk(NoChunk) returns enabled chunk %#v", chunk)
+	}
+	if chunk := NewListChunk(nil); chunk.IsEnabled() {
+		t.Errorf("NewListChunk(nil) returns enabled chunk %#v", chunk)
+	}
+
+	listMeta := api.ListMeta{TotalItems: 2}
+	NewListChunk(dataselect.NoChunk).SetListMeta(&listMeta)
+	if listMeta != (api.ListMeta{TotalItems: 2}) {
+		t.Errorf("SetListMeta() of disabled chunk changes list metadata to %#v", listMeta)
+	}
+}
+
+func TestGetChunkEventListChannel(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Event{
+		ObjectMeta: metaV1.ObjectMeta{Name: "event", Namespace: "default"},
+	})
+
+	chunk := NewListChunk(dataselect.NewChunkQuery(10, ""))
+	channel := GetChunkEventListChannel(client, NewSameNamespaceQuery("default"), chunk, 2)
+	for i := 0; i < 2; i++ {
+		if list, err := <-channel.List, <-channel.Error; err != nil || len(list.Items) != 0 {
+			t.Errorf("GetChunkEventListChannel() returns %#v and %v, expected no events", list, err)
+		}
+	}
+	if actions := client.Actions(); len(actions) != 0 {
+		t.Errorf("GetChunkEventListChannel() lists events of chunk: %#v", actions)
+	}
+
+	channel = GetChunkEventListChannel(client, NewSameNamespaceQuery("default"), nil, 1)
+	if list := <-channel.List; len(list.Items) != 1 {
+		t.Errorf("GetChunkEventListChannel() returns %#v for whole list, expected 1 event", list)
+	}
+}
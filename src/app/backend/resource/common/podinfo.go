@@ -14,7 +14,10 @@
 
 package common
 
-import api "k8s.io/client-go/pkg/api/v1"
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diagnosis"
+	api "k8s.io/client-go/pkg/api/v1"
+)
 
 // PodInfo represents aggregate information about controller's pods.
 type PodInfo struct {
@@ -38,6 +41,9 @@ type PodInfo struct {
 
 	// Unique warning messages related to pods in this resource.
 	Warnings []Event `json:"warnings"`
+
+	// Diagnosis of problems of pods in this resource. Nil when all pods are healthy.
+	Diagnosis *diagnosis.Diagnosis `json:"diagnosis"`
 }
 
 // GetPodInfo returns aggregate information about a group of pods.
@@ -0,0 +1,119 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// PodTemplateKinds are kinds of workloads, which have a pod template that can be changed by
+// UpdatePodTemplate.
+var PodTemplateKinds = []api.ResourceKind{
+	api.ResourceKindDeployment,
+	api.ResourceKindReplicaSet,
+	api.ResourceKindReplicationController,
+	api.ResourceKindDaemonSet,
+	api.ResourceKindStatefulSet,
+	api.ResourceKindJob,
+	api.ResourceKindCronJob,
+}
+
+// UpdatePodTemplate gets the workload, changes its pod template with the update function and
+// updates the workload. Returns bad request error for kinds without pod template.
+func UpdatePodTemplate(client client.Interface, kind api.ResourceKind, namespace, name string,
+	update func(*v1.PodTemplateSpec) error) error {
+	switch kind {
+	case api.ResourceKindDeployment:
+		deployment, err := client.ExtensionsV1beta1().Deployments(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := update(&deployment.Spec.Template); err != nil {
+			return err
+		}
+		_, err = client.ExtensionsV1beta1().Deployments(namespace).Update(deployment)
+		return err
+	case api.ResourceKindReplicaSet:
+		replicaSet, err := client.ExtensionsV1beta1().ReplicaSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := update(&replicaSet.Spec.Template); err != nil {
+			return err
+		}
+		_, err = client.ExtensionsV1beta1().ReplicaSets(namespace).Update(replicaSet)
+		return err
+	case api.ResourceKindReplicationController:
+		rc, err := client.CoreV1().ReplicationControllers(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if rc.Spec.Template == nil {
+			rc.Spec.Template = &v1.PodTemplateSpec{}
+		}
+		if err := update(rc.Spec.Template); err != nil {
+			return err
+		}
+		_, err = client.CoreV1().ReplicationControllers(namespace).Update(rc)
+		return err
+	case api.ResourceKindDaemonSet:
+		daemonSet, err := client.ExtensionsV1beta1().DaemonSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := update(&daemonSet.Spec.Template); err != nil {
+			return err
+		}
+		_, err = client.ExtensionsV1beta1().DaemonSets(namespace).Update(daemonSet)
+		return err
+	case api.ResourceKindStatefulSet:
+		statefulSet, err := client.AppsV1beta1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := update(&statefulSet.Spec.Template); err != nil {
+			return err
+		}
+		_, err = client.AppsV1beta1().StatefulSets(namespace).Update(statefulSet)
+		return err
+	case api.ResourceKindJob:
+		job, err := client.BatchV1().Jobs(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := update(&job.Spec.Template); err != nil {
+			return err
+		}
+		_, err = client.BatchV1().Jobs(namespace).Update(job)
+		return err
+	case api.ResourceKindCronJob:
+		cronJob, err := client.BatchV2alpha1().CronJobs(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := update(&cronJob.Spec.JobTemplate.Spec.Template); err != nil {
+			return err
+		}
+		_, err = client.BatchV2alpha1().CronJobs(namespace).Update(cronJob)
+		return err
+	}
+	return errorsK8s.NewBadRequest(fmt.Sprintf("%s has no pod template", kind))
+}
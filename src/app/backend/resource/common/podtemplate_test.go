@@ -0,0 +1,69 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	apps "k8s.io/client-go/pkg/apis/apps/v1beta1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func TestUpdatePodTemplate(t *testing.T) {
+	meta := metaV1.ObjectMeta{Name: "app", Namespace: "ns"}
+	client := fake.NewSimpleClientset(
+		&extensions.Deployment{ObjectMeta: meta},
+		&apps.StatefulSet{ObjectMeta: meta},
+		&v1.ReplicationController{ObjectMeta: meta},
+	)
+	addLabel := func(template *v1.PodTemplateSpec) error {
+		template.Labels = map[string]string{"updated": "true"}
+		return nil
+	}
+	expected := map[string]string{"updated": "true"}
+
+	for _, kind := range []api.ResourceKind{api.ResourceKindDeployment, api.ResourceKindStatefulSet,
+		api.ResourceKindReplicationController} {
+		if err := UpdatePodTemplate(client, kind, "ns", "app", addLabel); err != nil {
+			t.Errorf("UpdatePodTemplate(%s) returned error: %s", kind, err)
+		}
+	}
+
+	deployment, _ := client.ExtensionsV1beta1().Deployments("ns").Get("app", metaV1.GetOptions{})
+	if !reflect.DeepEqual(deployment.Spec.Template.Labels, expected) {
+		t.Errorf("Expected deployment template labels %v, got %v", expected, deployment.Spec.Template.Labels)
+	}
+	statefulSet, _ := client.AppsV1beta1().StatefulSets("ns").Get("app", metaV1.GetOptions{})
+	if !reflect.DeepEqual(statefulSet.Spec.Template.Labels, expected) {
+		t.Errorf("Expected stateful set template labels %v, got %v", expected, statefulSet.Spec.Template.Labels)
+	}
+	rc, _ := client.CoreV1().ReplicationControllers("ns").Get("app", metaV1.GetOptions{})
+	if !reflect.DeepEqual(rc.Spec.Template.Labels, expected) {
+		t.Errorf("Expected replication controller template labels %v, got %v", expected, rc.Spec.Template.Labels)
+	}
+
+	if err := UpdatePodTemplate(client, api.ResourceKindPod, "ns", "app", addLabel); !errorsK8s.IsBadRequest(err) {
+		t.Errorf("Expected bad request for pod, got %v", err)
+	}
+	if err := UpdatePodTemplate(client, api.ResourceKindJob, "ns", "app", addLabel); !errorsK8s.IsNotFound(err) {
+		t.Errorf("Expected not found for missing job, got %v", err)
+	}
+}
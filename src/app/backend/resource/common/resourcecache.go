@@ -0,0 +1,61 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/cache"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	client "k8s.io/client-go/kubernetes"
+	api "k8s.io/client-go/pkg/api/v1"
+)
+
+// resourceCache is used by list channels instead of the apiserver when set and when it serves
+// the client of the request.
+var resourceCache *cache.ResourceCache
+
+// SetResourceCache sets resource cache used by list channels. Must be called before the API
+// handler starts serving requests.
+func SetResourceCache(c *cache.ResourceCache) {
+	resourceCache = c
+}
+
+// getCachedList returns objects of given kind from the resource cache. False is returned if the
+// objects have to be listed from the apiserver, e.g., because the list options use field
+// selector, which the cache does not support. Returned objects are shared with the cache and must
+// not be modified.
+func getCachedList(client client.Interface, kind string, nsQuery *NamespaceQuery,
+	options metaV1.ListOptions) ([]interface{}, bool) {
+	if !resourceCache.Serves(client) {
+		return nil, false
+	}
+
+	if len(options.FieldSelector) > 0 && options.FieldSelector != fields.Everything().String() {
+		return nil, false
+	}
+
+	selector, err := labels.Parse(options.LabelSelector)
+	if err != nil {
+		return nil, false
+	}
+
+	namespace := api.NamespaceAll
+	if nsQuery != nil {
+		namespace = nsQuery.ToRequestParam()
+	}
+
+	return resourceCache.List(kind, namespace, selector)
+}
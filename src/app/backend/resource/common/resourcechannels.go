@@ -15,6 +15,7 @@
 package common
 
 import (
+	"github.com/kubernetes/dashboard/src/app/backend/cache"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -24,6 +25,7 @@ import (
 	autoscaling "k8s.io/client-go/pkg/apis/autoscaling/v1"
 	batch "k8s.io/client-go/pkg/apis/batch/v1"
 	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	policy "k8s.io/client-go/pkg/apis/policy/v1beta1"
 	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
 	storage "k8s.io/client-go/pkg/apis/storage/v1beta1"
 )
@@ -101,6 +103,9 @@ type ResourceChannels struct {
 	// List and error channels to HorizontalPodAutoscalers
 	HorizontalPodAutoscalerList HorizontalPodAutoscalerListChannel
 
+	// List and error channels to PodDisruptionBudgets
+	PodDisruptionBudgetList PodDisruptionBudgetListChannel
+
 	// List and error channels to ThirdPartyResources
 	ThirdPartyResourceList ThirdPartyResourceListChannel
 
@@ -130,13 +135,30 @@ type ServiceListChannel struct {
 // must be read numReads times.
 func GetServiceListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) ServiceListChannel {
+	return GetServiceListChunkChannel(client, nsQuery, nil, numReads)
+}
+
+// GetServiceListChunkChannel is GetServiceListChannel plus list chunk. Whole list is fetched if
+// the chunk is nil.
+func GetServiceListChunkChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) ServiceListChannel {
 
 	channel := ServiceListChannel{
 		List:  make(chan *api.ServiceList, numReads),
 		Error: make(chan error, numReads),
 	}
 	go func() {
-		list, err := client.CoreV1().Services(nsQuery.ToRequestParam()).List(listEverything)
+		list := &api.ServiceList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.CoreV1().RESTClient(), "services", nsQuery, list)
+		} else if items, ok := getCachedList(client, cache.Services, nsQuery, listEverything); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*api.Service))
+			}
+		} else {
+			list, err = client.CoreV1().Services(nsQuery.ToRequestParam()).List(listEverything)
+		}
 		var filteredItems []api.Service
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -163,14 +185,31 @@ type IngressListChannel struct {
 // must be read numReads times.
 func GetIngressListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) IngressListChannel {
+	return GetIngressListChunkChannel(client, nsQuery, nil, numReads)
+}
+
+// GetIngressListChunkChannel is GetIngressListChannel plus list chunk. Whole list is fetched if
+// the chunk is nil.
+func GetIngressListChunkChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) IngressListChannel {
 
 	channel := IngressListChannel{
 		List:  make(chan *extensions.IngressList, numReads),
 		Error: make(chan error, numReads),
 	}
 	go func() {
-		list, err := client.ExtensionsV1beta1().Ingresses(nsQuery.ToRequestParam()).
-			List(listEverything)
+		list := &extensions.IngressList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.ExtensionsV1beta1().RESTClient(), "ingresses", nsQuery, list)
+		} else if items, ok := getCachedList(client, cache.Ingresses, nsQuery, listEverything); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*extensions.Ingress))
+			}
+		} else {
+			list, err = client.ExtensionsV1beta1().Ingresses(nsQuery.ToRequestParam()).
+				List(listEverything)
+		}
 		var filteredItems []extensions.Ingress
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -197,6 +236,13 @@ type LimitRangeListChannel struct {
 // both must be read numReads times.
 func GetLimitRangeListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) LimitRangeListChannel {
+	return GetLimitRangeListChunkChannel(client, nsQuery, nil, numReads)
+}
+
+// GetLimitRangeListChunkChannel is GetLimitRangeListChannel plus list
+// chunk. Whole list is fetched if the chunk is nil.
+func GetLimitRangeListChunkChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) LimitRangeListChannel {
 
 	channel := LimitRangeListChannel{
 		List:  make(chan *api.LimitRangeList, numReads),
@@ -204,8 +250,14 @@ func GetLimitRangeListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	}
 
 	go func() {
-		list, err := client.CoreV1().LimitRanges(nsQuery.ToRequestParam()).
-			List(listEverything)
+		list := &api.LimitRangeList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.CoreV1().RESTClient(), "limitranges", nsQuery, list)
+		} else {
+			list, err = client.CoreV1().LimitRanges(nsQuery.ToRequestParam()).
+				List(listEverything)
+		}
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -224,13 +276,30 @@ type NodeListChannel struct {
 // GetNodeListChannel returns a pair of channels to a Node list and errors that both must be read
 // numReads times.
 func GetNodeListChannel(client client.Interface, numReads int) NodeListChannel {
+	return GetNodeListChunkChannel(client, nil, numReads)
+}
+
+// GetNodeListChunkChannel is GetNodeListChannel plus list chunk. Whole list is fetched if
+// the chunk is nil.
+func GetNodeListChunkChannel(client client.Interface,
+	chunk *ListChunk, numReads int) NodeListChannel {
 	channel := NodeListChannel{
 		List:  make(chan *api.NodeList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.CoreV1().Nodes().List(listEverything)
+		list := &api.NodeList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.CoreV1().RESTClient(), "nodes", nil, list)
+		} else if items, ok := getCachedList(client, cache.Nodes, nil, listEverything); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*api.Node))
+			}
+		} else {
+			list, err = client.CoreV1().Nodes().List(listEverything)
+		}
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -247,16 +316,32 @@ type NamespaceListChannel struct {
 }
 
 // GetNamespaceListChannel returns a pair of channels to a Namespace list and errors that both must
-// be read
-// numReads times.
+// be read numReads times.
 func GetNamespaceListChannel(client client.Interface, numReads int) NamespaceListChannel {
+	return GetNamespaceListChunkChannel(client, nil, numReads)
+}
+
+// GetNamespaceListChunkChannel is GetNamespaceListChannel plus list chunk. Whole list is fetched if
+// the chunk is nil.
+func GetNamespaceListChunkChannel(client client.Interface,
+	chunk *ListChunk, numReads int) NamespaceListChannel {
 	channel := NamespaceListChannel{
 		List:  make(chan *api.NamespaceList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.CoreV1().Namespaces().List(listEverything)
+		list := &api.NamespaceList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.CoreV1().RESTClient(), "namespaces", nil, list)
+		} else if items, ok := getCachedList(client, cache.Namespaces, nil, listEverything); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*api.Namespace))
+			}
+		} else {
+			list, err = client.CoreV1().Namespaces().List(listEverything)
+		}
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -279,6 +364,28 @@ func GetEventListChannel(client client.Interface,
 	return GetEventListChannelWithOptions(client, nsQuery, listEverything, numReads)
 }
 
+// GetChunkEventListChannel returns a pair of channels to events of resources of a list fetched
+// in chunks, that both must be read numReads times. Events can not be fetched only for resources
+// of the chunk, so they are not fetched at all and the list is empty, unless whole list is
+// fetched.
+func GetChunkEventListChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) EventListChannel {
+	if !chunk.IsEnabled() {
+		return GetEventListChannel(client, nsQuery, numReads)
+	}
+
+	channel := EventListChannel{
+		List:  make(chan *api.EventList, numReads),
+		Error: make(chan error, numReads),
+	}
+	for i := 0; i < numReads; i++ {
+		channel.List <- &api.EventList{}
+		channel.Error <- nil
+	}
+
+	return channel
+}
+
 // GetEventListChannelWithOptions is GetEventListChannel plus list options.
 func GetEventListChannelWithOptions(client client.Interface,
 	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) EventListChannel {
@@ -288,7 +395,15 @@ func GetEventListChannelWithOptions(client client.Interface,
 	}
 
 	go func() {
-		list, err := client.CoreV1().Events(nsQuery.ToRequestParam()).List(options)
+		list := &api.EventList{}
+		var err error
+		if items, ok := getCachedList(client, cache.Events, nsQuery, options); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*api.Event))
+			}
+		} else {
+			list, err = client.CoreV1().Events(nsQuery.ToRequestParam()).List(options)
+		}
 		var filteredItems []api.Event
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -321,14 +436,35 @@ func GetPodListChannel(client client.Interface,
 // GetPodListChannelWithOptions is GetPodListChannel plus listing options.
 func GetPodListChannelWithOptions(client client.Interface, nsQuery *NamespaceQuery,
 	options metaV1.ListOptions, numReads int) PodListChannel {
+	return getPodListChannel(client, nsQuery, options, nil, numReads)
+}
+
+// GetPodListChunkChannel is GetPodListChannel plus list chunk. Whole list is fetched if the chunk
+// is nil.
+func GetPodListChunkChannel(client client.Interface, nsQuery *NamespaceQuery, chunk *ListChunk,
+	numReads int) PodListChannel {
+	return getPodListChannel(client, nsQuery, listEverything, chunk, numReads)
+}
 
+func getPodListChannel(client client.Interface, nsQuery *NamespaceQuery,
+	options metaV1.ListOptions, chunk *ListChunk, numReads int) PodListChannel {
 	channel := PodListChannel{
 		List:  make(chan *api.PodList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.CoreV1().Pods(nsQuery.ToRequestParam()).List(options)
+		list := &api.PodList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.CoreV1().RESTClient(), "pods", nsQuery, list)
+		} else if items, ok := getCachedList(client, cache.Pods, nsQuery, options); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*api.Pod))
+			}
+		} else {
+			list, err = client.CoreV1().Pods(nsQuery.ToRequestParam()).List(options)
+		}
 		var filteredItems []api.Pod
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -356,6 +492,13 @@ type ReplicationControllerListChannel struct {
 // numReads times.
 func GetReplicationControllerListChannel(client client.Interface,
 	nsQuery *NamespaceQuery, numReads int) ReplicationControllerListChannel {
+	return GetReplicationControllerListChunkChannel(client, nsQuery, nil, numReads)
+}
+
+// GetReplicationControllerListChunkChannel is GetReplicationControllerListChannel plus list
+// chunk. Whole list is fetched if the chunk is nil.
+func GetReplicationControllerListChunkChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) ReplicationControllerListChannel {
 
 	channel := ReplicationControllerListChannel{
 		List:  make(chan *api.ReplicationControllerList, numReads),
@@ -363,8 +506,18 @@ func GetReplicationControllerListChannel(client client.Interface,
 	}
 
 	go func() {
-		list, err := client.CoreV1().ReplicationControllers(nsQuery.ToRequestParam()).
-			List(listEverything)
+		list := &api.ReplicationControllerList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.CoreV1().RESTClient(), "replicationcontrollers", nsQuery, list)
+		} else if items, ok := getCachedList(client, cache.ReplicationControllers, nsQuery, listEverything); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*api.ReplicationController))
+			}
+		} else {
+			list, err = client.CoreV1().ReplicationControllers(nsQuery.ToRequestParam()).
+				List(listEverything)
+		}
 		var filteredItems []api.ReplicationController
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -391,6 +544,13 @@ type DeploymentListChannel struct {
 // that both must be read numReads times.
 func GetDeploymentListChannel(client client.Interface,
 	nsQuery *NamespaceQuery, numReads int) DeploymentListChannel {
+	return GetDeploymentListChunkChannel(client, nsQuery, nil, numReads)
+}
+
+// GetDeploymentListChunkChannel is GetDeploymentListChannel plus list
+// chunk. Whole list is fetched if the chunk is nil.
+func GetDeploymentListChunkChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) DeploymentListChannel {
 
 	channel := DeploymentListChannel{
 		List:  make(chan *extensions.DeploymentList, numReads),
@@ -398,8 +558,18 @@ func GetDeploymentListChannel(client client.Interface,
 	}
 
 	go func() {
-		list, err := client.ExtensionsV1beta1().Deployments(nsQuery.ToRequestParam()).
-			List(listEverything)
+		list := &extensions.DeploymentList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.ExtensionsV1beta1().RESTClient(), "deployments", nsQuery, list)
+		} else if items, ok := getCachedList(client, cache.Deployments, nsQuery, listEverything); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*extensions.Deployment))
+			}
+		} else {
+			list, err = client.ExtensionsV1beta1().Deployments(nsQuery.ToRequestParam()).
+				List(listEverything)
+		}
 		var filteredItems []extensions.Deployment
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -433,14 +603,36 @@ func GetReplicaSetListChannel(client client.Interface,
 // by provided options and errors that both must be read numReads times.
 func GetReplicaSetListChannelWithOptions(client client.Interface,
 	nsQuery *NamespaceQuery, options metaV1.ListOptions, numReads int) ReplicaSetListChannel {
+	return getReplicaSetListChannel(client, nsQuery, options, nil, numReads)
+}
+
+// GetReplicaSetListChunkChannel is GetReplicaSetListChannel plus list chunk. Whole list is
+// fetched if the chunk is nil.
+func GetReplicaSetListChunkChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) ReplicaSetListChannel {
+	return getReplicaSetListChannel(client, nsQuery, listEverything, chunk, numReads)
+}
+
+func getReplicaSetListChannel(client client.Interface, nsQuery *NamespaceQuery,
+	options metaV1.ListOptions, chunk *ListChunk, numReads int) ReplicaSetListChannel {
 	channel := ReplicaSetListChannel{
 		List:  make(chan *extensions.ReplicaSetList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.ExtensionsV1beta1().ReplicaSets(nsQuery.ToRequestParam()).
-			List(options)
+		list := &extensions.ReplicaSetList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.ExtensionsV1beta1().RESTClient(), "replicasets", nsQuery, list)
+		} else if items, ok := getCachedList(client, cache.ReplicaSets, nsQuery, options); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*extensions.ReplicaSet))
+			}
+		} else {
+			list, err = client.ExtensionsV1beta1().ReplicaSets(nsQuery.ToRequestParam()).
+				List(options)
+		}
 		var filteredItems []extensions.ReplicaSet
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -467,14 +659,31 @@ type DaemonSetListChannel struct {
 // both must be read numReads times.
 func GetDaemonSetListChannel(client client.Interface,
 	nsQuery *NamespaceQuery, numReads int) DaemonSetListChannel {
+	return GetDaemonSetListChunkChannel(client, nsQuery, nil, numReads)
+}
+
+// GetDaemonSetListChunkChannel is GetDaemonSetListChannel plus list chunk. Whole list is fetched if
+// the chunk is nil.
+func GetDaemonSetListChunkChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) DaemonSetListChannel {
 	channel := DaemonSetListChannel{
 		List:  make(chan *extensions.DaemonSetList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.ExtensionsV1beta1().DaemonSets(nsQuery.ToRequestParam()).
-			List(listEverything)
+		list := &extensions.DaemonSetList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.ExtensionsV1beta1().RESTClient(), "daemonsets", nsQuery, list)
+		} else if items, ok := getCachedList(client, cache.DaemonSets, nsQuery, listEverything); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*extensions.DaemonSet))
+			}
+		} else {
+			list, err = client.ExtensionsV1beta1().DaemonSets(nsQuery.ToRequestParam()).
+				List(listEverything)
+		}
 		var filteredItems []extensions.DaemonSet
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -501,13 +710,30 @@ type JobListChannel struct {
 // both must be read numReads times.
 func GetJobListChannel(client client.Interface,
 	nsQuery *NamespaceQuery, numReads int) JobListChannel {
+	return GetJobListChunkChannel(client, nsQuery, nil, numReads)
+}
+
+// GetJobListChunkChannel is GetJobListChannel plus list chunk. Whole list is fetched if
+// the chunk is nil.
+func GetJobListChunkChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) JobListChannel {
 	channel := JobListChannel{
 		List:  make(chan *batch.JobList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.BatchV1().Jobs(nsQuery.ToRequestParam()).List(listEverything)
+		list := &batch.JobList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.BatchV1().RESTClient(), "jobs", nsQuery, list)
+		} else if items, ok := getCachedList(client, cache.Jobs, nsQuery, listEverything); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*batch.Job))
+			}
+		} else {
+			list, err = client.BatchV1().Jobs(nsQuery.ToRequestParam()).List(listEverything)
+		}
 		var filteredItems []batch.Job
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -534,14 +760,31 @@ type StatefulSetListChannel struct {
 // both must be read numReads times.
 func GetStatefulSetListChannel(client client.Interface,
 	nsQuery *NamespaceQuery, numReads int) StatefulSetListChannel {
+	return GetStatefulSetListChunkChannel(client, nsQuery, nil, numReads)
+}
+
+// GetStatefulSetListChunkChannel is GetStatefulSetListChannel plus list
+// chunk. Whole list is fetched if the chunk is nil.
+func GetStatefulSetListChunkChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) StatefulSetListChannel {
 	channel := StatefulSetListChannel{
 		List:  make(chan *apps.StatefulSetList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		statefulSets, err := client.AppsV1beta1().StatefulSets(nsQuery.ToRequestParam()).
-			List(listEverything)
+		statefulSets := &apps.StatefulSetList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.AppsV1beta1().RESTClient(), "statefulsets", nsQuery, statefulSets)
+		} else if items, ok := getCachedList(client, cache.StatefulSets, nsQuery, listEverything); ok {
+			for _, item := range items {
+				statefulSets.Items = append(statefulSets.Items, *item.(*apps.StatefulSet))
+			}
+		} else {
+			statefulSets, err = client.AppsV1beta1().StatefulSets(nsQuery.ToRequestParam()).
+				List(listEverything)
+		}
 		var filteredItems []apps.StatefulSet
 		for _, item := range statefulSets.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -568,6 +811,13 @@ type ConfigMapListChannel struct {
 // both must be read numReads times.
 func GetConfigMapListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) ConfigMapListChannel {
+	return GetConfigMapListChunkChannel(client, nsQuery, nil, numReads)
+}
+
+// GetConfigMapListChunkChannel is GetConfigMapListChannel plus list chunk. Whole list is fetched if
+// the chunk is nil.
+func GetConfigMapListChunkChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) ConfigMapListChannel {
 
 	channel := ConfigMapListChannel{
 		List:  make(chan *api.ConfigMapList, numReads),
@@ -575,8 +825,18 @@ func GetConfigMapListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	}
 
 	go func() {
-		list, err := client.CoreV1().ConfigMaps(nsQuery.ToRequestParam()).
-			List(listEverything)
+		list := &api.ConfigMapList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.CoreV1().RESTClient(), "configmaps", nsQuery, list)
+		} else if items, ok := getCachedList(client, cache.ConfigMaps, nsQuery, listEverything); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*api.ConfigMap))
+			}
+		} else {
+			list, err = client.CoreV1().ConfigMaps(nsQuery.ToRequestParam()).
+				List(listEverything)
+		}
 		var filteredItems []api.ConfigMap
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -603,6 +863,13 @@ type SecretListChannel struct {
 // both must be read numReads times.
 func GetSecretListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) SecretListChannel {
+	return GetSecretListChunkChannel(client, nsQuery, nil, numReads)
+}
+
+// GetSecretListChunkChannel is GetSecretListChannel plus list chunk. Whole list is fetched if
+// the chunk is nil.
+func GetSecretListChunkChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) SecretListChannel {
 
 	channel := SecretListChannel{
 		List:  make(chan *api.SecretList, numReads),
@@ -610,8 +877,14 @@ func GetSecretListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	}
 
 	go func() {
-		list, err := client.CoreV1().Secrets(nsQuery.ToRequestParam()).
-			List(listEverything)
+		list := &api.SecretList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.CoreV1().RESTClient(), "secrets", nsQuery, list)
+		} else {
+			list, err = client.CoreV1().Secrets(nsQuery.ToRequestParam()).
+				List(listEverything)
+		}
 		var filteredItems []api.Secret
 		for _, item := range list.Items {
 			if nsQuery.Matches(item.ObjectMeta.Namespace) {
@@ -628,6 +901,43 @@ func GetSecretListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	return channel
 }
 
+// GetTLSSecretListChannel returns a pair of channels to a list of TLS secrets and errors that both
+// must be read numReads times. Secrets listed from the resource cache have no private keys.
+func GetTLSSecretListChannel(client client.Interface, nsQuery *NamespaceQuery,
+	numReads int) SecretListChannel {
+
+	channel := SecretListChannel{
+		List:  make(chan *api.SecretList, numReads),
+		Error: make(chan error, numReads),
+	}
+	go func() {
+		list := &api.SecretList{}
+		var err error
+		if items, ok := getCachedList(client, cache.TLSSecrets, nsQuery, listEverything); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*api.Secret))
+			}
+		} else {
+			list, err = client.CoreV1().Secrets(nsQuery.ToRequestParam()).List(metaV1.ListOptions{
+				FieldSelector: fields.OneTermEqualSelector("type", string(api.SecretTypeTLS)).String(),
+			})
+		}
+		var filteredItems []api.Secret
+		for _, item := range list.Items {
+			if nsQuery.Matches(item.ObjectMeta.Namespace) && item.Type == api.SecretTypeTLS {
+				filteredItems = append(filteredItems, item)
+			}
+		}
+		list.Items = filteredItems
+		for i := 0; i < numReads; i++ {
+			channel.List <- list
+			channel.Error <- err
+		}
+	}()
+
+	return channel
+}
+
 // RoleListChannel is a list and error channels to Roles.
 type RoleListChannel struct {
 	List  chan *rbac.RoleList
@@ -739,13 +1049,30 @@ type PersistentVolumeListChannel struct {
 // that both must be read numReads times.
 func GetPersistentVolumeListChannel(client client.Interface,
 	numReads int) PersistentVolumeListChannel {
+	return GetPersistentVolumeListChunkChannel(client, nil, numReads)
+}
+
+// GetPersistentVolumeListChunkChannel is GetPersistentVolumeListChannel plus list
+// chunk. Whole list is fetched if the chunk is nil.
+func GetPersistentVolumeListChunkChannel(client client.Interface,
+	chunk *ListChunk, numReads int) PersistentVolumeListChannel {
 	channel := PersistentVolumeListChannel{
 		List:  make(chan *api.PersistentVolumeList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.CoreV1().PersistentVolumes().List(listEverything)
+		list := &api.PersistentVolumeList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.CoreV1().RESTClient(), "persistentvolumes", nil, list)
+		} else if items, ok := getCachedList(client, cache.PersistentVolumes, nil, listEverything); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*api.PersistentVolume))
+			}
+		} else {
+			list, err = client.CoreV1().PersistentVolumes().List(listEverything)
+		}
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -765,6 +1092,13 @@ type PersistentVolumeClaimListChannel struct {
 // and errors that both must be read numReads times.
 func GetPersistentVolumeClaimListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) PersistentVolumeClaimListChannel {
+	return GetPersistentVolumeClaimListChunkChannel(client, nsQuery, nil, numReads)
+}
+
+// GetPersistentVolumeClaimListChunkChannel is GetPersistentVolumeClaimListChannel plus list
+// chunk. Whole list is fetched if the chunk is nil.
+func GetPersistentVolumeClaimListChunkChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) PersistentVolumeClaimListChannel {
 
 	channel := PersistentVolumeClaimListChannel{
 		List:  make(chan *api.PersistentVolumeClaimList, numReads),
@@ -772,8 +1106,18 @@ func GetPersistentVolumeClaimListChannel(client client.Interface, nsQuery *Names
 	}
 
 	go func() {
-		list, err := client.CoreV1().PersistentVolumeClaims(nsQuery.ToRequestParam()).
-			List(listEverything)
+		list := &api.PersistentVolumeClaimList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.CoreV1().RESTClient(), "persistentvolumeclaims", nsQuery, list)
+		} else if items, ok := getCachedList(client, cache.PersistentVolumeClaims, nsQuery, listEverything); ok {
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*api.PersistentVolumeClaim))
+			}
+		} else {
+			list, err = client.CoreV1().PersistentVolumeClaims(nsQuery.ToRequestParam()).
+				List(listEverything)
+		}
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -793,6 +1137,13 @@ type ResourceQuotaListChannel struct {
 // both must be read numReads times.
 func GetResourceQuotaListChannel(client client.Interface, nsQuery *NamespaceQuery,
 	numReads int) ResourceQuotaListChannel {
+	return GetResourceQuotaListChunkChannel(client, nsQuery, nil, numReads)
+}
+
+// GetResourceQuotaListChunkChannel is GetResourceQuotaListChannel plus list
+// chunk. Whole list is fetched if the chunk is nil.
+func GetResourceQuotaListChunkChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) ResourceQuotaListChannel {
 
 	channel := ResourceQuotaListChannel{
 		List:  make(chan *api.ResourceQuotaList, numReads),
@@ -800,8 +1151,14 @@ func GetResourceQuotaListChannel(client client.Interface, nsQuery *NamespaceQuer
 	}
 
 	go func() {
-		list, err := client.CoreV1().ResourceQuotas(nsQuery.ToRequestParam()).
-			List(listEverything)
+		list := &api.ResourceQuotaList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.CoreV1().RESTClient(), "resourcequotas", nsQuery, list)
+		} else {
+			list, err = client.CoreV1().ResourceQuotas(nsQuery.ToRequestParam()).
+				List(listEverything)
+		}
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
@@ -838,6 +1195,46 @@ func GetHorizontalPodAutoscalerListChannel(client client.Interface, nsQuery *Nam
 	return channel
 }
 
+// PodDisruptionBudgetListChannel is a list and error channels to PodDisruptionBudgets.
+type PodDisruptionBudgetListChannel struct {
+	List  chan *policy.PodDisruptionBudgetList
+	Error chan error
+}
+
+// GetPodDisruptionBudgetListChannel returns a pair of channels to a PodDisruptionBudget list and
+// errors that both must be read numReads times.
+func GetPodDisruptionBudgetListChannel(client client.Interface, nsQuery *NamespaceQuery,
+	numReads int) PodDisruptionBudgetListChannel {
+	return GetPodDisruptionBudgetListChunkChannel(client, nsQuery, nil, numReads)
+}
+
+// GetPodDisruptionBudgetListChunkChannel is GetPodDisruptionBudgetListChannel plus list
+// chunk. Whole list is fetched if the chunk is nil.
+func GetPodDisruptionBudgetListChunkChannel(client client.Interface, nsQuery *NamespaceQuery,
+	chunk *ListChunk, numReads int) PodDisruptionBudgetListChannel {
+	channel := PodDisruptionBudgetListChannel{
+		List:  make(chan *policy.PodDisruptionBudgetList, numReads),
+		Error: make(chan error, numReads),
+	}
+
+	go func() {
+		list := &policy.PodDisruptionBudgetList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.PolicyV1beta1().RESTClient(), "poddisruptionbudgets", nsQuery, list)
+		} else {
+			list, err = client.PolicyV1beta1().PodDisruptionBudgets(
+				nsQuery.ToRequestParam()).List(listEverything)
+		}
+		for i := 0; i < numReads; i++ {
+			channel.List <- list
+			channel.Error <- err
+		}
+	}()
+
+	return channel
+}
+
 // ThirdPartyResourceListChannel is a list and error channels to third party resources.
 type ThirdPartyResourceListChannel struct {
 	List  chan *extensions.ThirdPartyResourceList
@@ -873,13 +1270,26 @@ type StorageClassListChannel struct {
 // GetStorageClassListChannel returns a pair of channels to a storage class list and
 // errors that both must be read numReads times.
 func GetStorageClassListChannel(client client.Interface, numReads int) StorageClassListChannel {
+	return GetStorageClassListChunkChannel(client, nil, numReads)
+}
+
+// GetStorageClassListChunkChannel is GetStorageClassListChannel plus list
+// chunk. Whole list is fetched if the chunk is nil.
+func GetStorageClassListChunkChannel(client client.Interface,
+	chunk *ListChunk, numReads int) StorageClassListChannel {
 	channel := StorageClassListChannel{
 		List:  make(chan *storage.StorageClassList, numReads),
 		Error: make(chan error, numReads),
 	}
 
 	go func() {
-		list, err := client.StorageV1beta1().StorageClasses().List(listEverything)
+		list := &storage.StorageClassList{}
+		var err error
+		if chunk.IsEnabled() {
+			err = chunk.List(client.StorageV1beta1().RESTClient(), "storageclasses", nil, list)
+		} else {
+			list, err = client.StorageV1beta1().StorageClasses().List(listEverything)
+		}
 		for i := 0; i < numReads; i++ {
 			channel.List <- list
 			channel.Error <- err
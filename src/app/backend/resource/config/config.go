@@ -15,9 +15,8 @@
 package config
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/configmap"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
@@ -40,7 +39,7 @@ type Config struct {
 func GetConfig(client *kubernetes.Clientset, nsQuery *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery) (*Config, error) {
 
-	log.Print("Getting config category")
+	logger.Info("Getting config category")
 	channels := &common.ResourceChannels{
 		ConfigMapList:             common.GetConfigMapListChannel(client, nsQuery, 1),
 		SecretList:                common.GetSecretListChannel(client, nsQuery, 1),
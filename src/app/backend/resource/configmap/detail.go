@@ -15,9 +15,8 @@
 package configmap
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
@@ -36,7 +35,7 @@ type ConfigMapDetail struct {
 
 // GetConfigMapDetail returns detailed information about a config map
 func GetConfigMapDetail(client *client.Clientset, namespace, name string) (*ConfigMapDetail, error) {
-	log.Printf("Getting details of %s config map in %s namespace", name, namespace)
+	logger.Infof("Getting details of %s config map in %s namespace", name, namespace)
 
 	rawConfigMap, err := client.ConfigMaps(namespace).Get(name, metaV1.GetOptions{})
 
@@ -15,10 +15,9 @@
 package configmap
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -46,12 +45,19 @@ type ConfigMap struct {
 
 // GetConfigMapList returns a list of all ConfigMaps in the cluster.
 func GetConfigMapList(client *client.Clientset, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*ConfigMapList, error) {
-	log.Printf("Getting list config maps in the namespace %s", nsQuery.ToRequestParam())
+	logger.Infof("Getting list config maps in the namespace %s", nsQuery.ToRequestParam())
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
 	channels := &common.ResourceChannels{
-		ConfigMapList: common.GetConfigMapListChannel(client, nsQuery, 1),
+		ConfigMapList: common.GetConfigMapListChunkChannel(client, nsQuery, chunk, 1),
+	}
+
+	configMapList, err := GetConfigMapListFromChannels(channels, dsQuery)
+	if err != nil {
+		return nil, err
 	}
 
-	return GetConfigMapListFromChannels(channels, dsQuery)
+	chunk.SetListMeta(&configMapList.ListMeta)
+	return configMapList, nil
 }
 
 // GetConfigMapListFromChannels returns a list of all Config Maps in the cluster reading required resource list once from the channels.
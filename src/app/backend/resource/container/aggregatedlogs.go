@@ -0,0 +1,140 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"sync"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/controller"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/logs"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// DefaultAggregatedTailLines is the number of newest lines returned if no limit was requested.
+var DefaultAggregatedTailLines int64 = 100
+
+// Logs of at most maxAggregatedLogSources sources are loaded, by aggregatedLogWorkers requests at
+// once. Other sources are dropped and the result is marked as truncated.
+const (
+	maxAggregatedLogSources = 50
+	aggregatedLogWorkers    = 10
+)
+
+// AggregatedLogOptions are options used to load logs from multiple sources.
+type AggregatedLogOptions struct {
+	// TailLines is the maximum number of newest lines loaded from every source and returned in
+	// total.
+	TailLines int64
+	// SinceTime if set, only logs newer than this time are loaded.
+	SinceTime *metaV1.Time
+}
+
+// GetAggregatedLogs loads logs of containers of pods from given log sources and merges them into a
+// single chronologically ordered list.
+func GetAggregatedLogs(client *client.Clientset, namespace string, logSources controller.LogSources,
+	options *AggregatedLogOptions) (*logs.AggregatedLogDetails, error) {
+	tailLines := options.TailLines
+	if tailLines <= 0 {
+		tailLines = DefaultAggregatedTailLines
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	sources, sourcesTruncated := getAggregatedLogSources(pods.Items, logSources.PodNames,
+		maxAggregatedLogSources)
+
+	lines := make([]logs.LogLines, len(sources))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < aggregatedLogWorkers && worker < len(sources); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				rawLogs, err := readRawPodLogs(client, namespace, sources[i].PodName,
+					mapToAggregatedLogOptions(sources[i].ContainerName, tailLines, options.SinceTime))
+				if err != nil {
+					sources[i].Error = err.Error()
+					continue
+				}
+				lines[i] = logs.ToLogLines(rawLogs)
+			}
+		}()
+	}
+	for i := range sources {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	logLines, truncated := logs.MergeLogLines(sources, lines, int(tailLines))
+	info := logs.AggregatedLogInfo{
+		Sources:   sources,
+		Truncated: truncated || sourcesTruncated,
+	}
+	if len(logLines) > 0 {
+		info.FromDate = logLines[0].Timestamp
+		info.ToDate = logLines[len(logLines)-1].Timestamp
+	}
+
+	return &logs.AggregatedLogDetails{
+		Info:     info,
+		LogLines: logLines,
+	}, nil
+}
+
+// getAggregatedLogSources returns log sources of containers of given pods in the order of pod
+// names. Pods, which no longer exist, are skipped. At most limit sources are returned, the second
+// return value is true if some were dropped.
+func getAggregatedLogSources(pods []v1.Pod, podNames []string, limit int) (
+	[]logs.AggregatedLogSource, bool) {
+	podsByName := make(map[string]v1.Pod)
+	for _, pod := range pods {
+		podsByName[pod.Name] = pod
+	}
+
+	sources := make([]logs.AggregatedLogSource, 0)
+	for _, podName := range podNames {
+		pod, ok := podsByName[podName]
+		if !ok {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			if len(sources) == limit {
+				return sources, true
+			}
+			sources = append(sources, logs.AggregatedLogSource{
+				PodName:       podName,
+				ContainerName: container.Name,
+			})
+		}
+	}
+	return sources, false
+}
+
+// mapToAggregatedLogOptions maps the aggregated log options to the corresponding api object.
+func mapToAggregatedLogOptions(container string, tailLines int64, sinceTime *metaV1.Time) *v1.PodLogOptions {
+	return &v1.PodLogOptions{
+		Container:  container,
+		Timestamps: true,
+		TailLines:  &tailLines,
+		SinceTime:  sinceTime,
+		LimitBytes: &byteReadLimit,
+	}
+}
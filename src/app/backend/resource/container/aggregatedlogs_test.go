@@ -0,0 +1,78 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/logs"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func getTestLogPod(name string, containerNames ...string) v1.Pod {
+	pod := v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: name}}
+	for _, containerName := range containerNames {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: containerName})
+	}
+	return pod
+}
+
+func TestGetAggregatedLogSources(t *testing.T) {
+	pods := []v1.Pod{
+		getTestLogPod("pod-1", "app", "sidecar"),
+		getTestLogPod("pod-2", "app"),
+		getTestLogPod("other", "other"),
+	}
+
+	cases := []struct {
+		info              string
+		podNames          []string
+		limit             int
+		expected          []logs.AggregatedLogSource
+		expectedTruncated bool
+	}{
+		{
+			"containers of every pod",
+			[]string{"pod-1", "pod-2", "deleted"},
+			10,
+			[]logs.AggregatedLogSource{
+				{PodName: "pod-1", ContainerName: "app"},
+				{PodName: "pod-1", ContainerName: "sidecar"},
+				{PodName: "pod-2", ContainerName: "app"},
+			},
+			false,
+		},
+		{
+			"limited sources",
+			[]string{"pod-1", "pod-2"},
+			2,
+			[]logs.AggregatedLogSource{
+				{PodName: "pod-1", ContainerName: "app"},
+				{PodName: "pod-1", ContainerName: "sidecar"},
+			},
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		actual, truncated := getAggregatedLogSources(pods, c.podNames, c.limit)
+		if !reflect.DeepEqual(actual, c.expected) || truncated != c.expectedTruncated {
+			t.Errorf("Test Case: %s. getAggregatedLogSources() returns (%#v, %v), expected (%#v, %v)",
+				c.info, actual, truncated, c.expected, c.expectedTruncated)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"io"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// LogFile is a complete log of a container that can be downloaded as a file. Logs of the
+// previous, terminated instance of the container come first if there are any.
+type LogFile struct {
+	io.Reader
+
+	// Name is a suggested file name of the log.
+	Name string
+
+	streams []io.ReadCloser
+}
+
+// Close closes all log streams the file is read from.
+func (self *LogFile) Close() error {
+	var result error
+	for _, stream := range self.streams {
+		if err := stream.Close(); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
+
+// GetLogFile opens complete log of particular pod and container, including logs of the previous
+// container instance if it has been restarted. When container is empty, logs of the first one
+// are returned. Caller is responsible for closing the file.
+func GetLogFile(client *client.Clientset, namespace, podID string, container string) (*LogFile, error) {
+	pod, err := client.Pods(namespace).Get(podID, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(container) == 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	logFile := &LogFile{Name: getLogFileName(podID, container)}
+
+	if hasPreviousLogs(pod, container) {
+		previous, err := openLogStream(client, namespace, podID, &v1.PodLogOptions{
+			Container:  container,
+			Previous:   true,
+			Timestamps: true,
+		})
+		// Previous container logs might have been garbage collected already, in which case only
+		// the current ones are returned.
+		if err == nil {
+			logFile.streams = append(logFile.streams, previous)
+		}
+	}
+
+	current, err := openLogStream(client, namespace, podID, &v1.PodLogOptions{
+		Container:  container,
+		Timestamps: true,
+	})
+	if err != nil {
+		logFile.Close()
+		return nil, err
+	}
+	logFile.streams = append(logFile.streams, current)
+
+	readers := make([]io.Reader, len(logFile.streams))
+	for i, stream := range logFile.streams {
+		readers[i] = stream
+	}
+	logFile.Reader = io.MultiReader(readers...)
+
+	return logFile, nil
+}
+
+// hasPreviousLogs returns true if given container of the pod has been restarted, so logs of its
+// previous instance can be retrieved.
+func hasPreviousLogs(pod *v1.Pod, container string) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container {
+			return status.RestartCount > 0 || status.LastTerminationState.Terminated != nil
+		}
+	}
+	return false
+}
+
+func getLogFileName(podID, container string) string {
+	return fmt.Sprintf("logs-from-%s-in-%s.log", container, podID)
+}
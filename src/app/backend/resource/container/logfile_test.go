@@ -0,0 +1,56 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"testing"
+
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func TestHasPreviousLogs(t *testing.T) {
+	cases := []struct {
+		info      string
+		statuses  []v1.ContainerStatus
+		container string
+		expected  bool
+	}{
+		{"no statuses", nil, "app", false},
+		{"not restarted", []v1.ContainerStatus{{Name: "app"}}, "app", false},
+		{"restarted", []v1.ContainerStatus{{Name: "app", RestartCount: 2}}, "app", true},
+		{
+			"terminated before",
+			[]v1.ContainerStatus{{
+				Name: "app",
+				LastTerminationState: v1.ContainerState{
+					Terminated: &v1.ContainerStateTerminated{ExitCode: 1},
+				},
+			}},
+			"app",
+			true,
+		},
+		{"other container restarted", []v1.ContainerStatus{{Name: "sidecar", RestartCount: 1},
+			{Name: "app"}}, "app", false},
+	}
+
+	for _, c := range cases {
+		pod := &v1.Pod{Status: v1.PodStatus{ContainerStatuses: c.statuses}}
+		actual := hasPreviousLogs(pod, c.container)
+		if actual != c.expected {
+			t.Errorf("%s: hasPreviousLogs(%#v, %#v) returns %#v, expected %#v", c.info, pod,
+				c.container, actual, c.expected)
+		}
+	}
+}
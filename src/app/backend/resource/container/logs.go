@@ -15,6 +15,8 @@
 package container
 
 import (
+	"bufio"
+	"io"
 	"io/ioutil"
 
 	"github.com/kubernetes/dashboard/src/app/backend/resource/logs"
@@ -30,6 +32,19 @@ var lineReadLimit int64 = 5000
 // maximum number of bytes loaded from the apiserver
 var byteReadLimit int64 = 500000
 
+// LogStreamOptions are options of a live stream of container logs.
+type LogStreamOptions struct {
+	// Follow keeps the stream open and sends new log lines as they appear.
+	Follow bool
+	// TailLines is the number of lines from the end of the logs to start with. All lines are sent
+	// if not set.
+	TailLines *int64
+	// SinceSeconds is a relative time in seconds before now from which to show logs.
+	SinceSeconds *int64
+	// Timestamps adds timestamp to the beginning of every log line.
+	Timestamps bool
+}
+
 // PodContainerList is a list of containers of a pod.
 type PodContainerList struct {
 	Containers []string `json:"containers"`
@@ -52,9 +67,12 @@ func GetPodContainers(client *client.Clientset, namespace, podID string) (*PodCo
 }
 
 // GetPodLogs returns logs for particular pod and container. When container
-// is null, logs for the first one are returned.
+// is null, logs for the first one are returned. Log lines not matching the filter are dropped
+// before the selection is applied. Filtered logs are read whole, line by line, and read limits
+// apply to matching lines only. If usePreviousLogs is true, logs of the previous terminated
+// instance of the container are returned.
 func GetPodLogs(client *client.Clientset, namespace, podID string, container string,
-	logSelector *logs.Selection) (*logs.LogDetails, error) {
+	logSelector *logs.Selection, logFilter *logs.LogFilter, usePreviousLogs bool) (*logs.LogDetails, error) {
 	pod, err := client.Pods(namespace).Get(podID, metaV1.GetOptions{})
 	if err != nil {
 		return nil, err
@@ -64,22 +82,122 @@ func GetPodLogs(client *client.Clientset, namespace, podID string, container str
 		container = pod.Spec.Containers[0].Name
 	}
 
-	logOptions := mapToLogOptions(container, logSelector)
+	if !logFilter.IsEmpty() {
+		details, err := getFilteredPodLogs(client, namespace, podID, container, logSelector, logFilter,
+			usePreviousLogs)
+		if err != nil {
+			return nil, err
+		}
+		details.Info.Previous = usePreviousLogs
+		return details, nil
+	}
+
+	logOptions := mapToLogOptions(container, logSelector, usePreviousLogs)
 	rawLogs, err := getRawPodLogs(client, namespace, podID, logOptions)
 	if err != nil {
 		return nil, err
 	}
-	details := ConstructLogs(podID, rawLogs, container, logSelector)
+	details := ConstructLogs(podID, rawLogs, container, logSelector, logFilter)
+	details.Info.Previous = usePreviousLogs
 	return details, nil
 }
 
+// getFilteredPodLogs reads whole logs of the container and keeps only lines matching the filter.
+func getFilteredPodLogs(client *client.Clientset, namespace, podID string, container string,
+	logSelector *logs.Selection, logFilter *logs.LogFilter, usePreviousLogs bool) (*logs.LogDetails, error) {
+	logOptions := &v1.PodLogOptions{
+		Container:  container,
+		Previous:   usePreviousLogs,
+		Timestamps: true,
+	}
+	if logFilter.SinceTime != nil {
+		// Let the apiserver skip older lines.
+		logOptions.SinceTime = &metaV1.Time{Time: *logFilter.SinceTime}
+	}
+
+	readCloser, err := openLogStream(client, namespace, podID, logOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer readCloser.Close()
+
+	logLines, readLimitReached, err := readFilteredLogLines(readCloser, logFilter,
+		logSelector.LogFilePosition)
+	if err != nil {
+		return nil, err
+	}
+	return constructLogDetails(podID, logLines, container, logSelector, readLimitReached), nil
+}
+
+// readFilteredLogLines reads logs line by line and keeps lines matching the filter. Read limits
+// apply to matching lines: the last lineReadLimit lines are kept for the end of the logs and lines
+// up to byteReadLimit bytes for the beginning. True is returned if matching lines were dropped.
+func readFilteredLogLines(reader io.Reader, logFilter *logs.LogFilter, logFilePosition string) (
+	logs.LogLines, bool, error) {
+	logLines := logs.LogLines{}
+	readLimitReached := false
+	var bytesLoaded int64
+
+	bufferedReader := bufio.NewReader(reader)
+	for {
+		line, err := bufferedReader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, false, err
+		}
+
+		for _, logLine := range logs.ToLogLines(line) {
+			if !logFilter.Matches(logLine) {
+				continue
+			}
+			if logFilePosition == logs.Beginning {
+				bytesLoaded += int64(len(line))
+				if bytesLoaded > byteReadLimit {
+					return logLines, true, nil
+				}
+			}
+			logLines = append(logLines, logLine)
+			if logFilePosition == logs.End && int64(len(logLines)) > lineReadLimit {
+				logLines = logLines[1:]
+				readLimitReached = true
+			}
+		}
+
+		if err == io.EOF {
+			return logLines, readLimitReached, nil
+		}
+	}
+}
+
+// StreamPodLogs opens a stream of logs for particular pod and container. When container is empty,
+// logs of the first one are streamed. Caller is responsible for closing the stream.
+func StreamPodLogs(client *client.Clientset, namespace, podID string, container string,
+	streamOptions *LogStreamOptions) (io.ReadCloser, error) {
+	if len(container) == 0 {
+		pod, err := client.Pods(namespace).Get(podID, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		container = pod.Spec.Containers[0].Name
+	}
+
+	logOptions := &v1.PodLogOptions{
+		Container:    container,
+		Follow:       streamOptions.Follow,
+		Timestamps:   streamOptions.Timestamps,
+		TailLines:    streamOptions.TailLines,
+		SinceSeconds: streamOptions.SinceSeconds,
+	}
+
+	return openLogStream(client, namespace, podID, logOptions)
+}
+
 // Maps the log selection to the corresponding api object
 // Read limits are set to avoid out of memory issues
-func mapToLogOptions(container string, logSelector *logs.Selection) *v1.PodLogOptions {
+func mapToLogOptions(container string, logSelector *logs.Selection, previous bool) *v1.PodLogOptions {
 	logOptions := &v1.PodLogOptions{
 		Container:  container,
 		Follow:     false,
-		Previous:   false,
+		Previous:   previous,
 		Timestamps: true,
 	}
 
@@ -92,9 +210,9 @@ func mapToLogOptions(container string, logSelector *logs.Selection) *v1.PodLogOp
 	return logOptions
 }
 
-// Construct a request for getting the logs for a pod and retrieves the logs.
-func getRawPodLogs(client *client.Clientset, namespace, podID string, logOptions *v1.PodLogOptions) (
-	string, error) {
+// Construct a request for getting the logs for a pod and opens a stream of logs.
+func openLogStream(client *client.Clientset, namespace, podID string, logOptions *v1.PodLogOptions) (
+	io.ReadCloser, error) {
 	req := client.Core().RESTClient().Get().
 		Namespace(namespace).
 		Name(podID).
@@ -102,7 +220,13 @@ func getRawPodLogs(client *client.Clientset, namespace, podID string, logOptions
 		SubResource("log").
 		VersionedParams(logOptions, scheme.ParameterCodec)
 
-	readCloser, err := req.Stream()
+	return req.Stream()
+}
+
+// Construct a request for getting the logs for a pod and retrieves the logs.
+func getRawPodLogs(client *client.Clientset, namespace, podID string, logOptions *v1.PodLogOptions) (
+	string, error) {
+	readCloser, err := openLogStream(client, namespace, podID, logOptions)
 	if err != nil {
 		return err.Error(), nil
 	}
@@ -117,12 +241,39 @@ func getRawPodLogs(client *client.Clientset, namespace, podID string, logOptions
 	return string(result), nil
 }
 
+// Construct a request for getting the logs for a pod and reads all of them. Unlike getRawPodLogs,
+// errors that occurred while opening the stream are returned.
+func readRawPodLogs(client *client.Clientset, namespace, podID string, logOptions *v1.PodLogOptions) (
+	string, error) {
+	readCloser, err := openLogStream(client, namespace, podID, logOptions)
+	if err != nil {
+		return "", err
+	}
+
+	defer readCloser.Close()
+
+	result, err := ioutil.ReadAll(readCloser)
+	if err != nil {
+		return "", err
+	}
+
+	return string(result), nil
+}
+
 // Build logs structure for given parameters.
-func ConstructLogs(podID string, rawLogs string, container string, logSelector *logs.Selection) *logs.LogDetails {
+func ConstructLogs(podID string, rawLogs string, container string, logSelector *logs.Selection,
+	logFilter *logs.LogFilter) *logs.LogDetails {
 	parsedLines := logs.ToLogLines(rawLogs)
-	logLines, fromDate, toDate, logSelection, lastPage := parsedLines.SelectLogs(logSelector)
-
 	readLimitReached := isReadLimitReached(int64(len(rawLogs)), int64(len(parsedLines)), logSelector.LogFilePosition)
+	return constructLogDetails(podID, parsedLines.Filter(logFilter), container, logSelector,
+		readLimitReached)
+}
+
+// constructLogDetails selects lines of the logs. Logs are truncated if read limits were reached and
+// the last page is selected.
+func constructLogDetails(podID string, filteredLines logs.LogLines, container string,
+	logSelector *logs.Selection, readLimitReached bool) *logs.LogDetails {
+	logLines, fromDate, toDate, logSelection, lastPage := filteredLines.SelectLogs(logSelector)
 	truncated := readLimitReached && lastPage
 
 	info := logs.LogInfo{
@@ -16,6 +16,7 @@ package container
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/kubernetes/dashboard/src/app/backend/resource/logs"
@@ -317,7 +318,7 @@ func TestGetLogs(t *testing.T) {
 		},
 	}
 	for _, c := range cases {
-		actual := ConstructLogs(c.podId, c.rawLogs, c.container, c.logSelector)
+		actual := ConstructLogs(c.podId, c.rawLogs, c.container, c.logSelector, nil)
 		if !reflect.DeepEqual(actual, c.expected) {
 			t.Errorf("Test Case: %s.\nReceived: %#v \nExpected: %#v\n\n", c.info, actual, c.expected)
 		}
@@ -330,6 +331,7 @@ func TestMapToLogOptions(t *testing.T) {
 		info        string
 		container   string
 		logSelector *logs.Selection
+		previous    bool
 		expected    *v1.PodLogOptions
 	}{
 		{"Byte limit must be set, when reading the log file from the beginning",
@@ -337,6 +339,7 @@ func TestMapToLogOptions(t *testing.T) {
 			&logs.Selection{
 				LogFilePosition: "beginning",
 			},
+			false,
 			&v1.PodLogOptions{
 				Container:  "test",
 				Timestamps: true,
@@ -348,18 +351,74 @@ func TestMapToLogOptions(t *testing.T) {
 			&logs.Selection{
 				LogFilePosition: "end",
 			},
+			false,
 			&v1.PodLogOptions{
 				Container:  "test",
 				Timestamps: true,
 				TailLines:  &lineReadLimit,
 			},
 		},
+		{"Previous must be set, when reading logs of the previous container",
+			"test",
+			&logs.Selection{
+				LogFilePosition: "end",
+			},
+			true,
+			&v1.PodLogOptions{
+				Container:  "test",
+				Previous:   true,
+				Timestamps: true,
+				TailLines:  &lineReadLimit,
+			},
+		},
 	}
 	for _, c := range cases {
-		actual := mapToLogOptions(c.container, c.logSelector)
+		actual := mapToLogOptions(c.container, c.logSelector, c.previous)
 		if !reflect.DeepEqual(actual, c.expected) {
 			t.Errorf("Test Case: %s.\nReceived: %#v \nExpected: %#v\n\n", c.info, actual, c.expected)
 		}
 
 	}
 }
+
+func TestReadFilteredLogLines(t *testing.T) {
+	defer func(lines, bytes int64) { lineReadLimit, byteReadLimit = lines, bytes }(lineReadLimit,
+		byteReadLimit)
+	lineReadLimit, byteReadLimit = 2, 20
+
+	rawLogs := "1 error a\n2 info b\n3 error c\n4 info d\n5 error e\n6 error f"
+	filter := &logs.LogFilter{Search: "error"}
+
+	cases := []struct {
+		info             string
+		position         string
+		expected         logs.LogLines
+		readLimitReached bool
+	}{
+		{"Line limit must apply to the last matching lines, when reading from the end",
+			logs.End,
+			logs.LogLines{{Timestamp: "5", Content: "error e"}, {Timestamp: "6", Content: "error f"}},
+			true,
+		},
+		{"Byte limit must apply to matching lines, when reading from the beginning",
+			logs.Beginning,
+			logs.LogLines{{Timestamp: "1", Content: "error a"}, {Timestamp: "3", Content: "error c"}},
+			true,
+		},
+	}
+	for _, c := range cases {
+		actual, readLimitReached, err := readFilteredLogLines(strings.NewReader(rawLogs), filter,
+			c.position)
+		if err != nil || !reflect.DeepEqual(actual, c.expected) || readLimitReached != c.readLimitReached {
+			t.Errorf("Test Case: %s.\nReceived: %#v, %t, %v \nExpected: %#v, %t\n\n", c.info, actual,
+				readLimitReached, err, c.expected, c.readLimitReached)
+		}
+	}
+
+	lineReadLimit = 10
+	actual, readLimitReached, _ := readFilteredLogLines(strings.NewReader(rawLogs), filter, logs.End)
+	if len(actual) != 4 || readLimitReached {
+		t.Errorf("Expected all 4 matching lines without reaching limit, received %#v, %t", actual,
+			readLimitReached)
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diagnosis"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -123,6 +124,7 @@ func (self JobController) Get(allPods []v1.Pod, allEvents []v1.Event) ResourceOw
 	}
 	podInfo := common.GetPodInfo(self.Status.Active, completions, matchingPods)
 	podInfo.Warnings = event.GetPodsEventWarnings(allEvents, matchingPods)
+	podInfo.Diagnosis = diagnosis.DiagnosePods(matchingPods, allEvents)
 
 	return ResourceOwner{
 		TypeMeta:        api.NewTypeMeta(api.ResourceKindJob),
@@ -154,6 +156,7 @@ func (self ReplicaSetController) Get(allPods []v1.Pod, allEvents []v1.Event) Res
 	matchingPods := common.FilterPodsByOwnerReference(self.Namespace, self.UID(), allPods)
 	podInfo := common.GetPodInfo(self.Status.Replicas, *self.Spec.Replicas, matchingPods)
 	podInfo.Warnings = event.GetPodsEventWarnings(allEvents, matchingPods)
+	podInfo.Diagnosis = diagnosis.DiagnosePods(matchingPods, allEvents)
 
 	return ResourceOwner{
 		TypeMeta:        api.NewTypeMeta(api.ResourceKindReplicaSet),
@@ -186,6 +189,7 @@ func (self ReplicationControllerController) Get(allPods []v1.Pod,
 	matchingPods := common.FilterPodsByOwnerReference(self.Namespace, self.UID(), allPods)
 	podInfo := common.GetPodInfo(self.Status.Replicas, *self.Spec.Replicas, matchingPods)
 	podInfo.Warnings = event.GetPodsEventWarnings(allEvents, matchingPods)
+	podInfo.Diagnosis = diagnosis.DiagnosePods(matchingPods, allEvents)
 
 	return ResourceOwner{
 		TypeMeta:        api.NewTypeMeta(api.ResourceKindReplicationController),
@@ -218,6 +222,7 @@ func (self DaemonSetController) Get(allPods []v1.Pod, allEvents []v1.Event) Reso
 	podInfo := common.GetPodInfo(self.Status.CurrentNumberScheduled,
 		self.Status.DesiredNumberScheduled, matchingPods)
 	podInfo.Warnings = event.GetPodsEventWarnings(allEvents, matchingPods)
+	podInfo.Diagnosis = diagnosis.DiagnosePods(matchingPods, allEvents)
 
 	return ResourceOwner{
 		TypeMeta:        api.NewTypeMeta(api.ResourceKindDaemonSet),
@@ -249,6 +254,7 @@ func (self StatefulSetController) Get(allPods []v1.Pod, allEvents []v1.Event) Re
 	matchingPods := common.FilterPodsByOwnerReference(self.Namespace, self.UID(), allPods)
 	podInfo := common.GetPodInfo(self.Status.Replicas, *self.Spec.Replicas, matchingPods)
 	podInfo.Warnings = event.GetPodsEventWarnings(allEvents, matchingPods)
+	podInfo.Diagnosis = diagnosis.DiagnosePods(matchingPods, allEvents)
 
 	return ResourceOwner{
 		TypeMeta:        api.NewTypeMeta(api.ResourceKindStatefulSet),
@@ -0,0 +1,307 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"math"
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/capacity"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	helper "k8s.io/client-go/pkg/api/v1/resource"
+)
+
+// HoursPerMonth is the average number of hours in a month.
+const HoursPerMonth = 730
+
+// Estimate is estimated monthly cost of a namespace, a workload or the whole cluster.
+type Estimate struct {
+	// Name of the namespace or workload. Empty for the whole cluster.
+	Name string `json:"name"`
+	// Namespace and Kind are set for workloads. Pods without controller are workloads of Pod kind.
+	Namespace string `json:"namespace,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+
+	// Number of scheduled pods.
+	Pods int `json:"pods"`
+
+	// Monthly cost of CPU and memory requested by pods.
+	CPURequestCost    float64 `json:"cpuRequestCost"`
+	MemoryRequestCost float64 `json:"memoryRequestCost"`
+	RequestCost       float64 `json:"requestCost"`
+
+	// Monthly cost of CPU and memory at their current usage. Nil if usage is not available.
+	UsageCost *float64 `json:"usageCost"`
+
+	// Monthly cost of requested resources, which are not used. Nil if usage is not available.
+	IdleCost *float64 `json:"idleCost"`
+}
+
+// Report is estimated monthly cost per namespace and per workload, meant for showback.
+type Report struct {
+	// Model is the name of the price model and Currency the currency of all costs.
+	Model         string `json:"model"`
+	Currency      string `json:"currency"`
+	HoursPerMonth int    `json:"hoursPerMonth"`
+
+	Total      Estimate   `json:"total"`
+	Namespaces []Estimate `json:"namespaces"`
+	Workloads  []Estimate `json:"workloads"`
+
+	// UsageAvailable is false when usage could not be downloaded from the metrics provider.
+	UsageAvailable bool `json:"usageAvailable"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// costs accumulates hourly costs of pods.
+type costs struct {
+	pods          int
+	cpuRequest    float64
+	memoryRequest float64
+	usage         float64
+	idle          float64
+}
+
+// workloadKey identifies a workload.
+type workloadKey struct {
+	namespace string
+	kind      string
+	name      string
+}
+
+// GetCostReport returns estimated monthly cost of namespaces and workloads in the namespaces of
+// the query. Cost of a pod is calculated from its requests, or usage, and prices of resources on its
+// node. Usage is taken from the metric client, which may be nil.
+func GetCostReport(client client.Interface, metricClient metricapi.MetricClient, model PriceModel,
+	nsQuery *common.NamespaceQuery) (*Report, error) {
+	logger.Infof("Getting cost report with %s price model", model.Name())
+
+	channels := &common.ResourceChannels{
+		NodeList:       common.GetNodeListChannel(client, 1),
+		PodList:        common.GetPodListChannel(client, nsQuery, 1),
+		ReplicaSetList: common.GetReplicaSetListChannel(client, nsQuery, 1),
+		JobList:        common.GetJobListChannel(client, nsQuery, 1),
+	}
+
+	nodes := <-channels.NodeList.List
+	err := <-channels.NodeList.Error
+	nonCriticalErrors, criticalError := errors.AppendError(err, make([]error, 0))
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	pods := <-channels.PodList.List
+	err = <-channels.PodList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	replicaSets := <-channels.ReplicaSetList.List
+	err = <-channels.ReplicaSetList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	jobs := <-channels.JobList.List
+	err = <-channels.JobList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	// Pods of replica sets and jobs are accounted to deployments and cron jobs owning them.
+	owners := make(map[types.UID]*metaV1.OwnerReference)
+	if replicaSets != nil {
+		for _, replicaSet := range replicaSets.Items {
+			owners[replicaSet.UID] = getControllerRef(replicaSet.OwnerReferences)
+		}
+	}
+	if jobs != nil {
+		for _, job := range jobs.Items {
+			owners[job.UID] = getControllerRef(job.OwnerReferences)
+		}
+	}
+
+	nodeItems := make([]v1.Node, 0)
+	if nodes != nil {
+		nodeItems = nodes.Items
+	}
+	scheduled := make([]v1.Pod, 0)
+	if pods != nil {
+		scheduled = capacity.GetScheduledPods(pods.Items)
+	}
+
+	usage, err := capacity.GetPodUsage(scheduled, metricClient)
+	if err != nil {
+		logger.Warningf("Skipping pod usage in cost report because of error: %s", err.Error())
+		usage = nil
+	}
+
+	report := toReport(model, nodeItems, scheduled, owners, usage)
+	report.Errors = nonCriticalErrors
+	return report, nil
+}
+
+func toReport(model PriceModel, nodes []v1.Node, pods []v1.Pod, owners map[types.UID]*metaV1.OwnerReference,
+	usage map[types.UID]capacity.PodUsage) *Report {
+	prices := make(map[string]Prices)
+	for i := range nodes {
+		prices[nodes[i].Name] = model.NodePrices(&nodes[i])
+	}
+
+	total := &costs{}
+	byNamespace := make(map[string]*costs)
+	byWorkload := make(map[workloadKey]*costs)
+	for _, pod := range pods {
+		nodePrices, ok := prices[pod.Spec.NodeName]
+		if !ok {
+			nodePrices = model.NodePrices(nil)
+		}
+		podCosts := getPodCosts(pod, nodePrices, usage)
+
+		if _, ok := byNamespace[pod.Namespace]; !ok {
+			byNamespace[pod.Namespace] = &costs{}
+		}
+		byNamespace[pod.Namespace].add(podCosts)
+
+		key := getWorkload(pod, owners)
+		if _, ok := byWorkload[key]; !ok {
+			byWorkload[key] = &costs{}
+		}
+		byWorkload[key].add(podCosts)
+		total.add(podCosts)
+	}
+
+	hasUsage := usage != nil
+	report := &Report{
+		Model:          model.Name(),
+		Currency:       model.Currency(),
+		HoursPerMonth:  HoursPerMonth,
+		Total:          total.toEstimate(hasUsage),
+		Namespaces:     make([]Estimate, 0, len(byNamespace)),
+		Workloads:      make([]Estimate, 0, len(byWorkload)),
+		UsageAvailable: hasUsage,
+	}
+	for name, namespaceCosts := range byNamespace {
+		estimate := namespaceCosts.toEstimate(hasUsage)
+		estimate.Name = name
+		report.Namespaces = append(report.Namespaces, estimate)
+	}
+	for key, workloadCosts := range byWorkload {
+		estimate := workloadCosts.toEstimate(hasUsage)
+		estimate.Name, estimate.Namespace, estimate.Kind = key.name, key.namespace, key.kind
+		report.Workloads = append(report.Workloads, estimate)
+	}
+
+	sortEstimates(report.Namespaces)
+	sortEstimates(report.Workloads)
+	return report
+}
+
+// getPodCosts returns hourly costs of the pod.
+func getPodCosts(pod v1.Pod, prices Prices, usage map[types.UID]capacity.PodUsage) costs {
+	result := costs{pods: 1}
+	requests, _, err := helper.PodRequestsAndLimits(&pod)
+	if err != nil {
+		return result
+	}
+
+	cpu, memory := requests[v1.ResourceCPU], requests[v1.ResourceMemory]
+	cpuRequested := float64(cpu.MilliValue()) / 1000
+	memoryRequested := float64(memory.Value()) / bytesPerGB
+	result.cpuRequest = cpuRequested * prices.CPUHour
+	result.memoryRequest = memoryRequested * prices.MemoryGBHour
+
+	if podUsage, ok := usage[pod.UID]; ok {
+		cpuUsed := float64(podUsage.CPU) / 1000
+		memoryUsed := float64(podUsage.Memory) / bytesPerGB
+		result.usage = cpuUsed*prices.CPUHour + memoryUsed*prices.MemoryGBHour
+		result.idle = math.Max(0, cpuRequested-cpuUsed)*prices.CPUHour +
+			math.Max(0, memoryRequested-memoryUsed)*prices.MemoryGBHour
+	}
+	return result
+}
+
+// getWorkload returns the workload owning the pod. Pods without controller are their own workloads.
+func getWorkload(pod v1.Pod, owners map[types.UID]*metaV1.OwnerReference) workloadKey {
+	ref := getControllerRef(pod.OwnerReferences)
+	if ref == nil {
+		return workloadKey{namespace: pod.Namespace, kind: "Pod", name: pod.Name}
+	}
+	if owner := owners[ref.UID]; owner != nil {
+		ref = owner
+	}
+	return workloadKey{namespace: pod.Namespace, kind: ref.Kind, name: ref.Name}
+}
+
+func getControllerRef(references []metaV1.OwnerReference) *metaV1.OwnerReference {
+	for i := range references {
+		if references[i].Controller != nil && *references[i].Controller {
+			return &references[i]
+		}
+	}
+	return nil
+}
+
+func (self *costs) add(other costs) {
+	self.pods += other.pods
+	self.cpuRequest += other.cpuRequest
+	self.memoryRequest += other.memoryRequest
+	self.usage += other.usage
+	self.idle += other.idle
+}
+
+// toEstimate converts hourly costs to monthly estimate rounded to cents.
+func (self *costs) toEstimate(hasUsage bool) Estimate {
+	result := Estimate{
+		Pods:              self.pods,
+		CPURequestCost:    monthly(self.cpuRequest),
+		MemoryRequestCost: monthly(self.memoryRequest),
+		RequestCost:       monthly(self.cpuRequest + self.memoryRequest),
+	}
+	if hasUsage {
+		usage, idle := monthly(self.usage), monthly(self.idle)
+		result.UsageCost, result.IdleCost = &usage, &idle
+	}
+	return result
+}
+
+func monthly(hourly float64) float64 {
+	return math.Round(hourly*HoursPerMonth*100) / 100
+}
+
+// sortEstimates sorts estimates from the most expensive.
+func sortEstimates(estimates []Estimate) {
+	sort.Slice(estimates, func(i, j int) bool {
+		if estimates[i].RequestCost != estimates[j].RequestCost {
+			return estimates[i].RequestCost > estimates[j].RequestCost
+		}
+		if estimates[i].Namespace != estimates[j].Namespace {
+			return estimates[i].Namespace < estimates[j].Namespace
+		}
+		return estimates[i].Name < estimates[j].Name
+	})
+}
@@ -0,0 +1,166 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/capacity"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/settings"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func newNode(name, instanceType, cpu, memory string) *v1.Node {
+	resources := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse(cpu),
+		v1.ResourceMemory: resource.MustParse(memory),
+	}
+	return &v1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: name,
+			Labels: map[string]string{"beta.kubernetes.io/instance-type": instanceType}},
+		Status: v1.NodeStatus{Capacity: resources, Allocatable: resources},
+	}
+}
+
+func newPod(namespace, name, node, cpu, memory string, owner *metaV1.OwnerReference) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID(name)},
+		Spec: v1.PodSpec{NodeName: node, Containers: []v1.Container{{
+			Name: "main",
+			Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse(memory),
+			}},
+		}}},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+	if owner != nil {
+		pod.OwnerReferences = []metaV1.OwnerReference{*owner}
+	}
+	return pod
+}
+
+func controllerRef(kind, name, uid string) *metaV1.OwnerReference {
+	controller := true
+	return &metaV1.OwnerReference{Kind: kind, Name: name, UID: types.UID(uid), Controller: &controller}
+}
+
+func TestNodePrices(t *testing.T) {
+	model := NewPriceModel(&settings.CostModel{
+		Currency:          "EUR",
+		CPUHourPrice:      0.04,
+		MemoryGBHourPrice: 0.005,
+		NodeHourPrices:    map[string]float64{"m5.large": 0.1},
+	})
+	if model.Name() != ModelConfigured || model.Currency() != "EUR" {
+		t.Errorf("NewPriceModel() returns model %s with currency %s", model.Name(), model.Currency())
+	}
+
+	cases := []struct {
+		node     *v1.Node
+		expected Prices
+	}{
+		{nil, Prices{CPUHour: 0.04, MemoryGBHour: 0.005}},
+		{newNode("node-1", "m5.xlarge", "4", "16Gi"), Prices{CPUHour: 0.04, MemoryGBHour: 0.005}},
+		// 2 * 0.04 + 8 * 0.005 = 0.12, so prices are scaled to the node price of 0.1.
+		{newNode("node-2", "m5.large", "2", "8Gi"), Prices{CPUHour: 0.04 / 1.2, MemoryGBHour: 0.005 / 1.2}},
+	}
+	for _, c := range cases {
+		actual := model.NodePrices(c.node)
+		if !almostEqual(actual.CPUHour, c.expected.CPUHour) || !almostEqual(actual.MemoryGBHour, c.expected.MemoryGBHour) {
+			t.Errorf("NodePrices(%v) == %v, expected %v", c.node, actual, c.expected)
+		}
+	}
+
+	model = NewPriceModel(&settings.CostModel{CPUHourPrice: 0.04})
+	if prices := model.NodePrices(nil); prices.MemoryGBHour != DefaultMemoryGBHourPrice {
+		t.Errorf("NodePrices() == %v, expected default price of memory", prices)
+	}
+	if model := NewPriceModel(nil); model.Name() != ModelDefault || model.Currency() != DefaultCurrency ||
+		model.NodePrices(nil) != (Prices{CPUHour: DefaultCPUHourPrice, MemoryGBHour: DefaultMemoryGBHourPrice}) {
+		t.Errorf("NewPriceModel(nil) returns %#v", model)
+	}
+}
+
+func almostEqual(a, b float64) bool {
+	return a-b < 1e-9 && b-a < 1e-9
+}
+
+func TestGetCostReport(t *testing.T) {
+	replicaSet := &extensions.ReplicaSet{ObjectMeta: metaV1.ObjectMeta{Namespace: "prod", Name: "api-1", UID: "rs-1",
+		OwnerReferences: []metaV1.OwnerReference{*controllerRef("Deployment", "api", "d-1")}}}
+	finished := newPod("prod", "job-1", "node-1", "1", "1Gi", nil)
+	finished.Status.Phase = v1.PodSucceeded
+	client := fake.NewSimpleClientset(
+		newNode("node-1", "", "4", "16Gi"),
+		replicaSet,
+		newPod("prod", "api-1-a", "node-1", "1", "2Gi", controllerRef("ReplicaSet", "api-1", "rs-1")),
+		newPod("prod", "api-1-b", "node-1", "1", "2Gi", controllerRef("ReplicaSet", "api-1", "rs-1")),
+		newPod("prod", "debug", "node-1", "500m", "1Gi", nil),
+		newPod("dev", "web-0", "gone", "2", "0", controllerRef("StatefulSet", "web", "s-1")),
+		finished,
+	)
+	model := NewPriceModel(&settings.CostModel{CPUHourPrice: 0.01, MemoryGBHourPrice: 0.001})
+
+	report, err := GetCostReport(client, nil, model, common.NewNamespaceQuery(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 4.5 cores * 0.01 * 730 + 5 GiB * 0.001 * 730
+	expectedTotal := Estimate{Pods: 4, CPURequestCost: 32.85, MemoryRequestCost: 3.65, RequestCost: 36.5}
+	if !reflect.DeepEqual(report.Total, expectedTotal) || report.UsageAvailable || report.Model != ModelConfigured ||
+		report.Currency != DefaultCurrency || report.HoursPerMonth != HoursPerMonth {
+		t.Errorf("GetCostReport() returns %#v, expected total %#v", report, expectedTotal)
+	}
+
+	namespaces := make([]string, 0)
+	for _, estimate := range report.Namespaces {
+		namespaces = append(namespaces, estimate.Name)
+	}
+	if !reflect.DeepEqual(namespaces, []string{"prod", "dev"}) {
+		t.Errorf("GetCostReport() returns namespaces %v", namespaces)
+	}
+
+	workloads := make([]string, 0)
+	for _, estimate := range report.Workloads {
+		workloads = append(workloads, estimate.Kind+" "+estimate.Namespace+"/"+estimate.Name)
+	}
+	expected := []string{"Deployment prod/api", "StatefulSet dev/web", "Pod prod/debug"}
+	if !reflect.DeepEqual(workloads, expected) {
+		t.Errorf("GetCostReport() returns workloads %v, expected %v", workloads, expected)
+	}
+}
+
+func TestToReportWithUsage(t *testing.T) {
+	pod := newPod("prod", "api", "node-1", "1", "1Gi", nil)
+	model := NewPriceModel(&settings.CostModel{CPUHourPrice: 0.01, MemoryGBHourPrice: 0.001})
+	usage := map[types.UID]capacity.PodUsage{pod.UID: {CPU: 1500, Memory: 512 * 1024 * 1024}}
+
+	report := toReport(model, []v1.Node{*newNode("node-1", "", "4", "16Gi")}, []v1.Pod{*pod}, nil, usage)
+	total := report.Total
+	// Usage of CPU above requests does not reduce idle cost of memory.
+	if !report.UsageAvailable || total.UsageCost == nil || *total.UsageCost != 11.32 ||
+		total.IdleCost == nil || *total.IdleCost != 0.37 {
+		t.Errorf("toReport() returns %#v", total)
+	}
+}
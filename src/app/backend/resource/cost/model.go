@@ -0,0 +1,123 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cost estimates monthly cost of namespaces and workloads from resources requested and
+// used by their pods. Prices of resources come from a pluggable price model.
+package cost
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/settings"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// Default prices of resources per hour, similar to on-demand prices of general purpose instances
+// of public clouds.
+const (
+	DefaultCurrency          = "USD"
+	DefaultCPUHourPrice      = 0.031611
+	DefaultMemoryGBHourPrice = 0.004237
+)
+
+// Names of price models.
+const (
+	ModelDefault    = "Default"
+	ModelConfigured = "Configured"
+)
+
+// Labels of nodes with their instance type. The beta label is set by older kubelets.
+var instanceTypeLabels = []string{
+	"node.kubernetes.io/instance-type",
+	"beta.kubernetes.io/instance-type",
+}
+
+const bytesPerGB = 1024 * 1024 * 1024
+
+// Prices are prices of resources on a node per hour.
+type Prices struct {
+	// CPUHour is the price of one CPU core per hour.
+	CPUHour float64
+	// MemoryGBHour is the price of one GiB of memory per hour.
+	MemoryGBHour float64
+}
+
+// PriceModel provides prices of resources on nodes. Models can be backed by configured prices or
+// by billing APIs of cloud providers.
+type PriceModel interface {
+	// Name of the model shown in reports.
+	Name() string
+	// Currency of prices.
+	Currency() string
+	// NodePrices returns prices of resources on the node. Node is nil for pods scheduled to nodes,
+	// which do not exist anymore.
+	NodePrices(node *v1.Node) Prices
+}
+
+// configuredModel is a price model with prices from settings.
+type configuredModel struct {
+	name  string
+	model settings.CostModel
+}
+
+// NewPriceModel returns price model with prices configured in settings. Zero prices fall back to
+// default prices, and so does nil configuration.
+func NewPriceModel(config *settings.CostModel) PriceModel {
+	if config == nil {
+		return &configuredModel{name: ModelDefault}
+	}
+	return &configuredModel{name: ModelConfigured, model: *config}
+}
+
+// Name implements PriceModel interface.
+func (self *configuredModel) Name() string {
+	return self.name
+}
+
+// Currency implements PriceModel interface.
+func (self *configuredModel) Currency() string {
+	if len(self.model.Currency) == 0 {
+		return DefaultCurrency
+	}
+	return self.model.Currency
+}
+
+// NodePrices implements PriceModel interface. Price of a node, whose instance type has a price, is
+// split between its CPU and memory in the ratio of CPU and memory prices.
+func (self *configuredModel) NodePrices(node *v1.Node) Prices {
+	prices := Prices{CPUHour: self.model.CPUHourPrice, MemoryGBHour: self.model.MemoryGBHourPrice}
+	if prices.CPUHour == 0 {
+		prices.CPUHour = DefaultCPUHourPrice
+	}
+	if prices.MemoryGBHour == 0 {
+		prices.MemoryGBHour = DefaultMemoryGBHourPrice
+	}
+	if node == nil {
+		return prices
+	}
+
+	for _, label := range instanceTypeLabels {
+		nodePrice, ok := self.model.NodeHourPrices[node.Labels[label]]
+		if !ok {
+			continue
+		}
+
+		resources := node.Status.Capacity
+		cores := float64(resources.Cpu().MilliValue()) / 1000
+		gigabytes := float64(resources.Memory().Value()) / bytesPerGB
+		if weighted := cores*prices.CPUHour + gigabytes*prices.MemoryGBHour; weighted > 0 {
+			scale := nodePrice / weighted
+			return Prices{CPUHour: prices.CPUHour * scale, MemoryGBHour: prices.MemoryGBHour * scale}
+		}
+	}
+	return prices
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cronjob
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	batch "k8s.io/client-go/pkg/apis/batch/v1"
+	batch2 "k8s.io/client-go/pkg/apis/batch/v2alpha1"
+)
+
+const (
+	// InstantiateAnnotation marks Jobs created from Cron Job on demand. Same annotation is used by
+	// kubectl create job --from=cronjob.
+	InstantiateAnnotation = "cronjob.kubernetes.io/instantiate"
+
+	// Maximum length of Job name, so that names of its pods are valid labels.
+	maxJobNameLength = 63
+
+	// Length of the suffix appended to names of manually triggered Jobs.
+	manualJobSuffixLength = len("-manual-") + 10
+)
+
+// TriggerCronJob creates a Job from the Cron Job's job template, i.e. runs the Cron Job now.
+func TriggerCronJob(client client.Interface, namespace, name string) (*api.ObjectMeta, error) {
+	cronJob, err := client.BatchV2alpha1().CronJobs(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := client.BatchV1().Jobs(namespace).Create(newManualJob(cronJob, time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Created %s job from %s cron job in %s namespace", job.Name, name, namespace)
+	meta := api.NewObjectMeta(job.ObjectMeta)
+	return &meta, nil
+}
+
+// newManualJob creates Job from the Cron Job's job template, owned by the Cron Job, so that it is
+// listed among its Jobs.
+func newManualJob(cronJob *batch2.CronJob, now time.Time) *batch.Job {
+	prefix := cronJob.Name
+	if len(prefix) > maxJobNameLength-manualJobSuffixLength {
+		prefix = strings.TrimRight(prefix[:maxJobNameLength-manualJobSuffixLength], "-")
+	}
+
+	annotations := map[string]string{InstantiateAnnotation: "manual"}
+	for key, value := range cronJob.Spec.JobTemplate.Annotations {
+		annotations[key] = value
+	}
+
+	controller := true
+	return &batch.Job{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-manual-%d", prefix, now.Unix()),
+			Namespace:   cronJob.Namespace,
+			Labels:      cronJob.Spec.JobTemplate.Labels,
+			Annotations: annotations,
+			OwnerReferences: []metaV1.OwnerReference{{
+				APIVersion: "batch/v2alpha1",
+				Kind:       "CronJob",
+				Name:       cronJob.Name,
+				UID:        cronJob.UID,
+				Controller: &controller,
+			}},
+		},
+		Spec: cronJob.Spec.JobTemplate.Spec,
+	}
+}
+
+// SetCronJobSuspend suspends or resumes subsequent executions of the Cron Job. Jobs which are
+// already running are not affected.
+func SetCronJobSuspend(client client.Interface, namespace, name string, suspend bool) (*CronJob, error) {
+	logger.Infof("Setting suspend of %s cron job in %s namespace to %t", name, namespace, suspend)
+
+	cronJob, err := client.BatchV2alpha1().CronJobs(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	cronJob.Spec.Suspend = &suspend
+	cronJob, err = client.BatchV2alpha1().CronJobs(namespace).Update(cronJob)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toCronJob(cronJob)
+	return &result, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cronjob
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	batch "k8s.io/client-go/pkg/apis/batch/v1"
+	batch2 "k8s.io/client-go/pkg/apis/batch/v2alpha1"
+)
+
+func newCronJob(name string) *batch2.CronJob {
+	return &batch2.CronJob{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default", UID: "cron-uid"},
+		Spec: batch2.CronJobSpec{
+			Schedule: "*/5 * * * *",
+			JobTemplate: batch2.JobTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{"app": "backup"}},
+			},
+		},
+	}
+}
+
+func TestNewManualJob(t *testing.T) {
+	cases := []struct {
+		name, expected string
+	}{
+		{"backup", "backup-manual-1500000000"},
+		{"a-very-long-cron-job-name-which-does-not-fit-into-a-job-name",
+			"a-very-long-cron-job-name-which-does-not-fit-manual-1500000000"},
+	}
+
+	for _, c := range cases {
+		job := newManualJob(newCronJob(c.name), time.Unix(1500000000, 0))
+		if job.Name != c.expected || len(job.Name) > maxJobNameLength {
+			t.Errorf("newManualJob(%#v) returns job named %#v, expected %#v", c.name, job.Name,
+				c.expected)
+		}
+		if job.Annotations[InstantiateAnnotation] != "manual" ||
+			!reflect.DeepEqual(job.Labels, map[string]string{"app": "backup"}) ||
+			job.OwnerReferences[0].UID != "cron-uid" {
+			t.Errorf("newManualJob(%#v) returns %#v, expected job owned by the cron job", c.name, job)
+		}
+	}
+}
+
+func TestCronJobActions(t *testing.T) {
+	client := fake.NewSimpleClientset(newCronJob("backup"), &batch.Job{
+		ObjectMeta: metaV1.ObjectMeta{Name: "other", Namespace: "default"},
+	})
+
+	if _, err := TriggerCronJob(client, "default", "backup"); err != nil {
+		t.Fatalf("TriggerCronJob() returns error %s", err)
+	}
+
+	jobs, err := GetCronJobJobs(client, nil, dataselect.NoDataSelect, "default", "backup")
+	if err != nil {
+		t.Fatalf("GetCronJobJobs() returns error %s", err)
+	}
+	if len(jobs.Jobs) != 1 || jobs.Jobs[0].ObjectMeta.Annotations[InstantiateAnnotation] != "manual" {
+		t.Errorf("GetCronJobJobs() returns %#v, expected manually triggered job", jobs.Jobs)
+	}
+
+	for _, suspend := range []bool{true, false} {
+		cronJob, err := SetCronJobSuspend(client, "default", "backup", suspend)
+		if err != nil || cronJob.Suspend != suspend {
+			t.Errorf("SetCronJobSuspend(%t) returns %#v, %v", suspend, cronJob, err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cronjob
+
+import (
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/job"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	batch2 "k8s.io/client-go/pkg/apis/batch/v2alpha1"
+)
+
+// CronJobDetail contains Cron Job details.
+type CronJobDetail struct {
+	CronJob `json:",inline"`
+
+	ConcurrencyPolicy       string `json:"concurrencyPolicy"`
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds"`
+
+	// Jobs spawned by the Cron Job.
+	Jobs job.JobList `json:"jobs"`
+}
+
+// GetCronJobDetail gets Cron Job details together with the Jobs it spawned.
+func GetCronJobDetail(client client.Interface, metricClient metricapi.MetricClient, namespace,
+	name string) (*CronJobDetail, error) {
+	logger.Infof("Getting details of %s cron job in %s namespace", name, namespace)
+
+	cronJob, err := client.BatchV2alpha1().CronJobs(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := getCronJobJobs(client, metricClient, dataselect.DefaultDataSelectWithMetrics, cronJob)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCronJobDetail(cronJob, *jobs), nil
+}
+
+func toCronJobDetail(cronJob *batch2.CronJob, jobs job.JobList) *CronJobDetail {
+	return &CronJobDetail{
+		CronJob:                 toCronJob(cronJob),
+		ConcurrencyPolicy:       string(cronJob.Spec.ConcurrencyPolicy),
+		StartingDeadlineSeconds: cronJob.Spec.StartingDeadlineSeconds,
+		Jobs:                    jobs,
+	}
+}
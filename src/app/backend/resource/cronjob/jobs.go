@@ -0,0 +1,87 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cronjob
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/job"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	batch "k8s.io/client-go/pkg/apis/batch/v1"
+	batch2 "k8s.io/client-go/pkg/apis/batch/v2alpha1"
+)
+
+// GetCronJobJobs returns list of Jobs spawned by the Cron Job, both scheduled and triggered
+// manually.
+func GetCronJobJobs(client client.Interface, metricClient metricapi.MetricClient,
+	dsQuery *dataselect.DataSelectQuery, namespace, name string) (*job.JobList, error) {
+	cronJob, err := client.BatchV2alpha1().CronJobs(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return getCronJobJobs(client, metricClient, dsQuery, cronJob)
+}
+
+func getCronJobJobs(client client.Interface, metricClient metricapi.MetricClient,
+	dsQuery *dataselect.DataSelectQuery, cronJob *batch2.CronJob) (*job.JobList, error) {
+	nsQuery := common.NewSameNamespaceQuery(cronJob.Namespace)
+	channels := &common.ResourceChannels{
+		JobList:   common.GetJobListChannel(client, nsQuery, 1),
+		PodList:   common.GetPodListChannel(client, nsQuery, 1),
+		EventList: common.GetEventListChannel(client, nsQuery, 1),
+	}
+
+	jobs := <-channels.JobList.List
+	err := <-channels.JobList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	pods := <-channels.PodList.List
+	err = <-channels.PodList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	events := <-channels.EventList.List
+	err = <-channels.EventList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	return job.ToJobList(filterJobsByOwnerUID(cronJob, jobs.Items), pods.Items, events.Items,
+		nonCriticalErrors, dsQuery, metricClient), nil
+}
+
+// filterJobsByOwnerUID returns Jobs controlled by the Cron Job.
+func filterJobsByOwnerUID(cronJob *batch2.CronJob, jobs []batch.Job) []batch.Job {
+	result := make([]batch.Job, 0)
+	for _, item := range jobs {
+		for _, owner := range item.OwnerReferences {
+			if owner.UID == cronJob.UID {
+				result = append(result, item)
+				break
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cronjob
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	batch2 "k8s.io/client-go/pkg/apis/batch/v2alpha1"
+)
+
+// CronJobList contains a list of CronJobs in the cluster.
+type CronJobList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+	Items    []CronJob    `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// CronJob is a presentation layer view of Kubernetes CronJob resource.
+type CronJob struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Schedule in Cron format.
+	Schedule string `json:"schedule"`
+
+	// Whether subsequent executions are suspended.
+	Suspend bool `json:"suspend"`
+
+	// Number of currently running jobs.
+	Active int `json:"active"`
+
+	// Last time the job was successfully scheduled.
+	LastSchedule *metaV1.Time `json:"lastSchedule"`
+}
+
+// GetCronJobList returns a list of all CronJobs in the cluster.
+func GetCronJobList(client client.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*CronJobList, error) {
+	logger.Info("Getting list of all cron jobs in the cluster")
+
+	cronJobs := make([]batch2.CronJob, 0)
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
+	list := &batch2.CronJobList{}
+	var err error
+	if chunk.IsEnabled() {
+		err = chunk.List(client.BatchV2alpha1().RESTClient(), "cronjobs", nsQuery, list)
+	} else {
+		list, err = client.BatchV2alpha1().CronJobs(nsQuery.ToRequestParam()).List(metaV1.ListOptions{})
+	}
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+	if list != nil {
+		for _, item := range list.Items {
+			if nsQuery.Matches(item.Namespace) {
+				cronJobs = append(cronJobs, item)
+			}
+		}
+	}
+
+	cronJobList := toCronJobList(cronJobs, nonCriticalErrors, dsQuery)
+	chunk.SetListMeta(&cronJobList.ListMeta)
+	return cronJobList, nil
+}
+
+func toCronJobList(cronJobs []batch2.CronJob, nonCriticalErrors []error,
+	dsQuery *dataselect.DataSelectQuery) *CronJobList {
+	list := &CronJobList{
+		Items:    make([]CronJob, 0),
+		ListMeta: api.ListMeta{TotalItems: len(cronJobs)},
+		Errors:   nonCriticalErrors,
+	}
+
+	cronJobCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(cronJobs), dsQuery)
+	cronJobs = fromCells(cronJobCells)
+	list.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for i := range cronJobs {
+		list.Items = append(list.Items, toCronJob(&cronJobs[i]))
+	}
+
+	return list
+}
+
+func toCronJob(cronJob *batch2.CronJob) CronJob {
+	return CronJob{
+		ObjectMeta:   api.NewObjectMeta(cronJob.ObjectMeta),
+		TypeMeta:     api.NewTypeMeta(api.ResourceKindCronJob),
+		Schedule:     cronJob.Spec.Schedule,
+		Suspend:      cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend,
+		Active:       len(cronJob.Status.Active),
+		LastSchedule: cronJob.Status.LastScheduleTime,
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customresourcedefinition
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// Custom resource definitions are served by the apiextensions API group, which is not part of the
+// vendored client, so they are read with the dynamic client and decoded to the types below.
+var customResourceDefinitionGroupVersion = schema.GroupVersion{
+	Group:   "apiextensions.k8s.io",
+	Version: "v1beta1",
+}
+
+var customResourceDefinitionResource = &metaV1.APIResource{
+	Name:       "customresourcedefinitions",
+	Namespaced: false,
+	Kind:       "CustomResourceDefinition",
+}
+
+// NamespacedScope is the scope of custom resources that live in namespaces.
+const NamespacedScope = "Namespaced"
+
+// customResourceDefinition is a subset of apiextensions/v1beta1 CustomResourceDefinition.
+type customResourceDefinition struct {
+	metaV1.TypeMeta `json:",inline"`
+	ObjectMeta      metaV1.ObjectMeta            `json:"metadata"`
+	Spec            customResourceDefinitionSpec `json:"spec"`
+}
+
+type customResourceDefinitionSpec struct {
+	Group   string                        `json:"group"`
+	Version string                        `json:"version"`
+	Scope   string                        `json:"scope"`
+	Names   CustomResourceDefinitionNames `json:"names"`
+}
+
+// CustomResourceDefinitionNames are names used to serve custom resources.
+type CustomResourceDefinitionNames struct {
+	Plural     string   `json:"plural"`
+	Singular   string   `json:"singular,omitempty"`
+	ShortNames []string `json:"shortNames,omitempty"`
+	Kind       string   `json:"kind"`
+	ListKind   string   `json:"listKind,omitempty"`
+}
+
+// fromUnstructured decodes custom resource definition from its unstructured representation.
+func fromUnstructured(obj *unstructured.Unstructured) (*customResourceDefinition, error) {
+	raw, err := json.Marshal(obj.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	crd := new(customResourceDefinition)
+	if err := json.Unmarshal(raw, crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+// groupVersion returns group version under which custom resources are served.
+func (self *customResourceDefinition) groupVersion() schema.GroupVersion {
+	return schema.GroupVersion{Group: self.Spec.Group, Version: self.Spec.Version}
+}
+
+// apiResource returns API resource of custom resources defined by the definition.
+func (self *customResourceDefinition) apiResource() *metaV1.APIResource {
+	return &metaV1.APIResource{
+		Name:       self.Spec.Names.Plural,
+		Namespaced: self.Spec.Scope == NamespacedScope,
+		Kind:       self.Spec.Names.Kind,
+	}
+}
+
+// newDynamicClient creates dynamic client for given API group version.
+func newDynamicClient(config *rest.Config, groupVersion schema.GroupVersion) (*dynamic.Client, error) {
+	cfg := *config
+	cfg.GroupVersion = &groupVersion
+	cfg.APIPath = "/apis"
+	return dynamic.NewClient(&cfg)
+}
+
+// getCustomResourceDefinition gets custom resource definition by name.
+func getCustomResourceDefinition(config *rest.Config, name string) (*customResourceDefinition, error) {
+	client, err := newDynamicClient(config, customResourceDefinitionGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := client.Resource(customResourceDefinitionResource, "").Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromUnstructured(obj)
+}
+
+// newResourceClient creates client for custom resources defined by the given definition.
+func newResourceClient(config *rest.Config, crd *customResourceDefinition,
+	namespace string) (*dynamic.ResourceClient, error) {
+	client, err := newDynamicClient(config, crd.groupVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Resource(crd.apiResource(), namespace), nil
+}
+
+// toResourceKind returns dashboard resource kind of custom resources, i.e. lower case kind.
+func toResourceKind(kind string) string {
+	return strings.ToLower(kind)
+}
+
+type CustomResourceDefinitionCell customResourceDefinition
+
+func (self CustomResourceDefinitionCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []customResourceDefinition) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = CustomResourceDefinitionCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []customResourceDefinition {
+	std := make([]customResourceDefinition, len(cells))
+	for i := range std {
+		std[i] = customResourceDefinition(cells[i].(CustomResourceDefinitionCell))
+	}
+	return std
+}
+
+type CustomResourceObjectCell unstructured.Unstructured
+
+func (self CustomResourceObjectCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	obj := unstructured.Unstructured(self)
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(obj.GetName())
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(obj.GetCreationTimestamp().Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(obj.GetNamespace())
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toObjectCells(std []unstructured.Unstructured) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = CustomResourceObjectCell(std[i])
+	}
+	return cells
+}
+
+func fromObjectCells(cells []dataselect.DataCell) []unstructured.Unstructured {
+	std := make([]unstructured.Unstructured, len(cells))
+	for i := range std {
+		std[i] = unstructured.Unstructured(cells[i].(CustomResourceObjectCell))
+	}
+	return std
+}
@@ -0,0 +1,51 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customresourcedefinition
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"k8s.io/client-go/rest"
+)
+
+// CustomResourceDefinitionDetail contains custom resource definition details together with
+// the list of its custom resources.
+type CustomResourceDefinitionDetail struct {
+	CustomResourceDefinition `json:",inline"`
+
+	Objects CustomResourceObjectList `json:"objects"`
+}
+
+// GetCustomResourceDefinitionDetail returns detailed information about custom resource definition.
+func GetCustomResourceDefinitionDetail(config *rest.Config, name string) (*CustomResourceDefinitionDetail, error) {
+	logger.Infof("Getting details of %s custom resource definition", name)
+
+	crd, err := getCustomResourceDefinition(config, name)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := getCustomResourceObjectList(config, crd, common.NewNamespaceQuery(nil),
+		dataselect.DefaultDataSelect)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CustomResourceDefinitionDetail{
+		CustomResourceDefinition: toCustomResourceDefinition(crd),
+		Objects:                  *objects,
+	}, nil
+}
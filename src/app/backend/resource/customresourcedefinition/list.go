@@ -0,0 +1,108 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customresourcedefinition
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+)
+
+// CustomResourceDefinition is a presentation layer view of Kubernetes custom resource definition.
+type CustomResourceDefinition struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// API group and version under which custom resources are served.
+	Group   string `json:"group"`
+	Version string `json:"version"`
+
+	// Scope of custom resources, either Namespaced or Cluster.
+	Scope string                        `json:"scope"`
+	Names CustomResourceDefinitionNames `json:"names"`
+}
+
+// CustomResourceDefinitionList contains a list of custom resource definitions in the cluster.
+type CustomResourceDefinitionList struct {
+	ListMeta api.ListMeta               `json:"listMeta"`
+	Items    []CustomResourceDefinition `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetCustomResourceDefinitionList returns a list of custom resource definitions in the cluster.
+func GetCustomResourceDefinitionList(config *rest.Config,
+	dsQuery *dataselect.DataSelectQuery) (*CustomResourceDefinitionList, error) {
+	logger.Info("Getting list of custom resource definitions")
+
+	client, err := newDynamicClient(config, customResourceDefinitionGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	crds := make([]customResourceDefinition, 0)
+	obj, err := client.Resource(customResourceDefinitionResource, "").List(metaV1.ListOptions{})
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	if list, ok := obj.(*unstructured.UnstructuredList); ok && err == nil {
+		for i := range list.Items {
+			crd, err := fromUnstructured(&list.Items[i])
+			if err != nil {
+				return nil, err
+			}
+			crds = append(crds, *crd)
+		}
+	}
+
+	return toCustomResourceDefinitionList(crds, nonCriticalErrors, dsQuery), nil
+}
+
+func toCustomResourceDefinitionList(crds []customResourceDefinition, nonCriticalErrors []error,
+	dsQuery *dataselect.DataSelectQuery) *CustomResourceDefinitionList {
+	result := &CustomResourceDefinitionList{
+		Items:    make([]CustomResourceDefinition, 0),
+		ListMeta: api.ListMeta{TotalItems: len(crds)},
+		Errors:   nonCriticalErrors,
+	}
+
+	crdCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(crds), dsQuery)
+	crds = fromCells(crdCells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for i := range crds {
+		result.Items = append(result.Items, toCustomResourceDefinition(&crds[i]))
+	}
+
+	return result
+}
+
+func toCustomResourceDefinition(crd *customResourceDefinition) CustomResourceDefinition {
+	return CustomResourceDefinition{
+		ObjectMeta: api.NewObjectMeta(crd.ObjectMeta),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindCustomResourceDefinition),
+		Group:      crd.Spec.Group,
+		Version:    crd.Spec.Version,
+		Scope:      crd.Spec.Scope,
+		Names:      crd.Spec.Names,
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customresourcedefinition
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetCustomResourceDefinitionList(t *testing.T) {
+	cases := []struct {
+		objects  []unstructured.Unstructured
+		expected *CustomResourceDefinitionList
+	}{
+		{
+			nil,
+			&CustomResourceDefinitionList{
+				ListMeta: api.ListMeta{TotalItems: 0},
+				Items:    []CustomResourceDefinition{},
+			},
+		},
+		{
+			[]unstructured.Unstructured{{Object: map[string]interface{}{
+				"apiVersion": "apiextensions.k8s.io/v1beta1",
+				"kind":       "CustomResourceDefinition",
+				"metadata":   map[string]interface{}{"name": "certificates.certmanager.k8s.io"},
+				"spec": map[string]interface{}{
+					"group":   "certmanager.k8s.io",
+					"version": "v1alpha1",
+					"scope":   "Namespaced",
+					"names": map[string]interface{}{
+						"plural":     "certificates",
+						"kind":       "Certificate",
+						"shortNames": []interface{}{"cert"},
+					},
+				},
+			}}},
+			&CustomResourceDefinitionList{
+				ListMeta: api.ListMeta{TotalItems: 1},
+				Items: []CustomResourceDefinition{{
+					ObjectMeta: api.ObjectMeta{Name: "certificates.certmanager.k8s.io"},
+					TypeMeta:   api.TypeMeta{Kind: api.ResourceKindCustomResourceDefinition},
+					Group:      "certmanager.k8s.io",
+					Version:    "v1alpha1",
+					Scope:      "Namespaced",
+					Names: CustomResourceDefinitionNames{
+						Plural:     "certificates",
+						Kind:       "Certificate",
+						ShortNames: []string{"cert"},
+					},
+				}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		crds := make([]customResourceDefinition, 0)
+		for i := range c.objects {
+			crd, err := fromUnstructured(&c.objects[i])
+			if err != nil {
+				t.Fatalf("fromUnstructured(%#v) returned error: %s", c.objects[i], err)
+			}
+			crds = append(crds, *crd)
+		}
+
+		actual := toCustomResourceDefinitionList(crds, nil, dataselect.NoDataSelect)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("toCustomResourceDefinitionList(%#v) == %#v, expected %#v", crds, actual,
+				c.expected)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customresourcedefinition
+
+import (
+	"fmt"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/rest"
+)
+
+// CustomResourceObject is a single custom resource on the list.
+type CustomResourceObject struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+}
+
+// CustomResourceObjectList contains a list of custom resources of a single definition.
+type CustomResourceObjectList struct {
+	ListMeta api.ListMeta           `json:"listMeta"`
+	Items    []CustomResourceObject `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// CustomResourceObjectDetail contains generic representation of a custom resource. Spec and
+// status are not known upfront, so they are returned as they are.
+type CustomResourceObjectDetail struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	Spec   interface{} `json:"spec,omitempty"`
+	Status interface{} `json:"status,omitempty"`
+
+	// Whole object, used by the YAML editor.
+	Object map[string]interface{} `json:"object"`
+}
+
+// CustomResourceObjectSpec is a specification of custom resource to create or update.
+type CustomResourceObjectSpec struct {
+	// YAML or JSON content of the object.
+	Content string `json:"content"`
+}
+
+// GetCustomResourceObjectList returns custom resources of the given definition.
+func GetCustomResourceObjectList(config *rest.Config, crdName string, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*CustomResourceObjectList, error) {
+	logger.Infof("Getting custom resources of %s definition", crdName)
+
+	crd, err := getCustomResourceDefinition(config, crdName)
+	if err != nil {
+		return nil, err
+	}
+
+	return getCustomResourceObjectList(config, crd, nsQuery, dsQuery)
+}
+
+func getCustomResourceObjectList(config *rest.Config, crd *customResourceDefinition,
+	nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*CustomResourceObjectList, error) {
+	client, err := newResourceClient(config, crd, nsQuery.ToRequestParam())
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]unstructured.Unstructured, 0)
+	obj, err := client.List(metaV1.ListOptions{})
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	if list, ok := obj.(*unstructured.UnstructuredList); ok && err == nil {
+		for _, item := range list.Items {
+			if nsQuery.Matches(item.GetNamespace()) || !crd.apiResource().Namespaced {
+				objects = append(objects, item)
+			}
+		}
+	}
+
+	return toCustomResourceObjectList(crd, objects, nonCriticalErrors, dsQuery), nil
+}
+
+func toCustomResourceObjectList(crd *customResourceDefinition, objects []unstructured.Unstructured,
+	nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *CustomResourceObjectList {
+	result := &CustomResourceObjectList{
+		Items:    make([]CustomResourceObject, 0),
+		ListMeta: api.ListMeta{TotalItems: len(objects)},
+		Errors:   nonCriticalErrors,
+	}
+
+	objectCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toObjectCells(objects), dsQuery)
+	objects = fromObjectCells(objectCells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for i := range objects {
+		result.Items = append(result.Items, CustomResourceObject{
+			ObjectMeta: toObjectMeta(&objects[i]),
+			TypeMeta:   api.NewTypeMeta(api.ResourceKind(toResourceKind(crd.Spec.Names.Kind))),
+		})
+	}
+
+	return result
+}
+
+// GetCustomResourceObjectDetail returns custom resource of the given definition.
+func GetCustomResourceObjectDetail(config *rest.Config, crdName, namespace,
+	name string) (*CustomResourceObjectDetail, error) {
+	logger.Infof("Getting details of %s custom resource in %s namespace", name, namespace)
+
+	crd, err := getCustomResourceDefinition(config, crdName)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newResourceClient(config, crd, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := client.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCustomResourceObjectDetail(obj), nil
+}
+
+func toCustomResourceObjectDetail(obj *unstructured.Unstructured) *CustomResourceObjectDetail {
+	return &CustomResourceObjectDetail{
+		ObjectMeta: toObjectMeta(obj),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKind(toResourceKind(obj.GetKind()))),
+		Spec:       obj.Object["spec"],
+		Status:     obj.Object["status"],
+		Object:     obj.Object,
+	}
+}
+
+// CreateCustomResourceObject creates custom resource of the given definition from YAML or JSON
+// content.
+func CreateCustomResourceObject(config *rest.Config, crdName, namespace string,
+	spec *CustomResourceObjectSpec) (*CustomResourceObjectDetail, error) {
+	crd, err := getCustomResourceDefinition(config, crdName)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := parseCustomResourceObject(crd, spec.Content)
+	if err != nil {
+		return nil, err
+	}
+	if len(obj.GetNamespace()) == 0 {
+		obj.SetNamespace(namespace)
+	}
+
+	logger.Infof("Creating %s custom resource in %s namespace", obj.GetName(), obj.GetNamespace())
+	client, err := newResourceClient(config, crd, obj.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.Create(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCustomResourceObjectDetail(created), nil
+}
+
+// UpdateCustomResourceObject replaces custom resource with YAML or JSON content. Content has to
+// carry resource version of the edited object, so that concurrent modifications are rejected with
+// a conflict.
+func UpdateCustomResourceObject(config *rest.Config, crdName, namespace, name string,
+	spec *CustomResourceObjectSpec) (*CustomResourceObjectDetail, error) {
+	crd, err := getCustomResourceDefinition(config, crdName)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := parseCustomResourceObject(crd, spec.Content)
+	if err != nil {
+		return nil, err
+	}
+	if obj.GetName() != name || (len(obj.GetNamespace()) > 0 && obj.GetNamespace() != namespace) {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf(
+			"object name and namespace have to match %s/%s", namespace, name))
+	}
+	obj.SetNamespace(namespace)
+
+	logger.Infof("Updating %s custom resource in %s namespace", name, namespace)
+	client, err := newResourceClient(config, crd, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := client.Update(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCustomResourceObjectDetail(updated), nil
+}
+
+// DeleteCustomResourceObject deletes custom resource of the given definition.
+func DeleteCustomResourceObject(config *rest.Config, crdName, namespace, name string) error {
+	logger.Infof("Deleting %s custom resource in %s namespace", name, namespace)
+
+	crd, err := getCustomResourceDefinition(config, crdName)
+	if err != nil {
+		return err
+	}
+
+	client, err := newResourceClient(config, crd, namespace)
+	if err != nil {
+		return err
+	}
+
+	return client.Delete(name, &metaV1.DeleteOptions{})
+}
+
+// parseCustomResourceObject parses YAML or JSON content and verifies that it describes a custom
+// resource of the given definition.
+func parseCustomResourceObject(crd *customResourceDefinition, content string) (*unstructured.Unstructured, error) {
+	raw, err := yaml.ToJSON([]byte(content))
+	if err != nil {
+		return nil, errorsK8s.NewBadRequest(err.Error())
+	}
+
+	obj := new(unstructured.Unstructured)
+	if err := obj.UnmarshalJSON(raw); err != nil {
+		return nil, errorsK8s.NewBadRequest(err.Error())
+	}
+
+	gvk := obj.GroupVersionKind()
+	if gvk.GroupVersion() != crd.groupVersion() || gvk.Kind != crd.Spec.Names.Kind {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("expected %s of %s, got %s of %s",
+			crd.Spec.Names.Kind, crd.groupVersion(), gvk.Kind, gvk.GroupVersion()))
+	}
+	if len(obj.GetName()) == 0 {
+		return nil, errorsK8s.NewBadRequest("object name is required")
+	}
+
+	return obj, nil
+}
+
+func toObjectMeta(obj *unstructured.Unstructured) api.ObjectMeta {
+	return api.ObjectMeta{
+		Name:              obj.GetName(),
+		Namespace:         obj.GetNamespace(),
+		Labels:            obj.GetLabels(),
+		Annotations:       obj.GetAnnotations(),
+		CreationTimestamp: obj.GetCreationTimestamp(),
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package customresourcedefinition
+
+import (
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestParseCustomResourceObject(t *testing.T) {
+	crd := &customResourceDefinition{
+		Spec: customResourceDefinitionSpec{
+			Group:   "kafka.strimzi.io",
+			Version: "v1alpha1",
+			Scope:   NamespacedScope,
+			Names:   CustomResourceDefinitionNames{Plural: "kafkas", Kind: "Kafka"},
+		},
+	}
+
+	cases := []struct {
+		content    string
+		name       string
+		badRequest bool
+	}{
+		{
+			"apiVersion: kafka.strimzi.io/v1alpha1\nkind: Kafka\nmetadata:\n  name: my-cluster\n" +
+				"spec:\n  replicas: 3\n",
+			"my-cluster", false,
+		},
+		{
+			`{"apiVersion": "kafka.strimzi.io/v1alpha1", "kind": "Kafka", "metadata": {"name": "json"}}`,
+			"json", false,
+		},
+		{
+			"apiVersion: kafka.strimzi.io/v1beta1\nkind: Kafka\nmetadata:\n  name: my-cluster\n",
+			"", true,
+		},
+		{
+			"apiVersion: kafka.strimzi.io/v1alpha1\nkind: KafkaTopic\nmetadata:\n  name: my-topic\n",
+			"", true,
+		},
+		{
+			"apiVersion: kafka.strimzi.io/v1alpha1\nkind: Kafka\n",
+			"", true,
+		},
+		{
+			"not: [valid",
+			"", true,
+		},
+	}
+
+	for _, c := range cases {
+		obj, err := parseCustomResourceObject(crd, c.content)
+		if c.badRequest {
+			if !errorsK8s.IsBadRequest(err) {
+				t.Errorf("parseCustomResourceObject(%#v) returns error %#v, expected bad request",
+					c.content, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseCustomResourceObject(%#v) returns error %#v", c.content, err)
+			continue
+		}
+		if obj.GetName() != c.name {
+			t.Errorf("parseCustomResourceObject(%#v) returns object named %#v, expected %#v",
+				c.content, obj.GetName(), c.name)
+		}
+	}
+}
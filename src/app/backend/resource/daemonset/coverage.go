@@ -0,0 +1,160 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemonset
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/rollout"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/scheduling"
+	api "k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+// NodeCoverageStatus tells whether a daemon pod runs on a node and if not, why.
+type NodeCoverageStatus string
+
+const (
+	// NodeCovered means that a daemon pod runs on the node.
+	NodeCovered NodeCoverageStatus = "Covered"
+	// NodeSelectorMismatch means that the node labels do not match node selector of the pod template.
+	NodeSelectorMismatch NodeCoverageStatus = "NodeSelectorMismatch"
+	// NodeAffinityMismatch means that the node labels do not match required node affinity of the pod
+	// template.
+	NodeAffinityMismatch NodeCoverageStatus = "NodeAffinityMismatch"
+	// NodeTaintNotTolerated means that the node has a taint, which is not tolerated by the pod template.
+	NodeTaintNotTolerated NodeCoverageStatus = "TaintNotTolerated"
+	// NodePodMissing means that the daemon pod should run on the node, but it does not, e.g. because
+	// the node is out of resources or the pod was not created yet.
+	NodePodMissing NodeCoverageStatus = "PodMissing"
+)
+
+// Tolerations added to every daemon pod by the daemon set controller.
+var daemonTolerations = []api.Toleration{
+	{Key: "node.kubernetes.io/not-ready", Operator: api.TolerationOpExists, Effect: api.TaintEffectNoExecute},
+	{Key: "node.kubernetes.io/unreachable", Operator: api.TolerationOpExists, Effect: api.TaintEffectNoExecute},
+	{Key: "node.alpha.kubernetes.io/notReady", Operator: api.TolerationOpExists, Effect: api.TaintEffectNoExecute},
+	{Key: "node.alpha.kubernetes.io/unreachable", Operator: api.TolerationOpExists,
+		Effect: api.TaintEffectNoExecute},
+	{Key: "node.kubernetes.io/disk-pressure", Operator: api.TolerationOpExists, Effect: api.TaintEffectNoSchedule},
+	{Key: "node.kubernetes.io/memory-pressure", Operator: api.TolerationOpExists,
+		Effect: api.TaintEffectNoSchedule},
+	{Key: "node.kubernetes.io/pid-pressure", Operator: api.TolerationOpExists, Effect: api.TaintEffectNoSchedule},
+	{Key: "node.kubernetes.io/unschedulable", Operator: api.TolerationOpExists, Effect: api.TaintEffectNoSchedule},
+}
+
+// NodeCoverage describes whether a daemon pod runs on a node.
+type NodeCoverage struct {
+	NodeName string             `json:"nodeName"`
+	Status   NodeCoverageStatus `json:"status"`
+
+	// Human readable explanation, why a daemon pod does not run on the node.
+	Message string `json:"message,omitempty"`
+
+	// Name of the daemon pod running on the node.
+	PodName string `json:"podName,omitempty"`
+
+	// True when the daemon pod runs the current pod template.
+	PodUpdated bool `json:"podUpdated"`
+}
+
+// DaemonSetCoverage lists nodes of the cluster and whether the daemon set covers them.
+type DaemonSetCoverage struct {
+	// Number of nodes with a daemon pod.
+	Covered int `json:"covered"`
+
+	// Number of nodes, which the daemon set does not target.
+	Excluded int `json:"excluded"`
+
+	// Number of nodes, which the daemon set targets, but have no daemon pod.
+	Missing int `json:"missing"`
+
+	Nodes []NodeCoverage `json:"nodes"`
+}
+
+// getDaemonSetCoverage returns coverage of the given nodes by pods of the daemon set. Nodes that are
+// missing a pod are listed first.
+func getDaemonSetCoverage(daemonSet *extensions.DaemonSet, nodes []api.Node, pods []api.Pod) DaemonSetCoverage {
+	podsByNode := make(map[string]api.Pod)
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil && len(pod.Spec.NodeName) > 0 {
+			podsByNode[pod.Spec.NodeName] = pod
+		}
+	}
+
+	coverage := DaemonSetCoverage{Nodes: make([]NodeCoverage, 0, len(nodes))}
+	for _, node := range nodes {
+		nodeCoverage := NodeCoverage{NodeName: node.Name}
+		if pod, exists := podsByNode[node.Name]; exists {
+			nodeCoverage.Status = NodeCovered
+			nodeCoverage.PodName = pod.Name
+			nodeCoverage.PodUpdated = rollout.IsDaemonPodUpdated(daemonSet, pod)
+			coverage.Covered++
+		} else {
+			nodeCoverage.Status, nodeCoverage.Message = explainMissingPod(&daemonSet.Spec.Template.Spec, &node)
+			if nodeCoverage.Status == NodePodMissing {
+				coverage.Missing++
+			} else {
+				coverage.Excluded++
+			}
+		}
+		coverage.Nodes = append(coverage.Nodes, nodeCoverage)
+	}
+
+	sort.SliceStable(coverage.Nodes, func(i, j int) bool {
+		return coverageOrder(coverage.Nodes[i].Status) < coverageOrder(coverage.Nodes[j].Status)
+	})
+	return coverage
+}
+
+// coverageOrder sorts nodes missing a pod before excluded nodes and covered nodes last.
+func coverageOrder(status NodeCoverageStatus) int {
+	switch status {
+	case NodePodMissing:
+		return 0
+	case NodeCovered:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// explainMissingPod returns the reason why a daemon pod with the given spec does not run on the node.
+func explainMissingPod(spec *api.PodSpec, node *api.Node) (NodeCoverageStatus, string) {
+	if mismatched := scheduling.MismatchedNodeSelector(spec.NodeSelector, node.Labels); len(mismatched) > 0 {
+		return NodeSelectorMismatch, fmt.Sprintf("Node does not have labels %s",
+			strings.Join(mismatched, ", "))
+	}
+
+	if !scheduling.MatchesNodeAffinity(spec.Affinity, node.Labels) {
+		return NodeAffinityMismatch, "Node labels do not match required node affinity"
+	}
+
+	if taints := untoleratedTaints(spec.Tolerations, node.Spec.Taints); len(taints) > 0 {
+		return NodeTaintNotTolerated, fmt.Sprintf("Node has taints %s, which are not tolerated",
+			strings.Join(taints, ", "))
+	}
+
+	return NodePodMissing, "Daemon pod should run on the node, but it does not"
+}
+
+// untoleratedTaints returns NoSchedule and NoExecute taints of the node, which are not tolerated by
+// the pod tolerations or the tolerations added by the daemon set controller.
+func untoleratedTaints(tolerations []api.Toleration, taints []api.Taint) []string {
+	return scheduling.UntoleratedTaints(append(append([]api.Toleration{}, tolerations...), daemonTolerations...),
+		taints)
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemonset
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func TestGetDaemonSetCoverage(t *testing.T) {
+	daemonSet := &extensions.DaemonSet{Spec: extensions.DaemonSetSpec{
+		Template: api.PodTemplateSpec{Spec: api.PodSpec{
+			NodeSelector: map[string]string{"role": "worker"},
+			Affinity: &api.Affinity{NodeAffinity: &api.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &api.NodeSelector{
+					NodeSelectorTerms: []api.NodeSelectorTerm{{MatchExpressions: []api.NodeSelectorRequirement{
+						{Key: "zone", Operator: api.NodeSelectorOpNotIn, Values: []string{"zone-b"}},
+					}}},
+				},
+			}},
+			Tolerations: []api.Toleration{
+				{Key: "dedicated", Operator: api.TolerationOpEqual, Value: "logging"},
+			},
+			Containers: []api.Container{{Name: "agent", Image: "agent:1"}},
+		}},
+	}}
+
+	node := func(name string, labels map[string]string, taints ...api.Taint) api.Node {
+		return api.Node{
+			ObjectMeta: metaV1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       api.NodeSpec{Taints: taints},
+		}
+	}
+	worker := map[string]string{"role": "worker", "zone": "zone-a"}
+
+	nodes := []api.Node{
+		node("covered", worker),
+		node("master", map[string]string{"role": "master"}),
+		node("zone-b", map[string]string{"role": "worker", "zone": "zone-b"}),
+		node("tainted", worker, api.Taint{Key: "gpu", Value: "true", Effect: api.TaintEffectNoSchedule}),
+		node("tolerated", worker,
+			api.Taint{Key: "dedicated", Value: "logging", Effect: api.TaintEffectNoSchedule},
+			api.Taint{Key: "node.kubernetes.io/unreachable", Effect: api.TaintEffectNoExecute},
+			api.Taint{Key: "spot", Effect: api.TaintEffectPreferNoSchedule}),
+	}
+	pods := []api.Pod{
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "agent-1"},
+			Spec: api.PodSpec{NodeName: "covered",
+				Containers: []api.Container{{Name: "agent", Image: "agent:1"}}},
+		},
+	}
+
+	expected := DaemonSetCoverage{
+		Covered:  1,
+		Excluded: 3,
+		Missing:  1,
+		Nodes: []NodeCoverage{
+			{NodeName: "tolerated", Status: NodePodMissing,
+				Message: "Daemon pod should run on the node, but it does not"},
+			{NodeName: "master", Status: NodeSelectorMismatch, Message: "Node does not have labels role=worker"},
+			{NodeName: "zone-b", Status: NodeAffinityMismatch,
+				Message: "Node labels do not match required node affinity"},
+			{NodeName: "tainted", Status: NodeTaintNotTolerated,
+				Message: "Node has taints gpu=true:NoSchedule, which are not tolerated"},
+			{NodeName: "covered", Status: NodeCovered, PodName: "agent-1", PodUpdated: true},
+		},
+	}
+
+	actual := getDaemonSetCoverage(daemonSet, nodes, pods)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("getDaemonSetCoverage() == \ngot: %#v, \nexpected %#v", actual, expected)
+	}
+}
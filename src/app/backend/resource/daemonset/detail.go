@@ -15,14 +15,15 @@
 package daemonset
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	ds "github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gitops"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/pod"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/rollout"
 	resourceService "github.com/kubernetes/dashboard/src/app/backend/resource/service"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -49,12 +50,21 @@ type DaemonSetDetail struct {
 	// Detailed information about service related to Daemon Set.
 	ServiceList resourceService.ServiceList `json:"serviceList"`
 
+	// Progress of the rollout of the current pod template.
+	RolloutProgress rollout.RolloutProgress `json:"rolloutProgress"`
+
+	// Nodes of the cluster and whether a daemon pod runs on them.
+	NodeCoverage DaemonSetCoverage `json:"nodeCoverage"`
+
 	// True when the data contains at least one pod with metrics information, false otherwise.
 	HasMetrics bool `json:"hasMetrics"`
 
 	// List of events related to this daemon set
 	EventList common.EventList `json:"eventList"`
 
+	// GitOps tool managing the daemon set. Set only when it is synced by Argo CD or Flux.
+	GitOps *gitops.Ownership `json:"gitops,omitempty"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -62,7 +72,7 @@ type DaemonSetDetail struct {
 // Returns detailed information about the given daemon set in the given namespace.
 func GetDaemonSetDetail(client k8sClient.Interface, metricClient metricapi.MetricClient,
 	namespace, name string) (*DaemonSetDetail, error) {
-	log.Printf("Getting details of %s daemon set in %s namespace", name, namespace)
+	logger.Infof("Getting details of %s daemon set in %s namespace", name, namespace)
 
 	daemonSet, err := client.ExtensionsV1beta1().DaemonSets(namespace).Get(name, metaV1.GetOptions{})
 	if err != nil {
@@ -75,7 +85,13 @@ func GetDaemonSetDetail(client k8sClient.Interface, metricClient metricapi.Metri
 		return nil, criticalError
 	}
 
-	podInfo, err := getDaemonSetPodInfo(client, daemonSet)
+	rawPods, err := getRawDaemonSetPods(client, name, namespace)
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	nodes, err := getNodes(client)
 	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
 	if criticalError != nil {
 		return nil, criticalError
@@ -94,14 +110,16 @@ func GetDaemonSetDetail(client k8sClient.Interface, metricClient metricapi.Metri
 	}
 
 	daemonSetDetail := &DaemonSetDetail{
-		ObjectMeta:    api.NewObjectMeta(daemonSet.ObjectMeta),
-		TypeMeta:      api.NewTypeMeta(api.ResourceKindDaemonSet),
-		LabelSelector: daemonSet.Spec.Selector,
-		PodInfo:       *podInfo,
-		PodList:       *podList,
-		ServiceList:   *serviceList,
-		EventList:     *eventList,
-		Errors:        nonCriticalErrors,
+		ObjectMeta:      api.NewObjectMeta(daemonSet.ObjectMeta),
+		TypeMeta:        api.NewTypeMeta(api.ResourceKindDaemonSet),
+		LabelSelector:   daemonSet.Spec.Selector,
+		PodInfo:         *getDaemonSetPodInfo(daemonSet, rawPods),
+		PodList:         *podList,
+		ServiceList:     *serviceList,
+		RolloutProgress: *rollout.GetDaemonSetProgress(daemonSet),
+		NodeCoverage:    getDaemonSetCoverage(daemonSet, nodes, rawPods),
+		EventList:       *eventList,
+		Errors:          nonCriticalErrors,
 	}
 
 	for _, container := range daemonSet.Spec.Template.Spec.Containers {
@@ -118,7 +136,7 @@ func GetDaemonSetDetail(client k8sClient.Interface, metricClient metricapi.Metri
 func DeleteDaemonSet(client k8sClient.Interface, namespace, name string,
 	deleteServices bool) error {
 
-	log.Printf("Deleting %s daemon set from %s namespace", name, namespace)
+	logger.Infof("Deleting %s daemon set from %s namespace", name, namespace)
 
 	if deleteServices {
 		if err := DeleteDaemonSetServices(client, namespace, name); err != nil {
@@ -141,14 +159,14 @@ func DeleteDaemonSet(client k8sClient.Interface, namespace, name string,
 		}
 	}
 
-	log.Printf("Successfully deleted %s daemon set from %s namespace", name, namespace)
+	logger.Infof("Successfully deleted %s daemon set from %s namespace", name, namespace)
 
 	return nil
 }
 
 // DeleteDaemonSetServices deletes services related to daemon set with given name in given namespace.
 func DeleteDaemonSetServices(client k8sClient.Interface, namespace, name string) error {
-	log.Printf("Deleting services related to %s daemon set from %s namespace", name,
+	logger.Infof("Deleting services related to %s daemon set from %s namespace", name,
 		namespace)
 
 	daemonSet, err := client.Extensions().DaemonSets(namespace).Get(name, metaV1.GetOptions{})
@@ -172,7 +190,7 @@ func DeleteDaemonSetServices(client k8sClient.Interface, namespace, name string)
 		}
 	}
 
-	log.Printf("Successfully deleted services related to %s daemon set from %s namespace",
+	logger.Infof("Successfully deleted services related to %s daemon set from %s namespace",
 		name, namespace)
 
 	return nil
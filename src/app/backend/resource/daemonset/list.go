@@ -20,6 +20,7 @@ import (
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diagnosis"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
@@ -51,14 +52,21 @@ type DaemonSet struct {
 // GetDaemonSetList returns a list of all Daemon Set in the cluster.
 func GetDaemonSetList(client *client.Clientset, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery,
 	metricClient metricapi.MetricClient) (*DaemonSetList, error) {
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
 	channels := &common.ResourceChannels{
-		DaemonSetList: common.GetDaemonSetListChannel(client, nsQuery, 1),
+		DaemonSetList: common.GetDaemonSetListChunkChannel(client, nsQuery, chunk, 1),
 		ServiceList:   common.GetServiceListChannel(client, nsQuery, 1),
 		PodList:       common.GetPodListChannel(client, nsQuery, 1),
-		EventList:     common.GetEventListChannel(client, nsQuery, 1),
+		EventList:     common.GetChunkEventListChannel(client, nsQuery, chunk, 1),
 	}
 
-	return GetDaemonSetListFromChannels(channels, dsQuery, metricClient)
+	daemonSetList, err := GetDaemonSetListFromChannels(channels, dsQuery, metricClient)
+	if err != nil {
+		return nil, err
+	}
+
+	chunk.SetListMeta(&daemonSetList.ListMeta)
+	return daemonSetList, nil
 }
 
 // GetDaemonSetListFromChannels returns a list of all Daemon Seet in the cluster
@@ -114,6 +122,7 @@ func toDaemonSetList(daemonSets []extensions.DaemonSet, pods []v1.Pod, events []
 		podInfo := common.GetPodInfo(daemonSet.Status.CurrentNumberScheduled,
 			daemonSet.Status.DesiredNumberScheduled, matchingPods)
 		podInfo.Warnings = event.GetPodsEventWarnings(events, matchingPods)
+		podInfo.Diagnosis = diagnosis.DiagnosePods(matchingPods, events)
 
 		daemonSetList.DaemonSets = append(daemonSetList.DaemonSets, DaemonSet{
 			ObjectMeta:      api.NewObjectMeta(daemonSet.ObjectMeta),
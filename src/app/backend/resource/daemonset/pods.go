@@ -15,9 +15,8 @@
 package daemonset
 
 import (
-	"log"
-
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
@@ -31,7 +30,7 @@ import (
 // GetDaemonSetPods return list of pods targeting daemon set.
 func GetDaemonSetPods(client k8sClient.Interface, metricClient metricapi.MetricClient,
 	dsQuery *dataselect.DataSelectQuery, daemonSetName, namespace string) (*pod.PodList, error) {
-	log.Printf("Getting replication controller %s pods in namespace %s", daemonSetName, namespace)
+	logger.Infof("Getting replication controller %s pods in namespace %s", daemonSetName, namespace)
 
 	pods, err := getRawDaemonSetPods(client, daemonSetName, namespace)
 	if err != nil {
@@ -68,15 +67,22 @@ func getRawDaemonSetPods(client k8sClient.Interface, daemonSetName, namespace st
 }
 
 // Returns simple info about pods(running, desired, failing, etc.) related to given daemon set.
-func getDaemonSetPodInfo(client k8sClient.Interface, daemonSet *extensions.DaemonSet) (
-	*common.PodInfo, error) {
+func getDaemonSetPodInfo(daemonSet *extensions.DaemonSet, pods []api.Pod) *common.PodInfo {
+	podInfo := common.GetPodInfo(daemonSet.Status.CurrentNumberScheduled,
+		daemonSet.Status.DesiredNumberScheduled, pods)
+	return &podInfo
+}
 
-	pods, err := getRawDaemonSetPods(client, daemonSet.Name, daemonSet.Namespace)
-	if err != nil {
+// Returns nodes of the cluster, which are checked for daemon set coverage.
+func getNodes(client k8sClient.Interface) ([]api.Node, error) {
+	channels := &common.ResourceChannels{
+		NodeList: common.GetNodeListChannel(client, 1),
+	}
+
+	nodeList := <-channels.NodeList.List
+	if err := <-channels.NodeList.Error; err != nil {
 		return nil, err
 	}
 
-	podInfo := common.GetPodInfo(daemonSet.Status.CurrentNumberScheduled,
-		daemonSet.Status.DesiredNumberScheduled, pods)
-	return &podInfo, nil
+	return nodeList.Items, nil
 }
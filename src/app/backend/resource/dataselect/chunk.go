@@ -0,0 +1,39 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataselect
+
+// ChunkQuery holds options of fetching a list from the apiserver in chunks, using limit and
+// continue token of the list API. Unlike pagination, which selects a page from the whole list,
+// only the requested chunk is fetched, so sorting and filtering apply to the chunk only.
+type ChunkQuery struct {
+	// Limit is the maximum number of items fetched from the apiserver. Chunking is disabled if
+	// it is not positive.
+	Limit int64
+	// Continue is the token returned together with the previous chunk. Empty for the first chunk.
+	Continue string
+}
+
+// NoChunk is an option to fetch whole list at once.
+var NoChunk = &ChunkQuery{}
+
+// NewChunkQuery returns chunk query based on given parameters.
+func NewChunkQuery(limit int64, continueToken string) *ChunkQuery {
+	return &ChunkQuery{Limit: limit, Continue: continueToken}
+}
+
+// IsEnabled returns true if a single chunk of the list should be fetched.
+func (self *ChunkQuery) IsEnabled() bool {
+	return self != nil && self.Limit > 0
+}
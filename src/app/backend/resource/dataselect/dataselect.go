@@ -19,8 +19,8 @@ import (
 
 	"errors"
 
-	"github.com/emicklei/go-restful/log"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 )
 
 // GenericDataCell describes the interface of the data cell that contains all the necessary methods needed to perform
@@ -108,7 +108,7 @@ func (self *DataSelector) Filter() *DataSelector {
 	filteredList := []DataCell{}
 
 	for _, c := range self.GenericDataList {
-		matches := true
+		matches := self.DataSelectQuery.SelectorQuery.Matches(c)
 		for _, filterBy := range self.DataSelectQuery.FilterQuery.FilterByList {
 			v := c.GetProperty(filterBy.Property)
 			if v == nil {
@@ -129,6 +129,77 @@ func (self *DataSelector) Filter() *DataSelector {
 	return self
 }
 
+// metricSortCell wraps data cell with values of metrics used for sorting.
+type metricSortCell struct {
+	DataCell
+	metrics map[PropertyName]ComparableValue
+}
+
+// GetProperty returns metric value for metric properties and delegates to the wrapped cell
+// otherwise.
+func (self metricSortCell) GetProperty(name PropertyName) ComparableValue {
+	if value, ok := self.metrics[name]; ok {
+		return value
+	}
+	return self.DataCell.GetProperty(name)
+}
+
+// metricProperties maps properties that are sorted by metric values to metric names.
+var metricProperties = map[PropertyName]string{
+	CpuUsageProperty:    metricapi.CpuUsage,
+	MemoryUsageProperty: metricapi.MemoryUsage,
+}
+
+// SortWithMetrics sorts the data like Sort, but when data is sorted by cpu or memory usage, the
+// metrics are downloaded for all data cells first. Returns itself to allow method chaining.
+func (self *DataSelector) SortWithMetrics(metricClient metricapi.MetricClient) *DataSelector {
+	var properties []PropertyName
+	for _, sortBy := range self.DataSelectQuery.SortQuery.SortByList {
+		if _, ok := metricProperties[sortBy.Property]; ok {
+			properties = append(properties, sortBy.Property)
+		}
+	}
+	if len(properties) == 0 || metricClient == nil {
+		return self.Sort()
+	}
+
+	selectors := make([]metricapi.ResourceSelector, len(self.GenericDataList))
+	for i, dataCell := range self.GenericDataList {
+		metricDataCell, ok := dataCell.(MetricDataCell)
+		if !ok {
+			logger.Warningf("Data cell does not implement MetricDataCell. Skipping sort by metrics. %v", dataCell)
+			return self.Sort()
+		}
+		selectors[i] = *metricDataCell.GetResourceSelector()
+	}
+
+	wrapped := make([]DataCell, len(self.GenericDataList))
+	for i, dataCell := range self.GenericDataList {
+		wrapped[i] = metricSortCell{DataCell: dataCell, metrics: make(map[PropertyName]ComparableValue)}
+	}
+
+	for _, property := range properties {
+		promises := metricClient.DownloadMetric(selectors, metricProperties[property],
+			self.CachedResources)
+		for i, promise := range promises {
+			value := StdComparableInt(0)
+			if metric, err := promise.GetMetric(); err == nil && metric != nil &&
+				len(metric.DataPoints) > 0 {
+				value = StdComparableInt(metric.DataPoints[len(metric.DataPoints)-1].Y)
+			}
+			wrapped[i].(metricSortCell).metrics[property] = value
+		}
+	}
+
+	self.GenericDataList = wrapped
+	self.Sort()
+	for i, dataCell := range self.GenericDataList {
+		self.GenericDataList[i] = dataCell.(metricSortCell).DataCell
+	}
+
+	return self
+}
+
 func (self *DataSelector) getMetrics(metricClient metricapi.MetricClient) (
 	[]metricapi.MetricPromises, error) {
 	metricPromises := make([]metricapi.MetricPromises, 0)
@@ -147,7 +218,7 @@ func (self *DataSelector) getMetrics(metricClient metricapi.MetricClient) (
 		// make sure data cells support metrics
 		metricDataCell, ok := dataCell.(MetricDataCell)
 		if !ok {
-			log.Printf("Data cell does not implement MetricDataCell. Skipping. %v", dataCell)
+			logger.Warningf("Data cell does not implement MetricDataCell. Skipping. %v", dataCell)
 			continue
 		}
 
@@ -167,7 +238,7 @@ func (self *DataSelector) getMetrics(metricClient metricapi.MetricClient) (
 func (self *DataSelector) GetMetrics(metricClient metricapi.MetricClient) *DataSelector {
 	metricPromisesList, err := self.getMetrics(metricClient)
 	if err != nil {
-		log.Print(err)
+		logger.Error(err)
 		return self
 	}
 
@@ -185,13 +256,13 @@ func (self *DataSelector) GetMetrics(metricClient metricapi.MetricClient) *DataS
 func (self *DataSelector) GetCumulativeMetrics(metricClient metricapi.MetricClient) *DataSelector {
 	metricPromisesList, err := self.getMetrics(metricClient)
 	if err != nil {
-		log.Print(err)
+		logger.Error(err)
 		return self
 	}
 
 	metricNames := self.DataSelectQuery.MetricQuery.MetricNames
 	if metricNames == nil {
-		log.Print("No metrics specified. Skipping metrics.")
+		logger.Info("No metrics specified. Skipping metrics.")
 		return self
 	}
 
@@ -236,7 +307,8 @@ func GenericDataSelect(dataList []DataCell, dsQuery *DataSelectQuery) []DataCell
 		GenericDataList: dataList,
 		DataSelectQuery: dsQuery,
 	}
-	return SelectableData.Sort().Paginate().GenericDataList
+	// Pipeline is Filter -> Sort -> Paginate
+	return SelectableData.Filter().Sort().Paginate().GenericDataList
 }
 
 // GenericDataSelectWithFilter takes a list of GenericDataCells and DataSelectQuery and returns selected data as instructed by dsQuery.
@@ -262,7 +334,8 @@ func GenericDataSelectWithMetrics(dataList []DataCell, dsQuery *DataSelectQuery,
 		CachedResources: cachedResources,
 	}
 	// Pipeline is Filter -> Sort -> CollectMetrics -> Paginate
-	processed := SelectableData.Sort().GetCumulativeMetrics(metricClient).Paginate()
+	processed := SelectableData.Filter().SortWithMetrics(metricClient).GetCumulativeMetrics(metricClient).
+		Paginate()
 	return processed.GenericDataList, processed.CumulativeMetricsPromises
 }
 
@@ -278,7 +351,7 @@ func GenericDataSelectWithFilterAndMetrics(dataList []DataCell, dsQuery *DataSel
 	// Pipeline is Filter -> Sort -> CollectMetrics -> Paginate
 	filtered := SelectableData.Filter()
 	filteredTotal := len(filtered.GenericDataList)
-	processed := filtered.Sort().GetCumulativeMetrics(metricClient).Paginate()
+	processed := filtered.SortWithMetrics(metricClient).GetCumulativeMetrics(metricClient).Paginate()
 	return processed.GenericDataList, processed.CumulativeMetricsPromises, filteredTotal
 }
 
@@ -26,6 +26,10 @@ type DataSelectQuery struct {
 	SortQuery       *SortQuery
 	FilterQuery     *FilterQuery
 	MetricQuery     *MetricQuery
+	// ChunkQuery is optional. If nil or disabled, whole list is fetched from the apiserver.
+	ChunkQuery *ChunkQuery
+	// SelectorQuery is optional. If nil, cells are not filtered by selectors.
+	SelectorQuery *SelectorQuery
 }
 
 var NoMetrics = NewMetricQuery(nil, nil)
@@ -24,4 +24,8 @@ const (
 	CreationTimestampProperty = "creationTimestamp"
 	NamespaceProperty         = "namespace"
 	StatusProperty            = "status"
+	// Properties sorted by the latest value of cpu and memory usage metrics. These are only
+	// available when metrics are downloaded during data select.
+	CpuUsageProperty    = "cpu"
+	MemoryUsageProperty = "memory"
 )
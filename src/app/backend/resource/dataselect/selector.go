@@ -0,0 +1,156 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataselect
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SelectorQuery holds label selector, field selector and free-text search used to filter data
+// cells. Unlike FilterQuery, which filters by properties, it works with object metadata, so it can
+// be applied to any cell wrapping a Kubernetes object.
+type SelectorQuery struct {
+	// LabelSelector that object labels have to match.
+	LabelSelector labels.Selector
+	// FieldSelector that object fields have to match. Supported fields are metadata.name,
+	// metadata.namespace and fields returned by FieldsDataCell.
+	FieldSelector fields.Selector
+	// Search is a case-insensitive text that object name, namespace, label key or label value has
+	// to contain.
+	Search string
+}
+
+// NoSelector is an option for no selector filtering.
+var NoSelector = &SelectorQuery{
+	LabelSelector: labels.Everything(),
+	FieldSelector: fields.Everything(),
+}
+
+// FieldsDataCell extends interface of DataCells with additional fields that can be used in field
+// selectors, e.g., status.phase of a pod.
+type FieldsDataCell interface {
+	DataCell
+	// GetFields returns fields of the cell in addition to metadata.name and metadata.namespace.
+	GetFields() fields.Set
+}
+
+// NewSelectorQuery parses selectors and returns selector query. Empty selectors match everything.
+func NewSelectorQuery(labelSelector, fieldSelector, search string) (*SelectorQuery, error) {
+	parsedLabelSelector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedFieldSelector, err := fields.ParseSelector(fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SelectorQuery{
+		LabelSelector: parsedLabelSelector,
+		FieldSelector: parsedFieldSelector,
+		Search:        strings.ToLower(search),
+	}, nil
+}
+
+// IsEmpty returns true if the query matches all cells.
+func (self *SelectorQuery) IsEmpty() bool {
+	return self == nil || (self.LabelSelector.Empty() && self.FieldSelector.Empty() &&
+		len(self.Search) == 0)
+}
+
+// Matches returns true if the cell matches the query. Cells that do not wrap Kubernetes objects
+// only match empty query.
+func (self *SelectorQuery) Matches(cell DataCell) bool {
+	if self.IsEmpty() {
+		return true
+	}
+
+	objectMeta, ok := getObjectMeta(cell)
+	if !ok {
+		return false
+	}
+
+	if !self.LabelSelector.Matches(labels.Set(objectMeta.Labels)) {
+		return false
+	}
+
+	cellFields := fields.Set{
+		"metadata.name":      objectMeta.Name,
+		"metadata.namespace": objectMeta.Namespace,
+	}
+	if fieldsCell, ok := cell.(FieldsDataCell); ok {
+		for field, value := range fieldsCell.GetFields() {
+			cellFields[field] = value
+		}
+	}
+	if !self.FieldSelector.Matches(cellFields) {
+		return false
+	}
+
+	return len(self.Search) == 0 || matchesSearch(objectMeta, self.Search)
+}
+
+// matchesSearch returns true if name, namespace or any label of the object contains the search.
+func matchesSearch(objectMeta *metaV1.ObjectMeta, search string) bool {
+	candidates := []string{objectMeta.Name, objectMeta.Namespace}
+	for key, value := range objectMeta.Labels {
+		candidates = append(candidates, key, value)
+	}
+
+	for _, candidate := range candidates {
+		if strings.Contains(strings.ToLower(candidate), search) {
+			return true
+		}
+	}
+	return false
+}
+
+// getObjectMeta returns metadata of the Kubernetes object wrapped by the cell. Cells are
+// conversions of API types, e.g. PodCell is v1.Pod, so the metadata is their ObjectMeta field.
+// Cells of Dashboard types, e.g. role bindings, have metadata converted to api.ObjectMeta.
+func getObjectMeta(cell DataCell) (*metaV1.ObjectMeta, bool) {
+	value := reflect.ValueOf(cell)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	field := value.FieldByName("ObjectMeta")
+	if !field.IsValid() {
+		return nil, false
+	}
+
+	switch objectMeta := field.Interface().(type) {
+	case metaV1.ObjectMeta:
+		return &objectMeta, true
+	case api.ObjectMeta:
+		return &metaV1.ObjectMeta{
+			Name:      objectMeta.Name,
+			Namespace: objectMeta.Namespace,
+			Labels:    objectMeta.Labels,
+		}, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataselect
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// objectTestCell wraps object metadata like cells of API types do.
+type objectTestCell struct {
+	metaV1.ObjectMeta
+	Phase string
+}
+
+func (self objectTestCell) GetProperty(name PropertyName) ComparableValue {
+	switch name {
+	case NameProperty:
+		return StdComparableString(self.Name)
+	default:
+		return nil
+	}
+}
+
+func (self objectTestCell) GetFields() fields.Set {
+	return fields.Set{"status.phase": self.Phase}
+}
+
+func (self objectTestCell) GetResourceSelector() *metricapi.ResourceSelector {
+	return &metricapi.ResourceSelector{ResourceName: self.Name}
+}
+
+func newObjectTestCell(namespace, name, phase string, cellLabels map[string]string) objectTestCell {
+	return objectTestCell{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: namespace, Name: name, Labels: cellLabels},
+		Phase:      phase,
+	}
+}
+
+func getCellNames(cells []DataCell) []string {
+	names := []string{}
+	for _, cell := range cells {
+		names = append(names, cell.(objectTestCell).Name)
+	}
+	return names
+}
+
+func TestSelectorQuery(t *testing.T) {
+	cells := []DataCell{
+		newObjectTestCell("default", "frontend", "Running", map[string]string{"app": "web", "tier": "fe"}),
+		newObjectTestCell("default", "backend", "Pending", map[string]string{"app": "web", "tier": "be"}),
+		newObjectTestCell("kube-system", "kube-dns", "Running", map[string]string{"k8s-app": "dns"}),
+	}
+
+	cases := []struct {
+		labelSelector, fieldSelector, search string
+		expected                             []string
+	}{
+		{"", "", "", []string{"frontend", "backend", "kube-dns"}},
+		{"app=web", "", "", []string{"frontend", "backend"}},
+		{"app=web,tier!=fe", "", "", []string{"backend"}},
+		{"", "metadata.namespace=kube-system", "", []string{"kube-dns"}},
+		{"", "status.phase=Running", "", []string{"frontend", "kube-dns"}},
+		{"", "", "DNS", []string{"kube-dns"}},
+		{"", "", "fe", []string{"frontend"}},
+		{"app=web", "status.phase=Running", "front", []string{"frontend"}},
+	}
+
+	for _, c := range cases {
+		selectorQuery, err := NewSelectorQuery(c.labelSelector, c.fieldSelector, c.search)
+		if err != nil {
+			t.Fatalf("NewSelectorQuery(%#v, %#v, %#v) returns unexpected error: %v", c.labelSelector,
+				c.fieldSelector, c.search, err)
+		}
+
+		dsQuery := NewDataSelectQuery(NoPagination, NoSort, NoFilter, NoMetrics)
+		dsQuery.SelectorQuery = selectorQuery
+		selected, total := GenericDataSelectWithFilter(cells, dsQuery)
+		actual := getCellNames(selected)
+		if !reflect.DeepEqual(actual, c.expected) || total != len(c.expected) {
+			t.Errorf("GenericDataSelectWithFilter() with selectors (%#v, %#v, %#v) returns %#v, "+
+				"expected %#v", c.labelSelector, c.fieldSelector, c.search, actual, c.expected)
+		}
+
+		if actual := getCellNames(GenericDataSelect(cells, dsQuery)); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("GenericDataSelect() with selectors (%#v, %#v, %#v) returns %#v, expected %#v",
+				c.labelSelector, c.fieldSelector, c.search, actual, c.expected)
+		}
+	}
+
+	if _, err := NewSelectorQuery("app in (", "", ""); err == nil {
+		t.Error("NewSelectorQuery() should return error for invalid label selector")
+	}
+	if _, err := NewSelectorQuery("", "invalid", ""); err == nil {
+		t.Error("NewSelectorQuery() should return error for invalid field selector")
+	}
+}
+
+// dashboardObjectTestCell wraps metadata converted to Dashboard type like cells of Dashboard types do.
+type dashboardObjectTestCell struct {
+	ObjectMeta api.ObjectMeta
+}
+
+func (self dashboardObjectTestCell) GetProperty(name PropertyName) ComparableValue {
+	return nil
+}
+
+func TestSelectorQueryOfDashboardObjects(t *testing.T) {
+	selectorQuery, _ := NewSelectorQuery("app=web", "metadata.namespace=default", "")
+	cases := []struct {
+		cell     dashboardObjectTestCell
+		expected bool
+	}{
+		{dashboardObjectTestCell{api.ObjectMeta{Name: "a", Namespace: "default",
+			Labels: map[string]string{"app": "web"}}}, true},
+		{dashboardObjectTestCell{api.ObjectMeta{Name: "b", Namespace: "default",
+			Labels: map[string]string{"app": "db"}}}, false},
+		{dashboardObjectTestCell{api.ObjectMeta{Name: "c", Namespace: "prod",
+			Labels: map[string]string{"app": "web"}}}, false},
+	}
+
+	for _, c := range cases {
+		if actual := selectorQuery.Matches(c.cell); actual != c.expected {
+			t.Errorf("Matches(%#v) == %t, expected %t", c.cell.ObjectMeta, actual, c.expected)
+		}
+	}
+}
+
+// fakeMetricClient returns the latest value of a metric from the values map keyed by resource
+// name.
+type fakeMetricClient struct {
+	metricapi.MetricClient
+	values map[string]int64
+}
+
+func (self fakeMetricClient) DownloadMetric(selectors []metricapi.ResourceSelector, metricName string,
+	cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	promises := metricapi.NewMetricPromises(len(selectors))
+	for i, selector := range selectors {
+		promises[i].Metric <- &metricapi.Metric{
+			MetricName: metricName,
+			DataPoints: metricapi.DataPoints{{X: 1, Y: 0}, {X: 2, Y: self.values[selector.ResourceName]}},
+		}
+		promises[i].Error <- nil
+	}
+	return promises
+}
+
+func TestSortWithMetrics(t *testing.T) {
+	cells := []DataCell{
+		newObjectTestCell("default", "small", "", nil),
+		newObjectTestCell("default", "large", "", nil),
+		newObjectTestCell("default", "medium", "", nil),
+	}
+	metricClient := fakeMetricClient{values: map[string]int64{"small": 10, "medium": 50, "large": 100}}
+
+	cases := []struct {
+		sortQuery *SortQuery
+		expected  []string
+	}{
+		{NewSortQuery([]string{"d", CpuUsageProperty}), []string{"large", "medium", "small"}},
+		{NewSortQuery([]string{"a", MemoryUsageProperty}), []string{"small", "medium", "large"}},
+		{NewSortQuery([]string{"a", NameProperty}), []string{"large", "medium", "small"}},
+	}
+
+	for _, c := range cases {
+		selector := DataSelector{
+			GenericDataList: append([]DataCell{}, cells...),
+			DataSelectQuery: NewDataSelectQuery(NoPagination, c.sortQuery, NoFilter, NoMetrics),
+		}
+		actual := getCellNames(selector.SortWithMetrics(metricClient).GenericDataList)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("SortWithMetrics() with %#v returns %#v, expected %#v", c.sortQuery, actual,
+				c.expected)
+		}
+	}
+}
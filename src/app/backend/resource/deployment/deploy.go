@@ -15,11 +15,14 @@
 package deployment
 
 import (
+	"encoding/json"
 	"fmt"
-	"log"
 	"strings"
 
+	"github.com/ghodss/yaml"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -84,6 +87,27 @@ type AppDeploymentSpec struct {
 
 	// Whether to run the container as privileged user (essentially equivalent to root on the host).
 	RunAsPrivileged bool `json:"runAsPrivileged"`
+
+	// Optional memory limit for the container.
+	MemoryLimit *resource.Quantity `json:"memoryLimit"`
+
+	// Optional CPU limit for the container.
+	CpuLimit *resource.Quantity `json:"cpuLimit"`
+
+	// Optional probe that restarts the container when it fails.
+	LivenessProbe *ProbeSpec `json:"livenessProbe"`
+
+	// Optional probe that removes the pod from service endpoints when it fails.
+	ReadinessProbe *ProbeSpec `json:"readinessProbe"`
+
+	// Containers that run to completion, in order, before the application container starts.
+	InitContainers []InitContainerSpec `json:"initContainers"`
+
+	// Config maps and secrets whose keys are exposed as environment variables of the container.
+	EnvFrom []EnvFromSpec `json:"envFrom"`
+
+	// Labels that a node must have for the pods to be scheduled on it.
+	NodeSelector []Label `json:"nodeSelector"`
 }
 
 // AppDeploymentFromFileSpec is a specification for deployment from file
@@ -113,6 +137,12 @@ type AppDeploymentFromFileResponse struct {
 	Error string `json:"error"`
 }
 
+// AppDeploymentPreview contains manifests of the objects created for an app deployment spec.
+type AppDeploymentPreview struct {
+	// Multi-document YAML with the objects, in the order they would be created.
+	Content string `json:"content"`
+}
+
 // PortMapping is a specification of port mapping for an application deployment.
 type PortMapping struct {
 	// Port that will be exposed on the service.
@@ -134,6 +164,74 @@ type EnvironmentVariable struct {
 	Value string `json:"value"`
 }
 
+// Probe types supported by ProbeSpec.
+const (
+	ProbeTypeHTTPGet   = "httpGet"
+	ProbeTypeTCPSocket = "tcpSocket"
+	ProbeTypeExec      = "exec"
+)
+
+// ProbeSpec is a simplified specification of a container liveness or readiness probe.
+type ProbeSpec struct {
+	// Type of the probe, one of ProbeTypeHTTPGet, ProbeTypeTCPSocket or ProbeTypeExec.
+	Type string `json:"type"`
+
+	// Path requested by an HTTP probe.
+	Path string `json:"path"`
+
+	// Container port checked by an HTTP or TCP probe.
+	Port int32 `json:"port"`
+
+	// Command run inside the container by an exec probe.
+	Command []string `json:"command"`
+
+	// Number of seconds after the container has started before the probe is initiated.
+	InitialDelaySeconds int32 `json:"initialDelaySeconds"`
+
+	// How often, in seconds, to perform the probe. Kubernetes default is used when zero.
+	PeriodSeconds int32 `json:"periodSeconds"`
+
+	// Number of seconds after which the probe times out. Kubernetes default is used when zero.
+	TimeoutSeconds int32 `json:"timeoutSeconds"`
+
+	// Number of consecutive failures after which the probe is considered failed. Kubernetes
+	// default is used when zero.
+	FailureThreshold int32 `json:"failureThreshold"`
+}
+
+// InitContainerSpec is a specification of an init container of an app deployment.
+type InitContainerSpec struct {
+	// Name of the container. Generated from the application name when empty.
+	Name string `json:"name"`
+
+	// Docker image path for the container.
+	ContainerImage string `json:"containerImage"`
+
+	// Command that is executed instead of container entrypoint, if specified.
+	ContainerCommand *string `json:"containerCommand"`
+
+	// Arguments for the specified container command or container entrypoint.
+	ContainerCommandArgs *string `json:"containerCommandArgs"`
+}
+
+// Kinds of objects that EnvFromSpec can refer to.
+const (
+	EnvFromConfigMap = "ConfigMap"
+	EnvFromSecret    = "Secret"
+)
+
+// EnvFromSpec refers to a config map or a secret whose keys become environment variables.
+type EnvFromSpec struct {
+	// Kind of the object, either EnvFromConfigMap or EnvFromSecret.
+	Kind string `json:"kind"`
+
+	// Name of the object in the application namespace.
+	Name string `json:"name"`
+
+	// Optional prefix prepended to every key.
+	Prefix string `json:"prefix"`
+}
+
 // Label is a structure representing label assignable to Pod/RC/Service
 type Label struct {
 	// Label key
@@ -153,8 +251,61 @@ type Protocols struct {
 // client. App deployment consists of a deployment and an optional service. Both of them
 // share common labels.
 func DeployApp(spec *AppDeploymentSpec, client client.Interface) error {
-	log.Printf("Deploying %s application into %s namespace", spec.Name, spec.Namespace)
+	logger.Infof("Deploying %s application into %s namespace", spec.Name, spec.Namespace)
+
+	deployment, service, err := getAppObjects(spec)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.ExtensionsV1beta1().Deployments(spec.Namespace).Create(deployment)
+
+	if err != nil {
+		// TODO(bryk): Roll back created resources in case of error.
+		return err
+	}
+
+	if service != nil {
+		_, err = client.CoreV1().Services(spec.Namespace).Create(service)
+
+		// TODO(bryk): Roll back created resources in case of error.
+		return err
+	}
+
+	return nil
+}
 
+// PreviewApp returns manifests of the objects that DeployApp would create for the given
+// configuration, without creating them.
+func PreviewApp(spec *AppDeploymentSpec) (*AppDeploymentPreview, error) {
+	deployment, service, err := getAppObjects(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment.TypeMeta = metaV1.TypeMeta{APIVersion: "extensions/v1beta1", Kind: "Deployment"}
+	deployment.Namespace = spec.Namespace
+	objects := []interface{}{deployment}
+	if service != nil {
+		service.TypeMeta = metaV1.TypeMeta{APIVersion: "v1", Kind: "Service"}
+		service.Namespace = spec.Namespace
+		objects = append(objects, service)
+	}
+
+	documents := make([]string, 0, len(objects))
+	for _, object := range objects {
+		document, err := toManifest(object)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+	return &AppDeploymentPreview{Content: strings.Join(documents, "---\n")}, nil
+}
+
+// getAppObjects builds the deployment and, if there are port mappings, the service for an app
+// deployment.
+func getAppObjects(spec *AppDeploymentSpec) (*extensions.Deployment, *api.Service, error) {
 	annotations := map[string]string{}
 	if spec.Description != nil {
 		annotations[DescriptionAnnotationKey] = *spec.Description
@@ -191,8 +342,37 @@ func DeployApp(spec *AppDeploymentSpec, client client.Interface) error {
 	if spec.MemoryRequirement != nil {
 		containerSpec.Resources.Requests[api.ResourceMemory] = *spec.MemoryRequirement
 	}
+	if spec.CpuLimit != nil || spec.MemoryLimit != nil {
+		containerSpec.Resources.Limits = make(map[api.ResourceName]resource.Quantity)
+		if spec.CpuLimit != nil {
+			containerSpec.Resources.Limits[api.ResourceCPU] = *spec.CpuLimit
+		}
+		if spec.MemoryLimit != nil {
+			containerSpec.Resources.Limits[api.ResourceMemory] = *spec.MemoryLimit
+		}
+	}
+
+	var err error
+	if containerSpec.LivenessProbe, err = convertProbeSpec(spec.LivenessProbe); err != nil {
+		return nil, nil, err
+	}
+	if containerSpec.ReadinessProbe, err = convertProbeSpec(spec.ReadinessProbe); err != nil {
+		return nil, nil, err
+	}
+	if containerSpec.EnvFrom, err = convertEnvFromSpec(spec.EnvFrom); err != nil {
+		return nil, nil, err
+	}
+	initContainers, err := convertInitContainerSpec(spec.Name, spec.InitContainers)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	podSpec := api.PodSpec{
-		Containers: []api.Container{containerSpec},
+		InitContainers: initContainers,
+		Containers:     []api.Container{containerSpec},
+	}
+	if len(spec.NodeSelector) > 0 {
+		podSpec.NodeSelector = getLabelsMap(spec.NodeSelector)
 	}
 	if spec.ImagePullSecret != nil {
 		podSpec.ImagePullSecrets = []api.LocalObjectReference{{Name: *spec.ImagePullSecret}}
@@ -210,48 +390,69 @@ func DeployApp(spec *AppDeploymentSpec, client client.Interface) error {
 			Template: podTemplate,
 		},
 	}
-	_, err := client.ExtensionsV1beta1().Deployments(spec.Namespace).Create(deployment)
+	if len(spec.PortMappings) == 0 {
+		return deployment, nil, nil
+	}
 
-	if err != nil {
-		// TODO(bryk): Roll back created resources in case of error.
-		return err
+	service := &api.Service{
+		ObjectMeta: objectMeta,
+		Spec: api.ServiceSpec{
+			Selector: labels,
+		},
 	}
 
-	if len(spec.PortMappings) > 0 {
-		service := &api.Service{
-			ObjectMeta: objectMeta,
-			Spec: api.ServiceSpec{
-				Selector: labels,
-			},
-		}
+	if spec.IsExternal {
+		service.Spec.Type = api.ServiceTypeLoadBalancer
+	} else {
+		service.Spec.Type = api.ServiceTypeClusterIP
+	}
 
-		if spec.IsExternal {
-			service.Spec.Type = api.ServiceTypeLoadBalancer
-		} else {
-			service.Spec.Type = api.ServiceTypeClusterIP
-		}
+	for _, portMapping := range spec.PortMappings {
+		servicePort :=
+			api.ServicePort{
+				Protocol: portMapping.Protocol,
+				Port:     portMapping.Port,
+				Name:     generatePortMappingName(portMapping),
+				TargetPort: intstr.IntOrString{
+					Type:   intstr.Int,
+					IntVal: portMapping.TargetPort,
+				},
+			}
+		service.Spec.Ports = append(service.Spec.Ports, servicePort)
+	}
 
-		for _, portMapping := range spec.PortMappings {
-			servicePort :=
-				api.ServicePort{
-					Protocol: portMapping.Protocol,
-					Port:     portMapping.Port,
-					Name:     generatePortMappingName(portMapping),
-					TargetPort: intstr.IntOrString{
-						Type:   intstr.Int,
-						IntVal: portMapping.TargetPort,
-					},
-				}
-			service.Spec.Ports = append(service.Spec.Ports, servicePort)
-		}
+	return deployment, service, nil
+}
 
-		_, err = client.CoreV1().Services(spec.Namespace).Create(service)
+// toManifest converts object to YAML, omitting status and unset creation timestamps, which are
+// not part of what a user would write or check in.
+func toManifest(object interface{}) (string, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+	content := map[string]interface{}{}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return "", err
+	}
+	delete(content, "status")
+	removeEmptyTimestamps(content)
 
-		// TODO(bryk): Roll back created resources in case of error.
-		return err
+	out, err := yaml.Marshal(content)
+	if err != nil {
+		return "", err
 	}
+	return string(out), nil
+}
 
-	return nil
+func removeEmptyTimestamps(content map[string]interface{}) {
+	for key, value := range content {
+		if key == "creationTimestamp" && value == nil {
+			delete(content, key)
+		} else if nested, ok := value.(map[string]interface{}); ok {
+			removeEmptyTimestamps(nested)
+		}
+	}
 }
 
 // GetAvailableProtocols returns list of available protocols. Currently it is TCP and UDP.
@@ -267,6 +468,88 @@ func convertEnvVarsSpec(variables []EnvironmentVariable) []api.EnvVar {
 	return result
 }
 
+// convertProbeSpec converts simplified probe specification to the Kubernetes one. Nil is returned
+// when no probe is specified.
+func convertProbeSpec(spec *ProbeSpec) (*api.Probe, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	probe := &api.Probe{
+		InitialDelaySeconds: spec.InitialDelaySeconds,
+		PeriodSeconds:       spec.PeriodSeconds,
+		TimeoutSeconds:      spec.TimeoutSeconds,
+		FailureThreshold:    spec.FailureThreshold,
+	}
+	switch spec.Type {
+	case ProbeTypeHTTPGet, ProbeTypeTCPSocket:
+		if spec.Port <= 0 {
+			return nil, errorsK8s.NewBadRequest(fmt.Sprintf("%s probe requires a port", spec.Type))
+		}
+		port := intstr.FromInt(int(spec.Port))
+		if spec.Type == ProbeTypeHTTPGet {
+			probe.HTTPGet = &api.HTTPGetAction{Path: spec.Path, Port: port}
+		} else {
+			probe.TCPSocket = &api.TCPSocketAction{Port: port}
+		}
+	case ProbeTypeExec:
+		if len(spec.Command) == 0 {
+			return nil, errorsK8s.NewBadRequest("exec probe requires a command")
+		}
+		probe.Exec = &api.ExecAction{Command: spec.Command}
+	default:
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("unknown probe type %q", spec.Type))
+	}
+	return probe, nil
+}
+
+func convertEnvFromSpec(specs []EnvFromSpec) ([]api.EnvFromSource, error) {
+	var result []api.EnvFromSource
+	for _, spec := range specs {
+		if spec.Name == "" {
+			return nil, errorsK8s.NewBadRequest("name of environment source is required")
+		}
+		source := api.EnvFromSource{Prefix: spec.Prefix}
+		reference := api.LocalObjectReference{Name: spec.Name}
+		switch spec.Kind {
+		case EnvFromConfigMap:
+			source.ConfigMapRef = &api.ConfigMapEnvSource{LocalObjectReference: reference}
+		case EnvFromSecret:
+			source.SecretRef = &api.SecretEnvSource{LocalObjectReference: reference}
+		default:
+			return nil, errorsK8s.NewBadRequest(
+				fmt.Sprintf("unknown kind of environment source %q", spec.Kind))
+		}
+		result = append(result, source)
+	}
+	return result, nil
+}
+
+func convertInitContainerSpec(appName string, specs []InitContainerSpec) ([]api.Container, error) {
+	var result []api.Container
+	for i, spec := range specs {
+		if spec.ContainerImage == "" {
+			return nil, errorsK8s.NewBadRequest(
+				fmt.Sprintf("image of init container %d is required", i))
+		}
+		container := api.Container{
+			Name:  spec.Name,
+			Image: spec.ContainerImage,
+		}
+		if container.Name == "" {
+			container.Name = fmt.Sprintf("%s-init-%d", appName, i)
+		}
+		if spec.ContainerCommand != nil {
+			container.Command = []string{*spec.ContainerCommand}
+		}
+		if spec.ContainerCommandArgs != nil {
+			container.Args = []string{*spec.ContainerCommandArgs}
+		}
+		result = append(result, container)
+	}
+	return result, nil
+}
+
 func generatePortMappingName(portMapping PortMapping) string {
 	base := fmt.Sprintf("%s-%d-%d-", strings.ToLower(string(portMapping.Protocol)),
 		portMapping.Port, portMapping.TargetPort)
@@ -308,7 +591,7 @@ func DeployAppFromFile(cfg clientcmd.ClientConfig, spec *AppDeploymentFromFileSp
 	mapper, typer := factory.Object()
 	reader := strings.NewReader(spec.Content)
 
-	fmt.Printf("Namespace for deploy from file: %s\n", spec.Namespace)
+	logger.Infof("Namespace for deploy from file: %s", spec.Namespace)
 
 	builder := kubectlResource.NewBuilder(mapper, kubectlResource.LegacyCategoryExpander, typer,
 		kubectlResource.ClientMapperFunc(factory.ClientForMapping), factory.Decoder(true)).
@@ -329,7 +612,7 @@ func DeployAppFromFile(cfg clientcmd.ClientConfig, spec *AppDeploymentFromFileSp
 		isDeployed, err := createObjectFromInfoFn(info)
 		if isDeployed {
 			deployedResourcesCount++
-			log.Printf("%s is deployed", info.Name)
+			logger.Infof("%s is deployed", info.Name)
 		}
 		return err
 	})
@@ -17,6 +17,7 @@ package deployment
 import (
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -132,6 +133,87 @@ func TestDeployShouldPopulateEnvVars(t *testing.T) {
 	}
 }
 
+func TestDeployShouldPopulateProductionSettings(t *testing.T) {
+	cpuLimit := resource.MustParse("500m")
+	memoryLimit := resource.MustParse("128Mi")
+	command := "sh"
+	spec := &AppDeploymentSpec{
+		Namespace:      "foo-namespace",
+		Name:           "foo-name",
+		CpuLimit:       &cpuLimit,
+		MemoryLimit:    &memoryLimit,
+		LivenessProbe:  &ProbeSpec{Type: ProbeTypeHTTPGet, Path: "/healthz", Port: 8080, PeriodSeconds: 5},
+		ReadinessProbe: &ProbeSpec{Type: ProbeTypeTCPSocket, Port: 8080},
+		InitContainers: []InitContainerSpec{{ContainerImage: "busybox", ContainerCommand: &command}},
+		EnvFrom: []EnvFromSpec{
+			{Kind: EnvFromConfigMap, Name: "foo-config"},
+			{Kind: EnvFromSecret, Name: "foo-secret", Prefix: "SECRET_"},
+		},
+		NodeSelector: []Label{{Key: "disktype", Value: "ssd"}},
+	}
+	testClient := fake.NewSimpleClientset()
+
+	if err := DeployApp(spec, testClient); err != nil {
+		t.Fatalf("DeployApp(): unexpected error %v", err)
+	}
+
+	createAction := testClient.Actions()[0].(core.CreateActionImpl)
+	podSpec := createAction.GetObject().(*extensions.Deployment).Spec.Template.Spec
+	container := podSpec.Containers[0]
+
+	expectedLimits := api.ResourceList{api.ResourceCPU: cpuLimit, api.ResourceMemory: memoryLimit}
+	if !reflect.DeepEqual(container.Resources.Limits, expectedLimits) {
+		t.Errorf("Expected limits to be %#v but got %#v", expectedLimits, container.Resources.Limits)
+	}
+	if container.LivenessProbe.HTTPGet == nil || container.LivenessProbe.HTTPGet.Path != "/healthz" ||
+		container.LivenessProbe.HTTPGet.Port.IntValue() != 8080 || container.LivenessProbe.PeriodSeconds != 5 {
+		t.Errorf("Unexpected liveness probe %#v", container.LivenessProbe)
+	}
+	if container.ReadinessProbe.TCPSocket == nil || container.ReadinessProbe.TCPSocket.Port.IntValue() != 8080 {
+		t.Errorf("Unexpected readiness probe %#v", container.ReadinessProbe)
+	}
+
+	expectedEnvFrom := []api.EnvFromSource{
+		{ConfigMapRef: &api.ConfigMapEnvSource{LocalObjectReference: api.LocalObjectReference{Name: "foo-config"}}},
+		{Prefix: "SECRET_", SecretRef: &api.SecretEnvSource{LocalObjectReference: api.LocalObjectReference{Name: "foo-secret"}}},
+	}
+	if !reflect.DeepEqual(container.EnvFrom, expectedEnvFrom) {
+		t.Errorf("Expected env from to be %#v but got %#v", expectedEnvFrom, container.EnvFrom)
+	}
+
+	expectedInit := []api.Container{{Name: "foo-name-init-0", Image: "busybox", Command: []string{"sh"}}}
+	if !reflect.DeepEqual(podSpec.InitContainers, expectedInit) {
+		t.Errorf("Expected init containers to be %#v but got %#v", expectedInit, podSpec.InitContainers)
+	}
+
+	if !reflect.DeepEqual(podSpec.NodeSelector, map[string]string{"disktype": "ssd"}) {
+		t.Errorf("Unexpected node selector %#v", podSpec.NodeSelector)
+	}
+}
+
+func TestDeployShouldRejectInvalidProductionSettings(t *testing.T) {
+	cases := []*AppDeploymentSpec{
+		{Name: "foo", LivenessProbe: &ProbeSpec{Type: "grpc", Port: 80}},
+		{Name: "foo", ReadinessProbe: &ProbeSpec{Type: ProbeTypeHTTPGet}},
+		{Name: "foo", LivenessProbe: &ProbeSpec{Type: ProbeTypeExec}},
+		{Name: "foo", EnvFrom: []EnvFromSpec{{Kind: "Pod", Name: "bar"}}},
+		{Name: "foo", EnvFrom: []EnvFromSpec{{Kind: EnvFromSecret}}},
+		{Name: "foo", InitContainers: []InitContainerSpec{{Name: "init"}}},
+	}
+	for _, c := range cases {
+		testClient := fake.NewSimpleClientset()
+
+		err := DeployApp(c, testClient)
+
+		if err == nil {
+			t.Errorf("DeployApp(%#v): expected error", c)
+		}
+		if len(testClient.Actions()) != 0 {
+			t.Errorf("DeployApp(%#v): expected no actions but got %#v", c, testClient.Actions())
+		}
+	}
+}
+
 func TestDeployShouldGeneratePortNames(t *testing.T) {
 	spec := PortMapping{Port: 80, TargetPort: 8080, Protocol: api.ProtocolTCP}
 
@@ -183,3 +265,46 @@ func TestGetAvailableProtocols(t *testing.T) {
 			expected, actual)
 	}
 }
+
+func TestPreviewApp(t *testing.T) {
+	spec := &AppDeploymentSpec{
+		Namespace:      "foo-namespace",
+		Name:           "foo-name",
+		ContainerImage: "nginx",
+		Replicas:       2,
+		Labels:         []Label{{Key: "app", Value: "foo-name"}},
+		PortMappings:   []PortMapping{{Port: 80, TargetPort: 8080, Protocol: api.ProtocolTCP}},
+	}
+
+	preview, err := PreviewApp(spec)
+	if err != nil {
+		t.Fatalf("PreviewApp(): unexpected error %v", err)
+	}
+
+	documents := strings.Split(preview.Content, "---\n")
+	if len(documents) != 2 {
+		t.Fatalf("Expected 2 documents but got %d:\n%s", len(documents), preview.Content)
+	}
+	for i, expected := range []string{"kind: Deployment\n", "kind: Service\n"} {
+		if !strings.Contains(documents[i], expected) {
+			t.Errorf("Expected document %d to contain %q but got:\n%s", i, expected, documents[i])
+		}
+		if !strings.Contains(documents[i], "namespace: foo-namespace\n") {
+			t.Errorf("Expected document %d to set namespace but got:\n%s", i, documents[i])
+		}
+		if strings.Contains(documents[i], "status:") || strings.Contains(documents[i], "creationTimestamp") {
+			t.Errorf("Expected document %d without server fields but got:\n%s", i, documents[i])
+		}
+	}
+}
+
+func TestPreviewAppWithoutService(t *testing.T) {
+	preview, err := PreviewApp(&AppDeploymentSpec{Name: "foo-name", ContainerImage: "nginx"})
+	if err != nil {
+		t.Fatalf("PreviewApp(): unexpected error %v", err)
+	}
+
+	if strings.Contains(preview.Content, "---") || strings.Contains(preview.Content, "kind: Service") {
+		t.Errorf("Expected deployment only but got:\n%s", preview.Content)
+	}
+}
@@ -15,14 +15,15 @@
 package deployment
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diagnosis"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gitops"
 	hpa "github.com/kubernetes/dashboard/src/app/backend/resource/horizontalpodautoscaler"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/pod"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/replicaset"
@@ -92,6 +93,9 @@ type DeploymentDetail struct {
 	// List of Horizontal Pod AutoScalers targeting this Deployment
 	HorizontalPodAutoscalerList hpa.HorizontalPodAutoscalerList `json:"horizontalPodAutoscalerList"`
 
+	// GitOps tool managing the deployment. Set only when it is synced by Argo CD or Flux.
+	GitOps *gitops.Ownership `json:"gitops,omitempty"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -100,7 +104,7 @@ type DeploymentDetail struct {
 func GetDeploymentDetail(client client.Interface, metricClient metricapi.MetricClient, namespace string,
 	deploymentName string) (*DeploymentDetail, error) {
 
-	log.Printf("Getting details of %s deployment in %s namespace", deploymentName, namespace)
+	logger.Infof("Getting details of %s deployment in %s namespace", deploymentName, namespace)
 
 	deployment, err := client.ExtensionsV1beta1().Deployments(namespace).Get(deploymentName, metaV1.GetOptions{})
 	if err != nil {
@@ -179,6 +183,8 @@ func GetDeploymentDetail(client client.Interface, metricClient metricapi.MetricC
 		}
 
 		newRsPodInfo.Warnings = event.GetPodsEventWarnings(events, rawPods.Items)
+
+		newRsPodInfo.Diagnosis = diagnosis.DiagnosePods(rawPods.Items, events)
 		newReplicaSet = replicaset.ToReplicaSet(newRs, &newRsPodInfo)
 	}
 
@@ -15,14 +15,15 @@
 package deployment
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diagnosis"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	"github.com/kubernetes/dashboard/src/app/backend/scaling"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
 	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
@@ -50,6 +51,9 @@ type Deployment struct {
 	// Aggregate information about pods belonging to this Deployment.
 	Pods common.PodInfo `json:"pods"`
 
+	// Desired and actual number of replicas, as reported by the scale subresource.
+	Replicas scaling.ReplicaCounts `json:"replicas"`
+
 	// Container images of the Deployment.
 	ContainerImages []string `json:"containerImages"`
 }
@@ -57,16 +61,23 @@ type Deployment struct {
 // GetDeploymentList returns a list of all Deployments in the cluster.
 func GetDeploymentList(client client.Interface, nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery,
 	metricClient metricapi.MetricClient) (*DeploymentList, error) {
-	log.Print("Getting list of all deployments in the cluster")
+	logger.Info("Getting list of all deployments in the cluster")
 
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
 	channels := &common.ResourceChannels{
-		DeploymentList: common.GetDeploymentListChannel(client, nsQuery, 1),
+		DeploymentList: common.GetDeploymentListChunkChannel(client, nsQuery, chunk, 1),
 		PodList:        common.GetPodListChannel(client, nsQuery, 1),
-		EventList:      common.GetEventListChannel(client, nsQuery, 1),
+		EventList:      common.GetChunkEventListChannel(client, nsQuery, chunk, 1),
 		ReplicaSetList: common.GetReplicaSetListChannel(client, nsQuery, 1),
 	}
 
-	return GetDeploymentListFromChannels(channels, dsQuery, metricClient)
+	deploymentList, err := GetDeploymentListFromChannels(channels, dsQuery, metricClient)
+	if err != nil {
+		return nil, err
+	}
+
+	chunk.SetListMeta(&deploymentList.ListMeta)
+	return deploymentList, nil
 }
 
 // GetDeploymentList returns a list of all Deployments in the cluster
@@ -126,6 +137,7 @@ func toDeploymentList(deployments []extensions.Deployment, pods []v1.Pod, events
 		matchingPods := common.FilterDeploymentPodsByOwnerReference(deployment, rs, pods)
 		podInfo := common.GetPodInfo(deployment.Status.Replicas, *deployment.Spec.Replicas, matchingPods)
 		podInfo.Warnings = event.GetPodsEventWarnings(events, matchingPods)
+		podInfo.Diagnosis = diagnosis.DiagnosePods(matchingPods, events)
 
 		deploymentList.Deployments = append(deploymentList.Deployments,
 			Deployment{
@@ -133,6 +145,8 @@ func toDeploymentList(deployments []extensions.Deployment, pods []v1.Pod, events
 				TypeMeta:        api.NewTypeMeta(api.ResourceKindDeployment),
 				ContainerImages: common.GetContainerImages(&deployment.Spec.Template.Spec),
 				Pods:            podInfo,
+				Replicas: scaling.NewReplicaCounts(deployment.Spec.Replicas,
+					deployment.Status.Replicas),
 			})
 	}
 
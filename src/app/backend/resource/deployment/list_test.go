@@ -23,6 +23,7 @@ import (
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/scaling"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/client-go/pkg/api/v1"
@@ -118,6 +119,7 @@ func TestGetDeploymentListFromChannels(t *testing.T) {
 						Failed:   0,
 						Warnings: []common.Event{},
 					},
+					Replicas: scaling.ReplicaCounts{DesiredReplicas: 21, ActualReplicas: 7},
 				}},
 				Errors: []error{},
 			},
@@ -0,0 +1,264 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+const (
+	// RevisionAnnotation is the annotation in which deployment controller stores revision of
+	// deployments and their replica sets.
+	RevisionAnnotation = "deployment.kubernetes.io/revision"
+
+	// ChangeCauseAnnotation is the annotation describing the change which caused the revision.
+	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+
+	// RestartedAtAnnotation is the pod template annotation changed to restart the rollout. Same
+	// annotation is used by kubectl rollout restart.
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+	// podTemplateHashLabel is added by deployment controller to pod templates of replica sets.
+	podTemplateHashLabel = "pod-template-hash"
+)
+
+// RolloutStatus describes rollout state of a deployment after a rollout action.
+type RolloutStatus struct {
+	// Whether the rollout is paused.
+	Paused bool `json:"paused"`
+
+	// Current revision of the deployment.
+	Revision int64 `json:"revision"`
+}
+
+// RolloutRevision is a single revision of a deployment, backed by a replica set.
+type RolloutRevision struct {
+	Revision          int64       `json:"revision"`
+	ReplicaSet        string      `json:"replicaSet"`
+	CreationTimestamp metaV1.Time `json:"creationTimestamp"`
+	ChangeCause       string      `json:"changeCause,omitempty"`
+	Images            []string    `json:"images"`
+
+	// Whether this revision is the one currently rolled out.
+	Current bool `json:"current"`
+}
+
+// RolloutHistory contains all revisions of a deployment, oldest first.
+type RolloutHistory struct {
+	Revisions []RolloutRevision `json:"revisions"`
+}
+
+// PauseDeployment pauses rollout of the deployment. Changes to the pod template of paused
+// deployment are not rolled out until it is resumed.
+func PauseDeployment(client client.Interface, namespace, name string) (*RolloutStatus, error) {
+	logger.Infof("Pausing rollout of %s deployment in %s namespace", name, namespace)
+	return updateDeployment(client, namespace, name, func(deployment *extensions.Deployment) error {
+		deployment.Spec.Paused = true
+		return nil
+	})
+}
+
+// ResumeDeployment resumes paused rollout of the deployment.
+func ResumeDeployment(client client.Interface, namespace, name string) (*RolloutStatus, error) {
+	logger.Infof("Resuming rollout of %s deployment in %s namespace", name, namespace)
+	return updateDeployment(client, namespace, name, func(deployment *extensions.Deployment) error {
+		deployment.Spec.Paused = false
+		return nil
+	})
+}
+
+// RestartDeployment triggers new rollout of the deployment by changing pod template annotation,
+// so that all pods are recreated.
+func RestartDeployment(client client.Interface, namespace, name string) (*RolloutStatus, error) {
+	logger.Infof("Restarting rollout of %s deployment in %s namespace", name, namespace)
+	return updateDeployment(client, namespace, name, func(deployment *extensions.Deployment) error {
+		if deployment.Spec.Paused {
+			return errorsK8s.NewBadRequest("cannot restart paused deployment, resume it first")
+		}
+		if deployment.Spec.Template.ObjectMeta.Annotations == nil {
+			deployment.Spec.Template.ObjectMeta.Annotations = make(map[string]string)
+		}
+		deployment.Spec.Template.ObjectMeta.Annotations[RestartedAtAnnotation] =
+			time.Now().Format(time.RFC3339)
+		return nil
+	})
+}
+
+// RollbackDeployment rolls the deployment back to the given revision by copying pod template of
+// its replica set. Revision 0 means the previous revision.
+func RollbackDeployment(client client.Interface, namespace, name string,
+	revision int64) (*RolloutStatus, error) {
+	logger.Infof("Rolling back %s deployment in %s namespace to revision %d", name, namespace, revision)
+	return updateDeployment(client, namespace, name, func(deployment *extensions.Deployment) error {
+		if deployment.Spec.Paused {
+			return errorsK8s.NewBadRequest("cannot roll back paused deployment, resume it first")
+		}
+
+		replicaSets, err := getDeploymentReplicaSets(client, deployment)
+		if err != nil {
+			return err
+		}
+
+		target := findRevision(replicaSets, getRevision(&deployment.ObjectMeta), revision)
+		if target == nil {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("revision %d of %s deployment not found",
+				revision, name))
+		}
+
+		template := target.Spec.Template
+		labels := make(map[string]string, len(template.Labels))
+		for key, value := range template.Labels {
+			if key != podTemplateHashLabel {
+				labels[key] = value
+			}
+		}
+		template.Labels = labels
+		deployment.Spec.Template = template
+		return nil
+	})
+}
+
+// GetDeploymentRolloutHistory returns revisions of the deployment.
+func GetDeploymentRolloutHistory(client client.Interface, namespace, name string) (*RolloutHistory, error) {
+	logger.Infof("Getting rollout history of %s deployment in %s namespace", name, namespace)
+
+	deployment, err := client.ExtensionsV1beta1().Deployments(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	replicaSets, err := getDeploymentReplicaSets(client, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRolloutHistory(deployment, replicaSets), nil
+}
+
+func toRolloutHistory(deployment *extensions.Deployment, replicaSets []extensions.ReplicaSet) *RolloutHistory {
+	newTemplate := GetNewReplicaSetTemplate(deployment)
+	history := &RolloutHistory{Revisions: make([]RolloutRevision, 0)}
+	for _, rs := range replicaSets {
+		revision := getRevision(&rs.ObjectMeta)
+		if revision == 0 {
+			continue
+		}
+
+		images := make([]string, 0)
+		for _, container := range rs.Spec.Template.Spec.Containers {
+			images = append(images, container.Image)
+		}
+
+		history.Revisions = append(history.Revisions, RolloutRevision{
+			Revision:          revision,
+			ReplicaSet:        rs.Name,
+			CreationTimestamp: rs.CreationTimestamp,
+			ChangeCause:       rs.Annotations[ChangeCauseAnnotation],
+			Images:            images,
+			Current:           common.EqualIgnoreHash(rs.Spec.Template, newTemplate),
+		})
+	}
+
+	sort.Slice(history.Revisions, func(i, j int) bool {
+		return history.Revisions[i].Revision < history.Revisions[j].Revision
+	})
+	return history
+}
+
+// findRevision returns replica set of the given revision. Revision 0 means the latest revision
+// older than the current one.
+func findRevision(replicaSets []extensions.ReplicaSet, current, revision int64) *extensions.ReplicaSet {
+	var target *extensions.ReplicaSet
+	var targetRevision int64
+	for i := range replicaSets {
+		rsRevision := getRevision(&replicaSets[i].ObjectMeta)
+		if revision > 0 && rsRevision == revision {
+			return &replicaSets[i]
+		}
+		if revision == 0 && rsRevision < current && rsRevision > targetRevision {
+			target, targetRevision = &replicaSets[i], rsRevision
+		}
+	}
+	return target
+}
+
+// getDeploymentReplicaSets returns replica sets controlled by the deployment.
+func getDeploymentReplicaSets(client client.Interface, deployment *extensions.Deployment) (
+	[]extensions.ReplicaSet, error) {
+	selector, err := metaV1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.ExtensionsV1beta1().ReplicaSets(deployment.Namespace).List(
+		metaV1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	replicaSets := make([]extensions.ReplicaSet, 0)
+	for _, rs := range list.Items {
+		for _, owner := range rs.OwnerReferences {
+			if owner.UID == deployment.UID {
+				replicaSets = append(replicaSets, rs)
+				break
+			}
+		}
+	}
+	return replicaSets, nil
+}
+
+// getRevision returns revision stored in object annotations or 0 when it is not set.
+func getRevision(meta *metaV1.ObjectMeta) int64 {
+	revision, err := strconv.ParseInt(meta.Annotations[RevisionAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return revision
+}
+
+// updateDeployment applies the change to the deployment and updates it. Update fails with
+// a conflict, if the deployment was modified in the meantime.
+func updateDeployment(client client.Interface, namespace, name string,
+	change func(*extensions.Deployment) error) (*RolloutStatus, error) {
+	deployment, err := client.ExtensionsV1beta1().Deployments(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := change(deployment); err != nil {
+		return nil, err
+	}
+
+	deployment, err = client.ExtensionsV1beta1().Deployments(namespace).Update(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RolloutStatus{
+		Paused:   deployment.Spec.Paused,
+		Revision: getRevision(&deployment.ObjectMeta),
+	}, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"reflect"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func newRolloutReplicaSet(name, revision, image string, owner types.UID) *extensions.ReplicaSet {
+	return &extensions.ReplicaSet{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			Labels:          map[string]string{"app": "web"},
+			Annotations:     map[string]string{RevisionAnnotation: revision},
+			OwnerReferences: []metaV1.OwnerReference{{UID: owner}},
+		},
+		Spec: extensions.ReplicaSetSpec{
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{
+					Labels: map[string]string{"app": "web", podTemplateHashLabel: name},
+				},
+				Spec: v1.PodSpec{Containers: []v1.Container{{Name: "web", Image: image}}},
+			},
+		},
+	}
+}
+
+func newRolloutDeployment() *extensions.Deployment {
+	return &extensions.Deployment{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "default",
+			UID:         "web-uid",
+			Annotations: map[string]string{RevisionAnnotation: "2"},
+		},
+		Spec: extensions.DeploymentSpec{
+			Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "web", Image: "web:2"}}},
+			},
+		},
+	}
+}
+
+func TestGetDeploymentRolloutHistory(t *testing.T) {
+	client := fake.NewSimpleClientset(newRolloutDeployment(),
+		newRolloutReplicaSet("web-2", "2", "web:2", "web-uid"),
+		newRolloutReplicaSet("web-1", "1", "web:1", "web-uid"),
+		newRolloutReplicaSet("other", "1", "web:1", "other-uid"))
+
+	actual, err := GetDeploymentRolloutHistory(client, "default", "web")
+	if err != nil {
+		t.Fatalf("GetDeploymentRolloutHistory() returns error %s", err)
+	}
+
+	expected := &RolloutHistory{Revisions: []RolloutRevision{
+		{Revision: 1, ReplicaSet: "web-1", Images: []string{"web:1"}, Current: false},
+		{Revision: 2, ReplicaSet: "web-2", Images: []string{"web:2"}, Current: true},
+	}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetDeploymentRolloutHistory() returns %#v, expected %#v", actual, expected)
+	}
+}
+
+func TestRolloutActions(t *testing.T) {
+	client := fake.NewSimpleClientset(newRolloutDeployment(),
+		newRolloutReplicaSet("web-2", "2", "web:2", "web-uid"),
+		newRolloutReplicaSet("web-1", "1", "web:1", "web-uid"))
+	deployments := client.ExtensionsV1beta1().Deployments("default")
+
+	status, err := PauseDeployment(client, "default", "web")
+	if err != nil || !status.Paused {
+		t.Fatalf("PauseDeployment() returns %#v, %v, expected paused deployment", status, err)
+	}
+
+	if _, err := RestartDeployment(client, "default", "web"); !errorsK8s.IsBadRequest(err) {
+		t.Errorf("RestartDeployment() of paused deployment returns %v, expected bad request", err)
+	}
+
+	status, err = ResumeDeployment(client, "default", "web")
+	if err != nil || status.Paused {
+		t.Fatalf("ResumeDeployment() returns %#v, %v, expected resumed deployment", status, err)
+	}
+
+	if _, err := RestartDeployment(client, "default", "web"); err != nil {
+		t.Fatalf("RestartDeployment() returns error %s", err)
+	}
+	deployment, _ := deployments.Get("web", metaV1.GetOptions{})
+	if len(deployment.Spec.Template.Annotations[RestartedAtAnnotation]) == 0 {
+		t.Errorf("RestartDeployment() does not set %s annotation", RestartedAtAnnotation)
+	}
+
+	if _, err := RollbackDeployment(client, "default", "web", 5); !errorsK8s.IsBadRequest(err) {
+		t.Errorf("RollbackDeployment() to missing revision returns %v, expected bad request", err)
+	}
+
+	if _, err := RollbackDeployment(client, "default", "web", 0); err != nil {
+		t.Fatalf("RollbackDeployment() returns error %s", err)
+	}
+	deployment, _ = deployments.Get("web", metaV1.GetOptions{})
+	expectedLabels := map[string]string{"app": "web"}
+	if image := deployment.Spec.Template.Spec.Containers[0].Image; image != "web:1" ||
+		!reflect.DeepEqual(deployment.Spec.Template.Labels, expectedLabels) {
+		t.Errorf("RollbackDeployment() sets template %#v, expected image web:1 and labels %#v",
+			deployment.Spec.Template, expectedLabels)
+	}
+}
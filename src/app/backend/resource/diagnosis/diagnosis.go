@@ -0,0 +1,150 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnosis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Machine readable reasons of problems.
+const (
+	ReasonProgressDeadlineExceeded   = "ProgressDeadlineExceeded"
+	ReasonFailedCreate               = "FailedCreate"
+	ReasonUnschedulable              = "Unschedulable"
+	ReasonImagePullBackOff           = "ImagePullBackOff"
+	ReasonErrImagePull               = "ErrImagePull"
+	ReasonInvalidImageName           = "InvalidImageName"
+	ReasonCreateContainerConfigError = "CreateContainerConfigError"
+	ReasonCreateContainerError       = "CreateContainerError"
+	ReasonOOMKilled                  = "OOMKilled"
+	ReasonCrashLoopBackOff           = "CrashLoopBackOff"
+	ReasonEvicted                    = "Evicted"
+	ReasonUnready                    = "Unready"
+)
+
+// Reasons of problems, ordered from the most to the least important. Reasons, which are not on the
+// list, i.e. reasons of job conditions, are placed right after reasons of workloads.
+var reasonPriority = []string{
+	ReasonProgressDeadlineExceeded,
+	ReasonFailedCreate,
+	ReasonUnschedulable,
+	ReasonImagePullBackOff,
+	ReasonErrImagePull,
+	ReasonInvalidImageName,
+	ReasonCreateContainerConfigError,
+	ReasonCreateContainerError,
+	ReasonOOMKilled,
+	ReasonCrashLoopBackOff,
+	ReasonEvicted,
+	ReasonUnready,
+}
+
+// Problem is a single cause of a workload not being healthy.
+type Problem struct {
+	// Reason is a machine readable cause, i.e. ImagePullBackOff.
+	Reason string `json:"reason"`
+
+	// Message is a short human readable explanation, i.e. registry auth failed.
+	Message string `json:"message"`
+
+	// Names of affected pods. Empty for problems of the workload itself.
+	Pods []string `json:"pods"`
+}
+
+// Diagnosis is a concise explanation of why a workload is not healthy.
+type Diagnosis struct {
+	// Healthy is true when no problem was found.
+	Healthy bool `json:"healthy"`
+
+	// Summary of the most important problem, i.e. "ImagePullBackOff: registry auth failed".
+	Summary string `json:"summary"`
+
+	// Problems found, ordered from the most important.
+	Problems []Problem `json:"problems"`
+}
+
+// String returns reason and message of the problem, i.e. "ImagePullBackOff: registry auth failed".
+func (p Problem) String() string {
+	if len(p.Message) == 0 {
+		return p.Reason
+	}
+	return fmt.Sprintf("%s: %s", p.Reason, p.Message)
+}
+
+// newDiagnosis merges problems with the same reason and message and orders them by importance.
+func newDiagnosis(problems []Problem) *Diagnosis {
+	merged := make([]Problem, 0)
+	for _, problem := range problems {
+		found := false
+		for i := range merged {
+			if merged[i].Reason == problem.Reason && merged[i].Message == problem.Message {
+				merged[i].Pods = appendUnique(merged[i].Pods, problem.Pods...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			problem.Pods = appendUnique(make([]string, 0), problem.Pods...)
+			merged = append(merged, problem)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return priority(merged[i].Reason) < priority(merged[j].Reason)
+	})
+
+	result := &Diagnosis{Healthy: len(merged) == 0, Problems: merged}
+	if len(merged) > 0 {
+		result.Summary = merged[0].String()
+	}
+	return result
+}
+
+// priority returns position of the reason in reasonPriority. Unknown reasons are placed right
+// after reasons of workloads as they come from workload conditions.
+func priority(reason string) int {
+	for i, r := range reasonPriority {
+		if r == reason {
+			return i
+		}
+	}
+	return 2
+}
+
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range list {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// lowerFirst lower cases the first letter of the message, so it reads well after the reason.
+func lowerFirst(message string) string {
+	if len(message) == 0 {
+		return message
+	}
+	return strings.ToLower(message[:1]) + message[1:]
+}
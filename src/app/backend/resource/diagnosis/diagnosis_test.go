@@ -0,0 +1,242 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnosis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func newPod(name string, phase v1.PodPhase, statuses ...v1.ContainerStatus) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "ns", Labels: map[string]string{"app": "test"}},
+		Status:     v1.PodStatus{Phase: phase, ContainerStatuses: statuses},
+	}
+}
+
+func newPodEvent(pod, reason, message string) v1.Event {
+	return v1.Event{
+		ObjectMeta:     metaV1.ObjectMeta{Name: pod + "." + reason, Namespace: "ns"},
+		InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: pod, Namespace: "ns"},
+		Type:           v1.EventTypeWarning,
+		Reason:         reason,
+		Message:        message,
+	}
+}
+
+func waiting(reason, message string) v1.ContainerStatus {
+	return v1.ContainerStatus{
+		Name:  "app",
+		Image: "registry.io/app:1",
+		State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: reason, Message: message}},
+	}
+}
+
+func TestDiagnosePods(t *testing.T) {
+	crashing := waiting(ReasonCrashLoopBackOff, "Back-off restarting failed container")
+	crashing.LastTerminationState.Terminated = &v1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}
+	oomKilled := waiting(ReasonCrashLoopBackOff, "Back-off restarting failed container")
+	oomKilled.LastTerminationState.Terminated = &v1.ContainerStateTerminated{ExitCode: 137, Reason: ReasonOOMKilled}
+
+	unschedulable := newPod("pending", v1.PodPending)
+	unschedulable.Status.Conditions = []v1.PodCondition{{
+		Type:    v1.PodScheduled,
+		Status:  v1.ConditionFalse,
+		Reason:  v1.PodReasonUnschedulable,
+		Message: "0/5 nodes are available: 5 Insufficient memory.",
+	}}
+
+	unready := newPod("unready", v1.PodRunning)
+	unready.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionFalse}}
+
+	evicted := newPod("evicted", v1.PodFailed)
+	evicted.Status.Reason = ReasonEvicted
+	evicted.Status.Message = "The node was low on resource: memory."
+
+	cases := []struct {
+		info     string
+		pods     []v1.Pod
+		events   []v1.Event
+		expected *Diagnosis
+	}{
+		{
+			"healthy pods",
+			[]v1.Pod{newPod("a", v1.PodRunning), newPod("b", v1.PodSucceeded)},
+			nil,
+			nil,
+		},
+		{
+			"image pull with failed registry auth",
+			[]v1.Pod{
+				newPod("a", v1.PodPending, waiting(ReasonImagePullBackOff, `Back-off pulling image "registry.io/app:1"`)),
+				newPod("b", v1.PodPending, waiting(ReasonImagePullBackOff, `Back-off pulling image "registry.io/app:1"`)),
+			},
+			[]v1.Event{
+				newPodEvent("a", "Failed", `Failed to pull image "registry.io/app:1": unauthorized: authentication required`),
+				newPodEvent("b", "Failed", `Failed to pull image "registry.io/app:1": unauthorized: authentication required`),
+			},
+			&Diagnosis{
+				Summary: "ImagePullBackOff: registry auth failed",
+				Problems: []Problem{
+					{Reason: ReasonImagePullBackOff, Message: "registry auth failed", Pods: []string{"a", "b"}},
+				},
+			},
+		},
+		{
+			"image not found",
+			[]v1.Pod{newPod("a", v1.PodPending, waiting(ReasonErrImagePull, "manifest unknown"))},
+			nil,
+			&Diagnosis{
+				Summary: "ErrImagePull: image registry.io/app:1 not found",
+				Problems: []Problem{
+					{Reason: ReasonErrImagePull, Message: "image registry.io/app:1 not found", Pods: []string{"a"}},
+				},
+			},
+		},
+		{
+			"unschedulable is more important than crashing",
+			[]v1.Pod{newPod("crashing", v1.PodRunning, crashing), unschedulable},
+			nil,
+			&Diagnosis{
+				Summary: "Unschedulable: insufficient memory on 5/5 nodes",
+				Problems: []Problem{
+					{Reason: ReasonUnschedulable, Message: "insufficient memory on 5/5 nodes", Pods: []string{"pending"}},
+					{Reason: ReasonCrashLoopBackOff, Message: "container app exited with code 1", Pods: []string{"crashing"}},
+				},
+			},
+		},
+		{
+			"crash caused by running out of memory",
+			[]v1.Pod{newPod("a", v1.PodRunning, oomKilled)},
+			nil,
+			&Diagnosis{
+				Summary: "OOMKilled: container app ran out of memory",
+				Problems: []Problem{
+					{Reason: ReasonOOMKilled, Message: "container app ran out of memory", Pods: []string{"a"}},
+				},
+			},
+		},
+		{
+			"missing secret, eviction and failed readiness probe",
+			[]v1.Pod{
+				newPod("config", v1.PodPending, waiting(ReasonCreateContainerConfigError, `Secret "db" not found`)),
+				evicted,
+				unready,
+			},
+			[]v1.Event{newPodEvent("unready", "Unhealthy", "Readiness probe failed: HTTP probe failed with statuscode: 503")},
+			&Diagnosis{
+				Summary: `CreateContainerConfigError: secret "db" not found`,
+				Problems: []Problem{
+					{Reason: ReasonCreateContainerConfigError, Message: `secret "db" not found`, Pods: []string{"config"}},
+					{Reason: ReasonEvicted, Message: "the node was low on resource: memory", Pods: []string{"evicted"}},
+					{Reason: ReasonUnready, Message: "readiness probe failed", Pods: []string{"unready"}},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		actual := DiagnosePods(c.pods, c.events)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("DiagnosePods() with %s == \n%#v\nexpected \n%#v", c.info, actual, c.expected)
+		}
+	}
+}
+
+func TestDescribeScheduling(t *testing.T) {
+	cases := []struct {
+		message  string
+		expected string
+	}{
+		{
+			"0/5 nodes are available: 2 node(s) had taints that the pod didn't tolerate, 3 Insufficient cpu.",
+			"insufficient cpu on 3/5 nodes, untolerated taints on 2/5 nodes",
+		},
+		{
+			"No nodes are available that match all of the following predicates:: Insufficient memory (4), MatchNodeSelector (1).",
+			"insufficient memory on 4 nodes, node selector mismatch on 1 nodes",
+		},
+		{
+			"Pod has unbound PersistentVolumeClaims",
+			"pod has unbound PersistentVolumeClaims",
+		},
+	}
+
+	for _, c := range cases {
+		actual := describeScheduling(c.message)
+		if actual != c.expected {
+			t.Errorf("describeScheduling(%q) == %q, expected %q", c.message, actual, c.expected)
+		}
+	}
+}
+
+func TestGetWorkloadDiagnosis(t *testing.T) {
+	replicas := int32(2)
+	deadline := int32(600)
+	deployment := &extensions.Deployment{
+		ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "ns"},
+		Spec: extensions.DeploymentSpec{
+			Replicas:                &replicas,
+			ProgressDeadlineSeconds: &deadline,
+			Selector:                &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+		},
+		Status: extensions.DeploymentStatus{
+			Conditions: []extensions.DeploymentCondition{
+				{
+					Type:   extensions.DeploymentProgressing,
+					Status: v1.ConditionFalse,
+					Reason: ReasonProgressDeadlineExceeded,
+				},
+				{
+					Type:   extensions.DeploymentReplicaFailure,
+					Status: v1.ConditionTrue,
+					Message: `pods "app-1" is forbidden: exceeded quota: compute, requested: memory=1Gi, ` +
+						`used: memory=4Gi, limited: memory=4Gi`,
+				},
+			},
+		},
+	}
+	pod := newPod("app-1", v1.PodPending, waiting(ReasonInvalidImageName, ""))
+	other := newPod("other", v1.PodPending, waiting(ReasonInvalidImageName, ""))
+	other.Labels = map[string]string{"app": "other"}
+
+	client := fake.NewSimpleClientset(deployment, &pod, &other)
+	actual, err := GetWorkloadDiagnosis(client, api.ResourceKindDeployment, "ns", "app")
+	if err != nil {
+		t.Fatalf("GetWorkloadDiagnosis() returned error: %s", err.Error())
+	}
+
+	expected := &Diagnosis{
+		Summary: "ProgressDeadlineExceeded: rollout has not progressed for 600s",
+		Problems: []Problem{
+			{Reason: ReasonProgressDeadlineExceeded, Message: "rollout has not progressed for 600s", Pods: []string{}},
+			{Reason: ReasonFailedCreate, Message: "exceeded quota: compute", Pods: []string{}},
+			{Reason: ReasonInvalidImageName, Message: "invalid image name registry.io/app:1", Pods: []string{"app-1"}},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetWorkloadDiagnosis() == \n%#v\nexpected \n%#v", actual, expected)
+	}
+
+	if _, err := GetWorkloadDiagnosis(client, api.ResourceKindService, "ns", "app"); err == nil {
+		t.Error("GetWorkloadDiagnosis() of service expected to fail")
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnosis
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+var (
+	// schedulerMessage matches messages of the scheduler, i.e.
+	// "0/5 nodes are available: 3 Insufficient memory, 2 node(s) had taints that the pod didn't tolerate."
+	schedulerMessage = regexp.MustCompile(`^0/(\d+) nodes are available: (.+?)\.?$`)
+	// schedulerPart matches a single cause in the scheduler message, i.e. "3 Insufficient memory".
+	schedulerPart = regexp.MustCompile(`^(\d+) (.+)$`)
+	// predicatesMessage matches messages of older schedulers, i.e.
+	// "No nodes are available that match all of the following predicates:: Insufficient cpu (5)."
+	predicatesMessage = regexp.MustCompile(`^No nodes are available that match all of the (?:following )?predicates:+ (.+?)\.?$`)
+	// predicatesPart matches a single cause in the older scheduler message, i.e. "Insufficient cpu (5)".
+	predicatesPart = regexp.MustCompile(`^(.+) \((\d+)\)$`)
+)
+
+// Short descriptions of scheduling failures keyed by text, which the cause in the scheduler
+// message starts with.
+var schedulingCauses = []struct {
+	prefix      string
+	description string
+}{
+	{"node(s) had taint", "untolerated taints"},
+	{"PodToleratesNodeTaints", "untolerated taints"},
+	{"node(s) didn't match node selector", "node selector mismatch"},
+	{"node(s) didn't match pod's node affinity", "node affinity mismatch"},
+	{"MatchNodeSelector", "node selector mismatch"},
+	{"node(s) had volume node affinity conflict", "volume node affinity conflict"},
+	{"node(s) were unschedulable", "nodes unschedulable"},
+	{"node(s) were not ready", "nodes not ready"},
+	{"node(s) didn't have free ports", "no free host ports"},
+	{"PodFitsHostPorts", "no free host ports"},
+}
+
+// Fragments of image pull errors and their short descriptions. The first matching one is used.
+var pullErrors = []struct {
+	fragments   []string
+	description string
+}{
+	{[]string{"unauthorized", "authentication required", "no basic auth credentials", "access denied",
+		"denied:", "forbidden"}, "registry auth failed"},
+	{[]string{"not found", "manifest unknown", "does not exist"}, "image %s not found"},
+	{[]string{"no such host", "i/o timeout", "connection refused", "network is unreachable"},
+		"registry unreachable"},
+}
+
+// DiagnosePods returns problems of the pods found in their statuses and related events. Nil is
+// returned when all pods are healthy.
+func DiagnosePods(pods []v1.Pod, events []v1.Event) *Diagnosis {
+	problems := make([]Problem, 0)
+	for _, pod := range pods {
+		problems = append(problems, diagnosePod(pod, events)...)
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return newDiagnosis(problems)
+}
+
+// diagnosePod returns problems of a single pod.
+func diagnosePod(pod v1.Pod, events []v1.Event) []Problem {
+	if pod.Status.Phase == v1.PodSucceeded {
+		return nil
+	}
+
+	newProblem := func(reason, message string) Problem {
+		return Problem{Reason: reason, Message: message, Pods: []string{pod.Name}}
+	}
+
+	if pod.Status.Reason == ReasonEvicted {
+		return []Problem{newProblem(ReasonEvicted, lowerFirst(strings.TrimSuffix(pod.Status.Message, ".")))}
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionFalse &&
+			condition.Reason == v1.PodReasonUnschedulable {
+			return []Problem{newProblem(ReasonUnschedulable, describeScheduling(condition.Message))}
+		}
+	}
+
+	podEvents := getPodEvents(pod, events)
+	problems := make([]Problem, 0)
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...),
+		pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if problem := diagnoseContainer(status, podEvents); problem != nil {
+			problem.Pods = []string{pod.Name}
+			problems = append(problems, *problem)
+		}
+	}
+
+	if len(problems) == 0 && pod.Status.Phase == v1.PodRunning && isNotReady(pod) {
+		problems = append(problems, newProblem(ReasonUnready, describeUnready(podEvents)))
+	}
+	return problems
+}
+
+// diagnoseContainer returns the problem of a container or nil if there is none.
+func diagnoseContainer(status v1.ContainerStatus, events []v1.Event) *Problem {
+	if status.State.Waiting == nil {
+		return nil
+	}
+
+	waiting := status.State.Waiting
+	switch waiting.Reason {
+	case ReasonImagePullBackOff, ReasonErrImagePull:
+		return &Problem{Reason: waiting.Reason, Message: describePullError(status.Image, waiting.Message, events)}
+	case ReasonInvalidImageName:
+		return &Problem{Reason: waiting.Reason, Message: fmt.Sprintf("invalid image name %s", status.Image)}
+	case ReasonCreateContainerConfigError, ReasonCreateContainerError:
+		return &Problem{Reason: waiting.Reason, Message: lowerFirst(waiting.Message)}
+	case ReasonCrashLoopBackOff:
+		terminated := status.LastTerminationState.Terminated
+		if terminated == nil {
+			return &Problem{Reason: ReasonCrashLoopBackOff,
+				Message: fmt.Sprintf("container %s keeps crashing", status.Name)}
+		}
+		if terminated.Reason == ReasonOOMKilled {
+			return &Problem{Reason: ReasonOOMKilled,
+				Message: fmt.Sprintf("container %s ran out of memory", status.Name)}
+		}
+		return &Problem{Reason: ReasonCrashLoopBackOff,
+			Message: fmt.Sprintf("container %s exited with code %d", status.Name, terminated.ExitCode)}
+	}
+	return nil
+}
+
+// describePullError returns a short description of the image pull failure. Details are usually
+// present only in events, so the waiting message is just a fallback.
+func describePullError(image, waitingMessage string, events []v1.Event) string {
+	messages := []string{strings.ToLower(waitingMessage)}
+	for _, event := range events {
+		if event.Type == v1.EventTypeWarning && strings.Contains(strings.ToLower(event.Message), "pull") {
+			messages = append(messages, strings.ToLower(event.Message))
+		}
+	}
+
+	for _, pullError := range pullErrors {
+		for _, fragment := range pullError.fragments {
+			for _, message := range messages {
+				if strings.Contains(message, fragment) {
+					if strings.Contains(pullError.description, "%s") {
+						return fmt.Sprintf(pullError.description, image)
+					}
+					return pullError.description
+				}
+			}
+		}
+	}
+	return fmt.Sprintf("cannot pull image %s", image)
+}
+
+// SchedulingCause is a single cause of scheduling failure reported by the scheduler.
+type SchedulingCause struct {
+	// Cause as reported by the scheduler, i.e. "Insufficient memory".
+	Cause string `json:"cause"`
+
+	// Description is a short description of the cause, i.e. "insufficient memory".
+	Description string `json:"description"`
+
+	// Nodes is the number of nodes the cause applies to.
+	Nodes int `json:"nodes"`
+}
+
+// ParseSchedulingMessage returns causes in the scheduler message, most frequent first, and the
+// number of nodes considered by the scheduler, which older schedulers do not report. False is
+// returned for messages in unknown format.
+func ParseSchedulingMessage(message string) ([]SchedulingCause, int, bool) {
+	causes := make([]SchedulingCause, 0)
+	total := 0
+
+	if match := schedulerMessage.FindStringSubmatch(message); match != nil {
+		total, _ = strconv.Atoi(match[1])
+		for _, part := range strings.Split(match[2], ", ") {
+			partMatch := schedulerPart.FindStringSubmatch(strings.TrimSpace(part))
+			if partMatch == nil {
+				return nil, 0, false
+			}
+			count, _ := strconv.Atoi(partMatch[1])
+			causes = append(causes, SchedulingCause{partMatch[2], describeSchedulingCause(partMatch[2]), count})
+		}
+	} else if match := predicatesMessage.FindStringSubmatch(message); match != nil {
+		for _, part := range strings.Split(match[1], ", ") {
+			partMatch := predicatesPart.FindStringSubmatch(strings.TrimSpace(part))
+			if partMatch == nil {
+				return nil, 0, false
+			}
+			count, _ := strconv.Atoi(partMatch[2])
+			causes = append(causes, SchedulingCause{partMatch[1], describeSchedulingCause(partMatch[1]), count})
+		}
+	} else {
+		return nil, 0, false
+	}
+
+	sort.SliceStable(causes, func(i, j int) bool { return causes[i].Nodes > causes[j].Nodes })
+	return causes, total, true
+}
+
+// describeScheduling turns the scheduler message into a short description, i.e. "insufficient
+// memory on 5/5 nodes". Messages in unknown format are returned as they are.
+func describeScheduling(message string) string {
+	causes, total, ok := ParseSchedulingMessage(message)
+	if !ok {
+		return lowerFirst(message)
+	}
+
+	descriptions := make([]string, 0)
+	for _, c := range causes {
+		if total > 0 {
+			descriptions = append(descriptions, fmt.Sprintf("%s on %d/%d nodes", c.Description, c.Nodes, total))
+		} else {
+			descriptions = append(descriptions, fmt.Sprintf("%s on %d nodes", c.Description, c.Nodes))
+		}
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// describeSchedulingCause returns short description of a single cause from the scheduler message.
+func describeSchedulingCause(cause string) string {
+	for _, known := range schedulingCauses {
+		if strings.HasPrefix(strings.ToLower(cause), strings.ToLower(known.prefix)) {
+			return known.description
+		}
+	}
+	return lowerFirst(strings.TrimPrefix(cause, "node(s) "))
+}
+
+// describeUnready returns the reason why a running pod is not ready based on probe events.
+func describeUnready(events []v1.Event) string {
+	for _, prefix := range []string{"Readiness", "Liveness"} {
+		for _, event := range events {
+			if event.Reason == "Unhealthy" && strings.HasPrefix(event.Message, prefix+" probe failed") {
+				return strings.ToLower(prefix) + " probe failed"
+			}
+		}
+	}
+	return "containers are not ready"
+}
+
+// getPodEvents returns events involving the pod.
+func getPodEvents(pod v1.Pod, events []v1.Event) []v1.Event {
+	result := make([]v1.Event, 0)
+	for _, event := range events {
+		if (len(pod.UID) > 0 && event.InvolvedObject.UID == pod.UID) ||
+			(event.InvolvedObject.Kind == "Pod" && event.InvolvedObject.Name == pod.Name &&
+				event.InvolvedObject.Namespace == pod.Namespace) {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// isNotReady returns true when the pod reports, that it is not ready. Pods without the condition
+// are not considered unready.
+func isNotReady(pod v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionFalse
+		}
+	}
+	return false
+}
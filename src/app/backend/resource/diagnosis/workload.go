@@ -0,0 +1,208 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnosis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	batch "k8s.io/client-go/pkg/apis/batch/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+// workload contains parts of a workload needed to diagnose it.
+type workload struct {
+	uid      types.UID
+	kind     string
+	selector labels.Selector
+	// pod is set when the workload is a single pod, which is diagnosed instead of selected pods.
+	pod *v1.Pod
+	// Problems found in conditions of the workload.
+	problems []Problem
+}
+
+// GetWorkloadDiagnosis returns diagnosis of the workload of given kind based on its conditions,
+// events and states of its pods.
+func GetWorkloadDiagnosis(client client.Interface, kind api.ResourceKind, namespace,
+	name string) (*Diagnosis, error) {
+	target, err := getWorkload(client, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]v1.Pod, 0)
+	if target.pod != nil {
+		pods = append(pods, *target.pod)
+	} else {
+		podList, err := client.CoreV1().Pods(namespace).List(metaV1.ListOptions{
+			LabelSelector: target.selector.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		pods = podList.Items
+	}
+
+	events, err := client.CoreV1().Events(namespace).List(metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	problems := append(target.problems, getFailedCreateProblems(target, name, events.Items)...)
+	for _, pod := range pods {
+		problems = append(problems, diagnosePod(pod, events.Items)...)
+	}
+	return newDiagnosis(problems), nil
+}
+
+// getWorkload returns the workload of given kind with problems found in its conditions.
+func getWorkload(client client.Interface, kind api.ResourceKind, namespace, name string) (*workload, error) {
+	switch kind {
+	case api.ResourceKindPod:
+		pod, err := client.CoreV1().Pods(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &workload{uid: pod.UID, kind: "Pod", pod: pod, problems: make([]Problem, 0)}, nil
+	case api.ResourceKindDeployment:
+		deployment, err := client.ExtensionsV1beta1().Deployments(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return newWorkload(deployment.UID, "Deployment", deployment.Spec.Selector,
+			getDeploymentProblems(deployment))
+	case api.ResourceKindReplicaSet:
+		replicaSet, err := client.ExtensionsV1beta1().ReplicaSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		problems := make([]Problem, 0)
+		for _, condition := range replicaSet.Status.Conditions {
+			if condition.Type == extensions.ReplicaSetReplicaFailure && condition.Status == v1.ConditionTrue {
+				problems = append(problems, Problem{Reason: ReasonFailedCreate,
+					Message: describeFailedCreate(condition.Message)})
+			}
+		}
+		return newWorkload(replicaSet.UID, "ReplicaSet", replicaSet.Spec.Selector, problems)
+	case api.ResourceKindReplicationController:
+		rc, err := client.CoreV1().ReplicationControllers(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		problems := make([]Problem, 0)
+		for _, condition := range rc.Status.Conditions {
+			if condition.Type == v1.ReplicationControllerReplicaFailure && condition.Status == v1.ConditionTrue {
+				problems = append(problems, Problem{Reason: ReasonFailedCreate,
+					Message: describeFailedCreate(condition.Message)})
+			}
+		}
+		return newWorkload(rc.UID, "ReplicationController",
+			&metaV1.LabelSelector{MatchLabels: rc.Spec.Selector}, problems)
+	case api.ResourceKindDaemonSet:
+		daemonSet, err := client.ExtensionsV1beta1().DaemonSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return newWorkload(daemonSet.UID, "DaemonSet", daemonSet.Spec.Selector, make([]Problem, 0))
+	case api.ResourceKindStatefulSet:
+		statefulSet, err := client.AppsV1beta1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return newWorkload(statefulSet.UID, "StatefulSet", statefulSet.Spec.Selector, make([]Problem, 0))
+	case api.ResourceKindJob:
+		job, err := client.BatchV1().Jobs(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		problems := make([]Problem, 0)
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batch.JobFailed && condition.Status == v1.ConditionTrue {
+				problems = append(problems, Problem{Reason: condition.Reason, Message: lowerFirst(condition.Message)})
+			}
+		}
+		return newWorkload(job.UID, "Job", job.Spec.Selector, problems)
+	}
+	return nil, errorsK8s.NewBadRequest(fmt.Sprintf("diagnosis of %s is not supported", kind))
+}
+
+func newWorkload(uid types.UID, kind string, selector *metaV1.LabelSelector, problems []Problem) (*workload, error) {
+	labelSelector, err := metaV1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return &workload{uid: uid, kind: kind, selector: labelSelector, problems: problems}, nil
+}
+
+// getDeploymentProblems returns problems found in conditions of the deployment.
+func getDeploymentProblems(deployment *extensions.Deployment) []Problem {
+	problems := make([]Problem, 0)
+	for _, condition := range deployment.Status.Conditions {
+		switch {
+		case condition.Type == extensions.DeploymentProgressing && condition.Status == v1.ConditionFalse &&
+			condition.Reason == ReasonProgressDeadlineExceeded:
+			message := "rollout is not progressing"
+			if deployment.Spec.ProgressDeadlineSeconds != nil {
+				message = fmt.Sprintf("rollout has not progressed for %ds", *deployment.Spec.ProgressDeadlineSeconds)
+			}
+			problems = append(problems, Problem{Reason: ReasonProgressDeadlineExceeded, Message: message})
+		case condition.Type == extensions.DeploymentReplicaFailure && condition.Status == v1.ConditionTrue:
+			problems = append(problems, Problem{Reason: ReasonFailedCreate,
+				Message: describeFailedCreate(condition.Message)})
+		}
+	}
+	return problems
+}
+
+// getFailedCreateProblems returns problems from warning events reporting, that the workload
+// could not create its pods.
+func getFailedCreateProblems(target *workload, name string, events []v1.Event) []Problem {
+	problems := make([]Problem, 0)
+	for _, event := range events {
+		if event.Type != v1.EventTypeWarning || event.Reason != ReasonFailedCreate {
+			continue
+		}
+		if (len(target.uid) > 0 && event.InvolvedObject.UID == target.uid) ||
+			(event.InvolvedObject.Kind == target.kind && event.InvolvedObject.Name == name) {
+			problems = append(problems, Problem{Reason: ReasonFailedCreate,
+				Message: describeFailedCreate(event.Message)})
+		}
+	}
+	return problems
+}
+
+// describeFailedCreate returns short description of the pod creation failure, i.e.
+// "exceeded quota: compute-quota".
+func describeFailedCreate(message string) string {
+	message = strings.TrimPrefix(message, "Error creating: ")
+	if index := strings.Index(message, "exceeded quota: "); index >= 0 {
+		quota := message[index:]
+		if end := strings.Index(quota, ","); end >= 0 {
+			quota = quota[:end]
+		}
+		return quota
+	}
+	if index := strings.Index(message, "is forbidden: "); index >= 0 {
+		return message[index+len("is forbidden: "):]
+	}
+	return lowerFirst(message)
+}
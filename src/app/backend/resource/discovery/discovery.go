@@ -15,9 +15,8 @@
 package discovery
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/ingress"
@@ -38,7 +37,7 @@ type Discovery struct {
 func GetDiscovery(client *kubernetes.Clientset, nsQuery *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery) (*Discovery, error) {
 
-	log.Print("Getting discovery and load balancing category")
+	logger.Info("Getting discovery and load balancing category")
 	channels := &common.ResourceChannels{
 		ServiceList: common.GetServiceListChannel(client, nsQuery, 1),
 		IngressList: common.GetIngressListChannel(client, nsQuery, 1),
@@ -0,0 +1,256 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dns resolves DNS names from within the cluster to help debugging cluster DNS.
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Lookups are cancelled after lookupTimeout.
+var lookupTimeout = 10 * time.Second
+
+// Record types that can be looked up.
+const (
+	RecordTypeA     = "A"
+	RecordTypeAAAA  = "AAAA"
+	RecordTypeCNAME = "CNAME"
+	RecordTypeSRV   = "SRV"
+	RecordTypePTR   = "PTR"
+)
+
+// Resolvers used for lookups.
+const (
+	// ResolverDashboard resolves names using resolver of the Dashboard pod.
+	ResolverDashboard = "dashboard"
+	// ResolverPod resolves names by running nslookup in the target pod.
+	ResolverPod = "pod"
+)
+
+// DNSLookupSpec describes a name to resolve and where to resolve it.
+type DNSLookupSpec struct {
+	// Name to resolve. For PTR lookups it is an IP address.
+	Name string `json:"name"`
+
+	// Service and Namespace can be used instead of Name to resolve <service>.<namespace>.svc.
+	Service   string `json:"service"`
+	Namespace string `json:"namespace"`
+
+	// Type of the looked up records. A and AAAA records are looked up when empty.
+	Type string `json:"type"`
+
+	// Pod, PodNamespace and Container select the pod to run the lookup in. The lookup runs in the
+	// Dashboard pod when Pod is empty.
+	Pod          string `json:"pod"`
+	PodNamespace string `json:"podNamespace"`
+	Container    string `json:"container"`
+}
+
+// DNSRecord is a single resolved record.
+type DNSRecord struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+
+	// Port, Priority and Weight are set for SRV records only.
+	Port     uint16 `json:"port,omitempty"`
+	Priority uint16 `json:"priority,omitempty"`
+	Weight   uint16 `json:"weight,omitempty"`
+}
+
+// DNSLookupResult contains records resolved for a name and time it took to resolve them.
+type DNSLookupResult struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Resolver string `json:"resolver"`
+
+	Records []DNSRecord `json:"records"`
+
+	// Time of the lookup in milliseconds. For lookups in pods it includes the time to start nslookup.
+	DurationMillis float64 `json:"durationMillis"`
+
+	// Error returned by the resolver, e.g. when the name does not exist.
+	Error string `json:"error"`
+
+	// Resolver configuration of the Dashboard pod. Only set for lookups in the Dashboard pod.
+	ResolvConf *ResolvConf `json:"resolvConf,omitempty"`
+
+	// Output of nslookup. Only set for lookups in pods.
+	Output string `json:"output,omitempty"`
+}
+
+// resolver is implemented by net.Resolver. It can be replaced in tests.
+type resolver interface {
+	LookupIP(ctx context.Context, network, host string) ([]net.IP, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+}
+
+var dashboardResolver resolver = net.DefaultResolver
+
+// addressNetworks are networks of addresses looked up for address record types. Addresses of both
+// families are looked up when the type is not set.
+var addressNetworks = map[string]string{
+	"":             "ip",
+	RecordTypeA:    "ip4",
+	RecordTypeAAAA: "ip6",
+}
+
+// Lookup resolves the name described by the spec either in the Dashboard pod or in the target pod.
+// Resolution failures are reported in the result, not as errors.
+func Lookup(client kubernetes.Interface, config *rest.Config, spec *DNSLookupSpec) (*DNSLookupResult, error) {
+	name, recordType, err := normalizeSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(spec.Pod) == 0 {
+		logger.Infof("Resolving %s records of %s in Dashboard pod", recordType, name)
+		return lookupInDashboard(spec, name, recordType), nil
+	}
+
+	logger.Infof("Resolving %s records of %s in pod %s in %s namespace", recordType, name, spec.Pod,
+		spec.PodNamespace)
+	return lookupInPod(client, config, spec, name, recordType)
+}
+
+func normalizeSpec(spec *DNSLookupSpec) (string, string, error) {
+	name := strings.TrimSpace(spec.Name)
+	if len(spec.Service) > 0 {
+		if len(spec.Namespace) == 0 {
+			return "", "", errorsK8s.NewBadRequest("namespace of the service is required")
+		}
+		name = fmt.Sprintf("%s.%s.svc", spec.Service, spec.Namespace)
+	}
+	if len(name) == 0 {
+		return "", "", errorsK8s.NewBadRequest("name or service to resolve is required")
+	}
+
+	recordType := strings.ToUpper(spec.Type)
+	switch recordType {
+	case "":
+		recordType = RecordTypeA
+	case RecordTypeA, RecordTypeAAAA, RecordTypeCNAME, RecordTypeSRV:
+	case RecordTypePTR:
+		if net.ParseIP(name) == nil {
+			return "", "", errorsK8s.NewBadRequest("PTR lookup requires an IP address")
+		}
+	default:
+		return "", "", errorsK8s.NewBadRequest(fmt.Sprintf("unsupported record type %s", spec.Type))
+	}
+
+	if len(spec.Pod) > 0 && len(spec.PodNamespace) == 0 {
+		return "", "", errorsK8s.NewBadRequest("namespace of the pod is required")
+	}
+	return name, recordType, nil
+}
+
+func lookupInDashboard(spec *DNSLookupSpec, name, recordType string) *DNSLookupResult {
+	result := &DNSLookupResult{
+		Name:     name,
+		Type:     recordType,
+		Resolver: ResolverDashboard,
+		Records:  make([]DNSRecord, 0),
+	}
+	if resolvConf, err := readResolvConf(); err == nil {
+		result.ResolvConf = resolvConf
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	defer cancel()
+
+	start := time.Now()
+	var err error
+	switch recordType {
+	case RecordTypeA, RecordTypeAAAA:
+		var ips []net.IP
+		ips, err = dashboardResolver.LookupIP(ctx, getAddressNetwork(spec), name)
+		for _, ip := range ips {
+			result.Records = append(result.Records, toAddressRecord(ip))
+		}
+	case RecordTypeCNAME:
+		var cname string
+		cname, err = dashboardResolver.LookupCNAME(ctx, name)
+		if err == nil {
+			result.Records = append(result.Records, DNSRecord{Type: RecordTypeCNAME, Value: cname})
+		}
+	case RecordTypeSRV:
+		var srvs []*net.SRV
+		_, srvs, err = dashboardResolver.LookupSRV(ctx, "", "", name)
+		for _, srv := range srvs {
+			result.Records = append(result.Records, DNSRecord{Type: RecordTypeSRV, Value: srv.Target,
+				Port: srv.Port, Priority: srv.Priority, Weight: srv.Weight})
+		}
+	case RecordTypePTR:
+		var names []string
+		names, err = dashboardResolver.LookupAddr(ctx, name)
+		for _, ptr := range names {
+			result.Records = append(result.Records, DNSRecord{Type: RecordTypePTR, Value: ptr})
+		}
+	}
+	result.DurationMillis = float64(time.Since(start)/time.Microsecond) / 1000
+
+	if err != nil {
+		result.Error = err.Error()
+	}
+	sortRecords(result.Records)
+	return result
+}
+
+// getAddressNetwork returns network of addresses requested by the spec, either ip4, ip6 or ip for
+// both families.
+func getAddressNetwork(spec *DNSLookupSpec) string {
+	return addressNetworks[strings.ToUpper(spec.Type)]
+}
+
+// filterAddressRecords removes address records of the family that was not requested, as nslookup
+// returns addresses of both families.
+func filterAddressRecords(records []DNSRecord, network string) []DNSRecord {
+	result := make([]DNSRecord, 0, len(records))
+	for _, record := range records {
+		if (network == "ip4" && record.Type == RecordTypeAAAA) ||
+			(network == "ip6" && record.Type == RecordTypeA) {
+			continue
+		}
+		result = append(result, record)
+	}
+	return result
+}
+
+func toAddressRecord(ip net.IP) DNSRecord {
+	if ip.To4() != nil {
+		return DNSRecord{Type: RecordTypeA, Value: ip.String()}
+	}
+	return DNSRecord{Type: RecordTypeAAAA, Value: ip.String()}
+}
+
+func sortRecords(records []DNSRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Type != records[j].Type {
+			return records[i].Type < records[j].Type
+		}
+		return records[i].Value < records[j].Value
+	})
+}
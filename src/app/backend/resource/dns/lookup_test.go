@@ -0,0 +1,252 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+type fakeResolver struct {
+	ips []net.IP
+	err error
+}
+
+func (r *fakeResolver) LookupIP(ctx context.Context, network, host string) ([]net.IP, error) {
+	result := make([]net.IP, 0)
+	for _, ip := range r.ips {
+		if network == "ip" || (network == "ip4") == (ip.To4() != nil) {
+			result = append(result, ip)
+		}
+	}
+	return result, r.err
+}
+
+func (r *fakeResolver) LookupCNAME(ctx context.Context, host string) (string, error) {
+	return "target.example.com.", r.err
+}
+
+func (r *fakeResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	return "", []*net.SRV{{Target: "web-0.web.default.svc.", Port: 80, Priority: 0, Weight: 100}}, r.err
+}
+
+func (r *fakeResolver) LookupAddr(ctx context.Context, addr string) ([]string, error) {
+	return []string{"kubernetes.default.svc."}, r.err
+}
+
+func TestNormalizeSpec(t *testing.T) {
+	cases := []struct {
+		spec         *DNSLookupSpec
+		expectedName string
+		expectedType string
+		expectedErr  bool
+	}{
+		{&DNSLookupSpec{Name: " kubernetes.default "}, "kubernetes.default", RecordTypeA, false},
+		{&DNSLookupSpec{Service: "web", Namespace: "prod", Type: "srv"}, "web.prod.svc", RecordTypeSRV, false},
+		{&DNSLookupSpec{Service: "web"}, "", "", true},
+		{&DNSLookupSpec{}, "", "", true},
+		{&DNSLookupSpec{Name: "example.com", Type: "MX"}, "", "", true},
+		{&DNSLookupSpec{Name: "example.com", Type: "PTR"}, "", "", true},
+		{&DNSLookupSpec{Name: "10.0.0.1", Type: "PTR"}, "10.0.0.1", RecordTypePTR, false},
+		{&DNSLookupSpec{Name: "example.com", Pod: "debug"}, "", "", true},
+	}
+
+	for _, c := range cases {
+		name, recordType, err := normalizeSpec(c.spec)
+		if (err != nil) != c.expectedErr {
+			t.Errorf("normalizeSpec(%#v) returned error %v, expected error: %t", c.spec, err, c.expectedErr)
+			continue
+		}
+		if name != c.expectedName || recordType != c.expectedType {
+			t.Errorf("normalizeSpec(%#v) == %s, %s, expected %s, %s", c.spec, name, recordType,
+				c.expectedName, c.expectedType)
+		}
+	}
+}
+
+func TestLookupInDashboard(t *testing.T) {
+	file, err := ioutil.TempFile("", "resolv.conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString("# generated\nnameserver 10.96.0.10\nsearch default.svc.cluster.local svc.cluster.local\n" +
+		"options ndots:5\n")
+	file.Close()
+
+	defer func(path string, r resolver) { resolvConfPath, dashboardResolver = path, r }(resolvConfPath,
+		dashboardResolver)
+	resolvConfPath = file.Name()
+	dashboardResolver = &fakeResolver{ips: []net.IP{net.ParseIP("fd00::1"), net.ParseIP("10.96.0.1")}}
+
+	actual, err := Lookup(fake.NewSimpleClientset(), nil, &DNSLookupSpec{Name: "kubernetes.default"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedRecords := []DNSRecord{{Type: RecordTypeA, Value: "10.96.0.1"},
+		{Type: RecordTypeAAAA, Value: "fd00::1"}}
+	if !reflect.DeepEqual(actual.Records, expectedRecords) {
+		t.Errorf("Lookup returned records %#v, expected %#v", actual.Records, expectedRecords)
+	}
+	expectedConf := &ResolvConf{
+		Nameservers: []string{"10.96.0.10"},
+		Search:      []string{"default.svc.cluster.local", "svc.cluster.local"},
+		Options:     []string{"ndots:5"},
+	}
+	if !reflect.DeepEqual(actual.ResolvConf, expectedConf) {
+		t.Errorf("Lookup returned resolv.conf %#v, expected %#v", actual.ResolvConf, expectedConf)
+	}
+	if actual.Resolver != ResolverDashboard || len(actual.Error) > 0 {
+		t.Errorf("Lookup returned resolver %s and error %q", actual.Resolver, actual.Error)
+	}
+
+	actual, _ = Lookup(fake.NewSimpleClientset(), nil, &DNSLookupSpec{Name: "kubernetes.default",
+		Type: "A"})
+	expectedRecords = []DNSRecord{{Type: RecordTypeA, Value: "10.96.0.1"}}
+	if !reflect.DeepEqual(actual.Records, expectedRecords) {
+		t.Errorf("Lookup of A records returned %#v, expected %#v", actual.Records, expectedRecords)
+	}
+
+	dashboardResolver = &fakeResolver{err: errors.New("no such host")}
+	actual, _ = Lookup(fake.NewSimpleClientset(), nil, &DNSLookupSpec{Name: "missing.default"})
+	if actual.Error != "no such host" || len(actual.Records) != 0 {
+		t.Errorf("Lookup of missing name returned %#v", actual)
+	}
+}
+
+func TestParseNslookupOutput(t *testing.T) {
+	cases := []struct {
+		output          string
+		expectedRecords []DNSRecord
+		expectedErr     string
+	}{
+		{
+			"Server:\t\t10.96.0.10\nAddress:\t10.96.0.10#53\n\nName:\tkubernetes.default.svc.cluster.local\n" +
+				"Address: 10.96.0.1\n",
+			[]DNSRecord{{Type: RecordTypeA, Value: "10.96.0.1"}},
+			"",
+		},
+		{
+			"Server:    10.96.0.10\nAddress 1: 10.96.0.10 kube-dns.kube-system.svc.cluster.local\n\n" +
+				"Name:      web\nAddress 1: 10.0.0.5 web-0.web.default.svc.cluster.local\n" +
+				"Address 2: fd00::5\n",
+			[]DNSRecord{{Type: RecordTypeA, Value: "10.0.0.5"}, {Type: RecordTypeAAAA, Value: "fd00::5"}},
+			"",
+		},
+		{
+			"www.example.com\tcanonical name = example.com.\n",
+			[]DNSRecord{{Type: RecordTypeCNAME, Value: "example.com"}},
+			"",
+		},
+		{
+			"_http._tcp.web.default.svc.cluster.local\tservice = 0 100 80 web-0.web.default.svc.cluster.local.\n",
+			[]DNSRecord{{Type: RecordTypeSRV, Value: "web-0.web.default.svc.cluster.local", Port: 80,
+				Weight: 100}},
+			"",
+		},
+		{
+			"1.0.96.10.in-addr.arpa\tname = kubernetes.default.svc.cluster.local.\n",
+			[]DNSRecord{{Type: RecordTypePTR, Value: "kubernetes.default.svc.cluster.local"}},
+			"",
+		},
+		{
+			"Server:\t\t10.96.0.10\nAddress:\t10.96.0.10#53\n\n** server can't find missing: NXDOMAIN\n",
+			[]DNSRecord{},
+			"server can't find missing: NXDOMAIN",
+		},
+	}
+
+	for _, c := range cases {
+		records, errMsg := parseNslookupOutput(c.output)
+		if !reflect.DeepEqual(records, c.expectedRecords) || errMsg != c.expectedErr {
+			t.Errorf("parseNslookupOutput(%q) == %#v, %q, expected %#v, %q", c.output, records, errMsg,
+				c.expectedRecords, c.expectedErr)
+		}
+	}
+}
+
+func TestLookupInPod(t *testing.T) {
+	defer func(fn func(kubernetes.Interface, *rest.Config, string, string, string, []string,
+		<-chan struct{}) (string, string, error)) {
+		execInPod = fn
+	}(execInPod)
+
+	var command []string
+	execInPod = func(client kubernetes.Interface, config *rest.Config, namespace, pod, container string,
+		cmd []string, cancel <-chan struct{}) (string, string, error) {
+		command = cmd
+		return "Name:\tweb.prod.svc.cluster.local\nAddress: 10.0.0.5\nAddress: fd00::5\n", "", nil
+	}
+
+	actual, err := Lookup(fake.NewSimpleClientset(), nil, &DNSLookupSpec{Service: "web", Namespace: "prod",
+		Type: "AAAA", Pod: "debug", PodNamespace: "default"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCommand := []string{"nslookup", "-type=AAAA", "web.prod.svc"}
+	if !reflect.DeepEqual(command, expectedCommand) {
+		t.Errorf("Lookup executed %v, expected %v", command, expectedCommand)
+	}
+	if actual.Resolver != ResolverPod || len(actual.Records) != 1 || actual.Records[0].Value != "fd00::5" {
+		t.Errorf("Lookup returned %#v", actual)
+	}
+
+	execInPod = func(client kubernetes.Interface, config *rest.Config, namespace, pod, container string,
+		cmd []string, cancel <-chan struct{}) (string, string, error) {
+		return "", "", errors.New("executable file not found")
+	}
+	if _, err := Lookup(fake.NewSimpleClientset(), nil, &DNSLookupSpec{Name: "web", Pod: "debug",
+		PodNamespace: "default"}); err == nil {
+		t.Error("Lookup should fail when nslookup could not be run")
+	}
+}
+
+func TestLookupInPodCancelsExecOnTimeout(t *testing.T) {
+	defer func(fn func(kubernetes.Interface, *rest.Config, string, string, string, []string,
+		<-chan struct{}) (string, string, error), timeout time.Duration) {
+		execInPod, lookupTimeout = fn, timeout
+	}(execInPod, lookupTimeout)
+
+	lookupTimeout = 10 * time.Millisecond
+	cancelled := make(chan struct{})
+	execInPod = func(client kubernetes.Interface, config *rest.Config, namespace, pod, container string,
+		cmd []string, cancel <-chan struct{}) (string, string, error) {
+		<-cancel
+		close(cancelled)
+		return "", "", errors.New("exec was cancelled")
+	}
+
+	if _, err := Lookup(fake.NewSimpleClientset(), nil, &DNSLookupSpec{Name: "web", Pod: "debug",
+		PodNamespace: "default"}); err == nil {
+		t.Error("Lookup should fail when nslookup did not finish in time")
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("Lookup did not cancel exec after timeout")
+	}
+}
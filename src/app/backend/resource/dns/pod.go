@@ -0,0 +1,227 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/httpstream/spdy"
+	remotecommandconsts "k8s.io/apimachinery/pkg/util/remotecommand"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/unversioned/remotecommand"
+)
+
+// execInPod runs the command in the container and returns its standard and error output. The
+// stream is closed when cancel is closed. It is a variable, so that it can be replaced in tests.
+var execInPod = func(client kubernetes.Interface, config *rest.Config, namespace, pod, container string,
+	command []string, cancel <-chan struct{}) (string, string, error) {
+	req := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod).
+		Namespace(namespace).
+		SubResource("exec")
+
+	req.VersionedParams(&api.PodExecOptions{
+		Container: container,
+		Command:   command,
+		Stdout:    true,
+		Stderr:    true,
+	}, api.ParameterCodec)
+
+	tlsConfig, err := rest.TLSConfigFor(config)
+	if err != nil {
+		return "", "", err
+	}
+	upgrader := &cancelableUpgrader{UpgradeRoundTripper: spdy.NewRoundTripper(tlsConfig, true)}
+	transport, err := rest.HTTPWrappersForConfig(config, upgrader)
+	if err != nil {
+		return "", "", err
+	}
+	exec, err := remotecommand.NewStreamExecutor(upgrader,
+		func(http.RoundTripper) http.RoundTripper { return transport }, "POST", req.URL())
+	if err != nil {
+		return "", "", err
+	}
+
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-cancel:
+			upgrader.cancel()
+		case <-stopped:
+		}
+	}()
+
+	var stdout, stderr bytes.Buffer
+	err = exec.Stream(remotecommand.StreamOptions{
+		SupportedProtocols: remotecommandconsts.SupportedStreamingProtocols,
+		Stdout:             &stdout,
+		Stderr:             &stderr,
+	})
+	return stdout.String(), stderr.String(), err
+}
+
+// cancelableUpgrader keeps the connection upgraded for the exec stream, so that the stream can be
+// closed when the command does not finish in time.
+type cancelableUpgrader struct {
+	httpstream.UpgradeRoundTripper
+
+	lock      sync.Mutex
+	conn      httpstream.Connection
+	cancelled bool
+}
+
+// NewConnection implements httpstream.UpgradeRoundTripper. Connections upgraded after cancellation
+// are closed immediately.
+func (self *cancelableUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	conn, err := self.UpgradeRoundTripper.NewConnection(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if self.cancelled {
+		conn.Close()
+		return nil, errors.New("exec was cancelled")
+	}
+	self.conn = conn
+	return conn, nil
+}
+
+// cancel closes the connection, which ends the stream.
+func (self *cancelableUpgrader) cancel() {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	self.cancelled = true
+	if self.conn != nil {
+		self.conn.Close()
+	}
+}
+
+// lookupInPod runs nslookup in the pod selected by the spec and parses records from its output.
+func lookupInPod(client kubernetes.Interface, config *rest.Config, spec *DNSLookupSpec, name,
+	recordType string) (*DNSLookupResult, error) {
+	command := []string{"nslookup", name}
+	if recordType != RecordTypeA {
+		command = []string{"nslookup", "-type=" + recordType, name}
+	}
+
+	type execResult struct {
+		stdout, stderr string
+		err            error
+	}
+	done := make(chan execResult, 1)
+	cancel := make(chan struct{})
+
+	start := time.Now()
+	go func() {
+		stdout, stderr, err := execInPod(client, config, spec.PodNamespace, spec.Pod, spec.Container,
+			command, cancel)
+		done <- execResult{stdout, stderr, err}
+	}()
+
+	var output execResult
+	select {
+	case output = <-done:
+	case <-time.After(lookupTimeout):
+		// Closing the stream ends the exec goroutine, output written so far is discarded.
+		close(cancel)
+		output.err = fmt.Errorf("nslookup did not finish in %s", lookupTimeout)
+	}
+
+	result := &DNSLookupResult{
+		Name:           name,
+		Type:           recordType,
+		Resolver:       ResolverPod,
+		DurationMillis: float64(time.Since(start)/time.Microsecond) / 1000,
+		Output:         strings.TrimSpace(output.stdout + output.stderr),
+	}
+
+	var parseErr string
+	result.Records, parseErr = parseNslookupOutput(output.stdout + output.stderr)
+	result.Records = filterAddressRecords(result.Records, getAddressNetwork(spec))
+	switch {
+	case len(parseErr) > 0:
+		result.Error = parseErr
+	case output.err != nil && len(result.Records) == 0:
+		// Command was not started or timed out, e.g. because nslookup is not available in the container.
+		if len(result.Output) == 0 {
+			return nil, output.err
+		}
+		result.Error = output.err.Error()
+	}
+	sortRecords(result.Records)
+	return result, nil
+}
+
+// parseNslookupOutput returns records found in output of busybox or bind nslookup and the error
+// reported by nslookup, if any. Addresses of the DNS server are skipped.
+func parseNslookupOutput(output string) ([]DNSRecord, string) {
+	records := make([]DNSRecord, 0)
+	errors := make([]string, 0)
+	answer := false
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "** ") || strings.HasPrefix(line, ";; "):
+			errors = append(errors, strings.TrimSpace(strings.TrimLeft(line, "*;")))
+		case strings.HasPrefix(line, "Name:"):
+			answer = true
+		case answer && strings.HasPrefix(line, "Address"):
+			// "Address: 10.0.0.1" or "Address 1: 10.0.0.1 name".
+			parts := strings.SplitN(line, ":", 2)
+			if len(parts) == 2 {
+				if fields := strings.Fields(parts[1]); len(fields) > 0 {
+					if ip := net.ParseIP(fields[0]); ip != nil {
+						records = append(records, toAddressRecord(ip))
+					}
+				}
+			}
+		case strings.Contains(line, "canonical name = "):
+			records = append(records, DNSRecord{Type: RecordTypeCNAME, Value: valueAfter(line, "canonical name = ")})
+		case strings.Contains(line, "service = "):
+			fields := strings.Fields(valueAfter(line, "service = "))
+			if len(fields) == 4 {
+				priority, _ := strconv.ParseUint(fields[0], 10, 16)
+				weight, _ := strconv.ParseUint(fields[1], 10, 16)
+				port, _ := strconv.ParseUint(fields[2], 10, 16)
+				records = append(records, DNSRecord{Type: RecordTypeSRV, Value: strings.TrimSuffix(fields[3], "."),
+					Priority: uint16(priority), Weight: uint16(weight), Port: uint16(port)})
+			}
+		case strings.Contains(line, "name = "):
+			records = append(records, DNSRecord{Type: RecordTypePTR, Value: valueAfter(line, "name = ")})
+		}
+	}
+
+	return records, strings.Join(errors, "; ")
+}
+
+func valueAfter(line, separator string) string {
+	return strings.TrimSuffix(strings.TrimSpace(line[strings.Index(line, separator)+len(separator):]), ".")
+}
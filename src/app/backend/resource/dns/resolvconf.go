@@ -0,0 +1,58 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// resolvConfPath is the path of resolver configuration of the Dashboard pod.
+var resolvConfPath = "/etc/resolv.conf"
+
+// ResolvConf is resolver configuration of a pod.
+type ResolvConf struct {
+	Nameservers []string `json:"nameservers"`
+	Search      []string `json:"search"`
+	Options     []string `json:"options"`
+}
+
+func readResolvConf() (*ResolvConf, error) {
+	file, err := os.Open(resolvConfPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result := &ResolvConf{Nameservers: []string{}, Search: []string{}, Options: []string{}}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], ";") {
+			continue
+		}
+
+		switch fields[0] {
+		case "nameserver":
+			result.Nameservers = append(result.Nameservers, fields[1])
+		case "search", "domain":
+			result.Search = append(result.Search, fields[1:]...)
+		case "options":
+			result.Options = append(result.Options, fields[1:]...)
+		}
+	}
+	return result, scanner.Err()
+}
@@ -0,0 +1,275 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// AggregatedEventList contains events of an object and all objects owned by it, e.g. replica sets
+// and pods of a deployment. Events with the same reason are merged into one entry.
+type AggregatedEventList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	// Aggregated events sorted by the time they were last seen, most recent first.
+	Events []AggregatedEvent `json:"events"`
+}
+
+// AggregatedEvent merges events with the same reason and type reported for objects of one kind.
+type AggregatedEvent struct {
+	// Short, machine understandable string that gives the reason for the events.
+	Reason string `json:"reason"`
+
+	// Event type (at the moment only normal and warning are supported).
+	Type string `json:"type"`
+
+	// Message of the most recent event.
+	Message string `json:"message"`
+
+	// Kind of the objects that the events were reported for.
+	ObjectKind string `json:"objectKind"`
+
+	// Names of the objects that the events were reported for.
+	ObjectNames []string `json:"objectNames"`
+
+	// The number of times the events have occurred.
+	Count int32 `json:"count"`
+
+	// The time at which the first event was recorded.
+	FirstSeen metaV1.Time `json:"firstSeen"`
+
+	// The time at which the most recent event was recorded.
+	LastSeen metaV1.Time `json:"lastSeen"`
+}
+
+// GetAggregatedEvents returns events of the object with given kind and name together with events
+// of all objects it owns, directly or through other objects.
+func GetAggregatedEvents(client client.Interface, namespace, kind, name string) (*AggregatedEventList, error) {
+	root, err := getOwnerRoot(client, namespace, kind, name)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := &common.ResourceChannels{
+		ReplicaSetList: common.GetReplicaSetListChannel(client, common.NewSameNamespaceQuery(namespace), 1),
+		JobList:        common.GetJobListChannel(client, common.NewSameNamespaceQuery(namespace), 1),
+		PodList:        common.GetPodListChannel(client, common.NewSameNamespaceQuery(namespace), 1),
+		EventList:      common.GetEventListChannel(client, common.NewSameNamespaceQuery(namespace), 1),
+	}
+
+	replicaSets := <-channels.ReplicaSetList.List
+	if err := <-channels.ReplicaSetList.Error; err != nil {
+		return nil, err
+	}
+
+	jobs := <-channels.JobList.List
+	if err := <-channels.JobList.Error; err != nil {
+		return nil, err
+	}
+
+	pods := <-channels.PodList.List
+	if err := <-channels.PodList.Error; err != nil {
+		return nil, err
+	}
+
+	eventList := <-channels.EventList.List
+	if err := <-channels.EventList.Error; err != nil {
+		return nil, err
+	}
+
+	objects := []metaV1.ObjectMeta{}
+	for _, replicaSet := range replicaSets.Items {
+		objects = append(objects, replicaSet.ObjectMeta)
+	}
+	for _, job := range jobs.Items {
+		objects = append(objects, job.ObjectMeta)
+	}
+	for _, pod := range pods.Items {
+		objects = append(objects, pod.ObjectMeta)
+	}
+
+	events := filterEventsByUID(eventList.Items, getOwnedUIDs(root, objects))
+	if !IsTypeFilled(events) {
+		events = FillEventsType(events)
+	}
+
+	return aggregateEvents(events), nil
+}
+
+// getOwnerRoot returns metadata of the object that events are aggregated for.
+func getOwnerRoot(client client.Interface, namespace, kind, name string) (*metaV1.ObjectMeta, error) {
+	var meta metaV1.ObjectMeta
+
+	switch kind {
+	case api.ResourceKindDeployment:
+		deployment, err := client.ExtensionsV1beta1().Deployments(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		meta = deployment.ObjectMeta
+	case api.ResourceKindReplicaSet:
+		replicaSet, err := client.ExtensionsV1beta1().ReplicaSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		meta = replicaSet.ObjectMeta
+	case api.ResourceKindReplicationController:
+		rc, err := client.CoreV1().ReplicationControllers(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		meta = rc.ObjectMeta
+	case api.ResourceKindDaemonSet:
+		daemonSet, err := client.ExtensionsV1beta1().DaemonSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		meta = daemonSet.ObjectMeta
+	case api.ResourceKindStatefulSet:
+		statefulSet, err := client.AppsV1beta1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		meta = statefulSet.ObjectMeta
+	case api.ResourceKindCronJob:
+		cronJob, err := client.BatchV2alpha1().CronJobs(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		meta = cronJob.ObjectMeta
+	case api.ResourceKindJob:
+		job, err := client.BatchV1().Jobs(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		meta = job.ObjectMeta
+	case api.ResourceKindPod:
+		pod, err := client.CoreV1().Pods(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		meta = pod.ObjectMeta
+	default:
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("events can not be aggregated for %s", kind))
+	}
+
+	return &meta, nil
+}
+
+// getOwnedUIDs returns UIDs of the root and of all objects that are owned by it, directly or
+// through other objects.
+func getOwnedUIDs(root *metaV1.ObjectMeta, objects []metaV1.ObjectMeta) map[types.UID]bool {
+	children := make(map[types.UID][]types.UID)
+	for _, object := range objects {
+		for _, ref := range object.OwnerReferences {
+			children[ref.UID] = append(children[ref.UID], object.UID)
+		}
+	}
+
+	uids := map[types.UID]bool{root.UID: true}
+	queue := []types.UID{root.UID}
+	for len(queue) > 0 {
+		uid := queue[0]
+		queue = queue[1:]
+		for _, child := range children[uid] {
+			if !uids[child] {
+				uids[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	return uids
+}
+
+// filterEventsByUID returns events involving one of the objects with given UIDs.
+func filterEventsByUID(events []v1.Event, uids map[types.UID]bool) []v1.Event {
+	result := make([]v1.Event, 0)
+	for _, event := range events {
+		if uids[event.InvolvedObject.UID] {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// aggregateEvents merges events with the same reason and type reported for objects of the same
+// kind and sorts the result by the time the events were last seen.
+func aggregateEvents(events []v1.Event) *AggregatedEventList {
+	aggregated := make(map[string]*AggregatedEvent)
+	keys := make([]string, 0)
+
+	for _, event := range events {
+		key := event.InvolvedObject.Kind + "/" + event.Reason + "/" + event.Type
+		entry, ok := aggregated[key]
+		if !ok {
+			entry = &AggregatedEvent{
+				Reason:     event.Reason,
+				Type:       event.Type,
+				Message:    event.Message,
+				ObjectKind: event.InvolvedObject.Kind,
+				FirstSeen:  event.FirstTimestamp,
+				LastSeen:   event.LastTimestamp,
+			}
+			aggregated[key] = entry
+			keys = append(keys, key)
+		}
+
+		entry.Count += event.Count
+		if !containsString(entry.ObjectNames, event.InvolvedObject.Name) {
+			entry.ObjectNames = append(entry.ObjectNames, event.InvolvedObject.Name)
+		}
+		if event.FirstTimestamp.Before(entry.FirstSeen) {
+			entry.FirstSeen = event.FirstTimestamp
+		}
+		if entry.LastSeen.Before(event.LastTimestamp) {
+			entry.LastSeen = event.LastTimestamp
+			entry.Message = event.Message
+		}
+	}
+
+	result := &AggregatedEventList{
+		ListMeta: api.ListMeta{TotalItems: len(keys)},
+		Events:   make([]AggregatedEvent, 0, len(keys)),
+	}
+	for _, key := range keys {
+		sort.Strings(aggregated[key].ObjectNames)
+		result.Events = append(result.Events, *aggregated[key])
+	}
+
+	sort.SliceStable(result.Events, func(i, j int) bool {
+		return result.Events[j].LastSeen.Before(result.Events[i].LastSeen)
+	})
+
+	return result
+}
+
+func containsString(slice []string, value string) bool {
+	for _, item := range slice {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func newOwnedMeta(name string, uid, owner types.UID) metaV1.ObjectMeta {
+	meta := metaV1.ObjectMeta{Name: name, Namespace: "ns-1", UID: uid}
+	if owner != "" {
+		meta.OwnerReferences = []metaV1.OwnerReference{{UID: owner}}
+	}
+	return meta
+}
+
+func newTestEvent(name, kind, object string, uid types.UID, reason, message string, count int32,
+	first, last time.Time) *v1.Event {
+	return &v1.Event{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "ns-1"},
+		InvolvedObject: v1.ObjectReference{
+			Kind: kind, Name: object, Namespace: "ns-1", UID: uid,
+		},
+		Reason:         reason,
+		Message:        message,
+		Count:          count,
+		Type:           v1.EventTypeNormal,
+		FirstTimestamp: metaV1.NewTime(first),
+		LastTimestamp:  metaV1.NewTime(last),
+	}
+}
+
+func TestGetAggregatedEvents(t *testing.T) {
+	t0 := time.Date(2017, 5, 1, 10, 0, 0, 0, time.UTC)
+	minute := func(m int) time.Time { return t0.Add(time.Duration(m) * time.Minute) }
+
+	fakeClient := fake.NewSimpleClientset(
+		&extensions.Deployment{ObjectMeta: newOwnedMeta("web", "d-1", "")},
+		&extensions.ReplicaSet{ObjectMeta: newOwnedMeta("web-1", "rs-1", "d-1")},
+		&v1.Pod{ObjectMeta: newOwnedMeta("web-1-a", "p-1", "rs-1")},
+		&v1.Pod{ObjectMeta: newOwnedMeta("web-1-b", "p-2", "rs-1")},
+		&v1.Pod{ObjectMeta: newOwnedMeta("other", "p-3", "")},
+		newTestEvent("e-1", "Deployment", "web", "d-1", "ScalingReplicaSet",
+			"Scaled up replica set web-1 to 2", 1, minute(0), minute(0)),
+		newTestEvent("e-2", "ReplicaSet", "web-1", "rs-1", "SuccessfulCreate",
+			"Created pod: web-1-a", 2, minute(1), minute(2)),
+		newTestEvent("e-3", "Pod", "web-1-a", "p-1", "Pulled", "Image pulled", 1, minute(3), minute(3)),
+		newTestEvent("e-4", "Pod", "web-1-b", "p-2", "Pulled", "Image pulled again", 1, minute(2),
+			minute(4)),
+		newTestEvent("e-5", "Pod", "other", "p-3", "Pulled", "Image pulled", 1, minute(5), minute(5)),
+	)
+
+	actual, err := GetAggregatedEvents(fakeClient, "ns-1", api.ResourceKindDeployment, "web")
+	if err != nil {
+		t.Fatalf("GetAggregatedEvents() returns unexpected error: %v", err)
+	}
+
+	expected := &AggregatedEventList{
+		ListMeta: api.ListMeta{TotalItems: 3},
+		Events: []AggregatedEvent{
+			{
+				Reason: "Pulled", Type: v1.EventTypeNormal, Message: "Image pulled again",
+				ObjectKind: "Pod", ObjectNames: []string{"web-1-a", "web-1-b"}, Count: 2,
+				FirstSeen: metaV1.NewTime(minute(2)), LastSeen: metaV1.NewTime(minute(4)),
+			},
+			{
+				Reason: "SuccessfulCreate", Type: v1.EventTypeNormal, Message: "Created pod: web-1-a",
+				ObjectKind: "ReplicaSet", ObjectNames: []string{"web-1"}, Count: 2,
+				FirstSeen: metaV1.NewTime(minute(1)), LastSeen: metaV1.NewTime(minute(2)),
+			},
+			{
+				Reason: "ScalingReplicaSet", Type: v1.EventTypeNormal,
+				Message: "Scaled up replica set web-1 to 2", ObjectKind: "Deployment",
+				ObjectNames: []string{"web"}, Count: 1,
+				FirstSeen: metaV1.NewTime(minute(0)), LastSeen: metaV1.NewTime(minute(0)),
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetAggregatedEvents() ==\n%#v\nexpected\n%#v", actual, expected)
+	}
+}
+
+func TestGetAggregatedEventsUnsupportedKind(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset()
+	if _, err := GetAggregatedEvents(fakeClient, "ns-1", api.ResourceKindSecret, "s"); err == nil {
+		t.Error("GetAggregatedEvents() expected error for unsupported kind")
+	}
+}
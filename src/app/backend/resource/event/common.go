@@ -207,13 +207,13 @@ func ToEvent(event v1.Event) common.Event {
 // CreateEventList converts array of api events to common EventList structure
 func CreateEventList(events []v1.Event, dsQuery *dataselect.DataSelectQuery) common.EventList {
 
+	eventCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(events), dsQuery)
+	events = fromCells(eventCells)
 	eventList := common.EventList{
 		Events:   make([]common.Event, 0),
-		ListMeta: api.ListMeta{TotalItems: len(events)},
+		ListMeta: api.ListMeta{TotalItems: filteredTotal},
 	}
 
-	events = fromCells(dataselect.GenericDataSelect(toCells(events), dsQuery))
-
 	for _, event := range events {
 		eventDetail := ToEvent(event)
 		eventList.Events = append(eventList.Events, eventDetail)
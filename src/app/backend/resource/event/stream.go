@@ -0,0 +1,143 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// EventStreamFilter selects events sent by the cluster event stream. Empty fields match all
+// events.
+type EventStreamFilter struct {
+	// Type of events, e.g. Warning.
+	Type string `json:"type"`
+
+	// Kind of the objects involved in events, e.g. Pod. Case insensitive.
+	Kind string `json:"kind"`
+
+	// Reason of events, e.g. FailedScheduling.
+	Reason string `json:"reason"`
+}
+
+// Matches returns true if the event passes the filter.
+func (self EventStreamFilter) Matches(event *v1.Event) bool {
+	return (self.Type == "" || self.Type == event.Type) &&
+		(self.Kind == "" || strings.EqualFold(self.Kind, event.InvolvedObject.Kind)) &&
+		(self.Reason == "" || self.Reason == event.Reason)
+}
+
+// EventRate is the number of occurrences of events with the same type and reason reported for
+// objects of the same kind during a time window.
+type EventRate struct {
+	Type       string `json:"type"`
+	ObjectKind string `json:"objectKind"`
+	Reason     string `json:"reason"`
+	Count      int32  `json:"count"`
+}
+
+// EventRates are event rates counted during a time window.
+type EventRates struct {
+	// Length of the time window in seconds.
+	WindowSeconds int64 `json:"windowSeconds"`
+
+	// Rates sorted by count, highest first.
+	Rates []EventRate `json:"rates"`
+}
+
+// EventRateCounter counts occurrences of streamed events. Events are updated by the API server
+// when they occur again, so occurrences are computed from increments of their count.
+type EventRateCounter struct {
+	sync.Mutex
+	windowStart time.Time
+	counts      map[EventRate]int32
+	lastCounts  map[types.UID]int32
+}
+
+// NewEventRateCounter creates counter with the first window starting at given time.
+func NewEventRateCounter(now time.Time) *EventRateCounter {
+	return &EventRateCounter{
+		windowStart: now,
+		counts:      make(map[EventRate]int32),
+		lastCounts:  make(map[types.UID]int32),
+	}
+}
+
+// Add records occurrences of the event since it was last seen. Events seen for the first time
+// count only if they occurred in the current window, so that existing events sent when the
+// stream starts do not distort rates.
+func (self *EventRateCounter) Add(event *v1.Event) {
+	self.Lock()
+	defer self.Unlock()
+
+	count := event.Count
+	if count == 0 {
+		count = 1
+	}
+
+	last, seen := self.lastCounts[event.UID]
+	self.lastCounts[event.UID] = count
+	if !seen {
+		if event.LastTimestamp.Time.Before(self.windowStart) {
+			return
+		}
+		last = 0
+	}
+
+	if count > last {
+		key := EventRate{Type: event.Type, ObjectKind: event.InvolvedObject.Kind, Reason: event.Reason}
+		self.counts[key] += count - last
+	}
+}
+
+// Remove forgets a deleted event.
+func (self *EventRateCounter) Remove(event *v1.Event) {
+	self.Lock()
+	defer self.Unlock()
+	delete(self.lastCounts, event.UID)
+}
+
+// Flush returns rates counted since the previous flush and starts a new window.
+func (self *EventRateCounter) Flush(now time.Time) EventRates {
+	self.Lock()
+	defer self.Unlock()
+
+	result := EventRates{
+		WindowSeconds: int64(now.Sub(self.windowStart) / time.Second),
+		Rates:         make([]EventRate, 0, len(self.counts)),
+	}
+	for rate, count := range self.counts {
+		rate.Count = count
+		result.Rates = append(result.Rates, rate)
+	}
+	sort.Slice(result.Rates, func(i, j int) bool {
+		if result.Rates[i].Count != result.Rates[j].Count {
+			return result.Rates[i].Count > result.Rates[j].Count
+		}
+		if result.Rates[i].Reason != result.Rates[j].Reason {
+			return result.Rates[i].Reason < result.Rates[j].Reason
+		}
+		return result.Rates[i].ObjectKind+result.Rates[i].Type < result.Rates[j].ObjectKind+result.Rates[j].Type
+	})
+
+	self.windowStart = now
+	self.counts = make(map[EventRate]int32)
+	return result
+}
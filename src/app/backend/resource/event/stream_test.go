@@ -0,0 +1,96 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func TestEventStreamFilterMatches(t *testing.T) {
+	event := &v1.Event{
+		InvolvedObject: v1.ObjectReference{Kind: "Pod"},
+		Reason:         "FailedScheduling",
+		Type:           v1.EventTypeWarning,
+	}
+
+	cases := []struct {
+		filter   EventStreamFilter
+		expected bool
+	}{
+		{EventStreamFilter{}, true},
+		{EventStreamFilter{Type: v1.EventTypeWarning, Kind: "pod"}, true},
+		{EventStreamFilter{Reason: "FailedScheduling"}, true},
+		{EventStreamFilter{Type: v1.EventTypeNormal}, false},
+		{EventStreamFilter{Kind: "Node"}, false},
+		{EventStreamFilter{Reason: "BackOff"}, false},
+	}
+
+	for _, c := range cases {
+		if actual := c.filter.Matches(event); actual != c.expected {
+			t.Errorf("%#v.Matches() == %t, expected %t", c.filter, actual, c.expected)
+		}
+	}
+}
+
+func TestEventRateCounter(t *testing.T) {
+	start := time.Date(2017, 5, 1, 10, 0, 0, 0, time.UTC)
+	newEvent := func(uid types.UID, reason string, count int32, last time.Time) *v1.Event {
+		return &v1.Event{
+			ObjectMeta:     metaV1.ObjectMeta{UID: uid},
+			InvolvedObject: v1.ObjectReference{Kind: "Pod"},
+			Reason:         reason,
+			Type:           v1.EventTypeWarning,
+			Count:          count,
+			LastTimestamp:  metaV1.NewTime(last),
+		}
+	}
+
+	counter := NewEventRateCounter(start)
+	// Existing event sent when the stream starts, only its later occurrences are counted.
+	counter.Add(newEvent("e-1", "BackOff", 5, start.Add(-time.Minute)))
+	counter.Add(newEvent("e-1", "BackOff", 7, start.Add(time.Second)))
+	counter.Add(newEvent("e-2", "FailedScheduling", 1, start.Add(time.Second)))
+	counter.Add(newEvent("e-3", "BackOff", 1, start.Add(2*time.Second)))
+
+	actual := counter.Flush(start.Add(10 * time.Second))
+	expected := EventRates{
+		WindowSeconds: 10,
+		Rates: []EventRate{
+			{Type: v1.EventTypeWarning, ObjectKind: "Pod", Reason: "BackOff", Count: 3},
+			{Type: v1.EventTypeWarning, ObjectKind: "Pod", Reason: "FailedScheduling", Count: 1},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Flush() == %#v, expected %#v", actual, expected)
+	}
+
+	counter.Add(newEvent("e-2", "FailedScheduling", 3, start.Add(15*time.Second)))
+	actual = counter.Flush(start.Add(20 * time.Second))
+	expected = EventRates{
+		WindowSeconds: 10,
+		Rates: []EventRate{
+			{Type: v1.EventTypeWarning, ObjectKind: "Pod", Reason: "FailedScheduling", Count: 2},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Flush() == %#v, expected %#v", actual, expected)
+	}
+}
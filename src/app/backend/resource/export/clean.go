@@ -0,0 +1,122 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Metadata fields maintained by the server.
+var serverMetadataFields = []string{"uid", "resourceVersion", "managedFields", "generation", "selfLink",
+	"creationTimestamp", "deletionTimestamp", "deletionGracePeriodSeconds", "ownerReferences"}
+
+// Annotations written by clients and controllers, that describe state of the exported cluster.
+var serverAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"deployment.kubernetes.io/revision",
+	"pv.kubernetes.io/bind-completed",
+	"pv.kubernetes.io/bound-by-controller",
+	"volume.beta.kubernetes.io/storage-provisioner",
+	"volume.kubernetes.io/storage-provisioner",
+}
+
+// serverSpecFields are spec fields assigned by the server, by kind. Applying them in another
+// cluster fails or binds the object to resources of the exported cluster.
+var serverSpecFields = map[string][]string{
+	"Service":               {"clusterIP", "clusterIPs", "healthCheckNodePort"},
+	"PersistentVolumeClaim": {"volumeName"},
+	"Pod":                   {"nodeName"},
+}
+
+// Clean returns content of the object without status and fields maintained by the server. The
+// object is not modified, but nested fields are shared with it.
+func Clean(obj *unstructured.Unstructured) map[string]interface{} {
+	content := make(map[string]interface{}, len(obj.Object))
+	for key, value := range obj.Object {
+		if key != "status" {
+			content[key] = value
+		}
+	}
+
+	if metadata, ok := obj.Object["metadata"].(map[string]interface{}); ok {
+		cleaned := copyMap(metadata)
+		for _, field := range serverMetadataFields {
+			delete(cleaned, field)
+		}
+		if annotations, ok := cleaned["annotations"].(map[string]interface{}); ok {
+			annotations = copyMap(annotations)
+			for _, annotation := range serverAnnotations {
+				delete(annotations, annotation)
+			}
+			if len(annotations) == 0 {
+				delete(cleaned, "annotations")
+			} else {
+				cleaned["annotations"] = annotations
+			}
+		}
+		content["metadata"] = cleaned
+	}
+
+	if spec, ok := obj.Object["spec"].(map[string]interface{}); ok {
+		cleaned := copyMap(spec)
+		// Headless services have to stay headless.
+		headless := spec["clusterIP"] == "None"
+		for _, field := range serverSpecFields[obj.GetKind()] {
+			if !(headless && (field == "clusterIP" || field == "clusterIPs")) {
+				delete(cleaned, field)
+			}
+		}
+		content["spec"] = cleaned
+	}
+
+	// Token secrets of service accounts are generated by the cluster.
+	if obj.GetKind() == "ServiceAccount" {
+		delete(content, "secrets")
+	}
+	return content
+}
+
+// isGenerated returns true for objects created by the cluster in every namespace, e.g. the default
+// service account and its token.
+func isGenerated(obj *unstructured.Unstructured) bool {
+	switch obj.GetKind() {
+	case "ServiceAccount":
+		return obj.GetName() == "default"
+	case "Secret":
+		secretType, _ := obj.Object["type"].(string)
+		return secretType == "kubernetes.io/service-account-token"
+	case "ConfigMap":
+		return obj.GetName() == "kube-root-ca.crt"
+	}
+	return false
+}
+
+// isControlled returns true when the object is managed by a controller, e.g. a pod of a replica set.
+func isControlled(obj *unstructured.Unstructured) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Controller != nil && *ref.Controller {
+			return true
+		}
+	}
+	return false
+}
+
+func copyMap(source map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(source))
+	for key, value := range source {
+		result[key] = value
+	}
+	return result
+}
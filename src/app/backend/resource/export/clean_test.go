@@ -0,0 +1,121 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestClean(t *testing.T) {
+	cases := []struct {
+		obj      map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata": map[string]interface{}{
+					"name": "web", "namespace": "prod", "uid": "123", "resourceVersion": "5",
+					"creationTimestamp": "2017-01-01T00:00:00Z", "managedFields": []interface{}{},
+					"annotations": map[string]interface{}{
+						"kubectl.kubernetes.io/last-applied-configuration": "{}",
+					},
+					"labels": map[string]interface{}{"app": "web"},
+				},
+				"spec": map[string]interface{}{
+					"clusterIP": "10.0.0.1", "clusterIPs": []interface{}{"10.0.0.1"}, "type": "ClusterIP",
+				},
+				"status": map[string]interface{}{"loadBalancer": map[string]interface{}{}},
+			},
+			map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata": map[string]interface{}{
+					"name": "web", "namespace": "prod", "labels": map[string]interface{}{"app": "web"},
+				},
+				"spec": map[string]interface{}{"type": "ClusterIP"},
+			},
+		},
+		{
+			map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata":   map[string]interface{}{"name": "db"},
+				"spec":       map[string]interface{}{"clusterIP": "None", "clusterIPs": []interface{}{"None"}},
+			},
+			map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata":   map[string]interface{}{"name": "db"},
+				"spec":       map[string]interface{}{"clusterIP": "None", "clusterIPs": []interface{}{"None"}},
+			},
+		},
+		{
+			map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ServiceAccount",
+				"metadata": map[string]interface{}{"name": "builder", "annotations": map[string]interface{}{
+					"owner": "ci", "deployment.kubernetes.io/revision": "2"}},
+				"secrets": []interface{}{map[string]interface{}{"name": "builder-token-abcde"}},
+			},
+			map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ServiceAccount",
+				"metadata": map[string]interface{}{"name": "builder",
+					"annotations": map[string]interface{}{"owner": "ci"}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		obj := &unstructured.Unstructured{Object: c.obj}
+		actual := Clean(obj)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Clean(%#v) == \ngot %#v, \nexpected %#v", c.obj, actual, c.expected)
+		}
+		if _, ok := c.obj["metadata"].(map[string]interface{})["name"]; !ok {
+			t.Errorf("Clean modified the object %#v", c.obj)
+		}
+	}
+}
+
+func TestIsGenerated(t *testing.T) {
+	cases := []struct {
+		obj      map[string]interface{}
+		expected bool
+	}{
+		{map[string]interface{}{"kind": "ServiceAccount", "metadata": map[string]interface{}{"name": "default"}},
+			true},
+		{map[string]interface{}{"kind": "ServiceAccount", "metadata": map[string]interface{}{"name": "app"}},
+			false},
+		{map[string]interface{}{"kind": "Secret", "type": "kubernetes.io/service-account-token",
+			"metadata": map[string]interface{}{"name": "default-token-abcde"}}, true},
+		{map[string]interface{}{"kind": "Secret", "type": "Opaque",
+			"metadata": map[string]interface{}{"name": "credentials"}}, false},
+		{map[string]interface{}{"kind": "ConfigMap", "metadata": map[string]interface{}{"name": "kube-root-ca.crt"}},
+			true},
+	}
+
+	for _, c := range cases {
+		actual := isGenerated(&unstructured.Unstructured{Object: c.obj})
+		if actual != c.expected {
+			t.Errorf("isGenerated(%#v) == %t, expected %t", c.obj, actual, c.expected)
+		}
+	}
+}
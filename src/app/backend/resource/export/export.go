@@ -0,0 +1,230 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package export serializes objects to a multi document YAML bundle, which can be checked in to
+// a repository and applied to another cluster.
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apiresource"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// ObjectReference identifies a single object to export.
+type ObjectReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+}
+
+// ExportSpec selects objects to export. Objects are exported together with all objects of the
+// kinds in the namespace.
+type ExportSpec struct {
+	Objects []ObjectReference `json:"objects"`
+
+	// Namespace and Kinds select all objects of the kinds in the namespace. Kinds are matched by
+	// kind or resource name, optionally followed by the group, e.g. "Deployment",
+	// "deployments.apps" or "Ingress.networking.k8s.io".
+	Namespace string   `json:"namespace"`
+	Kinds     []string `json:"kinds"`
+
+	// IncludeOwned exports objects selected by kinds, that are managed by a controller, e.g. pods
+	// of replica sets. They are recreated by their owners, so they are skipped by default.
+	IncludeOwned bool `json:"includeOwned"`
+}
+
+// Bundle is a multi document YAML file with exported objects.
+type Bundle struct {
+	FileName string
+	Content  []byte
+	// Number of exported objects.
+	Objects int
+}
+
+// Export returns objects selected by the spec as a YAML bundle without status and fields
+// maintained by the server.
+func Export(discoveryClient discovery.DiscoveryInterface, config *rest.Config, spec *ExportSpec) (*Bundle,
+	error) {
+	if len(spec.Objects) == 0 && len(spec.Kinds) == 0 {
+		return nil, errorsK8s.NewBadRequest("objects or kinds to export are required")
+	}
+	if len(spec.Kinds) > 0 && len(spec.Namespace) == 0 {
+		return nil, errorsK8s.NewBadRequest("namespace is required to export kinds")
+	}
+	logger.Infof("Exporting %d objects and %d kinds in %s namespace", len(spec.Objects), len(spec.Kinds),
+		spec.Namespace)
+
+	objects := make([]*unstructured.Unstructured, 0)
+	for _, ref := range spec.Objects {
+		obj, err := getObject(discoveryClient, config, ref)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
+	}
+
+	if len(spec.Kinds) > 0 {
+		items, err := ListObjects(discoveryClient, config, spec.Namespace, spec.Kinds, spec.IncludeOwned)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, items...)
+	}
+
+	content, err := toYAMLBundle(objects)
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := "export.yaml"
+	if len(spec.Namespace) > 0 {
+		fileName = spec.Namespace + ".yaml"
+	}
+	return &Bundle{FileName: fileName, Content: content, Objects: len(objects)}, nil
+}
+
+func getObject(discoveryClient discovery.DiscoveryInterface, config *rest.Config, ref ObjectReference) (
+	*unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, errorsK8s.NewBadRequest(err.Error())
+	}
+	resource, err := apiresource.FindAPIResourceByKind(discoveryClient, gv.Group, gv.Version, ref.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := apply.NewRESTClient(config, gv)
+	if err != nil {
+		return nil, err
+	}
+
+	req := client.Get().Resource(resource.Name).Name(ref.Name)
+	if resource.Namespaced {
+		req = req.Namespace(ref.Namespace)
+	}
+	obj := new(unstructured.Unstructured)
+	if err := req.Do().Into(obj); err != nil {
+		return nil, err
+	}
+	obj.SetAPIVersion(resource.GroupVersion)
+	obj.SetKind(resource.Kind)
+	return obj, nil
+}
+
+// ListObjects returns objects of the kinds in the namespace in the order of kinds. Objects created by
+// the cluster in every namespace are skipped, as well as objects managed by controllers, unless
+// includeOwned is set. See ExportSpec for the format of kinds.
+func ListObjects(discoveryClient discovery.DiscoveryInterface, config *rest.Config, namespace string,
+	kinds []string, includeOwned bool) ([]*unstructured.Unstructured, error) {
+	objects := make([]*unstructured.Unstructured, 0)
+	for _, kind := range kinds {
+		resource, err := findKind(discoveryClient, kind)
+		if err != nil {
+			return nil, err
+		}
+		items, err := listObjects(config, resource, namespace)
+		if err != nil {
+			return nil, err
+		}
+		for i := range items {
+			if !isGenerated(&items[i]) && (includeOwned || !isControlled(&items[i])) {
+				objects = append(objects, &items[i])
+			}
+		}
+	}
+	return objects, nil
+}
+
+// findKind returns namespaced, listable resource in the preferred version of its group. Kinds
+// without a group are looked up in the core group first and then in other groups in alphabetical
+// order, e.g. "Deployment" is exported from the apps group rather than the extensions group.
+func findKind(discoveryClient discovery.DiscoveryInterface, kind string) (*apiresource.APIResource, error) {
+	namespaced := true
+	list, err := apiresource.GetAPIResourceList(discoveryClient, &apiresource.APIResourceQuery{
+		Namespaced: &namespaced,
+		Verbs:      []string{"list"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	name, group := kind, ""
+	if parts := strings.SplitN(kind, ".", 2); len(parts) == 2 {
+		name, group = parts[0], parts[1]
+	}
+	for i := range list.Resources {
+		resource := &list.Resources[i]
+		if !strings.EqualFold(resource.Kind, name) && !strings.EqualFold(resource.Name, name) {
+			continue
+		}
+		if len(group) == 0 || resource.Group == group {
+			return resource, nil
+		}
+	}
+	return nil, errorsK8s.NewBadRequest(fmt.Sprintf("kind %s is not served by the cluster", kind))
+}
+
+func listObjects(config *rest.Config, resource *apiresource.APIResource, namespace string) (
+	[]unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(resource.GroupVersion)
+	if err != nil {
+		return nil, err
+	}
+	client, err := apply.NewRESTClient(config, gv)
+	if err != nil {
+		return nil, err
+	}
+
+	list := new(unstructured.UnstructuredList)
+	if err := client.Get().Namespace(namespace).Resource(resource.Name).Do().Into(list); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(list.Items, func(i, j int) bool { return list.Items[i].GetName() < list.Items[j].GetName() })
+	for i := range list.Items {
+		list.Items[i].SetAPIVersion(resource.GroupVersion)
+		list.Items[i].SetKind(resource.Kind)
+	}
+	return list.Items, nil
+}
+
+// toYAMLBundle serializes cleaned objects to YAML documents separated by "---".
+func toYAMLBundle(objects []*unstructured.Unstructured) ([]byte, error) {
+	var buffer bytes.Buffer
+	for i, obj := range objects {
+		out, err := yaml.Marshal(Clean(obj))
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buffer.WriteString("---\n")
+		}
+		buffer.Write(out)
+	}
+	return buffer.Bytes(), nil
+}
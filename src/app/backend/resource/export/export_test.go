@@ -0,0 +1,113 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// apiServerResponses maps request paths to responses of the fake apiserver.
+var apiServerResponses = map[string]string{
+	"/api": `{"kind": "APIVersions", "versions": ["v1"]}`,
+	"/apis": `{"kind": "APIGroupList", "groups": [{"name": "apps",
+		"versions": [{"groupVersion": "apps/v1", "version": "v1"}],
+		"preferredVersion": {"groupVersion": "apps/v1", "version": "v1"}}]}`,
+	"/api/v1": `{"kind": "APIResourceList", "groupVersion": "v1", "resources": [
+		{"name": "configmaps", "namespaced": true, "kind": "ConfigMap", "verbs": ["get", "list"]},
+		{"name": "pods", "namespaced": true, "kind": "Pod", "verbs": ["get", "list"]}]}`,
+	"/apis/apps/v1": `{"kind": "APIResourceList", "groupVersion": "apps/v1", "resources": [
+		{"name": "deployments", "namespaced": true, "kind": "Deployment", "verbs": ["get", "list"]}]}`,
+	"/apis/apps/v1/namespaces/prod/deployments/web": `{"kind": "Deployment", "apiVersion": "apps/v1",
+		"metadata": {"name": "web", "namespace": "prod", "uid": "1", "generation": 3},
+		"spec": {"replicas": 2}, "status": {"replicas": 2}}`,
+	"/api/v1/namespaces/prod/configmaps": `{"kind": "ConfigMapList", "apiVersion": "v1", "items": [
+		{"metadata": {"name": "settings", "namespace": "prod", "resourceVersion": "7"}, "data": {"a": "b"}},
+		{"metadata": {"name": "kube-root-ca.crt", "namespace": "prod"}}]}`,
+	"/api/v1/namespaces/prod/pods": `{"kind": "PodList", "apiVersion": "v1", "items": [
+		{"metadata": {"name": "web-1", "namespace": "prod", "ownerReferences": [{"apiVersion": "apps/v1",
+			"kind": "ReplicaSet", "name": "web", "uid": "2", "controller": true}]},
+			"spec": {"nodeName": "node-1"}},
+		{"metadata": {"name": "debug", "namespace": "prod"}, "spec": {"nodeName": "node-1"}}]}`,
+}
+
+func newClients() (*httptest.Server, discovery.DiscoveryInterface, *rest.Config) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		response, ok := apiServerResponses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound",
+				"code": 404}`))
+			return
+		}
+		w.Write([]byte(response))
+	}))
+	config := &rest.Config{Host: server.URL}
+	return server, discovery.NewDiscoveryClientForConfigOrDie(config), config
+}
+
+func TestExport(t *testing.T) {
+	server, discoveryClient, config := newClients()
+	defer server.Close()
+
+	cases := []struct {
+		spec     *ExportSpec
+		expected string
+		fileName string
+	}{
+		{
+			&ExportSpec{Objects: []ObjectReference{
+				{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "prod", Name: "web"}}},
+			"apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n  namespace: prod\n" +
+				"spec:\n  replicas: 2\n",
+			"export.yaml",
+		},
+		{
+			&ExportSpec{Namespace: "prod", Kinds: []string{"configmaps", "Pod"}},
+			"apiVersion: v1\ndata:\n  a: b\nkind: ConfigMap\nmetadata:\n  name: settings\n  namespace: prod\n" +
+				"---\napiVersion: v1\nkind: Pod\nmetadata:\n  name: debug\n  namespace: prod\nspec: {}\n",
+			"prod.yaml",
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := Export(discoveryClient, config, c.spec)
+		if err != nil {
+			t.Fatalf("Export(%#v) returned error: %v", c.spec, err)
+		}
+		if string(actual.Content) != c.expected || actual.FileName != c.fileName {
+			t.Errorf("Export(%#v) == \ngot %s (%s), \nexpected %s (%s)", c.spec, actual.Content, actual.FileName,
+				c.expected, c.fileName)
+		}
+	}
+
+	invalid := []*ExportSpec{
+		{},
+		{Kinds: []string{"Pod"}},
+		{Namespace: "prod", Kinds: []string{"Widget"}},
+		{Namespace: "prod", Kinds: []string{"Pod.apps"}},
+		{Objects: []ObjectReference{{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "prod", Name: "db"}}},
+	}
+	for _, spec := range invalid {
+		if _, err := Export(discoveryClient, config, spec); err == nil {
+			t.Errorf("Expected error when exporting %#v", spec)
+		}
+	}
+}
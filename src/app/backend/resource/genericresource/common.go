@@ -0,0 +1,155 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package genericresource serves objects of any resource discovered in the cluster, including the
+// ones Dashboard has no dedicated views for.
+package genericresource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apiresource"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// CoreGroup is used in paths instead of the empty name of the core API group.
+const CoreGroup = "core"
+
+// ResourceRef identifies resource, whose objects are served.
+type ResourceRef struct {
+	Group    string
+	Version  string
+	Resource string
+}
+
+func (self ResourceRef) groupVersion() schema.GroupVersion {
+	if self.Group == CoreGroup {
+		return schema.GroupVersion{Version: self.Version}
+	}
+	return schema.GroupVersion{Group: self.Group, Version: self.Version}
+}
+
+// resourceClient is a REST client for objects of a discovered resource.
+type resourceClient struct {
+	client   *rest.RESTClient
+	resource *apiresource.APIResource
+}
+
+// newResourceClient finds the resource in discovered resources and creates REST client for it.
+// Verb has to be supported by the resource. Secrets are rejected, because their values are served
+// only masked by the secret endpoints or by the audited reveal endpoint.
+func newResourceClient(discoveryClient discovery.DiscoveryInterface, config *rest.Config, ref ResourceRef,
+	verb string) (*resourceClient, error) {
+	gv := ref.groupVersion()
+	if gv.Group == "" && ref.Resource == "secrets" {
+		return nil, errorsK8s.NewForbidden(schema.GroupResource{Resource: ref.Resource}, "",
+			fmt.Errorf("secrets are served by the secret endpoints only"))
+	}
+	resource, err := apiresource.FindAPIResource(discoveryClient, gv.Group, gv.Version, ref.Resource)
+	if err != nil {
+		return nil, err
+	}
+	if !contains(resource.Verbs, verb) {
+		return nil, errorsK8s.NewMethodNotSupported(schema.GroupResource{Group: gv.Group, Resource: ref.Resource},
+			verb)
+	}
+
+	client, err := apply.NewRESTClient(config, gv)
+	if err != nil {
+		return nil, err
+	}
+	return &resourceClient{client: client, resource: resource}, nil
+}
+
+// request creates request for objects in the namespace. Namespace is ignored for cluster scoped
+// resources.
+func (self *resourceClient) request(method, namespace string) *rest.Request {
+	req := self.client.Verb(method).Resource(self.resource.Name)
+	if self.resource.Namespaced {
+		req = req.Namespace(namespace)
+	}
+	return req
+}
+
+func (self *resourceClient) typeMeta() api.TypeMeta {
+	return api.NewTypeMeta(api.ResourceKind(strings.ToLower(self.resource.Kind)))
+}
+
+func toObjectMeta(obj *unstructured.Unstructured) api.ObjectMeta {
+	return api.ObjectMeta{
+		Name:              obj.GetName(),
+		Namespace:         obj.GetNamespace(),
+		Labels:            obj.GetLabels(),
+		Annotations:       obj.GetAnnotations(),
+		CreationTimestamp: obj.GetCreationTimestamp(),
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func describe(resource *apiresource.APIResource, namespace, name string) string {
+	if resource.Namespaced {
+		return fmt.Sprintf("%s %s in %s namespace", name, resource.Name, namespace)
+	}
+	return fmt.Sprintf("%s %s", name, resource.Name)
+}
+
+// The code below allows to perform complex data section on []GenericResourceObject
+
+type GenericResourceObjectCell GenericResourceObject
+
+func (self GenericResourceObjectCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []GenericResourceObject) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = GenericResourceObjectCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []GenericResourceObject {
+	std := make([]GenericResourceObject, len(cells))
+	for i := range std {
+		std[i] = GenericResourceObject(cells[i].(GenericResourceObjectCell))
+	}
+	return std
+}
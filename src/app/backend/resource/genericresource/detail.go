@@ -0,0 +1,159 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericresource
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apiresource"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gitops"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// GenericResourceDetail contains an object of any resource. Its structure is not known upfront,
+// so the whole object is returned.
+type GenericResourceDetail struct {
+	ObjectMeta api.ObjectMeta          `json:"objectMeta"`
+	TypeMeta   api.TypeMeta            `json:"typeMeta"`
+	Resource   apiresource.APIResource `json:"resource"`
+
+	// Whole object, used by the YAML editor.
+	Object map[string]interface{} `json:"object"`
+
+	// GitOps tool managing the object. Set only when it is synced by Argo CD or Flux.
+	GitOps *gitops.Ownership `json:"gitops,omitempty"`
+}
+
+// GenericResourceSpec is a specification of an object to update.
+type GenericResourceSpec struct {
+	// YAML or JSON content of the object.
+	Content string `json:"content"`
+}
+
+// GetGenericResourceDetail returns object of the resource. Namespace is ignored for cluster scoped
+// resources.
+func GetGenericResourceDetail(discoveryClient discovery.DiscoveryInterface, config *rest.Config, ref ResourceRef,
+	namespace, name string) (*GenericResourceDetail, error) {
+	client, err := newResourceClient(discoveryClient, config, ref, "get")
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Getting details of %s", describe(client.resource, namespace, name))
+
+	obj := new(unstructured.Unstructured)
+	if err := client.request("GET", namespace).Name(name).Do().Into(obj); err != nil {
+		return nil, err
+	}
+	return client.toDetail(obj), nil
+}
+
+// UpdateGenericResource replaces object of the resource with YAML or JSON content. Content has to
+// carry resource version of the edited object, so that concurrent modifications are rejected with
+// a conflict.
+func UpdateGenericResource(discoveryClient discovery.DiscoveryInterface, config *rest.Config, ref ResourceRef,
+	namespace, name string, spec *GenericResourceSpec) (*GenericResourceDetail, error) {
+	client, err := newResourceClient(discoveryClient, config, ref, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := client.parseObject(spec.Content)
+	if err != nil {
+		return nil, err
+	}
+	if !client.resource.Namespaced {
+		namespace = ""
+	}
+	if obj.GetName() != name || (len(obj.GetNamespace()) > 0 && obj.GetNamespace() != namespace) {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf(
+			"object name and namespace have to match %s/%s", namespace, name))
+	}
+	obj.SetNamespace(namespace)
+
+	raw, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Updating %s", describe(client.resource, namespace, name))
+	updated := new(unstructured.Unstructured)
+	if err := client.request("PUT", namespace).Name(name).Body(raw).Do().Into(updated); err != nil {
+		return nil, err
+	}
+	return client.toDetail(updated), nil
+}
+
+// DeleteGenericResource deletes object of the resource. Dependent objects are deleted in the
+// background.
+func DeleteGenericResource(discoveryClient discovery.DiscoveryInterface, config *rest.Config, ref ResourceRef,
+	namespace, name string) error {
+	client, err := newResourceClient(discoveryClient, config, ref, "delete")
+	if err != nil {
+		return err
+	}
+	logger.Infof("Deleting %s", describe(client.resource, namespace, name))
+
+	propagation := metaV1.DeletePropagationBackground
+	options, err := json.Marshal(&metaV1.DeleteOptions{
+		TypeMeta:          metaV1.TypeMeta{Kind: "DeleteOptions", APIVersion: "v1"},
+		PropagationPolicy: &propagation,
+	})
+	if err != nil {
+		return err
+	}
+
+	return client.request("DELETE", namespace).Name(name).Body(options).Do().Error()
+}
+
+// parseObject parses YAML or JSON content and verifies that it describes an object of the
+// resource.
+func (self *resourceClient) parseObject(content string) (*unstructured.Unstructured, error) {
+	raw, err := yaml.ToJSON([]byte(content))
+	if err != nil {
+		return nil, errorsK8s.NewBadRequest(err.Error())
+	}
+
+	obj := new(unstructured.Unstructured)
+	if err := obj.UnmarshalJSON(raw); err != nil {
+		return nil, errorsK8s.NewBadRequest(err.Error())
+	}
+
+	gvk := obj.GroupVersionKind()
+	if gvk.GroupVersion().String() != self.resource.GroupVersion || gvk.Kind != self.resource.Kind {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("expected %s of %s, got %s of %s",
+			self.resource.Kind, self.resource.GroupVersion, gvk.Kind, gvk.GroupVersion()))
+	}
+	if len(obj.GetName()) == 0 {
+		return nil, errorsK8s.NewBadRequest("object name is required")
+	}
+	return obj, nil
+}
+
+func (self *resourceClient) toDetail(obj *unstructured.Unstructured) *GenericResourceDetail {
+	return &GenericResourceDetail{
+		ObjectMeta: toObjectMeta(obj),
+		TypeMeta:   self.typeMeta(),
+		Resource:   *self.resource,
+		Object:     obj.Object,
+	}
+}
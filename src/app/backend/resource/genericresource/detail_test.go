@@ -0,0 +1,89 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericresource
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestGenericResourceDetail(t *testing.T) {
+	requests := make([]request, 0)
+	server := newFakeAPIServer(&requests)
+	defer server.Close()
+	discoveryClient, config := newClients(server)
+	ref := ResourceRef{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+	detail, err := GetGenericResourceDetail(discoveryClient, config, ref, "default", "small")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.ObjectMeta.Name != "small" || detail.Resource.Kind != "Widget" ||
+		fmt.Sprint(detail.Object["spec"].(map[string]interface{})["size"]) != "1" {
+		t.Errorf("Got detail %#v", detail)
+	}
+
+	content := "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: small\n  resourceVersion: \"1\"\n" +
+		"spec:\n  size: 2\n"
+	updated, err := UpdateGenericResource(discoveryClient, config, ref, "default", "small",
+		&GenericResourceSpec{Content: content})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.ObjectMeta.Namespace != "default" ||
+		fmt.Sprint(updated.Object["spec"].(map[string]interface{})["size"]) != "2" {
+		t.Errorf("Got updated object %#v", updated)
+	}
+
+	cases := []string{
+		"apiVersion: example.com/v2\nkind: Widget\nmetadata:\n  name: small\n",
+		"apiVersion: example.com/v1\nkind: Gadget\nmetadata:\n  name: small\n",
+		"apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: other\n",
+		"apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: small\n  namespace: prod\n",
+	}
+	for _, content := range cases {
+		if _, err := UpdateGenericResource(discoveryClient, config, ref, "default", "small",
+			&GenericResourceSpec{Content: content}); err == nil {
+			t.Errorf("Expected error when updating with %q", content)
+		}
+	}
+
+	if err := DeleteGenericResource(discoveryClient, config, ref, "default", "small"); err != nil {
+		t.Fatal(err)
+	}
+	last := requests[len(requests)-1]
+	if last.method != "DELETE" || !strings.Contains(last.body, `"propagationPolicy":"Background"`) {
+		t.Errorf("Got delete request %#v", last)
+	}
+}
+
+func TestGenericResourceDetailOfSecret(t *testing.T) {
+	requests := make([]request, 0)
+	server := newFakeAPIServer(&requests)
+	defer server.Close()
+	discoveryClient, config := newClients(server)
+	ref := ResourceRef{Group: CoreGroup, Version: "v1", Resource: "secrets"}
+
+	_, err := GetGenericResourceDetail(discoveryClient, config, ref, "default", "token")
+	if !errorsK8s.IsForbidden(err) {
+		t.Errorf("Expected forbidden error when getting secret but got %#v", err)
+	}
+	if len(requests) > 0 {
+		t.Errorf("Expected no requests for secret but got %#v", requests)
+	}
+}
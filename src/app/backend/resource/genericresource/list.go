@@ -0,0 +1,171 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericresource
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apiresource"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// tableAcceptHeader requests server side printing of lists. Servers without table support return
+// plain lists.
+const tableAcceptHeader = "application/json;as=Table;v=v1;g=meta.k8s.io," +
+	"application/json;as=Table;v=v1beta1;g=meta.k8s.io,application/json"
+
+// TableColumn is a column of the list printed by the server.
+type TableColumn struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Format      string `json:"format"`
+	Description string `json:"description"`
+	// Columns with priority 0 are shown by default, other columns only in wide views.
+	Priority int32 `json:"priority"`
+}
+
+// GenericResourceObject is a single object on the list together with its printed cells.
+type GenericResourceObject struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Cells contain values of columns in the same order.
+	Cells []interface{} `json:"cells"`
+}
+
+// GenericResourceList contains objects of a resource with columns printed by the server.
+type GenericResourceList struct {
+	ListMeta api.ListMeta            `json:"listMeta"`
+	Resource apiresource.APIResource `json:"resource"`
+	Columns  []TableColumn           `json:"columns"`
+	Items    []GenericResourceObject `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// table is a subset of meta.k8s.io Table returned by servers supporting server side printing.
+type table struct {
+	Kind              string        `json:"kind"`
+	ColumnDefinitions []TableColumn `json:"columnDefinitions"`
+	Rows              []struct {
+		Cells  []interface{}  `json:"cells"`
+		Object objectWithMeta `json:"object"`
+	} `json:"rows"`
+}
+
+// objectWithMeta decodes metadata of any object, e.g. PartialObjectMetadata included in tables.
+type objectWithMeta struct {
+	Metadata metaV1.ObjectMeta `json:"metadata"`
+}
+
+type objectList struct {
+	Items []objectWithMeta `json:"items"`
+}
+
+// defaultColumns are used for servers without table support.
+var defaultColumns = []TableColumn{
+	{Name: "Name", Type: "string", Format: "name", Description: "Name of the object."},
+	{Name: "Created", Type: "string", Format: "date-time", Description: "Creation time of the object."},
+}
+
+// GetGenericResourceList returns objects of the resource with the default columns printed by the
+// server.
+func GetGenericResourceList(discoveryClient discovery.DiscoveryInterface, config *rest.Config, ref ResourceRef,
+	nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*GenericResourceList, error) {
+	logger.Infof("Getting list of %s in %s", ref.Resource, ref.groupVersion())
+
+	client, err := newResourceClient(discoveryClient, config, ref, "list")
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := client.request("GET", nsQuery.ToRequestParam()).
+		SetHeader("Accept", tableAcceptHeader).
+		Param("includeObject", "Metadata").
+		Do().
+		Raw()
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	columns := defaultColumns
+	objects := make([]GenericResourceObject, 0)
+	if err == nil {
+		columns, objects, err = parseList(raw, client.typeMeta())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	filtered := make([]GenericResourceObject, 0, len(objects))
+	for _, object := range objects {
+		if !client.resource.Namespaced || nsQuery.Matches(object.ObjectMeta.Namespace) {
+			filtered = append(filtered, object)
+		}
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(filtered), dsQuery)
+	return &GenericResourceList{
+		ListMeta: api.ListMeta{TotalItems: filteredTotal},
+		Resource: *client.resource,
+		Columns:  columns,
+		Items:    fromCells(cells),
+		Errors:   nonCriticalErrors,
+	}, nil
+}
+
+// parseList parses table or plain list returned by the server.
+func parseList(raw []byte, typeMeta api.TypeMeta) ([]TableColumn, []GenericResourceObject, error) {
+	printed := table{}
+	if err := json.Unmarshal(raw, &printed); err != nil {
+		return nil, nil, err
+	}
+
+	objects := make([]GenericResourceObject, 0)
+	if printed.Kind == "Table" {
+		for _, row := range printed.Rows {
+			objects = append(objects, GenericResourceObject{
+				ObjectMeta: api.NewObjectMeta(row.Object.Metadata),
+				TypeMeta:   typeMeta,
+				Cells:      row.Cells,
+			})
+		}
+		return printed.ColumnDefinitions, objects, nil
+	}
+
+	list := objectList{}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, nil, err
+	}
+	for _, item := range list.Items {
+		objects = append(objects, GenericResourceObject{
+			ObjectMeta: api.NewObjectMeta(item.Metadata),
+			TypeMeta:   typeMeta,
+			Cells: []interface{}{item.Metadata.Name,
+				item.Metadata.CreationTimestamp.UTC().Format(time.RFC3339)},
+		})
+	}
+	return defaultColumns, objects, nil
+}
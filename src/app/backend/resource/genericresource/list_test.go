@@ -0,0 +1,135 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericresource
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// apiServerResponses maps request method and path to responses of the fake apiserver.
+var apiServerResponses = map[string]string{
+	"GET /api": `{"kind": "APIVersions", "versions": ["v1"]}`,
+	"GET /apis": `{"kind": "APIGroupList", "groups": [{"name": "example.com",
+		"versions": [{"groupVersion": "example.com/v1", "version": "v1"}],
+		"preferredVersion": {"groupVersion": "example.com/v1", "version": "v1"}}]}`,
+	"GET /api/v1": `{"kind": "APIResourceList", "groupVersion": "v1", "resources": [
+		{"name": "configmaps", "namespaced": true, "kind": "ConfigMap", "verbs": ["get", "list"]},
+		{"name": "nodes", "namespaced": false, "kind": "Node", "verbs": ["get"]}]}`,
+	"GET /apis/example.com/v1": `{"kind": "APIResourceList", "groupVersion": "example.com/v1", "resources": [
+		{"name": "widgets", "namespaced": true, "kind": "Widget", "verbs": ["get", "list", "update", "delete"]}]}`,
+	"GET /apis/example.com/v1/namespaces/default/widgets": `{"kind": "Table", "apiVersion": "meta.k8s.io/v1",
+		"columnDefinitions": [{"name": "Name", "type": "string", "format": "name"},
+			{"name": "Size", "type": "integer", "priority": 1}],
+		"rows": [
+			{"cells": ["small", 1], "object": {"metadata": {"name": "small", "namespace": "default"}}},
+			{"cells": ["large", 10], "object": {"metadata": {"name": "large", "namespace": "default"}}}]}`,
+	"GET /api/v1/configmaps": `{"kind": "ConfigMapList", "apiVersion": "v1", "items": [
+		{"metadata": {"name": "config", "namespace": "default", "creationTimestamp": "2017-01-01T00:00:00Z"}},
+		{"metadata": {"name": "other", "namespace": "kube-system", "creationTimestamp": "2017-01-01T00:00:00Z"}}]}`,
+	"GET /apis/example.com/v1/namespaces/default/widgets/small": `{"kind": "Widget",
+		"apiVersion": "example.com/v1", "metadata": {"name": "small", "namespace": "default",
+		"resourceVersion": "1"}, "spec": {"size": 1}}`,
+	"DELETE /apis/example.com/v1/namespaces/default/widgets/small": `{"kind": "Status", "apiVersion": "v1",
+		"status": "Success"}`,
+}
+
+type request struct {
+	method, path, accept, body string
+}
+
+// newFakeAPIServer returns server responding with apiServerResponses. Bodies of PUT requests are
+// echoed back.
+func newFakeAPIServer(requests *[]request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*requests = append(*requests, request{r.Method, r.URL.Path, r.Header.Get("Accept"), string(body)})
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "PUT" {
+			w.Write(body)
+			return
+		}
+		response, ok := apiServerResponses[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound",
+				"code": 404}`))
+			return
+		}
+		w.Write([]byte(response))
+	}))
+}
+
+func newClients(server *httptest.Server) (discovery.DiscoveryInterface, *rest.Config) {
+	config := &rest.Config{Host: server.URL}
+	return discovery.NewDiscoveryClientForConfigOrDie(config), config
+}
+
+func TestGetGenericResourceList(t *testing.T) {
+	requests := make([]request, 0)
+	server := newFakeAPIServer(&requests)
+	defer server.Close()
+	discoveryClient, config := newClients(server)
+
+	actual, err := GetGenericResourceList(discoveryClient, config,
+		ResourceRef{Group: "example.com", Version: "v1", Resource: "widgets"},
+		common.NewSameNamespaceQuery("default"), dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedColumns := []TableColumn{{Name: "Name", Type: "string", Format: "name"},
+		{Name: "Size", Type: "integer", Priority: 1}}
+	if !reflect.DeepEqual(actual.Columns, expectedColumns) {
+		t.Errorf("Got columns %#v, expected %#v", actual.Columns, expectedColumns)
+	}
+	if len(actual.Items) != 2 || actual.Items[1].ObjectMeta.Name != "large" ||
+		!reflect.DeepEqual(actual.Items[1].Cells, []interface{}{"large", float64(10)}) ||
+		actual.Items[1].TypeMeta.Kind != "widget" {
+		t.Errorf("Got items %#v", actual.Items)
+	}
+	if last := requests[len(requests)-1]; !strings.HasPrefix(last.accept, "application/json;as=Table") {
+		t.Errorf("Expected table to be requested, got Accept header %s", last.accept)
+	}
+
+	// Server without table support returns list, namespaces are filtered by Dashboard.
+	actual, err = GetGenericResourceList(discoveryClient, config,
+		ResourceRef{Group: CoreGroup, Version: "v1", Resource: "configmaps"},
+		common.NewNamespaceQuery([]string{"default", "prod"}), dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actual.Columns, defaultColumns) || len(actual.Items) != 1 ||
+		!reflect.DeepEqual(actual.Items[0].Cells, []interface{}{"config", "2017-01-01T00:00:00Z"}) {
+		t.Errorf("Got list %#v", actual)
+	}
+
+	// Nodes cannot be listed.
+	if _, err := GetGenericResourceList(discoveryClient, config,
+		ResourceRef{Group: CoreGroup, Version: "v1", Resource: "nodes"}, common.NewNamespaceQuery(nil),
+		dataselect.NoDataSelect); err == nil {
+		t.Error("Expected error for resource, which cannot be listed")
+	}
+}
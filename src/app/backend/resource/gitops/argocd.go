@@ -0,0 +1,217 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"encoding/json"
+	"strings"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Label and annotation used by Argo CD to track resources of applications.
+const (
+	argoCDInstanceLabel      = "app.kubernetes.io/instance"
+	argoCDTrackingAnnotation = "argocd.argoproj.io/tracking-id"
+)
+
+// ArgoCDGroupVersion is the group version of Argo CD API.
+var ArgoCDGroupVersion = schema.GroupVersion{Group: "argoproj.io", Version: "v1alpha1"}
+
+const argoCDApplicationResource = "applications"
+
+// application is the API representation of Argo CD Application.
+type application struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Source     *applicationSource  `json:"source"`
+		Sources    []applicationSource `json:"sources"`
+		SyncPolicy *struct {
+			Automated *struct {
+				SelfHeal bool `json:"selfHeal"`
+			} `json:"automated"`
+		} `json:"syncPolicy"`
+	} `json:"spec"`
+	Status struct {
+		Sync struct {
+			Status   string `json:"status"`
+			Revision string `json:"revision"`
+		} `json:"sync"`
+		Health struct {
+			Status string `json:"status"`
+		} `json:"health"`
+		Resources      []applicationResource `json:"resources"`
+		OperationState *struct {
+			Phase   string `json:"phase"`
+			Message string `json:"message"`
+		} `json:"operationState"`
+	} `json:"status"`
+}
+
+type applicationSource struct {
+	RepoURL        string `json:"repoURL"`
+	Path           string `json:"path"`
+	Chart          string `json:"chart"`
+	TargetRevision string `json:"targetRevision"`
+}
+
+type applicationResource struct {
+	Group     string `json:"group"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Health    *struct {
+		Status string `json:"status"`
+	} `json:"health"`
+}
+
+type applicationList struct {
+	Items []application `json:"items"`
+}
+
+// getArgoCDOwnership returns ownership of resources synced by Argo CD Application, or nil when the
+// resource is not tracked by any application. Tracking annotation identifies the application and
+// the resource. Instance label is also set by other tools, e.g. Helm, so the resource has to be
+// listed in resources of the application to be owned by it.
+func getArgoCDOwnership(restClients map[schema.GroupVersion]RESTClient, ref ObjectRef) (*Ownership, error) {
+	ownership := &Ownership{Tool: ToolArgoCD, OwnerKind: "Application"}
+	tracked := false
+	if id, ok := ref.Annotations[argoCDTrackingAnnotation]; ok {
+		appName, matches := parseTrackingID(id, ref)
+		if !matches {
+			// Annotation was copied from another resource, e.g. by kubectl get -o yaml.
+			return nil, nil
+		}
+		tracked = true
+		ownership.OwnerName = appName
+		if i := strings.Index(appName, "_"); i >= 0 {
+			ownership.OwnerNamespace, ownership.OwnerName = appName[:i], appName[i+1:]
+		}
+	} else if name, ok := ref.Labels[argoCDInstanceLabel]; ok && len(name) > 0 {
+		ownership.OwnerName = name
+	} else {
+		return nil, nil
+	}
+
+	app, err := findApplication(restClients, ownership.OwnerNamespace, ownership.OwnerName)
+	if err != nil || app == nil {
+		if tracked {
+			if err == nil {
+				err = errorsK8s.NewNotFound(schema.GroupResource{Group: ArgoCDGroupVersion.Group,
+					Resource: argoCDApplicationResource}, ownership.OwnerName)
+			}
+			return lookupFailed(ownership, err), nil
+		}
+		return nil, err
+	}
+
+	resource := app.findResource(ref)
+	if !tracked && resource == nil {
+		return nil, nil
+	}
+	ownership.OwnerNamespace = app.Namespace
+
+	source := app.Spec.Source
+	if source == nil && len(app.Spec.Sources) > 0 {
+		source = &app.Spec.Sources[0]
+	}
+	if source != nil {
+		ownership.RepoURL = source.RepoURL
+		ownership.Path = source.Path
+		if len(ownership.Path) == 0 {
+			ownership.Path = source.Chart
+		}
+		ownership.Revision = source.TargetRevision
+	}
+	ownership.AppliedRevision = app.Status.Sync.Revision
+	ownership.SelfHeal = app.Spec.SyncPolicy != nil && app.Spec.SyncPolicy.Automated != nil &&
+		app.Spec.SyncPolicy.Automated.SelfHeal
+
+	ownership.SyncStatus = app.Status.Sync.Status
+	ownership.HealthStatus = app.Status.Health.Status
+	if resource != nil {
+		ownership.SyncStatus = resource.Status
+		if resource.Health != nil {
+			ownership.HealthStatus = resource.Health.Status
+		}
+	}
+	if len(ownership.SyncStatus) == 0 {
+		ownership.SyncStatus = SyncStatusUnknown
+	}
+	if state := app.Status.OperationState; state != nil && (state.Phase == "Failed" || state.Phase == "Error") {
+		ownership.Message = state.Message
+	}
+	return ownership, nil
+}
+
+// parseTrackingID returns application name from tracking id in format
+// <application>:<group>/<kind>:<namespace>/<name> and whether the id identifies the resource.
+func parseTrackingID(id string, ref ObjectRef) (string, bool) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 {
+		return "", false
+	}
+	groupKind := strings.SplitN(parts[1], "/", 2)
+	namespaceName := strings.SplitN(parts[2], "/", 2)
+	if len(groupKind) != 2 || len(namespaceName) != 2 {
+		return "", false
+	}
+	return parts[0], groupKind[0] == ref.Group && groupKind[1] == ref.Kind &&
+		namespaceName[0] == ref.Namespace && namespaceName[1] == ref.Name
+}
+
+// findApplication returns Argo CD Application with the name. Applications in all namespaces are
+// searched, when namespace is empty.
+func findApplication(restClients map[schema.GroupVersion]RESTClient, namespace, name string) (*application, error) {
+	if len(namespace) > 0 {
+		raw, err := getRaw(restClients, ArgoCDGroupVersion, argoCDApplicationResource, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		app := &application{}
+		return app, json.Unmarshal(raw, app)
+	}
+
+	raw, err := getRaw(restClients, ArgoCDGroupVersion, argoCDApplicationResource, "", "")
+	if err != nil {
+		return nil, err
+	}
+	list := applicationList{}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		if list.Items[i].Name == name {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// findResource returns status of the resource in the application, or nil if the application does
+// not contain it.
+func (self *application) findResource(ref ObjectRef) *applicationResource {
+	for i := range self.Status.Resources {
+		resource := &self.Status.Resources[i]
+		if resource.Group == ref.Group && resource.Kind == ref.Kind && resource.Namespace == ref.Namespace &&
+			resource.Name == ref.Name {
+			return resource
+		}
+	}
+	return nil
+}
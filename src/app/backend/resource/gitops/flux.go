@@ -0,0 +1,194 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Labels set by Flux on the resources it applies.
+const (
+	fluxKustomizationNameLabel      = "kustomize.toolkit.fluxcd.io/name"
+	fluxKustomizationNamespaceLabel = "kustomize.toolkit.fluxcd.io/namespace"
+	fluxHelmReleaseNameLabel        = "helm.toolkit.fluxcd.io/name"
+	fluxHelmReleaseNamespaceLabel   = "helm.toolkit.fluxcd.io/namespace"
+)
+
+// Group versions and resources of Flux objects.
+var (
+	FluxKustomizationGroupVersion = schema.GroupVersion{Group: "kustomize.toolkit.fluxcd.io", Version: "v1"}
+	FluxHelmReleaseGroupVersion   = schema.GroupVersion{Group: "helm.toolkit.fluxcd.io", Version: "v2"}
+
+	fluxSources = map[string]struct {
+		gv       schema.GroupVersion
+		resource string
+	}{
+		"GitRepository":  {schema.GroupVersion{Group: "source.toolkit.fluxcd.io", Version: "v1"}, "gitrepositories"},
+		"HelmRepository": {schema.GroupVersion{Group: "source.toolkit.fluxcd.io", Version: "v1"}, "helmrepositories"},
+		"OCIRepository": {schema.GroupVersion{Group: "source.toolkit.fluxcd.io", Version: "v1beta2"},
+			"ocirepositories"},
+		"Bucket": {schema.GroupVersion{Group: "source.toolkit.fluxcd.io", Version: "v1beta2"}, "buckets"},
+	}
+)
+
+// fluxObject is the API representation of Flux Kustomization and HelmRelease. Client library does
+// not contain GitOps types, so only the fields used by Dashboard are declared in this package.
+type fluxObject struct {
+	Spec struct {
+		Path      string         `json:"path"`
+		SourceRef *fluxSourceRef `json:"sourceRef"`
+		Suspend   bool           `json:"suspend"`
+		Chart     *struct {
+			Spec struct {
+				Chart     string        `json:"chart"`
+				Version   string        `json:"version"`
+				SourceRef fluxSourceRef `json:"sourceRef"`
+			} `json:"spec"`
+		} `json:"chart"`
+		DriftDetection *struct {
+			Mode string `json:"mode"`
+		} `json:"driftDetection"`
+	} `json:"spec"`
+	Status struct {
+		Conditions []struct {
+			Type    string `json:"type"`
+			Status  string `json:"status"`
+			Message string `json:"message"`
+		} `json:"conditions"`
+		LastAppliedRevision   string `json:"lastAppliedRevision"`
+		LastAttemptedRevision string `json:"lastAttemptedRevision"`
+	} `json:"status"`
+}
+
+type fluxSourceRef struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+type fluxSource struct {
+	Spec struct {
+		URL        string `json:"url"`
+		Endpoint   string `json:"endpoint"`
+		BucketName string `json:"bucketName"`
+		Ref        *struct {
+			Branch string `json:"branch"`
+			Tag    string `json:"tag"`
+			SemVer string `json:"semver"`
+			Commit string `json:"commit"`
+			Name   string `json:"name"`
+		} `json:"ref"`
+	} `json:"spec"`
+}
+
+// getFluxOwnership returns ownership of resources applied by Flux Kustomization or HelmRelease,
+// or nil when the resource has no Flux labels.
+func getFluxOwnership(restClients map[schema.GroupVersion]RESTClient, ref ObjectRef) *Ownership {
+	ownership := &Ownership{Tool: ToolFlux}
+	gv, resource := FluxKustomizationGroupVersion, "kustomizations"
+	switch {
+	case len(ref.Labels[fluxKustomizationNameLabel]) > 0:
+		ownership.OwnerKind = "Kustomization"
+		ownership.OwnerName = ref.Labels[fluxKustomizationNameLabel]
+		ownership.OwnerNamespace = ref.Labels[fluxKustomizationNamespaceLabel]
+	case len(ref.Labels[fluxHelmReleaseNameLabel]) > 0:
+		ownership.OwnerKind = "HelmRelease"
+		ownership.OwnerName = ref.Labels[fluxHelmReleaseNameLabel]
+		ownership.OwnerNamespace = ref.Labels[fluxHelmReleaseNamespaceLabel]
+		gv, resource = FluxHelmReleaseGroupVersion, "helmreleases"
+	default:
+		return nil
+	}
+
+	raw, err := getRaw(restClients, gv, resource, ownership.OwnerNamespace, ownership.OwnerName)
+	if err != nil {
+		return lookupFailed(ownership, err)
+	}
+	owner := fluxObject{}
+	if err := json.Unmarshal(raw, &owner); err != nil {
+		return lookupFailed(ownership, err)
+	}
+
+	ownership.Path = owner.Spec.Path
+	ownership.Suspended = owner.Spec.Suspend
+	ownership.AppliedRevision = owner.Status.LastAppliedRevision
+	if len(ownership.AppliedRevision) == 0 {
+		ownership.AppliedRevision = owner.Status.LastAttemptedRevision
+	}
+	// Kustomizations correct drift on every reconciliation, Helm releases only when enabled.
+	ownership.SelfHeal = ownership.OwnerKind == "Kustomization" ||
+		(owner.Spec.DriftDetection != nil && owner.Spec.DriftDetection.Mode == "enabled")
+
+	ownership.SyncStatus = SyncStatusUnknown
+	for _, condition := range owner.Status.Conditions {
+		if condition.Type != "Ready" {
+			continue
+		}
+		switch condition.Status {
+		case "True":
+			ownership.SyncStatus = SyncStatusSynced
+		case "False":
+			ownership.SyncStatus = SyncStatusOutOfSync
+			ownership.Message = condition.Message
+		}
+	}
+
+	sourceRef := owner.Spec.SourceRef
+	if chart := owner.Spec.Chart; chart != nil {
+		ownership.Path = chart.Spec.Chart
+		ownership.Revision = chart.Spec.Version
+		sourceRef = &chart.Spec.SourceRef
+	}
+	if sourceRef != nil {
+		addFluxSource(restClients, ownership, *sourceRef)
+	}
+	return ownership
+}
+
+// addFluxSource adds URL and revision of the source to the ownership. Sources which cannot be read
+// are skipped, as the ownership is already known.
+func addFluxSource(restClients map[schema.GroupVersion]RESTClient, ownership *Ownership, ref fluxSourceRef) {
+	source, ok := fluxSources[ref.Kind]
+	if !ok {
+		return
+	}
+	if len(ref.Namespace) == 0 {
+		ref.Namespace = ownership.OwnerNamespace
+	}
+
+	raw, err := getRaw(restClients, source.gv, source.resource, ref.Namespace, ref.Name)
+	if err != nil {
+		return
+	}
+	item := fluxSource{}
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return
+	}
+
+	ownership.RepoURL = item.Spec.URL
+	if len(ownership.RepoURL) == 0 && len(item.Spec.BucketName) > 0 {
+		ownership.RepoURL = item.Spec.Endpoint + "/" + item.Spec.BucketName
+	}
+	if r := item.Spec.Ref; r != nil && len(ownership.Revision) == 0 {
+		for _, revision := range []string{r.Commit, r.Name, r.Tag, r.SemVer, r.Branch} {
+			if len(revision) > 0 {
+				ownership.Revision = revision
+				break
+			}
+		}
+	}
+}
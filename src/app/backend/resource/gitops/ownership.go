@@ -0,0 +1,161 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gitops finds Argo CD and Flux objects managing Kubernetes resources, so that Dashboard
+// can show where the resources come from and warn before they are edited manually.
+package gitops
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+// Tool is the name of a GitOps tool.
+type Tool string
+
+// List of supported GitOps tools.
+const (
+	ToolArgoCD Tool = "argocd"
+	ToolFlux   Tool = "flux"
+)
+
+// Sync statuses of managed resources, the same as in Argo CD. Flux statuses are derived from
+// readiness of the managing object.
+const (
+	SyncStatusSynced    = "Synced"
+	SyncStatusOutOfSync = "OutOfSync"
+	SyncStatusUnknown   = "Unknown"
+)
+
+// ObjectRef identifies a resource together with its labels and annotations, which tell whether it
+// is managed by a GitOps tool.
+type ObjectRef struct {
+	Group       string
+	Kind        string
+	Namespace   string
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// Ownership describes GitOps object managing a resource and the source it is synced from.
+type Ownership struct {
+	Tool Tool `json:"tool"`
+
+	// Kind, namespace and name of the managing object, e.g. Argo CD Application or Flux
+	// Kustomization.
+	OwnerKind      string `json:"ownerKind"`
+	OwnerNamespace string `json:"ownerNamespace"`
+	OwnerName      string `json:"ownerName"`
+
+	// Repository, path in it and revision the resource is synced from. Path is the chart name for
+	// Helm sources.
+	RepoURL  string `json:"repoURL"`
+	Path     string `json:"path"`
+	Revision string `json:"revision"`
+
+	// AppliedRevision is the last revision applied to the cluster, e.g. a commit.
+	AppliedRevision string `json:"appliedRevision"`
+
+	SyncStatus   string `json:"syncStatus"`
+	HealthStatus string `json:"healthStatus,omitempty"`
+
+	// SelfHeal tells whether manual changes are reverted by the tool on its next sync.
+	SelfHeal  bool `json:"selfHeal"`
+	Suspended bool `json:"suspended"`
+
+	// Message describes a failed sync, or why the managing object could not be read.
+	Message string `json:"message,omitempty"`
+}
+
+// GetOwnership returns GitOps ownership of the resource. Nil is returned when the resource is not
+// managed by a supported GitOps tool. Only resources with tracking labels or annotations of the
+// tools are looked up.
+func GetOwnership(config *rest.Config, ref ObjectRef) (*Ownership, error) {
+	restClients, err := newRESTClients(config)
+	if err != nil {
+		return nil, err
+	}
+	return getOwnership(restClients, ref)
+}
+
+func getOwnership(restClients map[schema.GroupVersion]RESTClient, ref ObjectRef) (*Ownership, error) {
+	if ownership := getFluxOwnership(restClients, ref); ownership != nil {
+		return ownership, nil
+	}
+	return getArgoCDOwnership(restClients, ref)
+}
+
+// EditWarning returns warning about manual edit of the managed resource.
+func (self *Ownership) EditWarning() string {
+	owner := fmt.Sprintf("%s %s/%s", self.OwnerKind, self.OwnerNamespace, self.OwnerName)
+	if self.SelfHeal && !self.Suspended {
+		return fmt.Sprintf("object is managed by %s and manual changes will be reverted on its next sync", owner)
+	}
+	return fmt.Sprintf("object is managed by %s and manual changes will make it out of sync with %s", owner,
+		self.RepoURL)
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Get() *rest.Request
+}
+
+// newRESTClients creates REST clients for group versions of GitOps resources.
+func newRESTClients(config *rest.Config) (map[schema.GroupVersion]RESTClient, error) {
+	groupVersions := []schema.GroupVersion{ArgoCDGroupVersion, FluxKustomizationGroupVersion,
+		FluxHelmReleaseGroupVersion}
+	for _, source := range fluxSources {
+		groupVersions = append(groupVersions, source.gv)
+	}
+
+	restClients := make(map[schema.GroupVersion]RESTClient)
+	for _, gv := range groupVersions {
+		if _, ok := restClients[gv]; ok {
+			continue
+		}
+		restClient, err := apply.NewRESTClient(config, gv)
+		if err != nil {
+			return nil, err
+		}
+		restClients[gv] = restClient
+	}
+	return restClients, nil
+}
+
+// getRaw gets object of a GitOps resource. All objects in the namespace are listed if name is
+// empty.
+func getRaw(restClients map[schema.GroupVersion]RESTClient, gv schema.GroupVersion, resource, namespace,
+	name string) ([]byte, error) {
+	request := restClients[gv].Get().Namespace(namespace).Resource(resource)
+	if len(name) > 0 {
+		request = request.Name(name)
+	}
+	return request.Do().Raw()
+}
+
+// lookupFailed records in the ownership, that the managing object could not be read. Ownership
+// known from tracking labels is still returned.
+func lookupFailed(ownership *Ownership, err error) *Ownership {
+	logger.Errorf("Failed to get %s %s/%s: %s", ownership.OwnerKind, ownership.OwnerNamespace,
+		ownership.OwnerName, err)
+	ownership.SyncStatus = SyncStatusUnknown
+	ownership.Message = fmt.Sprintf("cannot get %s: %s", strings.ToLower(ownership.OwnerKind), err.Error())
+	return ownership
+}
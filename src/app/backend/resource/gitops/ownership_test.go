@@ -0,0 +1,162 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+var testGitOpsObjects = map[string]string{
+	"kustomizations/flux-system/apps": `{"spec": {"path": "./apps/prod", "interval": "10m",
+	  "sourceRef": {"kind": "GitRepository", "name": "fleet"}},
+	  "status": {"lastAppliedRevision": "main@sha1:0a1b2c",
+	             "conditions": [{"type": "Ready", "status": "True"}]}}`,
+	"gitrepositories/flux-system/fleet": `{"spec": {"url": "https://github.com/example/fleet",
+	  "ref": {"branch": "main"}}}`,
+	"helmreleases/monitoring/grafana": `{"spec": {"suspend": true, "chart": {"spec": {"chart": "grafana",
+	  "version": "6.x", "sourceRef": {"kind": "HelmRepository", "name": "grafana", "namespace": "flux-system"}}}},
+	  "status": {"lastAttemptedRevision": "6.58.9",
+	             "conditions": [{"type": "Ready", "status": "False", "message": "install retries exhausted"}]}}`,
+	"helmrepositories/flux-system/grafana": `{"spec": {"url": "https://grafana.github.io/helm-charts"}}`,
+	"applications//": `{"items": [
+	  {"metadata": {"name": "guestbook", "namespace": "argocd"},
+	   "spec": {"source": {"repoURL": "https://github.com/example/apps", "path": "guestbook",
+	                       "targetRevision": "HEAD"},
+	            "syncPolicy": {"automated": {"selfHeal": true}}},
+	   "status": {"sync": {"status": "OutOfSync", "revision": "3f2e1d"}, "health": {"status": "Degraded"},
+	              "resources": [{"group": "apps", "kind": "Deployment", "namespace": "default", "name": "guestbook",
+	                             "status": "Synced", "health": {"status": "Healthy"}}]}}]}`,
+	"applications/team-a/billing": `{"metadata": {"name": "billing", "namespace": "team-a"},
+	  "spec": {"sources": [{"repoURL": "https://charts.example.com", "chart": "billing", "targetRevision": "1.2.0"}]},
+	  "status": {"sync": {"status": "Synced"}, "operationState": {"phase": "Failed", "message": "hook failed"}}}`,
+}
+
+// newGitOpsServer returns server of GitOps APIs, which serves test objects and forbids access to
+// other ones.
+func newGitOpsServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// Path is /apis/<group>/<version>[/namespaces/<namespace>]/<resource>[/<name>].
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")[3:]
+		namespace, name := "", ""
+		if len(parts) > 2 && parts[0] == "namespaces" {
+			namespace, parts = parts[1], parts[2:]
+		}
+		if len(parts) > 1 {
+			name = parts[1]
+		}
+		if raw, ok := testGitOpsObjects[parts[0]+"/"+namespace+"/"+name]; ok {
+			w.Write([]byte(raw))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+}
+
+func TestGetOwnership(t *testing.T) {
+	server := newGitOpsServer()
+	defer server.Close()
+	restClients, err := newRESTClients(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deployment := func(name string, labels, annotations map[string]string) ObjectRef {
+		return ObjectRef{Group: "apps", Kind: "Deployment", Namespace: "default", Name: name, Labels: labels,
+			Annotations: annotations}
+	}
+
+	cases := []struct {
+		ref      ObjectRef
+		expected *Ownership
+	}{
+		{deployment("plain", nil, nil), nil},
+		{
+			deployment("web", map[string]string{fluxKustomizationNameLabel: "apps",
+				fluxKustomizationNamespaceLabel: "flux-system"}, nil),
+			&Ownership{Tool: ToolFlux, OwnerKind: "Kustomization", OwnerNamespace: "flux-system",
+				OwnerName: "apps", RepoURL: "https://github.com/example/fleet", Path: "./apps/prod",
+				Revision: "main", AppliedRevision: "main@sha1:0a1b2c", SyncStatus: SyncStatusSynced,
+				SelfHeal: true},
+		},
+		{
+			deployment("grafana", map[string]string{fluxHelmReleaseNameLabel: "grafana",
+				fluxHelmReleaseNamespaceLabel: "monitoring"}, nil),
+			&Ownership{Tool: ToolFlux, OwnerKind: "HelmRelease", OwnerNamespace: "monitoring",
+				OwnerName: "grafana", RepoURL: "https://grafana.github.io/helm-charts", Path: "grafana",
+				Revision: "6.x", AppliedRevision: "6.58.9", SyncStatus: SyncStatusOutOfSync, Suspended: true,
+				Message: "install retries exhausted"},
+		},
+		{
+			deployment("missing", map[string]string{fluxKustomizationNameLabel: "missing",
+				fluxKustomizationNamespaceLabel: "flux-system"}, nil),
+			&Ownership{Tool: ToolFlux, OwnerKind: "Kustomization", OwnerNamespace: "flux-system",
+				OwnerName: "missing", SyncStatus: SyncStatusUnknown,
+				Message: "cannot get kustomization: unknown (get kustomizations.kustomize.toolkit.fluxcd.io missing)"},
+		},
+		{
+			deployment("guestbook", map[string]string{argoCDInstanceLabel: "guestbook"}, nil),
+			&Ownership{Tool: ToolArgoCD, OwnerKind: "Application", OwnerNamespace: "argocd",
+				OwnerName: "guestbook", RepoURL: "https://github.com/example/apps", Path: "guestbook",
+				Revision: "HEAD", AppliedRevision: "3f2e1d", SyncStatus: "Synced", HealthStatus: "Healthy",
+				SelfHeal: true},
+		},
+		// Instance label is set by Helm too, the deployment is not a resource of the application.
+		{deployment("helm-installed", map[string]string{argoCDInstanceLabel: "guestbook"}, nil), nil},
+		{
+			deployment("billing", nil, map[string]string{
+				argoCDTrackingAnnotation: "team-a_billing:apps/Deployment:default/billing"}),
+			&Ownership{Tool: ToolArgoCD, OwnerKind: "Application", OwnerNamespace: "team-a",
+				OwnerName: "billing", RepoURL: "https://charts.example.com", Path: "billing", Revision: "1.2.0",
+				SyncStatus: "Synced", Message: "hook failed"},
+		},
+		// Tracking annotation copied from another deployment.
+		{deployment("copy", nil, map[string]string{
+			argoCDTrackingAnnotation: "team-a_billing:apps/Deployment:default/billing"}), nil},
+	}
+	for _, c := range cases {
+		actual, err := getOwnership(restClients, c.ref)
+		if err != nil {
+			t.Errorf("GetOwnership(%s) returns unexpected error: %v", c.ref.Name, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("GetOwnership(%s) == \ngot: %#v, \nexpected %#v", c.ref.Name, actual, c.expected)
+		}
+	}
+}
+
+func TestEditWarning(t *testing.T) {
+	ownership := &Ownership{OwnerKind: "Application", OwnerNamespace: "argocd", OwnerName: "guestbook",
+		RepoURL: "https://github.com/example/apps", SelfHeal: true}
+	expected := "object is managed by Application argocd/guestbook and manual changes will be reverted on its " +
+		"next sync"
+	if actual := ownership.EditWarning(); actual != expected {
+		t.Errorf("EditWarning() == %q, expected %q", actual, expected)
+	}
+
+	ownership.Suspended = true
+	expected = "object is managed by Application argocd/guestbook and manual changes will make it out of sync " +
+		"with https://github.com/example/apps"
+	if actual := ownership.EditWarning(); actual != expected {
+		t.Errorf("EditWarning() == %q, expected %q", actual, expected)
+	}
+}
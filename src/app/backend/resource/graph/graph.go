@@ -0,0 +1,346 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// Types of relationships between objects.
+const (
+	// EdgeOwner points from an owner to the object it controls.
+	EdgeOwner = "owner"
+
+	// EdgeSelector points from a service to a pod it selects.
+	EdgeSelector = "selector"
+
+	// EdgeReference points from an object to a config map, secret, persistent volume claim or
+	// service account used by its pod spec.
+	EdgeReference = "reference"
+)
+
+// Node identifies an object in the graph.
+type Node struct {
+	Kind      api.ResourceKind `json:"kind"`
+	Namespace string           `json:"namespace"`
+	Name      string           `json:"name"`
+}
+
+// Edge is a directed relationship between two objects.
+type Edge struct {
+	From Node   `json:"from"`
+	To   Node   `json:"to"`
+	Type string `json:"type"`
+}
+
+// RelatedObjects is a graph of the objects related to the root object: its owners and children
+// (transitively), the objects they reference, the services selecting their pods and, for
+// referenced objects and services, the objects using them.
+type RelatedObjects struct {
+	Root  Node   `json:"root"`
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Kinds that can be the root of the graph. Objects of other kinds are only found through their
+// relationships with these.
+var supportedKinds = map[api.ResourceKind]bool{
+	api.ResourceKindPod:                   true,
+	api.ResourceKindReplicaSet:            true,
+	api.ResourceKindDeployment:            true,
+	api.ResourceKindStatefulSet:           true,
+	api.ResourceKindDaemonSet:             true,
+	api.ResourceKindJob:                   true,
+	api.ResourceKindReplicationController: true,
+	api.ResourceKindService:               true,
+	api.ResourceKindConfigMap:             true,
+	api.ResourceKindSecret:                true,
+	api.ResourceKindPersistentVolumeClaim: true,
+	api.ResourceKindServiceAccount:        true,
+}
+
+// GetRelatedObjects returns graph of the objects related to the object of given kind, namespace
+// and name.
+func GetRelatedObjects(client client.Interface, kind api.ResourceKind, namespace, name string) (
+	*RelatedObjects, error) {
+	if !supportedKinds[kind] {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("related objects of %s are not supported", kind))
+	}
+
+	logger.Infof("Getting objects related to %s %s in %s namespace", kind, name, namespace)
+
+	root := Node{Kind: kind, Namespace: namespace, Name: name}
+	b, err := getNamespaceGraph(client, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !b.known[root] {
+		if err := checkExists(client, root); err != nil {
+			return nil, err
+		}
+	}
+
+	return selectRelated(root, b.edges), nil
+}
+
+// getNamespaceGraph returns all relationships between objects in the namespace, together with
+// the set of objects that were listed and references of their pod specs.
+func getNamespaceGraph(client client.Interface, namespace string) (*edgeBuilder, error) {
+	options := metaV1.ListOptions{}
+	b := &edgeBuilder{namespace: namespace, known: map[Node]bool{},
+		references: map[Node][]podSpecReference{}}
+
+	pods, err := client.CoreV1().Pods(namespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		b.addObject(api.ResourceKindPod, pod.ObjectMeta, &pod.Spec)
+	}
+
+	replicaSets, err := client.ExtensionsV1beta1().ReplicaSets(namespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+	for i := range replicaSets.Items {
+		rs := &replicaSets.Items[i]
+		b.addObject(api.ResourceKindReplicaSet, rs.ObjectMeta, &rs.Spec.Template.Spec)
+	}
+
+	deployments, err := client.ExtensionsV1beta1().Deployments(namespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		b.addObject(api.ResourceKindDeployment, deployment.ObjectMeta, &deployment.Spec.Template.Spec)
+	}
+
+	statefulSets, err := client.AppsV1beta1().StatefulSets(namespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+	for i := range statefulSets.Items {
+		ss := &statefulSets.Items[i]
+		b.addObject(api.ResourceKindStatefulSet, ss.ObjectMeta, &ss.Spec.Template.Spec)
+	}
+
+	daemonSets, err := client.ExtensionsV1beta1().DaemonSets(namespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+	for i := range daemonSets.Items {
+		ds := &daemonSets.Items[i]
+		b.addObject(api.ResourceKindDaemonSet, ds.ObjectMeta, &ds.Spec.Template.Spec)
+	}
+
+	jobs, err := client.BatchV1().Jobs(namespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		b.addObject(api.ResourceKindJob, job.ObjectMeta, &job.Spec.Template.Spec)
+	}
+
+	rcs, err := client.CoreV1().ReplicationControllers(namespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rcs.Items {
+		rc := &rcs.Items[i]
+		var spec *v1.PodSpec
+		if rc.Spec.Template != nil {
+			spec = &rc.Spec.Template.Spec
+		}
+		b.addObject(api.ResourceKindReplicationController, rc.ObjectMeta, spec)
+	}
+
+	services, err := client.CoreV1().Services(namespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range services.Items {
+		node := b.node(api.ResourceKindService, service.Name)
+		b.known[node] = true
+		if len(service.Spec.Selector) == 0 {
+			continue
+		}
+		for _, pod := range pods.Items {
+			if api.IsSelectorMatching(service.Spec.Selector, pod.Labels) {
+				b.edges = append(b.edges, Edge{From: node, To: b.node(api.ResourceKindPod, pod.Name),
+					Type: EdgeSelector})
+			}
+		}
+	}
+
+	return b, nil
+}
+
+type edgeBuilder struct {
+	namespace  string
+	known      map[Node]bool
+	edges      []Edge
+	references map[Node][]podSpecReference
+}
+
+func (b *edgeBuilder) node(kind api.ResourceKind, name string) Node {
+	return Node{Kind: kind, Namespace: b.namespace, Name: name}
+}
+
+// addObject adds owner edges from the owners of the object and reference edges to the objects
+// used by its pod spec.
+func (b *edgeBuilder) addObject(kind api.ResourceKind, meta metaV1.ObjectMeta, spec *v1.PodSpec) {
+	node := b.node(kind, meta.Name)
+	b.known[node] = true
+	for _, ref := range meta.OwnerReferences {
+		owner := b.node(api.ResourceKind(strings.ToLower(ref.Kind)), ref.Name)
+		b.edges = append(b.edges, Edge{From: owner, To: node, Type: EdgeOwner})
+	}
+	if spec == nil {
+		return
+	}
+	b.references[node] = getPodSpecReferences(b.namespace, spec)
+	for _, ref := range GetPodSpecReferences(b.namespace, spec) {
+		b.edges = append(b.edges, Edge{From: node, To: ref, Type: EdgeReference})
+	}
+}
+
+// checkExists returns not found error if object, which is not listed when building edges, does
+// not exist.
+func checkExists(client client.Interface, node Node) error {
+	var err error
+	switch node.Kind {
+	case api.ResourceKindConfigMap:
+		_, err = client.CoreV1().ConfigMaps(node.Namespace).Get(node.Name, metaV1.GetOptions{})
+	case api.ResourceKindSecret:
+		_, err = client.CoreV1().Secrets(node.Namespace).Get(node.Name, metaV1.GetOptions{})
+	case api.ResourceKindPersistentVolumeClaim:
+		_, err = client.CoreV1().PersistentVolumeClaims(node.Namespace).Get(node.Name, metaV1.GetOptions{})
+	case api.ResourceKindServiceAccount:
+		_, err = client.CoreV1().ServiceAccounts(node.Namespace).Get(node.Name, metaV1.GetOptions{})
+	default:
+		err = errorsK8s.NewNotFound(schema.GroupResource{Resource: string(node.Kind)}, node.Name)
+	}
+	return err
+}
+
+// selectRelated returns the part of the namespace graph related to the root.
+func selectRelated(root Node, edges []Edge) *RelatedObjects {
+	outgoing := map[Node][]Edge{}
+	incoming := map[Node][]Edge{}
+	for _, edge := range edges {
+		outgoing[edge.From] = append(outgoing[edge.From], edge)
+		incoming[edge.To] = append(incoming[edge.To], edge)
+	}
+
+	selected := map[Edge]bool{}
+	// Owners of the root, transitively.
+	walk(root, func(node Node) []Edge { return filterEdges(incoming[node], EdgeOwner) },
+		func(edge Edge) Node { return edge.From }, selected)
+	// Children of the root, transitively. The root and its children are the objects whose
+	// references and selecting services are included.
+	family := walk(root, func(node Node) []Edge { return filterEdges(outgoing[node], EdgeOwner) },
+		func(edge Edge) Node { return edge.To }, selected)
+	for _, node := range family {
+		for _, edge := range filterEdges(outgoing[node], EdgeReference) {
+			selected[edge] = true
+		}
+		for _, edge := range filterEdges(incoming[node], EdgeSelector) {
+			selected[edge] = true
+		}
+	}
+	// Objects using the root, when it is a referenced object, and pods selected by the root, when
+	// it is a service.
+	for _, edge := range filterEdges(incoming[root], EdgeReference) {
+		selected[edge] = true
+	}
+	for _, edge := range filterEdges(outgoing[root], EdgeSelector) {
+		selected[edge] = true
+	}
+
+	result := &RelatedObjects{Root: root, Nodes: []Node{root}, Edges: []Edge{}}
+	nodes := map[Node]bool{root: true}
+	for edge := range selected {
+		result.Edges = append(result.Edges, edge)
+		for _, node := range []Node{edge.From, edge.To} {
+			if !nodes[node] {
+				nodes[node] = true
+				result.Nodes = append(result.Nodes, node)
+			}
+		}
+	}
+
+	sort.Slice(result.Nodes[1:], func(i, j int) bool {
+		return lessNode(result.Nodes[i+1], result.Nodes[j+1])
+	})
+	sort.Slice(result.Edges, func(i, j int) bool {
+		a, b := result.Edges[i], result.Edges[j]
+		if a.From != b.From {
+			return lessNode(a.From, b.From)
+		}
+		if a.To != b.To {
+			return lessNode(a.To, b.To)
+		}
+		return a.Type < b.Type
+	})
+	return result
+}
+
+// walk follows edges starting at the root, adds them to selected and returns visited nodes,
+// including the root.
+func walk(root Node, next func(Node) []Edge, target func(Edge) Node, selected map[Edge]bool) []Node {
+	visited := map[Node]bool{root: true}
+	queue := []Node{root}
+	for i := 0; i < len(queue); i++ {
+		for _, edge := range next(queue[i]) {
+			selected[edge] = true
+			node := target(edge)
+			if !visited[node] {
+				visited[node] = true
+				queue = append(queue, node)
+			}
+		}
+	}
+	return queue
+}
+
+func filterEdges(edges []Edge, edgeType string) []Edge {
+	var result []Edge
+	for _, edge := range edges {
+		if edge.Type == edgeType {
+			result = append(result, edge)
+		}
+	}
+	return result
+}
+
+func lessNode(a, b Node) bool {
+	if a.Kind != b.Kind {
+		return a.Kind < b.Kind
+	}
+	return a.Name < b.Name
+}
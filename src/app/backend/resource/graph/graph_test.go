@@ -0,0 +1,200 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func newTestObjects() []runtime.Object {
+	controller := true
+	podSpec := v1.PodSpec{
+		ServiceAccountName: "web",
+		Volumes: []v1.Volume{{
+			Name:         "config",
+			VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "web-config"}}},
+		}},
+		Containers: []v1.Container{{
+			Name: "web",
+			Env: []v1.EnvVar{{Name: "PASSWORD", ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "web-secret"}, Key: "password"}}}},
+		}},
+	}
+	return []runtime.Object{
+		&extensions.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       extensions.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: podSpec}},
+		},
+		&extensions.ReplicaSet{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web-1", Namespace: "default", OwnerReferences: []metaV1.OwnerReference{
+				{Kind: "Deployment", Name: "web", Controller: &controller}}},
+			Spec: extensions.ReplicaSetSpec{Template: v1.PodTemplateSpec{Spec: podSpec}},
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web-1-a", Namespace: "default", Labels: map[string]string{"app": "web"},
+				OwnerReferences: []metaV1.OwnerReference{{Kind: "ReplicaSet", Name: "web-1", Controller: &controller}}},
+			Spec: podSpec,
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "other", Namespace: "default", Labels: map[string]string{"app": "other"}},
+			Spec:       v1.PodSpec{ServiceAccountName: "web"},
+		},
+		&v1.Service{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       v1.ServiceSpec{Selector: map[string]string{"app": "web"}},
+		},
+		&v1.ConfigMap{ObjectMeta: metaV1.ObjectMeta{Name: "web-config", Namespace: "default"}},
+		&v1.ServiceAccount{ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"}},
+	}
+}
+
+func node(kind api.ResourceKind, name string) Node {
+	return Node{Kind: kind, Namespace: "default", Name: name}
+}
+
+func TestGetRelatedObjectsOfDeployment(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestObjects()...)
+
+	actual, err := GetRelatedObjects(client, api.ResourceKindDeployment, "default", "web")
+	if err != nil {
+		t.Fatalf("GetRelatedObjects(): unexpected error %v", err)
+	}
+
+	deployment := node(api.ResourceKindDeployment, "web")
+	rs := node(api.ResourceKindReplicaSet, "web-1")
+	pod := node(api.ResourceKindPod, "web-1-a")
+	configMap := node(api.ResourceKindConfigMap, "web-config")
+	secret := node(api.ResourceKindSecret, "web-secret")
+	sa := node(api.ResourceKindServiceAccount, "web")
+	service := node(api.ResourceKindService, "web")
+	expected := &RelatedObjects{
+		Root:  deployment,
+		Nodes: []Node{deployment, configMap, pod, rs, secret, service, sa},
+		Edges: []Edge{
+			{From: deployment, To: configMap, Type: EdgeReference},
+			{From: deployment, To: rs, Type: EdgeOwner},
+			{From: deployment, To: secret, Type: EdgeReference},
+			{From: deployment, To: sa, Type: EdgeReference},
+			{From: pod, To: configMap, Type: EdgeReference},
+			{From: pod, To: secret, Type: EdgeReference},
+			{From: pod, To: sa, Type: EdgeReference},
+			{From: rs, To: configMap, Type: EdgeReference},
+			{From: rs, To: pod, Type: EdgeOwner},
+			{From: rs, To: secret, Type: EdgeReference},
+			{From: rs, To: sa, Type: EdgeReference},
+			{From: service, To: pod, Type: EdgeSelector},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetRelatedObjects() ==\n%#v\nexpected\n%#v", actual, expected)
+	}
+}
+
+func TestGetRelatedObjectsOfPod(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestObjects()...)
+
+	actual, err := GetRelatedObjects(client, api.ResourceKindPod, "default", "web-1-a")
+	if err != nil {
+		t.Fatalf("GetRelatedObjects(): unexpected error %v", err)
+	}
+
+	var owners []Node
+	for _, edge := range actual.Edges {
+		if edge.Type == EdgeOwner {
+			owners = append(owners, edge.From)
+		}
+	}
+	expected := []Node{node(api.ResourceKindDeployment, "web"), node(api.ResourceKindReplicaSet, "web-1")}
+	if !reflect.DeepEqual(owners, expected) {
+		t.Errorf("Expected owners %#v but got %#v", expected, owners)
+	}
+}
+
+func TestGetRelatedObjectsOfServiceAccount(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestObjects()...)
+
+	actual, err := GetRelatedObjects(client, api.ResourceKindServiceAccount, "default", "web")
+	if err != nil {
+		t.Fatalf("GetRelatedObjects(): unexpected error %v", err)
+	}
+
+	var users []Node
+	for _, edge := range actual.Edges {
+		users = append(users, edge.From)
+	}
+	expected := []Node{
+		node(api.ResourceKindDeployment, "web"),
+		node(api.ResourceKindPod, "other"),
+		node(api.ResourceKindPod, "web-1-a"),
+		node(api.ResourceKindReplicaSet, "web-1"),
+	}
+	if !reflect.DeepEqual(users, expected) {
+		t.Errorf("Expected users %#v but got %#v", expected, users)
+	}
+}
+
+func TestGetRelatedObjectsErrors(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestObjects()...)
+
+	_, err := GetRelatedObjects(client, api.ResourceKindSecret, "default", "missing")
+	if !errorsK8s.IsNotFound(err) {
+		t.Errorf("Expected not found error for missing secret but got %v", err)
+	}
+	_, err = GetRelatedObjects(client, api.ResourceKindDeployment, "default", "missing")
+	if !errorsK8s.IsNotFound(err) {
+		t.Errorf("Expected not found error for missing deployment but got %v", err)
+	}
+	_, err = GetRelatedObjects(client, api.ResourceKindNode, "", "node-1")
+	if !errorsK8s.IsBadRequest(err) {
+		t.Errorf("Expected bad request error for node but got %v", err)
+	}
+}
+
+func TestGetPodSpecReferences(t *testing.T) {
+	spec := &v1.PodSpec{
+		Volumes: []v1.Volume{
+			{VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "tls"}}},
+			{VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}},
+			{VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{Sources: []v1.VolumeProjection{
+				{ConfigMap: &v1.ConfigMapProjection{LocalObjectReference: v1.LocalObjectReference{Name: "settings"}}},
+			}}}},
+		},
+		InitContainers: []v1.Container{{EnvFrom: []v1.EnvFromSource{
+			{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "tls"}}},
+		}}},
+		ImagePullSecrets: []v1.LocalObjectReference{{Name: "registry"}},
+	}
+
+	actual := GetPodSpecReferences("default", spec)
+
+	expected := []Node{
+		node(api.ResourceKindSecret, "tls"),
+		node(api.ResourceKindPersistentVolumeClaim, "data"),
+		node(api.ResourceKindConfigMap, "settings"),
+		node(api.ResourceKindSecret, "registry"),
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetPodSpecReferences() == %#v, expected %#v", actual, expected)
+	}
+}
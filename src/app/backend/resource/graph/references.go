@@ -0,0 +1,147 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// Ways in which a pod spec can reference an object.
+const (
+	ReferenceVolume          = "volume"
+	ReferenceProjectedVolume = "projectedVolume"
+	ReferenceEnv             = "env"
+	ReferenceEnvFrom         = "envFrom"
+	ReferenceImagePullSecret = "imagePullSecret"
+	ReferenceServiceAccount  = "serviceAccount"
+)
+
+// Reference describes a single use of an object by a pod spec.
+type Reference struct {
+	Type string `json:"type"`
+
+	// Volume is the name of the volume, for volume references.
+	Volume string `json:"volume,omitempty"`
+
+	// Container is the name of the container, for environment references.
+	Container string `json:"container,omitempty"`
+
+	// Env is the name of the environment variable and Key is the referenced key, for env
+	// references.
+	Env string `json:"env,omitempty"`
+	Key string `json:"key,omitempty"`
+}
+
+// podSpecReference is a reference together with the referenced object.
+type podSpecReference struct {
+	node      Node
+	reference Reference
+}
+
+// GetPodSpecReferences returns config maps, secrets, persistent volume claims and the service
+// account used by the given pod spec. Every object is returned once.
+func GetPodSpecReferences(namespace string, spec *v1.PodSpec) []Node {
+	seen := map[Node]bool{}
+	var nodes []Node
+	for _, ref := range getPodSpecReferences(namespace, spec) {
+		if !seen[ref.node] {
+			seen[ref.node] = true
+			nodes = append(nodes, ref.node)
+		}
+	}
+	return nodes
+}
+
+// getPodSpecReferences returns every reference of the given pod spec in the order of the spec.
+// Object can be referenced multiple times.
+func getPodSpecReferences(namespace string, spec *v1.PodSpec) []podSpecReference {
+	refs := &referenceList{namespace: namespace}
+
+	for _, volume := range spec.Volumes {
+		ref := Reference{Type: ReferenceVolume, Volume: volume.Name}
+		if volume.ConfigMap != nil {
+			refs.add(api.ResourceKindConfigMap, volume.ConfigMap.Name, ref)
+		}
+		if volume.Secret != nil {
+			refs.add(api.ResourceKindSecret, volume.Secret.SecretName, ref)
+		}
+		if volume.PersistentVolumeClaim != nil {
+			refs.add(api.ResourceKindPersistentVolumeClaim, volume.PersistentVolumeClaim.ClaimName, ref)
+		}
+		if volume.Projected != nil {
+			ref.Type = ReferenceProjectedVolume
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					refs.add(api.ResourceKindConfigMap, source.ConfigMap.Name, ref)
+				}
+				if source.Secret != nil {
+					refs.add(api.ResourceKindSecret, source.Secret.Name, ref)
+				}
+			}
+		}
+	}
+
+	containers := append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			ref := Reference{Type: ReferenceEnv, Container: container.Name, Env: env.Name}
+			if selector := env.ValueFrom.ConfigMapKeyRef; selector != nil {
+				ref.Key = selector.Key
+				refs.add(api.ResourceKindConfigMap, selector.Name, ref)
+			}
+			if selector := env.ValueFrom.SecretKeyRef; selector != nil {
+				ref.Key = selector.Key
+				refs.add(api.ResourceKindSecret, selector.Name, ref)
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			ref := Reference{Type: ReferenceEnvFrom, Container: container.Name}
+			if envFrom.ConfigMapRef != nil {
+				refs.add(api.ResourceKindConfigMap, envFrom.ConfigMapRef.Name, ref)
+			}
+			if envFrom.SecretRef != nil {
+				refs.add(api.ResourceKindSecret, envFrom.SecretRef.Name, ref)
+			}
+		}
+	}
+
+	for _, secret := range spec.ImagePullSecrets {
+		refs.add(api.ResourceKindSecret, secret.Name, Reference{Type: ReferenceImagePullSecret})
+	}
+	refs.add(api.ResourceKindServiceAccount, spec.ServiceAccountName,
+		Reference{Type: ReferenceServiceAccount})
+
+	return refs.references
+}
+
+// referenceList collects references in the order they were found, skipping empty names.
+type referenceList struct {
+	namespace  string
+	references []podSpecReference
+}
+
+func (r *referenceList) add(kind api.ResourceKind, name string, reference Reference) {
+	if name == "" {
+		return
+	}
+	r.references = append(r.references, podSpecReference{
+		node:      Node{Kind: kind, Namespace: r.namespace, Name: name},
+		reference: reference,
+	})
+}
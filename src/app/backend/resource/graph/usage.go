@@ -0,0 +1,134 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	client "k8s.io/client-go/kubernetes"
+)
+
+// ObjectUser is an object, whose pod spec uses the config map or secret.
+type ObjectUser struct {
+	Object Node `json:"object"`
+
+	// Controller is the top-level owner of the object, i.e. deployment of a pod. It is nil for
+	// objects without owners.
+	Controller *Node `json:"controller"`
+
+	// References lists all the ways in which the object uses the config map or secret.
+	References []Reference `json:"references"`
+}
+
+// ObjectUsage lists objects using config map or secret, so that the impact of its change or
+// deletion is known.
+type ObjectUsage struct {
+	Object Node         `json:"object"`
+	Users  []ObjectUser `json:"users"`
+
+	// Workloads are the distinct top-level objects affected by a change, i.e. controllers of the
+	// users or the users themselves when they have no owners.
+	Workloads []Node `json:"workloads"`
+}
+
+// Kinds, whose usage can be tracked.
+var usageKinds = map[api.ResourceKind]bool{
+	api.ResourceKindConfigMap: true,
+	api.ResourceKindSecret:    true,
+}
+
+// GetObjectUsage returns pods and workloads referencing the config map or secret through
+// volumes, projected volumes, environment variables or image pull secrets.
+func GetObjectUsage(client client.Interface, kind api.ResourceKind, namespace, name string) (
+	*ObjectUsage, error) {
+	if !usageKinds[kind] {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("usage of %s is not supported", kind))
+	}
+
+	logger.Infof("Getting usage of %s %s in %s namespace", kind, name, namespace)
+
+	object := Node{Kind: kind, Namespace: namespace, Name: name}
+	if err := checkExists(client, object); err != nil {
+		return nil, err
+	}
+	b, err := getNamespaceGraph(client, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return getObjectUsage(object, b.edges, b.references), nil
+}
+
+func getObjectUsage(object Node, edges []Edge, references map[Node][]podSpecReference) *ObjectUsage {
+	owners := map[Node]Node{}
+	for _, edge := range edges {
+		if edge.Type == EdgeOwner {
+			owners[edge.To] = edge.From
+		}
+	}
+
+	result := &ObjectUsage{Object: object, Users: []ObjectUser{}, Workloads: []Node{}}
+	workloads := map[Node]bool{}
+	for user, refs := range references {
+		var used []Reference
+		for _, ref := range refs {
+			if ref.node == object {
+				used = append(used, ref.reference)
+			}
+		}
+		if len(used) == 0 {
+			continue
+		}
+
+		objectUser := ObjectUser{Object: user, References: used}
+		workload := user
+		if controller, ok := getController(user, owners); ok {
+			objectUser.Controller = &controller
+			workload = controller
+		}
+		result.Users = append(result.Users, objectUser)
+		if !workloads[workload] {
+			workloads[workload] = true
+			result.Workloads = append(result.Workloads, workload)
+		}
+	}
+
+	sort.Slice(result.Users, func(i, j int) bool {
+		return lessNode(result.Users[i].Object, result.Users[j].Object)
+	})
+	sort.Slice(result.Workloads, func(i, j int) bool {
+		return lessNode(result.Workloads[i], result.Workloads[j])
+	})
+	return result
+}
+
+// getController returns the top-level owner of the object by following owner references.
+func getController(object Node, owners map[Node]Node) (Node, bool) {
+	visited := map[Node]bool{object: true}
+	current := object
+	for {
+		owner, ok := owners[current]
+		if !ok || visited[owner] {
+			break
+		}
+		visited[owner] = true
+		current = owner
+	}
+	return current, current != object
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func TestGetObjectUsage(t *testing.T) {
+	objects := append(newTestObjects(), &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "debug", Namespace: "default"},
+		Spec: v1.PodSpec{Containers: []v1.Container{{
+			Name: "shell",
+			EnvFrom: []v1.EnvFromSource{{ConfigMapRef: &v1.ConfigMapEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "web-config"}}}},
+		}}},
+	})
+	client := fake.NewSimpleClientset(objects...)
+
+	actual, err := GetObjectUsage(client, api.ResourceKindConfigMap, "default", "web-config")
+	if err != nil {
+		t.Fatalf("GetObjectUsage() returned error: %s", err)
+	}
+
+	deployment := node(api.ResourceKindDeployment, "web")
+	volume := []Reference{{Type: ReferenceVolume, Volume: "config"}}
+	expected := &ObjectUsage{
+		Object: node(api.ResourceKindConfigMap, "web-config"),
+		Users: []ObjectUser{
+			{Object: deployment, References: volume},
+			{Object: node(api.ResourceKindPod, "debug"),
+				References: []Reference{{Type: ReferenceEnvFrom, Container: "shell"}}},
+			{Object: node(api.ResourceKindPod, "web-1-a"), Controller: &deployment, References: volume},
+			{Object: node(api.ResourceKindReplicaSet, "web-1"), Controller: &deployment, References: volume},
+		},
+		Workloads: []Node{deployment, node(api.ResourceKindPod, "debug")},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetObjectUsage() == \ngot %#v, \nexpected %#v", actual, expected)
+	}
+}
+
+func TestGetObjectUsageOfSecretKey(t *testing.T) {
+	objects := append(newTestObjects(),
+		&v1.Secret{ObjectMeta: metaV1.ObjectMeta{Name: "web-secret", Namespace: "default"}})
+	client := fake.NewSimpleClientset(objects...)
+
+	actual, err := GetObjectUsage(client, api.ResourceKindSecret, "default", "web-secret")
+	if err != nil {
+		t.Fatalf("GetObjectUsage() returned error: %s", err)
+	}
+
+	expected := []Reference{{Type: ReferenceEnv, Container: "web", Env: "PASSWORD", Key: "password"}}
+	if len(actual.Users) != 3 || !reflect.DeepEqual(actual.Users[0].References, expected) {
+		t.Errorf("GetObjectUsage() == %#v, expected 3 users with references %#v", actual.Users, expected)
+	}
+}
+
+func TestGetObjectUsageErrors(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestObjects()...)
+
+	_, err := GetObjectUsage(client, api.ResourceKindService, "default", "web")
+	if !errorsK8s.IsBadRequest(err) {
+		t.Errorf("GetObjectUsage(service) == %v, expected bad request", err)
+	}
+
+	_, err = GetObjectUsage(client, api.ResourceKindConfigMap, "default", "missing")
+	if !errorsK8s.IsNotFound(err) {
+		t.Errorf("GetObjectUsage(missing) == %v, expected not found", err)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// RollbackRelease rolls the Helm release back to the given revision, the same way as
+// "helm rollback" does without hooks: manifest of the revision is applied and stored as a new
+// revision, which supersedes the deployed one. Revision 0 means the previous revision.
+func RollbackRelease(client kubernetes.Interface, config *rest.Config, namespace, name string,
+	revision int) (*ReleaseDetail, error) {
+	logger.Infof("Rolling back %s Helm release in %s namespace to revision %d", name, namespace,
+		revision)
+
+	revisions, err := getRevisions(client, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, newReleaseNotFoundError(name)
+	}
+
+	latest := revisions[len(revisions)-1]
+	if revision == 0 {
+		revision = latest.Version - 1
+	}
+	var target *storedRelease
+	for _, release := range revisions {
+		if release.Version == revision {
+			target = release
+		}
+	}
+	if target == nil {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("revision %d of %s Helm release not found",
+			revision, name))
+	}
+
+	result, err := apply.Apply(client, config, &apply.ApplySpec{
+		Content:   target.Manifest,
+		Namespace: namespace,
+		Force:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if failures := getFailures(result); len(failures) > 0 {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("could not apply manifest of revision %d: %s",
+			revision, strings.Join(failures, "; ")))
+	}
+
+	for _, release := range revisions {
+		if release.Info.Status == StatusDeployed {
+			if err := setStatus(client, release, StatusSuperseded); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	now := time.Now().Format(time.RFC3339Nano)
+	raw := copyMap(target.raw)
+	info := copyMap(asMap(raw["info"]))
+	info["first_deployed"] = latest.Info.FirstDeployed
+	info["last_deployed"] = now
+	info["status"] = StatusDeployed
+	info["description"] = fmt.Sprintf("Rollback to %d", revision)
+	raw["info"] = info
+	raw["version"] = latest.Version + 1
+
+	secret, err := newReleaseSecret(name, namespace, latest.Version+1, StatusDeployed, raw)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.CoreV1().Secrets(namespace).Create(secret); err != nil {
+		return nil, err
+	}
+
+	return GetReleaseDetail(client, namespace, name)
+}
+
+// UninstallRelease deletes objects of the latest revision of the Helm release and all its
+// revisions, the same way as "helm uninstall" does without hooks and --keep-history.
+func UninstallRelease(client kubernetes.Interface, config *rest.Config, namespace,
+	name string) (*apply.ApplyResult, error) {
+	logger.Infof("Uninstalling %s Helm release in %s namespace", name, namespace)
+
+	revisions, err := getRevisions(client, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, newReleaseNotFoundError(name)
+	}
+
+	result, err := apply.Delete(client, config, revisions[len(revisions)-1].Manifest, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if failures := getFailures(result); len(failures) > 0 {
+		// Revisions are kept, so that uninstall can be retried.
+		return result, nil
+	}
+
+	for _, release := range revisions {
+		err := client.CoreV1().Secrets(namespace).Delete(release.secret.Name, &metaV1.DeleteOptions{})
+		if err != nil && !errorsK8s.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// setStatus changes status of the stored revision.
+func setStatus(client kubernetes.Interface, release *storedRelease, status string) error {
+	raw := copyMap(release.raw)
+	info := copyMap(asMap(raw["info"]))
+	info["status"] = status
+	raw["info"] = info
+
+	data, err := encodeRelease(raw)
+	if err != nil {
+		return err
+	}
+
+	secret := *release.secret
+	secret.Labels = make(map[string]string, len(release.secret.Labels))
+	for key, value := range release.secret.Labels {
+		secret.Labels[key] = value
+	}
+	secret.Labels[statusLabel] = status
+	secret.Data = map[string][]byte{releaseKey: data}
+
+	_, err = client.CoreV1().Secrets(secret.Namespace).Update(&secret)
+	return err
+}
+
+// getFailures returns errors of objects that failed to be applied or deleted.
+func getFailures(result *apply.ApplyResult) []string {
+	failures := make([]string, 0)
+	for _, obj := range result.Objects {
+		if obj.Operation == apply.OperationFailed {
+			failures = append(failures, fmt.Sprintf("%s %s: %s", obj.Kind, obj.Name, obj.Error))
+		}
+	}
+	return failures
+}
+
+func asMap(value interface{}) map[string]interface{} {
+	if m, ok := value.(map[string]interface{}); ok {
+		return m
+	}
+	return map[string]interface{}{}
+}
+
+// copyMap makes a shallow copy of the map.
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,166 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ChartDetail is a version of a chart with its README, default values and values schema.
+type ChartDetail struct {
+	Chart
+
+	Home        string       `json:"home"`
+	Sources     []string     `json:"sources"`
+	Maintainers []Maintainer `json:"maintainers"`
+
+	// Content of README.md of the chart.
+	Readme string `json:"readme"`
+
+	// Default values of the chart, as YAML.
+	Values string `json:"values"`
+
+	// JSON schema of values from values.schema.json, if the chart has one. Frontend renders it as
+	// a form.
+	ValuesSchema json.RawMessage `json:"valuesSchema,omitempty"`
+}
+
+// Files of a chart archive shown in chart detail.
+const (
+	readmeFile       = "README.md"
+	valuesFile       = "values.yaml"
+	valuesSchemaFile = "values.schema.json"
+)
+
+// GetChartDetail returns detail of the chart version, or of the latest version if version is
+// empty. The chart archive is downloaded from the repository to read its files.
+func GetChartDetail(repos []Repository, repoName, name, version string) (*ChartDetail, error) {
+	logger.Infof("Getting details of %s chart from %s Helm repository", name, repoName)
+
+	repo, err := findRepository(repos, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := getRepositoryIndex(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := index.Entries[name]
+	if len(versions) == 0 {
+		return nil, errorsK8s.NewNotFound(chartResource, name)
+	}
+	selected := versions[0]
+	if len(version) > 0 {
+		found := false
+		for _, candidate := range versions {
+			if candidate.Version == version {
+				selected, found = candidate, true
+				break
+			}
+		}
+		if !found {
+			return nil, errorsK8s.NewNotFound(chartResource, name+"-"+version)
+		}
+	}
+	if len(selected.URLs) == 0 {
+		return nil, errorsK8s.NewServiceUnavailable(fmt.Sprintf("%s chart has no archive", name))
+	}
+
+	location, err := resolveURL(repo, selected.URLs[0])
+	if err != nil {
+		return nil, errorsK8s.NewServiceUnavailable(err.Error())
+	}
+	archive, err := download(location)
+	if err != nil {
+		return nil, errorsK8s.NewServiceUnavailable(err.Error())
+	}
+	files, err := readChartFiles(archive, readmeFile, valuesFile, valuesSchemaFile)
+	if err != nil {
+		return nil, errorsK8s.NewServiceUnavailable(fmt.Sprintf("invalid archive of %s chart: %v",
+			name, err))
+	}
+
+	detail := &ChartDetail{
+		Chart:       toChart(repo, name, selected),
+		Home:        selected.Home,
+		Sources:     selected.Sources,
+		Maintainers: selected.Maintainers,
+		Readme:      string(files[readmeFile]),
+		Values:      string(files[valuesFile]),
+	}
+	detail.Versions = make([]string, 0, len(versions))
+	for _, candidate := range versions {
+		detail.Versions = append(detail.Versions, candidate.Version)
+	}
+
+	if schema, exists := files[valuesSchemaFile]; exists {
+		if !json.Valid(schema) {
+			return nil, errorsK8s.NewServiceUnavailable(fmt.Sprintf("invalid %s of %s chart",
+				valuesSchemaFile, name))
+		}
+		detail.ValuesSchema = json.RawMessage(schema)
+	}
+	return detail, nil
+}
+
+// readChartFiles reads given files from the root directory of gzipped chart archive.
+func readChartFiles(archive []byte, names ...string) (map[string][]byte, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gzipReader.Close()
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	files := make(map[string][]byte)
+	reader := tar.NewReader(gzipReader)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Files of the chart are in a directory named after the chart.
+		parts := strings.Split(strings.TrimPrefix(header.Name, "./"), "/")
+		if len(parts) != 2 || !wanted[parts[1]] {
+			continue
+		}
+
+		data, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		files[parts[1]] = data
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestGetChartDetail(t *testing.T) {
+	server := newTestRepository(t)
+	defer server.Close()
+	repos := []Repository{{Name: "stable", URL: server.URL + "/charts"}}
+
+	detail, err := GetChartDetail(repos, "stable", "redis", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.Version != "2.0.0" || detail.Home != "https://redis.io" ||
+		detail.Readme != "# Redis\n" || detail.Values != "replicas: 1\n" ||
+		string(detail.ValuesSchema) != `{"type": "object"}` {
+		t.Errorf("GetChartDetail() == %#v, expected detail of redis 2.0.0", detail)
+	}
+
+	detail, err = GetChartDetail(repos, "stable", "redis", "1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.Version != "1.0.0" || detail.Readme != "" || detail.Values != "replicas: 2\n" ||
+		detail.ValuesSchema != nil {
+		t.Errorf("GetChartDetail() == %#v, expected detail of redis 1.0.0", detail)
+	}
+
+	if _, err := GetChartDetail(repos, "stable", "redis", "3.0.0"); !errorsK8s.IsNotFound(err) {
+		t.Errorf("GetChartDetail() returns %v for unknown version, expected not found", err)
+	}
+	if _, err := GetChartDetail(repos, "stable", "postgres", ""); !errorsK8s.IsNotFound(err) {
+		t.Errorf("GetChartDetail() returns %v for unknown chart, expected not found", err)
+	}
+	if _, err := GetChartDetail(repos, "incubator", "redis", ""); !errorsK8s.IsBadRequest(err) {
+		t.Errorf("GetChartDetail() returns %v for unknown repository, expected bad request", err)
+	}
+}
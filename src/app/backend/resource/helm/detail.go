@@ -0,0 +1,79 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ReleaseDetail is the latest revision of a Helm release with its manifest, values and history.
+type ReleaseDetail struct {
+	Release
+
+	// Notes rendered from NOTES.txt of the chart.
+	Notes string `json:"notes"`
+
+	// Manifest rendered from templates of the chart.
+	Manifest string `json:"manifest"`
+
+	// Values supplied by the user on install or upgrade.
+	Values map[string]interface{} `json:"values"`
+
+	// Default values of the chart.
+	ChartValues map[string]interface{} `json:"chartValues"`
+
+	// All revisions of the release, from the newest.
+	History []Release `json:"history"`
+}
+
+// GetReleaseDetail returns detail of the Helm release.
+func GetReleaseDetail(client kubernetes.Interface, namespace, name string) (*ReleaseDetail, error) {
+	logger.Infof("Getting details of %s Helm release in %s namespace", name, namespace)
+
+	revisions, err := getRevisions(client, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, newReleaseNotFoundError(name)
+	}
+
+	latest := revisions[len(revisions)-1]
+	detail := &ReleaseDetail{
+		Release:     toRelease(latest),
+		Notes:       latest.Info.Notes,
+		Manifest:    latest.Manifest,
+		Values:      latest.Config,
+		ChartValues: latest.Chart.Values,
+		History:     make([]Release, 0, len(revisions)),
+	}
+	for i := len(revisions) - 1; i >= 0; i-- {
+		detail.History = append(detail.History, toRelease(revisions[i]))
+	}
+	return detail, nil
+}
+
+// Resources used in errors about Helm releases and charts.
+var (
+	releaseResource = schema.GroupResource{Group: "helm.sh", Resource: "releases"}
+	chartResource   = schema.GroupResource{Group: "helm.sh", Resource: "charts"}
+)
+
+func newReleaseNotFoundError(name string) error {
+	return errorsK8s.NewNotFound(releaseResource, name)
+}
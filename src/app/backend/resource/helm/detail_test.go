@@ -0,0 +1,94 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"reflect"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+func TestGetReleaseDetail(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newTestReleaseSecret("web", "default", 1, StatusSuperseded),
+		newTestReleaseSecret("web", "default", 2, StatusDeployed),
+		newTestReleaseSecret("web", "prod", 3, StatusDeployed),
+	)
+
+	detail, err := GetReleaseDetail(client, "default", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if detail.Revision != 2 || detail.Notes != "Visit http://web" {
+		t.Errorf("GetReleaseDetail() returns revision %d with notes %q, expected revision 2",
+			detail.Revision, detail.Notes)
+	}
+	if expected := map[string]interface{}{"replicas": float64(2)}; !reflect.DeepEqual(detail.Values,
+		expected) {
+		t.Errorf("GetReleaseDetail() returns values %v, expected %v", detail.Values, expected)
+	}
+	history := []int{}
+	for _, release := range detail.History {
+		history = append(history, release.Revision)
+	}
+	if expected := []int{2, 1}; !reflect.DeepEqual(history, expected) {
+		t.Errorf("GetReleaseDetail() returns history %v, expected %v", history, expected)
+	}
+
+	if _, err := GetReleaseDetail(client, "default", "db"); !errorsK8s.IsNotFound(err) {
+		t.Errorf("GetReleaseDetail() returns %v for unknown release, expected not found error", err)
+	}
+}
+
+func TestRollbackReleaseToUnknownRevision(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestReleaseSecret("web", "default", 1, StatusDeployed))
+
+	// Revision 0 of the first revision does not exist.
+	for _, revision := range []int{0, 5} {
+		_, err := RollbackRelease(client, &rest.Config{}, "default", "web", revision)
+		if !errorsK8s.IsBadRequest(err) {
+			t.Errorf("RollbackRelease() to revision %d returns %v, expected bad request", revision, err)
+		}
+	}
+}
+
+func TestUninstallRelease(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newTestReleaseSecret("web", "default", 1, StatusSuperseded),
+		newTestReleaseSecret("web", "default", 2, StatusDeployed),
+		newTestReleaseSecret("db", "default", 1, StatusDeployed),
+	)
+
+	if _, err := UninstallRelease(client, &rest.Config{}, "default", "web"); err != nil {
+		t.Fatal(err)
+	}
+
+	secrets, err := client.CoreV1().Secrets("default").List(metaV1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, secret := range secrets.Items {
+		names = append(names, secret.Name)
+	}
+	if expected := []string{"sh.helm.release.v1.db.v1"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Secrets after uninstall are %v, expected %v", names, expected)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// maxReleaseNameLength is the maximum length of release names accepted by Helm.
+const maxReleaseNameLength = 53
+
+// InstallSpec is a specification of a chart installation.
+type InstallSpec struct {
+	// Name of the release.
+	ReleaseName string `json:"releaseName"`
+
+	// Namespace the release is installed to.
+	Namespace string `json:"namespace"`
+
+	// Version of the chart. Empty means the latest version.
+	Version string `json:"version"`
+
+	// Values overriding default values of the chart, e.g. filled in a form rendered from values
+	// schema of the chart.
+	Values map[string]interface{} `json:"values"`
+
+	// ValuesYAML overrides default values and Values of the chart.
+	ValuesYAML string `json:"valuesYaml"`
+}
+
+// UpgradeSpec is a specification of a release upgrade.
+type UpgradeSpec struct {
+	// Repository the chart is downloaded from.
+	Repository string `json:"repository"`
+
+	// Chart the release is upgraded to. Empty means the chart of the release.
+	Chart string `json:"chart"`
+
+	// Version of the chart. Empty means the latest version.
+	Version string `json:"version"`
+
+	// Values overriding default values of the chart.
+	Values map[string]interface{} `json:"values"`
+
+	// ValuesYAML overrides default values and Values of the chart.
+	ValuesYAML string `json:"valuesYaml"`
+
+	// ReuseValues merges values of the current revision with Values and ValuesYAML, otherwise
+	// values of the current revision are dropped.
+	ReuseValues bool `json:"reuseValues"`
+}
+
+// helmBinary is path to the Helm 3 binary charts are installed with. Rendering of chart templates
+// is not implemented in Dashboard, so charts can not be installed without it.
+var helmBinary string
+
+// runHelm runs Helm with given arguments and returns its combined output.
+var runHelm = func(args ...string) ([]byte, error) {
+	var output bytes.Buffer
+	cmd := exec.Command(helmBinary, args...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err := cmd.Run()
+	return output.Bytes(), err
+}
+
+// SetHelmBinary configures path to the Helm binary used to install charts.
+func SetHelmBinary(path string) {
+	helmBinary = path
+}
+
+// InstallChart installs the chart from the repository with "helm install". Helm runs with
+// kubeconfig of the user, so the release is created with permissions of the user and values are
+// validated against values schema of the chart.
+func InstallChart(client kubernetes.Interface, kubeConfig clientcmdapi.Config, repos []Repository,
+	repoName, chart string, spec *InstallSpec) (*ReleaseDetail, error) {
+	if len(helmBinary) == 0 {
+		return nil, errorsK8s.NewServiceUnavailable("installing charts is disabled, Dashboard " +
+			"has to be started with --helm-binary")
+	}
+	if err := validateInstallSpec(chart, spec); err != nil {
+		return nil, err
+	}
+
+	repo, err := findRepository(repos, repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Installing %s chart from %s Helm repository as %s release in %s namespace", chart,
+		repoName, spec.ReleaseName, spec.Namespace)
+
+	if err := runHelmChart(kubeConfig, "install", repo, chart, spec); err != nil {
+		return nil, err
+	}
+
+	return GetReleaseDetail(client, spec.Namespace, spec.ReleaseName)
+}
+
+// UpgradeRelease upgrades the Helm release to the chart from the repository with "helm upgrade".
+// Like on install, Helm runs with kubeconfig of the user.
+func UpgradeRelease(client kubernetes.Interface, kubeConfig clientcmdapi.Config, repos []Repository,
+	namespace, name string, spec *UpgradeSpec) (*ReleaseDetail, error) {
+	if len(helmBinary) == 0 {
+		return nil, errorsK8s.NewServiceUnavailable("upgrading releases is disabled, Dashboard " +
+			"has to be started with --helm-binary")
+	}
+
+	revisions, err := getRevisions(client, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, newReleaseNotFoundError(name)
+	}
+
+	chart := spec.Chart
+	if len(chart) == 0 {
+		chart = revisions[len(revisions)-1].Chart.Metadata.Name
+	}
+	installSpec := &InstallSpec{
+		ReleaseName: name,
+		Namespace:   namespace,
+		Version:     spec.Version,
+		Values:      spec.Values,
+		ValuesYAML:  spec.ValuesYAML,
+	}
+	if err := validateInstallSpec(chart, installSpec); err != nil {
+		return nil, err
+	}
+
+	repo, err := findRepository(repos, spec.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Upgrading %s Helm release in %s namespace to %s chart from %s Helm repository",
+		name, namespace, chart, spec.Repository)
+
+	flags := make([]string, 0)
+	if spec.ReuseValues {
+		flags = append(flags, "--reuse-values")
+	}
+	if err := runHelmChart(kubeConfig, "upgrade", repo, chart, installSpec, flags...); err != nil {
+		return nil, err
+	}
+
+	return GetReleaseDetail(client, namespace, name)
+}
+
+// runHelmChart runs the Helm command, e.g. "install" or "upgrade", of the release described by the
+// spec with the chart from the repository. Values and kubeconfig are passed to Helm in temporary
+// files.
+func runHelmChart(kubeConfig clientcmdapi.Config, command string, repo Repository, chart string,
+	spec *InstallSpec, flags ...string) error {
+	dir, err := ioutil.TempDir("", "helm-"+command)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	kubeConfigFile := dir + "/kubeconfig"
+	if err := clientcmd.WriteToFile(kubeConfig, kubeConfigFile); err != nil {
+		return err
+	}
+
+	values := spec.Values
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	valuesData, err := yaml.Marshal(values)
+	if err != nil {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("invalid values: %v", err))
+	}
+	valuesFile := dir + "/values.yaml"
+	if err := ioutil.WriteFile(valuesFile, valuesData, 0600); err != nil {
+		return err
+	}
+
+	args := []string{command, spec.ReleaseName, chart,
+		"--repo", repo.URL,
+		"--namespace", spec.Namespace,
+		"--values", valuesFile,
+		"--kubeconfig", kubeConfigFile,
+	}
+	if len(spec.ValuesYAML) > 0 {
+		if _, err := yaml.YAMLToJSON([]byte(spec.ValuesYAML)); err != nil {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("invalid values: %v", err))
+		}
+		yamlFile := dir + "/values-override.yaml"
+		if err := ioutil.WriteFile(yamlFile, []byte(spec.ValuesYAML), 0600); err != nil {
+			return err
+		}
+		args = append(args, "--values", yamlFile)
+	}
+	if len(spec.Version) > 0 {
+		args = append(args, "--version", spec.Version)
+	}
+	args = append(args, flags...)
+
+	if output, err := runHelm(args...); err != nil {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("could not %s %s chart: %s", command, chart,
+			strings.TrimSpace(string(output))))
+	}
+	return nil
+}
+
+// validateInstallSpec validates the spec. Chart and version must not start with a dash, as they
+// are passed to Helm as arguments.
+func validateInstallSpec(chart string, spec *InstallSpec) error {
+	if len(chart) == 0 || strings.HasPrefix(chart, "-") {
+		return errorsK8s.NewBadRequest("invalid chart name")
+	}
+	if errs := validation.IsDNS1123Label(spec.ReleaseName); len(errs) > 0 {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("invalid release name: %s",
+			strings.Join(errs, ", ")))
+	}
+	if len(spec.ReleaseName) > maxReleaseNameLength {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("release name can have at most %d characters",
+			maxReleaseNameLength))
+	}
+	if errs := validation.IsDNS1123Label(spec.Namespace); len(errs) > 0 {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("invalid namespace: %s",
+			strings.Join(errs, ", ")))
+	}
+	if strings.HasPrefix(spec.Version, "-") {
+		return errorsK8s.NewBadRequest("invalid chart version")
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes/fake"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestInstallChart(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestReleaseSecret("cache", "apps", 1, StatusDeployed))
+	repos := []Repository{{Name: "stable", URL: "https://charts.example.com"}}
+	kubeConfig := *clientcmdapi.NewConfig()
+
+	SetHelmBinary("")
+	_, err := InstallChart(client, kubeConfig, repos, "stable", "redis",
+		&InstallSpec{ReleaseName: "cache", Namespace: "apps"})
+	if statusErr, ok := err.(*errorsK8s.StatusError); !ok ||
+		statusErr.ErrStatus.Code != http.StatusServiceUnavailable {
+		t.Errorf("InstallChart() without Helm binary returns %v, expected service unavailable", err)
+	}
+
+	SetHelmBinary("helm")
+	defer SetHelmBinary("")
+	defer func(run func(...string) ([]byte, error)) { runHelm = run }(runHelm)
+
+	var args []string
+	var values string
+	runHelm = func(helmArgs ...string) ([]byte, error) {
+		args = helmArgs
+		data, err := ioutil.ReadFile(helmArgs[8])
+		values = string(data)
+		return nil, err
+	}
+
+	detail, err := InstallChart(client, kubeConfig, repos, "stable", "redis", &InstallSpec{
+		ReleaseName: "cache",
+		Namespace:   "apps",
+		Version:     "2.0.0",
+		Values:      map[string]interface{}{"replicas": 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.Name != "cache" {
+		t.Errorf("InstallChart() returns release %s, expected cache", detail.Name)
+	}
+	expectedArgs := []string{"install", "cache", "redis", "--repo", "https://charts.example.com",
+		"--namespace", "apps", "--values", args[8], "--kubeconfig", args[10], "--version", "2.0.0"}
+	if !reflect.DeepEqual(args, expectedArgs) {
+		t.Errorf("InstallChart() runs helm %v, expected %v", args, expectedArgs)
+	}
+	if values != "replicas: 3\n" {
+		t.Errorf("InstallChart() passes values %q, expected replicas: 3", values)
+	}
+
+	_, err = InstallChart(client, kubeConfig, repos, "stable", "redis",
+		&InstallSpec{ReleaseName: "cache", Namespace: "apps", ValuesYAML: "replicas: [3"})
+	if !errorsK8s.IsBadRequest(err) {
+		t.Errorf("InstallChart() returns %v for invalid values, expected bad request", err)
+	}
+
+	runHelm = func(...string) ([]byte, error) {
+		return []byte("Error: cannot re-use a name that is still in use\n"), errors.New("exit status 1")
+	}
+	_, err = InstallChart(client, kubeConfig, repos, "stable", "redis",
+		&InstallSpec{ReleaseName: "cache", Namespace: "apps"})
+	if !errorsK8s.IsBadRequest(err) {
+		t.Errorf("InstallChart() returns %v when Helm fails, expected bad request", err)
+	}
+}
+
+func TestUpgradeRelease(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestReleaseSecret("web", "apps", 1, StatusDeployed))
+	repos := []Repository{{Name: "stable", URL: "https://charts.example.com"}}
+	kubeConfig := *clientcmdapi.NewConfig()
+
+	SetHelmBinary("helm")
+	defer SetHelmBinary("")
+	defer func(run func(...string) ([]byte, error)) { runHelm = run }(runHelm)
+
+	var args []string
+	runHelm = func(helmArgs ...string) ([]byte, error) {
+		args = helmArgs
+		return nil, nil
+	}
+
+	detail, err := UpgradeRelease(client, kubeConfig, repos, "apps", "web",
+		&UpgradeSpec{Repository: "stable", Version: "1.0.2", ReuseValues: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.Name != "web" {
+		t.Errorf("UpgradeRelease() returns release %s, expected web", detail.Name)
+	}
+	expectedArgs := []string{"upgrade", "web", "nginx", "--repo", "https://charts.example.com",
+		"--namespace", "apps", "--values", args[8], "--kubeconfig", args[10], "--version", "1.0.2",
+		"--reuse-values"}
+	if !reflect.DeepEqual(args, expectedArgs) {
+		t.Errorf("UpgradeRelease() runs helm %v, expected %v", args, expectedArgs)
+	}
+
+	_, err = UpgradeRelease(client, kubeConfig, repos, "apps", "db",
+		&UpgradeSpec{Repository: "stable"})
+	if !errorsK8s.IsNotFound(err) {
+		t.Errorf("UpgradeRelease() of missing release returns %v, expected not found", err)
+	}
+
+	_, err = UpgradeRelease(client, kubeConfig, repos, "apps", "web",
+		&UpgradeSpec{Repository: "stable", Chart: "--debug"})
+	if !errorsK8s.IsBadRequest(err) {
+		t.Errorf("UpgradeRelease() returns %v for invalid chart, expected bad request", err)
+	}
+}
+
+func TestValidateInstallSpec(t *testing.T) {
+	cases := []struct {
+		chart string
+		spec  InstallSpec
+		valid bool
+	}{
+		{"redis", InstallSpec{ReleaseName: "cache", Namespace: "apps"}, true},
+		{"redis", InstallSpec{ReleaseName: "Cache", Namespace: "apps"}, false},
+		{"redis", InstallSpec{ReleaseName: strings.Repeat("a", 54), Namespace: "apps"}, false},
+		{"redis", InstallSpec{ReleaseName: "cache", Namespace: ""}, false},
+		{"redis", InstallSpec{ReleaseName: "cache", Namespace: "apps", Version: "--debug"}, false},
+		{"--debug", InstallSpec{ReleaseName: "cache", Namespace: "apps"}, false},
+	}
+
+	for _, c := range cases {
+		err := validateInstallSpec(c.chart, &c.spec)
+		if (err == nil) != c.valid {
+			t.Errorf("validateInstallSpec(%s, %#v) returns %v, expected valid %t", c.chart, c.spec,
+				err, c.valid)
+		}
+	}
+}
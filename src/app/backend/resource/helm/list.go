@@ -0,0 +1,150 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Release is the latest revision of a Helm release.
+type Release struct {
+	Name         string      `json:"name"`
+	Namespace    string      `json:"namespace"`
+	Revision     int         `json:"revision"`
+	Status       string      `json:"status"`
+	Chart        string      `json:"chart"`
+	ChartVersion string      `json:"chartVersion"`
+	AppVersion   string      `json:"appVersion"`
+	Updated      metaV1.Time `json:"updated"`
+	Description  string      `json:"description"`
+}
+
+// ReleaseList holds a list of Helm releases.
+type ReleaseList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+	Releases []Release    `json:"releases"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetReleaseList returns a list of Helm releases in given namespaces. Releases are read from
+// secrets Helm 3 stores them in, so user needs to be allowed to list secrets.
+func GetReleaseList(client kubernetes.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*ReleaseList, error) {
+	logger.Info("Getting list of Helm releases")
+
+	secrets, err := getReleaseSecrets(client, nsQuery.ToRequestParam(), "")
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	latest := make(map[string]*storedRelease)
+	for i := range secrets {
+		if !nsQuery.Matches(secrets[i].Namespace) {
+			continue
+		}
+
+		release, err := decodeRelease(&secrets[i])
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		key := secrets[i].Namespace + "/" + release.Name
+		if current, exists := latest[key]; !exists || current.Version < release.Version {
+			latest[key] = release
+		}
+	}
+
+	releases := make([]Release, 0, len(latest))
+	for _, release := range latest {
+		releases = append(releases, toRelease(release))
+	}
+
+	return toReleaseList(releases, nonCriticalErrors, dsQuery), nil
+}
+
+func toReleaseList(releases []Release, nonCriticalErrors []error,
+	dsQuery *dataselect.DataSelectQuery) *ReleaseList {
+	releaseCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(releases), dsQuery)
+	return &ReleaseList{
+		ListMeta: api.ListMeta{TotalItems: filteredTotal},
+		Releases: fromCells(releaseCells),
+		Errors:   nonCriticalErrors,
+	}
+}
+
+func toRelease(release *storedRelease) Release {
+	namespace := release.Namespace
+	if len(namespace) == 0 {
+		namespace = release.secret.Namespace
+	}
+
+	return Release{
+		Name:         release.Name,
+		Namespace:    namespace,
+		Revision:     release.Version,
+		Status:       release.Info.Status,
+		Chart:        release.Chart.Metadata.Name,
+		ChartVersion: release.Chart.Metadata.Version,
+		AppVersion:   release.Chart.Metadata.AppVersion,
+		Updated:      parseTime(release.Info.LastDeployed),
+		Description:  release.Info.Description,
+	}
+}
+
+// The code below allows to perform complex data section on []Release
+
+type ReleaseCell Release
+
+func (self ReleaseCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.Updated.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.Namespace)
+	case dataselect.StatusProperty:
+		return dataselect.StdComparableString(self.Status)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []Release) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = ReleaseCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []Release {
+	std := make([]Release, len(cells))
+	for i := range std {
+		std[i] = Release(cells[i].(ReleaseCell))
+	}
+	return std
+}
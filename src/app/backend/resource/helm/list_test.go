@@ -0,0 +1,93 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func TestGetReleaseList(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newTestReleaseSecret("web", "default", 1, StatusSuperseded),
+		newTestReleaseSecret("web", "default", 2, StatusDeployed),
+		newTestReleaseSecret("db", "prod", 1, StatusFailed),
+		&v1.Secret{ObjectMeta: metaV1.ObjectMeta{Name: "token", Namespace: "default",
+			Labels: map[string]string{"owner": "helm"}}},
+	)
+
+	cases := []struct {
+		namespaces []string
+		expected   *ReleaseList
+	}{
+		{
+			[]string{},
+			&ReleaseList{
+				ListMeta: api.ListMeta{TotalItems: 2},
+				Errors:   []error{},
+				Releases: []Release{
+					{
+						Name: "db", Namespace: "prod", Revision: 1, Status: StatusFailed,
+						Chart: "nginx", ChartVersion: "1.0.1", AppVersion: "1.13",
+						Updated:     metaV1.NewTime(time.Date(2017, 6, 1, 10, 0, 0, 0, time.UTC)),
+						Description: "Upgrade complete",
+					},
+					{
+						Name: "web", Namespace: "default", Revision: 2, Status: StatusDeployed,
+						Chart: "nginx", ChartVersion: "1.0.2", AppVersion: "1.13",
+						Updated:     metaV1.NewTime(time.Date(2017, 6, 2, 10, 0, 0, 0, time.UTC)),
+						Description: "Upgrade complete",
+					},
+				},
+			},
+		},
+		{
+			[]string{"prod"},
+			&ReleaseList{
+				ListMeta: api.ListMeta{TotalItems: 1},
+				Errors:   []error{},
+				Releases: []Release{
+					{
+						Name: "db", Namespace: "prod", Revision: 1, Status: StatusFailed,
+						Chart: "nginx", ChartVersion: "1.0.1", AppVersion: "1.13",
+						Updated:     metaV1.NewTime(time.Date(2017, 6, 1, 10, 0, 0, 0, time.UTC)),
+						Description: "Upgrade complete",
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := GetReleaseList(client, common.NewNamespaceQuery(c.namespaces),
+			dataselect.NewDataSelectQuery(dataselect.NoPagination,
+				dataselect.NewSortQuery([]string{"a", "name"}), dataselect.NoFilter,
+				dataselect.NoMetrics))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("GetReleaseList(%v) == %#v, expected %#v", c.namespaces, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Repository is a Helm chart repository.
+type Repository struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+
+	// BuiltIn repositories are configured with flags and can not be changed in Dashboard.
+	BuiltIn bool `json:"builtIn"`
+}
+
+// RepositoryList is a list of chart repositories of the app catalog.
+type RepositoryList struct {
+	Repositories []Repository `json:"repositories"`
+}
+
+// Chart is a chart available in a repository.
+type Chart struct {
+	Repository  string   `json:"repository"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Icon        string   `json:"icon"`
+	Keywords    []string `json:"keywords"`
+
+	// Latest version of the chart and of the application it deploys.
+	Version    string `json:"version"`
+	AppVersion string `json:"appVersion"`
+
+	// All versions of the chart, from the newest.
+	Versions []string `json:"versions"`
+}
+
+// ChartList is a list of charts available in chart repositories.
+type ChartList struct {
+	Charts []Chart `json:"charts"`
+
+	// List of non-critical errors, e.g. repositories that could not be reached.
+	Errors []error `json:"errors"`
+}
+
+// chartVersion is a version of a chart in the repository index. It holds metadata from Chart.yaml.
+type chartVersion struct {
+	Name        string       `json:"name"`
+	Version     string       `json:"version"`
+	AppVersion  string       `json:"appVersion"`
+	Description string       `json:"description"`
+	Icon        string       `json:"icon"`
+	Home        string       `json:"home"`
+	Sources     []string     `json:"sources"`
+	Keywords    []string     `json:"keywords"`
+	Maintainers []Maintainer `json:"maintainers"`
+	URLs        []string     `json:"urls"`
+}
+
+// Maintainer of a chart.
+type Maintainer struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// repositoryIndex is index.yaml of a chart repository. Versions of charts are sorted from the
+// newest.
+type repositoryIndex struct {
+	Entries map[string][]chartVersion `json:"entries"`
+}
+
+// repositories are chart repositories configured with flags.
+var repositories []Repository
+
+var repositoryClient = &http.Client{Timeout: 30 * time.Second}
+
+// SetRepositories configures built-in chart repositories.
+func SetRepositories(repos []Repository) {
+	repositories = repos
+}
+
+// Repositories returns built-in chart repositories.
+func Repositories() []Repository {
+	return repositories
+}
+
+// ParseRepositories parses built-in repositories in the form of name=url.
+func ParseRepositories(specs []string) ([]Repository, error) {
+	repos := make([]Repository, 0, len(specs))
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			return nil, fmt.Errorf("invalid Helm repository %q, expected name=url", spec)
+		}
+		repos = append(repos, Repository{Name: parts[0], URL: strings.TrimSuffix(parts[1], "/"),
+			BuiltIn: true})
+	}
+	return repos, nil
+}
+
+// GetChartList returns charts available in given repositories, whose name, description or
+// keywords contain the query, unless empty. Repositories that could not be reached are reported
+// as non-critical errors.
+func GetChartList(repos []Repository, query string) *ChartList {
+	logger.Infof("Getting list of charts from %d Helm repositories", len(repos))
+
+	query = strings.ToLower(query)
+	list := &ChartList{Charts: make([]Chart, 0), Errors: make([]error, 0)}
+	for _, repo := range repos {
+		index, err := getRepositoryIndex(repo)
+		if err != nil {
+			list.Errors = append(list.Errors, err)
+			continue
+		}
+
+		for name, versions := range index.Entries {
+			if len(versions) == 0 || !matchesQuery(versions[0], query) {
+				continue
+			}
+
+			chart := toChart(repo, name, versions[0])
+			chart.Versions = make([]string, 0, len(versions))
+			for _, version := range versions {
+				chart.Versions = append(chart.Versions, version.Version)
+			}
+			list.Charts = append(list.Charts, chart)
+		}
+	}
+
+	sort.Slice(list.Charts, func(i, j int) bool {
+		if list.Charts[i].Repository != list.Charts[j].Repository {
+			return list.Charts[i].Repository < list.Charts[j].Repository
+		}
+		return list.Charts[i].Name < list.Charts[j].Name
+	})
+	return list
+}
+
+func toChart(repo Repository, name string, version chartVersion) Chart {
+	return Chart{
+		Repository:  repo.Name,
+		Name:        name,
+		Description: version.Description,
+		Icon:        version.Icon,
+		Keywords:    version.Keywords,
+		Version:     version.Version,
+		AppVersion:  version.AppVersion,
+	}
+}
+
+func matchesQuery(version chartVersion, query string) bool {
+	if len(query) == 0 ||
+		strings.Contains(strings.ToLower(version.Name), query) ||
+		strings.Contains(strings.ToLower(version.Description), query) {
+		return true
+	}
+	for _, keyword := range version.Keywords {
+		if strings.Contains(strings.ToLower(keyword), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// findRepository returns repository with given name.
+func findRepository(repos []Repository, name string) (Repository, error) {
+	for _, repo := range repos {
+		if repo.Name == name {
+			return repo, nil
+		}
+	}
+	return Repository{}, errorsK8s.NewBadRequest(fmt.Sprintf("unknown Helm repository %s", name))
+}
+
+func getRepositoryIndex(repo Repository) (*repositoryIndex, error) {
+	data, err := download(repo.URL + "/index.yaml")
+	if err != nil {
+		return nil, errorsK8s.NewServiceUnavailable(fmt.Sprintf(
+			"could not get index of %s Helm repository: %v", repo.Name, err))
+	}
+
+	index := &repositoryIndex{}
+	if err := yaml.Unmarshal(data, index); err != nil {
+		return nil, errorsK8s.NewServiceUnavailable(fmt.Sprintf(
+			"invalid index of %s Helm repository: %v", repo.Name, err))
+	}
+	return index, nil
+}
+
+// maxDownloadSize limits size of downloaded indexes and chart archives.
+const maxDownloadSize = 20 << 20
+
+func download(location string) ([]byte, error) {
+	response, err := repositoryClient.Get(location)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s of %s", response.Status, location)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(response.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("could not download %s: %v", location, err)
+	}
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", location, maxDownloadSize)
+	}
+	return data, nil
+}
+
+// resolveURL resolves URL of a chart archive, which can be relative to the repository.
+func resolveURL(repo Repository, location string) (string, error) {
+	base, err := url.Parse(repo.URL + "/")
+	if err != nil {
+		return "", err
+	}
+	relative, err := url.Parse(location)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(relative).String(), nil
+}
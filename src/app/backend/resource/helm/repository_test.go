@@ -0,0 +1,149 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const testIndex = `apiVersion: v1
+entries:
+  redis:
+  - name: redis
+    version: 2.0.0
+    appVersion: "4.0"
+    description: Open source key-value store
+    keywords: [cache]
+    home: https://redis.io
+    urls: [redis-2.0.0.tgz]
+  - name: redis
+    version: 1.0.0
+    appVersion: "3.2"
+    description: Open source key-value store
+    keywords: [cache]
+    urls: [redis-1.0.0.tgz]
+  mysql:
+  - name: mysql
+    version: 0.3.0
+    appVersion: "5.7"
+    description: Relational database
+    urls: ["https://example.com/mysql-0.3.0.tgz"]
+`
+
+// newTestRepository serves index and redis chart archives under /charts.
+func newTestRepository(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/charts/index.yaml":
+			w.Write([]byte(testIndex))
+		case "/charts/redis-2.0.0.tgz":
+			w.Write(newTestChartArchive(t, "redis", map[string]string{
+				"Chart.yaml":             "name: redis\nversion: 2.0.0\n",
+				"README.md":              "# Redis\n",
+				"values.yaml":            "replicas: 1\n",
+				"values.schema.json":     `{"type": "object"}`,
+				"templates/service.yaml": "kind: Service\n",
+			}))
+		case "/charts/redis-1.0.0.tgz":
+			w.Write(newTestChartArchive(t, "redis", map[string]string{
+				"Chart.yaml":  "name: redis\nversion: 1.0.0\n",
+				"values.yaml": "replicas: 2\n",
+			}))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func newTestChartArchive(t *testing.T, name string, files map[string]string) []byte {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for path, content := range files {
+		header := &tar.Header{Name: name + "/" + path, Mode: 0644, Size: int64(len(content))}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func TestGetChartList(t *testing.T) {
+	server := newTestRepository(t)
+	defer server.Close()
+
+	repos, err := ParseRepositories([]string{"stable=" + server.URL + "/charts/",
+		"missing=" + server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mysql := Chart{Repository: "stable", Name: "mysql", Description: "Relational database",
+		Version: "0.3.0", AppVersion: "5.7", Versions: []string{"0.3.0"}}
+	redis := Chart{Repository: "stable", Name: "redis", Description: "Open source key-value store",
+		Keywords: []string{"cache"}, Version: "2.0.0", AppVersion: "4.0",
+		Versions: []string{"2.0.0", "1.0.0"}}
+
+	cases := []struct {
+		query    string
+		expected []Chart
+	}{
+		{"", []Chart{mysql, redis}},
+		{"CACHE", []Chart{redis}},
+		{"relational", []Chart{mysql}},
+		{"postgres", []Chart{}},
+	}
+
+	for _, c := range cases {
+		list := GetChartList(repos, c.query)
+		if !reflect.DeepEqual(list.Charts, c.expected) {
+			t.Errorf("GetChartList(%q) returns charts %#v, expected %#v", c.query, list.Charts,
+				c.expected)
+		}
+		if len(list.Errors) != 1 {
+			t.Errorf("GetChartList(%q) returns errors %v, expected error of missing repository",
+				c.query, list.Errors)
+		}
+	}
+}
+
+func TestParseRepositories(t *testing.T) {
+	repos, err := ParseRepositories([]string{"stable=https://charts.example.com/"})
+	expected := []Repository{{Name: "stable", URL: "https://charts.example.com", BuiltIn: true}}
+	if err != nil || !reflect.DeepEqual(repos, expected) {
+		t.Errorf("ParseRepositories() == %#v, %v, expected %#v", repos, err, expected)
+	}
+
+	for _, spec := range []string{"stable", "=http://charts", "stable="} {
+		if _, err := ParseRepositories([]string{spec}); err == nil {
+			t.Errorf("ParseRepositories(%q) returns no error", spec)
+		}
+	}
+}
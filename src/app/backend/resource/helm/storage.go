@@ -0,0 +1,216 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+const (
+	// ReleaseSecretType is the type of secrets Helm 3 stores revisions of releases in.
+	ReleaseSecretType = v1.SecretType("helm.sh/release.v1")
+
+	// releaseKey is the key of secret data with the encoded release.
+	releaseKey = "release"
+
+	// Labels Helm sets on release secrets.
+	ownerLabel   = "owner"
+	nameLabel    = "name"
+	statusLabel  = "status"
+	versionLabel = "version"
+	ownerHelm    = "helm"
+)
+
+// Statuses of release revisions set by Helm.
+const (
+	StatusDeployed   = "deployed"
+	StatusSuperseded = "superseded"
+	StatusFailed     = "failed"
+)
+
+// gzipMagic starts releases compressed by Helm.
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// storedRelease is a revision of a release in the storage format of Helm 3. Only fields shown by
+// Dashboard are decoded, raw keeps the whole document, so that revisions created by Dashboard
+// contain also chart templates and hooks.
+type storedRelease struct {
+	Name      string                 `json:"name"`
+	Namespace string                 `json:"namespace"`
+	Version   int                    `json:"version"`
+	Info      storedInfo             `json:"info"`
+	Chart     storedChart            `json:"chart"`
+	Config    map[string]interface{} `json:"config"`
+	Manifest  string                 `json:"manifest"`
+
+	raw    map[string]interface{}
+	secret *v1.Secret
+}
+
+// storedInfo holds times as strings, as Helm stores zero times as empty strings.
+type storedInfo struct {
+	FirstDeployed string `json:"first_deployed"`
+	LastDeployed  string `json:"last_deployed"`
+	Description   string `json:"description"`
+	Status        string `json:"status"`
+	Notes         string `json:"notes"`
+}
+
+type storedChart struct {
+	Metadata struct {
+		Name       string `json:"name"`
+		Version    string `json:"version"`
+		AppVersion string `json:"appVersion"`
+	} `json:"metadata"`
+	Values map[string]interface{} `json:"values"`
+}
+
+// decodeRelease decodes release stored in the secret. Secret data holds base64 encoded, usually
+// gzipped, JSON.
+func decodeRelease(secret *v1.Secret) (*storedRelease, error) {
+	data, err := base64.StdEncoding.DecodeString(string(secret.Data[releaseKey]))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode release secret %s: %v", secret.Name, err)
+	}
+
+	if bytes.HasPrefix(data, gzipMagic) {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress release secret %s: %v", secret.Name, err)
+		}
+		defer reader.Close()
+		if data, err = ioutil.ReadAll(reader); err != nil {
+			return nil, fmt.Errorf("could not decompress release secret %s: %v", secret.Name, err)
+		}
+	}
+
+	release := &storedRelease{secret: secret}
+	if err := json.Unmarshal(data, release); err != nil {
+		return nil, fmt.Errorf("could not decode release secret %s: %v", secret.Name, err)
+	}
+	if err := json.Unmarshal(data, &release.raw); err != nil {
+		return nil, fmt.Errorf("could not decode release secret %s: %v", secret.Name, err)
+	}
+	return release, nil
+}
+
+// encodeRelease encodes raw release the same way as Helm does.
+func encodeRelease(raw map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	writer, err := gzip.NewWriterLevel(&buffer, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return []byte(base64.StdEncoding.EncodeToString(buffer.Bytes())), nil
+}
+
+// getReleaseSecrets returns secrets of all revisions of releases in the namespace. Name limits
+// secrets to the release with the name, unless empty.
+func getReleaseSecrets(client kubernetes.Interface, namespace, name string) ([]v1.Secret, error) {
+	selector := ownerLabel + "=" + ownerHelm
+	if len(name) > 0 {
+		selector += "," + nameLabel + "=" + name
+	}
+
+	list, err := client.CoreV1().Secrets(namespace).List(metaV1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make([]v1.Secret, 0, len(list.Items))
+	for _, secret := range list.Items {
+		if secret.Type == ReleaseSecretType {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets, nil
+}
+
+// getRevisions returns all revisions of the release sorted from the oldest.
+func getRevisions(client kubernetes.Interface, namespace, name string) ([]*storedRelease, error) {
+	secrets, err := getReleaseSecrets(client, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*storedRelease, 0, len(secrets))
+	for i := range secrets {
+		release, err := decodeRelease(&secrets[i])
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, release)
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Version < revisions[j].Version })
+	return revisions, nil
+}
+
+// newReleaseSecret creates secret holding the revision of the release.
+func newReleaseSecret(name, namespace string, version int, status string,
+	raw map[string]interface{}) (*v1.Secret, error) {
+	data, err := encodeRelease(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      fmt.Sprintf("sh.helm.release.v1.%s.v%d", name, version),
+			Namespace: namespace,
+			Labels: map[string]string{
+				ownerLabel:   ownerHelm,
+				nameLabel:    name,
+				statusLabel:  status,
+				versionLabel: fmt.Sprint(version),
+			},
+		},
+		Type: ReleaseSecretType,
+		Data: map[string][]byte{releaseKey: data},
+	}, nil
+}
+
+// parseTime parses time stored by Helm. Invalid and empty times are zero.
+func parseTime(value string) metaV1.Time {
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return metaV1.Time{}
+	}
+	return metaV1.NewTime(parsed)
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"encoding/base64"
+	"reflect"
+	"strconv"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// newTestReleaseSecret creates secret with revision of a release, as stored by Helm.
+func newTestReleaseSecret(name, namespace string, version int, status string) *v1.Secret {
+	raw := map[string]interface{}{
+		"name":      name,
+		"namespace": namespace,
+		"version":   version,
+		"info": map[string]interface{}{
+			"first_deployed": "2017-06-01T10:00:00Z",
+			"last_deployed":  "2017-06-0" + strconv.Itoa(version) + "T10:00:00Z",
+			"deleted":        "",
+			"description":    "Upgrade complete",
+			"status":         status,
+			"notes":          "Visit http://" + name,
+		},
+		"chart": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":       "nginx",
+				"version":    "1.0." + strconv.Itoa(version),
+				"appVersion": "1.13",
+			},
+			"values":    map[string]interface{}{"replicas": 1},
+			"templates": []interface{}{map[string]interface{}{"name": "deployment.yaml"}},
+		},
+		"config":   map[string]interface{}{"replicas": version},
+		"manifest": "",
+	}
+
+	secret, err := newReleaseSecret(name, namespace, version, status, raw)
+	if err != nil {
+		panic(err)
+	}
+	return secret
+}
+
+func TestDecodeRelease(t *testing.T) {
+	secret := newTestReleaseSecret("web", "default", 2, StatusDeployed)
+
+	release, err := decodeRelease(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if release.Name != "web" || release.Version != 2 || release.Info.Status != StatusDeployed ||
+		release.Chart.Metadata.Version != "1.0.2" {
+		t.Errorf("decodeRelease() == %#v, expected revision 2 of web release", release)
+	}
+	if _, exists := release.raw["chart"].(map[string]interface{})["templates"]; !exists {
+		t.Error("decodeRelease() drops fields that are not shown by Dashboard")
+	}
+
+	expectedLabels := map[string]string{"owner": "helm", "name": "web", "status": "deployed",
+		"version": "2"}
+	if !reflect.DeepEqual(secret.Labels, expectedLabels) {
+		t.Errorf("Release secret has labels %v, expected %v", secret.Labels, expectedLabels)
+	}
+
+	// Releases stored by old versions of Helm are not compressed.
+	plain := &v1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: "plain"},
+		Data: map[string][]byte{releaseKey: []byte(base64.StdEncoding.EncodeToString(
+			[]byte(`{"name": "plain", "version": 1}`)))},
+	}
+	if release, err := decodeRelease(plain); err != nil || release.Name != "plain" {
+		t.Errorf("decodeRelease() == %#v, %v for uncompressed release", release, err)
+	}
+
+	invalid := &v1.Secret{Data: map[string][]byte{releaseKey: []byte("%%%")}}
+	if _, err := decodeRelease(invalid); err == nil {
+		t.Error("decodeRelease() returns no error for invalid release")
+	}
+}
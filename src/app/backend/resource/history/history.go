@@ -0,0 +1,247 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package history reconstructs change history of objects from their managed fields, deployment
+// revisions and snapshots stored by Dashboard on edits.
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/genericresource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// EntrySource tells where a history entry comes from.
+type EntrySource string
+
+const (
+	SourceCreation     EntrySource = "creation"
+	SourceManagedField EntrySource = "managedFields"
+	SourceRevision     EntrySource = "revision"
+	SourceSnapshot     EntrySource = "snapshot"
+)
+
+// FieldManager is a manager of fields of the object, as recorded by the apiserver. Only the last
+// operation of each manager is kept by the apiserver.
+type FieldManager struct {
+	Manager     string       `json:"manager"`
+	Operation   string       `json:"operation"`
+	Subresource string       `json:"subresource,omitempty"`
+	Time        *metaV1.Time `json:"time,omitempty"`
+
+	// Paths of fields owned by the manager, e.g. spec.template.spec.containers[name=nginx].image.
+	Fields []string `json:"fields"`
+}
+
+// HistoryEntry is a single change of the object on the timeline.
+type HistoryEntry struct {
+	Time   metaV1.Time `json:"time"`
+	Source EntrySource `json:"source"`
+
+	// Field manager, user or controller responsible for the change, when known.
+	Actor       string `json:"actor,omitempty"`
+	Description string `json:"description"`
+}
+
+// ObjectHistory is the change history of an object.
+type ObjectHistory struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	Managers []FieldManager `json:"managers"`
+
+	// Revisions of deployments. Empty for other kinds.
+	Revisions []deployment.RolloutRevision `json:"revisions"`
+
+	// Snapshots stored by Dashboard, oldest first.
+	Snapshots []Snapshot `json:"snapshots"`
+
+	// All changes, newest first.
+	Timeline []HistoryEntry `json:"timeline"`
+}
+
+// GetObjectHistory returns change history of the object of the resource.
+func GetObjectHistory(client kubernetes.Interface, config *rest.Config, ref genericresource.ResourceRef,
+	namespace, name string) (*ObjectHistory, error) {
+	detail, err := genericresource.GetGenericResourceDetail(client.Discovery(), config, ref, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{Object: detail.Object}
+	logger.Infof("Getting history of %s %s", obj.GetKind(), obj.GetName())
+
+	history := &ObjectHistory{
+		Kind:      obj.GetKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Managers:  getFieldManagers(obj),
+		Revisions: make([]deployment.RolloutRevision, 0),
+	}
+
+	if obj.GetKind() == "Deployment" {
+		rollout, err := deployment.GetDeploymentRolloutHistory(client, obj.GetNamespace(), obj.GetName())
+		if err != nil {
+			return nil, err
+		}
+		history.Revisions = rollout.Revisions
+	}
+
+	history.Snapshots, err = GetSnapshots(client, obj.GetNamespace(), obj.GetUID())
+	if err != nil {
+		return nil, err
+	}
+
+	history.Timeline = toTimeline(obj.GetCreationTimestamp(), history)
+	return history, nil
+}
+
+// toTimeline merges all sources of the history into entries sorted newest first.
+func toTimeline(created metaV1.Time, history *ObjectHistory) []HistoryEntry {
+	timeline := []HistoryEntry{{Time: created, Source: SourceCreation, Description: "Created"}}
+
+	for _, manager := range history.Managers {
+		if manager.Time == nil {
+			continue
+		}
+		description := fmt.Sprintf("%s of %d fields", manager.Operation, len(manager.Fields))
+		if len(manager.Subresource) > 0 {
+			description += fmt.Sprintf(" of %s subresource", manager.Subresource)
+		}
+		timeline = append(timeline, HistoryEntry{Time: *manager.Time, Source: SourceManagedField,
+			Actor: manager.Manager, Description: description})
+	}
+
+	for _, revision := range history.Revisions {
+		description := fmt.Sprintf("Revision %d", revision.Revision)
+		if len(revision.ChangeCause) > 0 {
+			description += ": " + revision.ChangeCause
+		} else if len(revision.Images) > 0 {
+			description += ": " + strings.Join(revision.Images, ", ")
+		}
+		timeline = append(timeline, HistoryEntry{Time: revision.CreationTimestamp, Source: SourceRevision,
+			Description: description})
+	}
+
+	for _, snapshot := range history.Snapshots {
+		timeline = append(timeline, HistoryEntry{Time: snapshot.Time, Source: SourceSnapshot,
+			Actor: snapshot.User, Description: "Edited in Dashboard"})
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool { return timeline[j].Time.Before(timeline[i].Time) })
+	return timeline
+}
+
+// getFieldManagers parses managed fields of the object. They are read from the unstructured object,
+// as they are not part of the vendored object meta.
+func getFieldManagers(obj *unstructured.Unstructured) []FieldManager {
+	metadata, _ := obj.Object["metadata"].(map[string]interface{})
+	entries, _ := metadata["managedFields"].([]interface{})
+	managers := make([]FieldManager, 0, len(entries))
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		manager := FieldManager{Fields: make([]string, 0)}
+		manager.Manager, _ = entryMap["manager"].(string)
+		manager.Operation, _ = entryMap["operation"].(string)
+		manager.Subresource, _ = entryMap["subresource"].(string)
+		if value, ok := entryMap["time"].(string); ok {
+			if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+				t := metaV1.NewTime(parsed)
+				manager.Time = &t
+			}
+		}
+		if fields, ok := entryMap["fieldsV1"].(map[string]interface{}); ok {
+			manager.Fields = flattenFields("", fields, manager.Fields)
+		}
+		managers = append(managers, manager)
+	}
+	return managers
+}
+
+// flattenFields returns paths of leaf fields of the FieldsV1 set.
+func flattenFields(path string, fields map[string]interface{}, result []string) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath, ok := fieldPath(path, key)
+		if !ok {
+			continue
+		}
+		child, _ := fields[key].(map[string]interface{})
+		if hasChildFields(child) {
+			result = flattenFields(childPath, child, result)
+		} else {
+			result = append(result, childPath)
+		}
+	}
+	return result
+}
+
+// fieldPath appends FieldsV1 key to the path. Keys are fields (f:), keyed list items (k:), set values
+// (v:) and list indexes (i:). The "." key marks ownership of the value itself and is skipped.
+func fieldPath(path, key string) (string, bool) {
+	switch {
+	case strings.HasPrefix(key, "f:"):
+		name := strings.TrimPrefix(key, "f:")
+		if strings.ContainsAny(name, "./[]") {
+			return fmt.Sprintf("%s[%q]", path, name), true
+		}
+		if len(path) == 0 {
+			return name, true
+		}
+		return path + "." + name, true
+	case strings.HasPrefix(key, "k:"):
+		itemKey := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(key, "k:")), &itemKey); err != nil {
+			return "", false
+		}
+		parts := make([]string, 0, len(itemKey))
+		for name, value := range itemKey {
+			parts = append(parts, fmt.Sprintf("%s=%v", name, value))
+		}
+		sort.Strings(parts)
+		return fmt.Sprintf("%s[%s]", path, strings.Join(parts, ",")), true
+	case strings.HasPrefix(key, "v:"), strings.HasPrefix(key, "i:"):
+		return fmt.Sprintf("%s[%s]", path, key[2:]), true
+	default:
+		return "", false
+	}
+}
+
+func hasChildFields(fields map[string]interface{}) bool {
+	for key := range fields {
+		if key != "." {
+			return true
+		}
+	}
+	return false
+}
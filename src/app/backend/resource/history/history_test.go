@@ -0,0 +1,177 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetFieldManagers(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": "web",
+			"managedFields": []interface{}{
+				map[string]interface{}{
+					"manager":   "kubectl",
+					"operation": "Apply",
+					"time":      "2021-03-01T10:00:00Z",
+					"fieldsV1": map[string]interface{}{
+						"f:metadata": map[string]interface{}{"f:annotations": map[string]interface{}{
+							"f:example.com/owner": map[string]interface{}{},
+						}},
+						"f:spec": map[string]interface{}{
+							"f:containers": map[string]interface{}{
+								`k:{"name":"nginx"}`: map[string]interface{}{
+									".":       map[string]interface{}{},
+									"f:image": map[string]interface{}{},
+								},
+							},
+							"f:finalizers": map[string]interface{}{`v:"a"`: map[string]interface{}{}},
+						},
+					},
+				},
+				map[string]interface{}{
+					"manager":     "kubelet",
+					"operation":   "Update",
+					"subresource": "status",
+				},
+			},
+		},
+	}}
+
+	managerTime := metaV1.NewTime(time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC))
+	expected := []FieldManager{
+		{Manager: "kubectl", Operation: "Apply", Time: &managerTime, Fields: []string{
+			`metadata.annotations["example.com/owner"]`,
+			"spec.containers[name=nginx].image",
+			`spec.finalizers["a"]`,
+		}},
+		{Manager: "kubelet", Operation: "Update", Subresource: "status", Fields: []string{}},
+	}
+
+	actual := getFieldManagers(obj)
+	if len(actual) != len(expected) {
+		t.Fatalf("getFieldManagers() returns %#v, expected %#v", actual, expected)
+	}
+	for i := range expected {
+		if (actual[i].Time == nil) != (expected[i].Time == nil) ||
+			actual[i].Time != nil && !actual[i].Time.Time.Equal(expected[i].Time.Time) {
+			t.Errorf("getFieldManagers()[%d].Time == %v, expected %v", i, actual[i].Time, expected[i].Time)
+		}
+		actual[i].Time, expected[i].Time = nil, nil
+		if !reflect.DeepEqual(actual[i], expected[i]) {
+			t.Errorf("getFieldManagers()[%d] == %#v, expected %#v", i, actual[i], expected[i])
+		}
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	newConfigMap := func(value string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":            "settings",
+				"namespace":       "default",
+				"uid":             "1234",
+				"resourceVersion": "7",
+			},
+			"data": map[string]interface{}{"key": value},
+		}}
+	}
+
+	for _, value := range []string{"a", "b"} {
+		if err := RecordSnapshot(client, newConfigMap(value), "alice"); err != nil {
+			t.Fatalf("RecordSnapshot() returns error %s", err)
+		}
+	}
+
+	secret := newConfigMap("c")
+	secret.SetKind("Secret")
+	if err := RecordSnapshot(client, secret, "alice"); err != nil {
+		t.Fatalf("RecordSnapshot() returns error %s", err)
+	}
+
+	snapshots, err := GetSnapshots(client, "default", "1234")
+	if err != nil {
+		t.Fatalf("GetSnapshots() returns error %s", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("GetSnapshots() returns %d snapshots, expected 2", len(snapshots))
+	}
+	if snapshots[0].User != "alice" || strings.Contains(snapshots[0].Content, "resourceVersion") {
+		t.Errorf("GetSnapshots()[0] == %#v, expected cleaned snapshot of alice", snapshots[0])
+	}
+	if len(snapshots[0].Diff) > 0 || !strings.Contains(snapshots[1].Diff, "+  key: b") {
+		t.Errorf("GetSnapshots() returns diffs %#v and %#v, expected only the second one", snapshots[0].Diff,
+			snapshots[1].Diff)
+	}
+
+	snapshots, err = GetSnapshots(client, "default", "5678")
+	if err != nil || len(snapshots) != 0 {
+		t.Errorf("GetSnapshots() for object without snapshots returns %#v, %v", snapshots, err)
+	}
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	data := make(map[string]string)
+	for i := 1; i <= MaxSnapshots+2; i++ {
+		data[strconv.Itoa(i*100)] = "{}"
+	}
+
+	pruneSnapshots(data)
+	if len(data) != MaxSnapshots {
+		t.Errorf("pruneSnapshots() keeps %d snapshots, expected %d", len(data), MaxSnapshots)
+	}
+	for _, key := range []string{"100", "200"} {
+		if _, ok := data[key]; ok {
+			t.Errorf("pruneSnapshots() keeps the oldest snapshot %s", key)
+		}
+	}
+}
+
+func TestToTimeline(t *testing.T) {
+	at := func(hour int) metaV1.Time { return metaV1.NewTime(time.Date(2021, 3, 1, hour, 0, 0, 0, time.UTC)) }
+	managerTime := at(3)
+	history := &ObjectHistory{
+		Managers: []FieldManager{{Manager: "kubectl", Operation: "Update", Time: &managerTime,
+			Fields: []string{"spec.replicas"}}},
+		Revisions: []deployment.RolloutRevision{{Revision: 2, CreationTimestamp: at(2),
+			Images: []string{"nginx:1.1"}}},
+		Snapshots: []Snapshot{{Time: at(4), User: "alice"}},
+	}
+
+	expected := []HistoryEntry{
+		{Time: at(4), Source: SourceSnapshot, Actor: "alice", Description: "Edited in Dashboard"},
+		{Time: at(3), Source: SourceManagedField, Actor: "kubectl", Description: "Update of 1 fields"},
+		{Time: at(2), Source: SourceRevision, Description: "Revision 2: nginx:1.1"},
+		{Time: at(1), Source: SourceCreation, Description: "Created"},
+	}
+
+	actual := toTimeline(at(1), history)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("toTimeline() returns %#v, expected %#v", actual, expected)
+	}
+}
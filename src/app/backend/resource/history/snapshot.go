@@ -0,0 +1,200 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/export"
+	"github.com/pmezard/go-difflib/difflib"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+const (
+	// MaxSnapshots is the number of snapshots kept for each object. Oldest snapshots are dropped.
+	MaxSnapshots = 10
+
+	// maxSnapshotsSize limits size of snapshots stored in a single config map, which has to stay
+	// below the object size limit of the apiserver.
+	maxSnapshotsSize = 512 * 1024
+
+	// snapshotConfigMapPrefix starts names of config maps with snapshots. Names end with the UID of
+	// the object, so that recreated objects do not inherit history.
+	snapshotConfigMapPrefix = "kubernetes-dashboard-history-"
+
+	// SnapshotLabel marks config maps with snapshots.
+	SnapshotLabel = "dashboard.kubernetes.io/history"
+
+	// Annotations identifying the object, whose snapshots are stored in the config map.
+	snapshotKindAnnotation = "dashboard.kubernetes.io/history-kind"
+	snapshotNameAnnotation = "dashboard.kubernetes.io/history-name"
+)
+
+// Snapshot is a state of an object stored by Dashboard after it was edited.
+type Snapshot struct {
+	Time metaV1.Time `json:"time"`
+
+	// Name of the user, who edited the object, as claimed by the request credentials.
+	User string `json:"user,omitempty"`
+
+	// YAML of the object without status and server maintained fields.
+	Content string `json:"content"`
+
+	// Unified diff from the previous snapshot. Empty for the oldest snapshot.
+	Diff string `json:"diff,omitempty"`
+}
+
+// RecordSnapshot stores the edited object in a config map in its namespace. Secrets and cluster
+// scoped objects are not recorded, so that secret data is not copied and no namespace is picked for
+// objects without one.
+func RecordSnapshot(client kubernetes.Interface, obj *unstructured.Unstructured, user string) error {
+	if obj.GetKind() == "Secret" || len(obj.GetNamespace()) == 0 || len(obj.GetUID()) == 0 {
+		return nil
+	}
+
+	content, err := yaml.Marshal(export.Clean(obj))
+	if err != nil {
+		return err
+	}
+	snapshot, err := json.Marshal(&Snapshot{
+		Time:    metaV1.Now(),
+		User:    user,
+		Content: string(content),
+	})
+	if err != nil {
+		return err
+	}
+
+	configMaps := client.CoreV1().ConfigMaps(obj.GetNamespace())
+	name := snapshotConfigMapName(obj.GetUID())
+	configMap, err := configMaps.Get(name, metaV1.GetOptions{})
+	create := errorsK8s.IsNotFound(err)
+	if create {
+		configMap = &v1.ConfigMap{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      name,
+				Namespace: obj.GetNamespace(),
+				Labels:    map[string]string{SnapshotLabel: "true"},
+				Annotations: map[string]string{
+					snapshotKindAnnotation: obj.GetKind(),
+					snapshotNameAnnotation: obj.GetName(),
+				},
+			},
+		}
+	} else if err != nil {
+		return err
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	configMap.Data[strconv.FormatInt(time.Now().UnixNano(), 10)] = string(snapshot)
+	pruneSnapshots(configMap.Data)
+
+	logger.Infof("Recording snapshot of %s %s in %s namespace", obj.GetKind(), obj.GetName(),
+		obj.GetNamespace())
+	if create {
+		_, err = configMaps.Create(configMap)
+	} else {
+		_, err = configMaps.Update(configMap)
+	}
+	return err
+}
+
+// GetSnapshots returns snapshots of the object, oldest first, each with a diff from the previous one.
+// Object without recorded snapshots, or whose snapshots the user cannot read, has no snapshots.
+func GetSnapshots(client kubernetes.Interface, namespace string, uid types.UID) ([]Snapshot, error) {
+	snapshots := make([]Snapshot, 0)
+	if len(namespace) == 0 {
+		return snapshots, nil
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Get(snapshotConfigMapName(uid),
+		metaV1.GetOptions{})
+	if errorsK8s.IsNotFound(err) || errorsK8s.IsForbidden(err) {
+		return snapshots, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range sortedSnapshotKeys(configMap.Data) {
+		snapshot := Snapshot{}
+		if err := json.Unmarshal([]byte(configMap.Data[key]), &snapshot); err != nil {
+			logger.Warningf("Skipping invalid snapshot %s in %s config map: %s", key, configMap.Name, err)
+			continue
+		}
+		if len(snapshots) > 0 {
+			snapshot.Diff, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(snapshots[len(snapshots)-1].Content),
+				B:        difflib.SplitLines(snapshot.Content),
+				FromFile: "previous",
+				ToFile:   "edited",
+				Context:  3,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// pruneSnapshots drops oldest snapshots above the count and size limits.
+func pruneSnapshots(data map[string]string) {
+	size := 0
+	for _, value := range data {
+		size += len(value)
+	}
+
+	for _, key := range sortedSnapshotKeys(data) {
+		if len(data) <= MaxSnapshots && size <= maxSnapshotsSize || len(data) == 1 {
+			return
+		}
+		size -= len(data[key])
+		delete(data, key)
+	}
+}
+
+// sortedSnapshotKeys returns keys of snapshots, which are their times in nanoseconds, oldest first.
+func sortedSnapshotKeys(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func snapshotConfigMapName(uid types.UID) string {
+	return fmt.Sprintf("%s%s", snapshotConfigMapPrefix, uid)
+}
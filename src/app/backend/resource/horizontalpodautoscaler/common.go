@@ -14,8 +14,25 @@
 
 package horizontalpodautoscaler
 
+import (
+	autoscaling "k8s.io/client-go/pkg/apis/autoscaling/v1"
+)
+
 // Simple mapping of an autoscaling.CrossVersionObjectReference
 type ScaleTargetRef struct {
-	Kind string `json:"kind"`
-	Name string `json:"name"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	APIVersion string `json:"apiVersion,omitempty"`
+}
+
+func toScaleTargetRef(ref autoscaling.CrossVersionObjectReference) *ScaleTargetRef {
+	return &ScaleTargetRef{Kind: ref.Kind, Name: ref.Name, APIVersion: ref.APIVersion}
+}
+
+func toCrossVersionObjectReference(ref ScaleTargetRef) autoscaling.CrossVersionObjectReference {
+	return autoscaling.CrossVersionObjectReference{
+		Kind:       ref.Kind,
+		Name:       ref.Name,
+		APIVersion: ref.APIVersion,
+	}
 }
@@ -0,0 +1,228 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horizontalpodautoscaler
+
+import (
+	"encoding/json"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	autoscalingapi "k8s.io/client-go/pkg/apis/autoscaling"
+	autoscaling "k8s.io/client-go/pkg/apis/autoscaling/v1"
+)
+
+// scaleTargetAPIVersions are API versions of kinds that can be scaled by horizontal pod
+// autoscaler, used when the scale target does not specify one.
+var scaleTargetAPIVersions = map[string]string{
+	"Deployment":            "extensions/v1beta1",
+	"ReplicaSet":            "extensions/v1beta1",
+	"ReplicationController": "v1",
+	"StatefulSet":           "apps/v1beta1",
+}
+
+// HorizontalPodAutoscalerSpec is a specification of horizontal pod autoscaler to create or
+// update.
+type HorizontalPodAutoscalerSpec struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// Workload scaled by the autoscaler, usually the workload whose detail page is displayed.
+	ScaleTargetRef ScaleTargetRef `json:"scaleTargetRef"`
+
+	MinReplicas *int32 `json:"minReplicas"`
+	MaxReplicas int32  `json:"maxReplicas"`
+
+	// Metric targets, current values are ignored.
+	Metrics []HorizontalPodAutoscalerMetric `json:"metrics"`
+}
+
+// CreateHorizontalPodAutoscaler creates horizontal pod autoscaler based on given spec.
+func CreateHorizontalPodAutoscaler(client client.Interface,
+	spec *HorizontalPodAutoscalerSpec) (*HorizontalPodAutoscalerDetail, error) {
+	logger.Infof("Creating %s horizontal pod autoscaler in %s namespace", spec.Name, spec.Namespace)
+
+	if err := validateHorizontalPodAutoscalerSpec(spec); err != nil {
+		return nil, err
+	}
+
+	hpa := &autoscaling.HorizontalPodAutoscaler{
+		ObjectMeta: metaV1.ObjectMeta{Name: spec.Name, Namespace: spec.Namespace},
+	}
+	if err := applySpec(hpa, spec); err != nil {
+		return nil, err
+	}
+
+	created, err := client.AutoscalingV1().HorizontalPodAutoscalers(spec.Namespace).Create(hpa)
+	if err != nil {
+		return nil, err
+	}
+	return getHorizontalPodAutoscalerDetail(created), nil
+}
+
+// UpdateHorizontalPodAutoscaler updates replica bounds, scale target and metrics of existing
+// horizontal pod autoscaler. Other fields of the autoscaler are kept.
+func UpdateHorizontalPodAutoscaler(client client.Interface, namespace, name string,
+	spec *HorizontalPodAutoscalerSpec) (*HorizontalPodAutoscalerDetail, error) {
+	logger.Infof("Updating %s horizontal pod autoscaler in %s namespace", name, namespace)
+
+	spec.Name = name
+	spec.Namespace = namespace
+	if err := validateHorizontalPodAutoscalerSpec(spec); err != nil {
+		return nil, err
+	}
+
+	hpa, err := client.AutoscalingV1().HorizontalPodAutoscalers(namespace).Get(name,
+		metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if err := applySpec(hpa, spec); err != nil {
+		return nil, err
+	}
+
+	updated, err := client.AutoscalingV1().HorizontalPodAutoscalers(namespace).Update(hpa)
+	if err != nil {
+		return nil, err
+	}
+	return getHorizontalPodAutoscalerDetail(updated), nil
+}
+
+// applySpec sets fields of the autoscaler from the spec. CPU utilization target is stored in the
+// v1 field, all other metrics in the metrics annotation, the same way apiserver converts
+// autoscaling/v2alpha1 objects.
+func applySpec(hpa *autoscaling.HorizontalPodAutoscaler, spec *HorizontalPodAutoscalerSpec) error {
+	target := spec.ScaleTargetRef
+	if target.APIVersion == "" {
+		target.APIVersion = scaleTargetAPIVersions[target.Kind]
+	}
+
+	hpa.Spec.ScaleTargetRef = toCrossVersionObjectReference(target)
+	hpa.Spec.MinReplicas = spec.MinReplicas
+	hpa.Spec.MaxReplicas = spec.MaxReplicas
+	hpa.Spec.TargetCPUUtilizationPercentage = nil
+
+	others := make([]autoscaling.MetricSpec, 0)
+	for _, metric := range spec.Metrics {
+		if metric.Type == string(autoscaling.ResourceMetricSourceType) &&
+			metric.Name == string(v1.ResourceCPU) && metric.TargetAverageUtilization != nil &&
+			hpa.Spec.TargetCPUUtilizationPercentage == nil {
+			hpa.Spec.TargetCPUUtilizationPercentage = metric.TargetAverageUtilization
+			continue
+		}
+		others = append(others, toMetricSpec(metric))
+	}
+
+	if len(others) == 0 {
+		delete(hpa.Annotations, autoscalingapi.MetricSpecsAnnotation)
+		return nil
+	}
+
+	data, err := json.Marshal(others)
+	if err != nil {
+		return err
+	}
+	if hpa.Annotations == nil {
+		hpa.Annotations = make(map[string]string)
+	}
+	hpa.Annotations[autoscalingapi.MetricSpecsAnnotation] = string(data)
+	return nil
+}
+
+// validateHorizontalPodAutoscalerSpec returns invalid error describing all problems of the spec.
+func validateHorizontalPodAutoscalerSpec(spec *HorizontalPodAutoscalerSpec) error {
+	errs := field.ErrorList{}
+
+	if spec.Name == "" {
+		errs = append(errs, field.Required(field.NewPath("name"), ""))
+	}
+
+	targetPath := field.NewPath("scaleTargetRef")
+	if _, ok := scaleTargetAPIVersions[spec.ScaleTargetRef.Kind]; !ok &&
+		spec.ScaleTargetRef.APIVersion == "" {
+		errs = append(errs, field.Invalid(targetPath.Child("kind"), spec.ScaleTargetRef.Kind,
+			"kind can not be scaled"))
+	}
+	if spec.ScaleTargetRef.Name == "" {
+		errs = append(errs, field.Required(targetPath.Child("name"), ""))
+	}
+
+	if spec.MaxReplicas < 1 {
+		errs = append(errs, field.Invalid(field.NewPath("maxReplicas"), spec.MaxReplicas,
+			"must be greater than or equal to 1"))
+	}
+	if spec.MinReplicas != nil {
+		if *spec.MinReplicas < 1 {
+			errs = append(errs, field.Invalid(field.NewPath("minReplicas"), *spec.MinReplicas,
+				"must be greater than or equal to 1"))
+		} else if *spec.MinReplicas > spec.MaxReplicas {
+			errs = append(errs, field.Invalid(field.NewPath("minReplicas"), *spec.MinReplicas,
+				"must be less than or equal to maxReplicas"))
+		}
+	}
+
+	for i, metric := range spec.Metrics {
+		errs = append(errs, validateMetric(field.NewPath("metrics").Index(i), metric)...)
+	}
+
+	if len(errs) > 0 {
+		return errorsK8s.NewInvalid(schema.GroupKind{Group: "autoscaling",
+			Kind: "HorizontalPodAutoscaler"}, spec.Name, errs)
+	}
+	return nil
+}
+
+func validateMetric(path *field.Path, metric HorizontalPodAutoscalerMetric) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if metric.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), ""))
+	}
+
+	switch autoscaling.MetricSourceType(metric.Type) {
+	case autoscaling.ResourceMetricSourceType:
+		if (metric.TargetAverageUtilization == nil) == (metric.TargetValue == nil) {
+			errs = append(errs, field.Invalid(path, metric.Name,
+				"exactly one of targetAverageUtilization and targetValue must be set"))
+		}
+		if metric.TargetAverageUtilization != nil && *metric.TargetAverageUtilization < 1 {
+			errs = append(errs, field.Invalid(path.Child("targetAverageUtilization"),
+				*metric.TargetAverageUtilization, "must be greater than 0"))
+		}
+	case autoscaling.PodsMetricSourceType:
+		if metric.TargetValue == nil {
+			errs = append(errs, field.Required(path.Child("targetValue"), ""))
+		}
+	case autoscaling.ObjectMetricSourceType:
+		if metric.TargetValue == nil {
+			errs = append(errs, field.Required(path.Child("targetValue"), ""))
+		}
+		if metric.Object == nil || metric.Object.Kind == "" || metric.Object.Name == "" {
+			errs = append(errs, field.Required(path.Child("object"), ""))
+		}
+	default:
+		errs = append(errs, field.NotSupported(path.Child("type"), metric.Type, []string{
+			string(autoscaling.ResourceMetricSourceType),
+			string(autoscaling.PodsMetricSourceType),
+			string(autoscaling.ObjectMetricSourceType),
+		}))
+	}
+
+	return errs
+}
@@ -0,0 +1,152 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horizontalpodautoscaler
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	autoscalingapi "k8s.io/client-go/pkg/apis/autoscaling"
+	autoscaling "k8s.io/client-go/pkg/apis/autoscaling/v1"
+)
+
+func TestCreateHorizontalPodAutoscaler(t *testing.T) {
+	minReplicas := int32(2)
+	targetCPU := int32(70)
+	targetRequests := resource.MustParse("10")
+	spec := &HorizontalPodAutoscalerSpec{
+		Name:           "hpa",
+		Namespace:      "ns",
+		ScaleTargetRef: ScaleTargetRef{Kind: "Deployment", Name: "app"},
+		MinReplicas:    &minReplicas,
+		MaxReplicas:    5,
+		Metrics: []HorizontalPodAutoscalerMetric{
+			{Type: "Resource", Name: "cpu", TargetAverageUtilization: &targetCPU},
+			{Type: "Pods", Name: "requests", TargetValue: &targetRequests},
+		},
+	}
+
+	fakeClient := fake.NewSimpleClientset()
+	if _, err := CreateHorizontalPodAutoscaler(fakeClient, spec); err != nil {
+		t.Fatalf("CreateHorizontalPodAutoscaler(%#v) returns error: %v", spec, err)
+	}
+
+	hpa, err := fakeClient.AutoscalingV1().HorizontalPodAutoscalers("ns").Get("hpa",
+		metaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("CreateHorizontalPodAutoscaler(%#v) does not create autoscaler: %v", spec, err)
+	}
+
+	expectedSpec := autoscaling.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscaling.CrossVersionObjectReference{Kind: "Deployment", Name: "app",
+			APIVersion: "extensions/v1beta1"},
+		MinReplicas:                    &minReplicas,
+		MaxReplicas:                    5,
+		TargetCPUUtilizationPercentage: &targetCPU,
+	}
+	if !reflect.DeepEqual(hpa.Spec, expectedSpec) {
+		t.Errorf("CreateHorizontalPodAutoscaler(%#v) creates spec %#v, expected %#v", spec,
+			hpa.Spec, expectedSpec)
+	}
+
+	expectedAnnotation := `[{"type":"Pods","pods":{"metricName":"requests","targetAverageValue":"10"}}]`
+	if actual := hpa.Annotations[autoscalingapi.MetricSpecsAnnotation]; actual != expectedAnnotation {
+		t.Errorf("CreateHorizontalPodAutoscaler(%#v) creates metrics annotation %s, expected %s",
+			spec, actual, expectedAnnotation)
+	}
+}
+
+func TestUpdateHorizontalPodAutoscaler(t *testing.T) {
+	targetCPU := int32(50)
+	fakeClient := fake.NewSimpleClientset(&autoscaling.HorizontalPodAutoscaler{
+		ObjectMeta: metaV1.ObjectMeta{Name: "hpa", Namespace: "ns",
+			Labels: map[string]string{"app": "app"},
+			Annotations: map[string]string{
+				autoscalingapi.MetricSpecsAnnotation: `[{"type":"Pods"}]`,
+			}},
+		Spec: autoscaling.HorizontalPodAutoscalerSpec{MaxReplicas: 3},
+	})
+	spec := &HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: ScaleTargetRef{Kind: "StatefulSet", Name: "db"},
+		MaxReplicas:    10,
+		Metrics: []HorizontalPodAutoscalerMetric{
+			{Type: "Resource", Name: "cpu", TargetAverageUtilization: &targetCPU},
+		},
+	}
+
+	actual, err := UpdateHorizontalPodAutoscaler(fakeClient, "ns", "hpa", spec)
+	if err != nil {
+		t.Fatalf("UpdateHorizontalPodAutoscaler(%#v) returns error: %v", spec, err)
+	}
+
+	if actual.MaxReplicas != 10 || actual.ScaleTargetRef.APIVersion != "apps/v1beta1" ||
+		actual.ObjectMeta.Labels["app"] != "app" {
+		t.Errorf("UpdateHorizontalPodAutoscaler(%#v) returns %#v", spec, actual)
+	}
+	if _, ok := actual.ObjectMeta.Annotations[autoscalingapi.MetricSpecsAnnotation]; ok {
+		t.Errorf("UpdateHorizontalPodAutoscaler(%#v) keeps metrics annotation", spec)
+	}
+}
+
+func TestValidateHorizontalPodAutoscalerSpec(t *testing.T) {
+	minReplicas := int32(4)
+	utilization := int32(50)
+	value := resource.MustParse("1")
+	valid := HorizontalPodAutoscalerSpec{
+		Name:           "hpa",
+		ScaleTargetRef: ScaleTargetRef{Kind: "Deployment", Name: "app"},
+		MaxReplicas:    3,
+	}
+
+	cases := []struct {
+		modify func(spec *HorizontalPodAutoscalerSpec)
+		valid  bool
+	}{
+		{func(spec *HorizontalPodAutoscalerSpec) {}, true},
+		{func(spec *HorizontalPodAutoscalerSpec) { spec.Name = "" }, false},
+		{func(spec *HorizontalPodAutoscalerSpec) { spec.ScaleTargetRef.Kind = "Pod" }, false},
+		{func(spec *HorizontalPodAutoscalerSpec) { spec.MaxReplicas = 0 }, false},
+		{func(spec *HorizontalPodAutoscalerSpec) { spec.MinReplicas = &minReplicas }, false},
+		{func(spec *HorizontalPodAutoscalerSpec) {
+			spec.Metrics = []HorizontalPodAutoscalerMetric{{Type: "Resource", Name: "memory",
+				TargetAverageUtilization: &utilization, TargetValue: &value}}
+		}, false},
+		{func(spec *HorizontalPodAutoscalerSpec) {
+			spec.Metrics = []HorizontalPodAutoscalerMetric{{Type: "Object", Name: "length",
+				TargetValue: &value}}
+		}, false},
+		{func(spec *HorizontalPodAutoscalerSpec) {
+			spec.Metrics = []HorizontalPodAutoscalerMetric{{Type: "External", Name: "queue",
+				TargetValue: &value}}
+		}, false},
+		{func(spec *HorizontalPodAutoscalerSpec) {
+			spec.Metrics = []HorizontalPodAutoscalerMetric{{Type: "Object", Name: "length",
+				Object: &ScaleTargetRef{Kind: "Service", Name: "queue"}, TargetValue: &value}}
+		}, true},
+	}
+
+	for i, c := range cases {
+		spec := valid
+		c.modify(&spec)
+		err := validateHorizontalPodAutoscalerSpec(&spec)
+		if (err == nil) != c.valid {
+			t.Errorf("case %d: validateHorizontalPodAutoscalerSpec(%#v) returns %v, expected valid: %t",
+				i, spec, err, c.valid)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horizontalpodautoscaler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	autoscaling "k8s.io/client-go/pkg/apis/autoscaling/v1"
+)
+
+// customMetricsAPIPath is the path of the custom metrics API served by metrics adapters, e.g.
+// Prometheus adapter.
+const customMetricsAPIPath = "/apis/custom.metrics.k8s.io/v1beta1"
+
+// metricValue and metricValueList mirror types of the custom metrics API, which is not part of
+// client-go.
+type metricValue struct {
+	DescribedObject v1.ObjectReference `json:"describedObject"`
+	MetricName      string             `json:"metricName"`
+	Timestamp       metaV1.Time        `json:"timestamp"`
+	Value           resource.Quantity  `json:"value"`
+}
+
+type metricValueList struct {
+	Items []metricValue `json:"items"`
+}
+
+// fillCustomMetrics sets current values of Pods and Object metrics that are not reported by the
+// autoscaler, e.g. because the autoscaler controller could not read them yet, by querying the
+// custom metrics API. Metrics that can not be read stay without current value.
+func fillCustomMetrics(client client.Interface, hpa *autoscaling.HorizontalPodAutoscaler,
+	metrics []HorizontalPodAutoscalerMetric) {
+	var selector labels.Selector
+	for i := range metrics {
+		metric := &metrics[i]
+		if metric.CurrentValue != nil {
+			continue
+		}
+
+		var value *resource.Quantity
+		var err error
+		switch autoscaling.MetricSourceType(metric.Type) {
+		case autoscaling.PodsMetricSourceType:
+			if selector == nil {
+				selector, err = getScaleTargetSelector(client, hpa)
+				if err != nil {
+					break
+				}
+			}
+			value, err = getPodsMetricValue(client, hpa.Namespace, metric.Name, selector)
+		case autoscaling.ObjectMetricSourceType:
+			value, err = getObjectMetricValue(client, hpa.Namespace, metric.Name, metric.Object)
+		default:
+			continue
+		}
+
+		if err != nil {
+			logger.Errorf("Could not get %s metric of %s horizontal pod autoscaler from custom metrics "+
+				"API: %s", metric.Name, hpa.Name, err)
+			continue
+		}
+		metric.CurrentValue = value
+	}
+}
+
+// getScaleTargetSelector returns selector of pods scaled by the autoscaler.
+func getScaleTargetSelector(client client.Interface,
+	hpa *autoscaling.HorizontalPodAutoscaler) (labels.Selector, error) {
+	scale, err := client.ExtensionsV1beta1().Scales(hpa.Namespace).Get(hpa.Spec.ScaleTargetRef.Kind,
+		hpa.Spec.ScaleTargetRef.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if scale.Status.TargetSelector != "" {
+		return labels.Parse(scale.Status.TargetSelector)
+	}
+	return labels.SelectorFromSet(scale.Status.Selector), nil
+}
+
+// getPodsMetricValue returns average value of the metric across pods matching the selector.
+func getPodsMetricValue(client client.Interface, namespace string, metricName string,
+	selector labels.Selector) (*resource.Quantity, error) {
+	list := metricValueList{}
+	err := getCustomMetric(client, []string{"namespaces", namespace, "pods", "*", metricName},
+		selector, &list)
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("no values of %s metric found", metricName)
+	}
+
+	var sum int64
+	for _, item := range list.Items {
+		sum += item.Value.MilliValue()
+	}
+	return resource.NewMilliQuantity(sum/int64(len(list.Items)), resource.DecimalSI), nil
+}
+
+// getObjectMetricValue returns value of the metric describing the object.
+func getObjectMetricValue(client client.Interface, namespace string, metricName string,
+	object *ScaleTargetRef) (*resource.Quantity, error) {
+	if object == nil {
+		return nil, fmt.Errorf("no object described by %s metric", metricName)
+	}
+
+	groupVersion, err := schema.ParseGroupVersion(object.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	plural, _ := meta.UnsafeGuessKindToResource(groupVersion.WithKind(object.Kind))
+	resourceName := plural.Resource
+	if groupVersion.Group != "" {
+		resourceName += "." + groupVersion.Group
+	}
+
+	list := metricValueList{}
+	err = getCustomMetric(client, []string{"namespaces", namespace, resourceName, object.Name,
+		metricName}, nil, &list)
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("no values of %s metric found", metricName)
+	}
+	return copyQuantity(list.Items[0].Value), nil
+}
+
+func getCustomMetric(client client.Interface, path []string, selector labels.Selector,
+	list *metricValueList) error {
+	request := client.CoreV1().RESTClient().Get().
+		AbsPath(append([]string{customMetricsAPIPath}, path...)...)
+	if selector != nil {
+		request = request.Param("labelSelector", selector.String())
+	}
+
+	raw, err := request.DoRaw()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, list)
+}
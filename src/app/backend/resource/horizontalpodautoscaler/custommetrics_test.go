@@ -0,0 +1,92 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horizontalpodautoscaler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	autoscalingapi "k8s.io/client-go/pkg/apis/autoscaling"
+	autoscaling "k8s.io/client-go/pkg/apis/autoscaling/v1"
+	"k8s.io/client-go/rest"
+)
+
+func TestFillCustomMetrics(t *testing.T) {
+	var podsSelector string
+	responses := map[string]string{
+		"/apis/extensions/v1beta1/namespaces/ns-1/deployments/app/scale": `{"kind": "Scale",
+			"apiVersion": "extensions/v1beta1", "metadata": {"name": "app", "namespace": "ns-1"},
+			"status": {"replicas": 2, "selector": {"app": "web"}, "targetSelector": "app=web"}}`,
+		"/apis/custom.metrics.k8s.io/v1beta1/namespaces/ns-1/pods/*/requests": `{"items": [
+			{"describedObject": {"kind": "Pod", "name": "app-1"}, "metricName": "requests", "value": "10"},
+			{"describedObject": {"kind": "Pod", "name": "app-2"}, "metricName": "requests", "value": "15"}]}`,
+		"/apis/custom.metrics.k8s.io/v1beta1/namespaces/ns-1/services/queue/length": `{"items": [
+			{"describedObject": {"kind": "Service", "name": "queue"}, "metricName": "length", "value": "42"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Path == "/apis/custom.metrics.k8s.io/v1beta1/namespaces/ns-1/pods/*/requests" {
+			podsSelector = r.URL.Query().Get("labelSelector")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	k8sClient, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("Could not create client: %v", err)
+	}
+
+	hpa := &autoscaling.HorizontalPodAutoscaler{
+		ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "ns-1", Annotations: map[string]string{
+			autoscalingapi.MetricSpecsAnnotation: `[` +
+				`{"type":"Pods","pods":{"metricName":"requests","targetAverageValue":"10"}},` +
+				`{"type":"Object","object":{"target":{"kind":"Service","name":"queue"},` +
+				`"metricName":"length","targetValue":"100"}},` +
+				`{"type":"Object","object":{"target":{"kind":"Service","name":"missing"},` +
+				`"metricName":"length","targetValue":"100"}}]`,
+		}},
+		Spec: autoscaling.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscaling.CrossVersionObjectReference{Kind: "Deployment", Name: "app"},
+		},
+	}
+	metrics := getMetrics(hpa)
+	fillCustomMetrics(k8sClient, hpa, metrics)
+
+	expected := []*resource.Quantity{
+		resource.NewMilliQuantity(12500, resource.DecimalSI),
+		copyQuantity(resource.MustParse("42")),
+		nil,
+	}
+	for i, metric := range metrics {
+		if !reflect.DeepEqual(metric.CurrentValue, expected[i]) {
+			t.Errorf("Current value of %s metric is %v, expected %v", metric.Name, metric.CurrentValue,
+				expected[i])
+		}
+	}
+	if podsSelector != "app=web" {
+		t.Errorf("Pods metric queried with %q selector, expected %q", podsSelector, "app=web")
+	}
+}
@@ -15,9 +15,8 @@
 package horizontalpodautoscaler
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	client "k8s.io/client-go/kubernetes"
 	autoscaling "k8s.io/client-go/pkg/apis/autoscaling/v1"
@@ -37,15 +36,20 @@ type HorizontalPodAutoscalerDetail struct {
 	CurrentCPUUtilizationPercentage *int32 `json:"currentCPUUtilizationPercentage"`
 	TargetCPUUtilizationPercentage  *int32 `json:"targetCPUUtilizationPercentage"`
 
+	// All metrics used by the autoscaler with their targets and current values.
+	Metrics []HorizontalPodAutoscalerMetric `json:"metrics"`
+
 	CurrentReplicas int32 `json:"currentReplicas"`
 	DesiredReplicas int32 `json:"desiredReplicas"`
 
 	LastScaleTime *v1.Time `json:"lastScaleTime"`
 }
 
-// GetHorizontalPodAutoscalerDetail returns detailed information about a horizontal pod autoscaler
+// GetHorizontalPodAutoscalerDetail returns detailed information about a horizontal pod autoscaler.
+// Current values of custom metrics not reported by the autoscaler are read from the custom
+// metrics API.
 func GetHorizontalPodAutoscalerDetail(client client.Interface, namespace string, name string) (*HorizontalPodAutoscalerDetail, error) {
-	log.Printf("Getting details of %s horizontal pod autoscaler", name)
+	logger.Infof("Getting details of %s horizontal pod autoscaler", name)
 
 	rawHorizontalPodAutoscaler, err := client.AutoscalingV1().HorizontalPodAutoscalers(namespace).Get(name, v1.GetOptions{})
 
@@ -53,7 +57,9 @@ func GetHorizontalPodAutoscalerDetail(client client.Interface, namespace string,
 		return nil, err
 	}
 
-	return getHorizontalPodAutoscalerDetail(rawHorizontalPodAutoscaler), nil
+	detail := getHorizontalPodAutoscalerDetail(rawHorizontalPodAutoscaler)
+	fillCustomMetrics(client, rawHorizontalPodAutoscaler, detail.Metrics)
+	return detail, nil
 }
 
 func getHorizontalPodAutoscalerDetail(horizontalPodAutoscaler *autoscaling.HorizontalPodAutoscaler) *HorizontalPodAutoscalerDetail {
@@ -62,15 +68,13 @@ func getHorizontalPodAutoscalerDetail(horizontalPodAutoscaler *autoscaling.Horiz
 		ObjectMeta: api.NewObjectMeta(horizontalPodAutoscaler.ObjectMeta),
 		TypeMeta:   api.NewTypeMeta(api.ResourceKindHorizontalPodAutoscaler),
 
-		ScaleTargetRef: ScaleTargetRef{
-			Kind: horizontalPodAutoscaler.Spec.ScaleTargetRef.Kind,
-			Name: horizontalPodAutoscaler.Spec.ScaleTargetRef.Name,
-		},
+		ScaleTargetRef: *toScaleTargetRef(horizontalPodAutoscaler.Spec.ScaleTargetRef),
 
 		MinReplicas:                     horizontalPodAutoscaler.Spec.MinReplicas,
 		MaxReplicas:                     horizontalPodAutoscaler.Spec.MaxReplicas,
 		CurrentCPUUtilizationPercentage: horizontalPodAutoscaler.Status.CurrentCPUUtilizationPercentage,
 		TargetCPUUtilizationPercentage:  horizontalPodAutoscaler.Spec.TargetCPUUtilizationPercentage,
+		Metrics:                         getMetrics(horizontalPodAutoscaler),
 
 		CurrentReplicas: horizontalPodAutoscaler.Status.CurrentReplicas,
 		DesiredReplicas: horizontalPodAutoscaler.Status.DesiredReplicas,
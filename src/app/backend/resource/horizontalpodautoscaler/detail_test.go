@@ -60,6 +60,7 @@ func TestGetHorizontalPodAutoscalerDetail(t *testing.T) {
 				MaxReplicas:     3,
 				CurrentReplicas: 1,
 				DesiredReplicas: 2,
+				Metrics:         []HorizontalPodAutoscalerMetric{},
 			},
 		},
 	}
@@ -41,6 +41,9 @@ type HorizontalPodAutoscaler struct {
 	MaxReplicas                     int32          `json:"maxReplicas"`
 	CurrentCPUUtilizationPercentage *int32         `json:"currentCPUUtilizationPercentage"`
 	TargetCPUUtilizationPercentage  *int32         `json:"targetCPUUtilizationPercentage"`
+
+	// All metrics used by the autoscaler, including custom metrics.
+	Metrics []HorizontalPodAutoscalerMetric `json:"metrics"`
 }
 
 func GetHorizontalPodAutoscalerList(client k8sClient.Interface, nsQuery *common.NamespaceQuery) (*HorizontalPodAutoscalerList, error) {
@@ -93,16 +96,14 @@ func toHorizontalPodAutoscalerList(hpas []autoscaling.HorizontalPodAutoscaler, n
 
 func toHorizontalPodAutoScaler(hpa *autoscaling.HorizontalPodAutoscaler) HorizontalPodAutoscaler {
 	return HorizontalPodAutoscaler{
-		ObjectMeta: api.NewObjectMeta(hpa.ObjectMeta),
-		TypeMeta:   api.NewTypeMeta(api.ResourceKindHorizontalPodAutoscaler),
-		ScaleTargetRef: ScaleTargetRef{
-			Kind: hpa.Spec.ScaleTargetRef.Kind,
-			Name: hpa.Spec.ScaleTargetRef.Name,
-		},
+		ObjectMeta:                      api.NewObjectMeta(hpa.ObjectMeta),
+		TypeMeta:                        api.NewTypeMeta(api.ResourceKindHorizontalPodAutoscaler),
+		ScaleTargetRef:                  *toScaleTargetRef(hpa.Spec.ScaleTargetRef),
 		MinReplicas:                     hpa.Spec.MinReplicas,
 		MaxReplicas:                     hpa.Spec.MaxReplicas,
 		CurrentCPUUtilizationPercentage: hpa.Status.CurrentCPUUtilizationPercentage,
 		TargetCPUUtilizationPercentage:  hpa.Spec.TargetCPUUtilizationPercentage,
+		Metrics:                         getMetrics(hpa),
 	}
 
 }
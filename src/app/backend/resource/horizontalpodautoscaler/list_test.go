@@ -90,6 +90,7 @@ var (
 				Name: "test-name1",
 			},
 			MaxReplicas: 3,
+			Metrics:     []HorizontalPodAutoscalerMetric{},
 		}, {
 			ObjectMeta: api.ObjectMeta{Name: "test-hpa2", Namespace: "test-ns"},
 			TypeMeta:   api.TypeMeta{Kind: api.ResourceKindHorizontalPodAutoscaler},
@@ -98,6 +99,7 @@ var (
 				Name: "test-name2",
 			},
 			MaxReplicas: 3,
+			Metrics:     []HorizontalPodAutoscalerMetric{},
 		}, {
 			ObjectMeta: api.ObjectMeta{Name: "test-hpa3", Namespace: "test-ns"},
 			TypeMeta:   api.TypeMeta{Kind: api.ResourceKindHorizontalPodAutoscaler},
@@ -106,6 +108,7 @@ var (
 				Name: "test-name2",
 			},
 			MaxReplicas: 3,
+			Metrics:     []HorizontalPodAutoscalerMetric{},
 		}, {
 			ObjectMeta: api.ObjectMeta{Name: "test-hpa4", Namespace: "test-ns"},
 			TypeMeta:   api.TypeMeta{Kind: api.ResourceKindHorizontalPodAutoscaler},
@@ -114,6 +117,7 @@ var (
 				Name: "test-name3",
 			},
 			MaxReplicas: 3,
+			Metrics:     []HorizontalPodAutoscalerMetric{},
 		},
 	}
 )
@@ -0,0 +1,172 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horizontalpodautoscaler
+
+import (
+	"encoding/json"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+	autoscalingapi "k8s.io/client-go/pkg/apis/autoscaling"
+	autoscaling "k8s.io/client-go/pkg/apis/autoscaling/v1"
+)
+
+// HorizontalPodAutoscalerMetric is a single metric used by horizontal pod autoscaler, with its
+// target and the latest observed value. Only CPU utilization is part of the autoscaling/v1 API,
+// other metrics (memory, custom pod metrics and object metrics) are stored in annotations of the
+// v1 object.
+type HorizontalPodAutoscalerMetric struct {
+	// Type is one of Resource, Pods or Object.
+	Type string `json:"type"`
+
+	// Name of the resource (e.g. cpu) for Resource metrics, otherwise name of the custom metric.
+	Name string `json:"name"`
+
+	// Object described by the metric, only used by Object metrics.
+	Object *ScaleTargetRef `json:"object,omitempty"`
+
+	TargetAverageUtilization *int32             `json:"targetAverageUtilization,omitempty"`
+	TargetValue              *resource.Quantity `json:"targetValue,omitempty"`
+
+	CurrentAverageUtilization *int32             `json:"currentAverageUtilization,omitempty"`
+	CurrentValue              *resource.Quantity `json:"currentValue,omitempty"`
+}
+
+// getMetrics returns all metrics of the autoscaler, pairing the targets with current values.
+func getMetrics(hpa *autoscaling.HorizontalPodAutoscaler) []HorizontalPodAutoscalerMetric {
+	metrics := make([]HorizontalPodAutoscalerMetric, 0)
+
+	if hpa.Spec.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, HorizontalPodAutoscalerMetric{
+			Type:                      string(autoscaling.ResourceMetricSourceType),
+			Name:                      string(v1.ResourceCPU),
+			TargetAverageUtilization:  hpa.Spec.TargetCPUUtilizationPercentage,
+			CurrentAverageUtilization: hpa.Status.CurrentCPUUtilizationPercentage,
+		})
+	}
+
+	specs := make([]autoscaling.MetricSpec, 0)
+	if err := unmarshalAnnotation(hpa, autoscalingapi.MetricSpecsAnnotation, &specs); err != nil {
+		return metrics
+	}
+	for _, spec := range specs {
+		metrics = append(metrics, fromMetricSpec(spec))
+	}
+
+	statuses := make([]autoscaling.MetricStatus, 0)
+	if err := unmarshalAnnotation(hpa, autoscalingapi.MetricStatusesAnnotation, &statuses); err != nil {
+		return metrics
+	}
+	for _, status := range statuses {
+		current := fromMetricStatus(status)
+		for i := range metrics {
+			if getMetricKey(metrics[i]) == getMetricKey(current) {
+				metrics[i].CurrentAverageUtilization = current.CurrentAverageUtilization
+				metrics[i].CurrentValue = current.CurrentValue
+			}
+		}
+	}
+
+	return metrics
+}
+
+func unmarshalAnnotation(hpa *autoscaling.HorizontalPodAutoscaler, annotation string,
+	target interface{}) error {
+	data, ok := hpa.Annotations[annotation]
+	if !ok {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(data), target); err != nil {
+		logger.Errorf("Invalid %s annotation of %s horizontal pod autoscaler: %v", annotation,
+			hpa.Name, err)
+		return err
+	}
+	return nil
+}
+
+// getMetricKey identifies a metric, so that target and status of the same metric can be paired.
+func getMetricKey(metric HorizontalPodAutoscalerMetric) string {
+	key := metric.Type + "/" + metric.Name
+	if metric.Object != nil {
+		key += "/" + metric.Object.Kind + "/" + metric.Object.Name
+	}
+	return key
+}
+
+func fromMetricSpec(spec autoscaling.MetricSpec) HorizontalPodAutoscalerMetric {
+	metric := HorizontalPodAutoscalerMetric{Type: string(spec.Type)}
+	switch {
+	case spec.Resource != nil:
+		metric.Name = string(spec.Resource.Name)
+		metric.TargetAverageUtilization = spec.Resource.TargetAverageUtilization
+		metric.TargetValue = spec.Resource.TargetAverageValue
+	case spec.Pods != nil:
+		metric.Name = spec.Pods.MetricName
+		metric.TargetValue = copyQuantity(spec.Pods.TargetAverageValue)
+	case spec.Object != nil:
+		metric.Name = spec.Object.MetricName
+		metric.Object = toScaleTargetRef(spec.Object.Target)
+		metric.TargetValue = copyQuantity(spec.Object.TargetValue)
+	}
+	return metric
+}
+
+func fromMetricStatus(status autoscaling.MetricStatus) HorizontalPodAutoscalerMetric {
+	metric := HorizontalPodAutoscalerMetric{Type: string(status.Type)}
+	switch {
+	case status.Resource != nil:
+		metric.Name = string(status.Resource.Name)
+		metric.CurrentAverageUtilization = status.Resource.CurrentAverageUtilization
+		metric.CurrentValue = copyQuantity(status.Resource.CurrentAverageValue)
+	case status.Pods != nil:
+		metric.Name = status.Pods.MetricName
+		metric.CurrentValue = copyQuantity(status.Pods.CurrentAverageValue)
+	case status.Object != nil:
+		metric.Name = status.Object.MetricName
+		metric.Object = toScaleTargetRef(status.Object.Target)
+		metric.CurrentValue = copyQuantity(status.Object.CurrentValue)
+	}
+	return metric
+}
+
+// toMetricSpec converts metric target to autoscaling metric spec. Metric has to be validated.
+func toMetricSpec(metric HorizontalPodAutoscalerMetric) autoscaling.MetricSpec {
+	spec := autoscaling.MetricSpec{Type: autoscaling.MetricSourceType(metric.Type)}
+	switch spec.Type {
+	case autoscaling.ResourceMetricSourceType:
+		spec.Resource = &autoscaling.ResourceMetricSource{
+			Name:                     v1.ResourceName(metric.Name),
+			TargetAverageUtilization: metric.TargetAverageUtilization,
+			TargetAverageValue:       metric.TargetValue,
+		}
+	case autoscaling.PodsMetricSourceType:
+		spec.Pods = &autoscaling.PodsMetricSource{
+			MetricName:         metric.Name,
+			TargetAverageValue: *metric.TargetValue,
+		}
+	case autoscaling.ObjectMetricSourceType:
+		spec.Object = &autoscaling.ObjectMetricSource{
+			Target:      toCrossVersionObjectReference(*metric.Object),
+			MetricName:  metric.Name,
+			TargetValue: *metric.TargetValue,
+		}
+	}
+	return spec
+}
+
+func copyQuantity(quantity resource.Quantity) *resource.Quantity {
+	return &quantity
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package horizontalpodautoscaler
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	autoscalingapi "k8s.io/client-go/pkg/apis/autoscaling"
+	autoscaling "k8s.io/client-go/pkg/apis/autoscaling/v1"
+)
+
+func TestGetMetrics(t *testing.T) {
+	targetCPU := int32(60)
+	currentCPU := int32(45)
+	targetRequests := resource.MustParse("10")
+	currentRequests := resource.MustParse("12")
+	targetQueue := resource.MustParse("100")
+
+	cases := []struct {
+		hpa      *autoscaling.HorizontalPodAutoscaler
+		expected []HorizontalPodAutoscalerMetric
+	}{
+		{
+			&autoscaling.HorizontalPodAutoscaler{},
+			[]HorizontalPodAutoscalerMetric{},
+		},
+		{
+			&autoscaling.HorizontalPodAutoscaler{
+				ObjectMeta: metaV1.ObjectMeta{Annotations: map[string]string{
+					autoscalingapi.MetricSpecsAnnotation: `[` +
+						`{"type":"Pods","pods":{"metricName":"requests","targetAverageValue":"10"}},` +
+						`{"type":"Object","object":{"target":{"kind":"Service","name":"queue"},` +
+						`"metricName":"length","targetValue":"100"}}]`,
+					autoscalingapi.MetricStatusesAnnotation: `[` +
+						`{"type":"Pods","pods":{"metricName":"requests","currentAverageValue":"12"}}]`,
+				}},
+				Spec: autoscaling.HorizontalPodAutoscalerSpec{
+					TargetCPUUtilizationPercentage: &targetCPU,
+				},
+				Status: autoscaling.HorizontalPodAutoscalerStatus{
+					CurrentCPUUtilizationPercentage: &currentCPU,
+				},
+			},
+			[]HorizontalPodAutoscalerMetric{
+				{
+					Type:                      "Resource",
+					Name:                      "cpu",
+					TargetAverageUtilization:  &targetCPU,
+					CurrentAverageUtilization: &currentCPU,
+				},
+				{
+					Type:         "Pods",
+					Name:         "requests",
+					TargetValue:  &targetRequests,
+					CurrentValue: &currentRequests,
+				},
+				{
+					Type:        "Object",
+					Name:        "length",
+					Object:      &ScaleTargetRef{Kind: "Service", Name: "queue"},
+					TargetValue: &targetQueue,
+				},
+			},
+		},
+		{
+			&autoscaling.HorizontalPodAutoscaler{
+				ObjectMeta: metaV1.ObjectMeta{Annotations: map[string]string{
+					autoscalingapi.MetricSpecsAnnotation: `invalid`,
+				}},
+			},
+			[]HorizontalPodAutoscalerMetric{},
+		},
+	}
+
+	for _, c := range cases {
+		actual := getMetrics(c.hpa)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("getMetrics(%#v) == \ngot: %#v, \nexpected %#v", c.hpa, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	scannerapi "github.com/kubernetes/dashboard/src/app/backend/integration/scanner/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// At most cap(scanSemaphore) scans run at once, shared by all requests. Images not scanned within
+// scanTimeout are returned without vulnerabilities. Scans already running continue in the
+// background, so that the next request gets them from the scanner cache, others are not started.
+var (
+	scanSemaphore = make(chan struct{}, 4)
+	scanTimeout   = 20 * time.Second
+)
+
+// Image is a distinct container image running in the cluster.
+type Image struct {
+	// Name is the image as specified in pod specs.
+	Name string `json:"name"`
+	// Digest is the digest of the image run by the container runtime. Empty for containers, which
+	// have not started yet.
+	Digest string `json:"digest"`
+	// Number of pods and containers running the image.
+	Pods       int `json:"pods"`
+	Containers int `json:"containers"`
+	// Namespaces of pods running the image.
+	Namespaces []string `json:"namespaces"`
+	// Vulnerabilities found by the active scanner. Nil if no scanner is enabled or the scan failed.
+	Vulnerabilities *scannerapi.VulnerabilitySummary `json:"vulnerabilities,omitempty"`
+	// ScanError describes why vulnerabilities are missing when scanner is enabled.
+	ScanError string `json:"scanError,omitempty"`
+}
+
+// ImageList contains distinct images running in the cluster, images run by the most pods first.
+type ImageList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+	Images   []Image      `json:"images"`
+	// Scanner is the id of the active scanner integration. Empty if no scanner is enabled.
+	Scanner string `json:"scanner"`
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetImageList returns distinct images of containers and init containers of pods, which are not
+// finished, in given namespaces. Vulnerabilities are attached when scannerClient is not nil.
+func GetImageList(client client.Interface, scannerClient scannerapi.ScannerClient,
+	nsQuery *common.NamespaceQuery) (*ImageList, error) {
+	logger.Info("Getting list of images")
+
+	channels := &common.ResourceChannels{
+		PodList: common.GetPodListChannel(client, nsQuery, 1),
+	}
+
+	pods := <-channels.PodList.List
+	err := <-channels.PodList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	result := toImageList(pods.Items)
+	result.Errors = nonCriticalErrors
+	if scannerClient != nil {
+		result.Scanner = string(scannerClient.ID())
+		scanImages(scannerClient, result.Images)
+	}
+	return result, nil
+}
+
+func toImageList(pods []v1.Pod) *ImageList {
+	type imageKey struct{ name, digest string }
+	images := make(map[imageKey]*Image)
+	podKeys := make(map[imageKey]map[string]bool)
+	namespaces := make(map[imageKey]map[string]bool)
+
+	for _, pod := range pods {
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+
+		digests := make(map[string]string)
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			digests[status.Name] = scannerapi.DigestFromImageID(status.ImageID)
+		}
+
+		for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			key := imageKey{container.Image, digests[container.Name]}
+			image, ok := images[key]
+			if !ok {
+				image = &Image{Name: key.name, Digest: key.digest}
+				images[key] = image
+				podKeys[key] = make(map[string]bool)
+				namespaces[key] = make(map[string]bool)
+			}
+			image.Containers++
+			podKeys[key][pod.Namespace+"/"+pod.Name] = true
+			namespaces[key][pod.Namespace] = true
+		}
+	}
+
+	result := &ImageList{Images: make([]Image, 0)}
+	for key, image := range images {
+		image.Pods = len(podKeys[key])
+		image.Namespaces = make([]string, 0)
+		for namespace := range namespaces[key] {
+			image.Namespaces = append(image.Namespaces, namespace)
+		}
+		sort.Strings(image.Namespaces)
+		result.Images = append(result.Images, *image)
+	}
+
+	sort.Slice(result.Images, func(i, j int) bool {
+		left, right := result.Images[i], result.Images[j]
+		if left.Pods != right.Pods {
+			return left.Pods > right.Pods
+		}
+		if left.Name != right.Name {
+			return left.Name < right.Name
+		}
+		return left.Digest < right.Digest
+	})
+	result.ListMeta = api.ListMeta{TotalItems: len(result.Images)}
+	return result
+}
+
+// scanImages attaches vulnerabilities to the images. Images, which were not scanned within the
+// scan timeout, get scan error.
+func scanImages(scannerClient scannerapi.ScannerClient, images []Image) {
+	type scanResult struct {
+		index   int
+		summary *scannerapi.VulnerabilitySummary
+		err     error
+	}
+
+	// Buffered, so that scans finished after the timeout do not block.
+	results := make(chan scanResult, len(images))
+	// Closed on return, so that scans waiting for the semaphore are not started after the timeout.
+	done := make(chan struct{})
+	defer close(done)
+	for i := range images {
+		go func(index int, image scannerapi.ImageReference) {
+			select {
+			case scanSemaphore <- struct{}{}:
+			case <-done:
+				return
+			}
+			defer func() { <-scanSemaphore }()
+			select {
+			case <-done:
+				return
+			default:
+			}
+			summary, err := scannerClient.Scan(image)
+			results <- scanResult{index, summary, err}
+		}(i, scannerapi.ImageReference{Name: images[i].Name, Digest: images[i].Digest})
+	}
+
+	timeout := time.After(scanTimeout)
+	for received := 0; received < len(images); received++ {
+		select {
+		case result := <-results:
+			if result.err != nil {
+				images[result.index].ScanError = result.err.Error()
+				continue
+			}
+			images[result.index].Vulnerabilities = result.summary
+		case <-timeout:
+			for i := range images {
+				if images[i].Vulnerabilities == nil && len(images[i].ScanError) == 0 {
+					images[i].ScanError = "Scan is still in progress"
+				}
+			}
+			return
+		}
+	}
+}
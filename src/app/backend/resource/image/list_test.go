@@ -0,0 +1,153 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	scannerapi "github.com/kubernetes/dashboard/src/app/backend/integration/scanner/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+type fakeScannerClient struct {
+	block chan struct{}
+}
+
+func (fakeScannerClient) HealthCheck() error { return nil }
+
+func (fakeScannerClient) ID() integrationapi.IntegrationID { return "fake" }
+
+func (self fakeScannerClient) Scan(image scannerapi.ImageReference) (*scannerapi.VulnerabilitySummary, error) {
+	switch image.Name {
+	case "broken":
+		return nil, errors.New("scan failed")
+	case "slow":
+		<-self.block
+	}
+	return &scannerapi.VulnerabilitySummary{Scanner: "fake", Scanned: true, High: 1, Total: 1}, nil
+}
+
+func getTestPod(namespace, name string, phase v1.PodPhase, images ...string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: namespace},
+		Status:     v1.PodStatus{Phase: phase},
+	}
+	for i, image := range images {
+		containerName := string(rune('a' + i))
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: containerName, Image: image})
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, v1.ContainerStatus{
+			Name:    containerName,
+			ImageID: "docker-pullable://" + image + "@sha256:" + image,
+		})
+	}
+	return pod
+}
+
+func TestGetImageList(t *testing.T) {
+	sidecar := getTestPod("kube-system", "sidecar", v1.PodRunning, "nginx", "envoy")
+	sidecar.Spec.InitContainers = []v1.Container{{Name: "init", Image: "busybox"}}
+	pending := getTestPod("default", "pending", v1.PodPending)
+	pending.Spec.Containers = []v1.Container{{Name: "a", Image: "nginx"}}
+
+	client := fake.NewSimpleClientset(
+		getTestPod("default", "web-1", v1.PodRunning, "nginx"),
+		getTestPod("default", "web-2", v1.PodRunning, "nginx"),
+		getTestPod("default", "done", v1.PodSucceeded, "job"),
+		sidecar,
+		pending,
+	)
+
+	actual, err := GetImageList(client, nil, common.NewNamespaceQuery(nil))
+	if err != nil {
+		t.Fatalf("GetImageList returned error: %s", err)
+	}
+
+	expected := &ImageList{
+		ListMeta: api.ListMeta{TotalItems: 4},
+		Images: []Image{
+			{Name: "nginx", Digest: "sha256:nginx", Pods: 3, Containers: 3,
+				Namespaces: []string{"default", "kube-system"}},
+			{Name: "busybox", Pods: 1, Containers: 1, Namespaces: []string{"kube-system"}},
+			{Name: "envoy", Digest: "sha256:envoy", Pods: 1, Containers: 1,
+				Namespaces: []string{"kube-system"}},
+			{Name: "nginx", Pods: 1, Containers: 1, Namespaces: []string{"default"}},
+		},
+		Errors: []error{},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetImageList() == \ngot: %#v, \nexpected %#v", actual, expected)
+	}
+}
+
+func TestScanImages(t *testing.T) {
+	scanTimeout = 100 * time.Millisecond
+	block := make(chan struct{})
+	defer close(block)
+
+	images := []Image{{Name: "nginx"}, {Name: "broken"}, {Name: "slow"}}
+	scanImages(fakeScannerClient{block: block}, images)
+
+	expected := []Image{
+		{Name: "nginx", Vulnerabilities: &scannerapi.VulnerabilitySummary{Scanner: "fake", Scanned: true,
+			High: 1, Total: 1}},
+		{Name: "broken", ScanError: "scan failed"},
+		{Name: "slow", ScanError: "Scan is still in progress"},
+	}
+	if !reflect.DeepEqual(images, expected) {
+		t.Errorf("scanImages() == \ngot: %#v, \nexpected %#v", images, expected)
+	}
+}
+
+type countingScannerClient struct {
+	fakeScannerClient
+	scans chan scannerapi.ImageReference
+}
+
+func (self countingScannerClient) Scan(image scannerapi.ImageReference) (*scannerapi.VulnerabilitySummary, error) {
+	self.scans <- image
+	return self.fakeScannerClient.Scan(image)
+}
+
+func TestScanImagesBusyScanner(t *testing.T) {
+	scanTimeout = 50 * time.Millisecond
+	// Scans of other requests occupy the scanner.
+	for i := 0; i < cap(scanSemaphore); i++ {
+		scanSemaphore <- struct{}{}
+	}
+
+	client := countingScannerClient{scans: make(chan scannerapi.ImageReference, 1)}
+	images := []Image{{Name: "nginx"}}
+	scanImages(client, images)
+	if images[0].ScanError != "Scan is still in progress" {
+		t.Errorf("Expected scan in progress, got %#v", images[0])
+	}
+
+	for i := 0; i < cap(scanSemaphore); i++ {
+		<-scanSemaphore
+	}
+	select {
+	case image := <-client.scans:
+		t.Errorf("Expected scan of %s not to start after the timeout", image.Name)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"fmt"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+// IngressBackendStatus describes health of the service that serves a single ingress path.
+type IngressBackendStatus struct {
+	// Host and path of the rule, both empty for the default backend.
+	Host string `json:"host"`
+	Path string `json:"path"`
+
+	// Default is true for the backend serving requests that do not match any rule.
+	Default bool `json:"default"`
+
+	ServiceName string `json:"serviceName"`
+	ServicePort string `json:"servicePort"`
+
+	// ServiceFound is false when the service or its port does not exist.
+	ServiceFound bool `json:"serviceFound"`
+
+	// Number of endpoints that can and can not receive traffic.
+	ReadyEndpoints    int `json:"readyEndpoints"`
+	NotReadyEndpoints int `json:"notReadyEndpoints"`
+
+	// Error describing why the backend can not be resolved.
+	Error string `json:"error,omitempty"`
+}
+
+// getBackendStatuses resolves services and endpoints of all ingress backends.
+func getBackendStatuses(client client.Interface, ingress *extensions.Ingress) []IngressBackendStatus {
+	result := make([]IngressBackendStatus, 0)
+
+	if ingress.Spec.Backend != nil {
+		status := getBackendStatus(client, ingress.Namespace, *ingress.Spec.Backend)
+		status.Default = true
+		result = append(result, status)
+	}
+
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			status := getBackendStatus(client, ingress.Namespace, path.Backend)
+			status.Host = rule.Host
+			status.Path = path.Path
+			result = append(result, status)
+		}
+	}
+
+	return result
+}
+
+func getBackendStatus(client client.Interface, namespace string,
+	backend extensions.IngressBackend) IngressBackendStatus {
+	status := IngressBackendStatus{
+		ServiceName: backend.ServiceName,
+		ServicePort: backend.ServicePort.String(),
+	}
+
+	service, err := client.CoreV1().Services(namespace).Get(backend.ServiceName, metaV1.GetOptions{})
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	servicePort := findServicePort(service, backend.ServicePort)
+	if servicePort == nil {
+		status.Error = fmt.Sprintf("service %s has no port %s", service.Name,
+			backend.ServicePort.String())
+		return status
+	}
+	status.ServiceFound = true
+
+	endpoints, err := client.CoreV1().Endpoints(namespace).Get(backend.ServiceName,
+		metaV1.GetOptions{})
+	if err != nil {
+		if !errorsK8s.IsNotFound(err) {
+			status.Error = err.Error()
+		}
+		return status
+	}
+
+	for _, subset := range endpoints.Subsets {
+		if !subsetHasPort(subset, servicePort.Name) {
+			continue
+		}
+		status.ReadyEndpoints += len(subset.Addresses)
+		status.NotReadyEndpoints += len(subset.NotReadyAddresses)
+	}
+
+	return status
+}
+
+// findServicePort returns port of the service referenced by ingress backend by number or name.
+func findServicePort(service *v1.Service, port intstr.IntOrString) *v1.ServicePort {
+	for i := range service.Spec.Ports {
+		servicePort := &service.Spec.Ports[i]
+		if port.Type == intstr.Int && servicePort.Port == port.IntVal ||
+			port.Type == intstr.String && servicePort.Name == port.StrVal {
+			return servicePort
+		}
+	}
+	return nil
+}
+
+// subsetHasPort returns true if the endpoints subset serves the service port. Endpoint ports are
+// named after service ports.
+func subsetHasPort(subset v1.EndpointSubset, name string) bool {
+	for _, port := range subset.Ports {
+		if port.Name == name {
+			return true
+		}
+	}
+	return false
+}
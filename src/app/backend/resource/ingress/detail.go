@@ -15,9 +15,11 @@
 package ingress
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	client "k8s.io/client-go/kubernetes"
 	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
@@ -35,11 +37,23 @@ type IngressDetail struct {
 
 	// Status is the current state of the Ingress.
 	Status extensions.IngressStatus `json:"status"`
+
+	// Backends are services serving the default backend and all paths, with their endpoints.
+	Backends []IngressBackendStatus `json:"backends"`
+
+	// TLS configurations with certificates read from their secrets.
+	TLS []IngressTLSStatus `json:"tls"`
+
+	// List of events related to this Ingress.
+	EventList common.EventList `json:"eventList"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
 }
 
 // GetIngressDetail returns returns detailed information about an ingress
 func GetIngressDetail(client client.Interface, namespace, name string) (*IngressDetail, error) {
-	log.Printf("Getting details of %s ingress in %s namespace", name, namespace)
+	logger.Infof("Getting details of %s ingress in %s namespace", name, namespace)
 
 	rawIngress, err := client.Extensions().Ingresses(namespace).Get(name, metaV1.GetOptions{})
 
@@ -47,7 +61,20 @@ func GetIngressDetail(client client.Interface, namespace, name string) (*Ingress
 		return nil, err
 	}
 
-	return getIngressDetail(rawIngress), nil
+	eventList, err := GetIngressEvents(client, dataselect.DefaultDataSelect, namespace, name)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	ingress := getIngressDetail(rawIngress)
+	ingress.Backends = getBackendStatuses(client, rawIngress)
+	ingress.TLS = getTLSStatuses(client, rawIngress)
+	if eventList != nil {
+		ingress.EventList = *eventList
+	}
+	ingress.Errors = nonCriticalErrors
+	return ingress, nil
 }
 
 func getIngressDetail(rawIngress *extensions.Ingress) *IngressDetail {
@@ -0,0 +1,170 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func getTestCertificate(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "shop.example.com"},
+		DNSNames:     []string{"shop.example.com"},
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestGetIngressDetail(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metaV1.ObjectMeta{Name: "shop", Namespace: "ns"},
+		Spec: extensions.IngressSpec{
+			Backend: &extensions.IngressBackend{ServiceName: "missing",
+				ServicePort: intstr.FromInt(80)},
+			TLS: []extensions.IngressTLS{
+				{Hosts: []string{"shop.example.com"}, SecretName: "shop-tls"},
+				{SecretName: "missing-tls"},
+			},
+			Rules: []extensions.IngressRule{{
+				Host: "shop.example.com",
+				IngressRuleValue: extensions.IngressRuleValue{
+					HTTP: &extensions.HTTPIngressRuleValue{Paths: []extensions.HTTPIngressPath{
+						{Path: "/", Backend: extensions.IngressBackend{ServiceName: "web",
+							ServicePort: intstr.FromString("http")}},
+						{Path: "/admin", Backend: extensions.IngressBackend{ServiceName: "web",
+							ServicePort: intstr.FromInt(8443)}},
+					}},
+				},
+			}},
+		},
+	}
+	service := &v1.Service{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "ns"},
+		Spec: v1.ServiceSpec{Ports: []v1.ServicePort{
+			{Name: "http", Port: 80},
+			{Name: "metrics", Port: 9090},
+		}},
+	}
+	endpoints := &v1.Endpoints{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "ns"},
+		Subsets: []v1.EndpointSubset{
+			{
+				Addresses:         []v1.EndpointAddress{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}},
+				NotReadyAddresses: []v1.EndpointAddress{{IP: "10.0.0.3"}},
+				Ports:             []v1.EndpointPort{{Name: "http", Port: 8080}},
+			},
+			{
+				Addresses: []v1.EndpointAddress{{IP: "10.0.0.4"}},
+				Ports:     []v1.EndpointPort{{Name: "metrics", Port: 9090}},
+			},
+		},
+	}
+	notAfter := time.Now().Add(30*24*time.Hour + time.Hour).UTC().Truncate(time.Second)
+	secret := &v1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: "shop-tls", Namespace: "ns"},
+		Data:       map[string][]byte{v1.TLSCertKey: getTestCertificate(t, notAfter)},
+	}
+
+	fakeClient := fake.NewSimpleClientset(ingress, service, endpoints, secret)
+	actual, err := GetIngressDetail(fakeClient, "ns", "shop")
+	if err != nil {
+		t.Fatalf("GetIngressDetail() returns error: %v", err)
+	}
+
+	backends := make([]IngressBackendStatus, len(actual.Backends))
+	for i, backend := range actual.Backends {
+		// Error messages are checked only for presence.
+		if backend.Error != "" {
+			backend.Error = "error"
+		}
+		backends[i] = backend
+	}
+	expectedBackends := []IngressBackendStatus{
+		{Default: true, ServiceName: "missing", ServicePort: "80", Error: "error"},
+		{Host: "shop.example.com", Path: "/", ServiceName: "web", ServicePort: "http",
+			ServiceFound: true, ReadyEndpoints: 2, NotReadyEndpoints: 1},
+		{Host: "shop.example.com", Path: "/admin", ServiceName: "web", ServicePort: "8443",
+			Error: "error"},
+	}
+	if !reflect.DeepEqual(backends, expectedBackends) {
+		t.Errorf("GetIngressDetail() returns backends %#v, expected %#v", backends,
+			expectedBackends)
+	}
+
+	if len(actual.TLS) != 2 {
+		t.Fatalf("GetIngressDetail() returns TLS %#v, expected 2 items", actual.TLS)
+	}
+	if actual.TLS[1].SecretFound || actual.TLS[1].Error == "" {
+		t.Errorf("GetIngressDetail() returns %#v for missing secret", actual.TLS[1])
+	}
+
+	expectedCertificate := &CertificateInfo{
+		Subject:         "shop.example.com",
+		Issuer:          "shop.example.com",
+		DNSNames:        []string{"shop.example.com"},
+		NotBefore:       metaV1.NewTime(notAfter.Add(-90 * 24 * time.Hour)),
+		NotAfter:        metaV1.NewTime(notAfter),
+		DaysUntilExpiry: 30,
+	}
+	certificate := actual.TLS[0].Certificate
+	if certificate != nil {
+		certificate.NotBefore = metaV1.NewTime(certificate.NotBefore.UTC())
+		certificate.NotAfter = metaV1.NewTime(certificate.NotAfter.UTC())
+	}
+	if !actual.TLS[0].SecretFound || !reflect.DeepEqual(certificate, expectedCertificate) {
+		t.Errorf("GetIngressDetail() returns TLS %#v with certificate %#v, expected %#v",
+			actual.TLS[0], certificate, expectedCertificate)
+	}
+}
+
+func TestParseCertificate(t *testing.T) {
+	now := time.Now()
+	certificate, err := parseCertificate(getTestCertificate(t, now.Add(-48*time.Hour)), now)
+	if err != nil {
+		t.Fatalf("parseCertificate() returns error: %v", err)
+	}
+	if !certificate.Expired || certificate.DaysUntilExpiry != -2 {
+		t.Errorf("parseCertificate() returns %#v, expected expired 2 days ago", certificate)
+	}
+
+	if _, err := parseCertificate([]byte("invalid"), now); err == nil {
+		t.Errorf("parseCertificate() of invalid data returns no error")
+	}
+}
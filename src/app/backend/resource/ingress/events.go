@@ -0,0 +1,41 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	client "k8s.io/client-go/kubernetes"
+)
+
+// GetIngressEvents returns model events for an ingress with the given name in the given namespace.
+func GetIngressEvents(client client.Interface, dsQuery *dataselect.DataSelectQuery, namespace, name string) (
+	*common.EventList, error) {
+
+	ingressEvents, err := event.GetEvents(client, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !event.IsTypeFilled(ingressEvents) {
+		ingressEvents = event.FillEventsType(ingressEvents)
+	}
+
+	events := event.CreateEventList(ingressEvents, dsQuery)
+	logger.Infof("Found %d events related to %s ingress in %s namespace", len(events.Events), name, namespace)
+	return &events, nil
+}
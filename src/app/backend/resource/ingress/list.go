@@ -19,9 +19,6 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/fields"
-	"k8s.io/apimachinery/pkg/labels"
 	client "k8s.io/client-go/kubernetes"
 	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
 )
@@ -48,17 +45,19 @@ type IngressList struct {
 
 // GetIngressList - return all ingresses in the given namespace.
 func GetIngressList(client client.Interface, namespace *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*IngressList, error) {
-	ingressList, err := client.Extensions().Ingresses(namespace.ToRequestParam()).List(metaV1.ListOptions{
-		LabelSelector: labels.Everything().String(),
-		FieldSelector: fields.Everything().String(),
-	})
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
+	channel := common.GetIngressListChunkChannel(client, namespace, chunk, 1)
+	ingressList := <-channel.List
+	err := <-channel.Error
 
 	nonCriticalErrors, criticalError := errors.HandleError(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return toIngressList(ingressList.Items, nonCriticalErrors, dsQuery), err
+	result := toIngressList(ingressList.Items, nonCriticalErrors, dsQuery)
+	chunk.SetListMeta(&result.ListMeta)
+	return result, err
 }
 
 // GetIngressListFromChannels - return all ingresses in the given namespace.
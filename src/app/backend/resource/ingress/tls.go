@@ -0,0 +1,117 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+// IngressTLSStatus describes TLS configuration of an ingress and the certificate it uses.
+type IngressTLSStatus struct {
+	Hosts      []string `json:"hosts"`
+	SecretName string   `json:"secretName"`
+
+	// SecretFound is false when the secret does not exist.
+	SecretFound bool `json:"secretFound"`
+
+	// Certificate stored in the secret, nil when it can not be parsed.
+	Certificate *CertificateInfo `json:"certificate"`
+
+	// Error describing why the certificate can not be read.
+	Error string `json:"error,omitempty"`
+}
+
+// CertificateInfo contains information about an x509 certificate relevant to its users.
+type CertificateInfo struct {
+	Subject   string      `json:"subject"`
+	Issuer    string      `json:"issuer"`
+	DNSNames  []string    `json:"dnsNames"`
+	NotBefore metaV1.Time `json:"notBefore"`
+	NotAfter  metaV1.Time `json:"notAfter"`
+	Expired   bool        `json:"expired"`
+
+	// Number of whole days until the certificate expires, negative when it already expired.
+	DaysUntilExpiry int `json:"daysUntilExpiry"`
+}
+
+// getTLSStatuses reads certificates from the secrets of all ingress TLS configurations.
+func getTLSStatuses(client client.Interface, ingress *extensions.Ingress) []IngressTLSStatus {
+	result := make([]IngressTLSStatus, 0)
+	now := time.Now()
+
+	for _, tls := range ingress.Spec.TLS {
+		status := IngressTLSStatus{Hosts: tls.Hosts, SecretName: tls.SecretName}
+		if status.Hosts == nil {
+			status.Hosts = make([]string, 0)
+		}
+
+		secret, err := client.CoreV1().Secrets(ingress.Namespace).Get(tls.SecretName,
+			metaV1.GetOptions{})
+		if err != nil {
+			status.Error = err.Error()
+			result = append(result, status)
+			continue
+		}
+		status.SecretFound = true
+
+		certificate, err := parseCertificate(secret.Data[v1.TLSCertKey], now)
+		if err != nil {
+			status.Error = err.Error()
+		}
+		status.Certificate = certificate
+		result = append(result, status)
+	}
+
+	return result
+}
+
+// parseCertificate parses the first certificate of PEM encoded certificate chain.
+func parseCertificate(data []byte, now time.Time) (*CertificateInfo, error) {
+	block, rest := pem.Decode(data)
+	for block != nil && block.Type != "CERTIFICATE" {
+		block, rest = pem.Decode(rest)
+	}
+	if block == nil {
+		return nil, errors.New("secret does not contain PEM encoded certificate")
+	}
+
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	dnsNames := certificate.DNSNames
+	if dnsNames == nil {
+		dnsNames = make([]string, 0)
+	}
+
+	return &CertificateInfo{
+		Subject:         certificate.Subject.CommonName,
+		Issuer:          certificate.Issuer.CommonName,
+		DNSNames:        dnsNames,
+		NotBefore:       metaV1.NewTime(certificate.NotBefore),
+		NotAfter:        metaV1.NewTime(certificate.NotAfter),
+		Expired:         now.After(certificate.NotAfter),
+		DaysUntilExpiry: int(certificate.NotAfter.Sub(now).Hours() / 24),
+	}, nil
+}
@@ -0,0 +1,233 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"sort"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/pkg/api/v1"
+	batch "k8s.io/client-go/pkg/apis/batch/v1"
+)
+
+// Backoff delays used by the job controller when it recreates failed pods.
+const (
+	jobBackoffBase = 10 * time.Second
+	jobBackoffMax  = 6 * time.Minute
+)
+
+// JobState is a summarized state of a Job.
+type JobState string
+
+const (
+	JobStateRunning  JobState = "Running"
+	JobStateComplete JobState = "Complete"
+	JobStateFailed   JobState = "Failed"
+)
+
+// JobCompletion tracks progress of a Job towards its desired number of completions.
+type JobCompletion struct {
+	// State of the job, derived from its conditions.
+	State JobState `json:"state"`
+
+	// Number of pods that are still running.
+	Active int32 `json:"active"`
+
+	// Number of pods that finished successfully.
+	Succeeded int32 `json:"succeeded"`
+
+	// Number of pods that failed.
+	Failed int32 `json:"failed"`
+
+	// Time when the job was started by the controller.
+	StartTime *metaV1.Time `json:"startTime"`
+
+	// Time when the job completed. Not set for failed and running jobs.
+	CompletionTime *metaV1.Time `json:"completionTime"`
+
+	// Seconds it took the job to complete, or seconds it has been running so far.
+	DurationSeconds int64 `json:"durationSeconds"`
+
+	// Reason and message of the Complete or Failed condition, if any.
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// JobBackoff describes whether the controller is waiting before it retries failed pods.
+type JobBackoff struct {
+	// Number of failed pods so far.
+	Failures int32 `json:"failures"`
+
+	// True when there are failures, no active pods and the job has not finished yet.
+	BackingOff bool `json:"backingOff"`
+
+	// Estimated delay before the next pod is created, based on the controller's exponential backoff.
+	NextRetrySeconds int64 `json:"nextRetrySeconds"`
+
+	// True when the job failed because it reached its backoff limit.
+	LimitExceeded bool `json:"limitExceeded"`
+}
+
+// JobPodStatus is a completion or failure record of a single pod of a Job.
+type JobPodStatus struct {
+	Name  string      `json:"name"`
+	Phase v1.PodPhase `json:"phase"`
+
+	// Container that failed, or the first container when none failed.
+	Container string `json:"container"`
+
+	// Exit code of the container. Nil when the container did not terminate yet.
+	ExitCode *int32 `json:"exitCode"`
+
+	// Reason and message of the container termination.
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+
+	Restarts   int32        `json:"restarts"`
+	StartTime  *metaV1.Time `json:"startTime"`
+	FinishTime *metaV1.Time `json:"finishTime"`
+}
+
+// getJobCompletion returns completion tracking information of the given job.
+func getJobCompletion(job *batch.Job, now time.Time) JobCompletion {
+	completion := JobCompletion{
+		State:          JobStateRunning,
+		Active:         job.Status.Active,
+		Succeeded:      job.Status.Succeeded,
+		Failed:         job.Status.Failed,
+		StartTime:      job.Status.StartTime,
+		CompletionTime: job.Status.CompletionTime,
+	}
+
+	end := now
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batch.JobComplete:
+			completion.State = JobStateComplete
+		case batch.JobFailed:
+			completion.State = JobStateFailed
+			end = condition.LastTransitionTime.Time
+		default:
+			continue
+		}
+		completion.Reason = condition.Reason
+		completion.Message = condition.Message
+	}
+
+	if job.Status.CompletionTime != nil {
+		end = job.Status.CompletionTime.Time
+	}
+	if job.Status.StartTime != nil && !end.Before(job.Status.StartTime.Time) {
+		completion.DurationSeconds = int64(end.Sub(job.Status.StartTime.Time) / time.Second)
+	}
+
+	return completion
+}
+
+// getJobBackoff returns backoff status of the given job.
+func getJobBackoff(job *batch.Job, completion JobCompletion) JobBackoff {
+	backoff := JobBackoff{
+		Failures:      job.Status.Failed,
+		LimitExceeded: completion.State == JobStateFailed && completion.Reason == "BackoffLimitExceeded",
+	}
+
+	if completion.State == JobStateRunning && job.Status.Failed > 0 && job.Status.Active == 0 {
+		backoff.BackingOff = true
+		delay := jobBackoffBase
+		for i := int32(1); i < job.Status.Failed && delay < jobBackoffMax; i++ {
+			delay *= 2
+		}
+		if delay > jobBackoffMax {
+			delay = jobBackoffMax
+		}
+		backoff.NextRetrySeconds = int64(delay / time.Second)
+	}
+
+	return backoff
+}
+
+// getJobPodStatuses returns completion and failure records of job pods, most recently started first.
+func getJobPodStatuses(pods []v1.Pod) []JobPodStatus {
+	result := make([]JobPodStatus, 0, len(pods))
+	for _, pod := range pods {
+		status := JobPodStatus{
+			Name:      pod.Name,
+			Phase:     pod.Status.Phase,
+			StartTime: pod.Status.StartTime,
+		}
+
+		containerStatus := findFailedContainer(&pod)
+		if containerStatus == nil && len(pod.Status.ContainerStatuses) > 0 {
+			containerStatus = &pod.Status.ContainerStatuses[0]
+		}
+
+		for _, cs := range pod.Status.ContainerStatuses {
+			status.Restarts += cs.RestartCount
+		}
+
+		if containerStatus != nil {
+			status.Container = containerStatus.Name
+			terminated := containerStatus.State.Terminated
+			if terminated == nil {
+				terminated = containerStatus.LastTerminationState.Terminated
+			}
+			if terminated != nil {
+				exitCode := terminated.ExitCode
+				status.ExitCode = &exitCode
+				status.Reason = terminated.Reason
+				status.Message = terminated.Message
+				finishedAt := terminated.FinishedAt
+				status.FinishTime = &finishedAt
+			}
+		}
+
+		result = append(result, status)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return startTimeOf(result[i]).After(startTimeOf(result[j]))
+	})
+	return result
+}
+
+// findFailedContainer returns status of a container that terminated with non-zero exit code, either in
+// its current or last state. Returns nil if no container failed.
+func findFailedContainer(pod *v1.Pod) *v1.ContainerStatus {
+	for i := range pod.Status.ContainerStatuses {
+		cs := &pod.Status.ContainerStatuses[i]
+		if cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 {
+			return cs
+		}
+	}
+	for i := range pod.Status.ContainerStatuses {
+		cs := &pod.Status.ContainerStatuses[i]
+		if cs.LastTerminationState.Terminated != nil && cs.LastTerminationState.Terminated.ExitCode != 0 {
+			return cs
+		}
+	}
+	return nil
+}
+
+func startTimeOf(status JobPodStatus) time.Time {
+	if status.StartTime == nil {
+		return time.Time{}
+	}
+	return status.StartTime.Time
+}
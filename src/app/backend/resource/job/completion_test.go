@@ -0,0 +1,192 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/pkg/api/v1"
+	batch "k8s.io/client-go/pkg/apis/batch/v1"
+)
+
+func TestGetJobCompletion(t *testing.T) {
+	start := time.Date(2017, 1, 1, 10, 0, 0, 0, time.UTC)
+	startTime := metaV1.NewTime(start)
+	completionTime := metaV1.NewTime(start.Add(90 * time.Second))
+
+	cases := []struct {
+		info     string
+		status   batch.JobStatus
+		expected JobCompletion
+	}{
+		{
+			"running job",
+			batch.JobStatus{StartTime: &startTime, Active: 1, Failed: 1},
+			JobCompletion{State: JobStateRunning, Active: 1, Failed: 1, StartTime: &startTime,
+				DurationSeconds: 300},
+		},
+		{
+			"completed job",
+			batch.JobStatus{
+				StartTime:      &startTime,
+				CompletionTime: &completionTime,
+				Succeeded:      2,
+				Conditions: []batch.JobCondition{
+					{Type: batch.JobComplete, Status: v1.ConditionTrue},
+				},
+			},
+			JobCompletion{State: JobStateComplete, Succeeded: 2, StartTime: &startTime,
+				CompletionTime: &completionTime, DurationSeconds: 90},
+		},
+		{
+			"failed job",
+			batch.JobStatus{
+				StartTime: &startTime,
+				Failed:    6,
+				Conditions: []batch.JobCondition{
+					{Type: batch.JobFailed, Status: v1.ConditionTrue, Reason: "BackoffLimitExceeded",
+						Message:            "Job has reach the specified backoff limit",
+						LastTransitionTime: metaV1.NewTime(start.Add(time.Minute))},
+				},
+			},
+			JobCompletion{State: JobStateFailed, Failed: 6, StartTime: &startTime, DurationSeconds: 60,
+				Reason: "BackoffLimitExceeded", Message: "Job has reach the specified backoff limit"},
+		},
+	}
+
+	for _, c := range cases {
+		job := &batch.Job{Status: c.status}
+		actual := getJobCompletion(job, start.Add(5*time.Minute))
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: getJobCompletion() == \ngot: %#v, \nexpected %#v", c.info, actual, c.expected)
+		}
+	}
+}
+
+func TestGetJobBackoff(t *testing.T) {
+	cases := []struct {
+		info       string
+		status     batch.JobStatus
+		completion JobCompletion
+		expected   JobBackoff
+	}{
+		{
+			"no failures",
+			batch.JobStatus{Active: 1},
+			JobCompletion{State: JobStateRunning},
+			JobBackoff{},
+		},
+		{
+			"failed pod is being retried",
+			batch.JobStatus{Active: 1, Failed: 2},
+			JobCompletion{State: JobStateRunning},
+			JobBackoff{Failures: 2},
+		},
+		{
+			"waiting before third retry",
+			batch.JobStatus{Failed: 3},
+			JobCompletion{State: JobStateRunning},
+			JobBackoff{Failures: 3, BackingOff: true, NextRetrySeconds: 40},
+		},
+		{
+			"delay is capped",
+			batch.JobStatus{Failed: 10},
+			JobCompletion{State: JobStateRunning},
+			JobBackoff{Failures: 10, BackingOff: true, NextRetrySeconds: 360},
+		},
+		{
+			"backoff limit exceeded",
+			batch.JobStatus{Failed: 6},
+			JobCompletion{State: JobStateFailed, Reason: "BackoffLimitExceeded"},
+			JobBackoff{Failures: 6, LimitExceeded: true},
+		},
+	}
+
+	for _, c := range cases {
+		actual := getJobBackoff(&batch.Job{Status: c.status}, c.completion)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: getJobBackoff() == \ngot: %#v, \nexpected %#v", c.info, actual, c.expected)
+		}
+	}
+}
+
+func TestGetJobPodStatuses(t *testing.T) {
+	earlier := metaV1.NewTime(time.Date(2017, 1, 1, 10, 0, 0, 0, time.UTC))
+	later := metaV1.NewTime(time.Date(2017, 1, 1, 10, 5, 0, 0, time.UTC))
+	finished := metaV1.NewTime(time.Date(2017, 1, 1, 10, 6, 0, 0, time.UTC))
+
+	pods := []v1.Pod{
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "succeeded"},
+			Status: v1.PodStatus{
+				Phase:     v1.PodSucceeded,
+				StartTime: &earlier,
+				ContainerStatuses: []v1.ContainerStatus{{
+					Name: "main",
+					State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+						ExitCode: 0, Reason: "Completed", FinishedAt: finished}},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "failed"},
+			Status: v1.PodStatus{
+				Phase:     v1.PodRunning,
+				StartTime: &later,
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: "sidecar", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+					{
+						Name:         "main",
+						RestartCount: 2,
+						State:        v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+						LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+							ExitCode: 1, Reason: "Error", FinishedAt: finished}},
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "pending"},
+			Status:     v1.PodStatus{Phase: v1.PodPending},
+		},
+	}
+
+	exitSuccess, exitError := int32(0), int32(1)
+	expected := []JobPodStatus{
+		{Name: "failed", Phase: v1.PodRunning, Container: "main", ExitCode: &exitError, Reason: "Error",
+			Restarts: 2, StartTime: &later, FinishTime: &finished},
+		{Name: "succeeded", Phase: v1.PodSucceeded, Container: "main", ExitCode: &exitSuccess,
+			Reason: "Completed", StartTime: &earlier, FinishTime: &finished},
+		{Name: "pending", Phase: v1.PodPending},
+	}
+
+	actual := getJobPodStatuses(pods)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("getJobPodStatuses() == \ngot: %#v, \nexpected %#v", actual, expected)
+	}
+
+	failed := findLastFailedContainer(pods)
+	expectedFailed := &failedContainer{pod: "failed", container: "main", previous: true}
+	if !reflect.DeepEqual(failed, expectedFailed) {
+		t.Errorf("findLastFailedContainer() == %#v, expected %#v", failed, expectedFailed)
+	}
+
+	if failed := findLastFailedContainer(pods[:1]); failed != nil {
+		t.Errorf("findLastFailedContainer() == %#v, expected nil", failed)
+	}
+}
@@ -15,6 +15,8 @@
 package job
 
 import (
+	"time"
+
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
@@ -23,6 +25,7 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/resource/pod"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
 	batch "k8s.io/client-go/pkg/apis/batch/v1"
 )
 
@@ -51,6 +54,15 @@ type JobDetail struct {
 	// Completions specifies the desired number of successfully finished pods the job should be run with.
 	Completions *int32 `json:"completions"`
 
+	// Completion tracks the progress of the job and the time it took to complete.
+	Completion JobCompletion `json:"completion"`
+
+	// Backoff describes whether failed pods are being retried.
+	Backoff JobBackoff `json:"backoff"`
+
+	// Completion and failure records of the job pods, most recently started first.
+	PodStatuses []JobPodStatus `json:"podStatuses"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -70,7 +82,7 @@ func GetJobDetail(client k8sClient.Interface, metricClient metricapi.MetricClien
 		return nil, criticalError
 	}
 
-	podInfo, err := getJobPodInfo(client, jobData)
+	rawPods, err := listJobPods(client, jobData)
 	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
 	if criticalError != nil {
 		return nil, criticalError
@@ -82,21 +94,25 @@ func GetJobDetail(client k8sClient.Interface, metricClient metricapi.MetricClien
 		return nil, criticalError
 	}
 
-	job := toJobDetail(jobData, *eventList, *podList, *podInfo, nonCriticalErrors)
+	job := toJobDetail(jobData, *eventList, *podList, rawPods, nonCriticalErrors)
 	return &job, nil
 }
 
-func toJobDetail(job *batch.Job, eventList common.EventList, podList pod.PodList, podInfo common.PodInfo,
+func toJobDetail(job *batch.Job, eventList common.EventList, podList pod.PodList, rawPods []v1.Pod,
 	nonCriticalErrors []error) JobDetail {
+	completion := getJobCompletion(job, time.Now())
 	return JobDetail{
 		ObjectMeta:      api.NewObjectMeta(job.ObjectMeta),
 		TypeMeta:        api.NewTypeMeta(api.ResourceKindJob),
 		ContainerImages: common.GetContainerImages(&job.Spec.Template.Spec),
-		PodInfo:         podInfo,
+		PodInfo:         *getJobPodInfo(job, rawPods),
 		PodList:         podList,
 		EventList:       eventList,
 		Parallelism:     job.Spec.Parallelism,
 		Completions:     job.Spec.Completions,
+		Completion:      completion,
+		Backoff:         getJobBackoff(job, completion),
+		PodStatuses:     getJobPodStatuses(rawPods),
 		Errors:          nonCriticalErrors,
 	}
 }
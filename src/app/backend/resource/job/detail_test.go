@@ -71,6 +71,8 @@ func TestGetJobDetail(t *testing.T) {
 				EventList:   common.EventList{Events: []common.Event{}},
 				Parallelism: &jobCompletions,
 				Completions: &parallelism,
+				Completion:  JobCompletion{State: JobStateRunning},
+				PodStatuses: []JobPodStatus{},
 				Errors:      []error{},
 			},
 		},
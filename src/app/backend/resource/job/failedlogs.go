@@ -0,0 +1,82 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/container"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/logs"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// failedContainer identifies the container whose logs should be shown for a failed job.
+type failedContainer struct {
+	pod       string
+	container string
+	// previous is set when the failure happened in an earlier, restarted instance of the container.
+	previous bool
+}
+
+// GetFailedPodLogs returns logs of the most recently failed container of the given job.
+func GetFailedPodLogs(client *client.Clientset, namespace, name string, logSelector *logs.Selection,
+	logFilter *logs.LogFilter) (*logs.LogDetails, error) {
+	pods, err := getRawJobPods(client, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	target := findLastFailedContainer(pods)
+	if target == nil {
+		return nil, errorsK8s.NewNotFound(schema.GroupResource{Resource: "failed pods"}, name)
+	}
+
+	return container.GetPodLogs(client, namespace, target.pod, target.container, logSelector, logFilter,
+		target.previous)
+}
+
+// findLastFailedContainer returns the failed container that terminated last, or nil when no container
+// failed.
+func findLastFailedContainer(pods []v1.Pod) *failedContainer {
+	var result *failedContainer
+	var lastFinishedAt time.Time
+
+	for i := range pods {
+		pod := &pods[i]
+		cs := findFailedContainer(pod)
+		if cs == nil {
+			continue
+		}
+
+		terminated := cs.State.Terminated
+		previous := false
+		if terminated == nil || terminated.ExitCode == 0 {
+			terminated = cs.LastTerminationState.Terminated
+			previous = true
+		}
+
+		if result != nil && !terminated.FinishedAt.After(lastFinishedAt) {
+			continue
+		}
+
+		lastFinishedAt = terminated.FinishedAt.Time
+		result = &failedContainer{pod: pod.Name, container: cs.Name, previous: previous}
+	}
+
+	return result
+}
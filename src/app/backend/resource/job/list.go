@@ -15,13 +15,13 @@
 package job
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diagnosis"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
@@ -59,15 +59,22 @@ type Job struct {
 // GetJobList returns a list of all Jobs in the cluster.
 func GetJobList(client client.Interface, nsQuery *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery, metricClient metricapi.MetricClient) (*JobList, error) {
-	log.Print("Getting list of all jobs in the cluster")
+	logger.Info("Getting list of all jobs in the cluster")
 
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
 	channels := &common.ResourceChannels{
-		JobList:   common.GetJobListChannel(client, nsQuery, 1),
+		JobList:   common.GetJobListChunkChannel(client, nsQuery, chunk, 1),
 		PodList:   common.GetPodListChannel(client, nsQuery, 1),
-		EventList: common.GetEventListChannel(client, nsQuery, 1),
+		EventList: common.GetChunkEventListChannel(client, nsQuery, chunk, 1),
+	}
+
+	jobList, err := GetJobListFromChannels(channels, dsQuery, metricClient)
+	if err != nil {
+		return nil, err
 	}
 
-	return GetJobListFromChannels(channels, dsQuery, metricClient)
+	chunk.SetListMeta(&jobList.ListMeta)
+	return jobList, nil
 }
 
 // GetJobListFromChannels returns a list of all Jobs in the cluster reading required resource list once from the channels.
@@ -95,10 +102,12 @@ func GetJobListFromChannels(channels *common.ResourceChannels, dsQuery *datasele
 		return nil, criticalError
 	}
 
-	return toJobList(jobs.Items, pods.Items, events.Items, nonCriticalErrors, dsQuery, metricClient), nil
+	return ToJobList(jobs.Items, pods.Items, events.Items, nonCriticalErrors, dsQuery, metricClient), nil
 }
 
-func toJobList(jobs []batch.Job, pods []v1.Pod, events []v1.Event, nonCriticalErrors []error,
+// ToJobList converts raw jobs to the job list, filling pod information from the given pods and
+// events.
+func ToJobList(jobs []batch.Job, pods []v1.Pod, events []v1.Event, nonCriticalErrors []error,
 	dsQuery *dataselect.DataSelectQuery, metricClient metricapi.MetricClient) *JobList {
 
 	jobList := &JobList{
@@ -123,6 +132,7 @@ func toJobList(jobs []batch.Job, pods []v1.Pod, events []v1.Event, nonCriticalEr
 		}
 		podInfo := common.GetPodInfo(job.Status.Active, completions, matchingPods)
 		podInfo.Warnings = event.GetPodsEventWarnings(events, matchingPods)
+		podInfo.Diagnosis = diagnosis.DiagnosePods(matchingPods, events)
 		jobList.Jobs = append(jobList.Jobs, toJob(&job, &podInfo))
 	}
 
@@ -15,9 +15,8 @@
 package job
 
 import (
-	"log"
-
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
@@ -33,7 +32,7 @@ import (
 // GetJobPods return list of pods targeting job.
 func GetJobPods(client k8sClient.Interface, metricClient metricapi.MetricClient,
 	dsQuery *dataselect.DataSelectQuery, namespace string, jobName string) (*pod.PodList, error) {
-	log.Printf("Getting replication controller %s pods in namespace %s", jobName, namespace)
+	logger.Infof("Getting replication controller %s pods in namespace %s", jobName, namespace)
 
 	pods, err := getRawJobPods(client, jobName, namespace)
 	if err != nil {
@@ -73,8 +72,8 @@ func getRawJobPods(client k8sClient.Interface, petSetName, namespace string) ([]
 	return podList.Items, nil
 }
 
-// Returns simple info about pods(running, desired, failing, etc.) related to given job.
-func getJobPodInfo(client k8sClient.Interface, job *batch.Job) (*common.PodInfo, error) {
+// Returns pods of the given job.
+func listJobPods(client k8sClient.Interface, job *batch.Job) ([]v1.Pod, error) {
 	labelSelector := labels.SelectorFromSet(job.Spec.Selector.MatchLabels)
 	channels := &common.ResourceChannels{
 		PodList: common.GetPodListChannelWithOptions(client, common.NewSameNamespaceQuery(
@@ -90,6 +89,11 @@ func getJobPodInfo(client k8sClient.Interface, job *batch.Job) (*common.PodInfo,
 		return nil, err
 	}
 
-	podInfo := common.GetPodInfo(job.Status.Active, *job.Spec.Completions, pods.Items)
-	return &podInfo, nil
+	return pods.Items, nil
+}
+
+// Returns simple info about pods(running, desired, failing, etc.) related to given job.
+func getJobPodInfo(job *batch.Job, pods []v1.Pod) *common.PodInfo {
+	podInfo := common.GetPodInfo(job.Status.Active, *job.Spec.Completions, pods)
+	return &podInfo
 }
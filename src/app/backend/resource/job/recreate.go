@@ -0,0 +1,90 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	batch "k8s.io/client-go/pkg/apis/batch/v1"
+)
+
+// Label added by the job controller to the selector and pod template of every job. It has to be removed
+// before the job is created again, because it refers to the UID of the old job.
+const controllerUIDLabel = "controller-uid"
+
+// RecreateJob deletes the given job together with its pods and creates it again with the same spec.
+func RecreateJob(client client.Interface, namespace, name string) (*Job, error) {
+	logger.Infof("Recreating job %s in namespace %s", name, namespace)
+
+	old, err := client.BatchV1().Jobs(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	propagation := metaV1.DeletePropagationBackground
+	err = client.BatchV1().Jobs(namespace).Delete(name, &metaV1.DeleteOptions{
+		PropagationPolicy: &propagation,
+		Preconditions:     &metaV1.Preconditions{UID: &old.UID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.BatchV1().Jobs(namespace).Create(copyJobForRecreate(old))
+	if err != nil {
+		return nil, err
+	}
+
+	job := toJob(created, &common.PodInfo{Warnings: []common.Event{}})
+	return &job, nil
+}
+
+// copyJobForRecreate returns a new job with the same metadata and spec as the given one, without any
+// fields set by the server or the job controller.
+func copyJobForRecreate(old *batch.Job) *batch.Job {
+	job := &batch.Job{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        old.Name,
+			Namespace:   old.Namespace,
+			Labels:      withoutLabel(old.Labels, controllerUIDLabel),
+			Annotations: old.Annotations,
+		},
+		Spec: old.Spec,
+	}
+
+	job.Spec.Template.ObjectMeta.Labels = withoutLabel(old.Spec.Template.Labels, controllerUIDLabel)
+	if job.Spec.ManualSelector == nil || !*job.Spec.ManualSelector {
+		// The selector is generated by the server again.
+		job.Spec.Selector = nil
+	}
+
+	return job
+}
+
+func withoutLabel(labels map[string]string, key string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+
+	result := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if k != key {
+			result[k] = v
+		}
+	}
+	return result
+}
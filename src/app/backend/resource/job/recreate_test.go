@@ -0,0 +1,77 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	batch "k8s.io/client-go/pkg/apis/batch/v1"
+)
+
+func TestRecreateJob(t *testing.T) {
+	labels := map[string]string{"controller-uid": "uid-1", "job-name": "job-1"}
+	old := &batch.Job{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name: "job-1", Namespace: "ns-1", UID: "uid-1", ResourceVersion: "7",
+			Labels: map[string]string{"app": "test"},
+		},
+		Spec: batch.JobSpec{
+			Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"controller-uid": "uid-1"}},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: labels},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "main", Image: "busybox"}}},
+			},
+		},
+		Status: batch.JobStatus{Failed: 6},
+	}
+
+	fakeClient := fake.NewSimpleClientset(old)
+	result, err := RecreateJob(fakeClient, "ns-1", "job-1")
+	if err != nil {
+		t.Fatalf("RecreateJob(): unexpected error %v", err)
+	}
+
+	if result.ObjectMeta.Name != "job-1" || !reflect.DeepEqual(result.ContainerImages, []string{"busybox"}) {
+		t.Errorf("RecreateJob() == %#v, expected recreated job-1", result)
+	}
+
+	verbs := []string{}
+	for _, action := range fakeClient.Actions() {
+		verbs = append(verbs, action.GetVerb())
+	}
+	if !reflect.DeepEqual(verbs, []string{"get", "delete", "create"}) {
+		t.Errorf("RecreateJob() performed actions %v, expected get, delete and create", verbs)
+	}
+
+	created, err := fakeClient.BatchV1().Jobs("ns-1").Get("job-1", metaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get(): unexpected error %v", err)
+	}
+	if created.UID != "" || created.ResourceVersion != "" || created.Spec.Selector != nil ||
+		created.Status.Failed != 0 {
+		t.Errorf("Recreated job keeps server populated fields: %#v", created)
+	}
+	if !reflect.DeepEqual(created.Spec.Template.Labels, map[string]string{"job-name": "job-1"}) {
+		t.Errorf("Recreated job template labels == %v, expected controller-uid to be removed",
+			created.Spec.Template.Labels)
+	}
+	if old.Spec.Template.Labels["controller-uid"] != "uid-1" {
+		t.Error("RecreateJob() modified labels of the original job")
+	}
+}
@@ -14,7 +14,15 @@
 
 package limitrange
 
-import api "k8s.io/client-go/pkg/api/v1"
+import (
+	"sort"
+
+	backendapi "github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	api "k8s.io/client-go/pkg/api/v1"
+)
 
 // limitRanges provides set of limit ranges by limit types and resource names
 type limitRangesMap map[api.LimitType]rangeMap
@@ -97,5 +105,43 @@ func ToLimitRanges(rawLimitRange *api.LimitRange) []LimitRangeItem {
 			limitRangeList = append(limitRangeList, *limit)
 		}
 	}
+	sort.Sort(limitRangeItems(limitRangeList))
 	return limitRangeList
 }
+
+// limitRangeItems sorts limit range items by resource type and name.
+type limitRangeItems []LimitRangeItem
+
+func (self limitRangeItems) Len() int      { return len(self) }
+func (self limitRangeItems) Swap(i, j int) { self[i], self[j] = self[j], self[i] }
+func (self limitRangeItems) Less(i, j int) bool {
+	if self[i].ResourceType != self[j].ResourceType {
+		return self[i].ResourceType < self[j].ResourceType
+	}
+	return self[i].ResourceName < self[j].ResourceName
+}
+
+// LimitRangeDetail provides the presentation layer view of Kubernetes Limit Range resource.
+type LimitRangeDetail struct {
+	ObjectMeta backendapi.ObjectMeta `json:"objectMeta"`
+	TypeMeta   backendapi.TypeMeta   `json:"typeMeta"`
+
+	// LimitRanges are limits of the limit range by resource type and name.
+	LimitRanges []LimitRangeItem `json:"limits"`
+}
+
+// GetLimitRangeDetail returns detailed information about a limit range.
+func GetLimitRangeDetail(client client.Interface, namespace, name string) (*LimitRangeDetail, error) {
+	logger.Infof("Getting details of %s limit range in %s namespace", name, namespace)
+
+	rawLimitRange, err := client.CoreV1().LimitRanges(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LimitRangeDetail{
+		ObjectMeta:  backendapi.NewObjectMeta(rawLimitRange.ObjectMeta),
+		TypeMeta:    backendapi.NewTypeMeta(backendapi.ResourceKindLimitRange),
+		LimitRanges: ToLimitRanges(rawLimitRange),
+	}, nil
+}
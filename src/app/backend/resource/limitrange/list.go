@@ -0,0 +1,87 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limitrange
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// LimitRangeList contains a list of Limit Ranges in the cluster.
+type LimitRangeList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	// Unordered list of Limit Ranges.
+	Items []LimitRange `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// LimitRange is a presentation layer view of Kubernetes Limit Range resource.
+type LimitRange struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+}
+
+// GetLimitRangeList returns a list of all Limit Ranges in the cluster.
+func GetLimitRangeList(client client.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*LimitRangeList, error) {
+	logger.Infof("Getting list of limit ranges in the namespace %s", nsQuery.ToRequestParam())
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
+	channel := common.GetLimitRangeListChunkChannel(client, nsQuery, chunk, 1)
+	limitRanges := <-channel.List
+	err := <-channel.Error
+
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	items := make([]v1.LimitRange, 0)
+	if limitRanges != nil {
+		items = limitRanges.Items
+	}
+	limitRangeList := toLimitRangeList(items, nonCriticalErrors, dsQuery)
+	chunk.SetListMeta(&limitRangeList.ListMeta)
+	return limitRangeList, nil
+}
+
+func toLimitRangeList(limitRanges []v1.LimitRange, nonCriticalErrors []error,
+	dsQuery *dataselect.DataSelectQuery) *LimitRangeList {
+	result := &LimitRangeList{
+		Items:    make([]LimitRange, 0),
+		ListMeta: api.ListMeta{TotalItems: len(limitRanges)},
+		Errors:   nonCriticalErrors,
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(limitRanges), dsQuery)
+	limitRanges = fromCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for _, item := range limitRanges {
+		result.Items = append(result.Items, LimitRange{
+			ObjectMeta: api.NewObjectMeta(item.ObjectMeta),
+			TypeMeta:   api.NewTypeMeta(api.ResourceKindLimitRange),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,150 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// Check identifies a best practice verified by the linter.
+type Check string
+
+const (
+	CheckLivenessProbe  Check = "LivenessProbe"
+	CheckReadinessProbe Check = "ReadinessProbe"
+	CheckResourceLimits Check = "ResourceLimits"
+	CheckImageTag       Check = "ImageTag"
+	CheckRunAsRoot      Check = "RunAsRoot"
+	CheckHostPath       Check = "HostPath"
+)
+
+// Severity of a finding. Danger findings are security risks, warnings affect reliability.
+type Severity string
+
+const (
+	SeverityDanger  Severity = "Danger"
+	SeverityWarning Severity = "Warning"
+)
+
+// severityPenalty is the number of points a finding of the severity subtracts from the score.
+var severityPenalty = map[Severity]int{
+	SeverityDanger:  20,
+	SeverityWarning: 10,
+}
+
+// Finding is a violation of a best practice.
+type Finding struct {
+	Check    Check    `json:"check"`
+	Severity Severity `json:"severity"`
+
+	// Container violating the best practice. Empty for findings of the whole pod.
+	Container string `json:"container,omitempty"`
+
+	Message string `json:"message"`
+}
+
+// lintPodSpec checks the pod spec. Probes are not required for pods of jobs, which are not
+// expected to serve traffic.
+func lintPodSpec(spec *v1.PodSpec, requireProbes bool) []Finding {
+	findings := make([]Finding, 0)
+
+	for _, volume := range spec.Volumes {
+		if volume.HostPath != nil {
+			findings = append(findings, Finding{Check: CheckHostPath, Severity: SeverityDanger,
+				Message: fmt.Sprintf("volume %s mounts host path %s", volume.Name, volume.HostPath.Path)})
+		}
+	}
+
+	containers := append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...)
+	for i, container := range containers {
+		isInit := i < len(spec.InitContainers)
+		finding := func(check Check, severity Severity, format string, args ...interface{}) {
+			findings = append(findings, Finding{Check: check, Severity: severity,
+				Container: container.Name, Message: fmt.Sprintf(format, args...)})
+		}
+
+		if requireProbes && !isInit {
+			if container.LivenessProbe == nil {
+				finding(CheckLivenessProbe, SeverityWarning, "liveness probe is not set")
+			}
+			if container.ReadinessProbe == nil {
+				finding(CheckReadinessProbe, SeverityWarning, "readiness probe is not set")
+			}
+		}
+
+		var missing []string
+		for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			if _, ok := container.Resources.Limits[name]; !ok {
+				missing = append(missing, string(name))
+			}
+		}
+		if len(missing) > 0 {
+			finding(CheckResourceLimits, SeverityWarning, "%s limits are not set", strings.Join(missing, " and "))
+		}
+
+		if tag := getImageTag(container.Image); tag == "" || tag == "latest" {
+			finding(CheckImageTag, SeverityWarning, "image %s is not pinned to a version", container.Image)
+		}
+
+		if runsAsRoot(spec.SecurityContext, container.SecurityContext) {
+			finding(CheckRunAsRoot, SeverityDanger, "container may run as root")
+		}
+	}
+	return findings
+}
+
+// getImageTag returns tag of the image, or "@" when the image is pinned by digest.
+func getImageTag(image string) string {
+	if strings.Contains(image, "@") {
+		return "@"
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+	return ""
+}
+
+// runsAsRoot returns true unless the container or the pod requires non-root user or sets non-root
+// user ID. Container settings take precedence over pod settings.
+func runsAsRoot(pod *v1.PodSecurityContext, container *v1.SecurityContext) bool {
+	if container != nil && container.RunAsUser != nil {
+		return *container.RunAsUser == 0
+	}
+	if pod != nil && pod.RunAsUser != nil {
+		return *pod.RunAsUser == 0
+	}
+	if container != nil && container.RunAsNonRoot != nil {
+		return !*container.RunAsNonRoot
+	}
+	if pod != nil && pod.RunAsNonRoot != nil {
+		return !*pod.RunAsNonRoot
+	}
+	return true
+}
+
+// score returns score of an object from 100 without findings down to 0.
+func score(findings []Finding) int {
+	result := 100
+	for _, finding := range findings {
+		result -= severityPenalty[finding.Severity]
+	}
+	if result < 0 {
+		return 0
+	}
+	return result
+}
@@ -0,0 +1,209 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint checks workloads against best practices, such as probes, resource limits, pinned
+// images and non-root users, and scores them.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// Paths of pod specs in objects of workload kinds.
+var podSpecPaths = map[string][]string{
+	"Pod":                   {"spec"},
+	"Deployment":            {"spec", "template", "spec"},
+	"ReplicaSet":            {"spec", "template", "spec"},
+	"ReplicationController": {"spec", "template", "spec"},
+	"StatefulSet":           {"spec", "template", "spec"},
+	"DaemonSet":             {"spec", "template", "spec"},
+	"Job":                   {"spec", "template", "spec"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// ObjectReport contains findings of a single workload.
+type ObjectReport struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// Score from 0 to 100, where 100 means no findings.
+	Score    int       `json:"score"`
+	Findings []Finding `json:"findings"`
+}
+
+// LintReport contains reports of all checked workloads, lowest scores first.
+type LintReport struct {
+	Objects []ObjectReport `json:"objects"`
+
+	// Average score of all objects, 100 when there are no objects.
+	Score int `json:"score"`
+
+	// Documents, which are not workloads and were not checked.
+	Skipped []string `json:"skipped"`
+}
+
+// LintSpec is a specification of manifests to check.
+type LintSpec struct {
+	// Multi-document YAML or JSON content.
+	Content string `json:"content"`
+}
+
+// GetLintReport checks live workloads in the namespaces. Pods owned by controllers are checked
+// through their controllers.
+func GetLintReport(client kubernetes.Interface, nsQuery *common.NamespaceQuery) (*LintReport, error) {
+	logger.Infof("Linting workloads in %s namespace", nsQuery.ToRequestParam())
+	namespace := nsQuery.ToRequestParam()
+	options := metaV1.ListOptions{}
+	reports := make([]ObjectReport, 0)
+	add := func(kind string, meta metaV1.ObjectMeta, spec *v1.PodSpec) {
+		if nsQuery.Matches(meta.Namespace) {
+			reports = append(reports, newObjectReport(kind, meta.Namespace, meta.Name, spec))
+		}
+	}
+
+	deployments, err := client.ExtensionsV1beta1().Deployments(namespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+	for i := range deployments.Items {
+		add("Deployment", deployments.Items[i].ObjectMeta, &deployments.Items[i].Spec.Template.Spec)
+	}
+
+	statefulSets, err := client.AppsV1beta1().StatefulSets(namespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+	for i := range statefulSets.Items {
+		add("StatefulSet", statefulSets.Items[i].ObjectMeta, &statefulSets.Items[i].Spec.Template.Spec)
+	}
+
+	daemonSets, err := client.ExtensionsV1beta1().DaemonSets(namespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+	for i := range daemonSets.Items {
+		add("DaemonSet", daemonSets.Items[i].ObjectMeta, &daemonSets.Items[i].Spec.Template.Spec)
+	}
+
+	jobs, err := client.BatchV1().Jobs(namespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+	for i := range jobs.Items {
+		add("Job", jobs.Items[i].ObjectMeta, &jobs.Items[i].Spec.Template.Spec)
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(options)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		if len(pods.Items[i].OwnerReferences) == 0 {
+			add("Pod", pods.Items[i].ObjectMeta, &pods.Items[i].Spec)
+		}
+	}
+
+	return newLintReport(reports, make([]string, 0)), nil
+}
+
+// LintManifests checks workloads in submitted manifests. Documents of other kinds are skipped.
+func LintManifests(spec *LintSpec) (*LintReport, error) {
+	objects, err := apply.ParseDocuments(spec.Content)
+	if err != nil {
+		return nil, errorsK8s.NewBadRequest(err.Error())
+	}
+
+	reports := make([]ObjectReport, 0)
+	skipped := make([]string, 0)
+	for _, obj := range objects {
+		podSpec, err := getPodSpec(obj)
+		if err != nil {
+			return nil, errorsK8s.NewBadRequest(fmt.Sprintf("%s %s: %s", obj.GetKind(), obj.GetName(), err))
+		}
+		if podSpec == nil {
+			skipped = append(skipped, obj.GetKind()+"/"+obj.GetName())
+			continue
+		}
+		reports = append(reports, newObjectReport(obj.GetKind(), obj.GetNamespace(), obj.GetName(), podSpec))
+	}
+	return newLintReport(reports, skipped), nil
+}
+
+// getPodSpec decodes pod spec of the workload. Nil is returned for objects of other kinds.
+func getPodSpec(obj *unstructured.Unstructured) (*v1.PodSpec, error) {
+	path, ok := podSpecPaths[obj.GetKind()]
+	if !ok {
+		return nil, nil
+	}
+
+	var value interface{} = obj.Object
+	for _, field := range path {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s is not an object", field)
+		}
+		value = fields[field]
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	spec := &v1.PodSpec{}
+	if err := json.Unmarshal(raw, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newObjectReport(kind, namespace, name string, spec *v1.PodSpec) ObjectReport {
+	requireProbes := kind != "Job" && kind != "CronJob"
+	findings := lintPodSpec(spec, requireProbes)
+	return ObjectReport{Kind: kind, Namespace: namespace, Name: name, Score: score(findings),
+		Findings: findings}
+}
+
+func newLintReport(reports []ObjectReport, skipped []string) *LintReport {
+	sort.SliceStable(reports, func(i, j int) bool {
+		if reports[i].Score != reports[j].Score {
+			return reports[i].Score < reports[j].Score
+		}
+		if reports[i].Kind != reports[j].Kind {
+			return reports[i].Kind < reports[j].Kind
+		}
+		return reports[i].Name < reports[j].Name
+	})
+
+	total := 100
+	if len(reports) > 0 {
+		total = 0
+		for _, report := range reports {
+			total += report.Score
+		}
+		total /= len(reports)
+	}
+	return &LintReport{Objects: reports, Score: total, Skipped: skipped}
+}
@@ -0,0 +1,168 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func newCompliantPodSpec() v1.PodSpec {
+	nonRoot := true
+	return v1.PodSpec{
+		SecurityContext: &v1.PodSecurityContext{RunAsNonRoot: &nonRoot},
+		Containers: []v1.Container{{
+			Name:           "app",
+			Image:          "example.com/app:1.0",
+			LivenessProbe:  &v1.Probe{},
+			ReadinessProbe: &v1.Probe{},
+			Resources: v1.ResourceRequirements{Limits: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("100m"),
+				v1.ResourceMemory: resource.MustParse("64Mi"),
+			}},
+		}},
+	}
+}
+
+func TestLintPodSpec(t *testing.T) {
+	root := types.UnixUserID(0)
+	cases := []struct {
+		name          string
+		change        func(spec *v1.PodSpec)
+		requireProbes bool
+		expected      []Check
+	}{
+		{"compliant", func(spec *v1.PodSpec) {}, true, []Check{}},
+		{"probes", func(spec *v1.PodSpec) {
+			spec.Containers[0].LivenessProbe = nil
+			spec.Containers[0].ReadinessProbe = nil
+		}, true, []Check{CheckLivenessProbe, CheckReadinessProbe}},
+		{"probes of job", func(spec *v1.PodSpec) {
+			spec.Containers[0].LivenessProbe = nil
+		}, false, []Check{}},
+		{"limits", func(spec *v1.PodSpec) {
+			delete(spec.Containers[0].Resources.Limits, v1.ResourceMemory)
+		}, true, []Check{CheckResourceLimits}},
+		{"latest", func(spec *v1.PodSpec) {
+			spec.Containers[0].Image = "localhost:5000/app:latest"
+		}, true, []Check{CheckImageTag}},
+		{"untagged", func(spec *v1.PodSpec) {
+			spec.Containers[0].Image = "localhost:5000/app"
+		}, true, []Check{CheckImageTag}},
+		{"digest", func(spec *v1.PodSpec) {
+			spec.Containers[0].Image = "app@sha256:0123"
+		}, true, []Check{}},
+		{"root user", func(spec *v1.PodSpec) {
+			spec.Containers[0].SecurityContext = &v1.SecurityContext{RunAsUser: &root}
+		}, true, []Check{CheckRunAsRoot}},
+		{"host path", func(spec *v1.PodSpec) {
+			spec.SecurityContext = nil
+			spec.Volumes = []v1.Volume{{Name: "docker", VolumeSource: v1.VolumeSource{
+				HostPath: &v1.HostPathVolumeSource{Path: "/var/run/docker.sock"}}}}
+		}, true, []Check{CheckHostPath, CheckRunAsRoot}},
+	}
+
+	for _, c := range cases {
+		spec := newCompliantPodSpec()
+		c.change(&spec)
+		actual := make([]Check, 0)
+		for _, finding := range lintPodSpec(&spec, c.requireProbes) {
+			actual = append(actual, finding.Check)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: lintPodSpec() returns %#v, expected %#v", c.name, actual, c.expected)
+		}
+	}
+}
+
+func TestLintManifests(t *testing.T) {
+	content := `apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - name: backup
+            image: backup:latest
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+`
+
+	report, err := LintManifests(&LintSpec{Content: content})
+	if err != nil {
+		t.Fatalf("LintManifests() returns error %s", err)
+	}
+
+	if len(report.Objects) != 1 || report.Objects[0].Kind != "CronJob" {
+		t.Fatalf("LintManifests() returns %#v, expected report of the cron job", report.Objects)
+	}
+	// Resource limits, image tag and root user. Probes are not required for jobs.
+	if actual := report.Objects[0].Score; actual != 60 || report.Score != 60 {
+		t.Errorf("LintManifests() returns score %d, expected 60", actual)
+	}
+	if !reflect.DeepEqual(report.Skipped, []string{"ConfigMap/settings"}) {
+		t.Errorf("LintManifests() skips %#v, expected ConfigMap/settings", report.Skipped)
+	}
+}
+
+func TestGetLintReport(t *testing.T) {
+	compliant := newCompliantPodSpec()
+	risky := newCompliantPodSpec()
+	risky.SecurityContext = nil
+	client := fake.NewSimpleClientset(
+		&extensions.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       extensions.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: compliant}},
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web-1", Namespace: "default",
+				OwnerReferences: []metaV1.OwnerReference{{Kind: "ReplicaSet", Name: "web"}}},
+			Spec: compliant,
+		},
+		&v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "debug", Namespace: "default"}, Spec: risky},
+	)
+
+	report, err := GetLintReport(client, common.NewSameNamespaceQuery("default"))
+	if err != nil {
+		t.Fatalf("GetLintReport() returns error %s", err)
+	}
+
+	actual := make([]string, 0)
+	for _, object := range report.Objects {
+		actual = append(actual, object.Kind+"/"+object.Name)
+	}
+	expected := []string{"Pod/debug", "Deployment/web"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetLintReport() returns %#v, expected %#v", actual, expected)
+	}
+	if report.Score != 90 {
+		t.Errorf("GetLintReport() returns score %d, expected 90", report.Score)
+	}
+}
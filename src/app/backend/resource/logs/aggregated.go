@@ -0,0 +1,112 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"time"
+)
+
+// AggregatedLogDetails is a representation of log lines merged from multiple log sources, e.g. all
+// containers of a pod or all pods of a deployment.
+type AggregatedLogDetails struct {
+	// Additional information of the logs.
+	Info AggregatedLogInfo `json:"info"`
+
+	// Log lines of all sources ordered by their timestamps.
+	LogLines []AggregatedLogLine `json:"logs"`
+}
+
+// AggregatedLogInfo is meta information about aggregated log lines.
+type AggregatedLogInfo struct {
+	// Sources that the log lines were loaded from.
+	Sources []AggregatedLogSource `json:"sources"`
+
+	// Date of the first log line.
+	FromDate LogTimestamp `json:"fromDate"`
+
+	// Date of the last log line. It can be used as a starting point for the next request to
+	// follow the logs.
+	ToDate LogTimestamp `json:"toDate"`
+
+	// Some log lines or sources were dropped because the limit of lines or sources was reached.
+	Truncated bool `json:"truncated"`
+}
+
+// AggregatedLogSource identifies single log file through the combination of pod and container.
+type AggregatedLogSource struct {
+	PodName       string `json:"podName"`
+	ContainerName string `json:"containerName"`
+	// Error that occurred while loading logs of this source, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// AggregatedLogLine is a single log line with information about its source.
+type AggregatedLogLine struct {
+	LogLine
+	PodName       string `json:"podName"`
+	ContainerName string `json:"containerName"`
+}
+
+// MergeLogLines merges log lines of multiple sources into a single chronologically ordered list.
+// Lines of every source have to be ordered already. Only the newest limit lines are returned, if
+// limit is positive.
+func MergeLogLines(sources []AggregatedLogSource, lines []LogLines, limit int) ([]AggregatedLogLine, bool) {
+	total := 0
+	for _, sourceLines := range lines {
+		total += len(sourceLines)
+	}
+
+	result := make([]AggregatedLogLine, 0, total)
+	indices := make([]int, len(lines))
+	for len(result) < total {
+		next := -1
+		for i, sourceLines := range lines {
+			if indices[i] >= len(sourceLines) {
+				continue
+			}
+			if next == -1 || sourceLines[indices[i]].Timestamp.Before(lines[next][indices[next]].Timestamp) {
+				next = i
+			}
+		}
+
+		result = append(result, AggregatedLogLine{
+			LogLine:       lines[next][indices[next]],
+			PodName:       sources[next].PodName,
+			ContainerName: sources[next].ContainerName,
+		})
+		indices[next]++
+	}
+
+	if limit > 0 && len(result) > limit {
+		return result[len(result)-limit:], true
+	}
+	return result, false
+}
+
+// Before returns true if the timestamp is earlier than the other one. Timestamps are compared as
+// RFC3339 times, falling back to string comparison if they can not be parsed.
+func (self LogTimestamp) Before(other LogTimestamp) bool {
+	selfTime, err := time.Parse(time.RFC3339Nano, string(self))
+	if err != nil {
+		return self < other
+	}
+
+	otherTime, err := time.Parse(time.RFC3339Nano, string(other))
+	if err != nil {
+		return self < other
+	}
+
+	return selfTime.Before(otherTime)
+}
@@ -0,0 +1,74 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeLogLines(t *testing.T) {
+	sources := []AggregatedLogSource{
+		{PodName: "pod-1", ContainerName: "app"},
+		{PodName: "pod-2", ContainerName: "app"},
+	}
+	lines := []LogLines{
+		ToLogLines("2017-06-01T10:00:00Z a1\n2017-06-01T10:00:02.5Z a2"),
+		ToLogLines("2017-06-01T10:00:01Z b1\n2017-06-01T10:00:02.25Z b2\n2017-06-01T10:00:03Z b3"),
+	}
+	line := func(timestamp, content, podName string) AggregatedLogLine {
+		return AggregatedLogLine{
+			LogLine:       LogLine{Timestamp: LogTimestamp(timestamp), Content: content},
+			PodName:       podName,
+			ContainerName: "app",
+		}
+	}
+
+	cases := []struct {
+		info              string
+		limit             int
+		expected          []AggregatedLogLine
+		expectedTruncated bool
+	}{
+		{
+			"merges all lines chronologically",
+			0,
+			[]AggregatedLogLine{
+				line("2017-06-01T10:00:00Z", "a1", "pod-1"),
+				line("2017-06-01T10:00:01Z", "b1", "pod-2"),
+				line("2017-06-01T10:00:02.25Z", "b2", "pod-2"),
+				line("2017-06-01T10:00:02.5Z", "a2", "pod-1"),
+				line("2017-06-01T10:00:03Z", "b3", "pod-2"),
+			},
+			false,
+		},
+		{
+			"returns only the newest lines if limit is reached",
+			2,
+			[]AggregatedLogLine{
+				line("2017-06-01T10:00:02.5Z", "a2", "pod-1"),
+				line("2017-06-01T10:00:03Z", "b3", "pod-2"),
+			},
+			true,
+		},
+	}
+	for _, c := range cases {
+		actual, truncated := MergeLogLines(sources, lines, c.limit)
+		if !reflect.DeepEqual(actual, c.expected) || truncated != c.expectedTruncated {
+			t.Errorf("Test Case: %s. MergeLogLines() returns (%#v, %v), expected (%#v, %v)", c.info, actual,
+				truncated, c.expected, c.expectedTruncated)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LogLevel is a severity of a log line detected from its content.
+type LogLevel string
+
+// List of log levels that can be detected.
+const (
+	LogLevelError   LogLevel = "error"
+	LogLevelWarning LogLevel = "warning"
+	LogLevelInfo    LogLevel = "info"
+	LogLevelDebug   LogLevel = "debug"
+	LogLevelUnknown LogLevel = "unknown"
+)
+
+// glogLevelRegexp matches lines logged with glog, e.g. "E0601 10:00:00.000000 ...".
+var glogLevelRegexp = regexp.MustCompile(`^([EWID])\d{4} \d{2}:\d{2}:\d{2}`)
+
+// levelKeywordRegexp matches the most common log level keywords, e.g. "[ERROR]" or "level=warn".
+var levelKeywordRegexp = regexp.MustCompile(
+	`(?i)\b(fatal|panic|critical|error|err|warning|warn|info|debug|trace)\b`)
+
+// Mapping of glog prefixes and keywords to log levels.
+var logLevelMapping = map[string]LogLevel{
+	"e":        LogLevelError,
+	"fatal":    LogLevelError,
+	"panic":    LogLevelError,
+	"critical": LogLevelError,
+	"error":    LogLevelError,
+	"err":      LogLevelError,
+	"w":        LogLevelWarning,
+	"warning":  LogLevelWarning,
+	"warn":     LogLevelWarning,
+	"i":        LogLevelInfo,
+	"info":     LogLevelInfo,
+	"d":        LogLevelDebug,
+	"debug":    LogLevelDebug,
+	"trace":    LogLevelDebug,
+}
+
+// LogFilter describes which log lines should be kept. Empty filter keeps all lines.
+type LogFilter struct {
+	// Search is a substring or, if SearchRegexp is set, a regular expression that log line content
+	// has to match.
+	Search string
+	// SearchRegexp is compiled Search expression, nil if plain substring search is used.
+	SearchRegexp *regexp.Regexp
+	// Levels are log levels to keep. All levels are kept if empty.
+	Levels []LogLevel
+	// SinceTime if set, only lines logged at or after this time are kept.
+	SinceTime *time.Time
+	// UntilTime if set, only lines logged before or at this time are kept.
+	UntilTime *time.Time
+}
+
+// NewLogFilter creates log filter. If useRegexp is true, search is compiled as a regular
+// expression.
+func NewLogFilter(search string, useRegexp bool, levels []LogLevel, sinceTime,
+	untilTime *time.Time) (*LogFilter, error) {
+	filter := &LogFilter{
+		Search:    search,
+		Levels:    levels,
+		SinceTime: sinceTime,
+		UntilTime: untilTime,
+	}
+
+	if useRegexp && len(search) > 0 {
+		searchRegexp, err := regexp.Compile(search)
+		if err != nil {
+			return nil, err
+		}
+		filter.SearchRegexp = searchRegexp
+	}
+
+	return filter, nil
+}
+
+// IsEmpty returns true if the filter keeps all log lines.
+func (self *LogFilter) IsEmpty() bool {
+	return self == nil || (len(self.Search) == 0 && len(self.Levels) == 0 && self.SinceTime == nil &&
+		self.UntilTime == nil)
+}
+
+// Matches returns true if the log line should be kept.
+func (self *LogFilter) Matches(line LogLine) bool {
+	if self.IsEmpty() {
+		return true
+	}
+
+	if self.SearchRegexp != nil {
+		if !self.SearchRegexp.MatchString(line.Content) {
+			return false
+		}
+	} else if len(self.Search) > 0 && !strings.Contains(line.Content, self.Search) {
+		return false
+	}
+
+	if len(self.Levels) > 0 && !containsLogLevel(self.Levels, DetectLogLevel(line.Content)) {
+		return false
+	}
+
+	if self.SinceTime != nil || self.UntilTime != nil {
+		lineTime, err := time.Parse(time.RFC3339Nano, string(line.Timestamp))
+		if err != nil {
+			return false
+		}
+		if self.SinceTime != nil && lineTime.Before(*self.SinceTime) {
+			return false
+		}
+		if self.UntilTime != nil && lineTime.After(*self.UntilTime) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Filter returns log lines matching given filter.
+func (self LogLines) Filter(filter *LogFilter) LogLines {
+	if filter.IsEmpty() {
+		return self
+	}
+
+	result := LogLines{}
+	for _, line := range self {
+		if filter.Matches(line) {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+// DetectLogLevel guesses log level of a line based on glog prefix or the first level keyword that
+// appears in the content.
+func DetectLogLevel(content string) LogLevel {
+	if match := glogLevelRegexp.FindStringSubmatch(content); match != nil {
+		return logLevelMapping[strings.ToLower(match[1])]
+	}
+
+	if match := levelKeywordRegexp.FindStringSubmatch(content); match != nil {
+		return logLevelMapping[strings.ToLower(match[1])]
+	}
+
+	return LogLevelUnknown
+}
+
+func containsLogLevel(levels []LogLevel, level LogLevel) bool {
+	for _, l := range levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDetectLogLevel(t *testing.T) {
+	cases := []struct {
+		content  string
+		expected LogLevel
+	}{
+		{"E0601 10:00:00.000000 1 main.go:10] failed", LogLevelError},
+		{"W0601 10:00:00.000000 1 main.go:10] slow", LogLevelWarning},
+		{"I0601 10:00:00.000000 1 main.go:10] started", LogLevelInfo},
+		{"[DEBUG] connecting", LogLevelDebug},
+		{"level=warn msg=retrying", LogLevelWarning},
+		{"panic: runtime error", LogLevelError},
+		{"server listening on :8080", LogLevelUnknown},
+		{"errors are expected here", LogLevelUnknown},
+	}
+
+	for _, c := range cases {
+		actual := DetectLogLevel(c.content)
+		if actual != c.expected {
+			t.Errorf("DetectLogLevel(%#v) returns %#v, expected %#v", c.content, actual, c.expected)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	lines := ToLogLines("2017-06-01T10:00:00Z [INFO] started\n" +
+		"2017-06-01T10:00:01Z [ERROR] request failed\n" +
+		"2017-06-01T10:00:02Z [WARN] request slow\n" +
+		"2017-06-01T10:00:03Z [INFO] request done")
+	since := time.Date(2017, 6, 1, 10, 0, 1, 0, time.UTC)
+	until := time.Date(2017, 6, 1, 10, 0, 2, 0, time.UTC)
+
+	cases := []struct {
+		info      string
+		search    string
+		useRegexp bool
+		levels    []LogLevel
+		since     *time.Time
+		until     *time.Time
+		expected  LogLines
+	}{
+		{"empty filter", "", false, nil, nil, nil, lines},
+		{"substring", "request", false, nil, nil, nil, lines[1:]},
+		{"regexp", "fail|slow", true, nil, nil, nil, lines[1:3]},
+		{"levels", "", false, []LogLevel{LogLevelError, LogLevelWarning}, nil, nil, lines[1:3]},
+		{"time range", "", false, nil, &since, &until, lines[1:3]},
+		{"combined", "request", false, []LogLevel{LogLevelInfo}, nil, nil, lines[3:]},
+		{"no match", "missing", false, nil, nil, nil, LogLines{}},
+	}
+
+	for _, c := range cases {
+		filter, err := NewLogFilter(c.search, c.useRegexp, c.levels, c.since, c.until)
+		if err != nil {
+			t.Fatalf("%s: NewLogFilter returns error: %v", c.info, err)
+		}
+		actual := lines.Filter(filter)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: Filter(%#v) returns %#v, expected %#v", c.info, filter, actual, c.expected)
+		}
+	}
+}
+
+func TestNewLogFilterInvalidRegexp(t *testing.T) {
+	if _, err := NewLogFilter("[", true, nil, nil, nil); err == nil {
+		t.Error("NewLogFilter with invalid regexp should return error")
+	}
+}
@@ -96,6 +96,9 @@ type LogInfo struct {
 
 	// Some log lines in the middle of the log file could not be loaded, because the log file is too large.
 	Truncated bool `json:"truncated"`
+
+	// Logs come from the previous terminated instance of the container.
+	Previous bool `json:"previous"`
 }
 
 // Selection of a slice of logs.
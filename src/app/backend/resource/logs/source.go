@@ -19,12 +19,16 @@ import "github.com/kubernetes/dashboard/src/app/backend/resource/common"
 import client "k8s.io/client-go/kubernetes"
 import meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 import "k8s.io/client-go/pkg/api/v1"
+import "github.com/kubernetes/dashboard/src/app/backend/api"
 
 // GetLogSources returns all log sources for a given resource. A log source identifies a log file through the combination of pod & container
 func GetLogSources(k8sClient *client.Clientset, ns string, resourceName string, resourceType string) (controller.LogSources, error) {
 	if resourceType == "pod" {
 		return getLogSourcesFromPod(k8sClient, ns, resourceName)
 	}
+	if resourceType == api.ResourceKindDeployment {
+		return getLogSourcesFromDeployment(k8sClient, ns, resourceName)
+	}
 	return getLogSourcesFromController(k8sClient, ns, resourceName, resourceType)
 }
 
@@ -48,3 +52,36 @@ func getLogSourcesFromController(k8sClient *client.Clientset, ns, resourceName,
 	}
 	return rc.GetLogSources(k8sClient), nil
 }
+
+// getLogSourcesFromDeployment returns all pods and containers of a deployment
+func getLogSourcesFromDeployment(k8sClient *client.Clientset, ns, resourceName string) (controller.LogSources, error) {
+	deployment, err := k8sClient.ExtensionsV1beta1().Deployments(ns).Get(resourceName, meta.GetOptions{})
+	if err != nil {
+		return controller.LogSources{}, err
+	}
+
+	channels := &common.ResourceChannels{
+		PodList:        common.GetPodListChannel(k8sClient, common.NewSameNamespaceQuery(ns), 1),
+		ReplicaSetList: common.GetReplicaSetListChannel(k8sClient, common.NewSameNamespaceQuery(ns), 1),
+	}
+
+	rawPods := <-channels.PodList.List
+	if err := <-channels.PodList.Error; err != nil {
+		return controller.LogSources{}, err
+	}
+
+	rawRs := <-channels.ReplicaSetList.List
+	if err := <-channels.ReplicaSetList.Error; err != nil {
+		return controller.LogSources{}, err
+	}
+
+	podNames := make([]string, 0)
+	for _, pod := range common.FilterDeploymentPodsByOwnerReference(*deployment, rawRs.Items, rawPods.Items) {
+		podNames = append(podNames, pod.Name)
+	}
+
+	return controller.LogSources{
+		ContainerNames: common.GetContainerNames(&deployment.Spec.Template.Spec),
+		PodNames:       podNames,
+	}, nil
+}
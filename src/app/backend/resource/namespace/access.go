@@ -0,0 +1,117 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/accessreview"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultAccessResource is the resource used to check access to namespaces, if none is given.
+	DefaultAccessResource = "pods"
+	// accessReviewWorkers is the number of namespaces reviewed concurrently.
+	accessReviewWorkers = 10
+)
+
+// NamespaceAccessList contains namespaces the user can access.
+type NamespaceAccessList struct {
+	// Sorted names of namespaces, in which the user can list the resource.
+	Namespaces []string `json:"namespaces"`
+
+	// AllNamespaces is true if the user can list the resource in all namespaces at once, so that
+	// "all namespaces" view can be used.
+	AllNamespaces bool `json:"allNamespaces"`
+}
+
+// GetAccessibleNamespaces returns namespaces, in which the user can list given resource. Names of
+// namespaces are listed with client of the user. If the user is not allowed to list namespaces,
+// they are listed with client of Dashboard, so that users with access to single namespaces can
+// still find them. Only namespaces the user has access to are returned.
+func GetAccessibleNamespaces(userClient, dashboardClient client.Interface,
+	resource string) (*NamespaceAccessList, error) {
+	if resource == "" {
+		resource = DefaultAccessResource
+	}
+
+	names, err := listNamespaceNames(userClient)
+	if err != nil {
+		if _, criticalError := errors.HandleError(err); criticalError != nil {
+			return nil, criticalError
+		}
+		if names, err = listNamespaceNames(dashboardClient); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &NamespaceAccessList{Namespaces: make([]string, 0)}
+	review := accessreview.ReviewAction(userClient,
+		accessreview.ResourceAction{Verb: "list", Resource: resource})
+	if review.Allowed {
+		result.AllNamespaces = true
+		result.Namespaces = names
+		return result, nil
+	}
+
+	allowed := make([]bool, len(names))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < accessReviewWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				allowed[i] = accessreview.ReviewAction(userClient, accessreview.ResourceAction{
+					Verb:      "list",
+					Resource:  resource,
+					Namespace: names[i],
+				}).Allowed
+			}
+		}()
+	}
+	for i := range names {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	for i, name := range names {
+		if allowed[i] {
+			result.Namespaces = append(result.Namespaces, name)
+		}
+	}
+	return result, nil
+}
+
+// listNamespaceNames returns sorted names of all namespaces. Forbidden error is returned if the
+// client is not allowed to list them.
+func listNamespaceNames(client client.Interface) ([]string, error) {
+	list, err := client.CoreV1().Namespaces().List(metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(list.Items))
+	for _, namespace := range list.Items {
+		names = append(names, namespace.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
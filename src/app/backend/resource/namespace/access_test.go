@@ -0,0 +1,100 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	authorization "k8s.io/client-go/pkg/apis/authorization/v1"
+	core "k8s.io/client-go/testing"
+)
+
+func newUserClient(canListNamespaces bool, allowed map[string]bool) *fake.Clientset {
+	client := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "team-b"}},
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "team-a"}},
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "kube-system"}},
+	)
+	if !canListNamespaces {
+		client.PrependReactor("list", "namespaces",
+			func(action core.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "",
+					nil)
+			})
+	}
+	client.PrependReactor("create", "selfsubjectaccessreviews",
+		func(action core.Action) (bool, runtime.Object, error) {
+			review := action.(core.CreateAction).GetObject().(*authorization.SelfSubjectAccessReview)
+			review.Status.Allowed = allowed[review.Spec.ResourceAttributes.Namespace]
+			return true, review, nil
+		})
+	return client
+}
+
+func TestGetAccessibleNamespaces(t *testing.T) {
+	dashboardClient := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "team-a"}},
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "team-b"}},
+		&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "kube-system"}},
+	)
+
+	cases := []struct {
+		info       string
+		userClient *fake.Clientset
+		expected   *NamespaceAccessList
+	}{
+		{
+			"cluster-wide access",
+			newUserClient(true, map[string]bool{"": true}),
+			&NamespaceAccessList{
+				Namespaces:    []string{"kube-system", "team-a", "team-b"},
+				AllNamespaces: true,
+			},
+		},
+		{
+			"access to some namespaces",
+			newUserClient(true, map[string]bool{"team-a": true, "team-b": true}),
+			&NamespaceAccessList{Namespaces: []string{"team-a", "team-b"}},
+		},
+		{
+			"namespaces listed by dashboard",
+			newUserClient(false, map[string]bool{"team-b": true}),
+			&NamespaceAccessList{Namespaces: []string{"team-b"}},
+		},
+		{
+			"no access",
+			newUserClient(false, map[string]bool{}),
+			&NamespaceAccessList{Namespaces: []string{}},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := GetAccessibleNamespaces(c.userClient, dashboardClient, "")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.info, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: GetAccessibleNamespaces() == %#v, expected %#v", c.info, actual, c.expected)
+		}
+	}
+}
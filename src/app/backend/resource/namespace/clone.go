@@ -0,0 +1,337 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apiresource"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/export"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	client "k8s.io/client-go/kubernetes"
+	api "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+// DefaultCloneKinds are copied, when clone spec does not list kinds.
+var DefaultCloneKinds = []string{"ConfigMap", "Service", "Deployment"}
+
+// podSpecPaths are paths to pod specs of workload kinds. References of pod specs to renamed config
+// maps and secrets are rewritten.
+var podSpecPaths = map[string][]string{
+	"Pod":         {"spec"},
+	"Deployment":  {"spec", "template", "spec"},
+	"ReplicaSet":  {"spec", "template", "spec"},
+	"StatefulSet": {"spec", "template", "spec"},
+	"DaemonSet":   {"spec", "template", "spec"},
+	"Job":         {"spec", "template", "spec"},
+	"CronJob":     {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// NamespaceCloneSpec describes objects copied to the target namespace and how they are renamed.
+type NamespaceCloneSpec struct {
+	// Target namespace.
+	Target string `json:"target"`
+
+	// Kinds of copied objects. DefaultCloneKinds are copied when empty. See export.ExportSpec for
+	// the format. Objects managed by controllers, e.g. replica sets of deployments, are skipped.
+	Kinds []string `json:"kinds"`
+
+	// IncludeSecrets copies secrets as well. Secrets are never copied unless this is set.
+	IncludeSecrets bool `json:"includeSecrets"`
+
+	// CreateNamespace creates the target namespace, if it does not exist.
+	CreateNamespace bool `json:"createNamespace"`
+
+	// NamePrefix and NameSuffix are added to names of copied objects. References to copied config
+	// maps, secrets and services from pod templates are renamed as well.
+	NamePrefix string `json:"namePrefix"`
+	NameSuffix string `json:"nameSuffix"`
+
+	// Labels added to copied objects and pod templates of copied workloads. Selectors are not
+	// changed.
+	Labels map[string]string `json:"labels"`
+}
+
+// ClonedObject is an object created in the target namespace.
+type ClonedObject struct {
+	Kind       string `json:"kind"`
+	SourceName string `json:"sourceName"`
+	Name       string `json:"name"`
+}
+
+// CloneFailure is an object, which could not be created in the target namespace, e.g. because it
+// already exists.
+type CloneFailure struct {
+	Kind  string `json:"kind"`
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// NamespaceCloneResult lists objects copied to the target namespace.
+type NamespaceCloneResult struct {
+	Source           string         `json:"source"`
+	Target           string         `json:"target"`
+	NamespaceCreated bool           `json:"namespaceCreated"`
+	Cloned           []ClonedObject `json:"cloned"`
+	Failed           []CloneFailure `json:"failed"`
+}
+
+// CloneNamespace copies objects of the selected kinds from the source namespace to the target
+// namespace. Objects are created one by one, failures do not stop copying of other objects.
+func CloneNamespace(client client.Interface, discoveryClient discovery.DiscoveryInterface, config *rest.Config,
+	source string, spec *NamespaceCloneSpec) (*NamespaceCloneResult, error) {
+	kinds, err := getCloneKinds(source, spec)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Cloning %s from %s namespace to %s namespace", strings.Join(kinds, ", "), source, spec.Target)
+
+	if _, err := client.CoreV1().Namespaces().Get(source, metaV1.GetOptions{}); err != nil {
+		return nil, err
+	}
+	result := &NamespaceCloneResult{Source: source, Target: spec.Target, Cloned: make([]ClonedObject, 0),
+		Failed: make([]CloneFailure, 0)}
+
+	_, err = client.CoreV1().Namespaces().Get(spec.Target, metaV1.GetOptions{})
+	if errorsK8s.IsNotFound(err) && spec.CreateNamespace {
+		_, err = client.CoreV1().Namespaces().Create(&api.Namespace{
+			ObjectMeta: metaV1.ObjectMeta{Name: spec.Target, Labels: spec.Labels}})
+		result.NamespaceCreated = err == nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := export.ListObjects(discoveryClient, config, source, kinds, false)
+	if err != nil {
+		return nil, err
+	}
+
+	renamed := make(map[string]map[string]string)
+	for _, obj := range objects {
+		if renamed[obj.GetKind()] == nil {
+			renamed[obj.GetKind()] = make(map[string]string)
+		}
+		renamed[obj.GetKind()][obj.GetName()] = spec.NamePrefix + obj.GetName() + spec.NameSuffix
+	}
+
+	for _, obj := range objects {
+		clone := &unstructured.Unstructured{Object: export.Clean(obj)}
+		rewriteClone(clone, spec, renamed)
+
+		if err := createObject(discoveryClient, config, clone); err != nil {
+			result.Failed = append(result.Failed, CloneFailure{Kind: obj.GetKind(), Name: obj.GetName(),
+				Error: err.Error()})
+			continue
+		}
+		result.Cloned = append(result.Cloned, ClonedObject{Kind: obj.GetKind(), SourceName: obj.GetName(),
+			Name: clone.GetName()})
+	}
+	return result, nil
+}
+
+// getCloneKinds validates the spec and returns kinds to copy.
+func getCloneKinds(source string, spec *NamespaceCloneSpec) ([]string, error) {
+	if len(spec.Target) == 0 {
+		return nil, errorsK8s.NewBadRequest("target namespace is required")
+	}
+	if spec.Target == source && len(spec.NamePrefix) == 0 && len(spec.NameSuffix) == 0 {
+		return nil, errorsK8s.NewBadRequest("objects cannot be copied to the same namespace without renaming")
+	}
+
+	kinds := spec.Kinds
+	if len(kinds) == 0 {
+		kinds = DefaultCloneKinds
+	}
+	for _, kind := range kinds {
+		name := strings.ToLower(strings.SplitN(kind, ".", 2)[0])
+		if name == "secret" || name == "secrets" {
+			return nil, errorsK8s.NewBadRequest("secrets are copied only when includeSecrets is set")
+		}
+	}
+	if spec.IncludeSecrets {
+		kinds = append([]string{"Secret"}, kinds...)
+	}
+	return kinds, nil
+}
+
+// rewriteClone moves the clone to the target namespace, renames it and its references to other
+// copied objects and adds labels.
+func rewriteClone(clone *unstructured.Unstructured, spec *NamespaceCloneSpec,
+	renamed map[string]map[string]string) {
+	clone.SetNamespace(spec.Target)
+	clone.SetName(renamed[clone.GetKind()][clone.GetName()])
+	if len(spec.Labels) > 0 {
+		clone.SetLabels(mergeLabels(clone.GetLabels(), spec.Labels))
+	}
+
+	switch clone.GetKind() {
+	case "Service":
+		// Node ports are allocated cluster wide, so copies need new ones.
+		if ports, ok := getField(clone.Object, "spec", "ports").([]interface{}); ok {
+			for _, port := range ports {
+				if port, ok := port.(map[string]interface{}); ok {
+					delete(port, "nodePort")
+				}
+			}
+		}
+	case "StatefulSet":
+		renameField(getMap(clone.Object, "spec"), "serviceName", renamed["Service"])
+	}
+
+	path, ok := podSpecPaths[clone.GetKind()]
+	if !ok {
+		return
+	}
+	if len(path) > 1 && len(spec.Labels) > 0 {
+		// Pod template metadata is next to the pod spec.
+		template := getMap(clone.Object, path[:len(path)-1]...)
+		if metadata := getMap(template, "metadata"); metadata != nil {
+			labels := make(map[string]string)
+			if existing, ok := metadata["labels"].(map[string]interface{}); ok {
+				for key, value := range existing {
+					labels[key] = fmt.Sprint(value)
+				}
+			}
+			metadata["labels"] = toInterfaceMap(mergeLabels(labels, spec.Labels))
+		}
+	}
+	rewritePodSpec(getMap(clone.Object, path...), renamed)
+}
+
+// rewritePodSpec renames references of the pod spec to copied config maps and secrets.
+func rewritePodSpec(podSpec map[string]interface{}, renamed map[string]map[string]string) {
+	if podSpec == nil {
+		return
+	}
+	configMaps, secrets := renamed["ConfigMap"], renamed["Secret"]
+
+	for _, volume := range getMaps(podSpec, "volumes") {
+		renameField(getMap(volume, "configMap"), "name", configMaps)
+		renameField(getMap(volume, "secret"), "secretName", secrets)
+		for _, source := range getMaps(getMap(volume, "projected"), "sources") {
+			renameField(getMap(source, "configMap"), "name", configMaps)
+			renameField(getMap(source, "secret"), "name", secrets)
+		}
+	}
+
+	for _, field := range []string{"containers", "initContainers"} {
+		for _, container := range getMaps(podSpec, field) {
+			for _, envFrom := range getMaps(container, "envFrom") {
+				renameField(getMap(envFrom, "configMapRef"), "name", configMaps)
+				renameField(getMap(envFrom, "secretRef"), "name", secrets)
+			}
+			for _, env := range getMaps(container, "env") {
+				renameField(getMap(env, "valueFrom", "configMapKeyRef"), "name", configMaps)
+				renameField(getMap(env, "valueFrom", "secretKeyRef"), "name", secrets)
+			}
+		}
+	}
+
+	for _, pullSecret := range getMaps(podSpec, "imagePullSecrets") {
+		renameField(pullSecret, "name", secrets)
+	}
+}
+
+// createObject creates the object in its namespace using resource discovered for its kind.
+func createObject(discoveryClient discovery.DiscoveryInterface, config *rest.Config,
+	obj *unstructured.Unstructured) error {
+	gv, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+	if err != nil {
+		return err
+	}
+	resource, err := apiresource.FindAPIResourceByKind(discoveryClient, gv.Group, gv.Version, obj.GetKind())
+	if err != nil {
+		return err
+	}
+	restClient, err := apply.NewRESTClient(config, gv)
+	if err != nil {
+		return err
+	}
+
+	raw, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return restClient.Post().Namespace(obj.GetNamespace()).Resource(resource.Name).Body(raw).Do().Error()
+}
+
+func mergeLabels(labels, added map[string]string) map[string]string {
+	result := make(map[string]string, len(labels)+len(added))
+	for key, value := range labels {
+		result[key] = value
+	}
+	for key, value := range added {
+		result[key] = value
+	}
+	return result
+}
+
+func toInterfaceMap(labels map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(labels))
+	for key, value := range labels {
+		result[key] = value
+	}
+	return result
+}
+
+// renameField replaces value of the field with its new name, if the value is a renamed object.
+func renameField(obj map[string]interface{}, field string, names map[string]string) {
+	if obj == nil {
+		return
+	}
+	if name, ok := obj[field].(string); ok {
+		if newName, ok := names[name]; ok {
+			obj[field] = newName
+		}
+	}
+}
+
+func getField(obj map[string]interface{}, path ...string) interface{} {
+	var current interface{} = obj
+	for _, field := range path {
+		fields, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = fields[field]
+	}
+	return current
+}
+
+func getMap(obj map[string]interface{}, path ...string) map[string]interface{} {
+	result, _ := getField(obj, path...).(map[string]interface{})
+	return result
+}
+
+// getMaps returns objects of the list field.
+func getMaps(obj map[string]interface{}, field string) []map[string]interface{} {
+	items, _ := getField(obj, field).([]interface{})
+	result := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if item, ok := item.(map[string]interface{}); ok {
+			result = append(result, item)
+		}
+	}
+	return result
+}
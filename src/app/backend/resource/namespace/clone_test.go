@@ -0,0 +1,203 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes/fake"
+	api "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+var cloneAPIServerResponses = map[string]string{
+	"/api": `{"kind": "APIVersions", "versions": ["v1"]}`,
+	"/apis": `{"kind": "APIGroupList", "groups": [{"name": "apps",
+		"versions": [{"groupVersion": "apps/v1", "version": "v1"}],
+		"preferredVersion": {"groupVersion": "apps/v1", "version": "v1"}}]}`,
+	"/api/v1": `{"kind": "APIResourceList", "groupVersion": "v1", "resources": [
+		{"name": "configmaps", "namespaced": true, "kind": "ConfigMap", "verbs": ["create", "list"]},
+		{"name": "secrets", "namespaced": true, "kind": "Secret", "verbs": ["create", "list"]},
+		{"name": "services", "namespaced": true, "kind": "Service", "verbs": ["create", "list"]}]}`,
+	"/apis/apps/v1": `{"kind": "APIResourceList", "groupVersion": "apps/v1", "resources": [
+		{"name": "deployments", "namespaced": true, "kind": "Deployment", "verbs": ["create", "list"]}]}`,
+	"/api/v1/namespaces/prod/configmaps": `{"kind": "ConfigMapList", "apiVersion": "v1", "items": [
+		{"metadata": {"name": "settings", "namespace": "prod", "uid": "1"}, "data": {"a": "b"}}]}`,
+	"/api/v1/namespaces/prod/secrets": `{"kind": "SecretList", "apiVersion": "v1", "items": [
+		{"metadata": {"name": "credentials", "namespace": "prod"}, "type": "Opaque"},
+		{"metadata": {"name": "default-token-abcde", "namespace": "prod"},
+			"type": "kubernetes.io/service-account-token"}]}`,
+	"/api/v1/namespaces/prod/services": `{"kind": "ServiceList", "apiVersion": "v1", "items": [
+		{"metadata": {"name": "web", "namespace": "prod"}, "spec": {"type": "NodePort",
+			"clusterIP": "10.0.0.1", "ports": [{"port": 80, "nodePort": 30080}]}}]}`,
+	"/apis/apps/v1/namespaces/prod/deployments": `{"kind": "DeploymentList", "apiVersion": "apps/v1", "items": [
+		{"metadata": {"name": "web", "namespace": "prod", "labels": {"app": "web"}},
+			"spec": {"selector": {"matchLabels": {"app": "web"}}, "template": {
+				"metadata": {"labels": {"app": "web"}},
+				"spec": {"containers": [{"name": "web", "envFrom": [{"configMapRef": {"name": "settings"}}],
+					"env": [{"name": "TOKEN", "valueFrom": {"secretKeyRef": {"name": "credentials", "key": "t"}}},
+						{"name": "OTHER", "valueFrom": {"configMapKeyRef": {"name": "shared", "key": "o"}}}]}],
+					"volumes": [{"name": "config", "configMap": {"name": "settings"}}]}}}}]}`,
+}
+
+func newCloneAPIServer(created *[]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" {
+			body, _ := ioutil.ReadAll(r.Body)
+			obj := make(map[string]interface{})
+			json.Unmarshal(body, &obj)
+			if strings.HasSuffix(r.URL.Path, "/services") {
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"kind": "Status", "apiVersion": "v1", "status": "Failure",
+					"reason": "AlreadyExists", "message": "services \"review-web\" already exists", "code": 409}`))
+				return
+			}
+			*created = append(*created, obj)
+			w.WriteHeader(http.StatusCreated)
+			w.Write(body)
+			return
+		}
+		response, ok := cloneAPIServerResponses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound",
+				"code": 404}`))
+			return
+		}
+		w.Write([]byte(response))
+	}))
+}
+
+func TestCloneNamespace(t *testing.T) {
+	created := make([]map[string]interface{}, 0)
+	server := newCloneAPIServer(&created)
+	defer server.Close()
+	config := &rest.Config{Host: server.URL}
+	discoveryClient := discovery.NewDiscoveryClientForConfigOrDie(config)
+	client := fake.NewSimpleClientset(&api.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "prod"}})
+
+	spec := &NamespaceCloneSpec{
+		Target:          "review",
+		IncludeSecrets:  true,
+		CreateNamespace: true,
+		NamePrefix:      "review-",
+		Labels:          map[string]string{"env": "review"},
+	}
+	result, err := CloneNamespace(client, discoveryClient, config, "prod", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCloned := []ClonedObject{
+		{Kind: "Secret", SourceName: "credentials", Name: "review-credentials"},
+		{Kind: "ConfigMap", SourceName: "settings", Name: "review-settings"},
+		{Kind: "Deployment", SourceName: "web", Name: "review-web"},
+	}
+	if !reflect.DeepEqual(result.Cloned, expectedCloned) || !result.NamespaceCreated {
+		t.Errorf("Got cloned objects %#v, expected %#v", result.Cloned, expectedCloned)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Kind != "Service" {
+		t.Errorf("Expected service to fail, got %#v", result.Failed)
+	}
+	if _, err := client.CoreV1().Namespaces().Get("review", metaV1.GetOptions{}); err != nil {
+		t.Errorf("Expected target namespace to be created: %v", err)
+	}
+
+	deployment := created[2]
+	metadata := deployment["metadata"].(map[string]interface{})
+	if metadata["namespace"] != "review" || !reflect.DeepEqual(metadata["labels"],
+		map[string]interface{}{"app": "web", "env": "review"}) {
+		t.Errorf("Got deployment metadata %#v", metadata)
+	}
+	template := getMap(deployment, "spec", "template")
+	if !reflect.DeepEqual(getMap(template, "metadata", "labels"), map[string]interface{}{"app": "web",
+		"env": "review"}) || !reflect.DeepEqual(getMap(deployment, "spec", "selector", "matchLabels"),
+		map[string]interface{}{"app": "web"}) {
+		t.Errorf("Got deployment template %#v", template)
+	}
+	container := getMaps(getMap(template, "spec"), "containers")[0]
+	env := getMaps(container, "env")
+	if getField(getMaps(container, "envFrom")[0], "configMapRef", "name") != "review-settings" ||
+		getField(env[0], "valueFrom", "secretKeyRef", "name") != "review-credentials" ||
+		getField(env[1], "valueFrom", "configMapKeyRef", "name") != "shared" ||
+		getField(getMaps(getMap(template, "spec"), "volumes")[0], "configMap", "name") != "review-settings" {
+		t.Errorf("References were not renamed in %#v", container)
+	}
+}
+
+func TestCloneNamespaceValidation(t *testing.T) {
+	cases := []*NamespaceCloneSpec{
+		{},
+		{Target: "prod"},
+		{Target: "review", Kinds: []string{"secrets"}},
+		// Target namespace does not exist.
+		{Target: "review", Kinds: []string{"ConfigMap"}},
+	}
+
+	for _, spec := range cases {
+		client := fake.NewSimpleClientset(&api.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "prod"}})
+		if _, err := CloneNamespace(client, nil, nil, "prod", spec); err == nil {
+			t.Errorf("Expected error when cloning with %#v", spec)
+		}
+	}
+}
+
+func TestRewriteClone(t *testing.T) {
+	renamed := map[string]map[string]string{
+		"Service":     {"db": "db-copy"},
+		"StatefulSet": {"db": "db-copy"},
+		"ConfigMap":   {"settings": "settings-copy"},
+	}
+	spec := &NamespaceCloneSpec{Target: "review"}
+
+	service := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":     "Service",
+		"metadata": map[string]interface{}{"name": "db", "namespace": "prod"},
+		"spec": map[string]interface{}{"ports": []interface{}{
+			map[string]interface{}{"port": int64(80), "nodePort": int64(30080)}}},
+	}}
+	rewriteClone(service, spec, renamed)
+	expectedPorts := []interface{}{map[string]interface{}{"port": int64(80)}}
+	if service.GetName() != "db-copy" || service.GetNamespace() != "review" ||
+		!reflect.DeepEqual(getField(service.Object, "spec", "ports"), expectedPorts) {
+		t.Errorf("Got service %#v", service.Object)
+	}
+
+	statefulSet := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":     "StatefulSet",
+		"metadata": map[string]interface{}{"name": "db"},
+		"spec": map[string]interface{}{"serviceName": "db", "template": map[string]interface{}{
+			"spec": map[string]interface{}{"volumes": []interface{}{map[string]interface{}{
+				"projected": map[string]interface{}{"sources": []interface{}{map[string]interface{}{
+					"configMap": map[string]interface{}{"name": "settings"}}}}}}}}},
+	}}
+	rewriteClone(statefulSet, spec, renamed)
+	source := getMaps(getMap(getMaps(getMap(statefulSet.Object, "spec", "template", "spec"), "volumes")[0],
+		"projected"), "sources")[0]
+	if getField(statefulSet.Object, "spec", "serviceName") != "db-copy" ||
+		getField(source, "configMap", "name") != "settings-copy" {
+		t.Errorf("Got stateful set %#v", statefulSet.Object)
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	client "k8s.io/client-go/kubernetes"
+	api "k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+	"k8s.io/client-go/rest"
+)
+
+// Names of the objects provisioned together with a namespace.
+const (
+	defaultQuotaName       = "default-quota"
+	defaultLimitRangeName  = "default-limits"
+	defaultDenyIngressName = "default-deny-ingress"
+)
+
+// NamespaceSpec is a specification of namespace to create. Apart from the name, all fields are
+// optional and describe objects provisioned in the namespace right after it is created.
+type NamespaceSpec struct {
+	// Name of the namespace.
+	Name string `json:"name"`
+
+	// Hard limits of the resource quota of the namespace, e.g. {"pods": "10", "requests.cpu": "4"}.
+	ResourceQuota map[string]string `json:"resourceQuota,omitempty"`
+
+	// Default resource requests and limits of containers in the namespace.
+	LimitRange *LimitRangeSpec `json:"limitRange,omitempty"`
+
+	// Whether to create network policy which denies all ingress traffic to pods in the namespace,
+	// unless allowed by other policies.
+	DefaultDenyIngress bool `json:"defaultDenyIngress,omitempty"`
+
+	// Bindings of cluster roles, e.g. admin, edit or view, to users, groups or service accounts in
+	// the namespace.
+	RoleBindings []RoleBindingSpec `json:"roleBindings,omitempty"`
+}
+
+// LimitRangeSpec describes default resources of containers, e.g. {"cpu": "100m"}.
+type LimitRangeSpec struct {
+	DefaultRequest map[string]string `json:"defaultRequest"`
+	Default        map[string]string `json:"default"`
+}
+
+// RoleBindingSpec binds a cluster role to subjects within the namespace.
+type RoleBindingSpec struct {
+	ClusterRole string        `json:"clusterRole"`
+	Subjects    []SubjectSpec `json:"subjects"`
+}
+
+// SubjectSpec is a user, group or service account. Namespace of a service account defaults to
+// the created namespace.
+type SubjectSpec struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// CreateNamespace creates namespace based on given specification, together with the objects
+// requested by the spec. All objects are validated before the namespace is created. When an
+// object cannot be created, the namespace is deleted, so that no partially provisioned namespace
+// is left behind.
+func CreateNamespace(spec *NamespaceSpec, client client.Interface) error {
+	return createNamespace(spec, client, client.ExtensionsV1beta1().RESTClient())
+}
+
+func createNamespace(spec *NamespaceSpec, client client.Interface, restClient RESTClient) error {
+	logger.Infof("Creating namespace %s", spec.Name)
+
+	objects, err := getNamespaceObjects(spec)
+	if err != nil {
+		return err
+	}
+
+	namespace := &api.Namespace{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name: spec.Name,
+		},
+	}
+
+	_, err = client.CoreV1().Namespaces().Create(namespace)
+	if err != nil {
+		return err
+	}
+
+	if err := objects.create(client, restClient, spec.Name); err != nil {
+		logger.Warningf("Rolling back creation of namespace %s: %v", spec.Name, err)
+		if deleteErr := client.CoreV1().Namespaces().Delete(spec.Name, &metaV1.DeleteOptions{}); deleteErr != nil {
+			logger.Errorf("Failed to delete namespace %s: %v", spec.Name, deleteErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// namespaceObjects are the objects provisioned in a new namespace.
+type namespaceObjects struct {
+	quota        *api.ResourceQuota
+	limitRange   *api.LimitRange
+	policy       *extensions.NetworkPolicy
+	roleBindings []*rbac.RoleBinding
+}
+
+func (o *namespaceObjects) create(client client.Interface, restClient RESTClient, namespace string) error {
+	if o.quota != nil {
+		if _, err := client.CoreV1().ResourceQuotas(namespace).Create(o.quota); err != nil {
+			return err
+		}
+	}
+	if o.limitRange != nil {
+		if _, err := client.CoreV1().LimitRanges(namespace).Create(o.limitRange); err != nil {
+			return err
+		}
+	}
+	if o.policy != nil {
+		if err := createNetworkPolicy(restClient, namespace, o.policy); err != nil {
+			return err
+		}
+	}
+	for _, binding := range o.roleBindings {
+		if _, err := client.RbacV1beta1().RoleBindings(namespace).Create(binding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Post() *rest.Request
+}
+
+// createNetworkPolicy creates network policy in the namespace. Typed client does not support
+// network policies yet, so the REST client of the extensions API group is used.
+func createNetworkPolicy(restClient RESTClient, namespace string, policy *extensions.NetworkPolicy) error {
+	return restClient.Post().
+		Namespace(namespace).
+		Resource("networkpolicies").
+		Body(policy).
+		Do().
+		Error()
+}
+
+// getNamespaceObjects builds objects requested by the spec, or returns bad request error
+// describing all problems with the spec.
+func getNamespaceObjects(spec *NamespaceSpec) (*namespaceObjects, error) {
+	var errs []string
+	objects := &namespaceObjects{}
+
+	if len(spec.ResourceQuota) > 0 {
+		hard, quotaErrs := toResourceList(spec.ResourceQuota, "resource quota")
+		errs = append(errs, quotaErrs...)
+		objects.quota = &api.ResourceQuota{
+			ObjectMeta: metaV1.ObjectMeta{Name: defaultQuotaName},
+			Spec:       api.ResourceQuotaSpec{Hard: hard},
+		}
+	}
+
+	if spec.LimitRange != nil {
+		defaultRequest, requestErrs := toResourceList(spec.LimitRange.DefaultRequest, "default request")
+		defaultLimit, limitErrs := toResourceList(spec.LimitRange.Default, "default limit")
+		errs = append(append(errs, requestErrs...), limitErrs...)
+		objects.limitRange = &api.LimitRange{
+			ObjectMeta: metaV1.ObjectMeta{Name: defaultLimitRangeName},
+			Spec: api.LimitRangeSpec{Limits: []api.LimitRangeItem{{
+				Type:           api.LimitTypeContainer,
+				DefaultRequest: defaultRequest,
+				Default:        defaultLimit,
+			}}},
+		}
+	}
+
+	if spec.DefaultDenyIngress {
+		objects.policy = &extensions.NetworkPolicy{
+			ObjectMeta: metaV1.ObjectMeta{Name: defaultDenyIngressName},
+			Spec:       extensions.NetworkPolicySpec{PodSelector: metaV1.LabelSelector{}},
+		}
+	}
+
+	for i, bindingSpec := range spec.RoleBindings {
+		binding, bindingErrs := toRoleBinding(spec.Name, i, bindingSpec)
+		errs = append(errs, bindingErrs...)
+		objects.roleBindings = append(objects.roleBindings, binding)
+	}
+
+	if len(errs) > 0 {
+		return nil, errorsK8s.NewBadRequest(strings.Join(errs, "; "))
+	}
+	return objects, nil
+}
+
+func toResourceList(values map[string]string, field string) (api.ResourceList, []string) {
+	var errs []string
+	result := api.ResourceList{}
+	for name, value := range values {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s %s: %v", field, name, err))
+			continue
+		}
+		result[api.ResourceName(name)] = quantity
+	}
+	return result, errs
+}
+
+func toRoleBinding(namespace string, index int, spec RoleBindingSpec) (*rbac.RoleBinding, []string) {
+	var errs []string
+	if spec.ClusterRole == "" {
+		errs = append(errs, fmt.Sprintf("role binding %d: cluster role is required", index))
+	}
+	if len(spec.Subjects) == 0 {
+		errs = append(errs, fmt.Sprintf("role binding %d: at least one subject is required", index))
+	}
+
+	binding := &rbac.RoleBinding{
+		ObjectMeta: metaV1.ObjectMeta{Name: fmt.Sprintf("%s-%d", spec.ClusterRole, index)},
+		RoleRef: rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     "ClusterRole",
+			Name:     spec.ClusterRole,
+		},
+	}
+	for _, subjectSpec := range spec.Subjects {
+		subject := rbac.Subject{Kind: subjectSpec.Kind, Name: subjectSpec.Name}
+		switch subjectSpec.Kind {
+		case rbac.UserKind, rbac.GroupKind:
+			subject.APIGroup = rbac.GroupName
+		case rbac.ServiceAccountKind:
+			subject.Namespace = subjectSpec.Namespace
+			if subject.Namespace == "" {
+				subject.Namespace = namespace
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("role binding %d: unknown subject kind %q", index,
+				subjectSpec.Kind))
+		}
+		if subjectSpec.Name == "" {
+			errs = append(errs, fmt.Sprintf("role binding %d: subject name is required", index))
+		}
+		binding.Subjects = append(binding.Subjects, subject)
+	}
+	for _, msg := range validation.IsDNS1123Subdomain(binding.Name) {
+		errs = append(errs, fmt.Sprintf("role binding %d: %s", index, msg))
+	}
+	return binding, errs
+}
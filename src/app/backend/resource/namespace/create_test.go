@@ -0,0 +1,159 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	api "k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+	"k8s.io/client-go/rest"
+	core "k8s.io/client-go/testing"
+)
+
+func newTestNamespaceSpec() *NamespaceSpec {
+	return &NamespaceSpec{
+		Name:          "team-a",
+		ResourceQuota: map[string]string{"pods": "10"},
+		LimitRange: &LimitRangeSpec{
+			DefaultRequest: map[string]string{"cpu": "100m"},
+			Default:        map[string]string{"memory": "256Mi"},
+		},
+		DefaultDenyIngress: true,
+		RoleBindings: []RoleBindingSpec{{
+			ClusterRole: "edit",
+			Subjects:    []SubjectSpec{{Kind: "Group", Name: "team-a"}, {Kind: "ServiceAccount", Name: "ci"}},
+		}},
+	}
+}
+
+// newNetworkPolicyClient returns client of extensions API group, which creates network policies
+// and keeps paths of the created ones.
+func newNetworkPolicyClient(t *testing.T) (RESTClient, *[]string, func()) {
+	created := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		policy := &extensions.NetworkPolicy{}
+		if err := json.NewDecoder(r.Body).Decode(policy); err != nil || r.Method != http.MethodPost {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		created = append(created, r.URL.Path+"/"+policy.Name)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(policy)
+	}))
+	k8sClient, err := client.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return k8sClient.ExtensionsV1beta1().RESTClient(), &created, server.Close
+}
+
+func getCreatedResources(client *fake.Clientset) []string {
+	var resources []string
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "create" {
+			resources = append(resources, action.GetResource().Resource)
+		}
+	}
+	return resources
+}
+
+func TestCreateNamespace(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	restClient, policies, closeServer := newNetworkPolicyClient(t)
+	defer closeServer()
+
+	if err := createNamespace(newTestNamespaceSpec(), client, restClient); err != nil {
+		t.Fatalf("CreateNamespace(): unexpected error %v", err)
+	}
+
+	expected := []string{"namespaces", "resourcequotas", "limitranges", "rolebindings"}
+	if actual := getCreatedResources(client); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected created resources %v but got %v", expected, actual)
+	}
+	expectedPolicies := []string{"/apis/extensions/v1beta1/namespaces/team-a/networkpolicies/" +
+		defaultDenyIngressName}
+	if !reflect.DeepEqual(*policies, expectedPolicies) {
+		t.Errorf("Expected created network policies %v but got %v", expectedPolicies, *policies)
+	}
+
+	quota, _ := client.CoreV1().ResourceQuotas("team-a").Get(defaultQuotaName, metaV1.GetOptions{})
+	if !reflect.DeepEqual(quota.Spec.Hard, api.ResourceList{api.ResourcePods: resource.MustParse("10")}) {
+		t.Errorf("Unexpected quota %#v", quota.Spec.Hard)
+	}
+
+	binding, _ := client.RbacV1beta1().RoleBindings("team-a").Get("edit-0", metaV1.GetOptions{})
+	expectedSubjects := []rbac.Subject{
+		{Kind: "Group", APIGroup: rbac.GroupName, Name: "team-a"},
+		{Kind: "ServiceAccount", Name: "ci", Namespace: "team-a"},
+	}
+	if binding.RoleRef.Name != "edit" || !reflect.DeepEqual(binding.Subjects, expectedSubjects) {
+		t.Errorf("Unexpected role binding %#v", binding)
+	}
+}
+
+func TestCreateNamespaceShouldRollBack(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	restClient, _, closeServer := newNetworkPolicyClient(t)
+	defer closeServer()
+	client.PrependReactor("create", "rolebindings", func(core.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("forbidden")
+	})
+
+	err := createNamespace(newTestNamespaceSpec(), client, restClient)
+
+	if err == nil || err.Error() != "forbidden" {
+		t.Errorf("Expected error from role binding creation but got %v", err)
+	}
+	if _, err := client.CoreV1().Namespaces().Get("team-a", metaV1.GetOptions{}); !errorsK8s.IsNotFound(err) {
+		t.Errorf("Expected namespace to be deleted but got %v", err)
+	}
+}
+
+func TestCreateNamespaceShouldValidateBeforeCreating(t *testing.T) {
+	cases := []*NamespaceSpec{
+		{Name: "foo", ResourceQuota: map[string]string{"pods": "ten"}},
+		{Name: "foo", LimitRange: &LimitRangeSpec{Default: map[string]string{"cpu": "x"}}},
+		{Name: "foo", RoleBindings: []RoleBindingSpec{{ClusterRole: "view"}}},
+		{Name: "foo", RoleBindings: []RoleBindingSpec{{Subjects: []SubjectSpec{{Kind: "User", Name: "a"}}}}},
+		{Name: "foo", RoleBindings: []RoleBindingSpec{
+			{ClusterRole: "view", Subjects: []SubjectSpec{{Kind: "Robot", Name: "a"}}}}},
+	}
+	for _, c := range cases {
+		client := fake.NewSimpleClientset()
+
+		err := CreateNamespace(c, client)
+
+		if !errorsK8s.IsBadRequest(err) {
+			t.Errorf("CreateNamespace(%#v): expected bad request but got %v", c, err)
+		}
+		if len(client.Actions()) != 0 {
+			t.Errorf("CreateNamespace(%#v): expected no actions but got %v", c, client.Actions())
+		}
+	}
+}
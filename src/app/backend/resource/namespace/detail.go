@@ -15,14 +15,14 @@
 package namespace
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/limitrange"
+	pdb "github.com/kubernetes/dashboard/src/app/backend/resource/poddisruptionbudget"
 	rq "github.com/kubernetes/dashboard/src/app/backend/resource/resourcequota"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -54,13 +54,16 @@ type NamespaceDetail struct {
 	// ResourceLimits is list of limit ranges associated to the namespace
 	ResourceLimits []limitrange.LimitRangeItem `json:"resourceLimits"`
 
+	// PodDisruptionBudgetList is list of pod disruption budgets in the namespace
+	PodDisruptionBudgetList *pdb.PodDisruptionBudgetList `json:"podDisruptionBudgetList"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
 
 // GetNamespaceDetail gets namespace details.
 func GetNamespaceDetail(client k8sClient.Interface, name string) (*NamespaceDetail, error) {
-	log.Printf("Getting details of %s namespace\n", name)
+	logger.Infof("Getting details of %s namespace\n", name)
 
 	namespace, err := client.CoreV1().Namespaces().Get(name, metaV1.GetOptions{})
 	if err != nil {
@@ -85,38 +88,41 @@ func GetNamespaceDetail(client k8sClient.Interface, name string) (*NamespaceDeta
 		return nil, criticalError
 	}
 
-	namespaceDetails := toNamespaceDetail(*namespace, events, resourceQuotaList, resourceLimits, nonCriticalErrors)
+	podDisruptionBudgetList, err := pdb.GetPodDisruptionBudgetList(client,
+		common.NewSameNamespaceQuery(namespace.Name), dataselect.DefaultDataSelect)
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	namespaceDetails := toNamespaceDetail(*namespace, events, resourceQuotaList, resourceLimits,
+		podDisruptionBudgetList, nonCriticalErrors)
 	return &namespaceDetails, nil
 }
 
 func toNamespaceDetail(namespace v1.Namespace, events common.EventList, resourceQuotaList *rq.ResourceQuotaDetailList,
-	resourceLimits []limitrange.LimitRangeItem, nonCriticalErrors []error) NamespaceDetail {
+	resourceLimits []limitrange.LimitRangeItem, podDisruptionBudgetList *pdb.PodDisruptionBudgetList,
+	nonCriticalErrors []error) NamespaceDetail {
 
 	return NamespaceDetail{
-		ObjectMeta:        api.NewObjectMeta(namespace.ObjectMeta),
-		TypeMeta:          api.NewTypeMeta(api.ResourceKindNamespace),
-		Phase:             namespace.Status.Phase,
-		EventList:         events,
-		ResourceQuotaList: resourceQuotaList,
-		ResourceLimits:    resourceLimits,
-		Errors:            nonCriticalErrors,
+		ObjectMeta:              api.NewObjectMeta(namespace.ObjectMeta),
+		TypeMeta:                api.NewTypeMeta(api.ResourceKindNamespace),
+		Phase:                   namespace.Status.Phase,
+		EventList:               events,
+		ResourceQuotaList:       resourceQuotaList,
+		ResourceLimits:          resourceLimits,
+		PodDisruptionBudgetList: podDisruptionBudgetList,
+		Errors:                  nonCriticalErrors,
 	}
 }
 
 func getResourceQuotas(client k8sClient.Interface, namespace v1.Namespace) (*rq.ResourceQuotaDetailList, error) {
 	list, err := client.CoreV1().ResourceQuotas(namespace.Name).List(listEverything)
-
-	result := &rq.ResourceQuotaDetailList{
-		Items:    make([]rq.ResourceQuotaDetail, 0),
-		ListMeta: api.ListMeta{TotalItems: len(list.Items)},
-	}
-
-	for _, item := range list.Items {
-		detail := rq.ToResourceQuotaDetail(&item)
-		result.Items = append(result.Items, *detail)
+	if err != nil {
+		return nil, err
 	}
 
-	return result, err
+	return rq.ToResourceQuotaDetailList(list.Items, []error{}, dataselect.NoDataSelect), nil
 }
 
 func getLimitRanges(client k8sClient.Interface, namespace v1.Namespace) ([]limitrange.LimitRangeItem, error) {
@@ -15,15 +15,11 @@
 package namespace
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/fields"
-	"k8s.io/apimachinery/pkg/labels"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
 )
@@ -64,19 +60,21 @@ func GetNamespaceListFromChannels(channels *common.ResourceChannels, dsQuery *da
 
 // GetNamespaceList returns a list of all namespaces in the cluster.
 func GetNamespaceList(client *client.Clientset, dsQuery *dataselect.DataSelectQuery) (*NamespaceList, error) {
-	log.Println("Getting list of namespaces")
+	logger.Info("Getting list of namespaces")
 
-	namespaces, err := client.Namespaces().List(metaV1.ListOptions{
-		LabelSelector: labels.Everything().String(),
-		FieldSelector: fields.Everything().String(),
-	})
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
+	channel := common.GetNamespaceListChunkChannel(client, chunk, 1)
+	namespaces := <-channel.List
+	err := <-channel.Error
 
 	nonCriticalErrors, criticalError := errors.HandleError(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return toNamespaceList(namespaces.Items, nonCriticalErrors, dsQuery), nil
+	namespaceList := toNamespaceList(namespaces.Items, nonCriticalErrors, dsQuery)
+	chunk.SetListMeta(&namespaceList.ListMeta)
+	return namespaceList, nil
 }
 
 func toNamespaceList(namespaces []v1.Namespace, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery) *NamespaceList {
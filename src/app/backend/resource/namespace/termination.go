@@ -0,0 +1,277 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/accessreview"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	client "k8s.io/client-go/kubernetes"
+	api "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+// NamespaceTermination describes progress of namespace deletion and the usual causes of a
+// namespace stuck in the terminating phase: objects with finalizers nobody removes and API
+// services that are unavailable, so that the namespace controller cannot delete their content.
+type NamespaceTermination struct {
+	Name              string             `json:"name"`
+	Phase             api.NamespacePhase `json:"phase"`
+	DeletionTimestamp *metaV1.Time       `json:"deletionTimestamp"`
+
+	// Finalizers of the namespace. They are removed by the namespace controller once all content
+	// of the namespace is deleted.
+	Finalizers []api.FinalizerName `json:"finalizers"`
+
+	// Objects remaining in the namespace, grouped by resource.
+	RemainingResources []RemainingResource `json:"remainingResources"`
+
+	// Finalizers of remaining objects, with number of objects waiting for each of them.
+	BlockingFinalizers []BlockingFinalizer `json:"blockingFinalizers"`
+
+	// API group versions or resources whose discovery or listing failed.
+	UnavailableAPIs []UnavailableAPI `json:"unavailableApis"`
+}
+
+// RemainingResource lists objects of a single resource remaining in the namespace.
+type RemainingResource struct {
+	GroupVersion string            `json:"groupVersion"`
+	Resource     string            `json:"resource"`
+	Kind         string            `json:"kind"`
+	Objects      []RemainingObject `json:"objects"`
+}
+
+// RemainingObject is an object remaining in the namespace.
+type RemainingObject struct {
+	Name              string       `json:"name"`
+	Finalizers        []string     `json:"finalizers"`
+	DeletionTimestamp *metaV1.Time `json:"deletionTimestamp"`
+}
+
+// BlockingFinalizer is a finalizer on remaining objects.
+type BlockingFinalizer struct {
+	Name    string `json:"name"`
+	Objects int    `json:"objects"`
+}
+
+// UnavailableAPI is a group version, or a resource in it, that could not be read.
+type UnavailableAPI struct {
+	GroupVersion string `json:"groupVersion"`
+	Resource     string `json:"resource,omitempty"`
+	Error        string `json:"error"`
+}
+
+// GetNamespaceTermination returns deletion progress of the namespace. Content of the namespace is
+// found with discovery, so that objects of custom resources are included as well.
+func GetNamespaceTermination(client client.Interface, config *rest.Config, name string) (
+	*NamespaceTermination, error) {
+	logger.Infof("Getting termination status of namespace %s", name)
+
+	namespace, err := client.CoreV1().Namespaces().Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	resources, unavailable, err := getRemainingResources(client, config, name)
+	if err != nil {
+		return nil, err
+	}
+	return toNamespaceTermination(namespace, resources, unavailable), nil
+}
+
+// ClearFinalizers removes finalizers of all objects remaining in a terminating namespace. When
+// some API services are unavailable, finalizers of the namespace itself are removed as well,
+// since the namespace controller cannot finish the deletion. This leaves behind whatever the
+// finalizers were supposed to clean up, so it is allowed only to cluster admins.
+func ClearFinalizers(client client.Interface, config *rest.Config, name string) (
+	*NamespaceTermination, error) {
+	namespace, err := client.CoreV1().Namespaces().Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if namespace.Status.Phase != api.NamespaceTerminating {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("namespace %s is not terminating", name))
+	}
+
+	review := accessreview.ReviewAction(client,
+		accessreview.ResourceAction{Verb: "*", Group: "*", Resource: "*"})
+	if !review.Allowed {
+		return nil, errorsK8s.NewForbidden(schema.GroupResource{Resource: "namespaces"}, name,
+			errors.New("clearing finalizers requires cluster admin access"))
+	}
+
+	logger.Infof("Clearing finalizers in namespace %s", name)
+	resources, unavailable, err := getRemainingResources(client, config, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []string
+	for _, resource := range resources {
+		gv, err := schema.ParseGroupVersion(resource.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		restClient, err := apply.NewRESTClient(config, gv)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range resource.Objects {
+			if len(object.Finalizers) == 0 {
+				continue
+			}
+			err := restClient.Patch(types.MergePatchType).
+				Namespace(name).
+				Resource(resource.Resource).
+				Name(object.Name).
+				Body([]byte(`{"metadata":{"finalizers":null}}`)).
+				Do().
+				Error()
+			if err != nil && !errorsK8s.IsNotFound(err) {
+				errs = append(errs, fmt.Sprintf("%s %s: %v", resource.Resource, object.Name, err))
+			}
+		}
+	}
+
+	if len(unavailable) > 0 && len(namespace.Spec.Finalizers) > 0 {
+		namespace.Spec.Finalizers = nil
+		if _, err := client.CoreV1().Namespaces().Finalize(namespace); err != nil {
+			errs = append(errs, fmt.Sprintf("namespace %s: %v", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to clear finalizers: %s", strings.Join(errs, "; "))
+	}
+
+	termination, err := GetNamespaceTermination(client, config, name)
+	if errorsK8s.IsNotFound(err) {
+		// Namespace has been deleted right after finalizers were cleared.
+		return &NamespaceTermination{Name: name, RemainingResources: []RemainingResource{},
+			BlockingFinalizers: []BlockingFinalizer{}, UnavailableAPIs: []UnavailableAPI{}}, nil
+	}
+	return termination, err
+}
+
+// getRemainingResources lists objects of all namespaced resources in preferred versions of the
+// API groups served by the cluster.
+func getRemainingResources(client client.Interface, config *rest.Config, namespace string) (
+	[]RemainingResource, []UnavailableAPI, error) {
+	groups, err := client.Discovery().ServerGroups()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources := []RemainingResource{}
+	unavailable := []UnavailableAPI{}
+	for _, group := range groups.Groups {
+		groupVersion := group.PreferredVersion.GroupVersion
+		list, err := client.Discovery().ServerResourcesForGroupVersion(groupVersion)
+		if err != nil {
+			unavailable = append(unavailable, UnavailableAPI{GroupVersion: groupVersion, Error: err.Error()})
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(groupVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+		restClient, err := apply.NewRESTClient(config, gv)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, apiResource := range list.APIResources {
+			// Skip subresources, e.g. deployments/scale, and resources that cannot be listed.
+			if !apiResource.Namespaced || strings.Contains(apiResource.Name, "/") ||
+				!hasVerb(apiResource.Verbs, "list") {
+				continue
+			}
+			objects := &unstructured.UnstructuredList{}
+			err := restClient.Get().Namespace(namespace).Resource(apiResource.Name).Do().Into(objects)
+			if err != nil {
+				unavailable = append(unavailable, UnavailableAPI{GroupVersion: groupVersion,
+					Resource: apiResource.Name, Error: err.Error()})
+				continue
+			}
+			if len(objects.Items) > 0 {
+				resources = append(resources, toRemainingResource(groupVersion, apiResource, objects))
+			}
+		}
+	}
+	return resources, unavailable, nil
+}
+
+func toRemainingResource(groupVersion string, apiResource metaV1.APIResource,
+	objects *unstructured.UnstructuredList) RemainingResource {
+	resource := RemainingResource{
+		GroupVersion: groupVersion,
+		Resource:     apiResource.Name,
+		Kind:         apiResource.Kind,
+		Objects:      []RemainingObject{},
+	}
+	for _, object := range objects.Items {
+		resource.Objects = append(resource.Objects, RemainingObject{
+			Name:              object.GetName(),
+			Finalizers:        object.GetFinalizers(),
+			DeletionTimestamp: object.GetDeletionTimestamp(),
+		})
+	}
+	return resource
+}
+
+func toNamespaceTermination(namespace *api.Namespace, resources []RemainingResource,
+	unavailable []UnavailableAPI) *NamespaceTermination {
+	counts := map[string]int{}
+	for _, resource := range resources {
+		for _, object := range resource.Objects {
+			for _, finalizer := range object.Finalizers {
+				counts[finalizer]++
+			}
+		}
+	}
+	blocking := []BlockingFinalizer{}
+	for name, count := range counts {
+		blocking = append(blocking, BlockingFinalizer{Name: name, Objects: count})
+	}
+	sort.Slice(blocking, func(i, j int) bool { return blocking[i].Name < blocking[j].Name })
+
+	return &NamespaceTermination{
+		Name:               namespace.Name,
+		Phase:              namespace.Status.Phase,
+		DeletionTimestamp:  namespace.DeletionTimestamp,
+		Finalizers:         namespace.Spec.Finalizers,
+		RemainingResources: resources,
+		BlockingFinalizers: blocking,
+		UnavailableAPIs:    unavailable,
+	}
+}
+
+func hasVerb(verbs metaV1.Verbs, verb string) bool {
+	for _, v := range verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"reflect"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	api "k8s.io/client-go/pkg/api/v1"
+	authorization "k8s.io/client-go/pkg/apis/authorization/v1"
+	"k8s.io/client-go/rest"
+	core "k8s.io/client-go/testing"
+)
+
+func newTestObject(name string, finalizers ...string) unstructured.Unstructured {
+	object := unstructured.Unstructured{Object: map[string]interface{}{}}
+	object.SetName(name)
+	object.SetFinalizers(finalizers)
+	return object
+}
+
+func TestToNamespaceTermination(t *testing.T) {
+	namespace := &api.Namespace{
+		ObjectMeta: metaV1.ObjectMeta{Name: "team-a"},
+		Spec:       api.NamespaceSpec{Finalizers: []api.FinalizerName{api.FinalizerKubernetes}},
+		Status:     api.NamespaceStatus{Phase: api.NamespaceTerminating},
+	}
+	pvcs := toRemainingResource("v1", metaV1.APIResource{Name: "persistentvolumeclaims",
+		Kind: "PersistentVolumeClaim"}, &unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+		newTestObject("data-0", "kubernetes.io/pvc-protection"),
+		newTestObject("data-1", "kubernetes.io/pvc-protection"),
+	}})
+	backups := toRemainingResource("example.com/v1", metaV1.APIResource{Name: "backups", Kind: "Backup"},
+		&unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+			newTestObject("nightly", "example.com/cleanup", "kubernetes.io/pvc-protection"),
+			newTestObject("weekly"),
+		}})
+	unavailable := []UnavailableAPI{{GroupVersion: "metrics.example.com/v1", Error: "service unavailable"}}
+
+	actual := toNamespaceTermination(namespace, []RemainingResource{pvcs, backups}, unavailable)
+
+	expected := &NamespaceTermination{
+		Name:               "team-a",
+		Phase:              api.NamespaceTerminating,
+		Finalizers:         []api.FinalizerName{api.FinalizerKubernetes},
+		RemainingResources: []RemainingResource{pvcs, backups},
+		BlockingFinalizers: []BlockingFinalizer{
+			{Name: "example.com/cleanup", Objects: 1},
+			{Name: "kubernetes.io/pvc-protection", Objects: 3},
+		},
+		UnavailableAPIs: unavailable,
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("toNamespaceTermination() ==\n%#v\nexpected\n%#v", actual, expected)
+	}
+	if len(backups.Objects) != 2 || backups.Objects[1].Name != "weekly" || len(backups.Objects[1].Finalizers) != 0 {
+		t.Errorf("Unexpected remaining objects %#v", backups.Objects)
+	}
+}
+
+func TestClearFinalizersShouldRequireTerminatingNamespace(t *testing.T) {
+	client := fake.NewSimpleClientset(&api.Namespace{
+		ObjectMeta: metaV1.ObjectMeta{Name: "team-a"},
+		Status:     api.NamespaceStatus{Phase: api.NamespaceActive},
+	})
+
+	_, err := ClearFinalizers(client, &rest.Config{}, "team-a")
+
+	if !errorsK8s.IsBadRequest(err) {
+		t.Errorf("Expected bad request error but got %v", err)
+	}
+}
+
+func TestClearFinalizersShouldRequireClusterAdmin(t *testing.T) {
+	client := fake.NewSimpleClientset(&api.Namespace{
+		ObjectMeta: metaV1.ObjectMeta{Name: "team-a"},
+		Status:     api.NamespaceStatus{Phase: api.NamespaceTerminating},
+	})
+	var reviewed *authorization.ResourceAttributes
+	client.PrependReactor("create", "selfsubjectaccessreviews",
+		func(action core.Action) (bool, runtime.Object, error) {
+			review := action.(core.CreateAction).GetObject().(*authorization.SelfSubjectAccessReview)
+			reviewed = review.Spec.ResourceAttributes
+			review.Status.Allowed = false
+			return true, review, nil
+		})
+
+	_, err := ClearFinalizers(client, &rest.Config{}, "team-a")
+
+	if !errorsK8s.IsForbidden(err) {
+		t.Errorf("Expected forbidden error but got %v", err)
+	}
+	expected := &authorization.ResourceAttributes{Verb: "*", Group: "*", Resource: "*"}
+	if !reflect.DeepEqual(reviewed, expected) {
+		t.Errorf("Expected review of %#v but got %#v", expected, reviewed)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+// NetworkPolicyDetail is a presentation layer view of Kubernetes Network Policy.
+type NetworkPolicyDetail struct {
+	NetworkPolicy `json:",inline"`
+
+	// Ingress rules of the policy. Connection to selected pods is allowed when any rule allows
+	// it, no rules mean that selected pods do not accept any connections.
+	Ingress []extensions.NetworkPolicyIngressRule `json:"ingress"`
+
+	// Names of pods selected by the policy.
+	Pods []string `json:"pods"`
+}
+
+// GetNetworkPolicyDetail returns detailed information about a network policy.
+func GetNetworkPolicyDetail(client client.Interface, namespace, name string) (*NetworkPolicyDetail, error) {
+	logger.Infof("Getting details of %s network policy in %s namespace", name, namespace)
+
+	policy := new(extensions.NetworkPolicy)
+	err := client.ExtensionsV1beta1().RESTClient().Get().
+		Namespace(namespace).
+		Resource("networkpolicies").
+		Name(name).
+		Do().
+		Into(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &NetworkPolicyDetail{
+		NetworkPolicy: toNetworkPolicy(policy),
+		Ingress:       policy.Spec.Ingress,
+		Pods:          make([]string, 0),
+	}
+	if detail.Ingress == nil {
+		detail.Ingress = make([]extensions.NetworkPolicyIngressRule, 0)
+	}
+	for _, pod := range pods.Items {
+		if selectsPod(policy, &pod) {
+			detail.Pods = append(detail.Pods, pod.Name)
+		}
+	}
+
+	return detail, nil
+}
@@ -0,0 +1,475 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+// Kinds of network graph nodes.
+const (
+	// NodeKindPod is a single pod.
+	NodeKindPod = "pod"
+	// NodeKindNamespaces are all pods in namespaces matched by a namespace selector.
+	NodeKindNamespaces = "namespaces"
+	// NodeKindAny is any source or destination, including traffic from outside of the cluster.
+	NodeKindAny = "any"
+)
+
+// anyNodeID is the id of the node representing any peer.
+const anyNodeID = "any"
+
+// allPorts is used when a rule allows connections to all ports.
+const allPorts = "all"
+
+// NetworkGraph describes which pods can connect to each other, so that the frontend can render
+// it as a graph. Network policies in this API version only restrict ingress traffic, so egress
+// of a pod is determined by ingress policies of its destinations.
+type NetworkGraph struct {
+	Nodes []NetworkGraphNode `json:"nodes"`
+	Edges []NetworkGraphEdge `json:"edges"`
+
+	// List of non-critical errors, that occurred during resource retrieval. The graph contains
+	// only peers from namespaces, that the user can read.
+	Errors []error `json:"errors"`
+}
+
+// NetworkGraphNode is a pod or a group of peers.
+type NetworkGraphNode struct {
+	ID        string            `json:"id"`
+	Kind      string            `json:"kind"`
+	Namespace string            `json:"namespace,omitempty"`
+	Name      string            `json:"name,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+
+	// Isolated pods are selected by at least one network policy and accept only connections
+	// allowed by these policies.
+	Isolated bool `json:"isolated"`
+
+	// Policies selecting the pod.
+	Policies []string `json:"policies,omitempty"`
+
+	// Selector and names of matching namespaces of namespaces nodes.
+	NamespaceSelector string   `json:"namespaceSelector,omitempty"`
+	Namespaces        []string `json:"namespaces,omitempty"`
+}
+
+// NetworkGraphEdge is an allowed connection from one node to another.
+type NetworkGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	// Policy allowing the connection, empty when the destination is not isolated.
+	Policy string `json:"policy,omitempty"`
+
+	// Allowed ports in protocol/port format, or "all".
+	Ports []string `json:"ports"`
+}
+
+// GetNamespaceNetworkGraph returns graph of connections allowed to pods in the namespace.
+func GetNamespaceNetworkGraph(client client.Interface, namespace string) (*NetworkGraph, error) {
+	logger.Infof("Getting network graph of %s namespace", namespace)
+
+	pods, err := client.CoreV1().Pods(namespace).List(metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := getNetworkPolicies(client, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces, nonCriticalErrors, err := getReadableNamespaces(client, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := buildNamespaceGraph(pods.Items, policies, namespaces)
+	graph.Errors = nonCriticalErrors
+	return graph, nil
+}
+
+// GetPodNetworkGraph returns graph of connections allowed to and from the pod. Users, that cannot
+// list pods and network policies in all namespaces, get a graph of namespaces they can read.
+func GetPodNetworkGraph(client client.Interface, namespace, name string) (*NetworkGraph, error) {
+	logger.Infof("Getting network graph of %s pod in %s namespace", name, namespace)
+
+	pod, err := client.CoreV1().Pods(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces, nonCriticalErrors, err := getReadableNamespaces(client, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, podErrors, err := getReadablePods(client, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	policies, policyErrors, err := getReadableNetworkPolicies(client, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := buildPodGraph(pod, pods, policies, namespaces)
+	graph.Errors = errors.MergeErrors(nonCriticalErrors, podErrors, policyErrors)
+	return graph, nil
+}
+
+// getReadableNamespaces returns all namespaces or, when the user cannot list them, only the given
+// namespace. Labels of the namespace are known only if the user can get it.
+func getReadableNamespaces(client client.Interface, namespace string) ([]v1.Namespace, []error,
+	error) {
+	list, err := client.CoreV1().Namespaces().List(metaV1.ListOptions{})
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+	if err == nil {
+		return list.Items, nonCriticalErrors, nil
+	}
+
+	ns, err := client.CoreV1().Namespaces().Get(namespace, metaV1.GetOptions{})
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+	if err != nil {
+		return []v1.Namespace{{ObjectMeta: metaV1.ObjectMeta{Name: namespace}}}, nonCriticalErrors,
+			nil
+	}
+	return []v1.Namespace{*ns}, nonCriticalErrors, nil
+}
+
+// getReadablePods returns pods from all namespaces or, when the user cannot list them, pods from
+// the given namespaces, that the user can read.
+func getReadablePods(client client.Interface, namespaces []v1.Namespace) ([]v1.Pod, []error,
+	error) {
+	list, err := client.CoreV1().Pods(v1.NamespaceAll).List(metaV1.ListOptions{})
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+	if err == nil {
+		return list.Items, nonCriticalErrors, nil
+	}
+
+	pods := make([]v1.Pod, 0)
+	for _, namespace := range namespaces {
+		list, err := client.CoreV1().Pods(namespace.Name).List(metaV1.ListOptions{})
+		nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+		if criticalError != nil {
+			return nil, nil, criticalError
+		}
+		if err == nil {
+			pods = append(pods, list.Items...)
+		}
+	}
+	return pods, nonCriticalErrors, nil
+}
+
+// getReadableNetworkPolicies returns network policies from all namespaces or, when the user cannot
+// list them, network policies from the given namespaces, that the user can read.
+func getReadableNetworkPolicies(client client.Interface, namespaces []v1.Namespace) (
+	[]extensions.NetworkPolicy, []error, error) {
+	policies, err := getNetworkPolicies(client, v1.NamespaceAll)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, nil, criticalError
+	}
+	if err == nil {
+		return policies, nonCriticalErrors, nil
+	}
+
+	policies = make([]extensions.NetworkPolicy, 0)
+	for _, namespace := range namespaces {
+		list, err := getNetworkPolicies(client, namespace.Name)
+		nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+		if criticalError != nil {
+			return nil, nil, criticalError
+		}
+		policies = append(policies, list...)
+	}
+	return policies, nonCriticalErrors, nil
+}
+
+// buildNamespaceGraph returns graph of ingress connections to all given pods.
+func buildNamespaceGraph(pods []v1.Pod, policies []extensions.NetworkPolicy,
+	namespaces []v1.Namespace) *NetworkGraph {
+	builder := newGraphBuilder(policies, namespaces)
+	for i := range pods {
+		builder.addPod(&pods[i])
+	}
+	for i := range pods {
+		builder.addIngress(&pods[i], pods)
+	}
+	return builder.graph
+}
+
+// buildPodGraph returns graph of ingress connections to the pod and egress connections from it.
+func buildPodGraph(pod *v1.Pod, pods []v1.Pod, policies []extensions.NetworkPolicy,
+	namespaces []v1.Namespace) *NetworkGraph {
+	builder := newGraphBuilder(policies, namespaces)
+	builder.addPod(pod)
+	builder.addIngress(pod, pods)
+	builder.addEgress(pod, pods)
+	return builder.graph
+}
+
+// graphBuilder adds nodes and edges to the network graph, so that every node is added once.
+type graphBuilder struct {
+	graph           *NetworkGraph
+	nodes           map[string]bool
+	policies        []extensions.NetworkPolicy
+	namespaceLabels map[string]map[string]string
+}
+
+func newGraphBuilder(policies []extensions.NetworkPolicy, namespaces []v1.Namespace) *graphBuilder {
+	namespaceLabels := make(map[string]map[string]string)
+	for _, namespace := range namespaces {
+		namespaceLabels[namespace.Name] = namespace.Labels
+	}
+
+	return &graphBuilder{
+		graph: &NetworkGraph{
+			Nodes: make([]NetworkGraphNode, 0),
+			Edges: make([]NetworkGraphEdge, 0),
+		},
+		nodes:           make(map[string]bool),
+		policies:        policies,
+		namespaceLabels: namespaceLabels,
+	}
+}
+
+func getPodNodeID(pod *v1.Pod) string {
+	return fmt.Sprintf("%s:%s/%s", NodeKindPod, pod.Namespace, pod.Name)
+}
+
+func (self *graphBuilder) addNode(node NetworkGraphNode) {
+	if self.nodes[node.ID] {
+		return
+	}
+	self.nodes[node.ID] = true
+	self.graph.Nodes = append(self.graph.Nodes, node)
+}
+
+func (self *graphBuilder) addPod(pod *v1.Pod) string {
+	policies := self.getSelectingPolicies(pod)
+	names := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		names = append(names, policy.Name)
+	}
+
+	node := NetworkGraphNode{
+		ID:        getPodNodeID(pod),
+		Kind:      NodeKindPod,
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		Labels:    pod.Labels,
+		Isolated:  len(policies) > 0,
+	}
+	if len(names) > 0 {
+		node.Policies = names
+	}
+	self.addNode(node)
+	return node.ID
+}
+
+func (self *graphBuilder) addAny() string {
+	self.addNode(NetworkGraphNode{ID: anyNodeID, Kind: NodeKindAny})
+	return anyNodeID
+}
+
+func (self *graphBuilder) addNamespaces(selector *metaV1.LabelSelector) string {
+	selectorString := metaV1.FormatLabelSelector(selector)
+	id := fmt.Sprintf("%s:%s", NodeKindNamespaces, selectorString)
+
+	names := make([]string, 0)
+	for name := range self.namespaceLabels {
+		if self.namespaceMatches(selector, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	self.addNode(NetworkGraphNode{
+		ID:                id,
+		Kind:              NodeKindNamespaces,
+		NamespaceSelector: selectorString,
+		Namespaces:        names,
+	})
+	return id
+}
+
+func (self *graphBuilder) addEdge(from, to, policy string, ports []string) {
+	self.graph.Edges = append(self.graph.Edges, NetworkGraphEdge{
+		From:   from,
+		To:     to,
+		Policy: policy,
+		Ports:  ports,
+	})
+}
+
+// addIngress adds edges from all peers allowed to connect to the pod. Pods matched by pod
+// selectors are looked up in candidates.
+func (self *graphBuilder) addIngress(pod *v1.Pod, candidates []v1.Pod) {
+	target := getPodNodeID(pod)
+	policies := self.getSelectingPolicies(pod)
+	if len(policies) == 0 {
+		self.addEdge(self.addAny(), target, "", []string{allPorts})
+		return
+	}
+
+	for _, policy := range policies {
+		for _, rule := range policy.Spec.Ingress {
+			ports := formatPorts(rule.Ports)
+			if len(rule.From) == 0 {
+				self.addEdge(self.addAny(), target, policy.Name, ports)
+				continue
+			}
+
+			for _, peer := range rule.From {
+				switch {
+				case peer.PodSelector != nil:
+					for i := range candidates {
+						source := &candidates[i]
+						if source.Namespace == policy.Namespace && getPodNodeID(source) != target &&
+							selectorMatches(peer.PodSelector, source.Labels) {
+							self.addEdge(self.addPod(source), target, policy.Name, ports)
+						}
+					}
+				case peer.NamespaceSelector != nil:
+					self.addEdge(self.addNamespaces(peer.NamespaceSelector), target, policy.Name,
+						ports)
+				}
+			}
+		}
+	}
+}
+
+// addEgress adds edges to all pods the pod is allowed to connect to. Connections to pods that are
+// not isolated are represented by a single edge to any node.
+func (self *graphBuilder) addEgress(pod *v1.Pod, destinations []v1.Pod) {
+	source := getPodNodeID(pod)
+	self.addEdge(source, self.addAny(), "", []string{allPorts})
+
+	for i := range destinations {
+		destination := &destinations[i]
+		if getPodNodeID(destination) == source {
+			continue
+		}
+		for _, policy := range self.getSelectingPolicies(destination) {
+			for _, rule := range policy.Spec.Ingress {
+				if self.ruleAllows(&policy, rule, pod) {
+					self.addEdge(source, self.addPod(destination), policy.Name,
+						formatPorts(rule.Ports))
+				}
+			}
+		}
+	}
+}
+
+// getSelectingPolicies returns policies that select the pod.
+func (self *graphBuilder) getSelectingPolicies(pod *v1.Pod) []extensions.NetworkPolicy {
+	result := make([]extensions.NetworkPolicy, 0)
+	for i := range self.policies {
+		if selectsPod(&self.policies[i], pod) {
+			result = append(result, self.policies[i])
+		}
+	}
+	return result
+}
+
+// ruleAllows returns true if the ingress rule of the policy allows connections from the pod.
+func (self *graphBuilder) ruleAllows(policy *extensions.NetworkPolicy,
+	rule extensions.NetworkPolicyIngressRule, pod *v1.Pod) bool {
+	if len(rule.From) == 0 {
+		return true
+	}
+	for _, peer := range rule.From {
+		switch {
+		case peer.PodSelector != nil:
+			if pod.Namespace == policy.Namespace && selectorMatches(peer.PodSelector, pod.Labels) {
+				return true
+			}
+		case peer.NamespaceSelector != nil:
+			if self.namespaceMatches(peer.NamespaceSelector, pod.Namespace) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (self *graphBuilder) namespaceMatches(selector *metaV1.LabelSelector, namespace string) bool {
+	namespaceLabels, ok := self.namespaceLabels[namespace]
+	return ok && selectorMatches(selector, namespaceLabels)
+}
+
+// selectsPod returns true if the policy applies to the pod.
+func selectsPod(policy *extensions.NetworkPolicy, pod *v1.Pod) bool {
+	return policy.Namespace == pod.Namespace && selectorMatches(&policy.Spec.PodSelector, pod.Labels)
+}
+
+// selectorMatches returns true if the labels match the selector. Invalid selectors do not match
+// anything.
+func selectorMatches(selector *metaV1.LabelSelector, podLabels map[string]string) bool {
+	s, err := metaV1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(podLabels))
+}
+
+// formatPorts returns ports of the rule in protocol/port format.
+func formatPorts(ports []extensions.NetworkPolicyPort) []string {
+	if len(ports) == 0 {
+		return []string{allPorts}
+	}
+
+	result := make([]string, 0, len(ports))
+	for _, port := range ports {
+		protocol := v1.ProtocolTCP
+		if port.Protocol != nil {
+			protocol = *port.Protocol
+		}
+		if port.Port == nil {
+			result = append(result, fmt.Sprintf("%s/%s", protocol, allPorts))
+		} else {
+			result = append(result, fmt.Sprintf("%s/%s", protocol, port.Port.String()))
+		}
+	}
+	return result
+}
+
+// String is used in log messages and tests.
+func (self NetworkGraphEdge) String() string {
+	return fmt.Sprintf("%s -> %s (%s) [%s]", self.From, self.To, self.Policy,
+		strings.Join(self.Ports, ","))
+}
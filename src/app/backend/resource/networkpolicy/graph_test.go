@@ -0,0 +1,219 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	core "k8s.io/client-go/testing"
+)
+
+func getGraphTestPod(namespace, name, app string) v1.Pod {
+	return v1.Pod{ObjectMeta: metaV1.ObjectMeta{Namespace: namespace, Name: name,
+		Labels: map[string]string{"app": app}}}
+}
+
+func getEdges(graph *NetworkGraph) []string {
+	edges := make([]string, 0)
+	for _, edge := range graph.Edges {
+		edges = append(edges, edge.String())
+	}
+	return edges
+}
+
+var (
+	graphTestPort       = intstr.FromInt(5432)
+	graphTestNamespaces = []v1.Namespace{
+		{ObjectMeta: metaV1.ObjectMeta{Name: "shop", Labels: map[string]string{"team": "shop"}}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "monitoring",
+			Labels: map[string]string{"team": "ops"}}},
+	}
+	graphTestPods = []v1.Pod{
+		getGraphTestPod("shop", "web", "web"),
+		getGraphTestPod("shop", "db", "db"),
+		getGraphTestPod("shop", "batch", "batch"),
+		getGraphTestPod("monitoring", "prometheus", "prometheus"),
+	}
+	// Only web can connect to db on port 5432, monitoring namespace can connect to any port.
+	graphTestPolicies = []extensions.NetworkPolicy{{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: "shop", Name: "db-access"},
+		Spec: extensions.NetworkPolicySpec{
+			PodSelector: metaV1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			Ingress: []extensions.NetworkPolicyIngressRule{
+				{
+					Ports: []extensions.NetworkPolicyPort{{Port: &graphTestPort}},
+					From: []extensions.NetworkPolicyPeer{{
+						PodSelector: &metaV1.LabelSelector{
+							MatchLabels: map[string]string{"app": "web"}},
+					}},
+				},
+				{
+					From: []extensions.NetworkPolicyPeer{{
+						NamespaceSelector: &metaV1.LabelSelector{
+							MatchLabels: map[string]string{"team": "ops"}},
+					}},
+				},
+			},
+		},
+	}}
+)
+
+func TestBuildNamespaceGraph(t *testing.T) {
+	graph := buildNamespaceGraph(graphTestPods[:3], graphTestPolicies, graphTestNamespaces)
+
+	expectedEdges := []string{
+		"any -> pod:shop/web () [all]",
+		"pod:shop/web -> pod:shop/db (db-access) [TCP/5432]",
+		"namespaces:team=ops -> pod:shop/db (db-access) [all]",
+		"any -> pod:shop/batch () [all]",
+	}
+	if edges := getEdges(graph); !reflect.DeepEqual(edges, expectedEdges) {
+		t.Errorf("buildNamespaceGraph() returns edges %#v, expected %#v", edges, expectedEdges)
+	}
+
+	for _, node := range graph.Nodes {
+		switch node.ID {
+		case "pod:shop/db":
+			if !node.Isolated || !reflect.DeepEqual(node.Policies, []string{"db-access"}) {
+				t.Errorf("buildNamespaceGraph() returns db node %#v, expected isolated", node)
+			}
+		case "namespaces:team=ops":
+			if !reflect.DeepEqual(node.Namespaces, []string{"monitoring"}) {
+				t.Errorf("buildNamespaceGraph() returns namespaces node %#v, expected monitoring",
+					node)
+			}
+		case "pod:shop/web", "pod:shop/batch":
+			if node.Isolated {
+				t.Errorf("buildNamespaceGraph() returns %#v, expected not isolated", node)
+			}
+		}
+	}
+	if len(graph.Nodes) != 5 {
+		t.Errorf("buildNamespaceGraph() returns %d nodes, expected 5: %#v", len(graph.Nodes),
+			graph.Nodes)
+	}
+}
+
+func TestBuildPodGraph(t *testing.T) {
+	cases := []struct {
+		pod           v1.Pod
+		expectedEdges []string
+	}{
+		{
+			graphTestPods[0],
+			[]string{
+				"any -> pod:shop/web () [all]",
+				"pod:shop/web -> any () [all]",
+				"pod:shop/web -> pod:shop/db (db-access) [TCP/5432]",
+			},
+		},
+		{
+			graphTestPods[2],
+			[]string{
+				"any -> pod:shop/batch () [all]",
+				"pod:shop/batch -> any () [all]",
+			},
+		},
+		{
+			graphTestPods[3],
+			[]string{
+				"any -> pod:monitoring/prometheus () [all]",
+				"pod:monitoring/prometheus -> any () [all]",
+				"pod:monitoring/prometheus -> pod:shop/db (db-access) [all]",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		graph := buildPodGraph(&c.pod, graphTestPods, graphTestPolicies, graphTestNamespaces)
+		if edges := getEdges(graph); !reflect.DeepEqual(edges, c.expectedEdges) {
+			t.Errorf("buildPodGraph(%s) returns edges %#v, expected %#v", c.pod.Name, edges,
+				c.expectedEdges)
+		}
+	}
+}
+
+// getNamespacedTestClient returns a client of a user, that can read only the shop namespace.
+func getNamespacedTestClient() *fake.Clientset {
+	fakeClient := fake.NewSimpleClientset(&graphTestNamespaces[0], &graphTestNamespaces[1],
+		&graphTestPods[0], &graphTestPods[3])
+	fakeClient.PrependReactor("*", "*", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "shop" {
+			return false, nil, nil
+		}
+		if get, ok := action.(core.GetAction); ok && get.GetName() == "shop" {
+			return false, nil, nil
+		}
+		resource := action.GetResource().Resource
+		return true, nil, errorsK8s.NewForbidden(schema.GroupResource{Resource: resource}, "",
+			nil)
+	})
+	return fakeClient
+}
+
+func TestGetReadableNamespaces(t *testing.T) {
+	namespaces, nonCriticalErrors, err := getReadableNamespaces(getNamespacedTestClient(), "shop")
+	if err != nil {
+		t.Fatalf("getReadableNamespaces() returns error: %v", err)
+	}
+	if !reflect.DeepEqual(namespaces, graphTestNamespaces[:1]) {
+		t.Errorf("getReadableNamespaces() == %#v, expected %#v", namespaces,
+			graphTestNamespaces[:1])
+	}
+	if len(nonCriticalErrors) != 1 {
+		t.Errorf("getReadableNamespaces() returns errors %#v, expected one", nonCriticalErrors)
+	}
+}
+
+func TestGetReadablePods(t *testing.T) {
+	pods, nonCriticalErrors, err := getReadablePods(getNamespacedTestClient(),
+		graphTestNamespaces)
+	if err != nil {
+		t.Fatalf("getReadablePods() returns error: %v", err)
+	}
+	if !reflect.DeepEqual(pods, graphTestPods[:1]) {
+		t.Errorf("getReadablePods() == %#v, expected %#v", pods, graphTestPods[:1])
+	}
+	if len(nonCriticalErrors) != 2 {
+		t.Errorf("getReadablePods() returns errors %#v, expected two", nonCriticalErrors)
+	}
+}
+
+func TestFormatPorts(t *testing.T) {
+	udp := v1.ProtocolUDP
+	port := intstr.FromString("dns")
+	cases := []struct {
+		ports    []extensions.NetworkPolicyPort
+		expected []string
+	}{
+		{nil, []string{"all"}},
+		{[]extensions.NetworkPolicyPort{{Protocol: &udp, Port: &port}}, []string{"UDP/dns"}},
+		{[]extensions.NetworkPolicyPort{{}}, []string{"TCP/all"}},
+	}
+	for _, c := range cases {
+		if actual := formatPorts(c.ports); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("formatPorts(%#v) == %#v, expected %#v", c.ports, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+// NetworkPolicyList contains a list of Network Policies in the cluster.
+type NetworkPolicyList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	// Unordered list of Network Policies.
+	Items []NetworkPolicy `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// NetworkPolicy describes which pods are allowed to connect to the pods it selects.
+type NetworkPolicy struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Pods selected by the policy, empty selector selects all pods in the namespace.
+	PodSelector metaV1.LabelSelector `json:"podSelector"`
+}
+
+// GetNetworkPolicyList returns a list of all Network Policies in the cluster.
+func GetNetworkPolicyList(client client.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*NetworkPolicyList, error) {
+	logger.Infof("Getting list of network policies in the namespace %s", nsQuery.ToRequestParam())
+
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
+	var policies []extensions.NetworkPolicy
+	var err error
+	if chunk.IsEnabled() {
+		list := new(extensions.NetworkPolicyList)
+		err = chunk.List(client.ExtensionsV1beta1().RESTClient(), "networkpolicies", nsQuery, list)
+		policies = list.Items
+	} else {
+		policies, err = getNetworkPolicies(client, nsQuery.ToRequestParam())
+	}
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	networkPolicyList := toNetworkPolicyList(policies, nonCriticalErrors, dsQuery)
+	chunk.SetListMeta(&networkPolicyList.ListMeta)
+	return networkPolicyList, nil
+}
+
+// getNetworkPolicies lists network policies in the namespace, all namespaces when it is empty.
+// Typed client does not support network policies yet, so the REST client of the extensions API
+// group is used.
+func getNetworkPolicies(client client.Interface, namespace string) ([]extensions.NetworkPolicy, error) {
+	list := new(extensions.NetworkPolicyList)
+	err := client.ExtensionsV1beta1().RESTClient().Get().
+		Namespace(namespace).
+		Resource("networkpolicies").
+		Do().
+		Into(list)
+	if err != nil {
+		return make([]extensions.NetworkPolicy, 0), err
+	}
+	return list.Items, nil
+}
+
+func toNetworkPolicy(policy *extensions.NetworkPolicy) NetworkPolicy {
+	return NetworkPolicy{
+		ObjectMeta:  api.NewObjectMeta(policy.ObjectMeta),
+		TypeMeta:    api.NewTypeMeta(api.ResourceKindNetworkPolicy),
+		PodSelector: policy.Spec.PodSelector,
+	}
+}
+
+func toNetworkPolicyList(policies []extensions.NetworkPolicy, nonCriticalErrors []error,
+	dsQuery *dataselect.DataSelectQuery) *NetworkPolicyList {
+	result := &NetworkPolicyList{
+		Items:    make([]NetworkPolicy, 0),
+		ListMeta: api.ListMeta{TotalItems: len(policies)},
+		Errors:   nonCriticalErrors,
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(policies), dsQuery)
+	policies = fromCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for _, item := range policies {
+		result.Items = append(result.Items, toNetworkPolicy(&item))
+	}
+
+	return result
+}
@@ -0,0 +1,137 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// nodePIDPressure is the condition reported by newer kubelets, which the client library does not
+// define yet.
+const nodePIDPressure v1.NodeConditionType = "PIDPressure"
+
+// conditionTimeTolerance is the largest difference between transition time of a condition and time
+// of the event reporting it, for which they are considered the same transition.
+const conditionTimeTolerance = time.Minute
+
+// ConditionTransition is a change of a node condition found in node events or in the current
+// status of the node.
+type ConditionTransition struct {
+	Type    v1.NodeConditionType `json:"type"`
+	Status  v1.ConditionStatus   `json:"status"`
+	Reason  string               `json:"reason"`
+	Message string               `json:"message"`
+	Time    metaV1.Time          `json:"time"`
+}
+
+// conditionEventReasons maps reasons of events recorded by kubelet to condition changes they
+// report.
+var conditionEventReasons = map[string]struct {
+	conditionType v1.NodeConditionType
+	status        v1.ConditionStatus
+}{
+	"NodeReady":                 {v1.NodeReady, v1.ConditionTrue},
+	"NodeNotReady":              {v1.NodeReady, v1.ConditionFalse},
+	"NodeHasSufficientMemory":   {v1.NodeMemoryPressure, v1.ConditionFalse},
+	"NodeHasInsufficientMemory": {v1.NodeMemoryPressure, v1.ConditionTrue},
+	"NodeHasNoDiskPressure":     {v1.NodeDiskPressure, v1.ConditionFalse},
+	"NodeHasDiskPressure":       {v1.NodeDiskPressure, v1.ConditionTrue},
+	"NodeHasSufficientDisk":     {v1.NodeOutOfDisk, v1.ConditionFalse},
+	"NodeOutOfDisk":             {v1.NodeOutOfDisk, v1.ConditionTrue},
+	"NodeHasSufficientPID":      {nodePIDPressure, v1.ConditionFalse},
+	"NodeHasInsufficientPID":    {nodePIDPressure, v1.ConditionTrue},
+}
+
+// pressureConditions are conditions, which signal a problem of the node when they are true.
+var pressureConditions = []v1.NodeConditionType{
+	v1.NodeMemoryPressure,
+	v1.NodeDiskPressure,
+	v1.NodeOutOfDisk,
+	v1.NodeNetworkUnavailable,
+	nodePIDPressure,
+}
+
+// getPressureConditions returns types of pressure conditions, which are true on the node.
+func getPressureConditions(node v1.Node) []v1.NodeConditionType {
+	result := make([]v1.NodeConditionType, 0)
+	for _, pressure := range pressureConditions {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == pressure && condition.Status == v1.ConditionTrue {
+				result = append(result, pressure)
+			}
+		}
+	}
+	return result
+}
+
+// getConditionHistory returns condition transitions reported by node events together with the
+// last transitions of the current conditions, newest first. Kubernetes does not keep older
+// transitions, so the history is only as long as events are retained.
+func getConditionHistory(node v1.Node, events []common.Event) []ConditionTransition {
+	result := make([]ConditionTransition, 0)
+	for _, condition := range node.Status.Conditions {
+		if condition.LastTransitionTime.IsZero() {
+			continue
+		}
+		result = append(result, ConditionTransition{
+			Type:    condition.Type,
+			Status:  condition.Status,
+			Reason:  condition.Reason,
+			Message: condition.Message,
+			Time:    condition.LastTransitionTime,
+		})
+	}
+
+	for _, event := range events {
+		change, ok := conditionEventReasons[event.Reason]
+		if !ok || hasTransition(result, change.conditionType, change.status, event.LastSeen) {
+			continue
+		}
+		result = append(result, ConditionTransition{
+			Type:    change.conditionType,
+			Status:  change.status,
+			Reason:  event.Reason,
+			Message: event.Message,
+			Time:    event.LastSeen,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[j].Time.Before(result[i].Time) })
+	return result
+}
+
+// hasTransition returns true if the transition to the status at given time is already known. The
+// transition time of a condition and the time of the matching event may differ by a few seconds.
+func hasTransition(transitions []ConditionTransition, conditionType v1.NodeConditionType,
+	status v1.ConditionStatus, at metaV1.Time) bool {
+	for _, transition := range transitions {
+		if transition.Type != conditionType || transition.Status != status {
+			continue
+		}
+		difference := transition.Time.Sub(at.Time)
+		if difference < 0 {
+			difference = -difference
+		}
+		if difference <= conditionTimeTolerance {
+			return true
+		}
+	}
+	return false
+}
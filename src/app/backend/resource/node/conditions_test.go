@@ -0,0 +1,96 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func TestGetPressureConditions(t *testing.T) {
+	node := v1.Node{Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+		{Type: v1.NodeReady, Status: v1.ConditionTrue},
+		{Type: v1.NodeMemoryPressure, Status: v1.ConditionFalse},
+		{Type: v1.NodeDiskPressure, Status: v1.ConditionTrue},
+		{Type: nodePIDPressure, Status: v1.ConditionTrue},
+	}}}
+
+	expected := []v1.NodeConditionType{v1.NodeDiskPressure, nodePIDPressure}
+	if actual := getPressureConditions(node); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("getPressureConditions(%#v) == %#v, expected %#v", node, actual, expected)
+	}
+}
+
+func TestGetConditionHistory(t *testing.T) {
+	base := time.Date(2017, 9, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) metaV1.Time { return metaV1.NewTime(base.Add(time.Duration(minutes) * time.Minute)) }
+
+	node := v1.Node{Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+		{Type: v1.NodeReady, Status: v1.ConditionTrue, Reason: "KubeletReady", LastTransitionTime: at(30)},
+		{Type: v1.NodeMemoryPressure, Status: v1.ConditionFalse},
+	}}}
+	events := []common.Event{
+		{Reason: "NodeReady", Message: "ready", LastSeen: metaV1.NewTime(at(30).Add(5 * time.Second))},
+		{Reason: "NodeNotReady", Message: "not ready", LastSeen: at(20)},
+		{Reason: "Starting", Message: "starting kubelet", LastSeen: at(10)},
+	}
+
+	expected := []ConditionTransition{
+		{Type: v1.NodeReady, Status: v1.ConditionTrue, Reason: "KubeletReady", Time: at(30)},
+		{Type: v1.NodeReady, Status: v1.ConditionFalse, Reason: "NodeNotReady", Message: "not ready",
+			Time: at(20)},
+	}
+	if actual := getConditionHistory(node, events); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("getConditionHistory() == \ngot: %#v, \nexpected %#v", actual, expected)
+	}
+}
+
+func TestGetPodAllocations(t *testing.T) {
+	node := v1.Node{Status: v1.NodeStatus{Capacity: v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("2"),
+		v1.ResourceMemory: resource.MustParse("1000"),
+	}}}
+	getPod := func(name, cpu, memory string) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec: v1.PodSpec{Containers: []v1.Container{{Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse(cpu),
+					v1.ResourceMemory: resource.MustParse(memory),
+				},
+			}}}},
+		}
+	}
+	pods := []v1.Pod{getPod("small", "100m", "100"), getPod("memory", "100m", "500"),
+		getPod("cpu", "1", "100")}
+
+	expected := []PodAllocation{
+		{Name: "cpu", Namespace: "ns", CPURequests: 1000, MemoryRequests: 100,
+			CPURequestsFraction: 50, MemoryRequestsFraction: 10},
+		{Name: "memory", Namespace: "ns", CPURequests: 100, MemoryRequests: 500,
+			CPURequestsFraction: 5, MemoryRequestsFraction: 50},
+		{Name: "small", Namespace: "ns", CPURequests: 100, MemoryRequests: 100,
+			CPURequestsFraction: 5, MemoryRequestsFraction: 10},
+	}
+	if actual := getPodAllocations(node, pods); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("getPodAllocations() == \ngot: %#v, \nexpected %#v", actual, expected)
+	}
+}
@@ -15,11 +15,10 @@
 package node
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
@@ -88,6 +87,9 @@ type NodeDetail struct {
 	// Resources allocated by node.
 	AllocatedResources NodeAllocatedResources `json:"allocatedResources"`
 
+	// Requests of pods on the node, the pods requesting the largest share of capacity first.
+	PodAllocations []PodAllocation `json:"podAllocations"`
+
 	// External ID of the node assigned by some machine database (e.g. a cloud provider).
 	ExternalID string `json:"externalID"`
 
@@ -106,6 +108,12 @@ type NodeDetail struct {
 	// Conditions is an array of current node conditions.
 	Conditions []common.Condition `json:"conditions"`
 
+	// Types of pressure conditions, i.e. MemoryPressure, which are currently true.
+	PressureConditions []v1.NodeConditionType `json:"pressureConditions"`
+
+	// Transitions of node conditions found in node events and node status, newest first.
+	ConditionHistory []ConditionTransition `json:"conditionHistory"`
+
 	// Container images of the node.
 	ContainerImages []string `json:"containerImages"`
 
@@ -127,7 +135,7 @@ type NodeDetail struct {
 
 // GetNodeDetail gets node details.
 func GetNodeDetail(client k8sClient.Interface, metricClient metricapi.MetricClient, name string) (*NodeDetail, error) {
-	log.Printf("Getting details of %s node", name)
+	logger.Infof("Getting details of %s node", name)
 
 	node, err := client.CoreV1().Nodes().Get(name, metaV1.GetOptions{})
 	if err != nil {
@@ -166,6 +174,9 @@ func GetNodeDetail(client k8sClient.Interface, metricClient metricapi.MetricClie
 
 	metrics, _ := metricPromises.GetMetrics()
 	nodeDetails := toNodeDetail(*node, podList, eventList, allocatedResources, metrics, nonCriticalErrors)
+	if pods != nil {
+		nodeDetails.PodAllocations = getPodAllocations(*node, pods.Items)
+	}
 	return &nodeDetails, nil
 }
 
@@ -277,6 +288,8 @@ func toNodeDetail(node v1.Node, pods *pod.PodList, eventList *common.EventList,
 		Unschedulable:      node.Spec.Unschedulable,
 		NodeInfo:           node.Status.NodeInfo,
 		Conditions:         getNodeConditions(node),
+		PressureConditions: getPressureConditions(node),
+		ConditionHistory:   getConditionHistory(node, eventList.Events),
 		ContainerImages:    getContainerImages(node),
 		PodList:            *pods,
 		EventList:          *eventList,
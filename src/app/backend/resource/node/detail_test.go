@@ -46,12 +46,14 @@ func TestGetNodeDetail(t *testing.T) {
 				},
 			},
 			&NodeDetail{
-				ObjectMeta:    api.ObjectMeta{Name: "test-node"},
-				TypeMeta:      api.TypeMeta{Kind: api.ResourceKindNode},
-				ExternalID:    "127.0.0.1",
-				PodCIDR:       "127.0.0.1",
-				ProviderID:    "ID-1",
-				Unschedulable: true,
+				ObjectMeta:         api.ObjectMeta{Name: "test-node"},
+				TypeMeta:           api.TypeMeta{Kind: api.ResourceKindNode},
+				ExternalID:         "127.0.0.1",
+				PodCIDR:            "127.0.0.1",
+				ProviderID:         "ID-1",
+				Unschedulable:      true,
+				PressureConditions: []v1.NodeConditionType{},
+				ConditionHistory:   []ConditionTransition{},
 				PodList: pod.PodList{
 					Pods:              []pod.Pod{},
 					Errors:            []error{},
@@ -75,8 +77,9 @@ func TestGetNodeDetail(t *testing.T) {
 					PodCapacity:            0,
 					PodFraction:            0,
 				},
-				Metrics: make([]metricapi.Metric, 0),
-				Errors:  []error{},
+				PodAllocations: []PodAllocation{},
+				Metrics:        make([]metricapi.Metric, 0),
+				Errors:         []error{},
 			},
 		},
 	}
@@ -0,0 +1,268 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	policy "k8s.io/client-go/pkg/apis/policy/v1beta1"
+)
+
+const (
+	// mirrorPodAnnotation marks static pods mirrored by kubelet, which can not be evicted.
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+	// defaultDrainTimeout is used when drain options do not specify timeout.
+	defaultDrainTimeout = 5 * time.Minute
+
+	// drainRetryInterval is the interval between eviction retries and pod deletion checks.
+	drainRetryInterval = 5 * time.Second
+)
+
+// Types of drain events.
+const (
+	DrainEventCordoned = "cordoned"
+	DrainEventSkipped  = "skipped"
+	DrainEventEvicting = "evicting"
+	DrainEventBlocked  = "blocked"
+	DrainEventEvicted  = "evicted"
+	DrainEventFailed   = "failed"
+	DrainEventFinished = "finished"
+)
+
+// NodeSchedulability describes whether new pods can be scheduled on the node.
+type NodeSchedulability struct {
+	Name          string `json:"name"`
+	Unschedulable bool   `json:"unschedulable"`
+}
+
+// DrainOptions control which pods are evicted from the node. Defaults follow kubectl drain.
+type DrainOptions struct {
+	// Evict pods not managed by a controller, which will not be recreated elsewhere.
+	Force bool `json:"force"`
+
+	// Skip pods managed by daemon sets, otherwise drain fails when there are any.
+	IgnoreDaemonSets bool `json:"ignoreDaemonSets"`
+
+	// Evict pods with emptyDir volumes, whose data will be lost.
+	DeleteLocalData bool `json:"deleteLocalData"`
+
+	// Grace period of evicted pods, pod's own grace period is used when nil.
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds"`
+
+	// Time to wait for all pods to be evicted, 5 minutes when 0.
+	TimeoutSeconds int `json:"timeoutSeconds"`
+}
+
+// DrainEvent reports progress of node drain.
+type DrainEvent struct {
+	Type      string `json:"type"`
+	Namespace string `json:"namespace,omitempty"`
+	Pod       string `json:"pod,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// CordonNode marks the node as unschedulable or schedulable by patching spec.unschedulable.
+func CordonNode(client k8sClient.Interface, name string, unschedulable bool) (*NodeSchedulability, error) {
+	logger.Infof("Setting unschedulable of %s node to %t", name, unschedulable)
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+	node, err := client.CoreV1().Nodes().Patch(name, types.StrategicMergePatchType, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NodeSchedulability{Name: node.Name, Unschedulable: node.Spec.Unschedulable}, nil
+}
+
+// DrainNode cordons the node and evicts its pods. Evictions respect pod disruption budgets, so
+// evictions rejected because of a budget are retried until the timeout. Progress is reported
+// through the progress function.
+func DrainNode(client k8sClient.Interface, name string, options *DrainOptions,
+	progress func(DrainEvent)) error {
+	logger.Infof("Draining %s node", name)
+
+	node, err := client.CoreV1().Nodes().Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if _, err := CordonNode(client, name, true); err != nil {
+		return err
+	}
+	progress(DrainEvent{Type: DrainEventCordoned, Message: fmt.Sprintf("Node %s cordoned", name)})
+
+	podList, err := getNodePods(client, *node)
+	if err != nil {
+		return err
+	}
+
+	pods, skipped, err := getPodsForDeletion(podList.Items, options)
+	if err != nil {
+		return err
+	}
+	for _, event := range skipped {
+		progress(event)
+	}
+
+	timeout := defaultDrainTimeout
+	if options.TimeoutSeconds > 0 {
+		timeout = time.Duration(options.TimeoutSeconds) * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+
+	for _, pod := range pods {
+		if err := evictPod(client, pod, options, deadline, progress); err != nil {
+			progress(DrainEvent{Type: DrainEventFailed, Namespace: pod.Namespace, Pod: pod.Name,
+				Message: err.Error()})
+			return err
+		}
+	}
+
+	for _, pod := range pods {
+		if err := waitForPodDeletion(client, pod, deadline); err != nil {
+			progress(DrainEvent{Type: DrainEventFailed, Namespace: pod.Namespace, Pod: pod.Name,
+				Message: err.Error()})
+			return err
+		}
+		progress(DrainEvent{Type: DrainEventEvicted, Namespace: pod.Namespace, Pod: pod.Name})
+	}
+
+	progress(DrainEvent{Type: DrainEventFinished, Message: fmt.Sprintf("Node %s drained", name)})
+	return nil
+}
+
+// getPodsForDeletion returns pods that have to be evicted and events about skipped pods. Error
+// is returned when a pod blocks the drain with the given options.
+func getPodsForDeletion(pods []v1.Pod, options *DrainOptions) ([]v1.Pod, []DrainEvent, error) {
+	result := make([]v1.Pod, 0)
+	skipped := make([]DrainEvent, 0)
+	blocking := make([]string, 0)
+
+	for _, pod := range pods {
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			skipped = append(skipped, DrainEvent{Type: DrainEventSkipped, Namespace: pod.Namespace,
+				Pod: pod.Name, Message: "mirror pod"})
+			continue
+		}
+
+		controller := getControllerRef(&pod)
+		if controller != nil && controller.Kind == "DaemonSet" {
+			if options.IgnoreDaemonSets {
+				skipped = append(skipped, DrainEvent{Type: DrainEventSkipped, Namespace: pod.Namespace,
+					Pod: pod.Name, Message: "managed by daemon set"})
+				continue
+			}
+			blocking = append(blocking, fmt.Sprintf("%s/%s is managed by daemon set", pod.Namespace,
+				pod.Name))
+			continue
+		}
+
+		if controller == nil && !options.Force {
+			blocking = append(blocking, fmt.Sprintf("%s/%s is not managed by a controller",
+				pod.Namespace, pod.Name))
+			continue
+		}
+
+		if hasLocalStorage(&pod) && !options.DeleteLocalData {
+			blocking = append(blocking, fmt.Sprintf("%s/%s uses local storage", pod.Namespace,
+				pod.Name))
+			continue
+		}
+
+		result = append(result, pod)
+	}
+
+	if len(blocking) > 0 {
+		return nil, nil, errorsK8s.NewBadRequest("cannot drain node: " + strings.Join(blocking, ", "))
+	}
+	return result, skipped, nil
+}
+
+func getControllerRef(pod *v1.Pod) *metaV1.OwnerReference {
+	for i := range pod.OwnerReferences {
+		ref := &pod.OwnerReferences[i]
+		if ref.Controller != nil && *ref.Controller {
+			return ref
+		}
+	}
+	return nil
+}
+
+func hasLocalStorage(pod *v1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// evictPod creates eviction of the pod, retrying while it is blocked by a disruption budget.
+func evictPod(client k8sClient.Interface, pod v1.Pod, options *DrainOptions, deadline time.Time,
+	progress func(DrainEvent)) error {
+	progress(DrainEvent{Type: DrainEventEvicting, Namespace: pod.Namespace, Pod: pod.Name})
+
+	eviction := &policy.Eviction{
+		ObjectMeta:    metaV1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		DeleteOptions: &metaV1.DeleteOptions{GracePeriodSeconds: options.GracePeriodSeconds},
+	}
+
+	for {
+		err := client.PolicyV1beta1().Evictions(pod.Namespace).Evict(eviction)
+		switch {
+		case err == nil || errorsK8s.IsNotFound(err):
+			return nil
+		case errorsK8s.IsTooManyRequests(err):
+			// Eviction would violate a pod disruption budget.
+			progress(DrainEvent{Type: DrainEventBlocked, Namespace: pod.Namespace, Pod: pod.Name,
+				Message: err.Error()})
+		default:
+			return err
+		}
+
+		if time.Now().Add(drainRetryInterval).After(deadline) {
+			return fmt.Errorf("timed out evicting pod %s/%s", pod.Namespace, pod.Name)
+		}
+		time.Sleep(drainRetryInterval)
+	}
+}
+
+// waitForPodDeletion waits until the pod is deleted or replaced by a pod with the same name.
+func waitForPodDeletion(client k8sClient.Interface, pod v1.Pod, deadline time.Time) error {
+	return wait.PollImmediate(drainRetryInterval, deadline.Sub(time.Now()), func() (bool, error) {
+		current, err := client.CoreV1().Pods(pod.Namespace).Get(pod.Name, metaV1.GetOptions{})
+		if errorsK8s.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return current.UID != pod.UID, nil
+	})
+}
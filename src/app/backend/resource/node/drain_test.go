@@ -0,0 +1,125 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func getDrainTestPod(name string, ownerKind string, emptyDir bool) v1.Pod {
+	pod := v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "ns"},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+	if ownerKind != "" {
+		controller := true
+		pod.OwnerReferences = []metaV1.OwnerReference{{Kind: ownerKind, Name: "owner",
+			Controller: &controller}}
+	}
+	if emptyDir {
+		pod.Spec.Volumes = []v1.Volume{{Name: "data",
+			VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+	}
+	return pod
+}
+
+func TestGetPodsForDeletion(t *testing.T) {
+	managed := getDrainTestPod("managed", "ReplicaSet", false)
+	daemon := getDrainTestPod("daemon", "DaemonSet", false)
+	unmanaged := getDrainTestPod("unmanaged", "", false)
+	local := getDrainTestPod("local", "ReplicaSet", true)
+	mirror := getDrainTestPod("mirror", "", false)
+	mirror.Annotations = map[string]string{mirrorPodAnnotation: "hash"}
+	finished := getDrainTestPod("finished", "", false)
+	finished.Status.Phase = v1.PodSucceeded
+
+	cases := []struct {
+		pods            []v1.Pod
+		options         *DrainOptions
+		expectedPods    []string
+		expectedSkipped []string
+		expectedErr     bool
+	}{
+		{
+			[]v1.Pod{managed, mirror, finished},
+			&DrainOptions{},
+			[]string{"managed"}, []string{"mirror"}, false,
+		},
+		{
+			[]v1.Pod{managed, daemon},
+			&DrainOptions{},
+			nil, nil, true,
+		},
+		{
+			[]v1.Pod{managed, daemon},
+			&DrainOptions{IgnoreDaemonSets: true},
+			[]string{"managed"}, []string{"daemon"}, false,
+		},
+		{
+			[]v1.Pod{unmanaged},
+			&DrainOptions{},
+			nil, nil, true,
+		},
+		{
+			[]v1.Pod{unmanaged},
+			&DrainOptions{Force: true},
+			[]string{"unmanaged"}, []string{}, false,
+		},
+		{
+			[]v1.Pod{local},
+			&DrainOptions{},
+			nil, nil, true,
+		},
+		{
+			[]v1.Pod{local},
+			&DrainOptions{DeleteLocalData: true},
+			[]string{"local"}, []string{}, false,
+		},
+	}
+
+	for _, c := range cases {
+		pods, skipped, err := getPodsForDeletion(c.pods, c.options)
+		if (err != nil) != c.expectedErr {
+			t.Errorf("getPodsForDeletion(%#v) returns error %v, expected error: %t", c.options,
+				err, c.expectedErr)
+			continue
+		}
+		if c.expectedErr {
+			continue
+		}
+
+		podNames := make([]string, 0)
+		for _, pod := range pods {
+			podNames = append(podNames, pod.Name)
+		}
+		skippedNames := make([]string, 0)
+		for _, event := range skipped {
+			skippedNames = append(skippedNames, event.Pod)
+		}
+
+		if !reflect.DeepEqual(podNames, c.expectedPods) {
+			t.Errorf("getPodsForDeletion(%#v) returns pods %#v, expected %#v", c.options,
+				podNames, c.expectedPods)
+		}
+		if !reflect.DeepEqual(skippedNames, c.expectedSkipped) {
+			t.Errorf("getPodsForDeletion(%#v) skips pods %#v, expected %#v", c.options,
+				skippedNames, c.expectedSkipped)
+		}
+	}
+}
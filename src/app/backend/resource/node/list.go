@@ -15,16 +15,12 @@
 package node
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/fields"
-	"k8s.io/apimachinery/pkg/labels"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
 )
@@ -65,17 +61,19 @@ func GetNodeListFromChannels(client client.Interface, channels *common.ResourceC
 
 // GetNodeList returns a list of all Nodes in the cluster.
 func GetNodeList(client client.Interface, dsQuery *dataselect.DataSelectQuery, metricClient metricapi.MetricClient) (*NodeList, error) {
-	nodes, err := client.CoreV1().Nodes().List(metaV1.ListOptions{
-		LabelSelector: labels.Everything().String(),
-		FieldSelector: fields.Everything().String(),
-	})
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
+	channel := common.GetNodeListChunkChannel(client, chunk, 1)
+	nodes := <-channel.List
+	err := <-channel.Error
 
 	nonCriticalErrors, criticalError := errors.HandleError(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return toNodeList(client, nodes.Items, nonCriticalErrors, dsQuery, metricClient), nil
+	nodeList := toNodeList(client, nodes.Items, nonCriticalErrors, dsQuery, metricClient)
+	chunk.SetListMeta(&nodeList.ListMeta)
+	return nodeList, nil
 }
 
 func toNodeList(client client.Interface, nodes []v1.Node, nonCriticalErrors []error, dsQuery *dataselect.DataSelectQuery,
@@ -94,7 +92,7 @@ func toNodeList(client client.Interface, nodes []v1.Node, nonCriticalErrors []er
 	for _, node := range nodes {
 		pods, err := getNodePods(client, node)
 		if err != nil {
-			log.Printf("Couldn't get pods of %s node: %s\n", node.Name, err)
+			logger.Errorf("Couldn't get pods of %s node: %s\n", node.Name, err)
 		}
 
 		nodeList.Nodes = append(nodeList.Nodes, toNode(node, pods))
@@ -112,7 +110,7 @@ func toNodeList(client client.Interface, nodes []v1.Node, nonCriticalErrors []er
 func toNode(node v1.Node, pods *v1.PodList) Node {
 	allocatedResources, err := getNodeAllocatedResources(node, pods)
 	if err != nil {
-		log.Printf("Couldn't get allocated resources of %s node: %s\n", node.Name, err)
+		logger.Errorf("Couldn't get allocated resources of %s node: %s\n", node.Name, err)
 	}
 
 	return Node{
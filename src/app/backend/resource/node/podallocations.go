@@ -0,0 +1,84 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"math"
+	"sort"
+
+	"k8s.io/client-go/pkg/api/v1"
+	helper "k8s.io/client-go/pkg/api/v1/resource"
+)
+
+// PodAllocation describes resources requested by a single pod on the node.
+type PodAllocation struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// CPU requests and limits in millicores.
+	CPURequests int64 `json:"cpuRequests"`
+	CPULimits   int64 `json:"cpuLimits"`
+
+	// Memory requests and limits in bytes.
+	MemoryRequests int64 `json:"memoryRequests"`
+	MemoryLimits   int64 `json:"memoryLimits"`
+
+	// Requested fractions of node capacity in percents.
+	CPURequestsFraction    float64 `json:"cpuRequestsFraction"`
+	MemoryRequestsFraction float64 `json:"memoryRequestsFraction"`
+}
+
+// getPodAllocations returns requests of pods on the node, the pods consuming the largest share of
+// CPU or memory capacity first.
+func getPodAllocations(node v1.Node, pods []v1.Pod) []PodAllocation {
+	cpuCapacity := float64(node.Status.Capacity.Cpu().MilliValue())
+	memoryCapacity := float64(node.Status.Capacity.Memory().Value())
+
+	result := make([]PodAllocation, 0)
+	for _, pod := range pods {
+		requests, limits, err := helper.PodRequestsAndLimits(&pod)
+		if err != nil {
+			continue
+		}
+
+		cpuRequests, cpuLimits := requests[v1.ResourceCPU], limits[v1.ResourceCPU]
+		memoryRequests, memoryLimits := requests[v1.ResourceMemory], limits[v1.ResourceMemory]
+		allocation := PodAllocation{
+			Name:           pod.Name,
+			Namespace:      pod.Namespace,
+			CPURequests:    cpuRequests.MilliValue(),
+			CPULimits:      cpuLimits.MilliValue(),
+			MemoryRequests: memoryRequests.Value(),
+			MemoryLimits:   memoryLimits.Value(),
+		}
+		if cpuCapacity > 0 {
+			allocation.CPURequestsFraction = float64(allocation.CPURequests) / cpuCapacity * 100
+		}
+		if memoryCapacity > 0 {
+			allocation.MemoryRequestsFraction = float64(allocation.MemoryRequests) / memoryCapacity * 100
+		}
+		result = append(result, allocation)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		left := math.Max(result[i].CPURequestsFraction, result[i].MemoryRequestsFraction)
+		right := math.Max(result[j].CPURequestsFraction, result[j].MemoryRequestsFraction)
+		if left != right {
+			return left > right
+		}
+		return result[i].Namespace+"/"+result[i].Name < result[j].Namespace+"/"+result[j].Name
+	})
+	return result
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// taintUpdateAttempts is how many times taints are updated when the node is concurrently modified.
+const taintUpdateAttempts = 3
+
+// NodeTaints contains taints of the node after they were changed.
+type NodeTaints struct {
+	Name   string     `json:"name"`
+	Taints []v1.Taint `json:"taints"`
+}
+
+// AddNodeTaint adds the taint to the node. Taint with the same key and effect is replaced.
+func AddNodeTaint(client k8sClient.Interface, name string, taint v1.Taint) (*NodeTaints, error) {
+	if err := validateTaint(taint); err != nil {
+		return nil, err
+	}
+	logger.Infof("Adding taint %s:%s to %s node", taint.Key, taint.Effect, name)
+
+	return updateNodeTaints(client, name, func(taints []v1.Taint) ([]v1.Taint, error) {
+		if taint.Effect == v1.TaintEffectNoExecute && taint.TimeAdded.IsZero() {
+			taint.TimeAdded = metaV1.Now()
+		}
+
+		result := make([]v1.Taint, 0)
+		for _, existing := range taints {
+			if existing.Key != taint.Key || existing.Effect != taint.Effect {
+				result = append(result, existing)
+			}
+		}
+		return append(result, taint), nil
+	})
+}
+
+// RemoveNodeTaint removes taints with given key from the node. When effect is empty, taints with
+// all effects are removed. Returns not found error if the node has no such taint.
+func RemoveNodeTaint(client k8sClient.Interface, name, key string, effect v1.TaintEffect) (*NodeTaints, error) {
+	logger.Infof("Removing taint %s:%s from %s node", key, effect, name)
+
+	return updateNodeTaints(client, name, func(taints []v1.Taint) ([]v1.Taint, error) {
+		result := make([]v1.Taint, 0)
+		for _, existing := range taints {
+			if existing.Key != key || (len(effect) > 0 && existing.Effect != effect) {
+				result = append(result, existing)
+			}
+		}
+
+		if len(result) == len(taints) {
+			return nil, errorsK8s.NewNotFound(schema.GroupResource{Resource: "taints"}, key)
+		}
+		return result, nil
+	})
+}
+
+// updateNodeTaints replaces taints of the node with the taints returned by the update function.
+// Update is retried when the node was modified between get and update.
+func updateNodeTaints(client k8sClient.Interface, name string,
+	update func([]v1.Taint) ([]v1.Taint, error)) (*NodeTaints, error) {
+	var err error
+	for attempt := 0; attempt < taintUpdateAttempts; attempt++ {
+		var node *v1.Node
+		node, err = client.CoreV1().Nodes().Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		taints, updateErr := update(node.Spec.Taints)
+		if updateErr != nil {
+			return nil, updateErr
+		}
+		node.Spec.Taints = taints
+
+		node, err = client.CoreV1().Nodes().Update(node)
+		if err == nil {
+			return &NodeTaints{Name: node.Name, Taints: node.Spec.Taints}, nil
+		}
+		if !errorsK8s.IsConflict(err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+func validateTaint(taint v1.Taint) error {
+	if len(taint.Key) == 0 {
+		return errorsK8s.NewBadRequest("taint key is required")
+	}
+
+	switch taint.Effect {
+	case v1.TaintEffectNoSchedule, v1.TaintEffectPreferNoSchedule, v1.TaintEffectNoExecute:
+		return nil
+	}
+	return errorsK8s.NewBadRequest(fmt.Sprintf("invalid taint effect %q, must be one of %s, %s and %s",
+		taint.Effect, v1.TaintEffectNoSchedule, v1.TaintEffectPreferNoSchedule, v1.TaintEffectNoExecute))
+}
@@ -0,0 +1,121 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func getTaintTestNode(taints ...v1.Taint) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: "node"},
+		Spec:       v1.NodeSpec{Taints: taints},
+	}
+}
+
+func TestAddNodeTaint(t *testing.T) {
+	dedicated := v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}
+	replaced := v1.Taint{Key: "dedicated", Value: "db", Effect: v1.TaintEffectNoSchedule}
+	preferred := v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectPreferNoSchedule}
+
+	cases := []struct {
+		node        *v1.Node
+		taint       v1.Taint
+		expected    []v1.Taint
+		expectedErr bool
+	}{
+		{getTaintTestNode(), dedicated, []v1.Taint{dedicated}, false},
+		{getTaintTestNode(dedicated), replaced, []v1.Taint{replaced}, false},
+		{getTaintTestNode(dedicated), preferred, []v1.Taint{dedicated, preferred}, false},
+		{getTaintTestNode(), v1.Taint{Effect: v1.TaintEffectNoSchedule}, nil, true},
+		{getTaintTestNode(), v1.Taint{Key: "dedicated", Effect: "Unknown"}, nil, true},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset(c.node)
+		actual, err := AddNodeTaint(client, "node", c.taint)
+		if c.expectedErr {
+			if !errorsK8s.IsBadRequest(err) {
+				t.Errorf("AddNodeTaint(client, node, %#v) == %v, expected bad request", c.taint, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("AddNodeTaint(client, node, %#v) returned error: %s", c.taint, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual.Taints, c.expected) {
+			t.Errorf("AddNodeTaint(client, node, %#v) == \ngot: %#v, \nexpected %#v", c.taint,
+				actual.Taints, c.expected)
+		}
+	}
+}
+
+func TestAddNodeTaintNoExecute(t *testing.T) {
+	client := fake.NewSimpleClientset(getTaintTestNode())
+	actual, err := AddNodeTaint(client, "node", v1.Taint{Key: "evict", Effect: v1.TaintEffectNoExecute})
+	if err != nil {
+		t.Fatalf("AddNodeTaint returned error: %s", err)
+	}
+	if len(actual.Taints) != 1 || actual.Taints[0].TimeAdded.IsZero() {
+		t.Errorf("Expected NoExecute taint with time added, got %#v", actual.Taints)
+	}
+}
+
+func TestRemoveNodeTaint(t *testing.T) {
+	noSchedule := v1.Taint{Key: "dedicated", Effect: v1.TaintEffectNoSchedule}
+	noExecute := v1.Taint{Key: "dedicated", Effect: v1.TaintEffectNoExecute}
+	other := v1.Taint{Key: "other", Effect: v1.TaintEffectNoSchedule}
+
+	cases := []struct {
+		node             *v1.Node
+		key              string
+		effect           v1.TaintEffect
+		expected         []v1.Taint
+		expectedNotFound bool
+	}{
+		{getTaintTestNode(noSchedule, noExecute, other), "dedicated", v1.TaintEffectNoExecute,
+			[]v1.Taint{noSchedule, other}, false},
+		{getTaintTestNode(noSchedule, noExecute, other), "dedicated", "",
+			[]v1.Taint{other}, false},
+		{getTaintTestNode(other), "dedicated", "", nil, true},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset(c.node)
+		actual, err := RemoveNodeTaint(client, "node", c.key, c.effect)
+		if c.expectedNotFound {
+			if !errorsK8s.IsNotFound(err) {
+				t.Errorf("RemoveNodeTaint(client, node, %s, %s) == %v, expected not found", c.key,
+					c.effect, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("RemoveNodeTaint(client, node, %s, %s) returned error: %s", c.key, c.effect, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual.Taints, c.expected) {
+			t.Errorf("RemoveNodeTaint(client, node, %s, %s) == \ngot: %#v, \nexpected %#v", c.key,
+				c.effect, actual.Taints, c.expected)
+		}
+	}
+}
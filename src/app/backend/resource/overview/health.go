@@ -0,0 +1,278 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package overview
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diagnosis"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+	helper "k8s.io/client-go/pkg/api/v1/resource"
+	apps "k8s.io/client-go/pkg/apis/apps/v1beta1"
+	batch "k8s.io/client-go/pkg/apis/batch/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+// warningEventWindow is the period, in which warning events are counted.
+const warningEventWindow = 10 * time.Minute
+
+// now returns current time. It is a variable, so it can be replaced in tests.
+var now = time.Now
+
+// NodeSummary describes readiness of nodes.
+type NodeSummary struct {
+	Total         int `json:"total"`
+	Ready         int `json:"ready"`
+	NotReady      int `json:"notReady"`
+	Unschedulable int `json:"unschedulable"`
+
+	// Names of nodes, which are not ready.
+	NotReadyNodes []string `json:"notReadyNodes"`
+}
+
+// ComponentHealth is health of a single control plane component, i.e. scheduler.
+type ComponentHealth struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	// Message or error reported by the component.
+	Message string `json:"message"`
+}
+
+// NamespaceHealth contains numbers of healthy and unhealthy workloads and pods in a namespace.
+type NamespaceHealth struct {
+	Namespace string `json:"namespace"`
+
+	// Deployments, stateful sets, daemon sets and jobs.
+	Workloads          int `json:"workloads"`
+	UnhealthyWorkloads int `json:"unhealthyWorkloads"`
+
+	Pods int `json:"pods"`
+	// Pods with problems found by the workload diagnosis, i.e. crashing or unschedulable pods.
+	FailingPods int `json:"failingPods"`
+}
+
+// WarningEventRate describes how many warning events occurred recently.
+type WarningEventRate struct {
+	// Length of the window in seconds.
+	WindowSeconds int64 `json:"windowSeconds"`
+	// Number of warning events last seen in the window.
+	Count int `json:"count"`
+	// Average number of warning events per minute in the window.
+	PerMinute float64 `json:"perMinute"`
+}
+
+// ResourceHeadroom is amount of a resource left for scheduling new pods on ready, schedulable
+// nodes.
+type ResourceHeadroom struct {
+	// Resource is one of cpu, memory and pods.
+	Resource string `json:"resource"`
+	// Allocatable amount summed across the nodes. CPU is in millicores and memory in bytes.
+	Allocatable int64 `json:"allocatable"`
+	// Amount requested by pods running on the nodes.
+	Requested int64 `json:"requested"`
+	// Amount, which is not requested yet. Zero if nodes are overcommitted.
+	Available int64 `json:"available"`
+	// Requested share of the allocatable amount in percents.
+	RequestedFraction float64 `json:"requestedFraction"`
+}
+
+// workloadLists contains workloads, which health is summarized per namespace.
+type workloadLists struct {
+	deployments  []extensions.Deployment
+	statefulSets []apps.StatefulSet
+	daemonSets   []extensions.DaemonSet
+	jobs         []batch.Job
+}
+
+func getNodeSummary(nodes []v1.Node) NodeSummary {
+	result := NodeSummary{Total: len(nodes), NotReadyNodes: make([]string, 0)}
+	for _, node := range nodes {
+		if isNodeReady(node) {
+			result.Ready++
+		} else {
+			result.NotReady++
+			result.NotReadyNodes = append(result.NotReadyNodes, node.Name)
+		}
+		if node.Spec.Unschedulable {
+			result.Unschedulable++
+		}
+	}
+	sort.Strings(result.NotReadyNodes)
+	return result
+}
+
+func isNodeReady(node v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func getControlPlaneHealth(components []v1.ComponentStatus) []ComponentHealth {
+	result := make([]ComponentHealth, 0)
+	for _, component := range components {
+		health := ComponentHealth{Name: component.Name}
+		for _, condition := range component.Conditions {
+			if condition.Type == v1.ComponentHealthy {
+				health.Healthy = condition.Status == v1.ConditionTrue
+				health.Message = condition.Message
+				if len(condition.Error) > 0 {
+					health.Message = condition.Error
+				}
+			}
+		}
+		result = append(result, health)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func getNamespaceHealth(workloads *workloadLists, pods []v1.Pod, events []v1.Event) []NamespaceHealth {
+	health := make(map[string]*NamespaceHealth)
+	get := func(namespace string) *NamespaceHealth {
+		if _, ok := health[namespace]; !ok {
+			health[namespace] = &NamespaceHealth{Namespace: namespace}
+		}
+		return health[namespace]
+	}
+	addWorkload := func(namespace string, healthy bool) {
+		namespaceHealth := get(namespace)
+		namespaceHealth.Workloads++
+		if !healthy {
+			namespaceHealth.UnhealthyWorkloads++
+		}
+	}
+
+	for _, deployment := range workloads.deployments {
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		addWorkload(deployment.Namespace, deployment.Status.AvailableReplicas >= replicas &&
+			deployment.Status.UpdatedReplicas >= replicas)
+	}
+	for _, statefulSet := range workloads.statefulSets {
+		replicas := int32(1)
+		if statefulSet.Spec.Replicas != nil {
+			replicas = *statefulSet.Spec.Replicas
+		}
+		addWorkload(statefulSet.Namespace, statefulSet.Status.Replicas >= replicas)
+	}
+	for _, daemonSet := range workloads.daemonSets {
+		addWorkload(daemonSet.Namespace, daemonSet.Status.NumberReady >= daemonSet.Status.DesiredNumberScheduled)
+	}
+	for _, job := range workloads.jobs {
+		addWorkload(job.Namespace, !isJobFailed(job))
+	}
+
+	for _, pod := range pods {
+		namespaceHealth := get(pod.Namespace)
+		namespaceHealth.Pods++
+		if diagnosis.DiagnosePods([]v1.Pod{pod}, events) != nil {
+			namespaceHealth.FailingPods++
+		}
+	}
+
+	result := make([]NamespaceHealth, 0)
+	for _, namespaceHealth := range health {
+		result = append(result, *namespaceHealth)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Namespace < result[j].Namespace })
+	return result
+}
+
+func isJobFailed(job batch.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batch.JobFailed && condition.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func getWarningEventRate(events []v1.Event) WarningEventRate {
+	since := now().Add(-warningEventWindow)
+	result := WarningEventRate{WindowSeconds: int64(warningEventWindow.Seconds())}
+	for _, event := range events {
+		if event.Type == v1.EventTypeWarning && event.LastTimestamp.Time.After(since) {
+			result.Count++
+		}
+	}
+	result.PerMinute = float64(result.Count) / warningEventWindow.Minutes()
+	return result
+}
+
+// getHeadroom returns cpu, memory and pods left on ready, schedulable nodes. Requests of pods,
+// which are finished or not scheduled yet, are not counted.
+func getHeadroom(nodes []v1.Node, pods []v1.Pod) []ResourceHeadroom {
+	readyNodes := make(map[string]bool)
+	var cpuAllocatable, memoryAllocatable, podsAllocatable int64
+	for _, node := range nodes {
+		if !isNodeReady(node) || node.Spec.Unschedulable {
+			continue
+		}
+		readyNodes[node.Name] = true
+
+		allocatable := node.Status.Allocatable
+		if len(allocatable) == 0 {
+			allocatable = node.Status.Capacity
+		}
+		cpuAllocatable += allocatable.Cpu().MilliValue()
+		memoryAllocatable += allocatable.Memory().Value()
+		podsAllocatable += allocatable.Pods().Value()
+	}
+
+	var cpuRequested, memoryRequested, podsRequested int64
+	for _, pod := range pods {
+		if !readyNodes[pod.Spec.NodeName] || pod.Status.Phase == v1.PodSucceeded ||
+			pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+
+		podsRequested++
+		requests, _, err := helper.PodRequestsAndLimits(&pod)
+		if err != nil {
+			continue
+		}
+		cpuRequested += quantityOf(requests, v1.ResourceCPU).MilliValue()
+		memoryRequested += quantityOf(requests, v1.ResourceMemory).Value()
+	}
+
+	return []ResourceHeadroom{
+		newHeadroom(string(v1.ResourceCPU), cpuAllocatable, cpuRequested),
+		newHeadroom(string(v1.ResourceMemory), memoryAllocatable, memoryRequested),
+		newHeadroom(string(v1.ResourcePods), podsAllocatable, podsRequested),
+	}
+}
+
+func quantityOf(list map[v1.ResourceName]resource.Quantity, name v1.ResourceName) *resource.Quantity {
+	quantity := list[name]
+	return &quantity
+}
+
+func newHeadroom(name string, allocatable, requested int64) ResourceHeadroom {
+	result := ResourceHeadroom{Resource: name, Allocatable: allocatable, Requested: requested}
+	if allocatable > requested {
+		result.Available = allocatable - requested
+	}
+	if allocatable > 0 {
+		result.RequestedFraction = float64(requested) / float64(allocatable) * 100
+	}
+	return result
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package overview
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+)
+
+// Overview is a summary of the cluster health shown on the landing page.
+type Overview struct {
+	// Readiness of nodes.
+	Nodes NodeSummary `json:"nodes"`
+
+	// Health of control plane components. Empty when component statuses can not be read.
+	ControlPlane []ComponentHealth `json:"controlPlane"`
+
+	// Health of workloads and pods per namespace, ordered by namespace name.
+	Namespaces []NamespaceHealth `json:"namespaces"`
+
+	// Rate of recent warning events.
+	WarningEvents WarningEventRate `json:"warningEvents"`
+
+	// Resources left for scheduling new pods.
+	Headroom []ResourceHeadroom `json:"headroom"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetOverview returns summary of the cluster health. Resources are listed through resource
+// channels, so they are served from the resource cache when it is enabled.
+func GetOverview(client client.Interface) (*Overview, error) {
+	logger.Info("Getting cluster overview")
+
+	nsQuery := common.NewNamespaceQuery(nil)
+	channels := &common.ResourceChannels{
+		NodeList:        common.GetNodeListChannel(client, 1),
+		PodList:         common.GetPodListChannel(client, nsQuery, 1),
+		EventList:       common.GetEventListChannel(client, nsQuery, 1),
+		DeploymentList:  common.GetDeploymentListChannel(client, nsQuery, 1),
+		StatefulSetList: common.GetStatefulSetListChannel(client, nsQuery, 1),
+		DaemonSetList:   common.GetDaemonSetListChannel(client, nsQuery, 1),
+		JobList:         common.GetJobListChannel(client, nsQuery, 1),
+	}
+
+	return GetOverviewFromChannels(client, channels)
+}
+
+// GetOverviewFromChannels returns summary of the cluster health from the channel sources.
+func GetOverviewFromChannels(client client.Interface, channels *common.ResourceChannels) (*Overview, error) {
+	nodes := <-channels.NodeList.List
+	err := <-channels.NodeList.Error
+	nonCriticalErrors, criticalError := errors.AppendError(err, make([]error, 0))
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	pods := <-channels.PodList.List
+	err = <-channels.PodList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	events := <-channels.EventList.List
+	err = <-channels.EventList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	deployments := <-channels.DeploymentList.List
+	err = <-channels.DeploymentList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	statefulSets := <-channels.StatefulSetList.List
+	err = <-channels.StatefulSetList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	daemonSets := <-channels.DaemonSetList.List
+	err = <-channels.DaemonSetList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	jobs := <-channels.JobList.List
+	err = <-channels.JobList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	// Component statuses are not cached and are often not readable by users, so any failure
+	// only leaves the control plane section empty.
+	controlPlane := make([]ComponentHealth, 0)
+	components, err := client.CoreV1().ComponentStatuses().List(metaV1.ListOptions{})
+	if err != nil {
+		logger.Warningf("Skipping control plane health because of error: %s", err.Error())
+	} else {
+		controlPlane = getControlPlaneHealth(components.Items)
+	}
+
+	workloads := &workloadLists{
+		deployments:  deployments.Items,
+		statefulSets: statefulSets.Items,
+		daemonSets:   daemonSets.Items,
+		jobs:         jobs.Items,
+	}
+
+	return &Overview{
+		Nodes:         getNodeSummary(nodes.Items),
+		ControlPlane:  controlPlane,
+		Namespaces:    getNamespaceHealth(workloads, pods.Items, events.Items),
+		WarningEvents: getWarningEventRate(events.Items),
+		Headroom:      getHeadroom(nodes.Items, pods.Items),
+		Errors:        nonCriticalErrors,
+	}, nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package overview
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	batch "k8s.io/client-go/pkg/apis/batch/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func newNode(name string, ready bool, cpu, memory string) *v1.Node {
+	status := v1.ConditionTrue
+	if !ready {
+		status = v1.ConditionFalse
+	}
+	return &v1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: status}},
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse(memory),
+				v1.ResourcePods:   resource.MustParse("10"),
+			},
+		},
+	}
+}
+
+func newPod(namespace, name, node string, phase v1.PodPhase, cpu, memory string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1.PodSpec{
+			NodeName: node,
+			Containers: []v1.Container{{
+				Name: "app",
+				Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse(cpu),
+					v1.ResourceMemory: resource.MustParse(memory),
+				}},
+			}},
+		},
+		Status: v1.PodStatus{Phase: phase},
+	}
+}
+
+func TestGetOverview(t *testing.T) {
+	current := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	defer func() { now = time.Now }()
+
+	replicas := int32(2)
+	crashing := newPod("apps", "crashing", "node-1", v1.PodRunning, "100m", "100Mi")
+	crashing.Status.ContainerStatuses = []v1.ContainerStatus{{
+		Name:  "app",
+		State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+	}}
+
+	client := fake.NewSimpleClientset(
+		newNode("node-1", true, "2", "4Gi"),
+		newNode("node-2", false, "2", "4Gi"),
+		newPod("apps", "web", "node-1", v1.PodRunning, "500m", "1Gi"),
+		crashing,
+		newPod("apps", "done", "node-1", v1.PodSucceeded, "1", "1Gi"),
+		newPod("kube-system", "dns", "node-2", v1.PodRunning, "100m", "100Mi"),
+		&extensions.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "apps"},
+			Spec:       extensions.DeploymentSpec{Replicas: &replicas},
+			Status:     extensions.DeploymentStatus{AvailableReplicas: 1, UpdatedReplicas: 2},
+		},
+		&batch.Job{
+			ObjectMeta: metaV1.ObjectMeta{Name: "migrate", Namespace: "apps"},
+		},
+		&v1.Event{
+			ObjectMeta:    metaV1.ObjectMeta{Name: "recent", Namespace: "apps"},
+			Type:          v1.EventTypeWarning,
+			LastTimestamp: metaV1.NewTime(current.Add(-time.Minute)),
+		},
+		&v1.Event{
+			ObjectMeta:    metaV1.ObjectMeta{Name: "old", Namespace: "apps"},
+			Type:          v1.EventTypeWarning,
+			LastTimestamp: metaV1.NewTime(current.Add(-time.Hour)),
+		},
+		&v1.ComponentStatus{
+			ObjectMeta: metaV1.ObjectMeta{Name: "scheduler"},
+			Conditions: []v1.ComponentCondition{{Type: v1.ComponentHealthy, Status: v1.ConditionTrue, Message: "ok"}},
+		},
+		&v1.ComponentStatus{
+			ObjectMeta: metaV1.ObjectMeta{Name: "etcd-0"},
+			Conditions: []v1.ComponentCondition{{Type: v1.ComponentHealthy, Status: v1.ConditionFalse,
+				Error: "connection refused"}},
+		},
+	)
+
+	actual, err := GetOverview(client)
+	if err != nil {
+		t.Fatalf("GetOverview() returned error: %s", err.Error())
+	}
+
+	expected := &Overview{
+		Nodes: NodeSummary{Total: 2, Ready: 1, NotReady: 1, NotReadyNodes: []string{"node-2"}},
+		ControlPlane: []ComponentHealth{
+			{Name: "etcd-0", Healthy: false, Message: "connection refused"},
+			{Name: "scheduler", Healthy: true, Message: "ok"},
+		},
+		Namespaces: []NamespaceHealth{
+			{Namespace: "apps", Workloads: 2, UnhealthyWorkloads: 1, Pods: 3, FailingPods: 1},
+			{Namespace: "kube-system", Pods: 1},
+		},
+		WarningEvents: WarningEventRate{WindowSeconds: 600, Count: 1, PerMinute: 0.1},
+		Headroom: []ResourceHeadroom{
+			{Resource: "cpu", Allocatable: 2000, Requested: 600, Available: 1400, RequestedFraction: 30},
+			{Resource: "memory", Allocatable: 4 << 30, Requested: 1124 << 20, Available: 4<<30 - 1124<<20,
+				RequestedFraction: float64(1124<<20) / float64(4<<30) * 100},
+			{Resource: "pods", Allocatable: 10, Requested: 2, Available: 8, RequestedFraction: 20},
+		},
+		Errors: make([]error, 0),
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetOverview() == \n%#v\nexpected \n%#v", actual, expected)
+	}
+}
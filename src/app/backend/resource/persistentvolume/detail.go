@@ -15,9 +15,8 @@
 package persistentvolume
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
@@ -38,7 +37,7 @@ type PersistentVolumeDetail struct {
 
 // GetPersistentVolumeDetail returns detailed information about a persistent volume
 func GetPersistentVolumeDetail(client client.Interface, name string) (*PersistentVolumeDetail, error) {
-	log.Printf("Getting details of %s persistent volume", name)
+	logger.Infof("Getting details of %s persistent volume", name)
 
 	rawPersistentVolume, err := client.CoreV1().PersistentVolumes().Get(name, metaV1.GetOptions{})
 	if err != nil {
@@ -15,10 +15,9 @@
 package persistentvolume
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	client "k8s.io/client-go/kubernetes"
@@ -47,12 +46,19 @@ type PersistentVolume struct {
 
 // GetPersistentVolumeList returns a list of all Persistent Volumes in the cluster.
 func GetPersistentVolumeList(client *client.Clientset, dsQuery *dataselect.DataSelectQuery) (*PersistentVolumeList, error) {
-	log.Print("Getting list persistent volumes")
+	logger.Info("Getting list persistent volumes")
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
 	channels := &common.ResourceChannels{
-		PersistentVolumeList: common.GetPersistentVolumeListChannel(client, 1),
+		PersistentVolumeList: common.GetPersistentVolumeListChunkChannel(client, chunk, 1),
+	}
+
+	persistentVolumeList, err := GetPersistentVolumeListFromChannels(channels, dsQuery)
+	if err != nil {
+		return nil, err
 	}
 
-	return GetPersistentVolumeListFromChannels(channels, dsQuery)
+	chunk.SetListMeta(&persistentVolumeList.ListMeta)
+	return persistentVolumeList, nil
 }
 
 // GetPersistentVolumeListFromChannels returns a list of all Persistent Volumes in the cluster
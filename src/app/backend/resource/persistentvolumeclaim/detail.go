@@ -15,9 +15,8 @@
 package persistentvolumeclaim
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
@@ -35,7 +34,7 @@ type PersistentVolumeClaimDetail struct {
 
 // GetPersistentVolumeClaimDetail returns detailed information about a persistent volume claim
 func GetPersistentVolumeClaimDetail(client *client.Clientset, namespace string, name string) (*PersistentVolumeClaimDetail, error) {
-	log.Printf("Getting details of %s persistent volume claim", name)
+	logger.Infof("Getting details of %s persistent volume claim", name)
 
 	rawPersistentVolumeClaim, err := client.PersistentVolumeClaims(namespace).Get(name, metaV1.GetOptions{})
 
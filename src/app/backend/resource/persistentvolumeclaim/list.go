@@ -15,10 +15,9 @@
 package persistentvolumeclaim
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	client "k8s.io/client-go/kubernetes"
@@ -48,12 +47,19 @@ type PersistentVolumeClaim struct {
 func GetPersistentVolumeClaimList(client *client.Clientset, nsQuery *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery) (*PersistentVolumeClaimList, error) {
 
-	log.Print("Getting list persistent volumes claims")
+	logger.Info("Getting list persistent volumes claims")
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
 	channels := &common.ResourceChannels{
-		PersistentVolumeClaimList: common.GetPersistentVolumeClaimListChannel(client, nsQuery, 1),
+		PersistentVolumeClaimList: common.GetPersistentVolumeClaimListChunkChannel(client, nsQuery, chunk, 1),
+	}
+
+	persistentVolumeClaimList, err := GetPersistentVolumeClaimListFromChannels(channels, nsQuery, dsQuery)
+	if err != nil {
+		return nil, err
 	}
 
-	return GetPersistentVolumeClaimListFromChannels(channels, nsQuery, dsQuery)
+	chunk.SetListMeta(&persistentVolumeClaimList.ListMeta)
+	return persistentVolumeClaimList, nil
 }
 
 // GetPersistentVolumeClaimListFromChannels returns a list of all Persistent Volume Claims in the cluster
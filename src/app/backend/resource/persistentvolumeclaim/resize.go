@@ -0,0 +1,102 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistentvolumeclaim
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/storageclass"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// PersistentVolumeClaimResizeSpec describes requested new size of persistent volume claim.
+type PersistentVolumeClaimResizeSpec struct {
+	// Size is the new storage request, i.e. "20Gi". It has to be bigger than the current one.
+	Size string `json:"size"`
+}
+
+// ResizePersistentVolumeClaim increases storage request of persistent volume claim. Claims can be
+// only expanded and only if their storage class allows volume expansion.
+func ResizePersistentVolumeClaim(client client.Interface, namespace, name string,
+	spec *PersistentVolumeClaimResizeSpec) (*PersistentVolumeClaimDetail, error) {
+	return resizePersistentVolumeClaim(client, client.StorageV1().RESTClient(), namespace, name, spec)
+}
+
+func resizePersistentVolumeClaim(client client.Interface, storageClient storageclass.RESTClient, namespace,
+	name string, spec *PersistentVolumeClaimResizeSpec) (*PersistentVolumeClaimDetail, error) {
+	logger.Infof("Resizing %s persistent volume claim in %s namespace to %s", name, namespace, spec.Size)
+
+	size, err := resource.ParseQuantity(spec.Size)
+	if err != nil {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("invalid size %q: %s", spec.Size, err.Error()))
+	}
+
+	claim, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	storageClass := getStorageClassName(claim)
+	if len(storageClass) == 0 {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf(
+			"persistent volume claim %s has no storage class and cannot be expanded", name))
+	}
+	policy, err := storageclass.GetStorageClassPolicy(storageClient, storageClass)
+	if err != nil {
+		return nil, err
+	}
+	if policy.AllowVolumeExpansion == nil || !*policy.AllowVolumeExpansion {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf(
+			"storage class %s does not allow volume expansion", storageClass))
+	}
+
+	current := claim.Spec.Resources.Requests[v1.ResourceStorage]
+	if size.Cmp(current) <= 0 {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf(
+			"new size %s has to be bigger than current size %s", size.String(), current.String()))
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"resources": map[string]interface{}{
+				"requests": map[string]interface{}{string(v1.ResourceStorage): size.String()},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claim, err = client.CoreV1().PersistentVolumeClaims(namespace).Patch(name, types.MergePatchType, patch)
+	if err != nil {
+		return nil, err
+	}
+	return getPersistentVolumeClaimDetail(claim), nil
+}
+
+// getStorageClassName returns storage class of the claim, falling back to the beta annotation.
+func getStorageClassName(claim *v1.PersistentVolumeClaim) string {
+	if claim.Spec.StorageClassName != nil {
+		return *claim.Spec.StorageClassName
+	}
+	return claim.Annotations[v1.BetaStorageClassAnnotation]
+}
@@ -0,0 +1,126 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistentvolumeclaim
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+	core "k8s.io/client-go/testing"
+)
+
+func newTestClaim(storageClass string, size string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metaV1.ObjectMeta{Name: "data", Namespace: "default"},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClass,
+			AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(size)},
+			},
+		},
+		Status: v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound},
+	}
+}
+
+func TestResizePersistentVolumeClaim(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/apis/storage.k8s.io/v1/storageclasses/")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"metadata": {"name": %q}, "allowVolumeExpansion": %t}`, name, name == "expandable")
+	}))
+	defer server.Close()
+	storageClient, err := apply.NewRESTClient(&rest.Config{Host: server.URL},
+		schema.GroupVersion{Group: "storage.k8s.io", Version: "v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		claim        *v1.PersistentVolumeClaim
+		size         string
+		expectedCode int32
+	}{
+		{newTestClaim("expandable", "10Gi"), "20Gi", 0},
+		{newTestClaim("expandable", "10Gi"), "5Gi", 400},
+		{newTestClaim("expandable", "10Gi"), "10Gi", 400},
+		{newTestClaim("expandable", "10Gi"), "big", 400},
+		{newTestClaim("fixed", "10Gi"), "20Gi", 400},
+		{newTestClaim("", "10Gi"), "20Gi", 400},
+	}
+
+	for _, c := range cases {
+		fakeClient := fake.NewSimpleClientset(c.claim)
+		var patch string
+		fakeClient.PrependReactor("patch", "persistentvolumeclaims",
+			func(action core.Action) (bool, runtime.Object, error) {
+				patch = string(action.(core.PatchActionImpl).Patch)
+				resized := newTestClaim("expandable", c.size)
+				return true, resized, nil
+			})
+
+		actual, err := resizePersistentVolumeClaim(fakeClient, storageClient, "default", "data",
+			&PersistentVolumeClaimResizeSpec{Size: c.size})
+
+		if c.expectedCode != 0 {
+			statusErr, ok := err.(*errorsK8s.StatusError)
+			if !ok || statusErr.ErrStatus.Code != c.expectedCode {
+				t.Errorf("ResizePersistentVolumeClaim(%s, %s) == error %v, expected code %d",
+					*c.claim.Spec.StorageClassName, c.size, err, c.expectedCode)
+			}
+			if len(patch) > 0 {
+				t.Errorf("ResizePersistentVolumeClaim(%s, %s) patched claim, expected no patch",
+					*c.claim.Spec.StorageClassName, c.size)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("ResizePersistentVolumeClaim(%s) returned error: %s", c.size, err)
+		}
+		expectedPatch := `{"spec":{"resources":{"requests":{"storage":"20Gi"}}}}`
+		if patch != expectedPatch {
+			t.Errorf("ResizePersistentVolumeClaim(%s) sent patch %s, expected %s", c.size, patch,
+				expectedPatch)
+		}
+		if actual.ObjectMeta.Name != "data" {
+			t.Errorf("ResizePersistentVolumeClaim(%s) == %#v, expected detail of data claim", c.size, actual)
+		}
+	}
+}
+
+func TestGetStorageClassName(t *testing.T) {
+	annotated := &v1.PersistentVolumeClaim{ObjectMeta: metaV1.ObjectMeta{
+		Annotations: map[string]string{v1.BetaStorageClassAnnotation: "legacy"},
+	}}
+	if actual := getStorageClassName(annotated); actual != "legacy" {
+		t.Errorf("getStorageClassName(annotated) == %s, expected legacy", actual)
+	}
+	if actual := getStorageClassName(newTestClaim("standard", "1Gi")); actual != "standard" {
+		t.Errorf("getStorageClassName(claim) == %s, expected standard", actual)
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistentvolumeclaim
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+// SnapshotGroupVersion is the group version of volume snapshot API.
+var SnapshotGroupVersion = schema.GroupVersion{Group: "snapshot.storage.k8s.io", Version: "v1"}
+
+const volumeSnapshotResource = "volumesnapshots"
+
+// VolumeSnapshot provides the presentation layer view of volume snapshot resource.
+type VolumeSnapshot struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// PersistentVolumeClaim is the name of snapshotted claim.
+	PersistentVolumeClaim string `json:"persistentVolumeClaim"`
+	VolumeSnapshotClass   string `json:"volumeSnapshotClass"`
+	ReadyToUse            bool   `json:"readyToUse"`
+
+	// RestoreSize is the minimum size of a claim restored from the snapshot.
+	RestoreSize string `json:"restoreSize"`
+
+	// Error is the last error reported by the snapshot controller.
+	Error string `json:"error"`
+}
+
+// VolumeSnapshotList contains a list of volume snapshots.
+type VolumeSnapshotList struct {
+	ListMeta  api.ListMeta     `json:"listMeta"`
+	Snapshots []VolumeSnapshot `json:"snapshots"`
+}
+
+// VolumeSnapshotSpec describes volume snapshot to create.
+type VolumeSnapshotSpec struct {
+	Name string `json:"name"`
+
+	// VolumeSnapshotClass is optional. Default snapshot class is used when it is empty.
+	VolumeSnapshotClass string `json:"volumeSnapshotClass"`
+}
+
+// VolumeSnapshotRestoreSpec describes persistent volume claim to create from the snapshot.
+type VolumeSnapshotRestoreSpec struct {
+	Name string `json:"name"`
+
+	// StorageClass is optional. Storage class of snapshotted claim is used when it is empty.
+	StorageClass string `json:"storageClass"`
+
+	// Size is optional. Restore size of the snapshot is used when it is empty.
+	Size string `json:"size"`
+}
+
+// volumeSnapshot is the API representation of volume snapshot. Client library does not
+// contain snapshot types, so only the fields used by Dashboard are declared.
+type volumeSnapshot struct {
+	metaV1.TypeMeta   `json:",inline"`
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              volumeSnapshotSpec    `json:"spec"`
+	Status            *volumeSnapshotStatus `json:"status,omitempty"`
+}
+
+type volumeSnapshotSpec struct {
+	Source                  volumeSnapshotSource `json:"source"`
+	VolumeSnapshotClassName *string              `json:"volumeSnapshotClassName,omitempty"`
+}
+
+type volumeSnapshotSource struct {
+	PersistentVolumeClaimName *string `json:"persistentVolumeClaimName,omitempty"`
+}
+
+type volumeSnapshotStatus struct {
+	ReadyToUse  *bool                `json:"readyToUse,omitempty"`
+	RestoreSize *resource.Quantity   `json:"restoreSize,omitempty"`
+	Error       *volumeSnapshotError `json:"error,omitempty"`
+}
+
+type volumeSnapshotError struct {
+	Message *string `json:"message,omitempty"`
+}
+
+type volumeSnapshotList struct {
+	Items []volumeSnapshot `json:"items"`
+}
+
+// GetVolumeSnapshotList returns volume snapshots of the persistent volume claim.
+func GetVolumeSnapshotList(config *rest.Config, namespace, claim string) (*VolumeSnapshotList, error) {
+	logger.Infof("Getting volume snapshots of %s persistent volume claim in %s namespace", claim, namespace)
+
+	restClient, err := apply.NewRESTClient(config, SnapshotGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := restClient.Get().Namespace(namespace).Resource(volumeSnapshotResource).Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := volumeSnapshotList{}
+	if err := json.Unmarshal(raw, &snapshots); err != nil {
+		return nil, err
+	}
+	return toVolumeSnapshotList(snapshots.Items, claim), nil
+}
+
+// CreateVolumeSnapshot creates snapshot of the bound persistent volume claim.
+func CreateVolumeSnapshot(client client.Interface, config *rest.Config, namespace, claim string,
+	spec *VolumeSnapshotSpec) (*VolumeSnapshot, error) {
+	logger.Infof("Creating %s volume snapshot of %s persistent volume claim in %s namespace", spec.Name,
+		claim, namespace)
+
+	if len(spec.Name) == 0 {
+		return nil, errorsK8s.NewBadRequest("volume snapshot name is required")
+	}
+	pvc, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(claim, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if pvc.Status.Phase != v1.ClaimBound {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf(
+			"persistent volume claim %s is not bound and cannot be snapshotted", claim))
+	}
+
+	body, err := json.Marshal(newVolumeSnapshot(namespace, claim, spec))
+	if err != nil {
+		return nil, err
+	}
+	restClient, err := apply.NewRESTClient(config, SnapshotGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := restClient.Post().Namespace(namespace).Resource(volumeSnapshotResource).Body(body).Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	created := volumeSnapshot{}
+	if err := json.Unmarshal(raw, &created); err != nil {
+		return nil, err
+	}
+	return toVolumeSnapshot(created), nil
+}
+
+// RestoreVolumeSnapshot creates new persistent volume claim populated with data of the snapshot.
+func RestoreVolumeSnapshot(client client.Interface, config *rest.Config, namespace, name string,
+	spec *VolumeSnapshotRestoreSpec) (*PersistentVolumeClaimDetail, error) {
+	logger.Infof("Restoring %s volume snapshot in %s namespace to %s persistent volume claim", name,
+		namespace, spec.Name)
+
+	restClient, err := apply.NewRESTClient(config, SnapshotGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := restClient.Get().Namespace(namespace).Resource(volumeSnapshotResource).Name(name).Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+	snapshot := volumeSnapshot{}
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, err
+	}
+
+	var source *v1.PersistentVolumeClaim
+	if claim := snapshot.Spec.Source.PersistentVolumeClaimName; claim != nil {
+		source, err = client.CoreV1().PersistentVolumeClaims(namespace).Get(*claim, metaV1.GetOptions{})
+		if err != nil && !errorsK8s.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	pvc, err := newRestoredClaim(namespace, &snapshot, source, spec)
+	if err != nil {
+		return nil, err
+	}
+	created, err := createClaimFromSnapshot(client.CoreV1().RESTClient(), namespace, pvc)
+	if err != nil {
+		return nil, err
+	}
+	return getPersistentVolumeClaimDetail(created), nil
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Post() *rest.Request
+}
+
+// createClaimFromSnapshot creates persistent volume claim with snapshot data source. Data source
+// is not part of the typed claim yet, so the claim is passed as a raw object. Rest client is the
+// client of core API group.
+func createClaimFromSnapshot(restClient RESTClient, namespace string,
+	claim map[string]interface{}) (*v1.PersistentVolumeClaim, error) {
+	body, err := json.Marshal(claim)
+	if err != nil {
+		return nil, err
+	}
+
+	created := &v1.PersistentVolumeClaim{}
+	err = restClient.Post().
+		Namespace(namespace).
+		Resource("persistentvolumeclaims").
+		Body(body).
+		Do().
+		Into(created)
+	return created, err
+}
+
+// newRestoredClaim builds raw persistent volume claim restoring the snapshot. Source claim is
+// used for defaults and may be nil when it was already deleted.
+func newRestoredClaim(namespace string, snapshot *volumeSnapshot, source *v1.PersistentVolumeClaim,
+	spec *VolumeSnapshotRestoreSpec) (map[string]interface{}, error) {
+	if len(spec.Name) == 0 {
+		return nil, errorsK8s.NewBadRequest("persistent volume claim name is required")
+	}
+	if snapshot.Status == nil || snapshot.Status.ReadyToUse == nil || !*snapshot.Status.ReadyToUse {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("volume snapshot %s is not ready to use",
+			snapshot.Name))
+	}
+
+	var size resource.Quantity
+	switch {
+	case len(spec.Size) > 0:
+		quantity, err := resource.ParseQuantity(spec.Size)
+		if err != nil {
+			return nil, errorsK8s.NewBadRequest(fmt.Sprintf("invalid size %q: %s", spec.Size, err.Error()))
+		}
+		size = quantity
+	case snapshot.Status.RestoreSize != nil:
+		size = *snapshot.Status.RestoreSize
+	case source != nil:
+		size = source.Spec.Resources.Requests[v1.ResourceStorage]
+	}
+	if size.IsZero() {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf(
+			"size of persistent volume claim restored from %s snapshot is required", snapshot.Name))
+	}
+	if snapshot.Status.RestoreSize != nil && size.Cmp(*snapshot.Status.RestoreSize) < 0 {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("size %s is smaller than restore size %s",
+			size.String(), snapshot.Status.RestoreSize.String()))
+	}
+
+	claim := &v1.PersistentVolumeClaim{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+		ObjectMeta: metaV1.ObjectMeta{Name: spec.Name, Namespace: namespace},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: size},
+			},
+		},
+	}
+	storageClass := spec.StorageClass
+	if source != nil {
+		claim.Spec.AccessModes = source.Spec.AccessModes
+		if len(storageClass) == 0 {
+			storageClass = getStorageClassName(source)
+		}
+	}
+	if len(storageClass) > 0 {
+		claim.Spec.StorageClassName = &storageClass
+	}
+
+	raw, err := json.Marshal(claim)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+	delete(result, "status")
+	result["spec"].(map[string]interface{})["dataSource"] = map[string]interface{}{
+		"apiGroup": SnapshotGroupVersion.Group,
+		"kind":     "VolumeSnapshot",
+		"name":     snapshot.Name,
+	}
+	return result, nil
+}
+
+// newVolumeSnapshot builds volume snapshot of the persistent volume claim.
+func newVolumeSnapshot(namespace, claim string, spec *VolumeSnapshotSpec) *volumeSnapshot {
+	snapshot := &volumeSnapshot{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: SnapshotGroupVersion.String(), Kind: "VolumeSnapshot"},
+		ObjectMeta: metaV1.ObjectMeta{Name: spec.Name, Namespace: namespace},
+		Spec:       volumeSnapshotSpec{Source: volumeSnapshotSource{PersistentVolumeClaimName: &claim}},
+	}
+	if len(spec.VolumeSnapshotClass) > 0 {
+		class := spec.VolumeSnapshotClass
+		snapshot.Spec.VolumeSnapshotClassName = &class
+	}
+	return snapshot
+}
+
+func toVolumeSnapshotList(snapshots []volumeSnapshot, claim string) *VolumeSnapshotList {
+	result := &VolumeSnapshotList{Snapshots: make([]VolumeSnapshot, 0)}
+	for _, snapshot := range snapshots {
+		source := snapshot.Spec.Source.PersistentVolumeClaimName
+		if source != nil && *source == claim {
+			result.Snapshots = append(result.Snapshots, *toVolumeSnapshot(snapshot))
+		}
+	}
+	result.ListMeta = api.ListMeta{TotalItems: len(result.Snapshots)}
+	return result
+}
+
+func toVolumeSnapshot(snapshot volumeSnapshot) *VolumeSnapshot {
+	result := &VolumeSnapshot{
+		ObjectMeta: api.NewObjectMeta(snapshot.ObjectMeta),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindVolumeSnapshot),
+	}
+	if snapshot.Spec.Source.PersistentVolumeClaimName != nil {
+		result.PersistentVolumeClaim = *snapshot.Spec.Source.PersistentVolumeClaimName
+	}
+	if snapshot.Spec.VolumeSnapshotClassName != nil {
+		result.VolumeSnapshotClass = *snapshot.Spec.VolumeSnapshotClassName
+	}
+	if status := snapshot.Status; status != nil {
+		result.ReadyToUse = status.ReadyToUse != nil && *status.ReadyToUse
+		if status.RestoreSize != nil {
+			result.RestoreSize = status.RestoreSize.String()
+		}
+		if status.Error != nil && status.Error.Message != nil {
+			result.Error = *status.Error.Message
+		}
+	}
+	return result
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistentvolumeclaim
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func newTestSnapshot(name, claim string, ready bool, restoreSize string) volumeSnapshot {
+	size := resource.MustParse(restoreSize)
+	return volumeSnapshot{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       volumeSnapshotSpec{Source: volumeSnapshotSource{PersistentVolumeClaimName: &claim}},
+		Status:     &volumeSnapshotStatus{ReadyToUse: &ready, RestoreSize: &size},
+	}
+}
+
+func TestToVolumeSnapshotList(t *testing.T) {
+	message := "snapshot failed"
+	failed := newTestSnapshot("failed", "data", false, "10Gi")
+	failed.Status.Error = &volumeSnapshotError{Message: &message}
+	snapshots := []volumeSnapshot{
+		newTestSnapshot("nightly", "data", true, "10Gi"),
+		newTestSnapshot("other", "logs", true, "1Gi"),
+		failed,
+	}
+
+	actual := toVolumeSnapshotList(snapshots, "data")
+
+	expected := &VolumeSnapshotList{
+		ListMeta: api.ListMeta{TotalItems: 2},
+		Snapshots: []VolumeSnapshot{
+			{
+				ObjectMeta:            api.ObjectMeta{Name: "nightly", Namespace: "default"},
+				TypeMeta:              api.TypeMeta{Kind: api.ResourceKindVolumeSnapshot},
+				PersistentVolumeClaim: "data",
+				ReadyToUse:            true,
+				RestoreSize:           "10Gi",
+			},
+			{
+				ObjectMeta:            api.ObjectMeta{Name: "failed", Namespace: "default"},
+				TypeMeta:              api.TypeMeta{Kind: api.ResourceKindVolumeSnapshot},
+				PersistentVolumeClaim: "data",
+				RestoreSize:           "10Gi",
+				Error:                 "snapshot failed",
+			},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("toVolumeSnapshotList() == %#v, expected %#v", actual, expected)
+	}
+}
+
+func TestNewVolumeSnapshot(t *testing.T) {
+	snapshot := newVolumeSnapshot("default", "data",
+		&VolumeSnapshotSpec{Name: "nightly", VolumeSnapshotClass: "csi"})
+
+	raw, _ := json.Marshal(snapshot)
+	expected := `{"kind":"VolumeSnapshot","apiVersion":"snapshot.storage.k8s.io/v1",` +
+		`"metadata":{"name":"nightly","namespace":"default","creationTimestamp":null},` +
+		`"spec":{"source":{"persistentVolumeClaimName":"data"},"volumeSnapshotClassName":"csi"}}`
+	if string(raw) != expected {
+		t.Errorf("newVolumeSnapshot() == %s, expected %s", raw, expected)
+	}
+}
+
+func TestNewRestoredClaim(t *testing.T) {
+	source := newTestClaim("standard", "10Gi")
+	source.Spec.AccessModes = []v1.PersistentVolumeAccessMode{v1.ReadWriteMany}
+	ready := newTestSnapshot("nightly", "data", true, "10Gi")
+	notReady := newTestSnapshot("nightly", "data", false, "10Gi")
+
+	cases := []struct {
+		snapshot     volumeSnapshot
+		source       bool
+		spec         VolumeSnapshotRestoreSpec
+		expected     string
+		expectedCode int32
+	}{
+		{
+			ready, true, VolumeSnapshotRestoreSpec{Name: "restored"},
+			`{"apiVersion":"v1","kind":"PersistentVolumeClaim","metadata":{"creationTimestamp":null,` +
+				`"name":"restored","namespace":"default"},"spec":{"accessModes":["ReadWriteMany"],` +
+				`"dataSource":{"apiGroup":"snapshot.storage.k8s.io","kind":"VolumeSnapshot","name":"nightly"},` +
+				`"resources":{"requests":{"storage":"10Gi"}},"storageClassName":"standard"}}`, 0,
+		},
+		{
+			ready, false, VolumeSnapshotRestoreSpec{Name: "restored", Size: "20Gi", StorageClass: "fast"},
+			`{"apiVersion":"v1","kind":"PersistentVolumeClaim","metadata":{"creationTimestamp":null,` +
+				`"name":"restored","namespace":"default"},"spec":{"accessModes":["ReadWriteOnce"],` +
+				`"dataSource":{"apiGroup":"snapshot.storage.k8s.io","kind":"VolumeSnapshot","name":"nightly"},` +
+				`"resources":{"requests":{"storage":"20Gi"}},"storageClassName":"fast"}}`, 0,
+		},
+		{ready, true, VolumeSnapshotRestoreSpec{}, "", 400},
+		{ready, true, VolumeSnapshotRestoreSpec{Name: "restored", Size: "5Gi"}, "", 400},
+		{notReady, true, VolumeSnapshotRestoreSpec{Name: "restored"}, "", 400},
+	}
+
+	for _, c := range cases {
+		claimSource := source
+		if !c.source {
+			claimSource = nil
+		}
+		actual, err := newRestoredClaim("default", &c.snapshot, claimSource, &c.spec)
+
+		if c.expectedCode != 0 {
+			statusErr, ok := err.(*errorsK8s.StatusError)
+			if !ok || statusErr.ErrStatus.Code != c.expectedCode {
+				t.Errorf("newRestoredClaim(%#v) == error %v, expected code %d", c.spec, err, c.expectedCode)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("newRestoredClaim(%#v) returned error: %s", c.spec, err)
+		}
+		raw, _ := json.Marshal(actual)
+		if string(raw) != c.expected {
+			t.Errorf("newRestoredClaim(%#v) == %s, expected %s", c.spec, raw, c.expected)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	policy "k8s.io/client-go/pkg/apis/policy/v1beta1"
+)
+
+// PodDeleteOptions control how pods are evicted or deleted.
+type PodDeleteOptions struct {
+	// Grace period in seconds, pod's own grace period is used when nil.
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds"`
+
+	// Force removes pods immediately, overriding the grace period with 0.
+	Force bool `json:"force"`
+}
+
+// PodReference identifies a single pod.
+type PodReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// PodBatchDeleteSpec is a request to delete multiple pods at once.
+type PodBatchDeleteSpec struct {
+	PodDeleteOptions `json:",inline"`
+	Pods             []PodReference `json:"pods"`
+}
+
+// PodActionResult is the result of an action on a single pod.
+type PodActionResult struct {
+	PodReference `json:",inline"`
+	Succeeded    bool   `json:"succeeded"`
+	Error        string `json:"error,omitempty"`
+}
+
+// PodBatchDeleteResult contains results of all pods of the batch delete request.
+type PodBatchDeleteResult struct {
+	Results []PodActionResult `json:"results"`
+}
+
+// toDeleteOptions converts pod delete options to Kubernetes delete options.
+func toDeleteOptions(options *PodDeleteOptions) *metaV1.DeleteOptions {
+	gracePeriod := options.GracePeriodSeconds
+	if options.Force {
+		zero := int64(0)
+		gracePeriod = &zero
+	}
+	return &metaV1.DeleteOptions{GracePeriodSeconds: gracePeriod}
+}
+
+// EvictPod evicts the pod using the Eviction API, so that pod disruption budgets are respected.
+// TooManyRequests error is returned when the eviction would violate a disruption budget.
+func EvictPod(client client.Interface, namespace, name string, options *PodDeleteOptions) error {
+	logger.Infof("Evicting %s pod in %s namespace", name, namespace)
+
+	// Make sure the pod exists, as the eviction of missing pod is not reported as an error by
+	// all apiserver versions.
+	if _, err := client.CoreV1().Pods(namespace).Get(name, metaV1.GetOptions{}); err != nil {
+		return err
+	}
+
+	return client.PolicyV1beta1().Evictions(namespace).Evict(&policy.Eviction{
+		ObjectMeta:    metaV1.ObjectMeta{Name: name, Namespace: namespace},
+		DeleteOptions: toDeleteOptions(options),
+	})
+}
+
+// DeletePods deletes all pods of the spec. A failure of a single pod does not stop deletion of
+// others, results are reported per pod.
+func DeletePods(client client.Interface, spec *PodBatchDeleteSpec) (*PodBatchDeleteResult, error) {
+	if len(spec.Pods) == 0 {
+		return nil, errorsK8s.NewBadRequest("no pods to delete")
+	}
+
+	logger.Infof("Deleting %d pods", len(spec.Pods))
+
+	deleteOptions := toDeleteOptions(&spec.PodDeleteOptions)
+	result := &PodBatchDeleteResult{Results: make([]PodActionResult, 0, len(spec.Pods))}
+	for _, ref := range spec.Pods {
+		podResult := PodActionResult{PodReference: ref, Succeeded: true}
+		if err := client.CoreV1().Pods(ref.Namespace).Delete(ref.Name, deleteOptions); err != nil {
+			podResult.Succeeded = false
+			podResult.Error = err.Error()
+		}
+		result.Results = append(result.Results, podResult)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	core "k8s.io/client-go/testing"
+)
+
+func TestEvictPod(t *testing.T) {
+	gracePeriod := int64(10)
+	zero := int64(0)
+	cases := []struct {
+		options             *PodDeleteOptions
+		expectedGracePeriod *int64
+	}{
+		{&PodDeleteOptions{}, nil},
+		{&PodDeleteOptions{GracePeriodSeconds: &gracePeriod}, &gracePeriod},
+		{&PodDeleteOptions{GracePeriodSeconds: &gracePeriod, Force: true}, &zero},
+	}
+
+	for _, c := range cases {
+		fakeClient := fake.NewSimpleClientset(&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "pod-1", Namespace: "ns-1"},
+		})
+		// Fake evictions are get actions without name, which the object tracker can not handle.
+		fakeClient.PrependReactor("post", "pods", func(action core.Action) (bool, runtime.Object,
+			error) {
+			return true, nil, nil
+		})
+
+		if err := EvictPod(fakeClient, "ns-1", "pod-1", c.options); err != nil {
+			t.Errorf("EvictPod(%#v) returns error: %v", c.options, err)
+			continue
+		}
+
+		actions := fakeClient.Actions()
+		eviction := actions[len(actions)-1].(core.GetActionImpl)
+		if eviction.Subresource != "eviction" {
+			t.Errorf("EvictPod(%#v) calls %#v, expected eviction", c.options, eviction)
+		}
+	}
+
+	for _, c := range cases {
+		actual := toDeleteOptions(c.options).GracePeriodSeconds
+		if !reflect.DeepEqual(actual, c.expectedGracePeriod) {
+			t.Errorf("toDeleteOptions(%#v) returns grace period %#v, expected %#v", c.options,
+				actual, c.expectedGracePeriod)
+		}
+	}
+}
+
+func TestEvictMissingPod(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset()
+	if err := EvictPod(fakeClient, "ns-1", "pod-1", &PodDeleteOptions{}); err == nil {
+		t.Errorf("EvictPod() of missing pod returns no error")
+	}
+}
+
+func TestDeletePods(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pod-1", Namespace: "ns-1"}},
+		&v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pod-2", Namespace: "ns-2"}},
+	)
+	spec := &PodBatchDeleteSpec{Pods: []PodReference{
+		{Namespace: "ns-1", Name: "pod-1"},
+		{Namespace: "ns-1", Name: "missing"},
+		{Namespace: "ns-2", Name: "pod-2"},
+	}}
+
+	result, err := DeletePods(fakeClient, spec)
+	if err != nil {
+		t.Fatalf("DeletePods(%#v) returns error: %v", spec, err)
+	}
+
+	succeeded := make([]bool, 0)
+	for _, podResult := range result.Results {
+		succeeded = append(succeeded, podResult.Succeeded)
+	}
+	expected := []bool{true, false, true}
+	if !reflect.DeepEqual(succeeded, expected) {
+		t.Errorf("DeletePods(%#v) returns %#v, expected succeeded %#v", spec, result, expected)
+	}
+
+	if _, err := DeletePods(fakeClient, &PodBatchDeleteSpec{}); err == nil {
+		t.Errorf("DeletePods() without pods returns no error")
+	}
+}
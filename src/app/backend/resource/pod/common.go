@@ -19,6 +19,7 @@ import (
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/pkg/api/v1"
 )
 
@@ -86,6 +87,14 @@ func getPodStatusStatus(pod v1.Pod, warnings []common.Event) string {
 
 type PodCell v1.Pod
 
+// GetFields returns pod fields that can be used in field selectors.
+func (self PodCell) GetFields() fields.Set {
+	return fields.Set{
+		"spec.nodeName": self.Spec.NodeName,
+		"status.phase":  string(self.Status.Phase),
+	}
+}
+
 func (self PodCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
 	switch name {
 	case dataselect.NameProperty:
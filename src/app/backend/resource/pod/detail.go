@@ -18,15 +18,17 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"log"
 	"math"
 	"strconv"
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	errorHandler "github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/priorityclass"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/servicemesh"
 
 	"github.com/kubernetes/dashboard/src/app/backend/resource/controller"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -76,6 +78,12 @@ type PodDetail struct {
 	// Events is list of events associated with a pod.
 	EventList common.EventList `json:"eventList"`
 
+	// Service mesh status of the pod. Set only when a service mesh is installed in the cluster.
+	Mesh *servicemesh.PodMesh `json:"mesh,omitempty"`
+
+	// Scheduling priority of the pod with preemptions it caused or suffered.
+	Priority *priorityclass.PodPriority `json:"priority,omitempty"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -96,6 +104,54 @@ type Container struct {
 
 	// Command arguments
 	Args []string `json:"args"`
+
+	// Resources requested and limited by the container compared with its current usage.
+	Resources ContainerResources `json:"resources"`
+
+	// Count of restarts of the container.
+	RestartCount int32 `json:"restartCount"`
+
+	// Termination of the previous instance of the container. Nil if it has not been restarted.
+	LastTermination *ContainerTermination `json:"lastTermination"`
+
+	// OOMKilled is true if the container was killed for running out of memory last time it
+	// terminated.
+	OOMKilled bool `json:"oomKilled"`
+}
+
+// ContainerTermination describes why and when a container terminated. Logs of the terminated
+// container can be read as previous logs until it is restarted again.
+type ContainerTermination struct {
+	ExitCode   int32       `json:"exitCode"`
+	Signal     int32       `json:"signal"`
+	Reason     string      `json:"reason"`
+	Message    string      `json:"message"`
+	StartedAt  metaV1.Time `json:"startedAt"`
+	FinishedAt metaV1.Time `json:"finishedAt"`
+}
+
+// ContainerResources compares usage of a container with its requests and limits. CPU is in
+// millicores and memory in bytes. Zero request or limit means that it is not set.
+type ContainerResources struct {
+	CPURequests    int64 `json:"cpuRequests"`
+	CPULimits      int64 `json:"cpuLimits"`
+	MemoryRequests int64 `json:"memoryRequests"`
+	MemoryLimits   int64 `json:"memoryLimits"`
+
+	// Usage is nil if it is not known, i.e. metrics are not available or container is not running.
+	CPUUsage    *uint64 `json:"cpuUsage"`
+	MemoryUsage *uint64 `json:"memoryUsage"`
+
+	// Share of CPU periods, in which the container was throttled. Nil if it is not known.
+	CPUThrottledRatio *float64 `json:"cpuThrottledRatio"`
+
+	// CPUThrottled is true if the container was throttled in a significant share of CPU periods,
+	// or uses nearly all of its CPU limit when throttling is not known.
+	CPUThrottled bool `json:"cpuThrottled"`
+
+	// MemoryPressure is true if the container uses nearly all of its memory limit, or it was
+	// killed for running out of memory last time it terminated.
+	MemoryPressure bool `json:"memoryPressure"`
 }
 
 // EnvVar represents an environment variable of a container.
@@ -115,7 +171,7 @@ type EnvVar struct {
 // GetPodDetail returns the details (PodDetail) of a named Pod from a particular namespace.
 // TODO(maciaszczykm): Owner reference should be used instead of created by annotation.
 func GetPodDetail(client kubernetes.Interface, metricClient metricapi.MetricClient, namespace, name string) (*PodDetail, error) {
-	log.Printf("Getting details of %s pod in %s namespace", name, namespace)
+	logger.Infof("Getting details of %s pod in %s namespace", name, namespace)
 
 	channels := &common.ResourceChannels{
 		ConfigMapList: common.GetConfigMapListChannel(client, common.NewSameNamespaceQuery(namespace), 1),
@@ -137,6 +193,8 @@ func GetPodDetail(client kubernetes.Interface, metricClient metricapi.MetricClie
 		controller = *creatorRef
 	}
 
+	containerUsage := getContainerUsage(metricClient, pod)
+
 	_, metricPromises := dataselect.GenericDataSelectWithMetrics(toCells([]v1.Pod{*pod}),
 		dataselect.StdMetricsDataSelect, metricapi.NoResourceCache, metricClient)
 	metrics, _ := metricPromises.GetMetrics()
@@ -161,7 +219,8 @@ func GetPodDetail(client kubernetes.Interface, metricClient metricapi.MetricClie
 		return nil, criticalError
 	}
 
-	podDetail := toPodDetail(pod, metrics, configMapList, secretList, controller, eventList, nonCriticalErrors)
+	podDetail := toPodDetail(pod, metrics, containerUsage, configMapList, secretList, controller, eventList,
+		nonCriticalErrors)
 	return &podDetail, nil
 }
 
@@ -225,7 +284,8 @@ func isNotFoundError(err error) bool {
 	return statusErr.ErrStatus.Code == 404
 }
 
-func extractContainerInfo(containerList []v1.Container, pod *v1.Pod, configMaps *v1.ConfigMapList, secrets *v1.SecretList) []Container {
+func extractContainerInfo(containerList []v1.Container, pod *v1.Pod, usage map[string]metricapi.ContainerUsage,
+	configMaps *v1.ConfigMapList, secrets *v1.SecretList) []Container {
 	containers := make([]Container, 0)
 	for _, container := range containerList {
 		vars := make([]EnvVar, 0)
@@ -241,19 +301,60 @@ func extractContainerInfo(containerList []v1.Container, pod *v1.Pod, configMaps
 			}
 			vars = append(vars, variable)
 		}
+		status := getContainerStatus(pod, container.Name)
 		containers = append(containers, Container{
-			Name:     container.Name,
-			Image:    container.Image,
-			Env:      vars,
-			Commands: container.Command,
-			Args:     container.Args,
+			Name:            container.Name,
+			Image:           container.Image,
+			Env:             vars,
+			Commands:        container.Command,
+			Args:            container.Args,
+			Resources:       toContainerResources(container, pod, usage[container.Name]),
+			RestartCount:    status.RestartCount,
+			LastTermination: toContainerTermination(status.LastTerminationState.Terminated),
+			OOMKilled:       isOOMKilled(status),
 		})
 	}
 	return containers
 }
 
-func toPodDetail(pod *v1.Pod, metrics []metricapi.Metric, configMaps *v1.ConfigMapList, secrets *v1.SecretList,
-	controller controller.ResourceOwner, events *common.EventList, nonCriticalErrors []error) PodDetail {
+// getContainerStatus returns status of the container, or empty status if the container has not
+// been created yet.
+func getContainerStatus(pod *v1.Pod, name string) v1.ContainerStatus {
+	for _, status := range append(pod.Status.ContainerStatuses, pod.Status.InitContainerStatuses...) {
+		if status.Name == name {
+			return status
+		}
+	}
+	return v1.ContainerStatus{}
+}
+
+func toContainerTermination(state *v1.ContainerStateTerminated) *ContainerTermination {
+	if state == nil {
+		return nil
+	}
+	return &ContainerTermination{
+		ExitCode:   state.ExitCode,
+		Signal:     state.Signal,
+		Reason:     state.Reason,
+		Message:    state.Message,
+		StartedAt:  state.StartedAt,
+		FinishedAt: state.FinishedAt,
+	}
+}
+
+// isOOMKilled returns true if the last terminated instance of the container, which may be the
+// current one, was killed for running out of memory.
+func isOOMKilled(status v1.ContainerStatus) bool {
+	terminated := status.State.Terminated
+	if terminated == nil {
+		terminated = status.LastTerminationState.Terminated
+	}
+	return terminated != nil && terminated.Reason == oomKilledReason
+}
+
+func toPodDetail(pod *v1.Pod, metrics []metricapi.Metric, containerUsage map[string]metricapi.ContainerUsage,
+	configMaps *v1.ConfigMapList, secrets *v1.SecretList, controller controller.ResourceOwner,
+	events *common.EventList, nonCriticalErrors []error) PodDetail {
 	return PodDetail{
 		ObjectMeta:     api.NewObjectMeta(pod.ObjectMeta),
 		TypeMeta:       api.NewTypeMeta(api.ResourceKindPod),
@@ -262,8 +363,8 @@ func toPodDetail(pod *v1.Pod, metrics []metricapi.Metric, configMaps *v1.ConfigM
 		RestartCount:   getRestartCount(*pod),
 		NodeName:       pod.Spec.NodeName,
 		Controller:     controller,
-		Containers:     extractContainerInfo(pod.Spec.Containers, pod, configMaps, secrets),
-		InitContainers: extractContainerInfo(pod.Spec.InitContainers, pod, configMaps, secrets),
+		Containers:     extractContainerInfo(pod.Spec.Containers, pod, containerUsage, configMaps, secrets),
+		InitContainers: extractContainerInfo(pod.Spec.InitContainers, pod, containerUsage, configMaps, secrets),
 		Metrics:        metrics,
 		Conditions:     getPodConditions(*pod),
 		EventList:      *events,
@@ -305,12 +406,12 @@ func evalValueFrom(src *v1.EnvVarSource, container *v1.Container, pod *v1.Pod,
 		internalFieldPath, _, err := kubeapi.Scheme.ConvertFieldLabel(src.FieldRef.APIVersion,
 			"Pod", src.FieldRef.FieldPath, "")
 		if err != nil {
-			log.Println(err)
+			logger.Error(err)
 			return ""
 		}
 		valueFrom, err := fieldpath.ExtractFieldPathAsString(pod, internalFieldPath)
 		if err != nil {
-			log.Println(err)
+			logger.Error(err)
 			return ""
 		}
 		return valueFrom
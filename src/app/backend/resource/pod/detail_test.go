@@ -144,3 +144,34 @@ func TestEvalValueFrom(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractContainerInfoTermination(t *testing.T) {
+	finished := metaV1.Unix(1500000000, 0)
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app"}, {Name: "sidecar"}}},
+		Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{
+			Name:         "app",
+			RestartCount: 3,
+			State:        v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+				ExitCode: 137, Signal: 9, Reason: "OOMKilled", FinishedAt: finished,
+			}},
+		}}},
+	}
+
+	containers := extractContainerInfo(pod.Spec.Containers, pod, nil, &v1.ConfigMapList{}, &v1.SecretList{})
+
+	expected := &ContainerTermination{ExitCode: 137, Signal: 9, Reason: "OOMKilled", FinishedAt: finished}
+	app := containers[0]
+	if app.RestartCount != 3 || !app.OOMKilled || !reflect.DeepEqual(app.LastTermination, expected) {
+		t.Errorf("extractContainerInfo() == %#v, expected restarted OOM killed container", app)
+	}
+	if !app.Resources.MemoryPressure {
+		t.Error("Expected memory pressure of OOM killed container")
+	}
+
+	sidecar := containers[1]
+	if sidecar.RestartCount != 0 || sidecar.OOMKilled || sidecar.LastTermination != nil {
+		t.Errorf("extractContainerInfo() == %#v, expected container without termination", sidecar)
+	}
+}
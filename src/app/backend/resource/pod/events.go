@@ -15,8 +15,7 @@
 package pod
 
 import (
-	"log"
-
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
@@ -34,7 +33,7 @@ func GetEventsForPod(client client.Interface, dsQuery *dataselect.DataSelectQuer
 
 	events := event.CreateEventList(podEvents, dsQuery)
 
-	log.Printf("Found %d events related to %s pod in %s namespace", len(events.Events), podName,
+	logger.Infof("Found %d events related to %s pod in %s namespace", len(events.Events), podName,
 		namespace)
 
 	return &events, nil
@@ -15,15 +15,13 @@
 package pod
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sClient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
 )
@@ -71,14 +69,21 @@ type Pod struct {
 // GetPodList returns a list of all Pods in the cluster.
 func GetPodList(client k8sClient.Interface, metricClient metricapi.MetricClient, nsQuery *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery) (*PodList, error) {
-	log.Print("Getting list of all pods in the cluster")
+	logger.Info("Getting list of all pods in the cluster")
 
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
 	channels := &common.ResourceChannels{
-		PodList:   common.GetPodListChannelWithOptions(client, nsQuery, metaV1.ListOptions{}, 1),
-		EventList: common.GetEventListChannel(client, nsQuery, 1),
+		PodList:   common.GetPodListChunkChannel(client, nsQuery, chunk, 1),
+		EventList: common.GetChunkEventListChannel(client, nsQuery, chunk, 1),
+	}
+
+	podList, err := GetPodListFromChannels(channels, dsQuery, metricClient)
+	if err != nil {
+		return nil, err
 	}
 
-	return GetPodListFromChannels(channels, dsQuery, metricClient)
+	chunk.SetListMeta(&podList.ListMeta)
+	return podList, nil
 }
 
 // GetPodListFromChannels returns a list of all Pods in the cluster
@@ -118,7 +123,7 @@ func ToPodList(pods []v1.Pod, events []v1.Event, nonCriticalErrors []error, dsQu
 
 	metrics, err := getMetricsPerPod(pods, metricClient, dsQuery)
 	if err != nil {
-		log.Printf("Skipping Heapster metrics because of error: %s\n", err)
+		logger.Warningf("Skipping Heapster metrics because of error: %s\n", err)
 	}
 
 	for _, pod := range pods {
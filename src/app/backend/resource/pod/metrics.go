@@ -15,12 +15,11 @@
 package pod
 
 import (
-	"log"
-
 	"errors"
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/pkg/api/v1"
@@ -48,7 +47,7 @@ type PodMetrics struct {
 func getMetricsPerPod(pods []v1.Pod, metricClient metricapi.MetricClient,
 	dsQuery *dataselect.DataSelectQuery) (
 	*MetricsByPod, error) {
-	log.Println("Getting pod metrics")
+	logger.Info("Getting pod metrics")
 
 	result := &MetricsByPod{MetricsMap: make(map[types.UID]PodMetrics)}
 
@@ -61,7 +60,7 @@ func getMetricsPerPod(pods []v1.Pod, metricClient metricapi.MetricClient,
 	for _, m := range metrics {
 		uid, err := getPodUIDFromMetric(m)
 		if err != nil {
-			log.Printf("Skipping metric because of error: %s", err.Error())
+			logger.Warningf("Skipping metric because of error: %s", err.Error())
 		}
 
 		podMetrics := PodMetrics{}
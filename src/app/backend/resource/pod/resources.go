@@ -0,0 +1,81 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// Thresholds used to flag containers, that are likely to need more resources.
+const (
+	// throttledRatioThreshold is the share of throttled CPU periods, which slows down the
+	// container noticeably.
+	throttledRatioThreshold = 0.25
+	// limitUsageThreshold is the share of CPU or memory limit, which is considered nearly all.
+	limitUsageThreshold = 0.9
+)
+
+// oomKilledReason is the reason of termination of containers killed for running out of memory.
+const oomKilledReason = "OOMKilled"
+
+// getContainerUsage returns usage of containers of the pod if the metric client can report it.
+// Usage is optional, so errors are only logged.
+func getContainerUsage(metricClient metricapi.MetricClient, pod *v1.Pod) map[string]metricapi.ContainerUsage {
+	containerClient, ok := metricClient.(metricapi.ContainerMetricClient)
+	if !ok {
+		return nil
+	}
+
+	usage, err := containerClient.ContainerUsage(pod.Namespace, pod.Name)
+	if err != nil {
+		logger.Warningf("Skipping usage of containers of pod %s: %s", pod.Name, err.Error())
+		return nil
+	}
+	return usage
+}
+
+func toContainerResources(container v1.Container, pod *v1.Pod, usage metricapi.ContainerUsage) ContainerResources {
+	requests, limits := container.Resources.Requests, container.Resources.Limits
+	result := ContainerResources{
+		CPURequests:       requests.Cpu().MilliValue(),
+		CPULimits:         limits.Cpu().MilliValue(),
+		MemoryRequests:    requests.Memory().Value(),
+		MemoryLimits:      limits.Memory().Value(),
+		CPUUsage:          usage.CPUUsage,
+		MemoryUsage:       usage.MemoryUsage,
+		CPUThrottledRatio: usage.CPUThrottledRatio,
+	}
+
+	if result.CPUThrottledRatio != nil {
+		result.CPUThrottled = *result.CPUThrottledRatio >= throttledRatioThreshold
+	} else if result.CPUUsage != nil {
+		result.CPUThrottled = isNearLimit(*result.CPUUsage, result.CPULimits)
+	}
+
+	if result.MemoryUsage != nil {
+		result.MemoryPressure = isNearLimit(*result.MemoryUsage, result.MemoryLimits)
+	}
+	if isOOMKilled(getContainerStatus(pod, container.Name)) {
+		result.MemoryPressure = true
+	}
+	return result
+}
+
+// isNearLimit returns true if the usage is close to the limit. Zero limit means no limit.
+func isNearLimit(usage uint64, limit int64) bool {
+	return limit > 0 && float64(usage) >= limitUsageThreshold*float64(limit)
+}
@@ -0,0 +1,87 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"reflect"
+	"testing"
+
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func TestToContainerResources(t *testing.T) {
+	quantity := func(value uint64) *uint64 { return &value }
+	ratio := func(value float64) *float64 { return &value }
+	container := v1.Container{
+		Name: "app",
+		Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("100m"),
+				v1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+			Limits: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("500m"),
+				v1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+		},
+	}
+	oomKilled := &v1.Pod{Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{
+		Name: "app",
+		LastTerminationState: v1.ContainerState{
+			Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled"},
+		},
+	}}}}
+
+	cases := []struct {
+		container v1.Container
+		pod       *v1.Pod
+		usage     metricapi.ContainerUsage
+		expected  ContainerResources
+	}{
+		{
+			v1.Container{Name: "app"}, &v1.Pod{}, metricapi.ContainerUsage{},
+			ContainerResources{},
+		},
+		{
+			container, &v1.Pod{},
+			metricapi.ContainerUsage{CPUUsage: quantity(50), MemoryUsage: quantity(32 * 1024 * 1024)},
+			ContainerResources{CPURequests: 100, CPULimits: 500, MemoryRequests: 64 * 1024 * 1024,
+				MemoryLimits: 128 * 1024 * 1024, CPUUsage: quantity(50), MemoryUsage: quantity(32 * 1024 * 1024)},
+		},
+		{
+			container, &v1.Pod{},
+			metricapi.ContainerUsage{CPUUsage: quantity(480), MemoryUsage: quantity(120 * 1024 * 1024)},
+			ContainerResources{CPURequests: 100, CPULimits: 500, MemoryRequests: 64 * 1024 * 1024,
+				MemoryLimits: 128 * 1024 * 1024, CPUUsage: quantity(480), MemoryUsage: quantity(120 * 1024 * 1024),
+				CPUThrottled: true, MemoryPressure: true},
+		},
+		{
+			container, oomKilled,
+			metricapi.ContainerUsage{CPUUsage: quantity(480), CPUThrottledRatio: ratio(0.1)},
+			ContainerResources{CPURequests: 100, CPULimits: 500, MemoryRequests: 64 * 1024 * 1024,
+				MemoryLimits: 128 * 1024 * 1024, CPUUsage: quantity(480), CPUThrottledRatio: ratio(0.1),
+				MemoryPressure: true},
+		},
+	}
+
+	for _, c := range cases {
+		actual := toContainerResources(c.container, c.pod, c.usage)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("toContainerResources(%#v) == \n%#v\nexpected \n%#v", c.usage, actual, c.expected)
+		}
+	}
+}
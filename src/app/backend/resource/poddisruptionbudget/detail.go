@@ -0,0 +1,63 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poddisruptionbudget
+
+import (
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	policy "k8s.io/client-go/pkg/apis/policy/v1beta1"
+)
+
+// PodDisruptionBudgetDetail is a presentation layer view of Kubernetes Pod Disruption Budget.
+type PodDisruptionBudgetDetail struct {
+	PodDisruptionBudget `json:",inline"`
+
+	// Most recent generation observed when updating the status.
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// Names of pods whose eviction was processed by the apiserver, but that were not deleted
+	// yet.
+	DisruptedPods []string `json:"disruptedPods"`
+}
+
+// GetPodDisruptionBudgetDetail returns detailed information about a pod disruption budget.
+func GetPodDisruptionBudgetDetail(client client.Interface, namespace,
+	name string) (*PodDisruptionBudgetDetail, error) {
+	logger.Infof("Getting details of %s pod disruption budget in %s namespace", name, namespace)
+
+	pdb, err := client.PolicyV1beta1().PodDisruptionBudgets(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPodDisruptionBudgetDetail(pdb), nil
+}
+
+func toPodDisruptionBudgetDetail(pdb *policy.PodDisruptionBudget) *PodDisruptionBudgetDetail {
+	disruptedPods := make([]string, 0, len(pdb.Status.DisruptedPods))
+	for name := range pdb.Status.DisruptedPods {
+		disruptedPods = append(disruptedPods, name)
+	}
+	sort.Strings(disruptedPods)
+
+	return &PodDisruptionBudgetDetail{
+		PodDisruptionBudget: toPodDisruptionBudget(pdb),
+		ObservedGeneration:  pdb.Status.ObservedGeneration,
+		DisruptedPods:       disruptedPods,
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poddisruptionbudget
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	policy "k8s.io/client-go/pkg/apis/policy/v1beta1"
+)
+
+// PodDisruptionBudgetList contains a list of Pod Disruption Budgets in the cluster.
+type PodDisruptionBudgetList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	// Unordered list of Pod Disruption Budgets.
+	Items []PodDisruptionBudget `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// PodDisruptionBudget limits the number of pods of a replicated application that are down
+// simultaneously from voluntary disruptions, e.g. node drains.
+type PodDisruptionBudget struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Number or percentage of pods that must remain available after an eviction.
+	MinAvailable string `json:"minAvailable"`
+
+	// Label query over pods whose evictions are managed by the budget.
+	Selector *metaV1.LabelSelector `json:"selector"`
+
+	// Number of pod disruptions that are currently allowed.
+	DisruptionsAllowed int32 `json:"disruptionsAllowed"`
+
+	CurrentHealthy int32 `json:"currentHealthy"`
+	DesiredHealthy int32 `json:"desiredHealthy"`
+	ExpectedPods   int32 `json:"expectedPods"`
+}
+
+// GetPodDisruptionBudgetList returns a list of all Pod Disruption Budgets in the cluster.
+func GetPodDisruptionBudgetList(client client.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*PodDisruptionBudgetList, error) {
+	logger.Infof("Getting list of pod disruption budgets in the namespace %s", nsQuery.ToRequestParam())
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
+	channels := &common.ResourceChannels{
+		PodDisruptionBudgetList: common.GetPodDisruptionBudgetListChunkChannel(client, nsQuery, chunk, 1),
+	}
+
+	podDisruptionBudgetList, err := GetPodDisruptionBudgetListFromChannels(channels, dsQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	chunk.SetListMeta(&podDisruptionBudgetList.ListMeta)
+	return podDisruptionBudgetList, nil
+}
+
+// GetPodDisruptionBudgetListFromChannels returns a list of all Pod Disruption Budgets in the
+// cluster reading required resource list once from the channels.
+func GetPodDisruptionBudgetListFromChannels(channels *common.ResourceChannels,
+	dsQuery *dataselect.DataSelectQuery) (*PodDisruptionBudgetList, error) {
+	pdbs := <-channels.PodDisruptionBudgetList.List
+	err := <-channels.PodDisruptionBudgetList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	items := make([]policy.PodDisruptionBudget, 0)
+	if pdbs != nil {
+		items = pdbs.Items
+	}
+	return toPodDisruptionBudgetList(items, nonCriticalErrors, dsQuery), nil
+}
+
+func toPodDisruptionBudget(pdb *policy.PodDisruptionBudget) PodDisruptionBudget {
+	return PodDisruptionBudget{
+		ObjectMeta:         api.NewObjectMeta(pdb.ObjectMeta),
+		TypeMeta:           api.NewTypeMeta(api.ResourceKindPodDisruptionBudget),
+		MinAvailable:       pdb.Spec.MinAvailable.String(),
+		Selector:           pdb.Spec.Selector,
+		DisruptionsAllowed: pdb.Status.PodDisruptionsAllowed,
+		CurrentHealthy:     pdb.Status.CurrentHealthy,
+		DesiredHealthy:     pdb.Status.DesiredHealthy,
+		ExpectedPods:       pdb.Status.ExpectedPods,
+	}
+}
+
+func toPodDisruptionBudgetList(pdbs []policy.PodDisruptionBudget, nonCriticalErrors []error,
+	dsQuery *dataselect.DataSelectQuery) *PodDisruptionBudgetList {
+	result := &PodDisruptionBudgetList{
+		Items:    make([]PodDisruptionBudget, 0),
+		ListMeta: api.ListMeta{TotalItems: len(pdbs)},
+		Errors:   nonCriticalErrors,
+	}
+
+	pdbCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(pdbs), dsQuery)
+	pdbs = fromCells(pdbCells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for _, item := range pdbs {
+		result.Items = append(result.Items, toPodDisruptionBudget(&item))
+	}
+
+	return result
+}
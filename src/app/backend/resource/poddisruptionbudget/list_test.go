@@ -0,0 +1,105 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poddisruptionbudget
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	policy "k8s.io/client-go/pkg/apis/policy/v1beta1"
+)
+
+func TestGetPodDisruptionBudgetList(t *testing.T) {
+	selector := &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	cases := []struct {
+		pdbList  *policy.PodDisruptionBudgetList
+		expected *PodDisruptionBudgetList
+	}{
+		{
+			&policy.PodDisruptionBudgetList{Items: []policy.PodDisruptionBudget{{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "ns"},
+				Spec: policy.PodDisruptionBudgetSpec{
+					MinAvailable: intstr.FromString("50%"),
+					Selector:     selector,
+				},
+				Status: policy.PodDisruptionBudgetStatus{
+					PodDisruptionsAllowed: 1,
+					CurrentHealthy:        3,
+					DesiredHealthy:        2,
+					ExpectedPods:          4,
+				},
+			}}},
+			&PodDisruptionBudgetList{
+				ListMeta: api.ListMeta{TotalItems: 1},
+				Items: []PodDisruptionBudget{{
+					ObjectMeta:         api.ObjectMeta{Name: "web", Namespace: "ns"},
+					TypeMeta:           api.TypeMeta{Kind: api.ResourceKindPodDisruptionBudget},
+					MinAvailable:       "50%",
+					Selector:           selector,
+					DisruptionsAllowed: 1,
+					CurrentHealthy:     3,
+					DesiredHealthy:     2,
+					ExpectedPods:       4,
+				}},
+				Errors: []error{},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		fakeClient := fake.NewSimpleClientset(c.pdbList)
+		actual, _ := GetPodDisruptionBudgetList(fakeClient, common.NewNamespaceQuery(nil),
+			dataselect.NoDataSelect)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("GetPodDisruptionBudgetList(client, nil) == \ngot: %#v, \nexpected %#v",
+				actual, c.expected)
+		}
+	}
+}
+
+func TestToPodDisruptionBudgetDetail(t *testing.T) {
+	pdb := &policy.PodDisruptionBudget{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "ns"},
+		Spec:       policy.PodDisruptionBudgetSpec{MinAvailable: intstr.FromInt(2)},
+		Status: policy.PodDisruptionBudgetStatus{
+			ObservedGeneration: 3,
+			DisruptedPods: map[string]metaV1.Time{
+				"web-2": {},
+				"web-1": {},
+			},
+		},
+	}
+
+	actual := toPodDisruptionBudgetDetail(pdb)
+	expected := &PodDisruptionBudgetDetail{
+		PodDisruptionBudget: PodDisruptionBudget{
+			ObjectMeta:   api.ObjectMeta{Name: "web", Namespace: "ns"},
+			TypeMeta:     api.TypeMeta{Kind: api.ResourceKindPodDisruptionBudget},
+			MinAvailable: "2",
+		},
+		ObservedGeneration: 3,
+		DisruptedPods:      []string{"web-1", "web-2"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("toPodDisruptionBudgetDetail(%#v) == \ngot: %#v, \nexpected %#v", pdb, actual,
+			expected)
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podsecurity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Violation is a part of a pod spec, which is not allowed by a Pod Security Standards level.
+type Violation struct {
+	// Check is the name of the control of Pod Security Standards, e.g. hostNamespaces.
+	Check string `json:"check"`
+
+	// Level is the least restrictive level, which does not allow the pod spec.
+	Level Level `json:"level"`
+
+	// Container violating the level. Empty for violations of the whole pod.
+	Container string `json:"container,omitempty"`
+
+	Message string `json:"message"`
+}
+
+// Capabilities containers may add at baseline level.
+var baselineCapabilities = toSet("AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL", "MKNOD",
+	"NET_BIND_SERVICE", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT")
+
+// SELinux types allowed at baseline level. Empty type means the default one.
+var baselineSELinuxTypes = toSet("", "container_t", "container_init_t", "container_kvm_t")
+
+// Sysctls allowed at baseline level.
+var baselineSysctls = toSet("kernel.shm_rmid_forced", "net.ipv4.ip_local_port_range",
+	"net.ipv4.ip_unprivileged_port_start", "net.ipv4.tcp_syncookies", "net.ipv4.ping_group_range",
+	"net.ipv4.ip_local_reserved_ports", "net.ipv4.tcp_keepalive_time", "net.ipv4.tcp_fin_timeout",
+	"net.ipv4.tcp_keepalive_intvl", "net.ipv4.tcp_keepalive_probes")
+
+// Volume types allowed at restricted level.
+var restrictedVolumeTypes = toSet("configMap", "csi", "downwardAPI", "emptyDir", "ephemeral",
+	"persistentVolumeClaim", "projected", "secret")
+
+// Annotations with seccomp and AppArmor profiles used before the profiles became fields.
+const (
+	seccompPodAnnotation        = "seccomp.security.alpha.kubernetes.io/pod"
+	seccompContainerAnnotation  = "container.seccomp.security.alpha.kubernetes.io/"
+	appArmorContainerAnnotation = "container.apparmor.security.beta.kubernetes.io/"
+)
+
+// podChecker collects violations of a pod spec. Pod specs are checked in their unstructured form,
+// as the vendored API types lack some of the checked fields, e.g. seccomp profiles.
+type podChecker struct {
+	annotations map[string]string
+	spec        map[string]interface{}
+	violations  []Violation
+}
+
+// evaluatePodSpec returns all violations of baseline and restricted levels by the pod spec.
+func evaluatePodSpec(annotations map[string]string, spec map[string]interface{}) []Violation {
+	checker := &podChecker{annotations: annotations, spec: spec, violations: make([]Violation, 0)}
+	checker.checkPod()
+	for _, container := range getContainers(spec) {
+		checker.checkContainer(container)
+	}
+	return checker.violations
+}
+
+func (self *podChecker) add(check string, level Level, container, format string, args ...interface{}) {
+	self.violations = append(self.violations, Violation{Check: check, Level: level, Container: container,
+		Message: fmt.Sprintf(format, args...)})
+}
+
+func (self *podChecker) checkPod() {
+	for _, field := range []string{"hostNetwork", "hostPID", "hostIPC"} {
+		if value, _ := self.spec[field].(bool); value {
+			self.add("hostNamespaces", LevelBaseline, "", "%s is true", field)
+		}
+	}
+
+	for _, item := range getSlice(self.spec, "volumes") {
+		volume, _ := item.(map[string]interface{})
+		for volumeType := range volume {
+			switch {
+			case volumeType == "name":
+			case volumeType == "hostPath":
+				self.add("hostPathVolumes", LevelBaseline, "", "volume %v uses host path", volume["name"])
+			case !restrictedVolumeTypes[volumeType]:
+				self.add("restrictedVolumes", LevelRestricted, "", "volume %v uses %s volume type",
+					volume["name"], volumeType)
+			}
+		}
+	}
+
+	context := getMap(self.spec, "securityContext")
+	for _, item := range getSlice(context, "sysctls") {
+		sysctl, _ := item.(map[string]interface{})
+		if name, _ := sysctl["name"].(string); !baselineSysctls[name] {
+			self.add("sysctls", LevelBaseline, "", "sysctl %s is not allowed", name)
+		}
+	}
+
+	self.checkSELinux("", context)
+	if getString(getMap(context, "seccompProfile"), "type") == "Unconfined" ||
+		self.annotations[seccompPodAnnotation] == "unconfined" {
+		self.add("seccomp", LevelBaseline, "", "seccomp profile is Unconfined")
+	}
+	if getString(getMap(context, "appArmorProfile"), "type") == "Unconfined" {
+		self.add("appArmor", LevelBaseline, "", "AppArmor profile is Unconfined")
+	}
+	if user, ok := context["runAsUser"]; ok && isZero(user) {
+		self.add("runAsUser", LevelRestricted, "", "runAsUser is 0")
+	}
+}
+
+func (self *podChecker) checkContainer(container map[string]interface{}) {
+	name, _ := container["name"].(string)
+	podContext := getMap(self.spec, "securityContext")
+	context := getMap(container, "securityContext")
+
+	if privileged, _ := context["privileged"].(bool); privileged {
+		self.add("privileged", LevelBaseline, name, "container is privileged")
+	}
+	if hostProcess, _ := getMap(context, "windowsOptions")["hostProcess"].(bool); hostProcess {
+		self.add("hostProcess", LevelBaseline, name, "container is a Windows host process")
+	}
+	if procMount := getString(context, "procMount"); procMount != "" && procMount != "Default" {
+		self.add("procMount", LevelBaseline, name, "procMount is %s", procMount)
+	}
+
+	for _, item := range getSlice(container, "ports") {
+		port, _ := item.(map[string]interface{})
+		if hostPort, ok := port["hostPort"]; ok && !isZero(hostPort) {
+			self.add("hostPorts", LevelBaseline, name, "container uses host port %v", hostPort)
+		}
+	}
+
+	capabilities := getMap(context, "capabilities")
+	dropsAll := false
+	for _, item := range getSlice(capabilities, "drop") {
+		dropsAll = dropsAll || item == "ALL"
+	}
+	for _, item := range getSlice(capabilities, "add") {
+		capability, _ := item.(string)
+		if !baselineCapabilities[capability] {
+			self.add("capabilities", LevelBaseline, name, "capability %s is added", capability)
+		} else if capability != "NET_BIND_SERVICE" {
+			self.add("capabilities", LevelRestricted, name, "capability %s is added", capability)
+		}
+	}
+	if !dropsAll {
+		self.add("capabilities", LevelRestricted, name, "capabilities do not drop ALL")
+	}
+
+	self.checkSELinux(name, context)
+	if profile := self.annotations[appArmorContainerAnnotation+name]; profile != "" &&
+		profile != "runtime/default" && !strings.HasPrefix(profile, "localhost/") {
+		self.add("appArmor", LevelBaseline, name, "AppArmor profile is %s", profile)
+	}
+	if getString(getMap(context, "appArmorProfile"), "type") == "Unconfined" {
+		self.add("appArmor", LevelBaseline, name, "AppArmor profile is Unconfined")
+	}
+
+	seccomp := getString(getMap(context, "seccompProfile"), "type")
+	if annotation := self.annotations[seccompContainerAnnotation+name]; seccomp == "" && annotation != "" {
+		seccomp = annotationToSeccompType(annotation)
+	}
+	if seccomp == "Unconfined" {
+		self.add("seccomp", LevelBaseline, name, "seccomp profile is Unconfined")
+	} else if seccomp == "" && !self.hasPodSeccomp(podContext) {
+		self.add("seccomp", LevelRestricted, name, "seccomp profile is not set")
+	} else if seccomp != "" && seccomp != "RuntimeDefault" && seccomp != "Localhost" {
+		self.add("seccomp", LevelRestricted, name, "seccomp profile is %s", seccomp)
+	}
+
+	if escalation, ok := context["allowPrivilegeEscalation"].(bool); !ok || escalation {
+		self.add("allowPrivilegeEscalation", LevelRestricted, name, "allowPrivilegeEscalation is not false")
+	}
+
+	nonRoot, ok := context["runAsNonRoot"].(bool)
+	if !ok {
+		nonRoot, _ = podContext["runAsNonRoot"].(bool)
+	}
+	if !nonRoot {
+		self.add("runAsNonRoot", LevelRestricted, name, "runAsNonRoot is not true")
+	}
+	if user, ok := context["runAsUser"]; ok && isZero(user) {
+		self.add("runAsUser", LevelRestricted, name, "runAsUser is 0")
+	}
+}
+
+// hasPodSeccomp returns true when the pod sets seccomp profile inherited by its containers. Unconfined
+// pod profile is reported by the pod check.
+func (self *podChecker) hasPodSeccomp(podContext map[string]interface{}) bool {
+	seccomp := getString(getMap(podContext, "seccompProfile"), "type")
+	if annotation := self.annotations[seccompPodAnnotation]; seccomp == "" && annotation != "" {
+		seccomp = annotationToSeccompType(annotation)
+	}
+	return seccomp != ""
+}
+
+// checkSELinux checks SELinux options of the pod, when container is empty, or of the container.
+func (self *podChecker) checkSELinux(container string, context map[string]interface{}) {
+	options := getMap(context, "seLinuxOptions")
+	if seLinuxType := getString(options, "type"); !baselineSELinuxTypes[seLinuxType] {
+		self.add("seLinux", LevelBaseline, container, "SELinux type %s is not allowed", seLinuxType)
+	}
+	if getString(options, "user") != "" || getString(options, "role") != "" {
+		self.add("seLinux", LevelBaseline, container, "SELinux user or role is set")
+	}
+}
+
+// annotationToSeccompType converts seccomp profile annotation to the profile type.
+func annotationToSeccompType(annotation string) string {
+	switch {
+	case annotation == "runtime/default" || annotation == "docker/default":
+		return "RuntimeDefault"
+	case strings.HasPrefix(annotation, "localhost/"):
+		return "Localhost"
+	case annotation == "unconfined":
+		return "Unconfined"
+	default:
+		return annotation
+	}
+}
+
+// getContainers returns init, regular and ephemeral containers of the pod spec.
+func getContainers(spec map[string]interface{}) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+	for _, field := range []string{"initContainers", "containers", "ephemeralContainers"} {
+		for _, item := range getSlice(spec, field) {
+			if container, ok := item.(map[string]interface{}); ok {
+				result = append(result, container)
+			}
+		}
+	}
+	return result
+}
+
+func getMap(obj map[string]interface{}, field string) map[string]interface{} {
+	value, _ := obj[field].(map[string]interface{})
+	return value
+}
+
+func getSlice(obj map[string]interface{}, field string) []interface{} {
+	value, _ := obj[field].([]interface{})
+	return value
+}
+
+func getString(obj map[string]interface{}, field string) string {
+	value, _ := obj[field].(string)
+	return value
+}
+
+// isZero returns true for zero numbers decoded from JSON as either int64 or float64.
+func isZero(value interface{}) bool {
+	return fmt.Sprint(value) == "0"
+}
+
+func toSet(values ...string) map[string]bool {
+	result := make(map[string]bool, len(values))
+	for _, value := range values {
+		result[value] = true
+	}
+	return result
+}
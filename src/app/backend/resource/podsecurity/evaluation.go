@@ -0,0 +1,278 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package podsecurity previews Pod Security admission. Workloads of a namespace are evaluated
+// against baseline and restricted levels of Pod Security Standards, so that it is known which of
+// them would be rejected once a level is enforced, e.g. when migrating from pod security policies.
+package podsecurity
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Level is a level of Pod Security Standards.
+type Level string
+
+const (
+	LevelPrivileged Level = "privileged"
+	LevelBaseline   Level = "baseline"
+	LevelRestricted Level = "restricted"
+)
+
+// Namespace labels configuring Pod Security admission.
+const (
+	EnforceLabel = "pod-security.kubernetes.io/enforce"
+	WarnLabel    = "pod-security.kubernetes.io/warn"
+	AuditLabel   = "pod-security.kubernetes.io/audit"
+)
+
+// podSecurityLabels are all labels configuring Pod Security admission, including versions.
+var podSecurityLabels = []string{EnforceLabel, EnforceLabel + "-version", WarnLabel, WarnLabel + "-version",
+	AuditLabel, AuditLabel + "-version"}
+
+// workloadResource is a resource of objects with pod specs.
+type workloadResource struct {
+	gv       schema.GroupVersion
+	resource string
+	kind     string
+
+	// Path of the pod spec in objects.
+	specPath []string
+}
+
+// Workload resources evaluated in namespaces. Objects owned by controllers are evaluated through
+// their controllers.
+var workloadResources = []workloadResource{
+	{schema.GroupVersion{Version: "v1"}, "pods", "Pod", []string{"spec"}},
+	{schema.GroupVersion{Version: "v1"}, "replicationcontrollers", "ReplicationController",
+		[]string{"spec", "template", "spec"}},
+	{schema.GroupVersion{Group: "apps", Version: "v1"}, "deployments", "Deployment",
+		[]string{"spec", "template", "spec"}},
+	{schema.GroupVersion{Group: "apps", Version: "v1"}, "replicasets", "ReplicaSet",
+		[]string{"spec", "template", "spec"}},
+	{schema.GroupVersion{Group: "apps", Version: "v1"}, "statefulsets", "StatefulSet",
+		[]string{"spec", "template", "spec"}},
+	{schema.GroupVersion{Group: "apps", Version: "v1"}, "daemonsets", "DaemonSet",
+		[]string{"spec", "template", "spec"}},
+	{schema.GroupVersion{Group: "batch", Version: "v1"}, "jobs", "Job", []string{"spec", "template", "spec"}},
+	{schema.GroupVersion{Group: "batch", Version: "v1"}, "cronjobs", "CronJob",
+		[]string{"spec", "jobTemplate", "spec", "template", "spec"}},
+}
+
+// WorkloadEvaluation is a result of evaluating a single workload.
+type WorkloadEvaluation struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+
+	// Whether pods of the workload would be admitted at the evaluated level.
+	Allowed bool `json:"allowed"`
+
+	// The most restrictive level admitting pods of the workload.
+	StrictestLevel Level `json:"strictestLevel"`
+
+	// Violations of baseline and restricted levels.
+	Violations []Violation `json:"violations"`
+}
+
+// PodSecurityEvaluation is a result of evaluating all workloads of a namespace.
+type PodSecurityEvaluation struct {
+	Namespace string `json:"namespace"`
+
+	// Pod Security admission labels of the namespace.
+	Labels map[string]string `json:"labels"`
+
+	// Level workloads were evaluated against.
+	Level Level `json:"level"`
+
+	// Workloads, rejected ones first.
+	Workloads []WorkloadEvaluation `json:"workloads"`
+	Rejected  int                  `json:"rejected"`
+}
+
+// ParseLevel parses level of Pod Security Standards. Empty string is returned for empty value.
+func ParseLevel(value string) (Level, error) {
+	switch level := Level(value); level {
+	case "", LevelPrivileged, LevelBaseline, LevelRestricted:
+		return level, nil
+	default:
+		return "", errorsK8s.NewBadRequest(fmt.Sprintf("unknown pod security level %s", value))
+	}
+}
+
+// allows returns true when the level allows pod specs with the violation.
+func (self Level) allows(violation Violation) bool {
+	switch self {
+	case LevelPrivileged:
+		return true
+	case LevelBaseline:
+		return violation.Level == LevelRestricted
+	default:
+		return false
+	}
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Get() *rest.Request
+}
+
+// newRESTClients creates REST clients for group versions of evaluated workload resources.
+func newRESTClients(config *rest.Config) (map[schema.GroupVersion]RESTClient, error) {
+	restClients := make(map[schema.GroupVersion]RESTClient)
+	for _, resource := range workloadResources {
+		if _, ok := restClients[resource.gv]; ok {
+			continue
+		}
+		restClient, err := apply.NewRESTClient(config, resource.gv)
+		if err != nil {
+			return nil, err
+		}
+		restClients[resource.gv] = restClient
+	}
+	return restClients, nil
+}
+
+// listObjects lists objects of the resource in the namespace.
+func listObjects(restClient RESTClient, resource, namespace string) ([]unstructured.Unstructured, error) {
+	list := new(unstructured.UnstructuredList)
+	if err := restClient.Get().Namespace(namespace).Resource(resource).Do().Into(list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// EvaluateNamespace evaluates workloads of the namespace against the level. When the level is
+// empty, the level enforced in the namespace is used. Namespaces without the enforce label run at
+// privileged level, which admits all workloads. Versions of levels are not taken into account,
+// workloads are evaluated against the latest version of Pod Security Standards.
+func EvaluateNamespace(client kubernetes.Interface, config *rest.Config, namespace string,
+	level Level) (*PodSecurityEvaluation, error) {
+	restClients, err := newRESTClients(config)
+	if err != nil {
+		return nil, err
+	}
+	return evaluateNamespace(client, restClients, namespace, level)
+}
+
+func evaluateNamespace(client kubernetes.Interface, restClients map[schema.GroupVersion]RESTClient,
+	namespace string, level Level) (*PodSecurityEvaluation, error) {
+	ns, err := client.CoreV1().Namespaces().Get(namespace, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PodSecurityEvaluation{
+		Namespace: namespace,
+		Labels:    make(map[string]string),
+		Level:     level,
+		Workloads: make([]WorkloadEvaluation, 0),
+	}
+	for _, label := range podSecurityLabels {
+		if value, ok := ns.Labels[label]; ok {
+			result.Labels[label] = value
+		}
+	}
+	if len(result.Level) == 0 {
+		result.Level = LevelPrivileged
+		if enforced, err := ParseLevel(ns.Labels[EnforceLabel]); err == nil && len(enforced) > 0 {
+			result.Level = enforced
+		}
+	}
+
+	logger.Infof("Evaluating workloads in %s namespace against %s pod security level", namespace, result.Level)
+	for _, resource := range workloadResources {
+		objects, err := listObjects(restClients[resource.gv], resource.resource, namespace)
+		if errorsK8s.IsNotFound(err) {
+			// Resource is not served by the cluster, e.g. batch/v1 cron jobs by older clusters.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range objects {
+			if isControlled(&objects[i]) {
+				continue
+			}
+			evaluation := evaluateWorkload(resource, &objects[i], result.Level)
+			if !evaluation.Allowed {
+				result.Rejected++
+			}
+			result.Workloads = append(result.Workloads, evaluation)
+		}
+	}
+
+	sort.SliceStable(result.Workloads, func(i, j int) bool {
+		a, b := result.Workloads[i], result.Workloads[j]
+		if a.Allowed != b.Allowed {
+			return !a.Allowed
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return result, nil
+}
+
+func evaluateWorkload(resource workloadResource, obj *unstructured.Unstructured, level Level) WorkloadEvaluation {
+	// Annotations of the pod template, which sit next to its spec.
+	var template interface{} = obj.Object
+	for _, field := range resource.specPath[:len(resource.specPath)-1] {
+		template = getMap(template.(map[string]interface{}), field)
+	}
+	templateMap, _ := template.(map[string]interface{})
+	annotations := make(map[string]string)
+	for key, value := range getMap(getMap(templateMap, "metadata"), "annotations") {
+		annotations[key] = fmt.Sprint(value)
+	}
+
+	violations := evaluatePodSpec(annotations, getMap(templateMap, resource.specPath[len(resource.specPath)-1]))
+	evaluation := WorkloadEvaluation{
+		Kind:           resource.kind,
+		Name:           obj.GetName(),
+		Allowed:        true,
+		StrictestLevel: LevelRestricted,
+		Violations:     violations,
+	}
+	for _, violation := range violations {
+		evaluation.Allowed = evaluation.Allowed && level.allows(violation)
+		if violation.Level == LevelBaseline {
+			evaluation.StrictestLevel = LevelPrivileged
+		} else if evaluation.StrictestLevel == LevelRestricted {
+			evaluation.StrictestLevel = LevelBaseline
+		}
+	}
+	return evaluation
+}
+
+// isControlled returns true when the object is owned by a controller.
+func isControlled(obj *unstructured.Unstructured) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Controller != nil && *ref.Controller {
+			return true
+		}
+	}
+	return false
+}
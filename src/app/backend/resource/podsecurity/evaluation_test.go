@@ -0,0 +1,194 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podsecurity
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+// newRestrictedContainer returns container, which is allowed at restricted level.
+func newRestrictedContainer() map[string]interface{} {
+	return map[string]interface{}{
+		"name":  "app",
+		"image": "app:1.0",
+		"securityContext": map[string]interface{}{
+			"allowPrivilegeEscalation": false,
+			"runAsNonRoot":             true,
+			"seccompProfile":           map[string]interface{}{"type": "RuntimeDefault"},
+			"capabilities":             map[string]interface{}{"drop": []interface{}{"ALL"}},
+		},
+	}
+}
+
+func TestEvaluatePodSpec(t *testing.T) {
+	cases := []struct {
+		name        string
+		annotations map[string]string
+		change      func(spec, container map[string]interface{})
+		expected    []string
+	}{
+		{"restricted", nil, func(spec, container map[string]interface{}) {}, []string{}},
+		{"host network", nil, func(spec, container map[string]interface{}) {
+			spec["hostNetwork"] = true
+		}, []string{"baseline/hostNamespaces"}},
+		{"privileged", nil, func(spec, container map[string]interface{}) {
+			getMap(container, "securityContext")["privileged"] = true
+		}, []string{"baseline/privileged"}},
+		{"volumes", nil, func(spec, container map[string]interface{}) {
+			spec["volumes"] = []interface{}{
+				map[string]interface{}{"name": "a", "hostPath": map[string]interface{}{"path": "/"}},
+				map[string]interface{}{"name": "b", "nfs": map[string]interface{}{"server": "nfs"}},
+				map[string]interface{}{"name": "c", "configMap": map[string]interface{}{"name": "c"}},
+			}
+		}, []string{"baseline/hostPathVolumes", "restricted/restrictedVolumes"}},
+		{"capabilities", nil, func(spec, container map[string]interface{}) {
+			getMap(getMap(container, "securityContext"), "capabilities")["add"] =
+				[]interface{}{"SYS_ADMIN", "CHOWN", "NET_BIND_SERVICE"}
+		}, []string{"baseline/capabilities", "restricted/capabilities"}},
+		{"host port", nil, func(spec, container map[string]interface{}) {
+			container["ports"] = []interface{}{map[string]interface{}{"containerPort": int64(80),
+				"hostPort": int64(80)}}
+		}, []string{"baseline/hostPorts"}},
+		{"unconfined seccomp annotation", map[string]string{seccompContainerAnnotation + "app": "unconfined"},
+			func(spec, container map[string]interface{}) {
+				delete(getMap(container, "securityContext"), "seccompProfile")
+			}, []string{"baseline/seccomp"}},
+		{"pod seccomp and non-root", nil, func(spec, container map[string]interface{}) {
+			context := getMap(container, "securityContext")
+			delete(context, "seccompProfile")
+			delete(context, "runAsNonRoot")
+			spec["securityContext"] = map[string]interface{}{
+				"runAsNonRoot":   true,
+				"seccompProfile": map[string]interface{}{"type": "Localhost"},
+			}
+		}, []string{}},
+		{"default security context", nil, func(spec, container map[string]interface{}) {
+			delete(container, "securityContext")
+		}, []string{"restricted/capabilities", "restricted/seccomp", "restricted/allowPrivilegeEscalation",
+			"restricted/runAsNonRoot"}},
+		{"root user", nil, func(spec, container map[string]interface{}) {
+			getMap(container, "securityContext")["runAsUser"] = int64(0)
+		}, []string{"restricted/runAsUser"}},
+	}
+
+	for _, c := range cases {
+		container := newRestrictedContainer()
+		spec := map[string]interface{}{"containers": []interface{}{container}}
+		c.change(spec, container)
+
+		actual := make([]string, 0)
+		for _, violation := range evaluatePodSpec(c.annotations, spec) {
+			actual = append(actual, string(violation.Level)+"/"+violation.Check)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: evaluatePodSpec() returns %#v, expected %#v", c.name, actual, c.expected)
+		}
+	}
+}
+
+func TestEvaluateNamespace(t *testing.T) {
+	newObject := func(kind, name string, container map[string]interface{}) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"kind":     kind,
+			"metadata": map[string]interface{}{"name": name, "namespace": "apps"},
+			"spec": map[string]interface{}{"template": map[string]interface{}{
+				"spec": map[string]interface{}{"containers": []interface{}{container}},
+			}},
+		}}
+	}
+
+	privileged := newRestrictedContainer()
+	getMap(privileged, "securityContext")["privileged"] = true
+	defaulted := newRestrictedContainer()
+	delete(defaulted, "securityContext")
+	ownedPod := unstructured.Unstructured{Object: map[string]interface{}{"metadata": map[string]interface{}{
+		"name": "web-1-abcde",
+		"ownerReferences": []interface{}{
+			map[string]interface{}{"kind": "ReplicaSet", "name": "web-1", "controller": true},
+		},
+	}}}
+
+	objects := map[string][]unstructured.Unstructured{
+		"pods":        {ownedPod},
+		"deployments": {newObject("Deployment", "web", newRestrictedContainer())},
+		"daemonsets":  {newObject("DaemonSet", "agent", privileged)},
+		"jobs":        {newObject("Job", "migrate", defaulted)},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		items, ok := objects[path.Base(r.URL.Path)]
+		if !ok || path.Base(path.Dir(r.URL.Path)) != "apps" {
+			http.NotFound(w, r)
+			return
+		}
+		list := &unstructured.UnstructuredList{Object: map[string]interface{}{"kind": "List"}, Items: items}
+		data, err := list.MarshalJSON()
+		if err != nil {
+			t.Fatalf("MarshalJSON() returns error %s", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	defer server.Close()
+	restClients, err := newRESTClients(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("newRESTClients() returns error %s", err)
+	}
+
+	client := fake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "apps",
+		Labels: map[string]string{EnforceLabel: "baseline", "team": "a"}}})
+
+	cases := []struct {
+		level         Level
+		expectedLevel Level
+		expected      []string
+	}{
+		{"", LevelBaseline, []string{"DaemonSet/agent/false/privileged", "Deployment/web/true/restricted",
+			"Job/migrate/true/baseline"}},
+		{LevelRestricted, LevelRestricted, []string{"DaemonSet/agent/false/privileged",
+			"Job/migrate/false/baseline", "Deployment/web/true/restricted"}},
+	}
+
+	for _, c := range cases {
+		evaluation, err := evaluateNamespace(client, restClients, "apps", c.level)
+		if err != nil {
+			t.Fatalf("EvaluateNamespace() returns error %s", err)
+		}
+		if evaluation.Level != c.expectedLevel ||
+			!reflect.DeepEqual(evaluation.Labels, map[string]string{EnforceLabel: "baseline"}) {
+			t.Errorf("EvaluateNamespace(%s) evaluates at %s with labels %#v, expected %s", c.level,
+				evaluation.Level, evaluation.Labels, c.expectedLevel)
+		}
+
+		actual := make([]string, 0)
+		for _, workload := range evaluation.Workloads {
+			actual = append(actual, fmt.Sprintf("%s/%s/%t/%s", workload.Kind, workload.Name, workload.Allowed,
+				workload.StrictestLevel))
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("EvaluateNamespace(%s) returns %#v, expected %#v", c.level, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityclass
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// Reasons of events and conditions reporting preemption.
+const (
+	// reasonPreempted is reported by the scheduler on pods preempted by pods with higher priority.
+	reasonPreempted = "Preempted"
+	// reasonPreempting is reported by kubelet on pods evicted to admit critical pods.
+	reasonPreempting = "Preempting"
+	// reasonFailedScheduling is reported by the scheduler, also when it preempts pods.
+	reasonFailedScheduling = "FailedScheduling"
+
+	// disruptionTargetCondition is set on pods, which are about to be deleted due to a disruption.
+	disruptionTargetCondition = "DisruptionTarget"
+	// reasonPreemptionByScheduler is the reason of disruption target condition of preempted pods.
+	reasonPreemptionByScheduler = "PreemptionByScheduler"
+)
+
+// preemptedPattern matches message of events of preempted pods. The scheduler reports preemptor as
+// namespace/name, newer versions as pod UID.
+var preemptedPattern = regexp.MustCompile(`^Preempted by (?:pod )?(\S+) on node (\S+)`)
+
+// PodPriority is scheduling priority of a pod with preemptions it caused or suffered.
+type PodPriority struct {
+	PriorityClassName string `json:"priorityClassName"`
+
+	// Priority is nil when it was not resolved by the apiserver.
+	Priority         *int32 `json:"priority"`
+	PreemptionPolicy string `json:"preemptionPolicy"`
+
+	// NominatedNodeName is the node, where the scheduler preempted pods to make room for the pod,
+	// while the pod waits for them to terminate.
+	NominatedNodeName string `json:"nominatedNodeName"`
+
+	// PreemptedBy is set when the pod was preempted.
+	PreemptedBy *Preemption `json:"preemptedBy"`
+
+	// Victims are pods preempted to make room for the pod.
+	Victims []Preemption `json:"victims"`
+
+	// Events are preemption and scheduling failure events of the pod.
+	Events []common.Event `json:"events"`
+}
+
+// Preemption describes a pod preempted by another pod.
+type Preemption struct {
+	// Namespace and Pod are of the preempted pod in case of victims and of the preemptor otherwise.
+	// Pod is a pod UID, when the scheduler does not report preemptor name, and empty, when the pod
+	// was evicted by kubelet to admit a critical pod.
+	Namespace string      `json:"namespace"`
+	Pod       string      `json:"pod"`
+	Node      string      `json:"node"`
+	Reason    string      `json:"reason"`
+	Message   string      `json:"message"`
+	Time      metaV1.Time `json:"time"`
+}
+
+// pod is the API representation of pod fields, which are not part of the typed pod of the client
+// library yet.
+type pod struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		PriorityClassName string  `json:"priorityClassName"`
+		Priority          *int32  `json:"priority"`
+		PreemptionPolicy  *string `json:"preemptionPolicy"`
+		NodeName          string  `json:"nodeName"`
+	} `json:"spec"`
+	Status struct {
+		NominatedNodeName string                   `json:"nominatedNodeName"`
+		Conditions        []podDisruptionCondition `json:"conditions"`
+	} `json:"status"`
+}
+
+type podDisruptionCondition struct {
+	Type               string      `json:"type"`
+	Status             string      `json:"status"`
+	Reason             string      `json:"reason"`
+	Message            string      `json:"message"`
+	LastTransitionTime metaV1.Time `json:"lastTransitionTime"`
+}
+
+// getRawPod gets the pod decoded with fields unknown to the client library. Rest client is the
+// client of core API group.
+func getRawPod(restClient RESTClient, namespace, name string) ([]byte, error) {
+	return restClient.Get().Namespace(namespace).Resource("pods").Name(name).Do().Raw()
+}
+
+// GetPodPriority returns priority of the pod with pods it preempted and the pod, which preempted
+// it. Victims are looked for in all namespaces, or in the namespace of the pod, when events of all
+// namespaces cannot be listed.
+func GetPodPriority(client kubernetes.Interface, namespace, name string) (*PodPriority, error) {
+	return getPodPriority(client, client.CoreV1().RESTClient(), namespace, name)
+}
+
+func getPodPriority(client kubernetes.Interface, restClient RESTClient, namespace, name string) (*PodPriority,
+	error) {
+	logger.Infof("Getting priority of %s pod in %s namespace", name, namespace)
+
+	raw, err := getRawPod(restClient, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	item := pod{}
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return nil, err
+	}
+
+	result := &PodPriority{
+		PriorityClassName: item.Spec.PriorityClassName,
+		Priority:          item.Spec.Priority,
+		PreemptionPolicy:  PreemptLowerPriority,
+		NominatedNodeName: item.Status.NominatedNodeName,
+		Victims:           make([]Preemption, 0),
+		Events:            make([]common.Event, 0),
+	}
+	if item.Spec.PreemptionPolicy != nil {
+		result.PreemptionPolicy = *item.Spec.PreemptionPolicy
+	}
+
+	podEvents, err := event.GetEvents(client, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range podEvents {
+		// Events of previous pods with the same name, e.g. of stateful sets, are skipped.
+		if e.InvolvedObject.Name != name || (len(e.InvolvedObject.UID) > 0 && e.InvolvedObject.UID != item.UID) {
+			continue
+		}
+		switch {
+		case e.Reason == reasonPreempted || e.Reason == reasonPreempting:
+			result.PreemptedBy = toPreemptor(e, item.Spec.NodeName)
+		case e.Reason == reasonFailedScheduling && strings.Contains(e.Message, "preemption"):
+		default:
+			continue
+		}
+		result.Events = append(result.Events, event.ToEvent(e))
+	}
+	if result.PreemptedBy == nil {
+		result.PreemptedBy = preemptionFromCondition(item)
+	}
+
+	victims, err := getPreemptedEvents(client, namespace)
+	if err != nil {
+		return nil, err
+	}
+	result.Victims = toVictims(victims, item)
+	return result, nil
+}
+
+// getPreemptedEvents lists events of preempted pods in all namespaces, or in the namespace, when
+// the user is not allowed to list events of all namespaces.
+func getPreemptedEvents(client kubernetes.Interface, namespace string) ([]v1.Event, error) {
+	options := metaV1.ListOptions{FieldSelector: fields.OneTermEqualSelector("reason", reasonPreempted).String()}
+	list, err := client.CoreV1().Events(v1.NamespaceAll).List(options)
+	if errorsK8s.IsForbidden(err) {
+		list, err = client.CoreV1().Events(namespace).List(options)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// toPreemptor converts event of the preempted pod to the preemption.
+func toPreemptor(e v1.Event, node string) *Preemption {
+	result := &Preemption{Node: node, Reason: e.Reason, Message: e.Message, Time: e.LastTimestamp}
+	if match := preemptedPattern.FindStringSubmatch(e.Message); match != nil {
+		result.Node = match[2]
+		if parts := strings.SplitN(match[1], "/", 2); len(parts) == 2 {
+			result.Namespace, result.Pod = parts[0], parts[1]
+		} else {
+			result.Pod = match[1]
+		}
+	}
+	return result
+}
+
+// preemptionFromCondition returns preemption reported by disruption target condition, which is
+// kept after events of the pod expire.
+func preemptionFromCondition(item pod) *Preemption {
+	for _, c := range item.Status.Conditions {
+		if c.Type == disruptionTargetCondition && c.Status == string(v1.ConditionTrue) &&
+			c.Reason == reasonPreemptionByScheduler {
+			return &Preemption{Node: item.Spec.NodeName, Reason: c.Reason, Message: c.Message,
+				Time: c.LastTransitionTime}
+		}
+	}
+	return nil
+}
+
+// toVictims returns pods preempted by the pod, newest first.
+func toVictims(events []v1.Event, item pod) []Preemption {
+	result := make([]Preemption, 0)
+	for _, e := range events {
+		if e.Reason != reasonPreempted {
+			continue
+		}
+		match := preemptedPattern.FindStringSubmatch(e.Message)
+		if match == nil || (match[1] != item.Namespace+"/"+item.Name && match[1] != string(item.UID)) {
+			continue
+		}
+		result = append(result, Preemption{
+			Namespace: e.InvolvedObject.Namespace,
+			Pod:       e.InvolvedObject.Name,
+			Node:      match[2],
+			Reason:    e.Reason,
+			Message:   e.Message,
+			Time:      e.LastTimestamp,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[j].Time.Before(result[i].Time) })
+	return result
+}
@@ -0,0 +1,132 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityclass
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+func newEvent(namespace, pod, uid, reason, message string, minute int) *v1.Event {
+	return &v1.Event{
+		ObjectMeta: metaV1.ObjectMeta{Name: pod + "." + reason, Namespace: namespace},
+		InvolvedObject: v1.ObjectReference{Kind: "Pod", Namespace: namespace, Name: pod,
+			UID: types.UID(uid)},
+		Reason:        reason,
+		Message:       message,
+		LastTimestamp: metaV1.NewTime(time.Date(2021, 6, 1, 12, minute, 0, 0, time.UTC)),
+	}
+}
+
+// newPodClient returns client of core API group, which serves raw pods by namespace and name.
+func newPodClient(t *testing.T, pods map[string]string) (RESTClient, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Path is /api/v1/namespaces/<namespace>/pods/<name>.
+		parts := strings.Split(r.URL.Path, "/")
+		if len(parts) != 7 {
+			http.NotFound(w, r)
+			return
+		}
+		raw, ok := pods[parts[4]+"/"+parts[6]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(raw))
+	}))
+	restClient, err := apply.NewRESTClient(&rest.Config{Host: server.URL}, schema.GroupVersion{Version: "v1"})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return restClient, server.Close
+}
+
+func TestGetPodPriority(t *testing.T) {
+	restClient, closeServer := newPodClient(t, map[string]string{
+		"prod/api-0": `{"metadata": {"name": "api-0", "namespace": "prod", "uid": "api-uid"},
+		  "spec": {"priorityClassName": "critical", "priority": 100000},
+		  "status": {"nominatedNodeName": "node-2"}}`,
+		"batch/job-1": `{"metadata": {"name": "job-1", "namespace": "batch", "uid": "job-uid"},
+		  "spec": {"priority": 0, "preemptionPolicy": "Never", "nodeName": "node-2"}}`,
+		"batch/job-2": `{"metadata": {"name": "job-2", "namespace": "batch", "uid": "job-2-uid"},
+		  "spec": {"nodeName": "node-3"},
+		  "status": {"conditions": [{"type": "DisruptionTarget", "status": "True",
+		    "reason": "PreemptionByScheduler",
+		    "message": "default-scheduler: preempting to accommodate a higher priority pod",
+		    "lastTransitionTime": "2021-06-01T12:05:00Z"}]}}`,
+	})
+	defer closeServer()
+
+	client := fake.NewSimpleClientset(
+		newEvent("prod", "api-0", "api-uid", reasonFailedScheduling,
+			"0/3 nodes are available: 3 Insufficient cpu. preemption: 0/3 nodes are available.", 0),
+		newEvent("prod", "api-0", "api-uid", "Scheduled", "Successfully assigned prod/api-0 to node-2", 2),
+		newEvent("batch", "job-1", "job-uid", reasonPreempted, "Preempted by prod/api-0 on node node-2", 1),
+		newEvent("batch", "job-0", "job-0-uid", reasonPreempted, "Preempted by pod api-uid on node node-1", 3),
+		newEvent("batch", "job-3", "job-3-uid", reasonPreempted, "Preempted by prod/web-0 on node node-1", 4),
+	)
+
+	priority, err := getPodPriority(client, restClient, "prod", "api-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if priority.PriorityClassName != "critical" || priority.Priority == nil || *priority.Priority != 100000 ||
+		priority.PreemptionPolicy != PreemptLowerPriority || priority.NominatedNodeName != "node-2" ||
+		priority.PreemptedBy != nil || len(priority.Events) != 1 {
+		t.Errorf("GetPodPriority() returns %#v for preemptor", priority)
+	}
+	actual := make([]string, 0)
+	for _, victim := range priority.Victims {
+		actual = append(actual, victim.Namespace+"/"+victim.Pod+" on "+victim.Node)
+	}
+	if expected := []string{"batch/job-0 on node-1", "batch/job-1 on node-2"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetPodPriority() returns victims %v, expected %v", actual, expected)
+	}
+
+	priority, err = getPodPriority(client, restClient, "batch", "job-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Preemption{Namespace: "prod", Pod: "api-0", Node: "node-2", Reason: reasonPreempted,
+		Message: "Preempted by prod/api-0 on node node-2",
+		Time:    metaV1.NewTime(time.Date(2021, 6, 1, 12, 1, 0, 0, time.UTC))}
+	if priority.PreemptionPolicy != PreemptNever || !reflect.DeepEqual(priority.PreemptedBy, expected) ||
+		len(priority.Victims) != 0 {
+		t.Errorf("GetPodPriority() returns %#v for victim, expected to be preempted by %#v", priority, expected)
+	}
+
+	priority, err = getPodPriority(client, restClient, "batch", "job-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preemption := priority.PreemptedBy; preemption == nil || preemption.Reason != reasonPreemptionByScheduler ||
+		preemption.Node != "node-3" || len(preemption.Pod) != 0 {
+		t.Errorf("GetPodPriority() returns preemption %#v for victim with expired events", preemption)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package priorityclass serves priority classes and explains scheduling priority and preemptions
+// of pods.
+package priorityclass
+
+import (
+	"encoding/json"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+// GroupVersion is the group version of scheduling API.
+var GroupVersion = schema.GroupVersion{Group: "scheduling.k8s.io", Version: "v1"}
+
+const priorityClassResource = "priorityclasses"
+
+// Preemption policies of priority classes and pods.
+const (
+	PreemptLowerPriority = "PreemptLowerPriority"
+	PreemptNever         = "Never"
+)
+
+// PriorityClass provides the presentation layer view of Kubernetes priority class.
+type PriorityClass struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Value is the priority of pods using the class. Pods with higher priority are scheduled first
+	// and can preempt pods with lower priority.
+	Value int32 `json:"value"`
+
+	// GlobalDefault is true when the class is used by pods without priority class name.
+	GlobalDefault bool `json:"globalDefault"`
+
+	// PreemptionPolicy is PreemptLowerPriority or Never.
+	PreemptionPolicy string `json:"preemptionPolicy"`
+	Description      string `json:"description"`
+
+	// System is true for system-cluster-critical and system-node-critical classes.
+	System bool `json:"system"`
+}
+
+// PriorityClassList contains a list of priority classes.
+type PriorityClassList struct {
+	ListMeta        api.ListMeta    `json:"listMeta"`
+	PriorityClasses []PriorityClass `json:"priorityClasses"`
+}
+
+// priorityClass is the API representation of priority class. Client library does not contain
+// scheduling types, so only the fields used by Dashboard are declared in this package.
+type priorityClass struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Value             int32   `json:"value"`
+	GlobalDefault     bool    `json:"globalDefault"`
+	PreemptionPolicy  *string `json:"preemptionPolicy"`
+	Description       string  `json:"description"`
+}
+
+type priorityClassList struct {
+	Items []priorityClass `json:"items"`
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Get() *rest.Request
+}
+
+// newRESTClient creates REST client of scheduling API.
+func newRESTClient(config *rest.Config) (RESTClient, error) {
+	return apply.NewRESTClient(config, GroupVersion)
+}
+
+// getRaw gets priority classes. All of them are listed if name is empty.
+func getRaw(restClient RESTClient, name string) ([]byte, error) {
+	request := restClient.Get().Resource(priorityClassResource)
+	if len(name) > 0 {
+		request = request.Name(name)
+	}
+	return request.Do().Raw()
+}
+
+// GetPriorityClassList returns priority classes in the cluster.
+func GetPriorityClassList(config *rest.Config, dsQuery *dataselect.DataSelectQuery) (*PriorityClassList, error) {
+	restClient, err := newRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return getPriorityClassList(restClient, dsQuery)
+}
+
+func getPriorityClassList(restClient RESTClient, dsQuery *dataselect.DataSelectQuery) (*PriorityClassList,
+	error) {
+	logger.Info("Getting list of priority classes in the cluster")
+
+	raw, err := getRaw(restClient, "")
+	if err != nil {
+		return nil, err
+	}
+	list := priorityClassList{}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+
+	items := make([]PriorityClass, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, toPriorityClass(item))
+	}
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(items), dsQuery)
+	return &PriorityClassList{
+		ListMeta:        api.ListMeta{TotalItems: filteredTotal},
+		PriorityClasses: fromCells(cells),
+	}, nil
+}
+
+// GetPriorityClassDetail returns priority class of the name.
+func GetPriorityClassDetail(config *rest.Config, name string) (*PriorityClass, error) {
+	logger.Infof("Getting details of %s priority class", name)
+
+	restClient, err := newRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := getRaw(restClient, name)
+	if err != nil {
+		return nil, err
+	}
+	item := priorityClass{}
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return nil, err
+	}
+	result := toPriorityClass(item)
+	return &result, nil
+}
+
+func toPriorityClass(item priorityClass) PriorityClass {
+	result := PriorityClass{
+		ObjectMeta:       api.NewObjectMeta(item.ObjectMeta),
+		TypeMeta:         api.NewTypeMeta(api.ResourceKindPriorityClass),
+		Value:            item.Value,
+		GlobalDefault:    item.GlobalDefault,
+		PreemptionPolicy: PreemptLowerPriority,
+		Description:      item.Description,
+		System:           item.Name == "system-cluster-critical" || item.Name == "system-node-critical",
+	}
+	if item.PreemptionPolicy != nil {
+		result.PreemptionPolicy = *item.PreemptionPolicy
+	}
+	return result
+}
+
+// The code below allows to perform complex data section on []PriorityClass. Status property sorts
+// classes by their value.
+
+type PriorityClassCell PriorityClass
+
+func (self PriorityClassCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.StatusProperty:
+		return dataselect.StdComparableInt(int(self.Value))
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []PriorityClass) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = PriorityClassCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []PriorityClass {
+	std := make([]PriorityClass, len(cells))
+	for i := range std {
+		std[i] = PriorityClass(cells[i].(PriorityClassCell))
+	}
+	return std
+}
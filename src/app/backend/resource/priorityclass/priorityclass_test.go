@@ -0,0 +1,74 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityclass
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"k8s.io/client-go/rest"
+)
+
+const testPriorityClasses = `{"items": [
+  {"metadata": {"name": "system-node-critical"}, "value": 2000001000,
+   "description": "Used for system critical pods that must not be moved from their current node."},
+  {"metadata": {"name": "batch"}, "value": 100, "preemptionPolicy": "Never"},
+  {"metadata": {"name": "default"}, "value": 1000, "globalDefault": true}
+]}`
+
+func TestGetPriorityClassList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/scheduling.k8s.io/v1/priorityclasses" {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testPriorityClasses))
+	}))
+	defer server.Close()
+	restClient, err := newRESTClient(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byValue := dataselect.NewDataSelectQuery(dataselect.NoPagination,
+		dataselect.NewSortQuery([]string{"d", string(dataselect.StatusProperty)}), dataselect.NoFilter,
+		dataselect.NoMetrics)
+	list, err := getPriorityClassList(restClient, byValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []PriorityClass{
+		{Value: 2000001000, PreemptionPolicy: PreemptLowerPriority, System: true,
+			Description: "Used for system critical pods that must not be moved from their current node."},
+		{Value: 1000, PreemptionPolicy: PreemptLowerPriority, GlobalDefault: true},
+		{Value: 100, PreemptionPolicy: PreemptNever},
+	}
+	if list.ListMeta.TotalItems != len(expected) {
+		t.Fatalf("GetPriorityClassList() returns %d classes, expected %d", list.ListMeta.TotalItems,
+			len(expected))
+	}
+	for i, class := range list.PriorityClasses {
+		class.ObjectMeta, class.TypeMeta = expected[i].ObjectMeta, expected[i].TypeMeta
+		if !reflect.DeepEqual(class, expected[i]) {
+			t.Errorf("GetPriorityClassList() returns %#v at %d, expected %#v", class, i, expected[i])
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbacpermissions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	client "k8s.io/client-go/kubernetes"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+)
+
+const (
+	// serviceAccountUserPrefix is the prefix of user names of service accounts.
+	serviceAccountUserPrefix = "system:serviceaccount:"
+
+	// Groups, which service accounts and authenticated users implicitly belong to. Service
+	// accounts also belong to the group of their namespace, which has the prefix and namespace name.
+	serviceAccountsGroup       = "system:serviceaccounts"
+	serviceAccountsGroupPrefix = "system:serviceaccounts:"
+	authenticatedGroup         = "system:authenticated"
+)
+
+// Subject is a user, group or service account, which roles are bound to.
+type Subject struct {
+	// Kind is one of User, Group and ServiceAccount.
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// BindingRef identifies the binding granting permissions and the role it binds.
+type BindingRef struct {
+	// Kind is either RoleBinding or ClusterRoleBinding.
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	RoleKind  string `json:"roleKind"`
+	RoleName  string `json:"roleName"`
+}
+
+// rbacState contains all roles and bindings in the cluster.
+type rbacState struct {
+	roles               []rbac.Role
+	clusterRoles        []rbac.ClusterRole
+	roleBindings        []rbac.RoleBinding
+	clusterRoleBindings []rbac.ClusterRoleBinding
+}
+
+// binding is a role binding or cluster role binding together with rules of the bound role.
+type binding struct {
+	ref      BindingRef
+	subjects []rbac.Subject
+
+	// namespace where the rules apply. Empty for cluster role bindings.
+	namespace string
+
+	// rules of the bound role. Nil if the role does not exist.
+	rules []rbac.PolicyRule
+}
+
+func getRbacState(client client.Interface) (*rbacState, error) {
+	channels := &common.ResourceChannels{
+		RoleList:               common.GetRoleListChannel(client, 1),
+		ClusterRoleList:        common.GetClusterRoleListChannel(client, 1),
+		RoleBindingList:        common.GetRoleBindingListChannel(client, 1),
+		ClusterRoleBindingList: common.GetClusterRoleBindingListChannel(client, 1),
+	}
+
+	// Partial RBAC state would give wrong answers, so all errors are critical.
+	roles := <-channels.RoleList.List
+	if err := <-channels.RoleList.Error; err != nil {
+		return nil, err
+	}
+	clusterRoles := <-channels.ClusterRoleList.List
+	if err := <-channels.ClusterRoleList.Error; err != nil {
+		return nil, err
+	}
+	roleBindings := <-channels.RoleBindingList.List
+	if err := <-channels.RoleBindingList.Error; err != nil {
+		return nil, err
+	}
+	clusterRoleBindings := <-channels.ClusterRoleBindingList.List
+	if err := <-channels.ClusterRoleBindingList.Error; err != nil {
+		return nil, err
+	}
+
+	return &rbacState{
+		roles:               roles.Items,
+		clusterRoles:        clusterRoles.Items,
+		roleBindings:        roleBindings.Items,
+		clusterRoleBindings: clusterRoleBindings.Items,
+	}, nil
+}
+
+// getBindings resolves role references of all bindings.
+func (self *rbacState) getBindings() []binding {
+	roles := make(map[string][]rbac.PolicyRule)
+	for _, role := range self.roles {
+		roles[role.Namespace+"/"+role.Name] = role.Rules
+	}
+	clusterRoles := make(map[string][]rbac.PolicyRule)
+	for _, role := range self.clusterRoles {
+		clusterRoles[role.Name] = role.Rules
+	}
+
+	var result []binding
+	for _, item := range self.clusterRoleBindings {
+		result = append(result, binding{
+			ref: BindingRef{Kind: "ClusterRoleBinding", Name: item.Name, RoleKind: item.RoleRef.Kind,
+				RoleName: item.RoleRef.Name},
+			subjects: item.Subjects,
+			rules:    clusterRoles[item.RoleRef.Name],
+		})
+	}
+	for _, item := range self.roleBindings {
+		rules := clusterRoles[item.RoleRef.Name]
+		if item.RoleRef.Kind == "Role" {
+			rules = roles[item.Namespace+"/"+item.RoleRef.Name]
+		}
+		result = append(result, binding{
+			ref: BindingRef{Kind: "RoleBinding", Name: item.Name, Namespace: item.Namespace,
+				RoleKind: item.RoleRef.Kind, RoleName: item.RoleRef.Name},
+			subjects:  item.Subjects,
+			namespace: item.Namespace,
+			rules:     rules,
+		})
+	}
+	return result
+}
+
+// validateSubject returns bad request error if the subject is incomplete.
+func validateSubject(subject Subject) error {
+	switch subject.Kind {
+	case rbac.UserKind, rbac.GroupKind:
+	case rbac.ServiceAccountKind:
+		if len(subject.Namespace) == 0 {
+			return errorsK8s.NewBadRequest("namespace of service account is required")
+		}
+	default:
+		return errorsK8s.NewBadRequest(fmt.Sprintf("invalid subject kind %q, expected %s, %s or %s",
+			subject.Kind, rbac.UserKind, rbac.GroupKind, rbac.ServiceAccountKind))
+	}
+	if len(subject.Name) == 0 {
+		return errorsK8s.NewBadRequest("subject name is required")
+	}
+	return nil
+}
+
+// matchesSubject returns true if the binding subject applies to the subject. Service accounts are
+// also matched by their user name and implicit groups.
+func matchesSubject(bound rbac.Subject, subject Subject) bool {
+	switch subject.Kind {
+	case rbac.ServiceAccountKind:
+		switch bound.Kind {
+		case rbac.ServiceAccountKind:
+			return bound.Name == subject.Name && bound.Namespace == subject.Namespace
+		case rbac.UserKind:
+			return bound.Name == serviceAccountUserPrefix+subject.Namespace+":"+subject.Name
+		case rbac.GroupKind:
+			return bound.Name == serviceAccountsGroup || bound.Name == authenticatedGroup ||
+				bound.Name == serviceAccountsGroupPrefix+subject.Namespace
+		}
+	case rbac.UserKind:
+		if strings.HasPrefix(subject.Name, serviceAccountUserPrefix) {
+			parts := strings.SplitN(strings.TrimPrefix(subject.Name, serviceAccountUserPrefix), ":", 2)
+			if len(parts) == 2 {
+				return matchesSubject(bound, Subject{Kind: rbac.ServiceAccountKind, Namespace: parts[0],
+					Name: parts[1]})
+			}
+		}
+		return bound.Kind == rbac.UserKind && bound.Name == subject.Name ||
+			bound.Kind == rbac.GroupKind && bound.Name == authenticatedGroup
+	case rbac.GroupKind:
+		return bound.Kind == rbac.GroupKind && bound.Name == subject.Name
+	}
+	return false
+}
+
+func toSubject(subject rbac.Subject) Subject {
+	return Subject{Kind: subject.Kind, Name: subject.Name, Namespace: subject.Namespace}
+}
+
+// contains returns true if values contain the value or the wildcard.
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == rbac.VerbAll || item == value {
+			return true
+		}
+	}
+	return false
+}
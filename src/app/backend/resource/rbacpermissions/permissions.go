@@ -0,0 +1,212 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbacpermissions
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	client "k8s.io/client-go/kubernetes"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+)
+
+// ResourcePermission lists verbs allowed on resources of an API group. Wildcards are kept as "*".
+type ResourcePermission struct {
+	APIGroup string `json:"apiGroup"`
+	Resource string `json:"resource"`
+
+	// ResourceNames restrict the permission to the named objects. Empty means all objects.
+	ResourceNames []string `json:"resourceNames"`
+	Verbs         []string `json:"verbs"`
+}
+
+// NonResourcePermission lists verbs allowed on non-resource URL, i.e. /healthz.
+type NonResourcePermission struct {
+	URL   string   `json:"url"`
+	Verbs []string `json:"verbs"`
+}
+
+// NamespacePermissions are effective permissions in a namespace, including the ones granted in
+// all namespaces.
+type NamespacePermissions struct {
+	Namespace   string               `json:"namespace"`
+	Permissions []ResourcePermission `json:"permissions"`
+}
+
+// SubjectPermissions are effective permissions of a subject aggregated from all its bindings.
+type SubjectPermissions struct {
+	Subject  Subject      `json:"subject"`
+	Bindings []BindingRef `json:"bindings"`
+
+	// MissingRoles are bindings of the subject referring to roles, which do not exist.
+	MissingRoles []BindingRef `json:"missingRoles"`
+
+	// ClusterPermissions are granted in all namespaces by cluster role bindings.
+	ClusterPermissions     []ResourcePermission    `json:"clusterPermissions"`
+	NonResourcePermissions []NonResourcePermission `json:"nonResourcePermissions"`
+
+	// NamespacePermissions are listed for namespaces, where the subject has role bindings.
+	NamespacePermissions []NamespacePermissions `json:"namespacePermissions"`
+}
+
+// GetSubjectPermissions returns effective permissions of the user, group or service account.
+func GetSubjectPermissions(client client.Interface, subject Subject) (*SubjectPermissions, error) {
+	if err := validateSubject(subject); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Getting permissions of %s %s", strings.ToLower(subject.Kind), subject.Name)
+
+	state, err := getRbacState(client)
+	if err != nil {
+		return nil, err
+	}
+	return toSubjectPermissions(subject, state.getBindings()), nil
+}
+
+func toSubjectPermissions(subject Subject, bindings []binding) *SubjectPermissions {
+	result := &SubjectPermissions{
+		Subject:                subject,
+		Bindings:               []BindingRef{},
+		MissingRoles:           []BindingRef{},
+		NamespacePermissions:   []NamespacePermissions{},
+		NonResourcePermissions: []NonResourcePermission{},
+	}
+
+	cluster := newPermissionSet()
+	nonResource := map[string]map[string]bool{}
+	namespaces := map[string][]rbac.PolicyRule{}
+	for _, b := range bindings {
+		if !bindsSubject(b, subject) {
+			continue
+		}
+		result.Bindings = append(result.Bindings, b.ref)
+		if b.rules == nil {
+			result.MissingRoles = append(result.MissingRoles, b.ref)
+			continue
+		}
+		if len(b.namespace) > 0 {
+			namespaces[b.namespace] = append(namespaces[b.namespace], b.rules...)
+			continue
+		}
+		for _, rule := range b.rules {
+			cluster.add(rule)
+			for _, url := range rule.NonResourceURLs {
+				if nonResource[url] == nil {
+					nonResource[url] = map[string]bool{}
+				}
+				for _, verb := range rule.Verbs {
+					nonResource[url][verb] = true
+				}
+			}
+		}
+	}
+
+	result.ClusterPermissions = cluster.list()
+	for url, verbs := range nonResource {
+		result.NonResourcePermissions = append(result.NonResourcePermissions,
+			NonResourcePermission{URL: url, Verbs: sortedKeys(verbs)})
+	}
+	sort.Slice(result.NonResourcePermissions, func(i, j int) bool {
+		return result.NonResourcePermissions[i].URL < result.NonResourcePermissions[j].URL
+	})
+
+	for namespace, rules := range namespaces {
+		permissions := newPermissionSet()
+		for _, permission := range result.ClusterPermissions {
+			permissions.addPermission(permission)
+		}
+		for _, rule := range rules {
+			permissions.add(rule)
+		}
+		result.NamespacePermissions = append(result.NamespacePermissions,
+			NamespacePermissions{Namespace: namespace, Permissions: permissions.list()})
+	}
+	sort.Slice(result.NamespacePermissions, func(i, j int) bool {
+		return result.NamespacePermissions[i].Namespace < result.NamespacePermissions[j].Namespace
+	})
+	return result
+}
+
+func bindsSubject(b binding, subject Subject) bool {
+	for _, bound := range b.subjects {
+		if matchesSubject(bound, subject) {
+			return true
+		}
+	}
+	return false
+}
+
+// permissionSet merges verbs of rules granting access to the same resources.
+type permissionSet struct {
+	verbs       map[string]map[string]bool
+	permissions map[string]ResourcePermission
+}
+
+func newPermissionSet() *permissionSet {
+	return &permissionSet{verbs: map[string]map[string]bool{}, permissions: map[string]ResourcePermission{}}
+}
+
+func (self *permissionSet) add(rule rbac.PolicyRule) {
+	for _, group := range rule.APIGroups {
+		for _, resource := range rule.Resources {
+			self.addPermission(ResourcePermission{APIGroup: group, Resource: resource,
+				ResourceNames: rule.ResourceNames, Verbs: rule.Verbs})
+		}
+	}
+}
+
+func (self *permissionSet) addPermission(permission ResourcePermission) {
+	names := append([]string{}, permission.ResourceNames...)
+	sort.Strings(names)
+	key := strings.Join([]string{permission.APIGroup, permission.Resource, strings.Join(names, ",")}, "/")
+	if _, ok := self.permissions[key]; !ok {
+		self.permissions[key] = ResourcePermission{APIGroup: permission.APIGroup,
+			Resource: permission.Resource, ResourceNames: names}
+		self.verbs[key] = map[string]bool{}
+	}
+	for _, verb := range permission.Verbs {
+		self.verbs[key][verb] = true
+	}
+}
+
+func (self *permissionSet) list() []ResourcePermission {
+	result := make([]ResourcePermission, 0, len(self.permissions))
+	for key, permission := range self.permissions {
+		permission.Verbs = sortedKeys(self.verbs[key])
+		result = append(result, permission)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.APIGroup != b.APIGroup {
+			return a.APIGroup < b.APIGroup
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		return strings.Join(a.ResourceNames, ",") < strings.Join(b.ResourceNames, ",")
+	})
+	return result
+}
+
+func sortedKeys(set map[string]bool) []string {
+	result := make([]string, 0, len(set))
+	for key := range set {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,157 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbacpermissions
+
+import (
+	"reflect"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+)
+
+func getTestObjects() []runtime.Object {
+	return []runtime.Object{
+		&rbac.ClusterRole{
+			ObjectMeta: metaV1.ObjectMeta{Name: "view"},
+			Rules: []rbac.PolicyRule{
+				{Verbs: []string{"get", "list"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+				{Verbs: []string{"get"}, NonResourceURLs: []string{"/healthz"}},
+			},
+		},
+		&rbac.Role{
+			ObjectMeta: metaV1.ObjectMeta{Name: "deleter", Namespace: "prod"},
+			Rules: []rbac.PolicyRule{
+				{Verbs: []string{"delete", "get"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+				{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"secrets"},
+					ResourceNames: []string{"token"}},
+			},
+		},
+		&rbac.ClusterRoleBinding{
+			ObjectMeta: metaV1.ObjectMeta{Name: "viewers"},
+			RoleRef:    rbac.RoleRef{Kind: "ClusterRole", Name: "view"},
+			Subjects:   []rbac.Subject{{Kind: rbac.GroupKind, Name: "system:serviceaccounts:prod"}},
+		},
+		&rbac.RoleBinding{
+			ObjectMeta: metaV1.ObjectMeta{Name: "deleters", Namespace: "prod"},
+			RoleRef:    rbac.RoleRef{Kind: "Role", Name: "deleter"},
+			Subjects: []rbac.Subject{
+				{Kind: rbac.ServiceAccountKind, Name: "cleaner", Namespace: "prod"},
+				{Kind: rbac.UserKind, Name: "alice"},
+			},
+		},
+		&rbac.RoleBinding{
+			ObjectMeta: metaV1.ObjectMeta{Name: "broken", Namespace: "dev"},
+			RoleRef:    rbac.RoleRef{Kind: "Role", Name: "missing"},
+			Subjects:   []rbac.Subject{{Kind: rbac.UserKind, Name: "alice"}},
+		},
+	}
+}
+
+func TestGetSubjectPermissions(t *testing.T) {
+	client := fake.NewSimpleClientset(getTestObjects()...)
+	subject := Subject{Kind: rbac.ServiceAccountKind, Name: "cleaner", Namespace: "prod"}
+
+	actual, err := GetSubjectPermissions(client, subject)
+	if err != nil {
+		t.Fatalf("GetSubjectPermissions() returned error: %v", err)
+	}
+
+	view := ResourcePermission{APIGroup: "", Resource: "pods", ResourceNames: []string{},
+		Verbs: []string{"get", "list"}}
+	expected := &SubjectPermissions{
+		Subject: subject,
+		Bindings: []BindingRef{
+			{Kind: "ClusterRoleBinding", Name: "viewers", RoleKind: "ClusterRole", RoleName: "view"},
+			{Kind: "RoleBinding", Name: "deleters", Namespace: "prod", RoleKind: "Role",
+				RoleName: "deleter"},
+		},
+		MissingRoles:           []BindingRef{},
+		ClusterPermissions:     []ResourcePermission{view},
+		NonResourcePermissions: []NonResourcePermission{{URL: "/healthz", Verbs: []string{"get"}}},
+		NamespacePermissions: []NamespacePermissions{{
+			Namespace: "prod",
+			Permissions: []ResourcePermission{
+				{APIGroup: "", Resource: "pods", ResourceNames: []string{},
+					Verbs: []string{"delete", "get", "list"}},
+				{APIGroup: "", Resource: "secrets", ResourceNames: []string{"token"},
+					Verbs: []string{"get"}},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetSubjectPermissions() == \n%#v\nexpected \n%#v", actual, expected)
+	}
+}
+
+func TestGetSubjectPermissionsMissingRole(t *testing.T) {
+	client := fake.NewSimpleClientset(getTestObjects()...)
+
+	actual, err := GetSubjectPermissions(client, Subject{Kind: rbac.UserKind, Name: "alice"})
+	if err != nil {
+		t.Fatalf("GetSubjectPermissions() returned error: %v", err)
+	}
+
+	expected := []BindingRef{{Kind: "RoleBinding", Name: "broken", Namespace: "dev", RoleKind: "Role",
+		RoleName: "missing"}}
+	if !reflect.DeepEqual(actual.MissingRoles, expected) {
+		t.Errorf("MissingRoles == %#v, expected %#v", actual.MissingRoles, expected)
+	}
+	if len(actual.ClusterPermissions) != 0 || len(actual.NamespacePermissions) != 1 {
+		t.Errorf("Unexpected permissions %#v", actual)
+	}
+}
+
+func TestGetSubjectPermissionsInvalidSubject(t *testing.T) {
+	cases := []Subject{
+		{Kind: "Robot", Name: "r2d2"},
+		{Kind: rbac.ServiceAccountKind, Name: "default"},
+		{Kind: rbac.UserKind},
+	}
+	for _, c := range cases {
+		_, err := GetSubjectPermissions(fake.NewSimpleClientset(), c)
+		if !errorsK8s.IsBadRequest(err) {
+			t.Errorf("GetSubjectPermissions(%#v) returned %v, expected bad request", c, err)
+		}
+	}
+}
+
+func TestMatchesSubject(t *testing.T) {
+	sa := Subject{Kind: rbac.ServiceAccountKind, Name: "default", Namespace: "kube-system"}
+	cases := []struct {
+		bound    rbac.Subject
+		subject  Subject
+		expected bool
+	}{
+		{rbac.Subject{Kind: rbac.ServiceAccountKind, Name: "default", Namespace: "kube-system"}, sa, true},
+		{rbac.Subject{Kind: rbac.ServiceAccountKind, Name: "default", Namespace: "default"}, sa, false},
+		{rbac.Subject{Kind: rbac.UserKind, Name: "system:serviceaccount:kube-system:default"}, sa, true},
+		{rbac.Subject{Kind: rbac.GroupKind, Name: "system:serviceaccounts"}, sa, true},
+		{rbac.Subject{Kind: rbac.GroupKind, Name: "system:serviceaccounts:default"}, sa, false},
+		{rbac.Subject{Kind: rbac.ServiceAccountKind, Name: "default", Namespace: "kube-system"},
+			Subject{Kind: rbac.UserKind, Name: "system:serviceaccount:kube-system:default"}, true},
+		{rbac.Subject{Kind: rbac.GroupKind, Name: "system:authenticated"},
+			Subject{Kind: rbac.UserKind, Name: "alice"}, true},
+		{rbac.Subject{Kind: rbac.UserKind, Name: "alice"}, Subject{Kind: rbac.GroupKind, Name: "alice"}, false},
+	}
+	for _, c := range cases {
+		if actual := matchesSubject(c.bound, c.subject); actual != c.expected {
+			t.Errorf("matchesSubject(%#v, %#v) == %t, expected %t", c.bound, c.subject, actual, c.expected)
+		}
+	}
+}
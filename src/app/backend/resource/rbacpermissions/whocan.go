@@ -0,0 +1,124 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbacpermissions
+
+import (
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	client "k8s.io/client-go/kubernetes"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+)
+
+// WhoCanQuery describes action to find subjects allowed to perform it.
+type WhoCanQuery struct {
+	Verb     string `json:"verb"`
+	APIGroup string `json:"apiGroup"`
+	Resource string `json:"resource"`
+
+	// ResourceName is optional. If empty, only rules allowing the action on all objects match.
+	ResourceName string `json:"resourceName"`
+
+	// Namespace is optional. If empty, only subjects allowed to perform the action in all
+	// namespaces are returned.
+	Namespace string `json:"namespace"`
+}
+
+// SubjectAccess is a subject allowed to perform the action, together with the bindings allowing
+// it.
+type SubjectAccess struct {
+	Subject  Subject      `json:"subject"`
+	Bindings []BindingRef `json:"bindings"`
+}
+
+// WhoCanResult lists subjects allowed to perform the action.
+type WhoCanResult struct {
+	Query    WhoCanQuery     `json:"query"`
+	Subjects []SubjectAccess `json:"subjects"`
+}
+
+// GetWhoCan returns subjects, whose roles allow the action, i.e. who can delete pods in the prod
+// namespace. Implicit group memberships of the listed subjects are not expanded.
+func GetWhoCan(client client.Interface, query WhoCanQuery) (*WhoCanResult, error) {
+	if len(query.Verb) == 0 || len(query.Resource) == 0 {
+		return nil, errorsK8s.NewBadRequest("verb and resource are required")
+	}
+
+	logger.Infof("Getting subjects who can %s %s in namespace %q", query.Verb, query.Resource,
+		query.Namespace)
+
+	state, err := getRbacState(client)
+	if err != nil {
+		return nil, err
+	}
+	return toWhoCanResult(query, state.getBindings()), nil
+}
+
+func toWhoCanResult(query WhoCanQuery, bindings []binding) *WhoCanResult {
+	result := &WhoCanResult{Query: query, Subjects: []SubjectAccess{}}
+	subjects := map[Subject]int{}
+	for _, b := range bindings {
+		if len(b.namespace) > 0 && b.namespace != query.Namespace {
+			continue
+		}
+		if !allows(b.rules, query) {
+			continue
+		}
+		for _, bound := range b.subjects {
+			subject := toSubject(bound)
+			i, ok := subjects[subject]
+			if !ok {
+				i = len(result.Subjects)
+				subjects[subject] = i
+				result.Subjects = append(result.Subjects, SubjectAccess{Subject: subject})
+			}
+			result.Subjects[i].Bindings = append(result.Subjects[i].Bindings, b.ref)
+		}
+	}
+
+	sort.Slice(result.Subjects, func(i, j int) bool {
+		a, b := result.Subjects[i].Subject, result.Subjects[j].Subject
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return result
+}
+
+// allows returns true if any of the rules allows the queried action.
+func allows(rules []rbac.PolicyRule, query WhoCanQuery) bool {
+	for _, rule := range rules {
+		if !contains(rule.Verbs, query.Verb) || !contains(rule.APIGroups, query.APIGroup) ||
+			!contains(rule.Resources, query.Resource) {
+			continue
+		}
+		if len(rule.ResourceNames) == 0 {
+			return true
+		}
+		if len(query.ResourceName) > 0 {
+			for _, name := range rule.ResourceNames {
+				if name == query.ResourceName {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbacpermissions
+
+import (
+	"reflect"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes/fake"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+)
+
+func TestGetWhoCan(t *testing.T) {
+	deleters := BindingRef{Kind: "RoleBinding", Name: "deleters", Namespace: "prod", RoleKind: "Role",
+		RoleName: "deleter"}
+	viewers := BindingRef{Kind: "ClusterRoleBinding", Name: "viewers", RoleKind: "ClusterRole",
+		RoleName: "view"}
+	cases := []struct {
+		query    WhoCanQuery
+		expected []SubjectAccess
+	}{
+		{
+			WhoCanQuery{Verb: "delete", Resource: "pods", Namespace: "prod"},
+			[]SubjectAccess{
+				{Subject{Kind: rbac.ServiceAccountKind, Name: "cleaner", Namespace: "prod"},
+					[]BindingRef{deleters}},
+				{Subject{Kind: rbac.UserKind, Name: "alice"}, []BindingRef{deleters}},
+			},
+		},
+		{
+			WhoCanQuery{Verb: "delete", Resource: "pods", Namespace: "dev"},
+			[]SubjectAccess{},
+		},
+		{
+			WhoCanQuery{Verb: "get", Resource: "pods", Namespace: "prod"},
+			[]SubjectAccess{
+				{Subject{Kind: rbac.GroupKind, Name: "system:serviceaccounts:prod"}, []BindingRef{viewers}},
+				{Subject{Kind: rbac.ServiceAccountKind, Name: "cleaner", Namespace: "prod"},
+					[]BindingRef{deleters}},
+				{Subject{Kind: rbac.UserKind, Name: "alice"}, []BindingRef{deleters}},
+			},
+		},
+		{
+			WhoCanQuery{Verb: "get", Resource: "secrets", Namespace: "prod"},
+			[]SubjectAccess{},
+		},
+		{
+			WhoCanQuery{Verb: "get", Resource: "secrets", Namespace: "prod", ResourceName: "token"},
+			[]SubjectAccess{
+				{Subject{Kind: rbac.ServiceAccountKind, Name: "cleaner", Namespace: "prod"},
+					[]BindingRef{deleters}},
+				{Subject{Kind: rbac.UserKind, Name: "alice"}, []BindingRef{deleters}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := GetWhoCan(fake.NewSimpleClientset(getTestObjects()...), c.query)
+		if err != nil {
+			t.Fatalf("GetWhoCan(%#v) returned error: %v", c.query, err)
+		}
+		if !reflect.DeepEqual(actual.Subjects, c.expected) {
+			t.Errorf("GetWhoCan(%#v) == \n%#v\nexpected \n%#v", c.query, actual.Subjects, c.expected)
+		}
+	}
+}
+
+func TestGetWhoCanInvalidQuery(t *testing.T) {
+	_, err := GetWhoCan(fake.NewSimpleClientset(), WhoCanQuery{Verb: "get"})
+	if !errorsK8s.IsBadRequest(err) {
+		t.Errorf("GetWhoCan() returned %v, expected bad request", err)
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbacrolebindings
+
+import (
+	"fmt"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/rbacroles"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+)
+
+// RbacRoleBindingSpec is a specification of role binding to create or update. Role binding
+// without namespace is created as a ClusterRoleBinding.
+type RbacRoleBindingSpec struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Labels    map[string]string `json:"labels"`
+	RoleRef   rbac.RoleRef      `json:"roleRef"`
+	Subjects  []rbac.Subject    `json:"subjects"`
+}
+
+// CreateRbacRoleBinding creates a RoleBinding or ClusterRoleBinding after checking that the user
+// is allowed to bind the role.
+func CreateRbacRoleBinding(client client.Interface, spec *RbacRoleBindingSpec) (*RbacRoleBinding, error) {
+	logger.Infof("Creating role binding %s in namespace %q", spec.Name, spec.Namespace)
+
+	if err := validateRbacRoleBindingSpec(spec); err != nil {
+		return nil, err
+	}
+	if err := checkBindingEscalation(client, spec); err != nil {
+		return nil, err
+	}
+
+	meta := metaV1.ObjectMeta{Name: spec.Name, Namespace: spec.Namespace, Labels: spec.Labels}
+	if len(spec.Namespace) == 0 {
+		binding, err := client.RbacV1beta1().ClusterRoleBindings().Create(&rbac.ClusterRoleBinding{
+			ObjectMeta: meta, RoleRef: spec.RoleRef, Subjects: spec.Subjects})
+		if err != nil {
+			return nil, err
+		}
+		return toRbacRoleBinding(binding.ObjectMeta, api.ResourceKindRbacClusterRoleBinding,
+			binding.RoleRef, binding.Subjects), nil
+	}
+
+	binding, err := client.RbacV1beta1().RoleBindings(spec.Namespace).Create(&rbac.RoleBinding{
+		ObjectMeta: meta, RoleRef: spec.RoleRef, Subjects: spec.Subjects})
+	if err != nil {
+		return nil, err
+	}
+	return toRbacRoleBinding(binding.ObjectMeta, api.ResourceKindRbacRoleBinding, binding.RoleRef,
+		binding.Subjects), nil
+}
+
+// UpdateRbacRoleBinding replaces subjects of existing RoleBinding or ClusterRoleBinding. Labels
+// are replaced only if given. Role reference can not be changed.
+func UpdateRbacRoleBinding(client client.Interface, spec *RbacRoleBindingSpec) (*RbacRoleBinding, error) {
+	logger.Infof("Updating role binding %s in namespace %q", spec.Name, spec.Namespace)
+
+	if err := validateRbacRoleBindingSpec(spec); err != nil {
+		return nil, err
+	}
+	if err := checkBindingEscalation(client, spec); err != nil {
+		return nil, err
+	}
+
+	if len(spec.Namespace) == 0 {
+		binding, err := client.RbacV1beta1().ClusterRoleBindings().Get(spec.Name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if binding.RoleRef != spec.RoleRef {
+			return nil, newRoleRefChangedError(binding.RoleRef)
+		}
+		binding.Subjects = spec.Subjects
+		if spec.Labels != nil {
+			binding.Labels = spec.Labels
+		}
+		binding, err = client.RbacV1beta1().ClusterRoleBindings().Update(binding)
+		if err != nil {
+			return nil, err
+		}
+		return toRbacRoleBinding(binding.ObjectMeta, api.ResourceKindRbacClusterRoleBinding,
+			binding.RoleRef, binding.Subjects), nil
+	}
+
+	binding, err := client.RbacV1beta1().RoleBindings(spec.Namespace).Get(spec.Name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if binding.RoleRef != spec.RoleRef {
+		return nil, newRoleRefChangedError(binding.RoleRef)
+	}
+	binding.Subjects = spec.Subjects
+	if spec.Labels != nil {
+		binding.Labels = spec.Labels
+	}
+	binding, err = client.RbacV1beta1().RoleBindings(spec.Namespace).Update(binding)
+	if err != nil {
+		return nil, err
+	}
+	return toRbacRoleBinding(binding.ObjectMeta, api.ResourceKindRbacRoleBinding, binding.RoleRef,
+		binding.Subjects), nil
+}
+
+// DeleteRbacRoleBinding deletes RoleBinding from the namespace, or ClusterRoleBinding if the
+// namespace is empty.
+func DeleteRbacRoleBinding(client client.Interface, namespace, name string) error {
+	logger.Infof("Deleting role binding %s in namespace %q", name, namespace)
+	if len(namespace) == 0 {
+		return client.RbacV1beta1().ClusterRoleBindings().Delete(name, &metaV1.DeleteOptions{})
+	}
+	return client.RbacV1beta1().RoleBindings(namespace).Delete(name, &metaV1.DeleteOptions{})
+}
+
+// validateRbacRoleBindingSpec returns bad request error if the spec is invalid. API groups of the
+// role reference and subjects, and namespaces of service accounts bound in a namespace are
+// defaulted.
+func validateRbacRoleBindingSpec(spec *RbacRoleBindingSpec) error {
+	if len(spec.Name) == 0 {
+		return errorsK8s.NewBadRequest("name is required")
+	}
+
+	switch spec.RoleRef.Kind {
+	case "ClusterRole":
+	case "Role":
+		if len(spec.Namespace) == 0 {
+			return errorsK8s.NewBadRequest("cluster role binding can only reference a ClusterRole")
+		}
+	default:
+		return errorsK8s.NewBadRequest(fmt.Sprintf("invalid role kind %q, expected Role or ClusterRole",
+			spec.RoleRef.Kind))
+	}
+	if len(spec.RoleRef.Name) == 0 {
+		return errorsK8s.NewBadRequest("role name is required")
+	}
+	if len(spec.RoleRef.APIGroup) == 0 {
+		spec.RoleRef.APIGroup = rbac.GroupName
+	}
+
+	for i := range spec.Subjects {
+		subject := &spec.Subjects[i]
+		if len(subject.Name) == 0 {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("subject %d: name is required", i))
+		}
+		switch subject.Kind {
+		case rbac.UserKind, rbac.GroupKind:
+			if len(subject.APIGroup) == 0 {
+				subject.APIGroup = rbac.GroupName
+			}
+		case rbac.ServiceAccountKind:
+			if len(subject.Namespace) == 0 {
+				subject.Namespace = spec.Namespace
+			}
+			if len(subject.Namespace) == 0 {
+				return errorsK8s.NewBadRequest(fmt.Sprintf(
+					"subject %d: namespace of service account is required", i))
+			}
+		default:
+			return errorsK8s.NewBadRequest(fmt.Sprintf("subject %d: invalid kind %q", i, subject.Kind))
+		}
+	}
+	return nil
+}
+
+// checkBindingEscalation returns forbidden error if the user is neither allowed to bind the role,
+// nor has all permissions the role grants.
+func checkBindingEscalation(client client.Interface, spec *RbacRoleBindingSpec) error {
+	resource := "clusterroles"
+	if spec.RoleRef.Kind == "Role" {
+		resource = "roles"
+	}
+	allowed, err := rbacroles.IsAllowed(client, "bind", resource, spec.Namespace, spec.RoleRef.Name)
+	if err != nil || allowed {
+		return err
+	}
+
+	var rules []rbac.PolicyRule
+	if spec.RoleRef.Kind == "Role" {
+		role, err := client.RbacV1beta1().Roles(spec.Namespace).Get(spec.RoleRef.Name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		rules = role.Rules
+	} else {
+		role, err := client.RbacV1beta1().ClusterRoles().Get(spec.RoleRef.Name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		rules = role.Rules
+	}
+
+	missing, err := rbacroles.GetMissingPermissions(client, spec.Namespace, rules)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return rbacroles.NewEscalationError(resource, spec.RoleRef.Name, missing)
+	}
+	return nil
+}
+
+func newRoleRefChangedError(roleRef rbac.RoleRef) error {
+	return errorsK8s.NewBadRequest(fmt.Sprintf(
+		"role reference can not be changed from %s %s, delete and recreate the binding",
+		roleRef.Kind, roleRef.Name))
+}
+
+func toRbacRoleBinding(meta metaV1.ObjectMeta, kind api.ResourceKind, roleRef rbac.RoleRef,
+	subjects []rbac.Subject) *RbacRoleBinding {
+	return &RbacRoleBinding{
+		ObjectMeta: api.NewObjectMeta(meta),
+		TypeMeta:   api.NewTypeMeta(kind),
+		Name:       meta.Name,
+		Namespace:  meta.Namespace,
+		RoleRef:    roleRef,
+		Subjects:   subjects,
+	}
+}
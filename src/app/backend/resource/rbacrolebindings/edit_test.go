@@ -0,0 +1,134 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbacrolebindings
+
+import (
+	"reflect"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	authorization "k8s.io/client-go/pkg/apis/authorization/v1"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+	core "k8s.io/client-go/testing"
+)
+
+// newReviewingClient returns fake client allowing only the given verbs, regardless of resource.
+func newReviewingClient(verbs []string, objects ...runtime.Object) *fake.Clientset {
+	client := fake.NewSimpleClientset(objects...)
+	client.PrependReactor("create", "selfsubjectaccessreviews",
+		func(action core.Action) (bool, runtime.Object, error) {
+			review := action.(core.CreateAction).GetObject().(*authorization.SelfSubjectAccessReview)
+			for _, verb := range verbs {
+				review.Status.Allowed = review.Status.Allowed || verb == review.Spec.ResourceAttributes.Verb
+			}
+			return true, review, nil
+		})
+	return client
+}
+
+func getTestRole() *rbac.Role {
+	return &rbac.Role{
+		ObjectMeta: metaV1.ObjectMeta{Name: "deleter", Namespace: "prod"},
+		Rules: []rbac.PolicyRule{
+			{Verbs: []string{"delete"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+		},
+	}
+}
+
+func TestCreateRbacRoleBinding(t *testing.T) {
+	spec := func() *RbacRoleBindingSpec {
+		return &RbacRoleBindingSpec{
+			Name:      "deleters",
+			Namespace: "prod",
+			RoleRef:   rbac.RoleRef{Kind: "Role", Name: "deleter"},
+			Subjects: []rbac.Subject{
+				{Kind: rbac.ServiceAccountKind, Name: "cleaner"},
+				{Kind: rbac.UserKind, Name: "alice"},
+			},
+		}
+	}
+	cases := []struct {
+		verbs     []string
+		forbidden bool
+	}{
+		{[]string{"bind"}, false},
+		{[]string{"delete"}, false},
+		{[]string{"get"}, true},
+	}
+
+	for _, c := range cases {
+		actual, err := CreateRbacRoleBinding(newReviewingClient(c.verbs, getTestRole()), spec())
+		if c.forbidden {
+			if !errorsK8s.IsForbidden(err) {
+				t.Errorf("CreateRbacRoleBinding() with %v returned %v, expected forbidden", c.verbs, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("CreateRbacRoleBinding() with %v returned error: %v", c.verbs, err)
+		}
+
+		expected := []rbac.Subject{
+			{Kind: rbac.ServiceAccountKind, Name: "cleaner", Namespace: "prod"},
+			{Kind: rbac.UserKind, APIGroup: rbac.GroupName, Name: "alice"},
+		}
+		if !reflect.DeepEqual(actual.Subjects, expected) || actual.RoleRef.APIGroup != rbac.GroupName {
+			t.Errorf("CreateRbacRoleBinding() == %#v, expected defaulted subjects and role reference", actual)
+		}
+	}
+}
+
+func TestCreateRbacRoleBindingInvalid(t *testing.T) {
+	cases := []*RbacRoleBindingSpec{
+		{Name: "a", RoleRef: rbac.RoleRef{Kind: "Role", Name: "view"}},
+		{Name: "a", RoleRef: rbac.RoleRef{Kind: "Policy", Name: "view"}},
+		{Name: "a", RoleRef: rbac.RoleRef{Kind: "ClusterRole", Name: "view"},
+			Subjects: []rbac.Subject{{Kind: rbac.ServiceAccountKind, Name: "default"}}},
+		{RoleRef: rbac.RoleRef{Kind: "ClusterRole", Name: "view"}},
+	}
+	for _, c := range cases {
+		_, err := CreateRbacRoleBinding(newReviewingClient([]string{"bind"}), c)
+		if !errorsK8s.IsBadRequest(err) {
+			t.Errorf("CreateRbacRoleBinding(%#v) returned %v, expected bad request", c, err)
+		}
+	}
+}
+
+func TestUpdateRbacRoleBinding(t *testing.T) {
+	client := newReviewingClient([]string{"bind"}, &rbac.ClusterRoleBinding{
+		ObjectMeta: metaV1.ObjectMeta{Name: "viewers"},
+		RoleRef:    rbac.RoleRef{APIGroup: rbac.GroupName, Kind: "ClusterRole", Name: "view"},
+	})
+	subjects := []rbac.Subject{{Kind: rbac.GroupKind, APIGroup: rbac.GroupName, Name: "devs"}}
+
+	_, err := UpdateRbacRoleBinding(client, &RbacRoleBindingSpec{Name: "viewers",
+		RoleRef: rbac.RoleRef{Kind: "ClusterRole", Name: "view"}, Subjects: subjects})
+	if err != nil {
+		t.Fatalf("UpdateRbacRoleBinding() returned error: %v", err)
+	}
+	binding, _ := client.RbacV1beta1().ClusterRoleBindings().Get("viewers", metaV1.GetOptions{})
+	if !reflect.DeepEqual(binding.Subjects, subjects) {
+		t.Errorf("Subjects == %#v, expected %#v", binding.Subjects, subjects)
+	}
+
+	_, err = UpdateRbacRoleBinding(client, &RbacRoleBindingSpec{Name: "viewers",
+		RoleRef: rbac.RoleRef{Kind: "ClusterRole", Name: "admin"}, Subjects: subjects})
+	if !errorsK8s.IsBadRequest(err) {
+		t.Errorf("UpdateRbacRoleBinding() returned %v, expected bad request for changed role", err)
+	}
+}
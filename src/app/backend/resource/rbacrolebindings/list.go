@@ -15,9 +15,8 @@
 package rbacrolebindings
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	client "k8s.io/client-go/kubernetes"
@@ -45,7 +44,7 @@ type RbacRoleBinding struct {
 
 // GetRbacRoleBindingList returns a list of all RBAC Role Bindings in the cluster.
 func GetRbacRoleBindingList(client *client.Clientset, dsQuery *dataselect.DataSelectQuery) (*RbacRoleBindingList, error) {
-	log.Print("Getting list rbac role bindings")
+	logger.Info("Getting list rbac role bindings")
 	channels := &common.ResourceChannels{
 		RoleList:        common.GetRoleListChannel(client, 1),
 		ClusterRoleList: common.GetClusterRoleListChannel(client, 1),
@@ -100,10 +99,10 @@ func SimplifyRbacRoleBindingLists(roleBindings []rbac.RoleBinding, clusterRoleBi
 				Subjects:   item.Subjects,
 			})
 	}
-	selectedItems := fromCells(dataselect.GenericDataSelect(toCells(items), dsQuery))
+	selectedCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(items), dsQuery)
 	result := &RbacRoleBindingList{
-		Items:    selectedItems,
-		ListMeta: api.ListMeta{TotalItems: len(roleBindings) + len(clusterRoleBindings)},
+		Items:    fromCells(selectedCells),
+		ListMeta: api.ListMeta{TotalItems: filteredTotal},
 	}
 	return result
 }
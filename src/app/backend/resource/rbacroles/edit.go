@@ -0,0 +1,160 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbacroles
+
+import (
+	"fmt"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+)
+
+// RbacRoleSpec is a specification of role to create or update. Role without namespace is created
+// as a ClusterRole.
+type RbacRoleSpec struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Labels    map[string]string `json:"labels"`
+	Rules     []rbac.PolicyRule `json:"rules"`
+}
+
+// CreateRbacRole creates a Role or ClusterRole after checking that the user is allowed to grant
+// its rules.
+func CreateRbacRole(client client.Interface, spec *RbacRoleSpec) (*RbacRole, error) {
+	logger.Infof("Creating role %s in namespace %q", spec.Name, spec.Namespace)
+
+	if err := validateRbacRoleSpec(spec); err != nil {
+		return nil, err
+	}
+	if err := checkRoleEscalation(client, spec); err != nil {
+		return nil, err
+	}
+
+	meta := metaV1.ObjectMeta{Name: spec.Name, Namespace: spec.Namespace, Labels: spec.Labels}
+	if len(spec.Namespace) == 0 {
+		role, err := client.RbacV1beta1().ClusterRoles().Create(
+			&rbac.ClusterRole{ObjectMeta: meta, Rules: spec.Rules})
+		if err != nil {
+			return nil, err
+		}
+		result := toRbacRole(role.ObjectMeta, api.ResourceKindRbacClusterRole)
+		return &result, nil
+	}
+
+	role, err := client.RbacV1beta1().Roles(spec.Namespace).Create(
+		&rbac.Role{ObjectMeta: meta, Rules: spec.Rules})
+	if err != nil {
+		return nil, err
+	}
+	result := toRbacRole(role.ObjectMeta, api.ResourceKindRbacRole)
+	return &result, nil
+}
+
+// UpdateRbacRole replaces rules of existing Role or ClusterRole. Labels are replaced only if
+// given.
+func UpdateRbacRole(client client.Interface, spec *RbacRoleSpec) (*RbacRole, error) {
+	logger.Infof("Updating role %s in namespace %q", spec.Name, spec.Namespace)
+
+	if err := validateRbacRoleSpec(spec); err != nil {
+		return nil, err
+	}
+	if err := checkRoleEscalation(client, spec); err != nil {
+		return nil, err
+	}
+
+	if len(spec.Namespace) == 0 {
+		role, err := client.RbacV1beta1().ClusterRoles().Get(spec.Name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		role.Rules = spec.Rules
+		if spec.Labels != nil {
+			role.Labels = spec.Labels
+		}
+		role, err = client.RbacV1beta1().ClusterRoles().Update(role)
+		if err != nil {
+			return nil, err
+		}
+		result := toRbacRole(role.ObjectMeta, api.ResourceKindRbacClusterRole)
+		return &result, nil
+	}
+
+	role, err := client.RbacV1beta1().Roles(spec.Namespace).Get(spec.Name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	role.Rules = spec.Rules
+	if spec.Labels != nil {
+		role.Labels = spec.Labels
+	}
+	role, err = client.RbacV1beta1().Roles(spec.Namespace).Update(role)
+	if err != nil {
+		return nil, err
+	}
+	result := toRbacRole(role.ObjectMeta, api.ResourceKindRbacRole)
+	return &result, nil
+}
+
+// DeleteRbacRole deletes Role from the namespace, or ClusterRole if the namespace is empty.
+func DeleteRbacRole(client client.Interface, namespace, name string) error {
+	logger.Infof("Deleting role %s in namespace %q", name, namespace)
+	if len(namespace) == 0 {
+		return client.RbacV1beta1().ClusterRoles().Delete(name, &metaV1.DeleteOptions{})
+	}
+	return client.RbacV1beta1().Roles(namespace).Delete(name, &metaV1.DeleteOptions{})
+}
+
+// ValidateRules returns bad request error describing the first invalid rule. Non-resource URLs
+// are allowed only in cluster wide rules.
+func ValidateRules(rules []rbac.PolicyRule, namespaced bool) error {
+	for i, rule := range rules {
+		var err string
+		switch {
+		case len(rule.Verbs) == 0:
+			err = "verbs are required"
+		case len(rule.NonResourceURLs) > 0 && namespaced:
+			err = "namespaced rules can not apply to non-resource URLs"
+		case len(rule.NonResourceURLs) > 0 && (len(rule.APIGroups) > 0 || len(rule.Resources) > 0):
+			err = "rules can not apply to both resources and non-resource URLs"
+		case len(rule.NonResourceURLs) == 0 && len(rule.Resources) == 0:
+			err = "resources or non-resource URLs are required"
+		case len(rule.NonResourceURLs) == 0 && len(rule.APIGroups) == 0:
+			err = `API groups are required, use "" for the core group`
+		}
+		if len(err) > 0 {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("rule %d: %s", i, err))
+		}
+	}
+	return nil
+}
+
+func validateRbacRoleSpec(spec *RbacRoleSpec) error {
+	if len(spec.Name) == 0 {
+		return errorsK8s.NewBadRequest("name is required")
+	}
+	return ValidateRules(spec.Rules, len(spec.Namespace) > 0)
+}
+
+// getRoleResource returns resource of the role, roles or clusterroles if the namespace is empty.
+func getRoleResource(namespace string) string {
+	if len(namespace) == 0 {
+		return "clusterroles"
+	}
+	return "roles"
+}
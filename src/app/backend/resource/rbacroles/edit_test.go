@@ -0,0 +1,165 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbacroles
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	authorization "k8s.io/client-go/pkg/apis/authorization/v1"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+	core "k8s.io/client-go/testing"
+)
+
+// newReviewingClient returns fake client allowing only the given verbs, regardless of resource.
+func newReviewingClient(verbs []string, objects ...runtime.Object) *fake.Clientset {
+	client := fake.NewSimpleClientset(objects...)
+	client.PrependReactor("create", "selfsubjectaccessreviews",
+		func(action core.Action) (bool, runtime.Object, error) {
+			review := action.(core.CreateAction).GetObject().(*authorization.SelfSubjectAccessReview)
+			var verb string
+			if review.Spec.ResourceAttributes != nil {
+				verb = review.Spec.ResourceAttributes.Verb
+			} else {
+				verb = review.Spec.NonResourceAttributes.Verb
+			}
+			for _, allowed := range verbs {
+				review.Status.Allowed = review.Status.Allowed || allowed == verb
+			}
+			return true, review, nil
+		})
+	return client
+}
+
+func TestCreateRbacRole(t *testing.T) {
+	rules := []rbac.PolicyRule{
+		{Verbs: []string{"get", "delete"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+		{Verbs: []string{"get"}, NonResourceURLs: []string{"/healthz"}},
+	}
+	cases := []struct {
+		verbs    []string
+		spec     *RbacRoleSpec
+		expected *RbacRole
+		missing  bool
+	}{
+		{
+			[]string{"escalate"},
+			&RbacRoleSpec{Name: "admin", Rules: rules},
+			&RbacRole{ObjectMeta: api.ObjectMeta{Name: "admin"},
+				TypeMeta: api.TypeMeta{Kind: api.ResourceKindRbacClusterRole}},
+			false,
+		},
+		{
+			[]string{"get", "delete"},
+			&RbacRoleSpec{Name: "admin", Rules: rules},
+			&RbacRole{ObjectMeta: api.ObjectMeta{Name: "admin"},
+				TypeMeta: api.TypeMeta{Kind: api.ResourceKindRbacClusterRole}},
+			false,
+		},
+		{
+			[]string{"get"},
+			&RbacRoleSpec{Name: "admin", Rules: rules},
+			nil,
+			true,
+		},
+		{
+			[]string{"get", "delete"},
+			&RbacRoleSpec{Name: "pod-admin", Namespace: "prod", Rules: rules[:1]},
+			&RbacRole{ObjectMeta: api.ObjectMeta{Name: "pod-admin", Namespace: "prod"},
+				TypeMeta: api.TypeMeta{Kind: api.ResourceKindRbacRole}},
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := CreateRbacRole(newReviewingClient(c.verbs), c.spec)
+		if c.missing {
+			if !errorsK8s.IsForbidden(err) {
+				t.Errorf("CreateRbacRole(%#v) returned %v, expected forbidden", c.spec, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("CreateRbacRole(%#v) returned error: %v", c.spec, err)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("CreateRbacRole(%#v) == %#v, expected %#v", c.spec, actual, c.expected)
+		}
+	}
+}
+
+func TestGetMissingPermissions(t *testing.T) {
+	rules := []rbac.PolicyRule{
+		{Verbs: []string{"get", "update"}, APIGroups: []string{"apps"},
+			Resources: []string{"deployments", "deployments/scale"}, ResourceNames: []string{"web"}},
+	}
+	actual, err := GetMissingPermissions(newReviewingClient([]string{"get"}), "prod", rules)
+	if err != nil {
+		t.Fatalf("GetMissingPermissions() returned error: %v", err)
+	}
+	expected := []string{`update deployments.apps "web"`, `update deployments/scale.apps "web"`}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetMissingPermissions() == %#v, expected %#v", actual, expected)
+	}
+}
+
+func TestUpdateRbacRole(t *testing.T) {
+	client := newReviewingClient([]string{"escalate"}, &rbac.Role{
+		ObjectMeta: metaV1.ObjectMeta{Name: "reader", Namespace: "prod", Labels: map[string]string{"a": "b"}},
+	})
+	rules := []rbac.PolicyRule{{Verbs: []string{"list"}, APIGroups: []string{""}, Resources: []string{"pods"}}}
+
+	if _, err := UpdateRbacRole(client, &RbacRoleSpec{Name: "reader", Namespace: "prod", Rules: rules}); err != nil {
+		t.Fatalf("UpdateRbacRole() returned error: %v", err)
+	}
+
+	role, _ := client.RbacV1beta1().Roles("prod").Get("reader", metaV1.GetOptions{})
+	if !reflect.DeepEqual(role.Rules, rules) || role.Labels["a"] != "b" {
+		t.Errorf("Role was not updated correctly: %#v", role)
+	}
+
+	_, err := UpdateRbacRole(client, &RbacRoleSpec{Name: "writer", Namespace: "prod", Rules: rules})
+	if !errorsK8s.IsNotFound(err) {
+		t.Errorf("UpdateRbacRole() returned %v, expected not found", err)
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	cases := []struct {
+		rule       rbac.PolicyRule
+		namespaced bool
+		valid      bool
+	}{
+		{rbac.PolicyRule{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"pods"}}, true, true},
+		{rbac.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}}, true, false},
+		{rbac.PolicyRule{Verbs: []string{"get"}, Resources: []string{"pods"}}, true, false},
+		{rbac.PolicyRule{Verbs: []string{"get"}, APIGroups: []string{""}}, false, false},
+		{rbac.PolicyRule{Verbs: []string{"get"}, NonResourceURLs: []string{"/metrics"}}, false, true},
+		{rbac.PolicyRule{Verbs: []string{"get"}, NonResourceURLs: []string{"/metrics"}}, true, false},
+		{rbac.PolicyRule{Verbs: []string{"get"}, NonResourceURLs: []string{"/metrics"},
+			Resources: []string{"pods"}}, false, false},
+	}
+	for _, c := range cases {
+		err := ValidateRules([]rbac.PolicyRule{c.rule}, c.namespaced)
+		if c.valid && err != nil || !c.valid && !errorsK8s.IsBadRequest(err) {
+			t.Errorf("ValidateRules(%#v, %t) returned %v", c.rule, c.namespaced, err)
+		}
+	}
+}
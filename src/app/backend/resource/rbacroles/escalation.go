@@ -0,0 +1,161 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rbacroles
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/accessreview"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	client "k8s.io/client-go/kubernetes"
+	authorization "k8s.io/client-go/pkg/apis/authorization/v1"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+)
+
+// IsAllowed checks whether the user is allowed to use the verb, i.e. bind or escalate, on the
+// roles or clusterroles resource.
+func IsAllowed(client client.Interface, verb, resource, namespace, name string) (bool, error) {
+	review := accessreview.ReviewAction(client, accessreview.ResourceAction{
+		Verb:      verb,
+		Group:     rbac.GroupName,
+		Resource:  resource,
+		Namespace: namespace,
+		Name:      name,
+	})
+	if len(review.Error) > 0 {
+		return false, errors.New(review.Error)
+	}
+	return review.Allowed, nil
+}
+
+// GetMissingPermissions returns actions allowed by the rules in the namespace, empty for all
+// namespaces, which the user is not allowed to perform. Kubernetes refuses to grant permissions
+// the user does not have, unless the user is allowed to escalate or bind the role.
+func GetMissingPermissions(client client.Interface, namespace string, rules []rbac.PolicyRule) (
+	[]string, error) {
+	missing := make([]string, 0)
+	for _, rule := range rules {
+		for _, action := range toResourceActions(namespace, rule) {
+			review := accessreview.ReviewAction(client, action)
+			if len(review.Error) > 0 {
+				return nil, errors.New(review.Error)
+			}
+			if !review.Allowed {
+				missing = append(missing, describeAction(action))
+			}
+		}
+
+		for _, url := range rule.NonResourceURLs {
+			for _, verb := range rule.Verbs {
+				allowed, err := isNonResourceAllowed(client, verb, url)
+				if err != nil {
+					return nil, err
+				}
+				if !allowed {
+					missing = append(missing, fmt.Sprintf("%s %s", verb, url))
+				}
+			}
+		}
+	}
+	return missing, nil
+}
+
+// NewEscalationError returns forbidden error listing the permissions the user can not grant.
+func NewEscalationError(resource, name string, missing []string) error {
+	return errorsK8s.NewForbidden(schema.GroupResource{Group: rbac.GroupName, Resource: resource},
+		name, fmt.Errorf("permissions the user does not have can not be granted: %s",
+			strings.Join(missing, ", ")))
+}
+
+// checkRoleEscalation returns forbidden error if the user is not allowed to create or update the
+// role with the given rules.
+func checkRoleEscalation(client client.Interface, spec *RbacRoleSpec) error {
+	resource := getRoleResource(spec.Namespace)
+	allowed, err := IsAllowed(client, "escalate", resource, spec.Namespace, spec.Name)
+	if err != nil || allowed {
+		return err
+	}
+
+	missing, err := GetMissingPermissions(client, spec.Namespace, spec.Rules)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return NewEscalationError(resource, spec.Name, missing)
+	}
+	return nil
+}
+
+// toResourceActions expands the rule to single actions. Wildcards are reviewed as they are, so
+// that granting "*" requires having "*".
+func toResourceActions(namespace string, rule rbac.PolicyRule) []accessreview.ResourceAction {
+	names := rule.ResourceNames
+	if len(names) == 0 {
+		names = []string{""}
+	}
+
+	actions := make([]accessreview.ResourceAction, 0)
+	for _, group := range rule.APIGroups {
+		for _, resource := range rule.Resources {
+			subresource := ""
+			if parts := strings.SplitN(resource, "/", 2); len(parts) == 2 {
+				resource, subresource = parts[0], parts[1]
+			}
+			for _, verb := range rule.Verbs {
+				for _, name := range names {
+					actions = append(actions, accessreview.ResourceAction{
+						Verb:        verb,
+						Group:       group,
+						Resource:    resource,
+						Subresource: subresource,
+						Namespace:   namespace,
+						Name:        name,
+					})
+				}
+			}
+		}
+	}
+	return actions
+}
+
+func describeAction(action accessreview.ResourceAction) string {
+	resource := action.Resource
+	if len(action.Subresource) > 0 {
+		resource += "/" + action.Subresource
+	}
+	if len(action.Group) > 0 {
+		resource += "." + action.Group
+	}
+	if len(action.Name) > 0 {
+		return fmt.Sprintf("%s %s %q", action.Verb, resource, action.Name)
+	}
+	return fmt.Sprintf("%s %s", action.Verb, resource)
+}
+
+func isNonResourceAllowed(client client.Interface, verb, url string) (bool, error) {
+	result, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(
+		&authorization.SelfSubjectAccessReview{
+			Spec: authorization.SelfSubjectAccessReviewSpec{
+				NonResourceAttributes: &authorization.NonResourceAttributes{Path: url, Verb: verb},
+			},
+		})
+	if err != nil {
+		return false, err
+	}
+	return result.Status.Allowed, nil
+}
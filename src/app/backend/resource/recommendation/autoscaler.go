@@ -0,0 +1,107 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommendation
+
+import (
+	"encoding/json"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+// GroupVersion is the group version of Vertical Pod Autoscaler API.
+var GroupVersion = schema.GroupVersion{Group: "autoscaling.k8s.io", Version: "v1"}
+
+const verticalPodAutoscalerResource = "verticalpodautoscalers"
+
+// verticalPodAutoscaler is the API representation of Vertical Pod Autoscaler. Client library does
+// not contain its types, so only the fields used by Dashboard are declared in this package.
+type verticalPodAutoscaler struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		TargetRef struct {
+			Kind string `json:"kind"`
+			Name string `json:"name"`
+		} `json:"targetRef"`
+	} `json:"spec"`
+	Status struct {
+		Recommendation *struct {
+			ContainerRecommendations []struct {
+				ContainerName string          `json:"containerName"`
+				Target        v1.ResourceList `json:"target"`
+			} `json:"containerRecommendations"`
+		} `json:"recommendation"`
+	} `json:"status"`
+}
+
+type verticalPodAutoscalerList struct {
+	Items []verticalPodAutoscaler `json:"items"`
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Get() *rest.Request
+}
+
+// newRESTClient creates REST client of Vertical Pod Autoscaler API.
+func newRESTClient(config *rest.Config) (RESTClient, error) {
+	return apply.NewRESTClient(config, GroupVersion)
+}
+
+// getRaw lists Vertical Pod Autoscalers in the namespace.
+func getRaw(restClient RESTClient, namespace string) ([]byte, error) {
+	return restClient.Get().Namespace(namespace).Resource(verticalPodAutoscalerResource).Do().Raw()
+}
+
+// getVerticalPodAutoscaler returns Vertical Pod Autoscaler targeting the workload. Nil is returned
+// if there is none, Vertical Pod Autoscaler is not installed or the user cannot list them.
+func getVerticalPodAutoscaler(restClient RESTClient, kind, namespace, name string) (*verticalPodAutoscaler,
+	error) {
+	raw, err := getRaw(restClient, namespace)
+	if errorsK8s.IsNotFound(err) || errorsK8s.IsForbidden(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	list := verticalPodAutoscalerList{}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		target := list.Items[i].Spec.TargetRef
+		if target.Kind == kind && target.Name == name {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// targets returns target recommendations by container name.
+func (self *verticalPodAutoscaler) targets() map[string]v1.ResourceList {
+	result := make(map[string]v1.ResourceList)
+	if self.Status.Recommendation == nil {
+		return result
+	}
+	for _, container := range self.Status.Recommendation.ContainerRecommendations {
+		result[container.ContainerName] = container.Target
+	}
+	return result
+}
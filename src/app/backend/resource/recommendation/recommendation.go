@@ -0,0 +1,336 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package recommendation suggests requests and limits of containers of workloads based on their
+// usage history or on recommendations of Vertical Pod Autoscaler, if it is installed.
+package recommendation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+// Sources of recommendations.
+const (
+	SourceVerticalPodAutoscaler = "VerticalPodAutoscaler"
+	SourceUsageHistory          = "UsageHistory"
+)
+
+const (
+	// safetyMargin is added on top of the usage percentile, as a fraction of it.
+	safetyMargin = 0.15
+	// minSamples is the number of usage samples needed to recommend resources of a container.
+	minSamples = 10
+	// minCPU in millicores and minMemory in bytes are the smallest recommended requests.
+	minCPU    = 10
+	minMemory = 16 * 1024 * 1024
+	// memoryUnit is the unit recommended memory is rounded up to.
+	memoryUnit = 1024 * 1024
+)
+
+// Options of calculation of recommendations from usage history.
+type Options struct {
+	// CPUPercentile of CPU usage samples recommended as CPU request, between 1 and 100.
+	CPUPercentile float64 `json:"cpuPercentile"`
+	// MemoryPercentile of memory usage samples recommended as memory request, between 1 and 100.
+	MemoryPercentile float64 `json:"memoryPercentile"`
+}
+
+// DefaultOptions are used for options, which are not set.
+var DefaultOptions = Options{CPUPercentile: 90, MemoryPercentile: 95}
+
+// Recommendation contains recommended resources of containers of a workload.
+type Recommendation struct {
+	Kind      api.ResourceKind `json:"kind"`
+	Namespace string           `json:"namespace"`
+	Name      string           `json:"name"`
+	Options   Options          `json:"options"`
+
+	// VerticalPodAutoscaler is the name of Vertical Pod Autoscaler targeting the workload, if any.
+	VerticalPodAutoscaler string `json:"verticalPodAutoscaler,omitempty"`
+
+	Containers []ContainerRecommendation `json:"containers"`
+}
+
+// ContainerRecommendation contains current and recommended resources of a single container.
+type ContainerRecommendation struct {
+	Name string `json:"name"`
+
+	// Current are resources of the container in the pod template.
+	Current Resources `json:"current"`
+
+	// Recommended are CPU and memory requests and limits recommended for the container. Limits are
+	// recommended only for containers, which have them, with the current ratio of limit to request.
+	// Nil if there is not enough data, in which case Reason explains why.
+	Recommended *Resources `json:"recommended"`
+	Reason      string     `json:"reason,omitempty"`
+
+	// Source is SourceVerticalPodAutoscaler or SourceUsageHistory.
+	Source string `json:"source,omitempty"`
+
+	// Samples is the number of usage samples of the container.
+	Samples int `json:"samples"`
+
+	// CPU usage in millicores and memory usage in bytes. Nil if there are no samples.
+	CPU    *Percentiles `json:"cpu"`
+	Memory *Percentiles `json:"memory"`
+}
+
+// Resources are requests and limits of a container.
+type Resources struct {
+	Requests v1.ResourceList `json:"requests"`
+	Limits   v1.ResourceList `json:"limits"`
+}
+
+// Percentiles summarize usage samples.
+type Percentiles struct {
+	P50 int64 `json:"p50"`
+	P90 int64 `json:"p90"`
+	P95 int64 `json:"p95"`
+	P99 int64 `json:"p99"`
+	Max int64 `json:"max"`
+}
+
+// GetRecommendation returns recommended resources of containers of the workload. Recommendations
+// of Vertical Pod Autoscaler targeting the workload are preferred, otherwise they are calculated
+// from usage history kept by the metric client.
+func GetRecommendation(client kubernetes.Interface, config *rest.Config, metricClient metricapi.MetricClient,
+	kind api.ResourceKind, namespace, name string, options Options) (*Recommendation, error) {
+	restClient, err := newRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return getRecommendation(client, restClient, metricClient, kind, namespace, name, options)
+}
+
+func getRecommendation(client kubernetes.Interface, restClient RESTClient, metricClient metricapi.MetricClient,
+	kind api.ResourceKind, namespace, name string, options Options) (*Recommendation, error) {
+	options = withDefaults(options)
+	if err := validateOptions(options); err != nil {
+		return nil, err
+	}
+	logger.Infof("Getting resource recommendations of %s %s in %s namespace", kind, name, namespace)
+
+	target, err := getWorkload(client, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	autoscaler, err := getVerticalPodAutoscaler(restClient, target.kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(metaV1.ListOptions{LabelSelector: target.selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		names = append(names, pod.Name)
+	}
+
+	var history map[string][]metricapi.ContainerUsage
+	if historyClient, ok := metricClient.(metricapi.ContainerHistoryClient); ok {
+		history = historyClient.ContainerUsageHistory(namespace, names)
+	}
+
+	result := &Recommendation{
+		Kind:       kind,
+		Namespace:  namespace,
+		Name:       name,
+		Options:    options,
+		Containers: make([]ContainerRecommendation, 0, len(target.template.Spec.Containers)),
+	}
+	var targets map[string]v1.ResourceList
+	if autoscaler != nil {
+		result.VerticalPodAutoscaler = autoscaler.Name
+		targets = autoscaler.targets()
+	}
+	for _, container := range target.template.Spec.Containers {
+		result.Containers = append(result.Containers,
+			recommend(container, targets[container.Name], history, options))
+	}
+	return result, nil
+}
+
+// recommend returns recommendation of the container. Target is the recommendation of Vertical Pod
+// Autoscaler, it is used if set. History is nil if the metric client does not keep usage history.
+func recommend(container v1.Container, target v1.ResourceList, history map[string][]metricapi.ContainerUsage,
+	options Options) ContainerRecommendation {
+	result := ContainerRecommendation{
+		Name: container.Name,
+		Current: Resources{
+			Requests: resourcesOf(container.Resources.Requests),
+			Limits:   resourcesOf(container.Resources.Limits),
+		},
+	}
+
+	cpu, memory := samplesOf(history[container.Name])
+	result.Samples = len(cpu)
+	if len(memory) > result.Samples {
+		result.Samples = len(memory)
+	}
+	result.CPU = percentiles(cpu)
+	result.Memory = percentiles(memory)
+
+	switch {
+	case len(target) > 0:
+		result.Source = SourceVerticalPodAutoscaler
+		result.Recommended = withLimits(resourcesOf(target), result.Current, nil)
+	case len(cpu) >= minSamples && len(memory) >= minSamples:
+		result.Source = SourceUsageHistory
+		requests := v1.ResourceList{
+			v1.ResourceCPU: *resource.NewMilliQuantity(
+				maxInt64(withMargin(percentile(cpu, options.CPUPercentile)), minCPU), resource.DecimalSI),
+			v1.ResourceMemory: *resource.NewQuantity(roundUp(
+				maxInt64(withMargin(percentile(memory, options.MemoryPercentile)), minMemory), memoryUnit),
+				resource.BinarySI),
+		}
+		result.Recommended = withLimits(requests, result.Current, result.Memory)
+	case history == nil:
+		result.Reason = "Usage history is not kept by the active metric integration"
+	case result.Samples == 0:
+		result.Reason = "No usage history of the container, enable the metrics scraper or wait until usage is collected"
+	default:
+		result.Reason = fmt.Sprintf("Only %d usage samples of the container, at least %d are needed", result.Samples,
+			minSamples)
+	}
+	return result
+}
+
+// withLimits returns recommended requests with limits, which keep the current ratio of limit to
+// request. Memory limit is not recommended below the peak memory usage with the safety margin,
+// so that the container is not killed for running out of memory.
+func withLimits(requests v1.ResourceList, current Resources, memory *Percentiles) *Resources {
+	result := &Resources{Requests: requests, Limits: v1.ResourceList{}}
+	for name, request := range requests {
+		limit, ok := current.Limits[name]
+		if !ok {
+			continue
+		}
+		currentRequest, ok := current.Requests[name]
+		if !ok || currentRequest.IsZero() {
+			// Request defaults to the limit.
+			currentRequest = limit
+		}
+
+		ratio := float64(limit.MilliValue()) / float64(currentRequest.MilliValue())
+		if name == v1.ResourceMemory {
+			value := roundUp(int64(math.Ceil(float64(request.Value())*ratio)), memoryUnit)
+			if memory != nil {
+				value = maxInt64(value, roundUp(withMargin(memory.Max), memoryUnit))
+			}
+			result.Limits[name] = *resource.NewQuantity(value, resource.BinarySI)
+			continue
+		}
+		value := int64(math.Ceil(float64(request.MilliValue()) * ratio))
+		result.Limits[name] = *resource.NewMilliQuantity(value, resource.DecimalSI)
+	}
+	return result
+}
+
+// resourcesOf returns CPU and memory from the resource list.
+func resourcesOf(list v1.ResourceList) v1.ResourceList {
+	result := v1.ResourceList{}
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		if quantity, ok := list[name]; ok {
+			result[name] = quantity
+		}
+	}
+	return result
+}
+
+// samplesOf returns CPU usage in millicores and memory usage in bytes, both sorted.
+func samplesOf(usage []metricapi.ContainerUsage) (cpu, memory []int64) {
+	cpu, memory = make([]int64, 0, len(usage)), make([]int64, 0, len(usage))
+	for _, sample := range usage {
+		if sample.CPUUsage != nil {
+			cpu = append(cpu, int64(*sample.CPUUsage))
+		}
+		if sample.MemoryUsage != nil {
+			memory = append(memory, int64(*sample.MemoryUsage))
+		}
+	}
+	sort.Slice(cpu, func(i, j int) bool { return cpu[i] < cpu[j] })
+	sort.Slice(memory, func(i, j int) bool { return memory[i] < memory[j] })
+	return cpu, memory
+}
+
+func percentiles(samples []int64) *Percentiles {
+	if len(samples) == 0 {
+		return nil
+	}
+	return &Percentiles{
+		P50: percentile(samples, 50),
+		P90: percentile(samples, 90),
+		P95: percentile(samples, 95),
+		P99: percentile(samples, 99),
+		Max: samples[len(samples)-1],
+	}
+}
+
+// percentile returns nearest-rank percentile of sorted samples.
+func percentile(samples []int64, p float64) int64 {
+	rank := int(math.Ceil(p / 100 * float64(len(samples))))
+	if rank < 1 {
+		rank = 1
+	}
+	return samples[rank-1]
+}
+
+func withMargin(value int64) int64 {
+	return int64(math.Ceil(float64(value) * (1 + safetyMargin)))
+}
+
+func roundUp(value, unit int64) int64 {
+	return (value + unit - 1) / unit * unit
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func withDefaults(options Options) Options {
+	if options.CPUPercentile == 0 {
+		options.CPUPercentile = DefaultOptions.CPUPercentile
+	}
+	if options.MemoryPercentile == 0 {
+		options.MemoryPercentile = DefaultOptions.MemoryPercentile
+	}
+	return options
+}
+
+func validateOptions(options Options) error {
+	for _, p := range []float64{options.CPUPercentile, options.MemoryPercentile} {
+		if p < 1 || p > 100 {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("percentile has to be between 1 and 100, got %g", p))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommendation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	"k8s.io/client-go/rest"
+)
+
+// fakeMetricClient keeps usage history of containers by container name.
+type fakeMetricClient struct {
+	history map[string][]metricapi.ContainerUsage
+}
+
+func (self fakeMetricClient) DownloadMetric(selectors []metricapi.ResourceSelector, metricName string,
+	cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	return nil
+}
+
+func (self fakeMetricClient) DownloadMetrics(selectors []metricapi.ResourceSelector, metricNames []string,
+	cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	return nil
+}
+
+func (self fakeMetricClient) AggregateMetrics(metrics metricapi.MetricPromises, metricName string,
+	aggregations metricapi.AggregationModes) metricapi.MetricPromises {
+	return metrics
+}
+
+func (self fakeMetricClient) HealthCheck() error {
+	return nil
+}
+
+func (self fakeMetricClient) ID() integrationapi.IntegrationID {
+	return "fake"
+}
+
+func (self fakeMetricClient) ContainerUsageHistory(namespace string,
+	pods []string) map[string][]metricapi.ContainerUsage {
+	if len(pods) == 0 {
+		return map[string][]metricapi.ContainerUsage{}
+	}
+	return self.history
+}
+
+// samples returns n usage samples growing by the step of CPU in millicores and memory in Mi.
+func samples(n int, cpu, memory uint64) []metricapi.ContainerUsage {
+	result := make([]metricapi.ContainerUsage, 0, n)
+	for i := uint64(1); i <= uint64(n); i++ {
+		cpuUsage, memoryUsage := i*cpu, i*memory*1024*1024
+		result = append(result, metricapi.ContainerUsage{CPUUsage: &cpuUsage, MemoryUsage: &memoryUsage})
+	}
+	return result
+}
+
+// newAutoscalerClient returns client of Vertical Pod Autoscaler API, which lists the raw autoscalers
+// in prod namespace. The API is not served when raw is empty.
+func newAutoscalerClient(t *testing.T, raw string) (RESTClient, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(raw) == 0 || r.URL.Path != "/apis/autoscaling.k8s.io/v1/namespaces/prod/verticalpodautoscalers" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(raw))
+	}))
+	restClient, err := newRESTClient(&rest.Config{Host: server.URL})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return restClient, server.Close
+}
+
+func newDeployment() *extensions.Deployment {
+	labels := map[string]string{"app": "api"}
+	return &extensions.Deployment{
+		ObjectMeta: metaV1.ObjectMeta{Name: "api", Namespace: "prod"},
+		Spec: extensions.DeploymentSpec{
+			Selector: &metaV1.LabelSelector{MatchLabels: labels},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: labels},
+				Spec: v1.PodSpec{Containers: []v1.Container{
+					{
+						Name: "app",
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{
+								v1.ResourceCPU:    resource.MustParse("500m"),
+								v1.ResourceMemory: resource.MustParse("512Mi"),
+							},
+							Limits: v1.ResourceList{
+								v1.ResourceCPU:    resource.MustParse("1"),
+								v1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+					{Name: "sidecar"},
+					{Name: "new"},
+				}},
+			},
+		},
+	}
+}
+
+func newClient() *fake.Clientset {
+	return fake.NewSimpleClientset(newDeployment(), &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "api-1", Namespace: "prod", Labels: map[string]string{"app": "api"}},
+	})
+}
+
+func quantities(list v1.ResourceList) [2]string {
+	cpu, memory := list[v1.ResourceCPU], list[v1.ResourceMemory]
+	return [2]string{cpu.String(), memory.String()}
+}
+
+func TestGetRecommendationFromUsageHistory(t *testing.T) {
+	restClient, closeServer := newAutoscalerClient(t, "")
+	defer closeServer()
+	metricClient := fakeMetricClient{history: map[string][]metricapi.ContainerUsage{
+		"app":     samples(20, 10, 10),
+		"sidecar": samples(20, 0, 0),
+		"new":     samples(3, 10, 10),
+	}}
+
+	recommendation, err := getRecommendation(newClient(), restClient, metricClient, api.ResourceKindDeployment, "prod",
+		"api", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recommendation.Options != DefaultOptions || len(recommendation.Containers) != 3 ||
+		len(recommendation.VerticalPodAutoscaler) > 0 {
+		t.Fatalf("GetRecommendation() returns %#v", recommendation)
+	}
+
+	app := recommendation.Containers[0]
+	if app.Source != SourceUsageHistory || app.Samples != 20 || app.CPU.P90 != 180 || app.CPU.Max != 200 {
+		t.Errorf("GetRecommendation() returns %#v for app container", app)
+	}
+	if actual := quantities(app.Recommended.Requests); actual != [2]string{"207m", "219Mi"} {
+		t.Errorf("GetRecommendation() returns requests %v for app container", actual)
+	}
+	if actual := quantities(app.Recommended.Limits); actual != [2]string{"414m", "438Mi"} {
+		t.Errorf("GetRecommendation() returns limits %v for app container", actual)
+	}
+
+	sidecar := recommendation.Containers[1]
+	if actual := quantities(sidecar.Recommended.Requests); actual != [2]string{"10m", "16Mi"} ||
+		len(sidecar.Recommended.Limits) != 0 {
+		t.Errorf("GetRecommendation() returns %#v for sidecar container", sidecar.Recommended)
+	}
+
+	if created := recommendation.Containers[2]; created.Recommended != nil || created.Samples != 3 ||
+		created.Reason != "Only 3 usage samples of the container, at least 10 are needed" {
+		t.Errorf("GetRecommendation() returns %#v for new container", created)
+	}
+
+	if _, err := getRecommendation(newClient(), restClient, metricClient, api.ResourceKindDeployment, "prod", "api",
+		Options{CPUPercentile: 120}); !errorsK8s.IsBadRequest(err) {
+		t.Errorf("GetRecommendation() returns %v for invalid percentile, expected bad request", err)
+	}
+	if _, err := getRecommendation(newClient(), restClient, metricClient, api.ResourceKindJob, "prod", "api",
+		Options{}); !errorsK8s.IsBadRequest(err) {
+		t.Errorf("GetRecommendation() returns %v for job, expected bad request", err)
+	}
+}
+
+func TestGetRecommendationFromVerticalPodAutoscaler(t *testing.T) {
+	restClient, closeServer := newAutoscalerClient(t, `{"items": [
+	  {"metadata": {"name": "web"}, "spec": {"targetRef": {"kind": "Deployment", "name": "web"}}},
+	  {"metadata": {"name": "api"}, "spec": {"targetRef": {"kind": "Deployment", "name": "api"}},
+	   "status": {"recommendation": {"containerRecommendations": [
+	     {"containerName": "app", "target": {"cpu": "300m", "memory": "256Mi"}}]}}}]}`)
+	defer closeServer()
+
+	recommendation, err := getRecommendation(newClient(), restClient, nil, api.ResourceKindDeployment, "prod", "api",
+		Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := recommendation.Containers[0]
+	if recommendation.VerticalPodAutoscaler != "api" || app.Source != SourceVerticalPodAutoscaler ||
+		quantities(app.Recommended.Requests) != [2]string{"300m", "256Mi"} ||
+		quantities(app.Recommended.Limits) != [2]string{"600m", "512Mi"} {
+		t.Errorf("GetRecommendation() returns %#v for app container", app)
+	}
+	if sidecar := recommendation.Containers[1]; sidecar.Recommended != nil ||
+		sidecar.Reason != "Usage history is not kept by the active metric integration" {
+		t.Errorf("GetRecommendation() returns %#v for sidecar container", sidecar)
+	}
+}
+
+func TestApplyRecommendation(t *testing.T) {
+	client := newClient()
+	spec := &ApplySpec{Containers: []ContainerResources{
+		{Name: "app", Resources: Resources{Requests: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("500m"),
+			v1.ResourceMemory: resource.MustParse("256Mi"),
+		}}},
+		{Name: "sidecar", Resources: Resources{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("10m")}}},
+	}}
+
+	result, err := ApplyRecommendation(client, api.ResourceKindDeployment, "prod", "api", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Containers) != 2 || result.Containers[0] != "app" || result.Containers[1] != "sidecar" {
+		t.Errorf("ApplyRecommendation() returns %v", result.Containers)
+	}
+
+	deployment, _ := client.ExtensionsV1beta1().Deployments("prod").Get("api", metaV1.GetOptions{})
+	containers := deployment.Spec.Template.Spec.Containers
+	if quantities(containers[0].Resources.Requests) != [2]string{"500m", "256Mi"} ||
+		quantities(containers[0].Resources.Limits) != [2]string{"1", "1Gi"} ||
+		quantities(containers[1].Resources.Requests) != [2]string{"10m", "0"} {
+		t.Errorf("ApplyRecommendation() sets resources %v", containers)
+	}
+
+	invalid := []*ApplySpec{
+		{},
+		{Containers: []ContainerResources{{Name: "missing"}}},
+		{Containers: []ContainerResources{{Name: "app", Resources: Resources{
+			Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+			Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+		}}}},
+	}
+	for _, spec := range invalid {
+		if _, err := ApplyRecommendation(client, api.ResourceKindDeployment, "prod", "api",
+			spec); !errorsK8s.IsBadRequest(err) {
+			t.Errorf("ApplyRecommendation(%v) returns %v, expected bad request", spec, err)
+		}
+	}
+}
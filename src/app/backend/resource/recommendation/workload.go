@@ -0,0 +1,187 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommendation
+
+import (
+	"fmt"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// ApplySpec describes resources to set on containers of a workload, usually the recommended ones.
+type ApplySpec struct {
+	Containers []ContainerResources `json:"containers"`
+}
+
+// ContainerResources are CPU and memory requests and limits of a container. Resources, which are
+// not set, are left unchanged.
+type ContainerResources struct {
+	Name string `json:"name"`
+	Resources
+}
+
+// ApplyResult lists containers, whose resources were changed.
+type ApplyResult struct {
+	Containers []string `json:"containers"`
+}
+
+// workload contains parts of a workload needed to recommend its resources.
+type workload struct {
+	// kind as used in target references of Vertical Pod Autoscalers.
+	kind     string
+	selector labels.Selector
+	template *v1.PodTemplateSpec
+}
+
+// getWorkload returns the workload of given kind. Only kinds, which are supported by Vertical Pod
+// Autoscaler and keep their pods running, are supported.
+func getWorkload(client kubernetes.Interface, kind api.ResourceKind, namespace, name string) (*workload, error) {
+	var kindName string
+	var selector *metaV1.LabelSelector
+	var template *v1.PodTemplateSpec
+	switch kind {
+	case api.ResourceKindDeployment:
+		deployment, err := client.ExtensionsV1beta1().Deployments(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		kindName, selector, template = "Deployment", deployment.Spec.Selector, &deployment.Spec.Template
+	case api.ResourceKindStatefulSet:
+		statefulSet, err := client.AppsV1beta1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		kindName, selector, template = "StatefulSet", statefulSet.Spec.Selector, &statefulSet.Spec.Template
+	case api.ResourceKindDaemonSet:
+		daemonSet, err := client.ExtensionsV1beta1().DaemonSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		kindName, selector, template = "DaemonSet", daemonSet.Spec.Selector, &daemonSet.Spec.Template
+	case api.ResourceKindReplicaSet:
+		replicaSet, err := client.ExtensionsV1beta1().ReplicaSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		kindName, selector, template = "ReplicaSet", replicaSet.Spec.Selector, &replicaSet.Spec.Template
+	default:
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("resource recommendations of %s are not supported", kind))
+	}
+
+	labelSelector, err := metaV1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return &workload{kind: kindName, selector: labelSelector, template: template}, nil
+}
+
+// ApplyRecommendation sets requests and limits of containers in the pod template of the workload.
+// Workloads roll out the change, so pods are recreated with new resources.
+func ApplyRecommendation(client kubernetes.Interface, kind api.ResourceKind, namespace, name string,
+	spec *ApplySpec) (*ApplyResult, error) {
+	if _, err := getWorkload(client, kind, namespace, name); err != nil {
+		return nil, err
+	}
+	if len(spec.Containers) == 0 {
+		return nil, errorsK8s.NewBadRequest("at least one container is required")
+	}
+	for _, container := range spec.Containers {
+		if err := validateResources(container); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &ApplyResult{Containers: make([]string, 0)}
+	err := common.UpdatePodTemplate(client, kind, namespace, name, func(template *v1.PodTemplateSpec) error {
+		for _, resources := range spec.Containers {
+			container := findContainer(&template.Spec, resources.Name)
+			if container == nil {
+				return errorsK8s.NewBadRequest(fmt.Sprintf("container %s not found", resources.Name))
+			}
+			if setResources(container, resources.Resources) {
+				result.Containers = append(result.Containers, container.Name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Applied resource recommendations to containers %v of %s %s in %s namespace", result.Containers,
+		kind, name, namespace)
+	return result, nil
+}
+
+func findContainer(spec *v1.PodSpec, name string) *v1.Container {
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == name {
+			return &spec.Containers[i]
+		}
+	}
+	return nil
+}
+
+// setResources sets CPU and memory requests and limits of the container and returns true if any
+// of them has changed.
+func setResources(container *v1.Container, resources Resources) bool {
+	changed := false
+	set := func(list *v1.ResourceList, values v1.ResourceList) {
+		for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			value, ok := values[name]
+			if !ok {
+				continue
+			}
+			if current, ok := (*list)[name]; ok && current.Cmp(value) == 0 {
+				continue
+			}
+			if *list == nil {
+				*list = v1.ResourceList{}
+			}
+			(*list)[name] = value
+			changed = true
+		}
+	}
+	set(&container.Resources.Requests, resources.Requests)
+	set(&container.Resources.Limits, resources.Limits)
+	return changed
+}
+
+// validateResources returns bad request error if resources are negative or requests exceed
+// limits. Limits, which are not set, are not checked.
+func validateResources(container ContainerResources) error {
+	for _, list := range []v1.ResourceList{container.Requests, container.Limits} {
+		for name, quantity := range list {
+			if quantity.Sign() < 0 {
+				return errorsK8s.NewBadRequest(fmt.Sprintf("%s of %s container cannot be negative", name,
+					container.Name))
+			}
+		}
+	}
+	for name, request := range container.Requests {
+		if limit, ok := container.Limits[name]; ok && request.Cmp(limit) > 0 {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("%s request of %s container exceeds its limit", name,
+				container.Name))
+		}
+	}
+	return nil
+}
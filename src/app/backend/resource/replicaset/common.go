@@ -19,6 +19,7 @@ import (
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/scaling"
 	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
 )
 
@@ -32,6 +33,9 @@ type ReplicaSet struct {
 	// Aggregate information about pods belonging to this Replica Set.
 	Pods common.PodInfo `json:"pods"`
 
+	// Desired and actual number of replicas, as reported by the scale subresource.
+	Replicas scaling.ReplicaCounts `json:"replicas"`
+
 	// Container images of the Replica Set.
 	ContainerImages []string `json:"containerImages"`
 }
@@ -43,6 +47,7 @@ func ToReplicaSet(replicaSet *extensions.ReplicaSet, podInfo *common.PodInfo) Re
 		TypeMeta:        api.NewTypeMeta(api.ResourceKindReplicaSet),
 		ContainerImages: common.GetContainerImages(&replicaSet.Spec.Template.Spec),
 		Pods:            *podInfo,
+		Replicas:        scaling.NewReplicaCounts(replicaSet.Spec.Replicas, replicaSet.Status.Replicas),
 	}
 }
 
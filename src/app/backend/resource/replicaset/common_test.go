@@ -20,6 +20,7 @@ import (
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/scaling"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
 )
@@ -37,6 +38,7 @@ func TestToReplicaSet(t *testing.T) {
 				ObjectMeta: api.ObjectMeta{Name: "replica-set"},
 				TypeMeta:   api.TypeMeta{Kind: api.ResourceKindReplicaSet},
 				Pods:       common.PodInfo{Running: 1, Warnings: []common.Event{}},
+				Replicas:   scaling.ReplicaCounts{DesiredReplicas: 1},
 			},
 		},
 	}
@@ -15,11 +15,10 @@
 package replicaset
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	ds "github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	hpa "github.com/kubernetes/dashboard/src/app/backend/resource/horizontalpodautoscaler"
@@ -65,7 +64,7 @@ type ReplicaSetDetail struct {
 // GetReplicaSetDetail gets replica set details.
 func GetReplicaSetDetail(client k8sClient.Interface, metricClient metricapi.MetricClient,
 	namespace, name string) (*ReplicaSetDetail, error) {
-	log.Printf("Getting details of %s service in %s namespace", name, namespace)
+	logger.Infof("Getting details of %s service in %s namespace", name, namespace)
 
 	rs, err := client.ExtensionsV1beta1().ReplicaSets(namespace).Get(name, metaV1.GetOptions{})
 	if err != nil {
@@ -15,13 +15,13 @@
 package replicaset
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diagnosis"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
@@ -43,15 +43,22 @@ type ReplicaSetList struct {
 // GetReplicaSetList returns a list of all Replica Sets in the cluster.
 func GetReplicaSetList(client client.Interface, nsQuery *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery, metricClient metricapi.MetricClient) (*ReplicaSetList, error) {
-	log.Print("Getting list of all replica sets in the cluster")
+	logger.Info("Getting list of all replica sets in the cluster")
 
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
 	channels := &common.ResourceChannels{
-		ReplicaSetList: common.GetReplicaSetListChannel(client, nsQuery, 1),
+		ReplicaSetList: common.GetReplicaSetListChunkChannel(client, nsQuery, chunk, 1),
 		PodList:        common.GetPodListChannel(client, nsQuery, 1),
-		EventList:      common.GetEventListChannel(client, nsQuery, 1),
+		EventList:      common.GetChunkEventListChannel(client, nsQuery, chunk, 1),
+	}
+
+	replicaSetList, err := GetReplicaSetListFromChannels(channels, dsQuery, metricClient)
+	if err != nil {
+		return nil, err
 	}
 
-	return GetReplicaSetListFromChannels(channels, dsQuery, metricClient)
+	chunk.SetListMeta(&replicaSetList.ListMeta)
+	return replicaSetList, nil
 }
 
 // GetReplicaSetListFromChannels returns a list of all Replica Sets in the cluster
@@ -109,6 +116,7 @@ func ToReplicaSetList(replicaSets []extensions.ReplicaSet, pods []v1.Pod, events
 		podInfo := common.GetPodInfo(replicaSet.Status.Replicas, *replicaSet.Spec.Replicas,
 			matchingPods)
 		podInfo.Warnings = event.GetPodsEventWarnings(events, matchingPods)
+		podInfo.Diagnosis = diagnosis.DiagnosePods(matchingPods, events)
 		replicaSetList.ReplicaSets = append(replicaSetList.ReplicaSets,
 			ToReplicaSet(&replicaSet, &podInfo))
 	}
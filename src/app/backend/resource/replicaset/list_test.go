@@ -23,6 +23,7 @@ import (
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/scaling"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -150,6 +151,7 @@ func TestGetReplicaSetListFromChannels(t *testing.T) {
 						Failed:   1,
 						Warnings: []common.Event{},
 					},
+					Replicas: scaling.ReplicaCounts{DesiredReplicas: 21, ActualReplicas: 7},
 				}},
 				Errors: []error{},
 			},
@@ -235,6 +237,7 @@ func TestCreateReplicaSetList(t *testing.T) {
 						ObjectMeta: api.ObjectMeta{Name: "replica-set", Namespace: "ns-1"},
 						TypeMeta:   api.TypeMeta{Kind: api.ResourceKindReplicaSet},
 						Pods:       common.PodInfo{Warnings: []common.Event{}},
+						Replicas:   scaling.ReplicaCounts{DesiredReplicas: 0},
 					},
 				},
 			},
@@ -285,6 +288,7 @@ func TestGetReplicaSetList(t *testing.T) {
 							Desired:  replicas,
 							Warnings: make([]common.Event, 0),
 						},
+						Replicas: scaling.ReplicaCounts{DesiredReplicas: replicas},
 					},
 				},
 				Errors:            []error{},
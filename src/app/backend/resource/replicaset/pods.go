@@ -15,9 +15,8 @@
 package replicaset
 
 import (
-	"log"
-
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
@@ -33,7 +32,7 @@ import (
 // GetReplicaSetPods return list of pods targeting replica set.
 func GetReplicaSetPods(client k8sClient.Interface, metricClient metricapi.MetricClient,
 	dsQuery *dataselect.DataSelectQuery, petSetName, namespace string) (*pod.PodList, error) {
-	log.Printf("Getting replication controller %s pods in namespace %s", petSetName, namespace)
+	logger.Infof("Getting replication controller %s pods in namespace %s", petSetName, namespace)
 
 	pods, err := getRawReplicaSetPods(client, petSetName, namespace)
 	if err != nil {
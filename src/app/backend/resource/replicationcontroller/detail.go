@@ -15,11 +15,10 @@
 package replicationcontroller
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	ds "github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	hpa "github.com/kubernetes/dashboard/src/app/backend/resource/horizontalpodautoscaler"
@@ -73,7 +72,7 @@ type ReplicationControllerSpec struct {
 // in the given namespace.
 func GetReplicationControllerDetail(client k8sClient.Interface, metricClient metricapi.MetricClient,
 	namespace, name string) (*ReplicationControllerDetail, error) {
-	log.Printf("Getting details of %s replication controller in %s namespace", name, namespace)
+	logger.Infof("Getting details of %s replication controller in %s namespace", name, namespace)
 
 	replicationController, err := client.CoreV1().ReplicationControllers(namespace).Get(name, metaV1.GetOptions{})
 	if err != nil {
@@ -118,7 +117,7 @@ func GetReplicationControllerDetail(client k8sClient.Interface, metricClient met
 
 // UpdateReplicasCount updates number of replicas in Replication Controller based on Replication Controller Spec
 func UpdateReplicasCount(client k8sClient.Interface, namespace, name string, spec *ReplicationControllerSpec) error {
-	log.Printf("Updating replicas count to %d for %s replication controller from %s namespace",
+	logger.Infof("Updating replicas count to %d for %s replication controller from %s namespace",
 		spec.Replicas, name, namespace)
 
 	replicationController, err := client.CoreV1().ReplicationControllers(namespace).Get(name, metaV1.GetOptions{})
@@ -133,7 +132,7 @@ func UpdateReplicasCount(client k8sClient.Interface, namespace, name string, spe
 		return err
 	}
 
-	log.Printf("Successfully updated replicas count to %d for %s replication controller from %s namespace",
+	logger.Infof("Successfully updated replicas count to %d for %s replication controller from %s namespace",
 		spec.Replicas, name, namespace)
 
 	return nil
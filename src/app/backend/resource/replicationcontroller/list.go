@@ -15,13 +15,13 @@
 package replicationcontroller
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diagnosis"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
@@ -42,15 +42,22 @@ type ReplicationControllerList struct {
 // GetReplicationControllerList returns a list of all Replication Controllers in the cluster.
 func GetReplicationControllerList(client client.Interface, nsQuery *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery, metricClient metricapi.MetricClient) (*ReplicationControllerList, error) {
-	log.Print("Getting list of all replication controllers in the cluster")
+	logger.Info("Getting list of all replication controllers in the cluster")
 
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
 	channels := &common.ResourceChannels{
-		ReplicationControllerList: common.GetReplicationControllerListChannel(client, nsQuery, 1),
+		ReplicationControllerList: common.GetReplicationControllerListChunkChannel(client, nsQuery, chunk, 1),
 		PodList:                   common.GetPodListChannel(client, nsQuery, 1),
-		EventList:                 common.GetEventListChannel(client, nsQuery, 1),
+		EventList:                 common.GetChunkEventListChannel(client, nsQuery, chunk, 1),
+	}
+
+	replicationControllerList, err := GetReplicationControllerListFromChannels(channels, dsQuery, metricClient)
+	if err != nil {
+		return nil, err
 	}
 
-	return GetReplicationControllerListFromChannels(channels, dsQuery, metricClient)
+	chunk.SetListMeta(&replicationControllerList.ListMeta)
+	return replicationControllerList, nil
 }
 
 // GetReplicationControllerListFromChannels returns a list of all Replication Controllers in the cluster
@@ -103,6 +110,7 @@ func toReplicationControllerList(replicationControllers []v1.ReplicationControll
 
 		podInfo := common.GetPodInfo(rc.Status.Replicas, *rc.Spec.Replicas, matchingPods)
 		podInfo.Warnings = event.GetPodsEventWarnings(events, matchingPods)
+		podInfo.Diagnosis = diagnosis.DiagnosePods(matchingPods, events)
 
 		replicationController := ToReplicationController(&rc, &podInfo)
 		rcList.ReplicationControllers = append(rcList.ReplicationControllers, replicationController)
@@ -15,9 +15,8 @@
 package replicationcontroller
 
 import (
-	"log"
-
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
@@ -34,7 +33,7 @@ import (
 func GetReplicationControllerPods(client k8sClient.Interface,
 	metricClient metricapi.MetricClient,
 	dsQuery *dataselect.DataSelectQuery, rcName, namespace string) (*pod.PodList, error) {
-	log.Printf("Getting replication controller %s pods in namespace %s", rcName, namespace)
+	logger.Infof("Getting replication controller %s pods in namespace %s", rcName, namespace)
 
 	pods, err := getRawReplicationControllerPods(client, rcName, namespace)
 	if err != nil {
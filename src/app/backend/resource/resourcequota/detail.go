@@ -16,6 +16,10 @@ package resourcequota
 
 import (
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
 )
 
@@ -23,6 +27,10 @@ import (
 type ResourceStatus struct {
 	Used string `json:"used,omitempty"`
 	Hard string `json:"hard,omitempty"`
+
+	// UsedPercentage is the used amount as a percentage of the hard limit, so that the quotas
+	// close to the limit can be highlighted.
+	UsedPercentage int64 `json:"usedPercentage"`
 }
 
 // ResourceQuotaDetail provides the presentation layer view of Kubernetes Resource Quotas resource.
@@ -41,6 +49,9 @@ type ResourceQuotaDetail struct {
 type ResourceQuotaDetailList struct {
 	ListMeta api.ListMeta          `json:"listMeta"`
 	Items    []ResourceQuotaDetail `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
 }
 
 func ToResourceQuotaDetail(rawResourceQuota *v1.ResourceQuota) *ResourceQuotaDetail {
@@ -49,8 +60,9 @@ func ToResourceQuotaDetail(rawResourceQuota *v1.ResourceQuota) *ResourceQuotaDet
 	for key, value := range rawResourceQuota.Status.Hard {
 		used := rawResourceQuota.Status.Used[key]
 		statusList[key] = ResourceStatus{
-			Used: used.String(),
-			Hard: value.String(),
+			Used:           used.String(),
+			Hard:           value.String(),
+			UsedPercentage: getUsedPercentage(used, value),
 		}
 	}
 	return &ResourceQuotaDetail{
@@ -60,3 +72,23 @@ func ToResourceQuotaDetail(rawResourceQuota *v1.ResourceQuota) *ResourceQuotaDet
 		StatusList: statusList,
 	}
 }
+
+// GetResourceQuotaDetail returns detailed information about a resource quota.
+func GetResourceQuotaDetail(client client.Interface, namespace, name string) (*ResourceQuotaDetail, error) {
+	logger.Infof("Getting details of %s resource quota in %s namespace", name, namespace)
+
+	rawResourceQuota, err := client.CoreV1().ResourceQuotas(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return ToResourceQuotaDetail(rawResourceQuota), nil
+}
+
+// getUsedPercentage returns used quantity as a percentage of the hard limit.
+func getUsedPercentage(used, hard resource.Quantity) int64 {
+	if hard.MilliValue() <= 0 {
+		return 0
+	}
+	return used.MilliValue() * 100 / hard.MilliValue()
+}
@@ -60,8 +60,9 @@ func TestGetResourceQuotaDetail(t *testing.T) {
 				},
 				StatusList: map[v1.ResourceName]ResourceStatus{
 					v1.ResourceMemory: {
-						Hard: testMemoryQuantity.String(),
-						Used: testMemoryQuantity.String(),
+						Hard:           testMemoryQuantity.String(),
+						Used:           testMemoryQuantity.String(),
+						UsedPercentage: 100,
 					},
 				},
 			},
@@ -75,3 +76,22 @@ func TestGetResourceQuotaDetail(t *testing.T) {
 		}
 	}
 }
+
+func TestGetUsedPercentage(t *testing.T) {
+	cases := []struct {
+		used, hard string
+		expected   int64
+	}{
+		{"0", "10", 0},
+		{"500m", "2", 25},
+		{"3Gi", "4Gi", 75},
+		{"5", "0", 0},
+	}
+	for _, c := range cases {
+		actual := getUsedPercentage(resource.MustParse(c.used), resource.MustParse(c.hard))
+		if actual != c.expected {
+			t.Errorf("getUsedPercentage(%s, %s) == %d, expected %d", c.used, c.hard, actual,
+				c.expected)
+		}
+	}
+}
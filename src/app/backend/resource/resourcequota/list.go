@@ -0,0 +1,68 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcequota
+
+import (
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// GetResourceQuotaList returns a list of all Resource Quotas in the cluster with their usage.
+func GetResourceQuotaList(client client.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*ResourceQuotaDetailList, error) {
+	logger.Infof("Getting list of resource quotas in the namespace %s", nsQuery.ToRequestParam())
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
+	channel := common.GetResourceQuotaListChunkChannel(client, nsQuery, chunk, 1)
+	resourceQuotas := <-channel.List
+	err := <-channel.Error
+
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	items := make([]v1.ResourceQuota, 0)
+	if resourceQuotas != nil {
+		items = resourceQuotas.Items
+	}
+	resourceQuotaList := ToResourceQuotaDetailList(items, nonCriticalErrors, dsQuery)
+	chunk.SetListMeta(&resourceQuotaList.ListMeta)
+	return resourceQuotaList, nil
+}
+
+// ToResourceQuotaDetailList converts resource quotas to the list of their details.
+func ToResourceQuotaDetailList(resourceQuotas []v1.ResourceQuota, nonCriticalErrors []error,
+	dsQuery *dataselect.DataSelectQuery) *ResourceQuotaDetailList {
+	result := &ResourceQuotaDetailList{
+		Items:    make([]ResourceQuotaDetail, 0),
+		ListMeta: api.ListMeta{TotalItems: len(resourceQuotas)},
+		Errors:   nonCriticalErrors,
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(resourceQuotas), dsQuery)
+	resourceQuotas = fromCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for _, item := range resourceQuotas {
+		result.Items = append(result.Items, *ToResourceQuotaDetail(&item))
+	}
+
+	return result
+}
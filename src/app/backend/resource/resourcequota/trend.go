@@ -0,0 +1,214 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcequota
+
+import (
+	"sort"
+	"time"
+
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+const (
+	// nearLimitPercentage is the utilization of a quota, which is reported as near its limit.
+	nearLimitPercentage = 90
+	// minTrendSamples is the number of usage samples needed to estimate when a quota is exhausted.
+	minTrendSamples = 3
+	// maxProjection is the longest period, for which exhaustion of a quota is projected.
+	maxProjection = 365 * 24 * time.Hour
+)
+
+// quotaUsageResources are quota resources, which are compared with CPU and memory usage.
+var quotaUsageResources = map[v1.ResourceName]v1.ResourceName{
+	v1.ResourceCPU:            v1.ResourceCPU,
+	v1.ResourceRequestsCPU:    v1.ResourceCPU,
+	v1.ResourceLimitsCPU:      v1.ResourceCPU,
+	v1.ResourceMemory:         v1.ResourceMemory,
+	v1.ResourceRequestsMemory: v1.ResourceMemory,
+	v1.ResourceLimitsMemory:   v1.ResourceMemory,
+}
+
+// QuotaTrend is utilization of CPU and memory quotas of a namespace over time.
+type QuotaTrend struct {
+	Namespace string `json:"namespace"`
+
+	// HistoryAvailable is false when the metric client does not keep usage history, in which case
+	// only the current state of quotas is returned.
+	HistoryAvailable bool `json:"historyAvailable"`
+
+	// Usage of all pods in the namespace over time, oldest first.
+	Usage []UsagePoint `json:"usage"`
+
+	// Resources are CPU and memory resources limited by quotas of the namespace.
+	Resources []QuotaResourceTrend `json:"resources"`
+}
+
+// UsagePoint is CPU usage in millicores and memory usage in bytes at a point in time.
+type UsagePoint struct {
+	Timestamp metaV1.Time `json:"timestamp"`
+	CPU       int64       `json:"cpu"`
+	Memory    int64       `json:"memory"`
+}
+
+// QuotaResourceTrend is utilization of a single resource limited by a quota.
+type QuotaResourceTrend struct {
+	// Quota is the name of the resource quota and Resource the limited resource, i.e. limits.cpu.
+	Quota    string          `json:"quota"`
+	Resource v1.ResourceName `json:"resource"`
+
+	// Status is the current state of the quota as accounted by the apiserver, i.e. sum of requests
+	// of pods for requests.cpu.
+	Status ResourceStatus `json:"status"`
+
+	// UsagePercentages are usage of the resource as percentages of the hard limit over time, in the
+	// same order as Usage of the trend.
+	UsagePercentages []int64 `json:"usagePercentages"`
+
+	// GrowthPerHour is the growth of usage per hour in millicores or bytes, estimated by linear
+	// regression. Nil if there are not enough samples.
+	GrowthPerHour *int64 `json:"growthPerHour"`
+
+	// ExhaustedAt is the estimated time when usage reaches the hard limit if it keeps growing at the
+	// current rate. Nil if it does not grow or would not reach the limit within a year.
+	ExhaustedAt *metaV1.Time `json:"exhaustedAt"`
+
+	// NearLimit is true when the quota or the latest usage is close to the hard limit.
+	NearLimit bool `json:"nearLimit"`
+}
+
+// GetQuotaTrend returns utilization of CPU and memory quotas of the namespace over time, based on
+// usage history kept by the metric client.
+func GetQuotaTrend(client client.Interface, metricClient metricapi.MetricClient,
+	namespace string) (*QuotaTrend, error) {
+	logger.Infof("Getting quota trend of %s namespace", namespace)
+
+	quotas, err := client.CoreV1().ResourceQuotas(namespace).List(metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &QuotaTrend{
+		Namespace: namespace,
+		Usage:     make([]UsagePoint, 0),
+		Resources: make([]QuotaResourceTrend, 0),
+	}
+	if historyClient, ok := metricClient.(metricapi.NamespaceHistoryClient); ok {
+		result.HistoryAvailable = true
+		for _, sample := range historyClient.NamespaceUsageHistory(namespace) {
+			result.Usage = append(result.Usage, UsagePoint{
+				Timestamp: metaV1.NewTime(sample.Timestamp),
+				CPU:       int64(sample.CPUUsage),
+				Memory:    int64(sample.MemoryUsage),
+			})
+		}
+	}
+
+	for _, quota := range quotas.Items {
+		status := ToResourceQuotaDetail(&quota).StatusList
+		for name, hard := range quota.Status.Hard {
+			usageResource, ok := quotaUsageResources[name]
+			if !ok {
+				continue
+			}
+			limit := hard.MilliValue()
+			if usageResource == v1.ResourceMemory {
+				limit = hard.Value()
+			}
+			result.Resources = append(result.Resources,
+				toQuotaResourceTrend(quota.Name, name, status[name], limit, usageResource, result.Usage))
+		}
+	}
+
+	sort.Slice(result.Resources, func(i, j int) bool {
+		if result.Resources[i].Quota != result.Resources[j].Quota {
+			return result.Resources[i].Quota < result.Resources[j].Quota
+		}
+		return result.Resources[i].Resource < result.Resources[j].Resource
+	})
+	return result, nil
+}
+
+func toQuotaResourceTrend(quota string, name v1.ResourceName, status ResourceStatus, limit int64,
+	usageResource v1.ResourceName, usage []UsagePoint) QuotaResourceTrend {
+	result := QuotaResourceTrend{
+		Quota:            quota,
+		Resource:         name,
+		Status:           status,
+		UsagePercentages: make([]int64, 0, len(usage)),
+		NearLimit:        status.UsedPercentage >= nearLimitPercentage,
+	}
+
+	values := make([]int64, 0, len(usage))
+	for _, point := range usage {
+		value := point.CPU
+		if usageResource == v1.ResourceMemory {
+			value = point.Memory
+		}
+		values = append(values, value)
+		if limit > 0 {
+			result.UsagePercentages = append(result.UsagePercentages, value*100/limit)
+		}
+	}
+	if len(values) == 0 || limit <= 0 {
+		return result
+	}
+
+	last := values[len(values)-1]
+	if last*100/limit >= nearLimitPercentage {
+		result.NearLimit = true
+	}
+	if len(values) < minTrendSamples {
+		return result
+	}
+
+	slope := growthPerHour(usage, values)
+	growth := int64(slope)
+	result.GrowthPerHour = &growth
+	lastTime := usage[len(usage)-1].Timestamp.Time
+	switch {
+	case last >= limit:
+		exhaustedAt := metaV1.NewTime(lastTime)
+		result.ExhaustedAt = &exhaustedAt
+	case slope > 0 && float64(limit-last)/slope < maxProjection.Hours():
+		hours := float64(limit-last) / slope
+		exhaustedAt := metaV1.NewTime(lastTime.Add(time.Duration(hours * float64(time.Hour))))
+		result.ExhaustedAt = &exhaustedAt
+	}
+	return result
+}
+
+// growthPerHour returns slope of the least squares regression line of values over time in hours.
+func growthPerHour(usage []UsagePoint, values []int64) float64 {
+	start := usage[0].Timestamp.Time
+	n := float64(len(values))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, value := range values {
+		x := usage[i].Timestamp.Sub(start).Hours()
+		y := float64(value)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcequota
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// fakeMetricClient keeps usage history of namespaces.
+type fakeMetricClient struct {
+	history map[string][]metricapi.UsageSample
+}
+
+func (self fakeMetricClient) DownloadMetric(selectors []metricapi.ResourceSelector, metricName string,
+	cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	return nil
+}
+
+func (self fakeMetricClient) DownloadMetrics(selectors []metricapi.ResourceSelector, metricNames []string,
+	cachedResources *metricapi.CachedResources) metricapi.MetricPromises {
+	return nil
+}
+
+func (self fakeMetricClient) AggregateMetrics(metrics metricapi.MetricPromises, metricName string,
+	aggregations metricapi.AggregationModes) metricapi.MetricPromises {
+	return metrics
+}
+
+func (self fakeMetricClient) HealthCheck() error {
+	return nil
+}
+
+func (self fakeMetricClient) ID() integrationapi.IntegrationID {
+	return "fake"
+}
+
+func (self fakeMetricClient) NamespaceUsageHistory(namespace string) []metricapi.UsageSample {
+	return self.history[namespace]
+}
+
+func TestGetQuotaTrend(t *testing.T) {
+	start := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	samples := make([]metricapi.UsageSample, 0)
+	for i := 0; i < 4; i++ {
+		samples = append(samples, metricapi.UsageSample{
+			Timestamp:   start.Add(time.Duration(i) * time.Hour),
+			CPUUsage:    uint64(1000 + 100*i),
+			MemoryUsage: 256 * 1024 * 1024,
+		})
+	}
+	metricClient := fakeMetricClient{history: map[string][]metricapi.UsageSample{"prod": samples}}
+
+	client := fake.NewSimpleClientset(&v1.ResourceQuota{
+		ObjectMeta: metaV1.ObjectMeta{Name: "compute", Namespace: "prod"},
+		Status: v1.ResourceQuotaStatus{
+			Hard: v1.ResourceList{
+				v1.ResourceLimitsCPU:      resource.MustParse("2"),
+				v1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+				v1.ResourcePods:           resource.MustParse("10"),
+			},
+			Used: v1.ResourceList{
+				v1.ResourceLimitsCPU:      resource.MustParse("1500m"),
+				v1.ResourceRequestsMemory: resource.MustParse("512Mi"),
+				v1.ResourcePods:           resource.MustParse("3"),
+			},
+		},
+	})
+
+	trend, err := GetQuotaTrend(client, metricClient, "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !trend.HistoryAvailable || len(trend.Usage) != 4 || len(trend.Resources) != 2 {
+		t.Fatalf("GetQuotaTrend() returns %#v", trend)
+	}
+
+	cpu := trend.Resources[0]
+	if cpu.Resource != v1.ResourceLimitsCPU || cpu.Status.UsedPercentage != 75 || cpu.NearLimit ||
+		!reflect.DeepEqual(cpu.UsagePercentages, []int64{50, 55, 60, 65}) ||
+		cpu.GrowthPerHour == nil || *cpu.GrowthPerHour != 100 {
+		t.Errorf("GetQuotaTrend() returns %#v for CPU", cpu)
+	}
+	if expected := start.Add(10 * time.Hour); cpu.ExhaustedAt == nil || !cpu.ExhaustedAt.Time.Equal(expected) {
+		t.Errorf("GetQuotaTrend() returns CPU exhausted at %v, expected %v", cpu.ExhaustedAt, expected)
+	}
+
+	memory := trend.Resources[1]
+	if memory.Resource != v1.ResourceRequestsMemory || memory.ExhaustedAt != nil ||
+		!reflect.DeepEqual(memory.UsagePercentages, []int64{25, 25, 25, 25}) {
+		t.Errorf("GetQuotaTrend() returns %#v for memory", memory)
+	}
+
+	trend, err = GetQuotaTrend(client, nil, "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trend.HistoryAvailable || len(trend.Usage) != 0 || len(trend.Resources) != 2 ||
+		trend.Resources[0].GrowthPerHour != nil {
+		t.Errorf("GetQuotaTrend() returns %#v without metric client", trend)
+	}
+}
+
+func TestQuotaResourceTrendNearLimit(t *testing.T) {
+	usage := []UsagePoint{{Timestamp: metaV1.NewTime(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)), CPU: 950}}
+	trend := toQuotaResourceTrend("compute", v1.ResourceCPU, ResourceStatus{UsedPercentage: 50}, 1000,
+		v1.ResourceCPU, usage)
+	if !trend.NearLimit || trend.GrowthPerHour != nil || trend.ExhaustedAt != nil {
+		t.Errorf("toQuotaResourceTrend() returns %#v", trend)
+	}
+}
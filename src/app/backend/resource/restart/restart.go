@@ -0,0 +1,134 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restart
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/graph"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+// RestartSpec describes restart of workloads using config map or secret.
+type RestartSpec struct {
+	// DryRun only lists workloads, which would be restarted, without restarting them. It is meant
+	// to be used to ask user for confirmation.
+	DryRun bool `json:"dryRun"`
+}
+
+// RestartResult is the outcome of restarting single workload.
+type RestartResult struct {
+	Workload graph.Node `json:"workload"`
+
+	// Restarted is true if new rollout was triggered, or would be triggered in dry run.
+	Restarted bool `json:"restarted"`
+
+	// Reason explains why the workload was not restarted.
+	Reason string `json:"reason,omitempty"`
+}
+
+// RestartResultList contains restart results of all workloads using the object.
+type RestartResultList struct {
+	Object  graph.Node      `json:"object"`
+	DryRun  bool            `json:"dryRun"`
+	Results []RestartResult `json:"results"`
+}
+
+// restartFuncs trigger new rollout of the workload of given kind. Workloads of other kinds do not
+// replace their pods when pod template changes.
+var restartFuncs = map[api.ResourceKind]func(client.Interface, string, string) error{
+	api.ResourceKindDeployment:  restartDeployment,
+	api.ResourceKindStatefulSet: restartStatefulSet,
+	api.ResourceKindDaemonSet:   restartDaemonSet,
+}
+
+// RestartUsers performs rollout restart of all workloads referencing the config map or secret,
+// so that they pick up its new content. Failure to restart one workload does not stop restarts of
+// the others.
+func RestartUsers(client client.Interface, kind api.ResourceKind, namespace, name string,
+	spec *RestartSpec) (*RestartResultList, error) {
+	usage, err := graph.GetObjectUsage(client, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Restarting %d workloads using %s %s in %s namespace (dry run: %t)",
+		len(usage.Workloads), kind, name, namespace, spec.DryRun)
+
+	result := &RestartResultList{Object: usage.Object, DryRun: spec.DryRun, Results: []RestartResult{}}
+	for _, workload := range usage.Workloads {
+		restart, ok := restartFuncs[workload.Kind]
+		if !ok {
+			result.Results = append(result.Results, RestartResult{
+				Workload: workload,
+				Reason:   fmt.Sprintf("%s does not support rollout restart, recreate its pods manually", workload.Kind),
+			})
+			continue
+		}
+		if !spec.DryRun {
+			if err := restart(client, workload.Namespace, workload.Name); err != nil {
+				result.Results = append(result.Results, RestartResult{Workload: workload, Reason: err.Error()})
+				continue
+			}
+		}
+		result.Results = append(result.Results, RestartResult{Workload: workload, Restarted: true})
+	}
+	return result, nil
+}
+
+func restartDeployment(client client.Interface, namespace, name string) error {
+	_, err := deployment.RestartDeployment(client, namespace, name)
+	return err
+}
+
+func restartStatefulSet(client client.Interface, namespace, name string) error {
+	statefulSet, err := client.AppsV1beta1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	setRestartedAt(&statefulSet.Spec.Template)
+	_, err = client.AppsV1beta1().StatefulSets(namespace).Update(statefulSet)
+	return err
+}
+
+func restartDaemonSet(client client.Interface, namespace, name string) error {
+	daemonSet, err := client.ExtensionsV1beta1().DaemonSets(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if daemonSet.Spec.UpdateStrategy.Type == extensions.OnDeleteDaemonSetStrategyType {
+		return errorsK8s.NewBadRequest(
+			"daemon set uses OnDelete update strategy, its pods have to be deleted to restart them")
+	}
+	setRestartedAt(&daemonSet.Spec.Template)
+	_, err = client.ExtensionsV1beta1().DaemonSets(namespace).Update(daemonSet)
+	return err
+}
+
+// setRestartedAt changes pod template annotation in the same way as kubectl rollout restart.
+func setRestartedAt(template *v1.PodTemplateSpec) {
+	if template.ObjectMeta.Annotations == nil {
+		template.ObjectMeta.Annotations = make(map[string]string)
+	}
+	template.ObjectMeta.Annotations[deployment.RestartedAtAnnotation] = time.Now().Format(time.RFC3339)
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restart
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/graph"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	apps "k8s.io/client-go/pkg/apis/apps/v1beta1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func newTestObjects() []runtime.Object {
+	template := v1.PodTemplateSpec{Spec: v1.PodSpec{
+		Containers: []v1.Container{{
+			Name: "app",
+			EnvFrom: []v1.EnvFromSource{{ConfigMapRef: &v1.ConfigMapEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "settings"}}}},
+		}},
+	}}
+	meta := func(name string) metaV1.ObjectMeta {
+		return metaV1.ObjectMeta{Name: name, Namespace: "default"}
+	}
+	return []runtime.Object{
+		&v1.ConfigMap{ObjectMeta: meta("settings")},
+		&extensions.Deployment{ObjectMeta: meta("web"), Spec: extensions.DeploymentSpec{Template: template}},
+		&extensions.Deployment{ObjectMeta: meta("paused"),
+			Spec: extensions.DeploymentSpec{Template: template, Paused: true}},
+		&apps.StatefulSet{ObjectMeta: meta("db"), Spec: apps.StatefulSetSpec{Template: template}},
+		&extensions.DaemonSet{ObjectMeta: meta("agent"), Spec: extensions.DaemonSetSpec{
+			Template:       template,
+			UpdateStrategy: extensions.DaemonSetUpdateStrategy{Type: extensions.OnDeleteDaemonSetStrategyType},
+		}},
+		&v1.Pod{ObjectMeta: meta("debug"), Spec: template.Spec},
+		&extensions.Deployment{ObjectMeta: meta("unrelated")},
+	}
+}
+
+func node(kind api.ResourceKind, name string) graph.Node {
+	return graph.Node{Kind: kind, Namespace: "default", Name: name}
+}
+
+func TestRestartUsers(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestObjects()...)
+
+	actual, err := RestartUsers(client, api.ResourceKindConfigMap, "default", "settings", &RestartSpec{})
+	if err != nil {
+		t.Fatalf("RestartUsers() returned error: %s", err)
+	}
+
+	expected := []RestartResult{
+		{Workload: node(api.ResourceKindDaemonSet, "agent"),
+			Reason: "daemon set uses OnDelete update strategy, its pods have to be deleted to restart them"},
+		{Workload: node(api.ResourceKindDeployment, "paused"),
+			Reason: "cannot restart paused deployment, resume it first"},
+		{Workload: node(api.ResourceKindDeployment, "web"), Restarted: true},
+		{Workload: node(api.ResourceKindPod, "debug"),
+			Reason: "pod does not support rollout restart, recreate its pods manually"},
+		{Workload: node(api.ResourceKindStatefulSet, "db"), Restarted: true},
+	}
+	if !reflect.DeepEqual(actual.Results, expected) {
+		t.Errorf("RestartUsers() == \ngot %#v, \nexpected %#v", actual.Results, expected)
+	}
+
+	web, _ := client.ExtensionsV1beta1().Deployments("default").Get("web", metaV1.GetOptions{})
+	if _, ok := web.Spec.Template.Annotations[deployment.RestartedAtAnnotation]; !ok {
+		t.Errorf("RestartUsers() did not restart web deployment")
+	}
+	db, _ := client.AppsV1beta1().StatefulSets("default").Get("db", metaV1.GetOptions{})
+	if _, ok := db.Spec.Template.Annotations[deployment.RestartedAtAnnotation]; !ok {
+		t.Errorf("RestartUsers() did not restart db stateful set")
+	}
+	unrelated, _ := client.ExtensionsV1beta1().Deployments("default").Get("unrelated", metaV1.GetOptions{})
+	if _, ok := unrelated.Spec.Template.Annotations[deployment.RestartedAtAnnotation]; ok {
+		t.Errorf("RestartUsers() restarted unrelated deployment")
+	}
+}
+
+func TestRestartUsersDryRun(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestObjects()...)
+
+	actual, err := RestartUsers(client, api.ResourceKindConfigMap, "default", "settings",
+		&RestartSpec{DryRun: true})
+	if err != nil {
+		t.Fatalf("RestartUsers() returned error: %s", err)
+	}
+
+	if !actual.DryRun || len(actual.Results) != 5 {
+		t.Errorf("RestartUsers() == %#v, expected dry run with 5 results", actual)
+	}
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "update" {
+			t.Errorf("RestartUsers() in dry run performed %#v", action)
+		}
+	}
+}
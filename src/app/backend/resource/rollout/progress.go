@@ -0,0 +1,262 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	apps "k8s.io/client-go/pkg/apis/apps/v1beta1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+// Rollout progress is polled every progressInterval. Watching stops with an error after
+// progressTimeout, if the rollout did not finish.
+var (
+	progressInterval = 2 * time.Second
+	progressTimeout  = 15 * time.Minute
+)
+
+// progressDeadlineExceeded is the reason of deployment Progressing condition, when the rollout did
+// not progress within its progress deadline.
+const progressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+// RolloutProgress describes how far the rollout of a workload got.
+type RolloutProgress struct {
+	// Desired number of replicas. For daemon sets it is the number of nodes the daemon should run on.
+	Replicas int32 `json:"replicas"`
+	// Replicas running the current pod template.
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+	// Replicas, which are ready and available.
+	ReadyReplicas     int32 `json:"readyReplicas"`
+	AvailableReplicas int32 `json:"availableReplicas"`
+	// Done is true when all replicas run the current pod template and are available.
+	Done bool `json:"done"`
+	// Message describes the state of the rollout.
+	Message string `json:"message"`
+}
+
+// RollsOut returns true if workloads of the kind roll out changes of their pod template.
+func RollsOut(kind api.ResourceKind) bool {
+	return kind == api.ResourceKindDeployment || kind == api.ResourceKindDaemonSet ||
+		kind == api.ResourceKindStatefulSet
+}
+
+// GetRolloutProgress returns progress of the rollout of the workload's current pod template.
+// Returns bad request error for kinds, which do not roll out pod template changes.
+func GetRolloutProgress(client client.Interface, kind api.ResourceKind, namespace, name string) (
+	*RolloutProgress, error) {
+	switch kind {
+	case api.ResourceKindDeployment:
+		deployment, err := client.ExtensionsV1beta1().Deployments(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return getDeploymentProgress(deployment)
+	case api.ResourceKindDaemonSet:
+		daemonSet, err := client.ExtensionsV1beta1().DaemonSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return GetDaemonSetProgress(daemonSet), nil
+	case api.ResourceKindStatefulSet:
+		statefulSet, err := client.AppsV1beta1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return getStatefulSetProgress(client, statefulSet)
+	}
+	return nil, errorsK8s.NewBadRequest(fmt.Sprintf("%s does not roll out pod template changes", kind))
+}
+
+// WaitForRollout reports progress of the rollout whenever it changes, until the rollout is done.
+// Returns error if the rollout failed or did not finish in time.
+func WaitForRollout(client client.Interface, kind api.ResourceKind, namespace, name string,
+	report func(RolloutProgress)) error {
+	var last *RolloutProgress
+	deadline := time.Now().Add(progressTimeout)
+	for {
+		progress, err := GetRolloutProgress(client, kind, namespace, name)
+		if err != nil {
+			return err
+		}
+		if last == nil || *last != *progress {
+			report(*progress)
+			last = progress
+		}
+		if progress.Done {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("rollout of %s %s did not finish in %s", kind, name, progressTimeout)
+		}
+		time.Sleep(progressInterval)
+	}
+}
+
+func getDeploymentProgress(deployment *extensions.Deployment) (*RolloutProgress, error) {
+	progress := &RolloutProgress{
+		Replicas:          getReplicas(deployment.Spec.Replicas),
+		UpdatedReplicas:   deployment.Status.UpdatedReplicas,
+		ReadyReplicas:     deployment.Status.ReadyReplicas,
+		AvailableReplicas: deployment.Status.AvailableReplicas,
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == extensions.DeploymentProgressing && condition.Reason == progressDeadlineExceeded {
+			return nil, fmt.Errorf("rollout of deployment %s exceeded its progress deadline: %s",
+				deployment.Name, condition.Message)
+		}
+	}
+
+	switch {
+	case deployment.Spec.Paused:
+		progress.Message = "Rollout is paused"
+	case deployment.Status.ObservedGeneration < deployment.Generation:
+		progress.Message = "Waiting for the rollout to start"
+	case progress.UpdatedReplicas < progress.Replicas:
+		progress.Message = fmt.Sprintf("%d of %d replicas updated", progress.UpdatedReplicas, progress.Replicas)
+	case deployment.Status.Replicas > progress.UpdatedReplicas:
+		progress.Message = fmt.Sprintf("%d old replicas pending termination",
+			deployment.Status.Replicas-progress.UpdatedReplicas)
+	case progress.AvailableReplicas < progress.UpdatedReplicas:
+		progress.Message = fmt.Sprintf("%d of %d updated replicas available", progress.AvailableReplicas,
+			progress.UpdatedReplicas)
+	default:
+		progress.Done = true
+		progress.Message = "Rollout finished"
+	}
+	return progress, nil
+}
+
+// GetDaemonSetProgress returns progress of the rollout of the daemon set's current pod template.
+func GetDaemonSetProgress(daemonSet *extensions.DaemonSet) *RolloutProgress {
+	status := daemonSet.Status
+	progress := &RolloutProgress{
+		Replicas:          status.DesiredNumberScheduled,
+		UpdatedReplicas:   status.UpdatedNumberScheduled,
+		ReadyReplicas:     status.NumberReady,
+		AvailableReplicas: status.NumberAvailable,
+	}
+
+	switch {
+	case daemonSet.Spec.UpdateStrategy.Type == extensions.OnDeleteDaemonSetStrategyType:
+		progress.Done = true
+		progress.Message = "Daemon set uses OnDelete update strategy, its pods have to be deleted to update them"
+	case status.ObservedGeneration < daemonSet.Generation:
+		progress.Message = "Waiting for the rollout to start"
+	case progress.UpdatedReplicas < progress.Replicas:
+		progress.Message = fmt.Sprintf("%d of %d pods updated", progress.UpdatedReplicas, progress.Replicas)
+	case progress.AvailableReplicas < progress.Replicas:
+		progress.Message = fmt.Sprintf("%d of %d updated pods available", progress.AvailableReplicas,
+			progress.Replicas)
+	default:
+		progress.Done = true
+		progress.Message = "Rollout finished"
+	}
+	return progress
+}
+
+// getStatefulSetProgress counts updated pods by comparing their images with the pod template, as
+// stateful set status of this API version does not report updated replicas.
+func getStatefulSetProgress(client client.Interface, statefulSet *apps.StatefulSet) (*RolloutProgress, error) {
+	selector, err := metaV1.LabelSelectorAsSelector(statefulSet.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	if statefulSet.Spec.Selector == nil {
+		selector = labels.SelectorFromSet(statefulSet.Spec.Template.Labels)
+	}
+	pods, err := client.CoreV1().Pods(statefulSet.Namespace).List(metaV1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &RolloutProgress{Replicas: getReplicas(statefulSet.Spec.Replicas)}
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil || !hasTemplateImages(pod, statefulSet.Spec.Template.Spec) {
+			continue
+		}
+		progress.UpdatedReplicas++
+		if isPodReady(pod) {
+			progress.ReadyReplicas++
+			progress.AvailableReplicas++
+		}
+	}
+
+	observed := statefulSet.Status.ObservedGeneration
+	switch {
+	case observed == nil || *observed < statefulSet.Generation:
+		progress.Message = "Waiting for the rollout to start"
+	case progress.UpdatedReplicas < progress.Replicas:
+		progress.Message = fmt.Sprintf("%d of %d pods updated", progress.UpdatedReplicas, progress.Replicas)
+	case progress.ReadyReplicas < progress.Replicas:
+		progress.Message = fmt.Sprintf("%d of %d updated pods ready", progress.ReadyReplicas, progress.Replicas)
+	default:
+		progress.Done = true
+		progress.Message = "Rollout finished"
+	}
+	return progress, nil
+}
+
+// IsDaemonPodUpdated returns true if the pod runs the current pod template of the daemon set. Pods are
+// compared by the template generation label, or by images if the label is not set.
+func IsDaemonPodUpdated(daemonSet *extensions.DaemonSet, pod v1.Pod) bool {
+	generation, exists := pod.Labels[extensions.DaemonSetTemplateGenerationKey]
+	if exists && daemonSet.Spec.TemplateGeneration > 0 {
+		return generation == strconv.FormatInt(daemonSet.Spec.TemplateGeneration, 10)
+	}
+	return hasTemplateImages(pod, daemonSet.Spec.Template.Spec)
+}
+
+// hasTemplateImages returns true if all containers of the pod run images of the pod template.
+func hasTemplateImages(pod v1.Pod, template v1.PodSpec) bool {
+	images := make(map[string]string)
+	for _, container := range append(template.InitContainers, template.Containers...) {
+		images[container.Name] = container.Image
+	}
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if images[container.Name] != container.Image {
+			return false
+		}
+	}
+	return true
+}
+
+func isPodReady(pod v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// getReplicas returns desired number of replicas, which defaults to 1.
+func getReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
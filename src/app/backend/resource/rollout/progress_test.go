@@ -0,0 +1,170 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	apps "k8s.io/client-go/pkg/apis/apps/v1beta1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func getProgressTestDeployment(updated, available int32) *extensions.Deployment {
+	replicas := int32(3)
+	return &extensions.Deployment{
+		ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "ns", Generation: 2},
+		Spec:       extensions.DeploymentSpec{Replicas: &replicas},
+		Status: extensions.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           3,
+			UpdatedReplicas:    updated,
+			ReadyReplicas:      available,
+			AvailableReplicas:  available,
+		},
+	}
+}
+
+func TestGetDeploymentProgress(t *testing.T) {
+	notObserved := getProgressTestDeployment(3, 3)
+	notObserved.Status.ObservedGeneration = 1
+	failed := getProgressTestDeployment(1, 0)
+	failed.Status.Conditions = []extensions.DeploymentCondition{{Type: extensions.DeploymentProgressing,
+		Reason: progressDeadlineExceeded}}
+
+	cases := []struct {
+		deployment      *extensions.Deployment
+		expectedDone    bool
+		expectedMessage string
+		expectedErr     bool
+	}{
+		{notObserved, false, "Waiting for the rollout to start", false},
+		{getProgressTestDeployment(1, 1), false, "1 of 3 replicas updated", false},
+		{getProgressTestDeployment(3, 2), false, "2 of 3 updated replicas available", false},
+		{getProgressTestDeployment(3, 3), true, "Rollout finished", false},
+		{failed, false, "", true},
+	}
+
+	for _, c := range cases {
+		actual, err := getDeploymentProgress(c.deployment)
+		if c.expectedErr {
+			if err == nil {
+				t.Errorf("Expected error for %#v", c.deployment.Status)
+			}
+			continue
+		}
+		if err != nil || actual.Done != c.expectedDone || actual.Message != c.expectedMessage {
+			t.Errorf("getDeploymentProgress() == %#v, %v, expected done %t and message %q", actual, err,
+				c.expectedDone, c.expectedMessage)
+		}
+	}
+}
+
+func TestGetStatefulSetProgress(t *testing.T) {
+	replicas := int32(2)
+	observed := int64(1)
+	statefulSet := &apps.StatefulSet{
+		ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: "ns", Generation: 1},
+		Spec: apps.StatefulSetSpec{
+			Replicas: &replicas,
+			Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{
+				{Name: "db", Image: "postgres:10"},
+			}}},
+		},
+		Status: apps.StatefulSetStatus{ObservedGeneration: &observed, Replicas: 2},
+	}
+	getPod := func(name, image string, ready v1.ConditionStatus) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "ns", Labels: map[string]string{"app": "db"}},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "db", Image: image}}},
+			Status:     v1.PodStatus{Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: ready}}},
+		}
+	}
+
+	client := fake.NewSimpleClientset(statefulSet, getPod("db-0", "postgres:9", v1.ConditionTrue),
+		getPod("db-1", "postgres:10", v1.ConditionTrue))
+	actual, err := GetRolloutProgress(client, api.ResourceKindStatefulSet, "ns", "db")
+	if err != nil {
+		t.Fatalf("GetRolloutProgress returned error: %s", err)
+	}
+	expected := &RolloutProgress{Replicas: 2, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1,
+		Message: "1 of 2 pods updated"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetRolloutProgress() == %#v, expected %#v", actual, expected)
+	}
+}
+
+func TestIsDaemonPodUpdated(t *testing.T) {
+	daemonSet := &extensions.DaemonSet{Spec: extensions.DaemonSetSpec{
+		TemplateGeneration: 2,
+		Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "agent", Image: "agent:2"}},
+		}},
+	}}
+
+	cases := []struct {
+		info     string
+		pod      v1.Pod
+		expected bool
+	}{
+		{"current generation", v1.Pod{ObjectMeta: metaV1.ObjectMeta{
+			Labels: map[string]string{"pod-template-generation": "2"}}}, true},
+		{"old generation", v1.Pod{ObjectMeta: metaV1.ObjectMeta{
+			Labels: map[string]string{"pod-template-generation": "1"}}}, false},
+		{"no label, current image", v1.Pod{Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "agent", Image: "agent:2"}}}}, true},
+		{"no label, old image", v1.Pod{Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "agent", Image: "agent:1"}}}}, false},
+	}
+
+	for _, c := range cases {
+		if actual := IsDaemonPodUpdated(daemonSet, c.pod); actual != c.expected {
+			t.Errorf("%s: IsDaemonPodUpdated() == %t, expected %t", c.info, actual, c.expected)
+		}
+	}
+}
+
+func TestGetRolloutProgressUnsupportedKind(t *testing.T) {
+	_, err := GetRolloutProgress(fake.NewSimpleClientset(), api.ResourceKindJob, "ns", "job")
+	if !errorsK8s.IsBadRequest(err) {
+		t.Errorf("Expected bad request for job, got %v", err)
+	}
+}
+
+func TestWaitForRollout(t *testing.T) {
+	progressInterval = time.Millisecond
+	deployment := getProgressTestDeployment(1, 1)
+	client := fake.NewSimpleClientset(deployment)
+
+	reports := make([]RolloutProgress, 0)
+	err := WaitForRollout(client, api.ResourceKindDeployment, "ns", "app", func(progress RolloutProgress) {
+		reports = append(reports, progress)
+		// Simulate the deployment controller finishing the rollout.
+		client.ExtensionsV1beta1().Deployments("ns").Update(getProgressTestDeployment(3, 3))
+	})
+	if err != nil {
+		t.Fatalf("WaitForRollout returned error: %s", err)
+	}
+	if len(reports) != 2 || reports[0].Done || !reports[1].Done {
+		t.Errorf("Expected progress and finish to be reported, got %#v", reports)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// SetImageSpec describes image change of a single container, the same as kubectl set image.
+type SetImageSpec struct {
+	// Container is the name of the container or init container. May be empty if the pod template
+	// has a single container.
+	Container string `json:"container"`
+
+	// Image is the new image of the container, e.g. nginx:1.13. Only one of Image and Tag can be
+	// set.
+	Image string `json:"image"`
+
+	// Tag replaces only the tag of the current image, e.g. 1.13 changes nginx:1.12 to nginx:1.13.
+	Tag string `json:"tag"`
+
+	// Watch requests progress of the triggered rollout to be streamed over a watch session.
+	Watch bool `json:"watch"`
+}
+
+// SetImageResult describes the image change.
+type SetImageResult struct {
+	Container string `json:"container"`
+	OldImage  string `json:"oldImage"`
+	Image     string `json:"image"`
+	// Changed is false if the container already had the image and no rollout was triggered.
+	Changed bool `json:"changed"`
+}
+
+// SetImage changes image of a container in the pod template of the workload. Deployments, daemon
+// sets and stateful sets roll out the change, other kinds only use it for pods created later.
+func SetImage(client client.Interface, kind api.ResourceKind, namespace, name string,
+	spec *SetImageSpec) (*SetImageResult, error) {
+	if (len(spec.Image) == 0) == (len(spec.Tag) == 0) {
+		return nil, errorsK8s.NewBadRequest("exactly one of image and tag is required")
+	}
+
+	result := new(SetImageResult)
+	err := common.UpdatePodTemplate(client, kind, namespace, name, func(template *v1.PodTemplateSpec) error {
+		container, err := findContainer(&template.Spec, spec.Container)
+		if err != nil {
+			return err
+		}
+
+		result.Container = container.Name
+		result.OldImage = container.Image
+		result.Image = spec.Image
+		if len(spec.Tag) > 0 {
+			result.Image = replaceTag(container.Image, spec.Tag)
+		}
+		result.Changed = result.Image != result.OldImage
+		container.Image = result.Image
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Set image of %s container of %s %s in %s namespace from %s to %s", result.Container,
+		kind, name, namespace, result.OldImage, result.Image)
+	return result, nil
+}
+
+// findContainer returns container or init container with given name. The only container is
+// returned if the name is empty.
+func findContainer(spec *v1.PodSpec, name string) (*v1.Container, error) {
+	if len(name) == 0 {
+		if len(spec.Containers) != 1 {
+			return nil, errorsK8s.NewBadRequest("container name is required for pods with more than one container")
+		}
+		return &spec.Containers[0], nil
+	}
+
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == name {
+			return &spec.Containers[i], nil
+		}
+	}
+	for i := range spec.InitContainers {
+		if spec.InitContainers[i].Name == name {
+			return &spec.InitContainers[i], nil
+		}
+	}
+	return nil, errorsK8s.NewBadRequest(fmt.Sprintf("container %s not found", name))
+}
+
+// replaceTag replaces tag of the image. Digest of the image is dropped, as it pins the old tag.
+func replaceTag(image, tag string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image + ":" + tag
+}
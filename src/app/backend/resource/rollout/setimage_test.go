@@ -0,0 +1,94 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+func getSetImageTestDeployment(containers ...v1.Container) *extensions.Deployment {
+	deployment := &extensions.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "ns"}}
+	deployment.Spec.Template.Spec.Containers = containers
+	deployment.Spec.Template.Spec.InitContainers = []v1.Container{{Name: "init", Image: "busybox"}}
+	return deployment
+}
+
+func TestSetImage(t *testing.T) {
+	web := v1.Container{Name: "web", Image: "registry:5000/nginx:1.12@sha256:abc"}
+	sidecar := v1.Container{Name: "sidecar", Image: "envoy"}
+
+	cases := []struct {
+		containers  []v1.Container
+		spec        *SetImageSpec
+		expected    *SetImageResult
+		expectedErr bool
+	}{
+		{
+			[]v1.Container{web},
+			&SetImageSpec{Tag: "1.13"},
+			&SetImageResult{Container: "web", OldImage: web.Image, Image: "registry:5000/nginx:1.13",
+				Changed: true},
+			false,
+		},
+		{
+			[]v1.Container{web, sidecar},
+			&SetImageSpec{Container: "sidecar", Image: "envoy"},
+			&SetImageResult{Container: "sidecar", OldImage: "envoy", Image: "envoy"},
+			false,
+		},
+		{
+			[]v1.Container{web, sidecar},
+			&SetImageSpec{Container: "init", Tag: "1.27"},
+			&SetImageResult{Container: "init", OldImage: "busybox", Image: "busybox:1.27", Changed: true},
+			false,
+		},
+		{[]v1.Container{web, sidecar}, &SetImageSpec{Tag: "1.13"}, nil, true},
+		{[]v1.Container{web}, &SetImageSpec{Container: "missing", Tag: "1.13"}, nil, true},
+		{[]v1.Container{web}, &SetImageSpec{Image: "nginx", Tag: "1.13"}, nil, true},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset(getSetImageTestDeployment(c.containers...))
+		actual, err := SetImage(client, api.ResourceKindDeployment, "ns", "app", c.spec)
+		if c.expectedErr {
+			if !errorsK8s.IsBadRequest(err) {
+				t.Errorf("SetImage(%#v) == %v, expected bad request", c.spec, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("SetImage(%#v) returned error: %s", c.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("SetImage(%#v) == %#v, expected %#v", c.spec, actual, c.expected)
+		}
+
+		deployment, _ := client.ExtensionsV1beta1().Deployments("ns").Get("app", metaV1.GetOptions{})
+		container, _ := findContainer(&deployment.Spec.Template.Spec, c.expected.Container)
+		if container.Image != c.expected.Image {
+			t.Errorf("Expected %s container to have %s image, got %s", c.expected.Container,
+				c.expected.Image, container.Image)
+		}
+	}
+}
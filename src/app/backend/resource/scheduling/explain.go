@@ -0,0 +1,351 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduling
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diagnosis"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	helper "k8s.io/client-go/pkg/api/v1/resource"
+)
+
+// Predicates evaluated by Dashboard, in the order the scheduler evaluates them. Inter-pod affinity,
+// topology spread constraints and volume topology are not evaluated, they are reported only in
+// the scheduler message.
+const (
+	PredicateNodeUnschedulable = "NodeUnschedulable"
+	PredicateNodeNotReady      = "NodeNotReady"
+	PredicateNodeSelector      = "NodeSelector"
+	PredicateNodeAffinity      = "NodeAffinity"
+	PredicateTaintToleration   = "TaintToleration"
+	PredicateNodePorts         = "NodePorts"
+	PredicateNodeResourcesFit  = "NodeResourcesFit"
+)
+
+// Taints set by the node lifecycle controller on nodes, which are not ready, and on cordoned nodes.
+const (
+	taintNodeNotReady      = "node.kubernetes.io/not-ready"
+	taintNodeUnreachable   = "node.kubernetes.io/unreachable"
+	taintNodeUnschedulable = "node.kubernetes.io/unschedulable"
+)
+
+// Reasons of events related to scheduling of pods.
+var schedulingEventReasons = map[string]bool{
+	"Scheduled":         true,
+	"FailedScheduling":  true,
+	"TriggeredScaleUp":  true,
+	"NotTriggerScaleUp": true,
+}
+
+// SchedulingExplanation explains why a pod was not scheduled, with the scheduler message and the
+// predicates evaluated against current nodes.
+type SchedulingExplanation struct {
+	Namespace string `json:"namespace"`
+	PodName   string `json:"podName"`
+
+	// NodeName is the node the pod is scheduled to. Empty for pending pods.
+	NodeName string `json:"nodeName"`
+
+	// Unschedulable is true, when the scheduler reported that the pod does not fit any node.
+	Unschedulable bool `json:"unschedulable"`
+
+	// SchedulerMessage is the last message of the scheduler about the pod, with causes parsed from
+	// it, if its format is known.
+	SchedulerMessage string                      `json:"schedulerMessage"`
+	SchedulerCauses  []diagnosis.SchedulingCause `json:"schedulerCauses"`
+
+	// Requests are resources requested by the pod, including init containers.
+	Requests v1.ResourceList `json:"requests"`
+
+	// FittingNodes is the number of nodes passing all predicates.
+	FittingNodes int `json:"fittingNodes"`
+
+	// Summary is the number of nodes failing each predicate, most frequent first.
+	Summary []PredicateSummary `json:"summary"`
+
+	// Nodes are the evaluated nodes, those with the fewest failures first.
+	Nodes []NodeFit `json:"nodes"`
+
+	// Events are scheduling events of the pod.
+	Events []common.Event `json:"events"`
+}
+
+// PredicateSummary is the number of nodes failing a predicate.
+type PredicateSummary struct {
+	Predicate string `json:"predicate"`
+	Nodes     int    `json:"nodes"`
+}
+
+// NodeFit tells whether the pod fits a node and if not, why.
+type NodeFit struct {
+	NodeName string       `json:"nodeName"`
+	Fits     bool         `json:"fits"`
+	Failures []FitFailure `json:"failures"`
+}
+
+// FitFailure is a predicate the node fails for the pod.
+type FitFailure struct {
+	Predicate string `json:"predicate"`
+	Message   string `json:"message"`
+}
+
+// ExplainPodScheduling evaluates scheduling predicates of the pod against all nodes of the cluster
+// and returns them together with what the scheduler reported.
+func ExplainPodScheduling(client kubernetes.Interface, namespace, name string) (*SchedulingExplanation, error) {
+	logger.Infof("Explaining scheduling of %s pod in %s namespace", name, namespace)
+
+	pod, err := client.CoreV1().Pods(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := client.CoreV1().Nodes().List(metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	selector, err := fields.ParseSelector("status.phase!=" + string(v1.PodSucceeded) +
+		",status.phase!=" + string(v1.PodFailed))
+	if err != nil {
+		return nil, err
+	}
+	pods, err := client.CoreV1().Pods(v1.NamespaceAll).List(metaV1.ListOptions{FieldSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	events, err := event.GetEvents(client, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return explain(pod, nodes.Items, pods.Items, events)
+}
+
+func explain(pod *v1.Pod, nodes []v1.Node, pods []v1.Pod, events []v1.Event) (*SchedulingExplanation, error) {
+	requests, _, err := helper.PodRequestsAndLimits(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SchedulingExplanation{
+		Namespace:       pod.Namespace,
+		PodName:         pod.Name,
+		NodeName:        pod.Spec.NodeName,
+		SchedulerCauses: make([]diagnosis.SchedulingCause, 0),
+		Requests:        v1.ResourceList{},
+		Summary:         make([]PredicateSummary, 0),
+		Nodes:           make([]NodeFit, 0, len(nodes)),
+		Events:          make([]common.Event, 0),
+	}
+	for name, quantity := range requests {
+		if !quantity.IsZero() {
+			result.Requests[name] = quantity
+		}
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionFalse {
+			result.Unschedulable = condition.Reason == v1.PodReasonUnschedulable
+			result.SchedulerMessage = condition.Message
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].LastTimestamp.Before(events[j].LastTimestamp) })
+	for _, e := range events {
+		if e.InvolvedObject.Name != pod.Name || !schedulingEventReasons[e.Reason] {
+			continue
+		}
+		if e.Reason == "FailedScheduling" && len(result.SchedulerMessage) == 0 {
+			result.SchedulerMessage = e.Message
+		}
+		result.Events = append(result.Events, event.ToEvent(e))
+	}
+	if causes, _, ok := diagnosis.ParseSchedulingMessage(result.SchedulerMessage); ok {
+		result.SchedulerCauses = causes
+	}
+
+	podsByNode := make(map[string][]v1.Pod)
+	for _, p := range pods {
+		if p.UID != pod.UID && len(p.Spec.NodeName) > 0 {
+			podsByNode[p.Spec.NodeName] = append(podsByNode[p.Spec.NodeName], p)
+		}
+	}
+
+	failing := make(map[string]int)
+	for i := range nodes {
+		failures := evaluateNode(pod, result.Requests, &nodes[i], podsByNode[nodes[i].Name])
+		fit := NodeFit{NodeName: nodes[i].Name, Fits: len(failures) == 0, Failures: failures}
+		if fit.Fits {
+			result.FittingNodes++
+		}
+		for _, failure := range failures {
+			failing[failure.Predicate]++
+		}
+		result.Nodes = append(result.Nodes, fit)
+	}
+
+	sort.SliceStable(result.Nodes, func(i, j int) bool {
+		if len(result.Nodes[i].Failures) != len(result.Nodes[j].Failures) {
+			return len(result.Nodes[i].Failures) < len(result.Nodes[j].Failures)
+		}
+		return result.Nodes[i].NodeName < result.Nodes[j].NodeName
+	})
+	for predicate, count := range failing {
+		result.Summary = append(result.Summary, PredicateSummary{Predicate: predicate, Nodes: count})
+	}
+	sort.Slice(result.Summary, func(i, j int) bool {
+		if result.Summary[i].Nodes != result.Summary[j].Nodes {
+			return result.Summary[i].Nodes > result.Summary[j].Nodes
+		}
+		return result.Summary[i].Predicate < result.Summary[j].Predicate
+	})
+	return result, nil
+}
+
+// evaluateNode returns all predicates the node fails for the pod. Pods are the other pods running
+// on the node.
+func evaluateNode(pod *v1.Pod, requests v1.ResourceList, node *v1.Node, pods []v1.Pod) []FitFailure {
+	failures := make([]FitFailure, 0)
+	fail := func(predicate, message string, args ...interface{}) {
+		failures = append(failures, FitFailure{Predicate: predicate, Message: fmt.Sprintf(message, args...)})
+	}
+
+	cordon := v1.Taint{Key: taintNodeUnschedulable, Effect: v1.TaintEffectNoSchedule}
+	if node.Spec.Unschedulable && len(UntoleratedTaints(pod.Spec.Tolerations, []v1.Taint{cordon})) > 0 {
+		fail(PredicateNodeUnschedulable, "Node is cordoned")
+	}
+	if !isReady(node) && !hasTaint(node, taintNodeNotReady) && !hasTaint(node, taintNodeUnreachable) {
+		fail(PredicateNodeNotReady, "Node is not ready")
+	}
+
+	if mismatched := MismatchedNodeSelector(pod.Spec.NodeSelector, node.Labels); len(mismatched) > 0 {
+		fail(PredicateNodeSelector, "Node does not have labels %s", strings.Join(mismatched, ", "))
+	}
+	if !MatchesNodeAffinity(pod.Spec.Affinity, node.Labels) {
+		fail(PredicateNodeAffinity, "Node labels do not match required node affinity")
+	}
+	if taints := UntoleratedTaints(pod.Spec.Tolerations, node.Spec.Taints); len(taints) > 0 {
+		fail(PredicateTaintToleration, "Node has taints %s, which are not tolerated", strings.Join(taints, ", "))
+	}
+
+	for _, conflict := range conflictingHostPorts(pod, pods) {
+		fail(PredicateNodePorts, "Host port %s is used by pod %s", conflict[0], conflict[1])
+	}
+
+	used := v1.ResourceList{}
+	for i := range pods {
+		podRequests, _, err := helper.PodRequestsAndLimits(&pods[i])
+		if err != nil {
+			continue
+		}
+		for name, quantity := range podRequests {
+			total := used[name]
+			total.Add(quantity)
+			used[name] = total
+		}
+	}
+
+	names := make([]string, 0, len(requests))
+	for name := range requests {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		requested := requests[v1.ResourceName(name)]
+		allocatable, ok := node.Status.Allocatable[v1.ResourceName(name)]
+		if !ok {
+			fail(PredicateNodeResourcesFit, "Node does not provide %s", name)
+			continue
+		}
+		free := allocatable.Copy()
+		free.Sub(used[v1.ResourceName(name)])
+		if free.Cmp(requested) < 0 {
+			fail(PredicateNodeResourcesFit, "Insufficient %s: requested %s, %s free of %s allocatable", name,
+				requested.String(), nonNegative(free).String(), allocatable.String())
+		}
+	}
+	if allocatable, ok := node.Status.Allocatable[v1.ResourcePods]; ok && int64(len(pods)) >= allocatable.Value() {
+		fail(PredicateNodeResourcesFit, "Too many pods: node runs %d of %d pods", len(pods), allocatable.Value())
+	}
+	return failures
+}
+
+// conflictingHostPorts returns host ports of the pod, which are used by the other pods, as pairs of
+// port with protocol and namespace/name of the pod using it.
+func conflictingHostPorts(pod *v1.Pod, pods []v1.Pod) [][2]string {
+	usedBy := make(map[string]string)
+	for _, p := range pods {
+		for _, port := range hostPorts(&p) {
+			usedBy[port] = p.Namespace + "/" + p.Name
+		}
+	}
+
+	result := make([][2]string, 0)
+	for _, port := range hostPorts(pod) {
+		if other, ok := usedBy[port]; ok {
+			result = append(result, [2]string{port, other})
+		}
+	}
+	return result
+}
+
+// hostPorts returns host ports of containers of the pod, i.e. 8080/TCP.
+func hostPorts(pod *v1.Pod) []string {
+	result := make([]string, 0)
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.HostPort > 0 {
+				protocol := port.Protocol
+				if len(protocol) == 0 {
+					protocol = v1.ProtocolTCP
+				}
+				result = append(result, fmt.Sprintf("%d/%s", port.HostPort, protocol))
+			}
+		}
+	}
+	return result
+}
+
+func isReady(node *v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func hasTaint(node *v1.Node, key string) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func nonNegative(quantity *resource.Quantity) *resource.Quantity {
+	if quantity.Sign() < 0 {
+		return resource.NewQuantity(0, quantity.Format)
+	}
+	return quantity
+}
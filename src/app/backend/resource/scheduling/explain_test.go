@@ -0,0 +1,160 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduling
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func newNode(name string, ready bool, cpu string, labels map[string]string, taints ...v1.Taint) *v1.Node {
+	status := v1.ConditionTrue
+	if !ready {
+		status = v1.ConditionFalse
+	}
+	return &v1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       v1.NodeSpec{Taints: taints},
+		Status: v1.NodeStatus{
+			Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: status}},
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:  resource.MustParse(cpu),
+				v1.ResourcePods: resource.MustParse("10"),
+			},
+		},
+	}
+}
+
+func newPod(namespace, name, node, cpu string, hostPort int32) *v1.Pod {
+	container := v1.Container{
+		Name: "main",
+		Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)},
+		},
+	}
+	if hostPort > 0 {
+		container.Ports = []v1.ContainerPort{{ContainerPort: hostPort, HostPort: hostPort}}
+	}
+	return &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID("uid-" + name)},
+		Spec:       v1.PodSpec{NodeName: node, Containers: []v1.Container{container}},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+}
+
+func TestExplainPodScheduling(t *testing.T) {
+	pending := newPod("prod", "api-0", "", "1500m", 8080)
+	pending.Spec.NodeSelector = map[string]string{"disk": "ssd"}
+	pending.Status = v1.PodStatus{
+		Phase: v1.PodPending,
+		Conditions: []v1.PodCondition{{
+			Type:    v1.PodScheduled,
+			Status:  v1.ConditionFalse,
+			Reason:  v1.PodReasonUnschedulable,
+			Message: "0/4 nodes are available: 1 Insufficient cpu, 3 node(s) didn't match node selector.",
+		}},
+	}
+
+	cordoned := newNode("node-c", true, "4", map[string]string{"disk": "ssd"})
+	cordoned.Spec.Unschedulable = true
+	client := fake.NewSimpleClientset(
+		pending,
+		newNode("node-a", true, "2", map[string]string{"disk": "ssd"}),
+		newNode("node-b", true, "4", nil,
+			v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}),
+		cordoned,
+		newNode("node-d", false, "4", map[string]string{"disk": "ssd"}),
+		newNode("node-e", true, "4", map[string]string{"disk": "ssd"}),
+		newPod("batch", "job-0", "node-a", "1", 0),
+		newPod("batch", "job-1", "node-e", "500m", 8080),
+		&v1.Event{
+			ObjectMeta:     metaV1.ObjectMeta{Namespace: "prod", Name: "api-0.1"},
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Namespace: "prod", Name: "api-0"},
+			Reason:         "FailedScheduling",
+			Message:        "0/4 nodes are available: 4 Insufficient cpu.",
+			Type:           v1.EventTypeWarning,
+			LastTimestamp:  metaV1.NewTime(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)),
+		},
+	)
+
+	explanation, err := ExplainPodScheduling(client, "prod", "api-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !explanation.Unschedulable || explanation.FittingNodes != 0 || len(explanation.Events) != 1 ||
+		explanation.SchedulerMessage != pending.Status.Conditions[0].Message ||
+		len(explanation.SchedulerCauses) != 2 {
+		t.Errorf("ExplainPodScheduling() returns %#v", explanation)
+	}
+	if cpu := explanation.Requests[v1.ResourceCPU]; cpu.String() != "1500m" {
+		t.Errorf("ExplainPodScheduling() returns %s cpu requests, expected 1500m", cpu.String())
+	}
+
+	expected := map[string][]string{
+		"node-a": {PredicateNodeResourcesFit},
+		"node-b": {PredicateNodeSelector, PredicateTaintToleration},
+		"node-c": {PredicateNodeUnschedulable},
+		"node-d": {PredicateNodeNotReady},
+		"node-e": {PredicateNodePorts},
+	}
+	names := make([]string, 0)
+	for _, node := range explanation.Nodes {
+		names = append(names, node.NodeName)
+		predicates := make([]string, 0)
+		for _, failure := range node.Failures {
+			predicates = append(predicates, failure.Predicate)
+		}
+		if !reflect.DeepEqual(predicates, expected[node.NodeName]) || node.Fits {
+			t.Errorf("ExplainPodScheduling() returns %v failures for %s, expected %v",
+				node.Failures, node.NodeName, expected[node.NodeName])
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"node-a", "node-c", "node-d", "node-e", "node-b"}) {
+		t.Errorf("ExplainPodScheduling() returns nodes in order %v", names)
+	}
+	if explanation.Nodes[0].Failures[0].Message != "Insufficient cpu: requested 1500m, 1 free of 2 allocatable" {
+		t.Errorf("ExplainPodScheduling() returns %q", explanation.Nodes[0].Failures[0].Message)
+	}
+	if len(explanation.Summary) != 6 || explanation.Summary[0].Predicate != PredicateNodeNotReady {
+		t.Errorf("ExplainPodScheduling() returns summary %v", explanation.Summary)
+	}
+}
+
+func TestExplainPodSchedulingTolerations(t *testing.T) {
+	pod := newPod("prod", "api-0", "", "100m", 0)
+	pod.Spec.Tolerations = []v1.Toleration{
+		{Key: taintNodeUnschedulable, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+		{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "gpu"},
+	}
+	node := newNode("node-a", true, "1", nil,
+		v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule})
+	node.Spec.Unschedulable = true
+
+	explanation, err := explain(pod, []v1.Node{*node}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if explanation.FittingNodes != 1 || !explanation.Nodes[0].Fits || len(explanation.Summary) != 0 {
+		t.Errorf("explain() returns %#v, expected node to fit", explanation)
+	}
+}
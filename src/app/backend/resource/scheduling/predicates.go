@@ -0,0 +1,115 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scheduling evaluates scheduling predicates of pods against nodes, so that it can be
+// explained why a pod does not run on a node.
+package scheduling
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// MismatchedNodeSelector returns requirements of the node selector, which the node labels do not
+// satisfy, as key=value pairs.
+func MismatchedNodeSelector(nodeSelector, nodeLabels map[string]string) []string {
+	mismatched := make([]string, 0)
+	for key, value := range nodeSelector {
+		if nodeLabels[key] != value {
+			mismatched = append(mismatched, key+"="+value)
+		}
+	}
+	sort.Strings(mismatched)
+	return mismatched
+}
+
+// MatchesNodeAffinity returns true if the node labels match at least one of required node selector
+// terms. Terms, which cannot be parsed, never match.
+func MatchesNodeAffinity(affinity *v1.Affinity, nodeLabels map[string]string) bool {
+	if affinity == nil || affinity.NodeAffinity == nil ||
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	for _, term := range terms {
+		selector, err := nodeSelectorTermAsSelector(term)
+		if err == nil && selector.Matches(labels.Set(nodeLabels)) {
+			return true
+		}
+	}
+	return false
+}
+
+func nodeSelectorTermAsSelector(term v1.NodeSelectorTerm) (labels.Selector, error) {
+	if len(term.MatchExpressions) == 0 {
+		return labels.Nothing(), nil
+	}
+
+	selector := labels.NewSelector()
+	for _, expression := range term.MatchExpressions {
+		var op selection.Operator
+		switch expression.Operator {
+		case v1.NodeSelectorOpIn:
+			op = selection.In
+		case v1.NodeSelectorOpNotIn:
+			op = selection.NotIn
+		case v1.NodeSelectorOpExists:
+			op = selection.Exists
+		case v1.NodeSelectorOpDoesNotExist:
+			op = selection.DoesNotExist
+		case v1.NodeSelectorOpGt:
+			op = selection.GreaterThan
+		case v1.NodeSelectorOpLt:
+			op = selection.LessThan
+		default:
+			return nil, fmt.Errorf("unknown node selector operator %q", expression.Operator)
+		}
+
+		requirement, err := labels.NewRequirement(expression.Key, op, expression.Values)
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*requirement)
+	}
+	return selector, nil
+}
+
+// UntoleratedTaints returns NoSchedule and NoExecute taints, which are not tolerated by the
+// tolerations.
+func UntoleratedTaints(tolerations []v1.Toleration, taints []v1.Taint) []string {
+	result := make([]string, 0)
+	for i := range taints {
+		taint := &taints[i]
+		if taint.Effect == v1.TaintEffectPreferNoSchedule {
+			continue
+		}
+
+		tolerated := false
+		for j := range tolerations {
+			if tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			result = append(result, taint.ToString())
+		}
+	}
+	return result
+}
@@ -15,33 +15,52 @@
 package secret
 
 import (
-	"log"
+	"fmt"
+	"sort"
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/accessreview"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
 )
 
 // SecretDetail API resource provides mechanisms to inject containers with configuration data while keeping
-// containers agnostic of Kubernetes
+// containers agnostic of Kubernetes. Values of the secret are masked, they are returned only by
+// RevealSecretData.
 type SecretDetail struct {
 	ObjectMeta api.ObjectMeta `json:"objectMeta"`
 	TypeMeta   api.TypeMeta   `json:"typeMeta"`
 
+	// Keys of the secret data with sizes of their values.
+	Keys []SecretDataKey `json:"keys"`
+
+	// Used to facilitate programmatic handling of secret data.
+	Type v1.SecretType `json:"type"`
+}
+
+// SecretDataKey is a key of the secret data.
+type SecretDataKey struct {
+	Name string `json:"name"`
+
+	// Size of the value in bytes.
+	Size int `json:"size"`
+}
+
+// SecretData contains values of the secret.
+type SecretData struct {
 	// Data contains the secret data.  Each key must be a valid DNS_SUBDOMAIN
 	// or leading dot followed by valid DNS_SUBDOMAIN.
 	// The serialized form of the secret data is a base64 encoded string,
 	// representing the arbitrary (possibly non-string) data value here.
 	Data map[string][]byte `json:"data"`
-
-	// Used to facilitate programmatic handling of secret data.
-	Type v1.SecretType `json:"type"`
 }
 
 // GetSecretDetail returns returns detailed information about a secret
 func GetSecretDetail(client *client.Clientset, namespace, name string) (*SecretDetail, error) {
-	log.Printf("Getting details of %s secret in %s namespace\n", name, namespace)
+	logger.Infof("Getting details of %s secret in %s namespace\n", name, namespace)
 
 	rawSecret, err := client.Secrets(namespace).Get(name, metaV1.GetOptions{})
 	if err != nil {
@@ -51,11 +70,52 @@ func GetSecretDetail(client *client.Clientset, namespace, name string) (*SecretD
 	return getSecretDetail(rawSecret), nil
 }
 
+// RevealSecretData returns values of the secret. The user has to be allowed to get the secret,
+// which is checked with access review before the secret is read, so that values are not returned
+// to users who can see the secret only through resources cached by Dashboard.
+func RevealSecretData(client client.Interface, namespace, name string) (*SecretData, error) {
+	if err := checkSecretAccess(client, "get", namespace, name); err != nil {
+		return nil, err
+	}
+
+	rawSecret, err := client.CoreV1().Secrets(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SecretData{Data: rawSecret.Data}, nil
+}
+
+// checkSecretAccess returns forbidden error unless access review allows the user to perform the
+// verb on secrets in the namespace. Empty name means any secret.
+func checkSecretAccess(client client.Interface, verb, namespace, name string) error {
+	review := accessreview.ReviewAction(client, accessreview.ResourceAction{
+		Verb:      verb,
+		Resource:  "secrets",
+		Namespace: namespace,
+		Name:      name,
+	})
+	if !review.Allowed {
+		reason := review.Reason
+		if review.Error != "" {
+			reason = review.Error
+		}
+		return errorsK8s.NewForbidden(v1.Resource("secrets"), name, fmt.Errorf("access denied: %s", reason))
+	}
+	return nil
+}
+
 func getSecretDetail(rawSecret *v1.Secret) *SecretDetail {
+	keys := make([]SecretDataKey, 0, len(rawSecret.Data))
+	for key, value := range rawSecret.Data {
+		keys = append(keys, SecretDataKey{Name: key, Size: len(value)})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+
 	return &SecretDetail{
 		ObjectMeta: api.NewObjectMeta(rawSecret.ObjectMeta),
 		TypeMeta:   api.NewTypeMeta(api.ResourceKindSecret),
-		Data:       rawSecret.Data,
+		Keys:       keys,
 		Type:       rawSecret.Type,
 	}
 }
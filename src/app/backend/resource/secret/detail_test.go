@@ -19,8 +19,13 @@ import (
 	"testing"
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/pkg/api/v1"
+	authorization "k8s.io/client-go/pkg/apis/authorization/v1"
+	core "k8s.io/client-go/testing"
 )
 
 func TestGetSecretDetail(t *testing.T) {
@@ -30,7 +35,7 @@ func TestGetSecretDetail(t *testing.T) {
 	}{
 		{
 			&v1.Secret{
-				Data: map[string][]byte{"app": {0, 1, 2, 3}},
+				Data: map[string][]byte{"app": {0, 1, 2, 3}, "TOKEN": {4}},
 				ObjectMeta: metaV1.ObjectMeta{
 					Name: "foo",
 				},
@@ -42,7 +47,7 @@ func TestGetSecretDetail(t *testing.T) {
 				ObjectMeta: api.ObjectMeta{
 					Name: "foo",
 				},
-				Data: map[string][]byte{"app": {0, 1, 2, 3}},
+				Keys: []SecretDataKey{{Name: "TOKEN", Size: 1}, {Name: "app", Size: 4}},
 			},
 		},
 	}
@@ -53,3 +58,29 @@ func TestGetSecretDetail(t *testing.T) {
 		}
 	}
 }
+
+func TestRevealSecretData(t *testing.T) {
+	newClient := func(allowed bool) *fake.Clientset {
+		client := fake.NewSimpleClientset(&v1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{Name: "foo", Namespace: "default"},
+			Data:       map[string][]byte{"app": {0, 1, 2, 3}},
+		})
+		client.PrependReactor("create", "selfsubjectaccessreviews",
+			func(action core.Action) (bool, runtime.Object, error) {
+				review := action.(core.CreateAction).GetObject().(*authorization.SelfSubjectAccessReview)
+				review.Status.Allowed = allowed
+				return true, review, nil
+			})
+		return client
+	}
+
+	actual, err := RevealSecretData(newClient(true), "default", "foo")
+	expected := &SecretData{Data: map[string][]byte{"app": {0, 1, 2, 3}}}
+	if err != nil || !reflect.DeepEqual(actual, expected) {
+		t.Errorf("RevealSecretData() == %#v, %v, expected %#v", actual, err, expected)
+	}
+
+	if _, err := RevealSecretData(newClient(false), "default", "foo"); !errors.IsForbidden(err) {
+		t.Errorf("RevealSecretData(): expected forbidden error, but got %v", err)
+	}
+}
@@ -15,15 +15,12 @@
 package secret
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/fields"
-	"k8s.io/apimachinery/pkg/labels"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
 )
@@ -84,19 +81,21 @@ type SecretList struct {
 
 // GetSecretList returns all secrets in the given namespace.
 func GetSecretList(client *client.Clientset, namespace *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*SecretList, error) {
-	log.Printf("Getting list of secrets in %s namespace\n", namespace)
+	logger.Infof("Getting list of secrets in %s namespace\n", namespace)
 
-	secretList, err := client.Secrets(namespace.ToRequestParam()).List(metaV1.ListOptions{
-		LabelSelector: labels.Everything().String(),
-		FieldSelector: fields.Everything().String(),
-	})
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
+	channel := common.GetSecretListChunkChannel(client, namespace, chunk, 1)
+	secretList := <-channel.List
+	err := <-channel.Error
 
 	nonCriticalErrors, criticalError := errors.HandleError(err)
 	if criticalError != nil {
 		return nil, criticalError
 	}
 
-	return toSecretList(secretList.Items, nonCriticalErrors, dsQuery), nil
+	result := toSecretList(secretList.Items, nonCriticalErrors, dsQuery)
+	chunk.SetListMeta(&result.ListMeta)
+	return result, nil
 }
 
 // GetSecretListFromChannels returns a list of all Secrets in the cluster reading required resource list once from the channels.
@@ -0,0 +1,328 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// dockerHubServer is the server name Docker uses in configs for Docker Hub. Its registry API is
+// served by dockerHubRegistry.
+const (
+	dockerHubServer   = "https://index.docker.io/v1/"
+	dockerHubRegistry = "https://registry-1.docker.io"
+)
+
+// registryTestClient is used to test registry credentials. Redirects are not followed, so that
+// registries can not make Dashboard request other servers. Replaced in tests.
+var registryTestClient = &http.Client{
+	Timeout: 15 * time.Second,
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// RegistryCredentialSpec is a specification of a secret of docker-registry type, the same as
+// created by kubectl create secret docker-registry. Implements SecretSpec.
+type RegistryCredentialSpec struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// Server is the registry address, e.g. quay.io or https://registry.example.com:5000. Defaults
+	// to Docker Hub.
+	Server   string `json:"server"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email"`
+
+	// SkipTest saves the credentials without testing them against the registry first, e.g. when
+	// the registry is not reachable from Dashboard.
+	SkipTest bool `json:"skipTest"`
+
+	// ServiceAccounts in the namespace, which the secret is attached to after it is created.
+	ServiceAccounts []string `json:"serviceAccounts"`
+}
+
+// dockerConfigJSON is the content of .dockerconfigjson key of docker-registry secrets.
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email,omitempty"`
+	Auth     string `json:"auth"`
+}
+
+// GetName returns the name of the secret.
+func (spec *RegistryCredentialSpec) GetName() string {
+	return spec.Name
+}
+
+// GetType returns the type of the secret, which is always api.SecretTypeDockerConfigJson.
+func (spec *RegistryCredentialSpec) GetType() v1.SecretType {
+	return v1.SecretTypeDockerConfigJson
+}
+
+// GetNamespace returns the namespace of the secret.
+func (spec *RegistryCredentialSpec) GetNamespace() string {
+	return spec.Namespace
+}
+
+// GetData returns the docker config with credentials of the registry.
+func (spec *RegistryCredentialSpec) GetData() map[string][]byte {
+	config := dockerConfigJSON{Auths: map[string]dockerConfigEntry{
+		spec.getServer(): {
+			Username: spec.Username,
+			Password: spec.Password,
+			Email:    spec.Email,
+			Auth:     base64.StdEncoding.EncodeToString([]byte(spec.Username + ":" + spec.Password)),
+		},
+	}}
+	// Marshalling of the struct with string fields cannot fail.
+	data, _ := json.Marshal(config)
+	return map[string][]byte{v1.DockerConfigJsonKey: data}
+}
+
+func (spec *RegistryCredentialSpec) getServer() string {
+	if len(spec.Server) == 0 {
+		return dockerHubServer
+	}
+	return spec.Server
+}
+
+// RegistryCredentialTest is the result of logging in to the registry with the credentials.
+type RegistryCredentialTest struct {
+	// Registry is the address of the registry API the credentials were tested against.
+	Registry string `json:"registry"`
+	Valid    bool   `json:"valid"`
+	// Message explains why the credentials are not valid.
+	Message string `json:"message,omitempty"`
+}
+
+// RegistryCredentialResult is the outcome of creating a docker-registry secret.
+type RegistryCredentialResult struct {
+	Secret Secret `json:"secret"`
+	// Test is the result of testing the credentials. Nil if the test was skipped.
+	Test *RegistryCredentialTest `json:"test,omitempty"`
+	// ServiceAccounts the secret was attached to.
+	ServiceAccounts []string `json:"serviceAccounts"`
+}
+
+// ImagePullSecretTarget is a service account or a workload an image pull secret is attached to.
+type ImagePullSecretTarget struct {
+	Kind api.ResourceKind `json:"kind"`
+	Name string           `json:"name"`
+}
+
+// CreateRegistryCredentials tests the credentials against the registry, creates a docker-registry
+// secret and attaches it to service accounts of the spec. Invalid credentials are not saved unless
+// the test is skipped.
+func CreateRegistryCredentials(client client.Interface, spec *RegistryCredentialSpec) (
+	*RegistryCredentialResult, error) {
+	if len(spec.Name) == 0 || len(spec.Namespace) == 0 || len(spec.Username) == 0 {
+		return nil, errorsK8s.NewBadRequest("name, namespace and username are required")
+	}
+
+	result := &RegistryCredentialResult{ServiceAccounts: make([]string, 0)}
+	if !spec.SkipTest {
+		test, err := TestRegistryCredentials(client, spec)
+		if err != nil {
+			return nil, err
+		}
+		result.Test = test
+		if !result.Test.Valid {
+			return nil, errorsK8s.NewBadRequest(fmt.Sprintf("credentials were rejected by %s: %s",
+				result.Test.Registry, result.Test.Message))
+		}
+	}
+
+	logger.Infof("Creating registry credentials %s for %s in %s namespace", spec.Name, spec.getServer(),
+		spec.Namespace)
+	secret, err := client.CoreV1().Secrets(spec.Namespace).Create(&v1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: spec.Name, Namespace: spec.Namespace},
+		Type:       spec.GetType(),
+		Data:       spec.GetData(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Secret = *toSecret(secret)
+
+	for _, serviceAccount := range spec.ServiceAccounts {
+		target := ImagePullSecretTarget{Kind: api.ResourceKindServiceAccount, Name: serviceAccount}
+		if err := AttachImagePullSecret(client, spec.Namespace, spec.Name, target); err != nil {
+			return result, err
+		}
+		result.ServiceAccounts = append(result.ServiceAccounts, serviceAccount)
+	}
+	return result, nil
+}
+
+// AttachImagePullSecret adds the secret to image pull secrets of a service account or of the pod
+// template of a workload in the same namespace. Attaching already attached secret does nothing.
+func AttachImagePullSecret(client client.Interface, namespace, secretName string,
+	target ImagePullSecretTarget) error {
+	if _, err := client.CoreV1().Secrets(namespace).Get(secretName, metaV1.GetOptions{}); err != nil {
+		return err
+	}
+	logger.Infof("Attaching image pull secret %s to %s %s in %s namespace", secretName, target.Kind,
+		target.Name, namespace)
+
+	if target.Kind == api.ResourceKindServiceAccount {
+		serviceAccount, err := client.CoreV1().ServiceAccounts(namespace).Get(target.Name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if hasImagePullSecret(serviceAccount.ImagePullSecrets, secretName) {
+			return nil
+		}
+		serviceAccount.ImagePullSecrets = append(serviceAccount.ImagePullSecrets,
+			v1.LocalObjectReference{Name: secretName})
+		_, err = client.CoreV1().ServiceAccounts(namespace).Update(serviceAccount)
+		return err
+	}
+
+	return common.UpdatePodTemplate(client, target.Kind, namespace, target.Name,
+		func(template *v1.PodTemplateSpec) error {
+			if !hasImagePullSecret(template.Spec.ImagePullSecrets, secretName) {
+				template.Spec.ImagePullSecrets = append(template.Spec.ImagePullSecrets,
+					v1.LocalObjectReference{Name: secretName})
+			}
+			return nil
+		})
+}
+
+func hasImagePullSecret(references []v1.LocalObjectReference, name string) bool {
+	for _, reference := range references {
+		if reference.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// bearerChallengePattern matches parameters of WWW-Authenticate header of token authentication.
+var bearerChallengePattern = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// TestRegistryCredentials logs in to the registry with the credentials in the same way as docker
+// login does, using either basic or token authentication of the registry API. Requests are sent by
+// Dashboard, so only users allowed to create secrets in the namespace of the spec can test
+// credentials.
+func TestRegistryCredentials(client client.Interface, spec *RegistryCredentialSpec) (
+	*RegistryCredentialTest, error) {
+	if err := checkSecretAccess(client, "create", spec.Namespace, ""); err != nil {
+		return nil, err
+	}
+	return testRegistryCredentials(spec), nil
+}
+
+func testRegistryCredentials(spec *RegistryCredentialSpec) *RegistryCredentialTest {
+	registry := getRegistryURL(spec.getServer())
+	result := &RegistryCredentialTest{Registry: registry}
+
+	response, err := registryTestClient.Get(registry + "/v2/")
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+	response.Body.Close()
+	switch response.StatusCode {
+	case http.StatusOK:
+		// Registry does not require authentication.
+		result.Valid = true
+		return result
+	case http.StatusUnauthorized:
+	default:
+		result.Message = fmt.Sprintf("registry API responded with %s", response.Status)
+		return result
+	}
+
+	challenge := response.Header.Get("WWW-Authenticate")
+	loginURL := registry + "/v2/"
+	if strings.HasPrefix(strings.ToLower(challenge), "bearer") {
+		parameters := make(map[string]string)
+		for _, match := range bearerChallengePattern.FindAllStringSubmatch(challenge, -1) {
+			parameters[strings.ToLower(match[1])] = match[2]
+		}
+		if len(parameters["realm"]) == 0 {
+			result.Message = "registry requested token authentication without realm"
+			return result
+		}
+		realm, err := url.Parse(parameters["realm"])
+		if err != nil || (realm.Scheme != "http" && realm.Scheme != "https") {
+			result.Message = fmt.Sprintf("registry requested token authentication with invalid realm %q",
+				parameters["realm"])
+			return result
+		}
+		// Realm can have its own query, service is added to it.
+		query := realm.Query()
+		if service := parameters["service"]; len(service) > 0 {
+			query.Set("service", service)
+		}
+		realm.RawQuery = query.Encode()
+		loginURL = realm.String()
+	}
+
+	request, err := http.NewRequest(http.MethodGet, loginURL, nil)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+	request.SetBasicAuth(spec.Username, spec.Password)
+	response, err = registryTestClient.Do(request)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+	response.Body.Close()
+
+	result.Valid = response.StatusCode == http.StatusOK
+	if !result.Valid {
+		result.Message = fmt.Sprintf("login failed with %s", response.Status)
+	}
+	return result
+}
+
+// getRegistryURL returns base URL of registry API of the server. HTTPS is used if the server has no
+// scheme.
+func getRegistryURL(server string) string {
+	if server == dockerHubServer || server == "docker.io" || server == "index.docker.io" {
+		return dockerHubRegistry
+	}
+	if !strings.Contains(server, "://") {
+		server = "https://" + server
+	}
+	if parsed, err := url.Parse(server); err == nil {
+		return parsed.Scheme + "://" + parsed.Host
+	}
+	return strings.TrimSuffix(server, "/")
+}
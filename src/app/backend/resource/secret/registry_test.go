@@ -0,0 +1,238 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	authorization "k8s.io/client-go/pkg/apis/authorization/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	core "k8s.io/client-go/testing"
+)
+
+// reviewAccess makes access reviews of the client return allowed.
+func reviewAccess(client *fake.Clientset, allowed bool) *fake.Clientset {
+	client.PrependReactor("create", "selfsubjectaccessreviews",
+		func(action core.Action) (bool, runtime.Object, error) {
+			review := action.(core.CreateAction).GetObject().(*authorization.SelfSubjectAccessReview)
+			review.Status.Allowed = allowed
+			return true, review, nil
+		})
+	return client
+}
+
+// newTestRegistry returns registry accepting user:pass. Token authentication is used if token is
+// true and basic authentication otherwise.
+func newTestRegistry(token bool) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		authorized := ok && user == "user" && password == "pass"
+		switch {
+		case r.URL.Path == "/v2/" && token:
+			w.Header().Set("WWW-Authenticate",
+				`Bearer realm="`+server.URL+`/token",service="registry.test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/" && !authorized:
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/token" && (!authorized || r.URL.Query().Get("service") != "registry.test"):
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	return server
+}
+
+func TestTestRegistryCredentials(t *testing.T) {
+	client := reviewAccess(fake.NewSimpleClientset(), true)
+	for _, token := range []bool{true, false} {
+		server := newTestRegistry(token)
+
+		valid, err := TestRegistryCredentials(client, &RegistryCredentialSpec{Namespace: "ns",
+			Server: server.URL, Username: "user", Password: "pass"})
+		if err != nil || !valid.Valid || valid.Registry != server.URL {
+			t.Errorf("Expected valid credentials with token authentication %t, got %#v, %v", token, valid, err)
+		}
+
+		invalid, err := TestRegistryCredentials(client, &RegistryCredentialSpec{Namespace: "ns",
+			Server: server.URL, Username: "user", Password: "wrong"})
+		if err != nil || invalid.Valid || len(invalid.Message) == 0 {
+			t.Errorf("Expected invalid credentials with token authentication %t, got %#v, %v", token,
+				invalid, err)
+		}
+		server.Close()
+	}
+}
+
+func TestTestRegistryCredentialsRealmWithQuery(t *testing.T) {
+	var query string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/" {
+			w.Header().Set("WWW-Authenticate",
+				`Bearer realm="`+server.URL+`/token?account=user&scope=registry",service="registry.test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		query = r.URL.RawQuery
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	result := testRegistryCredentials(&RegistryCredentialSpec{Server: server.URL, Username: "user",
+		Password: "pass"})
+	if !result.Valid || query != "account=user&scope=registry&service=registry.test" {
+		t.Errorf("Expected service to be added to the realm query, got %#v with query %q", result, query)
+	}
+}
+
+func TestTestRegistryCredentialsIsRestricted(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		w.Header().Set("WWW-Authenticate", `Bearer realm="file:///etc/passwd"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		http.Redirect(w, r, "/internal", http.StatusFound)
+	}))
+	defer redirecting.Close()
+	spec := &RegistryCredentialSpec{Namespace: "ns", Server: server.URL, Username: "user", Password: "pass"}
+
+	forbidden := reviewAccess(fake.NewSimpleClientset(), false)
+	if _, err := TestRegistryCredentials(forbidden, spec); !errorsK8s.IsForbidden(err) {
+		t.Errorf("Expected forbidden error for user not allowed to create secrets, got %v", err)
+	}
+	if len(requested) > 0 {
+		t.Errorf("Expected no requests for user not allowed to create secrets, got %v", requested)
+	}
+
+	client := reviewAccess(fake.NewSimpleClientset(), true)
+	if result, err := TestRegistryCredentials(client, spec); err != nil || result.Valid {
+		t.Errorf("Expected invalid credentials for realm with file scheme, got %#v, %v", result, err)
+	}
+
+	requested = nil
+	result := testRegistryCredentials(&RegistryCredentialSpec{Server: redirecting.URL, Username: "user",
+		Password: "pass"})
+	if result.Valid || !reflect.DeepEqual(requested, []string{"/v2/"}) {
+		t.Errorf("Expected redirect not to be followed, got %#v after requests %v", result, requested)
+	}
+}
+
+func TestGetRegistryURL(t *testing.T) {
+	cases := []struct {
+		server, expected string
+	}{
+		{"", dockerHubRegistry},
+		{dockerHubServer, dockerHubRegistry},
+		{"docker.io", dockerHubRegistry},
+		{"quay.io", "https://quay.io"},
+		{"http://registry.local:5000/v2/", "http://registry.local:5000"},
+	}
+	for _, c := range cases {
+		spec := &RegistryCredentialSpec{Server: c.server}
+		if actual := getRegistryURL(spec.getServer()); actual != c.expected {
+			t.Errorf("getRegistryURL(%q) == %q, expected %q", c.server, actual, c.expected)
+		}
+	}
+}
+
+func TestCreateRegistryCredentials(t *testing.T) {
+	server := newTestRegistry(false)
+	defer server.Close()
+	client := reviewAccess(fake.NewSimpleClientset(&v1.ServiceAccount{
+		ObjectMeta: metaV1.ObjectMeta{Name: "default", Namespace: "ns"},
+	}), true)
+
+	spec := &RegistryCredentialSpec{Name: "registry", Namespace: "ns", Server: server.URL,
+		Username: "user", Password: "pass", ServiceAccounts: []string{"default"}}
+	result, err := CreateRegistryCredentials(client, spec)
+	if err != nil {
+		t.Fatalf("CreateRegistryCredentials returned error: %s", err)
+	}
+	if !result.Test.Valid || !reflect.DeepEqual(result.ServiceAccounts, []string{"default"}) {
+		t.Errorf("Unexpected result %#v", result)
+	}
+
+	secret, _ := client.CoreV1().Secrets("ns").Get("registry", metaV1.GetOptions{})
+	if secret.Type != v1.SecretTypeDockerConfigJson {
+		t.Errorf("Expected secret of %s type, got %s", v1.SecretTypeDockerConfigJson, secret.Type)
+	}
+	config := dockerConfigJSON{}
+	if err := json.Unmarshal(secret.Data[v1.DockerConfigJsonKey], &config); err != nil {
+		t.Fatalf("Could not parse docker config: %s", err)
+	}
+	expected := dockerConfigEntry{Username: "user", Password: "pass", Auth: "dXNlcjpwYXNz"}
+	if !reflect.DeepEqual(config.Auths[server.URL], expected) {
+		t.Errorf("Expected docker config entry %#v, got %#v", expected, config.Auths)
+	}
+
+	serviceAccount, _ := client.CoreV1().ServiceAccounts("ns").Get("default", metaV1.GetOptions{})
+	if !hasImagePullSecret(serviceAccount.ImagePullSecrets, "registry") {
+		t.Errorf("Expected secret to be attached to service account, got %#v", serviceAccount.ImagePullSecrets)
+	}
+
+	spec.Name, spec.Password = "invalid", "wrong"
+	if _, err := CreateRegistryCredentials(client, spec); !errorsK8s.IsBadRequest(err) {
+		t.Errorf("Expected bad request for invalid credentials, got %v", err)
+	}
+	if _, err := client.CoreV1().Secrets("ns").Get("invalid", metaV1.GetOptions{}); !errorsK8s.IsNotFound(err) {
+		t.Errorf("Expected invalid credentials not to be saved, got %v", err)
+	}
+
+	spec.SkipTest = true
+	if result, err := CreateRegistryCredentials(client, spec); err != nil || result.Test != nil {
+		t.Errorf("Expected untested credentials to be saved, got %#v, %v", result, err)
+	}
+}
+
+func TestAttachImagePullSecret(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Secret{ObjectMeta: metaV1.ObjectMeta{Name: "registry", Namespace: "ns"}},
+		&extensions.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "ns"}},
+	)
+	target := ImagePullSecretTarget{Kind: api.ResourceKindDeployment, Name: "app"}
+
+	for i := 0; i < 2; i++ {
+		if err := AttachImagePullSecret(client, "ns", "registry", target); err != nil {
+			t.Fatalf("AttachImagePullSecret returned error: %s", err)
+		}
+	}
+	deployment, _ := client.ExtensionsV1beta1().Deployments("ns").Get("app", metaV1.GetOptions{})
+	expected := []v1.LocalObjectReference{{Name: "registry"}}
+	if !reflect.DeepEqual(deployment.Spec.Template.Spec.ImagePullSecrets, expected) {
+		t.Errorf("Expected image pull secrets %#v, got %#v", expected,
+			deployment.Spec.Template.Spec.ImagePullSecrets)
+	}
+
+	if err := AttachImagePullSecret(client, "ns", "missing", target); !errorsK8s.IsNotFound(err) {
+		t.Errorf("Expected not found for missing secret, got %v", err)
+	}
+}
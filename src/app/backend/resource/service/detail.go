@@ -15,15 +15,16 @@
 package service
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gitops"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/pod"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/servicemesh"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -60,6 +61,12 @@ type ServiceDetail struct {
 	// PodList represents list of pods targeted by same label selector as this service.
 	PodList pod.PodList `json:"podList"`
 
+	// Service mesh status of the service. Set only when a service mesh is installed in the cluster.
+	Mesh *servicemesh.ServiceMesh `json:"mesh,omitempty"`
+
+	// GitOps tool managing the service. Set only when it is synced by Argo CD or Flux.
+	GitOps *gitops.Ownership `json:"gitops,omitempty"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -68,7 +75,7 @@ type ServiceDetail struct {
 func GetServiceDetail(client k8sClient.Interface, metricClient metricapi.MetricClient, namespace, name string,
 	dsQuery *dataselect.DataSelectQuery) (*ServiceDetail, error) {
 
-	log.Printf("Getting details of %s service in %s namespace", name, namespace)
+	logger.Infof("Getting details of %s service in %s namespace", name, namespace)
 	serviceData, err := client.CoreV1().Services(namespace).Get(name, metaV1.GetOptions{})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,397 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+// EndpointSliceGroupVersion is the group version of endpoint slice API.
+var EndpointSliceGroupVersion = schema.GroupVersion{Group: "discovery.k8s.io", Version: "v1"}
+
+const (
+	endpointSliceResource = "endpointslices"
+	serviceNameLabel      = "kubernetes.io/service-name"
+)
+
+// Sources of service endpoints.
+const (
+	EndpointSourceEndpointSlice = "EndpointSlice"
+	EndpointSourceEndpoints     = "Endpoints"
+)
+
+// ServiceEndpoint is a single network endpoint of a service, usually a pod.
+type ServiceEndpoint struct {
+	Addresses []string `json:"addresses"`
+
+	// Ready means that the endpoint receives traffic.
+	Ready bool `json:"ready"`
+	// Serving means that the endpoint passes its readiness probe, even when it is terminating.
+	Serving     bool `json:"serving"`
+	Terminating bool `json:"terminating"`
+
+	Hostname string `json:"hostname"`
+	NodeName string `json:"nodeName"`
+	Zone     string `json:"zone"`
+
+	// Name of the pod backing the endpoint. Empty if the endpoint is not a pod in the namespace of
+	// the service.
+	Pod string `json:"pod"`
+}
+
+// EndpointSlicePort is a port exposed by endpoints of a slice.
+type EndpointSlicePort struct {
+	Name     string      `json:"name"`
+	Port     int32       `json:"port"`
+	Protocol v1.Protocol `json:"protocol"`
+}
+
+// EndpointSlice is a group of endpoints of a service.
+type EndpointSlice struct {
+	Name        string              `json:"name"`
+	AddressType string              `json:"addressType"`
+	Ports       []EndpointSlicePort `json:"ports"`
+	Endpoints   []ServiceEndpoint   `json:"endpoints"`
+}
+
+// ServiceEndpointSliceList contains endpoint slices of a service together with hints explaining
+// missing endpoints.
+type ServiceEndpointSliceList struct {
+	// Source tells whether endpoints were read from endpoint slices or from the older endpoints API.
+	Source string `json:"source"`
+
+	Slices []EndpointSlice `json:"slices"`
+
+	ReadyEndpoints    int `json:"readyEndpoints"`
+	NotReadyEndpoints int `json:"notReadyEndpoints"`
+
+	// Hints explaining why the service has no ready endpoints.
+	Hints []string `json:"hints"`
+}
+
+// endpointSlice is the API representation of endpoint slice. Client library does not contain
+// endpoint slice types, so only the fields used by Dashboard are declared.
+type endpointSlice struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	AddressType       string                  `json:"addressType"`
+	Endpoints         []endpointSliceEndpoint `json:"endpoints"`
+	Ports             []endpointSlicePort     `json:"ports"`
+}
+
+type endpointSliceEndpoint struct {
+	Addresses  []string `json:"addresses"`
+	Conditions struct {
+		Ready       *bool `json:"ready"`
+		Serving     *bool `json:"serving"`
+		Terminating *bool `json:"terminating"`
+	} `json:"conditions"`
+	Hostname  *string             `json:"hostname"`
+	NodeName  *string             `json:"nodeName"`
+	Zone      *string             `json:"zone"`
+	TargetRef *v1.ObjectReference `json:"targetRef"`
+}
+
+type endpointSlicePort struct {
+	Name     *string      `json:"name"`
+	Port     *int32       `json:"port"`
+	Protocol *v1.Protocol `json:"protocol"`
+}
+
+type endpointSliceList struct {
+	Items []endpointSlice `json:"items"`
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Get() *rest.Request
+}
+
+// listEndpointSlices returns endpoint slices of the service.
+func listEndpointSlices(restClient RESTClient, namespace, name string) ([]endpointSlice, error) {
+	raw, err := restClient.Get().
+		Namespace(namespace).
+		Resource(endpointSliceResource).
+		Param("labelSelector", labels.SelectorFromSet(map[string]string{serviceNameLabel: name}).String()).
+		Do().
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	slices := endpointSliceList{}
+	if err := json.Unmarshal(raw, &slices); err != nil {
+		return nil, err
+	}
+	return slices.Items, nil
+}
+
+// GetServiceEndpointSlices returns endpoints of the service. Endpoints are read from the older
+// endpoints API, if endpoint slices are not served by the cluster.
+func GetServiceEndpointSlices(client k8sClient.Interface, config *rest.Config, namespace, name string) (
+	*ServiceEndpointSliceList, error) {
+	logger.Infof("Getting endpoint slices of %s service in %s namespace", name, namespace)
+
+	restClient, err := apply.NewRESTClient(config, EndpointSliceGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+	return getServiceEndpointSlices(client, restClient, namespace, name)
+}
+
+func getServiceEndpointSlices(client k8sClient.Interface, restClient RESTClient, namespace,
+	name string) (*ServiceEndpointSliceList, error) {
+	service, err := client.CoreV1().Services(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	source, slices, err := getEndpointSlices(client, restClient, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ServiceEndpointSliceList{Source: source, Slices: slices}
+
+	for _, slice := range result.Slices {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Ready {
+				result.ReadyEndpoints++
+			} else {
+				result.NotReadyEndpoints++
+			}
+		}
+	}
+
+	if result.ReadyEndpoints == 0 {
+		hints, err := getMissingEndpointHints(client, service, result)
+		if err != nil {
+			return nil, err
+		}
+		result.Hints = hints
+	} else {
+		result.Hints = make([]string, 0)
+	}
+
+	return result, nil
+}
+
+// getEndpointSlices returns endpoint slices of the service and their source. Rest client is the
+// client of endpoint slice API.
+func getEndpointSlices(client k8sClient.Interface, restClient RESTClient, namespace, name string) (
+	string, []EndpointSlice, error) {
+	rawSlices, err := listEndpointSlices(restClient, namespace, name)
+	if err == nil {
+		return EndpointSourceEndpointSlice, toEndpointSlices(rawSlices), nil
+	}
+	if !errorsK8s.IsNotFound(err) {
+		return "", nil, err
+	}
+
+	endpoints, err := client.CoreV1().Endpoints(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil && !errorsK8s.IsNotFound(err) {
+		return "", nil, err
+	}
+	if errorsK8s.IsNotFound(err) {
+		endpoints = nil
+	}
+	return EndpointSourceEndpoints, endpointsToSlices(endpoints), nil
+}
+
+func toEndpointSlices(raw []endpointSlice) []EndpointSlice {
+	result := make([]EndpointSlice, 0, len(raw))
+	for _, item := range raw {
+		slice := EndpointSlice{
+			Name:        item.Name,
+			AddressType: item.AddressType,
+			Ports:       make([]EndpointSlicePort, 0, len(item.Ports)),
+			Endpoints:   make([]ServiceEndpoint, 0, len(item.Endpoints)),
+		}
+
+		for _, port := range item.Ports {
+			slicePort := EndpointSlicePort{Protocol: v1.ProtocolTCP}
+			if port.Name != nil {
+				slicePort.Name = *port.Name
+			}
+			if port.Port != nil {
+				slicePort.Port = *port.Port
+			}
+			if port.Protocol != nil {
+				slicePort.Protocol = *port.Protocol
+			}
+			slice.Ports = append(slice.Ports, slicePort)
+		}
+
+		for _, endpoint := range item.Endpoints {
+			// Unknown ready condition is interpreted as ready, serving defaults to ready.
+			ready := endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+			serviceEndpoint := ServiceEndpoint{
+				Addresses:   endpoint.Addresses,
+				Ready:       ready,
+				Serving:     ready,
+				Terminating: endpoint.Conditions.Terminating != nil && *endpoint.Conditions.Terminating,
+				Hostname:    stringValue(endpoint.Hostname),
+				NodeName:    stringValue(endpoint.NodeName),
+				Zone:        stringValue(endpoint.Zone),
+			}
+			if endpoint.Conditions.Serving != nil {
+				serviceEndpoint.Serving = *endpoint.Conditions.Serving
+			}
+			serviceEndpoint.Pod = getEndpointPodName(endpoint.TargetRef, item.Namespace)
+			slice.Endpoints = append(slice.Endpoints, serviceEndpoint)
+		}
+
+		result = append(result, slice)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// endpointsToSlices converts subsets of endpoints to slices, one slice per subset.
+func endpointsToSlices(endpoints *v1.Endpoints) []EndpointSlice {
+	result := make([]EndpointSlice, 0)
+	if endpoints == nil {
+		return result
+	}
+
+	for i, subset := range endpoints.Subsets {
+		slice := EndpointSlice{
+			Name:        fmt.Sprintf("%s-%d", endpoints.Name, i),
+			AddressType: "IPv4",
+			Ports:       make([]EndpointSlicePort, 0, len(subset.Ports)),
+			Endpoints:   make([]ServiceEndpoint, 0, len(subset.Addresses)+len(subset.NotReadyAddresses)),
+		}
+		for _, port := range subset.Ports {
+			slice.Ports = append(slice.Ports, EndpointSlicePort{Name: port.Name, Port: port.Port,
+				Protocol: port.Protocol})
+		}
+		for _, address := range subset.Addresses {
+			slice.Endpoints = append(slice.Endpoints, toServiceEndpoint(address, endpoints.Namespace, true))
+		}
+		for _, address := range subset.NotReadyAddresses {
+			slice.Endpoints = append(slice.Endpoints, toServiceEndpoint(address, endpoints.Namespace, false))
+		}
+		result = append(result, slice)
+	}
+	return result
+}
+
+func toServiceEndpoint(address v1.EndpointAddress, namespace string, ready bool) ServiceEndpoint {
+	return ServiceEndpoint{
+		Addresses: []string{address.IP},
+		Ready:     ready,
+		Serving:   ready,
+		Hostname:  address.Hostname,
+		NodeName:  stringValue(address.NodeName),
+		Pod:       getEndpointPodName(address.TargetRef, namespace),
+	}
+}
+
+// getEndpointPodName returns name of the pod the endpoint references, if it is a pod in the given
+// namespace. References without namespace are in the namespace of the endpoint.
+func getEndpointPodName(targetRef *v1.ObjectReference, namespace string) string {
+	if targetRef == nil || targetRef.Kind != "Pod" {
+		return ""
+	}
+	if len(targetRef.Namespace) > 0 && targetRef.Namespace != namespace {
+		return ""
+	}
+	return targetRef.Name
+}
+
+// getMissingEndpointHints explains why the service has no ready endpoints.
+func getMissingEndpointHints(client k8sClient.Interface, service *v1.Service,
+	endpoints *ServiceEndpointSliceList) ([]string, error) {
+	hints := make([]string, 0)
+
+	if service.Spec.Type == v1.ServiceTypeExternalName {
+		return append(hints, fmt.Sprintf("Service of type ExternalName has no endpoints, it resolves to %s",
+			service.Spec.ExternalName)), nil
+	}
+
+	if len(service.Spec.Selector) == 0 {
+		return append(hints, "Service has no selector, its endpoints have to be created manually"), nil
+	}
+
+	selector := labels.SelectorFromSet(service.Spec.Selector)
+	pods, err := client.CoreV1().Pods(service.Namespace).List(metaV1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pods.Items) == 0 {
+		return append(hints, fmt.Sprintf("No pods in namespace %s match the service selector %s",
+			service.Namespace, selector.String())), nil
+	}
+
+	running := 0
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == v1.PodRunning {
+			running++
+		}
+	}
+	if running == 0 {
+		hints = append(hints, fmt.Sprintf("%d pods match the service selector, but none of them is running",
+			len(pods.Items)))
+	} else if endpoints.NotReadyEndpoints > 0 {
+		hints = append(hints, fmt.Sprintf("%d endpoints are not ready, check readiness probes of the pods",
+			endpoints.NotReadyEndpoints))
+	} else {
+		hints = append(hints, fmt.Sprintf("%d running pods match the service selector, but none of them is "+
+			"ready", running))
+	}
+
+	for _, port := range service.Spec.Ports {
+		if port.TargetPort.Type == intstr.String && !podsExposePort(pods.Items, port.TargetPort.StrVal) {
+			hints = append(hints, fmt.Sprintf("Target port %s of service port %d is not a named port of the pods",
+				port.TargetPort.StrVal, port.Port))
+		}
+	}
+
+	return hints, nil
+}
+
+func podsExposePort(pods []v1.Pod, name string) bool {
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			for _, port := range container.Ports {
+				if port.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
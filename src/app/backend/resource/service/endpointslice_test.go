@@ -0,0 +1,185 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+func newEndpointTestService(selector map[string]string, targetPort intstr.IntOrString) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1.ServiceSpec{
+			Selector:  selector,
+			ClusterIP: "10.0.0.10",
+			Ports:     []v1.ServicePort{{Name: "http", Port: 80, TargetPort: targetPort}},
+		},
+	}
+}
+
+// newEndpointSliceServer returns apiserver, which serves the endpoint slices. Endpoint slice API is
+// not served if slices are nil.
+func newEndpointSliceServer(slices []endpointSlice) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slices == nil || r.URL.Path != "/apis/discovery.k8s.io/v1/namespaces/default/endpointslices" ||
+			r.URL.Query().Get("labelSelector") != serviceNameLabel+"=web" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(endpointSliceList{Items: slices})
+	}))
+}
+
+// newEndpointSliceClient returns client of endpoint slice API served by the server.
+func newEndpointSliceClient(t *testing.T, server *httptest.Server) RESTClient {
+	restClient, err := apply.NewRESTClient(&rest.Config{Host: server.URL}, EndpointSliceGroupVersion)
+	if err != nil {
+		t.Fatalf("NewRESTClient(): unexpected error %v", err)
+	}
+	return restClient
+}
+
+func TestGetServiceEndpointSlices(t *testing.T) {
+	notReady, terminating := false, true
+	name, port, nodeName := "http", int32(8080), "node-1"
+	raw := endpointSlice{ObjectMeta: metaV1.ObjectMeta{Name: "web-abc"}, AddressType: "IPv4",
+		Ports: []endpointSlicePort{{Name: &name, Port: &port}}}
+	raw.Endpoints = make([]endpointSliceEndpoint, 2)
+	raw.Endpoints[0].Addresses = []string{"10.1.0.1"}
+	raw.Endpoints[0].NodeName = &nodeName
+	raw.Endpoints[0].TargetRef = &v1.ObjectReference{Kind: "Pod", Name: "web-1"}
+	raw.Endpoints[1].Addresses = []string{"10.1.0.2"}
+	raw.Endpoints[1].Conditions.Ready = &notReady
+	raw.Endpoints[1].Conditions.Terminating = &terminating
+	server := newEndpointSliceServer([]endpointSlice{raw})
+	defer server.Close()
+
+	client := fake.NewSimpleClientset(newEndpointTestService(map[string]string{"app": "web"},
+		intstr.FromInt(8080)))
+	actual, err := getServiceEndpointSlices(client, newEndpointSliceClient(t, server), "default", "web")
+	if err != nil {
+		t.Fatalf("GetServiceEndpointSlices(): unexpected error %v", err)
+	}
+
+	expected := &ServiceEndpointSliceList{
+		Source: EndpointSourceEndpointSlice,
+		Slices: []EndpointSlice{{
+			Name:        "web-abc",
+			AddressType: "IPv4",
+			Ports:       []EndpointSlicePort{{Name: "http", Port: 8080, Protocol: v1.ProtocolTCP}},
+			Endpoints: []ServiceEndpoint{
+				{Addresses: []string{"10.1.0.1"}, Ready: true, Serving: true, NodeName: "node-1", Pod: "web-1"},
+				{Addresses: []string{"10.1.0.2"}, Terminating: true},
+			},
+		}},
+		ReadyEndpoints:    1,
+		NotReadyEndpoints: 1,
+		Hints:             []string{},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetServiceEndpointSlices() == \ngot: %#v, \nexpected %#v", actual, expected)
+	}
+}
+
+func TestGetServiceEndpointSlicesFromEndpoints(t *testing.T) {
+	server := newEndpointSliceServer(nil)
+	defer server.Close()
+
+	endpoints := &v1.Endpoints{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Subsets: []v1.EndpointSubset{{
+			NotReadyAddresses: []v1.EndpointAddress{
+				{IP: "10.1.0.1", TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-1"}},
+			},
+			Ports: []v1.EndpointPort{{Name: "http", Port: 8080, Protocol: v1.ProtocolTCP}},
+		}},
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+	client := fake.NewSimpleClientset(newEndpointTestService(map[string]string{"app": "web"},
+		intstr.FromString("http")), endpoints, pod)
+
+	actual, err := getServiceEndpointSlices(client, newEndpointSliceClient(t, server), "default", "web")
+	if err != nil {
+		t.Fatalf("GetServiceEndpointSlices(): unexpected error %v", err)
+	}
+
+	expected := &ServiceEndpointSliceList{
+		Source: EndpointSourceEndpoints,
+		Slices: []EndpointSlice{{
+			Name:        "web-0",
+			AddressType: "IPv4",
+			Ports:       []EndpointSlicePort{{Name: "http", Port: 8080, Protocol: v1.ProtocolTCP}},
+			Endpoints:   []ServiceEndpoint{{Addresses: []string{"10.1.0.1"}, Pod: "web-1"}},
+		}},
+		NotReadyEndpoints: 1,
+		Hints: []string{
+			"1 endpoints are not ready, check readiness probes of the pods",
+			"Target port http of service port 80 is not a named port of the pods",
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetServiceEndpointSlices() == \ngot: %#v, \nexpected %#v", actual, expected)
+	}
+}
+
+func TestGetMissingEndpointHints(t *testing.T) {
+	cases := []struct {
+		info     string
+		service  *v1.Service
+		expected []string
+	}{
+		{
+			"no selector",
+			newEndpointTestService(nil, intstr.FromInt(80)),
+			[]string{"Service has no selector, its endpoints have to be created manually"},
+		},
+		{
+			"selector matches no pods",
+			newEndpointTestService(map[string]string{"app": "db"}, intstr.FromInt(80)),
+			[]string{"No pods in namespace default match the service selector app=db"},
+		},
+		{
+			"external name",
+			&v1.Service{Spec: v1.ServiceSpec{Type: v1.ServiceTypeExternalName, ExternalName: "example.com"}},
+			[]string{"Service of type ExternalName has no endpoints, it resolves to example.com"},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := getMissingEndpointHints(fake.NewSimpleClientset(), c.service, &ServiceEndpointSliceList{})
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", c.info, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: getMissingEndpointHints() == %v, expected %v", c.info, actual, c.expected)
+		}
+	}
+}
@@ -15,8 +15,7 @@
 package service
 
 import (
-	"log"
-
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
@@ -37,6 +36,6 @@ func GetServiceEvents(client client.Interface, dsQuery *dataselect.DataSelectQue
 	}
 
 	events := event.CreateEventList(serviceEvents, dsQuery)
-	log.Printf("Found %d events related to %s service in %s namespace", len(events.Events), name, namespace)
+	logger.Infof("Found %d events related to %s service in %s namespace", len(events.Events), name, namespace)
 	return &events, nil
 }
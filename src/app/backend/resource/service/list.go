@@ -15,10 +15,9 @@
 package service
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	client "k8s.io/client-go/kubernetes"
@@ -63,13 +62,20 @@ type ServiceList struct {
 // GetServiceList returns a list of all services in the cluster.
 func GetServiceList(client client.Interface, nsQuery *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery) (*ServiceList, error) {
-	log.Print("Getting list of all services in the cluster")
+	logger.Info("Getting list of all services in the cluster")
 
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
 	channels := &common.ResourceChannels{
-		ServiceList: common.GetServiceListChannel(client, nsQuery, 1),
+		ServiceList: common.GetServiceListChunkChannel(client, nsQuery, chunk, 1),
+	}
+
+	serviceList, err := GetServiceListFromChannels(channels, dsQuery)
+	if err != nil {
+		return nil, err
 	}
 
-	return GetServiceListFromChannels(channels, dsQuery)
+	chunk.SetListMeta(&serviceList.ListMeta)
+	return serviceList, nil
 }
 
 // GetServiceListFromChannels returns a list of all services in the cluster.
@@ -0,0 +1,90 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// PortForwardTarget is a pod and container port that backs a service port. Port forward sessions
+// opened for a service are connected to it, the same way as kubectl port-forward does.
+type PortForwardTarget struct {
+	// Name of the pod that traffic is forwarded to.
+	Pod string `json:"pod"`
+
+	// Port of the pod that traffic is forwarded to.
+	Port int32 `json:"port"`
+}
+
+// GetPortForwardTarget returns a ready pod that backs given port of the service together with
+// the port that the service port is mapped to in this pod.
+func GetPortForwardTarget(client k8sClient.Interface, namespace, name string, port int32) (
+	*PortForwardTarget, error) {
+	service, err := client.CoreV1().Services(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := client.CoreV1().Endpoints(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPortForwardTarget(service, endpoints, port)
+}
+
+// toPortForwardTarget picks the first ready pod address from the endpoints of the service that
+// exposes the endpoint port matching given service port.
+func toPortForwardTarget(service *v1.Service, endpoints *v1.Endpoints, port int32) (
+	*PortForwardTarget, error) {
+	servicePort := findServicePort(service, port)
+	if servicePort == nil {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("service %s does not expose port %d",
+			service.Name, port))
+	}
+
+	for _, subset := range endpoints.Subsets {
+		for _, endpointPort := range subset.Ports {
+			if endpointPort.Name != servicePort.Name {
+				continue
+			}
+
+			for _, address := range subset.Addresses {
+				if address.TargetRef != nil && address.TargetRef.Kind == "Pod" {
+					return &PortForwardTarget{Pod: address.TargetRef.Name, Port: endpointPort.Port}, nil
+				}
+			}
+		}
+	}
+
+	return nil, errorsK8s.NewServiceUnavailable(fmt.Sprintf("no ready pods back port %d of service %s",
+		port, service.Name))
+}
+
+// findServicePort returns the service port with given number or nil if the service does not
+// expose it.
+func findServicePort(service *v1.Service, port int32) *v1.ServicePort {
+	for i := range service.Spec.Ports {
+		if service.Spec.Ports[i].Port == port {
+			return &service.Spec.Ports[i]
+		}
+	}
+	return nil
+}
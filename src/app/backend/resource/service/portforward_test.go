@@ -0,0 +1,89 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func TestGetPortForwardTarget(t *testing.T) {
+	service := &v1.Service{
+		ObjectMeta: metaV1.ObjectMeta{Name: "svc-1", Namespace: "ns-1"},
+		Spec: v1.ServiceSpec{Ports: []v1.ServicePort{
+			{Name: "http", Port: 80},
+			{Name: "metrics", Port: 9090},
+		}},
+	}
+	endpoints := &v1.Endpoints{
+		ObjectMeta: metaV1.ObjectMeta{Name: "svc-1", Namespace: "ns-1"},
+		Subsets: []v1.EndpointSubset{
+			{
+				Addresses: []v1.EndpointAddress{{IP: "10.0.0.1"}},
+				Ports:     []v1.EndpointPort{{Name: "http", Port: 8080}},
+			},
+			{
+				Addresses: []v1.EndpointAddress{{
+					IP:        "10.0.0.2",
+					TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "pod-1", Namespace: "ns-1"},
+				}},
+				NotReadyAddresses: []v1.EndpointAddress{{
+					IP:        "10.0.0.3",
+					TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "pod-2", Namespace: "ns-1"},
+				}},
+				Ports: []v1.EndpointPort{{Name: "http", Port: 8080}, {Name: "metrics", Port: 9100}},
+			},
+		},
+	}
+
+	cases := []struct {
+		port          int32
+		expected      *PortForwardTarget
+		expectedError bool
+	}{
+		{80, &PortForwardTarget{Pod: "pod-1", Port: 8080}, false},
+		{9090, &PortForwardTarget{Pod: "pod-1", Port: 9100}, false},
+		{443, nil, true},
+	}
+
+	for _, c := range cases {
+		fakeClient := fake.NewSimpleClientset(service, endpoints)
+		actual, err := GetPortForwardTarget(fakeClient, "ns-1", "svc-1", c.port)
+		if (err != nil) != c.expectedError {
+			t.Errorf("GetPortForwardTarget(%d) returned error %v, expected error: %t", c.port, err,
+				c.expectedError)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("GetPortForwardTarget(%d) == %#v, expected %#v", c.port, actual, c.expected)
+		}
+	}
+}
+
+func TestGetPortForwardTargetWithoutReadyPods(t *testing.T) {
+	service := &v1.Service{
+		ObjectMeta: metaV1.ObjectMeta{Name: "svc-1", Namespace: "ns-1"},
+		Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 80}}},
+	}
+	endpoints := &v1.Endpoints{ObjectMeta: metaV1.ObjectMeta{Name: "svc-1", Namespace: "ns-1"}}
+
+	fakeClient := fake.NewSimpleClientset(service, endpoints)
+	if _, err := GetPortForwardTarget(fakeClient, "ns-1", "svc-1", 80); err == nil {
+		t.Error("GetPortForwardTarget() expected error when the service has no ready pods")
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+// Connections opened by the probe time out after probeTimeout. At most maxProbedEndpoints ready
+// endpoints are probed directly.
+var (
+	probeTimeout       = 3 * time.Second
+	maxProbedEndpoints = 10
+)
+
+// dialService opens a TCP connection to the address and closes it right away. It is a variable, so
+// that it can be replaced in tests.
+var dialService = func(address string, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// ProbeTarget is a result of connecting to a single address of a service.
+type ProbeTarget struct {
+	// Name of the target, ClusterIP or name of the pod behind an endpoint.
+	Name    string `json:"name"`
+	Address string `json:"address"`
+
+	Reachable bool `json:"reachable"`
+
+	// Time it took to open the connection in milliseconds.
+	LatencyMillis float64 `json:"latencyMillis"`
+
+	Error string `json:"error"`
+}
+
+// ConnectivityProbe is a result of connecting from the Dashboard pod to a service port, both through its
+// cluster IP and directly to its ready endpoints.
+type ConnectivityProbe struct {
+	Port int32 `json:"port"`
+
+	// Reachable is true when the service can be connected to through its cluster IP, or through any of
+	// its endpoints for headless services.
+	Reachable bool `json:"reachable"`
+
+	Targets []ProbeTarget `json:"targets"`
+}
+
+// ProbeService connects to the given TCP port of the service. If port is zero, the first service port is
+// probed.
+func ProbeService(client k8sClient.Interface, config *rest.Config, namespace, name string, port int32) (
+	*ConnectivityProbe, error) {
+	logger.Infof("Probing connectivity of %s service in %s namespace", name, namespace)
+
+	restClient, err := apply.NewRESTClient(config, EndpointSliceGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+	return probeService(client, restClient, namespace, name, port)
+}
+
+func probeService(client k8sClient.Interface, restClient RESTClient, namespace, name string,
+	port int32) (*ConnectivityProbe, error) {
+	service, err := client.CoreV1().Services(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if service.Spec.Type == v1.ServiceTypeExternalName {
+		return nil, errorsK8s.NewBadRequest("services of type ExternalName cannot be probed")
+	}
+
+	var servicePort *v1.ServicePort
+	if port == 0 && len(service.Spec.Ports) > 0 {
+		servicePort = &service.Spec.Ports[0]
+	} else {
+		servicePort = findServicePort(service, port)
+	}
+	if servicePort == nil {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("service %s does not expose port %d", name, port))
+	}
+	if servicePort.Protocol != "" && servicePort.Protocol != v1.ProtocolTCP {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("only TCP ports can be probed, port %d uses %s",
+			servicePort.Port, servicePort.Protocol))
+	}
+
+	_, slices, err := getEndpointSlices(client, restClient, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := getProbeTargets(service, servicePort, slices)
+	probeTargets(targets)
+
+	result := &ConnectivityProbe{Port: servicePort.Port, Targets: targets}
+	headless := len(service.Spec.ClusterIP) == 0 || service.Spec.ClusterIP == v1.ClusterIPNone
+	for i, target := range targets {
+		if target.Reachable && (headless || i == 0) {
+			result.Reachable = true
+		}
+	}
+	return result, nil
+}
+
+// getProbeTargets returns the cluster IP of the service followed by ready endpoints serving the port.
+// Only endpoints backed by pods in the namespace of the service are probed, other endpoints may
+// point to any address.
+func getProbeTargets(service *v1.Service, servicePort *v1.ServicePort, slices []EndpointSlice) []ProbeTarget {
+	targets := make([]ProbeTarget, 0)
+	if len(service.Spec.ClusterIP) > 0 && service.Spec.ClusterIP != v1.ClusterIPNone {
+		targets = append(targets, ProbeTarget{
+			Name:    "ClusterIP",
+			Address: net.JoinHostPort(service.Spec.ClusterIP, strconv.Itoa(int(servicePort.Port))),
+		})
+	}
+
+	endpoints := 0
+	for _, slice := range slices {
+		var endpointPort *EndpointSlicePort
+		for i := range slice.Ports {
+			if slice.Ports[i].Name == servicePort.Name {
+				endpointPort = &slice.Ports[i]
+			}
+		}
+		if endpointPort == nil {
+			continue
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			if !endpoint.Ready || len(endpoint.Addresses) == 0 || len(endpoint.Pod) == 0 ||
+				endpoints >= maxProbedEndpoints {
+				continue
+			}
+			targets = append(targets, ProbeTarget{
+				Name:    endpoint.Pod,
+				Address: net.JoinHostPort(endpoint.Addresses[0], strconv.Itoa(int(endpointPort.Port))),
+			})
+			endpoints++
+		}
+	}
+	return targets
+}
+
+// probeTargets connects to all targets in parallel and records the results.
+func probeTargets(targets []ProbeTarget) {
+	var wg sync.WaitGroup
+	for i := range targets {
+		wg.Add(1)
+		go func(target *ProbeTarget) {
+			defer wg.Done()
+			start := time.Now()
+			err := dialService(target.Address, probeTimeout)
+			if err != nil {
+				target.Error = getProbeError(err)
+				return
+			}
+			target.Reachable = true
+			target.LatencyMillis = float64(time.Since(start)/time.Microsecond) / 1000
+		}(&targets[i])
+	}
+	wg.Wait()
+}
+
+// getProbeError describes why the connection failed without details of the dial error, which could
+// reveal the network of the Dashboard pod.
+func getProbeError(err error) string {
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return "Connection timed out"
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return "Connection refused"
+	}
+	return "Connection failed"
+}
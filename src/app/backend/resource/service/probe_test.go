@@ -0,0 +1,121 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"errors"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func TestProbeService(t *testing.T) {
+	defer func(original func(string, time.Duration) error) { dialService = original }(dialService)
+	sliceServer := newEndpointSliceServer([]endpointSlice{})
+	defer sliceServer.Close()
+	endpointsServer := newEndpointSliceServer(nil)
+	defer endpointsServer.Close()
+
+	dialService = func(address string, timeout time.Duration) error {
+		if address == "10.1.0.2:8080" {
+			return &net.OpError{Op: "dial", Net: "tcp",
+				Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+		}
+		return nil
+	}
+
+	endpoints := &v1.Endpoints{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+		Subsets: []v1.EndpointSubset{{
+			Addresses: []v1.EndpointAddress{
+				{IP: "10.1.0.1", TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-1"}},
+				{IP: "10.1.0.2", TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-2"}},
+				// Endpoints, which are not pods in the namespace of the service, are not probed.
+				{IP: "10.1.0.4", TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-4",
+					Namespace: "other"}},
+				{IP: "10.1.0.5"},
+			},
+			NotReadyAddresses: []v1.EndpointAddress{{IP: "10.1.0.3"}},
+			Ports:             []v1.EndpointPort{{Name: "http", Port: 8080, Protocol: v1.ProtocolTCP}},
+		}},
+	}
+	client := fake.NewSimpleClientset(newEndpointTestService(map[string]string{"app": "web"},
+		intstr.FromInt(8080)), endpoints)
+
+	// Endpoint slices are empty, so endpoints are not probed.
+	actual, err := probeService(client, newEndpointSliceClient(t, sliceServer), "default", "web", 0)
+	if err != nil {
+		t.Fatalf("ProbeService(): unexpected error %v", err)
+	}
+	if !actual.Reachable || len(actual.Targets) != 1 || actual.Targets[0].Address != "10.0.0.10:80" {
+		t.Errorf("ProbeService() == %#v, expected reachable cluster IP only", actual)
+	}
+
+	// Endpoints API is used, when endpoint slices are not served.
+	actual, err = probeService(client, newEndpointSliceClient(t, endpointsServer), "default", "web", 80)
+	if err != nil {
+		t.Fatalf("ProbeService(): unexpected error %v", err)
+	}
+
+	expected := map[string]bool{"10.0.0.10:80": true, "10.1.0.1:8080": true, "10.1.0.2:8080": false}
+	if len(actual.Targets) != len(expected) {
+		t.Fatalf("ProbeService() probed %#v, expected %v", actual.Targets, expected)
+	}
+	for _, target := range actual.Targets {
+		reachable, exists := expected[target.Address]
+		if !exists || target.Reachable != reachable || (!reachable && target.Error != "Connection refused") {
+			t.Errorf("Unexpected probe target %#v", target)
+		}
+	}
+	if actual.Targets[1].Name != "web-1" {
+		t.Errorf("Expected endpoint targets to be named by pods, got %s", actual.Targets[1].Name)
+	}
+
+	_, err = probeService(client, newEndpointSliceClient(t, endpointsServer), "default", "web", 443)
+	if !errorsK8s.IsBadRequest(err) {
+		t.Errorf("Expected bad request for a port not exposed by the service, got %v", err)
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestGetProbeError(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected string
+	}{
+		{&net.OpError{Op: "dial", Err: timeoutError{}}, "Connection timed out"},
+		{&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)},
+			"Connection refused"},
+		{errors.New("dial tcp 10.0.0.1:80: connect: no route to host"), "Connection failed"},
+	}
+	for _, c := range cases {
+		if actual := getProbeError(c.err); actual != c.expected {
+			t.Errorf("getProbeError(%v) == %s, expected %s", c.err, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceaccount
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// defaultExpirationSeconds is the lifetime of minted tokens, when it is not specified.
+	defaultExpirationSeconds = 3600
+
+	// minExpirationSeconds is the shortest token lifetime accepted by the TokenRequest API.
+	minExpirationSeconds = 600
+
+	roleKind        = "Role"
+	clusterRoleKind = "ClusterRole"
+)
+
+// KubeConfigSpec describes service account to create or reuse, roles to bind to it and the
+// kubeconfig to generate.
+type KubeConfigSpec struct {
+	// ServiceAccount is the name of the service account. It is created if it does not exist.
+	ServiceAccount string `json:"serviceAccount"`
+
+	Roles []RoleRefSpec `json:"roles"`
+
+	// ExpirationSeconds is the lifetime of the token. Defaults to one hour.
+	ExpirationSeconds int64 `json:"expirationSeconds"`
+
+	// Server is the API server URL written to the kubeconfig. Defaults to the URL used by
+	// Dashboard, which may not be reachable from outside of the cluster.
+	Server string `json:"server"`
+}
+
+// RoleRefSpec is a role or cluster role to bind to the service account.
+type RoleRefSpec struct {
+	// Kind is either Role or ClusterRole.
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+
+	// ClusterWide binds cluster role in all namespaces using cluster role binding. Otherwise,
+	// roles are bound only in the namespace of the service account.
+	ClusterWide bool `json:"clusterWide"`
+}
+
+// GeneratedKubeConfig is a kubeconfig authenticating as the service account.
+type GeneratedKubeConfig struct {
+	ServiceAccount string `json:"serviceAccount"`
+
+	// Created is true if the service account did not exist before.
+	Created bool `json:"created"`
+
+	// Bindings are names of role bindings and cluster role bindings of the service account.
+	Bindings []string `json:"bindings"`
+
+	ExpirationTimestamp metaV1.Time `json:"expirationTimestamp"`
+	FileName            string      `json:"fileName"`
+	KubeConfig          string      `json:"kubeConfig"`
+}
+
+// tokenRequest is the TokenRequest API object. Client library does not contain its types yet, so
+// only the fields used by Dashboard are declared.
+type tokenRequest struct {
+	metaV1.TypeMeta `json:",inline"`
+	Spec            tokenRequestSpec   `json:"spec"`
+	Status          tokenRequestStatus `json:"status"`
+}
+
+type tokenRequestSpec struct {
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+type tokenRequestStatus struct {
+	Token               string      `json:"token"`
+	ExpirationTimestamp metaV1.Time `json:"expirationTimestamp"`
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Post() *rest.Request
+}
+
+// createToken mints token of the service account using TokenRequest API. Rest client is the client
+// of core API group.
+func createToken(restClient RESTClient, namespace, name string, expirationSeconds int64) (
+	*tokenRequestStatus, error) {
+	body, err := json.Marshal(&tokenRequest{
+		TypeMeta: metaV1.TypeMeta{APIVersion: "authentication.k8s.io/v1", Kind: "TokenRequest"},
+		Spec:     tokenRequestSpec{ExpirationSeconds: &expirationSeconds},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := restClient.Post().
+		Namespace(namespace).
+		Resource("serviceaccounts").
+		Name(name).
+		SubResource("token").
+		Body(body).
+		Do().
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &tokenRequest{}
+	if err := json.Unmarshal(raw, result); err != nil {
+		return nil, err
+	}
+	return &result.Status, nil
+}
+
+// GenerateKubeConfig creates the service account unless it exists, binds requested roles to it,
+// mints its token and returns kubeconfig using the token.
+func GenerateKubeConfig(client client.Interface, config *rest.Config, namespace string,
+	spec *KubeConfigSpec) (*GeneratedKubeConfig, error) {
+	return generateKubeConfig(client, client.CoreV1().RESTClient(), config, namespace, spec)
+}
+
+func generateKubeConfig(client client.Interface, restClient RESTClient, config *rest.Config, namespace string,
+	spec *KubeConfigSpec) (*GeneratedKubeConfig, error) {
+	logger.Infof("Generating kubeconfig of %s service account in %s namespace", spec.ServiceAccount,
+		namespace)
+
+	if err := validateKubeConfigSpec(client, namespace, spec); err != nil {
+		return nil, err
+	}
+	expirationSeconds := spec.ExpirationSeconds
+	if expirationSeconds == 0 {
+		expirationSeconds = defaultExpirationSeconds
+	}
+
+	result := &GeneratedKubeConfig{ServiceAccount: spec.ServiceAccount, Bindings: []string{}}
+	_, err := client.CoreV1().ServiceAccounts(namespace).Get(spec.ServiceAccount, metaV1.GetOptions{})
+	if errorsK8s.IsNotFound(err) {
+		_, err = client.CoreV1().ServiceAccounts(namespace).Create(&v1.ServiceAccount{
+			ObjectMeta: metaV1.ObjectMeta{Name: spec.ServiceAccount, Namespace: namespace},
+		})
+		result.Created = true
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	subject := rbac.Subject{Kind: rbac.ServiceAccountKind, Name: spec.ServiceAccount, Namespace: namespace}
+	for _, role := range spec.Roles {
+		name, err := ensureBinding(client, namespace, role, subject)
+		if err != nil {
+			return nil, err
+		}
+		result.Bindings = append(result.Bindings, name)
+	}
+
+	token, err := createToken(restClient, namespace, spec.ServiceAccount, expirationSeconds)
+	if err != nil {
+		return nil, err
+	}
+	result.ExpirationTimestamp = token.ExpirationTimestamp
+
+	server := spec.Server
+	if len(server) == 0 {
+		server = config.Host
+	}
+	kubeConfig, err := buildKubeConfig(config, server, namespace, spec.ServiceAccount, token.Token)
+	if err != nil {
+		return nil, err
+	}
+	result.KubeConfig = string(kubeConfig)
+	result.FileName = fmt.Sprintf("%s-%s.kubeconfig", namespace, spec.ServiceAccount)
+	return result, nil
+}
+
+// validateKubeConfigSpec returns bad request error describing all problems with the spec.
+func validateKubeConfigSpec(client client.Interface, namespace string, spec *KubeConfigSpec) error {
+	var errs []string
+	for _, msg := range validation.IsDNS1123Subdomain(spec.ServiceAccount) {
+		errs = append(errs, fmt.Sprintf("invalid service account name %q: %s", spec.ServiceAccount, msg))
+	}
+	if spec.ExpirationSeconds != 0 && spec.ExpirationSeconds < minExpirationSeconds {
+		errs = append(errs, fmt.Sprintf("token expiration has to be at least %d seconds",
+			minExpirationSeconds))
+	}
+
+	for _, role := range spec.Roles {
+		var err error
+		switch role.Kind {
+		case roleKind:
+			if role.ClusterWide {
+				errs = append(errs, fmt.Sprintf("role %s cannot be bound cluster wide", role.Name))
+				continue
+			}
+			_, err = client.RbacV1beta1().Roles(namespace).Get(role.Name, metaV1.GetOptions{})
+		case clusterRoleKind:
+			_, err = client.RbacV1beta1().ClusterRoles().Get(role.Name, metaV1.GetOptions{})
+		default:
+			errs = append(errs, fmt.Sprintf("invalid kind %q of role %s, expected %s or %s", role.Kind,
+				role.Name, roleKind, clusterRoleKind))
+			continue
+		}
+		if errorsK8s.IsNotFound(err) {
+			errs = append(errs, fmt.Sprintf("%s %s not found", strings.ToLower(role.Kind), role.Name))
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if len(errs) > 0 {
+		return errorsK8s.NewBadRequest(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ensureBinding creates binding of the role to the subject and returns its name. Existing binding
+// of the same name is accepted only if it binds the same role to the subject.
+func ensureBinding(client client.Interface, namespace string, role RoleRefSpec,
+	subject rbac.Subject) (string, error) {
+	roleRef := rbac.RoleRef{APIGroup: rbac.GroupName, Kind: role.Kind, Name: role.Name}
+	meta := metaV1.ObjectMeta{Name: fmt.Sprintf("%s-%s", subject.Name, role.Name)}
+	subjects := []rbac.Subject{subject}
+
+	if role.ClusterWide {
+		meta.Name = fmt.Sprintf("%s-%s-%s", namespace, subject.Name, role.Name)
+		_, err := client.RbacV1beta1().ClusterRoleBindings().Create(&rbac.ClusterRoleBinding{
+			ObjectMeta: meta, RoleRef: roleRef, Subjects: subjects})
+		if errorsK8s.IsAlreadyExists(err) {
+			var existing *rbac.ClusterRoleBinding
+			existing, err = client.RbacV1beta1().ClusterRoleBindings().Get(meta.Name, metaV1.GetOptions{})
+			if err == nil {
+				err = checkExistingBinding(meta.Name, existing.RoleRef, roleRef, existing.Subjects, subject)
+			}
+		}
+		return meta.Name, err
+	}
+
+	meta.Namespace = namespace
+	_, err := client.RbacV1beta1().RoleBindings(namespace).Create(&rbac.RoleBinding{
+		ObjectMeta: meta, RoleRef: roleRef, Subjects: subjects})
+	if errorsK8s.IsAlreadyExists(err) {
+		var existing *rbac.RoleBinding
+		existing, err = client.RbacV1beta1().RoleBindings(namespace).Get(meta.Name, metaV1.GetOptions{})
+		if err == nil {
+			err = checkExistingBinding(meta.Name, existing.RoleRef, roleRef, existing.Subjects, subject)
+		}
+	}
+	return meta.Name, err
+}
+
+func checkExistingBinding(name string, existing, expected rbac.RoleRef, subjects []rbac.Subject,
+	subject rbac.Subject) error {
+	if existing != expected {
+		return errorsK8s.NewConflict(rbac.Resource("rolebindings"), name,
+			fmt.Errorf("binding already exists and refers to %s %s", existing.Kind, existing.Name))
+	}
+	for _, s := range subjects {
+		if s.Kind == subject.Kind && s.Name == subject.Name && s.Namespace == subject.Namespace {
+			return nil
+		}
+	}
+	return errorsK8s.NewConflict(rbac.Resource("rolebindings"), name,
+		fmt.Errorf("binding already exists and does not include service account %s", subject.Name))
+}
+
+// buildKubeConfig returns serialized kubeconfig authenticating with the token. Certificate
+// authority file is inlined, so that the kubeconfig can be used on other machines.
+func buildKubeConfig(config *rest.Config, server, namespace, serviceAccount, token string) (
+	[]byte, error) {
+	caData := config.TLSClientConfig.CAData
+	if len(caData) == 0 && len(config.TLSClientConfig.CAFile) > 0 {
+		data, err := ioutil.ReadFile(config.TLSClientConfig.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		caData = data
+	}
+
+	name := fmt.Sprintf("%s-%s", namespace, serviceAccount)
+	kubeConfig := clientcmdapi.NewConfig()
+	kubeConfig.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   server,
+		CertificateAuthorityData: caData,
+		InsecureSkipTLSVerify:    config.TLSClientConfig.Insecure,
+	}
+	kubeConfig.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: token}
+	kubeConfig.Contexts[name] = &clientcmdapi.Context{
+		Cluster:   name,
+		AuthInfo:  name,
+		Namespace: namespace,
+	}
+	kubeConfig.CurrentContext = name
+	return clientcmd.Write(*kubeConfig)
+}
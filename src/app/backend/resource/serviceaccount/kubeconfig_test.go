@@ -0,0 +1,178 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceaccount
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1beta1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var expiration = metaV1.NewTime(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+
+// newTokenClient returns client of core API group, which mints tokens of service accounts in ci
+// namespace using TokenRequest API.
+func newTokenClient(t *testing.T) (RESTClient, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		if r.Method != http.MethodPost || len(parts) != 8 ||
+			strings.Join(parts[:6], "/") != "/api/v1/namespaces/ci/serviceaccounts" || parts[7] != "token" {
+			http.NotFound(w, r)
+			return
+		}
+
+		request := &tokenRequest{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			t.Errorf("createToken() sent invalid token request: %s", err)
+		}
+		if expirationSeconds := request.Spec.ExpirationSeconds; expirationSeconds == nil ||
+			*expirationSeconds != defaultExpirationSeconds {
+			t.Errorf("createToken() called with expiration %v, expected %d", expirationSeconds,
+				defaultExpirationSeconds)
+		}
+		request.Status = tokenRequestStatus{Token: "ci-" + parts[6] + "-token", ExpirationTimestamp: expiration}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(request)
+	}))
+	restClient, err := apply.NewRESTClient(&rest.Config{Host: server.URL}, schema.GroupVersion{Version: "v1"})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return restClient, server.Close
+}
+
+func newTestClient() *fake.Clientset {
+	return fake.NewSimpleClientset(
+		&rbac.ClusterRole{ObjectMeta: metaV1.ObjectMeta{Name: "view"}},
+		&rbac.Role{ObjectMeta: metaV1.ObjectMeta{Name: "deployer", Namespace: "ci"}},
+	)
+}
+
+func TestGenerateKubeConfig(t *testing.T) {
+	restClient, closeServer := newTokenClient(t)
+	defer closeServer()
+	fakeClient := newTestClient()
+	config := &rest.Config{Host: "https://10.0.0.1:443",
+		TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")}}
+
+	actual, err := generateKubeConfig(fakeClient, restClient, config, "ci", &KubeConfigSpec{
+		ServiceAccount: "pipeline",
+		Roles: []RoleRefSpec{
+			{Kind: "Role", Name: "deployer"},
+			{Kind: "ClusterRole", Name: "view", ClusterWide: true},
+		},
+		Server: "https://cluster.example.com",
+	})
+	if err != nil {
+		t.Fatalf("GenerateKubeConfig() returned error: %s", err)
+	}
+
+	if !actual.Created || actual.FileName != "ci-pipeline.kubeconfig" ||
+		!reflect.DeepEqual(actual.Bindings, []string{"pipeline-deployer", "ci-pipeline-view"}) ||
+		!actual.ExpirationTimestamp.Equal(expiration) {
+		t.Errorf("GenerateKubeConfig() == %#v, unexpected result", actual)
+	}
+
+	if _, err := fakeClient.CoreV1().ServiceAccounts("ci").Get("pipeline", metaV1.GetOptions{}); err != nil {
+		t.Errorf("GenerateKubeConfig() did not create service account: %s", err)
+	}
+	binding, err := fakeClient.RbacV1beta1().ClusterRoleBindings().Get("ci-pipeline-view", metaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("GenerateKubeConfig() did not create cluster role binding: %s", err)
+	}
+	expectedSubjects := []rbac.Subject{{Kind: "ServiceAccount", Name: "pipeline", Namespace: "ci"}}
+	if !reflect.DeepEqual(binding.Subjects, expectedSubjects) {
+		t.Errorf("cluster role binding subjects == %#v, expected %#v", binding.Subjects, expectedSubjects)
+	}
+
+	kubeConfig, err := clientcmd.Load([]byte(actual.KubeConfig))
+	if err != nil {
+		t.Fatalf("GenerateKubeConfig() returned invalid kubeconfig: %s", err)
+	}
+	context := kubeConfig.Contexts[kubeConfig.CurrentContext]
+	if context == nil || context.Namespace != "ci" {
+		t.Fatalf("kubeconfig context == %#v, expected context in ci namespace", context)
+	}
+	cluster := kubeConfig.Clusters[context.Cluster]
+	if cluster.Server != "https://cluster.example.com" || string(cluster.CertificateAuthorityData) != "ca" {
+		t.Errorf("kubeconfig cluster == %#v, expected overridden server and inlined CA", cluster)
+	}
+	if token := kubeConfig.AuthInfos[context.AuthInfo].Token; token != "ci-pipeline-token" {
+		t.Errorf("kubeconfig token == %s, expected ci-pipeline-token", token)
+	}
+
+	// Generating kubeconfig again reuses the service account and its bindings.
+	actual, err = generateKubeConfig(fakeClient, restClient, config, "ci", &KubeConfigSpec{
+		ServiceAccount: "pipeline",
+		Roles:          []RoleRefSpec{{Kind: "Role", Name: "deployer"}},
+	})
+	if err != nil {
+		t.Fatalf("GenerateKubeConfig() returned error for existing service account: %s", err)
+	}
+	if actual.Created {
+		t.Errorf("GenerateKubeConfig() == %#v, expected existing service account", actual)
+	}
+}
+
+func TestGenerateKubeConfigErrors(t *testing.T) {
+	restClient, closeServer := newTokenClient(t)
+	defer closeServer()
+	config := &rest.Config{Host: "https://10.0.0.1:443"}
+
+	cases := []struct {
+		spec     KubeConfigSpec
+		conflict bool
+	}{
+		{spec: KubeConfigSpec{ServiceAccount: "Invalid_Name"}},
+		{spec: KubeConfigSpec{ServiceAccount: "pipeline", ExpirationSeconds: 60}},
+		{spec: KubeConfigSpec{ServiceAccount: "pipeline", Roles: []RoleRefSpec{{Kind: "Group", Name: "view"}}}},
+		{spec: KubeConfigSpec{ServiceAccount: "pipeline", Roles: []RoleRefSpec{{Kind: "Role", Name: "missing"}}}},
+		{spec: KubeConfigSpec{ServiceAccount: "pipeline",
+			Roles: []RoleRefSpec{{Kind: "Role", Name: "deployer", ClusterWide: true}}}},
+		{spec: KubeConfigSpec{ServiceAccount: "other", Roles: []RoleRefSpec{{Kind: "Role", Name: "deployer"}}},
+			conflict: true},
+	}
+
+	for _, c := range cases {
+		fakeClient := newTestClient()
+		fakeClient.RbacV1beta1().RoleBindings("ci").Create(&rbac.RoleBinding{
+			ObjectMeta: metaV1.ObjectMeta{Name: "other-deployer", Namespace: "ci"},
+			RoleRef:    rbac.RoleRef{APIGroup: rbac.GroupName, Kind: "Role", Name: "admin"},
+		})
+
+		_, err := generateKubeConfig(fakeClient, restClient, config, "ci", &c.spec)
+
+		if c.conflict && !errorsK8s.IsConflict(err) {
+			t.Errorf("GenerateKubeConfig(%#v) == %v, expected conflict", c.spec, err)
+		}
+		if !c.conflict && !errorsK8s.IsBadRequest(err) {
+			t.Errorf("GenerateKubeConfig(%#v) == %v, expected bad request", c.spec, err)
+		}
+	}
+}
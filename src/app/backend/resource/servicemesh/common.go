@@ -0,0 +1,275 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package servicemesh shows how workloads take part in Istio or Linkerd service mesh, when any of
+// them is installed in the cluster.
+package servicemesh
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// Group versions of service mesh APIs.
+var (
+	IstioNetworkingGroupVersion = schema.GroupVersion{Group: "networking.istio.io", Version: "v1beta1"}
+	IstioSecurityGroupVersion   = schema.GroupVersion{Group: "security.istio.io", Version: "v1beta1"}
+	LinkerdGroupVersion         = schema.GroupVersion{Group: "linkerd.io", Version: "v1alpha2"}
+)
+
+// Names of service mesh resources.
+const (
+	virtualServiceResource     = "virtualservices"
+	destinationRuleResource    = "destinationrules"
+	peerAuthenticationResource = "peerauthentications"
+	serviceProfileResource     = "serviceprofiles"
+)
+
+// Mesh is the name of a service mesh.
+type Mesh string
+
+// List of supported service meshes.
+const (
+	MeshIstio   Mesh = "istio"
+	MeshLinkerd Mesh = "linkerd"
+)
+
+// IstioRootNamespace is the namespace of Istio control plane. Policies without selector in this
+// namespace apply to the whole mesh.
+const IstioRootNamespace = "istio-system"
+
+// Status tells which service meshes are installed in the cluster.
+type Status struct {
+	Istio   bool `json:"istio"`
+	Linkerd bool `json:"linkerd"`
+}
+
+// Installed returns true when any service mesh is installed.
+func (self Status) Installed() bool {
+	return self.Istio || self.Linkerd
+}
+
+// GetStatus detects service meshes by looking for their API groups in discovery.
+func GetStatus(client discovery.DiscoveryInterface) (*Status, error) {
+	istio, err := isServed(client, IstioNetworkingGroupVersion, virtualServiceResource)
+	if err != nil {
+		return nil, err
+	}
+	linkerd, err := isServed(client, LinkerdGroupVersion, serviceProfileResource)
+	if err != nil {
+		return nil, err
+	}
+	return &Status{Istio: istio, Linkerd: linkerd}, nil
+}
+
+func isServed(client discovery.DiscoveryInterface, gv schema.GroupVersion, resource string) (bool, error) {
+	list, err := client.ServerResourcesForGroupVersion(gv.String())
+	if errorsK8s.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, item := range list.APIResources {
+		if item.Name == resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Get() *rest.Request
+}
+
+// newRESTClients creates REST clients for group versions of service mesh APIs.
+func newRESTClients(config *rest.Config) (map[schema.GroupVersion]RESTClient, error) {
+	restClients := make(map[schema.GroupVersion]RESTClient)
+	for _, gv := range []schema.GroupVersion{IstioNetworkingGroupVersion, IstioSecurityGroupVersion,
+		LinkerdGroupVersion} {
+		restClient, err := apply.NewRESTClient(config, gv)
+		if err != nil {
+			return nil, err
+		}
+		restClients[gv] = restClient
+	}
+	return restClients, nil
+}
+
+// listRaw lists objects of a service mesh resource in the namespace.
+func listRaw(restClients map[schema.GroupVersion]RESTClient, gv schema.GroupVersion, resource,
+	namespace string) ([]byte, error) {
+	raw, err := restClients[gv].Get().Namespace(namespace).Resource(resource).Do().Raw()
+	if errorsK8s.IsNotFound(err) {
+		return nil, errorsK8s.NewNotFound(schema.GroupResource{Group: gv.Group, Resource: resource},
+			fmt.Sprintf("%s (%s is not served)", resource, gv.String()))
+	}
+	return raw, err
+}
+
+// hostMatchesService returns true when host used in a mesh object of the namespace refers to the
+// service. Short names are resolved in the namespace of the object, the same as in Istio.
+func hostMatchesService(host, namespace, service, serviceNamespace string) bool {
+	if !strings.Contains(host, ".") {
+		host = host + "." + namespace
+	}
+	name := service + "." + serviceNamespace
+	if host == name || strings.HasPrefix(host, name+".svc") {
+		return true
+	}
+	if strings.HasPrefix(host, "*.") {
+		suffix := host[1:]
+		return strings.HasSuffix(name, suffix) || strings.HasSuffix(name+".svc.cluster.local", suffix)
+	}
+	return false
+}
+
+// The code below allows to perform complex data section on []VirtualService, []DestinationRule,
+// []PeerAuthentication and []ServiceProfile
+
+type VirtualServiceCell VirtualService
+
+func (self VirtualServiceCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toVirtualServiceCells(std []VirtualService) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = VirtualServiceCell(std[i])
+	}
+	return cells
+}
+
+func fromVirtualServiceCells(cells []dataselect.DataCell) []VirtualService {
+	std := make([]VirtualService, len(cells))
+	for i := range std {
+		std[i] = VirtualService(cells[i].(VirtualServiceCell))
+	}
+	return std
+}
+
+type DestinationRuleCell DestinationRule
+
+func (self DestinationRuleCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toDestinationRuleCells(std []DestinationRule) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = DestinationRuleCell(std[i])
+	}
+	return cells
+}
+
+func fromDestinationRuleCells(cells []dataselect.DataCell) []DestinationRule {
+	std := make([]DestinationRule, len(cells))
+	for i := range std {
+		std[i] = DestinationRule(cells[i].(DestinationRuleCell))
+	}
+	return std
+}
+
+type PeerAuthenticationCell PeerAuthentication
+
+func (self PeerAuthenticationCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toPeerAuthenticationCells(std []PeerAuthentication) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = PeerAuthenticationCell(std[i])
+	}
+	return cells
+}
+
+func fromPeerAuthenticationCells(cells []dataselect.DataCell) []PeerAuthentication {
+	std := make([]PeerAuthentication, len(cells))
+	for i := range std {
+		std[i] = PeerAuthentication(cells[i].(PeerAuthenticationCell))
+	}
+	return std
+}
+
+type ServiceProfileCell ServiceProfile
+
+func (self ServiceProfileCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toServiceProfileCells(std []ServiceProfile) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = ServiceProfileCell(std[i])
+	}
+	return cells
+}
+
+func fromServiceProfileCells(cells []dataselect.DataCell) []ServiceProfile {
+	std := make([]ServiceProfile, len(cells))
+	for i := range std {
+		std[i] = ServiceProfile(cells[i].(ServiceProfileCell))
+	}
+	return std
+}
@@ -0,0 +1,392 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicemesh
+
+import (
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+// Names of sidecar containers and labels and annotations controlling their injection.
+const (
+	istioProxyContainer   = "istio-proxy"
+	linkerdProxyContainer = "linkerd-proxy"
+
+	istioInjectLabel        = "sidecar.istio.io/inject"
+	istioInjectionLabel     = "istio-injection"
+	istioRevisionLabel      = "istio.io/rev"
+	linkerdInjectAnnotation = "linkerd.io/inject"
+	injectionDisabled       = "disabled"
+)
+
+// Mutual TLS modes. Istio modes are taken from peer authentication policies, Linkerd encrypts
+// traffic between meshed pods automatically.
+const (
+	mtlsUnset      = "UNSET"
+	mtlsPermissive = "PERMISSIVE"
+	mtlsAutomatic  = "AUTOMATIC"
+)
+
+// PodMesh describes how a pod takes part in service mesh.
+type PodMesh struct {
+	// Mesh is the mesh the pod takes part in, or would take part in after it is recreated. Empty
+	// when the pod is not meshed.
+	Mesh Mesh `json:"mesh"`
+
+	SidecarInjected bool   `json:"sidecarInjected"`
+	ProxyImage      string `json:"proxyImage,omitempty"`
+
+	// InjectionEnabled tells whether sidecar is injected into new pods like this one. Injection
+	// source is the label or annotation deciding it.
+	InjectionEnabled bool   `json:"injectionEnabled"`
+	InjectionSource  string `json:"injectionSource"`
+
+	// MTLSMode is the mode of mutual TLS for traffic received by the pod. MTLSPolicy is the
+	// peer authentication policy setting the mode, empty when the mesh default is used.
+	MTLSMode   string `json:"mtlsMode"`
+	MTLSPolicy string `json:"mtlsPolicy"`
+}
+
+// ServiceMesh describes how a service takes part in service mesh.
+type ServiceMesh struct {
+	Mesh Mesh `json:"mesh"`
+
+	// Pods selected by the service and pods with sidecar among them.
+	Pods       int `json:"pods"`
+	MeshedPods int `json:"meshedPods"`
+
+	MTLSMode   string `json:"mtlsMode"`
+	MTLSPolicy string `json:"mtlsPolicy"`
+
+	// Mesh objects routing traffic to the service.
+	VirtualServices  []VirtualService  `json:"virtualServices"`
+	DestinationRules []DestinationRule `json:"destinationRules"`
+	ServiceProfiles  []ServiceProfile  `json:"serviceProfiles"`
+}
+
+// GetPodMesh returns service mesh status of the pod. Nil is returned when no service mesh is
+// installed.
+func GetPodMesh(client kubernetes.Interface, config *rest.Config, namespace, name string) (*PodMesh, error) {
+	restClients, err := newRESTClients(config)
+	if err != nil {
+		return nil, err
+	}
+	return getPodMesh(client, restClients, namespace, name)
+}
+
+func getPodMesh(client kubernetes.Interface, restClients map[schema.GroupVersion]RESTClient, namespace,
+	name string) (*PodMesh, error) {
+	status, err := GetStatus(client.Discovery())
+	if err != nil || !status.Installed() {
+		return nil, err
+	}
+	logger.Infof("Getting service mesh status of %s pod in %s namespace", name, namespace)
+
+	pod, err := client.CoreV1().Pods(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ns, err := client.CoreV1().Namespaces().Get(namespace, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toPodMesh(*status, pod, ns)
+	if result.Mesh == MeshIstio && result.SidecarInjected {
+		policies, err := listMTLSPolicies(restClients, namespace)
+		if err != nil {
+			return nil, err
+		}
+		result.MTLSMode, result.MTLSPolicy = resolveMTLS(policies, namespace, pod.Labels)
+	}
+	return result, nil
+}
+
+// GetServiceMesh returns service mesh status of the service together with mesh objects routing
+// traffic to it. Nil is returned when no service mesh is installed.
+func GetServiceMesh(client kubernetes.Interface, config *rest.Config, namespace, name string) (*ServiceMesh,
+	error) {
+	restClients, err := newRESTClients(config)
+	if err != nil {
+		return nil, err
+	}
+	return getServiceMesh(client, restClients, namespace, name)
+}
+
+func getServiceMesh(client kubernetes.Interface, restClients map[schema.GroupVersion]RESTClient, namespace,
+	name string) (*ServiceMesh, error) {
+	status, err := GetStatus(client.Discovery())
+	if err != nil || !status.Installed() {
+		return nil, err
+	}
+	logger.Infof("Getting service mesh status of %s service in %s namespace", name, namespace)
+
+	service, err := client.CoreV1().Services(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods := &v1.PodList{}
+	if len(service.Spec.Selector) > 0 {
+		pods, err = client.CoreV1().Pods(namespace).List(metaV1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &ServiceMesh{
+		VirtualServices:  make([]VirtualService, 0),
+		DestinationRules: make([]DestinationRule, 0),
+		ServiceProfiles:  make([]ServiceProfile, 0),
+	}
+	result.Mesh, result.Pods, result.MeshedPods = countMeshedPods(pods.Items)
+
+	if status.Istio {
+		if err := addIstioObjects(restClients, service, result); err != nil {
+			return nil, err
+		}
+	}
+	if status.Linkerd {
+		profiles, err := listServiceProfiles(restClients, "")
+		if err != nil && !errorsK8s.IsNotFound(err) {
+			return nil, err
+		}
+		for _, profile := range profiles {
+			if hostMatchesService(profile.Name, profile.Namespace, service.Name, namespace) {
+				result.ServiceProfiles = append(result.ServiceProfiles, toServiceProfile(profile))
+			}
+		}
+		if result.Mesh == MeshLinkerd {
+			result.MTLSMode = mtlsAutomatic
+		}
+	}
+	return result, nil
+}
+
+// addIstioObjects adds virtual services and destination rules of the service to the result, and
+// mutual TLS mode of its pods, when they are in Istio mesh.
+func addIstioObjects(restClients map[schema.GroupVersion]RESTClient, service *v1.Service, result *ServiceMesh) error {
+	virtualServices, err := listVirtualServices(restClients, "")
+	if err != nil {
+		return err
+	}
+	for _, item := range virtualServices {
+		if item.refersTo(service) {
+			result.VirtualServices = append(result.VirtualServices, toVirtualService(item))
+		}
+	}
+
+	destinationRules, err := listDestinationRules(restClients, "")
+	if err != nil {
+		return err
+	}
+	for _, item := range destinationRules {
+		if hostMatchesService(item.Spec.Host, item.Namespace, service.Name, service.Namespace) {
+			result.DestinationRules = append(result.DestinationRules, toDestinationRule(item))
+		}
+	}
+
+	if result.Mesh == MeshIstio {
+		policies, err := listMTLSPolicies(restClients, service.Namespace)
+		if err != nil {
+			return err
+		}
+		result.MTLSMode, result.MTLSPolicy = resolveMTLS(policies, service.Namespace, service.Spec.Selector)
+	}
+	return nil
+}
+
+// refersTo returns true when any host or route destination of the virtual service is the service.
+func (self virtualService) refersTo(service *v1.Service) bool {
+	for _, host := range append(self.Spec.Hosts, self.destinations()...) {
+		if hostMatchesService(host, self.Namespace, service.Name, service.Namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// toPodMesh finds the mesh of the pod from its sidecar, or from injection settings when it has
+// no sidecar.
+func toPodMesh(status Status, pod *v1.Pod, namespace *v1.Namespace) *PodMesh {
+	result := &PodMesh{}
+	mesh, image := sidecarOf(pod)
+	if len(mesh) > 0 {
+		result.Mesh = mesh
+		result.SidecarInjected = true
+		result.ProxyImage = image
+		result.InjectionEnabled, result.InjectionSource = injection(mesh, pod, namespace)
+	} else {
+		for _, candidate := range status.meshes() {
+			if enabled, source := injection(candidate, pod, namespace); enabled {
+				result.Mesh = candidate
+				result.InjectionEnabled = true
+				result.InjectionSource = source
+				break
+			}
+		}
+	}
+
+	if result.Mesh == MeshLinkerd && result.SidecarInjected {
+		result.MTLSMode = mtlsAutomatic
+	}
+	return result
+}
+
+func (self Status) meshes() []Mesh {
+	result := make([]Mesh, 0)
+	if self.Istio {
+		result = append(result, MeshIstio)
+	}
+	if self.Linkerd {
+		result = append(result, MeshLinkerd)
+	}
+	return result
+}
+
+// sidecarOf returns mesh and image of the sidecar proxy of the pod.
+func sidecarOf(pod *v1.Pod) (Mesh, string) {
+	for _, container := range pod.Spec.Containers {
+		switch container.Name {
+		case istioProxyContainer:
+			return MeshIstio, container.Image
+		case linkerdProxyContainer:
+			return MeshLinkerd, container.Image
+		}
+	}
+	return "", ""
+}
+
+// injection tells whether sidecar of the mesh is injected into the pod and which label or
+// annotation decides it.
+func injection(mesh Mesh, pod *v1.Pod, namespace *v1.Namespace) (bool, string) {
+	switch mesh {
+	case MeshIstio:
+		if namespace.Labels[istioInjectionLabel] == injectionDisabled {
+			return false, "namespace label " + istioInjectionLabel
+		}
+		if value, ok := pod.Labels[istioInjectLabel]; ok {
+			return value == "true", "pod label " + istioInjectLabel
+		}
+		if value, ok := pod.Annotations[istioInjectLabel]; ok {
+			return value == "true", "pod annotation " + istioInjectLabel
+		}
+		if value, ok := namespace.Labels[istioInjectionLabel]; ok {
+			return value == "enabled", "namespace label " + istioInjectionLabel
+		}
+		if _, ok := namespace.Labels[istioRevisionLabel]; ok {
+			return true, "namespace label " + istioRevisionLabel
+		}
+	case MeshLinkerd:
+		if value, ok := pod.Annotations[linkerdInjectAnnotation]; ok {
+			return value != injectionDisabled, "pod annotation " + linkerdInjectAnnotation
+		}
+		if value, ok := namespace.Annotations[linkerdInjectAnnotation]; ok {
+			return value != injectionDisabled, "namespace annotation " + linkerdInjectAnnotation
+		}
+	}
+	return false, ""
+}
+
+// countMeshedPods returns the mesh most of the meshed pods take part in, the number of pods and
+// the number of meshed pods.
+func countMeshedPods(pods []v1.Pod) (Mesh, int, int) {
+	counts := make(map[Mesh]int)
+	meshed := 0
+	for i := range pods {
+		if mesh, _ := sidecarOf(&pods[i]); len(mesh) > 0 {
+			counts[mesh]++
+			meshed++
+		}
+	}
+
+	var result Mesh
+	for _, mesh := range []Mesh{MeshIstio, MeshLinkerd} {
+		if counts[mesh] > counts[result] {
+			result = mesh
+		}
+	}
+	return result, len(pods), meshed
+}
+
+// listMTLSPolicies returns peer authentication policies in the namespace and in Istio root
+// namespace. Missing security API is the same as no policies.
+func listMTLSPolicies(restClients map[schema.GroupVersion]RESTClient, namespace string) ([]peerAuthentication, error) {
+	namespaces := []string{namespace}
+	if namespace != IstioRootNamespace {
+		namespaces = append(namespaces, IstioRootNamespace)
+	}
+
+	result := make([]peerAuthentication, 0)
+	for _, ns := range namespaces {
+		policies, err := listPeerAuthentications(restClients, ns)
+		if errorsK8s.IsNotFound(err) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, policies...)
+	}
+	return result, nil
+}
+
+// resolveMTLS returns mutual TLS mode of workloads with the labels and the policy setting it.
+// Workload policies take precedence over namespace policies, which take precedence over mesh
+// policies in the root namespace. Policies with unset mode inherit the mode, and the oldest policy
+// wins when there is more than one on the same level, the same as in Istio.
+func resolveMTLS(policies []peerAuthentication, namespace string, workloadLabels map[string]string) (string,
+	string) {
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].CreationTimestamp.Before(policies[j].CreationTimestamp)
+	})
+
+	var mesh, ns, workload *peerAuthentication
+	for i := range policies {
+		policy := &policies[i]
+		selector := policy.selector()
+		switch {
+		case policy.Namespace == namespace && len(selector) > 0:
+			if workload == nil && labels.SelectorFromSet(selector).Matches(labels.Set(workloadLabels)) {
+				workload = policy
+			}
+		case policy.Namespace == namespace && namespace != IstioRootNamespace:
+			if ns == nil {
+				ns = policy
+			}
+		case policy.Namespace == IstioRootNamespace && len(selector) == 0:
+			if mesh == nil {
+				mesh = policy
+			}
+		}
+	}
+
+	mode, source := mtlsPermissive, ""
+	for _, policy := range []*peerAuthentication{mesh, ns, workload} {
+		if policy != nil && policy.mode() != mtlsUnset {
+			mode, source = policy.mode(), policy.Namespace+"/"+policy.Name
+		}
+	}
+	return mode, source
+}
@@ -0,0 +1,240 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicemesh
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+var testMeshObjects = map[string]string{
+	virtualServiceResource: `{"items": [
+	  {"metadata": {"name": "reviews", "namespace": "default"},
+	   "spec": {"hosts": ["reviews"], "http": [{"route": [{"destination": {"host": "reviews", "subset": "v2"}}]}]}},
+	  {"metadata": {"name": "gateway", "namespace": "istio-system"},
+	   "spec": {"hosts": ["bookinfo.example.com"], "gateways": ["bookinfo"],
+	            "http": [{"route": [{"destination": {"host": "reviews.default.svc.cluster.local"}}]}]}},
+	  {"metadata": {"name": "ratings", "namespace": "default"}, "spec": {"hosts": ["ratings"]}}]}`,
+	destinationRuleResource: `{"items": [
+	  {"metadata": {"name": "reviews", "namespace": "default"},
+	   "spec": {"host": "reviews.default.svc.cluster.local", "subsets": [{"name": "v1"}, {"name": "v2"}],
+	            "trafficPolicy": {"tls": {"mode": "ISTIO_MUTUAL"}}}},
+	  {"metadata": {"name": "ratings", "namespace": "default"}, "spec": {"host": "ratings"}}]}`,
+	peerAuthenticationResource + "/default": `{"items": [
+	  {"metadata": {"name": "default", "namespace": "default"}, "spec": {"mtls": {"mode": "STRICT"}}}]}`,
+	peerAuthenticationResource + "/istio-system": `{"items": [
+	  {"metadata": {"name": "default", "namespace": "istio-system"}, "spec": {"mtls": {"mode": "PERMISSIVE"}}}]}`,
+	serviceProfileResource: `{"items": [
+	  {"metadata": {"name": "reviews.default.svc.cluster.local", "namespace": "default"},
+	   "spec": {"routes": [{"name": "GET /reviews"}]}}]}`,
+}
+
+// newMeshRESTClients returns clients of service mesh APIs, which serve test objects.
+func newMeshRESTClients(t *testing.T) (map[schema.GroupVersion]RESTClient, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Path is /apis/<group>/<version>[/namespaces/<namespace>]/<resource>.
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")[3:]
+		resource := parts[len(parts)-1]
+		raw, ok := testMeshObjects[resource]
+		if len(parts) == 3 {
+			if namespaced, exists := testMeshObjects[resource+"/"+parts[1]]; exists {
+				raw, ok = namespaced, true
+			}
+		}
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(raw))
+	}))
+	restClients, err := newRESTClients(&rest.Config{Host: server.URL})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return restClients, server.Close
+}
+
+func newMeshClient(objects ...runtime.Object) *fake.Clientset {
+	client := fake.NewSimpleClientset(objects...)
+	client.Resources = []*metaV1.APIResourceList{
+		{GroupVersion: IstioNetworkingGroupVersion.String(),
+			APIResources: []metaV1.APIResource{{Name: virtualServiceResource}}},
+		{GroupVersion: LinkerdGroupVersion.String(),
+			APIResources: []metaV1.APIResource{{Name: serviceProfileResource}}},
+	}
+	return client
+}
+
+func newPod(name string, podLabels map[string]string, containers ...string) *v1.Pod {
+	pod := &v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default", Labels: podLabels}}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: container, Image: container + ":1.0"})
+	}
+	return pod
+}
+
+func TestGetPodMesh(t *testing.T) {
+	restClients, closeServer := newMeshRESTClients(t)
+	defer closeServer()
+
+	namespace := &v1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "default",
+		Labels: map[string]string{istioInjectionLabel: "enabled"}}}
+	client := newMeshClient(namespace,
+		newPod("meshed", nil, "app", istioProxyContainer),
+		newPod("pending", nil, "app"),
+		newPod("excluded", map[string]string{istioInjectLabel: "false"}, "app"))
+
+	cases := []struct {
+		name     string
+		expected *PodMesh
+	}{
+		{"meshed", &PodMesh{Mesh: MeshIstio, SidecarInjected: true, ProxyImage: "istio-proxy:1.0",
+			InjectionEnabled: true, InjectionSource: "namespace label istio-injection", MTLSMode: "STRICT",
+			MTLSPolicy: "default/default"}},
+		{"pending", &PodMesh{Mesh: MeshIstio, InjectionEnabled: true,
+			InjectionSource: "namespace label istio-injection"}},
+		{"excluded", &PodMesh{}},
+	}
+	for _, c := range cases {
+		actual, err := getPodMesh(client, restClients, "default", c.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("GetPodMesh(%#v) == %#v, expected %#v", c.name, actual, c.expected)
+		}
+	}
+
+	if actual, err := getPodMesh(fake.NewSimpleClientset(), restClients, "default", "meshed"); actual != nil ||
+		err == nil {
+		t.Errorf("GetPodMesh() == %#v, %v without discovery, expected error", actual, err)
+	}
+}
+
+func TestGetServiceMesh(t *testing.T) {
+	restClients, closeServer := newMeshRESTClients(t)
+	defer closeServer()
+
+	selector := map[string]string{"app": "reviews"}
+	client := newMeshClient(
+		&v1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "reviews", Namespace: "default"},
+			Spec: v1.ServiceSpec{Selector: selector}},
+		newPod("reviews-1", selector, "app", istioProxyContainer),
+		newPod("reviews-2", selector, "app"))
+
+	actual, err := getServiceMesh(client, restClients, "default", "reviews")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual.Mesh != MeshIstio || actual.Pods != 2 || actual.MeshedPods != 1 || actual.MTLSMode != "STRICT" {
+		t.Errorf("GetServiceMesh() == %#v, expected Istio with 1 of 2 meshed pods and strict mTLS", actual)
+	}
+
+	virtualServices := make([]string, 0)
+	for _, item := range actual.VirtualServices {
+		virtualServices = append(virtualServices, item.ObjectMeta.Namespace+"/"+item.ObjectMeta.Name)
+	}
+	if !reflect.DeepEqual(virtualServices, []string{"default/reviews", "istio-system/gateway"}) {
+		t.Errorf("GetServiceMesh() returns virtual services %#v", virtualServices)
+	}
+	if len(actual.DestinationRules) != 1 || actual.DestinationRules[0].TLSMode != "ISTIO_MUTUAL" ||
+		!reflect.DeepEqual(actual.DestinationRules[0].Subsets, []string{"v1", "v2"}) {
+		t.Errorf("GetServiceMesh() returns destination rules %#v", actual.DestinationRules)
+	}
+	if len(actual.ServiceProfiles) != 1 ||
+		!reflect.DeepEqual(actual.ServiceProfiles[0].Routes, []string{"GET /reviews"}) {
+		t.Errorf("GetServiceMesh() returns service profiles %#v", actual.ServiceProfiles)
+	}
+}
+
+func TestResolveMTLS(t *testing.T) {
+	newPolicy := func(namespace, name, mode string, selector map[string]string, created int64) peerAuthentication {
+		policy := peerAuthentication{ObjectMeta: metaV1.ObjectMeta{Namespace: namespace, Name: name,
+			CreationTimestamp: metaV1.Unix(created, 0)}}
+		if selector != nil {
+			policy.Spec.Selector = &struct {
+				MatchLabels map[string]string `json:"matchLabels"`
+			}{selector}
+		}
+		if len(mode) > 0 {
+			policy.Spec.MTLS = &struct {
+				Mode string `json:"mode"`
+			}{mode}
+		}
+		return policy
+	}
+
+	cases := []struct {
+		policies     []peerAuthentication
+		labels       map[string]string
+		mode, policy string
+	}{
+		{nil, nil, "PERMISSIVE", ""},
+		{[]peerAuthentication{newPolicy("istio-system", "mesh", "STRICT", nil, 1)}, nil, "STRICT",
+			"istio-system/mesh"},
+		{[]peerAuthentication{
+			newPolicy("istio-system", "mesh", "STRICT", nil, 1),
+			newPolicy("default", "namespace", "DISABLE", nil, 2),
+		}, nil, "DISABLE", "default/namespace"},
+		{[]peerAuthentication{
+			newPolicy("istio-system", "mesh", "STRICT", nil, 1),
+			newPolicy("default", "unset", "", nil, 2),
+			newPolicy("default", "db", "PERMISSIVE", map[string]string{"app": "db"}, 3),
+		}, map[string]string{"app": "web"}, "STRICT", "istio-system/mesh"},
+		{[]peerAuthentication{
+			newPolicy("default", "newer", "STRICT", map[string]string{"app": "web"}, 5),
+			newPolicy("default", "older", "PERMISSIVE", map[string]string{"app": "web"}, 4),
+			newPolicy("default", "namespace", "STRICT", nil, 3),
+		}, map[string]string{"app": "web", "version": "v1"}, "PERMISSIVE", "default/older"},
+	}
+	for _, c := range cases {
+		mode, policy := resolveMTLS(c.policies, "default", c.labels)
+		if mode != c.mode || policy != c.policy {
+			t.Errorf("resolveMTLS(%#v) == %s, %s, expected %s, %s", c.policies, mode, policy, c.mode, c.policy)
+		}
+	}
+}
+
+func TestHostMatchesService(t *testing.T) {
+	cases := []struct {
+		host, namespace string
+		expected        bool
+	}{
+		{"reviews", "default", true},
+		{"reviews", "other", false},
+		{"reviews.default", "other", true},
+		{"reviews.default.svc.cluster.local", "other", true},
+		{"reviews.other.svc.cluster.local", "default", false},
+		{"*.default.svc.cluster.local", "other", true},
+		{"ratings", "default", false},
+	}
+	for _, c := range cases {
+		if actual := hostMatchesService(c.host, c.namespace, "reviews", "default"); actual != c.expected {
+			t.Errorf("hostMatchesService(%#v, %#v) == %t, expected %t", c.host, c.namespace, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,411 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicemesh
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+// VirtualService provides the presentation layer view of Istio virtual service.
+type VirtualService struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	Hosts    []string `json:"hosts"`
+	Gateways []string `json:"gateways"`
+
+	// Destinations are hosts of all HTTP, TLS and TCP routes.
+	Destinations []string `json:"destinations"`
+}
+
+// VirtualServiceList contains a list of Istio virtual services.
+type VirtualServiceList struct {
+	ListMeta        api.ListMeta     `json:"listMeta"`
+	VirtualServices []VirtualService `json:"virtualServices"`
+}
+
+// DestinationRule provides the presentation layer view of Istio destination rule.
+type DestinationRule struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	Host    string   `json:"host"`
+	Subsets []string `json:"subsets"`
+
+	// TLSMode is the TLS mode used by clients of the host, e.g. ISTIO_MUTUAL or DISABLE.
+	TLSMode      string `json:"tlsMode"`
+	LoadBalancer string `json:"loadBalancer"`
+}
+
+// DestinationRuleList contains a list of Istio destination rules.
+type DestinationRuleList struct {
+	ListMeta         api.ListMeta      `json:"listMeta"`
+	DestinationRules []DestinationRule `json:"destinationRules"`
+}
+
+// PeerAuthentication provides the presentation layer view of Istio peer authentication policy.
+type PeerAuthentication struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Selector of workloads the policy applies to. Empty selector applies to the whole namespace.
+	Selector map[string]string `json:"selector"`
+
+	// Mode is the mutual TLS mode, one of STRICT, PERMISSIVE, DISABLE or UNSET.
+	Mode string `json:"mode"`
+}
+
+// PeerAuthenticationList contains a list of Istio peer authentication policies.
+type PeerAuthenticationList struct {
+	ListMeta            api.ListMeta         `json:"listMeta"`
+	PeerAuthentications []PeerAuthentication `json:"peerAuthentications"`
+}
+
+// ServiceProfile provides the presentation layer view of Linkerd service profile.
+type ServiceProfile struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Routes are names of routes defined by the profile.
+	Routes []string `json:"routes"`
+
+	// RetryBudget is set, when retries of the routes are limited.
+	RetryBudget bool `json:"retryBudget"`
+}
+
+// ServiceProfileList contains a list of Linkerd service profiles.
+type ServiceProfileList struct {
+	ListMeta        api.ListMeta     `json:"listMeta"`
+	ServiceProfiles []ServiceProfile `json:"serviceProfiles"`
+}
+
+// virtualService is the API representation of Istio virtual service. Client library does not
+// contain service mesh types, so only the fields used by Dashboard are declared in this package.
+type virtualService struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Hosts    []string `json:"hosts"`
+		Gateways []string `json:"gateways"`
+		HTTP     []route  `json:"http"`
+		TLS      []route  `json:"tls"`
+		TCP      []route  `json:"tcp"`
+	} `json:"spec"`
+}
+
+type route struct {
+	Route []struct {
+		Destination struct {
+			Host   string `json:"host"`
+			Subset string `json:"subset"`
+		} `json:"destination"`
+	} `json:"route"`
+}
+
+type destinationRule struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Host          string `json:"host"`
+		TrafficPolicy *struct {
+			TLS *struct {
+				Mode string `json:"mode"`
+			} `json:"tls"`
+			LoadBalancer *struct {
+				Simple string `json:"simple"`
+			} `json:"loadBalancer"`
+		} `json:"trafficPolicy"`
+		Subsets []struct {
+			Name string `json:"name"`
+		} `json:"subsets"`
+	} `json:"spec"`
+}
+
+type peerAuthentication struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Selector *struct {
+			MatchLabels map[string]string `json:"matchLabels"`
+		} `json:"selector"`
+		MTLS *struct {
+			Mode string `json:"mode"`
+		} `json:"mtls"`
+	} `json:"spec"`
+}
+
+type serviceProfile struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Routes []struct {
+			Name string `json:"name"`
+		} `json:"routes"`
+		RetryBudget *json.RawMessage `json:"retryBudget"`
+	} `json:"spec"`
+}
+
+// GetVirtualServiceList returns Istio virtual services in the namespaces.
+func GetVirtualServiceList(config *rest.Config, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*VirtualServiceList, error) {
+	logger.Info("Getting list of Istio virtual services")
+
+	restClients, err := newRESTClients(config)
+	if err != nil {
+		return nil, err
+	}
+	items, err := listVirtualServices(restClients, nsQuery.ToRequestParam())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]VirtualService, 0, len(items))
+	for _, item := range items {
+		if nsQuery.Matches(item.Namespace) {
+			result = append(result, toVirtualService(item))
+		}
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toVirtualServiceCells(result), dsQuery)
+	return &VirtualServiceList{
+		ListMeta:        api.ListMeta{TotalItems: filteredTotal},
+		VirtualServices: fromVirtualServiceCells(cells),
+	}, nil
+}
+
+// GetDestinationRuleList returns Istio destination rules in the namespaces.
+func GetDestinationRuleList(config *rest.Config, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*DestinationRuleList, error) {
+	logger.Info("Getting list of Istio destination rules")
+
+	restClients, err := newRESTClients(config)
+	if err != nil {
+		return nil, err
+	}
+	items, err := listDestinationRules(restClients, nsQuery.ToRequestParam())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]DestinationRule, 0, len(items))
+	for _, item := range items {
+		if nsQuery.Matches(item.Namespace) {
+			result = append(result, toDestinationRule(item))
+		}
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toDestinationRuleCells(result), dsQuery)
+	return &DestinationRuleList{
+		ListMeta:         api.ListMeta{TotalItems: filteredTotal},
+		DestinationRules: fromDestinationRuleCells(cells),
+	}, nil
+}
+
+// GetPeerAuthenticationList returns Istio peer authentication policies in the namespaces.
+func GetPeerAuthenticationList(config *rest.Config, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*PeerAuthenticationList, error) {
+	logger.Info("Getting list of Istio peer authentication policies")
+
+	restClients, err := newRESTClients(config)
+	if err != nil {
+		return nil, err
+	}
+	items, err := listPeerAuthentications(restClients, nsQuery.ToRequestParam())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]PeerAuthentication, 0, len(items))
+	for _, item := range items {
+		if nsQuery.Matches(item.Namespace) {
+			result = append(result, toPeerAuthentication(item))
+		}
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toPeerAuthenticationCells(result), dsQuery)
+	return &PeerAuthenticationList{
+		ListMeta:            api.ListMeta{TotalItems: filteredTotal},
+		PeerAuthentications: fromPeerAuthenticationCells(cells),
+	}, nil
+}
+
+// GetServiceProfileList returns Linkerd service profiles in the namespaces.
+func GetServiceProfileList(config *rest.Config, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*ServiceProfileList, error) {
+	logger.Info("Getting list of Linkerd service profiles")
+
+	restClients, err := newRESTClients(config)
+	if err != nil {
+		return nil, err
+	}
+	items, err := listServiceProfiles(restClients, nsQuery.ToRequestParam())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]ServiceProfile, 0, len(items))
+	for _, item := range items {
+		if nsQuery.Matches(item.Namespace) {
+			result = append(result, toServiceProfile(item))
+		}
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toServiceProfileCells(result), dsQuery)
+	return &ServiceProfileList{
+		ListMeta:        api.ListMeta{TotalItems: filteredTotal},
+		ServiceProfiles: fromServiceProfileCells(cells),
+	}, nil
+}
+
+func listVirtualServices(restClients map[schema.GroupVersion]RESTClient, namespace string) ([]virtualService, error) {
+	list := struct {
+		Items []virtualService `json:"items"`
+	}{}
+	err := listInto(restClients, IstioNetworkingGroupVersion, virtualServiceResource, namespace, &list)
+	return list.Items, err
+}
+
+func listDestinationRules(restClients map[schema.GroupVersion]RESTClient, namespace string) ([]destinationRule, error) {
+	list := struct {
+		Items []destinationRule `json:"items"`
+	}{}
+	err := listInto(restClients, IstioNetworkingGroupVersion, destinationRuleResource, namespace, &list)
+	return list.Items, err
+}
+
+func listPeerAuthentications(restClients map[schema.GroupVersion]RESTClient, namespace string) (
+	[]peerAuthentication, error) {
+	list := struct {
+		Items []peerAuthentication `json:"items"`
+	}{}
+	err := listInto(restClients, IstioSecurityGroupVersion, peerAuthenticationResource, namespace, &list)
+	return list.Items, err
+}
+
+func listServiceProfiles(restClients map[schema.GroupVersion]RESTClient, namespace string) ([]serviceProfile, error) {
+	list := struct {
+		Items []serviceProfile `json:"items"`
+	}{}
+	err := listInto(restClients, LinkerdGroupVersion, serviceProfileResource, namespace, &list)
+	return list.Items, err
+}
+
+func listInto(restClients map[schema.GroupVersion]RESTClient, gv schema.GroupVersion, resource,
+	namespace string, list interface{}) error {
+	raw, err := listRaw(restClients, gv, resource, namespace)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, list)
+}
+
+func toVirtualService(item virtualService) VirtualService {
+	result := VirtualService{
+		ObjectMeta:   api.NewObjectMeta(item.ObjectMeta),
+		TypeMeta:     api.NewTypeMeta(api.ResourceKindVirtualService),
+		Hosts:        item.Spec.Hosts,
+		Gateways:     item.Spec.Gateways,
+		Destinations: item.destinations(),
+	}
+	if result.Hosts == nil {
+		result.Hosts = make([]string, 0)
+	}
+	if result.Gateways == nil {
+		result.Gateways = make([]string, 0)
+	}
+	return result
+}
+
+// destinations returns sorted unique destination hosts of all routes.
+func (self virtualService) destinations() []string {
+	hosts := make(map[string]bool)
+	for _, routes := range [][]route{self.Spec.HTTP, self.Spec.TLS, self.Spec.TCP} {
+		for _, r := range routes {
+			for _, destination := range r.Route {
+				hosts[destination.Destination.Host] = true
+			}
+		}
+	}
+
+	result := make([]string, 0, len(hosts))
+	for host := range hosts {
+		result = append(result, host)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func toDestinationRule(item destinationRule) DestinationRule {
+	result := DestinationRule{
+		ObjectMeta: api.NewObjectMeta(item.ObjectMeta),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindDestinationRule),
+		Host:       item.Spec.Host,
+		Subsets:    make([]string, 0, len(item.Spec.Subsets)),
+	}
+	for _, subset := range item.Spec.Subsets {
+		result.Subsets = append(result.Subsets, subset.Name)
+	}
+	if policy := item.Spec.TrafficPolicy; policy != nil {
+		if policy.TLS != nil {
+			result.TLSMode = policy.TLS.Mode
+		}
+		if policy.LoadBalancer != nil {
+			result.LoadBalancer = policy.LoadBalancer.Simple
+		}
+	}
+	return result
+}
+
+func toPeerAuthentication(item peerAuthentication) PeerAuthentication {
+	result := PeerAuthentication{
+		ObjectMeta: api.NewObjectMeta(item.ObjectMeta),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindPeerAuthentication),
+		Selector:   item.selector(),
+		Mode:       item.mode(),
+	}
+	if result.Selector == nil {
+		result.Selector = make(map[string]string)
+	}
+	return result
+}
+
+func (self peerAuthentication) selector() map[string]string {
+	if self.Spec.Selector == nil {
+		return nil
+	}
+	return self.Spec.Selector.MatchLabels
+}
+
+// mode returns mutual TLS mode of the policy. Policies without mode inherit it from the parent.
+func (self peerAuthentication) mode() string {
+	if self.Spec.MTLS == nil || len(self.Spec.MTLS.Mode) == 0 {
+		return mtlsUnset
+	}
+	return self.Spec.MTLS.Mode
+}
+
+func toServiceProfile(item serviceProfile) ServiceProfile {
+	result := ServiceProfile{
+		ObjectMeta:  api.NewObjectMeta(item.ObjectMeta),
+		TypeMeta:    api.NewTypeMeta(api.ResourceKindServiceProfile),
+		Routes:      make([]string, 0, len(item.Spec.Routes)),
+		RetryBudget: item.Spec.RetryBudget != nil,
+	}
+	for _, r := range item.Spec.Routes {
+		result.Routes = append(result.Routes, r.Name)
+	}
+	return result
+}
@@ -15,13 +15,13 @@
 package statefulset
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	ds "github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/gitops"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/pod"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sClient "k8s.io/client-go/kubernetes"
@@ -38,6 +38,9 @@ type StatefulSetDetail struct {
 	ContainerImages []string         `json:"containerImages"`
 	EventList       common.EventList `json:"eventList"`
 
+	// GitOps tool managing the stateful set. Set only when it is synced by Argo CD or Flux.
+	GitOps *gitops.Ownership `json:"gitops,omitempty"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -45,7 +48,7 @@ type StatefulSetDetail struct {
 // GetStatefulSetDetail gets Stateful Set details.
 func GetStatefulSetDetail(client *k8sClient.Clientset, metricClient metricapi.MetricClient, namespace,
 	name string) (*StatefulSetDetail, error) {
-	log.Printf("Getting details of %s statefulset in %s namespace", name, namespace)
+	logger.Infof("Getting details of %s statefulset in %s namespace", name, namespace)
 
 	ss, err := client.AppsV1beta1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
 	if err != nil {
@@ -15,14 +15,15 @@
 package statefulset
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diagnosis"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	"github.com/kubernetes/dashboard/src/app/backend/scaling"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api/v1"
 	apps "k8s.io/client-go/pkg/apis/apps/v1beta1"
@@ -50,6 +51,9 @@ type StatefulSet struct {
 	// Aggregate information about pods belonging to this Pet Set.
 	Pods common.PodInfo `json:"pods"`
 
+	// Desired and actual number of replicas, as reported by the scale subresource.
+	Replicas scaling.ReplicaCounts `json:"replicas"`
+
 	// Container images of the Stateful Set.
 	ContainerImages []string `json:"containerImages"`
 }
@@ -57,15 +61,22 @@ type StatefulSet struct {
 // GetStatefulSetList returns a list of all Stateful Sets in the cluster.
 func GetStatefulSetList(client *client.Clientset, nsQuery *common.NamespaceQuery,
 	dsQuery *dataselect.DataSelectQuery, metricClient metricapi.MetricClient) (*StatefulSetList, error) {
-	log.Print("Getting list of all pet sets in the cluster")
+	logger.Info("Getting list of all pet sets in the cluster")
 
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
 	channels := &common.ResourceChannels{
-		StatefulSetList: common.GetStatefulSetListChannel(client, nsQuery, 1),
+		StatefulSetList: common.GetStatefulSetListChunkChannel(client, nsQuery, chunk, 1),
 		PodList:         common.GetPodListChannel(client, nsQuery, 1),
-		EventList:       common.GetEventListChannel(client, nsQuery, 1),
+		EventList:       common.GetChunkEventListChannel(client, nsQuery, chunk, 1),
+	}
+
+	statefulSetList, err := GetStatefulSetListFromChannels(channels, dsQuery, metricClient)
+	if err != nil {
+		return nil, err
 	}
 
-	return GetStatefulSetListFromChannels(channels, dsQuery, metricClient)
+	chunk.SetListMeta(&statefulSetList.ListMeta)
+	return statefulSetList, nil
 }
 
 // GetStatefulSetListFromChannels returns a list of all Stateful Sets in the cluster reading
@@ -119,6 +130,7 @@ func toStatefulSetList(statefulSets []apps.StatefulSet, pods []v1.Pod, events []
 		// TODO(floreks): Conversion should be omitted when client type will be updated
 		podInfo := common.GetPodInfo(statefulSet.Status.Replicas, *statefulSet.Spec.Replicas, matchingPods)
 		podInfo.Warnings = event.GetPodsEventWarnings(events, matchingPods)
+		podInfo.Diagnosis = diagnosis.DiagnosePods(matchingPods, events)
 		statefulSetList.StatefulSets = append(statefulSetList.StatefulSets, toStatefulSet(&statefulSet, &podInfo))
 	}
 
@@ -137,5 +149,6 @@ func toStatefulSet(statefulSet *apps.StatefulSet, podInfo *common.PodInfo) State
 		TypeMeta:        api.NewTypeMeta(api.ResourceKindStatefulSet),
 		ContainerImages: common.GetContainerImages(&statefulSet.Spec.Template.Spec),
 		Pods:            *podInfo,
+		Replicas:        scaling.NewReplicaCounts(statefulSet.Spec.Replicas, statefulSet.Status.Replicas),
 	}
 }
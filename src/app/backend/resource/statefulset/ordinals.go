@@ -0,0 +1,245 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statefulset
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	apps "k8s.io/client-go/pkg/apis/apps/v1beta1"
+)
+
+// OrdinalClaim is a persistent volume claim created from a volume claim template for one ordinal.
+type OrdinalClaim struct {
+	// Name of the volume claim template.
+	Template string `json:"template"`
+
+	Name         string                           `json:"name"`
+	Phase        v1.PersistentVolumeClaimPhase    `json:"phase"`
+	Capacity     string                           `json:"capacity"`
+	StorageClass string                           `json:"storageClass"`
+	Volume       string                           `json:"volume"`
+	VolumePhase  v1.PersistentVolumePhase         `json:"volumePhase"`
+	Reclaim      v1.PersistentVolumeReclaimPolicy `json:"reclaimPolicy"`
+}
+
+// StatefulSetOrdinal describes pod and claims of a single stateful set ordinal.
+type StatefulSetOrdinal struct {
+	Ordinal int `json:"ordinal"`
+
+	// Name of the pod. Empty if the pod does not exist.
+	Pod      string      `json:"pod"`
+	Phase    v1.PodPhase `json:"phase"`
+	Ready    bool        `json:"ready"`
+	NodeName string      `json:"nodeName"`
+
+	// True when the ordinal is not lower than desired replicas, i.e. only retained claims remain.
+	ScaledDown bool `json:"scaledDown"`
+
+	Claims []OrdinalClaim `json:"claims"`
+}
+
+// StatefulSetOrdinals lists ordinals of a stateful set including ordinals above desired replicas, which
+// still have claims.
+type StatefulSetOrdinals struct {
+	Replicas int32 `json:"replicas"`
+
+	// Rolling update strategy of the stateful set.
+	UpdateStrategy StatefulSetUpdateStrategy `json:"updateStrategy"`
+
+	Ordinals []StatefulSetOrdinal `json:"ordinals"`
+}
+
+// GetStatefulSetOrdinals returns pods and claims of the stateful set grouped by ordinal.
+func GetStatefulSetOrdinals(client k8sClient.Interface, namespace, name string) (*StatefulSetOrdinals, error) {
+	logger.Infof("Getting ordinals of %s stateful set in %s namespace", name, namespace)
+
+	statefulSet, err := client.AppsV1beta1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	strategy, err := GetStatefulSetUpdateStrategy(client.AppsV1beta1().RESTClient(), namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	nsQuery := common.NewSameNamespaceQuery(namespace)
+	channels := &common.ResourceChannels{
+		PodList:                   common.GetPodListChannel(client, nsQuery, 1),
+		PersistentVolumeClaimList: common.GetPersistentVolumeClaimListChannel(client, nsQuery, 1),
+		PersistentVolumeList:      common.GetPersistentVolumeListChannel(client, 1),
+	}
+
+	pods := <-channels.PodList.List
+	if err := <-channels.PodList.Error; err != nil {
+		return nil, err
+	}
+	claims := <-channels.PersistentVolumeClaimList.List
+	if err := <-channels.PersistentVolumeClaimList.Error; err != nil {
+		return nil, err
+	}
+	volumes := <-channels.PersistentVolumeList.List
+	if err := <-channels.PersistentVolumeList.Error; err != nil {
+		return nil, err
+	}
+
+	ownPods := common.FilterPodsByOwnerReference(namespace, statefulSet.UID, pods.Items)
+	ordinals := toStatefulSetOrdinals(statefulSet, ownPods, claims.Items, volumes.Items)
+	return &StatefulSetOrdinals{
+		Replicas:       getReplicas(statefulSet),
+		UpdateStrategy: *strategy,
+		Ordinals:       ordinals,
+	}, nil
+}
+
+func toStatefulSetOrdinals(statefulSet *apps.StatefulSet, pods []v1.Pod, claims []v1.PersistentVolumeClaim,
+	volumes []v1.PersistentVolume) []StatefulSetOrdinal {
+	replicas := int(getReplicas(statefulSet))
+	byOrdinal := make(map[int]*StatefulSetOrdinal)
+	get := func(ordinal int) *StatefulSetOrdinal {
+		if _, exists := byOrdinal[ordinal]; !exists {
+			byOrdinal[ordinal] = &StatefulSetOrdinal{
+				Ordinal:    ordinal,
+				ScaledDown: ordinal >= replicas,
+				Claims:     make([]OrdinalClaim, 0),
+			}
+		}
+		return byOrdinal[ordinal]
+	}
+
+	for i := 0; i < replicas; i++ {
+		get(i)
+	}
+
+	for _, pod := range pods {
+		ordinal, ok := parseOrdinal(statefulSet.Name, pod.Name)
+		if !ok {
+			continue
+		}
+		o := get(ordinal)
+		o.Pod = pod.Name
+		o.Phase = pod.Status.Phase
+		o.Ready = isPodReady(pod)
+		o.NodeName = pod.Spec.NodeName
+	}
+
+	volumesByName := make(map[string]v1.PersistentVolume)
+	for _, volume := range volumes {
+		volumesByName[volume.Name] = volume
+	}
+
+	for _, claim := range claims {
+		for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+			ordinal, ok := parseOrdinal(template.Name+"-"+statefulSet.Name, claim.Name)
+			if !ok {
+				continue
+			}
+			o := get(ordinal)
+			o.Claims = append(o.Claims, toOrdinalClaim(template.Name, claim, volumesByName))
+		}
+	}
+
+	result := make([]StatefulSetOrdinal, 0, len(byOrdinal))
+	for _, o := range byOrdinal {
+		sort.Slice(o.Claims, func(i, j int) bool { return o.Claims[i].Name < o.Claims[j].Name })
+		result = append(result, *o)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Ordinal < result[j].Ordinal })
+	return result
+}
+
+func toOrdinalClaim(template string, claim v1.PersistentVolumeClaim,
+	volumes map[string]v1.PersistentVolume) OrdinalClaim {
+	result := OrdinalClaim{
+		Template: template,
+		Name:     claim.Name,
+		Phase:    claim.Status.Phase,
+		Volume:   claim.Spec.VolumeName,
+	}
+	if storage, exists := claim.Status.Capacity[v1.ResourceStorage]; exists {
+		result.Capacity = storage.String()
+	}
+	if claim.Spec.StorageClassName != nil {
+		result.StorageClass = *claim.Spec.StorageClassName
+	} else {
+		result.StorageClass = claim.Annotations[v1.BetaStorageClassAnnotation]
+	}
+	if volume, exists := volumes[claim.Spec.VolumeName]; exists {
+		result.VolumePhase = volume.Status.Phase
+		result.Reclaim = volume.Spec.PersistentVolumeReclaimPolicy
+	}
+	return result
+}
+
+// DeleteStatefulSetPod deletes pod with the given ordinal. The stateful set controller creates it again
+// with the same identity and claims.
+func DeleteStatefulSetPod(client k8sClient.Interface, namespace, name string, ordinal int) error {
+	statefulSet, err := client.AppsV1beta1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	podName := fmt.Sprintf("%s-%d", name, ordinal)
+	pod, err := client.CoreV1().Pods(namespace).Get(podName, metaV1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if len(common.FilterPodsByOwnerReference(namespace, statefulSet.UID, []v1.Pod{*pod})) == 0 {
+		return errorsK8s.NewNotFound(schema.GroupResource{Resource: "pods"}, podName)
+	}
+
+	logger.Infof("Deleting pod with ordinal %d of %s stateful set in %s namespace", ordinal, name, namespace)
+	return client.CoreV1().Pods(namespace).Delete(podName, &metaV1.DeleteOptions{})
+}
+
+// parseOrdinal returns the ordinal of a pod or claim named <prefix>-<ordinal>.
+func parseOrdinal(prefix, name string) (int, bool) {
+	if !strings.HasPrefix(name, prefix+"-") {
+		return 0, false
+	}
+	suffix := strings.TrimPrefix(name, prefix+"-")
+	ordinal, err := strconv.Atoi(suffix)
+	if err != nil || ordinal < 0 || strconv.Itoa(ordinal) != suffix {
+		return 0, false
+	}
+	return ordinal, true
+}
+
+func isPodReady(pod v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// getReplicas returns desired number of replicas, which defaults to 1.
+func getReplicas(statefulSet *apps.StatefulSet) int32 {
+	if statefulSet.Spec.Replicas == nil {
+		return 1
+	}
+	return *statefulSet.Spec.Replicas
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statefulset
+
+import (
+	"reflect"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	apps "k8s.io/client-go/pkg/apis/apps/v1beta1"
+)
+
+func newOrdinalTestStatefulSet(replicas int32) *apps.StatefulSet {
+	return &apps.StatefulSet{
+		ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default", UID: "web-uid"},
+		Spec: apps.StatefulSetSpec{
+			Replicas: &replicas,
+			VolumeClaimTemplates: []v1.PersistentVolumeClaim{
+				{ObjectMeta: metaV1.ObjectMeta{Name: "data"}},
+			},
+		},
+	}
+}
+
+func newOrdinalTestPod(name string, owner *apps.StatefulSet) *v1.Pod {
+	controller := true
+	pod := &v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"}}
+	if owner != nil {
+		pod.OwnerReferences = []metaV1.OwnerReference{{UID: owner.UID, Controller: &controller}}
+	}
+	return pod
+}
+
+func newOrdinalTestClaim(name, volume string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       v1.PersistentVolumeClaimSpec{VolumeName: volume},
+		Status: v1.PersistentVolumeClaimStatus{
+			Phase:    v1.ClaimBound,
+			Capacity: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")},
+		},
+	}
+}
+
+func TestToStatefulSetOrdinals(t *testing.T) {
+	statefulSet := newOrdinalTestStatefulSet(2)
+	pod := newOrdinalTestPod("web-0", statefulSet)
+	pod.Spec.NodeName = "node-1"
+	pod.Status = v1.PodStatus{
+		Phase:      v1.PodRunning,
+		Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+	}
+	volume := v1.PersistentVolume{
+		ObjectMeta: metaV1.ObjectMeta{Name: "pv-0"},
+		Spec:       v1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimRetain},
+		Status:     v1.PersistentVolumeStatus{Phase: v1.VolumeBound},
+	}
+
+	actual := toStatefulSetOrdinals(statefulSet, []v1.Pod{*pod},
+		[]v1.PersistentVolumeClaim{
+			*newOrdinalTestClaim("data-web-0", "pv-0"),
+			*newOrdinalTestClaim("data-web-3", ""),
+			*newOrdinalTestClaim("data-web-x", ""),
+			*newOrdinalTestClaim("logs-web-0", ""),
+		},
+		[]v1.PersistentVolume{volume})
+
+	expected := []StatefulSetOrdinal{
+		{
+			Ordinal: 0, Pod: "web-0", Phase: v1.PodRunning, Ready: true, NodeName: "node-1",
+			Claims: []OrdinalClaim{{Template: "data", Name: "data-web-0", Phase: v1.ClaimBound,
+				Capacity: "1Gi", Volume: "pv-0", VolumePhase: v1.VolumeBound,
+				Reclaim: v1.PersistentVolumeReclaimRetain}},
+		},
+		{Ordinal: 1, Claims: []OrdinalClaim{}},
+		{
+			Ordinal: 3, ScaledDown: true,
+			Claims: []OrdinalClaim{{Template: "data", Name: "data-web-3", Phase: v1.ClaimBound,
+				Capacity: "1Gi"}},
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("toStatefulSetOrdinals() == \ngot: %#v, \nexpected %#v", actual, expected)
+	}
+}
+
+func TestParseOrdinal(t *testing.T) {
+	cases := []struct {
+		prefix, name string
+		ordinal      int
+		ok           bool
+	}{
+		{"web", "web-0", 0, true},
+		{"web", "web-12", 12, true},
+		{"web", "web-01", 0, false},
+		{"web", "web-db-0", 0, false},
+		{"web", "webapp-0", 0, false},
+		{"data-web", "data-web-2", 2, true},
+	}
+
+	for _, c := range cases {
+		ordinal, ok := parseOrdinal(c.prefix, c.name)
+		if ordinal != c.ordinal || ok != c.ok {
+			t.Errorf("parseOrdinal(%q, %q) == %d, %t, expected %d, %t", c.prefix, c.name, ordinal, ok,
+				c.ordinal, c.ok)
+		}
+	}
+}
+
+func TestDeleteStatefulSetPod(t *testing.T) {
+	statefulSet := newOrdinalTestStatefulSet(2)
+	fakeClient := fake.NewSimpleClientset(statefulSet, newOrdinalTestPod("web-0", statefulSet),
+		newOrdinalTestPod("web-1", nil))
+
+	if err := DeleteStatefulSetPod(fakeClient, "default", "web", 0); err != nil {
+		t.Fatalf("DeleteStatefulSetPod(0): unexpected error %v", err)
+	}
+	if _, err := fakeClient.CoreV1().Pods("default").Get("web-0", metaV1.GetOptions{}); !errorsK8s.IsNotFound(err) {
+		t.Errorf("Expected pod web-0 to be deleted, got %v", err)
+	}
+
+	if err := DeleteStatefulSetPod(fakeClient, "default", "web", 1); !errorsK8s.IsNotFound(err) {
+		t.Errorf("DeleteStatefulSetPod(1) == %v, expected not found for a pod not owned by the stateful set", err)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statefulset
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Update strategy types of stateful sets.
+const (
+	RollingUpdateStrategyType = "RollingUpdate"
+	OnDeleteStrategyType      = "OnDelete"
+)
+
+// StatefulSetUpdateStrategy contains update strategy of a stateful set, which is not part of the typed
+// stateful set of the client library yet.
+type StatefulSetUpdateStrategy struct {
+	Type string `json:"type"`
+
+	// Pods with ordinal lower than partition are not updated during rolling update.
+	Partition *int32 `json:"partition"`
+}
+
+// PartitionSpec is a request to change partition of a rolling update.
+type PartitionSpec struct {
+	Partition int32 `json:"partition"`
+}
+
+// rawStatefulSet is used to decode update strategy of a stateful set.
+type rawStatefulSet struct {
+	Spec struct {
+		UpdateStrategy struct {
+			Type          string `json:"type"`
+			RollingUpdate *struct {
+				Partition *int32 `json:"partition"`
+			} `json:"rollingUpdate"`
+		} `json:"updateStrategy"`
+	} `json:"spec"`
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Get() *rest.Request
+}
+
+// GetStatefulSetUpdateStrategy returns update strategy of the stateful set decoded from its raw object.
+// Rest client is the client of apps API group.
+func GetStatefulSetUpdateStrategy(restClient RESTClient, namespace, name string) (
+	*StatefulSetUpdateStrategy, error) {
+	raw, err := restClient.Get().
+		Namespace(namespace).
+		Resource("statefulsets").
+		Name(name).
+		Do().
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	statefulSet := &rawStatefulSet{}
+	if err := json.Unmarshal(raw, statefulSet); err != nil {
+		return nil, err
+	}
+
+	strategy := &StatefulSetUpdateStrategy{Type: statefulSet.Spec.UpdateStrategy.Type}
+	if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil {
+		strategy.Partition = rollingUpdate.Partition
+	}
+	return strategy, nil
+}
+
+// SetStatefulSetPartition changes partition of the stateful set rolling update. Pods with ordinal
+// greater or equal to the partition are updated, when the pod template changes. Setting the partition
+// to the number of replicas pauses the update, setting it to zero updates all pods.
+func SetStatefulSetPartition(client k8sClient.Interface, namespace, name string, spec *PartitionSpec) (
+	*StatefulSetUpdateStrategy, error) {
+	return setStatefulSetPartition(client, client.AppsV1beta1().RESTClient(), namespace, name, spec)
+}
+
+func setStatefulSetPartition(client k8sClient.Interface, restClient RESTClient, namespace, name string,
+	spec *PartitionSpec) (*StatefulSetUpdateStrategy, error) {
+	statefulSet, err := client.AppsV1beta1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := getReplicas(statefulSet)
+	if spec.Partition < 0 || spec.Partition > replicas {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("partition must be between 0 and %d", replicas))
+	}
+
+	strategy, err := GetStatefulSetUpdateStrategy(restClient, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if strategy.Type == OnDeleteStrategyType {
+		return nil, errorsK8s.NewBadRequest("stateful set uses OnDelete update strategy, partition applies " +
+			"only to rolling updates")
+	}
+
+	logger.Infof("Setting rolling update partition of %s stateful set in %s namespace to %d", name, namespace,
+		spec.Partition)
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"updateStrategy": map[string]interface{}{
+				"type":          RollingUpdateStrategyType,
+				"rollingUpdate": map[string]interface{}{"partition": spec.Partition},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = client.AppsV1beta1().StatefulSets(namespace).Patch(name, types.MergePatchType, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	partition := spec.Partition
+	return &StatefulSetUpdateStrategy{Type: RollingUpdateStrategyType, Partition: &partition}, nil
+}
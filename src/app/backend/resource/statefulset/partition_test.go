@@ -0,0 +1,99 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statefulset
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	core "k8s.io/client-go/testing"
+)
+
+func TestSetStatefulSetPartition(t *testing.T) {
+	var strategy string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/apps/v1beta1/namespaces/default/statefulsets/web" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"spec":{"updateStrategy":{"type":%q}}}`, strategy)
+	}))
+	defer server.Close()
+	restClient, err := apply.NewRESTClient(&rest.Config{Host: server.URL},
+		schema.GroupVersion{Group: "apps", Version: "v1beta1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		info          string
+		strategy      string
+		partition     int32
+		expectedPatch string
+		expectedCode  int32
+	}{
+		{"pause rollout", RollingUpdateStrategyType, 3,
+			`{"spec":{"updateStrategy":{"rollingUpdate":{"partition":3},"type":"RollingUpdate"}}}`, 0},
+		{"update all pods", RollingUpdateStrategyType, 0,
+			`{"spec":{"updateStrategy":{"rollingUpdate":{"partition":0},"type":"RollingUpdate"}}}`, 0},
+		{"partition above replicas", RollingUpdateStrategyType, 4, "", 400},
+		{"negative partition", RollingUpdateStrategyType, -1, "", 400},
+		{"on delete strategy", OnDeleteStrategyType, 1, "", 400},
+	}
+
+	for _, c := range cases {
+		strategy = c.strategy
+
+		fakeClient := fake.NewSimpleClientset(newOrdinalTestStatefulSet(3))
+		patch := ""
+		fakeClient.PrependReactor("patch", "statefulsets",
+			func(action core.Action) (bool, runtime.Object, error) {
+				patch = string(action.(core.PatchActionImpl).Patch)
+				return true, newOrdinalTestStatefulSet(3), nil
+			})
+
+		actual, err := setStatefulSetPartition(fakeClient, restClient, "default", "web",
+			&PartitionSpec{Partition: c.partition})
+		if c.expectedCode != 0 {
+			statusErr, ok := err.(*errorsK8s.StatusError)
+			if !ok || statusErr.ErrStatus.Code != c.expectedCode {
+				t.Errorf("%s: expected error with code %d, got %v", c.info, c.expectedCode, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", c.info, err)
+			continue
+		}
+		if patch != c.expectedPatch {
+			t.Errorf("%s: patch == %s, expected %s", c.info, patch, c.expectedPatch)
+		}
+		expected := &StatefulSetUpdateStrategy{Type: RollingUpdateStrategyType, Partition: &c.partition}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: SetStatefulSetPartition() == %#v, expected %#v", c.info, actual, expected)
+		}
+	}
+}
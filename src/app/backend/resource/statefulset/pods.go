@@ -15,9 +15,8 @@
 package statefulset
 
 import (
-	"log"
-
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
@@ -32,7 +31,7 @@ import (
 func GetStatefulSetPods(client *k8sClient.Clientset, metricClient metricapi.MetricClient,
 	dsQuery *dataselect.DataSelectQuery, name, namespace string) (*pod.PodList, error) {
 
-	log.Printf("Getting replication controller %s pods in namespace %s", name, namespace)
+	logger.Infof("Getting replication controller %s pods in namespace %s", name, namespace)
 
 	pods, err := getRawStatefulSetPods(client, name, namespace)
 	if err != nil {
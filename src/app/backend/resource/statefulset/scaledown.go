@@ -0,0 +1,183 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statefulset
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// Pods removed by scale down are polled every scaleDownInterval. Their claims are kept, if the pods do
+// not terminate within scaleDownTimeout.
+var (
+	scaleDownInterval = 2 * time.Second
+	scaleDownTimeout  = 15 * time.Minute
+)
+
+// ScaleDownSpec is a request to scale down a stateful set.
+type ScaleDownSpec struct {
+	Replicas int32 `json:"replicas"`
+
+	// DeleteClaims deletes claims of the removed ordinals once their pods terminate. Claims are retained
+	// by default, so that the data is reused when the stateful set is scaled up again.
+	DeleteClaims bool `json:"deleteClaims"`
+}
+
+// ScaleDownResult describes the outcome of a stateful set scale down.
+type ScaleDownResult struct {
+	PreviousReplicas int32 `json:"previousReplicas"`
+	Replicas         int32 `json:"replicas"`
+
+	// Claims of removed ordinals, which are kept.
+	RetainedClaims []string `json:"retainedClaims"`
+
+	// Claims of removed ordinals, which are deleted once their pods terminate.
+	DeletedClaims []string `json:"deletedClaims"`
+}
+
+// ScaleDownStatefulSet lowers the number of replicas of the stateful set and returns the claims of the
+// removed ordinals. Claims are not deleted by this function, see DeleteScaledDownClaims.
+func ScaleDownStatefulSet(client k8sClient.Interface, namespace, name string, spec *ScaleDownSpec) (
+	*ScaleDownResult, error) {
+	statefulSet, err := client.AppsV1beta1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	current := getReplicas(statefulSet)
+	if spec.Replicas < 0 || spec.Replicas >= current {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("replicas must be between 0 and %d", current-1))
+	}
+
+	claims, err := client.CoreV1().PersistentVolumeClaims(namespace).List(metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	removed := make([]string, 0)
+	for _, claim := range claims.Items {
+		for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+			ordinal, ok := parseOrdinal(template.Name+"-"+name, claim.Name)
+			if ok && ordinal >= int(spec.Replicas) && ordinal < int(current) {
+				removed = append(removed, claim.Name)
+			}
+		}
+	}
+	sort.Strings(removed)
+
+	logger.Infof("Scaling down %s stateful set in %s namespace from %d to %d replicas", name, namespace,
+		current, spec.Replicas)
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{"replicas": spec.Replicas},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.AppsV1beta1().StatefulSets(namespace).Patch(name, types.MergePatchType, patch); err != nil {
+		return nil, err
+	}
+
+	result := &ScaleDownResult{
+		PreviousReplicas: current,
+		Replicas:         spec.Replicas,
+		RetainedClaims:   removed,
+		DeletedClaims:    make([]string, 0),
+	}
+	if spec.DeleteClaims {
+		result.RetainedClaims, result.DeletedClaims = result.DeletedClaims, removed
+	}
+	return result, nil
+}
+
+// DeleteScaledDownClaims waits until pods with ordinal greater or equal to replicas terminate and then
+// deletes the given claims. Claims of ordinals, which became desired again in the meantime, are kept.
+func DeleteScaledDownClaims(client k8sClient.Interface, namespace, name string, replicas int32,
+	claims []string) error {
+	deadline := time.Now().Add(scaleDownTimeout)
+	for {
+		statefulSet, err := client.AppsV1beta1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if current := getReplicas(statefulSet); current > replicas {
+			replicas = current
+		}
+
+		pods, err := client.CoreV1().Pods(namespace).List(metaV1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		if !hasPodsAbove(name, replicas, common.FilterPodsByOwnerReference(namespace, statefulSet.UID,
+			pods.Items)) {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("pods of %s stateful set did not terminate in %s, claims %v were kept", name,
+				scaleDownTimeout, claims)
+		}
+		time.Sleep(scaleDownInterval)
+	}
+
+	statefulSet, err := client.AppsV1beta1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if current := getReplicas(statefulSet); current > replicas {
+		replicas = current
+	}
+
+	for _, claim := range claims {
+		if !isClaimOfRemovedOrdinal(statefulSet.Spec.VolumeClaimTemplates, name, claim, replicas) {
+			logger.Infof("Keeping claim %s, its ordinal is desired again", claim)
+			continue
+		}
+
+		logger.Infof("Deleting claim %s of scaled down %s stateful set in %s namespace", claim, name, namespace)
+		err := client.CoreV1().PersistentVolumeClaims(namespace).Delete(claim, &metaV1.DeleteOptions{})
+		if err != nil && !errorsK8s.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasPodsAbove(name string, replicas int32, pods []v1.Pod) bool {
+	for _, pod := range pods {
+		if ordinal, ok := parseOrdinal(name, pod.Name); ok && ordinal >= int(replicas) {
+			return true
+		}
+	}
+	return false
+}
+
+func isClaimOfRemovedOrdinal(templates []v1.PersistentVolumeClaim, name, claim string, replicas int32) bool {
+	for _, template := range templates {
+		if ordinal, ok := parseOrdinal(template.Name+"-"+name, claim); ok {
+			return ordinal >= int(replicas)
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statefulset
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	core "k8s.io/client-go/testing"
+)
+
+func TestScaleDownStatefulSet(t *testing.T) {
+	cases := []struct {
+		info     string
+		spec     ScaleDownSpec
+		expected *ScaleDownResult
+	}{
+		{
+			"retain claims",
+			ScaleDownSpec{Replicas: 1},
+			&ScaleDownResult{PreviousReplicas: 3, Replicas: 1,
+				RetainedClaims: []string{"data-web-1", "data-web-2"}, DeletedClaims: []string{}},
+		},
+		{
+			"delete claims",
+			ScaleDownSpec{Replicas: 2, DeleteClaims: true},
+			&ScaleDownResult{PreviousReplicas: 3, Replicas: 2,
+				RetainedClaims: []string{}, DeletedClaims: []string{"data-web-2"}},
+		},
+	}
+
+	for _, c := range cases {
+		fakeClient := fake.NewSimpleClientset(newOrdinalTestStatefulSet(3),
+			newOrdinalTestClaim("data-web-0", ""), newOrdinalTestClaim("data-web-1", ""),
+			newOrdinalTestClaim("data-web-2", ""), newOrdinalTestClaim("data-web-5", ""))
+		patch := ""
+		fakeClient.PrependReactor("patch", "statefulsets",
+			func(action core.Action) (bool, runtime.Object, error) {
+				patch = string(action.(core.PatchActionImpl).Patch)
+				return true, newOrdinalTestStatefulSet(c.spec.Replicas), nil
+			})
+
+		actual, err := ScaleDownStatefulSet(fakeClient, "default", "web", &c.spec)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", c.info, err)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: ScaleDownStatefulSet() == \ngot: %#v, \nexpected %#v", c.info, actual, c.expected)
+		}
+		if patch == "" {
+			t.Errorf("%s: expected replicas to be patched", c.info)
+		}
+	}
+
+	fakeClient := fake.NewSimpleClientset(newOrdinalTestStatefulSet(3))
+	_, err := ScaleDownStatefulSet(fakeClient, "default", "web", &ScaleDownSpec{Replicas: 3})
+	if !errorsK8s.IsBadRequest(err) {
+		t.Errorf("Expected bad request when replicas are not lowered, got %v", err)
+	}
+}
+
+func TestDeleteScaledDownClaims(t *testing.T) {
+	scaleDownInterval = time.Millisecond
+	defer func() { scaleDownInterval = 2 * time.Second }()
+
+	statefulSet := newOrdinalTestStatefulSet(1)
+	fakeClient := fake.NewSimpleClientset(statefulSet, newOrdinalTestPod("web-1", statefulSet),
+		newOrdinalTestClaim("data-web-1", ""), newOrdinalTestClaim("data-web-2", ""))
+
+	// The pod terminates after a few polls.
+	polls := 0
+	fakeClient.PrependReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		polls++
+		if polls >= 3 {
+			return true, &v1.PodList{}, nil
+		}
+		return false, nil, nil
+	})
+
+	// Ordinal 1 is desired again, because the stateful set was scaled up after the pod terminated.
+	scaled := newOrdinalTestStatefulSet(2)
+	gets := 0
+	fakeClient.PrependReactor("get", "statefulsets", func(action core.Action) (bool, runtime.Object, error) {
+		gets++
+		if gets > 3 {
+			return true, scaled, nil
+		}
+		return false, nil, nil
+	})
+
+	err := DeleteScaledDownClaims(fakeClient, "default", "web", 1, []string{"data-web-1", "data-web-2"})
+	if err != nil {
+		t.Fatalf("DeleteScaledDownClaims(): unexpected error %v", err)
+	}
+
+	if polls != 3 {
+		t.Errorf("Expected claims to be deleted after pods terminated, pods were listed %d times", polls)
+	}
+	assertClaimExists(t, fakeClient, "data-web-1", true)
+	assertClaimExists(t, fakeClient, "data-web-2", false)
+}
+
+func assertClaimExists(t *testing.T, client kubernetes.Interface, name string, expected bool) {
+	_, err := client.CoreV1().PersistentVolumeClaims("default").Get(name, metaV1.GetOptions{})
+	if exists := err == nil; exists != expected {
+		t.Errorf("Claim %s exists == %t, expected %t (%v)", name, exists, expected, err)
+	}
+}
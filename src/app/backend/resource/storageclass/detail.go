@@ -15,11 +15,18 @@
 package storageclass
 
 import (
-	"log"
+	"encoding/json"
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	storage "k8s.io/client-go/pkg/apis/storage/v1beta1"
+	"k8s.io/client-go/rest"
 )
 
 // StorageClass is a representation of a kubernetes StorageClass object.
@@ -42,15 +49,200 @@ type StorageClass struct {
 	Parameters map[string]string `json:"parameters"`
 }
 
-// GetStorageClass returns storage class object.
-func GetStorageClass(client kubernetes.Interface, name string) (*StorageClass, error) {
-	log.Printf("Getting details of %s storage class", name)
+// StorageClassDetail provides the presentation layer view of Kubernetes StorageClass resource
+// together with volumes provisioned by it and claims requesting it.
+type StorageClassDetail struct {
+	ObjectMeta  api.ObjectMeta    `json:"objectMeta"`
+	TypeMeta    api.TypeMeta      `json:"typeMeta"`
+	Provisioner string            `json:"provisioner"`
+	Parameters  map[string]string `json:"parameters"`
+
+	// ReclaimPolicy of dynamically provisioned volumes. Defaults to Delete.
+	ReclaimPolicy string `json:"reclaimPolicy"`
+
+	// VolumeBindingMode tells when volumes are provisioned and bound. Defaults to Immediate.
+	VolumeBindingMode    string `json:"volumeBindingMode"`
+	AllowVolumeExpansion bool   `json:"allowVolumeExpansion"`
+
+	PersistentVolumes      []StorageClassVolume `json:"persistentVolumes"`
+	PersistentVolumeClaims []StorageClassClaim  `json:"persistentVolumeClaims"`
+
+	// Capacity is the total capacity of persistent volumes of the storage class.
+	Capacity string `json:"capacity"`
+
+	// RequestedCapacity is the total storage requested by persistent volume claims of the storage
+	// class.
+	RequestedCapacity string `json:"requestedCapacity"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// StorageClassVolume is persistent volume of the storage class.
+type StorageClassVolume struct {
+	ObjectMeta api.ObjectMeta           `json:"objectMeta"`
+	TypeMeta   api.TypeMeta             `json:"typeMeta"`
+	Capacity   string                   `json:"capacity"`
+	Status     v1.PersistentVolumePhase `json:"status"`
+	Claim      string                   `json:"claim"`
+}
+
+// StorageClassClaim is persistent volume claim of the storage class.
+type StorageClassClaim struct {
+	ObjectMeta api.ObjectMeta                `json:"objectMeta"`
+	TypeMeta   api.TypeMeta                  `json:"typeMeta"`
+	Request    string                        `json:"request"`
+	Status     v1.PersistentVolumeClaimPhase `json:"status"`
+	Volume     string                        `json:"volume"`
+}
+
+// StorageClassPolicy contains storage class fields, which are not part of the typed storage class
+// of the client library yet.
+type StorageClassPolicy struct {
+	ReclaimPolicy        *string `json:"reclaimPolicy"`
+	VolumeBindingMode    *string `json:"volumeBindingMode"`
+	AllowVolumeExpansion *bool   `json:"allowVolumeExpansion"`
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Get() *rest.Request
+}
+
+// GetStorageClassPolicy returns policy fields of the storage class decoded from its raw object.
+// Rest client is the client of storage API group.
+func GetStorageClassPolicy(restClient RESTClient, name string) (*StorageClassPolicy, error) {
+	raw, err := restClient.Get().
+		Resource("storageclasses").
+		Name(name).
+		Do().
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &StorageClassPolicy{}
+	if err := json.Unmarshal(raw, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// GetStorageClass returns storage class detail.
+func GetStorageClass(client kubernetes.Interface, name string) (*StorageClassDetail, error) {
+	return getStorageClass(client, client.StorageV1().RESTClient(), name)
+}
+
+func getStorageClass(client kubernetes.Interface, restClient RESTClient, name string) (*StorageClassDetail,
+	error) {
+	logger.Infof("Getting details of %s storage class", name)
+
+	channels := &common.ResourceChannels{
+		PersistentVolumeList:      common.GetPersistentVolumeListChannel(client, 1),
+		PersistentVolumeClaimList: common.GetPersistentVolumeClaimListChannel(client, common.NewNamespaceQuery(nil), 1),
+	}
 
 	storage, err := client.StorageV1beta1().StorageClasses().Get(name, metaV1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
+	policy, err := GetStorageClassPolicy(restClient, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pvs := <-channels.PersistentVolumeList.List
+	err = <-channels.PersistentVolumeList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	pvcs := <-channels.PersistentVolumeClaimList.List
+	err = <-channels.PersistentVolumeClaimList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	return toStorageClassDetail(storage, policy, pvs.Items, pvcs.Items, nonCriticalErrors), nil
+}
+
+func toStorageClassDetail(storageClass *storage.StorageClass, policy *StorageClassPolicy,
+	pvs []v1.PersistentVolume, pvcs []v1.PersistentVolumeClaim, nonCriticalErrors []error) *StorageClassDetail {
+	result := &StorageClassDetail{
+		ObjectMeta:             api.NewObjectMeta(storageClass.ObjectMeta),
+		TypeMeta:               api.NewTypeMeta(api.ResourceKindStorageClass),
+		Provisioner:            storageClass.Provisioner,
+		Parameters:             storageClass.Parameters,
+		ReclaimPolicy:          string(v1.PersistentVolumeReclaimDelete),
+		VolumeBindingMode:      "Immediate",
+		PersistentVolumes:      make([]StorageClassVolume, 0),
+		PersistentVolumeClaims: make([]StorageClassClaim, 0),
+		Errors:                 nonCriticalErrors,
+	}
+	if policy.ReclaimPolicy != nil {
+		result.ReclaimPolicy = *policy.ReclaimPolicy
+	}
+	if policy.VolumeBindingMode != nil {
+		result.VolumeBindingMode = *policy.VolumeBindingMode
+	}
+	result.AllowVolumeExpansion = policy.AllowVolumeExpansion != nil && *policy.AllowVolumeExpansion
+
+	capacity := resource.Quantity{}
+	for _, pv := range pvs {
+		if getVolumeStorageClass(&pv) != storageClass.Name {
+			continue
+		}
+		volumeCapacity := pv.Spec.Capacity[v1.ResourceStorage]
+		capacity.Add(volumeCapacity)
+
+		volume := StorageClassVolume{
+			ObjectMeta: api.NewObjectMeta(pv.ObjectMeta),
+			TypeMeta:   api.NewTypeMeta(api.ResourceKindPersistentVolume),
+			Capacity:   volumeCapacity.String(),
+			Status:     pv.Status.Phase,
+		}
+		if pv.Spec.ClaimRef != nil {
+			volume.Claim = pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name
+		}
+		result.PersistentVolumes = append(result.PersistentVolumes, volume)
+	}
+
+	requested := resource.Quantity{}
+	for _, pvc := range pvcs {
+		if getClaimStorageClass(&pvc) != storageClass.Name {
+			continue
+		}
+		request := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+		requested.Add(request)
+
+		result.PersistentVolumeClaims = append(result.PersistentVolumeClaims, StorageClassClaim{
+			ObjectMeta: api.NewObjectMeta(pvc.ObjectMeta),
+			TypeMeta:   api.NewTypeMeta(api.ResourceKindPersistentVolumeClaim),
+			Request:    request.String(),
+			Status:     pvc.Status.Phase,
+			Volume:     pvc.Spec.VolumeName,
+		})
+	}
 
-	storageClass := toStorageClass(storage)
-	return &storageClass, nil
+	result.Capacity = capacity.String()
+	result.RequestedCapacity = requested.String()
+	return result
+}
+
+// getVolumeStorageClass returns storage class of the volume, falling back to the beta annotation.
+func getVolumeStorageClass(pv *v1.PersistentVolume) string {
+	if len(pv.Spec.StorageClassName) > 0 {
+		return pv.Spec.StorageClassName
+	}
+	return pv.Annotations[v1.BetaStorageClassAnnotation]
+}
+
+// getClaimStorageClass returns storage class of the claim, falling back to the beta annotation.
+func getClaimStorageClass(pvc *v1.PersistentVolumeClaim) string {
+	if pvc.Spec.StorageClassName != nil {
+		return *pvc.Spec.StorageClassName
+	}
+	return pvc.Annotations[v1.BetaStorageClassAnnotation]
 }
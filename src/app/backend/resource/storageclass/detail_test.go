@@ -0,0 +1,149 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storageclass
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+	storage "k8s.io/client-go/pkg/apis/storage/v1beta1"
+	"k8s.io/client-go/rest"
+)
+
+func TestGetStorageClass(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/storage.k8s.io/v1/storageclasses/fast" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"metadata": {"name": "fast"}, "reclaimPolicy": "Retain", "allowVolumeExpansion": true}`))
+	}))
+	defer server.Close()
+	restClient, err := apply.NewRESTClient(&rest.Config{Host: server.URL},
+		schema.GroupVersion{Group: "storage.k8s.io", Version: "v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fast := "fast"
+	slow := "slow"
+	fakeClient := fake.NewSimpleClientset(
+		&storage.StorageClass{
+			ObjectMeta:  metaV1.ObjectMeta{Name: "fast"},
+			Provisioner: "kubernetes.io/gce-pd",
+			Parameters:  map[string]string{"type": "pd-ssd"},
+		},
+		&v1.PersistentVolume{
+			ObjectMeta: metaV1.ObjectMeta{Name: "pv-1"},
+			Spec: v1.PersistentVolumeSpec{
+				StorageClassName: "fast",
+				Capacity:         v1.ResourceList{v1.ResourceStorage: resource.MustParse("10Gi")},
+				ClaimRef:         &v1.ObjectReference{Namespace: "default", Name: "data"},
+			},
+			Status: v1.PersistentVolumeStatus{Phase: v1.VolumeBound},
+		},
+		&v1.PersistentVolume{
+			ObjectMeta: metaV1.ObjectMeta{Name: "pv-2", Annotations: map[string]string{
+				v1.BetaStorageClassAnnotation: "fast"}},
+			Spec: v1.PersistentVolumeSpec{
+				Capacity: v1.ResourceList{v1.ResourceStorage: resource.MustParse("5Gi")},
+			},
+			Status: v1.PersistentVolumeStatus{Phase: v1.VolumeAvailable},
+		},
+		&v1.PersistentVolume{
+			ObjectMeta: metaV1.ObjectMeta{Name: "pv-3"},
+			Spec: v1.PersistentVolumeSpec{
+				StorageClassName: "slow",
+				Capacity:         v1.ResourceList{v1.ResourceStorage: resource.MustParse("100Gi")},
+			},
+		},
+		&v1.PersistentVolumeClaim{
+			ObjectMeta: metaV1.ObjectMeta{Name: "data", Namespace: "default"},
+			Spec: v1.PersistentVolumeClaimSpec{
+				StorageClassName: &fast,
+				VolumeName:       "pv-1",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("8Gi")},
+				},
+			},
+			Status: v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound},
+		},
+		&v1.PersistentVolumeClaim{
+			ObjectMeta: metaV1.ObjectMeta{Name: "archive", Namespace: "default"},
+			Spec: v1.PersistentVolumeClaimSpec{
+				StorageClassName: &slow,
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("50Gi")},
+				},
+			},
+		},
+	)
+
+	actual, err := getStorageClass(fakeClient, restClient, "fast")
+	if err != nil {
+		t.Fatalf("GetStorageClass() returned error: %s", err)
+	}
+
+	expected := &StorageClassDetail{
+		ObjectMeta:           api.ObjectMeta{Name: "fast"},
+		TypeMeta:             api.TypeMeta{Kind: api.ResourceKindStorageClass},
+		Provisioner:          "kubernetes.io/gce-pd",
+		Parameters:           map[string]string{"type": "pd-ssd"},
+		ReclaimPolicy:        "Retain",
+		VolumeBindingMode:    "Immediate",
+		AllowVolumeExpansion: true,
+		PersistentVolumes: []StorageClassVolume{
+			{
+				ObjectMeta: api.ObjectMeta{Name: "pv-1"},
+				TypeMeta:   api.TypeMeta{Kind: api.ResourceKindPersistentVolume},
+				Capacity:   "10Gi",
+				Status:     v1.VolumeBound,
+				Claim:      "default/data",
+			},
+			{
+				ObjectMeta: api.ObjectMeta{Name: "pv-2", Annotations: map[string]string{
+					v1.BetaStorageClassAnnotation: "fast"}},
+				TypeMeta: api.TypeMeta{Kind: api.ResourceKindPersistentVolume},
+				Capacity: "5Gi",
+				Status:   v1.VolumeAvailable,
+			},
+		},
+		PersistentVolumeClaims: []StorageClassClaim{
+			{
+				ObjectMeta: api.ObjectMeta{Name: "data", Namespace: "default"},
+				TypeMeta:   api.TypeMeta{Kind: api.ResourceKindPersistentVolumeClaim},
+				Request:    "8Gi",
+				Status:     v1.ClaimBound,
+				Volume:     "pv-1",
+			},
+		},
+		Capacity:          "15Gi",
+		RequestedCapacity: "8Gi",
+		Errors:            []error{},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetStorageClass() == \ngot %#v, \nexpected %#v", actual, expected)
+	}
+}
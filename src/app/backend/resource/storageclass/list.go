@@ -15,10 +15,9 @@
 package storageclass
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"k8s.io/client-go/kubernetes"
@@ -37,13 +36,20 @@ type StorageClassList struct {
 // GetStorageClassList returns a list of all storage class objects in the cluster.
 func GetStorageClassList(client kubernetes.Interface, dsQuery *dataselect.DataSelectQuery) (
 	*StorageClassList, error) {
-	log.Print("Getting list of storage classes in the cluster")
+	logger.Info("Getting list of storage classes in the cluster")
 
+	chunk := common.NewListChunk(dsQuery.ChunkQuery)
 	channels := &common.ResourceChannels{
-		StorageClassList: common.GetStorageClassListChannel(client, 1),
+		StorageClassList: common.GetStorageClassListChunkChannel(client, chunk, 1),
+	}
+
+	storageClassList, err := GetStorageClassListFromChannels(channels, dsQuery)
+	if err != nil {
+		return nil, err
 	}
 
-	return GetStorageClassListFromChannels(channels, dsQuery)
+	chunk.SetListMeta(&storageClassList.ListMeta)
+	return storageClassList, nil
 }
 
 // GetStorageClassListFromChannels returns a list of all storage class objects in the cluster.
@@ -15,9 +15,8 @@
 package thirdpartyresource
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sClient "k8s.io/client-go/kubernetes"
@@ -36,7 +35,7 @@ type ThirdPartyResourceDetail struct {
 
 // GetThirdPartyResourceDetail returns detailed information about a third party resource.
 func GetThirdPartyResourceDetail(client k8sClient.Interface, config *rest.Config, name string) (*ThirdPartyResourceDetail, error) {
-	log.Printf("Getting details of %s third party resource", name)
+	logger.Infof("Getting details of %s third party resource", name)
 
 	thirdPartyResource, err := client.ExtensionsV1beta1().ThirdPartyResources().Get(name, metaV1.GetOptions{})
 	if err != nil {
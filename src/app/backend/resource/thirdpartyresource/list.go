@@ -15,10 +15,9 @@
 package thirdpartyresource
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	k8sClient "k8s.io/client-go/kubernetes"
@@ -43,7 +42,7 @@ type ThirdPartyResourceList struct {
 
 // GetThirdPartyResourceList returns a list of third party resource templates.
 func GetThirdPartyResourceList(client k8sClient.Interface, dsQuery *dataselect.DataSelectQuery) (*ThirdPartyResourceList, error) {
-	log.Println("Getting list of third party resources")
+	logger.Info("Getting list of third party resources")
 
 	channels := &common.ResourceChannels{
 		ThirdPartyResourceList: common.GetThirdPartyResourceListChannel(client, 1),
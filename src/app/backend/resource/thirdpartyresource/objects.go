@@ -15,11 +15,11 @@
 package thirdpartyresource
 
 import (
-	"log"
 	"strings"
 
 	"github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -52,7 +52,7 @@ type ThirdPartyResourceObjectList struct {
 func GetThirdPartyResourceObjects(client k8sClient.Interface, config *rest.Config,
 	dsQuery *dataselect.DataSelectQuery, tprName string) (ThirdPartyResourceObjectList, error) {
 
-	log.Printf("Getting third party resource %s objects", tprName)
+	logger.Infof("Getting third party resource %s objects", tprName)
 	var list ThirdPartyResourceObjectList
 
 	thirdPartyResource, err := client.ExtensionsV1beta1().ThirdPartyResources().Get(tprName, metaV1.GetOptions{})
@@ -0,0 +1,322 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package velero
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/rest"
+)
+
+// Backup provides the presentation layer view of Velero backup.
+type Backup struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// IncludedNamespaces are backed up namespaces. Empty list means all namespaces.
+	IncludedNamespaces []string `json:"includedNamespaces"`
+	ExcludedNamespaces []string `json:"excludedNamespaces"`
+
+	StorageLocation string `json:"storageLocation"`
+	TTL             string `json:"ttl"`
+
+	// Schedule is the name of schedule, which created the backup. Empty for manual backups.
+	Schedule string `json:"schedule"`
+
+	// Phase is one of New, InProgress, Completed, PartiallyFailed, Failed, FailedValidation or
+	// Deleting.
+	Phase         string `json:"phase"`
+	FailureReason string `json:"failureReason,omitempty"`
+	Errors        int    `json:"errors"`
+	Warnings      int    `json:"warnings"`
+
+	ItemsBackedUp int `json:"itemsBackedUp"`
+	TotalItems    int `json:"totalItems"`
+
+	StartTimestamp      *metaV1.Time `json:"startTimestamp,omitempty"`
+	CompletionTimestamp *metaV1.Time `json:"completionTimestamp,omitempty"`
+	Expiration          *metaV1.Time `json:"expiration,omitempty"`
+}
+
+// BackupList contains a list of Velero backups.
+type BackupList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+	Backups  []Backup     `json:"backups"`
+
+	// Number of all backups, before data select, that failed.
+	Failed int `json:"failed"`
+}
+
+// BackupDetail is a Velero backup with restores from it.
+type BackupDetail struct {
+	Backup `json:",inline"`
+
+	Restores []Restore `json:"restores"`
+}
+
+// BackupSpec describes backup of a namespace to create.
+type BackupSpec struct {
+	// Namespace to back up.
+	Namespace string `json:"namespace"`
+
+	// Name is optional. Name of the namespace with a timestamp is used when it is empty.
+	Name string `json:"name"`
+
+	// StorageLocation is optional. Default backup storage location is used when it is empty.
+	StorageLocation string `json:"storageLocation"`
+
+	// TTL is optional, e.g. 720h. Velero keeps backups for 30 days when it is empty.
+	TTL string `json:"ttl"`
+
+	// SnapshotVolumes is optional. Velero takes snapshots of persistent volumes when it is not set.
+	SnapshotVolumes *bool `json:"snapshotVolumes"`
+}
+
+// backup is the API representation of Velero backup.
+type backup struct {
+	metaV1.TypeMeta   `json:",inline"`
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              backupSpec    `json:"spec"`
+	Status            *backupStatus `json:"status,omitempty"`
+}
+
+type backupSpec struct {
+	IncludedNamespaces []string         `json:"includedNamespaces,omitempty"`
+	ExcludedNamespaces []string         `json:"excludedNamespaces,omitempty"`
+	StorageLocation    string           `json:"storageLocation,omitempty"`
+	TTL                *metaV1.Duration `json:"ttl,omitempty"`
+	SnapshotVolumes    *bool            `json:"snapshotVolumes,omitempty"`
+}
+
+type backupStatus struct {
+	Phase               string          `json:"phase"`
+	FailureReason       string          `json:"failureReason"`
+	Errors              int             `json:"errors"`
+	Warnings            int             `json:"warnings"`
+	Progress            *backupProgress `json:"progress"`
+	StartTimestamp      *metaV1.Time    `json:"startTimestamp"`
+	CompletionTimestamp *metaV1.Time    `json:"completionTimestamp"`
+	Expiration          *metaV1.Time    `json:"expiration"`
+}
+
+type backupProgress struct {
+	TotalItems    int `json:"totalItems"`
+	ItemsBackedUp int `json:"itemsBackedUp"`
+}
+
+type backupList struct {
+	Items []backup `json:"items"`
+}
+
+// GetBackupList returns Velero backups in the namespaces.
+func GetBackupList(config *rest.Config, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*BackupList, error) {
+	restClient, err := newRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return getBackupList(restClient, nsQuery, dsQuery)
+}
+
+func getBackupList(restClient RESTClient, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*BackupList, error) {
+	logger.Info("Getting list of Velero backups")
+
+	raw, err := getRaw(restClient, backupResource, nsQuery.ToRequestParam(), "")
+	if err != nil {
+		return nil, err
+	}
+	list := backupList{}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+
+	result := &BackupList{Backups: make([]Backup, 0)}
+	items := make([]Backup, 0, len(list.Items))
+	for _, item := range list.Items {
+		if !nsQuery.Matches(item.Namespace) {
+			continue
+		}
+		converted := toBackup(item)
+		if converted.Phase != PhaseCompleted && isFinished(converted.Phase) {
+			result.Failed++
+		}
+		items = append(items, converted)
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toBackupCells(items), dsQuery)
+	result.Backups = fromBackupCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+	return result, nil
+}
+
+// GetBackupDetail returns Velero backup with restores from it.
+func GetBackupDetail(config *rest.Config, namespace, name string) (*BackupDetail, error) {
+	restClient, err := newRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return getBackupDetail(restClient, namespace, name)
+}
+
+func getBackupDetail(restClient RESTClient, namespace, name string) (*BackupDetail, error) {
+	logger.Infof("Getting details of %s Velero backup in %s namespace", name, namespace)
+
+	raw, err := getRaw(restClient, backupResource, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	item := backup{}
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return nil, err
+	}
+
+	restores, err := getRestores(restClient, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &BackupDetail{Backup: toBackup(item), Restores: restoresOf(restores, name)}, nil
+}
+
+// CreateBackup creates Velero backup of a namespace. The backup is created in Velero namespace,
+// which is the namespace of the storage location.
+func CreateBackup(config *rest.Config, spec *BackupSpec) (*Backup, error) {
+	restClient, err := newRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return createBackup(restClient, spec)
+}
+
+func createBackup(restClient RESTClient, spec *BackupSpec) (*Backup, error) {
+	logger.Infof("Creating Velero backup of %s namespace", spec.Namespace)
+
+	locations, err := getStorageLocations(restClient)
+	if err != nil {
+		return nil, err
+	}
+	location := defaultStorageLocation(locations, spec.StorageLocation)
+	if location == nil {
+		if len(spec.StorageLocation) > 0 {
+			return nil, errorsK8s.NewBadRequest(fmt.Sprintf("backup storage location %s does not exist",
+				spec.StorageLocation))
+		}
+		return nil, errorsK8s.NewBadRequest("Velero has no backup storage location")
+	}
+
+	obj, err := newBackup(spec, location, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := createRaw(restClient, backupResource, location.Namespace, body)
+	if err != nil {
+		return nil, err
+	}
+
+	created := backup{}
+	if err := json.Unmarshal(raw, &created); err != nil {
+		return nil, err
+	}
+	result := toBackup(created)
+	return &result, nil
+}
+
+// newBackup validates the spec and returns backup object to create in the storage location.
+func newBackup(spec *BackupSpec, location *StorageLocation, now time.Time) (*backup, error) {
+	if len(spec.Namespace) == 0 {
+		return nil, errorsK8s.NewBadRequest("namespace to back up is required")
+	}
+	name := spec.Name
+	if len(name) == 0 {
+		name = fmt.Sprintf("%s-%s", spec.Namespace, now.UTC().Format("20060102150405"))
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("invalid backup name %q: %v", name, errs))
+	}
+
+	result := &backup{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: GroupVersion.String(), Kind: "Backup"},
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: location.Namespace},
+		Spec: backupSpec{
+			IncludedNamespaces: []string{spec.Namespace},
+			StorageLocation:    location.Name,
+			SnapshotVolumes:    spec.SnapshotVolumes,
+		},
+	}
+	if len(spec.TTL) > 0 {
+		ttl, err := time.ParseDuration(spec.TTL)
+		if err != nil || ttl <= 0 {
+			return nil, errorsK8s.NewBadRequest(fmt.Sprintf("invalid backup TTL %q", spec.TTL))
+		}
+		result.Spec.TTL = &metaV1.Duration{Duration: ttl}
+	}
+	return result, nil
+}
+
+// isFinished returns true when Velero does not process the backup or restore anymore.
+func isFinished(phase string) bool {
+	switch phase {
+	case PhaseCompleted, PhasePartiallyFailed, "Failed", "FailedValidation":
+		return true
+	}
+	return false
+}
+
+func toBackup(item backup) Backup {
+	result := Backup{
+		ObjectMeta:         api.NewObjectMeta(item.ObjectMeta),
+		TypeMeta:           api.NewTypeMeta(api.ResourceKindVeleroBackup),
+		IncludedNamespaces: item.Spec.IncludedNamespaces,
+		ExcludedNamespaces: item.Spec.ExcludedNamespaces,
+		StorageLocation:    item.Spec.StorageLocation,
+		Schedule:           item.Labels[scheduleNameLabel],
+	}
+	if result.IncludedNamespaces == nil {
+		result.IncludedNamespaces = make([]string, 0)
+	}
+	if result.ExcludedNamespaces == nil {
+		result.ExcludedNamespaces = make([]string, 0)
+	}
+	if item.Spec.TTL != nil {
+		result.TTL = item.Spec.TTL.Duration.String()
+	}
+	if status := item.Status; status != nil {
+		result.Phase = status.Phase
+		result.FailureReason = status.FailureReason
+		result.Errors = status.Errors
+		result.Warnings = status.Warnings
+		result.StartTimestamp = status.StartTimestamp
+		result.CompletionTimestamp = status.CompletionTimestamp
+		result.Expiration = status.Expiration
+		if status.Progress != nil {
+			result.ItemsBackedUp = status.Progress.ItemsBackedUp
+			result.TotalItems = status.Progress.TotalItems
+		}
+	}
+	return result
+}
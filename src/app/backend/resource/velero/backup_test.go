@@ -0,0 +1,230 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package velero
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+)
+
+const testStorageLocations = `{"items": [
+  {"metadata": {"name": "secondary", "namespace": "velero"},
+   "spec": {"provider": "gcp", "objectStorage": {"bucket": "dr-backups"}}},
+  {"metadata": {"name": "default", "namespace": "velero"},
+   "spec": {"provider": "aws", "default": true, "objectStorage": {"bucket": "backups", "prefix": "prod"}},
+   "status": {"phase": "Available"}}
+]}`
+
+const testBackups = `{"items": [
+  {"metadata": {"name": "shop-daily-20210601", "namespace": "velero",
+                "labels": {"velero.io/schedule-name": "shop-daily"}},
+   "spec": {"includedNamespaces": ["shop"], "storageLocation": "default", "ttl": "720h0m0s"},
+   "status": {"phase": "Completed", "progress": {"totalItems": 42, "itemsBackedUp": 42},
+              "expiration": "2021-07-01T00:00:00Z"}},
+  {"metadata": {"name": "cluster", "namespace": "velero"},
+   "spec": {"excludedNamespaces": ["kube-system"]},
+   "status": {"phase": "PartiallyFailed", "errors": 3, "warnings": 1}},
+  {"metadata": {"name": "shop-manual", "namespace": "velero"},
+   "spec": {"includedNamespaces": ["shop"]},
+   "status": {"phase": "InProgress", "progress": {"totalItems": 40, "itemsBackedUp": 12}}}
+]}`
+
+const testRestores = `{"items": [
+  {"metadata": {"name": "cluster-1", "namespace": "velero", "creationTimestamp": "2021-06-01T00:00:00Z"},
+   "spec": {"backupName": "cluster"}, "status": {"phase": "Completed"}},
+  {"metadata": {"name": "cluster-2", "namespace": "velero", "creationTimestamp": "2021-06-02T00:00:00Z"},
+   "spec": {"backupName": "cluster", "namespaceMapping": {"shop": "shop-copy"}},
+   "status": {"phase": "InProgress", "progress": {"totalItems": 10, "itemsRestored": 4}}},
+  {"metadata": {"name": "other", "namespace": "velero"}, "spec": {"backupName": "shop-manual"}}
+]}`
+
+// newVeleroClient returns client of Velero API, which serves test objects. Created objects are
+// returned unchanged and the last one is kept in created.
+func newVeleroClient(t *testing.T, created *map[string]interface{}) (RESTClient, func()) {
+	objects := map[string]string{
+		"/apis/velero.io/v1/backupstoragelocations": testStorageLocations,
+		"/apis/velero.io/v1/backups":                testBackups,
+		"/apis/velero.io/v1/namespaces/velero/backups/cluster": `{"metadata": {"name": "cluster",
+		  "namespace": "velero"}, "status": {"phase": "PartiallyFailed"}}`,
+		"/apis/velero.io/v1/namespaces/velero/backups/shop-manual": `{"metadata": {"name": "shop-manual",
+		  "namespace": "velero"}, "status": {"phase": "InProgress"}}`,
+		"/apis/velero.io/v1/namespaces/velero/restores": testRestores,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			body, err := ioutil.ReadAll(r.Body)
+			if err == nil {
+				*created = map[string]interface{}{}
+				err = json.Unmarshal(body, created)
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			(*created)["resource"] = path.Base(r.URL.Path)
+			w.Write(body)
+			return
+		}
+
+		raw, ok := objects[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(raw))
+	}))
+	restClient, err := newRESTClient(&rest.Config{Host: server.URL})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return restClient, server.Close
+}
+
+func TestGetBackupList(t *testing.T) {
+	restClient, closeServer := newVeleroClient(t, nil)
+	defer closeServer()
+
+	list, err := getBackupList(restClient, common.NewNamespaceQuery(nil), dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.ListMeta.TotalItems != 3 || list.Failed != 1 {
+		t.Fatalf("GetBackupList() returns %d backups, %d failed, expected 3 and 1", list.ListMeta.TotalItems,
+			list.Failed)
+	}
+
+	scheduled := list.Backups[0]
+	if scheduled.Schedule != "shop-daily" || scheduled.TTL != "720h0m0s" || scheduled.ItemsBackedUp != 42 ||
+		scheduled.Expiration == nil || !reflect.DeepEqual(scheduled.IncludedNamespaces, []string{"shop"}) {
+		t.Errorf("GetBackupList() returns %#v for scheduled backup", scheduled)
+	}
+	if cluster := list.Backups[1]; cluster.Errors != 3 || len(cluster.IncludedNamespaces) != 0 ||
+		!reflect.DeepEqual(cluster.ExcludedNamespaces, []string{"kube-system"}) {
+		t.Errorf("GetBackupList() returns %#v for cluster backup", cluster)
+	}
+}
+
+func TestGetBackupDetail(t *testing.T) {
+	restClient, closeServer := newVeleroClient(t, nil)
+	defer closeServer()
+
+	detail, err := getBackupDetail(restClient, "velero", "cluster")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.Phase != PhasePartiallyFailed || len(detail.Restores) != 2 {
+		t.Fatalf("GetBackupDetail() returns %#v, expected partially failed backup with 2 restores", detail)
+	}
+	if latest := detail.Restores[0]; latest.ObjectMeta.Name != "cluster-2" || latest.ItemsRestored != 4 ||
+		latest.NamespaceMapping["shop"] != "shop-copy" {
+		t.Errorf("GetBackupDetail() returns %#v as the latest restore", latest)
+	}
+}
+
+func TestCreateBackup(t *testing.T) {
+	var created map[string]interface{}
+	restClient, closeServer := newVeleroClient(t, &created)
+	defer closeServer()
+	snapshotVolumes := false
+
+	backup, err := createBackup(restClient, &BackupSpec{Namespace: "shop", TTL: "24h", SnapshotVolumes: &snapshotVolumes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backup.ObjectMeta.Namespace != "velero" || backup.StorageLocation != "default" || backup.TTL != "24h0m0s" {
+		t.Errorf("CreateBackup() returns %#v, expected backup to default location in velero namespace", backup)
+	}
+	expected := map[string]interface{}{
+		"includedNamespaces": []interface{}{"shop"},
+		"storageLocation":    "default",
+		"ttl":                "24h0m0s",
+		"snapshotVolumes":    false,
+	}
+	if created["resource"] != backupResource || !reflect.DeepEqual(created["spec"], expected) {
+		t.Errorf("CreateBackup() creates %s with spec %#v, expected %#v", created["resource"], created["spec"],
+			expected)
+	}
+
+	cases := []*BackupSpec{
+		{},
+		{Namespace: "shop", StorageLocation: "missing"},
+		{Namespace: "shop", TTL: "month"},
+		{Namespace: "shop", Name: "Shop"},
+	}
+	for _, spec := range cases {
+		if _, err := createBackup(restClient, spec); !errorsK8s.IsBadRequest(err) {
+			t.Errorf("CreateBackup(%#v) returns %v, expected bad request", spec, err)
+		}
+	}
+}
+
+func TestNewBackupName(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC)
+	obj, err := newBackup(&BackupSpec{Namespace: "shop"}, &StorageLocation{Name: "default", Namespace: "velero"},
+		now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.Name != "shop-20210601123000" || obj.Spec.TTL != nil {
+		t.Errorf("newBackup() returns %#v, expected timestamped name and default TTL", obj)
+	}
+}
+
+func TestRestoreBackup(t *testing.T) {
+	var created map[string]interface{}
+	restClient, closeServer := newVeleroClient(t, &created)
+	defer closeServer()
+
+	restore, err := restoreBackup(restClient, "velero", "cluster", &RestoreSpec{Name: "cluster-copy",
+		IncludedNamespaces: []string{"shop"}, NamespaceMapping: map[string]string{"shop": "shop-copy"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restore.Backup != "cluster" || restore.ObjectMeta.Namespace != "velero" {
+		t.Errorf("RestoreBackup() returns %#v", restore)
+	}
+	expected := map[string]interface{}{
+		"backupName":         "cluster",
+		"includedNamespaces": []interface{}{"shop"},
+		"namespaceMapping":   map[string]interface{}{"shop": "shop-copy"},
+	}
+	if created["resource"] != restoreResource || !reflect.DeepEqual(created["spec"], expected) {
+		t.Errorf("RestoreBackup() creates %s with spec %#v, expected %#v", created["resource"], created["spec"],
+			expected)
+	}
+
+	if _, err := restoreBackup(restClient, "velero", "shop-manual", &RestoreSpec{}); !errorsK8s.IsBadRequest(err) {
+		t.Errorf("RestoreBackup() returns %v for backup in progress, expected bad request", err)
+	}
+	_, err = restoreBackup(restClient, "velero", "cluster",
+		&RestoreSpec{NamespaceMapping: map[string]string{"shop": "a_b"}})
+	if !errorsK8s.IsBadRequest(err) {
+		t.Errorf("RestoreBackup() returns %v for invalid namespace mapping, expected bad request", err)
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package velero serves backups, schedules and restores of Velero, when it is installed in the
+// cluster, and triggers backups of namespaces and restores from them.
+package velero
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/apply"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// GroupVersion is the group version of Velero API.
+var GroupVersion = schema.GroupVersion{Group: "velero.io", Version: "v1"}
+
+// Names of Velero resources.
+const (
+	backupResource                = "backups"
+	scheduleResource              = "schedules"
+	restoreResource               = "restores"
+	backupStorageLocationResource = "backupstoragelocations"
+)
+
+// Phases of backups and restores, in which they can be used to restore data.
+const (
+	PhaseCompleted       = "Completed"
+	PhasePartiallyFailed = "PartiallyFailed"
+)
+
+// scheduleNameLabel is set by Velero on backups created by schedules.
+const scheduleNameLabel = "velero.io/schedule-name"
+
+// Status tells whether Velero is installed in the cluster and where it keeps backups.
+type Status struct {
+	Installed    bool   `json:"installed"`
+	GroupVersion string `json:"groupVersion"`
+
+	// Namespace Velero is installed in. Backups, schedules and restores are created there. Empty
+	// when no backup storage location is configured.
+	Namespace string `json:"namespace"`
+
+	StorageLocations []StorageLocation `json:"storageLocations"`
+}
+
+// StorageLocation is an object storage, where Velero uploads backups.
+type StorageLocation struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Provider  string `json:"provider"`
+	Bucket    string `json:"bucket"`
+	Prefix    string `json:"prefix,omitempty"`
+	Default   bool   `json:"default"`
+
+	// Phase is Available, when Velero can access the storage, or Unavailable.
+	Phase              string       `json:"phase"`
+	LastValidationTime *metaV1.Time `json:"lastValidationTime,omitempty"`
+}
+
+// backupStorageLocation is the API representation of Velero backup storage location. Client library
+// does not contain Velero types, so only the fields used by Dashboard are declared in this package.
+type backupStorageLocation struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              backupStorageLocationSpec   `json:"spec"`
+	Status            backupStorageLocationStatus `json:"status"`
+}
+
+type backupStorageLocationSpec struct {
+	Provider      string        `json:"provider"`
+	Default       bool          `json:"default"`
+	ObjectStorage objectStorage `json:"objectStorage"`
+}
+
+type objectStorage struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix"`
+}
+
+type backupStorageLocationStatus struct {
+	Phase              string       `json:"phase"`
+	LastValidationTime *metaV1.Time `json:"lastValidationTime"`
+}
+
+type backupStorageLocationList struct {
+	Items []backupStorageLocation `json:"items"`
+}
+
+// GetStatus detects Velero by looking for its API group in discovery and returns its backup storage
+// locations.
+func GetStatus(client discovery.DiscoveryInterface, config *rest.Config) (*Status, error) {
+	result := &Status{GroupVersion: GroupVersion.String(), StorageLocations: make([]StorageLocation, 0)}
+
+	list, err := client.ServerResourcesForGroupVersion(GroupVersion.String())
+	if errorsK8s.IsNotFound(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range list.APIResources {
+		result.Installed = result.Installed || resource.Name == backupResource
+	}
+	if !result.Installed {
+		return result, nil
+	}
+
+	restClient, err := newRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := getStorageLocations(restClient)
+	if err != nil {
+		return nil, err
+	}
+	result.StorageLocations = locations
+	if location := defaultStorageLocation(locations, ""); location != nil {
+		result.Namespace = location.Namespace
+	}
+	return result, nil
+}
+
+// getStorageLocations returns backup storage locations in all namespaces, default ones first.
+func getStorageLocations(restClient RESTClient) ([]StorageLocation, error) {
+	raw, err := getRaw(restClient, backupStorageLocationResource, "", "")
+	if err != nil {
+		return nil, err
+	}
+	list := backupStorageLocationList{}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+
+	result := make([]StorageLocation, 0, len(list.Items))
+	for _, location := range list.Items {
+		result = append(result, StorageLocation{
+			Name:               location.Name,
+			Namespace:          location.Namespace,
+			Provider:           location.Spec.Provider,
+			Bucket:             location.Spec.ObjectStorage.Bucket,
+			Prefix:             location.Spec.ObjectStorage.Prefix,
+			Default:            location.Spec.Default,
+			Phase:              location.Status.Phase,
+			LastValidationTime: location.Status.LastValidationTime,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Default != result[j].Default {
+			return result[i].Default
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// defaultStorageLocation returns the location of the name or, when name is empty, the default
+// location. Velero uses the first location, when none of them is marked as default.
+func defaultStorageLocation(locations []StorageLocation, name string) *StorageLocation {
+	for i := range locations {
+		if locations[i].Name == name || (len(name) == 0 && locations[i].Default) {
+			return &locations[i]
+		}
+	}
+	if len(name) == 0 && len(locations) > 0 {
+		return &locations[0]
+	}
+	return nil
+}
+
+// RESTClient is an interface for REST operations used in this package.
+type RESTClient interface {
+	Get() *rest.Request
+	Post() *rest.Request
+}
+
+// newRESTClient creates REST client of Velero API.
+func newRESTClient(config *rest.Config) (RESTClient, error) {
+	return apply.NewRESTClient(config, GroupVersion)
+}
+
+// getRaw gets objects of a Velero resource. All objects in the namespace are listed if name is
+// empty.
+func getRaw(restClient RESTClient, resource, namespace, name string) ([]byte, error) {
+	request := restClient.Get().Namespace(namespace).Resource(resource)
+	if len(name) > 0 {
+		request = request.Name(name)
+	}
+	raw, err := request.Do().Raw()
+	if errorsK8s.IsNotFound(err) && len(name) == 0 {
+		return nil, errorsK8s.NewNotFound(schema.GroupResource{Group: GroupVersion.Group, Resource: resource},
+			fmt.Sprintf("%s (Velero is not installed)", resource))
+	}
+	return raw, err
+}
+
+// createRaw creates object of a Velero resource and returns the created object.
+func createRaw(restClient RESTClient, resource, namespace string, body []byte) ([]byte, error) {
+	return restClient.Post().Namespace(namespace).Resource(resource).Body(body).Do().Raw()
+}
+
+// The code below allows to perform complex data section on []Backup, []Schedule and []Restore
+
+type BackupCell Backup
+
+func (self BackupCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	case dataselect.StatusProperty:
+		return dataselect.StdComparableString(self.Phase)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toBackupCells(std []Backup) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = BackupCell(std[i])
+	}
+	return cells
+}
+
+func fromBackupCells(cells []dataselect.DataCell) []Backup {
+	std := make([]Backup, len(cells))
+	for i := range std {
+		std[i] = Backup(cells[i].(BackupCell))
+	}
+	return std
+}
+
+type ScheduleCell Schedule
+
+func (self ScheduleCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	case dataselect.StatusProperty:
+		return dataselect.StdComparableString(self.Phase)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toScheduleCells(std []Schedule) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = ScheduleCell(std[i])
+	}
+	return cells
+}
+
+func fromScheduleCells(cells []dataselect.DataCell) []Schedule {
+	std := make([]Schedule, len(cells))
+	for i := range std {
+		std[i] = Schedule(cells[i].(ScheduleCell))
+	}
+	return std
+}
+
+type RestoreCell Restore
+
+func (self RestoreCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	case dataselect.StatusProperty:
+		return dataselect.StdComparableString(self.Phase)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toRestoreCells(std []Restore) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = RestoreCell(std[i])
+	}
+	return cells
+}
+
+func fromRestoreCells(cells []dataselect.DataCell) []Restore {
+	std := make([]Restore, len(cells))
+	for i := range std {
+		std[i] = Restore(cells[i].(RestoreCell))
+	}
+	return std
+}
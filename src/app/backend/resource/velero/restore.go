@@ -0,0 +1,272 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package velero
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/rest"
+)
+
+// Restore provides the presentation layer view of Velero restore.
+type Restore struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Backup is the name of restored backup.
+	Backup string `json:"backup"`
+
+	// IncludedNamespaces are restored namespaces of the backup. Empty list means all namespaces.
+	IncludedNamespaces []string `json:"includedNamespaces"`
+
+	// NamespaceMapping maps namespaces of the backup to namespaces objects are restored to.
+	NamespaceMapping map[string]string `json:"namespaceMapping"`
+
+	// Phase is one of New, InProgress, Completed, PartiallyFailed, Failed or FailedValidation.
+	Phase         string `json:"phase"`
+	FailureReason string `json:"failureReason,omitempty"`
+	Errors        int    `json:"errors"`
+	Warnings      int    `json:"warnings"`
+
+	ItemsRestored int `json:"itemsRestored"`
+	TotalItems    int `json:"totalItems"`
+
+	StartTimestamp      *metaV1.Time `json:"startTimestamp,omitempty"`
+	CompletionTimestamp *metaV1.Time `json:"completionTimestamp,omitempty"`
+}
+
+// RestoreList contains a list of Velero restores.
+type RestoreList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+	Restores []Restore    `json:"restores"`
+}
+
+// RestoreSpec describes restore from a backup to create.
+type RestoreSpec struct {
+	// Name is optional. Name of the backup with a timestamp is used when it is empty.
+	Name string `json:"name"`
+
+	// IncludedNamespaces is optional. All namespaces of the backup are restored when it is empty.
+	IncludedNamespaces []string `json:"includedNamespaces"`
+
+	// NamespaceMapping is optional. It allows to restore namespaces of the backup to namespaces
+	// with other names, e.g. to inspect restored data next to the original namespace.
+	NamespaceMapping map[string]string `json:"namespaceMapping"`
+
+	// RestorePVs is optional. Velero restores persistent volumes from snapshots when it is not set.
+	RestorePVs *bool `json:"restorePVs"`
+}
+
+// restore is the API representation of Velero restore.
+type restore struct {
+	metaV1.TypeMeta   `json:",inline"`
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              restoreSpec    `json:"spec"`
+	Status            *restoreStatus `json:"status,omitempty"`
+}
+
+type restoreSpec struct {
+	BackupName         string            `json:"backupName"`
+	IncludedNamespaces []string          `json:"includedNamespaces,omitempty"`
+	NamespaceMapping   map[string]string `json:"namespaceMapping,omitempty"`
+	RestorePVs         *bool             `json:"restorePVs,omitempty"`
+}
+
+type restoreStatus struct {
+	Phase               string           `json:"phase"`
+	FailureReason       string           `json:"failureReason"`
+	Errors              int              `json:"errors"`
+	Warnings            int              `json:"warnings"`
+	Progress            *restoreProgress `json:"progress"`
+	StartTimestamp      *metaV1.Time     `json:"startTimestamp"`
+	CompletionTimestamp *metaV1.Time     `json:"completionTimestamp"`
+}
+
+type restoreProgress struct {
+	TotalItems    int `json:"totalItems"`
+	ItemsRestored int `json:"itemsRestored"`
+}
+
+type restoreList struct {
+	Items []restore `json:"items"`
+}
+
+// GetRestoreList returns Velero restores in the namespaces.
+func GetRestoreList(config *rest.Config, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*RestoreList, error) {
+	logger.Info("Getting list of Velero restores")
+
+	restClient, err := newRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	restores, err := getRestores(restClient, nsQuery.ToRequestParam())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]Restore, 0, len(restores))
+	for _, item := range restores {
+		if nsQuery.Matches(item.Namespace) {
+			items = append(items, toRestore(item))
+		}
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toRestoreCells(items), dsQuery)
+	return &RestoreList{
+		ListMeta: api.ListMeta{TotalItems: filteredTotal},
+		Restores: fromRestoreCells(cells),
+	}, nil
+}
+
+// RestoreBackup creates Velero restore from the backup. Only completed and partially failed backups
+// can be restored.
+func RestoreBackup(config *rest.Config, namespace, name string, spec *RestoreSpec) (*Restore, error) {
+	restClient, err := newRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return restoreBackup(restClient, namespace, name, spec)
+}
+
+func restoreBackup(restClient RESTClient, namespace, name string, spec *RestoreSpec) (*Restore, error) {
+	logger.Infof("Restoring %s Velero backup in %s namespace", name, namespace)
+
+	raw, err := getRaw(restClient, backupResource, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	item := backup{}
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return nil, err
+	}
+
+	obj, err := newRestore(toBackup(item), spec, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	raw, err = createRaw(restClient, restoreResource, namespace, body)
+	if err != nil {
+		return nil, err
+	}
+
+	created := restore{}
+	if err := json.Unmarshal(raw, &created); err != nil {
+		return nil, err
+	}
+	result := toRestore(created)
+	return &result, nil
+}
+
+// newRestore validates the spec and returns restore object of the backup to create.
+func newRestore(source Backup, spec *RestoreSpec, now time.Time) (*restore, error) {
+	if source.Phase != PhaseCompleted && source.Phase != PhasePartiallyFailed {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("backup %s cannot be restored in %s phase",
+			source.ObjectMeta.Name, source.Phase))
+	}
+	name := spec.Name
+	if len(name) == 0 {
+		name = fmt.Sprintf("%s-%s", source.ObjectMeta.Name, now.UTC().Format("20060102150405"))
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return nil, errorsK8s.NewBadRequest(fmt.Sprintf("invalid restore name %q: %v", name, errs))
+	}
+	for source, target := range spec.NamespaceMapping {
+		if errs := validation.IsDNS1123Label(target); len(errs) > 0 {
+			return nil, errorsK8s.NewBadRequest(fmt.Sprintf("invalid target namespace of %s: %v",
+				source, errs))
+		}
+	}
+
+	return &restore{
+		TypeMeta:   metaV1.TypeMeta{APIVersion: GroupVersion.String(), Kind: "Restore"},
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: source.ObjectMeta.Namespace},
+		Spec: restoreSpec{
+			BackupName:         source.ObjectMeta.Name,
+			IncludedNamespaces: spec.IncludedNamespaces,
+			NamespaceMapping:   spec.NamespaceMapping,
+			RestorePVs:         spec.RestorePVs,
+		},
+	}, nil
+}
+
+func getRestores(restClient RESTClient, namespace string) ([]restore, error) {
+	raw, err := getRaw(restClient, restoreResource, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	list := restoreList{}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// restoresOf returns restores from the backup, newest first.
+func restoresOf(restores []restore, backupName string) []Restore {
+	result := make([]Restore, 0)
+	for _, item := range restores {
+		if item.Spec.BackupName == backupName {
+			result = append(result, toRestore(item))
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[j].ObjectMeta.CreationTimestamp.Before(result[i].ObjectMeta.CreationTimestamp)
+	})
+	return result
+}
+
+func toRestore(item restore) Restore {
+	result := Restore{
+		ObjectMeta:         api.NewObjectMeta(item.ObjectMeta),
+		TypeMeta:           api.NewTypeMeta(api.ResourceKindVeleroRestore),
+		Backup:             item.Spec.BackupName,
+		IncludedNamespaces: item.Spec.IncludedNamespaces,
+		NamespaceMapping:   item.Spec.NamespaceMapping,
+	}
+	if result.IncludedNamespaces == nil {
+		result.IncludedNamespaces = make([]string, 0)
+	}
+	if result.NamespaceMapping == nil {
+		result.NamespaceMapping = make(map[string]string)
+	}
+	if status := item.Status; status != nil {
+		result.Phase = status.Phase
+		result.FailureReason = status.FailureReason
+		result.Errors = status.Errors
+		result.Warnings = status.Warnings
+		result.StartTimestamp = status.StartTimestamp
+		result.CompletionTimestamp = status.CompletionTimestamp
+		if status.Progress != nil {
+			result.ItemsRestored = status.Progress.ItemsRestored
+			result.TotalItems = status.Progress.TotalItems
+		}
+	}
+	return result
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package velero
+
+import (
+	"encoding/json"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// Schedule provides the presentation layer view of Velero schedule.
+type Schedule struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Schedule is a cron expression of backup times.
+	Schedule string `json:"schedule"`
+	Paused   bool   `json:"paused"`
+
+	// IncludedNamespaces are backed up namespaces. Empty list means all namespaces.
+	IncludedNamespaces []string `json:"includedNamespaces"`
+	StorageLocation    string   `json:"storageLocation"`
+	TTL                string   `json:"ttl"`
+
+	// Phase is one of New, Enabled or FailedValidation.
+	Phase            string       `json:"phase"`
+	ValidationErrors []string     `json:"validationErrors"`
+	LastBackup       *metaV1.Time `json:"lastBackup,omitempty"`
+}
+
+// ScheduleList contains a list of Velero schedules.
+type ScheduleList struct {
+	ListMeta  api.ListMeta `json:"listMeta"`
+	Schedules []Schedule   `json:"schedules"`
+}
+
+// schedule is the API representation of Velero schedule.
+type schedule struct {
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              scheduleSpec   `json:"spec"`
+	Status            scheduleStatus `json:"status"`
+}
+
+type scheduleSpec struct {
+	Schedule string     `json:"schedule"`
+	Paused   bool       `json:"paused"`
+	Template backupSpec `json:"template"`
+}
+
+type scheduleStatus struct {
+	Phase            string       `json:"phase"`
+	ValidationErrors []string     `json:"validationErrors"`
+	LastBackup       *metaV1.Time `json:"lastBackup"`
+}
+
+type scheduleList struct {
+	Items []schedule `json:"items"`
+}
+
+// GetScheduleList returns Velero schedules in the namespaces.
+func GetScheduleList(config *rest.Config, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*ScheduleList, error) {
+	logger.Info("Getting list of Velero schedules")
+
+	restClient, err := newRESTClient(config)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := getRaw(restClient, scheduleResource, nsQuery.ToRequestParam(), "")
+	if err != nil {
+		return nil, err
+	}
+	list := scheduleList{}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+
+	items := make([]Schedule, 0, len(list.Items))
+	for _, item := range list.Items {
+		if nsQuery.Matches(item.Namespace) {
+			items = append(items, toSchedule(item))
+		}
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toScheduleCells(items), dsQuery)
+	return &ScheduleList{
+		ListMeta:  api.ListMeta{TotalItems: filteredTotal},
+		Schedules: fromScheduleCells(cells),
+	}, nil
+}
+
+func toSchedule(item schedule) Schedule {
+	result := Schedule{
+		ObjectMeta:         api.NewObjectMeta(item.ObjectMeta),
+		TypeMeta:           api.NewTypeMeta(api.ResourceKindVeleroSchedule),
+		Schedule:           item.Spec.Schedule,
+		Paused:             item.Spec.Paused,
+		IncludedNamespaces: item.Spec.Template.IncludedNamespaces,
+		StorageLocation:    item.Spec.Template.StorageLocation,
+		Phase:              item.Status.Phase,
+		ValidationErrors:   item.Status.ValidationErrors,
+		LastBackup:         item.Status.LastBackup,
+	}
+	if result.IncludedNamespaces == nil {
+		result.IncludedNamespaces = make([]string, 0)
+	}
+	if result.ValidationErrors == nil {
+		result.ValidationErrors = make([]string, 0)
+	}
+	if item.Spec.Template.TTL != nil {
+		result.TTL = item.Spec.Template.TTL.Duration.String()
+	}
+	return result
+}
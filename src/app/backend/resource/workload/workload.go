@@ -15,10 +15,9 @@
 package workload
 
 import (
-	"log"
-
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/daemonset"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
@@ -49,7 +48,7 @@ type Workloads struct {
 func GetWorkloads(client *kubernetes.Clientset, metricClient metricapi.MetricClient,
 	nsQuery *common.NamespaceQuery, dsQuery *dataselect.DataSelectQuery) (*Workloads, error) {
 
-	log.Print("Getting lists of all workloads")
+	logger.Info("Getting lists of all workloads")
 	channels := &common.ResourceChannels{
 		ReplicationControllerList: common.GetReplicationControllerListChannel(client, nsQuery, 1),
 		ReplicaSetList:            common.GetReplicaSetListChannel(client, nsQuery, 2),
@@ -15,11 +15,17 @@
 package scaling
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	client "k8s.io/client-go/kubernetes"
+	apps "k8s.io/client-go/pkg/apis/apps/v1beta1"
 )
 
 // ReplicaCounts provide the desired and actual number of replicas.
@@ -28,30 +34,71 @@ type ReplicaCounts struct {
 	ActualReplicas  int32 `json:"actualReplicas"`
 }
 
+// NewReplicaCounts returns replica counts of a workload from desired number of replicas in its
+// spec, which defaults to 1, and actual number of replicas in its status, the same way as the scale
+// subresource reports them.
+func NewReplicaCounts(desired *int32, actual int32) ReplicaCounts {
+	rc := ReplicaCounts{DesiredReplicas: 1, ActualReplicas: actual}
+	if desired != nil {
+		rc.DesiredReplicas = *desired
+	}
+	return rc
+}
+
+// Kinds served by the scale subresource of the extensions API group.
+var extensionsScaleKinds = map[string]bool{
+	api.ResourceKindDeployment:            true,
+	api.ResourceKindReplicaSet:            true,
+	api.ResourceKindReplicationController: true,
+}
+
 // GetScaleSpec returns a populated ReplicaCounts object with desired and actual number of replicas.
 func GetScaleSpec(client client.Interface, kind, namespace, name string) (rc *ReplicaCounts, err error) {
-	rc = new(ReplicaCounts)
-	s, err := client.ExtensionsV1beta1().Scales(namespace).Get(kind, name)
-	if err != nil {
-		return nil, err
+	kind = strings.ToLower(kind)
+	switch {
+	case kind == api.ResourceKindStatefulSet:
+		s, err := getStatefulSetScale(client, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		return &ReplicaCounts{DesiredReplicas: s.Spec.Replicas, ActualReplicas: s.Status.Replicas}, nil
+	case extensionsScaleKinds[kind]:
+		s, err := client.ExtensionsV1beta1().Scales(namespace).Get(kind, name)
+		if err != nil {
+			return nil, err
+		}
+		return &ReplicaCounts{DesiredReplicas: s.Spec.Replicas, ActualReplicas: s.Status.Replicas}, nil
+	case kind == api.ResourceKindJob:
+		j, err := client.BatchV1().Jobs(namespace).Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return &ReplicaCounts{DesiredReplicas: getParallelism(j.Spec.Parallelism),
+			ActualReplicas: j.Status.Active}, nil
+	default:
+		return nil, newUnsupportedKindError(kind)
 	}
-	rc.DesiredReplicas = s.Spec.Replicas
-	rc.ActualReplicas = s.Status.Replicas
-
-	return
 }
 
-// ScaleResource scales the provided resource using the client scale method in the case of Deployment,
-// ReplicaSet, Replication Controller. In the case of a job we are using the jobs resource update
-// method since the client scale method does not provide one for the job.
+// ScaleResource sets desired number of replicas of the resource through its scale subresource.
+// Jobs have no scale subresource, so their parallelism is updated instead.
 func ScaleResource(client client.Interface, kind, namespace, name, count string) (rc *ReplicaCounts, err error) {
+	kind = strings.ToLower(kind)
+	replicas, err := parseReplicas(count)
+	if err != nil {
+		return nil, err
+	}
+
 	rc = new(ReplicaCounts)
-	if strings.ToLower(kind) == "job" {
-		err = scaleJobResource(client, namespace, name, count, rc)
-	} else if strings.ToLower(kind) == "statefulset" {
-		err = scaleStatefulSetResource(client, namespace, name, count, rc)
-	} else {
-		err = scaleGenericResource(client, kind, namespace, name, count, rc)
+	switch {
+	case kind == api.ResourceKindJob:
+		err = scaleJobResource(client, namespace, name, replicas, rc)
+	case kind == api.ResourceKindStatefulSet:
+		err = scaleStatefulSetResource(client, namespace, name, replicas, rc)
+	case extensionsScaleKinds[kind]:
+		err = scaleGenericResource(client, kind, namespace, name, replicas, rc)
+	default:
+		err = newUnsupportedKindError(kind)
 	}
 	if err != nil {
 		return nil, err
@@ -60,17 +107,39 @@ func ScaleResource(client client.Interface, kind, namespace, name, count string)
 	return
 }
 
-//ScaleGenericResource is used for Deployment, ReplicaSet, Replication Controller scaling.
-func scaleGenericResource(client client.Interface, kind, namespace, name, count string, rc *ReplicaCounts) error {
-	s, err := client.ExtensionsV1beta1().Scales(namespace).Get(kind, name)
+// parseReplicas parses and validates requested number of replicas.
+func parseReplicas(count string) (int32, error) {
+	path := field.NewPath("scaleBy")
+	c, err := strconv.ParseInt(count, 10, 32)
 	if err != nil {
-		return err
+		return 0, errorsK8s.NewInvalid(schema.GroupKind{Kind: "Scale"}, "",
+			field.ErrorList{field.Invalid(path, count, "must be an integer")})
 	}
-	c, err := strconv.Atoi(count)
+	if c < 0 {
+		return 0, errorsK8s.NewInvalid(schema.GroupKind{Kind: "Scale"}, "",
+			field.ErrorList{field.Invalid(path, count, "must be greater than or equal to 0")})
+	}
+	return int32(c), nil
+}
+
+func newUnsupportedKindError(kind string) error {
+	return errorsK8s.NewBadRequest(fmt.Sprintf("scaling of %s resources is not supported", kind))
+}
+
+func getParallelism(parallelism *int32) int32 {
+	if parallelism == nil {
+		return 1
+	}
+	return *parallelism
+}
+
+// scaleGenericResource is used for Deployment, ReplicaSet, Replication Controller scaling.
+func scaleGenericResource(client client.Interface, kind, namespace, name string, replicas int32, rc *ReplicaCounts) error {
+	s, err := client.ExtensionsV1beta1().Scales(namespace).Get(kind, name)
 	if err != nil {
 		return err
 	}
-	s.Spec.Replicas = int32(c)
+	s.Spec.Replicas = replicas
 	s, err = client.ExtensionsV1beta1().Scales(namespace).Update(kind, s)
 	if err != nil {
 		return err
@@ -81,42 +150,63 @@ func scaleGenericResource(client client.Interface, kind, namespace, name, count
 	return nil
 }
 
-// scaleJobResource is exclusively used for jobs as it does not increase/decrease pods but jobs parallelism attribute.
-func scaleJobResource(client client.Interface, namespace, name, count string, rc *ReplicaCounts) error {
+// scaleJobResource is exclusively used for jobs as it does not increase/decrease pods but jobs
+// parallelism attribute.
+func scaleJobResource(client client.Interface, namespace, name string, replicas int32, rc *ReplicaCounts) error {
 	j, err := client.BatchV1().Jobs(namespace).Get(name, metaV1.GetOptions{})
-	c, err := strconv.Atoi(count)
 	if err != nil {
 		return err
 	}
 
-	*j.Spec.Parallelism = int32(c)
+	j.Spec.Parallelism = &replicas
 	j, err = client.BatchV1().Jobs(namespace).Update(j)
 	if err != nil {
 		return err
 	}
 
 	rc.DesiredReplicas = *j.Spec.Parallelism
-	rc.ActualReplicas = *j.Spec.Parallelism
+	rc.ActualReplicas = j.Status.Active
 
 	return nil
 }
 
-// scaleStatefulSet is exclusively used for statefulsets
-func scaleStatefulSetResource(client client.Interface, namespace, name, count string, rc *ReplicaCounts) error {
-	ss, err := client.AppsV1beta1().StatefulSets(namespace).Get(name, metaV1.GetOptions{})
-	c, err := strconv.Atoi(count)
+// getStatefulSetScale gets scale subresource of the stateful set. Typed client of the apps API
+// group does not provide methods for it, so the REST client is used directly.
+func getStatefulSetScale(client client.Interface, namespace, name string) (*apps.Scale, error) {
+	s := new(apps.Scale)
+	err := client.AppsV1beta1().RESTClient().Get().
+		Namespace(namespace).
+		Resource("statefulsets").
+		Name(name).
+		SubResource("scale").
+		Do().
+		Into(s)
+	return s, err
+}
+
+// scaleStatefulSetResource is exclusively used for stateful sets.
+func scaleStatefulSetResource(client client.Interface, namespace, name string, replicas int32, rc *ReplicaCounts) error {
+	s, err := getStatefulSetScale(client, namespace, name)
 	if err != nil {
 		return err
 	}
 
-	*ss.Spec.Replicas = int32(c)
-	ss, err = client.AppsV1beta1().StatefulSets(namespace).Update(ss)
+	s.Spec.Replicas = replicas
+	result := new(apps.Scale)
+	err = client.AppsV1beta1().RESTClient().Put().
+		Namespace(namespace).
+		Resource("statefulsets").
+		Name(name).
+		SubResource("scale").
+		Body(s).
+		Do().
+		Into(result)
 	if err != nil {
 		return err
 	}
 
-	rc.DesiredReplicas = *ss.Spec.Replicas
-	rc.ActualReplicas = ss.Status.Replicas
+	rc.DesiredReplicas = result.Spec.Replicas
+	rc.ActualReplicas = result.Status.Replicas
 
 	return nil
 }
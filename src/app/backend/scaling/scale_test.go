@@ -0,0 +1,59 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scaling
+
+import (
+	"reflect"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	batch "k8s.io/client-go/pkg/apis/batch/v1"
+)
+
+func TestScaleResource(t *testing.T) {
+	parallelism := int32(1)
+	cases := []struct {
+		kind, count string
+		expected    *ReplicaCounts
+		invalid     bool
+		badRequest  bool
+	}{
+		{"job", "3", &ReplicaCounts{DesiredReplicas: 3, ActualReplicas: 1}, false, false},
+		{"Job", "0", &ReplicaCounts{DesiredReplicas: 0, ActualReplicas: 1}, false, false},
+		{"job", "-1", nil, true, false},
+		{"job", "three", nil, true, false},
+		{"pod", "3", nil, false, true},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset(&batch.Job{
+			ObjectMeta: metaV1.ObjectMeta{Name: "job", Namespace: "default"},
+			Spec:       batch.JobSpec{Parallelism: &parallelism},
+			Status:     batch.JobStatus{Active: 1},
+		})
+
+		actual, err := ScaleResource(client, c.kind, "default", "job", c.count)
+		if c.invalid != errorsK8s.IsInvalid(err) || c.badRequest != errorsK8s.IsBadRequest(err) {
+			t.Errorf("ScaleResource(client, %#v, %#v) returns error %#v", c.kind, c.count, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("ScaleResource(client, %#v, %#v) returns %#v, expected %#v", c.kind, c.count,
+				actual, c.expected)
+		}
+	}
+}
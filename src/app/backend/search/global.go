@@ -0,0 +1,313 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GlobalSearchResult is a list of resources of all kinds whose names match the search query,
+// ordered by relevance.
+type GlobalSearchResult struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	// Resources matching the query. Only name, namespace and creation timestamp are returned, so
+	// that e.g. secret data or annotations do not leak to the results.
+	Results []GlobalSearchItem `json:"results"`
+
+	// List of non-critical errors, that occurred during resource retrieval, e.g., kinds the user
+	// is not allowed to list.
+	Errors []error `json:"errors"`
+}
+
+// GlobalSearchItem is a single resource matching the search query.
+type GlobalSearchItem struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// relevance of the match, lower is better.
+	relevance int
+}
+
+// Relevance of the match between resource name and the query.
+const (
+	exactMatch = iota
+	prefixMatch
+	substringMatch
+	noMatch
+)
+
+// getRelevance returns how well the name matches the lower case query.
+func getRelevance(name, query string) int {
+	name = strings.ToLower(name)
+	switch {
+	case name == query:
+		return exactMatch
+	case strings.HasPrefix(name, query):
+		return prefixMatch
+	case strings.Contains(name, query):
+		return substringMatch
+	default:
+		return noMatch
+	}
+}
+
+// globalSearch collects matching resources and non-critical errors.
+type globalSearch struct {
+	query   string
+	results []GlobalSearchItem
+	errors  []error
+}
+
+// add adds the resource to results if its name matches the query.
+func (self *globalSearch) add(kind api.ResourceKind, objectMeta metaV1.ObjectMeta) {
+	relevance := getRelevance(objectMeta.Name, self.query)
+	if relevance == noMatch {
+		return
+	}
+
+	self.results = append(self.results, GlobalSearchItem{
+		ObjectMeta: api.ObjectMeta{
+			Name:              objectMeta.Name,
+			Namespace:         objectMeta.Namespace,
+			CreationTimestamp: objectMeta.CreationTimestamp,
+		},
+		TypeMeta:  api.NewTypeMeta(kind),
+		relevance: relevance,
+	})
+}
+
+// handleError records non-critical error and returns true if the list can be searched.
+func (self *globalSearch) handleError(err error) (bool, error) {
+	var criticalError error
+	self.errors, criticalError = errors.AppendError(err, self.errors)
+	return err == nil, criticalError
+}
+
+// GlobalSearch searches resources of all kinds by name. Lists are read through resource channels,
+// so they come from the resource cache when it is enabled. Resources the user is not allowed to
+// list are skipped and reported as non-critical errors.
+func GlobalSearch(client kubernetes.Interface, nsQuery *common.NamespaceQuery, query string,
+	dsQuery *dataselect.DataSelectQuery) (*GlobalSearchResult, error) {
+	channels := &common.ResourceChannels{
+		PodList:                   common.GetPodListChannel(client, nsQuery, 1),
+		ServiceList:               common.GetServiceListChannel(client, nsQuery, 1),
+		DeploymentList:            common.GetDeploymentListChannel(client, nsQuery, 1),
+		ReplicaSetList:            common.GetReplicaSetListChannel(client, nsQuery, 1),
+		ReplicationControllerList: common.GetReplicationControllerListChannel(client, nsQuery, 1),
+		DaemonSetList:             common.GetDaemonSetListChannel(client, nsQuery, 1),
+		StatefulSetList:           common.GetStatefulSetListChannel(client, nsQuery, 1),
+		JobList:                   common.GetJobListChannel(client, nsQuery, 1),
+		ConfigMapList:             common.GetConfigMapListChannel(client, nsQuery, 1),
+		SecretList:                common.GetSecretListChannel(client, nsQuery, 1),
+		IngressList:               common.GetIngressListChannel(client, nsQuery, 1),
+		PersistentVolumeClaimList: common.GetPersistentVolumeClaimListChannel(client, nsQuery, 1),
+		NamespaceList:             common.GetNamespaceListChannel(client, 1),
+		NodeList:                  common.GetNodeListChannel(client, 1),
+	}
+
+	search := &globalSearch{query: strings.ToLower(query), errors: make([]error, 0)}
+
+	pods := <-channels.PodList.List
+	if ok, err := search.handleError(<-channels.PodList.Error); err != nil {
+		return nil, err
+	} else if ok {
+		for _, item := range pods.Items {
+			search.add(api.ResourceKindPod, item.ObjectMeta)
+		}
+	}
+
+	services := <-channels.ServiceList.List
+	if ok, err := search.handleError(<-channels.ServiceList.Error); err != nil {
+		return nil, err
+	} else if ok {
+		for _, item := range services.Items {
+			search.add(api.ResourceKindService, item.ObjectMeta)
+		}
+	}
+
+	deployments := <-channels.DeploymentList.List
+	if ok, err := search.handleError(<-channels.DeploymentList.Error); err != nil {
+		return nil, err
+	} else if ok {
+		for _, item := range deployments.Items {
+			search.add(api.ResourceKindDeployment, item.ObjectMeta)
+		}
+	}
+
+	replicaSets := <-channels.ReplicaSetList.List
+	if ok, err := search.handleError(<-channels.ReplicaSetList.Error); err != nil {
+		return nil, err
+	} else if ok {
+		for _, item := range replicaSets.Items {
+			search.add(api.ResourceKindReplicaSet, item.ObjectMeta)
+		}
+	}
+
+	replicationControllers := <-channels.ReplicationControllerList.List
+	if ok, err := search.handleError(<-channels.ReplicationControllerList.Error); err != nil {
+		return nil, err
+	} else if ok {
+		for _, item := range replicationControllers.Items {
+			search.add(api.ResourceKindReplicationController, item.ObjectMeta)
+		}
+	}
+
+	daemonSets := <-channels.DaemonSetList.List
+	if ok, err := search.handleError(<-channels.DaemonSetList.Error); err != nil {
+		return nil, err
+	} else if ok {
+		for _, item := range daemonSets.Items {
+			search.add(api.ResourceKindDaemonSet, item.ObjectMeta)
+		}
+	}
+
+	statefulSets := <-channels.StatefulSetList.List
+	if ok, err := search.handleError(<-channels.StatefulSetList.Error); err != nil {
+		return nil, err
+	} else if ok {
+		for _, item := range statefulSets.Items {
+			search.add(api.ResourceKindStatefulSet, item.ObjectMeta)
+		}
+	}
+
+	jobs := <-channels.JobList.List
+	if ok, err := search.handleError(<-channels.JobList.Error); err != nil {
+		return nil, err
+	} else if ok {
+		for _, item := range jobs.Items {
+			search.add(api.ResourceKindJob, item.ObjectMeta)
+		}
+	}
+
+	configMaps := <-channels.ConfigMapList.List
+	if ok, err := search.handleError(<-channels.ConfigMapList.Error); err != nil {
+		return nil, err
+	} else if ok {
+		for _, item := range configMaps.Items {
+			search.add(api.ResourceKindConfigMap, item.ObjectMeta)
+		}
+	}
+
+	secrets := <-channels.SecretList.List
+	if ok, err := search.handleError(<-channels.SecretList.Error); err != nil {
+		return nil, err
+	} else if ok {
+		for _, item := range secrets.Items {
+			search.add(api.ResourceKindSecret, item.ObjectMeta)
+		}
+	}
+
+	ingresses := <-channels.IngressList.List
+	if ok, err := search.handleError(<-channels.IngressList.Error); err != nil {
+		return nil, err
+	} else if ok {
+		for _, item := range ingresses.Items {
+			search.add(api.ResourceKindIngress, item.ObjectMeta)
+		}
+	}
+
+	persistentVolumeClaims := <-channels.PersistentVolumeClaimList.List
+	if ok, err := search.handleError(<-channels.PersistentVolumeClaimList.Error); err != nil {
+		return nil, err
+	} else if ok {
+		for _, item := range persistentVolumeClaims.Items {
+			search.add(api.ResourceKindPersistentVolumeClaim, item.ObjectMeta)
+		}
+	}
+
+	namespaces := <-channels.NamespaceList.List
+	if ok, err := search.handleError(<-channels.NamespaceList.Error); err != nil {
+		return nil, err
+	} else if ok {
+		for _, item := range namespaces.Items {
+			if nsQuery.Matches(item.Name) {
+				search.add(api.ResourceKindNamespace, item.ObjectMeta)
+			}
+		}
+	}
+
+	nodes := <-channels.NodeList.List
+	if ok, err := search.handleError(<-channels.NodeList.Error); err != nil {
+		return nil, err
+	} else if ok {
+		for _, item := range nodes.Items {
+			search.add(api.ResourceKindNode, item.ObjectMeta)
+		}
+	}
+
+	return toGlobalSearchResult(search.results, search.errors, dsQuery), nil
+}
+
+// toGlobalSearchResult orders results by relevance, kind, namespace and name and applies
+// pagination.
+func toGlobalSearchResult(results []GlobalSearchItem, nonCriticalErrors []error,
+	dsQuery *dataselect.DataSelectQuery) *GlobalSearchResult {
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.relevance != b.relevance {
+			return a.relevance < b.relevance
+		}
+		if a.TypeMeta.Kind != b.TypeMeta.Kind {
+			return a.TypeMeta.Kind < b.TypeMeta.Kind
+		}
+		if a.ObjectMeta.Namespace != b.ObjectMeta.Namespace {
+			return a.ObjectMeta.Namespace < b.ObjectMeta.Namespace
+		}
+		return a.ObjectMeta.Name < b.ObjectMeta.Name
+	})
+
+	cells := make([]dataselect.DataCell, len(results))
+	for i := range results {
+		cells[i] = GlobalSearchItemCell(results[i])
+	}
+	selector := dataselect.DataSelector{GenericDataList: cells, DataSelectQuery: dsQuery}
+	cells = selector.Paginate().GenericDataList
+
+	result := &GlobalSearchResult{
+		ListMeta: api.ListMeta{TotalItems: len(results)},
+		Results:  make([]GlobalSearchItem, len(cells)),
+		Errors:   nonCriticalErrors,
+	}
+	for i := range cells {
+		result.Results[i] = GlobalSearchItem(cells[i].(GlobalSearchItemCell))
+	}
+	return result
+}
+
+// GlobalSearchItemCell allows to paginate search results with data select.
+type GlobalSearchItemCell GlobalSearchItem
+
+// GetProperty implements DataCell interface.
+func (self GlobalSearchItemCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func TestGetRelevance(t *testing.T) {
+	cases := []struct {
+		name, query string
+		expected    int
+	}{
+		{"frontend", "frontend", exactMatch},
+		{"Frontend", "frontend", exactMatch},
+		{"frontend-v2", "frontend", prefixMatch},
+		{"my-frontend", "frontend", substringMatch},
+		{"backend", "frontend", noMatch},
+	}
+	for _, c := range cases {
+		actual := getRelevance(c.name, c.query)
+		if actual != c.expected {
+			t.Errorf("getRelevance(%#v, %#v) returns %#v, expected %#v", c.name, c.query, actual,
+				c.expected)
+		}
+	}
+}
+
+func TestGlobalSearch(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "my-frontend", Namespace: "default"}},
+		&v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "backend", Namespace: "default"}},
+		&v1.Service{ObjectMeta: metaV1.ObjectMeta{Name: "frontend", Namespace: "default"}},
+		&v1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{Name: "frontend-token", Namespace: "default",
+				Annotations: map[string]string{"key": "value"}},
+			Data: map[string][]byte{"token": []byte("secret")},
+		},
+		&v1.ConfigMap{ObjectMeta: metaV1.ObjectMeta{Name: "frontend-config", Namespace: "other"}},
+	)
+
+	actual, err := GlobalSearch(client, common.NewNamespaceQuery([]string{"default"}), "FrontEnd",
+		dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatalf("GlobalSearch() returned error: %s", err)
+	}
+
+	expected := &GlobalSearchResult{
+		ListMeta: api.ListMeta{TotalItems: 3},
+		Results: []GlobalSearchItem{
+			{
+				ObjectMeta: api.ObjectMeta{Name: "frontend", Namespace: "default"},
+				TypeMeta:   api.TypeMeta{Kind: api.ResourceKindService},
+				relevance:  exactMatch,
+			},
+			{
+				ObjectMeta: api.ObjectMeta{Name: "frontend-token", Namespace: "default"},
+				TypeMeta:   api.TypeMeta{Kind: api.ResourceKindSecret},
+				relevance:  prefixMatch,
+			},
+			{
+				ObjectMeta: api.ObjectMeta{Name: "my-frontend", Namespace: "default"},
+				TypeMeta:   api.TypeMeta{Kind: api.ResourceKindPod},
+				relevance:  substringMatch,
+			},
+		},
+		Errors: []error{},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GlobalSearch() returns %#v, expected %#v", actual, expected)
+	}
+}
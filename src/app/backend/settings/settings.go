@@ -0,0 +1,361 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package settings persists global and per-user Dashboard settings in a ConfigMap. The ConfigMap is
+// watched, so changes made by other replicas or with kubectl are applied without restart.
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// ConfigMapName is the name of ConfigMap that settings are stored in.
+	ConfigMapName = "kubernetes-dashboard-settings"
+	// GlobalSettingsKey is the key of global settings in the ConfigMap.
+	GlobalSettingsKey = "global.json"
+	// UserSettingsKey is the key of per-user settings, by user name, in the ConfigMap.
+	UserSettingsKey = "users.json"
+
+	// MaxItemsPerPage limits number of items shown on a single page.
+	MaxItemsPerPage = 1000
+
+	// DefaultCertificateExpiryWarningDays is used when the warning threshold of certificates is not set.
+	DefaultCertificateExpiryWarningDays = 30
+)
+
+// LogsSettings are default settings of the logs view.
+type LogsSettings struct {
+	ShowTimestamp bool `json:"showTimestamp"`
+	Compact       bool `json:"compact"`
+	Inverted      bool `json:"inverted"`
+}
+
+// Settings are global settings of Dashboard.
+type Settings struct {
+	// ClusterName is the name of the cluster shown to users.
+	ClusterName      string       `json:"clusterName"`
+	ItemsPerPage     int          `json:"itemsPerPage"`
+	DefaultNamespace string       `json:"defaultNamespace"`
+	Logs             LogsSettings `json:"logs"`
+
+	// ChartRepositories are Helm chart repositories of the app catalog added in Dashboard.
+	ChartRepositories []ChartRepository `json:"chartRepositories,omitempty"`
+
+	// CertificateExpiryWarningDays is the number of days before expiry, when certificates start to be
+	// reported as expiring. Zero means DefaultCertificateExpiryWarningDays.
+	CertificateExpiryWarningDays int `json:"certificateExpiryWarningDays,omitempty"`
+
+	// GitOpsEditWarning enables warnings on edits of objects managed by Argo CD or Flux.
+	GitOpsEditWarning bool `json:"gitOpsEditWarning,omitempty"`
+
+	// CostModel configures prices used to estimate cost of namespaces and workloads. Default prices
+	// are used if it is not set.
+	CostModel *CostModel `json:"costModel,omitempty"`
+}
+
+// ChartRepository is a Helm chart repository.
+type ChartRepository struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// CostModel are prices of resources used to estimate cost.
+type CostModel struct {
+	// Currency of the prices, i.e. USD.
+	Currency string `json:"currency"`
+	// CPUHourPrice is the price of one CPU core per hour.
+	CPUHourPrice float64 `json:"cpuHourPrice"`
+	// MemoryGBHourPrice is the price of one GiB of memory per hour.
+	MemoryGBHourPrice float64 `json:"memoryGBHourPrice"`
+	// NodeHourPrices are prices of nodes per hour by instance type. Price of a node of listed type
+	// is split between its CPU and memory in the ratio of CPU and memory prices.
+	NodeHourPrices map[string]float64 `json:"nodeHourPrices,omitempty"`
+}
+
+// UserSettings are preferences of a single user. Fields that are not set fall back to global
+// settings.
+type UserSettings struct {
+	ItemsPerPage     int           `json:"itemsPerPage,omitempty"`
+	DefaultNamespace string        `json:"defaultNamespace,omitempty"`
+	Logs             *LogsSettings `json:"logs,omitempty"`
+}
+
+// DefaultSettings are used until settings are saved.
+var DefaultSettings = Settings{
+	ItemsPerPage:     15,
+	DefaultNamespace: "default",
+	Logs:             LogsSettings{Inverted: true},
+}
+
+// SettingsManager keeps settings loaded from the ConfigMap up to date and saves changes to it.
+type SettingsManager interface {
+	// GetGlobalSettings returns global settings.
+	GetGlobalSettings() Settings
+	// SaveGlobalSettings saves global settings with given client, so that only users allowed to
+	// update the ConfigMap can change them.
+	SaveGlobalSettings(client kubernetes.Interface, settings Settings) error
+	// GetUserSettings returns settings of given user.
+	GetUserSettings(user string) UserSettings
+	// SaveUserSettings saves settings of given user. The user has to be authenticated before.
+	SaveUserSettings(user string, settings UserSettings) error
+}
+
+// settingsManager implements SettingsManager with ConfigMap watched by Dashboard's own client.
+type settingsManager struct {
+	sync.RWMutex
+	client    kubernetes.Interface
+	namespace string
+	global    Settings
+	users     map[string]UserSettings
+}
+
+// GetGlobalSettings implements SettingsManager interface.
+func (self *settingsManager) GetGlobalSettings() Settings {
+	self.RLock()
+	defer self.RUnlock()
+	return self.global
+}
+
+// GetUserSettings implements SettingsManager interface.
+func (self *settingsManager) GetUserSettings(user string) UserSettings {
+	self.RLock()
+	defer self.RUnlock()
+	return self.users[user]
+}
+
+// SaveGlobalSettings implements SettingsManager interface.
+func (self *settingsManager) SaveGlobalSettings(client kubernetes.Interface, settings Settings) error {
+	if err := ValidateSettings(settings); err != nil {
+		return err
+	}
+
+	return self.save(client, func(configMap *v1.ConfigMap) error {
+		return marshalInto(configMap, GlobalSettingsKey, settings)
+	})
+}
+
+// SaveUserSettings implements SettingsManager interface.
+func (self *settingsManager) SaveUserSettings(user string, settings UserSettings) error {
+	if user == "" {
+		return errorsK8s.NewBadRequest("settings can be saved only for authenticated users")
+	}
+	if err := validateUserSettings(settings); err != nil {
+		return err
+	}
+
+	return self.save(self.client, func(configMap *v1.ConfigMap) error {
+		users := make(map[string]UserSettings)
+		if data, ok := configMap.Data[UserSettingsKey]; ok {
+			if err := json.Unmarshal([]byte(data), &users); err != nil {
+				return err
+			}
+		}
+		users[user] = settings
+		return marshalInto(configMap, UserSettingsKey, users)
+	})
+}
+
+// save applies the change to the ConfigMap, which is created if it does not exist yet, and
+// updates settings in memory without waiting for the watch event.
+func (self *settingsManager) save(client kubernetes.Interface, change func(*v1.ConfigMap) error) error {
+	configMaps := client.CoreV1().ConfigMaps(self.namespace)
+	configMap, err := configMaps.Get(ConfigMapName, metaV1.GetOptions{})
+	notFound := errorsK8s.IsNotFound(err)
+	if err != nil && !notFound {
+		return err
+	}
+	if notFound {
+		configMap = &v1.ConfigMap{
+			ObjectMeta: metaV1.ObjectMeta{Name: ConfigMapName, Namespace: self.namespace},
+		}
+	}
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+
+	if err := change(configMap); err != nil {
+		return err
+	}
+
+	if notFound {
+		configMap, err = configMaps.Create(configMap)
+	} else {
+		configMap, err = configMaps.Update(configMap)
+	}
+	if err != nil {
+		return err
+	}
+
+	self.load(configMap)
+	return nil
+}
+
+// load replaces settings in memory with settings from the ConfigMap. Nil ConfigMap restores
+// default settings.
+func (self *settingsManager) load(configMap *v1.ConfigMap) {
+	global := DefaultSettings
+	users := make(map[string]UserSettings)
+
+	if configMap != nil {
+		if data, ok := configMap.Data[GlobalSettingsKey]; ok {
+			if err := json.Unmarshal([]byte(data), &global); err != nil {
+				logger.Errorf("Invalid global settings in ConfigMap %s: %s", ConfigMapName, err)
+				global = DefaultSettings
+			}
+		}
+		if data, ok := configMap.Data[UserSettingsKey]; ok {
+			if err := json.Unmarshal([]byte(data), &users); err != nil {
+				logger.Errorf("Invalid user settings in ConfigMap %s: %s", ConfigMapName, err)
+			}
+		}
+	}
+
+	self.Lock()
+	defer self.Unlock()
+	self.global = global
+	self.users = users
+}
+
+// run watches the ConfigMap and reloads settings on every change.
+func (self *settingsManager) run(stop <-chan struct{}) {
+	selector := fields.OneTermEqualSelector("metadata.name", ConfigMapName).String()
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options metaV1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return self.client.CoreV1().ConfigMaps(self.namespace).List(options)
+		},
+		WatchFunc: func(options metaV1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return self.client.CoreV1().ConfigMaps(self.namespace).Watch(options)
+		},
+	}
+
+	_, controller := cache.NewInformer(listWatch, &v1.ConfigMap{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			self.load(obj.(*v1.ConfigMap))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			self.load(newObj.(*v1.ConfigMap))
+		},
+		DeleteFunc: func(obj interface{}) {
+			self.load(nil)
+		},
+	})
+	controller.Run(stop)
+}
+
+// ValidateSettings returns bad request error if settings are not valid.
+func ValidateSettings(settings Settings) error {
+	if settings.ItemsPerPage < 1 {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("items per page has to be between 1 and %d",
+			MaxItemsPerPage))
+	}
+	if err := validateChartRepositories(settings.ChartRepositories); err != nil {
+		return err
+	}
+	if settings.CertificateExpiryWarningDays < 0 {
+		return errorsK8s.NewBadRequest("certificate expiry warning days cannot be negative")
+	}
+	if err := validateCostModel(settings.CostModel); err != nil {
+		return err
+	}
+	return validateUserSettings(UserSettings{
+		ItemsPerPage:     settings.ItemsPerPage,
+		DefaultNamespace: settings.DefaultNamespace,
+	})
+}
+
+func validateChartRepositories(repos []ChartRepository) error {
+	names := make(map[string]bool, len(repos))
+	for _, repo := range repos {
+		if errs := validation.IsDNS1123Label(repo.Name); len(errs) > 0 {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("invalid chart repository name %q: %v",
+				repo.Name, errs))
+		}
+		if names[repo.Name] {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("duplicate chart repository %s", repo.Name))
+		}
+		names[repo.Name] = true
+
+		location, err := url.Parse(repo.URL)
+		if err != nil || (location.Scheme != "http" && location.Scheme != "https") ||
+			location.Host == "" {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("invalid URL of %s chart repository: %q",
+				repo.Name, repo.URL))
+		}
+	}
+	return nil
+}
+
+func validateCostModel(model *CostModel) error {
+	if model == nil {
+		return nil
+	}
+	if model.CPUHourPrice < 0 || model.MemoryGBHourPrice < 0 {
+		return errorsK8s.NewBadRequest("prices of CPU and memory cannot be negative")
+	}
+	for instanceType, price := range model.NodeHourPrices {
+		if price < 0 {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("price of %s nodes cannot be negative", instanceType))
+		}
+	}
+	return nil
+}
+
+func validateUserSettings(settings UserSettings) error {
+	if settings.ItemsPerPage < 0 || settings.ItemsPerPage > MaxItemsPerPage {
+		return errorsK8s.NewBadRequest(fmt.Sprintf("items per page has to be between 1 and %d",
+			MaxItemsPerPage))
+	}
+	if settings.DefaultNamespace != "" {
+		if errs := validation.IsDNS1123Label(settings.DefaultNamespace); len(errs) > 0 {
+			return errorsK8s.NewBadRequest(fmt.Sprintf("invalid default namespace: %v", errs))
+		}
+	}
+	return nil
+}
+
+func marshalInto(configMap *v1.ConfigMap, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	configMap.Data[key] = string(data)
+	return nil
+}
+
+// NewSettingsManager creates settings manager that stores settings in ConfigMap in given namespace
+// and watches it with given client until stop channel is closed.
+func NewSettingsManager(client kubernetes.Interface, namespace string,
+	stop <-chan struct{}) SettingsManager {
+	manager := &settingsManager{client: client, namespace: namespace}
+	manager.load(nil)
+	go manager.run(stop)
+	return manager
+}
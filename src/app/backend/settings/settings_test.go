@@ -0,0 +1,163 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package settings
+
+import (
+	"reflect"
+	"testing"
+
+	errorsK8s "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+func newTestManager() (*settingsManager, *fake.Clientset) {
+	client := fake.NewSimpleClientset()
+	manager := &settingsManager{client: client, namespace: "kube-system"}
+	manager.load(nil)
+	return manager, client
+}
+
+func TestSaveGlobalSettings(t *testing.T) {
+	manager, client := newTestManager()
+	if actual := manager.GetGlobalSettings(); !reflect.DeepEqual(actual, DefaultSettings) {
+		t.Errorf("GetGlobalSettings() == %#v, expected defaults %#v", actual, DefaultSettings)
+	}
+
+	expected := Settings{ClusterName: "prod", ItemsPerPage: 50, DefaultNamespace: "apps"}
+	if err := manager.SaveGlobalSettings(client, expected); err != nil {
+		t.Fatal(err)
+	}
+	if actual := manager.GetGlobalSettings(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetGlobalSettings() == %#v, expected %#v", actual, expected)
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps("kube-system").Get(ConfigMapName, metaV1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedData := `{"clusterName":"prod","itemsPerPage":50,"defaultNamespace":"apps",` +
+		`"logs":{"showTimestamp":false,"compact":false,"inverted":false}}`
+	if configMap.Data[GlobalSettingsKey] != expectedData {
+		t.Errorf("ConfigMap has global settings %s, expected %s", configMap.Data[GlobalSettingsKey],
+			expectedData)
+	}
+
+	expected.ItemsPerPage = 10
+	if err := manager.SaveGlobalSettings(client, expected); err != nil {
+		t.Fatal(err)
+	}
+	if actual := manager.GetGlobalSettings(); actual.ItemsPerPage != 10 {
+		t.Errorf("GetGlobalSettings() returns %d items per page after update, expected 10",
+			actual.ItemsPerPage)
+	}
+}
+
+func TestSaveUserSettings(t *testing.T) {
+	manager, _ := newTestManager()
+
+	jane := UserSettings{ItemsPerPage: 100, Logs: &LogsSettings{ShowTimestamp: true}}
+	john := UserSettings{DefaultNamespace: "john"}
+	if err := manager.SaveUserSettings("jane@example.com", jane); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.SaveUserSettings("john", john); err != nil {
+		t.Fatal(err)
+	}
+
+	if actual := manager.GetUserSettings("jane@example.com"); !reflect.DeepEqual(actual, jane) {
+		t.Errorf("GetUserSettings() == %#v, expected %#v", actual, jane)
+	}
+	if actual := manager.GetUserSettings("john"); !reflect.DeepEqual(actual, john) {
+		t.Errorf("GetUserSettings() == %#v, expected %#v", actual, john)
+	}
+	if actual := manager.GetUserSettings("unknown"); !reflect.DeepEqual(actual, UserSettings{}) {
+		t.Errorf("GetUserSettings() == %#v for unknown user, expected empty settings", actual)
+	}
+
+	if err := manager.SaveUserSettings("", john); !errorsK8s.IsBadRequest(err) {
+		t.Errorf("SaveUserSettings() for anonymous user returns %v, expected bad request", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	manager, _ := newTestManager()
+	manager.load(&v1.ConfigMap{Data: map[string]string{
+		GlobalSettingsKey: `{"clusterName": "staging", "itemsPerPage": 20}`,
+		UserSettingsKey:   `{"jane": {"itemsPerPage": 30}}`,
+	}})
+
+	expected := Settings{ClusterName: "staging", ItemsPerPage: 20, DefaultNamespace: "default",
+		Logs: LogsSettings{Inverted: true}}
+	if actual := manager.GetGlobalSettings(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("GetGlobalSettings() == %#v, expected %#v", actual, expected)
+	}
+	if actual := manager.GetUserSettings("jane"); actual.ItemsPerPage != 30 {
+		t.Errorf("GetUserSettings() returns %d items per page, expected 30", actual.ItemsPerPage)
+	}
+
+	manager.load(&v1.ConfigMap{Data: map[string]string{GlobalSettingsKey: "invalid"}})
+	if actual := manager.GetGlobalSettings(); !reflect.DeepEqual(actual, DefaultSettings) {
+		t.Errorf("GetGlobalSettings() == %#v for invalid data, expected defaults", actual)
+	}
+}
+
+func TestValidateSettings(t *testing.T) {
+	cases := []struct {
+		settings Settings
+		valid    bool
+	}{
+		{DefaultSettings, true},
+		{Settings{ItemsPerPage: 0}, false},
+		{Settings{ItemsPerPage: MaxItemsPerPage + 1}, false},
+		{Settings{ItemsPerPage: 10, DefaultNamespace: "Invalid_Namespace"}, false},
+		{Settings{ItemsPerPage: 10}, true},
+		{Settings{ItemsPerPage: 10, CertificateExpiryWarningDays: -1}, false},
+		{Settings{ItemsPerPage: 10, CostModel: &CostModel{CPUHourPrice: 0.03, MemoryGBHourPrice: 0.004}}, true},
+		{Settings{ItemsPerPage: 10, CostModel: &CostModel{CPUHourPrice: -1}}, false},
+		{Settings{ItemsPerPage: 10, CostModel: &CostModel{NodeHourPrices: map[string]float64{"m5.large": -1}}}, false},
+	}
+
+	for _, c := range cases {
+		err := ValidateSettings(c.settings)
+		if (err == nil) != c.valid {
+			t.Errorf("ValidateSettings(%#v) returns %v, expected valid %t", c.settings, err, c.valid)
+		}
+	}
+}
+
+func TestValidateChartRepositories(t *testing.T) {
+	cases := []struct {
+		repos []ChartRepository
+		valid bool
+	}{
+		{[]ChartRepository{{Name: "stable", URL: "https://charts.example.com"}}, true},
+		{[]ChartRepository{{Name: "Stable", URL: "https://charts.example.com"}}, false},
+		{[]ChartRepository{{Name: "stable", URL: "file:///etc"}}, false},
+		{[]ChartRepository{{Name: "stable", URL: "https://a.example.com"},
+			{Name: "stable", URL: "https://b.example.com"}}, false},
+	}
+
+	for _, c := range cases {
+		settings := DefaultSettings
+		settings.ChartRepositories = c.repos
+		err := ValidateSettings(settings)
+		if (err == nil) != c.valid {
+			t.Errorf("ValidateSettings() with repositories %v returns %v, expected valid %t", c.repos,
+				err, c.valid)
+		}
+	}
+}
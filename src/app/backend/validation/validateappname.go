@@ -15,8 +15,7 @@
 package validation
 
 import (
-	"log"
-
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	client "k8s.io/client-go/kubernetes"
@@ -37,7 +36,7 @@ type AppNameValidity struct {
 // ValidateAppName validates application name. When error is returned, name validity could not be
 // determined.
 func ValidateAppName(spec *AppNameValiditySpec, client client.Interface) (*AppNameValidity, error) {
-	log.Printf("Validating %s application name in %s namespace", spec.Name, spec.Namespace)
+	logger.Infof("Validating %s application name in %s namespace", spec.Name, spec.Namespace)
 
 	isValidRc := false
 	isValidService := false
@@ -62,7 +61,7 @@ func ValidateAppName(spec *AppNameValiditySpec, client client.Interface) (*AppNa
 
 	isValid := isValidRc && isValidService
 
-	log.Printf("Validation result for %s application name in %s namespace is %t", spec.Name,
+	logger.Infof("Validation result for %s application name in %s namespace is %t", spec.Name,
 		spec.Namespace, isValid)
 
 	return &AppNameValidity{Valid: isValid}, nil
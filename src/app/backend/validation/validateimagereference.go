@@ -15,9 +15,8 @@
 package validation
 
 import (
-	"log"
-
 	"github.com/docker/distribution/reference"
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 )
 
 // ImageReferenceValiditySpec is a specification of an image reference validation request.
@@ -36,7 +35,7 @@ type ImageReferenceValidity struct {
 
 // ValidateImageReference validates image reference.
 func ValidateImageReference(spec *ImageReferenceValiditySpec) (*ImageReferenceValidity, error) {
-	log.Printf("Validating %s as an image reference", spec.Reference)
+	logger.Infof("Validating %s as an image reference", spec.Reference)
 
 	s := spec.Reference
 	_, err := reference.ParseNamed(s)
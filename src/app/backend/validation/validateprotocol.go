@@ -15,8 +15,7 @@
 package validation
 
 import (
-	"log"
-
+	"github.com/kubernetes/dashboard/src/app/backend/logger"
 	api "k8s.io/client-go/pkg/api/v1"
 )
 
@@ -37,13 +36,13 @@ type ProtocolValidity struct {
 
 // ValidateProtocol validates protocol based on whether created service is set to NodePort or NodeBalancer type.
 func ValidateProtocol(spec *ProtocolValiditySpec) *ProtocolValidity {
-	log.Printf("Validating %s protocol for service with external set to %v", spec.Protocol, spec.IsExternal)
+	logger.Infof("Validating %s protocol for service with external set to %v", spec.Protocol, spec.IsExternal)
 
 	isValid := true
 	if spec.Protocol == api.ProtocolUDP && spec.IsExternal {
 		isValid = false
 	}
 
-	log.Printf("Validation result for %s protocol is %v", spec.Protocol, isValid)
+	logger.Infof("Validation result for %s protocol is %v", spec.Protocol, isValid)
 	return &ProtocolValidity{Valid: isValid}
 }
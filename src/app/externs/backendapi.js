@@ -44,6 +44,8 @@ backendApi.ErrStatus;
 /**
  * @typedef {{
  *   totalItems: number,
+ *   continue: (string|undefined),
+ *   remainingItemCount: (number|undefined),
  * }}
  */
 backendApi.ListMeta;
@@ -88,10 +90,347 @@ backendApi.Label;
  *   memoryRequirement: ?string,
  *   cpuRequirement: ?number,
  *   runAsPrivileged: boolean,
+ *   memoryLimit: (?string|undefined),
+ *   cpuLimit: (?number|undefined),
+ *   livenessProbe: (?backendApi.ProbeSpec|undefined),
+ *   readinessProbe: (?backendApi.ProbeSpec|undefined),
+ *   initContainers: (!Array<!backendApi.InitContainerSpec>|undefined),
+ *   envFrom: (!Array<!backendApi.EnvFromSpec>|undefined),
+ *   nodeSelector: (!Array<!backendApi.Label>|undefined),
  * }}
  */
 backendApi.AppDeploymentSpec;
 
+/**
+ * @typedef {{
+ *   type: string,
+ *   path: string,
+ *   port: number,
+ *   command: !Array<string>,
+ *   initialDelaySeconds: number,
+ *   periodSeconds: number,
+ *   timeoutSeconds: number,
+ *   failureThreshold: number
+ * }}
+ */
+backendApi.ProbeSpec;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   containerImage: string,
+ *   containerCommand: ?string,
+ *   containerCommandArgs: ?string
+ * }}
+ */
+backendApi.InitContainerSpec;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   name: string,
+ *   prefix: string
+ * }}
+ */
+backendApi.EnvFromSpec;
+
+/**
+ * @typedef {{
+ *   content: string
+ * }}
+ */
+backendApi.AppDeploymentPreview;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   namespace: string,
+ *   name: string
+ * }}
+ */
+backendApi.RelatedObjectNode;
+
+/**
+ * @typedef {{
+ *   from: !backendApi.RelatedObjectNode,
+ *   to: !backendApi.RelatedObjectNode,
+ *   type: string
+ * }}
+ */
+backendApi.RelatedObjectEdge;
+
+/**
+ * @typedef {{
+ *   root: !backendApi.RelatedObjectNode,
+ *   nodes: !Array<!backendApi.RelatedObjectNode>,
+ *   edges: !Array<!backendApi.RelatedObjectEdge>
+ * }}
+ */
+backendApi.RelatedObjects;
+
+/**
+ * @typedef {{
+ *   type: string,
+ *   volume: (string|undefined),
+ *   container: (string|undefined),
+ *   env: (string|undefined),
+ *   key: (string|undefined)
+ * }}
+ */
+backendApi.ObjectUsageReference;
+
+/**
+ * @typedef {{
+ *   object: !backendApi.RelatedObjectNode,
+ *   controller: ?backendApi.RelatedObjectNode,
+ *   references: !Array<!backendApi.ObjectUsageReference>
+ * }}
+ */
+backendApi.ObjectUser;
+
+/**
+ * @typedef {{
+ *   object: !backendApi.RelatedObjectNode,
+ *   users: !Array<!backendApi.ObjectUser>,
+ *   workloads: !Array<!backendApi.RelatedObjectNode>
+ * }}
+ */
+backendApi.ObjectUsage;
+
+/**
+ * @typedef {{
+ *   dryRun: boolean
+ * }}
+ */
+backendApi.RestartSpec;
+
+/**
+ * @typedef {{
+ *   workload: !backendApi.RelatedObjectNode,
+ *   restarted: boolean,
+ *   reason: (string|undefined)
+ * }}
+ */
+backendApi.RestartResult;
+
+/**
+ * @typedef {{
+ *   object: !backendApi.RelatedObjectNode,
+ *   dryRun: boolean,
+ *   results: !Array<!backendApi.RestartResult>
+ * }}
+ */
+backendApi.RestartResultList;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   name: string,
+ *   clusterWide: (boolean|undefined)
+ * }}
+ */
+backendApi.RoleRefSpec;
+
+/**
+ * @typedef {{
+ *   serviceAccount: string,
+ *   roles: !Array<!backendApi.RoleRefSpec>,
+ *   expirationSeconds: (number|undefined),
+ *   server: (string|undefined)
+ * }}
+ */
+backendApi.KubeConfigSpec;
+
+/**
+ * @typedef {{
+ *   serviceAccount: string,
+ *   created: boolean,
+ *   bindings: !Array<string>,
+ *   expirationTimestamp: string,
+ *   fileName: string,
+ *   kubeConfig: string
+ * }}
+ */
+backendApi.GeneratedKubeConfig;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   name: string,
+ *   namespace: (string|undefined)
+ * }}
+ */
+backendApi.RbacSubject;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   name: string,
+ *   namespace: (string|undefined),
+ *   roleKind: string,
+ *   roleName: string
+ * }}
+ */
+backendApi.RbacBindingRef;
+
+/**
+ * @typedef {{
+ *   apiGroup: string,
+ *   resource: string,
+ *   resourceNames: !Array<string>,
+ *   verbs: !Array<string>
+ * }}
+ */
+backendApi.ResourcePermission;
+
+/**
+ * @typedef {{
+ *   url: string,
+ *   verbs: !Array<string>
+ * }}
+ */
+backendApi.NonResourcePermission;
+
+/**
+ * @typedef {{
+ *   namespace: string,
+ *   permissions: !Array<!backendApi.ResourcePermission>
+ * }}
+ */
+backendApi.NamespacePermissions;
+
+/**
+ * @typedef {{
+ *   subject: !backendApi.RbacSubject,
+ *   bindings: !Array<!backendApi.RbacBindingRef>,
+ *   missingRoles: !Array<!backendApi.RbacBindingRef>,
+ *   clusterPermissions: !Array<!backendApi.ResourcePermission>,
+ *   nonResourcePermissions: !Array<!backendApi.NonResourcePermission>,
+ *   namespacePermissions: !Array<!backendApi.NamespacePermissions>
+ * }}
+ */
+backendApi.SubjectPermissions;
+
+/**
+ * @typedef {{
+ *   verb: string,
+ *   apiGroup: string,
+ *   resource: string,
+ *   resourceName: string,
+ *   namespace: string
+ * }}
+ */
+backendApi.WhoCanQuery;
+
+/**
+ * @typedef {{
+ *   subject: !backendApi.RbacSubject,
+ *   bindings: !Array<!backendApi.RbacBindingRef>
+ * }}
+ */
+backendApi.SubjectAccess;
+
+/**
+ * @typedef {{
+ *   query: !backendApi.WhoCanQuery,
+ *   subjects: !Array<!backendApi.SubjectAccess>
+ * }}
+ */
+backendApi.WhoCanResult;
+
+/**
+ * @typedef {{
+ *   verbs: !Array<string>,
+ *   apiGroups: (!Array<string>|undefined),
+ *   resources: (!Array<string>|undefined),
+ *   resourceNames: (!Array<string>|undefined),
+ *   nonResourceURLs: (!Array<string>|undefined)
+ * }}
+ */
+backendApi.PolicyRule;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   namespace: string,
+ *   labels: (!Object<string, string>|undefined),
+ *   rules: !Array<!backendApi.PolicyRule>
+ * }}
+ */
+backendApi.RbacRoleSpec;
+
+/**
+ * @typedef {{
+ *   apiGroup: (string|undefined),
+ *   kind: string,
+ *   name: string
+ * }}
+ */
+backendApi.RbacRoleRef;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   apiGroup: (string|undefined),
+ *   name: string,
+ *   namespace: (string|undefined)
+ * }}
+ */
+backendApi.RbacBindingSubject;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   namespace: string,
+ *   labels: (!Object<string, string>|undefined),
+ *   roleRef: !backendApi.RbacRoleRef,
+ *   subjects: !Array<!backendApi.RbacBindingSubject>
+ * }}
+ */
+backendApi.RbacRoleBindingSpec;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   namespace: string,
+ *   name: string
+ * }}
+ */
+backendApi.ResourceRef;
+
+/**
+ * @typedef {{
+ *   resources: !Array<!backendApi.ResourceRef>,
+ *   labels: !Object<string, string>,
+ *   removeLabels: !Array<string>,
+ *   annotations: !Object<string, string>,
+ *   removeAnnotations: !Array<string>,
+ *   dryRun: boolean
+ * }}
+ */
+backendApi.MetadataEditSpec;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   namespace: string,
+ *   name: string,
+ *   labels: !Object<string, string>,
+ *   annotations: !Object<string, string>,
+ *   changed: boolean,
+ *   error: (string|undefined)
+ * }}
+ */
+backendApi.MetadataEditResult;
+
+/**
+ * @typedef {{
+ *   dryRun: boolean,
+ *   results: !Array<!backendApi.MetadataEditResult>
+ * }}
+ */
+backendApi.MetadataEditResultList;
+
 /**
  * @typedef {{
  *   name: string,
@@ -238,11 +577,30 @@ backendApi.PodMetrics;
  *   pending: number,
  *   failed: number,
  *   succeeded: number,
- *   warnings: !Array<!backendApi.Event>
+ *   warnings: !Array<!backendApi.Event>,
+ *   diagnosis: ?backendApi.Diagnosis
  * }}
  */
 backendApi.PodInfo;
 
+/**
+ * @typedef {{
+ *   reason: string,
+ *   message: string,
+ *   pods: !Array<string>
+ * }}
+ */
+backendApi.DiagnosisProblem;
+
+/**
+ * @typedef {{
+ *   healthy: boolean,
+ *   summary: string,
+ *   problems: !Array<!backendApi.DiagnosisProblem>
+ * }}
+ */
+backendApi.Diagnosis;
+
 /**
  * @typedef {{
  *   objectMeta: !backendApi.ObjectMeta,
@@ -259,6 +617,7 @@ backendApi.ReplicationController;
  *   typeMeta: !backendApi.TypeMeta,
  *   pods: !backendApi.PodInfo,
  *   containerImages: !Array<string>,
+ *   replicas: !backendApi.ReplicaCounts,
  * }}
  */
 backendApi.ReplicaSet;
@@ -296,6 +655,46 @@ backendApi.ReplicaSetList;
  */
 backendApi.Job;
 
+/**
+ * @typedef {{
+ *   state: string,
+ *   active: number,
+ *   succeeded: number,
+ *   failed: number,
+ *   startTime: ?string,
+ *   completionTime: ?string,
+ *   durationSeconds: number,
+ *   reason: string,
+ *   message: string
+ * }}
+ */
+backendApi.JobCompletion;
+
+/**
+ * @typedef {{
+ *   failures: number,
+ *   backingOff: boolean,
+ *   nextRetrySeconds: number,
+ *   limitExceeded: boolean
+ * }}
+ */
+backendApi.JobBackoff;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   phase: string,
+ *   container: string,
+ *   exitCode: ?number,
+ *   reason: string,
+ *   message: string,
+ *   restarts: number,
+ *   startTime: ?string,
+ *   finishTime: ?string
+ * }}
+ */
+backendApi.JobPodStatus;
+
 /**
  * @typedef {{
  *   objectMeta: !backendApi.ObjectMeta,
@@ -305,7 +704,10 @@ backendApi.Job;
  *   containerImages: !Array<string>,
  *   eventList: !backendApi.EventList,
  *   parallelism: number,
- *   completions: number
+ *   completions: number,
+ *   completion: !backendApi.JobCompletion,
+ *   backoff: !backendApi.JobBackoff,
+ *   podStatuses: !Array<!backendApi.JobPodStatus>
  * }}
  */
 backendApi.JobDetail;
@@ -325,6 +727,7 @@ backendApi.JobList;
  *   typeMeta: !backendApi.TypeMeta,
  *   pods: !backendApi.PodInfo,
  *   containerImages: !Array<string>,
+ *   replicas: !backendApi.ReplicaCounts,
  * }}
  */
 backendApi.StatefulSet;
@@ -337,6 +740,7 @@ backendApi.StatefulSet;
  *   podList: !backendApi.PodList,
  *   containerImages: !Array<string>,
  *   eventList: !backendApi.EventList,
+ *   gitops: (!backendApi.GitOpsOwnership|undefined),
  *   errors: !Array<!backendApi.Error>
  * }}
  */
@@ -444,6 +848,7 @@ backendApi.ResourceQuotaDetail;
  * @typedef {{
  *   used: string,
  *   hard: string,
+ *   usedPercentage: number
  * }}
  */
 backendApi.ResourceQuotaStatus;
@@ -577,6 +982,7 @@ backendApi.FlockerVolumeSource;
  *   typeMeta: !backendApi.TypeMeta,
  *   pods: !backendApi.PodInfo,
  *   containerImages: !Array<string>,
+ *   replicas: !backendApi.ReplicaCounts,
  * }}
  */
 backendApi.Deployment;
@@ -621,6 +1027,7 @@ backendApi.DeploymentInfo;
  *   oldReplicaSetList: !backendApi.ReplicaSetList,
  *   newReplicaSet: !backendApi.ReplicaSet,
  *   events: !backendApi.EventList,
+ *   gitops: (!backendApi.GitOpsOwnership|undefined),
  *   errors: !Array<!backendApi.Error>
  * }}
  */
@@ -813,23 +1220,56 @@ backendApi.Pod;
  *   image: string,
  *   env: !Array<!backendApi.EnvVar>,
  *   commands: Array<string>,
- *   args: Array<string>
+ *   args: Array<string>,
+ *   resources: !backendApi.ContainerResources,
+ *   restartCount: number,
+ *   lastTermination: ?backendApi.ContainerTermination,
+ *   oomKilled: boolean
  * }}
  */
 backendApi.Container;
 
 /**
  * @typedef {{
- *   objectMeta: !backendApi.ObjectMeta,
- *   typeMeta: !backendApi.TypeMeta,
- *   initContainers: !Array<!backendApi.Container>,
- *   containers: !Array<!backendApi.Container>,
- *   podPhase: string,
- *   podIP: string,
- *   nodeName: string,
- *   restartCount: number,
- *   metrics: backendApi.PodMetrics,
- *   conditions: !backendApi.ConditionList,
+ *   exitCode: number,
+ *   signal: number,
+ *   reason: string,
+ *   message: string,
+ *   startedAt: string,
+ *   finishedAt: string
+ * }}
+ */
+backendApi.ContainerTermination;
+
+/**
+ * @typedef {{
+ *   cpuRequests: number,
+ *   cpuLimits: number,
+ *   memoryRequests: number,
+ *   memoryLimits: number,
+ *   cpuUsage: ?number,
+ *   memoryUsage: ?number,
+ *   cpuThrottledRatio: ?number,
+ *   cpuThrottled: boolean,
+ *   memoryPressure: boolean
+ * }}
+ */
+backendApi.ContainerResources;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   initContainers: !Array<!backendApi.Container>,
+ *   containers: !Array<!backendApi.Container>,
+ *   podPhase: string,
+ *   podIP: string,
+ *   nodeName: string,
+ *   restartCount: number,
+ *   metrics: backendApi.PodMetrics,
+ *   conditions: !backendApi.ConditionList,
+ *   mesh: (!backendApi.PodMesh|undefined),
+ *   priority: (!backendApi.PodPriority|undefined),
  *   errors: !Array<!backendApi.Error>
  * }}
  */
@@ -862,6 +1302,8 @@ backendApi.RoleList;
  *  type: string,
  *  clusterIP: string,
  *  podList: !backendApi.PodList,
+ *  mesh: (!backendApi.ServiceMesh|undefined),
+ *  gitops: (!backendApi.GitOpsOwnership|undefined),
  *  errors: !Array<!backendApi.Error>
  * }}
  */
@@ -898,13 +1340,37 @@ backendApi.ServiceList;
  *  podInfo: !backendApi.PodInfo,
  *  podList: !backendApi.PodList,
  *  serviceList: !backendApi.ServiceList,
+ *  rolloutProgress: !backendApi.RolloutProgress,
+ *  nodeCoverage: !backendApi.DaemonSetCoverage,
  *  hasMetrics: boolean,
  *  eventList: !backendApi.EventList,
+ *  gitops: (!backendApi.GitOpsOwnership|undefined),
  *  errors: !Array<!backendApi.Error>
  * }}
  */
 backendApi.DaemonSetDetail;
 
+/**
+ * @typedef {{
+ *  nodeName: string,
+ *  status: string,
+ *  message: (string|undefined),
+ *  podName: (string|undefined),
+ *  podUpdated: boolean
+ * }}
+ */
+backendApi.NodeCoverage;
+
+/**
+ * @typedef {{
+ *  covered: number,
+ *  excluded: number,
+ *  missing: number,
+ *  nodes: !Array<!backendApi.NodeCoverage>
+ * }}
+ */
+backendApi.DaemonSetCoverage;
+
 /**
  * @typedef {{
  *  objectMeta: !backendApi.ObjectMeta,
@@ -934,11 +1400,72 @@ backendApi.Endpoint;
 
 /**
  * @typedef {{
- *   name: string
+ *   name: string,
+ *   resourceQuota: (!Object<string, string>|undefined),
+ *   limitRange: (!backendApi.LimitRangeSpec|undefined),
+ *   defaultDenyIngress: (boolean|undefined),
+ *   roleBindings: (!Array<!backendApi.RoleBindingSpec>|undefined)
  * }}
  */
 backendApi.NamespaceSpec;
 
+/**
+ * @typedef {{
+ *   defaultRequest: !Object<string, string>,
+ *   default: !Object<string, string>
+ * }}
+ */
+backendApi.LimitRangeSpec;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   name: string,
+ *   namespace: (string|undefined)
+ * }}
+ */
+backendApi.SubjectSpec;
+
+/**
+ * @typedef {{
+ *   clusterRole: string,
+ *   subjects: !Array<!backendApi.SubjectSpec>
+ * }}
+ */
+backendApi.RoleBindingSpec;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   finalizers: !Array<string>,
+ *   deletionTimestamp: ?string
+ * }}
+ */
+backendApi.RemainingObject;
+
+/**
+ * @typedef {{
+ *   groupVersion: string,
+ *   resource: string,
+ *   kind: string,
+ *   objects: !Array<!backendApi.RemainingObject>
+ * }}
+ */
+backendApi.RemainingResource;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   phase: string,
+ *   deletionTimestamp: ?string,
+ *   finalizers: !Array<string>,
+ *   remainingResources: !Array<!backendApi.RemainingResource>,
+ *   blockingFinalizers: !Array<{name: string, objects: number}>,
+ *   unavailableApis: !Array<{groupVersion: string, resource: (string|undefined), error: string}>
+ * }}
+ */
+backendApi.NamespaceTermination;
+
 /**
  * @typedef {{
  *   name: string,
@@ -994,7 +1521,8 @@ backendApi.LogDetails;
  *   containerName: string,
  *   fromDate: string,
  *   toDate: string,
- *   truncated: boolean
+ *   truncated: boolean,
+ *   previous: boolean
  * }}
  */
 backendApi.LogInfo;
@@ -1086,6 +1614,46 @@ backendApi.ProtocolValiditySpec;
  */
 backendApi.SecretSpec;
 
+/**
+ * @typedef {{
+ *   name: string,
+ *   namespace: string,
+ *   server: string,
+ *   username: string,
+ *   password: string,
+ *   email: string,
+ *   skipTest: boolean,
+ *   serviceAccounts: !Array<string>
+ * }}
+ */
+backendApi.RegistryCredentialSpec;
+
+/**
+ * @typedef {{
+ *   registry: string,
+ *   valid: boolean,
+ *   message: (string|undefined)
+ * }}
+ */
+backendApi.RegistryCredentialTest;
+
+/**
+ * @typedef {{
+ *   secret: !backendApi.Secret,
+ *   test: (!backendApi.RegistryCredentialTest|undefined),
+ *   serviceAccounts: !Array<string>
+ * }}
+ */
+backendApi.RegistryCredentialResult;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   name: string
+ * }}
+ */
+backendApi.ImagePullSecretTarget;
+
 /**
  * @typedef {{
  *   objectMeta: !backendApi.ObjectMeta,
@@ -1121,11 +1689,27 @@ backendApi.NamespaceDetail;
  * @typedef {{
  *   objectMeta: !backendApi.ObjectMeta,
  *   typeMeta: !backendApi.TypeMeta,
- *   data: !Object<string, string>,
+ *   keys: !Array<!backendApi.SecretDataKey>,
+ *   type: string
  * }}
  */
 backendApi.SecretDetail;
 
+/**
+ * @typedef {{
+ *   name: string,
+ *   size: number
+ * }}
+ */
+backendApi.SecretDataKey;
+
+/**
+ * @typedef {{
+ *   data: !Object<string, string>
+ * }}
+ */
+backendApi.SecretData;
+
 /**
  * @typedef {{
  *   objectMeta: !backendApi.ObjectMeta,
@@ -1212,21 +1796,68 @@ backendApi.NodeInfo;
  */
 backendApi.NodeAllocatedResources;
 
+/**
+ * @typedef {{
+ *   name: string,
+ *   namespace: string,
+ *   cpuRequests: number,
+ *   cpuLimits: number,
+ *   memoryRequests: number,
+ *   memoryLimits: number,
+ *   cpuRequestsFraction: number,
+ *   memoryRequestsFraction: number
+ * }}
+ */
+backendApi.PodAllocation;
+
+/**
+ * @typedef {{
+ *   type: string,
+ *   status: string,
+ *   reason: string,
+ *   message: string,
+ *   time: string
+ * }}
+ */
+backendApi.ConditionTransition;
+
+/**
+ * @typedef {{
+ *   key: string,
+ *   value: string,
+ *   effect: string,
+ *   timeAdded: (string|undefined)
+ * }}
+ */
+backendApi.Taint;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   taints: !Array<!backendApi.Taint>
+ * }}
+ */
+backendApi.NodeTaints;
+
 /**
  * @typedef {{
  *   objectMeta: !backendApi.ObjectMeta,
  *   typeMeta: !backendApi.TypeMeta,
  *   phase: string,
  *   allocatedResources: !backendApi.NodeAllocatedResources,
+ *   podAllocations: !Array<!backendApi.PodAllocation>,
  *   externalID: string,
  *   podCIDR: string,
  *   providerID: string,
  *   unschedulable: boolean,
  *   nodeInfo: !backendApi.NodeInfo,
  *   conditions: !backendApi.ConditionList,
+ *   pressureConditions: !Array<string>,
+ *   conditionHistory: !Array<!backendApi.ConditionTransition>,
  *   containerImages: !Array<string>,
  *   podList: !backendApi.PodList,
  *   eventList: !backendApi.EventList,
+ *   taints: (!Array<!backendApi.Taint>|undefined),
  *   errors: !Array<!backendApi.Error>
  * }}
  */
@@ -1253,6 +1884,51 @@ backendApi.NodeList;
  */
 backendApi.PersistentVolumeClaimDetail;
 
+/**
+ * @typedef {{
+ *   size: string
+ * }}
+ */
+backendApi.PersistentVolumeClaimResizeSpec;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   persistentVolumeClaim: string,
+ *   volumeSnapshotClass: string,
+ *   readyToUse: boolean,
+ *   restoreSize: string,
+ *   error: string
+ * }}
+ */
+backendApi.VolumeSnapshot;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   snapshots: !Array<!backendApi.VolumeSnapshot>
+ * }}
+ */
+backendApi.VolumeSnapshotList;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   volumeSnapshotClass: (string|undefined)
+ * }}
+ */
+backendApi.VolumeSnapshotSpec;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   storageClass: (string|undefined),
+ *   size: (string|undefined)
+ * }}
+ */
+backendApi.VolumeSnapshotRestoreSpec;
+
 /**
  * @typedef {{
  *   objectMeta: !backendApi.ObjectMeta,
@@ -1340,6 +2016,46 @@ backendApi.HorizontalPodAutoscalerList;
  */
 backendApi.StorageClass;
 
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   capacity: string,
+ *   status: string,
+ *   claim: string
+ * }}
+ */
+backendApi.StorageClassVolume;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   request: string,
+ *   status: string,
+ *   volume: string
+ * }}
+ */
+backendApi.StorageClassClaim;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   provisioner: string,
+ *   parameters: !Object<string, string>,
+ *   reclaimPolicy: string,
+ *   volumeBindingMode: string,
+ *   allowVolumeExpansion: boolean,
+ *   persistentVolumes: !Array<!backendApi.StorageClassVolume>,
+ *   persistentVolumeClaims: !Array<!backendApi.StorageClassClaim>,
+ *   capacity: string,
+ *   requestedCapacity: string,
+ *   errors: !Array<!backendApi.Error>
+ * }}
+ */
+backendApi.StorageClassDetail;
+
 /**
  * @typedef {{
  *   listMeta: !backendApi.ListMeta,
@@ -1406,5 +2122,1683 @@ backendApi.ThirdPartyResourceObjectList;
  */
 backendApi.CsrfToken;
 
-/** @typedef {{serverTime: number}} */
-const appConfig_DO_NOT_USE_DIRECTLY = {};
+/**
+ * @typedef {{
+ *   name: string,
+ *   default: boolean
+ * }}
+ */
+backendApi.Cluster;
+
+/**
+ * @typedef {{
+ *   clusters: !Array<!backendApi.Cluster>
+ * }}
+ */
+backendApi.ClusterList;
+
+/**
+ * @typedef {{
+ *   label: string,
+ *   path: string
+ * }}
+ */
+backendApi.PluginMenuEntry;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   menuEntries: !Array<!backendApi.PluginMenuEntry>
+ * }}
+ */
+backendApi.Plugin;
+
+/**
+ * @typedef {{
+ *   plugins: !Array<!backendApi.Plugin>
+ * }}
+ */
+backendApi.PluginList;
+
+/**
+ * @typedef {{
+ *   repository: string,
+ *   name: string,
+ *   description: string,
+ *   icon: string,
+ *   keywords: !Array<string>,
+ *   version: string,
+ *   appVersion: string,
+ *   versions: !Array<string>
+ * }}
+ */
+backendApi.HelmChart;
+
+/**
+ * @typedef {{
+ *   charts: !Array<!backendApi.HelmChart>,
+ *   errors: !Array<!backendApi.Error>
+ * }}
+ */
+backendApi.HelmChartList;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   email: string
+ * }}
+ */
+backendApi.HelmMaintainer;
+
+/**
+ * @typedef {{
+ *   repository: string,
+ *   name: string,
+ *   description: string,
+ *   icon: string,
+ *   keywords: !Array<string>,
+ *   version: string,
+ *   appVersion: string,
+ *   versions: !Array<string>,
+ *   home: string,
+ *   sources: !Array<string>,
+ *   maintainers: !Array<!backendApi.HelmMaintainer>,
+ *   readme: string,
+ *   values: string,
+ *   valuesSchema: (!Object|undefined)
+ * }}
+ */
+backendApi.HelmChartDetail;
+
+/**
+ * @typedef {{
+ *   releaseName: string,
+ *   namespace: string,
+ *   version: string,
+ *   values: !Object<string, *>,
+ *   valuesYaml: string
+ * }}
+ */
+backendApi.HelmInstallSpec;
+
+/** @typedef {{serverTime: number, readOnly: boolean}} */
+const appConfig_DO_NOT_USE_DIRECTLY = {};
+
+/**
+ * @typedef {{
+ *   total: number,
+ *   ready: number,
+ *   notReady: number,
+ *   unschedulable: number,
+ *   notReadyNodes: !Array<string>
+ * }}
+ */
+backendApi.NodeSummary;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   healthy: boolean,
+ *   message: string
+ * }}
+ */
+backendApi.ComponentHealth;
+
+/**
+ * @typedef {{
+ *   namespace: string,
+ *   workloads: number,
+ *   unhealthyWorkloads: number,
+ *   pods: number,
+ *   failingPods: number
+ * }}
+ */
+backendApi.NamespaceHealth;
+
+/**
+ * @typedef {{
+ *   windowSeconds: number,
+ *   count: number,
+ *   perMinute: number
+ * }}
+ */
+backendApi.WarningEventRate;
+
+/**
+ * @typedef {{
+ *   resource: string,
+ *   allocatable: number,
+ *   requested: number,
+ *   available: number,
+ *   requestedFraction: number
+ * }}
+ */
+backendApi.ResourceHeadroom;
+
+/**
+ * @typedef {{
+ *   nodes: !backendApi.NodeSummary,
+ *   controlPlane: !Array<!backendApi.ComponentHealth>,
+ *   namespaces: !Array<!backendApi.NamespaceHealth>,
+ *   warningEvents: !backendApi.WarningEventRate,
+ *   headroom: !Array<!backendApi.ResourceHeadroom>,
+ *   errors: !Array<!backendApi.Error>
+ * }}
+ */
+backendApi.Overview;
+
+/**
+ * @typedef {{
+ *   allocatable: number,
+ *   requested: number,
+ *   used: ?number,
+ *   requestedFraction: number,
+ *   usedFraction: ?number
+ * }}
+ */
+backendApi.CapacityAllocation;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   cpu: !backendApi.CapacityAllocation,
+ *   memory: !backendApi.CapacityAllocation,
+ *   pods: number
+ * }}
+ */
+backendApi.CapacityEntry;
+
+/**
+ * @typedef {{
+ *   cluster: !backendApi.CapacityEntry,
+ *   nodes: !Array<!backendApi.CapacityEntry>,
+ *   namespaces: !Array<!backendApi.CapacityEntry>,
+ *   usageAvailable: boolean,
+ *   errors: !Array<!backendApi.Error>
+ * }}
+ */
+backendApi.CapacityReport;
+
+/**
+ * @typedef {{
+ *   scanner: string,
+ *   scanned: boolean,
+ *   critical: number,
+ *   high: number,
+ *   medium: number,
+ *   low: number,
+ *   unknown: number,
+ *   total: number,
+ *   scannedAt: (string|undefined)
+ * }}
+ */
+backendApi.VulnerabilitySummary;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   digest: string,
+ *   pods: number,
+ *   containers: number,
+ *   namespaces: !Array<string>,
+ *   vulnerabilities: (!backendApi.VulnerabilitySummary|undefined),
+ *   scanError: (string|undefined)
+ * }}
+ */
+backendApi.Image;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   images: !Array<!backendApi.Image>,
+ *   scanner: string,
+ *   errors: !Array<!backendApi.Error>
+ * }}
+ */
+backendApi.ImageList;
+
+/**
+ * @typedef {{
+ *   container: string,
+ *   image: string,
+ *   tag: string,
+ *   watch: boolean
+ * }}
+ */
+backendApi.SetImageSpec;
+
+/**
+ * @typedef {{
+ *   container: string,
+ *   oldImage: string,
+ *   image: string,
+ *   changed: boolean,
+ *   id: (string|undefined)
+ * }}
+ */
+backendApi.SetImageResponse;
+
+/**
+ * @typedef {{
+ *   replicas: number,
+ *   updatedReplicas: number,
+ *   readyReplicas: number,
+ *   availableReplicas: number,
+ *   done: boolean,
+ *   message: string
+ * }}
+ */
+backendApi.RolloutProgress;
+
+/**
+ * @typedef {{
+ *   template: string,
+ *   name: string,
+ *   phase: string,
+ *   capacity: string,
+ *   storageClass: string,
+ *   volume: string,
+ *   volumePhase: string,
+ *   reclaimPolicy: string
+ * }}
+ */
+backendApi.OrdinalClaim;
+
+/**
+ * @typedef {{
+ *   ordinal: number,
+ *   pod: string,
+ *   phase: string,
+ *   ready: boolean,
+ *   nodeName: string,
+ *   scaledDown: boolean,
+ *   claims: !Array<!backendApi.OrdinalClaim>
+ * }}
+ */
+backendApi.StatefulSetOrdinal;
+
+/**
+ * @typedef {{
+ *   type: string,
+ *   partition: ?number
+ * }}
+ */
+backendApi.StatefulSetUpdateStrategy;
+
+/**
+ * @typedef {{
+ *   replicas: number,
+ *   updateStrategy: !backendApi.StatefulSetUpdateStrategy,
+ *   ordinals: !Array<!backendApi.StatefulSetOrdinal>
+ * }}
+ */
+backendApi.StatefulSetOrdinals;
+
+/**
+ * @typedef {{
+ *   partition: number
+ * }}
+ */
+backendApi.PartitionSpec;
+
+/**
+ * @typedef {{
+ *   replicas: number,
+ *   deleteClaims: boolean
+ * }}
+ */
+backendApi.ScaleDownSpec;
+
+/**
+ * @typedef {{
+ *   previousReplicas: number,
+ *   replicas: number,
+ *   retainedClaims: !Array<string>,
+ *   deletedClaims: !Array<string>
+ * }}
+ */
+backendApi.ScaleDownResult;
+
+/**
+ * @typedef {{
+ *   addresses: !Array<string>,
+ *   ready: boolean,
+ *   serving: boolean,
+ *   terminating: boolean,
+ *   hostname: string,
+ *   nodeName: string,
+ *   zone: string,
+ *   pod: string
+ * }}
+ */
+backendApi.ServiceEndpoint;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   port: number,
+ *   protocol: string
+ * }}
+ */
+backendApi.EndpointSlicePort;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   addressType: string,
+ *   ports: !Array<!backendApi.EndpointSlicePort>,
+ *   endpoints: !Array<!backendApi.ServiceEndpoint>
+ * }}
+ */
+backendApi.EndpointSlice;
+
+/**
+ * @typedef {{
+ *   source: string,
+ *   slices: !Array<!backendApi.EndpointSlice>,
+ *   readyEndpoints: number,
+ *   notReadyEndpoints: number,
+ *   hints: !Array<string>
+ * }}
+ */
+backendApi.ServiceEndpointSliceList;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   address: string,
+ *   reachable: boolean,
+ *   latencyMillis: number,
+ *   error: string
+ * }}
+ */
+backendApi.ProbeTarget;
+
+/**
+ * @typedef {{
+ *   port: number,
+ *   reachable: boolean,
+ *   targets: !Array<!backendApi.ProbeTarget>
+ * }}
+ */
+backendApi.ConnectivityProbe;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   service: string,
+ *   namespace: string,
+ *   type: string,
+ *   pod: string,
+ *   podNamespace: string,
+ *   container: string
+ * }}
+ */
+backendApi.DNSLookupSpec;
+
+/**
+ * @typedef {{
+ *   type: string,
+ *   value: string,
+ *   port: (number|undefined),
+ *   priority: (number|undefined),
+ *   weight: (number|undefined)
+ * }}
+ */
+backendApi.DNSRecord;
+
+/**
+ * @typedef {{
+ *   nameservers: !Array<string>,
+ *   search: !Array<string>,
+ *   options: !Array<string>
+ * }}
+ */
+backendApi.ResolvConf;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   type: string,
+ *   resolver: string,
+ *   records: !Array<!backendApi.DNSRecord>,
+ *   durationMillis: number,
+ *   error: string,
+ *   resolvConf: (!backendApi.ResolvConf|undefined),
+ *   output: (string|undefined)
+ * }}
+ */
+backendApi.DNSLookupResult;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   type: string,
+ *   webhookCount: number,
+ *   unavailableWebhooks: number,
+ *   criticalIssues: number
+ * }}
+ */
+backendApi.AdmissionWebhookConfiguration;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   configurations: !Array<!backendApi.AdmissionWebhookConfiguration>,
+ *   errors: !Array<!backendApi.Error>
+ * }}
+ */
+backendApi.AdmissionWebhookConfigurationList;
+
+/**
+ * @typedef {{
+ *   namespace: string,
+ *   name: string,
+ *   path: string,
+ *   port: number
+ * }}
+ */
+backendApi.WebhookService;
+
+/**
+ * @typedef {{
+ *   operations: !Array<string>,
+ *   apiGroups: !Array<string>,
+ *   apiVersions: !Array<string>,
+ *   resources: !Array<string>,
+ *   scope: string
+ * }}
+ */
+backendApi.WebhookRule;
+
+/**
+ * @typedef {{
+ *   status: string,
+ *   readyEndpoints: number,
+ *   notReadyEndpoints: number,
+ *   message: string
+ * }}
+ */
+backendApi.WebhookBackend;
+
+/**
+ * @typedef {{
+ *   severity: string,
+ *   message: string
+ * }}
+ */
+backendApi.WebhookDiagnostic;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   service: ?backendApi.WebhookService,
+ *   url: string,
+ *   rules: !Array<!backendApi.WebhookRule>,
+ *   failurePolicy: string,
+ *   matchPolicy: string,
+ *   sideEffects: string,
+ *   timeoutSeconds: number,
+ *   reinvocationPolicy: string,
+ *   namespaceSelector: string,
+ *   objectSelector: string,
+ *   backend: !backendApi.WebhookBackend,
+ *   diagnostics: !Array<!backendApi.WebhookDiagnostic>
+ * }}
+ */
+backendApi.Webhook;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   type: string,
+ *   webhookCount: number,
+ *   unavailableWebhooks: number,
+ *   criticalIssues: number,
+ *   webhooks: !Array<!backendApi.Webhook>
+ * }}
+ */
+backendApi.AdmissionWebhookConfigurationDetail;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   preferredVersion: string,
+ *   versions: !Array<string>
+ * }}
+ */
+backendApi.APIGroup;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   singularName: string,
+ *   shortNames: !Array<string>,
+ *   kind: string,
+ *   group: string,
+ *   version: string,
+ *   groupVersion: string,
+ *   preferred: boolean,
+ *   namespaced: boolean,
+ *   verbs: !Array<string>,
+ *   subresources: !Array<string>
+ * }}
+ */
+backendApi.APIResource;
+
+/**
+ * @typedef {{
+ *   groupVersion: string,
+ *   error: string
+ * }}
+ */
+backendApi.FailedGroupVersion;
+
+/**
+ * @typedef {{
+ *   groups: !Array<!backendApi.APIGroup>,
+ *   resources: !Array<!backendApi.APIResource>,
+ *   failedGroupVersions: !Array<!backendApi.FailedGroupVersion>,
+ *   discoveredAt: string
+ * }}
+ */
+backendApi.APIResourceList;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   type: string,
+ *   format: string,
+ *   description: string,
+ *   priority: number
+ * }}
+ */
+backendApi.TableColumn;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   cells: !Array<?>
+ * }}
+ */
+backendApi.GenericResourceObject;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   resource: !backendApi.APIResource,
+ *   columns: !Array<!backendApi.TableColumn>,
+ *   items: !Array<!backendApi.GenericResourceObject>,
+ *   errors: !Array<!backendApi.Error>
+ * }}
+ */
+backendApi.GenericResourceList;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   resource: !backendApi.APIResource,
+ *   object: !Object,
+ *   gitops: (!backendApi.GitOpsOwnership|undefined)
+ * }}
+ */
+backendApi.GenericResourceDetail;
+
+/**
+ * @typedef {{
+ *   content: string
+ * }}
+ */
+backendApi.GenericResourceSpec;
+
+/**
+ * @typedef {{
+ *   apiVersion: string,
+ *   kind: string,
+ *   namespace: string,
+ *   name: string
+ * }}
+ */
+backendApi.ExportObjectReference;
+
+/**
+ * @typedef {{
+ *   objects: !Array<!backendApi.ExportObjectReference>,
+ *   namespace: string,
+ *   kinds: !Array<string>,
+ *   includeOwned: boolean
+ * }}
+ */
+backendApi.ExportSpec;
+
+/**
+ * @typedef {{
+ *   target: string,
+ *   kinds: !Array<string>,
+ *   includeSecrets: boolean,
+ *   createNamespace: boolean,
+ *   namePrefix: string,
+ *   nameSuffix: string,
+ *   labels: !Object<string, string>
+ * }}
+ */
+backendApi.NamespaceCloneSpec;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   sourceName: string,
+ *   name: string
+ * }}
+ */
+backendApi.ClonedObject;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   name: string,
+ *   error: string
+ * }}
+ */
+backendApi.CloneFailure;
+
+/**
+ * @typedef {{
+ *   source: string,
+ *   target: string,
+ *   namespaceCreated: boolean,
+ *   cloned: !Array<!backendApi.ClonedObject>,
+ *   failed: !Array<!backendApi.CloneFailure>
+ * }}
+ */
+backendApi.NamespaceCloneResult;
+
+/**
+ * @typedef {{
+ *   content: string,
+ *   namespace: string
+ * }}
+ */
+backendApi.DiffSpec;
+
+/**
+ * @typedef {{
+ *   manager: string,
+ *   operation: string
+ * }}
+ */
+backendApi.FieldOwner;
+
+/**
+ * @typedef {{
+ *   path: string,
+ *   change: string,
+ *   live: *,
+ *   manifest: *,
+ *   lastApplied: *,
+ *   owners: !Array<!backendApi.FieldOwner>,
+ *   conflict: boolean
+ * }}
+ */
+backendApi.FieldDiff;
+
+/**
+ * @typedef {{
+ *   apiVersion: string,
+ *   kind: string,
+ *   namespace: string,
+ *   name: string,
+ *   operation: string,
+ *   fields: !Array<!backendApi.FieldDiff>,
+ *   conflicts: number,
+ *   error: string
+ * }}
+ */
+backendApi.ObjectDiff;
+
+/**
+ * @typedef {{
+ *   objects: !Array<!backendApi.ObjectDiff>
+ * }}
+ */
+backendApi.DiffResult;
+
+/**
+ * @typedef {{
+ *   revision: number,
+ *   replicaSet: string,
+ *   creationTimestamp: string,
+ *   changeCause: string,
+ *   images: !Array<string>,
+ *   current: boolean
+ * }}
+ */
+backendApi.RolloutRevision;
+
+/**
+ * @typedef {{
+ *   manager: string,
+ *   operation: string,
+ *   subresource: string,
+ *   time: ?string,
+ *   fields: !Array<string>
+ * }}
+ */
+backendApi.FieldManager;
+
+/**
+ * @typedef {{
+ *   time: string,
+ *   user: string,
+ *   content: string,
+ *   diff: string
+ * }}
+ */
+backendApi.Snapshot;
+
+/**
+ * @typedef {{
+ *   time: string,
+ *   source: string,
+ *   actor: string,
+ *   description: string
+ * }}
+ */
+backendApi.HistoryEntry;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   namespace: string,
+ *   name: string,
+ *   managers: !Array<!backendApi.FieldManager>,
+ *   revisions: !Array<!backendApi.RolloutRevision>,
+ *   snapshots: !Array<!backendApi.Snapshot>,
+ *   timeline: !Array<!backendApi.HistoryEntry>
+ * }}
+ */
+backendApi.ObjectHistory;
+
+/**
+ * @typedef {{
+ *   check: string,
+ *   severity: string,
+ *   container: string,
+ *   message: string
+ * }}
+ */
+backendApi.LintFinding;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   namespace: string,
+ *   name: string,
+ *   score: number,
+ *   findings: !Array<!backendApi.LintFinding>
+ * }}
+ */
+backendApi.LintObjectReport;
+
+/**
+ * @typedef {{
+ *   objects: !Array<!backendApi.LintObjectReport>,
+ *   score: number,
+ *   skipped: !Array<string>
+ * }}
+ */
+backendApi.LintReport;
+
+/**
+ * @typedef {{
+ *   content: string
+ * }}
+ */
+backendApi.LintSpec;
+
+/**
+ * @typedef {{
+ *   check: string,
+ *   level: string,
+ *   container: string,
+ *   message: string
+ * }}
+ */
+backendApi.PodSecurityViolation;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   name: string,
+ *   allowed: boolean,
+ *   strictestLevel: string,
+ *   violations: !Array<!backendApi.PodSecurityViolation>
+ * }}
+ */
+backendApi.PodSecurityWorkloadEvaluation;
+
+/**
+ * @typedef {{
+ *   namespace: string,
+ *   labels: !Object<string, string>,
+ *   level: string,
+ *   workloads: !Array<!backendApi.PodSecurityWorkloadEvaluation>,
+ *   rejected: number
+ * }}
+ */
+backendApi.PodSecurityEvaluation;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   subject: string,
+ *   issuer: string,
+ *   serialNumber: string,
+ *   dnsNames: !Array<string>,
+ *   ipAddresses: !Array<string>,
+ *   notBefore: ?string,
+ *   notAfter: ?string,
+ *   daysToExpiry: number,
+ *   chainLength: number,
+ *   status: string,
+ *   error: ?string
+ * }}
+ */
+backendApi.Certificate;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   certificates: !Array<!backendApi.Certificate>,
+ *   warningDays: number,
+ *   expired: number,
+ *   expiring: number,
+ *   invalid: number,
+ *   errors: !Array<!backendApi.Error>
+ * }}
+ */
+backendApi.CertificateList;
+
+/**
+ * @typedef {{
+ *   installed: boolean,
+ *   groupVersion: string,
+ *   resources: !Array<string>
+ * }}
+ */
+backendApi.CertManagerStatus;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   kind: string,
+ *   group: ?string
+ * }}
+ */
+backendApi.CertManagerIssuerReference;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   secretName: string,
+ *   commonName: string,
+ *   dnsNames: !Array<string>,
+ *   issuerRef: !backendApi.CertManagerIssuerReference,
+ *   ready: boolean,
+ *   issuing: boolean,
+ *   reason: string,
+ *   message: string,
+ *   notBefore: ?string,
+ *   notAfter: ?string,
+ *   renewalTime: ?string,
+ *   revision: number,
+ *   failedIssuanceAttempts: number,
+ *   conditions: !Array<!backendApi.Condition>
+ * }}
+ */
+backendApi.CertManagerCertificate;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   certificates: !Array<!backendApi.CertManagerCertificate>,
+ *   notReady: number
+ * }}
+ */
+backendApi.CertManagerCertificateList;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   certificate: string,
+ *   revision: ?number,
+ *   issuerRef: !backendApi.CertManagerIssuerReference,
+ *   approved: boolean,
+ *   denied: boolean,
+ *   ready: boolean,
+ *   reason: string,
+ *   message: string,
+ *   failureTime: ?string,
+ *   conditions: !Array<!backendApi.Condition>
+ * }}
+ */
+backendApi.CertificateRequest;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   requests: !Array<!backendApi.CertificateRequest>
+ * }}
+ */
+backendApi.CertificateRequestList;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   secretName: string,
+ *   commonName: string,
+ *   dnsNames: !Array<string>,
+ *   issuerRef: !backendApi.CertManagerIssuerReference,
+ *   ready: boolean,
+ *   issuing: boolean,
+ *   reason: string,
+ *   message: string,
+ *   notBefore: ?string,
+ *   notAfter: ?string,
+ *   renewalTime: ?string,
+ *   revision: number,
+ *   failedIssuanceAttempts: number,
+ *   conditions: !Array<!backendApi.Condition>,
+ *   requests: !Array<!backendApi.CertificateRequest>
+ * }}
+ */
+backendApi.CertManagerCertificateDetail;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   type: string,
+ *   server: ?string,
+ *   email: ?string,
+ *   ready: boolean,
+ *   reason: string,
+ *   message: string,
+ *   conditions: !Array<!backendApi.Condition>
+ * }}
+ */
+backendApi.Issuer;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   issuers: !Array<!backendApi.Issuer>
+ * }}
+ */
+backendApi.IssuerList;
+
+/**
+ * @typedef {{
+ *   istio: boolean,
+ *   linkerd: boolean
+ * }}
+ */
+backendApi.ServiceMeshStatus;
+
+/**
+ * @typedef {{
+ *   mesh: string,
+ *   sidecarInjected: boolean,
+ *   proxyImage: ?string,
+ *   injectionEnabled: boolean,
+ *   injectionSource: string,
+ *   mtlsMode: string,
+ *   mtlsPolicy: string
+ * }}
+ */
+backendApi.PodMesh;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   hosts: !Array<string>,
+ *   gateways: !Array<string>,
+ *   destinations: !Array<string>
+ * }}
+ */
+backendApi.VirtualService;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   virtualServices: !Array<!backendApi.VirtualService>
+ * }}
+ */
+backendApi.VirtualServiceList;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   host: string,
+ *   subsets: !Array<string>,
+ *   tlsMode: string,
+ *   loadBalancer: string
+ * }}
+ */
+backendApi.DestinationRule;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   destinationRules: !Array<!backendApi.DestinationRule>
+ * }}
+ */
+backendApi.DestinationRuleList;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   selector: !Object<string, string>,
+ *   mode: string
+ * }}
+ */
+backendApi.PeerAuthentication;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   peerAuthentications: !Array<!backendApi.PeerAuthentication>
+ * }}
+ */
+backendApi.PeerAuthenticationList;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   routes: !Array<string>,
+ *   retryBudget: boolean
+ * }}
+ */
+backendApi.ServiceProfile;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   serviceProfiles: !Array<!backendApi.ServiceProfile>
+ * }}
+ */
+backendApi.ServiceProfileList;
+
+/**
+ * @typedef {{
+ *   mesh: string,
+ *   pods: number,
+ *   meshedPods: number,
+ *   mtlsMode: string,
+ *   mtlsPolicy: string,
+ *   virtualServices: !Array<!backendApi.VirtualService>,
+ *   destinationRules: !Array<!backendApi.DestinationRule>,
+ *   serviceProfiles: !Array<!backendApi.ServiceProfile>
+ * }}
+ */
+backendApi.ServiceMesh;
+
+/**
+ * @typedef {{
+ *   tool: string,
+ *   ownerKind: string,
+ *   ownerNamespace: string,
+ *   ownerName: string,
+ *   repoURL: string,
+ *   path: string,
+ *   revision: string,
+ *   appliedRevision: string,
+ *   syncStatus: string,
+ *   healthStatus: (string|undefined),
+ *   selfHeal: boolean,
+ *   suspended: boolean,
+ *   message: (string|undefined)
+ * }}
+ */
+backendApi.GitOpsOwnership;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   namespace: string,
+ *   provider: string,
+ *   bucket: string,
+ *   prefix: (string|undefined),
+ *   default: boolean,
+ *   phase: string,
+ *   lastValidationTime: (string|undefined)
+ * }}
+ */
+backendApi.VeleroStorageLocation;
+
+/**
+ * @typedef {{
+ *   installed: boolean,
+ *   groupVersion: string,
+ *   namespace: string,
+ *   storageLocations: !Array<!backendApi.VeleroStorageLocation>
+ * }}
+ */
+backendApi.VeleroStatus;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   includedNamespaces: !Array<string>,
+ *   excludedNamespaces: !Array<string>,
+ *   storageLocation: string,
+ *   ttl: string,
+ *   schedule: string,
+ *   phase: string,
+ *   failureReason: (string|undefined),
+ *   errors: number,
+ *   warnings: number,
+ *   itemsBackedUp: number,
+ *   totalItems: number,
+ *   startTimestamp: (string|undefined),
+ *   completionTimestamp: (string|undefined),
+ *   expiration: (string|undefined)
+ * }}
+ */
+backendApi.VeleroBackup;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   backups: !Array<!backendApi.VeleroBackup>,
+ *   failed: number
+ * }}
+ */
+backendApi.VeleroBackupList;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   includedNamespaces: !Array<string>,
+ *   excludedNamespaces: !Array<string>,
+ *   storageLocation: string,
+ *   ttl: string,
+ *   schedule: string,
+ *   phase: string,
+ *   failureReason: (string|undefined),
+ *   errors: number,
+ *   warnings: number,
+ *   itemsBackedUp: number,
+ *   totalItems: number,
+ *   startTimestamp: (string|undefined),
+ *   completionTimestamp: (string|undefined),
+ *   expiration: (string|undefined),
+ *   restores: !Array<!backendApi.VeleroRestore>
+ * }}
+ */
+backendApi.VeleroBackupDetail;
+
+/**
+ * @typedef {{
+ *   namespace: string,
+ *   name: string,
+ *   storageLocation: string,
+ *   ttl: string,
+ *   snapshotVolumes: (boolean|undefined)
+ * }}
+ */
+backendApi.VeleroBackupSpec;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   schedule: string,
+ *   paused: boolean,
+ *   includedNamespaces: !Array<string>,
+ *   storageLocation: string,
+ *   ttl: string,
+ *   phase: string,
+ *   validationErrors: !Array<string>,
+ *   lastBackup: (string|undefined)
+ * }}
+ */
+backendApi.VeleroSchedule;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   schedules: !Array<!backendApi.VeleroSchedule>
+ * }}
+ */
+backendApi.VeleroScheduleList;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   backup: string,
+ *   includedNamespaces: !Array<string>,
+ *   namespaceMapping: !Object<string, string>,
+ *   phase: string,
+ *   failureReason: (string|undefined),
+ *   errors: number,
+ *   warnings: number,
+ *   itemsRestored: number,
+ *   totalItems: number,
+ *   startTimestamp: (string|undefined),
+ *   completionTimestamp: (string|undefined)
+ * }}
+ */
+backendApi.VeleroRestore;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   restores: !Array<!backendApi.VeleroRestore>
+ * }}
+ */
+backendApi.VeleroRestoreList;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   includedNamespaces: !Array<string>,
+ *   namespaceMapping: !Object<string, string>,
+ *   restorePVs: (boolean|undefined)
+ * }}
+ */
+backendApi.VeleroRestoreSpec;
+
+/**
+ * @typedef {{
+ *   status: string,
+ *   details: (string|undefined),
+ *   lastProbeTime: (string|undefined),
+ *   lastTransitionTime: (string|undefined)
+ * }}
+ */
+backendApi.ClusterAutoscalerCondition;
+
+/**
+ * @typedef {{
+ *   registered: number,
+ *   ready: number,
+ *   unready: number,
+ *   notStarted: number,
+ *   longUnregistered: number
+ * }}
+ */
+backendApi.ClusterAutoscalerNodeCounts;
+
+/**
+ * @typedef {{
+ *   health: !backendApi.ClusterAutoscalerCondition,
+ *   nodeCounts: !backendApi.ClusterAutoscalerNodeCounts,
+ *   scaleUp: !backendApi.ClusterAutoscalerCondition,
+ *   scaleDown: !backendApi.ClusterAutoscalerCondition,
+ *   scaleDownCandidates: number
+ * }}
+ */
+backendApi.ClusterAutoscalerClusterWide;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   health: !backendApi.ClusterAutoscalerCondition,
+ *   nodeCounts: !backendApi.ClusterAutoscalerNodeCounts,
+ *   target: number,
+ *   minSize: number,
+ *   maxSize: number,
+ *   scaleUp: !backendApi.ClusterAutoscalerCondition,
+ *   scaleDown: !backendApi.ClusterAutoscalerCondition,
+ *   scaleDownCandidates: number,
+ *   backoffError: (string|undefined)
+ * }}
+ */
+backendApi.ClusterAutoscalerNodeGroup;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   namespace: string,
+ *   name: string,
+ *   reason: string,
+ *   message: string,
+ *   type: string,
+ *   count: number,
+ *   lastSeen: string
+ * }}
+ */
+backendApi.ClusterAutoscalerEvent;
+
+/**
+ * @typedef {{
+ *   installed: boolean,
+ *   namespace: string,
+ *   time: (string|undefined),
+ *   autoscalerStatus: (string|undefined),
+ *   message: (string|undefined),
+ *   clusterWide: !backendApi.ClusterAutoscalerClusterWide,
+ *   nodeGroups: !Array<!backendApi.ClusterAutoscalerNodeGroup>,
+ *   activity: !Array<!backendApi.ClusterAutoscalerEvent>,
+ *   unschedulablePods: !Array<!backendApi.ClusterAutoscalerEvent>,
+ *   errors: !Array<!backendApi.Error>
+ * }}
+ */
+backendApi.ClusterAutoscalerStatus;
+
+/**
+ * @typedef {{
+ *   objectMeta: !backendApi.ObjectMeta,
+ *   typeMeta: !backendApi.TypeMeta,
+ *   value: number,
+ *   globalDefault: boolean,
+ *   preemptionPolicy: string,
+ *   description: string,
+ *   system: boolean
+ * }}
+ */
+backendApi.PriorityClass;
+
+/**
+ * @typedef {{
+ *   listMeta: !backendApi.ListMeta,
+ *   priorityClasses: !Array<!backendApi.PriorityClass>
+ * }}
+ */
+backendApi.PriorityClassList;
+
+/**
+ * @typedef {{
+ *   namespace: string,
+ *   pod: string,
+ *   node: string,
+ *   reason: string,
+ *   message: string,
+ *   time: string
+ * }}
+ */
+backendApi.Preemption;
+
+/**
+ * @typedef {{
+ *   priorityClassName: string,
+ *   priority: ?number,
+ *   preemptionPolicy: string,
+ *   nominatedNodeName: string,
+ *   preemptedBy: ?backendApi.Preemption,
+ *   victims: !Array<!backendApi.Preemption>,
+ *   events: !Array<!backendApi.Event>
+ * }}
+ */
+backendApi.PodPriority;
+
+/**
+ * @typedef {{
+ *   cause: string,
+ *   description: string,
+ *   nodes: number
+ * }}
+ */
+backendApi.SchedulingCause;
+
+/**
+ * @typedef {{
+ *   predicate: string,
+ *   message: string
+ * }}
+ */
+backendApi.FitFailure;
+
+/**
+ * @typedef {{
+ *   nodeName: string,
+ *   fits: boolean,
+ *   failures: !Array<!backendApi.FitFailure>
+ * }}
+ */
+backendApi.NodeFit;
+
+/**
+ * @typedef {{
+ *   predicate: string,
+ *   nodes: number
+ * }}
+ */
+backendApi.PredicateSummary;
+
+/**
+ * @typedef {{
+ *   namespace: string,
+ *   podName: string,
+ *   nodeName: string,
+ *   unschedulable: boolean,
+ *   schedulerMessage: string,
+ *   schedulerCauses: !Array<!backendApi.SchedulingCause>,
+ *   requests: !Object<string, string>,
+ *   fittingNodes: number,
+ *   summary: !Array<!backendApi.PredicateSummary>,
+ *   nodes: !Array<!backendApi.NodeFit>,
+ *   events: !Array<!backendApi.Event>
+ * }}
+ */
+backendApi.SchedulingExplanation;
+
+/**
+ * @typedef {{
+ *   cpuPercentile: number,
+ *   memoryPercentile: number
+ * }}
+ */
+backendApi.RecommendationOptions;
+
+/**
+ * @typedef {{
+ *   requests: !Object<string, string>,
+ *   limits: !Object<string, string>
+ * }}
+ */
+backendApi.ContainerResourceValues;
+
+/**
+ * @typedef {{
+ *   p50: number,
+ *   p90: number,
+ *   p95: number,
+ *   p99: number,
+ *   max: number
+ * }}
+ */
+backendApi.UsagePercentiles;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   current: !backendApi.ContainerResourceValues,
+ *   recommended: ?backendApi.ContainerResourceValues,
+ *   reason: (string|undefined),
+ *   source: (string|undefined),
+ *   samples: number,
+ *   cpu: ?backendApi.UsagePercentiles,
+ *   memory: ?backendApi.UsagePercentiles
+ * }}
+ */
+backendApi.ContainerRecommendation;
+
+/**
+ * @typedef {{
+ *   kind: string,
+ *   namespace: string,
+ *   name: string,
+ *   options: !backendApi.RecommendationOptions,
+ *   verticalPodAutoscaler: (string|undefined),
+ *   containers: !Array<!backendApi.ContainerRecommendation>
+ * }}
+ */
+backendApi.Recommendation;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   requests: !Object<string, string>,
+ *   limits: !Object<string, string>
+ * }}
+ */
+backendApi.ContainerResourcesSpec;
+
+/**
+ * @typedef {{
+ *   containers: !Array<!backendApi.ContainerResourcesSpec>
+ * }}
+ */
+backendApi.ApplyRecommendationSpec;
+
+/**
+ * @typedef {{
+ *   containers: !Array<string>
+ * }}
+ */
+backendApi.ApplyRecommendationResult;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   namespace: (string|undefined),
+ *   kind: (string|undefined),
+ *   pods: number,
+ *   cpuRequestCost: number,
+ *   memoryRequestCost: number,
+ *   requestCost: number,
+ *   usageCost: ?number,
+ *   idleCost: ?number
+ * }}
+ */
+backendApi.CostEstimate;
+
+/**
+ * @typedef {{
+ *   model: string,
+ *   currency: string,
+ *   hoursPerMonth: number,
+ *   total: !backendApi.CostEstimate,
+ *   namespaces: !Array<!backendApi.CostEstimate>,
+ *   workloads: !Array<!backendApi.CostEstimate>,
+ *   usageAvailable: boolean,
+ *   errors: !Array<!backendApi.Error>
+ * }}
+ */
+backendApi.CostReport;
+
+/**
+ * @typedef {{
+ *   timestamp: string,
+ *   cpu: number,
+ *   memory: number
+ * }}
+ */
+backendApi.UsagePoint;
+
+/**
+ * @typedef {{
+ *   quota: string,
+ *   resource: string,
+ *   status: !backendApi.ResourceQuotaStatus,
+ *   usagePercentages: !Array<number>,
+ *   growthPerHour: ?number,
+ *   exhaustedAt: ?string,
+ *   nearLimit: boolean
+ * }}
+ */
+backendApi.QuotaResourceTrend;
+
+/**
+ * @typedef {{
+ *   namespace: string,
+ *   historyAvailable: boolean,
+ *   usage: !Array<!backendApi.UsagePoint>,
+ *   resources: !Array<!backendApi.QuotaResourceTrend>
+ * }}
+ */
+backendApi.QuotaTrend;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   type: string,
+ *   severity: string,
+ *   namespace: (string|undefined),
+ *   threshold: (number|undefined),
+ *   windowMinutes: (number|undefined),
+ *   disabled: (boolean|undefined)
+ * }}
+ */
+backendApi.AlertRule;
+
+/**
+ * @typedef {{
+ *   rule: string,
+ *   type: string,
+ *   severity: string,
+ *   state: string,
+ *   kind: string,
+ *   namespace: (string|undefined),
+ *   name: string,
+ *   message: string,
+ *   value: number,
+ *   firedAt: string,
+ *   resolvedAt: (string|undefined)
+ * }}
+ */
+backendApi.Alert;
+
+/**
+ * @typedef {{
+ *   alerts: !Array<!backendApi.Alert>,
+ *   firing: number,
+ *   evaluatedAt: (string|undefined),
+ *   errors: !Array<!backendApi.Error>
+ * }}
+ */
+backendApi.AlertList;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   type: string,
+ *   url: (string|undefined),
+ *   to: (!Array<string>|undefined)
+ * }}
+ */
+backendApi.NotificationChannel;
+
+/**
+ * @typedef {{
+ *   name: string,
+ *   namespaces: (!Array<string>|undefined),
+ *   severities: (!Array<string>|undefined),
+ *   channels: !Array<string>
+ * }}
+ */
+backendApi.NotificationRoute;
+
+/**
+ * @typedef {{
+ *   channel: (string|undefined)
+ * }}
+ */
+backendApi.RolloutNotificationSpec;
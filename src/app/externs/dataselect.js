@@ -44,6 +44,7 @@ DataSelectApi.SortableProperties;
  *  PAGINATE: number,
  *  SORT: number,
  *  FILTER: number,
+ *  REFRESH: number,
  *  }}
  */
 DataSelectApi.SupportedActions;
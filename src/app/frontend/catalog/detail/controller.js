@@ -0,0 +1,133 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import {stateName as workloads} from 'workloads/state';
+
+import {stateName as detailState} from './state';
+
+/**
+ * Controller for the chart detail and install view.
+ *
+ * @final
+ */
+export class CatalogChartController {
+  /**
+   * @param {!backendApi.HelmChartDetail} chartDetail
+   * @param {!ui.router.$state} $state
+   * @param {!ui.router.$stateParams} $stateParams
+   * @param {!angular.$resource} $resource
+   * @param {!angular.$log} $log
+   * @param {!Object} errorDialog
+   * @param {!./../../common/csrftoken/service.CsrfTokenService} kdCsrfTokenService
+   * @param {!./../../common/errorhandling/localizer_service.LocalizerService} localizerService
+   * @ngInject
+   */
+  constructor(
+      chartDetail, $state, $stateParams, $resource, $log, errorDialog, kdCsrfTokenService,
+      localizerService) {
+    /** @export {!backendApi.HelmChartDetail} */
+    this.chartDetail = chartDetail;
+
+    /** @export {!angular.FormController} */
+    this.form;
+
+    /** @export {!backendApi.HelmInstallSpec} */
+    this.spec = {
+      releaseName: chartDetail.name,
+      namespace: $stateParams['namespace'] || 'default',
+      version: chartDetail.version,
+      values: {},
+      valuesYaml: '',
+    };
+
+    /** @private {!ui.router.$state} */
+    this.state_ = $state;
+
+    /** @private {!angular.$resource} */
+    this.resource_ = $resource;
+
+    /** @private {!angular.$log} */
+    this.log_ = $log;
+
+    /** @private {!Object} */
+    this.errorDialog_ = errorDialog;
+
+    /** @private {!angular.$q.Promise} */
+    this.tokenPromise_ = kdCsrfTokenService.getTokenForAction('helmchart');
+
+    /** @private {!./../../common/errorhandling/localizer_service.LocalizerService} */
+    this.localizerService_ = localizerService;
+
+    /** @private {boolean} */
+    this.isInstallInProgress_ = false;
+
+    /** @export */
+    this.i18n = i18n;
+  }
+
+  /**
+   * Reloads the view with details of the selected chart version.
+   *
+   * @export
+   */
+  changeVersion() {
+    this.state_.go(detailState, {'version': this.spec.version});
+  }
+
+  /**
+   * @return {boolean}
+   * @export
+   */
+  isInstallDisabled() {
+    return this.isInstallInProgress_;
+  }
+
+  /**
+   * Installs the chart into the cluster and navigates to the workloads of the target namespace.
+   *
+   * @export
+   */
+  install() {
+    if (!this.form.$valid) {
+      return;
+    }
+    this.isInstallInProgress_ = true;
+    this.tokenPromise_
+        .then((token) => {
+          /** @type {!angular.Resource} */
+          let resource = this.resource_(
+              `api/v1/helmchart/${this.chartDetail.repository}/${this.chartDetail.name}/install`,
+              {}, {save: {method: 'POST', headers: {'X-CSRF-TOKEN': token}}});
+          return resource.save(this.spec).$promise;
+        })
+        .then(
+            (release) => {
+              this.log_.info('Chart has been installed: ', release);
+              this.state_.go(workloads, {'namespace': this.spec.namespace});
+            },
+            (err) => {
+              this.log_.error('Error installing chart:', err);
+              this.errorDialog_.open(
+                  this.i18n.MSG_CATALOG_INSTALL_ERROR, this.localizerService_.localize(err.data));
+            })
+        .finally(() => {
+          this.isInstallInProgress_ = false;
+        });
+  }
+}
+
+const i18n = {
+  /** @export {string} @desc Title of the dialog shown when installing a chart fails. */
+  MSG_CATALOG_INSTALL_ERROR: goog.getMsg('Installing chart has failed'),
+};
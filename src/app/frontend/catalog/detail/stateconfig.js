@@ -0,0 +1,57 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import {breadcrumbsConfig} from 'common/components/breadcrumbs/service';
+
+import {stateName as catalogList} from './../list/state';
+import {stateName as parentState, stateUrl} from './../state';
+import {CatalogChartController} from './controller';
+
+/**
+ * Config state object for the chart detail and install view.
+ *
+ * @type {!ui.router.StateConfig}
+ */
+export const config = {
+  url: `${stateUrl}/:repository/:chart?version`,
+  parent: parentState,
+  resolve: {
+    'chartDetail': resolveChartDetail,
+  },
+  data: {
+    [breadcrumbsConfig]: {
+      'label': '{{$stateParams.chart}}',
+      'parent': catalogList,
+    },
+  },
+  views: {
+    '': {
+      controller: CatalogChartController,
+      controllerAs: '$ctrl',
+      templateUrl: 'catalog/detail/detail.html',
+    },
+  },
+};
+
+/**
+ * @param {!angular.$resource} $resource
+ * @param {!ui.router.$stateParams} $stateParams
+ * @return {!angular.$q.Promise}
+ * @ngInject
+ */
+export function resolveChartDetail($resource, $stateParams) {
+  return $resource(`api/v1/helmchart/${$stateParams['repository']}/${$stateParams['chart']}`)
+      .get({'version': $stateParams['version']})
+      .$promise;
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import {stateName as detailState} from './../detail/state';
+import {stateName as listState} from './state';
+
+/**
+ * Controller for the app catalog list view.
+ *
+ * @final
+ */
+export class CatalogListController {
+  /**
+   * @param {!backendApi.HelmChartList} chartList
+   * @param {!ui.router.$state} $state
+   * @param {!ui.router.$stateParams} $stateParams
+   * @ngInject
+   */
+  constructor(chartList, $state, $stateParams) {
+    /** @export {!backendApi.HelmChartList} */
+    this.chartList = chartList;
+
+    /** @export {string} */
+    this.query = $stateParams['query'] || '';
+
+    /** @private {!ui.router.$state} */
+    this.state_ = $state;
+  }
+
+  /**
+   * Reloads the list with charts matching the current query.
+   *
+   * @export
+   */
+  search() {
+    this.state_.go(listState, {'query': this.query || null});
+  }
+
+  /**
+   * @param {!backendApi.HelmChart} chart
+   * @return {string}
+   * @export
+   */
+  getChartHref(chart) {
+    return this.state_.href(
+        detailState, {'repository': chart.repository, 'chart': chart.name});
+  }
+
+  /**
+   * @return {boolean}
+   * @export
+   */
+  shouldShowZeroState() {
+    return this.chartList.charts.length === 0;
+  }
+}
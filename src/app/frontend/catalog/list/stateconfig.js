@@ -0,0 +1,61 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import {breadcrumbsConfig} from 'common/components/breadcrumbs/service';
+
+import {stateName as parentState, stateUrl} from './../state';
+import {CatalogListController} from './controller';
+
+/**
+ * I18n object that defines strings for translation used in this file.
+ */
+const i18n = {
+  /** @type {string} @desc Label 'App Catalog' that appears as a breadcrumbs on the action bar. */
+  MSG_BREADCRUMBS_APP_CATALOG_LABEL: goog.getMsg('App Catalog'),
+};
+
+/**
+ * Config state object for the app catalog list view.
+ *
+ * @type {!ui.router.StateConfig}
+ */
+export const config = {
+  url: `${stateUrl}?query`,
+  parent: parentState,
+  resolve: {
+    'chartList': resolveChartList,
+  },
+  data: {
+    [breadcrumbsConfig]: {
+      'label': i18n.MSG_BREADCRUMBS_APP_CATALOG_LABEL,
+    },
+  },
+  views: {
+    '': {
+      controller: CatalogListController,
+      controllerAs: '$ctrl',
+      templateUrl: 'catalog/list/list.html',
+    },
+  },
+};
+
+/**
+ * @param {!angular.$resource} $resource
+ * @param {!ui.router.$stateParams} $stateParams
+ * @return {!angular.$q.Promise}
+ * @ngInject
+ */
+export function resolveChartList($resource, $stateParams) {
+  return $resource('api/v1/helmchart').get({'query': $stateParams['query']}).$promise;
+}
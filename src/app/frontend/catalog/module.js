@@ -0,0 +1,40 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import chromeModule from 'chrome/module';
+import componentsModule from 'common/components/module';
+import csrfTokenModule from 'common/csrftoken/module';
+import errorHandlingModule from 'common/errorhandling/module';
+
+import {valuesSchemaFormComponent} from './schemaform/component';
+import stateConfig from './stateconfig';
+
+/**
+ * Angular module for the app catalog, which lists charts from the configured chart repositories
+ * and installs them into the cluster.
+ */
+export default angular
+    .module(
+        'kubernetesDashboard.catalog',
+        [
+          'ngMaterial',
+          'ngResource',
+          'ui.router',
+          chromeModule.name,
+          componentsModule.name,
+          csrfTokenModule.name,
+          errorHandlingModule.name,
+        ])
+    .config(stateConfig)
+    .component('kdValuesSchemaForm', valuesSchemaFormComponent);
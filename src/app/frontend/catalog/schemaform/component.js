@@ -0,0 +1,110 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/**
+ * Controller for the form generated from a chart's values JSON schema. Nested objects are
+ * rendered by the same component, so every instance only handles one level of properties.
+ *
+ * @final
+ */
+export class ValuesSchemaFormController {
+  constructor() {
+    /** @export {!Object} Initialized from a binding. */
+    this.schema;
+
+    /** @export {!Object<string, *>} Initialized from a binding. */
+    this.values;
+  }
+
+  /**
+   * @return {!Object<string, !Object>}
+   * @export
+   */
+  getProperties() {
+    return this.schema['properties'] || {};
+  }
+
+  /**
+   * Returns values object for the nested property, creating it when missing.
+   *
+   * @param {string} name
+   * @return {!Object<string, *>}
+   * @export
+   */
+  getNestedValues(name) {
+    if (!angular.isObject(this.values[name])) {
+      this.values[name] = {};
+    }
+    return /** @type {!Object<string, *>} */ (this.values[name]);
+  }
+
+  /**
+   * @param {!Object} property
+   * @return {boolean}
+   * @export
+   */
+  isObject(property) {
+    return property['type'] === 'object' && angular.isObject(property['properties']);
+  }
+
+  /**
+   * @param {!Object} property
+   * @return {boolean}
+   * @export
+   */
+  isBoolean(property) {
+    return property['type'] === 'boolean';
+  }
+
+  /**
+   * @param {!Object} property
+   * @return {boolean}
+   * @export
+   */
+  isNumber(property) {
+    return property['type'] === 'integer' || property['type'] === 'number';
+  }
+
+  /**
+   * @param {!Object} property
+   * @return {boolean}
+   * @export
+   */
+  isEnum(property) {
+    return angular.isArray(property['enum']);
+  }
+
+  /**
+   * @param {!Object} property
+   * @return {boolean}
+   * @export
+   */
+  isString(property) {
+    return property['type'] === 'string' && !this.isEnum(property);
+  }
+}
+
+/**
+ * Definition object for the component that renders a form for chart values.
+ *
+ * @type {!angular.Component}
+ */
+export const valuesSchemaFormComponent = {
+  controller: ValuesSchemaFormController,
+  templateUrl: 'catalog/schemaform/schemaform.html',
+  bindings: {
+    'schema': '<',
+    'values': '<',
+  },
+};
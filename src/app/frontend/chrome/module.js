@@ -12,6 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+import clusterModule from 'common/cluster/module';
 import componentsModule from 'common/components/module';
 import namespaceModule from 'common/namespace/module';
 
@@ -29,6 +30,7 @@ export default angular
         [
           'ngMaterial',
           'ui.router',
+          clusterModule.name,
           componentsModule.name,
           namespaceModule.name,
           navModule.name,
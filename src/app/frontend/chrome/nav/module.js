@@ -33,6 +33,7 @@ import {hamburgerComponent} from './hamburger_component';
 import {navComponent} from './nav_component';
 import {NavService} from './nav_service';
 import {navItemComponent} from './navitem_component';
+import {pluginNavComponent} from './pluginnav_component';
 import {roleNavComponent} from './rolenav_component';
 import {thirdPartyResourceNavComponent} from './thirdpartyresourcenav_component';
 
@@ -53,5 +54,6 @@ export default angular
     .component('kdNavHamburger', hamburgerComponent)
     .component('kdNavItem', navItemComponent)
     .component('kdNav', navComponent)
+    .component('kdPluginNav', pluginNavComponent)
     .component('kdRoleNav', roleNavComponent)
     .component('kdThirdPartyResourceNav', thirdPartyResourceNavComponent);
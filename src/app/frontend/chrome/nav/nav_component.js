@@ -13,6 +13,7 @@
 // limitations under the License.
 
 import {stateName as aboutState} from 'about/state';
+import {stateName as catalogState} from 'catalog/list/state';
 import {stateName as clusterState} from 'cluster/state';
 import {stateName as configState} from 'config/state';
 import {stateName as configMapState} from 'configmap/list/state';
@@ -71,6 +72,7 @@ export class NavController {
       'discovery': discoveryState,
       'config': configState,
       'storageClass': storageClassState,
+      'catalog': catalogState,
       'about': aboutState,
     };
   }
@@ -0,0 +1,60 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+/**
+ * @final
+ */
+export class PluginNavController {
+  /**
+   * @param {!angular.$resource} $resource
+   * @ngInject
+   */
+  constructor($resource) {
+    /** @private {!angular.$resource} */
+    this.resource_ = $resource;
+
+    /** @export {!Array<!backendApi.PluginMenuEntry>} */
+    this.entries = [];
+
+    /** @export {!Array<string>} */
+    this.entryHrefs = [];
+  }
+
+  /**
+   * Resolves list of installed plugins to fill menu with their entries.
+   *
+   * @export
+   */
+  $onInit() {
+    this.resource_('api/v1/plugin').get().$promise.then((/** !backendApi.PluginList */ result) => {
+      this.entries = [];
+      this.entryHrefs = [];
+      (result.plugins || []).forEach((plugin) => {
+        (plugin.menuEntries || []).forEach((entry) => {
+          this.entries.push(entry);
+          this.entryHrefs.push(`api/v1/plugin/${plugin.name}${entry.path}`);
+        });
+      });
+    });
+  }
+}
+
+/**
+ * @type {!angular.Component}
+ */
+export const pluginNavComponent = {
+  controller: PluginNavController,
+  templateUrl: 'chrome/nav/pluginnav.html',
+};
@@ -46,6 +46,17 @@ export class AppConfigService {
     }
   }
 
+  /**
+   * Returns true when the backend rejects all requests that change state of the cluster, so
+   * actions that modify resources can not be used.
+   *
+   * @return {boolean}
+   * @export
+   */
+  isReadOnly() {
+    return !!this.appConfig_.readOnly;
+  }
+
   /**
    * Release version number of Dashboard. The token is replaced by the build process
    * @export
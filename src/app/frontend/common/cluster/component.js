@@ -0,0 +1,87 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/**
+ * @final
+ */
+export class ClusterSelectController {
+  /**
+   * @param {!angular.$resource} $resource
+   * @param {!ui.router.$state} $state
+   * @param {!./service.ClusterService} kdClusterService
+   * @ngInject
+   */
+  constructor($resource, $state, kdClusterService) {
+    /**
+     * Clusters managed by Dashboard, including the default one.
+     * @export {!Array<!backendApi.Cluster>}
+     */
+    this.clusters = [];
+
+    /** @export {string} */
+    this.selectedCluster = kdClusterService.getCluster();
+
+    /** @private {!angular.$resource} */
+    this.resource_ = $resource;
+
+    /** @private {!ui.router.$state} */
+    this.state_ = $state;
+
+    /** @private {!./service.ClusterService} */
+    this.clusterService_ = kdClusterService;
+  }
+
+  /**
+   * @export
+   */
+  $onInit() {
+    /** @type {!angular.Resource<!backendApi.ClusterList>} */
+    let resource = this.resource_('api/v1/clusterlist');
+    resource.get().$promise.then((/** !backendApi.ClusterList */ clusterList) => {
+      this.clusters = clusterList.clusters;
+      let known = this.clusters.some((cluster) => cluster.name === this.selectedCluster);
+      if (!known && this.selectedCluster) {
+        // Selected cluster was removed from Dashboard, so fall back to the default one.
+        this.selectedCluster = '';
+        this.changeCluster();
+      }
+    });
+  }
+
+  /**
+   * Returns true if there are clusters besides the default one to select from.
+   * @return {boolean}
+   * @export
+   */
+  isVisible() {
+    return this.clusters.length > 1;
+  }
+
+  /**
+   * @export
+   */
+  changeCluster() {
+    if (this.selectedCluster === this.clusterService_.getCluster()) {
+      return;
+    }
+    this.clusterService_.setCluster(this.selectedCluster);
+    this.state_.reload();
+  }
+}
+
+/** @type {!angular.Component} */
+export const clusterSelectComponent = {
+  controller: ClusterSelectController,
+  templateUrl: 'common/cluster/clusterselect.html',
+};
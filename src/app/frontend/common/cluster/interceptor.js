@@ -0,0 +1,48 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/** @const {string} */
+export const CLUSTER_PARAM = 'cluster';
+
+/** @const {string} */
+const API_PREFIX = 'api/v1/';
+
+/**
+ * Creates $http interceptor that makes API requests to the cluster selected by the user.
+ * @param {!./service.ClusterService} kdClusterService
+ * @return {!Object}
+ * @ngInject
+ */
+export function clusterInterceptor(kdClusterService) {
+  return {
+    request: (config) => {
+      let cluster = kdClusterService.getCluster();
+      if (!cluster || !config.url.startsWith(API_PREFIX)) {
+        return config;
+      }
+
+      config.params = config.params || {};
+      config.params[CLUSTER_PARAM] = cluster;
+      return config;
+    },
+  };
+}
+
+/**
+ * @param {!angular.$httpProvider} $httpProvider
+ * @ngInject
+ */
+export function clusterConfig($httpProvider) {
+  $httpProvider.interceptors.push('kdClusterInterceptor');
+}
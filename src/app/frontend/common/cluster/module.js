@@ -0,0 +1,34 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import {clusterSelectComponent} from './component';
+import {clusterConfig, clusterInterceptor} from './interceptor';
+import {ClusterService} from './service';
+
+/**
+ * Angular module with the cluster switcher and an interceptor making API requests to the selected
+ * cluster.
+ */
+export default angular
+    .module(
+        'kubernetesDashboard.common.cluster',
+        [
+          'ngMaterial',
+          'ngResource',
+          'ui.router',
+        ])
+    .component('kdClusterSelect', clusterSelectComponent)
+    .service('kdClusterService', ClusterService)
+    .factory('kdClusterInterceptor', clusterInterceptor)
+    .config(clusterConfig);
@@ -0,0 +1,60 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/** @const {string} */
+const STORAGE_KEY = 'kdCluster';
+
+/**
+ * Service that keeps the cluster selected by the user. API requests are made to the selected
+ * cluster. Empty string means the default cluster.
+ * @final
+ */
+export class ClusterService {
+  /**
+   * @param {!angular.$window} $window
+   * @ngInject
+   */
+  constructor($window) {
+    /** @private {!angular.$window} */
+    this.window_ = $window;
+  }
+
+  /**
+   * @return {string}
+   * @export
+   */
+  getCluster() {
+    try {
+      return this.window_.localStorage.getItem(STORAGE_KEY) || '';
+    } catch (e) {
+      return '';
+    }
+  }
+
+  /**
+   * @param {string} cluster
+   * @export
+   */
+  setCluster(cluster) {
+    try {
+      if (cluster) {
+        this.window_.localStorage.setItem(STORAGE_KEY, cluster);
+      } else {
+        this.window_.localStorage.removeItem(STORAGE_KEY);
+      }
+    } catch (e) {
+      // Storage may be disabled by the browser, in which case the default cluster is used.
+    }
+  }
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/**
+ * Shows diagnosis of a workload, i.e. "ImagePullBackOff: registry auth failed", in its detail
+ * header. Nothing is shown for healthy workloads.
+ *
+ * @final
+ */
+export class WorkloadDiagnosisController {
+  /**
+   * @param {!angular.$resource} $resource
+   * @ngInject
+   */
+  constructor($resource) {
+    /** @private {!angular.$resource} */
+    this.resource_ = $resource;
+
+    /**
+     * Initialized from binding. Kind of the workload, i.e. deployment.
+     *
+     * @export {string}
+     */
+    this.kind;
+
+    /**
+     * Initialized from binding.
+     *
+     * @export {!backendApi.ObjectMeta}
+     */
+    this.objectMeta;
+
+    /** @export {?backendApi.Diagnosis} */
+    this.diagnosis = null;
+
+    /** @export {boolean} */
+    this.expanded = false;
+  }
+
+  /** @export */
+  $onInit() {
+    this.resource_('api/v1/diagnosis/:kind/:namespace/:name')
+        .get({
+          'kind': this.kind,
+          'namespace': this.objectMeta.namespace,
+          'name': this.objectMeta.name,
+        })
+        .$promise.then((diagnosis) => {
+          this.diagnosis = diagnosis;
+        });
+  }
+
+  /**
+   * @return {boolean}
+   * @export
+   */
+  isVisible() {
+    return !!this.diagnosis && !this.diagnosis.healthy;
+  }
+
+  /**
+   * Toggles list of all found problems.
+   *
+   * @export
+   */
+  toggle() {
+    this.expanded = !this.expanded;
+  }
+}
+
+/**
+ * @type {!angular.Component}
+ */
+export const workloadDiagnosisComponent = {
+  bindings: {
+    'kind': '@',
+    'objectMeta': '<',
+  },
+  controller: WorkloadDiagnosisController,
+  templateUrl: 'common/components/diagnosis/diagnosis.html',
+};
@@ -22,6 +22,7 @@ import actionbarModule from './actionbar/module';
 import annotationsModule from './annotations/module';
 import {conditionListComponent} from './conditions/component';
 import {contentCardComponent} from './contentcard/component';
+import {workloadDiagnosisComponent} from './diagnosis/component';
 import endpointModule from './endpoint/module';
 import graphModule from './graph/module';
 import infoCardModule from './infocard/infocard_module';
@@ -45,6 +46,7 @@ export default angular
         'kubernetesDashboard.common.components',
         [
           'ngMaterial',
+          'ngResource',
           'ui.router',
           filtersModule.name,
           actionbarModule.name,
@@ -66,6 +68,7 @@ export default angular
     .component('kdObjectMetaInfoCard', infoCardComponent)
     .component('kdContentCard', contentCardComponent)
     .component('kdWarnings', warningsComponent)
+    .component('kdWorkloadDiagnosis', workloadDiagnosisComponent)
     .component('kdConditionList', conditionListComponent)
     .component('kdScaleButton', scaleButtonComponent)
     .directive('kdWarnThreshold', warnThresholdDirective);
@@ -12,7 +12,9 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+import dataSelectModule from 'common/dataselect/module';
 import resourceModule from 'common/resource/module';
+import watchModule from 'common/watch/module';
 
 import {logsButtonComponent} from './logsbutton_component';
 import {resourceCardComponent} from './resourcecard_component';
@@ -42,6 +44,8 @@ export default angular
           'ui.router',
           'angularUtils.directives.dirPagination',
           resourceModule.name,
+          dataSelectModule.name,
+          watchModule.name,
         ])
     .component('kdLogsButton', logsButtonComponent)
     .component('kdResourceCard', resourceCardComponent)
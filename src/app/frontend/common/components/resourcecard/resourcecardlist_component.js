@@ -21,9 +21,11 @@ const FOOTER_SLOT = 'footer';
 export class ResourceCardListController {
   /**
    * @param {!angular.$transclude} $transclude
+   * @param {!../../watch/service.WatchService} kdWatchService
+   * @param {!../../dataselect/service.DataSelectService} kdDataSelectService
    * @ngInject
    */
-  constructor($transclude) {
+  constructor($transclude, kdWatchService, kdDataSelectService) {
     /**
      * Whether this list is in a pending async state (e.g., loading new page);
      * @export {boolean}
@@ -60,6 +62,53 @@ export class ResourceCardListController {
 
     /** @export {angular.$resource|undefined} - Initialized from binding. */
     this.listResource;
+
+    /**
+     * Kind of listed resources, e.g. pod. When set, the list is refreshed after resources of the
+     * kind change. Initialized from binding.
+     * @export {string|undefined}
+     */
+    this.watchKind;
+
+    /** @private {!../../watch/service.WatchService} */
+    this.watchService_ = kdWatchService;
+
+    /** @private {!../../dataselect/service.DataSelectService} */
+    this.dataSelectService_ = kdDataSelectService;
+
+    /** @private {?function()} */
+    this.stopWatch_ = null;
+  }
+
+  /** @export */
+  $onInit() {
+    if (!this.watchKind || !this.listResource || !this.selectId) {
+      return;
+    }
+
+    if (!this.dataSelectService_.isRegistered(this.selectId)) {
+      this.dataSelectService_.registerInstance(this.selectId);
+    }
+    this.stopWatch_ = this.watchService_.watch(
+        this.watchKind, this.dataSelectService_.getNamespace(this.selectId),
+        () => this.refresh_());
+  }
+
+  /** @export */
+  $onDestroy() {
+    if (this.stopWatch_) {
+      this.stopWatch_();
+    }
+  }
+
+  /**
+   * Fetches the list again, keeping its current page, sorting and filter.
+   * @private
+   */
+  refresh_() {
+    this.dataSelectService_.refresh(this.listResource, this.selectId).then((list) => {
+      this.list = list;
+    });
   }
 
   /**
@@ -167,6 +216,8 @@ export const resourceCardListComponent = {
     'list': '=',
     /** {angular.$resource|undefined} */
     'listResource': '<',
+    /** {string|undefined} kind of listed resources, that is watched to refresh the list */
+    'watchKind': '@',
   },
   bindToController: true,
 };
@@ -0,0 +1,81 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+/** @const {string} */
+export const CSRF_TOKEN_HEADER = 'X-CSRF-TOKEN';
+
+/**
+ * Methods of requests that change state of the cluster and need CSRF token.
+ * @const {!Array<string>}
+ */
+const PROTECTED_METHODS = ['POST', 'PUT', 'PATCH', 'DELETE'];
+
+/** @const {string} */
+const API_PREFIX = 'api/v1/';
+
+/**
+ * Returns action, i.e. the resource, CSRF token of the request is issued for, or empty string if
+ * the request does not need the token.
+ * @param {string} method
+ * @param {string} url
+ * @return {string}
+ */
+export function getCsrfAction(method, url) {
+  if (!url.startsWith(API_PREFIX) || url.startsWith(`${API_PREFIX}csrftoken/`)) {
+    return '';
+  }
+
+  // Terminal and port forwarding sessions are created with GET requests.
+  let isSession = method === 'GET' && /\/(shell|portforward)\//.test(url);
+  if (PROTECTED_METHODS.indexOf(method) < 0 && !isSession) {
+    return '';
+  }
+  return url.substring(API_PREFIX.length).split(/[/?]/)[0];
+}
+
+/**
+ * Creates $http interceptor that adds CSRF token to API requests that change state of the
+ * cluster, unless it is already set.
+ * @param {!angular.$injector} $injector
+ * @return {!Object}
+ * @ngInject
+ */
+export function csrfTokenInterceptor($injector) {
+  return {
+    request: (config) => {
+      let action = getCsrfAction(config.method, config.url);
+      if (!action || config.headers[CSRF_TOKEN_HEADER]) {
+        return config;
+      }
+
+      // Service is fetched lazily, as it depends on $http, which can not be injected into its
+      // own interceptors.
+      /** @type {!./service.CsrfTokenService} */
+      let csrfTokenService = $injector.get('kdCsrfTokenService');
+      return csrfTokenService.getTokenForAction(action).then((token) => {
+        config.headers[CSRF_TOKEN_HEADER] = token;
+        return config;
+      });
+    },
+  };
+}
+
+/**
+ * @param {!angular.$httpProvider} $httpProvider
+ * @ngInject
+ */
+export function csrfTokenConfig($httpProvider) {
+  $httpProvider.interceptors.push('kdCsrfTokenInterceptor');
+}
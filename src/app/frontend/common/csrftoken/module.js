@@ -12,10 +12,11 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+import {csrfTokenConfig, csrfTokenInterceptor} from './interceptor';
 import {CsrfTokenService} from './service';
 
 /**
- * Module congaining a csrfToken service.
+ * Module containing a csrfToken service and an interceptor adding CSRF tokens to API requests.
  */
 export default angular
     .module(
@@ -23,4 +24,6 @@ export default angular
         [
           'ui.router',
         ])
-    .service('kdCsrfTokenService', CsrfTokenService);
+    .service('kdCsrfTokenService', CsrfTokenService)
+    .factory('kdCsrfTokenInterceptor', csrfTokenInterceptor)
+    .config(csrfTokenConfig);
@@ -22,6 +22,8 @@ const Actions = {
   SORT: 1,
   /** @export */
   FILTER: 2,
+  /** @export */
+  REFRESH: 3,
 };
 
 /**
@@ -94,18 +96,9 @@ export class DataSelectService {
    * @private
    */
   selectData_(listResource, dataSelectId, dataSelectQuery, action) {
-    let query = this.instances_.get(dataSelectId);
+    let query = this.getQuery_(dataSelectId);
     let name = this.stateParams_.objectName || query.name;
-    let namespace =
-        this.stateParams_.objectNamespace || this.stateParams_.namespace || query.namespace;
-
-    if (!query) {
-      throw new Error(`Data select query for given data select id ${dataSelectId} does not exist`);
-    }
-
-    if (this.kdNamespaceService_.isMultiNamespace(namespace)) {
-      namespace = '';
-    }
+    let namespace = this.getNamespace(dataSelectId);
 
     query.name = dataSelectQuery.name || name;
     query.namespace = dataSelectQuery.namespace || namespace;
@@ -125,11 +118,41 @@ export class DataSelectService {
 
     this.instances_.set(dataSelectId, query);
 
-    query = this.applySearch_(query);
+    // Search is applied to a copy, so that it is not added to the stored query again on refresh.
+    query = this.applySearch_(Object.assign({}, query));
 
     return listResource.get(query).$promise;
   }
 
+  /**
+   * @param {string} dataSelectId
+   * @return {!DataSelectApi.DataSelectQuery}
+   * @private
+   */
+  getQuery_(dataSelectId) {
+    let query = this.instances_.get(dataSelectId);
+    if (!query) {
+      throw new Error(`Data select query for given data select id ${dataSelectId} does not exist`);
+    }
+    return query;
+  }
+
+  /**
+   * Returns namespace data is selected from, empty when it is selected from all namespaces.
+   *
+   * @param {string} dataSelectId
+   * @return {string}
+   * @export
+   */
+  getNamespace(dataSelectId) {
+    let namespace = this.stateParams_.objectNamespace || this.stateParams_.namespace ||
+        this.getQuery_(dataSelectId).namespace;
+    if (this.kdNamespaceService_.isMultiNamespace(namespace)) {
+      return '';
+    }
+    return namespace;
+  }
+
   /**
    * @param query
    * @private
@@ -186,6 +209,19 @@ export class DataSelectService {
     return this.selectData_(listResource, dataSelectId, dataSelectQuery, this.actions_.FILTER);
   }
 
+  /**
+   * Selects data again with the current page, sorting and filter, e.g. after it has changed.
+   *
+   * @param listResource {!angular.$resource}
+   * @param dataSelectId {string}
+   * @return {!angular.$q.Promise}
+   * @export
+   */
+  refresh(listResource, dataSelectId) {
+    let dataSelectQuery = new DataSelectQueryBuilder().build();
+    return this.selectData_(listResource, dataSelectId, dataSelectQuery, this.actions_.REFRESH);
+  }
+
   /**
    * @param {string|undefined} [namespace]
    * @param {string|undefined} [name]
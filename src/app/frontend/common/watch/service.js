@@ -0,0 +1,91 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/**
+ * Time in milliseconds to wait for more watch events before views are refreshed, so that a burst
+ * of events, e.g. on rollout, results in a single refresh.
+ * @const {number}
+ */
+export const REFRESH_DELAY = 1000;
+
+/**
+ * Subscribes views to the watch hub of the backend, which pushes events of watched resources over
+ * SockJS, so that views are refreshed when resources change instead of polling the backend.
+ *
+ * @final
+ */
+export class WatchService {
+  /**
+   * @param {!angular.$resource} $resource
+   * @param {!angular.$timeout} $timeout
+   * @ngInject
+   */
+  constructor($resource, $timeout) {
+    /** @private {!angular.$resource} */
+    this.resource_ = $resource;
+    /** @private {!angular.$timeout} */
+    this.timeout_ = $timeout;
+  }
+
+  /**
+   * Watches resources of the kind and calls the callback after they change. Empty namespace
+   * watches all namespaces.
+   *
+   * @param {string} kind
+   * @param {string} namespace
+   * @param {function()} onChange
+   * @return {function()} Function, that stops the watch.
+   */
+  watch(kind, namespace, onChange) {
+    /** @type {SockJS} */
+    let conn = null;
+    /** @type {?angular.$q.Promise} */
+    let refresh = null;
+    let stopped = false;
+
+    let url = namespace ? `api/v1/watch/${kind}/${namespace}` : `api/v1/watch/${kind}`;
+    this.resource_(url).get({}, (watchResponse) => {
+      if (stopped) {
+        return;
+      }
+
+      // https://github.com/sockjs/sockjs-client
+      conn = new SockJS(`api/sockjs/watch?${watchResponse.id}`);
+      conn.onopen = () => {
+        conn.send(JSON.stringify({'Op': 'bind', 'SessionID': watchResponse.id}));
+      };
+      // Objects, that exist when the watch starts, are sent as added events too, so the view is
+      // refreshed once after the watch starts, which covers changes made while it was loading.
+      conn.onmessage = (evt) => {
+        let msg = JSON.parse(evt.data);
+        if (msg['Op'] === 'event' && !refresh) {
+          refresh = this.timeout_(() => {
+            refresh = null;
+            onChange();
+          }, REFRESH_DELAY);
+        }
+      };
+    });
+
+    return () => {
+      stopped = true;
+      if (refresh) {
+        this.timeout_.cancel(refresh);
+      }
+      if (conn) {
+        conn.close();
+      }
+    };
+  }
+}
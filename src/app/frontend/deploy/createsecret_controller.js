@@ -62,6 +62,33 @@ export default class CreateSecretController {
      */
     this.data;
 
+    /**
+     * Whether the secret is created from registry credentials instead of Base64 encoded data.
+     * @export {boolean}
+     */
+    this.useCredentials = false;
+
+    /**
+     * Registry credentials used when useCredentials is set.
+     * @export {{server: string, username: string, password: string, email: string}}
+     */
+    this.credentials = {server: '', username: '', password: '', email: ''};
+
+    /**
+     * Whether the credentials are saved without testing them against the registry.
+     * @export {boolean}
+     */
+    this.skipTest = false;
+
+    /**
+     * Result of the last test of the credentials. Null if they were not tested yet.
+     * @export {?backendApi.RegistryCredentialTest}
+     */
+    this.credentialTest = null;
+
+    /** @export {boolean} */
+    this.testing = false;
+
     /**
      * Max-length validation rule for secretName.
      * @export {number}
@@ -94,12 +121,59 @@ export default class CreateSecretController {
     this.mdDialog_.cancel();
   }
 
+  /**
+   * Tests the registry credentials by logging in to the registry from the backend.
+   * @export
+   */
+  testCredentials() {
+    this.testing = true;
+    this.credentialTest = null;
+    this.tokenPromise.then((token) => {
+      /** @type {!angular.Resource<!backendApi.RegistryCredentialSpec>} */
+      let resource = this.resource_(
+          'api/v1/secret/registry/test', {},
+          {save: {method: 'POST', headers: {'X-CSRF-TOKEN': token}}});
+
+      resource.save(
+          this.getRegistryCredentialSpec_(),
+          (result) => {
+            this.testing = false;
+            this.credentialTest = /** @type {!backendApi.RegistryCredentialTest} */ (result);
+          },
+          (err) => {
+            this.testing = false;
+            this.log_.info('Error testing registry credentials:', err);
+          });
+    });
+  }
+
+  /**
+   * @return {!backendApi.RegistryCredentialSpec}
+   * @private
+   */
+  getRegistryCredentialSpec_() {
+    return {
+      name: this.secretName,
+      namespace: this.namespace,
+      server: this.credentials.server,
+      username: this.credentials.username,
+      password: this.credentials.password,
+      email: this.credentials.email,
+      skipTest: this.skipTest,
+      serviceAccounts: [],
+    };
+  }
+
   /**
    * Creates new secret based on the state of the controller.
    * @export
    */
   createSecret() {
     if (!this.secretForm.$valid) return;
+    if (this.useCredentials) {
+      this.createRegistryCredentials_();
+      return;
+    }
 
     /** @type {!backendApi.SecretSpec} */
     let secretSpec = {
@@ -131,4 +205,36 @@ export default class CreateSecretController {
           this.log_.info('Error creating secret:', err);
         });
   }
+
+  /**
+   * Creates new docker-registry secret from the registry credentials. Backend tests the credentials
+   * first unless the test is skipped.
+   * @private
+   */
+  createRegistryCredentials_() {
+    this.tokenPromise.then(
+        (token) => {
+          /** @type {!angular.Resource<!backendApi.RegistryCredentialSpec>} */
+          let resource = this.resource_(
+              'api/v1/secret/registry', {},
+              {save: {method: 'POST', headers: {'X-CSRF-TOKEN': token}}});
+
+          resource.save(
+              this.getRegistryCredentialSpec_(),
+              (result) => {
+                this.log_.info('Successfully created registry credentials:', result);
+                this.mdDialog_.hide(this.secretName);
+              },
+              (err) => {
+                this.mdDialog_.hide();
+                this.errorDialog_.open('Error creating secret', err.data);
+                this.log_.info('Error creating secret:', err);
+              });
+        },
+        (err) => {
+          this.mdDialog_.hide();
+          this.errorDialog_.open('Error creating secret', err.data);
+          this.log_.info('Error creating secret:', err);
+        });
+  }
 }
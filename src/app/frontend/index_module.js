@@ -17,6 +17,7 @@
  * to bootstrap the application.
  */
 import aboutModule from './about/module';
+import catalogModule from './catalog/module';
 import chromeModule from './chrome/module';
 import clusterModule from './cluster/module';
 import csrfTokenModule from './common/csrftoken/module';
@@ -66,6 +67,7 @@ export default angular
           'ngSanitize',
           'ui.router',
           aboutModule.name,
+          catalogModule.name,
           chromeModule.name,
           daemonSetModule.name,
           deployModule.name,
@@ -23,6 +23,10 @@ const beginningOfLogFile = 'beginning';
 const endOfLogFile = 'end';
 const oldestTimestamp = 'oldest';
 const newestTimestamp = 'newest';
+// Maximum number of lines kept in the view while following logs, older lines are dropped.
+const maxFollowedLines = 10 * logsPerView;
+// Code the log stream is closed with when it could not be opened or was interrupted.
+const logStreamCloseFailed = 2;
 
 /**
  * Controller for the logs view.
@@ -36,9 +40,10 @@ export class LogsController {
    * @param {!angular.$document} $document
    * @param {!angular.$resource} $resource
    * @param {!../common/errorhandling/service.ErrorDialog} errorDialog
+   * @param {!angular.$timeout} $timeout
    * @ngInject
    */
-  constructor(logsService, $sce, $document, $resource, errorDialog) {
+  constructor(logsService, $sce, $document, $resource, errorDialog, $timeout) {
     /** @private {!angular.$sce} */
     this.sce_ = $sce;
 
@@ -87,6 +92,24 @@ export class LogsController {
     /** @export {number} */
     this.topIndex = 0;
 
+    /**
+     * Whether logs of the previous, terminated instance of the container are shown.
+     * @export {boolean}
+     */
+    this.previous = false;
+
+    /**
+     * Whether new lines are streamed to the view as they are logged.
+     * @export {boolean}
+     */
+    this.following = false;
+
+    /** @private {SockJS} */
+    this.conn_ = null;
+
+    /** @private {!angular.$timeout} */
+    this.timeout_ = $timeout;
+
     /** @private {!../common/errorhandling/service.ErrorDialog} */
     this.errorDialog_ = errorDialog;
 
@@ -102,10 +125,15 @@ export class LogsController {
     this.container = this.podLogs.info.containerName;
     this.pod = this.podLogs.info.podName;
     this.stateParams_ = this.$transition$.params();
+    this.previous = this.stateParams_.previous === 'true';
     this.updateUiModel(this.podLogs);
     this.topIndex = this.podLogs.logs.length;
   }
 
+  $onDestroy() {
+    this.stopFollow_();
+  }
+
 
   /**
    * Loads maxLogSize oldest lines of logs.
@@ -160,20 +188,144 @@ export class LogsController {
    * @private
    */
   loadView(logFilePosition, referenceTimestamp, referenceLinenum, offsetFrom, offsetTo) {
+    this.stopFollow_();
     let namespace = this.stateParams_.objectNamespace;
 
+    let params = {
+      'logFilePosition': logFilePosition,
+      'referenceTimestamp': referenceTimestamp,
+      'referenceLineNum': referenceLinenum,
+      'offsetFrom': offsetFrom,
+      'offsetTo': offsetTo,
+    };
+    if (this.previous) {
+      params['previous'] = true;
+    }
+
     this.resource_(`api/v1/log/${namespace}/${this.pod}/${this.container}`)
-        .get(
-            {
-              'logFilePosition': logFilePosition,
-              'referenceTimestamp': referenceTimestamp,
-              'referenceLineNum': referenceLinenum,
-              'offsetFrom': offsetFrom,
-              'offsetTo': offsetTo,
-            },
-            (podLogs) => {
-              this.updateUiModel(podLogs);
-            });
+        .get(params, (podLogs) => {
+          this.updateUiModel(podLogs);
+        });
+  }
+
+  /**
+   * Starts or stops following logs.
+   * @export
+   */
+  toggleFollow() {
+    if (this.following) {
+      this.stopFollow_();
+    } else {
+      this.startFollow_();
+    }
+  }
+
+  /**
+   * Replaces the view with the newest lines of logs and appends new lines as they are streamed
+   * from the backend. Logs of the previous container instance are not streamed, as they do not
+   * change.
+   * @private
+   */
+  startFollow_() {
+    this.stopFollow_();
+    this.following = true;
+    this.previous = false;
+    this.podLogs.logs = [];
+    this.logsSet = this.formatAllLogs_(this.podLogs.logs);
+
+    let namespace = this.stateParams_.objectNamespace;
+    let params = {
+      'follow': true,
+      'timestamps': true,
+      'tailLines': logsPerView,
+    };
+    this.resource_(`api/v1/log/${namespace}/${this.pod}/${this.container}/stream`)
+        .get(params, (logStreamResponse) => {
+          if (!this.following) {
+            return;
+          }
+
+          // https://github.com/sockjs/sockjs-client
+          this.conn_ = new SockJS(`api/sockjs/logs?${logStreamResponse.id}`);
+          this.conn_.onopen = this.onStreamOpen_.bind(this, logStreamResponse);
+          this.conn_.onmessage = this.onStreamMessage_.bind(this);
+          this.conn_.onclose = this.onStreamClose_.bind(this);
+        });
+  }
+
+  /**
+   * Stops following logs. Lines that were already streamed stay in the view.
+   * @private
+   */
+  stopFollow_() {
+    this.following = false;
+    if (this.conn_) {
+      let conn = this.conn_;
+      this.conn_ = null;
+      conn.close();
+    }
+  }
+
+  /**
+   * Attached to SockJS.onopen
+   * @private
+   */
+  onStreamOpen_(logStreamResponse) {
+    this.conn_.send(JSON.stringify({'Op': 'bind', 'SessionID': logStreamResponse.id}));
+  }
+
+  /**
+   * Attached to SockJS.onmessage. Appends streamed lines to the view and scrolls to the newest
+   * one.
+   * @private
+   */
+  onStreamMessage_(evt) {
+    let msg = JSON.parse(evt.data);
+    if (msg['Op'] !== 'stdout') {
+      return;
+    }
+
+    // SockJS callbacks run outside of the digest cycle.
+    this.timeout_(() => {
+      let lines = msg['Data'].split('\n').filter((line) => line.length > 0);
+      let logs = this.podLogs.logs.concat(lines.map((line) => this.parseStreamedLine_(line)));
+      this.podLogs.logs = logs.slice(-maxFollowedLines);
+      if (this.podLogs.logs.length > 0) {
+        this.podLogs.info.fromDate = this.podLogs.logs[0].timestamp;
+        this.podLogs.info.toDate = this.podLogs.logs[this.podLogs.logs.length - 1].timestamp;
+      }
+      this.logsSet = this.formatAllLogs_(this.podLogs.logs);
+      this.topIndex = this.logsSet.length;
+    });
+  }
+
+  /**
+   * Attached to SockJS.onclose
+   * @private
+   */
+  onStreamClose_(evt) {
+    this.timeout_(() => {
+      this.following = false;
+      this.conn_ = null;
+      if (evt.code === logStreamCloseFailed) {
+        this.errorDialog_.open(this.i18n.MSG_LOGS_STREAM_ERROR, evt.reason);
+      }
+    });
+  }
+
+  /**
+   * Splits streamed line into timestamp and content. Lines are streamed with timestamps
+   * prepended, e.g. "2017-06-01T10:00:00.000000000Z content".
+   * @param {string} line
+   * @return {!backendApi.LogLine}
+   * @private
+   */
+  parseStreamedLine_(line) {
+    let index = line.indexOf(' ');
+    if (index < 0) {
+      return {timestamp: line, content: ''};
+    }
+    return {timestamp: line.slice(0, index), content: line.slice(index + 1)};
   }
 
   /**
@@ -324,6 +476,16 @@ export class LogsController {
     this.loadNewest();
   }
 
+  /**
+   * Execute when a user switches between logs of the current and the previous instance of the
+   * container.
+   * @export
+   */
+  onPreviousChange() {
+    this.previous = !this.previous;
+    this.loadNewest();
+  }
+
   /**
    * Execute when a user changes the selected option for console font size.
    * @export
@@ -411,4 +573,6 @@ const i18n = {
   /** @export {string} @desc Error dialog indicating that parts of the log file is missing due to memory constraints. */
   MSG_LOGS_TRUNCATED_WARNING:
       goog.getMsg('The middle part of the log file cannot be loaded, because it is too big.'),
+  /** @export {string} @desc Error dialog shown when streaming of logs fails. */
+  MSG_LOGS_STREAM_ERROR: goog.getMsg('Following logs failed'),
 };
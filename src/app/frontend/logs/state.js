@@ -28,11 +28,22 @@ export class StateParams extends ResourceStateParams {
    * @param {string} objectNamespace
    * @param {string} objectName
    * @param {string} resourceType
+   * @param {string=} container
+   * @param {boolean=} previous
    */
-  constructor(objectNamespace, objectName, resourceType) {
+  constructor(objectNamespace, objectName, resourceType, container, previous) {
     super(objectNamespace, objectName);
 
     /** @export {string} Resource type (e.g. ReplicaSet, Pod) */
     this.resourceType = resourceType;
+
+    /** @export {string|undefined} Container to show logs of. First container by default. */
+    this.container = container;
+
+    /**
+     * @export {string|undefined} Set to 'true' to show logs of the previous, terminated instance
+     * of the container.
+     */
+    this.previous = previous ? 'true' : undefined;
   }
 }
@@ -27,7 +27,7 @@ import {stateName} from './state';
  */
 export default function stateConfig($stateProvider) {
   $stateProvider.state(stateName, {
-    url: `${appendDetailParamsToUrl('/log')}/:resourceType`,
+    url: `${appendDetailParamsToUrl('/log')}/:resourceType?container&previous`,
     parent: chromeStateName,
     resolve: {
       'logSources': resolveLogSources,
@@ -87,9 +87,14 @@ function resolvePodLogs($stateParams, $resource, logSources) {
     podName = logSources.podNames[0];
   }
 
+  let path = `api/v1/log/${namespace}/${podName}`;
+  if ($stateParams.container) {
+    path += `/${$stateParams.container}`;
+  }
+
   /** @type {!angular.Resource<!backendApi.Logs>} */
-  let resource = $resource(`api/v1/log/${namespace}/${podName}`);
-  return resource.get().$promise;
+  let resource = $resource(path);
+  return resource.get($stateParams.previous === 'true' ? {'previous': true} : {}).$promise;
 }
 
 const i18n = {
@@ -14,6 +14,7 @@
 
 import {StateParams} from 'common/resource/resourcedetail';
 import {stateName as configMapState} from 'configmap/detail/state';
+import {stateName as logsState, StateParams as LogsStateParams} from 'logs/state';
 import {stateName as secretState} from 'secret/detail/state';
 
 /**
@@ -23,9 +24,10 @@ export default class ContainerInfoController {
   /**
    * @param {!ui.router.$state} $state
    * @param {!angular.$window} $window
+   * @param {!angular.$filter} $filter
    * @ngInject
    */
-  constructor($state, $window) {
+  constructor($state, $window, $filter) {
     /**
      * Initialized from a binding
      * @export {string}
@@ -48,6 +50,32 @@ export default class ContainerInfoController {
 
     /** @private {!angular.$window} */
     this.window_ = $window;
+
+    /** @private {function(number): string} */
+    this.coresFilter_ = $filter('kdCores');
+
+    /** @private {function(number): string} */
+    this.memoryFilter_ = $filter('kdMemory');
+  }
+
+  /**
+   * Formats CPU requests or limits in millicores. Zero means that they are not set.
+   * @param {number} value
+   * @return {string}
+   * @export
+   */
+  formatCores(value) {
+    return value ? this.coresFilter_(value) : '-';
+  }
+
+  /**
+   * Formats memory requests or limits in bytes. Zero means that they are not set.
+   * @param {number} value
+   * @return {string}
+   * @export
+   */
+  formatMemory(value) {
+    return value ? this.memoryFilter_(value) : '-';
   }
 
   /**
@@ -131,6 +159,17 @@ export default class ContainerInfoController {
     return this.state_.href(configMapState, new StateParams(this.namespace, configMapKeyRef.name));
   }
 
+  /**
+   * Returns link to logs of the previous, terminated instance of the container.
+   * @param {!backendApi.Container} container
+   * @return {string}
+   * @export
+   */
+  getPreviousLogsHref(container) {
+    return this.state_.href(
+        logsState, new LogsStateParams(this.namespace, this.podName, 'pod', container.name, true));
+  }
+
   /**
    * @param {!backendApi.SecretKeyRef} secretKeyRef
    * @return {string}
@@ -19,14 +19,63 @@ export class SecretDetailController {
   /**
    * @param {!backendApi.SecretDetail} secretDetail
    * @param {!angular.$window} $window
+   * @param {!angular.$resource} $resource
    * @ngInject
    */
-  constructor(secretDetail, $window) {
+  constructor(secretDetail, $window, $resource) {
     /** @export {!backendApi.SecretDetail} */
     this.secretDetail = secretDetail;
 
+    /**
+     * Decoded secret data. It is fetched from the reveal endpoint only when the user asks to
+     * see a value, so secret contents are never part of the regular detail response.
+     * @export {?Object<string, string>}
+     */
+    this.data = null;
+
     /** @private {!angular.$window} */
     this.window_ = $window;
+
+    /** @private {!angular.$resource} */
+    this.resource_ = $resource;
+
+    /** @private {?angular.$q.Promise} */
+    this.revealPromise_ = null;
+  }
+
+  /**
+   * Fetches decoded secret data from the backend. The request is made at most once.
+   *
+   * @return {!angular.$q.Promise}
+   * @export
+   */
+  reveal() {
+    if (!this.revealPromise_) {
+      let objectMeta = this.secretDetail.objectMeta;
+      /** @type {!angular.Resource<!backendApi.SecretData>} */
+      let resource =
+          this.resource_(`api/v1/secret/${objectMeta.namespace}/${objectMeta.name}/reveal`);
+      this.revealPromise_ = resource.get().$promise.then(
+          (/** !backendApi.SecretData */ secretData) => {
+            this.data = secretData.data;
+          },
+          () => {
+            this.revealPromise_ = null;
+          });
+    }
+    return this.revealPromise_;
+  }
+
+  /**
+   * @param {string} key
+   * @return {string}
+   * @export
+   */
+  getDataValue(key) {
+    if (!this.data || this.data[key] === undefined) {
+      return '';
+    }
+    return this.formatDataValue(this.data[key]);
   }
 
   /**
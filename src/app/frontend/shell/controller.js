@@ -62,6 +62,12 @@ export class ShellController {
     /** @private {SockJS} */
     this.conn = null;
 
+    /**
+     * Message describing why the process exited, received in the exit message.
+     * @private {string}
+     */
+    this.exitMessage_ = '';
+
     this.prepareTerminal();
   }
 
@@ -145,6 +151,9 @@ export class ShellController {
       case 'toast':
         this.io.showOverlay(msg['Data']);
         break;
+      case 'exit':
+        this.exitMessage_ = this.getExitMessage_(msg);
+        break;
       default:
         // console.error('Unexpected message type:', msg);
     }
@@ -155,7 +164,9 @@ export class ShellController {
    * @private
    */
   onConnectionClose(evt) {
-    if (evt.reason !== '' && evt.code < 1000) {
+    if (this.exitMessage_ !== '') {
+      this.io.showOverlay(this.exitMessage_, null);
+    } else if (evt.reason !== '' && evt.code < 1000) {
       this.io.showOverlay(evt.reason, null);
     } else {
       this.io.showOverlay('Connection closed', null);
@@ -164,6 +175,25 @@ export class ShellController {
     this.term.uninstallKeyboard();
   }
 
+  /**
+   * Returns message shown to the user after the process exited.
+   * @param {!Object} msg exit message
+   * @return {string}
+   * @private
+   */
+  getExitMessage_(msg) {
+    switch (msg['Reason']) {
+      case 'NoShellFound':
+        return i18n.MSG_SHELL_NO_SHELL_FOUND;
+      case 'OOMKilled':
+        return i18n.MSG_SHELL_OOM_KILLED;
+      case 'Completed':
+        return i18n.MSG_SHELL_PROCESS_COMPLETED;
+      default:
+        return msg['Data'] || '';
+    }
+  }
+
   /**
    * Attached to hterm.io.onVTKeystroke
    * @private
@@ -200,3 +230,13 @@ export class ShellController {
             this.stateParams_.objectNamespace, this.stateParams_.objectName, container));
   }
 }
+
+const i18n = {
+  /** @export {string} @desc Message shown in the shell when no shell could be started in the container. */
+  MSG_SHELL_NO_SHELL_FOUND: goog.getMsg('No shell found in the container.'),
+  /** @export {string} @desc Message shown in the shell when the process was killed, because the container ran out of memory. */
+  MSG_SHELL_OOM_KILLED:
+      goog.getMsg('Process was killed, because the container ran out of memory.'),
+  /** @export {string} @desc Message shown in the shell after the process exited. */
+  MSG_SHELL_PROCESS_COMPLETED: goog.getMsg('Process exited'),
+};
@@ -17,11 +17,11 @@
  */
 export class ActionBarController {
   /**
-   * @param {!backendApi.StorageClass} storageClass
+   * @param {!backendApi.StorageClassDetail} storageClass
    * @ngInject
    */
   constructor(storageClass) {
-    /** @export {!backendApi.StorageClass} */
+    /** @export {!backendApi.StorageClassDetail} */
     this.details = storageClass;
   }
 }
@@ -20,7 +20,7 @@
 export const storageClassInfoComponent = {
   templateUrl: 'storageclass/detail/info.html',
   bindings: {
-    /** {!backendApi.StorageClass} */
+    /** {!backendApi.StorageClassDetail} */
     'storageClass': '=',
   },
 };
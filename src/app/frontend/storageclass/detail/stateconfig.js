@@ -55,7 +55,7 @@ export const config = {
 /**
  * @param {!./../../common/resource/resourcedetail.StateParams} $stateParams
  * @param {!angular.$resource} $resource
- * @return {!angular.Resource<!backendApi.StorageClass>}
+ * @return {!angular.Resource<!backendApi.StorageClassDetail>}
  * @ngInject
  */
 export function getStorageClassResource($resource, $stateParams) {
@@ -63,7 +63,7 @@ export function getStorageClassResource($resource, $stateParams) {
 }
 
 /**
- * @param {!angular.Resource<!backendApi.StorageClass>} storageClassResource
+ * @param {!angular.Resource<!backendApi.StorageClassDetail>} storageClassResource
  * @return {!angular.$q.Promise}
  * @ngInject
  */
@@ -0,0 +1,74 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import {StateParams} from 'common/resource/resourcedetail';
+import {stateName as persistentVolumeStateName} from 'persistentvolume/detail/state';
+import {stateName as persistentVolumeClaimStateName} from 'persistentvolumeclaim/detail/state';
+
+/**
+ * Controller for the storage class usage component. It lists volumes and claims of the storage
+ * class.
+ * @final
+ */
+export class StorageClassUsageController {
+  /**
+   * @param {!ui.router.$state} $state
+   * @ngInject
+   */
+  constructor($state) {
+    /**
+     * Initialized from the scope.
+     * @export {!backendApi.StorageClassDetail}
+     */
+    this.storageClass;
+
+    /** @private {!ui.router.$state} */
+    this.state_ = $state;
+  }
+
+  /**
+   * @param {!backendApi.StorageClassVolume} volume
+   * @return {string}
+   * @export
+   */
+  getVolumeDetailHref(volume) {
+    return this.state_.href(
+        persistentVolumeStateName, new StateParams('', volume.objectMeta.name));
+  }
+
+  /**
+   * @param {!backendApi.StorageClassClaim} claim
+   * @return {string}
+   * @export
+   */
+  getClaimDetailHref(claim) {
+    return this.state_.href(
+        persistentVolumeClaimStateName,
+        new StateParams(claim.objectMeta.namespace, claim.objectMeta.name));
+  }
+}
+
+/**
+ * Definition object for the component that displays Storage Class usage.
+ *
+ * @type {!angular.Component}
+ */
+export const storageClassUsageComponent = {
+  templateUrl: 'storageclass/detail/usage.html',
+  controller: StorageClassUsageController,
+  bindings: {
+    /** {!backendApi.StorageClassDetail} */
+    'storageClass': '<',
+  },
+};
@@ -19,6 +19,7 @@ import namespaceModule from 'common/namespace/module';
 import eventsModule from 'events/module';
 
 import {storageClassInfoComponent} from './detail/info_component';
+import {storageClassUsageComponent} from './detail/usage_component';
 import {storageClassCardComponent} from './list/card_component';
 import {storageClassCardListComponent} from './list/cardlist_component';
 import {storageClassListResource} from './list/stateconfig';
@@ -44,4 +45,5 @@ export default angular
     .component('kdStorageClassCard', storageClassCardComponent)
     .component('kdStorageClassCardList', storageClassCardListComponent)
     .component('kdStorageClassInfo', storageClassInfoComponent)
+    .component('kdStorageClassUsage', storageClassUsageComponent)
     .factory('kdStorageClassListResource', storageClassListResource);
@@ -0,0 +1,64 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import module from 'catalog/module';
+
+describe('Values schema form component', () => {
+  /** @type {!ValuesSchemaFormController} */
+  let ctrl;
+  /** @type {!Object<string, *>} */
+  let values;
+
+  beforeEach(() => {
+    angular.mock.module(module.name);
+    angular.mock.inject(($componentController) => {
+      values = {};
+      ctrl = $componentController('kdValuesSchemaForm', {}, {
+        schema: {
+          properties: {
+            replicaCount: {type: 'integer'},
+            image: {type: 'object', properties: {tag: {type: 'string'}}},
+            pullPolicy: {type: 'string', enum: ['Always', 'IfNotPresent']},
+            persistence: {type: 'boolean'},
+          },
+        },
+        values: values,
+      });
+    });
+  });
+
+  it('should classify properties by type', () => {
+    let props = ctrl.getProperties();
+
+    expect(ctrl.isNumber(props['replicaCount'])).toBe(true);
+    expect(ctrl.isObject(props['image'])).toBe(true);
+    expect(ctrl.isEnum(props['pullPolicy'])).toBe(true);
+    expect(ctrl.isString(props['pullPolicy'])).toBe(false);
+    expect(ctrl.isString(props['image']['properties']['tag'])).toBe(true);
+    expect(ctrl.isBoolean(props['persistence'])).toBe(true);
+  });
+
+  it('should create nested values on demand', () => {
+    ctrl.getNestedValues('image')['tag'] = 'v1';
+
+    expect(values).toEqual({image: {tag: 'v1'}});
+    expect(ctrl.getNestedValues('image')).toBe(values['image']);
+  });
+
+  it('should return no properties when schema has none', () => {
+    ctrl.schema = {type: 'object'};
+
+    expect(ctrl.getProperties()).toEqual({});
+  });
+});
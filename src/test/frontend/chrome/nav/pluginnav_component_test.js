@@ -0,0 +1,58 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import module from 'chrome/nav/module';
+
+describe('Plugin nav component', () => {
+  /** @type {!PluginNavController} */
+  let ctrl;
+  /** @type {!angular.$httpBackend} */
+  let httpBackend;
+
+  beforeEach(() => {
+    angular.mock.module(module.name);
+    angular.mock.inject(($componentController, $httpBackend) => {
+      ctrl = $componentController('kdPluginNav', {});
+      httpBackend = $httpBackend;
+    });
+  });
+
+  it('should fill menu with entries of plugins', () => {
+    httpBackend.expectGET('api/v1/plugin').respond({
+      plugins: [
+        {name: 'istio', menuEntries: [{label: 'Virtual Services', path: '/virtualservices'}]},
+        {name: 'velero', menuEntries: [{label: 'Backups', path: '/'}]},
+        {name: 'noop', menuEntries: []},
+      ],
+    });
+
+    ctrl.$onInit();
+    httpBackend.flush();
+
+    expect(ctrl.entries.map((entry) => entry.label)).toEqual(['Virtual Services', 'Backups']);
+    expect(ctrl.entryHrefs).toEqual([
+      'api/v1/plugin/istio/virtualservices',
+      'api/v1/plugin/velero/',
+    ]);
+  });
+
+  it('should hide menu when there are no plugins', () => {
+    httpBackend.expectGET('api/v1/plugin').respond({plugins: []});
+
+    ctrl.$onInit();
+    httpBackend.flush();
+
+    expect(ctrl.entries).toEqual([]);
+  });
+});
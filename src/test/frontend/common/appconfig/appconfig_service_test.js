@@ -42,4 +42,9 @@ describe('Appconfig service test', () => {
 
     expect(kdAppConfigService.getServerTime().getTime()).toBe(1234567890123 - 20 * 1000);
   });
+
+  it('should return read-only mode', () => {
+    expect(new AppConfigService({serverTime: 0, readOnly: true}).isReadOnly()).toBe(true);
+    expect(new AppConfigService({serverTime: 0}).isReadOnly()).toBe(false);
+  });
 });
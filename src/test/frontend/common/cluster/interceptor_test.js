@@ -0,0 +1,66 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import clusterModule from 'common/cluster/module';
+
+describe('Cluster interceptor', () => {
+  /** @type {!angular.$http} */
+  let http;
+  /** @type {!angular.$httpBackend} */
+  let httpBackend;
+  /** @type {!common/cluster/service.ClusterService} */
+  let clusterService;
+
+  beforeEach(() => {
+    angular.mock.module(clusterModule.name);
+    angular.mock.inject(($http, $httpBackend, kdClusterService) => {
+      http = $http;
+      httpBackend = $httpBackend;
+      clusterService = kdClusterService;
+    });
+  });
+
+  afterEach(() => {
+    clusterService.setCluster('');
+  });
+
+  it('should not change requests to the default cluster', () => {
+    httpBackend.expectGET('api/v1/pod/default').respond(200);
+
+    http.get('api/v1/pod/default');
+    httpBackend.flush();
+    httpBackend.verifyNoOutstandingExpectation();
+  });
+
+  it('should make API requests to the selected cluster', () => {
+    clusterService.setCluster('prod');
+    httpBackend.expectGET('api/v1/pod/default?cluster=prod').respond(200);
+    httpBackend.expectGET('api/v1/clusterlist?cluster=prod').respond(200);
+    httpBackend.expectGET('static/file.html').respond(200);
+
+    http.get('api/v1/pod/default');
+    http.get('api/v1/clusterlist');
+    http.get('static/file.html');
+    httpBackend.flush();
+    httpBackend.verifyNoOutstandingExpectation();
+  });
+
+  it('should remember selected cluster', () => {
+    expect(clusterService.getCluster()).toBe('');
+    clusterService.setCluster('prod');
+    expect(clusterService.getCluster()).toBe('prod');
+    clusterService.setCluster('');
+    expect(clusterService.getCluster()).toBe('');
+  });
+});
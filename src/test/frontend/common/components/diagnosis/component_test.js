@@ -0,0 +1,72 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import componentsModule from 'common/components/module';
+
+describe('Workload diagnosis component', () => {
+  /** @type {!common/components/diagnosis/component.WorkloadDiagnosisController} */
+  let ctrl;
+  /** @type {!angular.$httpBackend} */
+  let httpBackend;
+
+  beforeEach(() => {
+    angular.mock.module(componentsModule.name);
+    angular.mock.inject(($componentController, $httpBackend) => {
+      httpBackend = $httpBackend;
+      ctrl = $componentController('kdWorkloadDiagnosis', null, {
+        kind: 'deployment',
+        objectMeta: {name: 'app', namespace: 'default'},
+      });
+    });
+  });
+
+  it('should show diagnosis of unhealthy workload', () => {
+    httpBackend.expectGET('api/v1/diagnosis/deployment/default/app').respond({
+      healthy: false,
+      summary: 'ImagePullBackOff: registry auth failed',
+      problems: [
+        {reason: 'ImagePullBackOff', message: 'registry auth failed', pods: ['app-1']},
+        {reason: 'Unready', message: 'readiness probe failed', pods: ['app-2']},
+      ],
+    });
+
+    ctrl.$onInit();
+    expect(ctrl.isVisible()).toBe(false);
+    httpBackend.flush();
+
+    expect(ctrl.isVisible()).toBe(true);
+    expect(ctrl.diagnosis.summary).toBe('ImagePullBackOff: registry auth failed');
+  });
+
+  it('should hide diagnosis of healthy workload', () => {
+    httpBackend.expectGET('api/v1/diagnosis/deployment/default/app').respond({
+      healthy: true,
+      summary: '',
+      problems: [],
+    });
+
+    ctrl.$onInit();
+    httpBackend.flush();
+
+    expect(ctrl.isVisible()).toBe(false);
+  });
+
+  it('should toggle list of problems', () => {
+    expect(ctrl.expanded).toBe(false);
+    ctrl.toggle();
+    expect(ctrl.expanded).toBe(true);
+    ctrl.toggle();
+    expect(ctrl.expanded).toBe(false);
+  });
+});
@@ -0,0 +1,67 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+import csrfTokenModule from 'common/csrftoken/module';
+import {getCsrfAction} from 'common/csrftoken/interceptor';
+
+describe('CSRF token interceptor', () => {
+  /** @type {!angular.$http} */
+  let http;
+  /** @type {!angular.$httpBackend} */
+  let httpBackend;
+
+  beforeEach(() => {
+    angular.mock.module(csrfTokenModule.name);
+    angular.mock.inject(($http, $httpBackend) => {
+      http = $http;
+      httpBackend = $httpBackend;
+    });
+  });
+
+  it('should return action of requests that change state of the cluster', () => {
+    expect(getCsrfAction('DELETE', 'api/v1/_raw/pod/namespace/default/name/web')).toBe('_raw');
+    expect(getCsrfAction('PUT', 'api/v1/scale/deployment/default/web?scaleBy=2')).toBe('scale');
+    expect(getCsrfAction('GET', 'api/v1/pod/default/web/shell/nginx')).toBe('pod');
+    expect(getCsrfAction('GET', 'api/v1/pod/default/web/portforward/8080')).toBe('pod');
+    expect(getCsrfAction('GET', 'api/v1/service/default/web/portforward/80')).toBe('service');
+    expect(getCsrfAction('GET', 'api/v1/pod/default/web')).toBe('');
+    expect(getCsrfAction('GET', 'api/v1/csrftoken/pod')).toBe('');
+    expect(getCsrfAction('POST', 'static/file.html')).toBe('');
+  });
+
+  it('should add CSRF token to requests that change state of the cluster', () => {
+    httpBackend.expectGET('api/v1/csrftoken/_raw').respond(200, {token: 'csrf-token'});
+    httpBackend
+        .expectDELETE(
+            'api/v1/_raw/pod/namespace/default/name/web',
+            (headers) => headers['X-CSRF-TOKEN'] === 'csrf-token')
+        .respond(200);
+
+    http.delete('api/v1/_raw/pod/namespace/default/name/web');
+    httpBackend.flush();
+    httpBackend.verifyNoOutstandingExpectation();
+  });
+
+  it('should not replace CSRF token set by the caller', () => {
+    httpBackend
+        .expectPOST(
+            'api/v1/namespace', {}, (headers) => headers['X-CSRF-TOKEN'] === 'caller-token')
+        .respond(200);
+
+    http.post('api/v1/namespace', {}, {headers: {'X-CSRF-TOKEN': 'caller-token'}});
+    httpBackend.flush();
+    httpBackend.verifyNoOutstandingExpectation();
+  });
+});
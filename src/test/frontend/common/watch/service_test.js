@@ -0,0 +1,68 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import module from 'common/watch/module';
+import {REFRESH_DELAY} from 'common/watch/service';
+
+describe('Watch service', () => {
+  /** @type {!common/watch/service.WatchService} */
+  let service;
+  /** @type {!angular.$httpBackend} */
+  let httpBackend;
+  /** @type {!angular.$timeout} */
+  let timeout;
+  /** @type {!Object} */
+  let conn;
+
+  beforeEach(() => angular.mock.module(module.name));
+
+  beforeEach(angular.mock.inject((kdWatchService, $httpBackend, $timeout) => {
+    service = kdWatchService;
+    httpBackend = $httpBackend;
+    timeout = $timeout;
+    conn = jasmine.createSpyObj('SockJS', ['send', 'close']);
+    spyOn(window, 'SockJS').and.returnValue(conn);
+  }));
+
+  it('should refresh once after a burst of events', () => {
+    let onChange = jasmine.createSpy('onChange');
+    httpBackend.expectGET('api/v1/watch/pod/default').respond({id: 'session'});
+
+    service.watch('pod', 'default', onChange);
+    httpBackend.flush();
+
+    expect(window.SockJS).toHaveBeenCalledWith('api/sockjs/watch?session');
+    conn.onopen();
+    expect(conn.send).toHaveBeenCalledWith('{"Op":"bind","SessionID":"session"}');
+
+    conn.onmessage({data: '{"Op":"event","Type":"ADDED"}'});
+    conn.onmessage({data: '{"Op":"event","Type":"MODIFIED"}'});
+    timeout.flush(REFRESH_DELAY);
+    expect(onChange.calls.count()).toBe(1);
+  });
+
+  it('should watch all namespaces and stop', () => {
+    let onChange = jasmine.createSpy('onChange');
+    httpBackend.expectGET('api/v1/watch/node').respond({id: 'session'});
+
+    let stop = service.watch('node', '', onChange);
+    httpBackend.flush();
+    conn.onmessage({data: '{"Op":"event","Type":"DELETED"}'});
+    stop();
+    timeout.verifyNoPendingTasks();
+
+    expect(conn.close).toHaveBeenCalled();
+    expect(onChange).not.toHaveBeenCalled();
+  });
+});
@@ -135,6 +135,46 @@ describe('Create-Secret dialog', () => {
 
   });
 
+  it('should submit registry credentials', () => {
+    spyOn(ctrl.mdDialog_, 'hide');
+    ctrl.secretForm = {};
+    ctrl.secretForm.$valid = true;
+    ctrl.secretName = 'registry';
+    ctrl.useCredentials = true;
+    ctrl.credentials = {server: 'quay.io', username: 'user', password: 'pass', email: ''};
+    httpBackend
+        .expect('POST', 'api/v1/secret/registry', {
+          name: 'registry',
+          namespace: 'default',
+          server: 'quay.io',
+          username: 'user',
+          password: 'pass',
+          email: '',
+          skipTest: false,
+          serviceAccounts: [],
+        })
+        .respond(201, {});
+    // when
+    ctrl.createSecret();
+    httpBackend.flush();
+    // then
+    expect(ctrl.mdDialog_.hide).toHaveBeenCalledWith('registry');
+  });
+
+  it('should test registry credentials', () => {
+    ctrl.credentials = {server: 'quay.io', username: 'user', password: 'wrong', email: ''};
+    httpBackend.expectPOST('api/v1/secret/registry/test')
+        .respond(200, {registry: 'https://quay.io', valid: false, message: 'login failed'});
+    // when
+    ctrl.testCredentials();
+    expect(ctrl.testing).toBe(true);
+    httpBackend.flush();
+    // then
+    expect(ctrl.testing).toBe(false);
+    expect(ctrl.credentialTest.valid).toBe(false);
+    expect(ctrl.credentialTest.message).toBe('login failed');
+  });
+
   it('cancel dialog', () => {
     spyOn(ctrl.mdDialog_, 'cancel');
     // when
@@ -31,6 +31,9 @@ describe('Logs controller', () => {
   /** @type {!angular.$httpBackend} */
   let httpBackend;
 
+  /** @type {!angular.$timeout} */
+  let timeout;
+
   /** @type {string} */
   const mockNamespace = 'namespace11';
 
@@ -74,7 +77,7 @@ describe('Logs controller', () => {
     angular.mock.module(LogsModule.name);
     angular.mock.module(errorModule.name);
 
-    angular.mock.inject(($componentController, $httpBackend, errorDialog) => {
+    angular.mock.inject(($componentController, $httpBackend, errorDialog, $timeout) => {
       ctrl = $componentController(
           'kdLogs', {
             errorDialog: errorDialog,
@@ -89,6 +92,7 @@ describe('Logs controller', () => {
             },
           });
       httpBackend = $httpBackend;
+      timeout = $timeout;
     });
   });
 
@@ -139,6 +143,18 @@ describe('Logs controller', () => {
     expect(ctrl.currentSelection).toEqual(otherLogs.selection);
   });
 
+  it('should load logs of previous container instance', () => {
+    ctrl.$onInit();
+    ctrl.onPreviousChange();
+    expect(ctrl.previous).toBe(true);
+    httpBackend
+        .expectGET(
+            'api/v1/log/namespace11/test-pod/container-name?logFilePosition=end&offsetFrom=2000000000&offsetTo=2000000100&previous=true&referenceLineNum=0&referenceTimestamp=newest')
+        .respond(200, otherLogs);
+    httpBackend.flush();
+    expect(ctrl.logsSet.length).toEqual(2);
+  });
+
   it('should load older logs on loadOlder call', () => {
     ctrl.$onInit();
     ctrl.loadOlder();
@@ -179,5 +195,29 @@ describe('Logs controller', () => {
     expect(ctrl.currentSelection).toEqual(otherLogs.selection);
   });
 
+  it('should follow logs', () => {
+    let conn = jasmine.createSpyObj('SockJS', ['send', 'close']);
+    spyOn(window, 'SockJS').and.returnValue(conn);
+    ctrl.$onInit();
+
+    ctrl.toggleFollow();
+    expect(ctrl.following).toBe(true);
+    httpBackend
+        .expectGET(
+            'api/v1/log/namespace11/test-pod/container-name/stream?follow=true&tailLines=100&timestamps=true')
+        .respond(200, {id: 'session'});
+    httpBackend.flush();
+
+    expect(window.SockJS).toHaveBeenCalledWith('api/sockjs/logs?session');
+    conn.onopen();
+    expect(conn.send).toHaveBeenCalledWith('{"Op":"bind","SessionID":"session"}');
+    conn.onmessage({data: '{"Op":"stdout","Data":"4 d\\n5 e\\n"}'});
+    timeout.flush();
+    expect(ctrl.logsSet.length).toEqual(2);
+    expect(ctrl.logsSet[1].toString()).toEqual('e');
 
+    ctrl.toggleFollow();
+    expect(ctrl.following).toBe(false);
+    expect(conn.close).toHaveBeenCalled();
+  });
 });
@@ -35,4 +35,17 @@ describe('Container info component', () => {
     let cmkr = {name: 'foo', key: 'bar'};
     expect(ctrl.getEnvConfigMapHref(cmkr)).toBe('#!/configmap/foo-namespace/foo');
   });
+
+  it('should compute previous logs href', () => {
+    ctrl.podName = 'foo-pod';
+    expect(ctrl.getPreviousLogsHref({name: 'app'}))
+        .toBe('#!/log/foo-namespace/foo-pod/pod?container=app&previous=true');
+  });
+
+  it('should format requests and limits', () => {
+    expect(ctrl.formatCores(0)).toBe('-');
+    expect(ctrl.formatCores(250)).toBe('0.25');
+    expect(ctrl.formatMemory(0)).toBe('-');
+    expect(ctrl.formatMemory(1025)).toBe('1.001 Ki');
+  });
 });
@@ -36,4 +36,23 @@ describe('Secret Detail controller', () => {
 
     expect(ctrl.formatDataValue('SGVsbG8gd29ybGQ=')).toBe('Hello world');
   }));
+
+  it('should reveal secret data once', angular.mock.inject(($controller, $httpBackend) => {
+    let data = {objectMeta: {name: 'foo', namespace: 'bar'}};
+    /** @type {!SecretDetailController} */
+    let ctrl = $controller(SecretDetailController, {secretDetail: data});
+
+    $httpBackend.expectGET('api/v1/secret/bar/foo/reveal').respond({
+      data: {'app': 'SGVsbG8gd29ybGQ='},
+    });
+    expect(ctrl.getDataValue('app')).toBe('');
+
+    ctrl.reveal();
+    ctrl.reveal();
+    $httpBackend.flush();
+
+    expect(ctrl.getDataValue('app')).toBe('Hello world');
+    expect(ctrl.getDataValue('missing')).toBe('');
+    $httpBackend.verifyNoOutstandingRequest();
+  }));
 });
@@ -0,0 +1,42 @@
+// Copyright 2017 The Kubernetes Dashboard Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import persistentVolumeModule from 'persistentvolume/module';
+import persistentVolumeClaimModule from 'persistentvolumeclaim/module';
+import storageClassModule from 'storageclass/module';
+
+describe('Storage Class Usage controller', () => {
+  /** @type {!StorageClassUsageController} */
+  let ctrl;
+
+  beforeEach(() => {
+    angular.mock.module(storageClassModule.name);
+    angular.mock.module(persistentVolumeModule.name);
+    angular.mock.module(persistentVolumeClaimModule.name);
+
+    angular.mock.inject(($componentController, $rootScope) => {
+      ctrl = $componentController('kdStorageClassUsage', {$scope: $rootScope}, {storageClass: {}});
+    });
+  });
+
+  it('should return volume detail href', () => {
+    expect(ctrl.getVolumeDetailHref({objectMeta: {name: 'pv-1'}}))
+        .toEqual('#!/persistentvolume/pv-1');
+  });
+
+  it('should return claim detail href', () => {
+    expect(ctrl.getClaimDetailHref({objectMeta: {namespace: 'default', name: 'data'}}))
+        .toEqual('#!/persistentvolumeclaim/default/data');
+  });
+});